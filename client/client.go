@@ -0,0 +1,141 @@
+// Package client connects the headnode of a clusrun cluster to run jobs on the nodes, which lets Go services embed
+// cluster execution without the command line of clus.
+package client
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DefaultPort           = "50505"
+	DefaultConnectTimeout = 10 * time.Second
+	DefaultRetries        = 3
+	DefaultRetryInterval  = 2 * time.Second
+)
+
+// The options to connect the headnode
+type Options struct {
+	Headnode       string        // the address of headnode in format host[:port], the port is DefaultPort if omitted
+	Token          string        // the token to authenticate if authentication is enabled on the headnode
+	User           string        // the user to authenticate with the password, which takes precedence over the token
+	Password       string        // the password of the user
+	Secure         bool          // connect the headnode with TLS
+	TlsConfig      *tls.Config   // the TLS config of the secure connection, the certificate of headnode is not verified if nil as clus does
+	ConnectTimeout time.Duration // DefaultConnectTimeout if 0
+	Retries        int           // the times to retry a call failed by a transient error, DefaultRetries if 0 and no retry if negative
+	RetryInterval  time.Duration // DefaultRetryInterval if 0
+}
+
+// The client of a headnode, which is safe for concurrent use
+type Client struct {
+	options  Options
+	conn     *grpc.ClientConn
+	headnode pb.HeadnodeClient
+}
+
+// Connect the headnode, the client should be closed once it is not used
+func New(ctx context.Context, options Options) (*Client, error) {
+	if len(strings.TrimSpace(options.Headnode)) == 0 {
+		return nil, errors.New("The headnode is not specified")
+	}
+	if options.ConnectTimeout == 0 {
+		options.ConnectTimeout = DefaultConnectTimeout
+	}
+	if options.Retries == 0 {
+		options.Retries = DefaultRetries
+	} else if options.Retries < 0 {
+		options.Retries = 0
+	}
+	if options.RetryInterval == 0 {
+		options.RetryInterval = DefaultRetryInterval
+	}
+	dial_options := []grpc.DialOption{grpc.WithInsecure(), grpc.WithBlock()}
+	if options.Secure {
+		config := options.TlsConfig
+		if config == nil {
+			config = &tls.Config{InsecureSkipVerify: true}
+		}
+		dial_options[0] = grpc.WithTransportCredentials(credentials.NewTLS(config))
+	}
+	if creds := getCredentials(options); creds != nil {
+		dial_options = append(dial_options, grpc.WithPerRPCCredentials(creds))
+	}
+	ctx, cancel := context.WithTimeout(ctx, options.ConnectTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, ParseHeadnode(options.Headnode), dial_options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{options: options, conn: conn, headnode: pb.NewHeadnodeClient(conn)}, nil
+}
+
+// Close the connection to the headnode
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// The raw client of the headnode, for the calls not wrapped by this package
+func (c *Client) Headnode() pb.HeadnodeClient {
+	return c.headnode
+}
+
+// Get the address of headnode with DefaultPort if the port is omitted
+func ParseHeadnode(headnode string) string {
+	if _, _, err := net.SplitHostPort(headnode); err == nil {
+		return headnode
+	}
+	// The headnode without port, which may be an IPv6 address with or without brackets
+	return net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(headnode, "["), "]"), DefaultPort)
+}
+
+func getCredentials(options Options) credentials.PerRPCCredentials {
+	if len(options.User) > 0 {
+		return authorization("Basic " + base64.StdEncoding.EncodeToString([]byte(options.User+":"+options.Password)))
+	}
+	if len(options.Token) > 0 {
+		return authorization("Bearer " + options.Token)
+	}
+	return nil
+}
+
+// The authorization sent in metadata of each RPC to headnode
+type authorization string
+
+func (a authorization) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": string(a)}, nil
+}
+
+func (a authorization) RequireTransportSecurity() bool {
+	return false
+}
+
+// Call the function until it succeeds, the error is not transient, the retries are used up or the context is done
+func (c *Client) retry(ctx context.Context, f func() error) error {
+	for i := 0; ; i++ {
+		err := f()
+		if !isTransient(err) || i >= c.options.Retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(c.options.RetryInterval):
+		}
+	}
+}
+
+func isTransient(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
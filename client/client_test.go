@@ -0,0 +1,92 @@
+package client
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"testing"
+)
+
+func Test_ParseHeadnode(t *testing.T) {
+	cases := []struct {
+		headnode string
+		expected string
+	}{
+		{"host", "host:" + DefaultPort},
+		{"host:1000", "host:1000"},
+		{"10.0.0.1", "10.0.0.1:" + DefaultPort},
+		{"::1", "[::1]:" + DefaultPort},
+		{"[::1]", "[::1]:" + DefaultPort},
+		{"[::1]:1000", "[::1]:1000"},
+	}
+	for _, c := range cases {
+		if result := ParseHeadnode(c.headnode); result != c.expected {
+			t.Errorf("Expected %q of headnode %q, got %q", c.expected, c.headnode, result)
+		}
+	}
+}
+
+func Test_IsJobEnded(t *testing.T) {
+	ended := map[pb.JobState]bool{
+		pb.JobState_Finished:     true,
+		pb.JobState_Failed:       true,
+		pb.JobState_Canceled:     true,
+		pb.JobState_CancelFailed: true,
+		pb.JobState_Aborted:      true,
+		pb.JobState_Untracked:    true,
+	}
+	for state := range pb.JobState_name {
+		if result := IsJobEnded(pb.JobState(state)); result != ended[pb.JobState(state)] {
+			t.Errorf("Expected ended %v of state %v, got %v", ended[pb.JobState(state)], pb.JobState(state), result)
+		}
+	}
+}
+
+func Test_jobTracker(t *testing.T) {
+	tracker := newJobTracker(&pb.StartClusJobReply{JobId: 5, Nodes: []string{"n1", "n2", "n3"}})
+	replies := []*pb.StartClusJobReply{
+		{Node: "n1", Stdout: []byte("out")},
+		{Node: "n1", Stderr: []byte("error")},
+		{Progress: &pb.JobProgress{Nodes: 3}},
+		{Node: "n2", RescheduledTo: "n4"},
+		{Node: "n1", ExitCode: 1},
+		{Node: "n3", TimedOut: true, ExitCode: -1},
+		{Node: "n4", Stdout: []byte("ok")},
+		{Node: "n4"},
+		{Summary: &pb.JobSummary{}},
+	}
+	outputs := []*Output{}
+	for _, r := range replies {
+		if output := tracker.apply(r); output != nil {
+			outputs = append(outputs, output)
+		}
+	}
+	expected_outputs := []*Output{
+		{Node: "n1", Stdout: []byte("out")},
+		{Node: "n1", Stderr: []byte("error")},
+		{Node: "n2", RescheduledTo: "n4"},
+		{Node: "n1", End: true, ExitCode: 1},
+		{Node: "n3", End: true, ExitCode: -1, TimedOut: true},
+		{Node: "n4", Stdout: []byte("ok")},
+		{Node: "n4", End: true},
+	}
+	if !reflect.DeepEqual(outputs, expected_outputs) {
+		t.Errorf("Expected outputs %+v, got %+v", expected_outputs, outputs)
+	}
+	result := tracker.result
+	if result.JobId != 5 || !reflect.DeepEqual(result.Nodes, []string{"n1", "n4", "n3"}) || result.Summary == nil {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if expected := map[string]int32{"n1": 1, "n3": -1, "n4": 0}; !reflect.DeepEqual(result.ExitCodes, expected) {
+		t.Errorf("Expected exit codes %v, got %v", expected, result.ExitCodes)
+	}
+	if expected := []string{"n3"}; !reflect.DeepEqual(result.TimedOut, expected) {
+		t.Errorf("Expected timed out nodes %v, got %v", expected, result.TimedOut)
+	}
+	watch := tracker.watch
+	if watch.JobId != 5 || watch.StdoutOffsets["n1"] != 3 || watch.StderrOffsets["n1"] != 5 || watch.StdoutOffsets["n4"] != 2 {
+		t.Errorf("Unexpected offsets to reattach %v %v", watch.StdoutOffsets, watch.StderrOffsets)
+	}
+	if expected := []string{"n2", "n1", "n3", "n4"}; !reflect.DeepEqual(watch.EndedNodes, expected) {
+		t.Errorf("Expected ended nodes %v, got %v", expected, watch.EndedNodes)
+	}
+}
@@ -0,0 +1,235 @@
+package client
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const waitJobInterval = time.Second
+
+// The output of the task on a node, the last output of a node has no content but its end
+type Output struct {
+	Node          string
+	Stdout        []byte
+	Stderr        []byte
+	End           bool // the task on the node ends, with the exit code
+	ExitCode      int32
+	TimedOut      bool
+	RescheduledTo string // the task on the lost node is rescheduled to this node, and no more output of the lost node
+}
+
+// The callback of the output received, which is called in order of the output
+type OutputFunc func(output *Output)
+
+// The result of a job once its output ends
+type JobResult struct {
+	JobId     int32
+	Nodes     []string         // the nodes of the job, in which the lost nodes are replaced by the nodes rescheduled to
+	ExitCodes map[string]int32 // the exit code of the task on each node ended
+	TimedOut  []string         // the nodes on which the task timed out
+	Summary   *pb.JobSummary
+}
+
+// Start a job and receive its output until the tasks on all nodes end, the job is reattached from the output received
+// if the stream is lost by a transient error. The result received is returned with the error if the output is not
+// complete, in which the job id is 0 if the job is not started.
+func (c *Client) SubmitJob(ctx context.Context, request *pb.StartClusJobRequest, on_output OutputFunc) (*JobResult, error) {
+	request = proto.Clone(request).(*pb.StartClusJobRequest)
+	if len(request.RequestId) == 0 {
+		// The job is not created twice by the retries
+		request.RequestId = newRequestId()
+	}
+	request.Summary = true
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var stream pb.Headnode_StartClusJobClient
+	var first *pb.StartClusJobReply
+	err := c.retry(ctx, func() (err error) {
+		stream, err = c.headnode.StartClusJob(ctx, request, grpc.UseCompressor("gzip"))
+		if err == nil {
+			first, err = stream.Recv()
+		}
+		return
+	})
+	if err != nil {
+		return &JobResult{}, err
+	}
+	tracker := newJobTracker(first)
+	var replies jobReplyStream = stream
+	for {
+		reply, err := replies.Recv()
+		if err == io.EOF {
+			return tracker.result, nil
+		}
+		if err != nil {
+			if !isTransient(err) {
+				return tracker.result, err
+			}
+			if replies, err = c.reattachJob(ctx, tracker.watch); err != nil {
+				return tracker.result, err
+			}
+			continue
+		}
+		if output := tracker.apply(reply); output != nil && on_output != nil {
+			on_output(output)
+		}
+	}
+}
+
+// The stream of replies of StartClusJob or WatchClusJob
+type jobReplyStream interface {
+	Recv() (*pb.StartClusJobReply, error)
+}
+
+// Watch the job again from the output received
+func (c *Client) reattachJob(ctx context.Context, request *pb.WatchClusJobRequest) (jobReplyStream, error) {
+	var stream pb.Headnode_WatchClusJobClient
+	err := c.retry(ctx, func() (err error) {
+		stream, err = c.headnode.WatchClusJob(ctx, request)
+		if err == nil {
+			// The nodes of the job are known
+			_, err = stream.Recv()
+		}
+		return
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to reattach to job %v: %v", request.JobId, status.Convert(err).Message())
+	}
+	return stream, nil
+}
+
+// The result of a job tracked from its replies, and the output received to reattach to the job
+type jobTracker struct {
+	result *JobResult
+	watch  *pb.WatchClusJobRequest
+}
+
+func newJobTracker(first *pb.StartClusJobReply) *jobTracker {
+	job_id := first.GetJobId()
+	return &jobTracker{
+		result: &JobResult{JobId: job_id, Nodes: append([]string{}, first.GetNodes()...), ExitCodes: map[string]int32{}},
+		watch:  &pb.WatchClusJobRequest{JobId: job_id, StdoutOffsets: map[string]int64{}, StderrOffsets: map[string]int64{}},
+	}
+}
+
+// Apply a reply to the result, and get the output of a node in it
+func (t *jobTracker) apply(reply *pb.StartClusJobReply) *Output {
+	if s := reply.GetSummary(); s != nil {
+		// The summary is sent once the tasks on all nodes end
+		t.result.Summary = s
+		return nil
+	}
+	if reply.GetProgress() != nil {
+		return nil
+	}
+	node, stdout, stderr := reply.GetNode(), reply.GetStdout(), reply.GetStderr()
+	t.watch.StdoutOffsets[node] += int64(len(stdout))
+	t.watch.StderrOffsets[node] += int64(len(stderr))
+	output := &Output{Node: node, Stdout: stdout, Stderr: stderr}
+	if to_node := reply.GetRescheduledTo(); len(to_node) > 0 {
+		t.watch.EndedNodes = append(t.watch.EndedNodes, node)
+		for i := range t.result.Nodes {
+			if t.result.Nodes[i] == node {
+				t.result.Nodes[i] = to_node
+			}
+		}
+		output.RescheduledTo = to_node
+		return output
+	}
+	if len(stdout)+len(stderr) == 0 {
+		t.watch.EndedNodes = append(t.watch.EndedNodes, node)
+		output.End, output.ExitCode, output.TimedOut = true, reply.GetExitCode(), reply.GetTimedOut()
+		t.result.ExitCodes[node] = output.ExitCode
+		if output.TimedOut {
+			t.result.TimedOut = append(t.result.TimedOut, node)
+		}
+	}
+	return output
+}
+
+// Cancel the job and get its state after canceling
+func (c *Client) CancelJob(ctx context.Context, job_id int32) (pb.JobState, error) {
+	var reply *pb.CancelClusJobsReply
+	err := c.retry(ctx, func() (err error) {
+		reply, err = c.headnode.CancelClusJobs(ctx, &pb.CancelClusJobsRequest{JobIds: map[int32]bool{job_id: true}})
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+	state, ok := reply.GetResult()[job_id]
+	if !ok {
+		return 0, status.Errorf(codes.NotFound, "Job %v is not cancelled", job_id)
+	}
+	return state, nil
+}
+
+// Wait until the job ends and get it, the job is polled from the headnode
+func (c *Client) WaitForJob(ctx context.Context, job_id int32) (*pb.Job, error) {
+	for {
+		var reply *pb.GetJobsReply
+		err := c.retry(ctx, func() (err error) {
+			reply, err = c.headnode.GetJobs(ctx, &pb.GetJobsRequest{JobIds: map[int32]bool{job_id: true}})
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(reply.GetJobs()) == 0 {
+			return nil, status.Errorf(codes.NotFound, "Job %v is not found", job_id)
+		}
+		if job := reply.GetJobs()[0]; IsJobEnded(job.GetState()) {
+			return job, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(waitJobInterval):
+		}
+	}
+}
+
+// The job in the state will not change any more
+func IsJobEnded(state pb.JobState) bool {
+	switch state {
+	case pb.JobState_Created, pb.JobState_Dispatching, pb.JobState_Running, pb.JobState_Canceling, pb.JobState_Waiting:
+		return false
+	}
+	return true
+}
+
+// Get the nodes matching the request, all nodes if it is nil
+func (c *Client) ListNodes(ctx context.Context, request *pb.GetNodesRequest) ([]*pb.Node, error) {
+	if request == nil {
+		request = &pb.GetNodesRequest{}
+	}
+	var reply *pb.GetNodesReply
+	err := c.retry(ctx, func() (err error) {
+		reply, err = c.headnode.GetNodes(ctx, request)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetNodes(), nil
+}
+
+// The random id of a request to start job
+func newRequestId() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
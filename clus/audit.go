@@ -0,0 +1,96 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Audit(args []string) {
+	fs := flag.NewFlagSet("clus audit options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	since := fs.String("since", "", `get entries since a duration ago (e.g. "2h", "3d") or a time (e.g. "2020-05-01" or "2020-05-01 08:00:00")`)
+	until := fs.String("until", "", "get entries until a duration ago or a time, in the same format of -since")
+	action := fs.String("action", "", `get entries of an action or the actions under it, e.g. "job.submit" or "nodes"`)
+	source := fs.String("source", "", "get entries from the sources containing a certain string, e.g. a user or client address")
+	since_seq := fs.Int64("since-seq", 0, "get entries after a seq")
+	limit := fs.Int("limit", 0, "get at most a certain count of entries, 0 for all")
+	_ = fs.Parse(args)
+	if len(fs.Args()) > 0 {
+		displayAuditUsage(fs)
+		return
+	}
+	if *limit < 0 || *since_seq < 0 {
+		Fatallnf("Limit and seq should not be negative.")
+	}
+	request := &pb.GetAuditLogRequest{SinceSeq: *since_seq, Limit: int32(*limit), Action: *action, Source: *source}
+	var err error
+	now := time.Now()
+	if request.Since, err = parseJobTime(*since, now); err != nil {
+		Fatallnf("Invalid -since: %v", err)
+	}
+	if request.Until, err = parseJobTime(*until, now); err != nil {
+		Fatallnf("Invalid -until: %v", err)
+	}
+	getAuditLog(request)
+}
+
+func displayAuditUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus audit [options]
+
+  The mutating operations on the headnode, e.g. submitting or canceling jobs and setting configs,
+  are recorded with the clients calling them in an append-only log, in which each entry is chained to the previous one by hash.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+func getAuditLog(request *pb.GetAuditLogRequest) {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Get entries
+	reply, err := pb.NewHeadnodeClient(conn).GetAuditLog(ctx, request)
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support audit log.")
+	} else if err != nil {
+		Fatallnf("Failed to get audit log: %v", status.Convert(err).Message())
+	}
+	entries := reply.GetEntries()
+	for _, e := range entries {
+		Printlnf("%v", formatAuditEntry(e))
+	}
+	Printlnf("%v of %v entries are got, the hash of the last entry is %v", len(entries), reply.GetCount(), reply.GetLastHash())
+	if reply.GetVerified() {
+		Printlnf("Audit log is verified.")
+	} else {
+		Printlnf("Audit log is broken: %v", reply.GetVerifyError())
+	}
+}
+
+func formatAuditEntry(e *pb.AuditEntry) string {
+	job := ""
+	if e.JobId > 0 {
+		job = fmt.Sprintf(" job %v", e.JobId)
+	}
+	if len(e.RunAs) > 0 {
+		job += " as " + e.RunAs
+	}
+	detail := ""
+	if len(e.Detail) > 0 {
+		detail = ": " + e.Detail
+	}
+	return fmt.Sprintf("#%v [%v] %v%v from %v%v => %v", e.Seq, FormatTime(time.Unix(e.Time, 0)), e.Action, job, e.Source, detail, e.Result)
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"fmt"
+	"os"
+	osuser "os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	jobBookmarkPrefix = "@"
+)
+
+// Handle "clus job bookmark [<job id> <name>]" and "clus job unbookmark <names>"
+func JobBookmark(args []string) {
+	switch args[0] {
+	case "bookmark":
+		switch len(args) {
+		case 1:
+			printJobBookmarks()
+		case 3:
+			id, err := strconv.Atoi(args[1])
+			if err != nil || id <= 0 {
+				Fatallnf("Invalid job id: %q", args[1])
+			}
+			saveJobBookmark(int32(id), strings.TrimPrefix(args[2], jobBookmarkPrefix))
+		default:
+			Fatallnf("Usage: clus job bookmark [<job id> <name>]")
+		}
+	case "unbookmark":
+		if len(args) < 2 {
+			Fatallnf("Usage: clus job unbookmark <names>")
+		}
+		names := make([]string, len(args)-1)
+		for i, name := range args[1:] {
+			names[i] = strings.TrimPrefix(name, jobBookmarkPrefix)
+		}
+		deleteJobBookmarks(names)
+	}
+}
+
+// The bookmarks are saved on headnode for the user running clus, so that they are shared across machines
+func currentUser() string {
+	if u, err := osuser.Current(); err == nil && len(u.Username) > 0 {
+		return u.Username
+	}
+	for _, env := range []string{"USER", "USERNAME"} {
+		if name := os.Getenv(env); len(name) > 0 {
+			return name
+		}
+	}
+	Fatallnf("Failed to get the current user to bookmark jobs.")
+	return ""
+}
+
+func connectJobBookmarks() (pb.HeadnodeClient, func()) {
+	conn, cancel := ConnectHeadnode()
+	return pb.NewHeadnodeClient(conn), func() {
+		conn.Close()
+		cancel()
+	}
+}
+
+func saveJobBookmark(id int32, name string) {
+	c, close := connectJobBookmarks()
+	defer close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	request := &pb.SaveJobBookmarkRequest{User: currentUser(), Bookmark: &pb.JobBookmark{Name: name, JobId: id}}
+	if _, err := c.SaveJobBookmark(ctx, request); status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support job bookmarks.")
+	} else if err != nil {
+		Fatallnf("Failed to bookmark job: %v", status.Convert(err).Message())
+	}
+	Printlnf("Job %v is bookmarked as %v%v.", id, jobBookmarkPrefix, name)
+}
+
+func deleteJobBookmarks(names []string) {
+	c, close := connectJobBookmarks()
+	defer close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	reply, err := c.DeleteJobBookmarks(ctx, &pb.DeleteJobBookmarksRequest{User: currentUser(), Names: names})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support job bookmarks.")
+	} else if err != nil {
+		Fatallnf("Failed to delete job bookmarks: %v", status.Convert(err).Message())
+	}
+	if deleted := reply.GetDeleted(); len(deleted) > 0 {
+		Printlnf("Deleted %v job bookmarks: %v", len(deleted), strings.Join(deleted, ", "))
+	}
+	if not_found := reply.GetNotFound(); len(not_found) > 0 {
+		Printlnf("Job bookmarks not found: %v", strings.Join(not_found, ", "))
+	}
+}
+
+func getJobBookmarks(names []string) []*pb.JobBookmark {
+	c, close := connectJobBookmarks()
+	defer close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	reply, err := c.GetJobBookmarks(ctx, &pb.GetJobBookmarksRequest{User: currentUser(), Names: names})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support job bookmarks.")
+	} else if err != nil {
+		Fatallnf("Failed to get job bookmarks: %v", status.Convert(err).Message())
+	}
+	return reply.GetBookmarks()
+}
+
+func printJobBookmarks() {
+	bookmarks := getJobBookmarks(nil)
+	for _, b := range bookmarks {
+		Printlnf("%v%v\t%v (bookmarked at %v)", jobBookmarkPrefix, b.Name, b.JobId, FormatTime(time.Unix(b.CreateTime, 0)))
+	}
+	Printlnf("Job bookmark count: %v", len(bookmarks))
+}
+
+// Replace the job bookmarks like "@name" in args by the job ids
+func resolveJobBookmarks(args []string) []string {
+	var names []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, jobBookmarkPrefix) {
+			names = append(names, strings.TrimPrefix(arg, jobBookmarkPrefix))
+		}
+	}
+	if len(names) == 0 {
+		return args
+	}
+	ids := map[string]int32{}
+	for _, b := range getJobBookmarks(names) {
+		ids[b.Name] = b.JobId
+	}
+	return replaceJobBookmarks(args, ids)
+}
+
+func replaceJobBookmarks(args []string, ids map[string]int32) []string {
+	replaced := make([]string, len(args))
+	for i, arg := range args {
+		if id, ok := ids[strings.TrimPrefix(arg, jobBookmarkPrefix)]; ok && strings.HasPrefix(arg, jobBookmarkPrefix) {
+			arg = fmt.Sprintf("%v", id)
+		}
+		replaced[i] = arg
+	}
+	return replaced
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"flag"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/status"
+)
+
+func Collect(args []string) {
+	fs := flag.NewFlagSet("clus collect options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	nodes := fs.String("nodes", "", "specify certain nodes of the job to collect files from")
+	nodes_in_file := fs.String("nodes-in-file", "", "specify a file containg the nodes of the job to collect files from")
+	_ = fs.Parse(args)
+	if len(fs.Args()) < 2 {
+		displayCollectUsage(fs)
+		return
+	}
+	job_id, err := strconv.Atoi(fs.Arg(0))
+	if err != nil || job_id <= 0 {
+		Fatallnf("Invalid job id: %q", fs.Arg(0))
+	}
+	collectFiles(int32(job_id), fs.Args()[1:], ParseNodesOrGroups(*nodes, *nodes_in_file))
+}
+
+func displayCollectUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus collect [options] <job id> <file, directory or glob pattern>...
+
+  Relative paths are resolved against the working dir of the job.
+  The files are stored in <job id>/<node>/<path> of the output dir on headnode.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+func collectFiles(job_id int32, paths, nodes []string) {
+	RequireCapability("file staging")
+
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+
+	// Gather files
+	reply, err := pb.NewHeadnodeClient(conn).GatherFiles(context.Background(), &pb.GatherFilesRequest{JobId: job_id, Paths: paths, Nodes: nodes})
+	if err != nil {
+		Fatallnf("Failed to collect files: %v", status.Convert(err).Message())
+	}
+
+	// Print results
+	results := reply.GetResults()
+	names := make([]string, 0, len(results))
+	for node := range results {
+		names = append(names, node)
+	}
+	sort.Strings(names)
+	failed := []string{}
+	for _, node := range names {
+		Printlnf("[%v]: %v", node, results[node])
+		if !strings.HasPrefix(results[node], "Gathered") {
+			failed = append(failed, node)
+		}
+	}
+	Printlnf("%v of %v nodes succeeded.", len(names)-len(failed), len(names))
+	if len(failed) > 0 {
+		Printlnf("Failed nodes (%v/%v): %v", len(failed), len(names), Colorize(strings.Join(failed, ", "), colorRed))
+	}
+	Printlnf("Files are collected to %v on headnode %v", reply.GetDir(), *Headnode)
+}
@@ -1,46 +1,84 @@
 package main
 
 import (
+	pb "clusrun/protobuf"
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/crypto/ssh/terminal"
 	"golang.org/x/net/html/charset"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	Max_Int           = int(^uint(0) >> 1)
 	Min_Int           = -Max_Int - 1
-	DefaultPort       = "50505"
-	LocalHost         = "localhost:" + DefaultPort
+	defaultPort       = "50505"
+	defaultPortEnv    = "CLUSRUN_DEFAULT_PORT"
 	ConnectTimeout    = 10 * time.Second
 	DefaultLineLength = 60
+	TimeLayout        = "2006-01-02 15:04:05 -0700 MST"
+	authTokenEnv      = "CLUSRUN_TOKEN"
+	authUserEnv       = "CLUSRUN_USER"
+	authPasswordEnv   = "CLUSRUN_PASSWORD"
 )
 
 var (
 	LineEnding   string
 	ConsoleWidth int
+	DefaultPort  = defaultPort
 	Headnode     *string
 	secure     *bool
+	token        *string
+	user         *string
+	noColor      *bool
+	plain        *bool
+	lang         *string
+	utc          *bool
 )
 
 func SetGlobalParameters(fs *flag.FlagSet) {
-	Headnode = fs.String("headnode", LocalHost, "specify the headnode to connect")
+	DefaultPort = getDefaultPort(os.Getenv(defaultPortEnv))
+	Headnode = fs.String("headnode", "localhost:"+DefaultPort, "specify the headnode to connect, the port is "+defaultPort+" by default or from environment variable "+defaultPortEnv)
 	secure = fs.Bool("secure", false, "specify to connect headnode with secure connection")
+	token = fs.String("token", os.Getenv(authTokenEnv), "specify the token to access headnode if authentication is enabled, default is from environment variable "+authTokenEnv)
+	user = fs.String("user", os.Getenv(authUserEnv), "specify the user to access headnode if LDAP authentication is enabled, which requires -secure, the password is from environment variable "+authPasswordEnv+" or prompted, default is from environment variable "+authUserEnv)
+	noColor = fs.Bool("no-color", false, "disable colored output")
+	plain = fs.Bool("plain", false, "print plain output without colors, decorations and prefixes, for piping to other programs")
+	utc = fs.Bool("utc", false, "display times in UTC instead of the local zone of client")
+	lang = fs.String("lang", "", "specify the language of messages, one of: "+strings.Join(languages, ", ")+", default is from environment variable "+langEnv+" or the locale")
+}
+
+// The default port should be the same as the one of the cluster, which is changed by the environment variable on all nodes
+func getDefaultPort(port string) string {
+	if len(port) == 0 {
+		return defaultPort
+	}
+	if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+		Fatallnf("Invalid default port %q in environment variable %v", port, defaultPortEnv)
+	}
+	return port
 }
 
 func ParseHeadnode(headnode string) string {
-	if strings.Contains(headnode, ":") {
+	if _, _, err := net.SplitHostPort(headnode); err == nil {
 		return headnode
 	} else {
-		return headnode + ":" + DefaultPort
+		// The headnode without port, which may be an IPv6 address with or without brackets
+		return net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(headnode, "["), "]"), DefaultPort)
 	}
 }
 
@@ -78,6 +116,9 @@ func ParseNodesOrGroups(s, f string) []string {
 }
 
 func GetPaddingLine(heading string) string {
+	if IsPlain() {
+		return strings.Trim(heading, "-")
+	}
 	padding := "-"
 	line_length := DefaultLineLength
 	if ConsoleWidth > 0 {
@@ -104,6 +145,32 @@ func MaxInt(array ...int) int {
 }
 
 func ConnectHeadnode() (*grpc.ClientConn, context.CancelFunc) {
+	return connectHeadnodeWithCredentials(getHeadnodeCredentials())
+}
+
+// The credentials of the user or token given, otherwise the session cached by "clus login"
+func getHeadnodeCredentials() credentials.PerRPCCredentials {
+	if len(*user) > 0 {
+		return basicCredentials(getBasicCredential(*user))
+	} else if len(*token) > 0 {
+		return tokenCredentials(*token)
+	} else if session := getCachedSession(); session != nil {
+		return tokenCredentials(session.Token)
+	}
+	return nil
+}
+
+func connectHeadnodeWithCredentials(creds credentials.PerRPCCredentials) (*grpc.ClientConn, context.CancelFunc) {
+	conn, cancel, err := dialHeadnode(creds)
+	if err != nil {
+		Printlnf("Can not connect %v in %v: %v", *Headnode, ConnectTimeout, err)
+		Fatallnf("Please ensure the headnode is started and accessible.")
+	}
+	return conn, cancel
+}
+
+// Connect the headnode in the connect timeout, the error is returned for the caller to retry
+func dialHeadnode(creds credentials.PerRPCCredentials) (*grpc.ClientConn, context.CancelFunc, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), ConnectTimeout)
 	secureOption := grpc.WithInsecure()
 	if *secure {
@@ -112,16 +179,108 @@ func ConnectHeadnode() (*grpc.ClientConn, context.CancelFunc) {
 		}
 		secureOption = grpc.WithTransportCredentials(credentials.NewTLS(config))
 	}
-	conn, err := grpc.DialContext(ctx, ParseHeadnode(*Headnode), secureOption, grpc.WithBlock())
+	options := []grpc.DialOption{secureOption, grpc.WithBlock()}
+	if creds != nil {
+		options = append(options, grpc.WithPerRPCCredentials(creds))
+	}
+	conn, err := grpc.DialContext(ctx, ParseHeadnode(*Headnode), options...)
 	if err != nil {
-		Printlnf("Can not connect %v in %v: %v", *Headnode, ConnectTimeout, err)
-		Fatallnf("Please ensure the headnode is started and accessible.")
+		cancel()
+		return nil, nil, err
 	}
-	return conn, cancel
+	return conn, cancel, nil
+}
+
+// The token sent in metadata of each RPC to headnode
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// The user and password sent in metadata of each RPC to headnode
+type basicCredentials string
+
+func (c basicCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Basic " + string(c)}, nil
+}
+
+// The password is not sent over plaintext connection
+func (c basicCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+var (
+	basicCredential     string
+	basicCredentialOnce sync.Once
+)
+
+// The password is prompted only once even if the headnode is connected multiple times
+func getBasicCredential(user string) string {
+	if !*secure {
+		Fatallnf("The user and password can only be sent over secure connection, please specify -secure.")
+	}
+	basicCredentialOnce.Do(func() {
+		password, ok := os.LookupEnv(authPasswordEnv)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Password of %v: ", user)
+			b, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Fprintln(os.Stderr)
+			if err != nil {
+				Fatallnf("Failed to read password: %v", err)
+			}
+			password = string(b)
+		}
+		basicCredential = base64.StdEncoding.EncodeToString([]byte(user + ":" + password))
+	})
+	return basicCredential
+}
+
+// Return nil if the headnode doesn't support reporting capabilities
+func GetCapabilities() map[string]bool {
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	c := pb.NewHeadnodeClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	reply, err := c.GetCapabilities(ctx, &pb.Empty{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		Fatallnf("Failed to get capabilities of headnode: %v", err)
+	}
+	return reply.GetCapabilities()
+}
+
+// Exit if the headnode reports the capability disabled, skip checking for headnode not reporting capabilities
+func RequireCapability(capability string) {
+	if capabilities := GetCapabilities(); capabilities != nil && !capabilities[capability] {
+		Fatallnf("The %q capability is not enabled on headnode %v.", capability, *Headnode)
+	}
+}
+
+// Format the time in the local zone of client or in UTC with the offset, which keeps the times around DST transitions
+// unambiguous
+func FormatTime(t time.Time) string {
+	return DisplayTime(t).Format(TimeLayout)
+}
+
+// The time in the zone to display, which is UTC if specified or the local zone of client
+func DisplayTime(t time.Time) time.Time {
+	if utc != nil && *utc {
+		return t.UTC()
+	}
+	return t.Local()
 }
 
 func Printlnf(format string, v ...interface{}) {
-	fmt.Printf(format+LineEnding, v...)
+	fmt.Printf(T(format)+LineEnding, v...)
 }
 
 func Fatallnf(format string, v ...interface{}) {
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 )
 
 func Test_ParseNodesOrGroups(t *testing.T) {
@@ -81,3 +82,45 @@ item3
 		}
 	}
 }
+
+func Test_FormatTime(t *testing.T) {
+	local := time.Local
+	defer func() { time.Local = local }()
+	time.Local = time.FixedZone("CEST", 2*60*60)
+	cases := []struct {
+		time     time.Time
+		expected string
+	}{
+		{time.Date(2020, 5, 1, 8, 30, 15, 0, time.UTC), "2020-05-01 10:30:15 +0200 CEST"},
+		{time.Date(2020, 5, 1, 8, 30, 15, 0, time.FixedZone("", -7*60*60)), "2020-05-01 17:30:15 +0200 CEST"},
+		{time.Unix(0, 0), "1970-01-01 02:00:00 +0200 CEST"},
+	}
+	for _, c := range cases {
+		actual := FormatTime(c.time)
+		if actual != c.expected {
+			t.Errorf("\ntime=%v\nexpected=%v\n  actual=%v", c.time, c.expected, actual)
+		}
+		if parsed, err := parseJobTime(actual, time.Now()); err != nil || parsed != c.time.Unix() {
+			t.Errorf("\nformatted=%v is parsed to %v, %v", actual, parsed, err)
+		}
+	}
+}
+
+func Test_needSessionRefresh(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	cases := []struct {
+		expire, max_expire time.Time
+		expected           bool
+	}{
+		{now.Add(time.Hour), now.Add(24 * time.Hour), false},
+		{now.Add(10 * time.Minute), now.Add(24 * time.Hour), true},
+		{now.Add(10 * time.Minute), now.Add(10 * time.Minute), false},
+		{now.Add(-time.Minute), now.Add(time.Hour), true},
+	}
+	for _, c := range cases {
+		session := &cachedSession{Expire: c.expire.Unix(), MaxExpire: c.max_expire.Unix()}
+		if actual := needSessionRefresh(session, now); actual != c.expected {
+			t.Errorf("\nexpire=%v, max_expire=%v\nexpected=%v\n  actual=%v", c.expire, c.max_expire, c.expected, actual)
+		}
+	}
+}
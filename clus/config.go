@@ -0,0 +1,331 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	config_headnode = "headnode role"
+	config_clusnode = "clusnode role"
+	config_allNodes = "*"
+)
+
+func Config(args []string) {
+	if len(args) == 0 {
+		displayConfigUsage()
+		return
+	}
+	command := strings.ToLower(args[0])
+	fs := flag.NewFlagSet("clus config options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	file := fs.String("file", "clusrun.config.json", "specify the file to export configs to or import configs from")
+	dry_run := fs.Bool("dry-run", false, "validate the configs and show the changes without setting them")
+	switch command {
+	case "export":
+		_ = fs.Parse(args[1:])
+		exportConfigs(*file)
+	case "import":
+		_ = fs.Parse(args[1:])
+		importConfigs(*file, *dry_run)
+	case "rollback":
+		role := fs.String("role", "", "specify the role (headnode or clusnode) of the configs to roll back, default is headnode, or clusnode with -nodes")
+		nodes := fs.String("nodes", "", "roll back the clusnode configs pushed to the clusnodes separated by comma, "+config_allNodes+" for all nodes")
+		_ = fs.Parse(args[1:])
+		rollbackConfigs(*role, *nodes, *dry_run)
+	case "drift":
+		nodes := fs.String("nodes", config_allNodes, "compare the clusnode configs on the nodes separated by comma, "+config_allNodes+" for all nodes")
+		configs := fs.String("configs", "", "specify the clusnode configs to compare separated by comma, default is the configs can be pushed by headnode")
+		baseline := fs.String("baseline", "", `specify the expected values of configs separated by comma in format "name=value", default is the most common value among the nodes`)
+		remediate := fs.Bool("remediate", false, "push the baseline values to the nodes drifting from them")
+		_ = fs.Parse(args[1:])
+		reportConfigDrift(*nodes, *configs, *baseline, *remediate, *dry_run)
+	case "capabilities":
+		_ = fs.Parse(args[1:])
+		printCapabilities()
+	default:
+		displayConfigUsage()
+	}
+}
+
+func displayConfigUsage() {
+	Printlnf(`
+Usage:
+	clus config <command> [options]
+
+The commands are:
+	export          - export the configs of headnode role and clusnode role on the headnode to a file
+	import          - import the configs from a file to headnode role and clusnode role on the headnode
+	rollback        - restore the configs set last time on the headnode or the clusnodes to their previous values
+	drift           - compare the clusnode configs on the nodes with the baseline, and remediate the drifted ones
+	capabilities    - list the optional features enabled on the headnode
+
+Usage of export, import, rollback or drift:
+	clus config export [options]
+	clus config import [options]
+	clus config rollback [options]
+	clus config drift [options]
+	clus config export -h
+
+`)
+}
+
+func exportConfigs(file string) {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Get configs of both roles
+	headnode_configs, err := pb.NewHeadnodeClient(conn).GetConfigs(ctx, &pb.Empty{})
+	if err != nil {
+		Fatallnf("Failed to get configs of headnode role: %v", err)
+	}
+	clusnode_configs, err := pb.NewClusnodeClient(conn).GetConfigs(ctx, &pb.Empty{})
+	if err != nil {
+		Fatallnf("Failed to get configs of clusnode role: %v", err)
+	}
+	configs := map[string]map[string]string{
+		config_headnode: exportableConfigs(headnode_configs),
+		config_clusnode: exportableConfigs(clusnode_configs),
+	}
+
+	// Save configs to file
+	json_string, err := json.MarshalIndent(configs, "", "    ")
+	if err != nil {
+		Fatallnf("Failed to format configs: %v", err)
+	}
+	if err := ioutil.WriteFile(file, json_string, 0644); err != nil {
+		Fatallnf("Failed to write file %q: %v", file, err)
+	}
+	Printlnf("Configs are exported to %v", file)
+}
+
+// Skip the runtime states like connected headnodes and the masked sensitive configs, which can not be imported
+func exportableConfigs(reply *pb.GetConfigsReply) map[string]string {
+	sensitive := map[string]bool{}
+	for _, name := range reply.GetSensitive() {
+		sensitive[name] = true
+	}
+	exportable := map[string]string{}
+	for k, v := range reply.GetConfigs() {
+		if strings.HasPrefix(k, "(") || sensitive[k] {
+			continue
+		}
+		exportable[k] = v
+	}
+	return exportable
+}
+
+func importConfigs(file string, dry_run bool) {
+	// Load configs from file
+	var configs map[string]map[string]string
+	if err := json.Unmarshal([]byte(ReadFile(file)), &configs); err != nil {
+		Fatallnf("Failed to parse file %q: %v", file, err)
+	}
+	for role := range configs {
+		if role != config_headnode && role != config_clusnode {
+			Fatallnf("Invalid config role %q in file %q", role, file)
+		}
+	}
+
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Set configs of both roles
+	if c := configs[config_headnode]; len(c) > 0 {
+		reply, err := pb.NewHeadnodeClient(conn).SetConfigs(ctx, &pb.SetConfigsRequest{Configs: c, DryRun: dry_run})
+		if err != nil {
+			Fatallnf("Failed to import configs of headnode role: %v", err)
+		}
+		Printlnf("Import %v configs result:", config_headnode)
+		printConfigResults(reply.GetResults())
+	}
+	if c := configs[config_clusnode]; len(c) > 0 {
+		reply, err := pb.NewClusnodeClient(conn).SetConfigs(ctx, &pb.SetConfigsRequest{Configs: c, DryRun: dry_run})
+		if err != nil {
+			Fatallnf("Failed to import configs of clusnode role: %v", err)
+		}
+		Printlnf("Import %v configs result:", config_clusnode)
+		printConfigResults(reply.GetResults())
+	}
+}
+
+func printConfigResults(results map[string]string) {
+	keys := make([]string, 0, len(results))
+	for k := range results {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		Printlnf("\t%q: %v", k, results[k])
+	}
+}
+
+// Roll back the last set of the configs of the role on the headnode, or the clusnode configs on the nodes
+func rollbackConfigs(role, nodes string, dry_run bool) {
+	if len(role) == 0 {
+		role = "headnode"
+		if len(nodes) > 0 {
+			role = "clusnode"
+		}
+	}
+	if role != "headnode" && role != "clusnode" {
+		Fatallnf("Invalid role %q, should be headnode or clusnode.", role)
+	}
+	if len(nodes) > 0 && role != "clusnode" {
+		Fatallnf("Only the clusnode configs can be rolled back on the nodes.")
+	}
+
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	request := &pb.SetConfigsRequest{DryRun: dry_run, Rollback: true}
+	var reply *pb.SetConfigsReply
+	var err error
+	if len(nodes) > 0 {
+		request.Nodes = strings.Split(nodes, ",")
+		reply, err = pb.NewHeadnodeClient(conn).SetConfigs(ctx, request)
+	} else if role == "headnode" {
+		reply, err = pb.NewHeadnodeClient(conn).SetConfigs(ctx, request)
+	} else {
+		reply, err = pb.NewClusnodeClient(conn).SetConfigs(ctx, request)
+	}
+	if err != nil {
+		Fatallnf("Failed to roll back configs: %v", err)
+	}
+	if len(nodes) == 0 {
+		Printlnf("Roll back %v role configs result:", role)
+		printConfigResults(reply.GetResults())
+		return
+	}
+	results := reply.GetNodeResults()
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if result := results[name]; len(result.GetError()) > 0 {
+			Printlnf("Failed to roll back configs on %v: %v", name, result.GetError())
+		} else {
+			Printlnf("Roll back configs on %v result:", name)
+			printConfigResults(result.GetResults())
+		}
+	}
+}
+
+// Report the nodes whose clusnode configs drift from the baseline, and push the baseline values to them if remediate
+func reportConfigDrift(nodes, configs, baseline string, remediate, dry_run bool) {
+	request := &pb.GetConfigDriftRequest{
+		Nodes:     strings.Split(nodes, ","),
+		Configs:   ParseNodesOrGroups(configs, ""),
+		Baseline:  map[string]string{},
+		Remediate: remediate,
+		DryRun:    dry_run,
+	}
+	for _, item := range ParseNodesOrGroups(baseline, "") {
+		index := strings.Index(item, "=")
+		if index <= 0 {
+			Fatallnf("Invalid baseline %q, which should be in format name=value", item)
+		}
+		request.Baseline[strings.TrimSpace(item[:index])] = strings.TrimSpace(item[index+1:])
+	}
+
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	reply, err := pb.NewHeadnodeClient(conn).GetConfigDrift(ctx, request)
+	if err != nil {
+		Fatallnf("Failed to get config drift: %v", err)
+	}
+	Printlnf("Baseline of configs on %v nodes:", reply.GetNodes())
+	printConfigResults(reply.GetBaseline())
+	node_errors := reply.GetNodeErrors()
+	names := make([]string, 0, len(node_errors))
+	for name := range node_errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		Printlnf("Failed to get configs of %v: %v", name, node_errors[name])
+	}
+	drifts := reply.GetDrifts()
+	if len(drifts) == 0 {
+		Printlnf("No drift from the baseline is found.")
+		return
+	}
+	Printlnf("%v nodes drift from the baseline:", len(drifts))
+	for _, line := range formatConfigDrift(drifts, reply.GetBaseline()) {
+		Printlnf("%v", line)
+	}
+	results := reply.GetRemediations()
+	for _, d := range drifts {
+		result, ok := results[d.Node]
+		if !ok {
+			continue
+		}
+		if len(result.GetError()) > 0 {
+			Printlnf("Failed to remediate configs on %v: %v", d.Node, result.GetError())
+		} else {
+			Printlnf("Remediate configs on %v result:", d.Node)
+			printConfigResults(result.GetResults())
+		}
+	}
+}
+
+// Format the drifted configs of each node with the baseline values in a table
+func formatConfigDrift(drifts []*pb.ConfigDrift, baseline map[string]string) []string {
+	rows := [][]string{{"Node", "Config", "Value", "Baseline"}}
+	for _, d := range drifts {
+		names := make([]string, 0, len(d.Values))
+		for name := range d.Values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			rows = append(rows, []string{d.Node, name, d.Values[name], baseline[name]})
+		}
+	}
+	return formatTable(rows)
+}
+
+func printCapabilities() {
+	capabilities := GetCapabilities()
+	if capabilities == nil {
+		Fatallnf("The headnode doesn't support reporting capabilities.")
+	}
+	names := make([]string, 0, len(capabilities))
+	for name := range capabilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	Printlnf("Capabilities of headnode %v:", *Headnode)
+	for _, name := range names {
+		state := "disabled"
+		if capabilities[name] {
+			state = "enabled"
+		}
+		Printlnf("\t%v: %v", name, state)
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"testing"
+)
+
+func Test_exportableConfigs(t *testing.T) {
+	reply := &pb.GetConfigsReply{
+		Configs: map[string]string{
+			"log level":             "info",
+			"auth tokens":           "******",
+			"webhook":               "",
+			"(connected) headnodes": "headnode:50505",
+			"banner":                "******", // not sensitive, though it looks masked
+		},
+		Sensitive: []string{"auth tokens", "webhook"},
+	}
+	expected := map[string]string{"log level": "info", "banner": "******"}
+	if result := exportableConfigs(reply); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected exportable configs %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bufio"
+	pb "clusrun/protobuf"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	exportChunkSize     = 64 << 10
+	maxExportedJobBytes = 64 << 20
+)
+
+// Handle "clus job export [options]" and "clus job import [options] <file>"
+func JobExport(args []string) {
+	fs := flag.NewFlagSet("clus job "+args[0]+" options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	output := fs.String("output", "", "specify the tar file to export the output files of jobs to or import them from, the output files are not exported or imported if not specified")
+	switch args[0] {
+	case "export":
+		file := fs.String("o", "jobs.jsonl", "specify the file to export the jobs to, one job in JSON per line")
+		since := fs.String("since", "", `export jobs created since a duration ago (e.g. "2h", "30d") or a time (e.g. "2020-05-01" or "2020-05-01 08:00:00")`)
+		until := fs.String("until", "", "export jobs created until a duration ago or a time, in the same format of -since")
+		_ = fs.Parse(args[1:])
+		now := time.Now()
+		request := &pb.ExportJobsRequest{WithOutput: len(*output) > 0}
+		var err error
+		if request.Since, err = parseJobTime(*since, now); err != nil {
+			Fatallnf("Invalid -since: %v", err)
+		}
+		if request.Until, err = parseJobTime(*until, now); err != nil {
+			Fatallnf("Invalid -until: %v", err)
+		}
+		exportJobs(request, *file, *output)
+	case "import":
+		_ = fs.Parse(args[1:])
+		if len(fs.Args()) != 1 {
+			Fatallnf("Usage: clus job import [options] <file>")
+		}
+		importJobs(fs.Arg(0), *output)
+	}
+}
+
+func exportJobs(request *pb.ExportJobsRequest, file, output string) {
+	f, err := os.Create(file)
+	if err != nil {
+		Fatallnf("Failed to create file %q: %v", file, err)
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	var tar *os.File
+	if request.WithOutput {
+		if tar, err = os.Create(output); err != nil {
+			Fatallnf("Failed to create file %q: %v", output, err)
+		}
+		defer tar.Close()
+	}
+
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+
+	// Write the jobs and output files as they are received
+	stream, err := pb.NewHeadnodeClient(conn).ExportJobs(context.Background(), request)
+	if err != nil {
+		Fatallnf("Failed to export jobs: %v", status.Convert(err).Message())
+	}
+	count := 0
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if status.Code(err) == codes.Unimplemented {
+			Fatallnf("The headnode doesn't support exporting jobs.")
+		} else if err != nil {
+			Fatallnf("Failed to export jobs: %v", status.Convert(err).Message())
+		}
+		if job := reply.GetJob(); job != nil {
+			if err := writeExportedJob(w, job); err != nil {
+				Fatallnf("Failed to write file %q: %v", file, err)
+			}
+			count++
+		}
+		if data := reply.GetOutput(); len(data) > 0 && tar != nil {
+			if _, err := tar.Write(data); err != nil {
+				Fatallnf("Failed to write file %q: %v", output, err)
+			}
+		}
+	}
+	if err := w.Flush(); err != nil {
+		Fatallnf("Failed to write file %q: %v", file, err)
+	}
+	if tar != nil {
+		Printlnf("Exported %v jobs to %v and their output files to %v", count, file, output)
+	} else {
+		Printlnf("Exported %v jobs to %v", count, file)
+	}
+}
+
+// The jobs are exported in JSON lines with the proto names of fields, which can be parsed back by jsonpb
+func writeExportedJob(w io.Writer, job *pb.Job) error {
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	s, err := marshaler.MarshalToString(job)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, s)
+	return err
+}
+
+func readExportedJobs(r io.Reader) ([]*pb.Job, error) {
+	jobs := []*pb.Job{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxExportedJobBytes)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		job := &pb.Job{}
+		if err := jsonpb.UnmarshalString(scanner.Text(), job); err != nil {
+			return nil, fmt.Errorf(T("Invalid job at line %v: %v"), line, err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, scanner.Err()
+}
+
+func importJobs(file, output string) {
+	f, err := os.Open(file)
+	if err != nil {
+		Fatallnf("Failed to open file %q: %v", file, err)
+	}
+	defer f.Close()
+	jobs, err := readExportedJobs(f)
+	if err != nil {
+		Fatallnf("Failed to parse file %q: %v", file, err)
+	}
+	var tar *os.File
+	if len(output) > 0 {
+		if tar, err = os.Open(output); err != nil {
+			Fatallnf("Failed to open file %q: %v", output, err)
+		}
+		defer tar.Close()
+	}
+
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+
+	// Send the jobs and then the output files
+	stream, err := pb.NewHeadnodeClient(conn).ImportJobs(context.Background())
+	if err != nil {
+		Fatallnf("Failed to import jobs: %v", status.Convert(err).Message())
+	}
+	for _, job := range jobs {
+		if err := stream.Send(&pb.ImportJobsRequest{Job: job}); err != nil {
+			break
+		}
+	}
+	if tar != nil {
+		buffer := make([]byte, exportChunkSize)
+		for {
+			n, err := tar.Read(buffer)
+			if n > 0 {
+				if e := stream.Send(&pb.ImportJobsRequest{Output: buffer[:n]}); e != nil {
+					break
+				}
+			}
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				Fatallnf("Failed to read file %q: %v", output, err)
+			}
+		}
+	}
+
+	// The error of sending is got when closing
+	reply, err := stream.CloseAndRecv()
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support importing jobs.")
+	} else if err != nil {
+		Fatallnf("Failed to import jobs: %v", status.Convert(err).Message())
+	}
+	ids := reply.GetJobIds()
+	old_ids := make([]int, 0, len(ids))
+	for id := range ids {
+		old_ids = append(old_ids, int(id))
+	}
+	sort.Ints(old_ids)
+	for _, id := range old_ids {
+		if new_id := ids[int32(id)]; new_id != int32(id) {
+			Printlnf("Job %v is imported as job %v", id, new_id)
+		}
+	}
+	Printlnf("Imported %v jobs with %v output files", len(ids), reply.GetOutputFiles())
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	Lang_En   = "en"
+	Lang_ZhCn = "zh-CN"
+	langEnv   = "CLUSRUN_LANG"
+)
+
+var (
+	// The catalogs map the message formats in English to the ones in other languages, in which the arguments can be reordered by explicit index like %[2]v
+	messageCatalogs = map[string]map[string]string{
+		Lang_ZhCn: messageCatalog_ZhCn,
+	}
+	languages = []string{Lang_En, Lang_ZhCn}
+
+	// The environment variables of locale in the order of precedence on Unix
+	localeEnvs = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+)
+
+// Translate the message format to the selected language, the format is returned as is if it is not translated
+func T(format string) string {
+	if catalog, ok := messageCatalogs[getLanguage()]; ok {
+		if translated, ok := catalog[format]; ok {
+			return translated
+		}
+	}
+	return format
+}
+
+// The language is selected by -lang, or the environment variable CLUSRUN_LANG, or the locale, otherwise English
+func getLanguage() string {
+	var specified string
+	if lang != nil {
+		specified = *lang
+	}
+	return selectLanguage(specified, os.Getenv)
+}
+
+func selectLanguage(specified string, getenv func(string) string) string {
+	candidates := []string{specified, getenv(langEnv)}
+	for _, env := range localeEnvs {
+		candidates = append(candidates, getenv(env))
+	}
+	for _, c := range candidates {
+		if len(c) == 0 {
+			continue
+		}
+		// The first locale set is used even if it is not supported, like the precedence of locale environment variables
+		if l := parseLocale(c); len(l) > 0 {
+			return l
+		}
+		return Lang_En
+	}
+	return Lang_En
+}
+
+// Parse the locale like "zh_CN.UTF-8", "zh-cn" or "en_US" to a supported language, empty if not supported
+func parseLocale(locale string) string {
+	locale = strings.TrimSpace(locale)
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	switch {
+	case locale == "c" || locale == "posix" || locale == "en" || strings.HasPrefix(locale, "en-"):
+		return Lang_En
+	case locale == "zh" || locale == "zh-cn" || locale == "zh-hans" || strings.HasPrefix(locale, "zh-hans-") || locale == "zh-sg":
+		return Lang_ZhCn
+	}
+	return ""
+}
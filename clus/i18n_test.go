@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_parseLocale(t *testing.T) {
+	cases := []struct {
+		locale   string
+		expected string
+	}{
+		{"en", Lang_En},
+		{"en_US.UTF-8", Lang_En},
+		{"C", Lang_En},
+		{"POSIX", Lang_En},
+		{"zh-CN", Lang_ZhCn},
+		{"zh_CN.UTF-8", Lang_ZhCn},
+		{"zh_CN.GB18030@pinyin", Lang_ZhCn},
+		{"zh", Lang_ZhCn},
+		{"zh-Hans-CN", Lang_ZhCn},
+		{"zh_TW.UTF-8", ""},
+		{"fr_FR.UTF-8", ""},
+		{"", ""},
+	}
+	for _, c := range cases {
+		if actual := parseLocale(c.locale); actual != c.expected {
+			t.Errorf("\nlocale=%q\nexpected=%q\n  actual=%q", c.locale, c.expected, actual)
+		}
+	}
+}
+
+func Test_selectLanguage(t *testing.T) {
+	cases := []struct {
+		specified string
+		envs      map[string]string
+		expected  string
+	}{
+		{"", nil, Lang_En},
+		{"zh-CN", nil, Lang_ZhCn},
+		{"en", map[string]string{langEnv: "zh-CN", "LANG": "zh_CN.UTF-8"}, Lang_En},
+		{"", map[string]string{langEnv: "zh-CN", "LANG": "en_US.UTF-8"}, Lang_ZhCn},
+		{"", map[string]string{"LANG": "zh_CN.UTF-8"}, Lang_ZhCn},
+		{"", map[string]string{"LC_ALL": "en_US.UTF-8", "LANG": "zh_CN.UTF-8"}, Lang_En},
+		{"", map[string]string{"LC_MESSAGES": "zh_CN.UTF-8", "LANG": "en_US.UTF-8"}, Lang_ZhCn},
+		{"", map[string]string{"LC_ALL": "fr_FR.UTF-8", "LANG": "zh_CN.UTF-8"}, Lang_En},
+	}
+	for _, c := range cases {
+		getenv := func(key string) string { return c.envs[key] }
+		if actual := selectLanguage(c.specified, getenv); actual != c.expected {
+			t.Errorf("\nspecified=%q, envs=%v\nexpected=%q\n  actual=%q", c.specified, c.envs, c.expected, actual)
+		}
+	}
+}
+
+// Each translated message should use every argument of the original message exactly in valid verbs
+func Test_messageCatalogs(t *testing.T) {
+	for lang, catalog := range messageCatalogs {
+		for format, translated := range catalog {
+			count := strings.Count(strings.ReplaceAll(format, "%%", ""), "%")
+			args := make([]interface{}, count)
+			for i := range args {
+				args[i] = fmt.Sprintf("arg%v", i+1)
+			}
+			actual := fmt.Sprintf(translated, args...)
+			if strings.Contains(actual, "%!") {
+				t.Errorf("\nlang=%v\nformat=%q\ntranslated=%q\nformatted=%q", lang, format, translated, actual)
+				continue
+			}
+			for _, arg := range args {
+				if !strings.Contains(actual, arg.(string)) {
+					t.Errorf("\nlang=%v\nformat=%q\ntranslated=%q misses %v", lang, format, translated, arg)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+var messageCatalog_ZhCn = map[string]string{
+	// Connection and authentication
+	"Can not connect %v in %v: %v":                                                   "无法在 %[2]v 内连接 %[1]v：%[3]v",
+	"Please ensure the headnode is started and accessible.":                          "请确保头节点已启动且可以访问。",
+	"The %q capability is not enabled on headnode %v.":                               "头节点 %[2]v 未启用 %[1]q 功能。",
+	"Capabilities of headnode %v:":                                                   "头节点 %v 的功能：",
+	"The headnode doesn't support login.":                                            "头节点不支持登录。",
+	"The headnode doesn't support login by password or OIDC, please specify -token.": "头节点不支持密码或 OIDC 登录，请指定 -token。",
+	"Failed to log in: %v":                                                           "登录失败：%v",
+	"Logged in %v as %v with role %v, the session is refreshed until %v.":            "已以 %[2]v 身份登录 %[1]v，角色为 %[3]v，会话有效期刷新至 %[4]v。",
+	"Logged out %v.":    "已登出 %v。",
+	"Not logged in %v.": "未登录 %v。",
+	"To log in, open %v in a browser and enter the code %v":                      "请在浏览器中打开 %v 并输入代码 %v 以登录",
+	"To log in, open %v in a browser and confirm the code %v":                    "请在浏览器中打开 %v 并确认代码 %v 以登录",
+	"The OIDC provider doesn't support device flow":                              "OIDC 提供方不支持设备授权流程",
+	"No ID token is returned":                                                    "未返回 ID 令牌",
+	"The code is expired":                                                        "代码已过期",
+	"[Warning] Session of %v on %v is expired, please run \"clus login\" again.": "[警告] %[1]v 在 %[2]v 上的会话已过期，请重新运行 \"clus login\"。",
+	"[Warning] Failed to refresh session of %v on %v: %v":                        "[警告] 刷新 %[1]v 在 %[2]v 上的会话失败：%[3]v",
+	"[Warning] Failed to cache session: %v":                                      "[警告] 缓存会话失败：%v",
+	"[Warning] Ignore the invalid session cache %v: %v":                          "[警告] 忽略无效的会话缓存 %v：%v",
+	"[Warning] Failed to get console width: %v":                                  "[警告] 获取控制台宽度失败：%v",
+
+	// Unsupported features
+	"The headnode doesn't support job schedules.":             "头节点不支持作业计划。",
+	"The headnode doesn't support job templates.":             "头节点不支持作业模板。",
+	"The headnode doesn't support job bookmarks.":             "头节点不支持作业书签。",
+	"The headnode doesn't support undo.":                      "头节点不支持撤销。",
+	"The headnode doesn't support revalidating nodes.":        "头节点不支持重新验证节点。",
+	"The headnode doesn't support reporting cluster summary.": "头节点不支持报告集群摘要。",
+	"The headnode doesn't support reporting capabilities.":    "头节点不支持报告功能。",
+	"The headnode doesn't support removing nodes.":            "头节点不支持移除节点。",
+	"The headnode doesn't support node keys.":                 "头节点不支持节点密钥。",
+	"The headnode doesn't support draining nodes.":            "头节点不支持排空节点。",
+	"The headnode doesn't support subscribing events.":        "头节点不支持订阅事件。",
+	"The headnode doesn't support forwarding stdin.":          "头节点不支持转发标准输入。",
+	"The headnode doesn't support watching jobs.":             "头节点不支持监视作业。",
+	"The headnode or the node doesn't support shell.":         "头节点或节点不支持 shell。",
+	"Failed to open shell: %v":                                "打开 shell 失败：%v",
+	"Failed to set terminal in raw mode: %v":                  "设置终端为原始模式失败：%v",
+	"Shell session is closed: %v":                             "shell 会话已关闭：%v",
+	"Pseudo terminal is not supported on node %v.":            "节点 %v 不支持伪终端。",
+
+	// Jobs
+	"Invalid job id: %q":                                             "无效的作业 ID：%q",
+	"Invalid operation id: %q":                                       "无效的操作 ID：%q",
+	"Invalid range: %q":                                              "无效的范围：%q",
+	"Invalid id: %q":                                                 "无效的 ID：%q",
+	"Invalid job state: %q":                                          "无效的作业状态：%q",
+	"%q is neither a duration nor a time":                            "%q 既不是时长也不是时间",
+	"Can not get jobs: %v":                                           "无法获取作业：%v",
+	"Job count: %v":                                                  "作业数：%v",
+	"Jobs: %v running, %v queued":                                    "作业：%v 个运行中，%v 个排队中",
+	"Job %v started on %v nodes in cluster %q.":                      "作业 %[1]v 已在集群 %[3]q 的 %[2]v 个节点上启动。",
+	"Job %v will start on %v nodes in cluster %q after %v.":          "作业 %[1]v 将在 %[4]v 后于集群 %[3]q 的 %[2]v 个节点上启动。",
+	"Job %v is still running.":                                       "作业 %v 仍在运行。",
+	"%v of %v nodes succeeded.":                                      "%[2]v 个节点中有 %[1]v 个成功。",
+	"%v of %v nodes succeeded, %v failed, %v unreachable.":           "%[2]v 个节点中有 %[1]v 个成功，%[3]v 个失败，%[4]v 个不可达。",
+	"Slowest node: %v in %v":                                         "最慢的节点：%v，用时 %v",
+	"Wall time: %v, exit code: %v":                                   "总耗时：%v，退出码：%v",
+	"Failed nodes (%v/%v): %v":                                       "失败的节点（%v/%v）：%v",
+	"No failed nodes.":                                               "没有失败的节点。",
+	"Command %v on node %v in %v.":                                   "节点 %[2]v 上的命令%[1]v，耗时 %[3]v。",
+	"[%v/%v] Command %v on node %v in %v.":                           "[%[1]v/%[2]v] 节点 %[4]v 上的命令%[3]v，耗时 %[5]v。",
+	"Failure analysis:":                                              "失败分析：",
+	"Command on lost node %v is rescheduled to node %v.":             "失联节点 %v 上的命令已重新调度到节点 %v。",
+	"Please specify jobs to cancel.":                                 "请指定要取消的作业。",
+	"Please specify jobs to rerun.":                                  "请指定要重新运行的作业。",
+	"Please specify jobs to retry.":                                  "请指定要重试的作业。",
+	"No job is cancelled.":                                           "没有作业被取消。",
+	"No jobs to rerun.":                                              "没有要重新运行的作业。",
+	"No jobs to retry.":                                              "没有要重试的作业。",
+	"No jobs to purge.":                                              "没有要清除的作业。",
+	"Jobs to cancel in %v seconds: %v":                               "将在 %v 秒后取消的作业：%v",
+	"Conflict options: -rerun and -retry":                            "选项冲突：-rerun 和 -retry",
+	"Can not retry job with sweep option.":                           "无法重试带有 sweep 选项的作业。",
+	"Failed to get output: %v":                                       "获取输出失败：%v",
+	"Failed to receive output.":                                      "接收输出失败。",
+	"Failed to forward stdin: %v":                                    "转发标准输入失败：%v",
+	"Stopped forwarding stdin to %v nodes: %v":                       "已停止向 %v 个节点转发标准输入：%v",
+	"The stdin can not be forwarded to a job running in background.": "无法向后台运行的作业转发标准输入。",
+	"Output is dumped to %v":                                         "输出已转储到 %v",
+	"Dumping output to %v":                                           "正在将输出转储到 %v",
+	"Writing stdout to %v":                                           "正在将标准输出写入 %v",
+	"Writing stderr to %v":                                           "正在将标准错误写入 %v",
+	"Timeline of job %v is saved to %v":                              "作业 %v 的时间线已保存到 %v",
+	"Showing the first %v matched lines.":                            "显示前 %v 行匹配结果。",
+	"Offset and tail should not be negative.":                        "偏移量和末尾行数不能为负数。",
+	"Offset and tail can not be specified together.":                 "偏移量和末尾行数不能同时指定。",
+	"Per-node commands can not be specified with command or script.": "按节点命令不能与命令或脚本同时指定。",
+	"Percent should be an integer between 0 and 100":                 "百分比应为 0 到 100 之间的整数",
+	"Count should be a non-negative integer":                         "数量应为非负整数",
+	"No command for node %v":                                         "节点 %v 没有命令",
+	"Duplicate node %v":                                              "重复的节点 %v",
+	"No commands":                                                    "没有命令",
+	"Job schedule count: %v":                                         "作业计划数：%v",
+	"Job schedule %v is saved.":                                      "作业计划 %v 已保存。",
+	"Job schedules not found: %v":                                    "未找到作业计划：%v",
+	"Deleted %v job schedules: %v":                                   "已删除 %v 个作业计划：%v",
+	"Job template count: %v":                                         "作业模板数：%v",
+	"Job template %v is saved.":                                      "作业模板 %v 已保存。",
+	"Job templates not found: %v":                                    "未找到作业模板：%v",
+	"Deleted %v job templates: %v":                                   "已删除 %v 个作业模板：%v",
+	"Job bookmark count: %v":                                         "作业书签数：%v",
+	"Job bookmarks not found: %v":                                    "未找到作业书签：%v",
+	"Deleted %v job bookmarks: %v":                                   "已删除 %v 个作业书签：%v",
+	"No operations can be undone.":                                   "没有可以撤销的操作。",
+	"---%v nodes returned---":                                        "---%v 个节点返回---",
+	"---1 node returned---":                                          "---1 个节点返回---",
+	"[Warning] Output stream of job %v is lost, reattaching: %v":     "[警告] 作业 %v 的输出流已丢失，正在重新连接：%v",
+	"Reattached to job %v.":                                          "已重新连接到作业 %v。",
+	"[Warning] Failed to reconnect the headnode, retrying: %v":       "[警告] 重新连接头节点失败，正在重试：%v",
+	"Failed to reattach to job %v: %v":                               "重新连接到作业 %v 失败：%v",
+	"[Warning] Failed to reattach to job %v, retrying: %v":           "[警告] 重新连接到作业 %v 失败，正在重试：%v",
+
+	// Nodes
+	"Node count: %v":                "节点数：%v",
+	"Node groups: %v":               "节点组：%v",
+	"No group of nodes.":            "没有节点组。",
+	"Count of nodes in %v: %v":      "%v 中的节点数：%v",
+	"Removed nodes: %v":             "已移除的节点：%v",
+	"Removed %v lost nodes: %v":     "已移除 %v 个失联节点：%v",
+	"Skipped %v nodes not lost: %v": "已跳过 %v 个未失联的节点：%v",
+	"Skipped %v nodes not satisfying resource requirements, draining or excluded: %v": "已跳过 %v 个不满足资源要求、正在排空或被排除的节点：%v",
+	"Revalidating nodes...":                                          "正在重新验证节点...",
+	"Please specify one pattern of the lost nodes to remove.":        "请指定一个要移除的失联节点的模式。",
+	"Please specify at most one pattern of the nodes to revalidate.": "请最多指定一个要重新验证的节点的模式。",
+	"Please specify group-by option.":                                "请指定 group-by 选项。",
+	"Invalid group-by option: %v":                                    "无效的 group-by 选项：%v",
+	"Invalid order-by option: %v":                                    "无效的 order-by 选项：%v",
+	"Invalid node state option: %v":                                  "无效的节点状态选项：%v",
+	"Failed to format in JSON: %v":                                   "格式化为 JSON 失败：%v",
+	"Failed to write CSV: %v":                                        "写入 CSV 失败：%v",
+	"Invalid format option: %v":                                      "无效的格式选项：%v",
+	"Invalid column %q, valid columns are: %v":                       "无效的列 %q，有效的列为：%v",
+
+	// Events
+	"Please specify at most one of -nodes and -jobs.":    "请最多指定 -nodes 和 -jobs 中的一个。",
+	"Failed to watch events: %v":                         "监视事件失败：%v",
+	"[Warning] Event stream is broken, reconnecting: %v": "[警告] 事件流已中断，正在重新连接：%v",
+	"[%v] Node %v: %v -> %v":                             "[%v] 节点 %v：%v -> %v",
+	"[%v] Job %v: %v -> %v":                              "[%v] 作业 %v：%v -> %v",
+	"[%v] Job %v: %v -> %v, failed on %v of %v nodes":    "[%[1]v] 作业 %[2]v：%[3]v -> %[4]v，在 %[6]v 个节点中的 %[5]v 个上失败",
+
+	// Files and configs
+	"Failed to read file %q: %v":                                                            "读取文件 %q 失败：%v",
+	"Failed to write file %q: %v":                                                           "写入文件 %q 失败：%v",
+	"Invalid encoding %q of file %q":                                                        "文件 %[2]q 的编码 %[1]q 无效",
+	"Invalid parameter: %v":                                                                 "无效的参数：%v",
+	"Invalid file or directory to upload: %v":                                               "无效的上传文件或目录：%v",
+	"Failed to upload files: %v":                                                            "上传文件失败：%v",
+	"Failed to start uploading: %v":                                                         "开始上传失败：%v",
+	"Skip %v which is not a regular file":                                                   "跳过非普通文件 %v",
+	"Sent %v files (%v bytes) to headnode %v, distributing to nodes":                        "已向头节点 %[3]v 发送 %[1]v 个文件（%[2]v 字节），正在分发到节点",
+	"Files are collected to %v on headnode %v":                                              "文件已收集到头节点 %[2]v 上的 %[1]v",
+	"Configs are exported to %v":                                                            "配置已导出到 %v",
+	"Import %v configs result:":                                                             "导入 %v 配置的结果：",
+	"Invalid config role %q in file %q":                                                     "文件 %[2]q 中的配置角色 %[1]q 无效",
+	"[Warning] %v (lint rule %v)":                                                           "[警告] %v（检查规则 %v）",
+	"Invalid label %q, which should be in format key=value":                                 "无效的标签 %q，格式应为 key=value",
+	"The headnode doesn't support node labels.":                                             "头节点不支持节点标签。",
+	"Could not set node labels: %v":                                                         "无法设置节点标签：%v",
+	"Invalid default port %q in environment variable %v":                                    "环境变量 %[2]v 中的默认端口 %[1]q 无效",
+	"Per-OS commands can not be specified with per-node commands or script.":                "按操作系统的命令不能与按节点的命令或脚本同时指定。",
+	"Invalid per-OS commands file %v: %v":                                                   "无效的按操作系统命令文件 %v：%v",
+	"No command for OS %v":                                                                  "没有操作系统 %v 的命令",
+	"Duplicate OS %v":                                                                       "重复的操作系统 %v",
+	"Script can not be wrapped with PowerShell, please specify the shell instead.":          "脚本不能用 PowerShell 包装，请改为指定 shell。",
+	"Timeout should not be negative.":                                                       "超时不应为负数。",
+	"Retention should not be negative.":                                                     "保留时间不应为负数。",
+	"Limit should not be negative.":                                                         "数量限制不应为负数。",
+	"Invalid role %q, should be headnode or clusnode.":                                      "无效的角色 %q，应为 headnode 或 clusnode。",
+	"Only the clusnode configs can be rolled back on the nodes.":                            "只有 clusnode 配置可以在节点上回滚。",
+	"Failed to roll back configs: %v":                                                       "回滚配置失败：%v",
+	"Roll back %v role configs result:":                                                     "回滚 %v 角色配置的结果：",
+	"Failed to roll back configs on %v: %v":                                                 "在 %v 上回滚配置失败：%v",
+	"Roll back configs on %v result:":                                                       "在 %v 上回滚配置的结果：",
+	"Logged in %v as %v with role %v in cluster %v, the session is refreshed until %v.":     "已以 %[2]v 身份登录 %[1]v，角色为集群 %[4]v 中的 %[3]v，会话有效期刷新至 %[5]v。",
+	"Please specify at most one pattern of the nodes to test.":                              "请最多指定一个要测试的节点的模式。",
+	"Testing nodes...":                                                                      "正在测试节点...",
+	"The headnode doesn't support testing nodes.":                                           "头节点不支持测试节点。",
+	"Could not test nodes: %v":                                                              "无法测试节点：%v",
+	"Warning: clocks of %v nodes are skewed over the max, please check their time sync: %v": "警告：%v 个节点的时钟偏差超过上限，请检查它们的时间同步：%v",
+	"Usage: clus job import [options] <file>":                                               "用法：clus job import [options] <file>",
+	"Failed to create file %q: %v":                                                          "创建文件 %q 失败：%v",
+	"Failed to export jobs: %v":                                                             "导出作业失败：%v",
+	"The headnode doesn't support exporting jobs.":                                          "头节点不支持导出作业。",
+	"Exported %v jobs to %v and their output files to %v":                                   "已导出 %v 个作业到 %v，其输出文件到 %v",
+	"Exported %v jobs to %v":                                                                "已导出 %v 个作业到 %v",
+	"Invalid job at line %v: %v":                                                            "第 %v 行的作业无效：%v",
+	"Failed to open file %q: %v":                                                            "打开文件 %q 失败：%v",
+	"Failed to import jobs: %v":                                                             "导入作业失败：%v",
+	"The headnode doesn't support importing jobs.":                                          "头节点不支持导入作业。",
+	"Job %v is imported as job %v":                                                          "作业 %v 已导入为作业 %v",
+	"Imported %v jobs with %v output files":                                                 "已导入 %v 个作业及 %v 个输出文件",
+	"Limit and seq should not be negative.":                                                 "数量限制和序号不应为负数。",
+	"The headnode doesn't support audit log.":                                               "头节点不支持审计日志。",
+	"Failed to get audit log: %v":                                                           "获取审计日志失败：%v",
+	"%v of %v entries are got, the hash of the last entry is %v":                            "已获取 %v 条（共 %v 条）记录，最后一条记录的哈希为 %v",
+	"Audit log is verified.":                                                                "审计日志已验证。",
+	"Audit log is broken: %v":                                                               "审计日志已损坏：%v",
+	"[Warning] Failed to start job, retrying: %v":                                           "[警告] 启动作业失败，正在重试：%v",
+	"The stdin can not be forwarded to a job to confirm, which is read for confirmation.":   "无法将标准输入转发到需要确认的作业，标准输入将用于确认。",
+	"The headnode doesn't support dry run.":                                                 "头节点不支持试运行。",
+	"Job is not started.":                                                                   "作业未启动。",
+	"The job would start on %v nodes in cluster %q.":                                        "作业将在集群 %[2]q 中的 %[1]v 个节点上启动。",
+	"Run the command on %v nodes? [y/N] ":                                                   "在 %v 个节点上运行命令？[y/N] ",
+	"Could not watch nodes: %v":                                                             "无法监视节点：%v",
+	"Refresh and count should be positive, and failures should not be negative.":            "刷新间隔和数量应为正数，失败数量不应为负数。",
+	"The headnode doesn't support cluster summary.":                                         "头节点不支持集群摘要。",
+	"[Warning] Failed to refresh the view: %v":                                              "[警告] 刷新视图失败：%v",
+	"clus top - %v, headnode %v up %v":                                                      "clus top - %v，头节点 %v 已运行 %v",
+	"Nodes: %v total, %v":                                                                   "节点：共 %v 个，%v",
+	"Recent failures:":                                                                      "最近的失败：",
+	"[Warning] Event stream is broken: %v":                                                  "[警告] 事件流已中断：%v",
+	"No failure since the view is started.":                                                 "视图启动以来没有失败。",
+	"The headnode doesn't support rerunning jobs.":                                          "头节点不支持重新运行作业。",
+	"Job %v doesn't exist.":                                                                 "作业 %v 不存在。",
+	"%v/%v nodes finished, %v failed, %v running":                                           "%v/%v 个节点已完成，%v 个失败，%v 个正在运行",
+	"Usage: clus job stats <job id>":                                                        "用法：clus job stats <job id>",
+	"Output received: %v stdout, %v stderr":                                                 "已接收输出：标准输出 %v，标准错误 %v",
+	"Invalid baseline %q, which should be in format name=value":                             "无效的基线 %q，格式应为 name=value",
+	"Failed to get config drift: %v":                                                        "获取配置偏差失败：%v",
+	"Baseline of configs on %v nodes:":                                                      "%v 个节点上配置的基线：",
+	"Failed to get configs of %v: %v":                                                       "获取 %v 的配置失败：%v",
+	"No drift from the baseline is found.":                                                  "未发现与基线的偏差。",
+	"%v nodes drift from the baseline:":                                                     "%v 个节点偏离基线：",
+	"Failed to remediate configs on %v: %v":                                                 "在 %v 上修复配置失败：%v",
+	"Remediate configs on %v result:":                                                       "在 %v 上修复配置的结果：",
+	"The output of a job run across peer headnodes can not be reattached.":                  "跨对等头节点运行的作业的输出无法重新连接。",
+	"Job started on %v nodes across peer headnodes: %v.":                                    "作业已在对等头节点的 %v 个节点上启动：%v。",
+	"[Warning] Job is not started on peer headnode %v: %v":                                  "[警告] 作业未在对等头节点 %v 上启动：%v",
+	"Nice level should be between 0 and 19.":                                                "nice 级别应在 0 到 19 之间。",
+	"Please specify at most one pattern of the nodes to show history.":                      "请最多指定一个要显示历史的节点模式。",
+	"The headnode doesn't support node history.":                                            "头节点不支持节点历史。",
+	"Could not get node history: %v":                                                        "无法获取节点历史：%v",
+	"Invalid window %q, should be like 30m, 12h or 7d":                                      "无效的时间窗口 %q，应类似 30m、12h 或 7d",
+	"No window is specified":                                                                "未指定时间窗口",
+	"No node is found.":                                                                     "未找到节点。",
+}
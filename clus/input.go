@@ -0,0 +1,59 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	jobInputChunkSize = 32 * 1024
+)
+
+// Forward stdin to the job until it ends, each chunk is sent after the previous one is written on all nodes
+func forwardJobInput(ctx context.Context, c pb.HeadnodeClient, id int32) {
+	stream, err := c.ForwardJobInput(ctx)
+	if err == nil {
+		err = stream.Send(&pb.JobInputRequest{JobId: id})
+	}
+	var reply *pb.JobInputReply
+	if err == nil {
+		reply, err = stream.Recv()
+	}
+	if status.Code(err) == codes.Unimplemented {
+		Printlnf("The headnode doesn't support forwarding stdin.")
+		return
+	} else if err != nil {
+		Printlnf("Failed to forward stdin: %v", status.Convert(err).Message())
+		return
+	}
+	printJobInputFailures(reply.GetFailedNodes())
+	buffer := make([]byte, jobInputChunkSize)
+	for {
+		n, read_err := os.Stdin.Read(buffer)
+		request := &pb.JobInputRequest{Data: append([]byte{}, buffer[:n]...), Close: read_err != nil}
+		if err := stream.Send(request); err != nil {
+			_, err = stream.Recv()
+			Printlnf("Failed to forward stdin: %v", status.Convert(err).Message())
+			return
+		}
+		if reply, err = stream.Recv(); err != nil {
+			Printlnf("Failed to forward stdin: %v", status.Convert(err).Message())
+			return
+		}
+		printJobInputFailures(reply.GetFailedNodes())
+		if request.Close {
+			_ = stream.CloseSend()
+			return
+		}
+	}
+}
+
+func printJobInputFailures(failed map[string]string) {
+	if len(failed) > 0 {
+		Printlnf("Stopped forwarding stdin to %v nodes: %v", len(failed), formatSkippedNodes(failed))
+	}
+}
@@ -5,31 +5,59 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
-	jobId_all = 0
+	jobId_all         = 0
+	jobsStreamTimeout = time.Minute
+	jobTimeZoneLayout = "MST -0700" // the zone of headnode in job, e.g. "CST +0800"
+
+	maxFailureClusterNodes = 10
 )
 
 func Job(args []string) {
 	fs := flag.NewFlagSet("clus job options", flag.ExitOnError)
 	SetGlobalParameters(fs)
-	format := fs.String("format", "", "format the jobs in table or list")
+	format := fs.String("format", "", "format the jobs in table, wide (table without truncation), list, json (with all fields) or csv")
+	columns := fs.String("columns", "", "specify the columns (id, name, state, progress, create time, end time, command) separated by comma to display in table, wide or csv format")
 	cancel := fs.Bool("cancel", false, "cancel jobs")
 	rerun := fs.Bool("rerun", false, "rerun jobs")
 	retry := fs.Bool("retry", false, "retry jobs on the failed nodes")
+	env := fs.Bool("env", false, `show the environment variables captured on each node for jobs run with "clus run -capture-env"`)
+	selection := fs.String("select", "", `select the value in path (e.g. ".version" or ".disks[0].size") from the JSON results of jobs run with "clus run -json"`)
 	// output := fs.Bool("output", false, "get output of jobs")
 	// nodes := fs.String("nodes", "", "get info or output of jobs on certain nodes")
-	// state := fs.String("state", "", "get jobs in certain state")
+	states := fs.String("state", "", "get jobs in certain states separated by comma, e.g. failed,canceled")
+	since := fs.String("since", "", `get jobs created since a duration ago (e.g. "2h", "3d") or a time (e.g. "2020-05-01" or "2020-05-01 08:00:00")`)
+	until := fs.String("until", "", "get jobs created until a duration ago or a time, in the same format of -since")
+	node := fs.String("node", "", "get jobs run on a certain node")
+	command := fs.String("command", "", "get jobs with command containing a certain string")
+	cluster := fs.String("cluster", "", "get jobs in a certain cluster configured on headnode")
+	limit := fs.Int("limit", 0, "get at most a certain count of jobs, 0 for all")
+	offset := fs.Int("offset", 0, "skip a certain count of jobs in order of id, a negative offset is counted from the last job, e.g. \"-offset -20\" for the last 20 jobs")
 	_ = fs.Parse(args)
+	if a := fs.Arg(0); a == "bookmark" || a == "unbookmark" {
+		JobBookmark(fs.Args())
+		return
+	} else if a == "export" || a == "import" {
+		JobExport(fs.Args())
+		return
+	} else if a == "stats" {
+		JobStats(fs.Args())
+		return
+	}
 	no_job_args := len(fs.Args()) == 0
-	job_ids, err := parseJobIds(fs.Args())
+	job_ids, err := parseJobIds(resolveJobBookmarks(fs.Args()))
 	if err != nil {
 		Fatallnf("%v", err)
 	}
@@ -46,7 +74,40 @@ func Job(args []string) {
 	if no_job_args {
 		job_ids[jobId_all] = false
 	}
-	jobs := getJobs(job_ids)
+	if *limit < 0 {
+		Fatallnf("Limit should not be negative.")
+	}
+	request := &pb.GetJobsRequest{JobIds: job_ids, Select: *selection, Node: *node, Command: *command, Cluster: *cluster, Limit: int32(*limit), Offset: int32(*offset)}
+	if request.States, err = parseJobStates(*states); err != nil {
+		Fatallnf("%v", err)
+	}
+	now := time.Now()
+	if request.Since, err = parseJobTime(*since, now); err != nil {
+		Fatallnf("Invalid -since: %v", err)
+	}
+	if request.Until, err = parseJobTime(*until, now); err != nil {
+		Fatallnf("Invalid -until: %v", err)
+	}
+	if len(*format) == 0 {
+		if no_job_args && len(*selection) == 0 {
+			*format = "table"
+		} else {
+			*format = "list"
+		}
+	}
+	if !*rerun && !*retry && strings.ToLower(*format) == "list" {
+		// Print the jobs progressively as they are received
+		count := 0
+		streamJobs(request, func(job *pb.Job) {
+			jobPrintListItem(job, *env)
+			count++
+		})
+		Printlnf("Job count: %v", count)
+		return
+	}
+	// The table only shows the summary of jobs, in which the command is truncated
+	request.Summary = !*rerun && !*retry && strings.ToLower(*format) == "table" && len(*selection) == 0
+	jobs := getJobs(request)
 	if *rerun {
 		if *retry {
 			Printlnf("Conflict options: -rerun and -retry")
@@ -59,7 +120,13 @@ func Job(args []string) {
 				lebal := fmt.Sprintf("Rerun job %v", job.Id)
 				fmt.Printf("%v: ", lebal)
 				name := fmt.Sprintf("[%v] %v", lebal, job.Name)
-				RunJob(job.Command, job.Sweep, "", job.NodePattern, name, job.NodeGroups, job.SpecifiedNodes, job.Arguments, 0, 0, true, false, false)
+				nodes := job.SpecifiedNodes
+				if len(job.NodeCommands) > 0 {
+					nodes = nil
+				}
+				request := getRerunRequest(job, name, nodes, job.NodeCommands)
+				request.Sweep, request.Pattern, request.Groups, request.Labels, request.Where, request.MaxReschedules = job.Sweep, job.NodePattern, job.NodeGroups, job.Labels, job.Where, job.MaxReschedules
+				RunJob(request, runOptions{background: true})
 			}
 		}
 		return
@@ -84,24 +151,27 @@ func Job(args []string) {
 					for node := range job.FailedNodes {
 						failedNodes = append(failedNodes, node)
 					}
-					RunJob(job.Command, "", "", "", name, nil, failedNodes, job.Arguments, 0, 0, true, false, false)
+					node_commands := getNodeCommands(job, failedNodes)
+					if len(node_commands) > 0 {
+						failedNodes = nil
+					}
+					RunJob(getRerunRequest(job, name, failedNodes, node_commands), runOptions{background: true})
 				}
 			}
 		}
 		return
 	}
-	if len(*format) == 0 {
-		if no_job_args {
-			*format = "table"
-		} else {
-			*format = "list"
-		}
-	}
 	switch strings.ToLower(*format) {
-	case "table":
-		jobPrintTable(jobs)
-	case "list":
-		jobPrintList(jobs)
+	case "table", "wide":
+		jobPrintTable(jobs, ParseColumns(*columns, jobTableColumns), strings.ToLower(*format) == "wide")
+	case "json":
+		messages := make([]proto.Message, len(jobs))
+		for i, job := range jobs {
+			messages[i] = job
+		}
+		PrintJson(messages)
+	case "csv":
+		jobPrintCsv(jobs, ParseColumns(*columns, jobTableColumns))
 	default:
 		Printlnf("Invalid format option: %v", *format)
 		return
@@ -133,13 +203,13 @@ func parseJobIds(args []string) (job_ids map[int32]bool, err error) {
 				begin = parts[0]
 				end = parts[1]
 			} else {
-				err = fmt.Errorf("Invalid range: %q", id)
+				err = fmt.Errorf(T("Invalid range: %q"), id)
 				return
 			}
 			ids := make([]int, 2)
 			for i, val := range []string{begin, end} {
 				if job_id, e := strconv.Atoi(strings.TrimSpace(val)); e != nil || job_id == 0 || inverse && job_id < 0 {
-					err = fmt.Errorf("Invalid id: %q", val)
+					err = fmt.Errorf(T("Invalid id: %q"), val)
 					return
 				} else {
 					ids[i] = job_id
@@ -157,6 +227,49 @@ func parseJobIds(args []string) (job_ids map[int32]bool, err error) {
 	return
 }
 
+func parseJobStates(s string) ([]pb.JobState, error) {
+	states := []pb.JobState{}
+	for _, state := range strings.Split(s, ",") {
+		if state = strings.TrimSpace(state); len(state) == 0 {
+			continue
+		}
+		found := false
+		for name, value := range pb.JobState_value {
+			if strings.EqualFold(name, state) {
+				states = append(states, pb.JobState(value))
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf(T("Invalid job state: %q"), state)
+		}
+	}
+	return states, nil
+}
+
+// Parse a duration before now or a time in local time zone to unix seconds, "d" can be used for days in duration
+func parseJobTime(s string, now time.Time) (int64, error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+	duration, days, has_days := s, 0, false
+	if i := strings.Index(duration, "d"); i > 0 {
+		if d, err := strconv.Atoi(duration[:i]); err == nil && d >= 0 {
+			days, duration, has_days = d, duration[i+1:], true
+		}
+	}
+	if d, err := time.ParseDuration(duration); (err == nil && d >= 0) || (has_days && len(duration) == 0) {
+		return now.Add(-d - time.Duration(days)*24*time.Hour).Unix(), nil
+	}
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04", "2006-01-02 15:04:05", TimeLayout, time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t.Unix(), nil
+		}
+	}
+	return 0, fmt.Errorf(T("%q is neither a duration nor a time"), s)
+}
+
 func cancelJobs(job_ids map[int32]bool) {
 	// Setup connection
 	conn, cancel := ConnectHeadnode()
@@ -171,9 +284,15 @@ func cancelJobs(job_ids map[int32]bool) {
 	if err != nil {
 		Fatallnf("Can not cancel jobs: %v", err)
 	}
-	result := reply.GetResult()
+	result, delayed := reply.GetResult(), reply.GetDelayedJobIds()
+	if len(delayed) > 0 {
+		Printlnf("Jobs to cancel in %v seconds: %v", reply.GetDelaySecond(), delayed)
+		Printlnf("Run \"clus undo %v\" in time to keep them running.", reply.GetUndoId())
+	}
 	if len(result) == 0 {
-		Printlnf("No job is cancelled.")
+		if len(delayed) == 0 {
+			Printlnf("No job is cancelled.")
+		}
 	} else {
 		states := map[pb.JobState][]int32{}
 		for id, state := range result {
@@ -186,33 +305,132 @@ func cancelJobs(job_ids map[int32]bool) {
 	}
 }
 
-func getJobs(ids map[int32]bool) []*pb.Job {
+func getJobs(request *pb.GetJobsRequest) []*pb.Job {
+	jobs := []*pb.Job{}
+	streamJobs(request, func(job *pb.Job) {
+		jobs = append(jobs, job)
+	})
+	return jobs
+}
+
+// Receive the jobs one by one, or all at once from the headnode not supporting streaming
+func streamJobs(request *pb.GetJobsRequest, f func(*pb.Job)) {
 	// Setup connection
 	conn, cancel := ConnectHeadnode()
 	defer cancel()
 	defer conn.Close()
 	c := pb.NewHeadnodeClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), jobsStreamTimeout)
 	defer cancel()
 
 	// Get jobs in the cluster
-	reply, err := c.GetJobs(ctx, &pb.GetJobsRequest{JobIds: ids}, grpc.UseCompressor("gzip"))
+	stream, err := c.StreamJobs(ctx, request, grpc.UseCompressor("gzip"))
+	if err == nil {
+		var job *pb.Job
+		if job, err = stream.Recv(); err == nil || err == io.EOF {
+			for ; err == nil; job, err = stream.Recv() {
+				f(job)
+			}
+			if err == io.EOF {
+				return
+			}
+		}
+	}
+	if status.Code(err) != codes.Unimplemented {
+		Fatallnf("Can not get jobs: %v", status.Convert(err).Message())
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	reply, err := c.GetJobs(ctx, request, grpc.UseCompressor("gzip"))
 	if err != nil {
-		Fatallnf("Can not get jobs: %v", err)
+		Fatallnf("Can not get jobs: %v", status.Convert(err).Message())
 	}
-	jobs := reply.GetJobs()
-	return jobs
+	for _, job := range reply.GetJobs() {
+		f(job)
+	}
+}
+
+var jobTableColumns = []string{"Id", "Name", "State", "Progress", "Create Time", "End Time", "Command"}
+
+// Print the jobs in CSV with the values of jobTableColumns
+func jobPrintCsv(jobs []*pb.Job, columns map[string]bool) {
+	rows := make([][]string, len(jobs))
+	for i, job := range jobs {
+		end_time := ""
+		if job.EndTime != 0 {
+			end_time = FormatTime(time.Unix(job.EndTime, 0))
+		}
+		rows[i] = []string{fmt.Sprint(job.Id), job.Name, job.State.String(), job.Progress, FormatTime(time.Unix(job.CreateTime, 0)), end_time, job.Command}
+	}
+	PrintCsv(jobTableColumns, rows, columns)
+}
+
+// The request to run the job again on the nodes, with the options of the job
+func getRerunRequest(job *pb.Job, name string, nodes []string, node_commands map[string]string) *pb.StartClusJobRequest {
+	return &pb.StartClusJobRequest{
+		Command:          job.Command,
+		Arguments:        job.Arguments,
+		Exclude:          job.Exclude,
+		Nodes:            nodes,
+		Shell:            job.Shell,
+		ScriptName:       job.ScriptName,
+		OsCommands:       job.OsCommands,
+		Name:             name,
+		Checkpoint:       job.Checkpoint,
+		ShipCheckpoint:   job.ShipCheckpoint,
+		BandwidthLimitKb: job.BandwidthLimitKb,
+		WorkingDir:       job.WorkingDir,
+		RunAs:            job.RunAs,
+		NodeCommands:     node_commands,
+		JsonOutput:       job.JsonOutput,
+		DispatchOrder:    job.DispatchOrder,
+		Cluster:          job.Cluster,
+		CaptureEnv:       job.CaptureEnv,
+		Requirements:     job.Requirements,
+		Limits:           job.Limits,
+		EnvMode:          job.EnvMode,
+		OutputWindow:     job.OutputWindow,
+		Rolling:          job.Rolling,
+		FailFast:         job.FailFast,
+		Executor:         job.Executor,
+	}
+}
+
+// Get the per-node commands of the nodes in job, a node rescheduled to runs the command of the node rescheduled from
+func getNodeCommands(job *pb.Job, nodes []string) map[string]string {
+	if len(job.NodeCommands) == 0 {
+		return nil
+	}
+	rescheduled_from := map[string]string{}
+	for _, r := range job.Reschedules {
+		rescheduled_from[r.ToNode] = r.FromNode
+	}
+	node_commands := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		from := node
+		for i := 0; i <= len(job.Reschedules); i++ {
+			if command, ok := job.NodeCommands[from]; ok {
+				node_commands[node] = command
+				break
+			}
+			from = rescheduled_from[from]
+		}
+	}
+	return node_commands
 }
 
-func jobPrintTable(jobs []*pb.Job) {
+// The name, create time and end time are hidden in a narrow console unless the columns are specified
+// The names and commands of jobs are truncated to fit the console unless it is wide
+func jobPrintTable(jobs []*pb.Job, columns map[string]bool, wide bool) {
 	if len(jobs) > 0 {
 		gap := 3
 		min_console_width_for_name := 80
 		min_console_width_for_create_time := 120
 		min_console_width_for_end_time := 150
 		max_id_length, max_name_length, max_state_length, max_progress_length, max_create_time_length, max_end_time_length, max_command_length := getJobTableMaxLength(jobs)
-		header_id, header_name, header_state, header_progress, header_create_time, header_end_time, header_command := "Id", "Name", "State", "Progress", "Create Time", "End Time", "Command"
-		if max_name_length > 20 {
+		header_id, header_name, header_state, header_progress, header_create_time, header_end_time, header_command :=
+			jobTableColumns[0], jobTableColumns[1], jobTableColumns[2], jobTableColumns[3], jobTableColumns[4], jobTableColumns[5], jobTableColumns[6]
+		if max_name_length > 20 && !wide {
 			max_name_length = 20
 		}
 		min_command_length := len(header_command) + gap
@@ -240,22 +458,35 @@ func jobPrintTable(jobs []*pb.Job) {
 		if ConsoleWidth > 0 {
 			line_length = ConsoleWidth - 1
 		}
+		if columns != nil || wide {
+			min_console_width_for_name, min_console_width_for_create_time, min_console_width_for_end_time = 0, 0, 0
+		}
+		show_command := IsColumnSelected(columns, header_command)
+		if !IsColumnSelected(columns, header_id) {
+			header_id, max_id_length, id_width = "", 0, 0
+		}
+		if !IsColumnSelected(columns, header_state) {
+			header_state, max_state_length, state_width = "", 0, 0
+		}
+		if !IsColumnSelected(columns, header_progress) {
+			header_progress, max_progress_length, progress_width = "", 0, 0
+		}
 		remain_length := line_length - id_width - state_width - progress_width
-		if line_length > min_console_width_for_create_time {
+		if line_length > min_console_width_for_create_time && IsColumnSelected(columns, header_create_time) {
 			remain_length -= create_time_width
 		} else {
 			header_create_time = ""
 			max_create_time_length = 0
 			create_time_width = 0
 		}
-		if line_length > min_console_width_for_end_time {
+		if line_length > min_console_width_for_end_time && IsColumnSelected(columns, header_end_time) {
 			remain_length -= end_time_width
 		} else {
 			header_end_time = ""
 			max_end_time_length = 0
 			end_time_width = 0
 		}
-		if line_length > min_console_width_for_name {
+		if line_length > min_console_width_for_name && IsColumnSelected(columns, header_name) {
 			remain_length -= name_width
 		} else {
 			header_name = ""
@@ -265,12 +496,15 @@ func jobPrintTable(jobs []*pb.Job) {
 		if remain_length < min_command_length {
 			remain_length = min_command_length
 		}
-		if max_command_length > remain_length {
+		if max_command_length > remain_length && !wide {
 			max_command_length = remain_length
 		}
 		if max_command_length < len(header_command) {
 			max_command_length = len(header_command)
 		}
+		if !show_command {
+			header_command, max_command_length = "", 0
+		}
 		command_width := max_command_length
 		Printlnf("%-*s%-*s%-*s%-*s%-*s%-*s%-*s",
 			id_width, header_id,
@@ -298,24 +532,37 @@ func jobPrintTable(jobs []*pb.Job) {
 				name = name[:max_name_length-len(padding)]
 				name += padding
 			}
-			command := job.Command
+			command := ""
+			if show_command {
+				command = job.Command
+			}
 			if len(command) > max_command_length {
 				command = command[:max_command_length-len(padding)]
 				command += padding
 			}
 			create_time := ""
 			if create_time_width > 0 {
-				create_time = fmt.Sprintf("%v", time.Unix(job.CreateTime, 0))
+				create_time = FormatTime(time.Unix(job.CreateTime, 0))
 			}
 			end_time := ""
 			if end_time_width > 0 && job.EndTime != 0 {
-				end_time = fmt.Sprintf("%v", time.Unix(job.EndTime, 0))
+				end_time = FormatTime(time.Unix(job.EndTime, 0))
+			}
+			id, state, progress := "", "", ""
+			if id_width > 0 {
+				id = fmt.Sprintf("%v", job.Id)
 			}
-			Printlnf("%-*v%-*v%-*v%-*v%-*v%-*v%-*v",
-				id_width, job.Id,
+			if state_width > 0 {
+				state = ColorizeState(fmt.Sprintf("%-*v", state_width, job.State), job.State.String())
+			}
+			if progress_width > 0 {
+				progress = job.Progress
+			}
+			Printlnf("%-*v%-*v%v%-*v%-*v%-*v%-*v",
+				id_width, id,
 				name_width, name,
-				state_width, job.State,
-				progress_width, job.Progress,
+				state,
+				progress_width, progress,
 				create_time_width, create_time,
 				end_time_width, end_time,
 				command_width, command)
@@ -325,65 +572,314 @@ func jobPrintTable(jobs []*pb.Job) {
 	Printlnf("Job count: %v", len(jobs))
 }
 
-func jobPrintList(jobs []*pb.Job) {
-	item_id, item_name, item_state, item_progress, item_createTime, item_endTime, item_nodePattern, item_nodeGroups, item_specifiedNodes, item_nodes, item_failedNodes, item_cancelFailedNodes, item_sweep, item_arguments, item_command :=
-		"Id", "Name", "State", "Progress", "Create Time", "End Time", "Node Pattern", "Node Grouops", "Specified Nodes", "Nodes", "Failed Nodes", "Cancel Failed Nodes", "Sweep Parameter", "Arguments", "Command"
+func jobPrintListItem(job *pb.Job, show_env bool) {
+	item_id, item_name, item_state, item_progress, item_createTime, item_endTime, item_nodePattern, item_nodeGroups, item_specifiedNodes, item_nodes, item_failedNodes, item_cancelFailedNodes, item_reschedules, item_checkpoint, item_bandwidth, item_workingDir, item_runAs, item_dispatchOrder, item_sweep, item_arguments, item_command, item_results, item_environment, item_variables, item_requirements, item_skippedNodes, item_limits, item_envMode, item_outputWindow, item_rolling, item_failFast, item_after, item_stdin, item_checksum, item_correlationId, item_failureAnalysis, item_summary, item_labels, item_shell, item_script, item_orphanedNodes, item_lostNodes, item_truncatedNodes, item_timing, item_exclude, item_sweepValues, item_where, item_rerunOf, item_executor :=
+		"Id", "Name", "State", "Progress", "Create Time", "End Time", "Node Pattern", "Node Grouops", "Specified Nodes", "Nodes", "Failed Nodes", "Cancel Failed Nodes", "Rescheduled Nodes", "Checkpoint", "Bandwidth Limit", "Working Dir", "Run As", "Dispatch Order", "Sweep Parameter", "Arguments", "Command", "Results", "Environment", "Variables", "Requirements", "Skipped Nodes", "Limits", "Env Mode", "Output Window", "Rolling", "Fail Fast", "After", "Stdin", "Output Checksum", "Correlation Id", "Failure Analysis", "Summary", "Node Labels", "Shell", "Script", "Orphaned Nodes", "Lost Nodes", "Truncated Nodes", "Node Timing", "Exclude Pattern", "Sweep Values", "Node Probes", "Rerun Of", "Executor"
 	maxLength := MaxInt(len(item_id), len(item_name), len(item_state), len(item_progress), len(item_createTime), len(item_endTime), len(item_sweep), len(item_nodePattern),
-		len(item_nodeGroups), len(item_specifiedNodes), len(item_nodes), len(item_failedNodes), len(item_cancelFailedNodes), len(item_arguments), len(item_command))
+		len(item_nodeGroups), len(item_specifiedNodes), len(item_nodes), len(item_failedNodes), len(item_cancelFailedNodes), len(item_reschedules), len(item_checkpoint), len(item_bandwidth), len(item_workingDir), len(item_runAs), len(item_dispatchOrder), len(item_arguments), len(item_command), len(item_results), len(item_environment), len(item_variables), len(item_requirements), len(item_skippedNodes), len(item_limits), len(item_envMode), len(item_outputWindow), len(item_rolling), len(item_failFast), len(item_after), len(item_stdin), len(item_checksum), len(item_correlationId), len(item_failureAnalysis), len(item_summary), len(item_labels), len(item_shell), len(item_script), len(item_orphanedNodes), len(item_lostNodes), len(item_truncatedNodes), len(item_timing), len(item_exclude), len(item_sweepValues), len(item_where), len(item_rerunOf), len(item_executor))
 	print := func(name string, value interface{}) {
 		Printlnf("%-*v : %v", maxLength, name, value)
 	}
-	for _, job := range jobs {
-		print(item_id, job.Id)
-		print(item_name, job.Name)
-		print(item_state, job.State)
-		if progress := job.Progress; len(progress) > 0 {
-			print(item_progress, progress)
-		}
-		print(item_createTime, time.Unix(job.CreateTime, 0))
-		if endTime := job.EndTime; endTime > 0 {
-			print(item_endTime, time.Unix(endTime, 0))
-		}
-		if nodePattern := job.NodePattern; len(nodePattern) > 0 {
-			print(item_nodePattern, nodePattern)
-		}
-		if nodeGroups := job.NodeGroups; len(nodeGroups) > 0 {
-			print(item_nodeGroups, strings.Join(nodeGroups, ", "))
-		}
-		if specifiedNodes := job.SpecifiedNodes; len(specifiedNodes) > 0 {
-			print(item_specifiedNodes, strings.Join(specifiedNodes, ", "))
-		}
-		print(item_nodes, strings.Join(job.Nodes, ", "))
-		if failedNodes := job.FailedNodes; len(failedNodes) > 0 {
-			nodes := make([]string, 0, len(failedNodes))
-			for node := range failedNodes {
-				nodes = append(nodes, node)
+	print(item_id, job.Id)
+	print(item_name, job.Name)
+	print(item_state, job.State)
+	if progress := job.Progress; len(progress) > 0 {
+		print(item_progress, progress)
+	}
+	print(item_createTime, formatJobTime(job.CreateTime, job.TimeZone))
+	if endTime := job.EndTime; endTime > 0 {
+		print(item_endTime, formatJobTime(endTime, job.TimeZone))
+	}
+	if summary := job.Summary; summary != nil {
+		print(item_summary, formatJobSummary(summary))
+	}
+	if correlationId := job.CorrelationId; len(correlationId) > 0 {
+		print(item_correlationId, correlationId)
+	}
+	if rerunOf := job.RerunOf; rerunOf > 0 {
+		print(item_rerunOf, rerunOf)
+	}
+	if nodePattern := job.NodePattern; len(nodePattern) > 0 {
+		print(item_nodePattern, nodePattern)
+	}
+	if exclude := job.Exclude; len(exclude) > 0 {
+		print(item_exclude, exclude)
+	}
+	if nodeGroups := job.NodeGroups; len(nodeGroups) > 0 {
+		print(item_nodeGroups, strings.Join(nodeGroups, ", "))
+	}
+	if labels := job.Labels; len(labels) > 0 {
+		print(item_labels, strings.Join(labels, ", "))
+	}
+	if where := job.Where; len(where) > 0 {
+		print(item_where, strings.Join(where, ", "))
+	}
+	if specifiedNodes := job.SpecifiedNodes; len(specifiedNodes) > 0 {
+		print(item_specifiedNodes, strings.Join(specifiedNodes, ", "))
+	}
+	print(item_nodes, strings.Join(job.Nodes, ", "))
+	if requirements := formatRequirements(job.Requirements); len(requirements) > 0 {
+		print(item_requirements, requirements)
+	}
+	if skippedNodes := job.SkippedNodes; len(skippedNodes) > 0 {
+		print(item_skippedNodes, formatSkippedNodes(skippedNodes))
+	}
+	if failedNodes := job.FailedNodes; len(failedNodes) > 0 {
+		nodes := make([]string, 0, len(failedNodes))
+		for node := range failedNodes {
+			nodes = append(nodes, node)
+		}
+		sort.Strings(nodes)
+		timed_out := make(map[string]bool, len(job.TimedOutNodes))
+		for _, node := range job.TimedOutNodes {
+			timed_out[node] = true
+		}
+		for i, node := range nodes {
+			exitcode := failedNodes[node]
+			if exitcode != 0 {
+				nodes[i] += fmt.Sprintf(" -> %v", exitcode)
 			}
-			sort.Strings(nodes)
-			for i := range nodes {
-				if exitcode := failedNodes[nodes[i]]; exitcode != 0 {
-					nodes[i] += fmt.Sprintf(" -> %v", exitcode)
-				}
+			if timed_out[node] {
+				nodes[i] += " (timed out)"
 			}
-			print(item_failedNodes, strings.Join(nodes, ", "))
 		}
-		if cancelFailedNodes := job.CancelFailedNodes; len(cancelFailedNodes) > 0 {
-			print(item_cancelFailedNodes, strings.Join(cancelFailedNodes, ", "))
+		print(item_failedNodes, strings.Join(nodes, ", "))
+	}
+	for _, cluster := range job.FailureClusters {
+		print(item_failureAnalysis, formatFailureCluster(cluster, len(job.FailedNodes)))
+	}
+	if cancelFailedNodes := job.CancelFailedNodes; len(cancelFailedNodes) > 0 {
+		print(item_cancelFailedNodes, strings.Join(cancelFailedNodes, ", "))
+	}
+	if orphanedNodes := job.OrphanedNodes; len(orphanedNodes) > 0 {
+		print(item_orphanedNodes, strings.Join(orphanedNodes, ", "))
+	}
+	if lostNodes := job.LostNodes; len(lostNodes) > 0 {
+		print(item_lostNodes, strings.Join(lostNodes, ", "))
+	}
+	if truncatedNodes := job.TruncatedNodes; len(truncatedNodes) > 0 {
+		nodes := make([]string, 0, len(truncatedNodes))
+		for node, size := range truncatedNodes {
+			nodes = append(nodes, fmt.Sprintf("%v -> %v bytes", node, size))
 		}
-		if sweep := job.Sweep; len(sweep) > 0 {
-			print(item_sweep, sweep)
+		sort.Strings(nodes)
+		print(item_truncatedNodes, strings.Join(nodes, ", "))
+	}
+	if reschedules := job.Reschedules; len(reschedules) > 0 {
+		nodes := make([]string, 0, len(reschedules))
+		for _, r := range reschedules {
+			nodes = append(nodes, fmt.Sprintf("%v -> %v", r.FromNode, r.ToNode))
 		}
-		if args := job.Arguments; len(args) > 0 {
-			print(item_arguments, fmt.Sprintf("%q", args))
+		print(item_reschedules, strings.Join(nodes, ", "))
+	}
+	if checkpoint := job.Checkpoint; len(checkpoint) > 0 {
+		if job.ShipCheckpoint {
+			checkpoint += " (shipped on reschedule)"
 		}
+		print(item_checkpoint, checkpoint)
+	}
+	if bandwidth := job.BandwidthLimitKb; bandwidth > 0 {
+		print(item_bandwidth, fmt.Sprintf("%v KB/s", bandwidth))
+	}
+	if workingDir := job.WorkingDir; len(workingDir) > 0 {
+		print(item_workingDir, workingDir)
+	}
+	if runAs := job.RunAs; len(runAs) > 0 {
+		print(item_runAs, runAs)
+	}
+	if limits := job.Limits; limits.GetCpuPercent() > 0 || limits.GetMemoryMb() > 0 || limits.GetTimeoutSecond() > 0 || limits.GetRetentionSecond() > 0 || limits.GetNice() > 0 || len(limits.GetIoPriority()) > 0 {
+		var items []string
+		if limits.CpuPercent > 0 {
+			items = append(items, fmt.Sprintf("CPU %v%%", limits.CpuPercent))
+		}
+		if limits.MemoryMb > 0 {
+			items = append(items, fmt.Sprintf("memory %v MB", limits.MemoryMb))
+		}
+		if limits.TimeoutSecond > 0 {
+			items = append(items, fmt.Sprintf("timeout %v seconds", limits.TimeoutSecond))
+		}
+		if limits.RetentionSecond > 0 {
+			items = append(items, fmt.Sprintf("retention %v seconds", limits.RetentionSecond))
+		}
+		if limits.Nice > 0 {
+			items = append(items, fmt.Sprintf("nice %v", limits.Nice))
+		}
+		if len(limits.IoPriority) > 0 {
+			items = append(items, fmt.Sprintf("I/O priority %v", limits.IoPriority))
+		}
+		print(item_limits, strings.Join(items, ", "))
+	}
+	if envMode := job.EnvMode; len(envMode) > 0 {
+		print(item_envMode, envMode)
+	}
+	if window := job.OutputWindow; window != nil {
+		print(item_outputWindow, fmt.Sprintf("last %v KB every %v seconds", window.SizeKb, window.IntervalSecond))
+	}
+	if rolling := job.Rolling; rolling != nil {
+		print(item_rolling, formatRollingPolicy(rolling))
+	}
+	if failFast := job.FailFast; failFast != nil {
+		print(item_failFast, formatFailFast(failFast))
+	}
+	if after := job.After; after != nil {
+		print(item_after, formatJobDependency(after))
+	}
+	if job.ForwardStdin {
+		print(item_stdin, "Forwarded from client")
+	}
+	if order := job.DispatchOrder; len(order) > 0 {
+		print(item_dispatchOrder, order)
+	}
+	if sweep := job.Sweep; len(sweep) > 0 {
+		print(item_sweep, sweep)
+	}
+	if shell := job.Shell; len(shell) > 0 {
+		print(item_shell, shell)
+	}
+	if executor := job.Executor; executor != nil {
+		print(item_executor, formatJobExecutor(executor))
+	}
+	if script := job.ScriptName; len(script) > 0 {
+		print(item_script, script)
+	}
+	if args := job.Arguments; len(args) > 0 {
+		print(item_arguments, fmt.Sprintf("%q", args))
+	}
+	if len(job.NodeCommands) > 0 {
+		nodes := make([]string, 0, len(job.NodeCommands))
+		for node := range job.NodeCommands {
+			nodes = append(nodes, node)
+		}
+		sort.Strings(nodes)
+		for _, node := range nodes {
+			print(item_command, fmt.Sprintf("[%v]: %v", node, job.NodeCommands[node]))
+		}
+	} else if len(job.OsCommands) > 0 {
+		os_list := make([]string, 0, len(job.OsCommands))
+		for node_os := range job.OsCommands {
+			os_list = append(os_list, node_os)
+		}
+		sort.Strings(os_list)
+		for _, node_os := range os_list {
+			print(item_command, fmt.Sprintf("[%v]: %v", node_os, job.OsCommands[node_os]))
+		}
+		if len(job.Command) > 0 {
+			print(item_command, fmt.Sprintf("[*]: %v", job.Command))
+		}
+	} else {
 		print(item_command, job.Command)
-		Printlnf(GetPaddingLine(""))
 	}
-	Printlnf("Job count: %v", len(jobs))
+	if len(job.SweepValues) > 0 {
+		nodes := make([]string, 0, len(job.SweepValues))
+		for node := range job.SweepValues {
+			nodes = append(nodes, node)
+		}
+		sort.Strings(nodes)
+		for _, node := range nodes {
+			print(item_sweepValues, fmt.Sprintf("[%v]: %v", node, job.SweepValues[node]))
+		}
+	}
+	if len(job.Results) > 0 || len(job.ResultErrors) > 0 {
+		nodes := make([]string, 0, len(job.Results)+len(job.ResultErrors))
+		for node := range job.Results {
+			nodes = append(nodes, node)
+		}
+		for node := range job.ResultErrors {
+			nodes = append(nodes, node)
+		}
+		sort.Strings(nodes)
+		for _, node := range nodes {
+			if result, ok := job.Results[node]; ok {
+				print(item_results, fmt.Sprintf("[%v]: %v", node, result))
+			} else {
+				print(item_results, fmt.Sprintf("[%v]: %v", node, Colorize(job.ResultErrors[node], colorRed)))
+			}
+		}
+	}
+	now := time.Now()
+	for _, task := range job.Tasks {
+		print(item_timing, fmt.Sprintf("[%v]: %v", task.Node, formatTaskTiming(task, now)))
+	}
+	for _, task := range job.Tasks {
+		if len(task.ChecksumError) > 0 {
+			print(item_checksum, fmt.Sprintf("[%v]: %v", task.Node, Colorize(task.ChecksumError, colorRed)))
+		}
+	}
+	for _, task := range job.Tasks {
+		env := task.Environment
+		if env == nil {
+			continue
+		}
+		interpreter := env.Interpreter
+		if len(env.InterpreterVersion) > 0 {
+			interpreter += fmt.Sprintf(" (%v)", env.InterpreterVersion)
+		}
+		print(item_environment, fmt.Sprintf("[%v]: user %v, dir %v, interpreter %v, os %v", task.Node, env.User, env.WorkingDir, interpreter, env.Os))
+		if show_env {
+			names := make([]string, 0, len(env.Variables))
+			for name := range env.Variables {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				print(item_variables, fmt.Sprintf("[%v]: %v=%v", task.Node, name, env.Variables[name]))
+			}
+		}
+	}
+	Printlnf(GetPaddingLine(""))
+}
+
+// The time of dispatching and running the task on a node, the unfinished steps are counted until now
+func formatTaskTiming(task *pb.TaskSpan, now time.Time) string {
+	since := func(from, to int64) time.Duration {
+		if to == 0 {
+			to = now.UnixNano()
+		}
+		return time.Duration(to - from).Round(time.Millisecond)
+	}
+	timing := fmt.Sprintf("dispatched at %v", FormatTime(time.Unix(0, task.DispatchTime)))
+	if task.StartTime == 0 {
+		if task.EndTime == 0 {
+			return timing + fmt.Sprintf(", starting for %v", since(task.DispatchTime, 0))
+		}
+		return timing + fmt.Sprintf(", failed to start in %v", since(task.DispatchTime, task.EndTime))
+	}
+	timing += fmt.Sprintf(", started in %v", since(task.DispatchTime, task.StartTime))
+	if task.EndTime == 0 {
+		return timing + fmt.Sprintf(", running for %v", since(task.StartTime, 0))
+	}
+	timing += fmt.Sprintf(", ended in %v", since(task.StartTime, task.EndTime))
+	if task.Lost {
+		return timing + " as lost"
+	} else if task.TimedOut {
+		return timing + " by timeout"
+	}
+	return timing + fmt.Sprintf(" with exit code %v", task.ExitCode)
+}
+
+// Format the time of job in the zone to display, followed by the time on headnode if the job is created in another zone
+func formatJobTime(t int64, zone string) string {
+	display := DisplayTime(time.Unix(t, 0))
+	formatted := display.Format(TimeLayout)
+	if len(zone) == 0 || display.Format(jobTimeZoneLayout) == zone {
+		return formatted
+	}
+	z, err := time.Parse(jobTimeZoneLayout, zone)
+	if err != nil {
+		return formatted
+	}
+	return fmt.Sprintf("%v (%v on headnode)", formatted, display.In(z.Location()).Format(TimeLayout))
+}
+
+func formatJobSummary(s *pb.JobSummary) string {
+	summary := fmt.Sprintf("%v of %v nodes succeeded, %v failed, %v unreachable, exit code %v, wall time %v", s.SucceededNodes, s.Nodes, s.FailedNodes, s.UnreachableNodes, s.ExitCode, time.Duration(s.WallTime))
+	if len(s.SlowestNode) > 0 {
+		summary += fmt.Sprintf(", slowest node %v in %v", s.SlowestNode, time.Duration(s.SlowestDuration))
+	}
+	return summary
 }
 
 func getJobTableMaxLength(jobs []*pb.Job) (id, name, state, progress, create_time, end_time, command int) {
-	create_time = len(fmt.Sprintf("%v", time.Unix(0, 0)))
 	for _, job := range jobs {
 		if length := len(strconv.Itoa(int(job.Id))); length > id {
 			id = length
@@ -397,8 +893,17 @@ func getJobTableMaxLength(jobs []*pb.Job) (id, name, state, progress, create_tim
 		if length := len(job.Progress); length > progress {
 			progress = length
 		}
+		// The length of zone abbreviation may differ across DST transitions
+		if length := len(FormatTime(time.Unix(job.CreateTime, 0))); length > create_time {
+			create_time = length
+		}
 		if job.EndTime != 0 {
-			end_time = create_time
+			if length := len(FormatTime(time.Unix(job.EndTime, 0))); length > end_time {
+				end_time = length
+			}
+		}
+		if len(job.NodeCommands) > 0 {
+			job.Command = fmt.Sprintf("<commands of %v nodes>", len(job.NodeCommands))
 		}
 		job.Command = strings.NewReplacer("\r", `\r`, "\n", `\n`).Replace(job.Command)
 		if length := len(job.Command); length > command {
@@ -407,3 +912,108 @@ func getJobTableMaxLength(jobs []*pb.Job) (id, name, state, progress, create_tim
 	}
 	return
 }
+
+func formatRollingPolicy(r *pb.RollingPolicy) string {
+	var policy []string
+	if r.GetBatchSize() > 0 {
+		policy = append(policy, fmt.Sprintf("batches of %v nodes", r.BatchSize))
+	}
+	if r.GetStopOnFailures() > 0 {
+		policy = append(policy, fmt.Sprintf("stop on %v failed nodes", r.StopOnFailures))
+	}
+	return strings.Join(policy, ", ")
+}
+
+func formatFailFast(f *pb.FailFast) string {
+	if p := f.GetMaxFailurePercent(); p > 0 {
+		return fmt.Sprintf("abort if more than %v%% of nodes fail", p)
+	}
+	return fmt.Sprintf("abort if more than %v nodes fail", f.GetMaxFailures())
+}
+
+func formatJobDependency(after *pb.JobDependency) string {
+	if after.GetSuccessOnly() {
+		return fmt.Sprintf("job %v finishes successfully", after.GetJobId())
+	}
+	return fmt.Sprintf("job %v ends", after.GetJobId())
+}
+
+// Format the jobs created on the peer headnodes in federation mode, e.g. "east job 12, west job 7"
+func formatPeerJobs(peer_jobs map[string]int32) string {
+	items := make([]string, 0, len(peer_jobs))
+	for peer, job := range peer_jobs {
+		items = append(items, fmt.Sprintf("%v job %v", peer, job))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ", ")
+}
+
+func formatRequirements(r *pb.ResourceRequirements) string {
+	var requirements []string
+	if r.GetMinFreeMemoryMb() > 0 {
+		requirements = append(requirements, fmt.Sprintf("available memory >= %v MB", r.MinFreeMemoryMb))
+	}
+	if r.GetMinFreeDiskMb() > 0 {
+		requirements = append(requirements, fmt.Sprintf("free disk >= %v MB", r.MinFreeDiskMb))
+	}
+	if r.GetMaxCpuLoad() > 0 {
+		requirements = append(requirements, fmt.Sprintf("CPU load <= %v", r.MaxCpuLoad))
+	}
+	if r.GetMaxRunningJobs() > 0 {
+		requirements = append(requirements, fmt.Sprintf("running jobs <= %v", r.MaxRunningJobs))
+	}
+	if r.GetMinIdleGpus() > 0 {
+		requirements = append(requirements, fmt.Sprintf("idle GPUs >= %v", r.MinIdleGpus))
+	}
+	return strings.Join(requirements, ", ")
+}
+
+// Format the failed nodes sharing the similar stderr or the same node attribute, the nodes are truncated if too many
+func formatFailureCluster(c *pb.FailureCluster, failed int) string {
+	nodes := c.GetNodes()
+	list := strings.Join(nodes, ", ")
+	if len(nodes) > maxFailureClusterNodes {
+		list = fmt.Sprintf("%v and %v more", strings.Join(nodes[:maxFailureClusterNodes], ", "), len(nodes)-maxFailureClusterNodes)
+	}
+	if c.GetKind() == "stderr" {
+		return fmt.Sprintf("%v of %v failed nodes with stderr like %q: %v", len(nodes), failed, c.GetValue(), list)
+	}
+	return fmt.Sprintf("%v of %v failed nodes with %v %q (%v nodes in job): %v", len(nodes), failed, c.GetKind(), c.GetValue(), c.GetTotalNodes(), list)
+}
+
+// Print the failure clusters analyzed by headnode after the job fails, nothing is printed if the job can not be got
+func printJobFailureAnalysis(id int32) {
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	c := pb.NewHeadnodeClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	reply, err := c.GetJobs(ctx, &pb.GetJobsRequest{JobIds: map[int32]bool{id: false}}, grpc.UseCompressor("gzip"))
+	if err != nil {
+		return
+	}
+	for _, job := range reply.GetJobs() {
+		if len(job.FailureClusters) == 0 {
+			continue
+		}
+		Printlnf("Failure analysis:")
+		for _, cluster := range job.FailureClusters {
+			Printlnf("  %v", formatFailureCluster(cluster, len(job.FailedNodes)))
+		}
+	}
+}
+
+func formatJobExecutor(executor *pb.JobExecutor) string {
+	items := []string{executor.Name}
+	if len(executor.Name) == 0 {
+		items[0] = "exec"
+	}
+	if len(executor.Image) > 0 {
+		items = append(items, "image: "+executor.Image)
+	}
+	if len(executor.Mounts) > 0 {
+		items = append(items, "mounts: "+strings.Join(executor.Mounts, ", "))
+	}
+	return strings.Join(items, ", ")
+}
@@ -1,7 +1,17 @@
 package main
 
 import (
+	"bytes"
+	pb "clusrun/protobuf"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 )
 
 func Test_parseJobIds(t *testing.T) {
@@ -124,3 +134,267 @@ func Test_parseJobIds(t *testing.T) {
 		}
 	}
 }
+
+func Test_parseJobTime(t *testing.T) {
+	now := time.Date(2020, 5, 10, 12, 0, 0, 0, time.Local)
+	cases := []struct {
+		s        string
+		expected time.Time
+		valid    bool
+	}{
+		{"", time.Unix(0, 0), true},
+		{"2h", now.Add(-2 * time.Hour), true},
+		{"90m", now.Add(-90 * time.Minute), true},
+		{"3d", now.Add(-72 * time.Hour), true},
+		{"1d12h", now.Add(-36 * time.Hour), true},
+		{"0d", now, true},
+		{"2020-05-01", time.Date(2020, 5, 1, 0, 0, 0, 0, time.Local), true},
+		{"2020-05-01 08:30", time.Date(2020, 5, 1, 8, 30, 0, 0, time.Local), true},
+		{"2020-05-01 08:30:15", time.Date(2020, 5, 1, 8, 30, 15, 0, time.Local), true},
+		{"-2h", time.Time{}, false},
+		{"d", time.Time{}, false},
+		{"2 hours", time.Time{}, false},
+		{"2020-13-01", time.Time{}, false},
+	}
+
+	for _, c := range cases {
+		result, err := parseJobTime(c.s, now)
+		if (err == nil) != c.valid || (c.valid && result != c.expected.Unix()) {
+			t.Errorf("\ns=%q\nexpected=%v, valid=%v\n  actual=%v, error=%v", c.s, c.expected, c.valid, time.Unix(result, 0), err)
+		}
+	}
+}
+
+func Test_parseJobStates(t *testing.T) {
+	cases := []struct {
+		s        string
+		expected []pb.JobState
+		valid    bool
+	}{
+		{"", []pb.JobState{}, true},
+		{"failed", []pb.JobState{pb.JobState_Failed}, true},
+		{"Failed, canceled", []pb.JobState{pb.JobState_Failed, pb.JobState_Canceled}, true},
+		{"RUNNING,", []pb.JobState{pb.JobState_Running}, true},
+		{"done", nil, false},
+	}
+
+	for _, c := range cases {
+		if result, err := parseJobStates(c.s); (err == nil) != c.valid || !reflect.DeepEqual(result, c.expected) {
+			t.Errorf("\ns=%q\nexpected=%v, valid=%v\n  actual=%v, error=%v", c.s, c.expected, c.valid, result, err)
+		}
+	}
+}
+
+func Test_formatRequirements(t *testing.T) {
+	cases := []struct {
+		requirements *pb.ResourceRequirements
+		expected     string
+	}{
+		{nil, ""},
+		{&pb.ResourceRequirements{}, ""},
+		{&pb.ResourceRequirements{MaxCpuLoad: 0.5}, "CPU load <= 0.5"},
+		{&pb.ResourceRequirements{MinFreeMemoryMb: 512, MinFreeDiskMb: 1024, MaxRunningJobs: 3}, "available memory >= 512 MB, free disk >= 1024 MB, running jobs <= 3"},
+		{&pb.ResourceRequirements{MinIdleGpus: 2}, "idle GPUs >= 2"},
+	}
+
+	for _, c := range cases {
+		if actual := formatRequirements(c.requirements); actual != c.expected {
+			t.Errorf("\nrequirements=%v\nexpected: %q\n  actual: %q", c.requirements, c.expected, actual)
+		}
+	}
+}
+
+func Test_formatRollingPolicy(t *testing.T) {
+	cases := []struct {
+		rolling  *pb.RollingPolicy
+		expected string
+	}{
+		{nil, ""},
+		{&pb.RollingPolicy{BatchSize: 1}, "batches of 1 nodes"},
+		{&pb.RollingPolicy{StopOnFailures: 2}, "stop on 2 failed nodes"},
+		{&pb.RollingPolicy{BatchSize: 10, StopOnFailures: 3}, "batches of 10 nodes, stop on 3 failed nodes"},
+	}
+
+	for _, c := range cases {
+		if actual := formatRollingPolicy(c.rolling); actual != c.expected {
+			t.Errorf("\nrolling=%v\nexpected: %q\n  actual: %q", c.rolling, c.expected, actual)
+		}
+	}
+}
+
+func Test_replaceJobBookmarks(t *testing.T) {
+	ids := map[string]int32{"october-patching": 87, "upload": 3}
+	cases := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{}, []string{}},
+		{[]string{"1", "2-5"}, []string{"1", "2-5"}},
+		{[]string{"@october-patching"}, []string{"87"}},
+		{[]string{"@upload", "10", "@october-patching"}, []string{"3", "10", "87"}},
+		{[]string{"upload"}, []string{"upload"}},
+		{[]string{"@missing"}, []string{"@missing"}},
+	}
+
+	for _, c := range cases {
+		if actual := replaceJobBookmarks(c.args, ids); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("\nargs=%q\nexpected=%q\n  actual=%q", c.args, c.expected, actual)
+		}
+	}
+}
+
+func Test_formatFailureCluster(t *testing.T) {
+	many := []string{}
+	for i := 1; i <= 12; i++ {
+		many = append(many, fmt.Sprintf("n%v", i))
+	}
+	cases := []struct {
+		cluster  *pb.FailureCluster
+		failed   int
+		expected string
+	}{
+		{&pb.FailureCluster{Kind: "stderr", Value: "disk # is full", Nodes: []string{"n1", "n2"}, TotalNodes: 3}, 3, `2 of 3 failed nodes with stderr like "disk # is full": n1, n2`},
+		{&pb.FailureCluster{Kind: "os version", Value: "5.4.0", Nodes: []string{"n1", "n2"}, TotalNodes: 4}, 2, `2 of 2 failed nodes with os version "5.4.0" (4 nodes in job): n1, n2`},
+		{&pb.FailureCluster{Kind: "zone", Value: "rack1", Nodes: many, TotalNodes: 20}, 12, `12 of 12 failed nodes with zone "rack1" (20 nodes in job): n1, n2, n3, n4, n5, n6, n7, n8, n9, n10 and 2 more`},
+	}
+	for _, c := range cases {
+		if actual := formatFailureCluster(c.cluster, c.failed); actual != c.expected {
+			t.Errorf("\nexpected=%q\n  actual=%q", c.expected, actual)
+		}
+	}
+}
+
+// The jobs in JSON can be parsed back with all fields, including the times and the exit codes on nodes
+func Test_FormatJson(t *testing.T) {
+	jobs := []*pb.Job{
+		{Id: 1, Name: "a", Command: "echo 1", State: pb.JobState_Failed, CreateTime: 1588291200, EndTime: 1588291260, Nodes: []string{"n1", "n2"}, FailedNodes: map[string]int32{"n2": 3}},
+		{Id: 2, State: pb.JobState_Running, CreateTime: 1588291300, Requirements: &pb.ResourceRequirements{MinFreeMemoryMb: 1024}},
+	}
+	messages := []proto.Message{jobs[0], jobs[1]}
+	s, err := FormatJson(messages)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	items := []json.RawMessage{}
+	if err := json.Unmarshal([]byte(s), &items); err != nil {
+		t.Fatalf("invalid JSON %q: %v", s, err)
+	}
+	if len(items) != len(jobs) {
+		t.Fatalf("expected %v jobs, actual %v", len(jobs), len(items))
+	}
+	for i, item := range items {
+		job := &pb.Job{}
+		if err := jsonpb.UnmarshalString(string(item), job); err != nil {
+			t.Errorf("failed to parse %q: %v", item, err)
+		} else if !proto.Equal(job, jobs[i]) {
+			t.Errorf("\nexpected=%v\n  actual=%v", jobs[i], job)
+		}
+	}
+	if s, err := FormatJson(nil); s != "[]" || err != nil {
+		t.Errorf("unexpected %q, error %v", s, err)
+	}
+}
+
+func Test_formatTaskTiming(t *testing.T) {
+	now := time.Unix(1000, 0)
+	dispatch := now.Add(-10 * time.Second).UnixNano()
+	at := "dispatched at " + FormatTime(time.Unix(0, dispatch))
+	ms := int64(time.Millisecond)
+	cases := []struct {
+		task     *pb.TaskSpan
+		expected string
+	}{
+		{&pb.TaskSpan{DispatchTime: dispatch}, at + ", starting for 10s"},
+		{&pb.TaskSpan{DispatchTime: dispatch, EndTime: dispatch + 30*ms, ExitCode: -1}, at + ", failed to start in 30ms"},
+		{&pb.TaskSpan{DispatchTime: dispatch, StartTime: dispatch + 20*ms}, at + ", started in 20ms, running for 9.98s"},
+		{&pb.TaskSpan{DispatchTime: dispatch, StartTime: dispatch + 20*ms, EndTime: dispatch + 1020*ms, ExitCode: 2}, at + ", started in 20ms, ended in 1s with exit code 2"},
+		{&pb.TaskSpan{DispatchTime: dispatch, StartTime: dispatch + 20*ms, EndTime: dispatch + 5020*ms, ExitCode: 124, TimedOut: true}, at + ", started in 20ms, ended in 5s by timeout"},
+		{&pb.TaskSpan{DispatchTime: dispatch, StartTime: dispatch + 20*ms, EndTime: dispatch + 5020*ms, Lost: true}, at + ", started in 20ms, ended in 5s as lost"},
+	}
+	for _, c := range cases {
+		if actual := formatTaskTiming(c.task, now); actual != c.expected {
+			t.Errorf("\nexpected=%q\n  actual=%q", c.expected, actual)
+		}
+	}
+}
+
+func Test_exportedJobs(t *testing.T) {
+	jobs := []*pb.Job{
+		{Id: 1, Command: "hostname", State: pb.JobState_Finished, Nodes: []string{"node1", "node2"}},
+		{Id: 3, Command: "echo\n\"done\"", State: pb.JobState_Failed, FailedNodes: map[string]int32{"node2": 1}},
+	}
+	var b bytes.Buffer
+	for _, job := range jobs {
+		if err := writeExportedJob(&b, job); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	if lines := strings.Count(b.String(), "\n"); lines != len(jobs) {
+		t.Errorf("expected %v lines, got %v", len(jobs), lines)
+	}
+	actual, err := readExportedJobs(strings.NewReader(b.String() + "\n"))
+	if err != nil || len(actual) != len(jobs) {
+		t.Fatalf("expected %v jobs, got %v, error %v", len(jobs), len(actual), err)
+	}
+	for i, job := range actual {
+		if !proto.Equal(job, jobs[i]) {
+			t.Errorf("\nexpected=%v\n  actual=%v", jobs[i], job)
+		}
+	}
+	if _, err := readExportedJobs(strings.NewReader("{\"id\": 1}\nnot json\n")); err == nil {
+		t.Errorf("expected error parsing invalid job")
+	}
+}
+
+func Test_formatJobStats(t *testing.T) {
+	ms := int64(time.Millisecond)
+	job := &pb.Job{Id: 3, State: pb.JobState_Finished, Summary: &pb.JobSummary{WallTime: int64(2 * time.Second)}, Tasks: []*pb.TaskSpan{
+		{Node: "node1", StartTime: 10 * ms, EndTime: 1010 * ms, StdoutBytes: 2048},
+		{Node: "node2", StartTime: 30 * ms, EndTime: 530 * ms, StdoutBytes: 10, StderrBytes: 4096},
+		{Node: "node3"},
+	}}
+	expected := []string{
+		"Job               : 3",
+		"State             : Finished",
+		"Wall Time         : 2s",
+		"Stdout            : 2.0 KB",
+		"Stderr            : 4.0 KB",
+		"Dispatch Overhead : 20ms on average",
+		"",
+		"Node   Stdout  Stderr  Dispatch  Duration",
+		"-----  ------  ------  --------  --------",
+		"node2  10 B    4.0 KB  30ms      500ms",
+		"node1  2.0 KB  0 B     10ms      1s",
+		"node3  0 B     0 B     -         -",
+	}
+	if lines := formatJobStats(job, time.Now()); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("\nexpected:\n%v\n  actual:\n%v", strings.Join(expected, "\n"), strings.Join(lines, "\n"))
+	}
+}
+
+func Test_formatJobTime(t *testing.T) {
+	displayUtc := true
+	utc = &displayUtc
+	defer func() { utc = nil }()
+	created := time.Date(2021, 3, 1, 23, 30, 0, 0, time.UTC).Unix()
+	cases := []struct {
+		zone     string
+		expected string
+	}{
+		{"", "2021-03-01 23:30:00 +0000 UTC"},
+		{"UTC +0000", "2021-03-01 23:30:00 +0000 UTC"},
+		{"CST +0800", "2021-03-01 23:30:00 +0000 UTC (2021-03-02 07:30:00 +0800 CST on headnode)"},
+		{"invalid", "2021-03-01 23:30:00 +0000 UTC"},
+	}
+	for _, c := range cases {
+		if actual := formatJobTime(created, c.zone); actual != c.expected {
+			t.Errorf("\nzone=%q\nexpected=%q\n  actual=%q", c.zone, c.expected, actual)
+		}
+	}
+}
+
+func Test_formatPeerJobs(t *testing.T) {
+	if actual := formatPeerJobs(map[string]int32{"west": 7, "east": 12}); actual != "east job 12, west job 7" {
+		t.Errorf("Expected jobs of peers sorted, got %q", actual)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Handle "clus job stats <job id>"
+func JobStats(args []string) {
+	if len(args) != 2 {
+		Fatallnf("Usage: clus job stats <job id>")
+	}
+	arg := resolveJobBookmarks(args[1:])[0]
+	id, err := strconv.Atoi(arg)
+	if err != nil || id <= 0 {
+		Fatallnf("Invalid job id: %q", arg)
+	}
+	jobs := getJobs(&pb.GetJobsRequest{JobIds: map[int32]bool{int32(id): true}})
+	if len(jobs) == 0 {
+		Fatallnf("Job %v doesn't exist.", id)
+	}
+	for _, line := range formatJobStats(jobs[0], time.Now()) {
+		Printlnf("%v", line)
+	}
+}
+
+// Format the bytes of output, the wall time and the dispatch overhead of the job, and of the task on each node in
+// order of the bytes of output, so that the commands flooding the output are spotted
+func formatJobStats(job *pb.Job, now time.Time) []string {
+	var stdout, stderr, dispatch, started int64
+	for _, task := range job.Tasks {
+		stdout += task.StdoutBytes
+		stderr += task.StderrBytes
+		if task.StartTime > 0 {
+			dispatch += task.StartTime - task.DispatchTime
+			started++
+		}
+	}
+	wall_time := time.Duration(job.Summary.GetWallTime())
+	if wall_time == 0 {
+		end := now
+		if job.EndTime > 0 {
+			end = time.Unix(job.EndTime, 0)
+		}
+		wall_time = end.Sub(time.Unix(job.CreateTime, 0))
+	}
+	average_dispatch := "-"
+	if started > 0 {
+		average_dispatch = formatStatsDuration(dispatch / started)
+	}
+	lines := []string{
+		fmt.Sprintf("Job               : %v", job.Id),
+		fmt.Sprintf("State             : %v", job.State),
+		fmt.Sprintf("Wall Time         : %v", wall_time.Round(time.Millisecond)),
+		fmt.Sprintf("Stdout            : %v", formatBytes(stdout)),
+		fmt.Sprintf("Stderr            : %v", formatBytes(stderr)),
+		fmt.Sprintf("Dispatch Overhead : %v on average", average_dispatch),
+		"",
+	}
+
+	tasks := make([]*pb.TaskSpan, len(job.Tasks))
+	copy(tasks, job.Tasks)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i].StdoutBytes+tasks[i].StderrBytes, tasks[j].StdoutBytes+tasks[j].StderrBytes
+		if a != b {
+			return a > b
+		}
+		return tasks[i].Node < tasks[j].Node
+	})
+	rows := [][]string{{"Node", "Stdout", "Stderr", "Dispatch", "Duration"}}
+	for _, task := range tasks {
+		dispatch, duration := "-", "-"
+		if task.StartTime > 0 {
+			dispatch = formatStatsDuration(task.StartTime - task.DispatchTime)
+			if task.EndTime > 0 {
+				duration = formatStatsDuration(task.EndTime - task.StartTime)
+			} else {
+				duration = "running"
+			}
+		}
+		rows = append(rows, []string{task.Node, formatBytes(task.StdoutBytes), formatBytes(task.StderrBytes), dispatch, duration})
+	}
+	return append(lines, formatTable(rows)...)
+}
+
+func formatStatsDuration(nanoseconds int64) string {
+	return time.Duration(nanoseconds).Round(time.Millisecond).String()
+}
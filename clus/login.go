@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	oidcDeviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+	oidcDeviceScope     = "openid profile email"
+	oidcTimeout         = 10 * time.Second
+)
+
+func Login(args []string) {
+	fs := flag.NewFlagSet("clus login options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	_ = fs.Parse(args)
+	if len(fs.Args()) > 0 {
+		displayLoginUsage(fs)
+		return
+	}
+	session, err := login(getLoginCredentials())
+	if err != nil {
+		Fatallnf("%v", err)
+	}
+	headnode := ParseHeadnode(*Headnode)
+	if err := cacheSession(headnode, session); err != nil {
+		Fatallnf("Failed to cache session: %v", err)
+	}
+	if len(session.Cluster) > 0 {
+		Printlnf("Logged in %v as %v with role %v in cluster %v, the session is refreshed until %v.", headnode, session.User, session.Role, session.Cluster, FormatTime(time.Unix(session.MaxExpire, 0)))
+	} else {
+		Printlnf("Logged in %v as %v with role %v, the session is refreshed until %v.", headnode, session.User, session.Role, FormatTime(time.Unix(session.MaxExpire, 0)))
+	}
+}
+
+func Logout(args []string) {
+	fs := flag.NewFlagSet("clus logout options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	_ = fs.Parse(args)
+	headnode := ParseHeadnode(*Headnode)
+	if removed, err := removeCachedSession(headnode); err != nil {
+		Fatallnf("Failed to remove cached session: %v", err)
+	} else if !removed {
+		Printlnf("Not logged in %v.", headnode)
+	} else {
+		Printlnf("Logged out %v.", headnode)
+	}
+}
+
+func displayLoginUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+	clus login [options]
+
+	Log in the headnode and cache the session, which is used and refreshed by other commands without specifying credentials.
+	The credential to log in is the user and password if -user is specified, or the token if -token is specified,
+	otherwise an OIDC ID token got by device flow, or the user and password prompted, as the headnode supports.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+// Get the credentials given, otherwise get them as the headnode supports
+func getLoginCredentials() credentials.PerRPCCredentials {
+	if len(*user) > 0 {
+		return basicCredentials(getBasicCredential(*user))
+	} else if len(*token) > 0 {
+		return tokenCredentials(*token)
+	}
+	conn, cancel := connectHeadnodeWithCredentials(nil)
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), ConnectTimeout)
+	defer cancel()
+	options, err := pb.NewHeadnodeClient(conn).GetLoginOptions(ctx, &pb.Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support login.")
+	} else if err != nil {
+		Fatallnf("Failed to get login options: %v", status.Convert(err).Message())
+	}
+	if len(options.GetOidcIssuer()) > 0 {
+		id_token, err := getOidcIdTokenByDeviceFlow(options.GetOidcIssuer(), options.GetOidcClientId())
+		if err != nil {
+			Fatallnf("Failed to log in by OIDC: %v", err)
+		}
+		return tokenCredentials(id_token)
+	}
+	if options.GetPassword() {
+		fmt.Fprint(os.Stderr, "User: ")
+		name, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if name = strings.TrimSpace(name); len(name) == 0 {
+			Fatallnf("Failed to read user: %v", err)
+		}
+		return basicCredentials(getBasicCredential(name))
+	}
+	Fatallnf("The headnode doesn't support login by password or OIDC, please specify -token.")
+	return nil
+}
+
+func login(creds credentials.PerRPCCredentials) (*cachedSession, error) {
+	conn, cancel := connectHeadnodeWithCredentials(creds)
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), ConnectTimeout)
+	defer cancel()
+	reply, err := pb.NewHeadnodeClient(conn).Login(ctx, &pb.Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil, errors.New(T("The headnode doesn't support login."))
+	} else if err != nil {
+		return nil, fmt.Errorf(T("Failed to log in: %v"), status.Convert(err).Message())
+	}
+	return newCachedSession(reply), nil
+}
+
+// Get the ID token by OAuth 2.0 device authorization grant (RFC 8628), the user is asked to open the verification URI in a browser
+func getOidcIdTokenByDeviceFlow(issuer, client_id string) (string, error) {
+	client := &http.Client{Timeout: oidcTimeout}
+	var discovery struct {
+		DeviceEndpoint string `json:"device_authorization_endpoint"`
+		TokenEndpoint  string `json:"token_endpoint"`
+	}
+	resp, err := client.Get(strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration")
+	if err != nil {
+		return "", err
+	}
+	err = json.NewDecoder(resp.Body).Decode(&discovery)
+	resp.Body.Close()
+	if err != nil {
+		return "", fmt.Errorf("Invalid discovery document: %v", err)
+	}
+	if len(discovery.DeviceEndpoint) == 0 || len(discovery.TokenEndpoint) == 0 {
+		return "", errors.New(T("The OIDC provider doesn't support device flow"))
+	}
+
+	var device struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationUri         string `json:"verification_uri"`
+		VerificationUriComplete string `json:"verification_uri_complete"`
+		ExpiresIn               int    `json:"expires_in"`
+		Interval                int    `json:"interval"`
+	}
+	if err := postOidcForm(client, discovery.DeviceEndpoint, url.Values{"client_id": {client_id}, "scope": {oidcDeviceScope}}, &device); err != nil {
+		return "", err
+	}
+	if len(device.VerificationUriComplete) > 0 {
+		Printlnf("To log in, open %v in a browser and confirm the code %v", device.VerificationUriComplete, device.UserCode)
+	} else {
+		Printlnf("To log in, open %v in a browser and enter the code %v", device.VerificationUri, device.UserCode)
+	}
+
+	interval := time.Duration(device.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(device.ExpiresIn) * time.Second)
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+		var token struct {
+			IdToken string `json:"id_token"`
+			Error   string `json:"error"`
+		}
+		form := url.Values{"grant_type": {oidcDeviceGrantType}, "device_code": {device.DeviceCode}, "client_id": {client_id}}
+		if err := postOidcForm(client, discovery.TokenEndpoint, form, &token); err != nil && len(token.Error) == 0 {
+			return "", err
+		}
+		switch token.Error {
+		case "":
+			if len(token.IdToken) == 0 {
+				return "", errors.New(T("No ID token is returned"))
+			}
+			return token.IdToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return "", fmt.Errorf("Device flow failed: %v", token.Error)
+		}
+	}
+	return "", errors.New(T("The code is expired"))
+}
+
+// Post the form and decode the JSON reply, which is also decoded for an error status
+func postOidcForm(client *http.Client, endpoint string, form url.Values, v interface{}) error {
+	resp, err := client.PostForm(endpoint, form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("Invalid reply from %v: %v", endpoint, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%v returns %v", endpoint, resp.Status)
+	}
+	return nil
+}
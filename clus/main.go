@@ -35,6 +35,42 @@ func main() {
 		Run(args)
 	case "job":
 		Job(args)
+	case "config":
+		Config(args)
+	case "upload":
+		Upload(args)
+	case "collect":
+		Collect(args)
+	case "output":
+		Output(args)
+	case "purge":
+		Purge(args)
+	case "query":
+		Query(args)
+	case "search":
+		Search(args)
+	case "timeline":
+		Timeline(args)
+	case "undo":
+		Undo(args)
+	case "template":
+		Template(args)
+	case "schedule":
+		Schedule(args)
+	case "shell":
+		Shell(args)
+	case "watch":
+		Watch(args)
+	case "login":
+		Login(args)
+	case "logout":
+		Logout(args)
+	case "audit":
+		Audit(args)
+	case "top":
+		Top(args)
+	case "rerun":
+		Rerun(args)
 	default:
 		displayUsage()
 	}
@@ -46,12 +82,33 @@ Usage:
 	clus <command> [arguments]
 
 The commands are:
-	node            - list nodes, add nodes to groups or remove nodes from groups in the cluster
+	node            - list nodes, add nodes to groups, remove nodes from groups, drain nodes or remove lost nodes in the cluster
 	run             - run a command or script on nodes in the cluster
-	job             - list, cancel or rerun jobs in the cluster
+	job             - list, cancel, rerun, bookmark, export or import jobs in the cluster
+	config          - export or import configs of the headnode
+	upload          - upload a file or directory to nodes in the cluster
+	collect         - collect files from nodes of a finished job to headnode
+	output          - get the saved output of a job on nodes
+	purge           - purge finished jobs and their output by retention configs
+	query           - query the JSON results of a job on nodes in a table
+	search          - search text in the stored output of jobs
+	timeline        - export the timeline of a job on nodes in Chrome trace format
+	undo            - list or undo the delayed job cancellation and recent node removal
+	template        - list, save or delete the job templates on the headnode
+	schedule        - list, save, enable, disable or delete the scheduled jobs on the headnode
+	shell           - open an interactive shell on a node through the headnode
+	watch           - print the state changes of nodes and jobs in the cluster once they happen
+	login           - log in the headnode and cache the session for other commands
+	logout          - remove the cached session of the headnode
+	audit           - query the audit log of the mutating operations on the headnode
+	top             - keep refreshing the node states, running jobs, busiest nodes and recent failures in the cluster
+	rerun           - rerun a job as a new job on the nodes it ran on or only on its failed nodes
 
 Usage of node:
 	clus node [options]
+	clus node [options] drain|undrain [nodes]
+	clus node remove <pattern>
+	clus node [options] revalidate [pattern]
 	clus node -h
 
 Usage of run:
@@ -60,7 +117,87 @@ Usage of run:
 
 Usage of job:
 	clus job [options] [jobs]
+	clus job bookmark [<job id> <name>]
+	clus job unbookmark <names>
+	clus job export [options]
+	clus job import [options] <file>
+	clus job stats <job id>
 	clus job -h
 
+Usage of config:
+	clus config <command> [options]
+	clus config -h
+
+Usage of upload:
+	clus upload [options] -dest <dir> <file or directory>
+	clus upload -h
+
+Usage of collect:
+	clus collect [options] <job id> <path>...
+	clus collect -h
+
+Usage of output:
+	clus output [options] <job id>
+	clus output -h
+
+Usage of purge:
+	clus purge [options]
+	clus purge -h
+
+Usage of query:
+	clus query [options] <job id> [expression]
+	clus query -h
+
+Usage of search:
+	clus search [options] <text>
+	clus search -h
+
+Usage of timeline:
+	clus timeline [options] <job id>
+	clus timeline -h
+
+Usage of undo:
+	clus undo [options] [operation id]
+	clus undo -h
+
+Usage of template:
+	clus template [names]
+	clus template [options] save <name> <command>
+	clus template delete <names>
+	clus template -h
+
+Usage of schedule:
+	clus schedule [names]
+	clus schedule [options] save <name> <cron> [command]
+	clus schedule enable|disable|delete <names>
+	clus schedule -h
+
+Usage of shell:
+	clus shell [options] <node> [command]
+	clus shell -h
+
+Usage of watch:
+	clus watch [options]
+	clus watch -h
+
+Usage of login:
+	clus login [options]
+	clus login -h
+
+Usage of logout:
+	clus logout [options]
+
+Usage of audit:
+	clus audit [options]
+	clus audit -h
+
+Usage of top:
+	clus top [options]
+	clus top -h
+
+Usage of rerun:
+	clus rerun [options] <job id>
+	clus rerun -h
+
 `)
 }
@@ -3,42 +3,116 @@ package main
 import (
 	pb "clusrun/protobuf"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func Node(args []string) {
 	fs := flag.NewFlagSet("clus node options", flag.ExitOnError)
 	SetGlobalParameters(fs)
 	filterBy_pattern := fs.String("pattern", "", "filter nodes matching the specified regular expression pattern")
-	filterBy_state := fs.String("state", "", "filter nodes in the specified state (ready, error or lost)")
+	filterBy_state := fs.String("state", "", "filter nodes in the specified state (ready, error, lost or draining)")
 	filterBy_groups := fs.String("groups", "", "filter nodes in the specified node groups")
 	filterBy_groups_in_file := fs.String("groups-in-file", "", "filter nodes in the node groups specified by a file")
 	filterBy_groups_intersect := fs.Bool("intersect", false, "specify to filter nodes in intersection (union if not specified) of node groups")
-	groupBy := fs.String("group-by", "", "group the nodes by state or node group")         // name prefix, running jobs
-	orderBy := fs.String("order-by", "name", "sort the nodes by node name or node groups") // running jobs
-	format := fs.String("format", "table", "format the nodes in table, list or group")
+	filterBy_os := fs.String("os", "", "filter nodes running the specified OS (e.g. linux or windows)")
+	filterBy_arch := fs.String("arch", "", "filter nodes with the specified CPU architecture (e.g. amd64 or arm64)")
+	filterBy_cluster := fs.String("cluster", "", "filter nodes in the specified cluster configured on headnode")
+	filterBy_label := fs.String("label", "", `filter nodes with labels matching all the selectors separated by comma, in format "key=value", "key!=value", "key" (having the label) or "!key" (not having the label), e.g. "os=windows,gpu"`)
+	filterBy_where := fs.String("where", "", `filter nodes with probe values matching all the selectors separated by comma, in format "name<op>value" (op is =, !=, >, >=, < or <=, values are compared as numbers if both are), "name" (having the value) or "!name" (not having the value), e.g. "gpu=true,disk_free_gb>=100"`)
+	groupBy := fs.String("group-by", "", "group the nodes by state, node group, zone, os, arch or clusnode")                                     // name prefix, running jobs
+	orderBy := fs.String("order-by", "name", "sort the nodes by node name, node groups, zone, os, arch, version or clusnode separated by comma") // running jobs
+	format := fs.String("format", "table", "format the nodes in table, wide (table without truncation), list, group, json (with all fields) or csv")
+	columns := fs.String("columns", "", "specify the columns (node, state, os, arch, version, clusnode, success, groups) separated by comma to display in table, wide or csv format")
 	addGroups := fs.String("add-groups", "", "add nodes to the specified node groups")
 	removeGroups := fs.String("remove-groups", "", "remove nodes from the specified node groups")
+	setLabels := fs.String("set-labels", "", `set the labels in format "key=value" separated by comma on nodes, which override the labels with the same keys reported by nodes`)
+	removeLabels := fs.String("remove-labels", "", "remove the labels with the specified keys separated by comma set on nodes by -set-labels")
+	resetKeys := fs.Bool("reset-keys", false, "reset the keys of nodes, so that they are enrolled with new keys in next validation (e.g. after being reinstalled)")
 	// prefix := fs.Int("prefix", 0, "merge the nodes with same name prefix of specified length (only in table format)")
-	// monitor := fs.Bool("monitor", false, "keep refreshing the node information")
+	summary := fs.Bool("summary", false, "print the count of nodes in each state and node group, and the count of jobs")
+	monitor := fs.Bool("monitor", false, "keep refreshing the node information and print the changed nodes")
+	concurrency := fs.Int("concurrency", 0, "specify the max number of nodes to revalidate or test at the same time, default is decided by headnode")
+	noJob := fs.Bool("no-job", false, "specify to skip running a no-op job on nodes when testing them")
+	windows := fs.String("windows", "1d,7d,30d", "specify the windows till now separated by comma to compute the uptime of nodes in by \"clus node history\", in days (d), hours (h) or minutes (m), e.g. 12h,7d")
+	verbose := fs.Bool("verbose", false, "print the nodes in list format with the latest resource usage (CPU load, memory, disk and running jobs) reported in heartbeats")
 	// purge := fs.Bool("purge", false, "purge the lost nodes in headnode")
 	// reverse := fs.Bool("reverse", false, "reverse the order when displaying")
 	_ = fs.Parse(args)
+	drain, undrain := false, false
+	var drainNodeNames []string
 	if len(fs.Args()) > 0 {
 		// TODO: query nodes info
-		Fatallnf("Invalid parameter: %v", strings.Join(fs.Args(), " "))
+		switch strings.ToLower(fs.Arg(0)) {
+		case "drain":
+			drain = true
+		case "undrain":
+			undrain = true
+		case "remove":
+			if len(fs.Args()) != 2 {
+				Fatallnf("Please specify one pattern of the lost nodes to remove.")
+			}
+			removeLostNodes(fs.Arg(1))
+			return
+		case "revalidate":
+			if len(fs.Args()) > 2 {
+				Fatallnf("Please specify at most one pattern of the nodes to revalidate.")
+			}
+			revalidateNodes(fs.Arg(1), *concurrency)
+			return
+		case "test":
+			if len(fs.Args()) > 2 {
+				Fatallnf("Please specify at most one pattern of the nodes to test.")
+			}
+			testNodes(fs.Arg(1), *concurrency, *noJob)
+			return
+		case "history":
+			if len(fs.Args()) > 2 {
+				Fatallnf("Please specify at most one pattern of the nodes to show history.")
+			}
+			pattern := *filterBy_pattern
+			if len(fs.Args()) == 2 {
+				pattern = fs.Arg(1)
+			}
+			printNodeAvailability(pattern, *filterBy_cluster, *windows, strings.ToLower(*format) == "json")
+			return
+		default:
+			Fatallnf("Invalid parameter: %v", strings.Join(fs.Args(), " "))
+		}
+		drainNodeNames = fs.Args()[1:]
+	}
+
+	if *summary {
+		printClusterSummary()
+		return
 	}
 
 	// Get nodes
 	groups := ParseNodesOrGroups(*filterBy_groups, *filterBy_groups_in_file)
-	nodes := getNodes(*filterBy_pattern, *filterBy_state, groups, *filterBy_groups_intersect)
+	labels, where := ParseNodesOrGroups(*filterBy_label, ""), ParseNodesOrGroups(*filterBy_where, "")
+	if *monitor {
+		monitorNodes(*filterBy_pattern, *filterBy_state, groups, *filterBy_groups_intersect, *filterBy_os, *filterBy_arch, labels, where, *filterBy_cluster, *groupBy, *orderBy, ParseColumns(*columns, nodeTableColumns))
+		return
+	}
+	var groupMsgs []string
+	if (drain || undrain) && len(drainNodeNames) > 0 {
+		// Drain or undrain the specified nodes regardless of filters
+		groupMsgs = append(groupMsgs, drainNodes(drainNodeNames, undrain))
+		drain, undrain = false, false
+	}
+	nodes := getNodes(*filterBy_pattern, *filterBy_state, groups, *filterBy_groups_intersect, *filterBy_os, *filterBy_arch, labels, where, *filterBy_cluster)
 
 	// Add or remove node groups
-	var groupMsgs []string
 	if len(nodes) > 0 {
 		setGroups := false
 		if *addGroups != "" {
@@ -49,8 +123,23 @@ func Node(args []string) {
 			groupMsgs = append(groupMsgs, setNodeGroups(*removeGroups, nodes, true))
 			setGroups = true
 		}
+		if *setLabels != "" || *removeLabels != "" {
+			groupMsgs = append(groupMsgs, setNodeLabels(*setLabels, *removeLabels, nodes))
+			setGroups = true
+		}
 		if setGroups {
-			nodes = getNodes(*filterBy_pattern, *filterBy_state, groups, *filterBy_groups_intersect)
+			nodes = getNodes(*filterBy_pattern, *filterBy_state, groups, *filterBy_groups_intersect, *filterBy_os, *filterBy_arch, labels, where, *filterBy_cluster)
+		}
+		if *resetKeys {
+			groupMsgs = append(groupMsgs, resetNodeKeys(nodes))
+		}
+		if drain || undrain {
+			names := make([]string, len(nodes))
+			for i, node := range nodes {
+				names[i] = node.Name
+			}
+			groupMsgs = append(groupMsgs, drainNodes(names, undrain))
+			nodes = getNodes(*filterBy_pattern, *filterBy_state, groups, *filterBy_groups_intersect, *filterBy_os, *filterBy_arch, labels, where, *filterBy_cluster)
 		}
 	}
 	printGroupMsgs := func() {
@@ -62,23 +151,136 @@ func Node(args []string) {
 	}
 
 	// Print nodes
+	if *verbose && strings.ToLower(*format) == "table" {
+		*format = "list"
+	}
 	switch strings.ToLower(*format) {
-	case "table":
-		nodePrintTable(nodes, *groupBy, *orderBy)
+	case "table", "wide":
+		nodePrintTable(nodes, *groupBy, *orderBy, ParseColumns(*columns, nodeTableColumns), strings.ToLower(*format) == "wide")
+		printClockSkewedNodes(nodes)
 		printGroupMsgs()
 	case "list":
-		nodePrintList(nodes, *groupBy, *orderBy)
+		nodePrintList(nodes, *groupBy, *orderBy, *verbose)
+		printClockSkewedNodes(nodes)
 		printGroupMsgs()
 	case "group":
 		nodePrintGroups(nodes, *groupBy)
 		printGroupMsgs()
+	case "json", "csv":
+		// The messages are printed to stderr to keep the output parsable
+		for _, msg := range groupMsgs {
+			fmt.Fprint(os.Stderr, T(msg)+LineEnding)
+		}
+		sortNodes(nodes, *orderBy)
+		if strings.ToLower(*format) == "json" {
+			messages := make([]proto.Message, len(nodes))
+			for i, node := range nodes {
+				messages[i] = node
+			}
+			PrintJson(messages)
+		} else {
+			nodePrintCsv(nodes, ParseColumns(*columns, nodeTableColumns))
+		}
 	default:
 		Fatallnf("Invalid format option: %v", *format)
 	}
 }
 
-func getNodes(pattern, state string, groups []string, intersect bool) (nodes []*pb.Node) {
-	// Validate node state
+func printClusterSummary() {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Get summary
+	reply, err := pb.NewHeadnodeClient(conn).GetClusterSummary(ctx, &pb.Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support reporting cluster summary.")
+	} else if err != nil {
+		Fatallnf("Could not get cluster summary: %v", err)
+	}
+	states := []string{}
+	for _, state := range []pb.NodeState{pb.NodeState_Ready, pb.NodeState_Error, pb.NodeState_Lost, pb.NodeState_Draining} {
+		states = append(states, fmt.Sprintf("%v %v", reply.GetNodeStates()[state.String()], state))
+	}
+	groups := make([]string, 0, len(reply.GetNodeGroups()))
+	for group := range reply.GetNodeGroups() {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for i, group := range groups {
+		groups[i] = fmt.Sprintf("%v (%v)", group, reply.GetNodeGroups()[group])
+	}
+	Printlnf("Headnode: %v", *Headnode)
+	Printlnf("Uptime: %v", time.Duration(reply.GetUptime())*time.Second)
+	Printlnf("Nodes: %v (%v)", reply.GetNodes(), strings.Join(states, ", "))
+	if len(groups) > 0 {
+		Printlnf("Node groups: %v", strings.Join(groups, ", "))
+	}
+	Printlnf("Jobs: %v running, %v queued", reply.GetRunningJobs(), reply.GetQueuedJobs())
+	Printlnf("Output received: %v stdout, %v stderr", formatBytes(reply.GetStdoutBytes()), formatBytes(reply.GetStderrBytes()))
+}
+
+func getNodes(pattern, state string, groups []string, intersect bool, node_os, node_arch string, labels, where []string, cluster string) (nodes []*pb.Node) {
+	return queryNodes(pattern, state, groups, intersect, node_os, node_arch, labels, where, cluster, "").GetNodes()
+}
+
+// Print all nodes in the first time, then only print the nodes changed since last query
+func monitorNodes(pattern, state string, groups []string, intersect bool, node_os, node_arch string, labels, where []string, cluster, group_by, order_by string, columns map[string]bool) {
+	request := &pb.GetNodesRequest{Pattern: pattern, Groups: groups, State: parseNodeState(state), GroupsIntersect: intersect, Os: node_os, Arch: node_arch, Labels: labels, Where: where, Cluster: cluster}
+	if watchNodes(request, group_by, order_by, columns) {
+		return
+	}
+	version := ""
+	for {
+		reply := queryNodes(pattern, state, groups, intersect, node_os, node_arch, labels, where, cluster, version)
+		printNodeChanges(reply, group_by, order_by, columns)
+		version = reply.GetVersion()
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// Print the nodes pushed by headnode once they change, return false if the headnode doesn't support it
+func watchNodes(request *pb.GetNodesRequest, group_by, order_by string, columns map[string]bool) bool {
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := pb.NewHeadnodeClient(conn).WatchNodes(ctx, request)
+	if err != nil {
+		Fatallnf("Could not watch nodes: %v", err)
+	}
+	for {
+		reply, err := stream.Recv()
+		if status.Code(err) == codes.Unimplemented {
+			return false
+		} else if err != nil {
+			Fatallnf("Could not watch nodes: %v", err)
+		}
+		printNodeChanges(reply, group_by, order_by, columns)
+	}
+}
+
+func printNodeChanges(reply *pb.GetNodesReply, group_by, order_by string, columns map[string]bool) {
+	nodes, removed := reply.GetNodes(), reply.GetRemovedNodes()
+	if !reply.GetDelta() {
+		Printlnf("[%v] %v nodes:", time.Now().Format(time.Stamp), len(nodes))
+		nodePrintTable(nodes, group_by, order_by, columns, false)
+	} else if len(nodes) > 0 || len(removed) > 0 {
+		Printlnf("[%v] %v nodes changed, %v nodes removed:", time.Now().Format(time.Stamp), len(nodes), len(removed))
+		if len(nodes) > 0 {
+			nodePrintTable(nodes, group_by, order_by, columns, false)
+		}
+		if len(removed) > 0 {
+			Printlnf("Removed nodes: %v", strings.Join(removed, ", "))
+		}
+	}
+}
+
+func parseNodeState(state string) pb.NodeState {
 	node_state := pb.NodeState_Unknown
 	switch strings.ToLower(state) {
 	case "":
@@ -89,9 +291,16 @@ func getNodes(pattern, state string, groups []string, intersect bool) (nodes []*
 		node_state = pb.NodeState_Error
 	case "lost":
 		node_state = pb.NodeState_Lost
+	case "draining":
+		node_state = pb.NodeState_Draining
 	default:
 		Fatallnf("Invalid node state option: %v", state)
 	}
+	return node_state
+}
+
+func queryNodes(pattern, state string, groups []string, intersect bool, node_os, node_arch string, labels, where []string, cluster, since_version string) *pb.GetNodesReply {
+	node_state := parseNodeState(state)
 
 	// Setup connection
 	conn, cancel := ConnectHeadnode()
@@ -102,37 +311,82 @@ func getNodes(pattern, state string, groups []string, intersect bool) (nodes []*
 	defer cancel()
 
 	// Get nodes reporting to the headnode
-	reply, err := c.GetNodes(ctx, &pb.GetNodesRequest{Pattern: pattern, Groups: groups, State: node_state, GroupsIntersect: intersect})
+	reply, err := c.GetNodes(ctx, &pb.GetNodesRequest{Pattern: pattern, Groups: groups, State: node_state, GroupsIntersect: intersect, SinceVersion: since_version, Os: node_os, Arch: node_arch, Labels: labels, Where: where, Cluster: cluster})
 	if err != nil {
 		Fatallnf("Could not get nodes: %v", err)
 	}
-	return reply.GetNodes()
+	return reply
 }
 
-func nodePrintTable(nodes []*pb.Node, group_by, order_by string) {
+// The groups of nodes are truncated to fit the console unless it is wide
+func nodePrintTable(nodes []*pb.Node, group_by, order_by string, columns map[string]bool, wide bool) {
 	groups := getSortedGroups(nodes, group_by)
 	if len(groups) > 0 {
 		gap := 3
-		max_name_length, max_state_length, max_groups_length := getNodeTableMaxLength(nodes)
-		header_node, header_state, header_groups := "Node", "State", "Groups"
+		header_groups := nodeTableColumns[7]
 		min_groups_length := len(header_groups) + gap
-		if max_name_length < len(header_node) {
-			max_name_length = len(header_node)
+
+		// The columns before groups are padded to the longest value, and skipped if no node has a value (e.g. reported by an old clusnode)
+		type column struct {
+			header string
+			value  func(node *pb.Node) string
+			length int
+			width  int
+		}
+		fixed := []*column{}
+		for _, c := range []*column{
+			{header: nodeTableColumns[0], value: func(node *pb.Node) string { return node.Name }},
+			{header: nodeTableColumns[1], value: func(node *pb.Node) string { return node.State.String() }},
+			{header: nodeTableColumns[2], value: func(node *pb.Node) string { return formatNodeOS(node.System.GetOs()) }},
+			{header: nodeTableColumns[3], value: func(node *pb.Node) string { return node.System.GetArch() }},
+			{header: nodeTableColumns[4], value: func(node *pb.Node) string { return node.System.GetVersion() }},
+			{header: nodeTableColumns[5], value: func(node *pb.Node) string { return formatNodeBuild(node.Build) }},
+			{header: nodeTableColumns[6], value: func(node *pb.Node) string { return formatNodeSuccessRate(node.Stats) }},
+		} {
+			if !IsColumnSelected(columns, c.header) {
+				continue
+			}
+			for _, node := range nodes {
+				if length := len(c.value(node)); length > c.length {
+					c.length = length
+				}
+			}
+			if c.length == 0 {
+				continue
+			}
+			if c.length < len(c.header) {
+				c.length = len(c.header)
+			}
+			fixed = append(fixed, c)
+		}
+		max_groups_length := 0
+		if IsColumnSelected(columns, header_groups) {
+			for _, node := range nodes {
+				if length := len(strings.Join(node.Groups, ", ")); length > max_groups_length {
+					max_groups_length = length
+				}
+			}
 		}
-		if max_state_length < len(header_state) {
-			max_state_length = len(header_state)
+		fixed_width := 0
+		for i, c := range fixed {
+			c.width = c.length
+			if i < len(fixed)-1 || max_groups_length > 0 {
+				c.width += gap
+			}
+			fixed_width += c.width
 		}
-		name_width, state_width := max_name_length+gap, max_state_length+gap
 		line_length := DefaultLineLength
 		if ConsoleWidth > 0 {
 			line_length = ConsoleWidth - 1
 		}
-		remain_length := line_length - name_width - state_width
+		remain_length := line_length - fixed_width
+		if wide {
+			remain_length = max_groups_length
+		}
 		if remain_length < min_groups_length {
 			remain_length = min_groups_length
 		}
 		if max_groups_length == 0 {
-			state_width = max_state_length
 			header_groups = ""
 		} else {
 			if max_groups_length > remain_length {
@@ -146,39 +400,61 @@ func nodePrintTable(nodes []*pb.Node, group_by, order_by string) {
 			sortNodes(group, order_by)
 		}
 		groups_width := max_groups_length
-		Printlnf("%-*s%-*s%-*s",
-			name_width, header_node,
-			state_width, header_state,
-			groups_width, header_groups)
-		Printlnf("%-*s%-*s%-*s",
-			name_width, strings.Repeat("-", max_name_length),
-			state_width, strings.Repeat("-", max_state_length),
-			groups_width, strings.Repeat("-", max_groups_length))
+		header, separator := "", ""
+		for _, c := range fixed {
+			header += fmt.Sprintf("%-*s", c.width, c.header)
+			separator += fmt.Sprintf("%-*s", c.width, strings.Repeat("-", c.length))
+		}
+		Printlnf("%v%-*s", header, groups_width, header_groups)
+		Printlnf("%v%-*s", separator, groups_width, strings.Repeat("-", max_groups_length))
 		for i := range groups {
 			for _, node := range groups[i] {
-				node_groups := strings.Join(node.Groups, ", ")
+				line := ""
+				for _, c := range fixed {
+					value := fmt.Sprintf("%-*s", c.width, c.value(node))
+					if c.header == nodeTableColumns[1] {
+						value = ColorizeState(value, node.State.String())
+					}
+					line += value
+				}
+				node_groups := ""
+				if max_groups_length > 0 {
+					node_groups = strings.Join(node.Groups, ", ")
+				}
 				if len(node_groups) > max_groups_length {
 					padding := "..."
 					node_groups = node_groups[:max_groups_length-len(padding)]
 					node_groups += padding
 				}
-				Printlnf("%-*s%-*s%-*s",
-					name_width, node.Name,
-					state_width, node.State,
-					groups_width, node_groups)
+				Printlnf("%v%-*s", line, groups_width, node_groups)
 			}
 			if i < len(groups)-1 {
 				Printlnf("")
 			}
 		}
-		Printlnf(strings.Repeat("-", name_width+state_width+groups_width))
+		Printlnf(strings.Repeat("-", fixed_width+groups_width))
 	}
 	Printlnf("Node count: %v", len(nodes))
 }
 
-func nodePrintList(nodes []*pb.Node, group_by, order_by string) {
-	item_node, item_state, item_groups := "Node", "State", "Groups"
-	maxLength := MaxInt(len(item_node), len(item_state), len(item_groups))
+var nodeTableColumns = []string{"Node", "State", "OS", "Arch", "Version", "Clusnode", "Success", "Groups"}
+
+// Print the nodes in CSV with the values of nodeTableColumns
+func nodePrintCsv(nodes []*pb.Node, columns map[string]bool) {
+	rows := make([][]string, len(nodes))
+	for i, node := range nodes {
+		rows[i] = []string{node.Name, node.State.String(), formatNodeOS(node.System.GetOs()), node.System.GetArch(), node.System.GetVersion(), formatNodeBuild(node.Build), formatNodeSuccessRate(node.Stats), strings.Join(node.Groups, ", ")}
+	}
+	PrintCsv(nodeTableColumns, rows, columns)
+}
+
+func nodePrintList(nodes []*pb.Node, group_by, order_by string, verbose bool) {
+	item_node, item_state, item_groups, item_zone, item_system, item_build, item_load, item_memory, item_disk, item_jobs, item_gpus, item_sampleTime := "Node", "State", "Groups", "Zone", "System", "Clusnode", "CPU Load", "Memory", "Disk", "Running Jobs", "GPUs", "Sample Time"
+	item_jobsRun, item_successRate, item_avgDuration, item_lastJob, item_labels, item_probes, item_clockSkew := "Jobs Run", "Success Rate", "Avg Duration", "Last Job", "Labels", "Probes", "Clock Skew"
+	maxLength := MaxInt(len(item_node), len(item_state), len(item_groups), len(item_zone), len(item_labels), len(item_probes), len(item_system), len(item_build), len(item_jobsRun), len(item_successRate), len(item_avgDuration), len(item_lastJob), len(item_clockSkew))
+	if verbose {
+		maxLength = MaxInt(maxLength, len(item_load), len(item_memory), len(item_disk), len(item_jobs), len(item_gpus), len(item_sampleTime))
+	}
 	print := func(item string, value interface{}) {
 		Printlnf("%-*v : %v", maxLength, item, value)
 	}
@@ -193,12 +469,181 @@ func nodePrintList(nodes []*pb.Node, group_by, order_by string) {
 			if len(g) > 0 {
 				print(item_groups, g)
 			}
+			if len(nodes[j].Zone) > 0 {
+				print(item_zone, nodes[j].Zone)
+			}
+			if len(nodes[j].Labels) > 0 {
+				print(item_labels, formatNodeLabels(nodes[j].Labels))
+			}
+			if len(nodes[j].Probes) > 0 {
+				print(item_probes, formatNodeLabels(nodes[j].Probes))
+			}
+			if system := formatNodeSystem(nodes[j].System); len(system) > 0 {
+				print(item_system, system)
+			}
+			if build := formatNodeBuild(nodes[j].Build); len(build) > 0 {
+				print(item_build, build)
+			}
+			if nodes[j].ClockSkewMs != 0 {
+				print(item_clockSkew, formatClockSkew(nodes[j]))
+			}
+			if stats := nodes[j].Stats; stats.GetJobs() > 0 {
+				success_rate, avg_duration, last_job := formatNodeStats(stats)
+				print(item_jobsRun, stats.Jobs)
+				print(item_successRate, success_rate)
+				print(item_avgDuration, avg_duration)
+				print(item_lastJob, last_job)
+			}
+			if r := nodes[j].Resources; verbose && r != nil {
+				load, memory, disk := formatNodeResources(r)
+				print(item_load, load)
+				print(item_memory, memory)
+				print(item_disk, disk)
+				print(item_jobs, r.RunningJobs)
+				for i, gpu := range formatNodeGpus(r) {
+					if i == 0 {
+						print(item_gpus, gpu)
+					} else {
+						print("", gpu)
+					}
+				}
+				print(item_sampleTime, FormatTime(time.Unix(0, r.SampleTime)))
+			}
 			Printlnf(GetPaddingLine(""))
 		}
 	}
 	Printlnf("Node count: %v", len(nodes))
 }
 
+// Format the resource usage of node, the negative values are unavailable on the node
+func formatNodeResources(r *pb.NodeResources) (load, memory, disk string) {
+	load, memory, disk = "N/A", "N/A", "N/A"
+	if r.CpuLoad >= 0 {
+		load = fmt.Sprintf("%.2f", r.CpuLoad)
+	}
+	if r.MemoryTotal > 0 && r.MemoryAvailable >= 0 {
+		memory = fmt.Sprintf("%v available of %v", formatBytes(r.MemoryAvailable), formatBytes(r.MemoryTotal))
+	}
+	if r.DiskTotal > 0 && r.DiskFree >= 0 {
+		disk = fmt.Sprintf("%v free of %v", formatBytes(r.DiskFree), formatBytes(r.DiskTotal))
+	}
+	return
+}
+
+// Format the GPUs of node in lines, the first of which is the number of idle ones
+func formatNodeGpus(r *pb.NodeResources) []string {
+	if len(r.Gpus) == 0 {
+		return nil
+	}
+	lines := []string{fmt.Sprintf("%v idle of %v", r.IdleGpus, len(r.Gpus))}
+	for _, gpu := range r.Gpus {
+		line := fmt.Sprintf("%v: %v, driver %v, %v%% utilized, %v MB used of %v MB", gpu.Index, gpu.Model, gpu.Driver, gpu.Utilization, gpu.MemoryUsedMb, gpu.MemoryTotalMb)
+		if gpu.JobId > 0 {
+			line += fmt.Sprintf(", assigned to job %v", gpu.JobId)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Format the success rate of the jobs run on node like "95.0%", which is empty if no job is run
+func formatNodeSuccessRate(s *pb.NodeStats) string {
+	if s.GetJobs() == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.1f%%", float64(s.GetSucceededJobs())*100/float64(s.GetJobs()))
+}
+
+// Format the success rate, average duration and last job of the jobs run on node
+func formatNodeStats(s *pb.NodeStats) (success_rate, avg_duration, last_job string) {
+	success_rate, avg_duration = "N/A", "N/A"
+	if s.GetJobs() > 0 {
+		success_rate = fmt.Sprintf("%v (%v of %v failed)", formatNodeSuccessRate(s), s.Jobs-s.SucceededJobs, s.Jobs)
+		avg_duration = (time.Duration(s.TotalDurationMs/s.Jobs) * time.Millisecond).String()
+	}
+	result := "succeeded"
+	if !s.GetLastJobSucceeded() {
+		result = "failed"
+	}
+	last_job = fmt.Sprintf("%v %v at %v", s.GetLastJobId(), result, FormatTime(time.Unix(0, s.GetLastJobTime())))
+	return
+}
+
+// Format the clusnode version and protocol like "1.2.0 (protocol 2)", which is empty if not reported by an old clusnode
+func formatNodeBuild(b *pb.NodeBuild) string {
+	if len(b.GetVersion()) == 0 && b.GetProtocol() == 0 {
+		return ""
+	}
+	version := b.GetVersion()
+	if len(version) == 0 {
+		version = "unknown"
+	}
+	if b.GetProtocol() == 0 {
+		return version
+	}
+	return fmt.Sprintf("%v (protocol %v)", version, b.GetProtocol())
+}
+
+// Display the OS reported by clusnode in the common name
+func formatClockSkew(node *pb.Node) string {
+	skew := fmt.Sprintf("%+dms", node.ClockSkewMs)
+	if node.ClockSkewed {
+		skew += " (over the max)"
+	}
+	return skew
+}
+
+// Warn the nodes whose clocks are skewed over the max configured on headnode, which makes the time of jobs misleading
+func printClockSkewedNodes(nodes []*pb.Node) {
+	skewed := []string{}
+	for _, node := range nodes {
+		if node.ClockSkewed {
+			skewed = append(skewed, node.Name)
+		}
+	}
+	if len(skewed) > 0 {
+		Printlnf("Warning: clocks of %v nodes are skewed over the max, please check their time sync: %v", len(skewed), strings.Join(skewed, ", "))
+	}
+}
+
+func formatNodeOS(os string) string {
+	switch os {
+	case "windows":
+		return "Windows"
+	case "linux":
+		return "Linux"
+	case "darwin":
+		return "macOS"
+	}
+	return os
+}
+
+// Format the OS, architecture, OS version and time zone like "Linux amd64 (Ubuntu 20.04 LTS), zone CST +0800", which
+// is empty if not reported
+func formatNodeSystem(s *pb.NodeSystem) string {
+	system := strings.TrimSpace(formatNodeOS(s.GetOs()) + " " + s.GetArch())
+	if version := s.GetVersion(); len(version) > 0 {
+		system = strings.TrimSpace(fmt.Sprintf("%v (%v)", system, version))
+	}
+	if zone := s.GetTimeZone(); len(zone) > 0 {
+		system = strings.TrimPrefix(system+", zone "+zone, ", ")
+	}
+	return system
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%v B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 func nodePrintGroups(nodes []*pb.Node, group_by string) {
 	if len(group_by) == 0 {
 		Fatallnf("Please specify group-by option.")
@@ -227,10 +672,10 @@ func nodePrintGroups(nodes []*pb.Node, group_by string) {
 	Printlnf(GetPaddingLine(""))
 	for i := range groups {
 		category := "group"
-		if strings.ToLower(group_by) == "state" {
-			category = "state"
+		if g := strings.ToLower(group_by); g == "state" || g == "zone" {
+			category = g
 		}
-		label := "no group"
+		label := "no " + category
 		if group := groups[i].name; len(group) > 0 {
 			label = fmt.Sprintf("%v '%v'", category, group)
 		}
@@ -238,6 +683,46 @@ func nodePrintGroups(nodes []*pb.Node, group_by string) {
 	}
 }
 
+func formatNodeLabels(labels map[string]string) string {
+	items := make([]string, 0, len(labels))
+	for key, value := range labels {
+		items = append(items, key+"="+value)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ", ")
+}
+
+func setNodeLabels(set, remove string, nodes []*pb.Node) string {
+	labels := map[string]string{}
+	for _, label := range ParseNodesOrGroups(set, "") {
+		index := strings.Index(label, "=")
+		if index <= 0 {
+			Fatallnf("Invalid label %q, which should be in format key=value", label)
+		}
+		labels[strings.TrimSpace(label[:index])] = strings.TrimSpace(label[index+1:])
+	}
+	names := make([]string, len(nodes))
+	for i, node := range nodes {
+		names[i] = node.Name
+	}
+
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	c := pb.NewHeadnodeClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Set or remove labels of nodes
+	if _, err := c.SetNodeLabels(ctx, &pb.SetNodeLabelsRequest{Nodes: names, Labels: labels, RemoveLabels: ParseNodesOrGroups(remove, "")}); status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support node labels.")
+	} else if err != nil {
+		Fatallnf("Could not set node labels: %v", status.Convert(err).Message())
+	}
+	return fmt.Sprintf("Labels of %v nodes are updated", len(nodes))
+}
+
 func setNodeGroups(nodeGroups string, nodes []*pb.Node, remove bool) string {
 	// Parse node groups
 	all := false
@@ -278,6 +763,305 @@ func setNodeGroups(nodeGroups string, nodes []*pb.Node, remove bool) string {
 	return fmt.Sprintf("Nodes are %v %v", v, t)
 }
 
+func resetNodeKeys(nodes []*pb.Node) string {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Reset keys of nodes
+	names := make([]string, len(nodes))
+	for i, node := range nodes {
+		names[i] = node.Name
+	}
+	reply, err := pb.NewHeadnodeClient(conn).ResetNodeKeys(ctx, &pb.ResetNodeKeysRequest{Nodes: names})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support node keys.")
+	} else if err != nil {
+		Fatallnf("Could not reset node keys: %v", err)
+	}
+	reset := []string{}
+	for _, name := range names {
+		if reply.GetResults()[name] == "Reset" {
+			reset = append(reset, name)
+		}
+	}
+	return fmt.Sprintf("Keys of %v nodes are reset: %v", len(reset), strings.Join(reset, ", "))
+}
+
+// Stop dispatching new jobs to the nodes until undrained, the running jobs on them are not affected
+func drainNodes(names []string, undrain bool) string {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Drain or undrain nodes
+	reply, err := pb.NewHeadnodeClient(conn).DrainNodes(ctx, &pb.DrainNodesRequest{Nodes: names, Undrain: undrain})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support draining nodes.")
+	} else if err != nil {
+		Fatallnf("Could not drain nodes: %v", err)
+	}
+	return formatDrainResults(names, reply.GetResults(), undrain)
+}
+
+// Remove the lost nodes matching the pattern from the headnode, so that they are not listed any more
+func removeLostNodes(pattern string) {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Remove nodes
+	reply, err := pb.NewHeadnodeClient(conn).RemoveNodes(ctx, &pb.RemoveNodesRequest{Pattern: pattern})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support removing nodes.")
+	} else if err != nil {
+		Fatallnf("Could not remove nodes: %v", err)
+	}
+	removed, not_lost := reply.GetRemovedNodes(), reply.GetNotLostNodes()
+	Printlnf("Removed %v lost nodes: %v", len(removed), strings.Join(removed, ", "))
+	if len(not_lost) > 0 {
+		Printlnf("Skipped %v nodes not lost: %v", len(not_lost), strings.Join(not_lost, ", "))
+	}
+	if id := reply.GetUndoId(); id > 0 {
+		Printlnf("Run \"clus undo %v\" in an hour to restore the removed nodes.", id)
+	}
+}
+
+// Validate the nodes matching the pattern immediately, instead of waiting for the next validation after failures
+func revalidateNodes(pattern string, concurrency int) {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+
+	// The validation of each node takes up to a second
+	Printlnf("Revalidating nodes...")
+	reply, err := pb.NewHeadnodeClient(conn).RevalidateNodes(context.Background(), &pb.RevalidateNodesRequest{Pattern: pattern, Concurrency: int32(concurrency)})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support revalidating nodes.")
+	} else if err != nil {
+		Fatallnf("Could not revalidate nodes: %v", status.Convert(err).Message())
+	}
+	Printlnf(formatRevalidateResults(reply.GetValidatedNodes(), reply.GetFailedNodes()))
+}
+
+func formatRevalidateResults(validated []string, failed map[string]string) string {
+	lines := []string{fmt.Sprintf("Validated %v nodes: %v", len(validated), strings.Join(validated, ", "))}
+	if len(failed) > 0 {
+		nodes := make([]string, 0, len(failed))
+		for node := range failed {
+			nodes = append(nodes, node)
+		}
+		sort.Strings(nodes)
+		lines = append(lines, fmt.Sprintf("Failed to validate %v nodes:", len(failed)))
+		for _, node := range nodes {
+			lines = append(lines, fmt.Sprintf("\t%v: %v", node, failed[node]))
+		}
+	}
+	return strings.Join(lines, LineEnding)
+}
+
+// Measure the connectivity, latency, clock skew and job execution of the nodes matching the pattern
+func printNodeAvailability(pattern, cluster, windows string, json_format bool) {
+	labels, seconds, err := parseAvailabilityWindows(windows)
+	if err != nil {
+		Fatallnf("%v", err)
+	}
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	reply, err := pb.NewHeadnodeClient(conn).GetNodeAvailability(context.Background(), &pb.GetNodeAvailabilityRequest{Pattern: pattern, WindowSeconds: seconds, Cluster: cluster})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support node history.")
+	} else if err != nil {
+		Fatallnf("Could not get node history: %v", status.Convert(err).Message())
+	}
+	if json_format {
+		messages := make([]proto.Message, len(reply.GetNodes()))
+		for i, node := range reply.GetNodes() {
+			messages[i] = node
+		}
+		PrintJson(messages)
+		return
+	}
+	if len(reply.GetNodes()) == 0 {
+		Printlnf("No node is found.")
+		return
+	}
+	for _, line := range formatNodeAvailability(reply.GetNodes(), labels) {
+		Printlnf("%v", line)
+	}
+}
+
+// Parse the windows in format like 30m, 12h or 7d separated by comma to seconds
+func parseAvailabilityWindows(windows string) ([]string, []int64, error) {
+	labels, seconds := []string{}, []int64{}
+	for _, w := range strings.Split(windows, ",") {
+		if w = strings.TrimSpace(w); len(w) == 0 {
+			continue
+		}
+		var d time.Duration
+		var err error
+		if strings.HasSuffix(w, "d") {
+			var days int
+			days, err = strconv.Atoi(strings.TrimSuffix(w, "d"))
+			d = time.Duration(days) * 24 * time.Hour
+		} else {
+			d, err = time.ParseDuration(w)
+		}
+		if err != nil || d < time.Second {
+			return nil, nil, fmt.Errorf(T("Invalid window %q, should be like 30m, 12h or 7d"), w)
+		}
+		labels, seconds = append(labels, w), append(seconds, int64(d/time.Second))
+	}
+	if len(seconds) == 0 {
+		return nil, nil, errors.New(T("No window is specified"))
+	}
+	return labels, seconds, nil
+}
+
+// Format the uptime of nodes in table, the nodes with the lowest uptime in the last window are listed first
+func formatNodeAvailability(nodes []*pb.NodeAvailability, labels []string) []string {
+	nodes = append([]*pb.NodeAvailability{}, nodes...)
+	last_uptime := func(n *pb.NodeAvailability) float64 {
+		if len(n.Uptimes) == 0 || n.Uptimes[len(n.Uptimes)-1].Percent < 0 {
+			return 101
+		}
+		return n.Uptimes[len(n.Uptimes)-1].Percent
+	}
+	sort.SliceStable(nodes, func(i, j int) bool { return last_uptime(nodes[i]) < last_uptime(nodes[j]) })
+	header := []string{"Node", "State", "Since"}
+	for _, label := range labels {
+		header = append(header, "Uptime "+label)
+	}
+	rows := [][]string{header}
+	for _, n := range nodes {
+		state, since := "unknown", "-"
+		if n.Since > 0 {
+			state, since = "down", FormatTime(time.Unix(0, n.Since))
+			if n.Up {
+				state = "up"
+			}
+		}
+		row := []string{n.Node, state, since}
+		for i := range labels {
+			cell := "-"
+			if i < len(n.Uptimes) && n.Uptimes[i].Percent >= 0 {
+				u := n.Uptimes[i]
+				cell = fmt.Sprintf("%.2f%%", u.Percent)
+				if u.Downs > 0 {
+					cell += fmt.Sprintf(" (%v down, %v)", u.Downs, time.Duration(u.DownSeconds)*time.Second)
+				}
+			}
+			row = append(row, cell)
+		}
+		rows = append(rows, row)
+	}
+	return formatTable(rows)
+}
+
+func testNodes(pattern string, concurrency int, no_job bool) {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+
+	Printlnf("Testing nodes...")
+	reply, err := pb.NewHeadnodeClient(conn).TestNodes(context.Background(), &pb.TestNodesRequest{Pattern: pattern, Concurrency: int32(concurrency), SkipJob: no_job})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support testing nodes.")
+	} else if err != nil {
+		Fatallnf("Could not test nodes: %v", status.Convert(err).Message())
+	}
+	for _, line := range formatNodeTestResults(reply.GetResults(), no_job) {
+		Printlnf("%v", line)
+	}
+}
+
+const (
+	nodeTestSkewWarningMs    = 1000
+	nodeTestLatencyWarningMs = 200
+)
+
+func formatNodeTestResults(results []*pb.NodeTestResult, no_job bool) []string {
+	header := []string{"Node", "Heartbeat", "Latency", "Clock Skew", "No-op Job", "Error"}
+	rows := [][]string{header}
+	var failed, skewed, slow []string
+	for _, r := range results {
+		job := fmt.Sprintf("%vms", r.JobMs)
+		if no_job || r.JobMs == 0 && len(r.Error) > 0 {
+			job = "-"
+		}
+		row := []string{r.Node, fmt.Sprintf("%.1fs ago", float64(r.HeartbeatAgeMs)/1000), fmt.Sprintf("%vms", r.RoundTripMs), fmt.Sprintf("%+dms", r.ClockSkewMs), job, r.Error}
+		if r.RoundTripMs == 0 && len(r.Error) > 0 {
+			row[2], row[3] = "-", "-"
+		}
+		rows = append(rows, row)
+		if len(r.Error) > 0 {
+			failed = append(failed, r.Node)
+		}
+		if r.ClockSkewMs >= nodeTestSkewWarningMs || r.ClockSkewMs <= -nodeTestSkewWarningMs {
+			skewed = append(skewed, r.Node)
+		}
+		if r.RoundTripMs >= nodeTestLatencyWarningMs {
+			slow = append(slow, r.Node)
+		}
+	}
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = MaxInt(widths[i], len(cell)+2)
+		}
+	}
+	lines := []string{}
+	for i, row := range rows {
+		line := ""
+		for j, cell := range row {
+			line += fmt.Sprintf("%-*v", widths[j], cell)
+		}
+		lines = append(lines, strings.TrimRight(line, " "))
+		if i == 0 {
+			separator := ""
+			for j := range row {
+				separator += fmt.Sprintf("%-*v", widths[j], strings.Repeat("-", widths[j]-2))
+			}
+			lines = append(lines, strings.TrimRight(separator, " "))
+		}
+	}
+	lines = append(lines, fmt.Sprintf("Node count: %v", len(results)))
+	if len(failed) > 0 {
+		lines = append(lines, fmt.Sprintf("Failed nodes: %v", strings.Join(failed, ", ")))
+	}
+	if len(skewed) > 0 {
+		lines = append(lines, fmt.Sprintf("Nodes with clock skew over %vms, please check their time sync: %v", nodeTestSkewWarningMs, strings.Join(skewed, ", ")))
+	}
+	if len(slow) > 0 {
+		lines = append(lines, fmt.Sprintf("Nodes with latency over %vms, please check their network: %v", nodeTestLatencyWarningMs, strings.Join(slow, ", ")))
+	}
+	return lines
+}
+
+func formatDrainResults(names []string, results map[string]string, undrain bool) string {
+	action := "Drain"
+	if undrain {
+		action = "Undrain"
+	}
+	lines := []string{fmt.Sprintf("%v result of %v nodes:", action, len(names))}
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("\t%v: %v", name, results[name]))
+	}
+	return strings.Join(lines, LineEnding)
+}
+
 func printGroup(name string, nodes []string) {
 	if len(nodes) > 0 {
 		if len(name) > 0 {
@@ -343,25 +1127,30 @@ func getNodesByGroup(nodes []*pb.Node, groupby string, separate_group bool) map[
 				groups[group] = append(groups[group], nodes[i])
 			}
 		}
-	default:
-		Fatallnf("Invalid group-by option: %v", groupby)
-	}
-	return groups
-}
-
-func getNodeTableMaxLength(nodes []*pb.Node) (max_name_length, max_state_length, max_groups_length int) {
-	for i := range nodes {
-		if length := len(nodes[i].Name); length > max_name_length {
-			max_name_length = length
+	case "zone":
+		for i := range nodes {
+			zone := nodes[i].Zone
+			groups[zone] = append(groups[zone], nodes[i])
 		}
-		if length := len(nodes[i].State.String()); length > max_state_length {
-			max_state_length = length
+	case "os":
+		for i := range nodes {
+			os := formatNodeOS(nodes[i].System.GetOs())
+			groups[os] = append(groups[os], nodes[i])
 		}
-		if length := len(strings.Join(nodes[i].Groups, ", ")); length > max_groups_length {
-			max_groups_length = length
+	case "arch":
+		for i := range nodes {
+			arch := nodes[i].System.GetArch()
+			groups[arch] = append(groups[arch], nodes[i])
+		}
+	case "clusnode":
+		for i := range nodes {
+			version := nodes[i].Build.GetVersion()
+			groups[version] = append(groups[version], nodes[i])
 		}
+	default:
+		Fatallnf("Invalid group-by option: %v", groupby)
 	}
-	return
+	return groups
 }
 
 func getSortedGroups(nodes []*pb.Node, group_by string) (sorted_groups [][]*pb.Node) {
@@ -416,6 +1205,31 @@ func sortNodes(nodes []*pb.Node, order_by string) {
 							}
 						}
 				*/
+			case "zone":
+				result := strings.Compare(nodes[i].Zone, nodes[j].Zone)
+				if result != 0 {
+					return result < 0
+				}
+			case "os":
+				result := strings.Compare(nodes[i].System.GetOs(), nodes[j].System.GetOs())
+				if result != 0 {
+					return result < 0
+				}
+			case "arch":
+				result := strings.Compare(nodes[i].System.GetArch(), nodes[j].System.GetArch())
+				if result != 0 {
+					return result < 0
+				}
+			case "version", "osversion":
+				result := strings.Compare(nodes[i].System.GetVersion(), nodes[j].System.GetVersion())
+				if result != 0 {
+					return result < 0
+				}
+			case "clusnode":
+				result := strings.Compare(nodes[i].Build.GetVersion(), nodes[j].Build.GetVersion())
+				if result != 0 {
+					return result < 0
+				}
 			default:
 				Fatallnf("Invalid order-by option: %v", order_by)
 			}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_formatNodeResources(t *testing.T) {
+	cases := []struct {
+		resources *pb.NodeResources
+		load      string
+		memory    string
+		disk      string
+	}{
+		{&pb.NodeResources{CpuLoad: 0.5, MemoryTotal: 8 << 30, MemoryAvailable: 3 << 29, DiskTotal: 100 << 30, DiskFree: 1000}, "0.50", "1.5 GB available of 8.0 GB", "1000 B free of 100.0 GB"},
+		{&pb.NodeResources{CpuLoad: -1, MemoryTotal: -1, MemoryAvailable: -1, DiskTotal: 2 << 40, DiskFree: 0}, "N/A", "N/A", "0 B free of 2.0 TB"},
+		{&pb.NodeResources{}, "0.00", "N/A", "N/A"},
+	}
+
+	for _, c := range cases {
+		if load, memory, disk := formatNodeResources(c.resources); load != c.load || memory != c.memory || disk != c.disk {
+			t.Errorf("\nresources=%v\nexpected: %q, %q, %q\n  actual: %q, %q, %q", c.resources, c.load, c.memory, c.disk, load, memory, disk)
+		}
+	}
+}
+
+func Test_formatDrainResults(t *testing.T) {
+	cases := []struct {
+		names    []string
+		results  map[string]string
+		undrain  bool
+		expected string
+	}{
+		{[]string{}, nil, false, "Drain result of 0 nodes:"},
+		{[]string{"NODE1", "node2"}, map[string]string{"NODE1": "Drained", "node2": "Draining (2 running jobs)"}, false, "Drain result of 2 nodes:\n\tNODE1: Drained\n\tnode2: Draining (2 running jobs)"},
+		{[]string{"NODE3"}, map[string]string{"NODE3": "Not found"}, true, "Undrain result of 1 nodes:\n\tNODE3: Not found"},
+	}
+
+	LineEnding = "\n"
+	for _, c := range cases {
+		if result := formatDrainResults(c.names, c.results, c.undrain); result != c.expected {
+			t.Errorf("\nnames=%v, results=%v, undrain=%v\nexpected=%q\n  actual=%q", c.names, c.results, c.undrain, c.expected, result)
+		}
+	}
+}
+
+func Test_formatNodeSystem(t *testing.T) {
+	cases := []struct {
+		system   *pb.NodeSystem
+		expected string
+	}{
+		{nil, ""},
+		{&pb.NodeSystem{Os: "linux", Arch: "amd64", Version: "Ubuntu 20.04 LTS"}, "Linux amd64 (Ubuntu 20.04 LTS)"},
+		{&pb.NodeSystem{Os: "windows", Arch: "386"}, "Windows 386"},
+		{&pb.NodeSystem{Os: "freebsd", Arch: "arm64"}, "freebsd arm64"},
+		{&pb.NodeSystem{Version: "macOS 11"}, "(macOS 11)"},
+		{&pb.NodeSystem{Os: "linux", Arch: "amd64", TimeZone: "CST +0800"}, "Linux amd64, zone CST +0800"},
+		{&pb.NodeSystem{TimeZone: "UTC +0000"}, "zone UTC +0000"},
+	}
+
+	for _, c := range cases {
+		if result := formatNodeSystem(c.system); result != c.expected {
+			t.Errorf("\nsystem=%v\nexpected=%q\n  actual=%q", c.system, c.expected, result)
+		}
+	}
+}
+
+func Test_formatNodeBuild(t *testing.T) {
+	cases := []struct {
+		build    *pb.NodeBuild
+		expected string
+	}{
+		{nil, ""},
+		{&pb.NodeBuild{Version: "1.2.0", Protocol: 2}, "1.2.0 (protocol 2)"},
+		{&pb.NodeBuild{Protocol: 2}, "unknown (protocol 2)"},
+		{&pb.NodeBuild{Version: "dev"}, "dev"},
+	}
+
+	for _, c := range cases {
+		if result := formatNodeBuild(c.build); result != c.expected {
+			t.Errorf("\nbuild=%v\nexpected=%q\n  actual=%q", c.build, c.expected, result)
+		}
+	}
+}
+
+func Test_formatRevalidateResults(t *testing.T) {
+	cases := []struct {
+		validated []string
+		failed    map[string]string
+		expected  string
+	}{
+		{[]string{}, nil, "Validated 0 nodes: "},
+		{[]string{"NODE1", "NODE2"}, nil, "Validated 2 nodes: NODE1, NODE2"},
+		{[]string{"NODE1"}, map[string]string{"NODE3": "Failed to connect NODE3:50505", "NODE2": "Validation failed: invalid signature"}, "Validated 1 nodes: NODE1\nFailed to validate 2 nodes:\n\tNODE2: Validation failed: invalid signature\n\tNODE3: Failed to connect NODE3:50505"},
+	}
+
+	LineEnding = "\n"
+	for _, c := range cases {
+		if result := formatRevalidateResults(c.validated, c.failed); result != c.expected {
+			t.Errorf("\nvalidated=%v, failed=%v\nexpected=%q\n  actual=%q", c.validated, c.failed, c.expected, result)
+		}
+	}
+}
+
+func Test_formatNodeTestResults(t *testing.T) {
+	results := []*pb.NodeTestResult{
+		{Node: "NODE1", HeartbeatAgeMs: 1500, RoundTripMs: 3, ClockSkewMs: -2, JobMs: 12},
+		{Node: "NODE2", HeartbeatAgeMs: 30000, Error: "Failed to connect"},
+		{Node: "NODE3", RoundTripMs: 250, ClockSkewMs: 1500, JobMs: 20},
+	}
+	expected := []string{
+		"Node   Heartbeat  Latency  Clock Skew  No-op Job  Error",
+		"-----  ---------  -------  ----------  ---------  -----------------",
+		"NODE1  1.5s ago   3ms      -2ms        12ms",
+		"NODE2  30.0s ago  -        -           -          Failed to connect",
+		"NODE3  0.0s ago   250ms    +1500ms     20ms",
+		"Node count: 3",
+		"Failed nodes: NODE2",
+		"Nodes with clock skew over 1000ms, please check their time sync: NODE3",
+		"Nodes with latency over 200ms, please check their network: NODE3",
+	}
+	lines := formatNodeTestResults(results, false)
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %v lines, got %q", len(expected), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("\nexpected=%q\n  actual=%q", expected[i], lines[i])
+		}
+	}
+	if lines := formatNodeTestResults(results[:1], true); lines[2] != "NODE1  1.5s ago   3ms      -2ms        -" {
+		t.Errorf("Expected no-op job skipped, got %q", lines[2])
+	}
+}
+
+func Test_formatNodeStats(t *testing.T) {
+	last := time.Date(2020, 5, 1, 8, 30, 0, 0, time.UTC)
+	cases := []struct {
+		stats        *pb.NodeStats
+		success_rate string
+		avg_duration string
+		last_job     string
+	}{
+		{&pb.NodeStats{Jobs: 4, SucceededJobs: 3, TotalDurationMs: 10000, LastJobId: 12, LastJobTime: last.UnixNano(), LastJobSucceeded: false}, "75.0% (1 of 4 failed)", "2.5s", "12 failed at " + FormatTime(last)},
+		{&pb.NodeStats{Jobs: 3, SucceededJobs: 3, TotalDurationMs: 100, LastJobId: 5, LastJobTime: last.UnixNano(), LastJobSucceeded: true}, "100.0% (0 of 3 failed)", "33ms", "5 succeeded at " + FormatTime(last)},
+	}
+	for _, c := range cases {
+		if success_rate, avg_duration, last_job := formatNodeStats(c.stats); success_rate != c.success_rate || avg_duration != c.avg_duration || last_job != c.last_job {
+			t.Errorf("\nstats=%v\nexpected: %q, %q, %q\n  actual: %q, %q, %q", c.stats, c.success_rate, c.avg_duration, c.last_job, success_rate, avg_duration, last_job)
+		}
+	}
+	if rate := formatNodeSuccessRate(nil); len(rate) > 0 {
+		t.Errorf("unexpected success rate of node without jobs: %q", rate)
+	}
+}
+
+func Test_formatNodeGpus(t *testing.T) {
+	r := &pb.NodeResources{IdleGpus: 1, Gpus: []*pb.GpuInfo{
+		{Index: 0, Model: "Tesla T4", Driver: "535.104.05", Utilization: 3, MemoryUsedMb: 0, MemoryTotalMb: 15360},
+		{Index: 1, Model: "Tesla T4", Driver: "535.104.05", Utilization: 95, MemoryUsedMb: 2048, MemoryTotalMb: 15360, JobId: 7},
+	}}
+	expected := []string{
+		"1 idle of 2",
+		"0: Tesla T4, driver 535.104.05, 3% utilized, 0 MB used of 15360 MB",
+		"1: Tesla T4, driver 535.104.05, 95% utilized, 2048 MB used of 15360 MB, assigned to job 7",
+	}
+	if lines := formatNodeGpus(r); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("\nexpected: %q\n  actual: %q", expected, lines)
+	}
+	if lines := formatNodeGpus(&pb.NodeResources{}); len(lines) != 0 {
+		t.Errorf("Expected no GPU lines, got %q", lines)
+	}
+}
+
+func Test_formatConfigDrift(t *testing.T) {
+	drifts := []*pb.ConfigDrift{
+		{Node: "n2", Values: map[string]string{"log level": "debug", "job timeout": "3600"}},
+		{Node: "n3", Values: map[string]string{"log level": "error"}},
+	}
+	expected := []string{
+		"Node  Config       Value  Baseline",
+		"----  -----------  -----  --------",
+		"n2    job timeout  3600   0",
+		"n2    log level    debug  info",
+		"n3    log level    error  info",
+	}
+	if lines := formatConfigDrift(drifts, map[string]string{"log level": "info", "job timeout": "0"}); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("\nexpected: %q\n  actual: %q", expected, lines)
+	}
+}
+
+func Test_parseAvailabilityWindows(t *testing.T) {
+	labels, seconds, err := parseAvailabilityWindows("30m, 12h,7d,")
+	if err != nil || !reflect.DeepEqual(labels, []string{"30m", "12h", "7d"}) || !reflect.DeepEqual(seconds, []int64{1800, 43200, 604800}) {
+		t.Errorf("Unexpected windows %v %v %v", labels, seconds, err)
+	}
+	for _, windows := range []string{"", "7", "xd", "0d", "-1h", "1ms"} {
+		if _, _, err := parseAvailabilityWindows(windows); err == nil {
+			t.Errorf("Expected error of windows %q", windows)
+		}
+	}
+}
+
+func Test_formatNodeAvailability(t *testing.T) {
+	since := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	nodes := []*pb.NodeAvailability{
+		{Node: "n1", Up: true, Since: since.UnixNano(), Uptimes: []*pb.NodeUptime{{Percent: 100}, {Percent: 100}}},
+		{Node: "n2", Uptimes: []*pb.NodeUptime{{Percent: -1}, {Percent: -1}}},
+		{Node: "n3", Since: since.UnixNano(), Uptimes: []*pb.NodeUptime{{Percent: 50, Downs: 1, DownSeconds: 1800}, {Percent: 87.5, Downs: 2, DownSeconds: 9000}}},
+	}
+	time_string := FormatTime(since)
+	expected := []string{
+		"Node  State    Since" + strings.Repeat(" ", len(time_string)-3) + "Uptime 1h               Uptime 1d",
+		"----  -------  " + strings.Repeat("-", len(time_string)) + "  ----------------------  ------------------------",
+		"n3    down     " + time_string + "  50.00% (1 down, 30m0s)  87.50% (2 down, 2h30m0s)",
+		"n1    up       " + time_string + "  100.00%                 100.00%",
+		"n2    unknown  -" + strings.Repeat(" ", len(time_string)+1) + "-                       -",
+	}
+	if lines := formatNodeAvailability(nodes, []string{"1h", "1d"}); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("\nexpected: %q\n  actual: %q", expected, lines)
+	}
+}
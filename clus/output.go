@@ -0,0 +1,207 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/status"
+)
+
+func Output(args []string) {
+	fs := flag.NewFlagSet("clus output options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	node := fs.String("node", "", "get the output of the job on a certain node, default gets the output on all nodes")
+	offset := fs.Int64("offset", 0, "specify the byte offset in the output to start from")
+	tail := fs.Int("tail", 0, "get only the specified number of last lines of the output")
+	timing := fs.Bool("timing", false, "show the time of dispatching and running the job on each node before its output")
+	_ = fs.Parse(args)
+	if len(fs.Args()) != 1 {
+		displayOutputUsage(fs)
+		return
+	}
+	job_id, err := strconv.Atoi(fs.Arg(0))
+	if err != nil || job_id <= 0 {
+		Fatallnf("Invalid job id: %q", fs.Arg(0))
+	}
+	if *offset < 0 || *tail < 0 {
+		Fatallnf("Offset and tail should not be negative.")
+	}
+	if *offset > 0 && *tail > 0 {
+		Fatallnf("Offset and tail can not be specified together.")
+	}
+	getOutput(int32(job_id), *node, *offset, int32(*tail), *timing)
+}
+
+func displayOutputUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus output [options] <job id>
+
+  The stdout and stderr of the job saved on headnode are printed to stdout and stderr respectively.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+func getOutput(job_id int32, node string, offset int64, tail int32, timing bool) {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+
+	// Get output
+	stream, err := pb.NewHeadnodeClient(conn).GetOutput(context.Background(), &pb.GetOutputRequest{JobId: job_id, Node: node, Offset: offset, Tail: tail, Timing: timing})
+	if err != nil {
+		Fatallnf("Failed to get output: %v", status.Convert(err).Message())
+	}
+	last_node := ""
+	for {
+		output, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			Fatallnf("Failed to get output: %v", status.Convert(err).Message())
+		}
+		if len(node) == 0 && output.GetNode() != last_node {
+			Printlnf(GetPaddingLine(fmt.Sprintf("---[%v]---", output.GetNode())))
+			last_node = output.GetNode()
+		}
+		if task := output.GetTask(); task != nil {
+			Printlnf("%v", formatTaskTiming(task, time.Now()))
+		}
+		_, _ = os.Stdout.Write(output.GetStdout())
+		_, _ = os.Stderr.Write(output.GetStderr())
+	}
+}
+
+const (
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorReset  = "\x1b[0m"
+)
+
+// Color is only used in an interactive terminal, NO_COLOR environment variable is respected as well
+func colorEnabled() bool {
+	if (noColor != nil && *noColor) || IsPlain() || len(os.Getenv("NO_COLOR")) > 0 {
+		return false
+	}
+	return ConsoleWidth > 0 && runtime.GOOS != "windows"
+}
+
+func Colorize(s, color string) string {
+	if !colorEnabled() || len(s) == 0 {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// Color the padded text, so that the escape codes don't break the alignment of a table
+func ColorizeState(padded, state string) string {
+	switch strings.ToLower(state) {
+	case "ready", "finished", "canceled":
+		return Colorize(padded, colorGreen)
+	case "error", "failed", "cancelfailed", "aborted", "untracked":
+		return Colorize(padded, colorRed)
+	case "lost", "draining", "running", "dispatching", "canceling":
+		return Colorize(padded, colorYellow)
+	}
+	return padded
+}
+
+// Plain output is for piping, without decorations
+func IsPlain() bool {
+	return plain != nil && *plain
+}
+
+// Column names are case insensitive and spaces, dashes or underscores are ignored, e.g. "create-time" for "Create Time"
+func normalizeColumn(column string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(column)))
+}
+
+// Parse the columns to display in table, return nil if not specified
+func ParseColumns(columns string, valid []string) map[string]bool {
+	if len(strings.TrimSpace(columns)) == 0 {
+		return nil
+	}
+	valid_columns := map[string]bool{}
+	for _, c := range valid {
+		valid_columns[normalizeColumn(c)] = true
+	}
+	selected := map[string]bool{}
+	for _, c := range strings.Split(columns, ",") {
+		column := normalizeColumn(c)
+		if len(column) == 0 {
+			continue
+		}
+		if !valid_columns[column] {
+			Fatallnf("Invalid column %q, valid columns are: %v", strings.TrimSpace(c), strings.Join(valid, ", "))
+		}
+		selected[column] = true
+	}
+	return selected
+}
+
+func IsColumnSelected(columns map[string]bool, column string) bool {
+	return columns == nil || columns[normalizeColumn(column)]
+}
+
+// Format the messages in a JSON array with all fields in their proto names, which can be parsed back to the messages by jsonpb
+func FormatJson(messages []proto.Message) (string, error) {
+	if len(messages) == 0 {
+		return "[]", nil
+	}
+	marshaler := &jsonpb.Marshaler{OrigName: true, EmitDefaults: true, Indent: "  "}
+	items := make([]string, len(messages))
+	for i, m := range messages {
+		item, err := marshaler.MarshalToString(m)
+		if err != nil {
+			return "", err
+		}
+		items[i] = "  " + strings.ReplaceAll(item, "\n", "\n  ")
+	}
+	return "[\n" + strings.Join(items, ",\n") + "\n]", nil
+}
+
+func PrintJson(messages []proto.Message) {
+	json, err := FormatJson(messages)
+	if err != nil {
+		Fatallnf("Failed to format in JSON: %v", err)
+	}
+	fmt.Print(strings.ReplaceAll(json, "\n", LineEnding) + LineEnding)
+}
+
+// Print the header and rows in CSV, in which only the selected columns are kept
+func PrintCsv(header []string, rows [][]string, columns map[string]bool) {
+	selected := []int{}
+	for i, column := range header {
+		if IsColumnSelected(columns, column) {
+			selected = append(selected, i)
+		}
+	}
+	w := csv.NewWriter(os.Stdout)
+	w.UseCRLF = LineEnding == "\r\n"
+	for _, row := range append([][]string{header}, rows...) {
+		record := make([]string, len(selected))
+		for i, j := range selected {
+			record[i] = row[j]
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		Fatallnf("Failed to write CSV: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const maxProgressBarWidth = 40
+
+// The result of the task on a node once it ends
+type nodeResult struct {
+	state    string
+	exitCode int32
+	duration time.Duration
+}
+
+// Format the progress sent by the headnode in a bar fitting the console, or in text only if the output is plain
+func formatJobProgress(progress *pb.JobProgress, width int) string {
+	text := fmt.Sprintf(T("%v/%v nodes finished, %v failed, %v running"), progress.FinishedNodes, progress.Nodes, progress.FailedNodes, progress.RunningNodes)
+	percent := 100
+	if progress.Nodes > 0 {
+		percent = int(progress.FinishedNodes * 100 / progress.Nodes)
+	}
+	text = fmt.Sprintf("%3v%% %v", percent, text)
+	bar_width := width - len(text) - 3
+	if bar_width > maxProgressBarWidth {
+		bar_width = maxProgressBarWidth
+	}
+	if IsPlain() || bar_width < 10 {
+		return text
+	}
+	done := bar_width * percent / 100
+	return fmt.Sprintf("[%v%v] %v", strings.Repeat("#", done), strings.Repeat("-", bar_width-done), text)
+}
+
+// Format the results of nodes in a table, the nodes without result have not ended when the output ends
+func formatNodeResults(nodes []string, results map[string]nodeResult) []string {
+	rows := [][]string{{"Node", "State", "Exit Code", "Duration"}}
+	for _, node := range nodes {
+		if r, ok := results[node]; ok {
+			rows = append(rows, []string{node, r.state, fmt.Sprint(r.exitCode), r.duration.Round(time.Millisecond).String()})
+		} else {
+			rows = append(rows, []string{node, "unfinished", "-", "-"})
+		}
+	}
+	return formatTable(rows)
+}
+
+// Format the rows in columns aligned, the first row is the header followed by a separator
+func formatTable(rows [][]string) []string {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = MaxInt(widths[i], len(cell)+2)
+		}
+	}
+	lines := []string{}
+	for i, row := range rows {
+		line := ""
+		for j, cell := range row {
+			line += fmt.Sprintf("%-*v", widths[j], cell)
+		}
+		lines = append(lines, strings.TrimRight(line, " "))
+		if i == 0 {
+			separator := ""
+			for j := range row {
+				separator += fmt.Sprintf("%-*v", widths[j], strings.Repeat("-", widths[j]-2))
+			}
+			lines = append(lines, strings.TrimRight(separator, " "))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/status"
+)
+
+func Purge(args []string) {
+	fs := flag.NewFlagSet("clus purge options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	dry_run := fs.Bool("dry-run", false, "only list the jobs to purge without removing them")
+	_ = fs.Parse(args)
+	if len(fs.Args()) > 0 {
+		displayPurgeUsage(fs)
+		return
+	}
+	purgeJobs(*dry_run)
+}
+
+func displayPurgeUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus purge [options]
+
+  Finished jobs and their output are purged according to the retention configs of headnode,
+  which are also applied periodically on headnode.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+func purgeJobs(dry_run bool) {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+
+	// Purge jobs
+	reply, err := pb.NewHeadnodeClient(conn).PurgeJobs(context.Background(), &pb.PurgeJobsRequest{DryRun: dry_run})
+	if err != nil {
+		Fatallnf("Failed to purge jobs: %v", status.Convert(err).Message())
+	}
+
+	// Print result
+	ids := reply.GetJobIds()
+	if len(ids) == 0 {
+		Printlnf("No jobs to purge.")
+		return
+	}
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = fmt.Sprint(id)
+	}
+	purged := "Purged"
+	if dry_run {
+		purged = "To purge"
+	}
+	Printlnf("%v %v jobs (%v bytes of output): %v", purged, len(ids), reply.GetFreedBytes(), strings.Join(s, ", "))
+}
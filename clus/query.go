@@ -0,0 +1,92 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"flag"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/status"
+)
+
+func Query(args []string) {
+	fs := flag.NewFlagSet("clus query options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	_ = fs.Parse(args)
+	if len(fs.Args()) < 1 {
+		displayQueryUsage(fs)
+		return
+	}
+	job_id, err := strconv.Atoi(fs.Arg(0))
+	if err != nil || job_id <= 0 {
+		Fatallnf("Invalid job id: %q", fs.Arg(0))
+	}
+	queryResults(int32(job_id), strings.Join(fs.Args()[1:], " "))
+}
+
+func displayQueryUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus query [options] <job id> [expression]
+
+  Query the JSON results of a job run with "clus run -json" on nodes, the expression is in format:
+    [path[, path...]] [where path op value [and path op value...]]
+  in which path is like ".version" or ".disks[0].size", op is one of ==, !=, >, >=, <, <=, ~ (contains),
+  and value is a JSON literal or a word as string, e.g. '.version, .os.name where .cpus >= 4 and .os.name ~ linux'
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+func queryResults(job_id int32, expression string) {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+
+	// Query results
+	reply, err := pb.NewHeadnodeClient(conn).QueryResults(context.Background(), &pb.QueryResultsRequest{JobId: job_id, Expression: expression})
+	if err != nil {
+		Fatallnf("Failed to query results: %v", status.Convert(err).Message())
+	}
+
+	// Print table
+	columns, rows := append([]string{"Node"}, reply.GetColumns()...), reply.GetRows()
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		widths[i] = len(column)
+	}
+	for _, row := range rows {
+		widths[0] = MaxInt(widths[0], len(row.Node))
+		for i, value := range row.Values {
+			widths[i+1] = MaxInt(widths[i+1], len(value))
+		}
+	}
+	gap := 3
+	print_row := func(values []string) {
+		line := ""
+		for i, value := range values {
+			if i < len(values)-1 {
+				line += value + strings.Repeat(" ", widths[i]-len(value)+gap)
+			} else {
+				line += value
+			}
+		}
+		Printlnf("%v", line)
+	}
+	print_row(columns)
+	separators := make([]string, len(columns))
+	total_width := 0
+	for i := range columns {
+		separators[i] = strings.Repeat("-", widths[i])
+		total_width += widths[i] + gap
+	}
+	print_row(separators)
+	for _, row := range rows {
+		print_row(append([]string{row.Node}, row.Values...))
+	}
+	Printlnf(strings.Repeat("-", total_width-gap))
+	Printlnf("%v of %v nodes matched.", len(rows), reply.GetNodes())
+}
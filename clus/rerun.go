@@ -0,0 +1,72 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"flag"
+	"os"
+	"strconv"
+)
+
+func Rerun(args []string) {
+	fs := flag.NewFlagSet("clus rerun options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	failed_only := fs.Bool("failed-only", false, "rerun the job only on the nodes that failed or were unreachable in it, each of which runs the same command with the same sweep values")
+	name := fs.String("name", "", `specify the name of the new job, default is "[Rerun job <job id>] <job name>"`)
+	dump := fs.Bool("dump", false, "save the output to file")
+	dump_dir := fs.String("dump-dir", "", "save the output to files in the specified directory")
+	cache := fs.Int("cache", 1000, "specify the number of characters to cache and display for output of command on each node")
+	prompt := fs.Int("prompt", 1, "specify the number of nodes, the output of which will be displayed promptly")
+	background := fs.Bool("background", false, "run the job in background without displaying its output")
+	resilient := fs.Bool("resilient", false, "reattach to the job and continue displaying its output once the output stream is lost")
+	_ = fs.Parse(args)
+	if len(fs.Args()) != 1 {
+		displayRerunUsage(fs)
+		return
+	}
+	id, err := strconv.Atoi(fs.Arg(0))
+	if err != nil || id <= 0 {
+		Fatallnf("Invalid job id: %q", fs.Arg(0))
+	}
+
+	// The job rerun is got to display its command, the new job is created from it by the headnode
+	jobs := getJobs(&pb.GetJobsRequest{JobIds: map[int32]bool{int32(id): true}})
+	if len(jobs) == 0 {
+		Fatallnf("Job %v doesn't exist.", id)
+	}
+	job := jobs[0]
+	node_commands := job.NodeCommands
+	if *failed_only {
+		nodes := make([]string, 0, len(job.FailedNodes)+len(job.LostNodes))
+		for node := range job.FailedNodes {
+			nodes = append(nodes, node)
+		}
+		nodes = append(nodes, job.LostNodes...)
+		node_commands = getNodeCommands(job, nodes)
+	}
+	output_dir := ""
+	if len(*dump_dir) > 0 {
+		output_dir = createOutputDirAt(*dump_dir)
+	} else if *dump {
+		output_dir = createOutputDir()
+	}
+	rerun := &pb.RerunJobRequest{JobId: job.Id, FailedOnly: *failed_only, Name: *name}
+	// Only the fields displayed are set in the request, which is not sent
+	request := &pb.StartClusJobRequest{Command: job.Command, Sweep: job.Sweep, Name: *name, Arguments: job.Arguments, NodeCommands: node_commands}
+	options := runOptions{outputDir: output_dir, cacheSize: *cache, prompt: *prompt, background: *background, resilient: *resilient, rerun: rerun}
+	if exit_code := RunJob(request, options); exit_code != 0 {
+		os.Exit(int(exit_code))
+	}
+}
+
+func displayRerunUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus rerun [options] <job id>
+
+  Rerun the job as a new job, which is linked to the job in job history.
+  The new job runs on the nodes selected as the job did, or only on its failed nodes with -failed-only.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
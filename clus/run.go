@@ -1,8 +1,12 @@
 package main
 
 import (
+	"bufio"
 	pb "clusrun/protobuf"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -11,49 +15,408 @@ import (
 	"os/signal"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	_ "google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	checkpointEnv         = "CLUSRUN_CHECKPOINT_DIR"
+	cudaVisibleDevicesEnv = "CUDA_VISIBLE_DEVICES"
+	sweepSeparator        = ";"
+	sweepListSeparator    = ","
+	prefixTimeLayout      = "15:04:05.000"
+	startJobRetries       = 3
+
+	sweepValueSeparator      = "="
+	maxSweepFileSuffixLength = 64
+
+	defaultRedirectPrefix = "[{node}] "
+	federationAllPeers    = "*"
+)
+
 func Run(args []string) {
 	fs := flag.NewFlagSet("clus run options", flag.ExitOnError)
 	SetGlobalParameters(fs)
-	script := fs.String("script", "", "specify the script file containing commands to run")
+	script := fs.String("script", "", "specify the script file to run with the arguments, which is run by the shell of its extension (.cmd, .bat, .ps1 or .sh) or by its shebang line on the OS other than Windows if -shell is not specified")
 	// files := fs.String("files", "", "specify the files or directories, which will be copied to the working directory on each node")
 	dump := fs.Bool("dump", false, "save the output to file")
 	nodes := fs.String("nodes", "", "specify certain nodes to run the command")
 	nodes_in_file := fs.String("nodes-in-file", "", "specify a file containg the nodes to run the command")
 	pattern := fs.String("pattern", "", "specify nodes matching a certain regular expression pattern to run the command")
+	exclude := fs.String("exclude", "", "exclude nodes matching a certain regular expression pattern from running the command, even if they are specified by name")
 	groups := fs.String("groups", "", "specify certain node groups to run the command")
 	groups_in_file := fs.String("groups-in-file", "", "specify a file containg the node groups to run the command")
 	groups_intersect := fs.Bool("intersect", false, "specify to run the command in intersection (union if not specified) of node groups")
+	cluster := fs.String("cluster", "", "specify the cluster configured on headnode to run the command in its nodes, default is the cluster of the client if it is limited to one")
+	clusters := fs.String("clusters", "", "specify the peer headnodes federated with the headnode separated by comma to run the command on the nodes of their regions, "+federationAllPeers+" for all peers, in which the nodes are specified in format peer/node")
+	label := fs.String("label", "", `specify nodes with labels matching all the selectors separated by comma to run the command, in format "key=value", "key!=value", "key" or "!key", e.g. "os=windows,rack!=r1"`)
+	where := fs.String("where", "", `specify nodes with probe values matching all the selectors separated by comma to run the command, in format "name<op>value" (op is =, !=, >, >=, < or <=), "name" or "!name", e.g. "gpu=true,disk_free_gb>=100"`)
 	cache := fs.Int("cache", 1000, "specify the number of characters to cache and display for output of command on each node")
 	prompt := fs.Int("prompt", 1, "specify the number of nodes, the output of which will be displayed promptly")
-	sweep := fs.String("sweep", "", `perform parametric sweep by replacing specified placeholder string in the command on each node to sequence number (in specified range and step optionally) with format "placeholder[{begin[-end][:step]}]"`)
+	sweep := fs.String("sweep", "", `perform parametric sweep by replacing specified placeholder string in the command on each node to sequence number (in specified range and step optionally) with format "placeholder[{begin[-end][:step]}]", or to values in list with format "placeholder{value1,value2[,...]}" or "placeholder{@file}" (one value per line), multiple sweeps separated by ";" are combined`)
 	background := fs.Bool("background", false, "run command without printing output")
 	name := fs.String("name", "", "specify the job name")
 	powershell := fs.Bool("powershell", false, "wrap the command with PowerShell")
+	reschedule := fs.Int("reschedule", 0, "specify the max number of times to reschedule the command on lost nodes to other nodes")
+	checkpoint := fs.String("checkpoint", "", "specify the checkpoint name, the command can save checkpoint in the dir in environment variable "+checkpointEnv+", which is kept for jobs with the same checkpoint name on each node")
+	bandwidth := fs.Int("bandwidth", 0, "specify the max output bandwidth in KB per second of the job on all nodes, default 0 means unlimited")
+	working_dir := fs.String("working-dir", "", "specify the absolute path of working dir to run the command on each node, which will be created if missing")
+	run_as := fs.String("run-as", "", "specify the user to run the command as on each node, which should be allowed in clusnode configs")
+	ship_checkpoint := fs.Bool("ship-checkpoint", false, "ship the checkpoint to the node where the command is rescheduled to")
+	dispatch_order := fs.String("order", "", "specify the order to dispatch the command to nodes: alphabetical, random, group (taking nodes from each group in turn), duration (nodes with shorter last task first) or zone (one zone after another finishes), default is configured on headnode")
+	json_output := fs.Bool("json", false, "declare the output of the command on each node is a JSON document, which is parsed and stored in the job for selecting by \"clus job -select\"")
+	capture_env := fs.Bool("capture-env", false, "capture the environment variables, user, working dir and interpreter version of the command on each node into the job, shown by \"clus job\"")
+	env_mode := fs.String("env-mode", "", "specify the environment of the command on each node: inherit (the environment of clusnode), clean (a minimal one) or base (the minimal one with the base environment configured on the node), default is configured on each node")
+	output_window := fs.Int("output-window", 0, "only stream the last output in KB of the specified size on each node at each interval, the earlier output in the interval is skipped")
+	output_window_interval := fs.Int("output-window-interval", 0, "specify the interval in seconds to stream the output in window, default is 1 second")
+	batch_size := fs.Int("batch", 0, "run the command on nodes in batches of the specified size in dispatch order, each batch starts after the previous one finishes, 1 for one node after another, default 0 means all nodes at once")
+	stop_on_failures := fs.Int("stop-on-failures", 0, "stop starting the remaining batches once the specified number of nodes failed, default 0 means never stopping")
+	fail_fast := fs.String("fail-fast", "", `abort the job by cancelling the remaining nodes if more than the specified number (e.g. "3") or percent (e.g. "10%") of nodes fail`)
+	prefix := fs.String("prefix", "", `specify the template of prefix for each line of output displayed promptly, in which {node}, {stream} (stdout or stderr), {time} and {job} are replaced, e.g. "[{node}|{time}] "`)
+	prefix_dump := fs.Bool("prefix-dump", false, "also prefix each line of output dumped to files by the template of -prefix")
+	dump_dir := fs.String("dump-dir", "", "save the output of each node to files in the specified dir instead of a new dir in working dir")
+	stdout_file := fs.String("stdout", "", `write the stdout of all nodes to the specified file (or pipe) instead of the console, each line is prefixed by the template of -prefix or "[{node}] " by default, only the progress and summary are displayed on the console`)
+	stderr_file := fs.String("stderr", "", "write the stderr of all nodes to the specified file (or pipe) instead of the console, in the same way as -stdout")
+	min_free_memory := fs.Int64("min-free-memory", 0, "skip the nodes with less available memory in MB than specified, according to their latest heartbeats")
+	min_free_disk := fs.Int64("min-free-disk", 0, "skip the nodes with less free disk in MB than specified, according to their latest heartbeats")
+	max_load := fs.Float64("max-load", 0, "skip the nodes with higher CPU load than specified, according to their latest heartbeats")
+	max_running_jobs := fs.Int("max-running-jobs", 0, "skip the nodes running more jobs than specified, according to their latest heartbeats")
+	gpus := fs.Int("gpus", 0, "skip the nodes with less idle GPUs than specified according to their latest heartbeats, the specified number of idle GPUs are assigned to the command on each node by "+cudaVisibleDevicesEnv)
+	cpu_limit := fs.Int("cpu-limit", 0, "specify the max percent of all CPUs the command can use on each node, default is configured on each node")
+	memory_limit := fs.Int64("memory-limit", 0, "specify the max memory in MB the command can use on each node, default is configured on each node")
+	nice := fs.Int("nice", 0, "specify the nice level from 0 to 19 (lowest) of the command on each node to lower its CPU priority, which is mapped to the priority class on Windows")
+	io_priority := fs.String("io-priority", "", "specify the I/O priority of the command on each node lower than normal, one of: low, idle")
+	timeout := fs.Int("timeout", 0, "specify the max seconds the command can run on each node, after which it is killed by the node itself even if the headnode is unreachable, default is configured on each node")
+	retention := fs.Int("retention", 0, "specify the seconds to keep the scratch dir in environment variable CLUSRUN_SCRATCH_DIR on each node after the command ends, default is configured on each node")
+	after_job := fs.Int("after", 0, "hold the job on headnode until the specified job ends, then start it regardless of the result, e.g. to run a command after the upload job")
+	after_success := fs.Int("after-success", 0, "hold the job on headnode until the specified job ends, then start it only if the specified job finished successfully, otherwise cancel it")
+	forward_stdin := fs.Bool("stdin", false, `forward the stdin of clus to the command on each node until it ends, e.g. "cat hosts.txt | clus run -stdin xargs -n1 ping -c1", the input is slowed down to the pace of the slowest node`)
+	template := fs.String("template", "", "specify the job template saved on headnode to run, the command and default options of which are used if not specified")
+	node_commands_file := fs.String("node-commands", "", `specify a file containing a different command for each node in lines with format "<node> <command>", instead of the command for all nodes`)
+	os_commands_file := fs.String("os-commands", "", `specify a file containing a different command for each OS in lines with format "<os> <command>" (e.g. "windows dir" and "linux ls"), the nodes of other OS run the command if specified or are skipped`)
+	shell := fs.String("shell", "", "specify the shell to run the command on each node: cmd, powershell (pwsh on the OS other than Windows), pwsh, bash or sh, default is the one configured on Windows nodes (cmd by default) and bash on the others")
+	executor := fs.String("executor", "", "specify the executor to run the command on each node: exec (directly on the node), docker (in a container of the image) or ssh-localhost (in a login session of the user by ssh to the node), default is exec or docker if -image is specified")
+	image := fs.String("image", "", "specify the image of the container to run the command in on each node by the docker executor, which is pulled if missing on the node")
+	mounts := fs.String("mounts", "", `specify the dirs of each node separated by comma to mount into the container, in format "host_path:container_path[:ro]", the working dir, the scratch dir and the checkpoint dir are mounted at the same paths`)
 	// pick := fs.Int("pick", 0, "pick certain number of nodes to run, default 0 means pick all nodes")
-	// merge := fs.Bool("merge", false, "specify if merge outputs with the same content for different nodes")
+	merge := fs.Bool("merge", false, `group the nodes with identical output in the summary, e.g. "87 nodes returned: ...", instead of displaying the output of each node`)
+	request_id := fs.String("request-id", "", "specify the id of the request to start the job, with which the request retried on a network glitch, even by another run of clus, attaches to the job started by it instead of starting the command again, a random id is used if not specified")
+	dry_run := fs.Bool("dry-run", false, "only display the nodes to run the command and the command of each node after sweep parameters replaced, without running it")
+	confirm := fs.Bool("confirm", false, "display the nodes to run the command and the command of each node, and run it after confirmed")
+	resilient := fs.Bool("resilient", false, "reattach to the job and continue displaying its output once the output stream is lost, e.g. by a transient network drop, instead of failing to receive output")
+	interleave := fs.Bool("interleave", false, `display the output of all nodes promptly, each line of which is prefixed by the template of -prefix or "[{node}] " by default`)
 	_ = fs.Parse(args)
 	command := strings.Join(fs.Args(), " ")
+	node_list, group_list := ParseNodesOrGroups(*nodes, *nodes_in_file), ParseNodesOrGroups(*groups, *groups_in_file)
 	var arguments []string
-	if len(*script) > 0 {
+	var node_commands, os_commands map[string]string
+	var script_name string
+	if len(*os_commands_file) > 0 {
+		if len(*node_commands_file) > 0 || len(*script) > 0 {
+			Fatallnf("Per-OS commands can not be specified with per-node commands or script.")
+		}
+		var err error
+		if os_commands, err = parseOsCommands(ReadFile(*os_commands_file)); err != nil {
+			Fatallnf("Invalid per-OS commands file %v: %v", *os_commands_file, err)
+		}
+	}
+	if len(*template) > 0 {
+		if len(*node_commands_file) > 0 || len(*script) > 0 {
+			Fatallnf("Job template can not be specified with per-node commands or script.")
+		}
+		t := getJobTemplates([]string{*template})[0]
+		applyJobTemplate(t, &command, &node_list, &group_list, pattern, sweep, env_mode)
+		if len(*name) == 0 {
+			*name = t.Name
+		}
+	}
+	if len(*node_commands_file) > 0 {
+		if len(command) > 0 || len(*script) > 0 {
+			Fatallnf("Per-node commands can not be specified with command or script.")
+		}
+		if len(*nodes) > 0 || len(*nodes_in_file) > 0 || len(*pattern) > 0 || len(*groups) > 0 || len(*groups_in_file) > 0 || len(*label) > 0 {
+			Fatallnf("Nodes are specified by per-node commands, which can not be specified or filtered by other options.")
+		}
+		var err error
+		if node_commands, err = parseNodeCommands(ReadFile(*node_commands_file)); err != nil {
+			Fatallnf("Invalid per-node commands file %v: %v", *node_commands_file, err)
+		}
+	} else if len(*script) > 0 {
+		if *powershell {
+			Fatallnf("Script can not be wrapped with PowerShell, please specify the shell instead.")
+		}
 		command = ReadFile(*script)
 		arguments = fs.Args()
-	} else if len(command) <= 0 {
+		script_name = filepath.Base(*script)
+		if len(*shell) == 0 {
+			*shell = getScriptShell(script_name, command)
+		}
+	} else if len(command) <= 0 && len(os_commands) == 0 {
 		displayRunUsage(fs)
 		return
 	}
 	output_dir := ""
-	if *dump {
+	if len(*dump_dir) > 0 {
+		output_dir = createOutputDirAt(*dump_dir)
+	} else if *dump {
 		output_dir = createOutputDir()
 	}
-	RunJob(command, *sweep, output_dir, *pattern, *name, ParseNodesOrGroups(*groups, *groups_in_file), ParseNodesOrGroups(*nodes, *nodes_in_file), arguments, *cache, *prompt, *background, *groups_intersect, *powershell)
+	var stdout_redirect, stderr_redirect *outputRedirect
+	if len(*stdout_file) > 0 {
+		stdout_redirect = createOutputRedirect(*stdout_file, "stdout", *prefix)
+	}
+	if len(*stderr_file) > 0 {
+		if *stderr_file == *stdout_file {
+			stderr_redirect = &outputRedirect{w: stdout_redirect.w, stream: "stderr", template: stdout_redirect.template, pending: map[string]string{}}
+		} else {
+			stderr_redirect = createOutputRedirect(*stderr_file, "stderr", *prefix)
+		}
+	}
+	var requirements *pb.ResourceRequirements
+	if *min_free_memory != 0 || *min_free_disk != 0 || *max_load != 0 || *max_running_jobs != 0 || *gpus != 0 {
+		requirements = &pb.ResourceRequirements{MinFreeMemoryMb: *min_free_memory, MinFreeDiskMb: *min_free_disk, MaxCpuLoad: *max_load, MaxRunningJobs: int32(*max_running_jobs), MinIdleGpus: int32(*gpus)}
+	}
+	var limits *pb.JobLimits
+	if *cpu_limit != 0 || *memory_limit != 0 || *timeout != 0 || *retention != 0 || *nice != 0 || len(*io_priority) > 0 {
+		if *timeout < 0 {
+			Fatallnf("Timeout should not be negative.")
+		}
+		if *retention < 0 {
+			Fatallnf("Retention should not be negative.")
+		}
+		if *nice < 0 || *nice > 19 {
+			Fatallnf("Nice level should be between 0 and 19.")
+		}
+		limits = &pb.JobLimits{CpuPercent: int32(*cpu_limit), MemoryMb: *memory_limit, TimeoutSecond: int32(*timeout), RetentionSecond: int32(*retention), Nice: int32(*nice), IoPriority: *io_priority}
+	}
+	var window *pb.OutputWindow
+	if *output_window != 0 || *output_window_interval != 0 {
+		window = &pb.OutputWindow{SizeKb: int32(*output_window), IntervalSecond: int32(*output_window_interval)}
+	}
+	var rolling *pb.RollingPolicy
+	if *batch_size != 0 || *stop_on_failures != 0 {
+		rolling = &pb.RollingPolicy{BatchSize: int32(*batch_size), StopOnFailures: int32(*stop_on_failures)}
+	}
+	if *prefix_dump && len(*prefix) == 0 {
+		Fatallnf("The template of prefix is required to prefix the output dumped to files.")
+	}
+	if *interleave {
+		*prompt = math.MaxInt32
+		if len(*prefix) == 0 {
+			*prefix = defaultRedirectPrefix
+		}
+	}
+	failure_threshold, err := parseFailFast(*fail_fast)
+	if err != nil {
+		Fatallnf("Invalid fail fast %q: %v", *fail_fast, err)
+	}
+	var after *pb.JobDependency
+	if *after_job < 0 || *after_success < 0 || *after_job > 0 && *after_success > 0 {
+		Fatallnf("Invalid dependency, only one job id can be specified by -after or -after-success.")
+	} else if *after_job > 0 {
+		after = &pb.JobDependency{JobId: int32(*after_job)}
+	} else if *after_success > 0 {
+		after = &pb.JobDependency{JobId: int32(*after_success), SuccessOnly: true}
+	}
+	if *forward_stdin && *background {
+		Fatallnf("The stdin can not be forwarded to a job running in background.")
+	}
+	if *forward_stdin && *confirm {
+		Fatallnf("The stdin can not be forwarded to a job to confirm, which is read for confirmation.")
+	}
+	peers := ParseNodesOrGroups(*clusters, "")
+	if len(peers) > 0 {
+		if *resilient {
+			Fatallnf("The output of a job run across peer headnodes can not be reattached.")
+		}
+		RequireCapability("federation")
+	}
+	var job_executor *pb.JobExecutor
+	if len(*executor) > 0 || len(*image) > 0 || len(*mounts) > 0 {
+		job_executor = &pb.JobExecutor{Name: *executor, Image: *image, Mounts: ParseNodesOrGroups(*mounts, "")}
+		if len(*executor) == 0 {
+			job_executor.Name = "docker"
+		}
+		RequireCapability("containers")
+	}
+	request := &pb.StartClusJobRequest{
+		Command:          command,
+		Arguments:        arguments,
+		Sweep:            expandSweepFiles(*sweep),
+		Pattern:          *pattern,
+		Exclude:          *exclude,
+		Groups:           group_list,
+		GroupsIntersect:  *groups_intersect,
+		Nodes:            node_list,
+		Labels:           ParseNodesOrGroups(*label, ""),
+		Where:            ParseNodesOrGroups(*where, ""),
+		Shell:            *shell,
+		ScriptName:       script_name,
+		OsCommands:       os_commands,
+		Name:             *name,
+		MaxReschedules:   int32(*reschedule),
+		Checkpoint:       *checkpoint,
+		ShipCheckpoint:   *ship_checkpoint,
+		BandwidthLimitKb: int32(*bandwidth),
+		WorkingDir:       *working_dir,
+		RunAs:            *run_as,
+		NodeCommands:     node_commands,
+		JsonOutput:       *json_output,
+		DispatchOrder:    *dispatch_order,
+		Cluster:          *cluster,
+		CaptureEnv:       *capture_env,
+		Requirements:     requirements,
+		Limits:           limits,
+		EnvMode:          *env_mode,
+		OutputWindow:     window,
+		Rolling:          rolling,
+		FailFast:         failure_threshold,
+		After:            after,
+		ForwardStdin:     *forward_stdin,
+		Executor:         job_executor,
+		Peers:            peers,
+		RequestId:        *request_id,
+	}
+	options := runOptions{
+		outputDir:      output_dir,
+		cacheSize:      *cache,
+		prompt:         *prompt,
+		background:     *background,
+		powershell:     *powershell,
+		prefix:         *prefix,
+		prefixDump:     *prefix_dump,
+		merge:          *merge,
+		resilient:      *resilient,
+		dryRun:         *dry_run,
+		confirm:        *confirm,
+		stdoutRedirect: stdout_redirect,
+		stderrRedirect: stderr_redirect,
+	}
+	if exit_code := RunJob(request, options); exit_code != 0 {
+		os.Exit(int(exit_code))
+	}
+}
+
+// Parse the max failures in format "<count>" or "<percent>%"
+func parseFailFast(s string) (*pb.FailFast, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	if percent := strings.TrimSuffix(s, "%"); percent != s {
+		p, err := strconv.Atoi(percent)
+		if err != nil || p < 0 || p > 100 {
+			return nil, errors.New(T("Percent should be an integer between 0 and 100"))
+		}
+		return &pb.FailFast{MaxFailurePercent: int32(p)}, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return nil, errors.New(T("Count should be a non-negative integer"))
+	}
+	return &pb.FailFast{MaxFailures: int32(n)}, nil
+}
+
+// Parse lines in format "<node> <command>", empty lines are ignored
+func parseNodeCommands(content string) (map[string]string, error) {
+	node_commands := map[string]string{}
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); len(line) == 0 {
+			continue
+		}
+		index := strings.IndexAny(line, " \t")
+		if index < 0 {
+			return nil, fmt.Errorf(T("No command for node %v"), line)
+		}
+		node, command := line[:index], strings.TrimSpace(line[index:])
+		// The node is canonicalized by the headnode, whose case folding may be preserve, so it is sent as is
+		for n := range node_commands {
+			if strings.EqualFold(n, node) {
+				return nil, fmt.Errorf(T("Duplicate node %v"), node)
+			}
+		}
+		node_commands[node] = command
+	}
+	if len(node_commands) == 0 {
+		return nil, errors.New(T("No commands"))
+	}
+	return node_commands, nil
+}
+
+// Parse lines in format "<os> <command>", empty lines are ignored
+// The shell to run the script by its extension, the script with shebang line is left to the default one
+// so that it is run by the shebang on the OS other than Windows
+func getScriptShell(script_name, content string) string {
+	if strings.HasPrefix(content, "#!") {
+		return ""
+	}
+	switch strings.ToLower(filepath.Ext(script_name)) {
+	case ".cmd", ".bat":
+		return "cmd"
+	case ".ps1":
+		return "powershell"
+	case ".sh", ".bash":
+		return "bash"
+	}
+	return ""
+}
+
+func parseOsCommands(content string) (map[string]string, error) {
+	os_commands := map[string]string{}
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); len(line) == 0 {
+			continue
+		}
+		index := strings.IndexAny(line, " \t")
+		if index < 0 {
+			return nil, fmt.Errorf(T("No command for OS %v"), line)
+		}
+		node_os := strings.ToLower(line[:index])
+		if _, ok := os_commands[node_os]; ok {
+			return nil, fmt.Errorf(T("Duplicate OS %v"), node_os)
+		}
+		os_commands[node_os] = strings.TrimSpace(line[index:])
+	}
+	if len(os_commands) == 0 {
+		return nil, errors.New(T("No commands"))
+	}
+	return os_commands, nil
+}
+
+// Replace "{@file}" in sweeps with the list of values in file
+func expandSweepFiles(sweep string) string {
+	if len(sweep) == 0 {
+		return sweep
+	}
+	sweeps := strings.Split(sweep, sweepSeparator)
+	for i, s := range sweeps {
+		index := strings.LastIndex(s, "{@")
+		if index <= 0 || !strings.HasSuffix(s, "}") {
+			continue
+		}
+		values := []string{}
+		for _, line := range strings.Split(ReadFile(s[index+2:len(s)-1]), "\n") {
+			if line = strings.TrimSpace(line); len(line) == 0 {
+				continue
+			} else if strings.ContainsAny(line, sweepListSeparator+sweepSeparator+"{}") {
+				Fatallnf("Invalid sweep value %q in file, which should not contain %q, %q, \"{\" or \"}\"", line, sweepListSeparator, sweepSeparator)
+			}
+			values = append(values, line)
+		}
+		if len(values) == 0 {
+			Fatallnf("No sweep values in file %v", s[index+2:len(s)-1])
+		} else if len(values) == 1 {
+			values = append(values, values[0]) // a list should contain the separator
+		}
+		sweeps[i] = s[:index+1] + strings.Join(values, sweepListSeparator) + "}"
+	}
+	return strings.Join(sweeps, sweepSeparator)
 }
 
 func displayRunUsage(fs *flag.FlagSet) {
@@ -71,17 +434,68 @@ func createOutputDir() string {
 	if err != nil {
 		Fatallnf("Failed to get working dir: %v", err)
 	}
-	output_dir := filepath.Join(cur_dir, "clus.run."+time.Now().Format("20060102150405.000000000"))
+	return createOutputDirAt(filepath.Join(cur_dir, "clus.run."+time.Now().Format("20060102150405.000000000")))
+}
+
+func createOutputDirAt(output_dir string) string {
+	output_dir, err := filepath.Abs(output_dir)
+	if err != nil {
+		Fatallnf("Failed to get absolute path of output dir: %v", err)
+	}
 	if err := os.MkdirAll(output_dir, 0644); err != nil {
 		Fatallnf("Failed to create output dir: %v", err)
 	}
 	return output_dir
 }
 
-func RunJob(command, sweep, output_dir, pattern, name string, groups, nodes, arguments []string, cache_size, prompt int, background, intersect, powershell bool) {
-	dump := len(output_dir) > 0
-	if powershell {
+func createOutputRedirect(path, stream, template string) *outputRedirect {
+	f, err := os.Create(path)
+	if err != nil {
+		Fatallnf("Failed to create %v file: %v", stream, err)
+	}
+	if len(template) == 0 {
+		template = defaultRedirectPrefix
+	}
+	return &outputRedirect{w: f, stream: stream, template: template, pending: map[string]string{}}
+}
+
+// The options of running a job on the client, which are not sent to the headnode
+type runOptions struct {
+	outputDir      string
+	cacheSize      int
+	prompt         int
+	background     bool
+	powershell     bool
+	prefix         string
+	prefixDump     bool
+	merge          bool
+	resilient      bool
+	dryRun         bool
+	confirm        bool
+	stdoutRedirect *outputRedirect
+	stderrRedirect *outputRedirect
+	rerun          *pb.RerunJobRequest // the job rerun by the headnode instead of the request
+}
+
+func RunJob(request *pb.StartClusJobRequest, options runOptions) int32 {
+	cache_size, prompt, stdout_redirect, stderr_redirect := options.cacheSize, options.prompt, options.stdoutRedirect, options.stderrRedirect
+	command, node_commands := request.GetCommand(), request.GetNodeCommands()
+	dump := len(options.outputDir) > 0
+	redirect := stdout_redirect != nil || stderr_redirect != nil
+	if redirect {
+		// Only the progress and summary are displayed on console
+		prompt, cache_size = 0, 0
+		defer closeOutputRedirects(stdout_redirect, stderr_redirect)
+	}
+	if options.powershell {
 		command = fmt.Sprintf("PowerShell -ExecutionPolicy ByPass -Command \"%v\"", command)
+		request.Command = command
+		for node, c := range node_commands {
+			node_commands[node] = fmt.Sprintf("PowerShell -ExecutionPolicy ByPass -Command \"%v\"", c)
+		}
+		for node_os, c := range request.GetOsCommands() {
+			request.OsCommands[node_os] = fmt.Sprintf("PowerShell -ExecutionPolicy ByPass -Command \"%v\"", c)
+		}
 	}
 
 	// Setup connection
@@ -97,64 +511,155 @@ func RunJob(command, sweep, output_dir, pattern, name string, groups, nodes, arg
 	// 3. set ctx = context.WithTimeout(context.Background(), 10 * time.Second): out.Send() on headnode get error code = Canceled
 
 	// Start job
-	stream, err := c.StartClusJob(ctx, &pb.StartClusJobRequest{Command: command, Arguments: arguments, Sweep: sweep, Pattern: pattern, Groups: groups, GroupsIntersect: intersect, Nodes: nodes, Name: name}, grpc.UseCompressor("gzip"))
-	if err != nil {
-		Fatallnf("Failed to start job:", err)
+	if len(request.RequestId) == 0 {
+		request.RequestId = newRequestId()
+	}
+	request.Summary, request.Progress = true, true
+	if options.dryRun || options.confirm {
+		request.DryRun = true
+		_, reply, err := startClusJob(ctx, c, request)
+		if status.Code(err) == codes.Unimplemented {
+			Fatallnf("The headnode doesn't support dry run.")
+		} else if err != nil {
+			Fatallnf(status.Convert(err).Message())
+		}
+		printDryRun(reply)
+		if options.dryRun {
+			return 0
+		}
+		if !confirmRun(len(reply.GetNodes())) {
+			Printlnf("Job is not started.")
+			return 0
+		}
+		request.DryRun = false
+	}
+	var stream jobReplyStream
+	var output *pb.StartClusJobReply
+	var err error
+	if options.rerun != nil {
+		// The job is created by the headnode from the job rerun, instead of the request
+		options.rerun.RequestId, options.rerun.Progress = request.RequestId, true
+		stream, output, err = startRerunJob(ctx, c, options.rerun)
+	} else {
+		stream, output, err = startClusJob(ctx, c, request)
 	}
 	var finished_nodes, failed_nodes, all_nodes []string
+	node_results := map[string]nodeResult{}
 	var job_id int32
+	var job_summary *pb.JobSummary
+	var line_prefix outputPrefix
+	var sweep_values map[string]string
 	start_time := time.Now()
 	job_time := make([]time.Duration, 0, len(all_nodes))
-	if output, err := stream.Recv(); err != nil {
+	if err != nil {
 		Fatallnf(status.Convert(err).Message())
 	} else {
 		all_nodes = output.GetNodes()
 		job_id = output.GetJobId()
+		sweep_values = output.GetSweepValues()
+		line_prefix = outputPrefix{template: options.prefix, job: job_id}
+		for _, r := range []*outputRedirect{stdout_redirect, stderr_redirect} {
+			if r != nil {
+				r.job = job_id
+			}
+		}
 		job := fmt.Sprintf("%v", job_id)
-		if len(name) > 0 {
-			job += fmt.Sprintf(" %q", name)
+		if len(request.Name) > 0 {
+			job += fmt.Sprintf(" %q", request.Name)
+		}
+		if peer_jobs := output.GetPeerJobs(); len(peer_jobs) > 0 {
+			Printlnf("Job started on %v nodes across peer headnodes: %v.", len(all_nodes), formatPeerJobs(peer_jobs))
+		} else if request.After != nil {
+			Printlnf("Job %v will start on %v nodes in cluster %q after %v.", job, len(all_nodes), *Headnode, formatJobDependency(request.After))
+		} else {
+			Printlnf("Job %v started on %v nodes in cluster %q.", job, len(all_nodes), *Headnode)
+		}
+		peer_errors := output.GetPeerErrors()
+		peer_names := make([]string, 0, len(peer_errors))
+		for peer := range peer_errors {
+			peer_names = append(peer_names, peer)
+		}
+		sort.Strings(peer_names)
+		for _, peer := range peer_names {
+			Printlnf("[Warning] Job is not started on peer headnode %v: %v", peer, peer_errors[peer])
+		}
+		if skipped := output.GetSkippedNodes(); len(skipped) > 0 {
+			Printlnf("Skipped %v nodes not satisfying resource requirements, draining or excluded: %v", len(skipped), formatSkippedNodes(skipped))
+		}
+		for _, w := range output.GetWarnings() {
+			Printlnf("[Warning] %v (lint rule %v)", w.GetMessage(), w.GetRule())
 		}
-		Printlnf("Job %v started on %v nodes in cluster %q.", job, len(all_nodes), *Headnode)
 		if dump {
-			Printlnf("Dumping output to %v", output_dir)
-		} else if background {
-			return
+			Printlnf("Dumping output to %v", options.outputDir)
+		}
+		if stdout_redirect != nil {
+			Printlnf("Writing stdout to %v", stdout_redirect.Name())
 		}
-		if !background {
+		if stderr_redirect != nil {
+			Printlnf("Writing stderr to %v", stderr_redirect.Name())
+		}
+		if options.background && !dump && !redirect {
+			return 0
+		}
+		if !options.background {
 			Printlnf("")
-			if len(sweep) > 0 {
-				Printlnf("Sweep parameter: %v", sweep)
+			if len(request.Sweep) > 0 {
+				Printlnf("Sweep parameter: %v", request.Sweep)
 			}
-			if len(arguments) > 0 {
-				Printlnf("Arguments: %q", arguments)
+			if len(request.Arguments) > 0 {
+				Printlnf("Arguments: %q", request.Arguments)
+			}
+			if len(node_commands) > 0 {
+				Printlnf(GetPaddingLine("---Commands---"))
+				printNodeCommands(node_commands)
+			} else {
+				Printlnf(GetPaddingLine("---Command---"))
+				Printlnf(command)
 			}
-			Printlnf(GetPaddingLine("---Command---"))
-			Printlnf(command)
 			Printlnf(GetPaddingLine(""))
 			Printlnf("")
 		}
 	}
+	if request.GetForwardStdin() {
+		go forwardJobInput(ctx, c, job_id)
+	}
 
 	// Create output file
 	var f_stdout, f_stderr map[string]*os.File
+	create_output_file := func(node string) {
+		file := filepath.Join(options.outputDir, strings.NewReplacer(":", ".", "/", ".").Replace(node)+getSweepFileSuffix(sweep_values[node]))
+		stdout := file + ".out"
+		stderr := file + ".err"
+		if f_stdout[node], err = os.Create(stdout); err == nil {
+			f_stderr[node], err = os.Create(stderr)
+		}
+		if err != nil {
+			Fatallnf("Failed to create output file: %v", err)
+		}
+	}
 	if dump {
 		f_stdout = make(map[string]*os.File, len(all_nodes))
 		f_stderr = make(map[string]*os.File, len(all_nodes))
 		for _, node := range all_nodes {
-			file := filepath.Join(output_dir, strings.ReplaceAll(node, ":", "."))
-			stdout := file + ".out"
-			stderr := file + ".err"
-			if f_stdout[node], err = os.Create(stdout); err == nil {
-				f_stderr[node], err = os.Create(stderr)
-			}
-			if err != nil {
-				Fatallnf("Failed to create output file: %v", err)
-			}
-			defer f_stdout[node].Close()
-			defer f_stderr[node].Close()
+			create_output_file(node)
 		}
+		defer func() {
+			for node := range f_stdout {
+				f_stdout[node].Close()
+				f_stderr[node].Close()
+			}
+		}()
 	}
 
+	// The output received, from which the job is watched again once the stream is lost
+	var replies jobReplyStream = stream
+	close_replies := func() {}
+	defer func() { close_replies() }()
+	watch_request := &pb.WatchClusJobRequest{JobId: job_id, StdoutOffsets: map[string]int64{}, StderrOffsets: map[string]int64{}}
+
+	// The nodes whose last output dumped ends in the middle of a line
+	stdout_continued, stderr_continued := map[string]bool{}, map[string]bool{}
+
 	// Pick nodes whose output will be displayed promptly
 	if prompt < 0 {
 		prompt = 0
@@ -177,40 +682,106 @@ func RunJob(command, sweep, output_dir, pattern, name string, groups, nodes, arg
 	signal.Notify(ch, os.Interrupt)
 	go func() {
 		<-ch
-		summary(cache, finished_nodes, failed_nodes, all_nodes, cache_size, job_time)
+		summary(cache, finished_nodes, failed_nodes, all_nodes, cache_size, job_time, options.merge, nil)
 		if len(all_nodes) > len(finished_nodes) {
 			Printlnf("Job %v is still running.", job_id)
 		}
+		closeOutputRedirects(stdout_redirect, stderr_redirect)
 		os.Exit(0)
 	}()
 
 	// Receive output
 	for {
-		output, err := stream.Recv()
+		output, err := replies.Recv()
 		if err == io.EOF {
 			break
 		}
-		if err != nil {
+		if status.Code(err) == codes.Aborted {
+			// The job is not started since its dependency is not satisfied
+			Fatallnf(status.Convert(err).Message())
+		}
+		if err != nil && options.resilient {
+			Printlnf("[Warning] Output stream of job %v is lost, reattaching: %v", job_id, status.Convert(err).Message())
+			close_replies()
+			replies, close_replies = reattachJob(watch_request)
+			Printlnf("Reattached to job %v.", job_id)
+		} else if err != nil {
 			Printlnf("Failed to receive output.")
 			time.Sleep(time.Second)
+		} else if s := output.GetSummary(); s != nil {
+			// The summary is sent once the tasks on all nodes end
+			job_summary = s
+		} else if p := output.GetProgress(); p != nil {
+			if !options.background {
+				Printlnf("%v", formatJobProgress(p, ConsoleWidth))
+			}
 		} else {
 			node := output.GetNode()
-			stdout, stderr := output.GetStdout(), output.GetStderr()
+			stdout, stderr := string(output.GetStdout()), string(output.GetStderr())
+			watch_request.StdoutOffsets[node] += int64(len(stdout))
+			watch_request.StderrOffsets[node] += int64(len(stderr))
+
+			// The task on a lost node is rescheduled to another node
+			if to_node := output.GetRescheduledTo(); len(to_node) > 0 {
+				watch_request.EndedNodes = append(watch_request.EndedNodes, node)
+				for i := range all_nodes {
+					if all_nodes[i] == node {
+						all_nodes[i] = to_node
+					}
+				}
+				if _, ok := prompt_nodes[node]; ok {
+					prompt_nodes[to_node] = true
+				}
+				if _, ok := cache[node]; ok {
+					cache[to_node] = nil
+				}
+				if values, ok := sweep_values[node]; ok {
+					sweep_values[to_node] = values
+				}
+				if dump {
+					create_output_file(to_node)
+				}
+				if !options.background {
+					Printlnf("Command on lost node %v is rescheduled to node %v.", node, to_node)
+				}
+				continue
+			}
 			content := stdout + stderr
+			if len(content) == 0 {
+				watch_request.EndedNodes = append(watch_request.EndedNodes, node)
+			}
 
-			if !background {
+			if !options.background {
 				// End of output of a node
 				if len(content) == 0 {
 					state := "finished"
 					finished_nodes = append(finished_nodes, node)
 					exit_code := output.GetExitCode()
-					if exit_code != 0 {
+					result := nodeResult{state: state, exitCode: exit_code}
+					if output.GetTimedOut() {
+						state = fmt.Sprintf("timed out with exit code %v", exit_code)
+						result.state = "timed out"
+						failed_nodes = append(failed_nodes, node)
+					} else if exit_code != 0 {
 						state = fmt.Sprintf("failed with exit code %v", exit_code)
+						result.state = "failed"
 						failed_nodes = append(failed_nodes, node)
 					}
+					if err := flushOutputRedirects(node, stdout_redirect, stderr_redirect); err != nil {
+						Fatallnf("Failed to write redirected output: %v", err)
+					}
 					duration := time.Since(start_time)
 					job_time = append(job_time, duration)
-					Printlnf("[%v/%v] Command %v on node %v in %v.", len(finished_nodes), len(all_nodes), state, node, duration)
+					result.duration = duration
+					node_results[node] = result
+					if exit_code != 0 || output.GetTimedOut() {
+						state = Colorize(state, colorRed)
+					}
+					if IsPlain() {
+						Printlnf("Command %v on node %v in %v.", state, node, duration)
+					} else {
+						Printlnf("[%v/%v] Command %v on node %v in %v.", len(finished_nodes), len(all_nodes), state, node, duration)
+					}
 				} else {
 					// Cache output for summary
 					if cache_size > 0 {
@@ -225,13 +796,40 @@ func RunJob(command, sweep, output_dir, pattern, name string, groups, nodes, arg
 					// Print output promptly
 					content = strings.TrimSpace(content)
 					if _, ok := prompt_nodes[node]; ok && len(content) > 0 {
-						Printlnf("[%v]: %v", node, content)
+						if len(options.prefix) > 0 {
+							now := DisplayTime(time.Now())
+							for _, o := range []struct{ output, stream string }{{stdout, "stdout"}, {stderr, "stderr"}} {
+								if o.output = strings.TrimSpace(o.output); len(o.output) > 0 {
+									lines, _ := line_prefix.Apply(o.output, node, o.stream, now, false)
+									Printlnf("%v", lines)
+								}
+							}
+						} else if IsPlain() {
+							Printlnf("%v", content)
+						} else {
+							Printlnf("[%v]: %v", node, content)
+						}
 					}
 				}
 			}
 
+			// Write output to the redirected destinations
+			if redirect {
+				if err := stdout_redirect.Write(node, stdout); err != nil {
+					Fatallnf("Failed to write redirected stdout: %v", err)
+				}
+				if err := stderr_redirect.Write(node, stderr); err != nil {
+					Fatallnf("Failed to write redirected stderr: %v", err)
+				}
+			}
+
 			// Save output to file
 			if dump {
+				if options.prefixDump {
+					now := DisplayTime(time.Now())
+					stdout, stdout_continued[node] = line_prefix.Apply(stdout, node, "stdout", now, stdout_continued[node])
+					stderr, stderr_continued[node] = line_prefix.Apply(stderr, node, "stderr", now, stderr_continued[node])
+				}
 				if _, err = f_stdout[node].WriteString(stdout); err == nil {
 					_, err = f_stderr[node].WriteString(stderr)
 				}
@@ -241,32 +839,236 @@ func RunJob(command, sweep, output_dir, pattern, name string, groups, nodes, arg
 			}
 		}
 	}
-	if !background {
-		summary(cache, finished_nodes, failed_nodes, all_nodes, cache_size, job_time)
+	if !options.background {
+		summary(cache, finished_nodes, failed_nodes, all_nodes, cache_size, job_time, options.merge, job_summary)
+		Printlnf("")
+		for _, line := range formatNodeResults(all_nodes, node_results) {
+			Printlnf("%v", line)
+		}
+		if len(failed_nodes) > 0 {
+			printJobFailureAnalysis(job_id)
+		}
 	}
 	if dump {
-		Printlnf("Output is dumped to %v", output_dir)
+		Printlnf("Output is dumped to %v", options.outputDir)
+	}
+	return job_summary.GetExitCode()
+}
+
+// Start the job and get the first reply, the request is retried on transient errors, with which the headnode attaches to
+// the job if it is started by the request before
+func startClusJob(ctx context.Context, c pb.HeadnodeClient, request *pb.StartClusJobRequest) (pb.Headnode_StartClusJobClient, *pb.StartClusJobReply, error) {
+	for i := 0; ; i++ {
+		stream, err := c.StartClusJob(ctx, request, grpc.UseCompressor("gzip"))
+		var reply *pb.StartClusJobReply
+		if err == nil {
+			reply, err = stream.Recv()
+		}
+		if status.Code(err) != codes.Unavailable || i >= startJobRetries {
+			return stream, reply, err
+		}
+		Printlnf("[Warning] Failed to start job, retrying: %v", status.Convert(err).Message())
+		time.Sleep(watchReconnectInterval)
+	}
+}
+
+// Rerun the job and get the first reply, the request is retried on transient errors as startClusJob
+func startRerunJob(ctx context.Context, c pb.HeadnodeClient, request *pb.RerunJobRequest) (pb.Headnode_RerunJobClient, *pb.StartClusJobReply, error) {
+	for i := 0; ; i++ {
+		stream, err := c.RerunJob(ctx, request, grpc.UseCompressor("gzip"))
+		var reply *pb.StartClusJobReply
+		if err == nil {
+			reply, err = stream.Recv()
+		}
+		if status.Code(err) == codes.Unimplemented {
+			Fatallnf("The headnode doesn't support rerunning jobs.")
+		}
+		if status.Code(err) != codes.Unavailable || i >= startJobRetries {
+			return stream, reply, err
+		}
+		Printlnf("[Warning] Failed to start job, retrying: %v", status.Convert(err).Message())
+		time.Sleep(watchReconnectInterval)
 	}
 }
 
-func summary(cache map[string][]rune, finished_nodes, failed_nodes, all_nodes []string, cache_size int, job_time []time.Duration) {
+// The random id of a request to start job
+func newRequestId() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
+// The stream of replies of StartClusJob or WatchClusJob
+type jobReplyStream interface {
+	Recv() (*pb.StartClusJobReply, error)
+}
+
+// Watch the job again from the output received after the stream is lost, until it succeeds or the error is not transient
+func reattachJob(request *pb.WatchClusJobRequest) (jobReplyStream, func()) {
+	for {
+		time.Sleep(watchReconnectInterval)
+		conn, cancel_dial, err := dialHeadnode(getHeadnodeCredentials())
+		if err != nil {
+			Printlnf("[Warning] Failed to reconnect the headnode, retrying: %v", err)
+			continue
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		close_stream := func() {
+			cancel()
+			conn.Close()
+			cancel_dial()
+		}
+		stream, err := pb.NewHeadnodeClient(conn).WatchClusJob(ctx, request)
+		if err == nil {
+			// The nodes of the job are known
+			_, err = stream.Recv()
+		}
+		if err == nil {
+			return stream, close_stream
+		}
+		close_stream()
+		switch status.Code(err) {
+		case codes.Unimplemented:
+			Fatallnf("The headnode doesn't support watching jobs.")
+		case codes.NotFound, codes.Unauthenticated, codes.PermissionDenied:
+			Fatallnf("Failed to reattach to job %v: %v", request.JobId, status.Convert(err).Message())
+		}
+		Printlnf("[Warning] Failed to reattach to job %v, retrying: %v", request.JobId, status.Convert(err).Message())
+	}
+}
+
+// The prefix of each line of output
+type outputPrefix struct {
+	template string
+	job      int32
+}
+
+func (p outputPrefix) Format(node, stream string, t time.Time) string {
+	return strings.NewReplacer("{node}", node, "{stream}", stream, "{time}", t.Format(prefixTimeLayout), "{job}", fmt.Sprint(p.job)).Replace(p.template)
+}
+
+// Prefix each line of the output, and get whether it ends in the middle of a line.
+// The first line is not prefixed if it continues the last output in the middle of a line.
+func (p outputPrefix) Apply(output, node, stream string, t time.Time, continued bool) (string, bool) {
+	if len(output) == 0 {
+		return output, continued
+	}
+	prefix := p.Format(node, stream, t)
+	lines := strings.SplitAfter(output, "\n")
+	var b strings.Builder
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		if i > 0 || !continued {
+			b.WriteString(prefix)
+		}
+		b.WriteString(line)
+	}
+	return b.String(), !strings.HasSuffix(output, "\n")
+}
+
+// The aggregated output of all nodes in a stream redirected to a file or pipe.
+// The incomplete last line of each node is held until it completes or the node finishes,
+// so that lines of different nodes are not mixed.
+type outputRedirect struct {
+	w        io.Writer
+	stream   string
+	template string
+	job      int32
+	pending  map[string]string
+}
+
+func (r *outputRedirect) Name() string {
+	if f, ok := r.w.(*os.File); ok {
+		return f.Name()
+	}
+	return r.stream
+}
+
+func (r *outputRedirect) Write(node, output string) error {
+	if r == nil || len(output) == 0 {
+		return nil
+	}
+	output = r.pending[node] + output
+	index := strings.LastIndex(output, "\n")
+	r.pending[node] = output[index+1:]
+	return r.write(node, output[:index+1])
+}
+
+// Write the incomplete last line of the node
+func (r *outputRedirect) Flush(node string) error {
+	if r == nil {
+		return nil
+	}
+	output := r.pending[node]
+	delete(r.pending, node)
+	if len(output) == 0 {
+		return nil
+	}
+	return r.write(node, output+LineEnding)
+}
+
+func (r *outputRedirect) write(node, output string) error {
+	if len(output) == 0 {
+		return nil
+	}
+	lines, _ := outputPrefix{template: r.template, job: r.job}.Apply(output, node, r.stream, DisplayTime(time.Now()), false)
+	_, err := io.WriteString(r.w, lines)
+	return err
+}
+
+func flushOutputRedirects(node string, redirects ...*outputRedirect) error {
+	for _, r := range redirects {
+		if err := r.Flush(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func closeOutputRedirects(redirects ...*outputRedirect) {
+	for _, r := range redirects {
+		if r == nil {
+			continue
+		}
+		for node := range r.pending {
+			if err := r.Flush(node); err != nil {
+				Printlnf("Failed to write redirected %v: %v", r.stream, err)
+			}
+		}
+		if c, ok := r.w.(io.Closer); ok {
+			c.Close()
+		}
+	}
+}
+
+func summary(cache map[string][]rune, finished_nodes, failed_nodes, all_nodes []string, cache_size int, job_time []time.Duration, merge bool, job_summary *pb.JobSummary) {
 	if cache_size > 0 {
 		Printlnf("")
-		nodes := make([]string, 0, len(cache))
-		for node := range cache {
-			nodes = append(nodes, node)
-		}
-		sort.Strings(nodes)
-		for _, node := range nodes {
-			output := cache[node]
-			heading := fmt.Sprintf("---[%v]---", node)
-			Printlnf(GetPaddingLine(heading))
-			if over_size := len(output) - cache_size; over_size > 0 {
-				output = output[over_size:]
-				Printlnf("(Truncated)")
-				fmt.Print("...")
+		if merge {
+			for _, group := range groupNodesByOutput(cache) {
+				heading := fmt.Sprintf(T("---%v nodes returned---"), len(group.nodes))
+				if len(group.nodes) == 1 {
+					heading = T("---1 node returned---")
+				}
+				Printlnf(GetPaddingLine(heading))
+				Printlnf("[%v]", strings.Join(group.nodes, ", "))
+				printCachedOutput(group.output, cache_size)
+			}
+		} else {
+			nodes := make([]string, 0, len(cache))
+			for node := range cache {
+				nodes = append(nodes, node)
+			}
+			sort.Strings(nodes)
+			for _, node := range nodes {
+				heading := fmt.Sprintf("---[%v]---", node)
+				Printlnf(GetPaddingLine(heading))
+				printCachedOutput(cache[node], cache_size)
 			}
-			Printlnf(string(output))
 		}
 	}
 	min, max, mean, mid, std_dev := getTimeStat(job_time)
@@ -278,13 +1080,62 @@ func summary(cache map[string][]rune, finished_nodes, failed_nodes, all_nodes []
 		Printlnf(strings.NewReplacer(" ", LineEnding, ",", "").Replace(runtime))
 		Printlnf("")
 	}
-	Printlnf("%v of %v nodes succeeded.", len(finished_nodes)-len(failed_nodes), len(all_nodes))
+	if job_summary != nil {
+		// The summary of headnode counts the unreachable nodes apart from the failed ones
+		Printlnf("%v of %v nodes succeeded, %v failed, %v unreachable.", job_summary.SucceededNodes, job_summary.Nodes, job_summary.FailedNodes, job_summary.UnreachableNodes)
+		if len(job_summary.SlowestNode) > 0 {
+			Printlnf("Slowest node: %v in %v", job_summary.SlowestNode, time.Duration(job_summary.SlowestDuration))
+		}
+		Printlnf("Wall time: %v, exit code: %v", time.Duration(job_summary.WallTime), job_summary.ExitCode)
+	} else {
+		Printlnf("%v of %v nodes succeeded.", len(finished_nodes)-len(failed_nodes), len(all_nodes))
+	}
 	if len(failed_nodes) > 0 {
 		sort.Strings(failed_nodes)
-		Printlnf("Failed nodes (%v/%v): %v", len(failed_nodes), len(all_nodes), strings.Join(failed_nodes, ", "))
+		Printlnf("%v (%v/%v): %v", Colorize("Failed nodes", colorRed), len(failed_nodes), len(all_nodes), strings.Join(failed_nodes, ", "))
 	}
 }
 
+func printCachedOutput(output []rune, cache_size int) {
+	if over_size := len(output) - cache_size; over_size > 0 {
+		output = output[over_size:]
+		Printlnf("(Truncated)")
+		fmt.Print("...")
+	}
+	Printlnf(string(output))
+}
+
+// The nodes with identical cached output
+type outputGroup struct {
+	nodes  []string
+	output []rune
+}
+
+// Group the nodes by their cached output, the groups with more nodes first
+func groupNodesByOutput(cache map[string][]rune) []outputGroup {
+	indexes := map[string]int{}
+	groups := []outputGroup{}
+	for node, output := range cache {
+		key := string(output)
+		if i, ok := indexes[key]; ok {
+			groups[i].nodes = append(groups[i].nodes, node)
+		} else {
+			indexes[key] = len(groups)
+			groups = append(groups, outputGroup{nodes: []string{node}, output: output})
+		}
+	}
+	for _, group := range groups {
+		sort.Strings(group.nodes)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i].nodes) != len(groups[j].nodes) {
+			return len(groups[i].nodes) > len(groups[j].nodes)
+		}
+		return groups[i].nodes[0] < groups[j].nodes[0]
+	})
+	return groups
+}
+
 func getTimeStat(data []time.Duration) (min, max, mean, mid, std_dev time.Duration) {
 	n := len(data)
 	if n == 0 {
@@ -308,3 +1159,71 @@ func getTimeStat(data []time.Duration) (min, max, mean, mid, std_dev time.Durati
 	std_dev = time.Duration(math.Sqrt(sum_squares / float64(n)))
 	return
 }
+
+// Display the nodes and their commands resolved by the headnode in dry run
+func printDryRun(reply *pb.StartClusJobReply) {
+	nodes := reply.GetNodes()
+	Printlnf("The job would start on %v nodes in cluster %q.", len(nodes), *Headnode)
+	if skipped := reply.GetSkippedNodes(); len(skipped) > 0 {
+		Printlnf("Skipped %v nodes not satisfying resource requirements, draining or excluded: %v", len(skipped), formatSkippedNodes(skipped))
+	}
+	for _, w := range reply.GetWarnings() {
+		Printlnf("[Warning] %v (lint rule %v)", w.GetMessage(), w.GetRule())
+	}
+	Printlnf(GetPaddingLine("---Commands---"))
+	printNodeCommands(reply.GetNodeCommands())
+	Printlnf(GetPaddingLine(""))
+}
+
+// Ask for confirmation to run the job on the nodes, which is denied unless "y" or "yes" is input
+func confirmRun(nodes int) bool {
+	fmt.Fprintf(os.Stderr, T("Run the command on %v nodes? [y/N] "), nodes)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// The suffix of the output file names of a node with the sweep values, e.g. ".a_3" for "{x}=a;{n}=3", in the same way of
+// the output files stored on headnode
+func getSweepFileSuffix(values string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	parts := []string{}
+	for _, s := range strings.Split(values, sweepSeparator) {
+		if i := strings.LastIndex(s, sweepValueSeparator); i >= 0 {
+			s = s[i+1:]
+		}
+		parts = append(parts, s)
+	}
+	suffix := []rune(strings.Join(parts, "_"))
+	for i, c := range suffix {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '-' && c != '.' && c != '_' {
+			suffix[i] = '_'
+		}
+	}
+	if len(suffix) > maxSweepFileSuffixLength {
+		suffix = suffix[:maxSweepFileSuffixLength]
+	}
+	return "." + string(suffix)
+}
+
+func printNodeCommands(node_commands map[string]string) {
+	nodes := make([]string, 0, len(node_commands))
+	for node := range node_commands {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		Printlnf("[%v]: %v", node, node_commands[node])
+	}
+}
+
+func formatSkippedNodes(skipped map[string]string) string {
+	nodes := make([]string, 0, len(skipped))
+	for node, reason := range skipped {
+		nodes = append(nodes, fmt.Sprintf("%v (%v)", node, reason))
+	}
+	sort.Strings(nodes)
+	return strings.Join(nodes, ", ")
+}
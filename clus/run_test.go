@@ -0,0 +1,241 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseNodeCommands(t *testing.T) {
+	cases := []struct {
+		content  string
+		expected map[string]string
+		valid    bool
+	}{
+		{"node1 echo 1\nnode2\techo  2 \n\n", map[string]string{"node1": "echo 1", "node2": "echo  2"}, true},
+		{"  node1   hostname  \r\n", map[string]string{"node1": "hostname"}, true},
+		{"node1 echo 1\nNODE1 echo 2", nil, false},
+		{"node1", nil, false},
+		{"\n\n", nil, false},
+	}
+
+	for _, c := range cases {
+		if r, err := parseNodeCommands(c.content); (err == nil) != c.valid || !reflect.DeepEqual(r, c.expected) {
+			t.Errorf("\ncontent=%q\nexpected=%v\n  actual=%v\n   error=%v", c.content, c.expected, r, err)
+		}
+	}
+}
+
+func Test_parseOsCommands(t *testing.T) {
+	cases := []struct {
+		content  string
+		expected map[string]string
+		valid    bool
+	}{
+		{"windows dir /b\nLinux\tls -1 \n\n", map[string]string{"windows": "dir /b", "linux": "ls -1"}, true},
+		{"linux ls\nLINUX ls -l", nil, false},
+		{"windows", nil, false},
+		{"\n", nil, false},
+	}
+
+	for _, c := range cases {
+		if r, err := parseOsCommands(c.content); (err == nil) != c.valid || !reflect.DeepEqual(r, c.expected) {
+			t.Errorf("\ncontent=%q\nexpected=%v\n  actual=%v\n   error=%v", c.content, c.expected, r, err)
+		}
+	}
+}
+
+func Test_parseFailFast(t *testing.T) {
+	cases := []struct {
+		str      string
+		expected *pb.FailFast
+		valid    bool
+	}{
+		{"", nil, true},
+		{"0", &pb.FailFast{MaxFailures: 0}, true},
+		{"3", &pb.FailFast{MaxFailures: 3}, true},
+		{"10%", &pb.FailFast{MaxFailurePercent: 10}, true},
+		{"-1", nil, false},
+		{"101%", nil, false},
+		{"a%", nil, false},
+		{"3%%", nil, false},
+	}
+
+	for _, c := range cases {
+		if r, err := parseFailFast(c.str); (err == nil) != c.valid || r.String() != c.expected.String() {
+			t.Errorf("\nstr=%q\nexpected=%v\n  actual=%v\n   error=%v", c.str, c.expected, r, err)
+		}
+	}
+}
+
+func Test_outputPrefix(t *testing.T) {
+	now := time.Date(2020, 5, 1, 8, 30, 15, 123456789, time.Local)
+	cases := []struct {
+		template          string
+		output            string
+		continued         bool
+		expected          string
+		expectedContinued bool
+	}{
+		{"[{node}] ", "", false, "", false},
+		{"[{node}] ", "", true, "", true},
+		{"[{node}] ", "line1\nline2\n", false, "[NODE1] line1\n[NODE1] line2\n", false},
+		{"[{node}] ", "line1\nline2", false, "[NODE1] line1\n[NODE1] line2", true},
+		{"[{node}] ", "end\nline2", true, "end\n[NODE1] line2", true},
+		{"[{node}] ", "\n\n", false, "[NODE1] \n[NODE1] \n", false},
+		{"[{node}|{time}] ", "line1\r\n", false, "[NODE1|08:30:15.123] line1\r\n", false},
+		{"{job}:{node}:{stream}: ", "line1", false, "7:NODE1:stderr: line1", true},
+	}
+
+	for _, c := range cases {
+		p := outputPrefix{template: c.template, job: 7}
+		if actual, continued := p.Apply(c.output, "NODE1", "stderr", now, c.continued); actual != c.expected || continued != c.expectedContinued {
+			t.Errorf("\ntemplate=%q, output=%q, continued=%v\nexpected=%q, %v\n  actual=%q, %v", c.template, c.output, c.continued, c.expected, c.expectedContinued, actual, continued)
+		}
+	}
+}
+
+func Test_applyJobTemplate(t *testing.T) {
+	template := &pb.JobTemplate{Name: "disk-check", Command: "df -h", Nodes: []string{"NODE1"}, Pattern: "node.*", Sweep: "*{1-3}", EnvMode: "clean"}
+	cases := []struct {
+		command, pattern, sweep, envMode string
+		nodes, groups                    []string
+		expectedCommand, expectedPattern string
+		expectedSweep, expectedEnvMode   string
+		expectedNodes, expectedGroups    []string
+	}{
+		{"", "", "", "", nil, nil, "df -h", "node.*", "*{1-3}", "clean", []string{"NODE1"}, nil},
+		{"du -sh", "", "#{1,2}", "inherit", nil, nil, "du -sh", "node.*", "#{1,2}", "inherit", []string{"NODE1"}, nil},
+		{"", "", "", "", nil, []string{"group1"}, "df -h", "", "*{1-3}", "clean", nil, []string{"group1"}},
+		{"", "web.*", "", "", nil, nil, "df -h", "web.*", "*{1-3}", "clean", nil, nil},
+	}
+
+	for _, c := range cases {
+		command, pattern, sweep, env_mode, nodes, groups := c.command, c.pattern, c.sweep, c.envMode, c.nodes, c.groups
+		applyJobTemplate(template, &command, &nodes, &groups, &pattern, &sweep, &env_mode)
+		if command != c.expectedCommand || pattern != c.expectedPattern || sweep != c.expectedSweep || env_mode != c.expectedEnvMode || !reflect.DeepEqual(nodes, c.expectedNodes) || !reflect.DeepEqual(groups, c.expectedGroups) {
+			t.Errorf("\ncase=%+v\nactual=%q, %q, %q, %q, %v, %v", c, command, pattern, sweep, env_mode, nodes, groups)
+		}
+	}
+}
+
+func Test_outputRedirect(t *testing.T) {
+	var b strings.Builder
+	r := &outputRedirect{w: &b, stream: "stdout", template: defaultRedirectPrefix, job: 7, pending: map[string]string{}}
+	writes := []struct {
+		node, output string
+	}{
+		{"NODE1", "line1\nli"},
+		{"NODE2", "line1\n"},
+		{"NODE1", "ne2\n"},
+		{"NODE2", "partial"},
+		{"NODE1", "end"},
+	}
+	for _, w := range writes {
+		if err := r.Write(w.node, w.output); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Flush("NODE2"); err != nil {
+		t.Fatal(err)
+	}
+	expected := "[NODE1] line1\n[NODE2] line1\n[NODE1] line2\n[NODE2] partial" + LineEnding
+	if b.String() != expected {
+		t.Errorf("\nexpected=%q\n  actual=%q", expected, b.String())
+	}
+	if r.pending["NODE1"] != "end" {
+		t.Errorf("Unexpected pending output of NODE1: %q", r.pending["NODE1"])
+	}
+	var nilRedirect *outputRedirect
+	if err := nilRedirect.Write("NODE1", "line1\n"); err != nil {
+		t.Errorf("Unexpected error of nil redirect: %v", err)
+	}
+}
+
+func Test_groupNodesByOutput(t *testing.T) {
+	cache := map[string][]rune{
+		"node3": []rune("ok\n"),
+		"node1": []rune("ok\n"),
+		"node4": []rune("error\n"),
+		"node2": []rune("ok\n"),
+		"node5": nil,
+		"node0": []rune("error\n"),
+	}
+	expected := []outputGroup{
+		{[]string{"node1", "node2", "node3"}, []rune("ok\n")},
+		{[]string{"node0", "node4"}, []rune("error\n")},
+		{[]string{"node5"}, nil},
+	}
+	if groups := groupNodesByOutput(cache); !reflect.DeepEqual(groups, expected) {
+		t.Errorf("Expected %v, got %v", expected, groups)
+	}
+	if groups := groupNodesByOutput(map[string][]rune{}); len(groups) != 0 {
+		t.Errorf("Expected no groups, got %v", groups)
+	}
+}
+
+func Test_getScriptShell(t *testing.T) {
+	cases := []struct {
+		script, content, shell string
+	}{
+		{"setup.cmd", "echo %PATH%", "cmd"},
+		{"SETUP.BAT", "echo %PATH%", "cmd"},
+		{"setup.ps1", "Get-Process", "powershell"},
+		{"setup.sh", "echo $PATH", "bash"},
+		{"setup.sh", "#!/bin/sh\necho $PATH", ""},
+		{"setup.py", "#!/usr/bin/env python3\nprint(1)", ""},
+		{"setup", "echo $PATH", ""},
+	}
+	for _, c := range cases {
+		if shell := getScriptShell(c.script, c.content); shell != c.shell {
+			t.Errorf("Expected shell %q of script %v, got %q", c.shell, c.script, shell)
+		}
+	}
+}
+
+func Test_getSweepFileSuffix(t *testing.T) {
+	cases := map[string]string{
+		"":              "",
+		"{x}=a":         ".a",
+		"{x}=a;{n}=3":   ".a_3",
+		"n=v 1;m=x/y:z": ".v_1_x_y_z",
+		"p=é-1":         ".é-1",
+	}
+	for values, expected := range cases {
+		if suffix := getSweepFileSuffix(values); suffix != expected {
+			t.Errorf("Expected suffix %q of %q, got %q", expected, values, suffix)
+		}
+	}
+}
+
+func Test_formatJobProgress(t *testing.T) {
+	progress := &pb.JobProgress{Nodes: 4, FinishedNodes: 1, FailedNodes: 1, RunningNodes: 2}
+	if s := formatJobProgress(progress, 100); s != "[##########------------------------------]  25% 1/4 nodes finished, 1 failed, 2 running" {
+		t.Errorf("Unexpected progress bar: %q", s)
+	}
+	if s := formatJobProgress(progress, 40); s != " 25% 1/4 nodes finished, 1 failed, 2 running" {
+		t.Errorf("Expected progress without bar in narrow console, got %q", s)
+	}
+	if s := formatJobProgress(&pb.JobProgress{}, 0); s != "100% 0/0 nodes finished, 0 failed, 0 running" {
+		t.Errorf("Unexpected progress of job without nodes: %q", s)
+	}
+}
+
+func Test_formatNodeResults(t *testing.T) {
+	results := map[string]nodeResult{
+		"node1": {state: "finished", duration: 1500 * time.Millisecond},
+		"node2": {state: "failed", exitCode: 2, duration: 2 * time.Second},
+	}
+	expected := []string{
+		"Node   State       Exit Code  Duration",
+		"-----  ----------  ---------  --------",
+		"node1  finished    0          1.5s",
+		"node2  failed      2          2s",
+		"node3  unfinished  -          -",
+	}
+	if lines := formatNodeResults([]string{"node1", "node2", "node3"}, results); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Unexpected table of node results:\n%v", strings.Join(lines, "\n"))
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Schedule(args []string) {
+	fs := flag.NewFlagSet("clus schedule options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	name := fs.String("name", "", "specify the name of the jobs started by the schedule to save")
+	nodes := fs.String("nodes", "", "specify the nodes to run the command of the schedule to save")
+	pattern := fs.String("pattern", "", "specify the pattern of nodes to run the command of the schedule to save")
+	groups := fs.String("groups", "", "specify the node groups to run the command of the schedule to save")
+	intersect := fs.Bool("intersect", false, "specify to run the command on the intersection of node groups instead of the union")
+	sweep := fs.String("sweep", "", "specify the parametric sweep of the schedule to save, in the format of \"clus run -sweep\"")
+	env_mode := fs.String("env-mode", "", "specify the environment (inherit, clean or base) of the schedule to save")
+	template := fs.String("template", "", "specify the job template to fill the command and options not specified of the schedule to save")
+	disabled := fs.Bool("disabled", false, "specify to save the schedule disabled")
+	_ = fs.Parse(args)
+	if len(fs.Args()) == 0 {
+		printJobSchedules(nil)
+		return
+	}
+	switch strings.ToLower(fs.Arg(0)) {
+	case "save":
+		if len(fs.Args()) < 3 || len(fs.Args()) == 3 && len(*template) == 0 {
+			displayScheduleUsage(fs)
+			return
+		}
+		command := strings.Join(fs.Args()[3:], " ")
+		node_list, group_list := ParseNodesOrGroups(*nodes, ""), ParseNodesOrGroups(*groups, "")
+		if len(*template) > 0 {
+			applyJobTemplate(getJobTemplates([]string{*template})[0], &command, &node_list, &group_list, pattern, sweep, env_mode)
+			if len(*name) == 0 {
+				*name = *template
+			}
+		}
+		saveJobSchedule(&pb.JobSchedule{
+			Name:     fs.Arg(1),
+			Cron:     fs.Arg(2),
+			Disabled: *disabled,
+			Job: &pb.StartClusJobRequest{
+				Command:         command,
+				Nodes:           node_list,
+				Pattern:         *pattern,
+				Groups:          group_list,
+				GroupsIntersect: *intersect,
+				Sweep:           *sweep,
+				Name:            *name,
+				EnvMode:         *env_mode,
+			},
+		})
+	case "enable", "disable":
+		if len(fs.Args()) < 2 {
+			displayScheduleUsage(fs)
+			return
+		}
+		setJobSchedulesEnabled(fs.Args()[1:], strings.ToLower(fs.Arg(0)) == "enable")
+	case "delete":
+		if len(fs.Args()) < 2 {
+			displayScheduleUsage(fs)
+			return
+		}
+		deleteJobSchedules(fs.Args()[1:])
+	default:
+		printJobSchedules(fs.Args())
+	}
+}
+
+func displayScheduleUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus schedule [names]
+  clus schedule [options] save <name> <cron> [command]
+  clus schedule enable|disable|delete <names>
+
+  The headnode starts the job of a schedule on nodes when the time matches the cron expression,
+  and the jobs are listed by "clus job" as other jobs. The command can be omitted if -template is specified.
+
+  The cron expression has 5 fields: minute, hour, day of month, month and day of week,
+  in which each field can be "*", a number, a range "n-m" or a list "a,b,c" with optional step "/s",
+  e.g. "*/15 * * * *" or "0 2 * * 1-5". The aliases "@hourly", "@daily", "@weekly", "@monthly",
+  "@yearly" and "@every <duration>" (e.g. "@every 1h30m") are also supported.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+func connectJobSchedules() (pb.HeadnodeClient, func()) {
+	conn, cancel := ConnectHeadnode()
+	return pb.NewHeadnodeClient(conn), func() {
+		conn.Close()
+		cancel()
+	}
+}
+
+func saveJobSchedule(schedule *pb.JobSchedule) {
+	c, close := connectJobSchedules()
+	defer close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := c.SaveJobSchedule(ctx, schedule); status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support job schedules.")
+	} else if err != nil {
+		Fatallnf("Failed to save job schedule: %v", status.Convert(err).Message())
+	}
+	Printlnf("Job schedule %v is saved.", schedule.Name)
+}
+
+func setJobSchedulesEnabled(names []string, enabled bool) {
+	c, close := connectJobSchedules()
+	defer close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	reply, err := c.SetJobSchedulesEnabled(ctx, &pb.SetJobSchedulesEnabledRequest{Names: names, Enabled: enabled})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support job schedules.")
+	} else if err != nil {
+		Fatallnf("Failed to update job schedules: %v", status.Convert(err).Message())
+	}
+	action := "Disabled"
+	if enabled {
+		action = "Enabled"
+	}
+	if updated := reply.GetUpdated(); len(updated) > 0 {
+		Printlnf("%v %v job schedules: %v", action, len(updated), strings.Join(updated, ", "))
+	}
+	if not_found := reply.GetNotFound(); len(not_found) > 0 {
+		Printlnf("Job schedules not found: %v", strings.Join(not_found, ", "))
+	}
+}
+
+func deleteJobSchedules(names []string) {
+	c, close := connectJobSchedules()
+	defer close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	reply, err := c.DeleteJobSchedules(ctx, &pb.DeleteJobSchedulesRequest{Names: names})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support job schedules.")
+	} else if err != nil {
+		Fatallnf("Failed to delete job schedules: %v", status.Convert(err).Message())
+	}
+	if deleted := reply.GetDeleted(); len(deleted) > 0 {
+		Printlnf("Deleted %v job schedules: %v", len(deleted), strings.Join(deleted, ", "))
+	}
+	if not_found := reply.GetNotFound(); len(not_found) > 0 {
+		Printlnf("Job schedules not found: %v", strings.Join(not_found, ", "))
+	}
+}
+
+func printJobSchedules(names []string) {
+	c, close := connectJobSchedules()
+	defer close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	reply, err := c.GetJobSchedules(ctx, &pb.GetJobSchedulesRequest{Names: names})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support job schedules.")
+	} else if err != nil {
+		Fatallnf("Failed to get job schedules: %v", status.Convert(err).Message())
+	}
+	schedules := reply.GetSchedules()
+	for _, s := range schedules {
+		Printlnf(GetPaddingLine(fmt.Sprintf("---[%v]---", s.Name)))
+		Printlnf(formatJobSchedule(s))
+	}
+	if len(schedules) > 0 {
+		Printlnf(GetPaddingLine(""))
+	}
+	Printlnf("Job schedule count: %v", len(schedules))
+}
+
+func formatJobSchedule(s *pb.JobSchedule) string {
+	lines := []string{}
+	add := func(name string, value interface{}) {
+		lines = append(lines, fmt.Sprintf("%-10v : %v", name, value))
+	}
+	add("Cron", s.Cron)
+	if s.Disabled {
+		add("State", "Disabled")
+	} else {
+		add("State", "Enabled")
+		add("Next Run", FormatTime(time.Unix(s.NextRunTime, 0)))
+	}
+	if s.LastRunTime > 0 {
+		add("Last Run", FormatTime(time.Unix(s.LastRunTime, 0)))
+	}
+	if s.LastJobId > 0 {
+		add("Last Job", s.LastJobId)
+	}
+	if len(s.LastError) > 0 {
+		add("Last Error", s.LastError)
+	}
+	job := s.GetJob()
+	if len(job.GetName()) > 0 {
+		add("Job Name", job.GetName())
+	}
+	add("Command", job.GetCommand())
+	if len(job.GetNodes()) > 0 {
+		add("Nodes", strings.Join(job.GetNodes(), ", "))
+	}
+	if len(job.GetPattern()) > 0 {
+		add("Pattern", job.GetPattern())
+	}
+	if len(job.GetGroups()) > 0 {
+		add("Groups", strings.Join(job.GetGroups(), ", "))
+	}
+	if len(job.GetSweep()) > 0 {
+		add("Sweep", job.GetSweep())
+	}
+	if len(job.GetEnvMode()) > 0 {
+		add("Env Mode", job.GetEnvMode())
+	}
+	return strings.Join(lines, LineEnding)
+}
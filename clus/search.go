@@ -0,0 +1,75 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/status"
+)
+
+func Search(args []string) {
+	fs := flag.NewFlagSet("clus search options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	jobs := fs.String("jobs", "", "specify the jobs to search in, default searches in all jobs")
+	max := fs.Int("max", 100, "specify the max number of matched lines to display")
+	_ = fs.Parse(args)
+	if len(fs.Args()) == 0 {
+		displaySearchUsage(fs)
+		return
+	}
+	job_ids := map[int32]bool{}
+	if len(*jobs) > 0 {
+		var err error
+		if job_ids, err = parseJobIds([]string{*jobs}); err != nil {
+			Fatallnf("%v", err)
+		}
+	}
+	if *max <= 0 {
+		Fatallnf("The max number of matched lines should be positive.")
+	}
+	searchOutput(strings.Join(fs.Args(), " "), job_ids, *max)
+}
+
+func displaySearchUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus search [options] <text>
+
+  Search the lines containing the text in the stored output of finished jobs, from the latest job.
+  The text is matched case-insensitively, and should start and end at word boundaries.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+func searchOutput(text string, job_ids map[int32]bool, max int) {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+
+	// Search output
+	reply, err := pb.NewHeadnodeClient(conn).SearchOutput(context.Background(), &pb.SearchOutputRequest{Text: text, JobIds: job_ids, MaxMatches: int32(max)})
+	if err != nil {
+		Fatallnf("Failed to search output: %v", status.Convert(err).Message())
+	}
+
+	// Print matches
+	for _, match := range reply.GetMatches() {
+		stream := "stdout"
+		if match.Stderr {
+			stream = "stderr"
+		}
+		location := fmt.Sprintf("[Job %v][%v][%v:%v]", match.JobId, match.Node, stream, match.Line)
+		Printlnf("%v: %v", Colorize(location, colorYellow), match.Text)
+	}
+	if reply.GetTruncated() {
+		Printlnf("Showing the first %v matched lines.", len(reply.GetMatches()))
+	} else {
+		Printlnf("%v lines matched.", len(reply.GetMatches()))
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	sessionCacheFile     = "sessions.json"
+	sessionRefreshBefore = 30 * time.Minute
+)
+
+// The session issued by "clus login", cached per headnode in the config dir of the user
+type cachedSession struct {
+	Token     string `json:"token"`
+	User      string `json:"user"`
+	Role      string `json:"role"`
+	Cluster   string `json:"cluster,omitempty"`
+	Expire    int64  `json:"expire"`
+	MaxExpire int64  `json:"max_expire"`
+}
+
+func newCachedSession(reply *pb.LoginReply) *cachedSession {
+	return &cachedSession{
+		Token:     reply.GetToken(),
+		User:      reply.GetUser(),
+		Role:      reply.GetRole(),
+		Cluster:   reply.GetCluster(),
+		Expire:    reply.GetExpire(),
+		MaxExpire: reply.GetMaxExpire(),
+	}
+}
+
+func getSessionCachePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "clusrun", sessionCacheFile), nil
+}
+
+func loadCachedSessions() map[string]*cachedSession {
+	sessions := map[string]*cachedSession{}
+	path, err := getSessionCachePath()
+	if err != nil {
+		return sessions
+	}
+	if b, err := ioutil.ReadFile(path); err == nil {
+		if err := json.Unmarshal(b, &sessions); err != nil {
+			Printlnf("[Warning] Ignore the invalid session cache %v: %v", path, err)
+		}
+	}
+	return sessions
+}
+
+// The cache is only readable by the user, and replaced by rename so that it is not corrupted by concurrent commands
+func saveCachedSessions(sessions map[string]*cachedSession) error {
+	path, err := getSessionCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+	// The temp file is created with mode 0600
+	f, err := ioutil.TempFile(filepath.Dir(path), sessionCacheFile+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func cacheSession(headnode string, session *cachedSession) error {
+	sessions := loadCachedSessions()
+	sessions[headnode] = session
+	return saveCachedSessions(sessions)
+}
+
+func removeCachedSession(headnode string) (bool, error) {
+	sessions := loadCachedSessions()
+	if _, ok := sessions[headnode]; !ok {
+		return false, nil
+	}
+	delete(sessions, headnode)
+	return true, saveCachedSessions(sessions)
+}
+
+// Get the cached session of the headnode, which is refreshed if it expires soon
+func getCachedSession() *cachedSession {
+	headnode := ParseHeadnode(*Headnode)
+	session, ok := loadCachedSessions()[headnode]
+	if !ok {
+		return nil
+	}
+	now := time.Now()
+	if !now.Before(time.Unix(session.Expire, 0)) {
+		Printlnf("[Warning] Session of %v on %v is expired, please run \"clus login\" again.", session.User, headnode)
+		_, _ = removeCachedSession(headnode)
+		return nil
+	}
+	if needSessionRefresh(session, now) {
+		if refreshed, err := refreshSession(session); err != nil {
+			Printlnf("[Warning] Failed to refresh session of %v on %v: %v", session.User, headnode, err)
+		} else if err := cacheSession(headnode, refreshed); err != nil {
+			Printlnf("[Warning] Failed to cache session: %v", err)
+		} else {
+			session = refreshed
+		}
+	}
+	return session
+}
+
+// A session is refreshed before it expires, unless it can't be extended any more
+func needSessionRefresh(session *cachedSession, now time.Time) bool {
+	return session.Expire < session.MaxExpire && now.Add(sessionRefreshBefore).After(time.Unix(session.Expire, 0))
+}
+
+func refreshSession(session *cachedSession) (*cachedSession, error) {
+	conn, cancel := connectHeadnodeWithCredentials(tokenCredentials(session.Token))
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), ConnectTimeout)
+	defer cancel()
+	reply, err := pb.NewHeadnodeClient(conn).Login(ctx, &pb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return newCachedSession(reply), nil
+}
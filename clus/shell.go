@@ -0,0 +1,155 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh/terminal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	shellResizeInterval = 500 * time.Millisecond
+	shellInputSize      = 1024
+)
+
+func Shell(args []string) {
+	fs := flag.NewFlagSet("clus shell options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	run_as := fs.String("run-as", "", "specify the user to run the shell as on the node, which should be allowed in clusnode configs")
+	no_pty := fs.Bool("no-pty", false, "not to allocate a pseudo terminal on the node, which is also the case if stdin is not a terminal")
+	_ = fs.Parse(args)
+	if len(fs.Args()) == 0 {
+		displayShellUsage(fs)
+		return
+	}
+	exit_code, err := openShell(fs.Arg(0), strings.Join(fs.Args()[1:], " "), *run_as, !*no_pty)
+	if err != nil {
+		Fatallnf("%v", err)
+	}
+	os.Exit(exit_code)
+}
+
+func displayShellUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus shell [options] <node> [command]
+
+  Open an interactive shell (or run the command interactively) on the node through the headnode,
+  with a pseudo terminal on the node if stdin is a terminal, the window size and Ctrl-C are forwarded.
+  The session ends when the shell exits, and the shell is killed if the session is left.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+// Run the shell on node until it exits, and return its exit code
+func openShell(node, command, run_as string, pty bool) (int, error) {
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := pb.NewHeadnodeClient(conn).Shell(ctx)
+	if err != nil {
+		return 0, fmt.Errorf(T("Failed to open shell: %v"), status.Convert(err).Message())
+	}
+	var lock sync.Mutex
+	send := func(request *pb.ShellRequest) {
+		lock.Lock()
+		defer lock.Unlock()
+		_ = stream.Send(request)
+	}
+
+	// Open the shell with the size of terminal
+	stdin := int(os.Stdin.Fd())
+	pty = pty && terminal.IsTerminal(stdin)
+	request := &pb.ShellRequest{Node: node, Command: command, RunAs: run_as, Pty: pty}
+	if pty {
+		request.Term = os.Getenv("TERM")
+		request.Size = getTerminalSize()
+	}
+	send(request)
+	reply, err := stream.Recv()
+	if status.Code(err) == codes.Unimplemented {
+		return 0, errors.New(T("The headnode or the node doesn't support shell."))
+	} else if err != nil {
+		return 0, fmt.Errorf(T("Failed to open shell: %v"), status.Convert(err).Message())
+	}
+	if pty && !reply.GetPty() {
+		Printlnf("Pseudo terminal is not supported on node %v.", node)
+		pty = false
+	}
+
+	// Forward the input, window size and Ctrl-C
+	if pty {
+		// Ctrl-C is sent as input in raw mode
+		state, err := terminal.MakeRaw(stdin)
+		if err != nil {
+			return 0, fmt.Errorf(T("Failed to set terminal in raw mode: %v"), err)
+		}
+		defer terminal.Restore(stdin, state)
+		go func() {
+			size := request.Size
+			for {
+				time.Sleep(shellResizeInterval)
+				if s := getTerminalSize(); s.Rows != size.Rows || s.Cols != size.Cols {
+					size = s
+					send(&pb.ShellRequest{Size: size})
+				}
+			}
+		}()
+	} else {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, os.Interrupt)
+		defer signal.Stop(ch)
+		go func() {
+			for range ch {
+				send(&pb.ShellRequest{Interrupt: true})
+			}
+		}()
+	}
+	go func() {
+		buffer := make([]byte, shellInputSize)
+		for {
+			n, err := os.Stdin.Read(buffer)
+			if n > 0 {
+				send(&pb.ShellRequest{Input: append([]byte{}, buffer[:n]...)})
+			}
+			if err != nil {
+				send(&pb.ShellRequest{CloseInput: true})
+				return
+			}
+		}
+	}()
+
+	// Print the output until the shell exits
+	for {
+		reply, err := stream.Recv()
+		if err != nil {
+			return 0, fmt.Errorf(T("Shell session is closed: %v"), status.Convert(err).Message())
+		}
+		if reply.GetExited() {
+			return int(reply.GetExitCode()), nil
+		}
+		_, _ = os.Stdout.Write(reply.GetOutput())
+	}
+}
+
+func getTerminalSize() *pb.TerminalSize {
+	cols, rows, err := terminal.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return &pb.TerminalSize{}
+	}
+	return &pb.TerminalSize{Rows: int32(rows), Cols: int32(cols)}
+}
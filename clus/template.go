@@ -0,0 +1,178 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Template(args []string) {
+	fs := flag.NewFlagSet("clus template options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	description := fs.String("description", "", "specify the description of the template to save")
+	nodes := fs.String("nodes", "", "specify the default nodes to run the command of the template to save")
+	pattern := fs.String("pattern", "", "specify the default pattern of nodes to run the command of the template to save")
+	groups := fs.String("groups", "", "specify the default node groups to run the command of the template to save")
+	sweep := fs.String("sweep", "", "specify the default parametric sweep of the template to save, in the format of \"clus run -sweep\"")
+	env_mode := fs.String("env-mode", "", "specify the default environment (inherit, clean or base) of the template to save")
+	_ = fs.Parse(args)
+	if len(fs.Args()) == 0 {
+		printJobTemplates(nil)
+		return
+	}
+	switch strings.ToLower(fs.Arg(0)) {
+	case "save":
+		if len(fs.Args()) < 3 {
+			displayTemplateUsage(fs)
+			return
+		}
+		saveJobTemplate(&pb.JobTemplate{
+			Name:        fs.Arg(1),
+			Description: *description,
+			Command:     strings.Join(fs.Args()[2:], " "),
+			Nodes:       ParseNodesOrGroups(*nodes, ""),
+			Pattern:     *pattern,
+			Groups:      ParseNodesOrGroups(*groups, ""),
+			Sweep:       *sweep,
+			EnvMode:     *env_mode,
+		})
+	case "delete":
+		if len(fs.Args()) < 2 {
+			displayTemplateUsage(fs)
+			return
+		}
+		deleteJobTemplates(fs.Args()[1:])
+	default:
+		printJobTemplates(fs.Args())
+	}
+}
+
+func displayTemplateUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus template [names]
+  clus template [options] save <name> <command>
+  clus template delete <names>
+
+  The job templates saved on headnode are run by "clus run -template <name>",
+  in which the command and the default options of the template can be overridden.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+func connectJobTemplates() (pb.HeadnodeClient, func()) {
+	conn, cancel := ConnectHeadnode()
+	return pb.NewHeadnodeClient(conn), func() {
+		conn.Close()
+		cancel()
+	}
+}
+
+func saveJobTemplate(template *pb.JobTemplate) {
+	c, close := connectJobTemplates()
+	defer close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := c.SaveJobTemplate(ctx, template); status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support job templates.")
+	} else if err != nil {
+		Fatallnf("Failed to save job template: %v", status.Convert(err).Message())
+	}
+	Printlnf("Job template %v is saved.", template.Name)
+}
+
+func deleteJobTemplates(names []string) {
+	c, close := connectJobTemplates()
+	defer close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	reply, err := c.DeleteJobTemplates(ctx, &pb.DeleteJobTemplatesRequest{Names: names})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support job templates.")
+	} else if err != nil {
+		Fatallnf("Failed to delete job templates: %v", status.Convert(err).Message())
+	}
+	if deleted := reply.GetDeleted(); len(deleted) > 0 {
+		Printlnf("Deleted %v job templates: %v", len(deleted), strings.Join(deleted, ", "))
+	}
+	if not_found := reply.GetNotFound(); len(not_found) > 0 {
+		Printlnf("Job templates not found: %v", strings.Join(not_found, ", "))
+	}
+}
+
+func getJobTemplates(names []string) []*pb.JobTemplate {
+	c, close := connectJobTemplates()
+	defer close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	reply, err := c.GetJobTemplates(ctx, &pb.GetJobTemplatesRequest{Names: names})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support job templates.")
+	} else if err != nil {
+		Fatallnf("Failed to get job templates: %v", status.Convert(err).Message())
+	}
+	return reply.GetTemplates()
+}
+
+func printJobTemplates(names []string) {
+	templates := getJobTemplates(names)
+	for _, t := range templates {
+		Printlnf(GetPaddingLine(fmt.Sprintf("---[%v]---", t.Name)))
+		Printlnf(formatJobTemplate(t))
+	}
+	if len(templates) > 0 {
+		Printlnf(GetPaddingLine(""))
+	}
+	Printlnf("Job template count: %v", len(templates))
+}
+
+func formatJobTemplate(t *pb.JobTemplate) string {
+	lines := []string{}
+	add := func(name string, value interface{}) {
+		lines = append(lines, fmt.Sprintf("%-11v : %v", name, value))
+	}
+	if len(t.Description) > 0 {
+		add("Description", t.Description)
+	}
+	add("Command", t.Command)
+	if len(t.Nodes) > 0 {
+		add("Nodes", strings.Join(t.Nodes, ", "))
+	}
+	if len(t.Pattern) > 0 {
+		add("Pattern", t.Pattern)
+	}
+	if len(t.Groups) > 0 {
+		add("Groups", strings.Join(t.Groups, ", "))
+	}
+	if len(t.Sweep) > 0 {
+		add("Sweep", t.Sweep)
+	}
+	if len(t.EnvMode) > 0 {
+		add("Env Mode", t.EnvMode)
+	}
+	return strings.Join(lines, LineEnding)
+}
+
+// Fill the options not specified by the defaults in the template
+func applyJobTemplate(t *pb.JobTemplate, command *string, nodes, groups *[]string, pattern, sweep, env_mode *string) {
+	if len(*command) == 0 {
+		*command = t.Command
+	}
+	if len(*nodes) == 0 && len(*groups) == 0 && len(*pattern) == 0 {
+		*nodes, *groups, *pattern = t.Nodes, t.Groups, t.Pattern
+	}
+	if len(*sweep) == 0 {
+		*sweep = t.Sweep
+	}
+	if len(*env_mode) == 0 {
+		*env_mode = t.EnvMode
+	}
+}
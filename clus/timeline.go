@@ -0,0 +1,64 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+func Timeline(args []string) {
+	fs := flag.NewFlagSet("clus timeline options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	file := fs.String("file", "", `specify the file to save the timeline, default is "job<id>.trace.json"`)
+	_ = fs.Parse(args)
+	if len(fs.Args()) != 1 {
+		displayTimelineUsage(fs)
+		return
+	}
+	job_id, err := strconv.Atoi(fs.Arg(0))
+	if err != nil || job_id <= 0 {
+		Fatallnf("Invalid job id: %q", fs.Arg(0))
+	}
+	if len(*file) == 0 {
+		*file = fmt.Sprintf("job%v.trace.json", job_id)
+	}
+	exportTimeline(int32(job_id), *file)
+}
+
+func displayTimelineUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus timeline [options] <job id>
+
+  Export the dispatching and running time of a job on each node in Chrome trace format,
+  which can be opened in chrome://tracing or https://ui.perfetto.dev to find the stragglers and dispatch waves
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+func exportTimeline(job_id int32, file string) {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Export timeline
+	reply, err := pb.NewHeadnodeClient(conn).ExportTimeline(ctx, &pb.ExportTimelineRequest{JobId: job_id})
+	if err != nil {
+		Fatallnf("Failed to export timeline: %v", status.Convert(err).Message())
+	}
+	if err := ioutil.WriteFile(file, []byte(reply.GetTrace()), 0644); err != nil {
+		Fatallnf("Failed to save timeline to %v: %v", file, err)
+	}
+	Printlnf("Timeline of job %v is saved to %v", job_id, file)
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const clearScreen = "\033[H\033[2J"
+
+func Top(args []string) {
+	fs := flag.NewFlagSet("clus top options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	pattern := fs.String("pattern", "", "show the nodes matching the specified regular expression pattern")
+	refresh := fs.Int("refresh", 2, "refresh the view every specified seconds")
+	count := fs.Int("count", 20, "show at most the specified count of the busiest nodes")
+	failures := fs.Int("failures", 10, "show at most the specified count of the recent failures of jobs and nodes")
+	once := fs.Bool("once", false, "print the view once and exit")
+	_ = fs.Parse(args)
+	if len(fs.Args()) > 0 {
+		displayTopUsage(fs)
+		return
+	}
+	if *refresh <= 0 || *count <= 0 || *failures < 0 {
+		Fatallnf("Refresh and count should be positive, and failures should not be negative.")
+	}
+	top(*pattern, time.Duration(*refresh)*time.Second, *count, *failures, *once)
+}
+
+func displayTopUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus top [options]
+
+  Keep refreshing the node states, the running jobs, the busiest nodes and the recent failures in the cluster, until interrupted.
+  The failures are received from the event stream of the headnode since the view is started.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+// The recent failures received from the event stream, and the error of the stream if it is broken
+type topEvents struct {
+	lock     sync.Mutex
+	failures []*pb.ClusterEvent
+	limit    int
+	err      error
+}
+
+// Keep the failed jobs and the nodes becoming error or lost, the oldest ones are dropped beyond the limit
+func (e *topEvents) add(event *pb.ClusterEvent) {
+	if !isFailureEvent(event) {
+		return
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.failures = append(e.failures, event)
+	if len(e.failures) > e.limit {
+		e.failures = e.failures[len(e.failures)-e.limit:]
+	}
+}
+
+func (e *topEvents) setError(err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.err = err
+}
+
+func (e *topEvents) get() ([]*pb.ClusterEvent, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]*pb.ClusterEvent{}, e.failures...), e.err
+}
+
+func isFailureEvent(event *pb.ClusterEvent) bool {
+	if node := event.GetNode(); node != nil {
+		return node.To == pb.NodeState_Error || node.To == pb.NodeState_Lost
+	}
+	job := event.GetJob()
+	return job != nil && (job.To == pb.JobState_Failed || job.FailedNodes > 0 && job.To != job.From)
+}
+
+func top(pattern string, refresh time.Duration, count, failures int, once bool) {
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	c := pb.NewHeadnodeClient(conn)
+	events := &topEvents{limit: failures}
+	if once { // no failure is received before the view is printed
+		events.limit = 0
+	} else if failures > 0 {
+		go receiveTopEvents(c, pattern, events)
+	}
+	for {
+		view, err := getTopView(c, pattern, count, events)
+		if status.Code(err) == codes.Unimplemented {
+			Fatallnf("The headnode doesn't support cluster summary.")
+		}
+		if !once {
+			if colorEnabled() {
+				fmt.Print(clearScreen)
+			} else {
+				Printlnf(GetPaddingLine(""))
+			}
+		}
+		if err != nil {
+			Printlnf("[Warning] Failed to refresh the view: %v", status.Convert(err).Message())
+		} else {
+			fmt.Print(view)
+		}
+		if once {
+			return
+		}
+		time.Sleep(refresh)
+	}
+}
+
+// Receive the events until the view exits, and subscribe again if the stream is broken
+func receiveTopEvents(c pb.HeadnodeClient, pattern string, events *topEvents) {
+	for {
+		err := subscribeTopEvents(c, pattern, events)
+		events.setError(err)
+		if code := status.Code(err); code == codes.Unimplemented || code == codes.PermissionDenied || code == codes.InvalidArgument {
+			return
+		}
+		time.Sleep(watchReconnectInterval)
+	}
+}
+
+func subscribeTopEvents(c pb.HeadnodeClient, pattern string, events *topEvents) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.SubscribeEvents(ctx, &pb.SubscribeEventsRequest{NodePattern: pattern})
+	if err != nil {
+		return err
+	}
+	events.setError(nil)
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return status.Error(codes.Unavailable, "The headnode closed the stream")
+		} else if err != nil {
+			return err
+		}
+		events.add(event)
+	}
+}
+
+func getTopView(c pb.HeadnodeClient, pattern string, count int, events *topEvents) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	summary, err := c.GetClusterSummary(ctx, &pb.Empty{})
+	if err != nil {
+		return "", err
+	}
+	reply, err := c.GetNodes(ctx, &pb.GetNodesRequest{Pattern: pattern})
+	if err != nil {
+		return "", err
+	}
+	failures, events_err := events.get()
+	return formatTopView(time.Now(), summary, reply.GetNodes(), count, failures, events_err, events.limit), nil
+}
+
+func formatTopView(now time.Time, summary *pb.GetClusterSummaryReply, nodes []*pb.Node, count int, failures []*pb.ClusterEvent, events_err error, failures_limit int) string {
+	var b strings.Builder
+	line := func(format string, v ...interface{}) {
+		fmt.Fprintf(&b, T(format)+LineEnding, v...)
+	}
+	line("clus top - %v, headnode %v up %v", FormatTime(now), *Headnode, time.Duration(summary.GetUptime())*time.Second)
+	states := []string{}
+	for _, state := range []pb.NodeState{pb.NodeState_Ready, pb.NodeState_Draining, pb.NodeState_Error, pb.NodeState_Lost} {
+		if n := summary.GetNodeStates()[state.String()]; n > 0 {
+			states = append(states, fmt.Sprintf("%v %v", n, ColorizeState(strings.ToLower(state.String()), state.String())))
+		}
+	}
+	line("Nodes: %v total, %v", summary.GetNodes(), strings.Join(states, ", "))
+	line("Jobs: %v running, %v queued", summary.GetRunningJobs(), summary.GetQueuedJobs())
+	b.WriteString(LineEnding)
+
+	// The busiest nodes
+	nodes = getBusiestNodes(nodes, count)
+	name_width := len("Node")
+	for _, node := range nodes {
+		if len(node.Name) > name_width {
+			name_width = len(node.Name)
+		}
+	}
+	format := fmt.Sprintf("%%-%vv   %%v   %%6v   %%6v   %%v", name_width) + LineEnding
+	fmt.Fprintf(&b, format, "Node", fmt.Sprintf("%-8v", "State"), "Load", "Memory", "Jobs")
+	for _, node := range nodes {
+		load, memory, jobs := formatTopResources(node.Resources)
+		fmt.Fprintf(&b, format, node.Name, ColorizeState(fmt.Sprintf("%-8v", node.State), node.State.String()), load, memory, jobs)
+	}
+	b.WriteString(LineEnding)
+
+	// The recent failures, the latest first
+	if failures_limit > 0 {
+		line("Recent failures:")
+		if events_err != nil {
+			line("[Warning] Event stream is broken: %v", status.Convert(events_err).Message())
+		}
+		if len(failures) == 0 {
+			line("No failure since the view is started.")
+		}
+		for i := len(failures) - 1; i >= 0; i-- {
+			b.WriteString(formatClusterEvent(failures[i]) + LineEnding)
+		}
+	}
+	return b.String()
+}
+
+// Sort the nodes by running jobs and CPU load, the nodes without resource usage reported are the last
+func getBusiestNodes(nodes []*pb.Node, count int) []*pb.Node {
+	sorted := append([]*pb.Node{}, nodes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Resources, sorted[j].Resources
+		if (a == nil) != (b == nil) {
+			return a != nil
+		}
+		if a.GetRunningJobs() != b.GetRunningJobs() {
+			return a.GetRunningJobs() > b.GetRunningJobs()
+		}
+		if a.GetCpuLoad() != b.GetCpuLoad() {
+			return a.GetCpuLoad() > b.GetCpuLoad()
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	if len(sorted) > count {
+		sorted = sorted[:count]
+	}
+	return sorted
+}
+
+// Format the CPU load, the percentage of memory used and the running jobs, the negative values are unavailable on the node
+func formatTopResources(r *pb.NodeResources) (load, memory, jobs string) {
+	if r == nil {
+		return "N/A", "N/A", "N/A"
+	}
+	load, memory = "N/A", "N/A"
+	if r.CpuLoad >= 0 {
+		load = fmt.Sprintf("%.2f", r.CpuLoad)
+	}
+	if r.MemoryTotal > 0 && r.MemoryAvailable >= 0 {
+		memory = fmt.Sprintf("%.0f%%", float64(r.MemoryTotal-r.MemoryAvailable)*100/float64(r.MemoryTotal))
+	}
+	return load, memory, fmt.Sprint(r.RunningJobs)
+}
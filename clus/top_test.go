@@ -0,0 +1,74 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"testing"
+)
+
+func Test_getBusiestNodes(t *testing.T) {
+	nodes := []*pb.Node{
+		{Name: "node1"},
+		{Name: "node2", Resources: &pb.NodeResources{CpuLoad: 0.5, RunningJobs: 1}},
+		{Name: "node3", Resources: &pb.NodeResources{CpuLoad: 1.5, RunningJobs: 1}},
+		{Name: "node4", Resources: &pb.NodeResources{CpuLoad: 3, RunningJobs: 0}},
+		{Name: "node0", Resources: &pb.NodeResources{CpuLoad: 0.5, RunningJobs: 1}},
+	}
+	cases := []struct {
+		count    int
+		expected []string
+	}{
+		{10, []string{"node3", "node0", "node2", "node4", "node1"}},
+		{2, []string{"node3", "node0"}},
+	}
+
+	for _, c := range cases {
+		names := []string{}
+		for _, node := range getBusiestNodes(nodes, c.count) {
+			names = append(names, node.Name)
+		}
+		if !reflect.DeepEqual(names, c.expected) {
+			t.Errorf("\ncount=%v\nexpected=%v\n  actual=%v", c.count, c.expected, names)
+		}
+	}
+	if nodes[0].Name != "node1" {
+		t.Errorf("The nodes should not be sorted in place")
+	}
+}
+
+func Test_formatTopResources(t *testing.T) {
+	cases := []struct {
+		resources *pb.NodeResources
+		load      string
+		memory    string
+		jobs      string
+	}{
+		{nil, "N/A", "N/A", "N/A"},
+		{&pb.NodeResources{CpuLoad: 1.25, MemoryTotal: 8 << 30, MemoryAvailable: 2 << 30, RunningJobs: 3}, "1.25", "75%", "3"},
+		{&pb.NodeResources{CpuLoad: -1, MemoryTotal: -1, MemoryAvailable: -1}, "N/A", "N/A", "0"},
+	}
+
+	for _, c := range cases {
+		if load, memory, jobs := formatTopResources(c.resources); load != c.load || memory != c.memory || jobs != c.jobs {
+			t.Errorf("\nresources=%v\nexpected: %q, %q, %q\n  actual: %q, %q, %q", c.resources, c.load, c.memory, c.jobs, load, memory, jobs)
+		}
+	}
+}
+
+func Test_topEvents(t *testing.T) {
+	events := &topEvents{limit: 2}
+	events.add(&pb.ClusterEvent{Time: 1, Node: &pb.NodeEvent{Name: "node1", From: pb.NodeState_Ready, To: pb.NodeState_Lost}})
+	events.add(&pb.ClusterEvent{Time: 2, Node: &pb.NodeEvent{Name: "node1", From: pb.NodeState_Lost, To: pb.NodeState_Ready}})
+	events.add(&pb.ClusterEvent{Time: 3, Job: &pb.JobEvent{Id: 1, From: pb.JobState_Running, To: pb.JobState_Finished}})
+	events.add(&pb.ClusterEvent{Time: 4, Job: &pb.JobEvent{Id: 2, From: pb.JobState_Running, To: pb.JobState_Finished, FailedNodes: 1}})
+	events.add(&pb.ClusterEvent{Time: 5, Job: &pb.JobEvent{Id: 3, From: pb.JobState_Running, To: pb.JobState_Failed}})
+
+	failures, err := events.get()
+	times := []int64{}
+	for _, event := range failures {
+		times = append(times, event.Time)
+	}
+	if expected := []int64{4, 5}; !reflect.DeepEqual(times, expected) || err != nil {
+		t.Errorf("\nexpected=%v, <nil>\n  actual=%v, %v", expected, times, err)
+	}
+}
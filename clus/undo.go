@@ -0,0 +1,73 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"flag"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Undo(args []string) {
+	fs := flag.NewFlagSet("clus undo options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	_ = fs.Parse(args)
+	var id int
+	switch len(fs.Args()) {
+	case 0:
+	case 1:
+		var err error
+		if id, err = strconv.Atoi(fs.Arg(0)); err != nil || id <= 0 {
+			Fatallnf("Invalid operation id: %q", fs.Arg(0))
+		}
+	default:
+		displayUndoUsage(fs)
+		return
+	}
+	undo(int32(id))
+}
+
+func displayUndoUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus undo [options] [operation id]
+
+  The operations which can be undone are listed if the operation id is not specified,
+  including the job cancellation delayed by headnode config and the node removal in an hour.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+func undo(id int32) {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Undo or list operations
+	reply, err := pb.NewHeadnodeClient(conn).Undo(ctx, &pb.UndoRequest{Id: id})
+	if status.Code(err) == codes.Unimplemented {
+		Fatallnf("The headnode doesn't support undo.")
+	} else if err != nil {
+		Fatallnf("Failed to undo: %v", status.Convert(err).Message())
+	}
+	if id > 0 {
+		Printlnf("%v", reply.GetResult())
+		return
+	}
+	ops := reply.GetOperations()
+	if len(ops) == 0 {
+		Printlnf("No operations can be undone.")
+		return
+	}
+	for _, op := range ops {
+		Printlnf("%v\t%v (until %v)", op.Id, op.Description, FormatTime(time.Unix(op.ExpireTime, 0)))
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	uploadChunkSize = 1 << 20
+)
+
+func Upload(args []string) {
+	fs := flag.NewFlagSet("clus upload options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	destination := fs.String("dest", "", "specify the absolute path of dir on each node to upload the file or directory to")
+	nodes := fs.String("nodes", "", "specify certain nodes to upload to")
+	nodes_in_file := fs.String("nodes-in-file", "", "specify a file containg the nodes to upload to")
+	pattern := fs.String("pattern", "", "specify nodes matching a certain regular expression pattern to upload to")
+	groups := fs.String("groups", "", "specify certain node groups to upload to")
+	groups_in_file := fs.String("groups-in-file", "", "specify a file containg the node groups to upload to")
+	groups_intersect := fs.Bool("intersect", false, "specify to upload to intersection (union if not specified) of node groups")
+	_ = fs.Parse(args)
+	if len(fs.Args()) != 1 || len(*destination) == 0 {
+		displayUploadUsage(fs)
+		return
+	}
+	uploadFiles(fs.Arg(0), *destination, *pattern, ParseNodesOrGroups(*groups, *groups_in_file), ParseNodesOrGroups(*nodes, *nodes_in_file), *groups_intersect)
+}
+
+func displayUploadUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus upload [options] -dest <dir> <file or directory>
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+func uploadFiles(source, destination, pattern string, groups, nodes []string, intersect bool) {
+	source, err := filepath.Abs(source)
+	if err != nil {
+		Fatallnf("Invalid file or directory to upload: %v", err)
+	}
+	if _, err := os.Stat(source); err != nil {
+		Fatallnf("Failed to access %v: %v", source, err)
+	}
+	RequireCapability("file staging")
+
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := pb.NewHeadnodeClient(conn).UploadFiles(ctx, grpc.UseCompressor("gzip"))
+	if err != nil {
+		Fatallnf("Failed to start uploading: %v", err)
+	}
+	if err := stream.Send(&pb.UploadFilesRequest{Nodes: nodes, Pattern: pattern, Groups: groups, GroupsIntersect: intersect, Destination: destination}); err != nil {
+		Fatallnf("Failed to start uploading: %v", getUploadError(stream, err))
+	}
+
+	// Send files in chunks
+	base := filepath.Dir(source)
+	var files, bytes int64
+	err = filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if info.IsDir() {
+			return stream.Send(&pb.UploadFilesRequest{Chunk: &pb.FileChunk{Path: rel, IsDir: true}})
+		}
+		if !info.Mode().IsRegular() {
+			Printlnf("Skip %v which is not a regular file", path)
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		hasher := sha256.New()
+		buf := make([]byte, uploadChunkSize)
+		for {
+			n, err := io.ReadFull(f, buf)
+			if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+				return err
+			}
+			_, _ = hasher.Write(buf[:n])
+			chunk := &pb.FileChunk{Path: rel, Mode: uint32(info.Mode().Perm()), Data: buf[:n]}
+			if err != nil {
+				chunk.Eof = true
+				chunk.Sha256 = hex.EncodeToString(hasher.Sum(nil))
+			}
+			if err := stream.Send(&pb.UploadFilesRequest{Chunk: chunk}); err != nil {
+				return err
+			}
+			bytes += int64(n)
+			if chunk.Eof {
+				break
+			}
+		}
+		files++
+		return nil
+	})
+	if err != nil {
+		Fatallnf("Failed to upload %v: %v", source, getUploadError(stream, err))
+	}
+	Printlnf("Sent %v files (%v bytes) to headnode %v, distributing to nodes", files, bytes, *Headnode)
+
+	// Print results
+	reply, err := stream.CloseAndRecv()
+	if err != nil {
+		Fatallnf("Failed to upload files: %v", status.Convert(err).Message())
+	}
+	results := reply.GetResults()
+	names := make([]string, 0, len(results))
+	for node := range results {
+		names = append(names, node)
+	}
+	sort.Strings(names)
+	failed := []string{}
+	for _, node := range names {
+		Printlnf("[%v]: %v", node, results[node])
+		if !strings.HasPrefix(results[node], "Uploaded") {
+			failed = append(failed, node)
+		}
+	}
+	Printlnf("%v of %v nodes succeeded.", len(names)-len(failed), len(names))
+	if len(failed) > 0 {
+		Printlnf("Failed nodes (%v/%v): %v", len(failed), len(names), strings.Join(failed, ", "))
+	}
+}
+
+// The actual error of a failed sending is returned by receiving
+func getUploadError(stream pb.Headnode_UploadFilesClient, err error) string {
+	if err == io.EOF {
+		if _, e := stream.CloseAndRecv(); e != nil {
+			return status.Convert(e).Message()
+		}
+	}
+	return err.Error()
+}
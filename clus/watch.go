@@ -0,0 +1,108 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const watchReconnectInterval = 2 * time.Second
+
+func Watch(args []string) {
+	fs := flag.NewFlagSet("clus watch options", flag.ExitOnError)
+	SetGlobalParameters(fs)
+	pattern := fs.String("pattern", "", "watch the state changes of nodes matching the specified regular expression pattern")
+	nodesOnly := fs.Bool("nodes", false, "watch the state changes of nodes only")
+	jobsOnly := fs.Bool("jobs", false, "watch the state changes of jobs only")
+	_ = fs.Parse(args)
+	if len(fs.Args()) > 0 {
+		displayWatchUsage(fs)
+		return
+	}
+	if *nodesOnly && *jobsOnly {
+		Fatallnf("Please specify at most one of -nodes and -jobs.")
+	}
+	watch(&pb.SubscribeEventsRequest{ExcludeNodes: *jobsOnly, ExcludeJobs: *nodesOnly, NodePattern: *pattern})
+}
+
+func displayWatchUsage(fs *flag.FlagSet) {
+	Printlnf(`
+Usage:
+  clus watch [options]
+
+  Print the state changes of nodes and jobs in the cluster once they happen, until interrupted.
+
+Options:
+`)
+	fs.PrintDefaults()
+}
+
+// Receive the events from the headnode, and subscribe again if the stream is broken
+func watch(request *pb.SubscribeEventsRequest) {
+	for {
+		err := receiveEvents(request)
+		switch status.Code(err) {
+		case codes.Unimplemented:
+			Fatallnf("The headnode doesn't support subscribing events.")
+		case codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied:
+			Fatallnf("Failed to watch events: %v", status.Convert(err).Message())
+		}
+		Printlnf("[Warning] Event stream is broken, reconnecting: %v", status.Convert(err).Message())
+		time.Sleep(watchReconnectInterval)
+	}
+}
+
+func receiveEvents(request *pb.SubscribeEventsRequest) error {
+	// Setup connection
+	conn, cancel := ConnectHeadnode()
+	defer cancel()
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Subscribe events
+	stream, err := pb.NewHeadnodeClient(conn).SubscribeEvents(ctx, request)
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return status.Error(codes.Unavailable, "The headnode closed the stream")
+		} else if err != nil {
+			return err
+		}
+		Printlnf("%v", formatClusterEvent(event))
+	}
+}
+
+// Format the event in a line, or in tab separated fields for plain output
+func formatClusterEvent(event *pb.ClusterEvent) string {
+	t := DisplayTime(time.Unix(0, event.Time)).Format(time.Stamp)
+	if node := event.GetNode(); node != nil {
+		if IsPlain() {
+			return strings.Join([]string{t, "node", node.Name, node.From.String(), node.To.String()}, "\t")
+		}
+		return fmt.Sprintf(T("[%v] Node %v: %v -> %v"), t, node.Name, node.From, ColorizeState(node.To.String(), node.To.String()))
+	}
+	job := event.GetJob()
+	if IsPlain() {
+		return strings.Join([]string{t, "job", fmt.Sprint(job.Id), job.From.String(), job.To.String()}, "\t")
+	}
+	name := fmt.Sprint(job.Id)
+	if len(job.Name) > 0 {
+		name = fmt.Sprintf("%v (%v)", job.Id, job.Name)
+	}
+	state := ColorizeState(job.To.String(), job.To.String())
+	if job.FailedNodes > 0 {
+		return fmt.Sprintf(T("[%v] Job %v: %v -> %v, failed on %v of %v nodes"), t, name, job.From, state, job.FailedNodes, job.Nodes)
+	}
+	return fmt.Sprintf(T("[%v] Job %v: %v -> %v"), t, name, job.From, state)
+}
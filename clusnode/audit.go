@@ -0,0 +1,455 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	pb "clusrun/protobuf"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	AuditAction_JobStart        = "job.start"
+	AuditAction_JobEnd          = "job.end"
+	AuditAction_JobCancel       = "job.cancel"
+	AuditAction_JobOrphan       = "job.orphan"
+	AuditAction_ShellStart      = "shell.start"
+	AuditAction_ShellEnd        = "shell.end"
+	AuditAction_FilesReceive    = "files.receive"
+	AuditAction_FilesSend       = "files.send"
+	AuditAction_SetHeadnodes    = "headnodes.set"
+	AuditAction_SetConfigs      = "configs.set"
+	AuditAction_RollbackConfigs = "configs.rollback"
+
+	// The operations on headnode
+	AuditAction_JobSubmit       = "job.submit"
+	AuditAction_JobsCancel      = "jobs.cancel"
+	AuditAction_JobsPurge       = "jobs.purge"
+	AuditAction_JobsImport      = "jobs.import"
+	AuditAction_HeadnodeConfigs = "headnode.configs.set"
+	AuditAction_ConfigDrift     = "nodes.configs.drift"
+	AuditAction_NodeGroups      = "nodes.groups.set"
+	AuditAction_NodeLabels      = "nodes.labels.set"
+	AuditAction_NodesDrain      = "nodes.drain"
+	AuditAction_NodesRemove     = "nodes.remove"
+	AuditAction_NodesRevalidate = "nodes.revalidate"
+	AuditAction_NodeKeysReset   = "nodes.keys.reset"
+	AuditAction_FilesUpload     = "files.upload"
+	AuditAction_FilesGather     = "files.gather"
+	AuditAction_Undo            = "undo"
+	AuditAction_TemplateSave    = "template.save"
+	AuditAction_TemplatesDelete = "templates.delete"
+	AuditAction_ScheduleSave    = "schedule.save"
+	AuditAction_SchedulesEnable = "schedules.enable"
+	AuditAction_SchedulesDelete = "schedules.delete"
+	AuditAction_ShellOpen       = "shell.open"
+
+	maxAuditDetailLength = 1024 // the request in detail is truncated
+)
+
+var (
+	auditLogLock sync.Mutex
+	auditLogSeq  int64  // the seq of the last entry
+	auditLogHash string // the hash of the last entry
+
+	// The mutating RPCs of headnode audited with the clients calling them
+	auditedRpcs = map[string]string{
+		"/clusrun.Headnode/StartClusJob":           AuditAction_JobSubmit,
+		"/clusrun.Headnode/RerunJob":               AuditAction_JobSubmit,
+		"/clusrun.Headnode/CancelClusJobs":         AuditAction_JobsCancel,
+		"/clusrun.Headnode/PurgeJobs":              AuditAction_JobsPurge,
+		"/clusrun.Headnode/ImportJobs":             AuditAction_JobsImport,
+		"/clusrun.Headnode/SetConfigs":             AuditAction_HeadnodeConfigs,
+		"/clusrun.Headnode/GetConfigDrift":         AuditAction_ConfigDrift,
+		"/clusrun.Headnode/SetNodeGroups":          AuditAction_NodeGroups,
+		"/clusrun.Headnode/SetNodeLabels":          AuditAction_NodeLabels,
+		"/clusrun.Headnode/DrainNodes":             AuditAction_NodesDrain,
+		"/clusrun.Headnode/RemoveNodes":            AuditAction_NodesRemove,
+		"/clusrun.Headnode/RevalidateNodes":        AuditAction_NodesRevalidate,
+		"/clusrun.Headnode/ResetNodeKeys":          AuditAction_NodeKeysReset,
+		"/clusrun.Headnode/UploadFiles":            AuditAction_FilesUpload,
+		"/clusrun.Headnode/GatherFiles":            AuditAction_FilesGather,
+		"/clusrun.Headnode/Undo":                   AuditAction_Undo,
+		"/clusrun.Headnode/SaveJobTemplate":        AuditAction_TemplateSave,
+		"/clusrun.Headnode/DeleteJobTemplates":     AuditAction_TemplatesDelete,
+		"/clusrun.Headnode/SaveJobSchedule":        AuditAction_ScheduleSave,
+		"/clusrun.Headnode/SetJobSchedulesEnabled": AuditAction_SchedulesEnable,
+		"/clusrun.Headnode/DeleteJobSchedules":     AuditAction_SchedulesDelete,
+		"/clusrun.Headnode/Shell":                  AuditAction_ShellOpen,
+	}
+)
+
+// The hash of an entry covers its fields and the hash of the previous entry, so that modifying, inserting or removing
+// an entry breaks the chain from it on
+func hashAuditEntry(e *pb.AuditEntry) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%d\n%d\n%q\n%q\n%d\n%q\n%q\n%q\n%v", e.Seq, e.Time, e.Source, e.Action, e.JobId, e.RunAs, e.Detail, e.Result, e.PrevHash)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Verify the seqs and hashes of the consecutive entries following the entry with the seq and hash
+func verifyAuditEntries(entries []*pb.AuditEntry, prev_seq int64, prev_hash string) error {
+	for _, e := range entries {
+		if e.Seq != prev_seq+1 {
+			return fmt.Errorf("Entry %v is found after entry %v", e.Seq, prev_seq)
+		}
+		if e.PrevHash != prev_hash {
+			return fmt.Errorf("Previous hash of entry %v does not match entry %v", e.Seq, prev_seq)
+		}
+		if hashAuditEntry(e) != e.Hash {
+			return fmt.Errorf("Hash of entry %v does not match its content", e.Seq)
+		}
+		prev_seq, prev_hash = e.Seq, e.Hash
+	}
+	return nil
+}
+
+// The audit log is a file of entries in json lines, which are only appended
+func readAuditLog(file string) ([]*pb.AuditEntry, error) {
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	entries := []*pb.AuditEntry{}
+	reader := bufio.NewReader(f)
+	for line := 1; ; line++ {
+		b, err := reader.ReadBytes('\n')
+		if b = bytes.TrimSpace(b); len(b) > 0 {
+			var entry pb.AuditEntry
+			if err := json.Unmarshal(b, &entry); err != nil {
+				return entries, fmt.Errorf("Failed to parse line %v: %v", line, err)
+			}
+			entries = append(entries, &entry)
+		}
+		if err == io.EOF {
+			return entries, nil
+		} else if err != nil {
+			return entries, err
+		}
+	}
+}
+
+// Continue the chain from the last entry in the audit log, a broken chain is reported but kept as the evidence
+func loadAuditLog() {
+	entries, err := readAuditLog(db_auditLog)
+	if err == nil {
+		err = verifyAuditEntries(entries, 0, "")
+	}
+	if err != nil {
+		LogError("Audit log %v is broken: %v", db_auditLog, err)
+	}
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		auditLogSeq, auditLogHash = last.Seq, last.Hash
+	}
+	LogInfo("Loaded %v entries of audit log", len(entries))
+}
+
+// Append an entry of what is done on this clusnode to the audit log, which is chained to the last entry
+func appendAuditEntry(entry *pb.AuditEntry) {
+	auditLogLock.Lock()
+	defer auditLogLock.Unlock()
+	entry.Seq, entry.Time, entry.PrevHash = auditLogSeq+1, time.Now().Unix(), auditLogHash
+	entry.Hash = hashAuditEntry(entry)
+	json_string, err := json.Marshal(entry)
+	if err != nil {
+		LogError("Failed to marshal audit entry: %v", err)
+		return
+	}
+	f, err := os.OpenFile(db_auditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		LogError("Failed to open audit log: %v", err)
+		return
+	}
+	defer f.Close()
+	if _, err = f.Write(append(json_string, '\n')); err == nil {
+		err = f.Sync()
+	}
+	if err != nil {
+		LogError("Failed to append audit entry %v: %v", entry.Seq, err)
+		return
+	}
+	auditLogSeq, auditLogHash = entry.Seq, entry.Hash
+}
+
+func audit(source, action string, job_id int32, run_as, detail, result string) {
+	appendAuditEntry(&pb.AuditEntry{Source: source, Action: action, JobId: job_id, RunAs: run_as, Detail: detail, Result: result})
+}
+
+// The source of a call not from headnode is the address of client, and the user or token it is authenticated as
+func getAuditSource(ctx context.Context) string {
+	source := "unknown client"
+	if p, ok := peer.FromContext(ctx); ok {
+		source = "client " + p.Addr.String()
+	}
+	if client := getAuthClient(ctx); client != nil {
+		source += " as " + client.String()
+	}
+	return source
+}
+
+func formatAuditResult(err error) string {
+	if err != nil {
+		return "Failed: " + status.Convert(err).Message()
+	}
+	return "Succeeded"
+}
+
+func formatAuditConfigs(configs map[string]string) string {
+	items := make([]string, 0, len(configs))
+	for k, v := range configs {
+		items = append(items, fmt.Sprintf("%v=%q", k, v))
+	}
+	sort.Strings(items)
+	return strings.Join(items, " ")
+}
+
+// The request of an audited RPC in JSON, without the sensitive configs and the content of files or input
+func formatAuditRequest(req interface{}) string {
+	m, ok := req.(proto.Message)
+	if !ok || m == nil {
+		return ""
+	}
+	switch r := proto.Clone(m).(type) {
+	case *pb.SetConfigsRequest:
+		role := Config_Headnode
+		if len(r.Nodes) > 0 {
+			role = Config_Clusnode
+		}
+		r.Configs = maskConfigs(r.Configs, getRoleConfigs(role))
+		m = r
+	case *pb.GetConfigDriftRequest:
+		r.Baseline = maskConfigs(r.Baseline, getRoleConfigs(Config_Clusnode))
+		m = r
+	case *pb.UploadFilesRequest:
+		r.Chunk = nil
+		m = r
+	case *pb.ShellRequest:
+		r.Input = nil
+		m = r
+	case *pb.ImportJobsRequest:
+		return ""
+	}
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	detail, err := marshaler.MarshalToString(m)
+	if err != nil {
+		return fmt.Sprintf("Failed to format request: %v", err)
+	}
+	if len(detail) > maxAuditDetailLength {
+		detail = detail[:maxAuditDetailLength] + "..."
+	}
+	return detail
+}
+
+// Get the job id in the request or reply, e.g. the job submitted
+func getAuditJobId(messages ...interface{}) int32 {
+	for _, m := range messages {
+		if m, ok := m.(interface{ GetJobId() int32 }); ok && m.GetJobId() > 0 {
+			return m.GetJobId()
+		}
+	}
+	return 0
+}
+
+func getAuditRunAs(req interface{}) string {
+	if r, ok := req.(interface{ GetRunAs() string }); ok {
+		return r.GetRunAs()
+	}
+	return ""
+}
+
+func auditUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	action, ok := auditedRpcs[info.FullMethod]
+	if !ok {
+		return handler(ctx, req)
+	}
+	reply, err := handler(ctx, req)
+	audit(getAuditSource(ctx), action, getAuditJobId(req, reply), getAuditRunAs(req), formatAuditRequest(req), formatAuditResult(err))
+	return reply, err
+}
+
+func auditStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	action, ok := auditedRpcs[info.FullMethod]
+	if !ok {
+		return handler(srv, ss)
+	}
+	stream := &auditServerStream{ServerStream: ss, action: action, auditOnReply: info.IsServerStream}
+	err := handler(srv, stream)
+	stream.audit(nil, formatAuditResult(err))
+	return err
+}
+
+// The stream auditing the call with its first request, the calls streaming replies are audited once the first reply is
+// sent, so that a long running job or shell is audited when it starts rather than ends
+type auditServerStream struct {
+	grpc.ServerStream
+	action       string
+	auditOnReply bool
+	lock         sync.Mutex
+	received     bool
+	audited      bool
+	jobId        int32
+	runAs        string
+	detail       string
+}
+
+func (s *auditServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.lock.Lock()
+		if !s.received {
+			s.received = true
+			s.jobId, s.runAs, s.detail = getAuditJobId(m), getAuditRunAs(m), formatAuditRequest(m)
+		}
+		s.lock.Unlock()
+	}
+	return err
+}
+
+func (s *auditServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil && s.auditOnReply {
+		s.audit(m, "Started")
+	}
+	return err
+}
+
+func (s *auditServerStream) audit(reply interface{}, result string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.audited {
+		return
+	}
+	s.audited = true
+	job_id := s.jobId
+	if job_id == 0 {
+		job_id = getAuditJobId(reply)
+	}
+	audit(getAuditSource(s.Context()), s.action, job_id, s.runAs, s.detail, result)
+}
+
+// The entry matches the time range, the action or the actions under it, e.g. "nodes" for "nodes.drain", and the source
+// containing the given one
+func matchAuditEntry(e *pb.AuditEntry, in *pb.GetAuditLogRequest) bool {
+	if e.Seq <= in.GetSinceSeq() || (in.GetSince() > 0 && e.Time < in.GetSince()) || (in.GetUntil() > 0 && e.Time > in.GetUntil()) {
+		return false
+	}
+	if action := in.GetAction(); len(action) > 0 && e.Action != action && !strings.HasPrefix(e.Action, action+".") {
+		return false
+	}
+	return strings.Contains(strings.ToLower(e.Source), strings.ToLower(in.GetSource()))
+}
+
+// Get the entries matching the request with the result of verifying the whole chain, the last hash can be recorded
+// elsewhere to detect the entries removed from the end later
+func getAuditLog(in *pb.GetAuditLogRequest) (*pb.GetAuditLogReply, error) {
+	since, limit := in.GetSinceSeq(), in.GetLimit()
+	if since < 0 || limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Seq and limit should not be negative")
+	}
+	auditLogLock.Lock()
+	entries, err := readAuditLog(db_auditLog)
+	auditLogLock.Unlock()
+	reply := &pb.GetAuditLogReply{Count: int64(len(entries)), Verified: true}
+	if err == nil {
+		err = verifyAuditEntries(entries, 0, "")
+	}
+	if err != nil {
+		LogWarning("Audit log is not verified: %v", err)
+		reply.Verified, reply.VerifyError = false, err.Error()
+	}
+	if len(entries) > 0 {
+		reply.LastHash = entries[len(entries)-1].Hash
+	}
+	for _, e := range entries {
+		if !matchAuditEntry(e, in) {
+			continue
+		}
+		if limit > 0 && len(reply.Entries) >= int(limit) {
+			break
+		}
+		reply.Entries = append(reply.Entries, e)
+	}
+	return reply, nil
+}
+
+func (s *clusnode_server) GetAuditLog(ctx context.Context, in *pb.GetAuditLogRequest) (*pb.GetAuditLogReply, error) {
+	defer LogPanicBeforeExit()
+	return getAuditLog(in)
+}
+
+// The operations on headnode are audited in the same log as the ones on its clusnode role
+func (s *headnode_server) GetAuditLog(ctx context.Context, in *pb.GetAuditLogRequest) (*pb.GetAuditLogReply, error) {
+	defer LogPanicBeforeExit()
+	return getAuditLog(in)
+}
+
+func auditLog(args []string) {
+	fs := flag.NewFlagSet("clusnode audit options", flag.ExitOnError)
+	node := fs.String("node", localHost, "specify the node to get audit log from")
+	token := fs.String("token", os.Getenv(AuthTokenEnv), "specify the token to access the node if authentication is enabled, default is from environment variable "+AuthTokenEnv)
+	since := fs.Int64("since", 0, "only get the entries after the seq")
+	limit := fs.Int("limit", 0, "specify the max count of entries to get, 0 for all")
+	_ = fs.Parse(args)
+	_, _, host, err := ParseHostAddress(*node)
+	if err != nil {
+		Fatallnf("Failed to parse the host of node: %v", err)
+	}
+	conn, cancel := ConnectNode(host)
+	defer cancel()
+	if conn == nil {
+		Fatallnf("Please ensure the node is started.")
+	}
+	defer conn.Close()
+	c := pb.NewClusnodeClient(conn)
+	ctx, cancel := context.WithTimeout(withAuthToken(context.Background(), *token), 10*time.Second)
+	defer cancel()
+	reply, err := c.GetAuditLog(ctx, &pb.GetAuditLogRequest{SinceSeq: *since, Limit: int32(*limit)})
+	if err != nil {
+		Fatallnf("Failed to get audit log: %v", err)
+	}
+	entries := reply.GetEntries()
+	for _, e := range entries {
+		job := ""
+		if e.JobId > 0 {
+			job = fmt.Sprintf(" job %v", e.JobId)
+		}
+		if len(e.RunAs) > 0 {
+			job += " as " + e.RunAs
+		}
+		Printlnf("#%v [%v] %v%v from %v: %v => %v", e.Seq, time.Unix(e.Time, 0).Format("2006-01-02 15:04:05 -0700"), e.Action, job, e.Source, e.Detail, e.Result)
+	}
+
+	// The entries got are verified again in case the node is not trusted
+	if len(entries) > 0 {
+		if err := verifyAuditEntries(entries, entries[0].Seq-1, entries[0].PrevHash); err != nil {
+			reply.Verified, reply.VerifyError = false, err.Error()
+		}
+	}
+	Printlnf("%v of %v entries are got, the hash of the last entry is %v", len(entries), reply.GetCount(), reply.GetLastHash())
+	if reply.GetVerified() {
+		Printlnf("Audit log is verified.")
+	} else {
+		Printlnf("Audit log is broken: %v", reply.GetVerifyError())
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func Test_auditLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusrun")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	db_auditLog = filepath.Join(dir, "node.audit")
+	audit("headnode1", AuditAction_JobStart, 1, "user1", "hostname", "Started process 100")
+	audit("headnode1", AuditAction_JobEnd, 1, "user1", "hostname", "Exit code 0")
+	audit("client 127.0.0.1:1234", AuditAction_SetConfigs, 0, "", "a=\"b\"", "a=\"Set\"")
+	entries, err := readAuditLog(db_auditLog)
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("Expected 3 entries of audit log, got %v, %v", len(entries), err)
+	}
+	if err := verifyAuditEntries(entries, 0, ""); err != nil {
+		t.Errorf("Expected audit log verified, got %v", err)
+	}
+	if err := verifyAuditEntries(entries[1:], 1, entries[0].Hash); err != nil {
+		t.Errorf("Expected partial audit log verified, got %v", err)
+	}
+
+	// Modifying, removing or reordering entries breaks the chain
+	e := entries[1]
+	modified := &pb.AuditEntry{Seq: e.Seq, Time: e.Time, Source: e.Source, Action: e.Action, JobId: e.JobId, RunAs: e.RunAs, Detail: e.Detail, Result: "Exit code 1", PrevHash: e.PrevHash, Hash: e.Hash}
+	removed := []*pb.AuditEntry{entries[0], entries[2]}
+	reordered := []*pb.AuditEntry{entries[1], entries[0], entries[2]}
+	for _, tampered := range [][]*pb.AuditEntry{{entries[0], modified, entries[2]}, removed, reordered} {
+		if err := verifyAuditEntries(tampered, 0, ""); err == nil {
+			t.Errorf("Expected tampered audit log not verified")
+		}
+	}
+
+	// The chain continues from the last entry after reloaded
+	auditLogSeq, auditLogHash = 0, ""
+	loadAuditLog()
+	audit("headnode1", AuditAction_JobCancel, 2, "", "", "Canceled process 101")
+	if entries, err = readAuditLog(db_auditLog); err != nil || len(entries) != 4 || verifyAuditEntries(entries, 0, "") != nil {
+		t.Errorf("Expected 4 entries of audit log verified after reloaded, got %v, %v", len(entries), err)
+	}
+}
+
+func Test_auditRpcs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusrun")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	db_auditLog = filepath.Join(dir, "headnode.audit")
+	auditLogSeq, auditLogHash = 0, ""
+
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}
+	ctx := withAuthClient(peer.NewContext(context.Background(), &peer.Peer{Addr: addr}), &authClient{user: "alice", scope: authScope{Role: authRole_Admin}})
+	request := &pb.SetConfigsRequest{Configs: map[string]string{Config_Headnode_AuthTokens.Name: "admin:0123456789abcdef", Config_Headnode_MaxJobCount.Name: "100"}}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.SetConfigsReply{}, nil
+	}
+	if _, err := auditUnaryInterceptor(ctx, request, &grpc.UnaryServerInfo{FullMethod: "/clusrun.Headnode/SetConfigs"}, handler); err != nil {
+		t.Errorf("Expected no error from handler, got %v", err)
+	}
+	failed := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "Job 3 not found")
+	}
+	_, _ = auditUnaryInterceptor(context.Background(), &pb.CancelClusJobsRequest{JobIds: map[int32]bool{3: true}}, &grpc.UnaryServerInfo{FullMethod: "/clusrun.Headnode/CancelClusJobs"}, failed)
+	_, _ = auditUnaryInterceptor(ctx, &pb.GetJobsRequest{}, &grpc.UnaryServerInfo{FullMethod: "/clusrun.Headnode/GetJobs"}, handler)
+
+	entries, err := readAuditLog(db_auditLog)
+	if err != nil || len(entries) != 2 || verifyAuditEntries(entries, 0, "") != nil {
+		t.Fatalf("Expected 2 entries of audit log verified, got %v, %v", len(entries), err)
+	}
+	e := entries[0]
+	if e.Action != AuditAction_HeadnodeConfigs || e.Source != "client 10.0.0.1:1234 as user alice with role admin" || e.Result != "Succeeded" {
+		t.Errorf("Unexpected entry of setting configs: %v", e)
+	}
+	if strings.Contains(e.Detail, "0123456789abcdef") || !strings.Contains(e.Detail, "100") {
+		t.Errorf("Expected sensitive configs masked in detail: %v", e.Detail)
+	}
+	if e = entries[1]; e.Action != AuditAction_JobsCancel || e.Source != "unknown client" || e.Result != "Failed: Job 3 not found" {
+		t.Errorf("Unexpected entry of canceling jobs: %v", e)
+	}
+
+	// The entries are filtered by action, source and seq
+	for _, c := range []struct {
+		request  *pb.GetAuditLogRequest
+		expected int
+	}{
+		{&pb.GetAuditLogRequest{}, 2},
+		{&pb.GetAuditLogRequest{Action: "jobs"}, 1},
+		{&pb.GetAuditLogRequest{Action: "job"}, 0},
+		{&pb.GetAuditLogRequest{Source: "ALICE"}, 1},
+		{&pb.GetAuditLogRequest{SinceSeq: 1}, 1},
+		{&pb.GetAuditLogRequest{Limit: 1}, 1},
+		{&pb.GetAuditLogRequest{Until: entries[0].Time - 1}, 0},
+	} {
+		if reply, err := getAuditLog(c.request); err != nil || len(reply.Entries) != c.expected || !reply.Verified || reply.Count != 2 {
+			t.Errorf("Expected %v entries by %v, got %v, %v", c.expected, c.request, reply, err)
+		}
+	}
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	AuthTokenEnv       = "CLUSRUN_TOKEN"
+	AuthTokensNone     = "none"
+	authMetadataKey    = "authorization"
+	authTokenPrefix    = "Bearer "
+	authTokenSeparator = ";"
+	authRoleSeparator  = ":"
+	authTokenMinLength = 16
+)
+
+// The roles of clients, a role is allowed to call the RPCs allowed to the roles lower than it
+type authRole int
+
+const (
+	authRole_None authRole = iota // the RPCs called by nodes before being enrolled or authenticated by node keys in heartbeats
+	authRole_Reader
+	authRole_Operator
+	authRole_Admin
+)
+
+var (
+	authRoleNames = map[string]authRole{
+		"reader":   authRole_Reader,
+		"operator": authRole_Operator,
+		"admin":    authRole_Admin,
+	}
+
+	// The role required by each RPC, the RPCs not listed require admin
+	rpcRoles = map[string]authRole{
+		"/clusrun.Headnode/Heartbeat":              authRole_None,
+		"/clusrun.Headnode/HeartbeatStream":        authRole_None,
+		"/clusrun.Headnode/BatchHeartbeat":         authRole_None,
+		"/clusrun.Headnode/Tunnel":                 authRole_None,
+		"/clusrun.Headnode/GetCapabilities":        authRole_None,
+		"/clusrun.Headnode/GetNodes":               authRole_Reader,
+		"/clusrun.Headnode/GetJobs":                authRole_Reader,
+		"/clusrun.Headnode/StreamJobs":             authRole_Reader,
+		"/clusrun.Headnode/GetOutput":              authRole_Reader,
+		"/clusrun.Headnode/GetConfigs":             authRole_Reader,
+		"/clusrun.Headnode/GetClusterSummary":      authRole_Reader,
+		"/clusrun.Headnode/QueryResults":           authRole_Reader,
+		"/clusrun.Headnode/SearchOutput":           authRole_Reader,
+		"/clusrun.Headnode/ExportTimeline":         authRole_Reader,
+		"/clusrun.Headnode/SubscribeEvents":        authRole_Reader,
+		"/clusrun.Headnode/WatchNodes":             authRole_Reader,
+		"/clusrun.Headnode/GetNodeAvailability":    authRole_Reader,
+		"/clusrun.Headnode/WatchClusJob":           authRole_Reader,
+		"/clusrun.Headnode/StartClusJob":           authRole_Operator,
+		"/clusrun.Headnode/RerunJob":               authRole_Operator,
+		"/clusrun.Headnode/ForwardJobInput":        authRole_Operator,
+		"/clusrun.Headnode/CancelClusJobs":         authRole_Operator,
+		"/clusrun.Headnode/SetNodeGroups":          authRole_Operator,
+		"/clusrun.Headnode/SetNodeLabels":          authRole_Operator,
+		"/clusrun.Headnode/UploadFiles":            authRole_Operator,
+		"/clusrun.Headnode/GatherFiles":            authRole_Operator,
+		"/clusrun.Headnode/SetConfigs":             authRole_Admin,
+		"/clusrun.Headnode/GetConfigDrift":         authRole_Admin,
+		"/clusrun.Headnode/ResetNodeKeys":          authRole_Admin,
+		"/clusrun.Headnode/DrainNodes":             authRole_Operator,
+		"/clusrun.Headnode/RemoveNodes":            authRole_Admin,
+		"/clusrun.Headnode/RevalidateNodes":        authRole_Operator,
+		"/clusrun.Headnode/TestNodes":              authRole_Operator,
+		"/clusrun.Headnode/GetAuditLog":            authRole_Reader,
+		"/clusrun.Headnode/Undo":                   authRole_Operator,
+		"/clusrun.Headnode/SaveJobTemplate":        authRole_Operator,
+		"/clusrun.Headnode/GetJobTemplates":        authRole_Reader,
+		"/clusrun.Headnode/DeleteJobTemplates":     authRole_Operator,
+		"/clusrun.Headnode/SaveJobSchedule":        authRole_Operator,
+		"/clusrun.Headnode/GetJobSchedules":        authRole_Reader,
+		"/clusrun.Headnode/SetJobSchedulesEnabled": authRole_Operator,
+		"/clusrun.Headnode/DeleteJobSchedules":     authRole_Operator,
+		"/clusrun.Headnode/SaveJobBookmark":        authRole_Reader,
+		"/clusrun.Headnode/GetJobBookmarks":        authRole_Reader,
+		"/clusrun.Headnode/DeleteJobBookmarks":     authRole_Reader,
+		"/clusrun.Headnode/Shell":                  authRole_Admin,
+		"/clusrun.Headnode/PurgeJobs":              authRole_Admin,
+		"/clusrun.Headnode/ExportJobs":             authRole_Reader,
+		"/clusrun.Headnode/ImportJobs":             authRole_Admin,
+		"/clusrun.Headnode/GetLoginOptions":        authRole_None,
+		"/clusrun.Headnode/Login":                  authRole_Reader,
+		"/clusrun.Clusnode/Probe":                  authRole_None,
+		"/clusrun.Clusnode/Validate":               authRole_None,
+		"/clusrun.Clusnode/GetConfigs":             authRole_Reader,
+		"/clusrun.Clusnode/GetAuditLog":            authRole_Reader,
+		"/clusrun.Clusnode/SetConfigs":             authRole_Admin,
+		"/clusrun.Clusnode/SetHeadnodes":           authRole_Admin,
+	}
+
+	// The RPCs of clusnode called only by the headnodes it reports to, which are authenticated by the signatures of
+	// headnodes instead of the tokens
+	headnodeSignedRpcs = map[string]bool{
+		"/clusrun.Clusnode/StartJob":      true,
+		"/clusrun.Clusnode/WriteJobInput": true,
+		"/clusrun.Clusnode/CancelJob":     true,
+		"/clusrun.Clusnode/QueryJob":      true,
+		"/clusrun.Clusnode/ResumeJob":     true,
+		"/clusrun.Clusnode/RelayJob":      true,
+		"/clusrun.Clusnode/ApplyConfigs":  true,
+		"/clusrun.Clusnode/ReportConfigs": true,
+		"/clusrun.Clusnode/ReceiveFiles":  true,
+		"/clusrun.Clusnode/SendFiles":     true,
+		"/clusrun.Clusnode/Shell":         true,
+	}
+
+	authTokensValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		_, err := parseAuthTokens(v)
+		return err
+	}
+)
+
+func (r authRole) String() string {
+	for name, role := range authRoleNames {
+		if role == r {
+			return name
+		}
+	}
+	return "none"
+}
+
+// Parse the tokens in format "role:token[;role:token...]", empty for no authentication,
+// the role can be limited to a cluster in format "role@cluster"
+func parseAuthTokens(value string) (map[string]authScope, error) {
+	tokens := map[string]authScope{}
+	for _, item := range strings.Split(value, authTokenSeparator) {
+		if item = strings.TrimSpace(item); len(item) == 0 {
+			continue
+		}
+		i := strings.Index(item, authRoleSeparator)
+		if i < 0 {
+			return nil, fmt.Errorf("Missing role of token, expect format: role%vtoken", authRoleSeparator)
+		}
+		role, err := parseAuthScope(item[:i])
+		if err != nil {
+			return nil, err
+		}
+		token := item[i+1:]
+		if len(token) < authTokenMinLength {
+			return nil, fmt.Errorf("Token of role %v should have at least %v characters", role, authTokenMinLength)
+		}
+		if _, ok := tokens[token]; ok {
+			return nil, fmt.Errorf("Duplicate token of role %v", role)
+		}
+		tokens[token] = role
+	}
+	return tokens, nil
+}
+
+// Get the role of the token in the incoming metadata
+func getAuthRole(ctx context.Context, tokens map[string]authScope) (authScope, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return authScope{}, false
+	}
+	for _, v := range md.Get(authMetadataKey) {
+		if !strings.HasPrefix(v, authTokenPrefix) {
+			continue
+		}
+		given := []byte(strings.TrimPrefix(v, authTokenPrefix))
+		for token, role := range tokens {
+			if subtle.ConstantTimeCompare(given, []byte(token)) == 1 {
+				return role, true
+			}
+		}
+	}
+	return authScope{}, false
+}
+
+// Authenticate the credentials in the incoming metadata by the auth providers
+func getAuthIdentity(ctx context.Context) (*authIdentity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	err := errors.New("Missing credential")
+	for _, v := range md.Get(authMetadataKey) {
+		var identity *authIdentity
+		if identity, err = authenticateByProviders(v); err == nil {
+			return identity, nil
+		}
+	}
+	return nil, err
+}
+
+// Get the user and role of client by its session token, static token or credential verified by the auth providers,
+// the user is empty for a static token
+func authenticate(ctx context.Context, tokens map[string]authScope) (string, authScope, error) {
+	if session, ok := getAuthSession(ctx); ok {
+		return session.User, authScope{Role: session.Role, Cluster: session.Cluster}, nil
+	}
+	if role, ok := getAuthRole(ctx, tokens); ok {
+		return "", role, nil
+	}
+	if !isAuthProviderEnabled() {
+		return "", authScope{}, status.Error(codes.Unauthenticated, "Missing or invalid token")
+	}
+	identity, err := getAuthIdentity(ctx)
+	if err != nil {
+		LogWarning("Failed to authenticate: %v", err)
+		return "", authScope{}, status.Error(codes.Unauthenticated, "Missing or invalid credential")
+	}
+	role, ok := getIdentityRole(identity, parseAuthGroupRolesOrEmpty())
+	if !ok {
+		return identity.User, authScope{}, status.Errorf(codes.PermissionDenied, "User %v is not in any group with a role", identity.User)
+	}
+	return identity.User, role, nil
+}
+
+// Check the role of client for the RPC if any token or auth provider is configured,
+// and return the client authenticated, which is nil if no authentication is required
+func authorize(ctx context.Context, method string) (*authClient, error) {
+	if headnodeSignedRpcs[method] {
+		if len(getVerifiedHeadnode(ctx)) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "Call is not signed by headnode")
+		}
+		return nil, nil
+	}
+	tokens, err := parseAuthTokens(Config_Headnode_AuthTokens.GetString())
+	if err != nil {
+		LogError("Invalid auth tokens: %v", err)
+		return nil, status.Error(codes.Internal, "Invalid auth tokens")
+	}
+	if len(tokens) == 0 && !isAuthProviderEnabled() {
+		return nil, nil
+	}
+	required, ok := rpcRoles[method]
+	if !ok {
+		required = authRole_Admin
+	}
+	if required == authRole_None {
+		return nil, nil
+	}
+	client := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		client = p.Addr.String()
+	}
+	user, role, err := authenticate(ctx, tokens)
+	if len(user) > 0 {
+		client = fmt.Sprintf("%v (user %v)", client, user)
+	}
+	if err != nil {
+		LogContext(ctx).Warning("Rejected %v from %v: %v", method, client, status.Convert(err).Message())
+		return nil, err
+	}
+	if role.Role < required {
+		LogContext(ctx).Warning("Rejected %v from %v with role %v", method, client, role)
+		return nil, status.Errorf(codes.PermissionDenied, "Role %v is not allowed to call %v, which requires role %v", role, method, required)
+	}
+	if len(role.Cluster) > 0 && !clusterScopedRpcs[method] {
+		LogContext(ctx).Warning("Rejected %v from %v with role %v", method, client, role)
+		return nil, status.Errorf(codes.PermissionDenied, "Role %v is limited to cluster %v, which is not allowed to call %v", role, role.Cluster, method)
+	}
+	return &authClient{user: user, scope: role}, nil
+}
+
+// Verify the signature of headnode over the request for the RPCs called by headnodes, and set the headnode verified to
+// the context
+func authenticateHeadnode(ctx context.Context, method string, request interface{}) error {
+	headnode, err := verifyHeadnodeCall(ctx, method, request)
+	if err != nil {
+		client := "unknown"
+		if p, ok := peer.FromContext(ctx); ok {
+			client = p.Addr.String()
+		}
+		LogContext(ctx).Warning("Rejected %v from %v: %v", method, client, err)
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	setVerifiedHeadnode(ctx, headnode)
+	return nil
+}
+
+func authUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if headnodeSignedRpcs[info.FullMethod] {
+		ctx = withHeadnodeVerification(ctx)
+		if err := authenticateHeadnode(ctx, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+	}
+	client, err := authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(withAuthClient(ctx, client), req)
+}
+
+func authStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if headnodeSignedRpcs[info.FullMethod] {
+		// The signature of headnode is over the first request, so it is verified when the request is received
+		ss = &headnodeServerStream{ServerStream: ss, ctx: withHeadnodeVerification(ss.Context()), method: info.FullMethod}
+		return handler(srv, ss)
+	}
+	client, err := authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	if client != nil {
+		ss = &authServerStream{ServerStream: ss, ctx: withAuthClient(ss.Context(), client)}
+	}
+	return handler(srv, ss)
+}
+
+// The stream called by a headnode, which can't be replied before the signature over its first request is verified
+type headnodeServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	method   string
+	lock     sync.Mutex
+	received bool
+}
+
+func (s *headnodeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *headnodeServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.received {
+		return nil
+	}
+	s.received = true
+	return authenticateHeadnode(s.ctx, s.method, m)
+}
+
+func (s *headnodeServerStream) SendMsg(m interface{}) error {
+	if len(getVerifiedHeadnode(s.ctx)) == 0 {
+		return status.Error(codes.Unauthenticated, "Call is not signed by headnode")
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// The client authenticated by its user or token
+type authClient struct {
+	user  string // empty for a static token
+	scope authScope
+}
+
+func (c *authClient) String() string {
+	identity := "token of role " + c.scope.Role.String()
+	if len(c.user) > 0 {
+		identity = fmt.Sprintf("user %v with role %v", c.user, c.scope.Role)
+	}
+	if len(c.scope.Cluster) > 0 {
+		identity += " in cluster " + c.scope.Cluster
+	}
+	return identity
+}
+
+type authClientKey struct{}
+
+type verifiedHeadnodeKey struct{}
+
+// Attach the client and the cluster it is limited to
+func withAuthClient(ctx context.Context, client *authClient) context.Context {
+	if client == nil {
+		return ctx
+	}
+	return context.WithValue(withAuthCluster(ctx, client.scope.Cluster), authClientKey{}, client)
+}
+
+// Get the client authenticated, nil if authentication is disabled
+func getAuthClient(ctx context.Context) *authClient {
+	client, _ := ctx.Value(authClientKey{}).(*authClient)
+	return client
+}
+
+// The headnode whose signature of the call is verified, which is set when the first request of a stream is received
+type verifiedHeadnode struct {
+	lock     sync.Mutex
+	headnode string
+}
+
+// Attach the headnode to be verified for the call
+func withHeadnodeVerification(ctx context.Context) context.Context {
+	return context.WithValue(ctx, verifiedHeadnodeKey{}, &verifiedHeadnode{})
+}
+
+func setVerifiedHeadnode(ctx context.Context, headnode string) {
+	if v, ok := ctx.Value(verifiedHeadnodeKey{}).(*verifiedHeadnode); ok {
+		v.lock.Lock()
+		v.headnode = headnode
+		v.lock.Unlock()
+	}
+}
+
+// Get the headnode whose signature of the call is verified, empty if the call is not signed
+func getVerifiedHeadnode(ctx context.Context) string {
+	v, ok := ctx.Value(verifiedHeadnodeKey{}).(*verifiedHeadnode)
+	if !ok {
+		return ""
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.headnode
+}
+
+// Attach the token to the outgoing context of client
+func withAuthToken(ctx context.Context, token string) context.Context {
+	if len(token) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, authMetadataKey, authTokenPrefix+token)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"testing"
+)
+
+func Test_parseAuthTokens(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected map[string]authScope
+		valid    bool
+	}{
+		{"", map[string]authScope{}, true},
+		{"admin:0123456789abcdef", map[string]authScope{"0123456789abcdef": {Role: authRole_Admin}}, true},
+		{" Reader:0123456789abcdef ; operator:fedcba9876543210;", map[string]authScope{"0123456789abcdef": {Role: authRole_Reader}, "fedcba9876543210": {Role: authRole_Operator}}, true},
+		{"admin:0123456789:abcdef", map[string]authScope{"0123456789:abcdef": {Role: authRole_Admin}}, true},
+		{"operator@Team1:0123456789abcdef", map[string]authScope{"0123456789abcdef": {Role: authRole_Operator, Cluster: "team1"}}, true},
+		{"0123456789abcdef", nil, false},
+		{"root:0123456789abcdef", nil, false},
+		{"admin:short", nil, false},
+		{"admin:0123456789abcdef;reader:0123456789abcdef", nil, false},
+		{"operator@team 1:0123456789abcdef", nil, false},
+	}
+
+	for _, c := range cases {
+		tokens, err := parseAuthTokens(c.value)
+		if (err == nil) != c.valid || (err == nil && !reflect.DeepEqual(tokens, c.expected)) {
+			t.Errorf("\nvalue=%q\nexpected=%v, valid=%v\n  actual=%v, error=%v", c.value, c.expected, c.valid, tokens, err)
+		}
+	}
+}
+
+// Each RPC of headnode should have its role, instead of falling back to admin
+func Test_rpcRoles(t *testing.T) {
+	methods := pb.File_protobuf_clusrun_proto.Services().ByName("Headnode").Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := "/clusrun.Headnode/" + string(methods.Get(i).Name())
+		if _, ok := rpcRoles[method]; !ok {
+			t.Errorf("No role of %v", method)
+		}
+	}
+	if labels, groups := rpcRoles["/clusrun.Headnode/SetNodeLabels"], rpcRoles["/clusrun.Headnode/SetNodeGroups"]; labels != groups {
+		t.Errorf("Expected role %v of SetNodeLabels the same as SetNodeGroups, got %v", groups, labels)
+	}
+}
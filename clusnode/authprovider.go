@@ -0,0 +1,146 @@
+package main
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	authBasicPrefix  = "Basic "
+	authIdentityTtl  = 5 * time.Minute
+	authCacheMaxSize = 1000
+)
+
+// An auth provider verifies the credential of client with an external identity service, and returns the user and its groups
+type authProvider interface {
+	Name() string
+	Enabled() bool
+	// The prefix of the credential in metadata, e.g. "Basic " or "Bearer "
+	Scheme() string
+	Authenticate(credential string) (*authIdentity, error)
+}
+
+type authIdentity struct {
+	User   string
+	Groups []string
+	Expire time.Time
+}
+
+var (
+	authProviders = []authProvider{&ldapAuthProvider{}, &oidcAuthProvider{}}
+
+	// The identities authenticated recently, so that the identity service is not called in each RPC
+	authIdentities     = map[[sha256.Size]byte]*authIdentity{}
+	authIdentitiesLock sync.Mutex
+
+	authGroupRolesValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		_, err := parseAuthGroupRoles(v)
+		return err
+	}
+)
+
+// Parse the roles of groups in format "role:group[;role:group...]", a group can be given multiple roles and the highest one is used,
+// the role can be limited to a cluster in format "role@cluster"
+func parseAuthGroupRoles(value string) (map[string]authScope, error) {
+	roles := map[string]authScope{}
+	for _, item := range strings.Split(value, authTokenSeparator) {
+		if item = strings.TrimSpace(item); len(item) == 0 {
+			continue
+		}
+		i := strings.Index(item, authRoleSeparator)
+		if i < 0 {
+			return nil, fmt.Errorf("Missing role of group, expect format: role%vgroup", authRoleSeparator)
+		}
+		role, err := parseAuthScope(item[:i])
+		if err != nil {
+			return nil, err
+		}
+		group := strings.ToLower(strings.TrimSpace(item[i+1:]))
+		if len(group) == 0 {
+			return nil, fmt.Errorf("Empty group of role %v", role)
+		}
+		if role.higherThan(roles[group]) {
+			roles[group] = role
+		}
+	}
+	return roles, nil
+}
+
+func parseAuthGroupRolesOrEmpty() map[string]authScope {
+	roles, err := parseAuthGroupRoles(Config_Headnode_AuthGroupRoles.GetString())
+	if err != nil {
+		LogError("Invalid group roles: %v", err)
+	}
+	return roles
+}
+
+// The role of an identity is the highest role of its groups, the groups are matched ignoring case
+func getIdentityRole(identity *authIdentity, roles map[string]authScope) (authScope, bool) {
+	role := authScope{}
+	for _, group := range identity.Groups {
+		if r := roles[strings.ToLower(group)]; r.higherThan(role) {
+			role = r
+		}
+	}
+	return role, role.Role > authRole_None
+}
+
+func isAuthProviderEnabled() bool {
+	for _, p := range authProviders {
+		if p.Enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate the credential in metadata with the enabled provider of its scheme
+func authenticateByProviders(credential string) (*authIdentity, error) {
+	for _, p := range authProviders {
+		if !p.Enabled() || !strings.HasPrefix(credential, p.Scheme()) {
+			continue
+		}
+		key := sha256.Sum256([]byte(p.Name() + " " + credential))
+		authIdentitiesLock.Lock()
+		identity, ok := authIdentities[key]
+		authIdentitiesLock.Unlock()
+		if ok && time.Now().Before(identity.Expire) {
+			return identity, nil
+		}
+		identity, err := p.Authenticate(strings.TrimPrefix(credential, p.Scheme()))
+		if err != nil {
+			return nil, fmt.Errorf("%v authentication failed: %v", p.Name(), err)
+		}
+		if expire := time.Now().Add(authIdentityTtl); identity.Expire.IsZero() || identity.Expire.After(expire) {
+			identity.Expire = expire
+		}
+		cacheAuthIdentity(key, identity)
+		return identity, nil
+	}
+	return nil, errors.New("No auth provider for the credential")
+}
+
+func cacheAuthIdentity(key [sha256.Size]byte, identity *authIdentity) {
+	authIdentitiesLock.Lock()
+	defer authIdentitiesLock.Unlock()
+	if len(authIdentities) >= authCacheMaxSize {
+		now := time.Now()
+		for k, v := range authIdentities {
+			if now.After(v.Expire) {
+				delete(authIdentities, k)
+			}
+		}
+		if len(authIdentities) >= authCacheMaxSize {
+			authIdentities = map[[sha256.Size]byte]*authIdentity{}
+		}
+	}
+	authIdentities[key] = identity
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_getIdentityRole(t *testing.T) {
+	roles, err := parseAuthGroupRoles("reader:Users; operator:ops;admin:admins;reader:ops")
+	if err != nil {
+		t.Fatalf("failed to parse group roles: %v", err)
+	}
+	cases := []struct {
+		groups []string
+		role   authRole
+		ok     bool
+	}{
+		{nil, authRole_None, false},
+		{[]string{"others"}, authRole_None, false},
+		{[]string{"users"}, authRole_Reader, true},
+		{[]string{"Users", "OPS"}, authRole_Operator, true},
+		{[]string{"admins", "ops"}, authRole_Admin, true},
+	}
+	for _, c := range cases {
+		if role, ok := getIdentityRole(&authIdentity{Groups: c.groups}, roles); role.Role != c.role || ok != c.ok {
+			t.Errorf("\ngroups=%v\nexpected=%v, %v\n  actual=%v, %v", c.groups, c.role, c.ok, role, ok)
+		}
+	}
+	for _, invalid := range []string{"users", "writer:users", "admin:"} {
+		if _, err := parseAuthGroupRoles(invalid); err == nil {
+			t.Errorf("invalid group roles %q is parsed", invalid)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const availabilityRetention = 90 * 24 * time.Hour // the changes older than it are dropped once headnode starts
+
+var (
+	defaultAvailabilityWindows = []time.Duration{24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour}
+
+	nodeAvailability     = map[string][]availabilityChange{} // the changes of each node in order of time
+	nodeAvailabilityLock sync.Mutex
+)
+
+// A node changes between up and down, which is lost after its heartbeats time out
+type availabilityChange struct {
+	Node string
+	Time int64 // in unix nanoseconds
+	Up   bool
+}
+
+// Get the uptime of the nodes in the windows till now from the availability log
+func (s *headnode_server) GetNodeAvailability(ctx context.Context, in *pb.GetNodeAvailabilityRequest) (*pb.GetNodeAvailabilityReply, error) {
+	defer LogPanicBeforeExit()
+	pattern, err := regexp.Compile(in.GetPattern())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pattern %q: %v", in.GetPattern(), err)
+	}
+	cluster, err := getClientCluster(ctx, in.GetCluster())
+	if err != nil {
+		return nil, err
+	}
+	windows := defaultAvailabilityWindows
+	if len(in.GetWindowSeconds()) > 0 {
+		windows = make([]time.Duration, len(in.GetWindowSeconds()))
+		for i, seconds := range in.GetWindowSeconds() {
+			if windows[i] = time.Duration(seconds) * time.Second; windows[i] <= 0 || windows[i] > availabilityRetention {
+				return nil, status.Errorf(codes.InvalidArgument, "Invalid window %v seconds, should be positive and not longer than %v", seconds, availabilityRetention)
+			}
+		}
+	}
+	clusters := parseNodeClustersOrEmpty()
+	now := time.Now()
+	reply := &pb.GetNodeAvailabilityReply{}
+	nodeAvailabilityLock.Lock()
+	defer nodeAvailabilityLock.Unlock()
+	for _, record := range ReportedNodes.Snapshot() {
+		node := record.name
+		if !pattern.MatchString(node) || len(cluster) > 0 && getNodeCluster(clusters, node) != cluster {
+			continue
+		}
+		reply.Nodes = append(reply.Nodes, getNodeAvailability(node, nodeAvailability[node], windows, now))
+	}
+	sort.Slice(reply.Nodes, func(i, j int) bool { return reply.Nodes[i].Node < reply.Nodes[j].Node })
+	LogSampled("GetNodeAvailability", "returned availability of %v nodes", len(reply.Nodes))
+	return reply, nil
+}
+
+func getNodeAvailability(node string, changes []availabilityChange, windows []time.Duration, now time.Time) *pb.NodeAvailability {
+	availability := &pb.NodeAvailability{Node: node}
+	if len(changes) > 0 {
+		last := changes[len(changes)-1]
+		availability.Up, availability.Since = last.Up, last.Time
+	}
+	for _, window := range windows {
+		availability.Uptimes = append(availability.Uptimes, computeNodeUptime(changes, window, now))
+	}
+	return availability
+}
+
+// Compute the uptime of a node in the window till now from its changes, the time before its first change is unknown
+// thus not counted
+func computeNodeUptime(changes []availabilityChange, window time.Duration, now time.Time) *pb.NodeUptime {
+	start, end := now.Add(-window).UnixNano(), now.UnixNano()
+	uptime := &pb.NodeUptime{WindowSeconds: int64(window / time.Second), Percent: -1}
+	var up, known int64
+	for i, c := range changes {
+		from, to := c.Time, end
+		if i+1 < len(changes) {
+			to = changes[i+1].Time
+		}
+		if from < start {
+			from = start
+		} else if !c.Up {
+			uptime.Downs++
+		}
+		if to <= from {
+			continue
+		}
+		known += to - from
+		if c.Up {
+			up += to - from
+		}
+	}
+	if known > 0 {
+		uptime.Percent = float64(up) * 100 / float64(known)
+		uptime.DownSeconds = int64(time.Duration(known-up) / time.Second)
+	}
+	return uptime
+}
+
+// Get the nodes changing between up and down since their last changes, a node is down since its last report once it
+// is lost, and the nodes without changes recorded start with their current states
+func getAvailabilityChanges(history map[string][]availabilityChange, states map[string]pb.NodeState, last_reports map[string]time.Time, now time.Time) []availabilityChange {
+	changes := []availabilityChange{}
+	for node, state := range states {
+		up := state != pb.NodeState_Lost
+		t := now.UnixNano()
+		if !up && !last_reports[node].IsZero() {
+			t = last_reports[node].UnixNano()
+		}
+		if h := history[node]; len(h) > 0 {
+			if last := h[len(h)-1]; last.Up == up {
+				continue
+			} else if t < last.Time {
+				t = last.Time
+			}
+		}
+		changes = append(changes, availabilityChange{Node: node, Time: t, Up: up})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Node < changes[j].Node })
+	return changes
+}
+
+// Record the changes of the node states scanned to the availability log
+func recordNodeAvailability(states map[string]pb.NodeState, last_reports map[string]time.Time) {
+	nodeAvailabilityLock.Lock()
+	defer nodeAvailabilityLock.Unlock()
+	changes := getAvailabilityChanges(nodeAvailability, states, last_reports, time.Now())
+	if len(changes) == 0 {
+		return
+	}
+	for _, c := range changes {
+		nodeAvailability[c.Node] = append(nodeAvailability[c.Node], c)
+	}
+	if err := appendAvailabilityChanges(changes); err != nil {
+		LogError("Failed to append %v changes to availability log: %v", len(changes), err)
+	}
+}
+
+// The availability log is a file of changes in json lines, which are only appended while headnode is running
+func appendAvailabilityChanges(changes []availabilityChange) error {
+	f, err := os.OpenFile(db_availability, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(formatAvailabilityChanges(changes))
+	return err
+}
+
+func formatAvailabilityChanges(changes []availabilityChange) []byte {
+	var b bytes.Buffer
+	for _, c := range changes {
+		json_string, _ := json.Marshal(c)
+		b.Write(append(json_string, '\n'))
+	}
+	return b.Bytes()
+}
+
+func readAvailabilityLog(file string) ([]availabilityChange, error) {
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	changes := []availabilityChange{}
+	reader := bufio.NewReader(f)
+	for line := 1; ; line++ {
+		b, err := reader.ReadBytes('\n')
+		if b = bytes.TrimSpace(b); len(b) > 0 {
+			var c availabilityChange
+			if err := json.Unmarshal(b, &c); err != nil {
+				return changes, fmt.Errorf("Failed to parse line %v: %v", line, err)
+			}
+			changes = append(changes, c)
+		}
+		if err == io.EOF {
+			return changes, nil
+		} else if err != nil {
+			return changes, err
+		}
+	}
+}
+
+// Drop the changes before the cutoff, except the last one of each node which tells its state since then
+func compactAvailabilityChanges(changes []availabilityChange, cutoff int64) []availabilityChange {
+	last_before := map[string]int{}
+	for i, c := range changes {
+		if c.Time < cutoff {
+			last_before[c.Node] = i
+		}
+	}
+	compacted := make([]availabilityChange, 0, len(changes))
+	for i, c := range changes {
+		if j, ok := last_before[c.Node]; !ok || i >= j {
+			compacted = append(compacted, c)
+		}
+	}
+	return compacted
+}
+
+// Load the availability log and drop the changes out of retention from it
+func loadAvailabilityLog() {
+	changes, err := readAvailabilityLog(db_availability)
+	if err != nil {
+		LogError("Failed to load availability log %v: %v", db_availability, err)
+	}
+	compacted := compactAvailabilityChanges(changes, time.Now().Add(-availabilityRetention).UnixNano())
+	// The broken log is kept as is
+	if err == nil && len(compacted) < len(changes) {
+		if err := ioutil.WriteFile(db_availability, formatAvailabilityChanges(compacted), 0644); err != nil {
+			LogError("Failed to compact availability log: %v", err)
+		}
+	}
+	nodeAvailabilityLock.Lock()
+	defer nodeAvailabilityLock.Unlock()
+	nodeAvailability = map[string][]availabilityChange{}
+	for _, c := range compacted {
+		nodeAvailability[c.Node] = append(nodeAvailability[c.Node], c)
+	}
+	LogInfo("Loaded %v changes of availability log", len(compacted))
+}
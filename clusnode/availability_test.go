@@ -0,0 +1,79 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func Test_computeNodeUptime(t *testing.T) {
+	now := time.Unix(100000, 0)
+	at := func(seconds int64) int64 { return now.Add(-time.Duration(seconds) * time.Second).UnixNano() }
+	changes := []availabilityChange{
+		{Node: "n1", Time: at(5000), Up: true},
+		{Node: "n1", Time: at(3000), Up: false},
+		{Node: "n1", Time: at(2000), Up: true},
+		{Node: "n1", Time: at(500), Up: false},
+		{Node: "n1", Time: at(100), Up: true},
+	}
+	cases := []struct {
+		window   time.Duration
+		expected *pb.NodeUptime
+	}{
+		{time.Hour, &pb.NodeUptime{WindowSeconds: 3600, Percent: float64(2200) * 100 / 3600, Downs: 2, DownSeconds: 1400}},
+		{1000 * time.Second, &pb.NodeUptime{WindowSeconds: 1000, Percent: 60, Downs: 1, DownSeconds: 400}},
+		{2 * time.Hour, &pb.NodeUptime{WindowSeconds: 7200, Percent: 72, Downs: 2, DownSeconds: 1400}},
+	}
+	for _, c := range cases {
+		if uptime := computeNodeUptime(changes, c.window, now); !proto.Equal(uptime, c.expected) {
+			t.Errorf("Expected uptime %v in window %v, got %v", c.expected, c.window, uptime)
+		}
+	}
+	if uptime := computeNodeUptime(nil, time.Hour, now); uptime.Percent != -1 {
+		t.Errorf("Expected unknown uptime without changes, got %v", uptime)
+	}
+	if uptime := computeNodeUptime(changes[:1], 1000*time.Second, now); uptime.Percent != 100 || uptime.Downs != 0 {
+		t.Errorf("Expected full uptime since the change before window, got %v", uptime)
+	}
+}
+
+func Test_availabilityLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusrun")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	db_availability = filepath.Join(dir, "headnode.availability")
+	nodeAvailability = map[string][]availabilityChange{}
+	last_report := time.Now().Add(-time.Minute)
+	reports := map[string]time.Time{"n1": last_report, "n2": last_report}
+	recordNodeAvailability(map[string]pb.NodeState{"n1": pb.NodeState_Ready, "n2": pb.NodeState_Error}, reports)
+	recordNodeAvailability(map[string]pb.NodeState{"n1": pb.NodeState_Ready, "n2": pb.NodeState_Draining}, reports)
+	recordNodeAvailability(map[string]pb.NodeState{"n1": pb.NodeState_Lost, "n2": pb.NodeState_Ready}, reports)
+	changes, err := readAvailabilityLog(db_availability)
+	if err != nil || len(changes) != 3 {
+		t.Fatalf("Expected 3 changes in availability log, got %v, %v", changes, err)
+	}
+	// The lost node is down since its last report, which is not earlier than its last change
+	if c := changes[2]; c.Node != "n1" || c.Up || c.Time != changes[0].Time {
+		t.Errorf("Expected node n1 down since its last change, got %+v", c)
+	}
+
+	// The changes out of retention are dropped except the last one of each node once loaded
+	old := time.Now().Add(-availabilityRetention - time.Hour).UnixNano()
+	if err := appendAvailabilityChanges([]availabilityChange{{Node: "n3", Time: old, Up: true}, {Node: "n3", Time: old + 1, Up: false}}); err != nil {
+		t.Fatalf("Failed to append changes: %v", err)
+	}
+	loadAvailabilityLog()
+	if changes, err = readAvailabilityLog(db_availability); err != nil || len(changes) != 4 {
+		t.Errorf("Expected 4 changes in availability log after compacted, got %v, %v", changes, err)
+	}
+	if h := nodeAvailability["n3"]; len(h) != 1 || h[0].Up || len(nodeAvailability["n1"]) != 2 {
+		t.Errorf("Unexpected availability loaded %v", nodeAvailability)
+	}
+}
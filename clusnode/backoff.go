@@ -0,0 +1,85 @@
+package main
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	backoffBase   = time.Second
+	backoffJitter = 0.2 // the delays are randomized by 20% so that the retries to or from many nodes are spread
+
+	reconnectBackoffMax = 10 * time.Second // the max delay of clusnode to reconnect the headnode after heartbeat failures
+)
+
+var (
+	connectFailures sync.Map // host -> *connectFailure, the hosts failed to connect recently
+)
+
+// The delays between retries growing exponentially from the base up to the max, randomized by the jitter
+type backoff struct {
+	base   time.Duration
+	max    time.Duration
+	jitter float64
+}
+
+// Get the delay before the next retry after the failures
+func (b backoff) delay(failures int) time.Duration {
+	delay := b.base
+	for i := 0; i < failures && delay < b.max; i++ {
+		delay *= 2
+	}
+	if delay > b.max {
+		delay = b.max
+	}
+	if b.jitter > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * b.jitter * float64(delay))
+	}
+	return delay
+}
+
+// The backoff of headnode to validate and connect clusnodes after failures
+func getNodeBackoff() backoff {
+	return backoff{base: backoffBase, max: time.Duration(Config_Headnode_MaxBackoffSecond.GetInt()) * time.Second, jitter: backoffJitter}
+}
+
+func getReconnectBackoff() backoff {
+	return backoff{base: backoffBase, max: reconnectBackoffMax, jitter: backoffJitter}
+}
+
+// The consecutive failures to connect a host and the time before which it is not connected again
+type connectFailure struct {
+	failures int
+	until    time.Time
+	lock     sync.Mutex
+}
+
+// Get the remaining time the host is not connected after its failures
+func getConnectBackoff(host string) time.Duration {
+	if val, ok := connectFailures.Load(host); ok {
+		f := val.(*connectFailure)
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		if wait := time.Until(f.until); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+func recordConnectFailure(host string) time.Duration {
+	val, _ := connectFailures.LoadOrStore(host, &connectFailure{})
+	f := val.(*connectFailure)
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delay := getNodeBackoff().delay(f.failures)
+	f.failures++
+	f.until = time.Now().Add(delay)
+	return delay
+}
+
+// Connect the host immediately next time, e.g. after it is connected or reports again
+func resetConnectFailures(host string) {
+	connectFailures.Delete(host)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_backoff(t *testing.T) {
+	b := backoff{base: time.Second, max: 10 * time.Second, jitter: 0.2}
+	cases := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{3, 8 * time.Second},
+		{4, 10 * time.Second},
+		{100, 10 * time.Second},
+	}
+	for _, c := range cases {
+		for i := 0; i < 10; i++ {
+			if delay := b.delay(c.failures); delay < c.expected*8/10 || delay > c.expected*12/10 {
+				t.Errorf("Expected delay after %v failures within 20%% of %v, got %v", c.failures, c.expected, delay)
+			}
+		}
+	}
+	if delay := (backoff{base: time.Second, max: time.Minute}).delay(2); delay != 4*time.Second {
+		t.Errorf("Expected delay without jitter 4s, got %v", delay)
+	}
+	host := "BACKOFF-HOST:50505"
+	defer resetConnectFailures(host)
+	if wait := getConnectBackoff(host); wait != 0 {
+		t.Errorf("Expected no backoff before failures, got %v", wait)
+	}
+	first, second := recordConnectFailure(host), recordConnectFailure(host)
+	if wait := getConnectBackoff(host); wait <= 0 || wait > second || second <= first {
+		t.Errorf("Expected the backoff growing after failures, got %v %v and waiting %v", first, second, wait)
+	}
+	resetConnectFailures(host)
+	if wait := getConnectBackoff(host); wait != 0 {
+		t.Errorf("Expected no backoff after reset, got %v", wait)
+	}
+}
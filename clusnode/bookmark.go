@@ -0,0 +1,146 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *headnode_server) SaveJobBookmark(ctx context.Context, in *pb.SaveJobBookmarkRequest) (*pb.Empty, error) {
+	defer LogPanicBeforeExit()
+	user, bookmark := in.GetUser(), in.GetBookmark()
+	if err := validateJobBookmark(user, bookmark); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := GetJob(bookmark.JobId); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	db_bookmarksLock.Lock()
+	defer db_bookmarksLock.Unlock()
+	bookmarks, err := loadJobBookmarks()
+	if err != nil {
+		LogError("Failed to load job bookmarks: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if _, ok := bookmarks[user]; !ok {
+		bookmarks[user] = map[string]*pb.JobBookmark{}
+	}
+	bookmarks[user][bookmark.Name] = &pb.JobBookmark{Name: bookmark.Name, JobId: bookmark.JobId, CreateTime: time.Now().Unix()}
+	if err := saveJobBookmarks(bookmarks); err != nil {
+		LogError("Failed to save job bookmarks: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	LogInfo("Job %v is bookmarked as %v by user %v", bookmark.JobId, bookmark.Name, user)
+	return &pb.Empty{}, nil
+}
+
+func (s *headnode_server) GetJobBookmarks(ctx context.Context, in *pb.GetJobBookmarksRequest) (*pb.GetJobBookmarksReply, error) {
+	defer LogPanicBeforeExit()
+	db_bookmarksLock.Lock()
+	defer db_bookmarksLock.Unlock()
+	bookmarks, err := loadJobBookmarks()
+	if err != nil {
+		LogError("Failed to load job bookmarks: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	owned := bookmarks[in.GetUser()]
+	reply := &pb.GetJobBookmarksReply{}
+	if names := in.GetNames(); len(names) > 0 {
+		var not_found []string
+		for _, name := range names {
+			if b, ok := owned[name]; ok {
+				reply.Bookmarks = append(reply.Bookmarks, b)
+			} else {
+				not_found = append(not_found, name)
+			}
+		}
+		if len(not_found) > 0 {
+			return nil, status.Errorf(codes.NotFound, "Job bookmarks not found: %v", not_found)
+		}
+	} else {
+		for _, b := range owned {
+			reply.Bookmarks = append(reply.Bookmarks, b)
+		}
+		sort.Slice(reply.Bookmarks, func(i, j int) bool { return reply.Bookmarks[i].Name < reply.Bookmarks[j].Name })
+	}
+	return reply, nil
+}
+
+func (s *headnode_server) DeleteJobBookmarks(ctx context.Context, in *pb.DeleteJobBookmarksRequest) (*pb.DeleteJobBookmarksReply, error) {
+	defer LogPanicBeforeExit()
+	db_bookmarksLock.Lock()
+	defer db_bookmarksLock.Unlock()
+	bookmarks, err := loadJobBookmarks()
+	if err != nil {
+		LogError("Failed to load job bookmarks: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	user := in.GetUser()
+	owned := bookmarks[user]
+	reply := &pb.DeleteJobBookmarksReply{}
+	for _, name := range in.GetNames() {
+		if _, ok := owned[name]; ok {
+			delete(owned, name)
+			reply.Deleted = append(reply.Deleted, name)
+		} else {
+			reply.NotFound = append(reply.NotFound, name)
+		}
+	}
+	if len(reply.Deleted) > 0 {
+		if len(owned) == 0 {
+			delete(bookmarks, user)
+		}
+		if err := saveJobBookmarks(bookmarks); err != nil {
+			LogError("Failed to save job bookmarks: %v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	LogInfo("DeleteJobBookmarks of user %v result: deleted %v, not found %v", user, reply.Deleted, reply.NotFound)
+	return reply, nil
+}
+
+func validateJobBookmark(user string, b *pb.JobBookmark) error {
+	if len(strings.TrimSpace(user)) == 0 {
+		return errors.New("User is required to bookmark job")
+	}
+	if !templateNamePattern.MatchString(b.GetName()) {
+		return fmt.Errorf("Invalid bookmark name %q, which should only contain letters, digits, '_', '-' and '.'", b.GetName())
+	}
+	if b.GetJobId() <= 0 {
+		return fmt.Errorf("Invalid job id %v", b.GetJobId())
+	}
+	return nil
+}
+
+// The bookmarks file is created when the first bookmark is saved, in which bookmarks are grouped by user
+func loadJobBookmarks() (map[string]map[string]*pb.JobBookmark, error) {
+	bookmarks := map[string]map[string]*pb.JobBookmark{}
+	json_string, err := ioutil.ReadFile(db_bookmarks)
+	if os.IsNotExist(err) {
+		return bookmarks, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(json_string, &bookmarks); err != nil {
+		return nil, err
+	}
+	return bookmarks, nil
+}
+
+func saveJobBookmarks(bookmarks map[string]map[string]*pb.JobBookmark) error {
+	json_string, err := json.MarshalIndent(bookmarks, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(db_bookmarks, json_string, 0644)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"fmt"
+)
+
+const (
+	// The protocol version is bumped when a change of RPCs between headnode and clusnode is not compatible with the previous one,
+	// e.g. a headnode relying on a new field of clusnode reply
+	ProtocolVersion       = 2
+	MinProtocolVersion    = 1 // the oldest protocol of peers this build works with
+	legacyProtocolVersion = 1 // the protocol of builds not reporting it
+)
+
+var (
+	// The version of this build, set by -ldflags "-X main.ClusnodeVersion=<version>" when releasing
+	ClusnodeVersion = "dev"
+)
+
+func getNodeBuild() *pb.NodeBuild {
+	return &pb.NodeBuild{Version: ClusnodeVersion, Protocol: ProtocolVersion}
+}
+
+func getBuildProtocol(build *pb.NodeBuild) int32 {
+	if protocol := build.GetProtocol(); protocol > 0 {
+		return protocol
+	}
+	return legacyProtocolVersion
+}
+
+func formatNodeBuild(build *pb.NodeBuild) string {
+	version := build.GetVersion()
+	if len(version) == 0 {
+		version = "unknown"
+	}
+	return fmt.Sprintf("%v (protocol %v)", version, getBuildProtocol(build))
+}
+
+// Check if the peer can work with this build, return an error if it is incompatible and a warning if it may not support all features.
+// A peer of a different version is incompatible if the same version is required.
+func checkNodeBuild(build *pb.NodeBuild, require_same_version bool) (string, error) {
+	protocol := getBuildProtocol(build)
+	if protocol < MinProtocolVersion {
+		return "", fmt.Errorf("Protocol %v of build %v is older than the min protocol %v supported by %v", protocol, build.GetVersion(), MinProtocolVersion, ClusnodeVersion)
+	}
+	if require_same_version && build.GetVersion() != ClusnodeVersion {
+		return "", fmt.Errorf("Build %v is different from %v", formatNodeBuild(build), formatNodeBuild(getNodeBuild()))
+	}
+	if protocol < ProtocolVersion {
+		return fmt.Sprintf("Build %v is older than %v, features of the new protocol are not supported", formatNodeBuild(build), formatNodeBuild(getNodeBuild())), nil
+	} else if protocol > ProtocolVersion {
+		return fmt.Sprintf("Build %v is newer than %v, it may use features not supported", formatNodeBuild(build), formatNodeBuild(getNodeBuild())), nil
+	} else if build.GetVersion() != ClusnodeVersion {
+		return fmt.Sprintf("Build %v is different from %v", formatNodeBuild(build), formatNodeBuild(getNodeBuild())), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"testing"
+)
+
+func Test_checkNodeBuild(t *testing.T) {
+	cases := []struct {
+		build                *pb.NodeBuild
+		require_same_version bool
+		warning              bool
+		err                  bool
+	}{
+		{getNodeBuild(), false, false, false},
+		{getNodeBuild(), true, false, false},
+		{nil, false, ProtocolVersion > legacyProtocolVersion, legacyProtocolVersion < MinProtocolVersion},
+		{&pb.NodeBuild{Version: ClusnodeVersion + ".1", Protocol: ProtocolVersion}, false, true, false},
+		{&pb.NodeBuild{Version: ClusnodeVersion + ".1", Protocol: ProtocolVersion}, true, false, true},
+		{&pb.NodeBuild{Version: ClusnodeVersion, Protocol: ProtocolVersion + 1}, false, true, false},
+	}
+
+	for _, c := range cases {
+		warning, err := checkNodeBuild(c.build, c.require_same_version)
+		if len(warning) > 0 != c.warning || (err != nil) != c.err {
+			t.Errorf("\nbuild=%v, require_same_version=%v\nexpected warning=%v, error=%v\n  actual warning=%q, error=%v", formatNodeBuild(c.build), c.require_same_version, c.warning, c.err, warning, err)
+		}
+	}
+}
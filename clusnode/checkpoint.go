@@ -0,0 +1,170 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	CheckpointEnv            = "CLUSRUN_CHECKPOINT_DIR"
+	checkpointShipInterval   = 10 * time.Second
+	maxShippedCheckpointSize = 3 << 20 // keep the snapshot under the default max grpc message size
+)
+
+var (
+	checkpointNamePattern = regexp.MustCompile(`^[\w.-]+$`)
+)
+
+// The checkpoint of a job shared by tasks on all nodes, the latest snapshot of each node is kept for shipping on reschedule
+type taskCheckpoint struct {
+	name      string
+	ship      bool
+	snapshots sync.Map // node -> []byte
+}
+
+func newTaskCheckpoint(name string, ship bool) *taskCheckpoint {
+	return &taskCheckpoint{name: name, ship: ship}
+}
+
+func (c *taskCheckpoint) Snapshot(node string) []byte {
+	if c == nil || !c.ship {
+		return nil
+	}
+	if data, ok := c.snapshots.Load(node); ok {
+		return data.([]byte)
+	}
+	return nil
+}
+
+func ValidateCheckpointName(name string) error {
+	if len(name) > 0 && (!checkpointNamePattern.MatchString(name) || strings.Trim(name, ".") == "") {
+		return fmt.Errorf("Invalid checkpoint name %q", name)
+	}
+	return nil
+}
+
+// The checkpoint dir is kept across jobs from the same headnode with the same checkpoint name
+func getCheckpointDir(headnode, checkpoint string) string {
+	return filepath.Join(db_checkpointDir, FileNameFormatHost(headnode)+"."+checkpoint)
+}
+
+// Return a value changing with any file in the checkpoint dir
+func getCheckpointVersion(dir string) string {
+	var count, size, latest int64
+	_ = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		count++
+		size += info.Size()
+		if t := info.ModTime().UnixNano(); t > latest {
+			latest = t
+		}
+		return nil
+	})
+	return fmt.Sprintf("%v.%v.%v", count, size, latest)
+}
+
+func packCheckpoint(dir string) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if !info.Mode().IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if _, err := io.Copy(tw, f); err != nil {
+				return err
+			}
+		}
+		if buf.Len() > maxShippedCheckpointSize {
+			return errors.New("Checkpoint is too large to ship")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	if buf.Len() > maxShippedCheckpointSize {
+		return nil, errors.New("Checkpoint is too large to ship")
+	}
+	return buf.Bytes(), nil
+}
+
+func unpackCheckpoint(dir string, data []byte) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("Invalid file %q in checkpoint", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode)&0777)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+)
+
+// The checksum of the stdout and stderr of a task, computed on the clusnode for the output sent
+// and on the headnode for the output stored, which is not thread safe
+type outputChecksum struct {
+	stdout, stderr         hash.Hash
+	stdoutSize, stderrSize int64
+}
+
+func newOutputChecksum() *outputChecksum {
+	return &outputChecksum{stdout: sha256.New(), stderr: sha256.New()}
+}
+
+func (c *outputChecksum) Add(stdout, stderr []byte) {
+	_, _ = c.stdout.Write(stdout)
+	_, _ = c.stderr.Write(stderr)
+	c.stdoutSize += int64(len(stdout))
+	c.stderrSize += int64(len(stderr))
+}
+
+func (c *outputChecksum) Sum() *pb.OutputChecksum {
+	return &pb.OutputChecksum{
+		StdoutSha256: hex.EncodeToString(c.stdout.Sum(nil)),
+		StderrSha256: hex.EncodeToString(c.stderr.Sum(nil)),
+		StdoutSize:   c.stdoutSize,
+		StderrSize:   c.stderrSize,
+	}
+}
+
+// Compute the checksum of the output stored in files, which are read from the beginning without changing the write offset
+func checksumOutputFiles(f_out, f_err *os.File) (*pb.OutputChecksum, error) {
+	c := newOutputChecksum()
+	for _, f := range []struct {
+		file *os.File
+		hash hash.Hash
+		size *int64
+	}{{f_out, c.stdout, &c.stdoutSize}, {f_err, c.stderr, &c.stderrSize}} {
+		info, err := f.file.Stat()
+		if err != nil {
+			return nil, err
+		}
+		if *f.size, err = io.Copy(f.hash, io.NewSectionReader(f.file, 0, info.Size())); err != nil {
+			return nil, err
+		}
+	}
+	return c.Sum(), nil
+}
+
+// Return an error describing the streams whose output is different from the expected one
+func verifyOutputChecksum(expected, actual *pb.OutputChecksum) error {
+	var mismatches []string
+	check := func(name string, expected_size, actual_size int64, expected_sum, actual_sum string) {
+		if expected_size != actual_size {
+			mismatches = append(mismatches, fmt.Sprintf("%v has %v bytes instead of %v", name, actual_size, expected_size))
+		} else if expected_sum != actual_sum {
+			mismatches = append(mismatches, fmt.Sprintf("%v has sha256 %v instead of %v", name, actual_sum, expected_sum))
+		}
+	}
+	check("stdout", expected.GetStdoutSize(), actual.GetStdoutSize(), expected.GetStdoutSha256(), actual.GetStdoutSha256())
+	check("stderr", expected.GetStderrSize(), actual.GetStderrSize(), expected.GetStderrSha256(), actual.GetStderrSha256())
+	if len(mismatches) > 0 {
+		return fmt.Errorf("Output checksum mismatch: %v", strings.Join(mismatches, ", "))
+	}
+	return nil
+}
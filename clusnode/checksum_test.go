@@ -0,0 +1,51 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_verifyOutputChecksum(t *testing.T) {
+	sent := newOutputChecksum()
+	sent.Add([]byte("hello "), nil)
+	sent.Add([]byte("world"), []byte("error"))
+	expected := sent.Sum()
+
+	dir, err := ioutil.TempDir("", "clusnode-checksum")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	create := func(name, content string) *os.File {
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to create file: %v", err)
+		}
+		if _, err := f.WriteString(content); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		return f
+	}
+	f_out, f_err := create("stdout", "hello world"), create("stderr", "error")
+	defer f_out.Close()
+	defer f_err.Close()
+	if actual, err := checksumOutputFiles(f_out, f_err); err != nil {
+		t.Errorf("failed to compute checksum of files: %v", err)
+	} else if err := verifyOutputChecksum(expected, actual); err != nil {
+		t.Errorf("unexpected mismatch: %v", err)
+	}
+
+	truncated := newOutputChecksum()
+	truncated.Add([]byte("hello "), []byte("error"))
+	if err := verifyOutputChecksum(expected, truncated.Sum()); err == nil || !strings.Contains(err.Error(), "stdout has 6 bytes instead of 11") || strings.Contains(err.Error(), "stderr") {
+		t.Errorf("unexpected error of truncated output: %v", err)
+	}
+	corrupted := newOutputChecksum()
+	corrupted.Add([]byte("hello world"), []byte("errer"))
+	if err := verifyOutputChecksum(expected, corrupted.Sum()); err == nil || !strings.Contains(err.Error(), "stderr has sha256") {
+		t.Errorf("unexpected error of corrupted output: %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"clusrun/clusnode/platform"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ScratchEnv = "CLUSRUN_SCRATCH_DIR"
+
+	cleanupPeriod      = 10 * time.Minute
+	scratchEndedSuffix = ".ended" // the marker of an ended job next to its scratch dir, which keeps the retention of the job
+)
+
+var (
+	// The labels of the jobs not ended on this clusnode, whose files are never cleaned up
+	activeJobFiles     = map[string]bool{}
+	activeJobFilesLock sync.Mutex
+)
+
+// A scratch dir or command file left by an ended job
+type cleanupItem struct {
+	path      string
+	marker    string        // the marker removed with the item
+	ended     time.Time     // the time the job ended, or the modified time of an orphaned item
+	retention time.Duration // 0 for the default retention
+}
+
+func getScratchDir(job_label string) string {
+	return filepath.Join(db_scratchDir, job_label)
+}
+
+// Mark the files of the job active before creating them, so that they are not cleaned up until the job ends
+func beginJobFiles(job_label string) {
+	activeJobFilesLock.Lock()
+	defer activeJobFilesLock.Unlock()
+	activeJobFiles[job_label] = true
+}
+
+// The scratch dir is kept for the retention after the job ends, which is recorded in the marker
+func endJobFiles(job_label string, retention_second int32) {
+	activeJobFilesLock.Lock()
+	defer activeJobFilesLock.Unlock()
+	delete(activeJobFiles, job_label)
+	dir := getScratchDir(job_label)
+	if _, err := os.Stat(dir); err != nil {
+		return
+	}
+	retention := ""
+	if retention_second > 0 {
+		retention = strconv.Itoa(int(retention_second))
+	}
+	if err := ioutil.WriteFile(dir+scratchEndedSuffix, []byte(retention), 0644); err != nil {
+		LogWarning("Failed to mark scratch dir of job %v ended: %v", job_label, err)
+	}
+}
+
+// Create the scratch dir of the job, which is owned by the user to run job as
+func prepareScratchDir(job_label, run_as string) (string, error) {
+	dir := getScratchDir(job_label)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	if len(run_as) > 0 {
+		if err := platform.ChownToUser(dir, run_as); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+// A file belongs to an active job if it is named by the label of the job, with or without an extension
+func isJobFileActive(name string) bool {
+	for label := range activeJobFiles {
+		if name == label || strings.HasPrefix(name, label+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Collect the scratch dirs and command files of the ended jobs, including the ones orphaned by a crash of clusnode
+func collectCleanupItems() []cleanupItem {
+	activeJobFilesLock.Lock()
+	defer activeJobFilesLock.Unlock()
+	items := []cleanupItem{}
+	files, err := ioutil.ReadDir(db_scratchDir)
+	if err != nil {
+		LogWarning("Failed to read scratch dir: %v", err)
+	}
+	markers := map[string]os.FileInfo{}
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), scratchEndedSuffix) {
+			markers[strings.TrimSuffix(f.Name(), scratchEndedSuffix)] = f
+		}
+	}
+	for _, f := range files {
+		if !f.IsDir() || activeJobFiles[f.Name()] {
+			continue
+		}
+		item := cleanupItem{path: filepath.Join(db_scratchDir, f.Name()), ended: f.ModTime()}
+		if m, ok := markers[f.Name()]; ok {
+			item.marker, item.ended = item.path+scratchEndedSuffix, m.ModTime()
+			if b, err := ioutil.ReadFile(item.marker); err == nil {
+				if second, err := strconv.Atoi(strings.TrimSpace(string(b))); err == nil && second > 0 {
+					item.retention = time.Duration(second) * time.Second
+				}
+			}
+			delete(markers, f.Name())
+		}
+		items = append(items, item)
+	}
+	for label, m := range markers {
+		if !activeJobFiles[label] {
+			items = append(items, cleanupItem{path: filepath.Join(db_scratchDir, m.Name()), ended: m.ModTime()})
+		}
+	}
+	files, err = ioutil.ReadDir(db_cmdDir)
+	if err != nil {
+		LogWarning("Failed to read command dir: %v", err)
+	}
+	for _, f := range files {
+		if !isJobFileActive(f.Name()) {
+			items = append(items, cleanupItem{path: filepath.Join(db_cmdDir, f.Name()), ended: f.ModTime()})
+		}
+	}
+	return items
+}
+
+// Remove the items whose retention is over, then the oldest ones while the disk is under pressure regardless of retention,
+// return the count of items removed
+func cleanupItems(items []cleanupItem, now time.Time, default_retention time.Duration, pressured func() bool, remove func(cleanupItem) error) int {
+	sort.Slice(items, func(i, j int) bool { return items[i].ended.Before(items[j].ended) })
+	removed := 0
+	kept := []cleanupItem{}
+	for _, item := range items {
+		retention := item.retention
+		if retention <= 0 {
+			retention = default_retention
+		}
+		if now.Sub(item.ended) < retention {
+			kept = append(kept, item)
+		} else if err := remove(item); err != nil {
+			LogWarning("Failed to clean up %v: %v", item.path, err)
+		} else {
+			removed++
+		}
+	}
+	for _, item := range kept {
+		if !pressured() {
+			break
+		}
+		LogWarning("Clean up %v before its retention is over due to low free disk", item.path)
+		if err := remove(item); err != nil {
+			LogWarning("Failed to clean up %v: %v", item.path, err)
+		} else {
+			removed++
+		}
+	}
+	return removed
+}
+
+// The disk containing the scratch dirs is under pressure if its free percent is lower than the config
+func isDiskPressured() bool {
+	min := Config_Clusnode_CleanupMinFreeDisk.GetInt()
+	if min <= 0 {
+		return false
+	}
+	usage := platform.GetResourceUsage(db_scratchDir)
+	if usage.DiskTotal <= 0 || usage.DiskFree < 0 {
+		return false
+	}
+	return usage.DiskFree*100 < usage.DiskTotal*int64(min)
+}
+
+// Remove the item unless its job is started again after it is collected
+func removeCleanupItem(item cleanupItem) error {
+	activeJobFilesLock.Lock()
+	defer activeJobFilesLock.Unlock()
+	if isJobFileActive(filepath.Base(strings.TrimSuffix(item.path, scratchEndedSuffix))) {
+		return nil
+	}
+	if err := os.RemoveAll(item.path); err != nil {
+		return err
+	}
+	if len(item.marker) > 0 {
+		if err := os.Remove(item.marker); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func cleanupJobFiles() {
+	default_retention := time.Duration(Config_Clusnode_CleanupRetentionHours.GetInt()) * time.Hour
+	if removed := cleanupItems(collectCleanupItems(), time.Now(), default_retention, isDiskPressured, removeCleanupItem); removed > 0 {
+		LogInfo("Cleaned up %v scratch dirs and command files of ended jobs", removed)
+	}
+}
+
+func cleanupJobFilesPeriodically() {
+	defer LogPanicBeforeExit()
+	for {
+		cleanupJobFiles()
+		time.Sleep(cleanupPeriod)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_cleanupJobFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusrun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db_scratchDir, db_cmdDir = filepath.Join(dir, "scratch"), filepath.Join(dir, "command")
+	for _, d := range []string{db_scratchDir, db_cmdDir} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, job_label := range []string{"head.1", "head.2", "head.3"} {
+		beginJobFiles(job_label)
+		if _, err := prepareScratchDir(job_label, ""); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := CreateCommandFile(job_label, "echo", ".sh", false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	endJobFiles("head.1", 0)
+	endJobFiles("head.2", 3600)
+	items := collectCleanupItems()
+	if len(items) != 4 {
+		t.Fatalf("Expected scratch dirs and command files of 2 ended jobs, got %v", items)
+	}
+	for _, item := range items {
+		if strings.Contains(item.path, "head.3") {
+			t.Errorf("Expected files of active job not collected, got %v", item.path)
+		}
+		if strings.HasSuffix(item.path, "head.2") && item.retention != time.Hour {
+			t.Errorf("Expected retention of job kept, got %v", item.retention)
+		}
+	}
+
+	// The items over default retention are removed, then the oldest ones until the disk is not under pressure
+	now := time.Now()
+	items = []cleanupItem{{path: "a", ended: now.Add(-3 * time.Hour)}, {path: "b", ended: now.Add(-3 * time.Hour), retention: 4 * time.Hour}, {path: "c", ended: now.Add(-time.Hour)}, {path: "d", ended: now}}
+	removed := []string{}
+	remove := func(item cleanupItem) error {
+		removed = append(removed, item.path)
+		return nil
+	}
+	if count := cleanupItems(items, now, 2*time.Hour, func() bool { return false }, remove); count != 1 || !reflect.DeepEqual(removed, []string{"a"}) {
+		t.Errorf("Expected expired item removed, got %v", removed)
+	}
+	removed = removed[:0]
+	if count := cleanupItems(items, now, 2*time.Hour, func() bool { return len(removed) < 3 }, remove); count != 3 || !reflect.DeepEqual(removed, []string{"a", "b", "c"}) {
+		t.Errorf("Expected oldest items removed under disk pressure, got %v", removed)
+	}
+
+	cleanupItems(collectCleanupItems(), now, 0, func() bool { return false }, removeCleanupItem)
+	left := []string{}
+	for _, d := range []string{db_scratchDir, db_cmdDir} {
+		files, _ := ioutil.ReadDir(d)
+		for _, f := range files {
+			left = append(left, f.Name())
+		}
+	}
+	if expected := []string{"head.2", "head.2.ended", "head.3", "head.3.sh"}; !reflect.DeepEqual(left, expected) {
+		t.Errorf("Expected files of active job and job in retention left: %v, got %v", expected, left)
+	}
+	endJobFiles("head.3", 0)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ClockSkewAction_Warn  = "warn"
+	ClockSkewAction_Error = "error"
+)
+
+var (
+	nodeClockSkews sync.Map // the clock skew of each clusnode measured by its heartbeats, positive if its clock is ahead
+
+	clockSkewActions = []string{ClockSkewAction_Warn, ClockSkewAction_Error}
+
+	clockSkewActionValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if v != ClockSkewAction_Warn && v != ClockSkewAction_Error {
+			return fmt.Errorf("Value should be one of: %v", strings.Join(clockSkewActions, ", "))
+		}
+		return nil
+	}
+)
+
+// Measure the clock skew by the time the clusnode sends the heartbeat, which includes the one-way delay of the heartbeat,
+// and warn once the skew exceeds the max
+func storeNodeClockSkew(display_name string, timestamp int64) {
+	skew := time.Duration(timestamp - time.Now().UnixNano())
+	previous, loaded := nodeClockSkews.Load(display_name)
+	nodeClockSkews.Store(display_name, skew)
+	over, was_over := isClockSkewed(skew), loaded && isClockSkewed(previous.(time.Duration))
+	if over && !was_over {
+		LogWarning("Clock of %v is skewed by %v, which makes the timestamps and timeouts of jobs on it misleading, please check its time sync", display_name, skew.Round(time.Millisecond))
+	} else if !over && was_over {
+		LogInfo("Clock of %v is skewed by %v, which is within the max again", display_name, skew.Round(time.Millisecond))
+	}
+	if over != was_over && Config_Headnode_ClockSkewAction.GetString() == ClockSkewAction_Error {
+		MarkNodesChanged()
+	}
+}
+
+func isClockSkewed(skew time.Duration) bool {
+	max := time.Duration(Config_Headnode_MaxClockSkewSecond.GetInt()) * time.Second
+	return max > 0 && (skew > max || skew < -max)
+}
+
+// Get the clock skew of the clusnode and whether it exceeds the max
+func getNodeClockSkew(display_name string) (time.Duration, bool) {
+	if skew, ok := nodeClockSkews.Load(display_name); ok {
+		return skew.(time.Duration), isClockSkewed(skew.(time.Duration))
+	}
+	return 0, false
+}
+
+// The clusnode with its clock skewed over the max is in error state if configured, so that no job is dispatched to it
+func isNodeClockSkewError(display_name string) bool {
+	_, skewed := getNodeClockSkew(display_name)
+	return skewed && Config_Headnode_ClockSkewAction.GetString() == ClockSkewAction_Error
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"testing"
+	"time"
+)
+
+func Test_nodeClockSkew(t *testing.T) {
+	defer func() {
+		_ = Config_Headnode_ClockSkewAction.Set(Config_Headnode_ClockSkewAction.Value)
+	}()
+	node, now := "SKEWED-NODE", time.Now()
+	defer func() {
+		nodeClockSkews.Delete(node)
+		ReportedNodes.Remove(node)
+	}()
+	ReportedNodes.SetValidated(node)
+	max := time.Duration(Config_Headnode_MaxClockSkewSecond.GetInt()) * time.Second
+	storeNodeClockSkew(node, now.Add(max/2).UnixNano())
+	if skew, skewed := getNodeClockSkew(node); skewed || skew <= 0 || skew > max/2 {
+		t.Errorf("Expected skew within the max, got %v", skew)
+	}
+	storeNodeClockSkew(node, now.Add(-2*max).UnixNano())
+	if skew, skewed := getNodeClockSkew(node); !skewed || skew > -2*max {
+		t.Errorf("Expected skew over the max, got %v", skew)
+	}
+	if state := getNodeState(node, now); state != pb.NodeState_Ready {
+		t.Errorf("Expected node ready with clock skew warned, got %v", state)
+	}
+	if err := Config_Headnode_ClockSkewAction.Set(ClockSkewAction_Error); err != nil {
+		t.Fatalf("Failed to set clock skew action: %v", err)
+	}
+	if state := getNodeState(node, now); state != pb.NodeState_Error {
+		t.Errorf("Expected node error with clock skew over the max, got %v", state)
+	}
+	if err := Config_Headnode_ClockSkewAction.Set("ignore"); err == nil {
+		t.Errorf("Expected invalid clock skew action")
+	}
+}
@@ -6,13 +6,24 @@ import (
 
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	outputBufferSize = 1024
+	killPollPeriod   = 100 * time.Millisecond
 )
 
 var (
@@ -23,6 +34,9 @@ var (
 type heartbeat_state struct {
 	Connected bool
 	Stopped   bool
+	Validated bool // validated by the headnode since connected
+	Draining  bool // drained by the headnode, which is pushed over the heartbeat stream
+	Interval  int  // the heartbeat interval in seconds set by the headnode, 0 for the config
 }
 
 type clusnode_server struct {
@@ -31,8 +45,65 @@ type clusnode_server struct {
 
 func (s *clusnode_server) Validate(ctx context.Context, in *pb.ValidateRequest) (*pb.ValidateReply, error) {
 	defer LogPanicBeforeExit()
-	LogInfo("Received validation request from %v to %v", in.GetHeadnode(), in.GetClusnode())
-	return &pb.ValidateReply{Nodename: NodeName}, nil
+	headnode, reported := in.GetHeadnode(), in.GetReportedHeadnode()
+	LogInfo("Received validation request from %v to %v", headnode, in.GetClusnode())
+	if warning, err := checkNodeBuild(in.GetBuild(), false); err != nil {
+		LogWarning("Reject validation from incompatible headnode %v: %v", headnode, err)
+		return nil, status.Errorf(codes.FailedPrecondition, "Incompatible headnode: %v", err)
+	} else if len(warning) > 0 {
+		LogWarning("Headnode %v: %v", headnode, warning)
+	}
+	if key := in.GetKey(); len(key) > 0 {
+		// Only accept the key from a headnode this clusnode is reporting to
+		if state, ok := headnodesReporting.Load(reported); !ok || state.(*heartbeat_state).Stopped {
+			LogWarning("Reject key from headnode %v which is not reported to", headnode)
+			return nil, status.Errorf(codes.PermissionDenied, "Not reporting to headnode %v", reported)
+		}
+		if len(key) != nodeKeySize {
+			return nil, status.Error(codes.InvalidArgument, "Invalid key size")
+		} else if len(in.GetNonce()) != nodeNonceSize {
+			return nil, status.Error(codes.InvalidArgument, "Invalid nonce size")
+		}
+		if err := acceptHeadnodeKey(reported, in); err != nil {
+			LogWarning("Reject key from headnode %v: %v", headnode, err)
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		LogInfo("Enrolled by headnode %v with a new key", reported)
+	}
+	reply := &pb.ValidateReply{Nodename: NodeName, Build: getNodeBuild(), System: getNodeSystem()}
+	if key, ok := HeadnodeKeys.Load(reported); ok {
+		if len(in.GetNonce()) != nodeNonceSize {
+			return nil, status.Error(codes.InvalidArgument, "Invalid nonce size")
+		}
+		reply.Signature = signValidateReply(key.([]byte), in.GetNonce(), headnode, in.GetClusnode(), NodeName)
+	}
+	if secret := Config_ClusterSecret.GetString(); len(secret) > 0 {
+		if len(in.GetNonce()) != nodeNonceSize {
+			return nil, status.Error(codes.InvalidArgument, "Invalid nonce size")
+		}
+		reply.SecretSignature = signValidateSecret(secret, in.GetNonce(), headnode, in.GetClusnode(), NodeName)
+	}
+	if state, ok := headnodesReporting.Load(reported); ok && !state.(*heartbeat_state).Stopped && !state.(*heartbeat_state).Validated {
+		state.(*heartbeat_state).Validated = true
+		LogInfo("Validated by headnode %v", reported)
+	}
+	setHeartbeatInterval(reported, int(in.GetHeartbeatIntervalSecond()))
+	return reply, nil
+}
+
+// The headnode reported to should be given and be the one whose signature of the call is verified, so that a caller
+// can't claim to be another headnode
+func checkReportedHeadnode(ctx context.Context, reported string) error {
+	if len(reported) == 0 {
+		return status.Error(codes.InvalidArgument, "Missing headnode reported to")
+	}
+	if verified := getVerifiedHeadnode(ctx); reported != verified {
+		return status.Errorf(codes.PermissionDenied, "Call is not signed by headnode %v", reported)
+	}
+	if state, ok := headnodesReporting.Load(reported); !ok || state.(*heartbeat_state).Stopped {
+		return status.Errorf(codes.PermissionDenied, "Not reporting to headnode %v", reported)
+	}
+	return nil
 }
 
 func (s *clusnode_server) SetHeadnodes(ctx context.Context, in *pb.SetHeadnodesRequest) (*pb.SetHeadnodesReply, error) {
@@ -72,71 +143,339 @@ func (s *clusnode_server) SetHeadnodes(ctx context.Context, in *pb.SetHeadnodesR
 		}
 	}
 	LogInfo("SetHeadnodes results: %v", results)
+	audit(getAuditSource(ctx), AuditAction_SetHeadnodes, 0, "", fmt.Sprintf("%v %v", mode, headnodes), formatAuditConfigs(results))
 	SaveNodeConfigs()
 	return &pb.SetHeadnodesReply{Results: results}, nil
 }
 
 func (s *clusnode_server) StartJob(in *pb.StartJobRequest, out pb.Clusnode_StartJobServer) error {
 	defer LogPanicBeforeExit()
-	headnode, job_id, command, arguments := in.GetHeadnode(), in.GetJobId(), in.GetCommand(), in.GetArguments()
-	LogInfo("Receive StartJob from headnode %v to start job %v with command: %v", headnode, job_id, command)
+	headnode, job_id, command, arguments, checkpoint, ship_checkpoint := in.GetHeadnode(), in.GetJobId(), in.GetCommand(), in.GetArguments(), in.GetCheckpoint(), in.GetShipCheckpoint()
+	logger := LogContext(out.Context()).With(logFieldJob, job_id)
+	logger.Info("Receive StartJob from headnode %v to start job %v with command: %v", headnode, job_id, command)
+	// Only accept the job from a headnode this clusnode is reporting to
+	if err := checkReportedHeadnode(out.Context(), in.GetReportedHeadnode()); err != nil {
+		logger.Warning("Reject job %v from headnode %v: %v", job_id, headnode, status.Convert(err).Message())
+		return err
+	}
 	job_label := getJobLabel(headnode, int(job_id))
+	run_as := in.GetRunAs()
+	audit_detail := strings.Join(append([]string{command}, arguments...), " ")
+
+	// Check the command by rules
+	if err := checkCommand(headnode, command, arguments); err != nil {
+		logger.Warning("Reject job %v: %v", job_label, err)
+		audit(headnode, AuditAction_JobStart, job_id, run_as, audit_detail, "Rejected: "+err.Error())
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
 
-	// Create command file
-	cmd_file, err := CreateCommandFile(job_label, command)
+	// Get the executor to run the task by
+	executor, err := getJobExecutor(in.GetExecutor(), runtime.GOOS)
 	if err != nil {
+		logger.Warning("Reject job %v: %v", job_label, err)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Create command file, the script with shebang is run directly
+	shell, script_name := in.GetShell(), in.GetScriptName()
+	if err := validateScriptName(script_name); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	shebang := isShebangScript(script_name, shell, runtime.GOOS, command)
+	if len(shell) == 0 {
+		shell = getDefaultJobShell(runtime.GOOS)
+		if in.GetExecutor().GetName() == Executor_Docker {
+			// The image may not have bash
+			shell = JobShell_Sh
+		}
+	}
+	if _, _, err := getJobShellCommand(shell, runtime.GOOS, "", nil); err != nil {
+		logger.Warning("Reject job %v: %v", job_label, err)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	ext := getJobShellFileExt(shell)
+	if shebang {
+		ext = getScriptFileExt(script_name)
+	}
+	beginJobFiles(job_label)
+	cmd_file, err := CreateCommandFile(job_label, command, ext, shebang)
+	if err != nil {
+		endJobFiles(job_label, 0)
 		message := "Failed to create command file"
-		LogError(message+" for job %v", job_label)
+		logger.Error(message+" for job %v", job_label)
 		return errors.New(message)
 	}
-	defer cleanupJob(job_label, cmd_file)
+	defer cleanupJob(job_label, cmd_file, in.GetLimits().GetRetentionSecond())
 
-	// Run command
-	start_point := "/bin/bash"
-	args := []string{cmd_file}
-	if RunOnWindows {
-		start_point = "cmd"
-		args = []string{"/q", "/c", cmd_file}
+	// Check the user to run job as
+	if len(run_as) > 0 {
+		if err := checkRunAs(headnode, run_as); err != nil {
+			logger.Error("Failed to run job %v as user %v: %v", job_label, run_as, err)
+			audit(headnode, AuditAction_JobStart, job_id, run_as, audit_detail, "Rejected: "+err.Error())
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+
+	// Prepare working dir
+	var working_dir string
+	if dir := in.GetWorkingDir(); len(dir) > 0 {
+		if working_dir, err = prepareWorkingDir(dir, run_as); err != nil {
+			logger.Error("Failed to prepare working dir for job %v: %v", job_label, err)
+			return err
+		}
 	}
-	args = append(args, arguments...)
-	cmd := exec.Command(start_point, args...)
-	platform.SetSysProcAttr(cmd)
+
+	// Prepare scratch dir, which is kept for the retention after the job ends
+	scratch_dir, err := prepareScratchDir(job_label, run_as)
+	if err != nil {
+		message := "Failed to create scratch dir"
+		logger.Error("%v for job %v: %v", message, job_label, err)
+		return errors.New(message)
+	}
+
+	// Prepare checkpoint dir
+	var checkpoint_dir string
+	if len(checkpoint) > 0 {
+		if err := ValidateCheckpointName(checkpoint); err != nil {
+			return err
+		}
+		checkpoint_dir = getCheckpointDir(headnode, checkpoint)
+		if err := os.MkdirAll(checkpoint_dir, 0755); err != nil {
+			message := "Failed to create checkpoint dir"
+			logger.Error("%v for job %v: %v", message, job_label, err)
+			return errors.New(message)
+		}
+		if data := in.GetCheckpointData(); len(data) > 0 {
+			logger.Info("Restore shipped checkpoint of job %v to %v", job_label, checkpoint_dir)
+			if err := unpackCheckpoint(checkpoint_dir, data); err != nil {
+				message := "Failed to restore shipped checkpoint"
+				logger.Error("%v for job %v: %v", message, job_label, err)
+				return errors.New(message)
+			}
+		}
+		if len(run_as) > 0 {
+			if err := chownTree(checkpoint_dir, run_as); err != nil {
+				logger.Warning("Failed to change owner of checkpoint dir of job %v to %v: %v", job_label, run_as, err)
+			}
+		}
+	}
+
+	// Run command by the executor
+	start_point, args, _ := getJobShellCommand(shell, runtime.GOOS, cmd_file, arguments)
+	if shebang {
+		start_point, args = cmd_file, arguments
+	}
+	task := &executorTask{
+		label:      job_label,
+		startPoint: start_point,
+		args:       args,
+		workingDir: working_dir,
+		variables:  []string{ScratchEnv + "=" + scratch_dir},
+		dirs:       []string{filepath.Dir(cmd_file), scratch_dir},
+		runAs:      run_as,
+		limits:     getJobLimits(in.GetLimits()),
+		stdin:      in.GetForwardStdin(),
+	}
+	if shell == JobShell_Cmd && !shebang {
+		task.commandLine = getCmdCommandLine(cmd_file, arguments)
+	}
+	if task.env, err = getJobEnvironment(in.GetEnvMode(), Config_Clusnode_BaseEnvironment.GetString()); err != nil {
+		logger.Error("Failed to get environment of job %v: %v", job_label, err)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(checkpoint_dir) > 0 {
+		task.variables = append(task.variables, CheckpointEnv+"="+checkpoint_dir)
+		task.dirs = append(task.dirs, checkpoint_dir)
+	}
+	if gpus := in.GetGpus(); gpus > 0 {
+		if task.gpus, err = assignGpus(job_label, job_id, int(gpus)); err != nil {
+			logger.Warning("Reject job %v: %v", job_label, err)
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		defer releaseGpus(job_label)
+		logger.Info("Assign GPUs %v to job %v", task.gpus, job_label)
+		if in.GetExecutor().GetName() != Executor_Docker {
+			// The GPUs assigned are exposed to the container by docker instead
+			task.variables = append(task.variables, CudaVisibleDevicesEnv+"="+formatGpuIndexes(task.gpus))
+		}
+	}
+	if err := executor.Prepare(logger, task); err != nil {
+		logger.Error("Failed to prepare executor of job %v: %v", job_label, err)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	cmd, err := executor.Command(task)
+	if err != nil {
+		logger.Error("Failed to create command of job %v: %v", job_label, err)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if len(run_as) > 0 {
+		logger.Info("Run job %v as user %v", job_label, run_as)
+	}
+	job_limits, priority := task.limits, getJobPriority(in.GetLimits())
+	// The limits of an isolated task are applied by its executor
+	limited := isJobLimited(job_limits) && !executor.Isolated()
+	prioritized := priority != (platform.ProcessPriority{})
+	if prioritized && executor.Isolated() {
+		logger.Warning("The priority of job %v is not applied by executor %v", job_label, in.GetExecutor().GetName())
+		prioritized = false
+	}
+	var holder *processHolder
+	if (limited || prioritized) && !RunOnWindows {
+		if holder, err = holdCommand(cmd); err != nil {
+			logger.Error("Failed to hold job %v until capped: %v", job_label, err)
+			return errors.New("Failed to create job")
+		}
+		defer holder.Release()
+	}
+	var environment *pb.TaskEnvironment
+	if in.GetCaptureEnv() {
+		environment = captureEnvironment(cmd, run_as)
+	}
+	var stdin io.WriteCloser
 	var stdout, stderr io.Reader
-	if stdout, err = cmd.StdoutPipe(); err == nil {
-		if stderr, err = cmd.StderrPipe(); err == nil {
-			err = cmd.Start()
+	if in.GetForwardStdin() {
+		// The stdin is closed after the command exits if the input is not closed yet
+		stdin, err = cmd.StdinPipe()
+	}
+	if err == nil {
+		if stdout, err = cmd.StdoutPipe(); err == nil {
+			if stderr, err = cmd.StderrPipe(); err == nil {
+				err = cmd.Start()
+			}
 		}
 	}
+	if holder != nil {
+		holder.Started()
+	}
 	if err != nil {
 		message := "Failed to create job"
-		LogError("%v %v: %v", message, job_label, err)
+		logger.Error("%v %v: %v", message, job_label, err)
+		audit(headnode, AuditAction_JobStart, job_id, run_as, audit_detail, formatAuditResult(err))
 		return errors.New(message)
 	}
+	forgetEndedJob(job_label)
 	jobsPid.Store(job_label, cmd.Process.Pid)
+	taskExecutors.Store(job_label, executor)
+	defer taskExecutors.Delete(job_label)
+	audit(headnode, AuditAction_JobStart, job_id, run_as, audit_detail, "Started process "+strconv.Itoa(cmd.Process.Pid))
+	_, execute_span := startChildSpan(out.Context(), "job.execute")
+	execute_span.SetAttribute("process.pid", cmd.Process.Pid)
+	defer execute_span.End()
+	if stdin != nil {
+		registerJobStdin(job_label, stdin)
+	}
+	intent_headnode := in.GetReportedHeadnode()
+	if len(intent_headnode) == 0 {
+		intent_headnode = headnode
+	}
+	if err := saveTaskIntent(job_label, intent_headnode, job_id, cmd.Process.Pid); err != nil {
+		logger.Warning("Failed to save task intent of job %v: %v", job_label, err)
+	}
+	trackRunningJob(job_label, intent_headnode, job_id, cmd.Process.Pid, out.Context(), logger)
+	defer untrackRunningJob(job_label)
+	if limited {
+		defer releaseJobProcessLimit(job_label)
+	}
+	failure := ""
+	if limited && limitJobProcess(job_label, cmd.Process.Pid, job_limits) != nil {
+		failure = "Failed to cap the resources of job on node "
+	} else if prioritized && prioritizeJobProcess(job_label, cmd.Process.Pid, priority) != nil {
+		failure = "Failed to set the priority of job on node "
+	}
+	if len(failure) > 0 {
+		platform.KillProcessGroup(cmd.Process.Pid)
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return status.Error(codes.FailedPrecondition, failure+NodeName)
+	}
+	if holder != nil {
+		holder.Release()
+	}
+
+	// Kill the job after its timeout by this node itself, even if the headnode is unreachable
+	var timeout_timer *time.Timer
+	if timeout := getJobTimeout(in.GetLimits()); timeout > 0 {
+		pid := cmd.Process.Pid
+		timeout_timer = time.AfterFunc(timeout, func() {
+			logger.Warning("Job %v is timed out after %v", job_label, timeout)
+			killJobProcess(logger, job_label, pid)
+		})
+		defer timeout_timer.Stop()
+	}
+
+	// Send output by the relay, which is resumed by the headnode if the stream is broken by a transient network failure
+	// The checksum of all output is reported with exit code, for the headnode to verify the output stored
+	relay := newTaskOutputRelay(out, in.GetOutputLimit())
+	taskOutputRelays.Store(job_label, relay)
+	defer taskOutputRelays.Delete(job_label)
+	send := relay.Send
+	if environment != nil {
+		if err := send(&pb.StartJobReply{Environment: environment}); err != nil {
+			logger.Warning("Failed to send environment of job %v: %v", job_label, err)
+		}
+	}
+	send_checkpoint := func(version string) string {
+		if latest := getCheckpointVersion(checkpoint_dir); latest != version {
+			if data, err := packCheckpoint(checkpoint_dir); err != nil {
+				logger.Warning("Failed to pack checkpoint of job %v: %v", job_label, err)
+			} else if err := send(&pb.StartJobReply{CheckpointData: data}); err != nil {
+				logger.Warning("Failed to send checkpoint of job %v: %v", job_label, err)
+			}
+			return latest
+		}
+		return version
+	}
+	limiter := newOutputRateLimiter(in.GetOutputRateLimit(), outputBufferSize)
+
+	// Only send the last output in window at each interval if specified
+	window := in.GetOutputWindow()
+	windows := map[string]*outputWindow{}
+	stop_windows, windows_stopped := make(chan struct{}), make(chan struct{})
+	if window != nil {
+		windows["stdout"], windows["stderr"] = newOutputWindow("stdout", window.GetSizeKb()), newOutputWindow("stderr", window.GetSizeKb())
+		go func() {
+			defer close(windows_stopped)
+			sendOutputWindows(window, windows["stdout"], windows["stderr"], func(stdout, stderr []byte) error {
+				if len(stdout)+len(stderr) == 0 {
+					return nil
+				}
+				limiter.Wait(len(stdout) + len(stderr))
+				err := send(&pb.StartJobReply{Stdout: stdout, Stderr: stderr})
+				if err != nil {
+					logger.Error("Failed to send output in window to headnode: %v", err)
+				}
+				return err
+			}, stop_windows)
+		}()
+	}
 
-	// Send output
 	send_output := func(reader io.Reader, t string) {
-		buf := make([]byte, 1024)
+		buf := make([]byte, outputBufferSize)
 		for {
 			if n, err := reader.Read(buf); n > 0 {
-				output := string(buf[:n])
+				if w, ok := windows[t]; ok {
+					w.Write(buf[:n])
+					continue
+				}
+				limiter.Wait(n)
+				output := append([]byte{}, buf[:n]...) // the buffer is reused by the next read
 				var reply pb.StartJobReply
 				if t == "stdout" {
 					reply.Stdout = output
 				} else {
 					reply.Stderr = output
 				}
-				if err := out.Send(&reply); err != nil {
-					LogError("Failed to send %v to headnode: %v", t, err)
+				if err := send(&reply); err != nil {
+					logger.Error("Failed to send %v to headnode: %v", t, err)
 					break
 				}
 			} else {
 				if err == io.EOF {
-					LogInfo("Sending %v of job %v finished", t, job_label)
+					logger.Info("Sending %v of job %v finished", t, job_label)
 				} else if err != nil {
-					LogError("Failed to get %v of command: %v", t, err)
+					logger.Error("Failed to get %v of command: %v", t, err)
 				} else {
-					LogError("Unexpected empty %v", t)
+					logger.Error("Unexpected empty %v", t)
 				}
 				break
 			}
@@ -152,7 +491,35 @@ func (s *clusnode_server) StartJob(in *pb.StartJobRequest, out pb.Clusnode_Start
 		send_output(stderr, "stderr")
 		wg.Done()
 	}()
+
+	// Ship checkpoint periodically while the job is running
+	shipping := ship_checkpoint && len(checkpoint_dir) > 0
+	stop_shipping, shipping_stopped := make(chan struct{}), make(chan struct{})
+	var checkpoint_version string
+	if shipping {
+		checkpoint_version = getCheckpointVersion(checkpoint_dir)
+		go func() {
+			defer close(shipping_stopped)
+			for {
+				select {
+				case <-stop_shipping:
+					return
+				case <-time.After(checkpointShipInterval):
+					checkpoint_version = send_checkpoint(checkpoint_version)
+				}
+			}
+		}()
+	}
 	wg.Wait()
+	if window != nil {
+		close(stop_windows)
+		<-windows_stopped
+	}
+	if shipping {
+		close(stop_shipping)
+		<-shipping_stopped
+		send_checkpoint(checkpoint_version)
+	}
 
 	// Send exit code
 	exit_code := 0
@@ -161,10 +528,28 @@ func (s *clusnode_server) StartJob(in *pb.StartJobRequest, out pb.Clusnode_Start
 			exit_code = exitError.ExitCode()
 		}
 	}
-	LogInfo("Job %v finished with exit code %v", job_label, exit_code)
-	err = out.Send(&pb.StartJobReply{ExitCode: int32(exit_code)})
+	timed_out := timeout_timer != nil && !timeout_timer.Stop()
+	if timed_out {
+		exit_code = TimeoutExitCode
+		logger.Info("Job %v is killed by timeout with exit code %v", job_label, exit_code)
+		audit(headnode, AuditAction_JobEnd, job_id, run_as, audit_detail, "Timed out with exit code "+strconv.Itoa(exit_code))
+	} else {
+		logger.Info("Job %v finished with exit code %v", job_label, exit_code)
+		audit(headnode, AuditAction_JobEnd, job_id, run_as, audit_detail, "Exit code "+strconv.Itoa(exit_code))
+	}
+	recordEndedJob(job_label, int32(exit_code), timed_out, time.Now())
+	truncated := relay.FlushTruncated()
+	if truncated > 0 {
+		logger.Warning("Output of job %v is truncated by %v bytes beyond the limit %v bytes", job_label, truncated, in.GetOutputLimit())
+	}
+	sum := relay.Checksum()
+	execute_span.SetAttribute("process.exit_code", exit_code)
+	execute_span.SetAttribute("process.timed_out", timed_out)
+	execute_span.SetAttribute("output.stdout_bytes", sum.StdoutSize)
+	execute_span.SetAttribute("output.stderr_bytes", sum.StderrSize)
+	err = relay.Finish(&pb.StartJobReply{ExitCode: int32(exit_code), Checksum: sum, TimedOut: timed_out, TruncatedSize: truncated}, taskResumeTimeout)
 	if err != nil {
-		LogError("Failed to send exitcode of job %v", job_label)
+		logger.Error("Failed to send exitcode of job %v: %v", job_label, err)
 	}
 	return err
 }
@@ -172,43 +557,164 @@ func (s *clusnode_server) StartJob(in *pb.StartJobRequest, out pb.Clusnode_Start
 func (s *clusnode_server) CancelJob(ctx context.Context, in *pb.CancelJobRequest) (*pb.Empty, error) {
 	defer LogPanicBeforeExit()
 	headnode, job_id := in.GetHeadnode(), in.GetJobId()
-	LogInfo("Receive CancelJob from headnode %v to cancel job %v", headnode, job_id)
+	logger := LogContext(ctx).With(logFieldJob, job_id)
+	logger.Info("Receive CancelJob from headnode %v to cancel job %v", headnode, job_id)
 	job_label := getJobLabel(headnode, int(job_id))
 	if pid, ok := jobsPid.Load(job_label); ok {
 		pid := pid.(int)
-		if RunOnWindows {
-			cmd := []string{"TASKKILL", "/T", "/F", "/PID", strconv.Itoa(pid)}
-			LogInfo("Cancel job %v with command: %v", job_label, strings.Join(cmd, " "))
-			output, _ := exec.Command(cmd[0], cmd[1:]...).CombinedOutput()
-			LogInfo("Cancel job %v result: %s", job_label, output)
-		} else {
-			LogInfo("Cancel job %v by killing process group of process %v", job_label, pid)
-			platform.KillProcessGroup(pid)
-		}
+		killJobProcess(logger, job_label, pid)
+		audit(headnode, AuditAction_JobCancel, job_id, "", "", "Canceled process "+strconv.Itoa(pid))
 	} else {
-		LogWarning("Job %v is not running", job_label)
+		logger.Warning("Job %v is not running", job_label)
 	}
 	return &pb.Empty{}, nil
 }
 
 func (s *clusnode_server) SetConfigs(ctx context.Context, in *pb.SetConfigsRequest) (*pb.SetConfigsReply, error) {
 	defer LogPanicBeforeExit()
-	configs := in.GetConfigs()
-	results := SetNodeConfigs(Config_Clusnode, configs)
+	configs, err := getRequestedConfigs(Config_Clusnode, in.GetConfigs(), in.GetRollback())
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	results := SetNodeConfigs(Config_Clusnode, configs, in.GetDryRun())
+	if !in.GetDryRun() {
+		action := AuditAction_SetConfigs
+		if in.GetRollback() {
+			action = AuditAction_RollbackConfigs
+		}
+		audit(getAuditSource(ctx), action, 0, "", formatAuditConfigs(maskConfigs(configs, getRoleConfigs(Config_Clusnode))), formatAuditConfigs(results))
+	}
 	return &pb.SetConfigsReply{Results: results}, nil
 }
 
 func (s *clusnode_server) GetConfigs(ctx context.Context, in *pb.Empty) (*pb.GetConfigsReply, error) {
 	defer LogPanicBeforeExit()
 	results := GetNodeConfigs(Config_Clusnode)
-	return &pb.GetConfigsReply{Configs: results}, nil
+	return &pb.GetConfigsReply{Configs: results, Sensitive: getSensitiveConfigs(Config_Clusnode)}, nil
+}
+
+// A process identified by its pid and start time, since the pid may be reused after it exits
+type processRef struct {
+	pid   int
+	start uint64
+}
+
+// Terminate the process tree of job, and kill the processes still alive after the grace period in background.
+// The descendants are collected before terminating, since they may leave the process group by setsid and be
+// re-parented once their parents exit.
+func killJobProcess(logger *Logger, job_label string, pid int) {
+	grace := time.Duration(Config_Clusnode_KillGraceSecond.GetInt()) * time.Second
+	go func() {
+		defer LogPanicBeforeExit()
+		killTaskExecutor(logger, job_label, grace)
+	}()
+	if RunOnWindows {
+		if grace <= 0 {
+			runTaskkill(logger, job_label, pid, true)
+			return
+		}
+		runTaskkill(logger, job_label, pid, false)
+		go func() {
+			defer LogPanicBeforeExit()
+			time.Sleep(grace)
+			// The pid may be reused once the job ends
+			if p, ok := jobsPid.Load(job_label); ok && p.(int) == pid {
+				runTaskkill(logger, job_label, pid, true)
+			}
+		}()
+		return
+	}
+	descendants := getProcessDescendants([]int{pid}, platform.GetProcesses())
+	if grace <= 0 {
+		killProcessTree(logger, job_label, pid, descendants)
+		return
+	}
+	logger.Info("Terminate job %v by terminating process group of process %v and %v descendants", job_label, pid, len(descendants))
+	_ = platform.TerminateProcessGroup(pid)
+	for _, p := range descendants {
+		_ = platform.TerminateProcess(p.pid)
+	}
+	go func() {
+		defer LogPanicBeforeExit()
+		for deadline := time.Now().Add(grace); time.Now().Before(deadline); time.Sleep(killPollPeriod) {
+			if !platform.IsProcessGroupAlive(pid) && len(getAliveProcesses(descendants, platform.GetProcesses())) == 0 {
+				logger.Info("Job %v is terminated", job_label)
+				return
+			}
+		}
+		killProcessTree(logger, job_label, pid, descendants)
+	}()
+}
+
+// Kill the process group and the descendants still alive, with the ones they created
+func killProcessTree(logger *Logger, job_label string, pid int, descendants []processRef) {
+	processes := platform.GetProcesses()
+	alive := getAliveProcesses(descendants, processes)
+	roots := []int{pid}
+	for _, p := range alive {
+		roots = append(roots, p.pid)
+	}
+	alive = append(alive, getProcessDescendants(roots, processes)...)
+	logger.Info("Kill job %v by killing process group of process %v and %v descendants", job_label, pid, len(alive))
+	platform.KillProcessGroup(pid)
+	for _, p := range alive {
+		_ = platform.KillProcess(p.pid)
+	}
+}
+
+// TASKKILL kills the process tree without the processes whose parents have exited
+func runTaskkill(logger *Logger, job_label string, pid int, force bool) {
+	cmd := []string{"TASKKILL", "/T"}
+	if force {
+		cmd = append(cmd, "/F")
+	}
+	cmd = append(cmd, "/PID", strconv.Itoa(pid))
+	logger.Info("Kill job %v with command: %v", job_label, strings.Join(cmd, " "))
+	output, _ := exec.Command(cmd[0], cmd[1:]...).CombinedOutput()
+	logger.Info("Kill job %v result: %s", job_label, output)
+}
+
+// Get the descendants of the root processes, not including the roots
+func getProcessDescendants(roots []int, processes map[int]platform.ProcessEntry) []processRef {
+	children := map[int][]int{}
+	for pid, p := range processes {
+		children[p.Ppid] = append(children[p.Ppid], pid)
+	}
+	visited := map[int]bool{}
+	for _, pid := range roots {
+		visited[pid] = true
+	}
+	descendants := []processRef{}
+	for queue := append([]int{}, roots...); len(queue) > 0; queue = queue[1:] {
+		for _, child := range children[queue[0]] {
+			if !visited[child] {
+				visited[child] = true
+				descendants = append(descendants, processRef{pid: child, start: processes[child].StartTime})
+				queue = append(queue, child)
+			}
+		}
+	}
+	return descendants
 }
 
-func cleanupJob(job_label, cmd_file string) {
+func getAliveProcesses(refs []processRef, processes map[int]platform.ProcessEntry) []processRef {
+	alive := []processRef{}
+	for _, ref := range refs {
+		if p, ok := processes[ref.pid]; ok && p.StartTime == ref.start {
+			alive = append(alive, ref)
+		}
+	}
+	return alive
+}
+
+func cleanupJob(job_label, cmd_file string, retention_second int32) {
 	jobsPid.Delete(job_label)
+	jobsStdin.Delete(job_label)
+	removeTaskIntent(job_label)
 	if err := os.Remove(cmd_file); err != nil {
 		LogError("Failed to cleanup job %v: %v", job_label, err)
 	}
+	endJobFiles(job_label, retention_second)
 }
 
 func getJobLabel(headnode string, job_id int) string {
@@ -225,6 +731,7 @@ func AddHeadnode(headnode string) (string, error) {
 		if s.Stopped {
 			s.Stopped = false
 			s.Connected = false
+			s.Validated = false
 		} else {
 			if s.Connected {
 				return host, errors.New("Already connected")
@@ -250,6 +757,7 @@ func removeHeadnode(headnode string) (removed string, e error) {
 			e = errors.New("Already removed")
 		} else {
 			state.(*heartbeat_state).Stopped = true
+			forgetHeadnodeKey(host)
 			removed = host
 		}
 	} else {
@@ -273,9 +781,23 @@ func GetHeadnodes() (connected, connecting []string) {
 	return
 }
 
+func GetValidatedHeadnodes() (validated []string) {
+	headnodesReporting.Range(func(key, val interface{}) bool {
+		if state := val.(*heartbeat_state); !state.Stopped && state.Connected && state.Validated {
+			validated = append(validated, key.(string))
+		}
+		return true
+	})
+	return
+}
+
 func heartbeat(from, headnode string) {
 	connected := false
 	stopped := true
+	var stream *heartbeatStream
+	stream_supported := true
+	failures := 0 // the consecutive heartbeat failures, after which the clusnode reconnects with backoff
+	var err error
 	for {
 		// Known data race of heartbeat_state when adding or removing headnode
 		if state, ok := headnodesReporting.Load(headnode); ok && !state.(*heartbeat_state).Stopped {
@@ -283,30 +805,118 @@ func heartbeat(from, headnode string) {
 				LogInfo("Start heartbeat from %v to %v", from, headnode)
 				stopped = false
 			}
-			conn, cancelConn := ConnectNode(headnode)
-			if conn == nil {
+			// Send heartbeat through the relay if configured, otherwise over the stream kept open if the headnode supports
+			target := headnode
+			relay := Config_Clusnode_Relay.GetString()
+			if len(relay) > 0 {
+				if _, _, target, err = ParseHostAddress(relay); err != nil {
+					target = relay
+				}
+			}
+			request := &pb.HeartbeatRequest{Nodename: NodeName, Host: from, Headnode: headnode, Timestamp: time.Now().UnixNano(), Zone: Config_Clusnode_Zone.GetString(), Labels: getConfiguredNodeLabels(), Probes: getProbeValues(), Resources: getNodeResources(), System: getNodeSystem(), Build: getNodeBuild(), Relayed: len(relay) > 0, RestartLostJobs: getRestartLostJobs(headnode), OrphanedJobs: getOrphanedJobs(headnode), HeartbeatIntervalSecond: int32(getHeartbeatInterval(headnode))}
+			if key, ok := HeadnodeKeys.Load(headnode); ok {
+				request.Signature = signHeartbeat(key.([]byte), NodeName, from, headnode, request.Timestamp)
+			}
+			if len(relay) == 0 && stream_supported {
+				if stream == nil {
+					stream, err = openHeartbeatStream(headnode)
+				}
+				if stream != nil {
+					if err = stream.Send(request); err != nil {
+						stream.Close()
+						stream = nil
+					}
+				}
+				if status.Code(err) == codes.Unimplemented {
+					LogWarning("Headnode %v doesn't support heartbeat stream, send heartbeats by unary calls instead", headnode)
+					stream_supported = false
+				}
+			} else if stream != nil {
+				stream.Close()
+				stream = nil
+			}
+			if len(relay) > 0 || !stream_supported {
+				err = sendHeartbeat(target, request)
+			}
+			if err != nil {
+				LogError("Can not send heartbeat: %v", err)
 				connected = false
-			} else {
-				if !connected {
+				failures++
+			} else if !connected {
+				if len(relay) > 0 {
+					LogInfo("Connected to headnode %v through relay %v", headnode, target)
+				} else {
 					LogInfo("Connected to headnode %v", headnode)
-					connected = true
 				}
-				c := pb.NewHeadnodeClient(conn)
-				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-				_, err := c.Heartbeat(ctx, &pb.HeartbeatRequest{Nodename: NodeName, Host: from})
-				if err != nil {
-					LogError("Can not send heartbeat: %v", err)
-					connected = false
-				}
-				cancel()
-				conn.Close()
+				connected = true
+			}
+			if err == nil {
+				failures = 0
+			}
+			if err == nil && len(request.OrphanedJobs) > 0 {
+				removeReportedOrphanedJobs(headnode, request.OrphanedJobs)
 			}
 			state.(*heartbeat_state).Connected = connected
-			cancelConn()
+			if !connected {
+				state.(*heartbeat_state).Validated = false
+				state.(*heartbeat_state).Draining = false
+			}
+			if connected {
+				keepReverseTunnels(from, headnode)
+			}
 		} else if !stopped {
 			LogInfo("Stop heartbeat from %v to %v", from, headnode)
 			stopped = true
+			failures = 0
+			if stream != nil {
+				stream.Close()
+				stream = nil
+			}
+		}
+		if interval := time.Duration(getHeartbeatInterval(headnode)) * time.Second; failures > 0 {
+			if delay := getReconnectBackoff().delay(failures - 1); delay > interval {
+				interval = delay
+			}
+			time.Sleep(interval)
+		} else if interval != time.Duration(Config_Clusnode_HeartbeatIntervalSecond.GetInt())*time.Second {
+			time.Sleep(interval)
+		} else {
+			sleepConfigInterval(&Config_Clusnode_HeartbeatIntervalSecond, time.Second)
+		}
+	}
+}
+
+// Get the heartbeat interval in seconds to the headnode, which is set by the headnode or the config of clusnode
+func getHeartbeatInterval(headnode string) int {
+	if state, ok := headnodesReporting.Load(headnode); ok && state.(*heartbeat_state).Interval > 0 {
+		return state.(*heartbeat_state).Interval
+	}
+	return Config_Clusnode_HeartbeatIntervalSecond.GetInt()
+}
+
+// Heartbeat to the headnode at the interval set by it, or at the interval in config if it is 0
+func setHeartbeatInterval(headnode string, seconds int) {
+	if state, ok := headnodesReporting.Load(headnode); ok && state.(*heartbeat_state).Interval != seconds && seconds >= 0 {
+		state.(*heartbeat_state).Interval = seconds
+		if seconds > 0 {
+			LogInfo("Heartbeat interval to headnode %v is set to %v seconds", headnode, seconds)
+		} else {
+			LogInfo("Heartbeat interval to headnode %v is reset to the config", headnode)
 		}
-		time.Sleep(time.Duration(Config_Clusnode_HeartbeatIntervalSecond.GetInt()) * time.Second)
 	}
 }
+
+// Send the heartbeat by a unary call, to the relay or the headnode not supporting heartbeat stream
+func sendHeartbeat(target string, request *pb.HeartbeatRequest) error {
+	conn, cancelConn := ConnectNode(target)
+	defer cancelConn()
+	if conn == nil {
+		return status.Errorf(codes.Unavailable, "Can not connect %v", target)
+	}
+	defer conn.Close()
+	c := pb.NewHeadnodeClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := c.Heartbeat(ctx, request)
+	return err
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"clusrun/clusnode/platform"
+	pb "clusrun/protobuf"
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_getProcessDescendants(t *testing.T) {
+	processes := map[int]platform.ProcessEntry{
+		1:  {Ppid: 0, StartTime: 1},
+		10: {Ppid: 1, StartTime: 10},
+		11: {Ppid: 10, StartTime: 11},
+		12: {Ppid: 11, StartTime: 12},
+		13: {Ppid: 1, StartTime: 13}, // left the tree by setsid and re-parented
+		20: {Ppid: 1, StartTime: 20},
+	}
+	descendants := getProcessDescendants([]int{10}, processes)
+	if expected := []processRef{{11, 11}, {12, 12}}; !reflect.DeepEqual(descendants, expected) {
+		t.Errorf("Expected descendants %v, got %v", expected, descendants)
+	}
+	if d := getProcessDescendants([]int{10, 11}, processes); !reflect.DeepEqual(d, []processRef{{12, 12}}) {
+		t.Errorf("Expected roots excluded from descendants, got %v", d)
+	}
+
+	// The pid reused by another process is not alive
+	delete(processes, 11)
+	processes[12] = platform.ProcessEntry{Ppid: 1, StartTime: 30}
+	if alive := getAliveProcesses(append(descendants, processRef{13, 13}), processes); !reflect.DeepEqual(alive, []processRef{{13, 13}}) {
+		t.Errorf("Expected only process 13 alive, got %v", alive)
+	}
+
+	if processes := platform.GetProcesses(); processes != nil {
+		if p, ok := processes[os.Getpid()]; !ok || p.Ppid != os.Getppid() {
+			t.Errorf("Expected parent %v of this process, got %v", os.Getppid(), p)
+		}
+	}
+}
+
+type signedStartJobServer struct {
+	fakeStartJobServer
+	ctx context.Context
+}
+
+func (s *signedStartJobServer) Context() context.Context {
+	return s.ctx
+}
+
+func Test_checkReportedHeadnode(t *testing.T) {
+	headnodesReporting.Store("headnode:50505", &heartbeat_state{})
+	headnodesReporting.Store("stopped:50505", &heartbeat_state{Stopped: true})
+	defer headnodesReporting.Delete("headnode:50505")
+	defer headnodesReporting.Delete("stopped:50505")
+	signed_by := func(headnode string) context.Context {
+		ctx := withHeadnodeVerification(context.Background())
+		setVerifiedHeadnode(ctx, headnode)
+		return ctx
+	}
+	cases := []struct {
+		ctx      context.Context
+		reported string
+		code     codes.Code
+	}{
+		{signed_by("headnode:50505"), "headnode:50505", codes.OK},
+		{signed_by("headnode:50505"), "", codes.InvalidArgument},
+		{context.Background(), "headnode:50505", codes.PermissionDenied},
+		{signed_by("other:50505"), "headnode:50505", codes.PermissionDenied},
+		{signed_by("stopped:50505"), "stopped:50505", codes.PermissionDenied},
+	}
+
+	for _, c := range cases {
+		if err := checkReportedHeadnode(c.ctx, c.reported); status.Code(err) != c.code {
+			t.Errorf("\nreported=%v\nexpected code=%v\n  actual error=%v", c.reported, c.code, err)
+		}
+	}
+
+	// The job and configs are rejected before being handled if the headnode reported to is not the one signing the call
+	for _, c := range cases[1:] {
+		out := &signedStartJobServer{ctx: c.ctx}
+		if err := (&clusnode_server{}).StartJob(&pb.StartJobRequest{JobId: 1, Command: "echo", Headnode: "headnode:50505", ReportedHeadnode: c.reported}, out); status.Code(err) != c.code || len(out.replies) > 0 {
+			t.Errorf("\nreported=%v\nexpected job rejected with code=%v\n  actual error=%v", c.reported, c.code, err)
+		}
+		if _, err := (&clusnode_server{}).ApplyConfigs(c.ctx, &pb.ApplyConfigsRequest{Headnode: c.reported, Configs: map[string]string{Config_LogLevel.Name: "debug"}, DryRun: true}); status.Code(err) != c.code {
+			t.Errorf("\nreported=%v\nexpected configs rejected with code=%v\n  actual error=%v", c.reported, c.code, err)
+		}
+	}
+}
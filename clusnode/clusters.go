@@ -0,0 +1,198 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ClustersNone = "none"
+
+	clusterSeparator        = ";"
+	clusterPatternSeparator = ":"
+	authClusterSeparator    = "@" // the separator of role and cluster in the roles of tokens and groups, e.g. operator@team1
+)
+
+var (
+	clusterNameRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$`)
+
+	nodeClustersValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if _, err := parseNodeClusters(v); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	// The RPCs a client limited to a cluster can call, which only access the nodes and jobs in its cluster
+	clusterScopedRpcs = map[string]bool{
+		"/clusrun.Headnode/GetNodes":            true,
+		"/clusrun.Headnode/GetJobs":             true,
+		"/clusrun.Headnode/StreamJobs":          true,
+		"/clusrun.Headnode/GetOutput":           true,
+		"/clusrun.Headnode/WatchClusJob":        true,
+		"/clusrun.Headnode/StartClusJob":        true,
+		"/clusrun.Headnode/RerunJob":            true,
+		"/clusrun.Headnode/ForwardJobInput":     true,
+		"/clusrun.Headnode/CancelClusJobs":      true,
+		"/clusrun.Headnode/TestNodes":           true,
+		"/clusrun.Headnode/GetNodeAvailability": true,
+		"/clusrun.Headnode/ExportJobs":          true,
+		"/clusrun.Headnode/Login":               true,
+	}
+)
+
+// A named cluster of nodes, which serves a team without them seeing or running on the nodes of other clusters
+type nodeCluster struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// The role of client, which only applies to the nodes and jobs of the cluster if it is not empty
+type authScope struct {
+	Role    authRole
+	Cluster string
+}
+
+func (s authScope) String() string {
+	if len(s.Cluster) == 0 {
+		return s.Role.String()
+	}
+	return s.Role.String() + authClusterSeparator + s.Cluster
+}
+
+// A higher role wins, and a role not limited to a cluster wins over the same role limited to a cluster
+func (s authScope) higherThan(other authScope) bool {
+	return s.Role > other.Role || s.Role == other.Role && len(s.Cluster) == 0 && len(other.Cluster) > 0
+}
+
+func normalizeClusterName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !clusterNameRegexp.MatchString(name) {
+		return "", fmt.Errorf("Invalid cluster name %q, which should consist of letters, digits, '.', '_' or '-'", name)
+	}
+	return name, nil
+}
+
+// Parse the role in format "role" or "role@cluster"
+func parseAuthScope(s string) (authScope, error) {
+	name, cluster := s, ""
+	if i := strings.Index(s, authClusterSeparator); i >= 0 {
+		name = s[:i]
+		var err error
+		if cluster, err = normalizeClusterName(s[i+1:]); err != nil {
+			return authScope{}, err
+		}
+	}
+	role, ok := authRoleNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return authScope{}, fmt.Errorf("Invalid role %q, should be one of: admin, operator, reader", name)
+	}
+	return authScope{Role: role, Cluster: cluster}, nil
+}
+
+// Parse the clusters in format "cluster:pattern[;cluster:pattern...]", in which the pattern is a regular expression
+// matching the whole names of the nodes in the cluster
+func parseNodeClusters(value string) ([]nodeCluster, error) {
+	clusters := []nodeCluster{}
+	for _, item := range strings.Split(value, clusterSeparator) {
+		if item = strings.TrimSpace(item); len(item) == 0 {
+			continue
+		}
+		i := strings.Index(item, clusterPatternSeparator)
+		if i < 0 {
+			return nil, fmt.Errorf("Missing pattern of cluster, expect format: cluster%vpattern", clusterPatternSeparator)
+		}
+		name, err := normalizeClusterName(item[:i])
+		if err != nil {
+			return nil, err
+		}
+		pattern, err := regexp.Compile("(?i)^(?:" + strings.TrimSpace(item[i+1:]) + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("Invalid pattern of cluster %v: %v", name, err)
+		}
+		clusters = append(clusters, nodeCluster{name: name, pattern: pattern})
+	}
+	return clusters, nil
+}
+
+func parseNodeClustersOrEmpty() []nodeCluster {
+	clusters, err := parseNodeClusters(Config_Headnode_Clusters.GetString())
+	if err != nil {
+		LogError("Invalid clusters: %v", err)
+	}
+	return clusters
+}
+
+// The cluster of a node is the first one matching its name, or empty if none matches
+func getNodeCluster(clusters []nodeCluster, node string) string {
+	for _, c := range clusters {
+		if c.pattern.MatchString(node) {
+			return c.name
+		}
+	}
+	return ""
+}
+
+type authClusterKey struct{}
+
+func withAuthCluster(ctx context.Context, cluster string) context.Context {
+	if len(cluster) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, authClusterKey{}, cluster)
+}
+
+// Get the cluster the client is limited to, empty if the client is not limited
+func getAuthCluster(ctx context.Context) string {
+	cluster, _ := ctx.Value(authClusterKey{}).(string)
+	return cluster
+}
+
+// The stream whose context carries the cluster and identity of client
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// Get the cluster requested by the client, which is its own cluster if the client is limited to one
+func getClientCluster(ctx context.Context, requested string) (string, error) {
+	if len(requested) > 0 {
+		var err error
+		if requested, err = normalizeClusterName(requested); err != nil {
+			return "", status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	cluster := getAuthCluster(ctx)
+	if len(cluster) == 0 {
+		return requested, nil
+	}
+	if len(requested) > 0 && requested != cluster {
+		return "", status.Errorf(codes.PermissionDenied, "Not allowed to access cluster %v", requested)
+	}
+	return cluster, nil
+}
+
+// Get the job visible to the client, the jobs in other clusters are not found by the client limited to a cluster
+func getClientJob(ctx context.Context, id int32) (*pb.Job, error) {
+	job, err := GetJob(id)
+	if err == nil {
+		if cluster := getAuthCluster(ctx); len(cluster) > 0 && job.Cluster != cluster {
+			job, err = nil, fmt.Errorf("Job %v doesn't exist", id)
+		}
+	}
+	return job, err
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_nodeClusters(t *testing.T) {
+	clusters, err := parseNodeClusters(" Team1:web-\\d+ ; team2:db-.*;team3:.*")
+	if err != nil {
+		t.Fatalf("failed to parse clusters: %v", err)
+	}
+	for node, expected := range map[string]string{"WEB-1": "team1", "web-1x": "team3", "db-a": "team2", "other": "team3"} {
+		if cluster := getNodeCluster(clusters, node); cluster != expected {
+			t.Errorf("Expected cluster %v of node %v, got %v", expected, node, cluster)
+		}
+	}
+	for _, invalid := range []string{"team1", "team 1:web", "team1:web(", ":web"} {
+		if _, err := parseNodeClusters(invalid); err == nil {
+			t.Errorf("Expected error for clusters %q", invalid)
+		}
+	}
+
+	if !(authScope{Role: authRole_Operator}).higherThan(authScope{Role: authRole_Operator, Cluster: "team1"}) ||
+		(authScope{Role: authRole_Reader}).higherThan(authScope{Role: authRole_Operator, Cluster: "team1"}) {
+		t.Errorf("Unexpected order of auth scopes")
+	}
+
+	ctx := withAuthCluster(context.Background(), "team1")
+	if cluster, err := getClientCluster(ctx, ""); err != nil || cluster != "team1" {
+		t.Errorf("Expected the cluster of client, got %v, %v", cluster, err)
+	}
+	if _, err := getClientCluster(ctx, "team2"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected other cluster denied, got %v", err)
+	}
+	if cluster, err := getClientCluster(context.Background(), "Team2"); err != nil || cluster != "team2" {
+		t.Errorf("Expected the requested cluster, got %v, %v", cluster, err)
+	}
+	if _, err := getClientCluster(context.Background(), "team 2"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected invalid cluster rejected, got %v", err)
+	}
+
+	filter := &jobFilter{cluster: "team1"}
+	if !filter.match(&pb.Job{Cluster: "team1"}) || filter.match(&pb.Job{Cluster: "team2"}) || filter.match(&pb.Job{}) {
+		t.Errorf("Unexpected jobs matched by cluster")
+	}
+}
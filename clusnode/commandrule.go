@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	CommandRulesNone    = "none"
+	commandRule_Allow   = "allow"
+	commandRule_Deny    = "deny"
+	commandRulesExample = `[{"action":"allow","prefix":"hostname","headnodes":["host:50505"]},{"action":"deny","regex":".*"}]`
+)
+
+var (
+	commandRulesValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		_, err := parseCommandRules(v)
+		return err
+	}
+)
+
+// A rule matches a command by prefix or regular expression (any command if neither is specified) from the headnodes (any headnode if empty)
+type commandRule struct {
+	Action    string   `json:"action"`
+	Prefix    string   `json:"prefix,omitempty"`
+	Regex     string   `json:"regex,omitempty"`
+	Headnodes []string `json:"headnodes,omitempty"`
+	regex     *regexp.Regexp
+}
+
+// Parse the rules in JSON array, empty for allowing any command
+func parseCommandRules(value string) ([]*commandRule, error) {
+	if len(strings.TrimSpace(value)) == 0 {
+		return nil, nil
+	}
+	var rules []*commandRule
+	if err := json.Unmarshal([]byte(value), &rules); err != nil {
+		return nil, fmt.Errorf("Invalid rules, expect JSON array like %v: %v", commandRulesExample, err)
+	}
+	for i, rule := range rules {
+		if rule.Action != commandRule_Allow && rule.Action != commandRule_Deny {
+			return nil, fmt.Errorf("Invalid action %q in rule %v, should be %v or %v", rule.Action, i+1, commandRule_Allow, commandRule_Deny)
+		}
+		if len(rule.Prefix) > 0 && len(rule.Regex) > 0 {
+			return nil, fmt.Errorf("Prefix and regex can not be specified at the same time in rule %v", i+1)
+		}
+		if len(rule.Regex) > 0 {
+			regex, err := regexp.Compile(rule.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid regex in rule %v: %v", i+1, err)
+			}
+			rule.regex = regex
+		}
+	}
+	return rules, nil
+}
+
+func (r *commandRule) Match(headnode, command string) bool {
+	if len(r.Headnodes) > 0 {
+		found := false
+		for _, h := range r.Headnodes {
+			if isSameHost(headnode, h) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if r.regex != nil {
+		return r.regex.MatchString(command)
+	}
+	return strings.HasPrefix(command, r.Prefix)
+}
+
+// Check the command from the headnode by the first matching rule, a command matching no rule is denied if there is any rule
+func checkCommandRules(rules []*commandRule, headnode, command string) error {
+	for i, rule := range rules {
+		if rule.Match(headnode, command) {
+			if rule.Action == commandRule_Deny {
+				return fmt.Errorf("Command is denied by rule %v on node %v", i+1, NodeName)
+			}
+			return nil
+		}
+	}
+	if len(rules) > 0 {
+		return fmt.Errorf("Command is not allowed by any rule on node %v", NodeName)
+	}
+	return nil
+}
+
+func checkCommand(headnode, command string, arguments []string) error {
+	rules, err := parseCommandRules(Config_Clusnode_CommandRules.GetString())
+	if err != nil {
+		LogError("Invalid command rules: %v", err)
+		return fmt.Errorf("Invalid command rules on node %v", NodeName)
+	}
+	if len(arguments) > 0 {
+		command = strings.Join(append([]string{command}, arguments...), " ")
+	}
+	return checkCommandRules(rules, headnode, strings.TrimSpace(command))
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_checkCommandRules(t *testing.T) {
+	rules, err := parseCommandRules(`[
+		{"action": "deny", "regex": "\\brm\\b"},
+		{"action": "allow", "prefix": "hostname"},
+		{"action": "allow", "regex": "^(ip|ifconfig) ", "headnodes": ["prod:50505"]},
+		{"action": "deny", "prefix": "shutdown", "headnodes": ["test"]},
+		{"action": "allow", "prefix": "shutdown"}
+	]`)
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %v", err)
+	}
+	cases := []struct {
+		headnode string
+		command  string
+		allowed  bool
+	}{
+		{"prod:50505", "hostname", true},
+		{"prod:50505", "hostname && rm -rf /", false},
+		{"prod:50505", "ip addr", true},
+		{"test:50505", "ip addr", false},
+		{"test:50505", "shutdown -r", false},
+		{"prod:50505", "shutdown -r", true},
+		{"prod:50505", "whoami", false},
+	}
+
+	for _, c := range cases {
+		if err := checkCommandRules(rules, c.headnode, c.command); (err == nil) != c.allowed {
+			t.Errorf("\nheadnode=%v, command=%q\nexpected allowed=%v\n  actual error=%v", c.headnode, c.command, c.allowed, err)
+		}
+	}
+	if err := checkCommandRules(nil, "test:50505", "whoami"); err != nil {
+		t.Errorf("Command should be allowed without rules: %v", err)
+	}
+	for _, invalid := range []string{`{"action": "allow"}`, `[{"action": "permit"}]`, `[{"action": "deny", "regex": "("}]`, `[{"action": "deny", "prefix": "a", "regex": "b"}]`} {
+		if _, err := parseCommandRules(invalid); err == nil {
+			t.Errorf("Rules %v should be invalid", invalid)
+		}
+	}
+}
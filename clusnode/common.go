@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"strconv"
 	"strings"
@@ -17,7 +18,7 @@ import (
 const (
 	Max_Int        = int(^uint(0) >> 1)
 	Min_Int        = -Max_Int - 1
-	DefaultPort    = "50505"
+	defaultPort    = "50505"
 	ConnectTimeout = 30 * time.Second
 )
 
@@ -25,8 +26,11 @@ var (
 	LineEnding     string
 	RunOnWindows   bool
 	ExecutablePath string
-	NodeHost       string
+	NodeHost       string   // the host address advertised to other nodes, which may be an external one behind NAT
+	NodeListens    []string // the addresses this node listens on
 	NodeName       string
+	DefaultPort    = defaultPort // can be changed by the environment variable CLUSRUN_DEFAULT_PORT
+	StartTime      = time.Now()
 	Tls            struct {
 		Enabled  bool
 		CertFile string
@@ -34,32 +38,54 @@ var (
 	}
 )
 
+// Parse the host address in format hostname[:port], ipv4[:port], [ipv6][:port] or ipv6 without port, the port is the
+// default one if not specified
 func ParseHostAddress(address string) (hostname, port, host string, err error) {
-	segs := strings.Split(address, ":")
-	if len(segs) > 2 {
-		err = errors.New("Incorrect host address: " + address)
-		return
-	} else {
-		hostname = strings.ToUpper(strings.TrimSpace(segs[0]))
-		if len(hostname) == 0 {
-			err = errors.New("Empty address")
+	hostname = strings.TrimSpace(address)
+	if strings.HasPrefix(hostname, "[") {
+		i := strings.Index(hostname, "]")
+		if i < 0 || i < len(hostname)-1 && hostname[i+1] != ':' {
+			err = errors.New("Incorrect host address: " + address)
 			return
+		} else if i < len(hostname)-1 {
+			port = hostname[i+2:]
 		}
-		if hostname == "LOCALHOST" {
-			hostname = strings.ToUpper(NodeName)
+		hostname = hostname[1:i]
+		if ip := net.ParseIP(hostname); ip == nil || !strings.Contains(hostname, ":") {
+			err = errors.New("Incorrect IPv6 address: " + address)
+			return
 		}
-		if len(segs) == 2 {
-			temp_port, temp_err := strconv.ParseUint(segs[1], 10, 16)
-			if temp_err != nil {
-				err = errors.New("Incorrect port format: " + temp_err.Error())
-				return
-			}
-			port = strconv.Itoa(int(temp_port))
-		} else {
-			port = DefaultPort
+	} else if strings.Count(hostname, ":") > 1 {
+		if net.ParseIP(hostname) == nil {
+			err = errors.New("Incorrect host address: " + address)
+			return
 		}
+	} else if i := strings.Index(hostname, ":"); i >= 0 {
+		hostname, port = hostname[:i], hostname[i+1:]
+		if len(port) == 0 {
+			err = errors.New("Incorrect port format: empty port")
+			return
+		}
+	}
+	hostname = strings.ToUpper(strings.TrimSpace(hostname))
+	if len(hostname) == 0 {
+		err = errors.New("Empty address")
+		return
+	}
+	if hostname == "LOCALHOST" {
+		hostname = strings.ToUpper(NodeName)
+	}
+	if len(port) > 0 {
+		temp_port, temp_err := strconv.ParseUint(port, 10, 16)
+		if temp_err != nil {
+			err = errors.New("Incorrect port format: " + temp_err.Error())
+			return
+		}
+		port = strconv.Itoa(int(temp_port))
+	} else {
+		port = DefaultPort
 	}
-	host = hostname + ":" + port
+	host = net.JoinHostPort(hostname, port)
 	return
 }
 
@@ -67,7 +93,7 @@ func FileNameFormatHost(host string) string {
 	return strings.ReplaceAll(host, ":", ".")
 }
 
-func ConnectNode(host string) (*grpc.ClientConn, context.CancelFunc) {
+func ConnectNode(host string, options ...grpc.DialOption) (*grpc.ClientConn, context.CancelFunc) {
 	ctx, cancel := context.WithTimeout(context.Background(), ConnectTimeout)
 	secureOption := grpc.WithInsecure()
 	if Tls.Enabled {
@@ -76,7 +102,10 @@ func ConnectNode(host string) (*grpc.ClientConn, context.CancelFunc) {
 		}
 		secureOption = grpc.WithTransportCredentials(credentials.NewTLS(config))
 	}
-	conn, err := grpc.DialContext(ctx, host, secureOption, grpc.WithBlock())
+	options = append([]grpc.DialOption{secureOption, grpc.WithBlock(),
+		grpc.WithChainUnaryInterceptor(traceUnaryClientInterceptor, signHeadnodeUnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(traceStreamClientInterceptor, signHeadnodeStreamClientInterceptor)}, append(getGrpcDialOptions(), options...)...)
+	conn, err := grpc.DialContext(ctx, host, options...)
 	if err != nil {
 		LogError("Can not connect %v in %v: %v", host, ConnectTimeout, err)
 	}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ParseHostAddress(t *testing.T) {
+	cases := map[string][3]string{
+		"node1":             {"NODE1", DefaultPort, "NODE1:" + DefaultPort},
+		"node1:1234":        {"NODE1", "1234", "NODE1:1234"},
+		"10.0.0.1:1234":     {"10.0.0.1", "1234", "10.0.0.1:1234"},
+		"[::1]:1234":        {"::1", "1234", "[::1]:1234"},
+		"[fe80::1]":         {"FE80::1", DefaultPort, "[FE80::1]:" + DefaultPort},
+		"2001:db8::1":       {"2001:DB8::1", DefaultPort, "[2001:DB8::1]:" + DefaultPort},
+		" [2001:db8::2]:80": {"2001:DB8::2", "80", "[2001:DB8::2]:80"},
+	}
+	for address, expected := range cases {
+		hostname, port, host, err := ParseHostAddress(address)
+		if err != nil {
+			t.Errorf("Expected %q parsed, got error: %v", address, err)
+		} else if result := [3]string{hostname, port, host}; result != expected {
+			t.Errorf("Expected %q parsed to %v, got %v", address, expected, result)
+		}
+	}
+	for _, address := range []string{"", "node1:", "node1:abc", "[::1", "[::1]1234", "[node1]:1234", "1:2:3", "[::1]:99999"} {
+		if _, _, _, err := ParseHostAddress(address); err == nil {
+			t.Errorf("Expected error parsing %q", address)
+		}
+	}
+
+	listen_cases := map[string][]string{
+		"":                          {":1234"},
+		"0.0.0.0":                   {"0.0.0.0:1234"},
+		"10.0.0.1:80, [::1]":        {"10.0.0.1:80", "[::1]:1234"},
+		"[::]:50505,127.0.0.1:8080": {"[::]:50505", "127.0.0.1:8080"},
+		":80":                       {":80"},
+	}
+	for value, expected := range listen_cases {
+		if addresses, err := parseListenAddresses(value, "node1:1234"); err != nil {
+			t.Errorf("Expected listen addresses %q parsed, got error: %v", value, err)
+		} else if !reflect.DeepEqual(addresses, expected) {
+			t.Errorf("Expected listen addresses %q parsed to %v, got %v", value, expected, addresses)
+		}
+	}
+	for _, value := range []string{"10.0.0.1:abc", "[1:2:3]", "10.0.0.1:99999"} {
+		if _, err := parseListenAddresses(value, "node1:1234"); err == nil {
+			t.Errorf("Expected error parsing listen addresses %q", value)
+		}
+	}
+}
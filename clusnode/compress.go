@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	OutputCompression_None = "none"
+	OutputCompression_Gzip = "gzip"
+
+	compressedOutputExt = ".gz"
+)
+
+var (
+	outputCompressions = []string{OutputCompression_None, OutputCompression_Gzip}
+
+	outputCompressionValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if !isValidOutputCompression(v) {
+			return fmt.Errorf("Value should be one of: %v", strings.Join(outputCompressions, ", "))
+		}
+		return nil
+	}
+)
+
+func isValidOutputCompression(compression string) bool {
+	for _, c := range outputCompressions {
+		if c == compression {
+			return true
+		}
+	}
+	return false
+}
+
+// The call options to compress the output stream from clusnode, which responds in the same encoding
+func getOutputStreamCallOptions() []grpc.CallOption {
+	if Config_Headnode_OutputCompression.GetString() == OutputCompression_Gzip {
+		return []grpc.CallOption{grpc.UseCompressor(OutputCompression_Gzip)}
+	}
+	return nil
+}
+
+// Replace the stored output file with the compressed one
+func compressOutputFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	compressed := file + compressedOutputExt
+	temp := compressed + ".tmp"
+	f_gz, err := os.Create(temp)
+	if err != nil {
+		return err
+	}
+	w := gzip.NewWriter(f_gz)
+	if _, err = io.Copy(w, f); err == nil {
+		err = w.Close()
+	}
+	if err == nil {
+		err = f_gz.Sync()
+	}
+	if e := f_gz.Close(); err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Rename(temp, compressed)
+	}
+	if err != nil {
+		_ = os.Remove(temp)
+		return err
+	}
+	f.Close()
+	return os.Remove(file)
+}
+
+// The stored output file read in place, or in memory if it is compressed or encrypted
+type outputFile interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+	io.Closer
+}
+
+type memoryOutputFile struct {
+	*bytes.Reader
+}
+
+func (memoryOutputFile) Close() error {
+	return nil
+}
+
+// Open the stored output file or its compressed one, or the blob or object it refers to, and get the size of the output
+func openOutputFile(file string) (outputFile, int64, error) {
+	f, err := os.Open(file)
+	if err == nil {
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, 0, err
+		}
+		header := make([]byte, len(encryptedDataHeader))
+		if n, _ := f.ReadAt(header, 0); n < len(header) || !isEncryptedData(header) {
+			return f, info.Size(), nil
+		}
+		defer f.Close()
+		data, err := ioutil.ReadAll(f)
+		if err == nil {
+			data, err = decryptAtRest(data)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		return memoryOutputFile{bytes.NewReader(data)}, int64(len(data)), nil
+	} else if !os.IsNotExist(err) {
+		return nil, 0, err
+	}
+	f, err = os.Open(file + compressedOutputExt)
+	if os.IsNotExist(err) {
+		if hash, e := readOutputRef(file + outputRefExt); e == nil {
+			return openOutputFile(getOutputBlobPath(hash))
+		}
+		if key, e := readOutputObjectRef(file + outputObjectExt); e == nil {
+			return openOutputObject(key)
+		}
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+	if err == nil {
+		data, err = decryptAtRest(data)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err = ioutil.ReadAll(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return memoryOutputFile{bytes.NewReader(data)}, int64(len(data)), nil
+}
+
+func outputFileExists(file string) bool {
+	for _, f := range []string{file, file + compressedOutputExt, file + outputRefExt, file + outputObjectExt} {
+		if _, err := os.Stat(f); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func Test_compressOutputFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusrun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cases := []struct {
+		output   string
+		tail     int
+		expected string
+	}{
+		{"", 1, ""},
+		{"line1\nline2\nline3\n", 0, "line1\nline2\nline3\n"},
+		{"line1\nline2\nline3\n", 2, "line2\nline3\n"},
+		{"line1\nline2\nline3", 1, "line3"},
+	}
+	for i, c := range cases {
+		file := filepath.Join(dir, strconv.Itoa(i))
+		if err := ioutil.WriteFile(file, []byte(c.output), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := compressOutputFile(file); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(file); !os.IsNotExist(err) || !outputFileExists(file) {
+			t.Errorf("output file %v is not replaced by the compressed one: %v", file, err)
+		}
+		actual := ""
+		if err := sendOutputFile(file, 0, int32(c.tail), func(data string) error { actual += data; return nil }); err != nil {
+			t.Fatal(err)
+		}
+		if actual != c.expected {
+			t.Errorf("\noutput=%q\ntail=%v\nexpected=%q\n  actual=%q", c.output, c.tail, c.expected, actual)
+		}
+	}
+}
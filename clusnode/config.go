@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/fslock"
@@ -30,17 +33,176 @@ var (
 		}
 		return nil
 	}
+	nonNegativeIntValidator = func(value interface{}) error {
+		if v, ok := value.(int); !ok {
+			return errors.New("Invalid type")
+		} else if v < 0 {
+			return errors.New("Value should not be negative")
+		}
+		return nil
+	}
+	percentValidator = func(value interface{}) error {
+		if v, ok := value.(int); !ok {
+			return errors.New("Invalid type")
+		} else if v < 0 || v > 100 {
+			return errors.New("Value should be between 0 and 100")
+		}
+		return nil
+	}
+	reservedPercentValidator = func(value interface{}) error {
+		if v, ok := value.(int); !ok {
+			return errors.New("Invalid type")
+		} else if v < 0 || v > 99 {
+			return errors.New("Value should be between 0 and 99")
+		}
+		return nil
+	}
 
 	Config_Clusnode_HeartbeatIntervalSecond = ConfigItem{
 		Name:      "heartbeat interval in seconds",
 		Value:     1,
 		Validator: positiveIntValidator,
 	}
+	Config_Clusnode_AllowedWorkingDirs = ConfigItem{
+		Name:  "allowed working dirs of jobs",
+		Value: "",
+	}
+	Config_Clusnode_RunAsUsers = ConfigItem{
+		Name:  "users allowed to run jobs as",
+		Value: "",
+	}
+	Config_Clusnode_RunAsHeadnodes = ConfigItem{
+		Name:  "headnodes allowed to run jobs as other users",
+		Value: "",
+	}
+	Config_Clusnode_CommandRules = ConfigItem{
+		Name:      "command rules of jobs",
+		Value:     "",
+		Validator: commandRulesValidator,
+	}
+	Config_Clusnode_Zone = ConfigItem{
+		Name:  "zone",
+		Value: "",
+	}
+	Config_Clusnode_Labels = ConfigItem{
+		Name:      "labels",
+		Value:     "",
+		Validator: nodeLabelsValidator,
+	}
+	Config_Clusnode_Relay = ConfigItem{
+		Name:      "heartbeat relay",
+		Value:     "",
+		Validator: relayValidator,
+	}
+	Config_Clusnode_ReverseTunnels = ConfigItem{
+		Name:      "reverse tunnel count",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Clusnode_ReservedCpuPercent = ConfigItem{
+		Name:      "reserved CPU percent",
+		Value:     0,
+		Validator: reservedPercentValidator,
+	}
+	Config_Clusnode_ReservedMemoryMb = ConfigItem{
+		Name:      "reserved memory in MB",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Clusnode_JobCpuPercent = ConfigItem{
+		Name:      "job CPU limit percent",
+		Value:     0,
+		Validator: percentValidator,
+	}
+	Config_Clusnode_JobMemoryMb = ConfigItem{
+		Name:      "job memory limit in MB",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Clusnode_JobTimeoutSecond = ConfigItem{
+		Name:      "job timeout in seconds",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Clusnode_KillGraceSecond = ConfigItem{
+		Name:      "kill grace period in seconds",
+		Value:     5,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Clusnode_OrphanTimeoutSecond = ConfigItem{
+		Name:      "orphaned job timeout in seconds",
+		Value:     600,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Clusnode_OrphanAction = ConfigItem{
+		Name:      "orphaned job action",
+		Value:     OrphanAction_Kill,
+		Validator: orphanActionValidator,
+	}
+	Config_Clusnode_CleanupRetentionHours = ConfigItem{
+		Name:      "job files retention in hours",
+		Value:     24,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Clusnode_CleanupMinFreeDisk = ConfigItem{
+		Name:      "min free disk percent",
+		Value:     10,
+		Validator: percentValidator,
+	}
+	Config_Clusnode_EnvMode = ConfigItem{
+		Name:      "job environment mode",
+		Value:     EnvMode_Inherit,
+		Validator: envModeValidator,
+	}
+	Config_Clusnode_BaseEnvironment = ConfigItem{
+		Name:      "base environment of jobs",
+		Value:     "",
+		Validator: baseEnvValidator,
+	}
+	Config_Clusnode_AllowShell = ConfigItem{
+		Name:  "allow shell",
+		Value: false,
+	}
+	Config_Clusnode_Probes = ConfigItem{
+		Name:      "probes",
+		Value:     "",
+		Validator: probesValidator,
+	}
+	Config_Clusnode_ProbeIntervalSecond = ConfigItem{
+		Name:      "probe interval in seconds",
+		Value:     300,
+		Validator: positiveIntValidator,
+	}
+	Config_Clusnode_WindowsShell = ConfigItem{
+		Name:      "default shell on Windows",
+		Value:     JobShell_Cmd,
+		Validator: windowsShellValidator,
+	}
+	Config_Clusnode_Executors = ConfigItem{
+		Name:      "allowed executors",
+		Value:     strings.Join(jobExecutors, ","),
+		Validator: executorsValidator,
+	}
 	Config_Headnode_HeartbeatTimeoutSecond = ConfigItem{
 		Name:      "mark node lost after no heartbeat for seconds",
 		Value:     5,
 		Validator: positiveIntValidator,
 	}
+	Config_Headnode_NodeHeartbeatIntervalSecond = ConfigItem{
+		Name:      "node heartbeat interval in seconds",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_MaxClockSkewSecond = ConfigItem{
+		Name:      "max clock skew in seconds",
+		Value:     30,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_ClockSkewAction = ConfigItem{
+		Name:      "clock skew action",
+		Value:     ClockSkewAction_Warn,
+		Validator: clockSkewActionValidator,
+	}
 	Config_Headnode_MaxJobCount = ConfigItem{
 		Name:      "max job count",
 		Value:     100,
@@ -53,33 +215,462 @@ var (
 	}
 	Config_Headnode_OutputMaxTotalSizeMb = ConfigItem{
 		Name:      "max size for all job output in MB",
-		Value:     1000,
-		Validator: positiveIntValidator,
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_MaxNodeOutputMb = ConfigItem{
+		Name:      "max output of a job on one node in MB",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_MaxJobOutputMb = ConfigItem{
+		Name:      "max output of a job in MB",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_MaxJobAgeHours = ConfigItem{
+		Name:      "max job age in hours",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
 	}
 	Config_Headnode_PurgeLostForSecond = ConfigItem{
 		Name:      "purge nodes lost for seconds",
 		Value:     3600,
 		Validator: positiveIntValidator,
 	}
+	Config_Headnode_MaxParallelDispatch = ConfigItem{
+		Name:      "max parallel dispatch",
+		Value:     100,
+		Validator: positiveIntValidator,
+	}
+	Config_Headnode_ClientJobsPerMinute = ConfigItem{
+		Name:      "max jobs per minute of a client",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_ClientMaxRunningJobs = ConfigItem{
+		Name:      "max running jobs of a client",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_ShutdownTimeoutSecond = ConfigItem{
+		Name:      "shutdown timeout in seconds",
+		Value:     30,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_DispatchFanout = ConfigItem{
+		Name:      "dispatch fan-out",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_DispatchOrder = ConfigItem{
+		Name:      "dispatch order",
+		Value:     DispatchOrder_Alphabetical,
+		Validator: dispatchOrderValidator,
+	}
+	Config_Headnode_NodenameCase = ConfigItem{
+		Name:      "nodename case",
+		Value:     NodenameCase_Upper,
+		Validator: nodenameCaseValidator,
+	}
+	Config_Headnode_NodenameNormalization = ConfigItem{
+		Name:      "nodename normalization",
+		Value:     NodenameNormalization_None,
+		Validator: nodenameNormalizationValidator,
+	}
+	Config_Headnode_MaxJobBandwidthKb = ConfigItem{
+		Name:      "max job bandwidth in KB per second",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
 	Config_Headnode_StoreOutput = ConfigItem{
 		Name:  "store output",
 		Value: false,
 	}
-	Config_LogGoId = ConfigItem{
-		Name:  "add go id in logs",
+	Config_Headnode_IndexOutput = ConfigItem{
+		Name:  "index output",
+		Value: false,
+	}
+	Config_Headnode_PolicyWebhook = ConfigItem{
+		Name:      "policy webhook",
+		Value:     "",
+		Validator: policyWebhookValidator,
+		Sensitive: true,
+	}
+	Config_Headnode_PolicyWebhookTimeoutSecond = ConfigItem{
+		Name:      "policy webhook timeout in seconds",
+		Value:     5,
+		Validator: positiveIntValidator,
+	}
+	Config_Headnode_RequireSameVersion = ConfigItem{
+		Name:  "require same version",
+		Value: false,
+	}
+	Config_Headnode_LintLargeJobNodes = ConfigItem{
+		Name:      "lint large job node count",
+		Value:     100,
+		Validator: positiveIntValidator,
+	}
+	Config_Headnode_LintBlockingRules = ConfigItem{
+		Name:      "lint blocking rules",
+		Value:     "",
+		Validator: lintRulesValidator,
+	}
+	Config_Headnode_AuthTokens = ConfigItem{
+		Name:      "auth tokens",
+		Value:     "",
+		Validator: authTokensValidator,
+		Sensitive: true,
+	}
+	Config_Headnode_AuthLdapUrl = ConfigItem{
+		Name:      "auth LDAP URL",
+		Value:     "",
+		Validator: ldapUrlValidator,
+	}
+	Config_Headnode_AuthLdapUserDn = ConfigItem{
+		Name:      "auth LDAP user DN",
+		Value:     "",
+		Validator: ldapUserDnValidator,
+	}
+	Config_Headnode_AuthOidcIssuer = ConfigItem{
+		Name:      "auth OIDC issuer",
+		Value:     "",
+		Validator: oidcIssuerValidator,
+	}
+	Config_Headnode_AuthOidcClientId = ConfigItem{
+		Name:  "auth OIDC client id",
+		Value: "",
+	}
+	Config_Headnode_AuthGroupRoles = ConfigItem{
+		Name:      "auth group roles",
+		Value:     "",
+		Validator: authGroupRolesValidator,
+	}
+	Config_Headnode_Clusters = ConfigItem{
+		Name:      "clusters",
+		Value:     "",
+		Validator: nodeClustersValidator,
+	}
+	Config_Headnode_PeerHeadnodes = ConfigItem{
+		Name:      "peer headnodes",
+		Value:     "",
+		Validator: peerHeadnodesValidator,
+	}
+	Config_Headnode_ExcludedNodes = ConfigItem{
+		Name:      "excluded nodes",
+		Value:     "",
+		Validator: excludedNodesValidator,
+	}
+	Config_Headnode_RelayHeartbeats = ConfigItem{
+		Name:  "relay heartbeats",
+		Value: false,
+	}
+	Config_Headnode_RelayIntervalSecond = ConfigItem{
+		Name:      "relay interval in seconds",
+		Value:     1,
+		Validator: positiveIntValidator,
+	}
+	Config_Headnode_RestartReportTimeoutSecond = ConfigItem{
+		Name:      "restart report timeout in seconds",
+		Value:     10,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_ForgetLostNodesHours = ConfigItem{
+		Name:      "forget lost nodes after hours",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_NodeDiscovery = ConfigItem{
+		Name:      "node discovery",
+		Value:     "",
+		Validator: nodeDiscoveryValidator,
+	}
+	Config_Headnode_NodeDiscoveryIntervalSecond = ConfigItem{
+		Name:      "node discovery interval in seconds",
+		Value:     60,
+		Validator: positiveIntValidator,
+	}
+	Config_Headnode_NodeDiscoveryToken = ConfigItem{
+		Name:      "node discovery token",
+		Value:     "",
+		Sensitive: true,
+	}
+	Config_Headnode_CancelDelaySecond = ConfigItem{
+		Name:      "cancel delay in seconds",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_OutputCompression = ConfigItem{
+		Name:      "output compression",
+		Value:     OutputCompression_None,
+		Validator: outputCompressionValidator,
+	}
+	Config_Headnode_OutputStorage = ConfigItem{
+		Name:      "output storage",
+		Value:     OutputStorage_Dedup,
+		Validator: outputStorageValidator,
+	}
+	Config_Headnode_OutputObjectStore = ConfigItem{
+		Name:      "output object store",
+		Value:     "",
+		Validator: outputObjectStoreValidator,
+		Sensitive: true,
+	}
+	Config_Headnode_EncryptionKey = ConfigItem{
+		Name:      "encryption key",
+		Value:     "",
+		Validator: encryptionKeyValidator,
+		Sensitive: true,
+	}
+	Config_Headnode_PreviousEncryptionKeys = ConfigItem{
+		Name:      "previous encryption keys",
+		Value:     "",
+		Validator: previousEncryptionKeysValidator,
+		Sensitive: true,
+	}
+	Config_Headnode_FailureAnalysisMinNodes = ConfigItem{
+		Name:      "failure analysis min node count",
+		Value:     10,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_NotifyWebhooks = ConfigItem{
+		Name:      "notify webhooks",
+		Value:     "",
+		Validator: notifyWebhooksValidator,
+		Sensitive: true,
+	}
+	Config_Headnode_NotifySmtp = ConfigItem{
+		Name:      "notify SMTP",
+		Value:     "",
+		Validator: notifySmtpValidator,
+		Sensitive: true,
+	}
+	Config_Headnode_NotifyEvents = ConfigItem{
+		Name:      "notify events",
+		Value:     NotifyEventsAll,
+		Validator: notifyEventsValidator,
+	}
+	Config_Headnode_NotifyPattern = ConfigItem{
+		Name:      "notify pattern",
+		Value:     "",
+		Validator: notifyPatternValidator,
+	}
+	Config_Headnode_NotifyRetries = ConfigItem{
+		Name:      "notify retries",
+		Value:     3,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_MaxBackoffSecond = ConfigItem{
+		Name:      "max backoff in seconds",
+		Value:     60,
+		Validator: positiveIntValidator,
+	}
+	Config_Headnode_ConnectRetries = ConfigItem{
+		Name:      "connect retries",
+		Value:     2,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_Headnode_GrpcWebPort = ConfigItem{
+		Name:      "gRPC-Web port",
+		Value:     0,
+		Validator: portValidator,
+	}
+	Config_Headnode_ControlPort = ConfigItem{
+		Name:      "control port",
+		Value:     0,
+		Validator: portValidator,
+	}
+	Config_Headnode_GrpcWebOrigins = ConfigItem{
+		Name:  "gRPC-Web origins",
+		Value: "",
+	}
+	Config_LogLevel = ConfigItem{
+		Name:      "log level",
+		Value:     strings.ToLower(logLevel_Info),
+		Validator: logLevelValidator,
+	}
+	Config_LogFormat = ConfigItem{
+		Name:      "log format",
+		Value:     LogFormat_Text,
+		Validator: logFormatValidator,
+	}
+	Config_LogMaxSizeMb = ConfigItem{
+		Name:      "max log size in MB",
+		Value:     100,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_LogRotateHours = ConfigItem{
+		Name:      "log rotation in hours",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_LogMaxFiles = ConfigItem{
+		Name:      "max rotated log files",
+		Value:     10,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_LogSampleIntervalSecond = ConfigItem{
+		Name:      "log sample interval in seconds",
+		Value:     60,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_TraceOtlpEndpoint = ConfigItem{
+		Name:      "trace endpoint",
+		Value:     "",
+		Validator: traceEndpointValidator,
+	}
+	Config_TraceSamplePercent = ConfigItem{
+		Name:      "trace sample percent",
+		Value:     100,
+		Validator: percentValidator,
+	}
+	Config_GrpcKeepaliveTimeSecond = ConfigItem{
+		Name:      "gRPC keepalive time in seconds",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_GrpcKeepaliveTimeoutSecond = ConfigItem{
+		Name:      "gRPC keepalive timeout in seconds",
+		Value:     20,
+		Validator: positiveIntValidator,
+	}
+	Config_GrpcKeepalivePermitWithoutStream = ConfigItem{
+		Name:  "gRPC keepalive without stream",
 		Value: false,
 	}
+	Config_GrpcMaxRecvMsgSizeMb = ConfigItem{
+		Name:      "gRPC max receive message size in MB",
+		Value:     4,
+		Validator: positiveIntValidator,
+	}
+	Config_GrpcMaxSendMsgSizeMb = ConfigItem{
+		Name:      "gRPC max send message size in MB",
+		Value:     0,
+		Validator: nonNegativeIntValidator,
+	}
+	Config_ClusterSecret = ConfigItem{
+		Name:      "cluster secret",
+		Value:     "",
+		Validator: clusterSecretValidator,
+		Sensitive: true,
+	}
+	Config_SystemLogLevel = ConfigItem{
+		Name:  "system log level",
+		Value: systemLogLevel_None,
+		Validator: func(value interface{}) error {
+			if v, ok := value.(string); !ok {
+				return errors.New("Invalid type")
+			} else if getSystemLogLevel(v) < 0 {
+				return fmt.Errorf("Value should be one of: %v", strings.Join(systemLogLevels, ", "))
+			}
+			return nil
+		},
+	}
 
 	configs_clusnode = map[string]*ConfigItem{
 		Config_Clusnode_HeartbeatIntervalSecond.Name: &Config_Clusnode_HeartbeatIntervalSecond,
+		Config_Clusnode_AllowedWorkingDirs.Name:      &Config_Clusnode_AllowedWorkingDirs,
+		Config_Clusnode_RunAsUsers.Name:              &Config_Clusnode_RunAsUsers,
+		Config_Clusnode_RunAsHeadnodes.Name:          &Config_Clusnode_RunAsHeadnodes,
+		Config_Clusnode_Zone.Name:                    &Config_Clusnode_Zone,
+		Config_Clusnode_Labels.Name:                  &Config_Clusnode_Labels,
+		Config_Clusnode_CommandRules.Name:            &Config_Clusnode_CommandRules,
+		Config_Clusnode_ReservedCpuPercent.Name:      &Config_Clusnode_ReservedCpuPercent,
+		Config_Clusnode_ReservedMemoryMb.Name:        &Config_Clusnode_ReservedMemoryMb,
+		Config_Clusnode_JobCpuPercent.Name:           &Config_Clusnode_JobCpuPercent,
+		Config_Clusnode_JobMemoryMb.Name:             &Config_Clusnode_JobMemoryMb,
+		Config_Clusnode_JobTimeoutSecond.Name:        &Config_Clusnode_JobTimeoutSecond,
+		Config_Clusnode_KillGraceSecond.Name:         &Config_Clusnode_KillGraceSecond,
+		Config_Clusnode_OrphanTimeoutSecond.Name:     &Config_Clusnode_OrphanTimeoutSecond,
+		Config_Clusnode_OrphanAction.Name:            &Config_Clusnode_OrphanAction,
+		Config_Clusnode_CleanupRetentionHours.Name:   &Config_Clusnode_CleanupRetentionHours,
+		Config_Clusnode_CleanupMinFreeDisk.Name:      &Config_Clusnode_CleanupMinFreeDisk,
+		Config_Clusnode_Relay.Name:                   &Config_Clusnode_Relay,
+		Config_Clusnode_ReverseTunnels.Name:          &Config_Clusnode_ReverseTunnels,
+		Config_Clusnode_EnvMode.Name:                 &Config_Clusnode_EnvMode,
+		Config_Clusnode_BaseEnvironment.Name:         &Config_Clusnode_BaseEnvironment,
+		Config_Clusnode_AllowShell.Name:              &Config_Clusnode_AllowShell,
+		Config_Clusnode_WindowsShell.Name:            &Config_Clusnode_WindowsShell,
+		Config_Clusnode_Probes.Name:                  &Config_Clusnode_Probes,
+		Config_Clusnode_ProbeIntervalSecond.Name:     &Config_Clusnode_ProbeIntervalSecond,
+		Config_Clusnode_Executors.Name:               &Config_Clusnode_Executors,
 	}
 	configs_headnode = map[string]*ConfigItem{
-		Config_Headnode_HeartbeatTimeoutSecond.Name: &Config_Headnode_HeartbeatTimeoutSecond,
-		Config_Headnode_MaxJobCount.Name:            &Config_Headnode_MaxJobCount,
-		Config_Headnode_StoreOutput.Name:            &Config_Headnode_StoreOutput,
+		Config_Headnode_HeartbeatTimeoutSecond.Name:      &Config_Headnode_HeartbeatTimeoutSecond,
+		Config_Headnode_NodeHeartbeatIntervalSecond.Name: &Config_Headnode_NodeHeartbeatIntervalSecond,
+		Config_Headnode_MaxClockSkewSecond.Name:          &Config_Headnode_MaxClockSkewSecond,
+		Config_Headnode_ClockSkewAction.Name:             &Config_Headnode_ClockSkewAction,
+		Config_Headnode_MaxJobCount.Name:                 &Config_Headnode_MaxJobCount,
+		Config_Headnode_IndexOutput.Name:                 &Config_Headnode_IndexOutput,
+		Config_Headnode_StoreOutput.Name:                 &Config_Headnode_StoreOutput,
+		Config_Headnode_DispatchFanout.Name:              &Config_Headnode_DispatchFanout,
+		Config_Headnode_ClientJobsPerMinute.Name:         &Config_Headnode_ClientJobsPerMinute,
+		Config_Headnode_ClientMaxRunningJobs.Name:        &Config_Headnode_ClientMaxRunningJobs,
+		Config_Headnode_ShutdownTimeoutSecond.Name:       &Config_Headnode_ShutdownTimeoutSecond,
+		Config_Headnode_MaxParallelDispatch.Name:         &Config_Headnode_MaxParallelDispatch,
+		Config_Headnode_MaxJobBandwidthKb.Name:           &Config_Headnode_MaxJobBandwidthKb,
+		Config_Headnode_NodenameCase.Name:                &Config_Headnode_NodenameCase,
+		Config_Headnode_NodenameNormalization.Name:       &Config_Headnode_NodenameNormalization,
+		Config_Headnode_DispatchOrder.Name:               &Config_Headnode_DispatchOrder,
+		Config_Headnode_OutputMaxTotalSizeMb.Name:        &Config_Headnode_OutputMaxTotalSizeMb,
+		Config_Headnode_MaxNodeOutputMb.Name:             &Config_Headnode_MaxNodeOutputMb,
+		Config_Headnode_MaxJobOutputMb.Name:              &Config_Headnode_MaxJobOutputMb,
+		Config_Headnode_MaxJobAgeHours.Name:              &Config_Headnode_MaxJobAgeHours,
+		Config_Headnode_PolicyWebhook.Name:               &Config_Headnode_PolicyWebhook,
+		Config_Headnode_PolicyWebhookTimeoutSecond.Name:  &Config_Headnode_PolicyWebhookTimeoutSecond,
+		Config_Headnode_RequireSameVersion.Name:          &Config_Headnode_RequireSameVersion,
+		Config_Headnode_LintLargeJobNodes.Name:           &Config_Headnode_LintLargeJobNodes,
+		Config_Headnode_LintBlockingRules.Name:           &Config_Headnode_LintBlockingRules,
+		Config_Headnode_AuthTokens.Name:                  &Config_Headnode_AuthTokens,
+		Config_Headnode_AuthLdapUrl.Name:                 &Config_Headnode_AuthLdapUrl,
+		Config_Headnode_AuthLdapUserDn.Name:              &Config_Headnode_AuthLdapUserDn,
+		Config_Headnode_AuthOidcIssuer.Name:              &Config_Headnode_AuthOidcIssuer,
+		Config_Headnode_AuthOidcClientId.Name:            &Config_Headnode_AuthOidcClientId,
+		Config_Headnode_AuthGroupRoles.Name:              &Config_Headnode_AuthGroupRoles,
+		Config_Headnode_Clusters.Name:                    &Config_Headnode_Clusters,
+		Config_Headnode_PeerHeadnodes.Name:               &Config_Headnode_PeerHeadnodes,
+		Config_Headnode_ExcludedNodes.Name:               &Config_Headnode_ExcludedNodes,
+		Config_Headnode_RelayHeartbeats.Name:             &Config_Headnode_RelayHeartbeats,
+		Config_Headnode_RelayIntervalSecond.Name:         &Config_Headnode_RelayIntervalSecond,
+		Config_Headnode_RestartReportTimeoutSecond.Name:  &Config_Headnode_RestartReportTimeoutSecond,
+		Config_Headnode_ForgetLostNodesHours.Name:        &Config_Headnode_ForgetLostNodesHours,
+		Config_Headnode_OutputCompression.Name:           &Config_Headnode_OutputCompression,
+		Config_Headnode_OutputStorage.Name:               &Config_Headnode_OutputStorage,
+		Config_Headnode_OutputObjectStore.Name:           &Config_Headnode_OutputObjectStore,
+		Config_Headnode_EncryptionKey.Name:               &Config_Headnode_EncryptionKey,
+		Config_Headnode_PreviousEncryptionKeys.Name:      &Config_Headnode_PreviousEncryptionKeys,
+		Config_Headnode_NodeDiscovery.Name:               &Config_Headnode_NodeDiscovery,
+		Config_Headnode_NodeDiscoveryIntervalSecond.Name: &Config_Headnode_NodeDiscoveryIntervalSecond,
+		Config_Headnode_NodeDiscoveryToken.Name:          &Config_Headnode_NodeDiscoveryToken,
+		Config_Headnode_CancelDelaySecond.Name:           &Config_Headnode_CancelDelaySecond,
+		Config_Headnode_FailureAnalysisMinNodes.Name:     &Config_Headnode_FailureAnalysisMinNodes,
+		Config_Headnode_NotifyWebhooks.Name:              &Config_Headnode_NotifyWebhooks,
+		Config_Headnode_NotifySmtp.Name:                  &Config_Headnode_NotifySmtp,
+		Config_Headnode_NotifyEvents.Name:                &Config_Headnode_NotifyEvents,
+		Config_Headnode_NotifyPattern.Name:               &Config_Headnode_NotifyPattern,
+		Config_Headnode_NotifyRetries.Name:               &Config_Headnode_NotifyRetries,
+		Config_Headnode_MaxBackoffSecond.Name:            &Config_Headnode_MaxBackoffSecond,
+		Config_Headnode_ConnectRetries.Name:              &Config_Headnode_ConnectRetries,
+		Config_Headnode_GrpcWebPort.Name:                 &Config_Headnode_GrpcWebPort,
+		Config_Headnode_GrpcWebOrigins.Name:              &Config_Headnode_GrpcWebOrigins,
+		Config_Headnode_ControlPort.Name:                 &Config_Headnode_ControlPort,
 	}
 	configs_common = []*ConfigItem{
-		&Config_LogGoId,
+		&Config_LogLevel,
+		&Config_LogFormat,
+		&Config_LogMaxSizeMb,
+		&Config_LogRotateHours,
+		&Config_LogMaxFiles,
+		&Config_LogSampleIntervalSecond,
+		&Config_SystemLogLevel,
+		&Config_TraceOtlpEndpoint,
+		&Config_TraceSamplePercent,
+		&Config_GrpcKeepaliveTimeSecond,
+		&Config_GrpcKeepaliveTimeoutSecond,
+		&Config_GrpcKeepalivePermitWithoutStream,
+		&Config_GrpcMaxRecvMsgSizeMb,
+		&Config_GrpcMaxSendMsgSizeMb,
+		&Config_ClusterSecret,
 	}
 )
 
@@ -136,13 +727,19 @@ func SaveNodeConfigs() {
 	connected, connecting := GetHeadnodes()
 	clusnode_config[Config_Clusnode_Headnodes_Name] = append(connected, connecting...)
 	for _, config := range configs_clusnode {
-		clusnode_config[config.Name] = config.Value
+		if value, ok := config.fileValue(); ok {
+			clusnode_config[config.Name] = value
+		}
 	}
 	for _, config := range configs_headnode {
-		headnode_config[config.Name] = config.Value
+		if value, ok := config.fileValue(); ok {
+			headnode_config[config.Name] = value
+		}
 	}
 	for _, config := range configs_common {
-		node_config[config.Name] = config.Value
+		if value, ok := config.fileValue(); ok {
+			node_config[config.Name] = value
+		}
 	}
 
 	// Save config file
@@ -152,6 +749,15 @@ func SaveNodeConfigs() {
 }
 
 func LoadNodeConfigs() {
+	loadNodeConfigs(true)
+}
+
+// Reload the configs from the config file, e.g. after it is edited, the headnodes to report are not changed
+func ReloadNodeConfigs() {
+	loadNodeConfigs(false)
+}
+
+func loadNodeConfigs(load_headnodes bool) {
 	LogInfo("Loading node configs")
 	config, err := readConfigFile()
 	if err != nil {
@@ -177,7 +783,7 @@ func LoadNodeConfigs() {
 	} else {
 		if headnodes, ok := clusnode_config[Config_Clusnode_Headnodes_Name].([]interface{}); !ok {
 			LogWarning("Incorrect headnodes config format for clusnode role, skip")
-		} else {
+		} else if load_headnodes {
 			LogInfo("Adding loaded headnodes: %v", headnodes)
 			for _, headnode := range headnodes {
 				if h, ok := headnode.(string); !ok {
@@ -189,8 +795,8 @@ func LoadNodeConfigs() {
 		}
 		for _, config := range configs_clusnode {
 			if value, ok := clusnode_config[config.Name]; ok {
-				if err := config.Set(value); err != nil {
-					LogError("Failed to set %q for clusnode role to %v: %v", config.Name, value, err)
+				if err := config.setFileValue(value); err != nil {
+					LogError("Failed to set %q for clusnode role: %v", config.Name, err)
 				}
 			}
 		}
@@ -200,51 +806,105 @@ func LoadNodeConfigs() {
 	} else {
 		for _, config := range configs_headnode {
 			if value, ok := headnode_config[config.Name]; ok {
-				if err := config.Set(value); err != nil {
-					LogError("Failed to set %q for headnode role to %v: %v", config.Name, value, err)
+				if err := config.setFileValue(value); err != nil {
+					LogError("Failed to set %q for headnode role: %v", config.Name, err)
 				}
 			}
 		}
 	}
 	for _, config := range configs_common {
 		if value, ok := node_config[config.Name]; ok {
-			if err := config.Set(value); err != nil {
-				LogError("Failed to set %q to %v: %v", config.Name, value, err)
+			if err := config.setFileValue(value); err != nil {
+				LogError("Failed to set %q: %v", config.Name, err)
 			}
 		}
 	}
 }
 
-func SetNodeConfigs(role string, configs map[string]string) map[string]string {
-	LogInfo("SetConfigs: %v", configs)
-	var configs_role map[string]*ConfigItem
-	if role == Config_Clusnode {
-		configs_role = configs_clusnode
-	} else if role == Config_Headnode {
-		configs_role = configs_headnode
-	} else {
-		panic(fmt.Sprintf("Invalid config role: %v", role))
-	}
+var (
+	// The values of the configs before the last set of each role to roll back a bad change, which are kept in memory
+	previousConfigs     = map[string]map[string]string{}
+	previousConfigsLock sync.Mutex
+)
+
+// Set the configs of the role atomically, none of them is set if any name or value is invalid.
+// The changes are only reported if dry run, otherwise the values before the set are kept to roll back it.
+func SetNodeConfigs(role string, configs map[string]string, dry_run bool) map[string]string {
+	configs_role := getRoleConfigs(role)
+	LogInfo("SetConfigs (dry run: %v): %v", dry_run, maskConfigs(configs, configs_role))
+	previousConfigsLock.Lock()
+	defer previousConfigsLock.Unlock()
 	results := make(map[string]string)
+	values := make(map[*ConfigItem]interface{}, len(configs))
 	for k, v := range configs {
 		if config, ok := configs_role[k]; !ok {
 			results[k] = "Invalid config name"
-		} else if err := config.Set(v); err != nil {
-			results[k] = err.Error()
 		} else {
-			results[k] = v
+			values[config] = v
+		}
+	}
+	var parsed map[*ConfigItem]interface{}
+	var errs map[*ConfigItem]error
+	previous := make(map[string]string, len(values))
+	if len(results) == 0 {
+		if parsed, errs = parseConfigValues(values); len(errs) == 0 && !dry_run {
+			for config := range values {
+				previous[config.Name] = NodeConfigs.GetString(config)
+			}
+			errs = NodeConfigs.Update(values)
+		}
+	}
+	invalid := len(results) > 0 || len(errs) > 0
+	for config, v := range values {
+		if err, ok := errs[config]; ok {
+			results[config.Name] = err.Error()
+		} else if invalid {
+			results[config.Name] = "Not set since other configs are invalid"
+		} else if dry_run {
+			results[config.Name] = describeConfigChange(config, NodeConfigs.Get(config), parsed[config])
+		} else {
+			results[config.Name] = config.formatValue(v)
 		}
 	}
 	LogInfo("SetConfigs results: %v", results)
-	SaveNodeConfigs()
+	if !dry_run {
+		if !invalid {
+			previousConfigs[role] = previous
+		}
+		SaveNodeConfigs()
+	}
 	return results
 }
 
+func describeConfigChange(c *ConfigItem, from, to interface{}) string {
+	if from == to {
+		return "Unchanged"
+	}
+	return fmt.Sprintf("Would change from %v to %v", c.formatValue(from), c.formatValue(to))
+}
+
+// The configs to set for a request, which are the values before the last set if it is rolled back
+func getRequestedConfigs(role string, configs map[string]string, rollback bool) (map[string]string, error) {
+	if !rollback {
+		return configs, nil
+	}
+	previousConfigsLock.Lock()
+	defer previousConfigsLock.Unlock()
+	previous := previousConfigs[role]
+	if len(previous) == 0 {
+		return nil, errors.New("No configs are set since the node started")
+	}
+	configs = make(map[string]string, len(previous))
+	for k, v := range previous {
+		configs[k] = v
+	}
+	return configs, nil
+}
+
 func GetNodeConfigs(role string) map[string]string {
 	configs := map[string]string{}
-	var configs_role map[string]*ConfigItem
+	configs_role := getRoleConfigs(role)
 	if role == Config_Clusnode {
-		configs_role = configs_clusnode
 		connected, connecting := GetHeadnodes()
 		if len(connected) > 0 {
 			configs["(connected) "+Config_Clusnode_Headnodes_Name] = strings.Join(connected, ", ")
@@ -252,18 +912,51 @@ func GetNodeConfigs(role string) map[string]string {
 		if len(connecting) > 0 {
 			configs["(connecting) "+Config_Clusnode_Headnodes_Name] = strings.Join(connecting, ", ")
 		}
-	} else if role == Config_Headnode {
-		configs_role = configs_headnode
-	} else {
-		panic(fmt.Sprintf("Invalid config role: %v", role))
+		if validated := GetValidatedHeadnodes(); len(validated) > 0 {
+			configs["(validated) "+Config_Clusnode_Headnodes_Name] = strings.Join(validated, ", ")
+		}
+		if draining := GetDrainingHeadnodes(); len(draining) > 0 {
+			configs["(draining) "+Config_Clusnode_Headnodes_Name] = strings.Join(draining, ", ")
+		}
 	}
 	for _, config := range configs_role {
-		configs[config.Name] = fmt.Sprintf("%v", config.Value)
+		configs[config.Name] = config.displayValue()
 	}
-	LogInfo("GetConfigs results: %v", configs)
+	LogSampled("GetConfigs", "returned %v configs", len(configs))
+	LogDebug("GetConfigs results: %v", configs)
 	return configs
 }
 
+// The names of sensitive configs of the role, whose values are masked in GetNodeConfigs
+func getSensitiveConfigs(role string) []string {
+	names := []string{}
+	for _, config := range getRoleConfigs(role) {
+		if config.Sensitive {
+			names = append(names, config.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// The common configs of the node, e.g. logging, are set and got with the clusnode role
+func getRoleConfigs(role string) map[string]*ConfigItem {
+	switch role {
+	case Config_Clusnode:
+		configs := make(map[string]*ConfigItem, len(configs_clusnode)+len(configs_common))
+		for k, v := range configs_clusnode {
+			configs[k] = v
+		}
+		for _, c := range configs_common {
+			configs[c.Name] = c
+		}
+		return configs
+	case Config_Headnode:
+		return configs_headnode
+	}
+	panic(fmt.Sprintf("Invalid config role: %v", role))
+}
+
 func readConfigFile() (config map[string]interface{}, err error) {
 	json_string, err := ioutil.ReadFile(NodeConfigFile)
 	if err == nil {
@@ -280,43 +973,92 @@ func saveConfigFile(config map[string]interface{}) error {
 	return ioutil.WriteFile(NodeConfigFile, json_string, 0644)
 }
 
+func maskConfigs(configs map[string]string, configs_role map[string]*ConfigItem) map[string]string {
+	masked := make(map[string]string, len(configs))
+	for k, v := range configs {
+		if config, ok := configs_role[k]; ok && config.Sensitive {
+			masked[k] = MaskConfigValue(v)
+		} else {
+			masked[k] = v
+		}
+	}
+	return masked
+}
+
+// The config whose current value is kept in NodeConfigs
 type ConfigItem struct {
 	Name      string
-	Value     interface{}
+	Value     interface{} // default value, which is not changed when the config is set
 	Validator func(interface{}) error
+	Sensitive bool // sensitive value is encrypted in config file and masked when displayed
 }
 
 func (c *ConfigItem) Set(value interface{}) error {
+	if errs := NodeConfigs.Update(map[*ConfigItem]interface{}{c: value}); len(errs) > 0 {
+		return errs[c]
+	}
+	return nil
+}
+
+// Convert the value to the type of the default value and validate it
+func (c *ConfigItem) parse(value interface{}) (interface{}, error) {
 	v, err := convertType(value, reflect.TypeOf(c.Value).Kind())
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if c.Validator != nil {
 		if err := c.Validator(v); err != nil {
-			return err
+			return nil, err
 		}
 	}
-	c.Value = v
-	LogInfo("Set config %q to %v", c.Name, v)
-	return nil
+	return v, nil
 }
 
-func (c *ConfigItem) GetBool() (value bool) {
-	if v, err := convertType(c.Value, reflect.Bool); err != nil {
-		panic(err)
-	} else {
-		value = v.(bool)
+func (c *ConfigItem) displayValue() string {
+	return c.formatValue(NodeConfigs.Get(c))
+}
+
+func (c *ConfigItem) formatValue(value interface{}) string {
+	if c.Sensitive {
+		return MaskConfigValue(value)
 	}
-	return
+	return fmt.Sprintf("%v", value)
 }
 
-func (c *ConfigItem) GetInt() (value int) {
-	if v, err := convertType(c.Value, reflect.Int); err != nil {
-		panic(err)
-	} else {
-		value = v.(int)
+func (c *ConfigItem) fileValue() (interface{}, bool) {
+	value := NodeConfigs.Get(c)
+	if !c.Sensitive {
+		return value, true
 	}
-	return
+	value, err := EncryptConfigValue(fmt.Sprintf("%v", value))
+	if err != nil {
+		LogError("Failed to encrypt config %q: %v", c.Name, err)
+		return nil, false
+	}
+	return value, true
+}
+
+func (c *ConfigItem) setFileValue(value interface{}) error {
+	if s, ok := value.(string); ok && c.Sensitive {
+		plain, err := DecryptConfigValue(s)
+		if err != nil {
+			return fmt.Errorf("Failed to decrypt: %v", err)
+		}
+		value = plain
+	}
+	return c.Set(value)
+}
+
+func (c *ConfigItem) GetString() string {
+	return NodeConfigs.GetString(c)
+}
+
+func (c *ConfigItem) GetBool() bool {
+	return NodeConfigs.GetBool(c)
+}
+
+func (c *ConfigItem) GetInt() int {
+	return NodeConfigs.GetInt(c)
 }
 
 func convertType(from interface{}, t reflect.Kind) (to interface{}, err error) {
@@ -337,11 +1079,16 @@ func convertType(from interface{}, t reflect.Kind) (to interface{}, err error) {
 	case float64:
 		switch t {
 		case reflect.Int:
-			to = int(v)
-			err = nil
+			if v == math.Trunc(v) {
+				to = int(v)
+				err = nil
+			}
 		}
 	case string:
 		switch t {
+		case reflect.String:
+			to = v
+			err = nil
 		case reflect.Int:
 			if i, e := strconv.Atoi(v); e == nil {
 				to = i
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test_SetNodeConfigsDryRun(t *testing.T) {
+	count, age := Config_Headnode_MaxJobCount.Name, Config_Headnode_MaxJobAgeHours.Name
+	current := Config_Headnode_MaxJobCount.GetInt()
+	results := SetNodeConfigs(Config_Headnode, map[string]string{count: strconv.Itoa(current + 1), age: Config_Headnode_MaxJobAgeHours.GetString()}, true)
+	if expected := "Would change from " + strconv.Itoa(current) + " to " + strconv.Itoa(current+1); results[count] != expected || results[age] != "Unchanged" {
+		t.Errorf("Unexpected dry run results: %v", results)
+	}
+	if Config_Headnode_MaxJobCount.GetInt() != current {
+		t.Errorf("Config is set by dry run")
+	}
+	results = SetNodeConfigs(Config_Headnode, map[string]string{count: "0", age: "1"}, true)
+	if results[count] != "Value should be positive" || results[age] != "Not set since other configs are invalid" {
+		t.Errorf("Expected invalid config reported by dry run, got %v", results)
+	}
+	if _, err := convertType(1.5, reflect.Int); err == nil {
+		t.Errorf("Expected error for fractional number as int")
+	}
+
+	previousConfigsLock.Lock()
+	previousConfigs[Config_Headnode] = map[string]string{count: "10"}
+	previousConfigsLock.Unlock()
+	defer delete(previousConfigs, Config_Headnode)
+	if configs, err := getRequestedConfigs(Config_Headnode, map[string]string{age: "1"}, true); err != nil || !reflect.DeepEqual(configs, map[string]string{count: "10"}) {
+		t.Errorf("Expected the previous configs to roll back, got %v, %v", configs, err)
+	}
+	if _, err := getRequestedConfigs(Config_Clusnode, nil, true); err == nil {
+		t.Errorf("Expected error for no configs to roll back")
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// The store of node configs which are read and set concurrently by RPCs and background routines,
+// the components subscribe to the configs to apply the changes once they are set instead of reading them on each use
+type ConfigStore struct {
+	lock        sync.RWMutex
+	values      map[*ConfigItem]interface{}
+	changed     map[*ConfigItem]chan struct{}
+	subscribers []*configSubscriber
+
+	// The changes are notified in the order they are set
+	notify_lock sync.Mutex
+}
+
+type configSubscriber struct {
+	items    map[*ConfigItem]bool
+	callback func(changes map[*ConfigItem]interface{})
+}
+
+var NodeConfigs = newConfigStore()
+
+func newConfigStore() *ConfigStore {
+	return &ConfigStore{values: map[*ConfigItem]interface{}{}, changed: map[*ConfigItem]chan struct{}{}}
+}
+
+// Get the value of the config, which is the default value if it is not set
+func (s *ConfigStore) Get(c *ConfigItem) interface{} {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.get(c)
+}
+
+func (s *ConfigStore) get(c *ConfigItem) interface{} {
+	if v, ok := s.values[c]; ok {
+		return v
+	}
+	return c.Value
+}
+
+func (s *ConfigStore) GetString(c *ConfigItem) string {
+	return fmt.Sprintf("%v", s.Get(c))
+}
+
+func (s *ConfigStore) GetBool(c *ConfigItem) bool {
+	v, err := convertType(s.Get(c), reflect.Bool)
+	if err != nil {
+		panic(err)
+	}
+	return v.(bool)
+}
+
+func (s *ConfigStore) GetInt(c *ConfigItem) int {
+	v, err := convertType(s.Get(c), reflect.Int)
+	if err != nil {
+		panic(err)
+	}
+	return v.(int)
+}
+
+// Convert and validate the values of the configs without setting them
+func parseConfigValues(values map[*ConfigItem]interface{}) (map[*ConfigItem]interface{}, map[*ConfigItem]error) {
+	parsed := make(map[*ConfigItem]interface{}, len(values))
+	errs := map[*ConfigItem]error{}
+	for c, value := range values {
+		if v, err := c.parse(value); err != nil {
+			errs[c] = err
+		} else {
+			parsed[c] = v
+		}
+	}
+	return parsed, errs
+}
+
+// Set the values of the configs atomically, none of them is set if any value is invalid
+func (s *ConfigStore) Update(values map[*ConfigItem]interface{}) map[*ConfigItem]error {
+	parsed, errs := parseConfigValues(values)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	s.notify_lock.Lock()
+	defer s.notify_lock.Unlock()
+	s.lock.Lock()
+	changes := map[*ConfigItem]interface{}{}
+	for c, v := range parsed {
+		if s.get(c) != v {
+			changes[c] = v
+			if ch, ok := s.changed[c]; ok {
+				close(ch)
+				delete(s.changed, c)
+			}
+		}
+		s.values[c] = v
+	}
+	subscribers := make([]*configSubscriber, len(s.subscribers))
+	copy(subscribers, s.subscribers)
+	s.lock.Unlock()
+
+	for c, v := range parsed {
+		LogInfo("Set config %q to %v", c.Name, c.formatValue(v))
+	}
+	for _, subscriber := range subscribers {
+		subscriber.notify(changes)
+	}
+	return nil
+}
+
+// Subscribe the changes of the configs, the callback is called with the current values once subscribed,
+// and then with the changed configs and their new values after they are set, in which no config should be set
+func (s *ConfigStore) Subscribe(callback func(changes map[*ConfigItem]interface{}), items ...*ConfigItem) (unsubscribe func()) {
+	subscriber := &configSubscriber{items: make(map[*ConfigItem]bool, len(items)), callback: callback}
+	for _, c := range items {
+		subscriber.items[c] = true
+	}
+	s.notify_lock.Lock()
+	defer s.notify_lock.Unlock()
+	s.lock.Lock()
+	s.subscribers = append(s.subscribers, subscriber)
+	current := make(map[*ConfigItem]interface{}, len(items))
+	for _, c := range items {
+		current[c] = s.get(c)
+	}
+	s.lock.Unlock()
+	subscriber.notify(current)
+	return func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		for i, sub := range s.subscribers {
+			if sub == subscriber {
+				s.subscribers = append(s.subscribers[:i], s.subscribers[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (s *configSubscriber) notify(changes map[*ConfigItem]interface{}) {
+	subscribed := map[*ConfigItem]interface{}{}
+	for c, v := range changes {
+		if s.items[c] {
+			subscribed[c] = v
+		}
+	}
+	if len(subscribed) > 0 {
+		s.callback(subscribed)
+	}
+}
+
+// Get a channel which is closed once the config is changed, for the routines waiting on the config
+func (s *ConfigStore) Changed(c *ConfigItem) <-chan struct{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	ch, ok := s.changed[c]
+	if !ok {
+		ch = make(chan struct{})
+		s.changed[c] = ch
+	}
+	return ch
+}
+
+// Sleep for the interval in the config, which is restarted with the new interval if the config is changed
+func sleepConfigInterval(c *ConfigItem, unit time.Duration) {
+	for {
+		changed := NodeConfigs.Changed(c)
+		timer := time.NewTimer(time.Duration(NodeConfigs.GetInt(c)) * unit)
+		select {
+		case <-timer.C:
+			return
+		case <-changed:
+			timer.Stop()
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ConfigStore(t *testing.T) {
+	store := newConfigStore()
+	count := &ConfigItem{Name: "count", Value: 1, Validator: positiveIntValidator}
+	name := &ConfigItem{Name: "name", Value: "a"}
+	var notified []map[*ConfigItem]interface{}
+	unsubscribe := store.Subscribe(func(changes map[*ConfigItem]interface{}) {
+		notified = append(notified, changes)
+	}, count)
+	if expected := []map[*ConfigItem]interface{}{{count: 1}}; !reflect.DeepEqual(notified, expected) {
+		t.Errorf("unexpected notification when subscribed: %v", notified)
+	}
+	changed := store.Changed(count)
+
+	// None is set if any value is invalid
+	if errs := store.Update(map[*ConfigItem]interface{}{count: "0", name: "b"}); len(errs) != 1 || errs[count] == nil {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if store.GetInt(count) != 1 || store.GetString(name) != "a" || len(notified) != 1 {
+		t.Errorf("configs are set partially: %v, %v, %v", store.GetInt(count), store.GetString(name), notified)
+	}
+
+	if errs := store.Update(map[*ConfigItem]interface{}{count: "2", name: "b"}); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if store.GetInt(count) != 2 || store.GetString(name) != "b" {
+		t.Errorf("configs are not set: %v, %v", store.GetInt(count), store.GetString(name))
+	}
+	if len(notified) != 2 || !reflect.DeepEqual(notified[1], map[*ConfigItem]interface{}{count: 2}) {
+		t.Errorf("unexpected notifications: %v", notified)
+	}
+	select {
+	case <-changed:
+	default:
+		t.Errorf("changed channel is not closed")
+	}
+
+	// The subscriber is not notified if the value is not changed or after unsubscribing
+	_ = store.Update(map[*ConfigItem]interface{}{count: 2})
+	unsubscribe()
+	_ = store.Update(map[*ConfigItem]interface{}{count: 3})
+	if len(notified) != 2 {
+		t.Errorf("unexpected notifications: %v", notified)
+	}
+	if count.Value != 1 {
+		t.Errorf("default value is changed to %v", count.Value)
+	}
+}
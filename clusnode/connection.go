@@ -0,0 +1,137 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+const (
+	NodeConnectionIdleTimeout = 5 * time.Minute
+	nodeConnectionCheckPeriod = 10 * time.Second
+
+	// The flow control windows of the output lane are enlarged for the bulk output streams
+	outputLaneWindowSize     = 1 << 20
+	outputLaneConnWindowSize = 8 << 20
+)
+
+// The calls to a clusnode are separated into lanes with their own connections, so that the bulk output streams
+// in the output lane can not delay the small calls like cancellation and validation in the control lane
+type connectionLane int
+
+const (
+	connectionLane_Control connectionLane = iota
+	connectionLane_Output
+)
+
+var (
+	nodeConnections sync.Map // nodeConnectionKey -> *nodeConnection
+)
+
+type nodeConnectionKey struct {
+	host string
+	lane connectionLane
+}
+
+func (lane connectionLane) String() string {
+	if lane == connectionLane_Output {
+		return "output"
+	}
+	return "control"
+}
+
+func (lane connectionLane) dialOptions() []grpc.DialOption {
+	if lane == connectionLane_Output {
+		return []grpc.DialOption{grpc.WithInitialWindowSize(outputLaneWindowSize), grpc.WithInitialConnWindowSize(outputLaneConnWindowSize)}
+	}
+	return nil
+}
+
+// A connection to a clusnode shared by the jobs dispatched to or canceled on it
+type nodeConnection struct {
+	conn     *grpc.ClientConn
+	refs     int
+	lastUsed time.Time
+	evicted  bool
+	lock     sync.Mutex
+}
+
+// Get a cached connection to the host in the lane or dial a new one if there is no healthy one, call release after using it
+func GetNodeConnection(host string, lane connectionLane) (conn *grpc.ClientConn, release func()) {
+	key := nodeConnectionKey{host: host, lane: lane}
+	for {
+		val, _ := nodeConnections.LoadOrStore(key, &nodeConnection{})
+		c := val.(*nodeConnection)
+		c.lock.Lock()
+		if c.evicted { // removed from cache after being loaded
+			c.lock.Unlock()
+			continue
+		}
+		if c.conn != nil && !isConnectionHealthy(c.conn) {
+			LogWarning("Connection to %v in %v lane is in state %v, reconnect it", host, lane, c.conn.GetState())
+			c.conn.Close()
+			c.conn = nil
+		}
+		if c.conn == nil {
+			if wait := getConnectBackoff(host); wait > 0 {
+				c.lock.Unlock()
+				LogWarning("Skip connecting %v in %v lane in backoff for %v", host, lane, wait.Round(time.Millisecond))
+				return nil, nil
+			}
+			options := lane.dialOptions()
+			if isNodeTunneled(host) {
+				options = append(options, grpc.WithContextDialer(getReverseTunnels(getTunnelNode(host)).Dial))
+			}
+			new_conn, cancel := ConnectNode(host, options...)
+			cancel()
+			if new_conn == nil {
+				c.lock.Unlock()
+				LogWarning("Back off connecting %v for %v", host, recordConnectFailure(host).Round(time.Millisecond))
+				return nil, nil
+			}
+			resetConnectFailures(host)
+			c.conn = new_conn
+		}
+		c.refs++
+		c.lastUsed = time.Now()
+		conn = c.conn
+		c.lock.Unlock()
+		return conn, func() {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			c.refs--
+			c.lastUsed = time.Now()
+		}
+	}
+}
+
+func isConnectionHealthy(conn *grpc.ClientConn) bool {
+	state := conn.GetState()
+	return state != connectivity.TransientFailure && state != connectivity.Shutdown
+}
+
+// Close the connections which are unused longer than idle timeout or unhealthy
+func evictNodeConnections() {
+	for {
+		time.Sleep(nodeConnectionCheckPeriod)
+		nodeConnections.Range(func(key, val interface{}) bool {
+			k, c := key.(nodeConnectionKey), val.(*nodeConnection)
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			if c.refs > 0 {
+				return true
+			}
+			if c.conn == nil || time.Since(c.lastUsed) > NodeConnectionIdleTimeout || !isConnectionHealthy(c.conn) {
+				if c.conn != nil {
+					LogInfo("Close idle connection to %v in %v lane", k.host, k.lane)
+					c.conn.Close()
+				}
+				c.evicted = true
+				nodeConnections.Delete(key)
+			}
+			return true
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_controlLane(t *testing.T) {
+	for _, method := range []string{"/clusrun.Headnode/HeartbeatStream", "/clusrun.Headnode/CancelClusJobs", "/clusrun.Headnode/GetNodes"} {
+		if err := checkControlRpc(method); err != nil {
+			t.Errorf("Expected %v served on control port, got %v", method, err)
+		}
+	}
+	for _, method := range []string{"/clusrun.Headnode/StartClusJob", "/clusrun.Headnode/GetOutput", "/clusrun.Clusnode/StartJob"} {
+		if checkControlRpc(method) == nil {
+			t.Errorf("Expected %v not served on control port", method)
+		}
+	}
+	if len(connectionLane_Control.dialOptions()) != 0 || len(connectionLane_Output.dialOptions()) == 0 {
+		t.Errorf("Expected only output lane dialed with enlarged windows")
+	}
+	if (nodeConnectionKey{"node1:50505", connectionLane_Control}) == (nodeConnectionKey{"node1:50505", connectionLane_Output}) {
+		t.Errorf("Expected separate connections of lanes")
+	}
+}
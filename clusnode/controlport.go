@@ -0,0 +1,123 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"net"
+	"strconv"
+	"sync"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Each clusnode keeps a heartbeat stream on its own connection, so few streams are expected per connection
+	controlMaxConcurrentStreams = 16
+	controlMaxRecvMsgSize       = 4 << 20
+)
+
+var (
+	controlServer     *grpc.Server
+	controlServerPort int
+	controlServerLock sync.Mutex
+
+	// The small and latency sensitive RPCs served on the control port, the clusnodes reporting to the control port
+	// of headnode are not made lost by the heavy output traffic on the default port
+	controlRpcs = map[string]bool{
+		"/clusrun.Headnode/Heartbeat":         true,
+		"/clusrun.Headnode/HeartbeatStream":   true,
+		"/clusrun.Headnode/BatchHeartbeat":    true,
+		"/clusrun.Headnode/GetCapabilities":   true,
+		"/clusrun.Headnode/GetLoginOptions":   true,
+		"/clusrun.Headnode/Login":             true,
+		"/clusrun.Headnode/GetNodes":          true,
+		"/clusrun.Headnode/GetJobs":           true,
+		"/clusrun.Headnode/GetClusterSummary": true,
+		"/clusrun.Headnode/CancelClusJobs":    true,
+		"/clusrun.Headnode/DrainNodes":        true,
+	}
+)
+
+func checkControlRpc(method string) error {
+	if !controlRpcs[method] {
+		return status.Errorf(codes.Unimplemented, "%v is not served on the control port, please call it on the default port", method)
+	}
+	return nil
+}
+
+func controlUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := checkControlRpc(info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func controlStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := checkControlRpc(info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// Start or restart the control server with the options of the default server once the port is configured,
+// which is stopped if the port is 0
+func watchControlConfigs(options []grpc.ServerOption) {
+	NodeConfigs.Subscribe(func(changes map[*ConfigItem]interface{}) {
+		if v, ok := changes[&Config_Headnode_ControlPort]; ok {
+			restartControlServer(options, v.(int))
+		}
+	}, &Config_Headnode_ControlPort)
+}
+
+func restartControlServer(options []grpc.ServerOption, port int) {
+	controlServerLock.Lock()
+	defer controlServerLock.Unlock()
+	if controlServer != nil && controlServerPort == port {
+		return
+	}
+	stopControlServerLocked()
+	if port <= 0 {
+		return
+	}
+	lis, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		LogError("Failed to listen on control port %v: %v", port, err)
+		return
+	}
+
+	// The control server has its own listener, connections and limits, the non-control RPCs are rejected before authorization
+	options = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(controlUnaryInterceptor),
+		grpc.ChainStreamInterceptor(controlStreamInterceptor),
+		grpc.MaxConcurrentStreams(controlMaxConcurrentStreams),
+		grpc.MaxRecvMsgSize(controlMaxRecvMsgSize),
+	}, options...)
+	s := grpc.NewServer(options...)
+	pb.RegisterHeadnodeServer(s, &headnode_server{})
+	controlServer, controlServerPort = s, port
+	LogInfo("Start control server on port %v", port)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			LogError("Failed to serve control RPCs on port %v: %v", port, err)
+		}
+	}()
+}
+
+func stopControlServer() {
+	controlServerLock.Lock()
+	defer controlServerLock.Unlock()
+	stopControlServerLocked()
+}
+
+// The heartbeat streams never end by themselves, so the server is stopped without waiting for them,
+// and the clusnodes reconnect to report
+func stopControlServerLocked() {
+	if controlServer == nil {
+		return
+	}
+	controlServer.Stop()
+	controlServer, controlServerPort = nil, 0
+	LogInfo("Control server is stopped")
+}
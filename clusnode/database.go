@@ -4,59 +4,136 @@ import (
 	"bytes"
 	pb "clusrun/protobuf"
 	"compress/gzip"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const (
-	JobId_All = 0
+	JobId_All         = 0
+	nodesSaveInterval = time.Minute
+	jobsFlushInterval = time.Second
 )
 
 var (
 	db_outputDir      string
+	db_outputBlobDir  string
 	db_cmdDir         string
+	db_taskDir        string
+	db_scratchDir     string
+	db_checkpointDir  string
+	db_uploadDir      string
 	db_jobs           string
 	db_jobsLock       sync.Mutex
+	db_jobsChanged    int32
 	db_nodeGroups     string
 	db_nodeGroupsLock sync.Mutex
+	db_templates      string
+	db_templatesLock  sync.Mutex
+	db_schedules      string
+	db_schedulesLock  sync.Mutex
+	db_bookmarks      string
+	db_bookmarksLock  sync.Mutex
+	db_nodes          string
+	db_nodesLock      sync.Mutex
+	db_nodesChanged   int32
+	db_nodeKeys       string
+	db_headnodeKeys   string
+	db_auditLog       string
+	db_availability   string
+	db_keysLock       sync.Mutex
 )
 
+type storedNode struct {
+	Name        string
+	Host        string
+	LastSeen    int64
+	Validated   bool
+	Zone        string            `json:",omitempty"`
+	Labels      map[string]string `json:",omitempty"` // the labels reported by the node
+	AdminLabels map[string]string `json:",omitempty"` // the labels set on headnode
+	Draining    bool              `json:",omitempty"`
+	System      *pb.NodeSystem    `json:",omitempty"`
+	Build       *pb.NodeBuild     `json:",omitempty"`
+	Stats       *pb.NodeStats     `json:",omitempty"`
+}
+
 func InitDatabase() {
 	LogInfo("Initializing database")
-	default_db_dir := ExecutablePath + ".db"
-	headnode := filepath.Join(default_db_dir, FileNameFormatHost(NodeHost))
+	headnode := filepath.Join(DataDir, FileNameFormatHost(NodeHost))
 	db_outputDir = headnode + ".output"
-	db_cmdDir = headnode + ".command" // This directory is for clusnode not headnode, can be moved to other place when necessary
+	db_outputBlobDir = headnode + ".blobs"
+	db_cmdDir = headnode + ".command"     // This directory is for clusnode not headnode, can be moved to other place when necessary
+	db_taskDir = headnode + ".task"       // This directory is for clusnode not headnode
+	db_scratchDir = headnode + ".scratch" // This directory is for clusnode not headnode
+	db_checkpointDir = headnode + ".checkpoint"
+	db_uploadDir = headnode + ".upload"
 	db_jobs = headnode + ".jobs"
 	db_nodeGroups = headnode + ".groups"
+	db_templates = headnode + ".templates"
+	db_schedules = headnode + ".schedules"
+	db_bookmarks = headnode + ".bookmarks"
+	db_nodes = headnode + ".nodes"
+	db_nodeKeys = headnode + ".nodekeys"
+	db_headnodeKeys = headnode + ".headnodekeys" // This file is for clusnode not headnode
+	db_auditLog = headnode + ".audit"            // This file is for both clusnode and headnode
+	db_availability = headnode + ".availability"
 	if err := os.MkdirAll(db_outputDir, 0644); err != nil {
 		LogFatality("Failed to create output dir: %v", err)
 	}
+	if err := os.MkdirAll(db_outputBlobDir, 0644); err != nil {
+		LogFatality("Failed to create output blob dir: %v", err)
+	}
+	loadOutputBlobRefs()
 	if err := os.MkdirAll(db_cmdDir, 0644); err != nil {
 		LogFatality("Failed to create command dir for clusnode: %v", err)
 	}
+	if err := os.MkdirAll(db_taskDir, 0644); err != nil {
+		LogFatality("Failed to create task dir for clusnode: %v", err)
+	}
+	if err := os.MkdirAll(db_scratchDir, 0755); err != nil {
+		LogFatality("Failed to create scratch dir for clusnode: %v", err)
+	}
+	if err := loadTaskIntents(); err != nil {
+		LogError("Failed to load task intents: %v", err)
+	}
+	loadAuditLog()
+	loadAvailabilityLog()
+	if err := os.MkdirAll(db_checkpointDir, 0644); err != nil {
+		LogFatality("Failed to create checkpoint dir for clusnode: %v", err)
+	}
+	if err := os.MkdirAll(db_uploadDir, 0644); err != nil {
+		LogFatality("Failed to create upload dir: %v", err)
+	}
+	var reconciling []*pb.Job
 	if _, err := os.Stat(db_jobs); os.IsNotExist(err) {
 		if err = saveJobs([]*pb.Job{}); err != nil {
 			LogFatality("Failed to create database jobs file: %v", err)
 		}
 	} else {
-		// Cancel active jobs
+		// Reconcile active jobs
 		jobs, err := LoadJobs()
 		if err != nil {
 			LogFatality("Failed to load jobs: %v", err)
 		}
 		jobs_id := make(map[int32]bool, len(jobs))
 		for _, job := range jobs {
-			if isActiveState(job.State) {
-				job.State = pb.JobState_Canceling
-				// TODO: add job to cancel list
+			if job.State == pb.JobState_Waiting {
+				// The jobs waiting for dependencies are not dispatched after headnode restarts
+				job.State, job.EndTime = pb.JobState_Canceled, time.Now().Unix()
+			} else if isActiveState(job.State) {
+				// The jobs are reconciled with the clusnodes once the nodes are loaded
+				reconciling = append(reconciling, &pb.Job{Id: job.Id, State: job.State, Nodes: append([]string(nil), job.Nodes...)})
 			}
 			jobs_id[job.Id] = true
 		}
@@ -85,9 +162,49 @@ func InitDatabase() {
 	} else if err := loadNodeGroups(); err != nil {
 		LogFatality("Failed to load node groups: %v", err)
 	}
+	if _, err := os.Stat(db_nodes); os.IsNotExist(err) {
+		if err = ioutil.WriteFile(db_nodes, []byte("[]"), 0644); err != nil {
+			LogFatality("Failed to create database nodes file: %v", err)
+		}
+	} else if err := loadNodes(); err != nil {
+		LogFatality("Failed to load nodes: %v", err)
+	}
+	if err := loadKeys(db_nodeKeys, &NodeKeys); err != nil && !os.IsNotExist(err) {
+		LogFatality("Failed to load node keys: %v", err)
+	}
+	if err := loadKeys(db_headnodeKeys, &HeadnodeKeys); err != nil && !os.IsNotExist(err) {
+		LogFatality("Failed to load headnode keys for clusnode: %v", err)
+	}
+	go persistNodes()
+	go persistJobs()
+	reconcileJobs(reconciling)
+	go purgeJobsPeriodically()
+	go forgetLostNodesPeriodically()
+	go discoverNodesPeriodically()
+	go watchNodeStatesPeriodically()
+	go runJobSchedulesPeriodically()
 }
 
-func CreateNewJob(command, sweep, pattern, name string, groups, specifiedNodes, nodes, args []string) (int32, error) {
+// The values of a new job resolved by headnode instead of taken from its request, e.g. the nodes the job runs on
+type newJobOptions struct {
+	cluster          string
+	specifiedNodes   []string
+	nodes            []string
+	nodeCommands     map[string]string
+	osCommands       map[string]string
+	requirements     *pb.ResourceRequirements
+	selectors        []labelSelector
+	skippedNodes     map[string]string
+	sweepValues      map[string]string
+	bandwidthLimitKb int32
+	dispatchOrder    string
+	correlationId    string
+	client           string
+}
+
+func CreateNewJob(request *pb.StartClusJobRequest, options newJobOptions) (int32, error) {
+	request_id, client, after := request.GetRequestId(), options.client, request.GetAfter()
+
 	// Add new job in job list
 	db_jobsLock.Lock()
 	defer db_jobsLock.Unlock()
@@ -95,6 +212,9 @@ func CreateNewJob(command, sweep, pattern, name string, groups, specifiedNodes,
 	if err != nil {
 		return -1, err
 	}
+	if job := findRequestJob(jobs, request_id, client, time.Now()); job != nil {
+		return job.Id, errJobRequestExists
+	}
 	var last_id int32 = 0
 	if len(jobs) > 0 {
 		last_id = jobs[len(jobs)-1].Id
@@ -104,23 +224,62 @@ func CreateNewJob(command, sweep, pattern, name string, groups, specifiedNodes,
 		return -1, err
 	}
 	new_id := last_id + 1
+	now := time.Now()
+	state := pb.JobState_Created
+	if after != nil {
+		state = pb.JobState_Waiting
+	}
 	new_job := &pb.Job{
-		Id:             new_id,
-		Command:        command,
-		Sweep:          sweep,
-		Arguments:      args,
-		CreateTime:     time.Now().Unix(),
-		State:          pb.JobState_Created,
-		SpecifiedNodes: specifiedNodes,
-		NodePattern:    pattern,
-		NodeGroups:     groups,
-		Nodes:          nodes,
-		Name:           name,
+		Id:               new_id,
+		Command:          request.GetCommand(),
+		Sweep:            request.GetSweep(),
+		Arguments:        request.GetArguments(),
+		CreateTime:       now.Unix(),
+		TimeZone:         formatTimeZone(now),
+		State:            state,
+		SpecifiedNodes:   options.specifiedNodes,
+		NodePattern:      request.GetPattern(),
+		Cluster:          options.cluster,
+		NodeGroups:       request.GetGroups(),
+		Labels:           request.GetLabels(),
+		Where:            request.GetWhere(),
+		Nodes:            options.nodes,
+		Name:             request.GetName(),
+		MaxReschedules:   request.GetMaxReschedules(),
+		Checkpoint:       request.GetCheckpoint(),
+		ShipCheckpoint:   request.GetShipCheckpoint(),
+		BandwidthLimitKb: options.bandwidthLimitKb,
+		WorkingDir:       request.GetWorkingDir(),
+		RunAs:            request.GetRunAs(),
+		NodeCommands:     options.nodeCommands,
+		Shell:            request.GetShell(),
+		ScriptName:       request.GetScriptName(),
+		OsCommands:       options.osCommands,
+		JsonOutput:       request.GetJsonOutput(),
+		DispatchOrder:    options.dispatchOrder,
+		CaptureEnv:       request.GetCaptureEnv(),
+		Requirements:     options.requirements,
+		SkippedNodes:     options.skippedNodes,
+		Limits:           request.GetLimits(),
+		EnvMode:          request.GetEnvMode(),
+		OutputWindow:     request.GetOutputWindow(),
+		Rolling:          request.GetRolling(),
+		FailFast:         request.GetFailFast(),
+		After:            after,
+		ForwardStdin:     request.GetForwardStdin(),
+		CorrelationId:    options.correlationId,
+		RequestId:        request_id,
+		Client:           client,
+		Exclude:          request.GetExclude(),
+		SweepValues:      options.sweepValues,
+		RerunOf:          request.GetRerunOf(),
+		Executor:         request.GetExecutor(),
 	}
 	jobs = append(jobs, new_job)
 	if err := saveJobs(jobs); err != nil {
 		return -1, err
 	}
+	publishJobEvent(new_job, pb.JobState_Created)
 
 	// Cleanup output dir of old jobs
 	for _, id := range olds {
@@ -137,6 +296,8 @@ func CreateNewJob(command, sweep, pattern, name string, groups, specifiedNodes,
 
 func cleanupOutputDir(job_id int32) {
 	LogInfo("Clean up output dir of job %v", job_id)
+	releaseOutputRefs(getOutputDir(job_id))
+	deleteOutputObjects(getOutputDir(job_id))
 	if err := os.RemoveAll(getOutputDir(job_id)); err != nil {
 		LogWarning("Failed to cleanup output dir of job %v: %v", job_id, err)
 	}
@@ -165,8 +326,53 @@ func cleanupOldJobs(jobs []*pb.Job) ([]*pb.Job, []int32, error) {
 	return jobs, to_clean, nil
 }
 
+// Save jobs to the jobs file immediately, which also flushes the changes saved by saveJobsLater
 func saveJobs(jobs []*pb.Job) error {
-	j, err := json.MarshalIndent(jobs, "", "    ")
+	atomic.StoreInt32(&db_jobsChanged, 0)
+	if err := writeJobsFile(jobs); err != nil {
+		atomic.StoreInt32(&db_jobsChanged, 1)
+		return err
+	}
+	setJobsCache(jobs)
+	return nil
+}
+
+// Save jobs in cache and write them to the jobs file at the next flush, for the frequent changes of active jobs.
+// The changes lost in a crash are only of active jobs, which are cancelled when restarting anyway.
+func saveJobsLater(jobs []*pb.Job) {
+	setJobsCache(jobs)
+	atomic.StoreInt32(&db_jobsChanged, 1)
+}
+
+// Flush the jobs saved later to the jobs file in batch
+func persistJobs() {
+	for {
+		time.Sleep(jobsFlushInterval)
+		if err := flushJobs(); err != nil {
+			LogError("Failed to flush jobs: %v", err)
+		}
+	}
+}
+
+func flushJobs() error {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	if !atomic.CompareAndSwapInt32(&db_jobsChanged, 1, 0) {
+		return nil
+	}
+	db_jobsCacheLock.RLock()
+	jobs := db_jobsCache
+	db_jobsCacheLock.RUnlock()
+	if err := writeJobsFile(jobs); err != nil {
+		atomic.StoreInt32(&db_jobsChanged, 1)
+		return err
+	}
+	return nil
+}
+
+// Write the compressed jobs to a temp file and then replace the jobs file, so that the jobs file is never partially written
+func writeJobsFile(jobs []*pb.Job) error {
+	j, err := json.Marshal(jobs)
 	if err != nil {
 		return err
 	}
@@ -178,14 +384,44 @@ func saveJobs(jobs []*pb.Job) error {
 	if err := gz.Close(); err != nil {
 		return err
 	}
-	if err := ioutil.WriteFile(db_jobs, b.Bytes(), 0644); err != nil {
+	data, err := encryptAtRest(b.Bytes())
+	if err != nil {
 		return err
 	}
-	return nil
+	temp := db_jobs + ".tmp"
+	f, err := os.OpenFile(temp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp, db_jobs)
 }
 
+// Load a copy of all jobs, which can be changed and saved
 func LoadJobs() ([]*pb.Job, error) {
+	if err := ensureJobsCache(); err != nil {
+		return nil, err
+	}
+	db_jobsCacheLock.RLock()
+	defer db_jobsCacheLock.RUnlock()
+	return cloneJobs(db_jobsCache), nil
+}
+
+func readJobsFile() ([]*pb.Job, error) {
 	b, err := ioutil.ReadFile(db_jobs)
+	if err == nil {
+		b, err = decryptAtRest(b)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -205,31 +441,91 @@ func LoadJobs() ([]*pb.Job, error) {
 }
 
 func isActiveState(state pb.JobState) bool {
-	return state == pb.JobState_Dispatching || state == pb.JobState_Running || state == pb.JobState_Canceling
+	return state == pb.JobState_Dispatching || state == pb.JobState_Running || state == pb.JobState_Canceling || state == pb.JobState_Waiting
 }
 
 func UpdateJobState(id int32, from, to pb.JobState) error {
+	_, err := TryUpdateJobState(id, from, to)
+	return err
+}
+
+// Change the job state only if it is in the expected state, and report whether it is changed
+func TryUpdateJobState(id int32, from, to pb.JobState) (bool, error) {
 	db_jobsLock.Lock()
 	defer db_jobsLock.Unlock()
 	jobs, err := LoadJobs()
 	if err != nil {
-		return err
+		return false, err
 	}
+	var changed, ended *pb.Job
 	for _, job := range jobs {
 		if job.Id == id {
 			if from == job.State {
 				job.State = to
+				changed = job
+				if !isActiveState(to) && to != pb.JobState_Created {
+					job.EndTime = time.Now().Unix()
+					ended = job
+				}
 			} else {
 				LogWarning("Skip changing job %v state from %v to %v (Current state: %v)", id, from, to, job.State)
-				return nil
+				return false, nil
 			}
 			break
 		}
 	}
-	if err := saveJobs(jobs); err != nil {
-		return err
+	if isActiveState(to) {
+		saveJobsLater(jobs)
+	} else if err := saveJobs(jobs); err != nil {
+		return false, err
 	}
 	LogInfo("Job %v state changed from %v to %v", id, from, to)
+	if changed != nil {
+		publishJobEvent(changed, from)
+	}
+	if ended != nil {
+		notifyJob(ended)
+	}
+	return true, nil
+}
+
+func GetJobState(id int32) (pb.JobState, error) {
+	job, err := GetJob(id)
+	if err != nil {
+		return pb.JobState_Created, err
+	}
+	return job.State, nil
+}
+
+func GetJob(id int32) (*pb.Job, error) {
+	job, ok, err := getCachedJob(id)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, fmt.Errorf("Job %v doesn't exist", id)
+	}
+	return job, nil
+}
+
+func AddJobReschedule(id int32, reschedule *pb.Reschedule) error {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	jobs, err := LoadJobs()
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if job.Id == id {
+			job.Reschedules = append(job.Reschedules, reschedule)
+			job.Nodes = append(job.Nodes, reschedule.ToNode)
+			// The task rescheduled runs the command with the same sweep values
+			if values, ok := job.SweepValues[reschedule.FromNode]; ok {
+				job.SweepValues[reschedule.ToNode] = values
+			}
+			break
+		}
+	}
+	saveJobsLater(jobs)
 	return nil
 }
 
@@ -241,11 +537,13 @@ func UpdateFinishedJob(id int32) {
 		LogError("Failed to load jobs when finishing job %v: %v", id, err)
 		return
 	}
+	var finished *pb.Job
 	for _, job := range jobs {
 		if job.Id == id {
 			if job.State == pb.JobState_Running {
 				job.EndTime = time.Now().Unix()
 				job.State = pb.JobState_Finished
+				finished = job
 			}
 			break
 		}
@@ -255,9 +553,140 @@ func UpdateFinishedJob(id int32) {
 		return
 	}
 	LogInfo("Job %v finished", id)
+	if finished != nil {
+		publishJobEvent(finished, pb.JobState_Running)
+		notifyJob(finished)
+	}
+}
+
+func UpdateJobResults(id int32, results, result_errors map[string]string) {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	jobs, err := LoadJobs()
+	if err != nil {
+		LogError("Failed to load jobs when saving results of job %v: %v", id, err)
+		return
+	}
+	for _, job := range jobs {
+		if job.Id == id {
+			job.Results = results
+			job.ResultErrors = result_errors
+			break
+		}
+	}
+	saveJobsLater(jobs)
+	LogInfo("Results of job %v saved: %v parsed, %v failed", id, len(results), len(result_errors))
+}
+
+func UpdateJobTimeline(id int32, spans []*pb.TaskSpan) {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	jobs, err := LoadJobs()
+	if err != nil {
+		LogError("Failed to load jobs when saving timeline of job %v: %v", id, err)
+		return
+	}
+	for _, job := range jobs {
+		if job.Id == id {
+			job.Tasks = spans
+			break
+		}
+	}
+	saveJobsLater(jobs)
 }
 
-func UpdateFailedJob(id int32, exitCodes map[string]int32) {
+func UpdateJobSummary(id int32, summary *pb.JobSummary) {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	jobs, err := LoadJobs()
+	if err != nil {
+		LogError("Failed to load jobs when saving summary of job %v: %v", id, err)
+		return
+	}
+	for _, job := range jobs {
+		if job.Id == id {
+			job.Summary = summary
+			break
+		}
+	}
+	saveJobsLater(jobs)
+}
+
+func UpdateJobTimedOutNodes(id int32, nodes []string) {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	jobs, err := LoadJobs()
+	if err != nil {
+		LogError("Failed to load jobs when saving timed out nodes of job %v: %v", id, err)
+		return
+	}
+	for _, job := range jobs {
+		if job.Id == id {
+			job.TimedOutNodes = nodes
+			break
+		}
+	}
+	saveJobsLater(jobs)
+}
+
+func UpdateJobTruncatedNodes(id int32, nodes map[string]int64) {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	jobs, err := LoadJobs()
+	if err != nil {
+		LogError("Failed to load jobs when saving truncated nodes of job %v: %v", id, err)
+		return
+	}
+	for _, job := range jobs {
+		if job.Id == id {
+			job.TruncatedNodes = nodes
+			break
+		}
+	}
+	saveJobsLater(jobs)
+}
+
+func UpdateJobOrphanedNodes(id int32, node string) {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	jobs, err := LoadJobs()
+	if err != nil {
+		LogError("Failed to load jobs when saving orphaned nodes of job %v: %v", id, err)
+		return
+	}
+	for _, job := range jobs {
+		if job.Id == id {
+			for _, n := range job.OrphanedNodes {
+				if n == node {
+					return
+				}
+			}
+			job.OrphanedNodes = append(job.OrphanedNodes, node)
+			sort.Strings(job.OrphanedNodes)
+			break
+		}
+	}
+	saveJobsLater(jobs)
+}
+
+func UpdateJobFailureClusters(id int32, clusters []*pb.FailureCluster) {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	jobs, err := LoadJobs()
+	if err != nil {
+		LogError("Failed to load jobs when saving failure clusters of job %v: %v", id, err)
+		return
+	}
+	for _, job := range jobs {
+		if job.Id == id {
+			job.FailureClusters = clusters
+			break
+		}
+	}
+	saveJobsLater(jobs)
+}
+
+func UpdateFailedJob(id int32, exitCodes map[string]int32, aborted bool) {
 	db_jobsLock.Lock()
 	defer db_jobsLock.Unlock()
 	jobs, err := LoadJobs()
@@ -265,11 +694,16 @@ func UpdateFailedJob(id int32, exitCodes map[string]int32) {
 		LogError("Failed to load jobs when failing job %v: %v", id, err)
 		return
 	}
+	var failed *pb.Job
 	for _, job := range jobs {
 		if job.Id == id {
 			if job.State == pb.JobState_Running {
 				job.EndTime = time.Now().Unix()
 				job.State = pb.JobState_Failed
+				if aborted {
+					job.State = pb.JobState_Aborted
+				}
+				failed = job
 			}
 			job.FailedNodes = exitCodes
 			break
@@ -280,9 +714,14 @@ func UpdateFailedJob(id int32, exitCodes map[string]int32) {
 		return
 	}
 	LogInfo("Job %v failed", id)
+	if failed != nil {
+		publishJobEvent(failed, pb.JobState_Running)
+		notifyJob(failed)
+	}
 }
 
-func CancelJobs(job_ids map[int32]bool) (map[int32]pb.JobState, map[int32][]string, error) {
+// Cancel the jobs, which are only in the cluster if it is not empty
+func CancelJobs(job_ids map[int32]bool, cluster string) (map[int32]pb.JobState, map[int32][]string, error) {
 	db_jobsLock.Lock()
 	defer db_jobsLock.Unlock()
 	jobs, err := LoadJobs()
@@ -296,10 +735,20 @@ func CancelJobs(job_ids map[int32]bool) (map[int32]pb.JobState, map[int32][]stri
 	}
 	result := map[int32]pb.JobState{}
 	to_cancel := map[int32][]string{}
+	changed := map[*pb.Job]pb.JobState{}
 	for _, job := range jobs {
 		id := job.Id
+		if len(cluster) > 0 && job.Cluster != cluster {
+			continue
+		}
 		if _, ok := job_ids[id]; ok || cancel_all {
 			if isActiveState(job.State) {
+				changed[job] = job.State
+			}
+			if job.State == pb.JobState_Waiting {
+				// The job is not dispatched to nodes yet
+				job.State, job.EndTime = pb.JobState_Canceled, time.Now().Unix()
+			} else if isActiveState(job.State) {
 				job.State = pb.JobState_Canceling
 				to_cancel[id] = job.Nodes
 			}
@@ -309,9 +758,41 @@ func CancelJobs(job_ids map[int32]bool) (map[int32]pb.JobState, map[int32][]stri
 	if err := saveJobs(jobs); err != nil {
 		return nil, nil, err
 	}
+	for job, from := range changed {
+		if job.State != from {
+			publishJobEvent(job, from)
+		}
+	}
 	return result, to_cancel, nil
 }
 
+// Select the active jobs to cancel without changing them, and get the states of the others
+func SelectJobsToCancel(job_ids map[int32]bool, cluster string) (map[int32]pb.JobState, []int32, error) {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	jobs, err := LoadJobs()
+	if err != nil {
+		return nil, nil, err
+	}
+	job_ids = NormalizeJobIds(job_ids, jobs)
+	_, cancel_all := job_ids[JobId_All]
+	result := map[int32]pb.JobState{}
+	active := []int32{}
+	for _, job := range jobs {
+		if len(cluster) > 0 && job.Cluster != cluster {
+			continue
+		}
+		if _, ok := job_ids[job.Id]; ok || cancel_all {
+			if isActiveState(job.State) && job.State != pb.JobState_Canceling {
+				active = append(active, job.Id)
+			} else {
+				result[job.Id] = job.State
+			}
+		}
+	}
+	return result, active, nil
+}
+
 func UpdateCancelledJob(id int32, cancel_failed_nodes []string) {
 	db_jobsLock.Lock()
 	defer db_jobsLock.Unlock()
@@ -320,8 +801,11 @@ func UpdateCancelledJob(id int32, cancel_failed_nodes []string) {
 		LogError("Failed to load jobs when cancelling job %v: %v", id, err)
 		return
 	}
+	var cancelled *pb.Job
+	from := pb.JobState_Canceling
 	for _, job := range jobs {
 		if job.Id == id {
+			cancelled, from = job, job.State
 			job.EndTime = time.Now().Unix()
 			if len(cancel_failed_nodes) == 0 {
 				job.State = pb.JobState_Canceled
@@ -338,19 +822,63 @@ func UpdateCancelledJob(id int32, cancel_failed_nodes []string) {
 		return
 	}
 	LogInfo("Job %v cancelled", id)
+	if cancelled != nil {
+		publishJobEvent(cancelled, from)
+		notifyJob(cancelled)
+	}
 }
 
-func CreateCommandFile(job_label, command string) (string, error) {
-	file := filepath.Join(db_cmdDir, job_label)
-	if RunOnWindows {
-		file += ".cmd"
+// Update the job left active by the last run of headnode to the state reconciled with the clusnodes
+func UpdateReconciledJob(id int32, from, to pb.JobState, failed_nodes map[string]int32, lost_nodes []string) {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	jobs, err := LoadJobs()
+	if err != nil {
+		LogError("Failed to load jobs when reconciling job %v: %v", id, err)
+		return
+	}
+	var reconciled *pb.Job
+	for _, job := range jobs {
+		if job.Id == id {
+			if job.State == from {
+				job.EndTime = time.Now().Unix()
+				job.State = to
+				job.FailedNodes = failed_nodes
+				job.LostNodes = lost_nodes
+				sort.Strings(job.LostNodes)
+				reconciled = job
+			}
+			break
+		}
+	}
+	if reconciled == nil {
+		return
+	}
+	if err := saveJobs(jobs); err != nil {
+		LogError("Failed to save jobs when reconciling job %v: %v", id, err)
+		return
+	}
+	if len(lost_nodes) > 0 {
+		LogWarning("Job %v is reconciled to %v, the result is lost on nodes: %v", id, to, lost_nodes)
 	} else {
-		file += ".sh"
+		LogInfo("Job %v is reconciled to %v", id, to)
 	}
+	publishJobEvent(reconciled, from)
+	notifyJob(reconciled)
+}
+
+func CreateCommandFile(job_label, command, ext string, executable bool) (string, error) {
+	file := filepath.Join(db_cmdDir, job_label) + ext
 	LogInfo("Create file %v", file)
 	if err := ioutil.WriteFile(file, []byte(command), 0644); err != nil {
 		return file, err
 	}
+	if executable {
+		// The mode is not changed by WriteFile if the file exists
+		if err := os.Chmod(file, 0755); err != nil {
+			return file, err
+		}
+	}
 	return file, nil
 }
 
@@ -358,8 +886,9 @@ func getOutputDir(id int32) string {
 	return filepath.Join(db_outputDir, strconv.Itoa(int(id)))
 }
 
-func GetOutputFile(id int32, node string) (string, string) {
-	file := filepath.Join(getOutputDir(id), FileNameFormatHost(node))
+// The output files of the node are named with its sweep values if any, so that they can be matched to the parameters
+func GetOutputFile(job *pb.Job, node string) (string, string) {
+	file := filepath.Join(getOutputDir(job.Id), FileNameFormatHost(node)+getSweepFileSuffix(job.SweepValues[node]))
 	return file + ".out", file + ".err"
 }
 
@@ -423,3 +952,149 @@ func loadNodeGroups() error {
 	}
 	return nil
 }
+
+func MarkNodesChanged() {
+	atomic.StoreInt32(&db_nodesChanged, 1)
+	atomic.AddInt64(&nodesGeneration, 1)
+}
+
+// Save nodes soon after they change and refresh the last seen time periodically
+func persistNodes() {
+	last_save := time.Now()
+	for {
+		time.Sleep(time.Second)
+		if atomic.CompareAndSwapInt32(&db_nodesChanged, 1, 0) || time.Since(last_save) > nodesSaveInterval {
+			if err := SaveNodes(); err != nil {
+				LogError("Failed to save nodes: %v", err)
+			}
+			last_save = time.Now()
+		}
+	}
+}
+
+func SaveNodes() error {
+	db_nodesLock.Lock()
+	defer db_nodesLock.Unlock()
+	nodes := []storedNode{}
+	for _, record := range ReportedNodes.Snapshot() {
+		display_name := record.name
+		node := storedNode{
+			Name:      display_name,
+			Host:      parseHost(display_name),
+			LastSeen:  record.reportedTime.Unix(),
+			Validated: record.validated(),
+		}
+		if zone, ok := nodeZones.Load(display_name); ok {
+			node.Zone = zone.(string)
+		}
+		if labels, ok := nodeLabels.Load(display_name); ok {
+			node.Labels = labels.(map[string]string)
+		}
+		if labels, ok := nodeAdminLabels.Load(display_name); ok {
+			node.AdminLabels = labels.(map[string]string)
+		}
+		if system, ok := nodeSystems.Load(display_name); ok {
+			node.System = system.(*pb.NodeSystem)
+		}
+		if build, ok := nodeBuilds.Load(display_name); ok {
+			node.Build = build.(*pb.NodeBuild)
+		}
+		if stats, ok := nodeStats.Load(display_name); ok {
+			node.Stats = stats.(*pb.NodeStats)
+		}
+		node.Draining = isNodeDraining(display_name)
+		nodes = append(nodes, node)
+	}
+	if json_string, err := json.MarshalIndent(nodes, "", "    "); err != nil {
+		return err
+	} else if err := ioutil.WriteFile(db_nodes, json_string, 0644); err != nil {
+		return err
+	}
+	return nil
+}
+
+// The loaded nodes are regarded as lost until the next heartbeat, which triggers validation again
+func loadNodes() error {
+	db_nodesLock.Lock()
+	defer db_nodesLock.Unlock()
+	json_string, err := ioutil.ReadFile(db_nodes)
+	if err != nil {
+		return err
+	}
+	var nodes []storedNode
+	if err = json.Unmarshal(json_string, &nodes); err != nil {
+		return err
+	}
+	lost := time.Now().Add(-getHeartbeatTimeout() - time.Second)
+	for _, node := range nodes {
+		if len(strings.TrimSpace(node.Name)) == 0 {
+			continue
+		}
+		last_seen := time.Unix(node.LastSeen, 0)
+		if last_seen.After(lost) {
+			last_seen = lost
+		}
+		ReportedNodes.Touch(node.Name, last_seen)
+		if len(node.Zone) > 0 {
+			nodeZones.Store(node.Name, node.Zone)
+		}
+		if len(node.Labels) > 0 {
+			nodeLabels.Store(node.Name, node.Labels)
+		}
+		if len(node.AdminLabels) > 0 {
+			nodeAdminLabels.Store(node.Name, node.AdminLabels)
+		}
+		if node.System != nil {
+			nodeSystems.Store(node.Name, node.System)
+		}
+		if node.Build != nil {
+			nodeBuilds.Store(node.Name, node.Build)
+		}
+		if node.Stats != nil {
+			nodeStats.Store(node.Name, node.Stats)
+		}
+		if node.Draining {
+			nodeDraining.Store(node.Name, true)
+		}
+	}
+	LogInfo("Loaded %v nodes", len(nodes))
+	return nil
+}
+
+// The keys are saved in hex and only readable by the service account
+func saveKeys(file string, keys *sync.Map) error {
+	db_keysLock.Lock()
+	defer db_keysLock.Unlock()
+	m := map[string]string{}
+	keys.Range(func(k, v interface{}) bool {
+		m[k.(string)] = hex.EncodeToString(v.([]byte))
+		return true
+	})
+	if json_string, err := json.MarshalIndent(m, "", "    "); err != nil {
+		return err
+	} else if err := ioutil.WriteFile(file, json_string, 0600); err != nil {
+		return err
+	}
+	return nil
+}
+
+func loadKeys(file string, keys *sync.Map) error {
+	db_keysLock.Lock()
+	defer db_keysLock.Unlock()
+	json_string, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var m map[string]string
+	if err = json.Unmarshal(json_string, &m); err != nil {
+		return err
+	}
+	for k, v := range m {
+		key, err := hex.DecodeString(v)
+		if err != nil || len(key) != nodeKeySize {
+			return fmt.Errorf("Invalid key of %v", k)
+		}
+		keys.Store(k, key)
+	}
+	return nil
+}
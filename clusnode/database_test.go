@@ -0,0 +1,56 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func Test_saveJobsLater(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusrun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db_jobs = filepath.Join(dir, "jobs")
+	defer setJobsCache(nil)
+	read := func() []int32 {
+		ids := []int32{}
+		jobs, err := readJobsFile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, job := range jobs {
+			ids = append(ids, job.Id)
+		}
+		return ids
+	}
+
+	if err := saveJobs([]*pb.Job{{Id: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	saveJobsLater([]*pb.Job{{Id: 1}, {Id: 2}})
+	if ids := read(); !reflect.DeepEqual(ids, []int32{1}) {
+		t.Errorf("jobs saved later are written before flush: %v", ids)
+	}
+	if job, err := GetJob(2); err != nil || job.Id != 2 {
+		t.Errorf("jobs saved later are not cached: %v, %v", job, err)
+	}
+	if err := flushJobs(); err != nil {
+		t.Fatal(err)
+	}
+	if ids := read(); !reflect.DeepEqual(ids, []int32{1, 2}) {
+		t.Errorf("jobs saved later are not written after flush: %v", ids)
+	}
+	saveJobsLater([]*pb.Job{{Id: 1}, {Id: 2}, {Id: 3}})
+	if err := saveJobs([]*pb.Job{{Id: 1}, {Id: 2}, {Id: 3}, {Id: 4}}); err != nil {
+		t.Fatal(err)
+	}
+	if ids := read(); !reflect.DeepEqual(ids, []int32{1, 2, 3, 4}) || atomic.LoadInt32(&db_jobsChanged) != 0 {
+		t.Errorf("jobs saved later are not flushed by saving jobs: %v", ids)
+	}
+}
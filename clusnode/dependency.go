@@ -0,0 +1,55 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"fmt"
+	"time"
+)
+
+const (
+	jobDependencyCheckInterval = time.Second
+)
+
+// Wait until the dependency job ends and change the waiting job to dispatching,
+// or cancel the waiting job if the dependency job doesn't finish as required
+func waitJobDependency(id int32, after *pb.JobDependency) error {
+	for {
+		state, err := GetJobState(id)
+		if err != nil {
+			return err
+		}
+		if state != pb.JobState_Waiting {
+			return fmt.Errorf("Job %v is %v when waiting for job %v", id, state, after.JobId)
+		}
+		dependency, _ := GetJob(after.JobId) // The dependency job may be purged during waiting
+		ready, err := checkJobDependency(after, dependency)
+		if err != nil {
+			if _, e := TryUpdateJobState(id, pb.JobState_Waiting, pb.JobState_Canceled); e != nil {
+				LogError("Failed to cancel job %v: %v", id, e)
+			}
+			return err
+		}
+		if ready {
+			// The state is checked again in case the job is canceled just now
+			if changed, err := TryUpdateJobState(id, pb.JobState_Waiting, pb.JobState_Dispatching); err != nil || changed {
+				return err
+			}
+			continue
+		}
+		time.Sleep(jobDependencyCheckInterval)
+	}
+}
+
+// Check if the job can be started after the dependency job, which is nil if it doesn't exist
+func checkJobDependency(after *pb.JobDependency, dependency *pb.Job) (bool, error) {
+	if dependency == nil {
+		return false, fmt.Errorf("Dependency job %v doesn't exist", after.JobId)
+	}
+	if isActiveState(dependency.State) || dependency.State == pb.JobState_Created {
+		return false, nil
+	}
+	if after.SuccessOnly && dependency.State != pb.JobState_Finished {
+		return false, fmt.Errorf("Dependency job %v is %v instead of %v", after.JobId, dependency.State, pb.JobState_Finished)
+	}
+	return true, nil
+}
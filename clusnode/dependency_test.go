@@ -0,0 +1,32 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"testing"
+)
+
+func Test_checkJobDependency(t *testing.T) {
+	cases := []struct {
+		after      *pb.JobDependency
+		dependency *pb.Job
+		ready      bool
+		valid      bool
+	}{
+		{&pb.JobDependency{JobId: 1}, nil, false, false},
+		{&pb.JobDependency{JobId: 1}, &pb.Job{Id: 1, State: pb.JobState_Created}, false, true},
+		{&pb.JobDependency{JobId: 1}, &pb.Job{Id: 1, State: pb.JobState_Waiting}, false, true},
+		{&pb.JobDependency{JobId: 1}, &pb.Job{Id: 1, State: pb.JobState_Running}, false, true},
+		{&pb.JobDependency{JobId: 1}, &pb.Job{Id: 1, State: pb.JobState_Finished}, true, true},
+		{&pb.JobDependency{JobId: 1}, &pb.Job{Id: 1, State: pb.JobState_Failed}, true, true},
+		{&pb.JobDependency{JobId: 1, SuccessOnly: true}, &pb.Job{Id: 1, State: pb.JobState_Canceling}, false, true},
+		{&pb.JobDependency{JobId: 1, SuccessOnly: true}, &pb.Job{Id: 1, State: pb.JobState_Finished}, true, true},
+		{&pb.JobDependency{JobId: 1, SuccessOnly: true}, &pb.Job{Id: 1, State: pb.JobState_Canceled}, false, false},
+		{&pb.JobDependency{JobId: 1, SuccessOnly: true}, &pb.Job{Id: 1, State: pb.JobState_Aborted}, false, false},
+	}
+
+	for _, c := range cases {
+		if ready, err := checkJobDependency(c.after, c.dependency); ready != c.ready || (err == nil) != c.valid {
+			t.Errorf("\nafter=%v, dependency=%v\nexpected=%v, %v\n  actual=%v, %v", c.after, c.dependency, c.ready, c.valid, ready, err)
+		}
+	}
+}
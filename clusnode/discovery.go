@@ -0,0 +1,460 @@
+package main
+
+import (
+	"bytes"
+	pb "clusrun/protobuf"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+const (
+	NodeDiscoveryNone      = "none"
+	NodeDiscoverySeparator = ","
+
+	nodeDiscoveryTimeout = 30 * time.Second
+	consulTokenEnv       = "CONSUL_HTTP_TOKEN"
+	azureImdsTokenUrl    = "http://169.254.169.254/metadata/identity/oauth2/token?api-version=2018-02-01&resource=https%3A%2F%2Fmanagement.azure.com%2F"
+	azureVmssApiVersion  = "2018-10-01"
+	ec2ApiVersion        = "2016-11-15"
+)
+
+var (
+	joinedNodes sync.Map // the hosts discovered and asked to join this headnode successfully
+
+	nodeDiscoveryValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if _, err := parseNodeDiscovery(v); err != nil {
+			return err
+		}
+		return nil
+	}
+)
+
+// A source to learn the host addresses of clusnodes from, the port of a host is the one specified by the source or
+// the default port
+type nodeDiscoverySource struct {
+	u    *url.URL
+	port string
+}
+
+// Parse the sources separated by comma in formats:
+// dns://name[:port] for the A or AAAA records of name,
+// dns+srv://_service._proto.domain for the SRV records,
+// consul://agent:port/service[?tag=tag&scheme=https] for the healthy instances of the service,
+// etcd://endpoint:port/prefix[?scheme=https] for the values of the keys with the prefix,
+// azure-vmss://subscription/resource-group/scale-set[?port=port] for the instances by the managed identity,
+// aws-asg://region/group[?port=port] for the running instances by the credentials in environment variables
+func parseNodeDiscovery(value string) ([]*nodeDiscoverySource, error) {
+	sources := []*nodeDiscoverySource{}
+	for _, item := range strings.Split(value, NodeDiscoverySeparator) {
+		if item = strings.TrimSpace(item); len(item) == 0 {
+			continue
+		}
+		u, err := url.Parse(item)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid discovery source %q: %v", item, err)
+		}
+		source := &nodeDiscoverySource{u: u, port: DefaultPort}
+		if port := u.Query().Get("port"); len(port) > 0 {
+			source.port = port
+		}
+		path := strings.Trim(u.Path, "/")
+		switch u.Scheme {
+		case "dns":
+			if len(u.Port()) > 0 {
+				source.port = u.Port()
+			}
+			err = checkDiscoveryPart(u.Hostname(), "name")
+		case "dns+srv":
+			err = checkDiscoveryPart(u.Host, "name of SRV records")
+		case "consul":
+			if err = checkDiscoveryPart(u.Host, "address of agent"); err == nil {
+				err = checkDiscoveryPart(path, "service")
+			}
+		case "etcd":
+			err = checkDiscoveryPart(u.Host, "endpoint")
+		case "azure-vmss":
+			if err = checkDiscoveryPart(u.Host, "subscription"); err == nil && len(strings.Split(path, "/")) != 2 {
+				err = errors.New("Missing resource group or scale set")
+			}
+		case "aws-asg":
+			if err = checkDiscoveryPart(u.Host, "region"); err == nil {
+				err = checkDiscoveryPart(path, "auto scaling group")
+			}
+		default:
+			err = errors.New("Scheme should be one of: dns, dns+srv, consul, etcd, azure-vmss, aws-asg")
+		}
+		if err == nil {
+			if _, e := strconv.ParseUint(source.port, 10, 16); e != nil {
+				err = fmt.Errorf("Invalid port %q", source.port)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Invalid discovery source %q: %v", item, err)
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+func checkDiscoveryPart(value, name string) error {
+	if len(value) == 0 {
+		return errors.New("Missing " + name)
+	}
+	return nil
+}
+
+func (s *nodeDiscoverySource) String() string {
+	return s.u.Scheme + "://" + s.u.Host + s.u.Path
+}
+
+// Get the host addresses of the clusnodes in the source
+func (s *nodeDiscoverySource) Discover(ctx context.Context) ([]string, error) {
+	var addresses []string
+	var err error
+	switch s.u.Scheme {
+	case "dns":
+		addresses, err = net.DefaultResolver.LookupHost(ctx, s.u.Hostname())
+	case "dns+srv":
+		var records []*net.SRV
+		if _, records, err = net.DefaultResolver.LookupSRV(ctx, "", "", s.u.Host); err == nil {
+			for _, record := range records {
+				addresses = append(addresses, net.JoinHostPort(strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(record.Port))))
+			}
+		}
+	case "consul":
+		addresses, err = s.discoverConsul(ctx)
+	case "etcd":
+		addresses, err = s.discoverEtcd(ctx)
+	case "azure-vmss":
+		addresses, err = s.discoverAzureVmss(ctx)
+	case "aws-asg":
+		addresses, err = s.discoverAwsAsg(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	hosts := []string{}
+	for _, address := range addresses {
+		if net.ParseIP(address) != nil || !strings.Contains(address, ":") {
+			address = net.JoinHostPort(address, s.port)
+		}
+		if _, _, host, err := ParseHostAddress(address); err != nil {
+			LogWarning("Ignore invalid host address %q discovered from %v: %v", address, s, err)
+		} else {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
+func (s *nodeDiscoverySource) agentUrl(path string) string {
+	scheme := s.u.Query().Get("scheme")
+	if len(scheme) == 0 {
+		scheme = "http"
+	}
+	return scheme + "://" + s.u.Host + path
+}
+
+func (s *nodeDiscoverySource) discoverConsul(ctx context.Context) ([]string, error) {
+	query := url.Values{"passing": []string{"true"}}
+	if tag := s.u.Query().Get("tag"); len(tag) > 0 {
+		query.Set("tag", tag)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.agentUrl("/v1/health/service/"+url.PathEscape(strings.Trim(s.u.Path, "/"))+"?"+query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	if token := os.Getenv(consulTokenEnv); len(token) > 0 {
+		req.Header.Set("X-Consul-Token", token)
+	}
+	var entries []struct {
+		Node    struct{ Address string }
+		Service struct {
+			Address string
+			Port    int
+		}
+	}
+	if err := doDiscoveryRequest(req, &entries, json.Unmarshal); err != nil {
+		return nil, err
+	}
+	addresses := []string{}
+	for _, entry := range entries {
+		address, port := entry.Service.Address, s.port
+		if len(address) == 0 {
+			address = entry.Node.Address
+		}
+		if entry.Service.Port > 0 {
+			port = strconv.Itoa(entry.Service.Port)
+		}
+		addresses = append(addresses, net.JoinHostPort(address, port))
+	}
+	return addresses, nil
+}
+
+// The value of each key with the prefix is the host address of a clusnode
+func (s *nodeDiscoverySource) discoverEtcd(ctx context.Context) ([]string, error) {
+	prefix := []byte(strings.TrimPrefix(s.u.Path, "/"))
+	range_end := []byte{0}
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] < 0xff {
+			range_end = append(append([]byte{}, prefix[:i]...), prefix[i]+1)
+			break
+		}
+	}
+	if len(prefix) == 0 {
+		prefix = []byte{0}
+	}
+	body, _ := json.Marshal(map[string][]byte{"key": prefix, "range_end": range_end})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.agentUrl("/v3/kv/range"), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	var reply struct {
+		Kvs []struct{ Value []byte }
+	}
+	if err := doDiscoveryRequest(req, &reply, json.Unmarshal); err != nil {
+		return nil, err
+	}
+	addresses := []string{}
+	for _, kv := range reply.Kvs {
+		if address := strings.TrimSpace(string(kv.Value)); len(address) > 0 {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses, nil
+}
+
+// The primary private IP addresses of the instances in the scale set, listed by the managed identity of this node
+func (s *nodeDiscoverySource) discoverAzureVmss(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, azureImdsTokenUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := doDiscoveryRequest(req, &token, json.Unmarshal); err != nil {
+		return nil, fmt.Errorf("Failed to get token of managed identity: %v", err)
+	}
+	parts := strings.Split(strings.Trim(s.u.Path, "/"), "/")
+	next := fmt.Sprintf("https://management.azure.com/subscriptions/%v/resourceGroups/%v/providers/Microsoft.Compute/virtualMachineScaleSets/%v/networkInterfaces?api-version=%v",
+		url.PathEscape(s.u.Host), url.PathEscape(parts[0]), url.PathEscape(parts[1]), azureVmssApiVersion)
+	addresses := []string{}
+	for len(next) > 0 {
+		if req, err = http.NewRequestWithContext(ctx, http.MethodGet, next, nil); err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		var page struct {
+			Value []struct {
+				Properties struct {
+					IpConfigurations []struct {
+						Properties struct {
+							Primary          bool
+							PrivateIPAddress string
+						}
+					}
+				}
+			}
+			NextLink string
+		}
+		if err := doDiscoveryRequest(req, &page, json.Unmarshal); err != nil {
+			return nil, err
+		}
+		for _, nic := range page.Value {
+			for _, config := range nic.Properties.IpConfigurations {
+				if config.Properties.Primary && len(config.Properties.PrivateIPAddress) > 0 {
+					addresses = append(addresses, config.Properties.PrivateIPAddress)
+				}
+			}
+		}
+		next = page.NextLink
+	}
+	return addresses, nil
+}
+
+// The private IP addresses of the running instances in the auto scaling group, which are tagged with the group name
+func (s *nodeDiscoverySource) discoverAwsAsg(ctx context.Context) ([]string, error) {
+	access_key, secret_key := os.Getenv(s3AccessKeyEnv), os.Getenv(s3SecretKeyEnv)
+	if len(access_key) == 0 || len(secret_key) == 0 {
+		return nil, fmt.Errorf("Missing credentials of AWS in environment variables %v and %v", s3AccessKeyEnv, s3SecretKeyEnv)
+	}
+	region, empty_hash := s.u.Host, sha256.Sum256(nil)
+	addresses, next_token := []string{}, ""
+	for {
+		query := url.Values{
+			"Action":           []string{"DescribeInstances"},
+			"Version":          []string{ec2ApiVersion},
+			"Filter.1.Name":    []string{"tag:aws:autoscaling:groupName"},
+			"Filter.1.Value.1": []string{strings.Trim(s.u.Path, "/")},
+			"Filter.2.Name":    []string{"instance-state-name"},
+			"Filter.2.Value.1": []string{"running"},
+		}
+		if len(next_token) > 0 {
+			query.Set("NextToken", next_token)
+		}
+		u := &url.URL{Scheme: "https", Host: fmt.Sprintf("ec2.%v.amazonaws.com", region), Path: "/", RawQuery: strings.ReplaceAll(query.Encode(), "+", "%20")}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("x-amz-content-sha256", hex.EncodeToString(empty_hash[:]))
+		if token := os.Getenv(s3SessionTokenEnv); len(token) > 0 {
+			req.Header.Set("x-amz-security-token", token)
+		}
+		signAwsRequest(req, region, "ec2", access_key, secret_key, time.Now())
+		var reply struct {
+			Reservations []struct {
+				Instances []struct {
+					PrivateIpAddress string `xml:"privateIpAddress"`
+				} `xml:"instancesSet>item"`
+			} `xml:"reservationSet>item"`
+			NextToken string `xml:"nextToken"`
+		}
+		if err := doDiscoveryRequest(req, &reply, xml.Unmarshal); err != nil {
+			return nil, err
+		}
+		for _, reservation := range reply.Reservations {
+			for _, instance := range reservation.Instances {
+				if len(instance.PrivateIpAddress) > 0 {
+					addresses = append(addresses, instance.PrivateIpAddress)
+				}
+			}
+		}
+		if next_token = reply.NextToken; len(next_token) == 0 {
+			return addresses, nil
+		}
+	}
+}
+
+func doDiscoveryRequest(req *http.Request, v interface{}, unmarshal func([]byte, interface{}) error) error {
+	client := &http.Client{Timeout: nodeDiscoveryTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if err != nil {
+		return err
+	} else if resp.StatusCode != http.StatusOK {
+		if len(content) > 1024 {
+			content = content[:1024]
+		}
+		return fmt.Errorf("%v %v: %v", req.Method, resp.Status, strings.TrimSpace(string(content)))
+	}
+	return unmarshal(content, v)
+}
+
+func discoverNodesPeriodically() {
+	defer LogPanicBeforeExit()
+	for {
+		if value := Config_Headnode_NodeDiscovery.GetString(); len(value) > 0 {
+			discoverNodes(value)
+		}
+		time.Sleep(time.Duration(Config_Headnode_NodeDiscoveryIntervalSecond.GetInt()) * time.Second)
+	}
+}
+
+// Ask the clusnodes discovered but not reporting to this headnode to join it, the joined clusnodes are validated when
+// they report by heartbeats as usual, and the reporting clusnodes failed to be validated are revalidated immediately
+func discoverNodes(value string) {
+	sources, err := parseNodeDiscovery(value)
+	if err != nil {
+		LogError("Failed to parse node discovery: %v", err)
+		return
+	}
+	known := map[string]string{}
+	for _, record := range ReportedNodes.Snapshot() {
+		if _, _, host, err := ParseHostAddress(parseHost(record.name)); err == nil {
+			known[host] = record.name
+		}
+	}
+	discovered := map[string]bool{}
+	for _, source := range sources {
+		ctx, cancel := context.WithTimeout(context.Background(), nodeDiscoveryTimeout)
+		hosts, err := source.Discover(ctx)
+		cancel()
+		if err != nil {
+			LogWarning("Failed to discover nodes from %v: %v", source, err)
+			continue
+		}
+		for _, host := range hosts {
+			discovered[host] = true
+		}
+	}
+	var wg sync.WaitGroup
+	pool := newDispatchPool(defaultRevalidateConcurrency)
+	for host := range discovered {
+		if node, ok := known[host]; ok {
+			if number, ok := ReportedNodes.ValidateNumber(node); ok && number > 0 {
+				wg.Add(1)
+				pool.Acquire()
+				go func(node string) {
+					defer wg.Done()
+					defer pool.Release()
+					if err := revalidateNode(node); err != nil {
+						LogWarning("Failed to revalidate discovered node %v: %v", node, err)
+					}
+				}(node)
+			}
+		} else if _, ok := joinedNodes.Load(host); !ok {
+			wg.Add(1)
+			pool.Acquire()
+			go func(host string) {
+				defer wg.Done()
+				defer pool.Release()
+				if err := joinDiscoveredNode(host); err != nil {
+					LogWarning("Failed to ask discovered node %v to join: %v", host, err)
+				} else {
+					joinedNodes.Store(host, true)
+				}
+			}(host)
+		}
+	}
+	wg.Wait()
+}
+
+// Add this headnode to the clusnode, which requires the admin token if authentication is enabled on it
+func joinDiscoveredNode(host string) error {
+	conn, release := GetNodeConnection(host, connectionLane_Control)
+	if conn == nil {
+		return errors.New("Failed to connect")
+	}
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), nodeDiscoveryTimeout)
+	defer cancel()
+	ctx = withAuthToken(ctx, Config_Headnode_NodeDiscoveryToken.GetString())
+	reply, err := pb.NewClusnodeClient(conn).SetHeadnodes(ctx, &pb.SetHeadnodesRequest{Headnodes: []string{NodeHost}, Mode: pb.SetHeadnodesMode_Add})
+	if err != nil {
+		return errors.New(status.Convert(err).Message())
+	}
+	for _, result := range reply.GetResults() {
+		if result != "Added" && result != "Already connected" && result != "Connecting" {
+			return errors.New(result)
+		}
+	}
+	LogInfo("Discovered node %v is asked to join headnode %v", host, NodeHost)
+	return nil
+}
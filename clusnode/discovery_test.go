@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_nodeDiscovery(t *testing.T) {
+	for _, value := range []string{"", "dns://nodes.example.com", "dns://nodes.example.com:1234, dns+srv://_clusnode._tcp.example.com", "consul://127.0.0.1:8500/clusnode?tag=gpu", "etcd://127.0.0.1:2379/clusrun/nodes/", "azure-vmss://sub/rg/vmss?port=1234", "aws-asg://us-east-1/group"} {
+		if _, err := parseNodeDiscovery(value); err != nil {
+			t.Errorf("Expected node discovery %q parsed, got error: %v", value, err)
+		}
+	}
+	for _, value := range []string{"nodes.example.com", "http://nodes.example.com", "dns://nodes.example.com:abc", "consul://127.0.0.1:8500", "azure-vmss://sub/rg", "aws-asg://us-east-1", "dns://nodes.example.com?port=99999"} {
+		if _, err := parseNodeDiscovery(value); err == nil {
+			t.Errorf("Expected error parsing node discovery %q", value)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/health/service/clusnode":
+			if r.URL.Query().Get("passing") != "true" || r.URL.Query().Get("tag") != "gpu" {
+				t.Errorf("Expected only passing instances with tag queried, got query %v", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"Node":{"Address":"10.0.0.1"},"Service":{"Address":"","Port":50505}},{"Node":{"Address":"10.0.0.2"},"Service":{"Address":"node2","Port":0}}]`))
+		case "/v3/kv/range":
+			var request map[string][]byte
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil || string(request["key"]) != "nodes/" || string(request["range_end"]) != "nodes0" {
+				t.Errorf("Expected range of prefix nodes/, got %q to %q: %v", request["key"], request["range_end"], err)
+			}
+			_, _ = w.Write([]byte(`{"kvs":[{"key":"bm9kZXMvMQ==","value":"bm9kZTM6MTIzNA=="},{"key":"bm9kZXMvMg==","value":"MjAwMTpkYjg6OjE="}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	cases := map[string][]string{
+		"consul://" + host + "/clusnode?tag=gpu&port=1234": {"10.0.0.1:50505", "NODE2:1234"},
+		"etcd://" + host + "/nodes/":                       {"NODE3:1234", "[2001:DB8::1]:" + DefaultPort},
+	}
+	for value, expected := range cases {
+		sources, err := parseNodeDiscovery(value)
+		if err != nil {
+			t.Fatalf("Failed to parse node discovery %q: %v", value, err)
+		}
+		if hosts, err := sources[0].Discover(context.Background()); err != nil {
+			t.Errorf("Expected nodes discovered from %v, got error: %v", value, err)
+		} else if !reflect.DeepEqual(hosts, expected) {
+			t.Errorf("Expected nodes %v discovered from %v, got %v", expected, value, hosts)
+		}
+	}
+	sources, _ := parseNodeDiscovery("consul://" + host + "/unknown")
+	if _, err := sources[0].Discover(context.Background()); err == nil {
+		t.Errorf("Expected error discovering nodes of unknown service")
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"sync"
+	"time"
+)
+
+const (
+	maxOutputBatch      = 100
+	outputCoalesceDelay = 20 * time.Millisecond // the time to wait for more output to coalesce small sends
+)
+
+type jobReplySender interface {
+	Send(*pb.StartClusJobReply) error
+}
+
+// Limit the number of nodes being connected and dispatched at the same time in a job
+type dispatchPool chan struct{}
+
+func newDispatchPool(size int) dispatchPool {
+	return make(dispatchPool, size)
+}
+
+func (p dispatchPool) Acquire() {
+	p <- struct{}{}
+}
+
+func (p dispatchPool) Release() {
+	<-p
+}
+
+type dispatcher interface {
+	Acquire()
+	Release()
+}
+
+// Let the tasks of a job acquire the dispatch pool in the order of their turns
+type dispatchQueue struct {
+	pool dispatchPool
+	next int
+	cond *sync.Cond
+}
+
+// The first acquiring of a task waits for its turn, and the acquiring after rescheduling doesn't
+type dispatchTurn struct {
+	queue  *dispatchQueue
+	turn   int
+	passed bool
+}
+
+func newDispatchQueue(pool dispatchPool) *dispatchQueue {
+	return &dispatchQueue{pool: pool, cond: sync.NewCond(&sync.Mutex{})}
+}
+
+func (q *dispatchQueue) Turn(turn int) *dispatchTurn {
+	return &dispatchTurn{queue: q, turn: turn}
+}
+
+func (t *dispatchTurn) Acquire() {
+	if t.passed {
+		t.queue.pool.Acquire()
+		return
+	}
+	t.pass(t.queue.pool.Acquire)
+}
+
+func (t *dispatchTurn) Release() {
+	t.queue.pool.Release()
+}
+
+// Give up the turn if the task finishes without acquiring the pool
+func (t *dispatchTurn) Finish() {
+	if !t.passed {
+		t.pass(func() {})
+	}
+}
+
+func (t *dispatchTurn) pass(acquire func()) {
+	q := t.queue
+	q.cond.L.Lock()
+	for q.next != t.turn {
+		q.cond.Wait()
+	}
+	q.cond.L.Unlock()
+	acquire()
+	q.cond.L.Lock()
+	q.next++
+	q.cond.L.Unlock()
+	q.cond.Broadcast()
+	t.passed = true
+}
+
+// Let the tasks in a batch start after all tasks in the previous batch finish
+type dispatchBatches struct {
+	pending []sync.WaitGroup
+	done    []chan struct{}
+}
+
+func newDispatchBatches(count int, batches map[string]int) *dispatchBatches {
+	b := &dispatchBatches{pending: make([]sync.WaitGroup, count), done: make([]chan struct{}, count)}
+	for _, batch := range batches {
+		b.pending[batch].Add(1)
+	}
+	for i := range b.done {
+		b.done[i] = make(chan struct{})
+		go func(i int) {
+			b.pending[i].Wait()
+			close(b.done[i])
+		}(i)
+	}
+	return b
+}
+
+func (b *dispatchBatches) Wait(batch int) {
+	if batch > 0 {
+		<-b.done[batch-1]
+	}
+}
+
+func (b *dispatchBatches) Done(batch int) {
+	b.pending[batch].Done()
+}
+
+// Count the failed tasks of a job, in which the rescheduled ones are not counted
+func countFailedTasks(job_on_nodes *sync.Map) int32 {
+	var count int32
+	job_on_nodes.Range(func(k, v interface{}) bool {
+		if j := v.(jobOnNode); j.state == pb.JobState_Failed && !j.rescheduled {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// Fail the task not started on the node with the reason in stderr
+func skipTaskOnNode(id int32, node string, job_on_nodes *sync.Map, out jobReplySender, reason string) {
+	logger := getJobLogger(id).With(logFieldNode, node)
+	logger.Warning("Skip job %v on node %v: %v", id, node, reason)
+	job_on_nodes.Store(node, jobOnNode{state: pb.JobState_Failed, exitCode: -1, stderr: reason})
+	if err := out.Send(&pb.StartClusJobReply{Node: node, Stderr: []byte(reason + LineEnding)}); err != nil {
+		logger.Warning("Failed to redirect skipping of job %v on node %v: %v", id, node, err)
+	}
+	if err := out.Send(&pb.StartClusJobReply{Node: node, ExitCode: -1}); err != nil {
+		logger.Warning("Failed to redirect exit code of job %v on skipped node %v: %v", id, node, err)
+	}
+}
+
+// Serialize and batch the output sent to client, since a grpc stream doesn't support concurrent sending
+type jobOutputSender struct {
+	out     jobReplySender
+	queue   chan *pb.StartClusJobReply
+	done    chan struct{}
+	lastErr error
+	errLock sync.Mutex
+}
+
+func newJobOutputSender(out jobReplySender) *jobOutputSender {
+	s := &jobOutputSender{
+		out:   out,
+		queue: make(chan *pb.StartClusJobReply, maxOutputBatch),
+		done:  make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Return the error of the latest sending to client
+func (s *jobOutputSender) Send(reply *pb.StartClusJobReply) error {
+	s.queue <- reply
+	s.errLock.Lock()
+	defer s.errLock.Unlock()
+	return s.lastErr
+}
+
+func (s *jobOutputSender) Close() {
+	close(s.queue)
+	<-s.done
+}
+
+func (s *jobOutputSender) run() {
+	defer close(s.done)
+	for reply := range s.queue {
+		batch := []*pb.StartClusJobReply{reply}
+		timer := time.NewTimer(outputCoalesceDelay)
+	collect:
+		for len(batch) < maxOutputBatch {
+			select {
+			case r, ok := <-s.queue:
+				if !ok {
+					break collect
+				}
+				batch = append(batch, r)
+			case <-timer.C:
+				break collect
+			}
+		}
+		timer.Stop()
+		for _, r := range mergeOutputs(batch) {
+			err := s.out.Send(r)
+			s.errLock.Lock()
+			s.lastErr = err
+			s.errLock.Unlock()
+		}
+	}
+}
+
+// Merge adjacent stdout or stderr of the same node, the reply without output marks the end of a node thus is kept as is
+func mergeOutputs(batch []*pb.StartClusJobReply) []*pb.StartClusJobReply {
+	merged := make([]*pb.StartClusJobReply, 0, len(batch))
+	for _, reply := range batch {
+		if n := len(merged); n > 0 {
+			last := merged[n-1]
+			if last.Node == reply.Node && len(last.Stderr) == 0 && len(reply.Stderr) == 0 && len(last.Stdout) > 0 && len(reply.Stdout) > 0 {
+				last.Stdout = append(last.Stdout, reply.Stdout...)
+				continue
+			}
+			if last.Node == reply.Node && len(last.Stdout) == 0 && len(reply.Stdout) == 0 && len(last.Stderr) > 0 && len(reply.Stderr) > 0 {
+				last.Stderr = append(last.Stderr, reply.Stderr...)
+				continue
+			}
+		}
+		merged = append(merged, reply)
+	}
+	return merged
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	nodeDraining sync.Map // the clusnodes not receiving new jobs until undrained
+)
+
+func (s *headnode_server) DrainNodes(ctx context.Context, in *pb.DrainNodesRequest) (*pb.DrainNodesReply, error) {
+	defer LogPanicBeforeExit()
+	results := map[string]string{}
+	for _, node := range in.GetNodes() {
+		display_name, ok := findReportedNode(node)
+		if !ok {
+			results[node] = "Not found"
+			continue
+		}
+		if in.GetUndrain() {
+			if _, ok := nodeDraining.Load(display_name); ok {
+				nodeDraining.Delete(display_name)
+				LogInfo("Clusnode %v is undrained", display_name)
+				pushHeartbeatControl(display_name, pb.HeartbeatCommand_UndrainNode)
+				results[node] = "Undrained"
+			} else {
+				results[node] = "Not draining"
+			}
+		} else {
+			if _, loaded := nodeDraining.LoadOrStore(display_name, true); !loaded {
+				LogInfo("Clusnode %v is draining", display_name)
+				pushHeartbeatControl(display_name, pb.HeartbeatCommand_DrainNode)
+			}
+			if running := countRunningJobsOnNode(display_name); running > 0 {
+				results[node] = fmt.Sprintf("Draining (%v running jobs)", running)
+			} else {
+				results[node] = "Drained"
+			}
+		}
+	}
+	MarkNodesChanged()
+	LogInfo("DrainNodes result: %v", results)
+	return &pb.DrainNodesReply{Results: results}, nil
+}
+
+// Find the display name of the reported clusnode by display name or host
+func findReportedNode(node string) (string, bool) {
+	for _, record := range ReportedNodes.Snapshot() {
+		if strings.EqualFold(record.name, node) || strings.EqualFold(parseHost(record.name), node) {
+			return record.name, true
+		}
+	}
+	return "", false
+}
+
+func isNodeDraining(display_name string) bool {
+	_, ok := nodeDraining.Load(display_name)
+	return ok
+}
+
+// Count the jobs not finished on the clusnode yet
+func countRunningJobsOnNode(display_name string) int {
+	count := 0
+	Jobs.Range(func(k, v interface{}) bool {
+		if j, ok := v.(*sync.Map).Load(display_name); ok && j.(jobOnNode).state <= pb.JobState_Running {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// Skip the draining nodes for new jobs
+func filterDrainingNodes(nodes []string, skipped map[string]string) ([]string, map[string]string) {
+	remain := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if isNodeDraining(node) {
+			if skipped == nil {
+				skipped = map[string]string{}
+			}
+			skipped[node] = "draining"
+		} else {
+			remain = append(remain, node)
+		}
+	}
+	return remain, skipped
+}
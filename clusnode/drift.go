@@ -0,0 +1,187 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Compare the clusnode configs on the nodes with the cluster baseline, and push the baseline values to the nodes
+// deviating from it if remediation is requested
+func (s *headnode_server) GetConfigDrift(ctx context.Context, in *pb.GetConfigDriftRequest) (*pb.GetConfigDriftReply, error) {
+	defer LogPanicBeforeExit()
+	nodes, expected, remediate, dry_run := in.GetNodes(), in.GetBaseline(), in.GetRemediate(), in.GetDryRun()
+	if len(nodes) == 0 {
+		nodes = []string{PushNodesAll}
+	}
+	names, err := getDriftConfigNames(in.GetConfigs(), expected)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	node_configs, node_errors := getConfigsOnNodes(nodes)
+	baseline, drifts := computeConfigDrift(node_configs, names, expected)
+	LogContext(ctx).Info("Configs of %v nodes are compared, %v nodes drift from the baseline, %v nodes failed", len(node_configs), len(drifts), len(node_errors))
+	reply := &pb.GetConfigDriftReply{Baseline: baseline, Drifts: drifts, NodeErrors: node_errors, Nodes: int32(len(node_configs))}
+	if remediate && len(drifts) > 0 {
+		reply.Remediations = remediateConfigDrift(drifts, baseline, dry_run)
+	}
+	return reply, nil
+}
+
+// Report the clusnode configs to a headnode it is reporting to, which compares them with other nodes
+func (s *clusnode_server) ReportConfigs(ctx context.Context, in *pb.ReportConfigsRequest) (*pb.GetConfigsReply, error) {
+	defer LogPanicBeforeExit()
+	headnode := in.GetHeadnode()
+	if state, ok := headnodesReporting.Load(headnode); !ok || state.(*heartbeat_state).Stopped {
+		LogWarning("Reject reporting configs to headnode %v which is not reported to", headnode)
+		return nil, status.Errorf(codes.PermissionDenied, "Not reporting to headnode %v", headnode)
+	}
+	return &pb.GetConfigsReply{Configs: GetNodeConfigs(Config_Clusnode), Sensitive: getSensitiveConfigs(Config_Clusnode)}, nil
+}
+
+// The configs to compare are the ones can be pushed by headnode if not specified, and the ones with expected values
+func getDriftConfigNames(names []string, expected map[string]string) ([]string, error) {
+	if len(names) == 0 {
+		for _, c := range propagatableConfigs {
+			names = append(names, c.Name)
+		}
+	}
+	for k := range expected {
+		names = append(names, k)
+	}
+	configs := getRoleConfigs(Config_Clusnode)
+	added := map[string]bool{}
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, ok := configs[name]; !ok {
+			return nil, fmt.Errorf("Invalid clusnode config %q", name)
+		}
+		if !added[name] {
+			result, added[name] = append(result, name), true
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// Get the clusnode configs on the nodes in parallel, and the errors of the nodes whose configs are not got
+func getConfigsOnNodes(nodes []string) (map[string]map[string]string, map[string]string) {
+	resolved, failed := resolvePushNodes(nodes)
+	node_configs, node_errors := map[string]map[string]string{}, map[string]string{}
+	for node, result := range failed {
+		node_errors[node] = result.Error
+	}
+	var lock sync.Mutex
+	forEachNodeParallel(resolved, func(node string) {
+		configs, err := getConfigsOnNode(node)
+		lock.Lock()
+		defer lock.Unlock()
+		if err != nil {
+			node_errors[node] = err.Error()
+		} else {
+			node_configs[node] = configs
+		}
+	})
+	return node_configs, node_errors
+}
+
+func getConfigsOnNode(node string) (map[string]string, error) {
+	conn, release := GetNodeConnection(parseHost(node), connectionLane_Control)
+	if conn == nil {
+		return nil, errors.New("Failed to connect node")
+	}
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), propagateConfigsTimeout)
+	defer cancel()
+	headnode := getReportedHeadnode(node)
+	if len(headnode) == 0 {
+		headnode = NodeHost
+	}
+	reply, err := pb.NewClusnodeClient(conn).ReportConfigs(ctx, &pb.ReportConfigsRequest{Headnode: headnode})
+	if err != nil {
+		LogWarning("Failed to get configs of node %v: %v", node, err)
+		return nil, errors.New(status.Convert(err).Message())
+	}
+	return reply.GetConfigs(), nil
+}
+
+// Compare the configs of the nodes with the baseline, the configs without expected values take the most common values
+// among the nodes as baseline, in which a tie is broken by the smaller value, and the configs not reported by a node
+// are not compared on it
+func computeConfigDrift(node_configs map[string]map[string]string, names []string, expected map[string]string) (map[string]string, []*pb.ConfigDrift) {
+	baseline := map[string]string{}
+	for _, name := range names {
+		if v, ok := expected[name]; ok {
+			baseline[name] = v
+			continue
+		}
+		counts := map[string]int{}
+		for _, configs := range node_configs {
+			if v, ok := configs[name]; ok {
+				counts[v]++
+			}
+		}
+		most := -1
+		for v, count := range counts {
+			if current := baseline[name]; count > most || count == most && v < current {
+				baseline[name], most = v, count
+			}
+		}
+	}
+	drifts := []*pb.ConfigDrift{}
+	for node, configs := range node_configs {
+		values := map[string]string{}
+		for name, expected := range baseline {
+			if v, ok := configs[name]; ok && v != expected {
+				values[name] = v
+			}
+		}
+		if len(values) > 0 {
+			drifts = append(drifts, &pb.ConfigDrift{Node: node, Values: values})
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Node < drifts[j].Node })
+	return baseline, drifts
+}
+
+// Push the baseline values of the drifted configs to each node, the configs not allowed to push are reported in results
+func remediateConfigDrift(drifts []*pb.ConfigDrift, baseline map[string]string, dry_run bool) map[string]*pb.NodeConfigsResult {
+	LogInfo("Remediate the drifted configs on %v nodes, dry run: %v", len(drifts), dry_run)
+	results := map[string]*pb.NodeConfigsResult{}
+	configs := map[string]map[string]string{}
+	nodes := make([]string, 0, len(drifts))
+	for _, d := range drifts {
+		rejected := checkPropagatableConfigs(d.Values)
+		pushed := map[string]string{}
+		for name := range d.Values {
+			if _, ok := rejected[name]; !ok {
+				pushed[name] = baseline[name]
+			}
+		}
+		results[d.Node] = &pb.NodeConfigsResult{Results: rejected}
+		if len(pushed) > 0 {
+			configs[d.Node] = pushed
+			nodes = append(nodes, d.Node)
+		}
+	}
+	var lock sync.Mutex
+	forEachNodeParallel(nodes, func(node string) {
+		result := applyConfigsOnNode(&pb.ApplyConfigsRequest{Configs: configs[node], DryRun: dry_run}, node)
+		lock.Lock()
+		defer lock.Unlock()
+		for k, v := range results[node].Results {
+			if result.Results == nil {
+				result.Results = map[string]string{}
+			}
+			result.Results[k] = v
+		}
+		results[node] = result
+	})
+	return results
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_computeConfigDrift(t *testing.T) {
+	node_configs := map[string]map[string]string{
+		"n1": {"log level": "info", "job timeout": "0"},
+		"n2": {"log level": "info", "job timeout": "3600"},
+		"n3": {"log level": "debug", "job timeout": "0"},
+		"n4": {"log level": "info"},
+	}
+	baseline, drifts := computeConfigDrift(node_configs, []string{"job timeout", "log level"}, nil)
+	if expected := map[string]string{"log level": "info", "job timeout": "0"}; !reflect.DeepEqual(baseline, expected) {
+		t.Errorf("Expected baseline %v, got %v", expected, baseline)
+	}
+	if len(drifts) != 2 || drifts[0].Node != "n2" || !reflect.DeepEqual(drifts[0].Values, map[string]string{"job timeout": "3600"}) ||
+		drifts[1].Node != "n3" || !reflect.DeepEqual(drifts[1].Values, map[string]string{"log level": "debug"}) {
+		t.Errorf("Expected drifts of n2 and n3, got %v", drifts)
+	}
+	baseline, drifts = computeConfigDrift(node_configs, []string{"log level"}, map[string]string{"log level": "debug"})
+	if baseline["log level"] != "debug" || len(drifts) != 3 {
+		t.Errorf("Expected 3 nodes drifting from the expected baseline, got %v, %v", baseline, drifts)
+	}
+	baseline, _ = computeConfigDrift(map[string]map[string]string{"n1": {"log level": "warning"}, "n2": {"log level": "error"}}, []string{"log level"}, nil)
+	if baseline["log level"] != "error" {
+		t.Errorf("Expected the tie broken by the smaller value, got %v", baseline)
+	}
+	if _, err := getDriftConfigNames([]string{"no such config"}, nil); err == nil {
+		t.Errorf("Expected error to compare an invalid config")
+	}
+}
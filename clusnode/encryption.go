@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	EncryptionKey_KmsPrefix = "kms:"
+	EncryptionKey_Separator = ";"
+	EncryptionKeyNone       = "none"
+
+	encryptionKeySize    = 32
+	encryptionKeyIdSize  = 8
+	encryptionKmsTimeout = 30 * time.Second
+)
+
+var (
+	// The header of the data encrypted at rest, followed by the id of the key, the nonce and the sealed data. Neither
+	// gzip data nor json starts with it, so the data stored before the encryption is enabled is still readable.
+	encryptedDataHeader = []byte("\x00clusrun-encrypted\x00")
+
+	encryptionKeyCache = map[string][]byte{} // configured value -> key
+	encryptionKeyLock  sync.Mutex
+
+	encryptionKeyValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		return validateEncryptionKey(v)
+	}
+
+	previousEncryptionKeysValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		for _, key := range splitEncryptionKeys(v) {
+			if err := validateEncryptionKey(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+)
+
+func validateEncryptionKey(value string) error {
+	if strings.HasPrefix(value, EncryptionKey_KmsPrefix) {
+		if len(strings.Fields(value[len(EncryptionKey_KmsPrefix):])) == 0 {
+			return errors.New("KMS command is empty")
+		}
+	} else if len(value) > 0 {
+		if _, err := decodeEncryptionKey(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitEncryptionKeys(value string) []string {
+	keys := []string{}
+	for _, key := range strings.Split(value, EncryptionKey_Separator) {
+		if key = strings.TrimSpace(key); len(key) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func decodeEncryptionKey(s string) ([]byte, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(key) != encryptionKeySize {
+		return nil, fmt.Errorf("Key should be %v bytes in hex", encryptionKeySize)
+	}
+	return key, nil
+}
+
+// Get the key to encrypt the stored output and jobs on headnode, which is nil if not configured
+func getEncryptionKey() ([]byte, error) {
+	value := Config_Headnode_EncryptionKey.GetString()
+	if len(value) == 0 {
+		return nil, nil
+	}
+	return resolveEncryptionKey(value)
+}
+
+// Get the keys to decrypt the data stored, which are the key and the previous keys rotated, so that the data encrypted
+// by a previous key is still readable. The error is returned only if no key can be got.
+func getDecryptionKeys() ([][]byte, error) {
+	values := append([]string{Config_Headnode_EncryptionKey.GetString()}, splitEncryptionKeys(Config_Headnode_PreviousEncryptionKeys.GetString())...)
+	keys := [][]byte{}
+	var err error
+	for _, value := range values {
+		if len(value) == 0 {
+			continue
+		}
+		key, e := resolveEncryptionKey(value)
+		if e != nil {
+			LogError("Failed to get decryption key: %v", e)
+			err = e
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) > 0 {
+		return keys, nil
+	}
+	return nil, err
+}
+
+// The KMS command is run once for each configured value, and its stdout should be the key in hex
+func resolveEncryptionKey(value string) ([]byte, error) {
+	encryptionKeyLock.Lock()
+	defer encryptionKeyLock.Unlock()
+	if key, ok := encryptionKeyCache[value]; ok {
+		return key, nil
+	}
+	var key []byte
+	var err error
+	if strings.HasPrefix(value, EncryptionKey_KmsPrefix) {
+		key, err = runEncryptionKms(value[len(EncryptionKey_KmsPrefix):])
+	} else {
+		key, err = decodeEncryptionKey(value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	encryptionKeyCache[value] = key
+	return key, nil
+}
+
+// The id of the key stored with the data it encrypts, by which the key is found to decrypt the data after rotation
+func getEncryptionKeyId(key []byte) []byte {
+	sum := sha256.Sum256(key)
+	return sum[:encryptionKeyIdSize]
+}
+
+func runEncryptionKms(command string) ([]byte, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, errors.New("KMS command is empty")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), encryptionKmsTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, args[0], args[1:]...).Output()
+	if err != nil {
+		if e, ok := err.(*exec.ExitError); ok && len(e.Stderr) > 0 {
+			err = fmt.Errorf("%v: %s", err, bytes.TrimSpace(e.Stderr))
+		}
+		return nil, fmt.Errorf("Failed to get key by KMS command: %v", err)
+	}
+	key, err := decodeEncryptionKey(string(output))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid key got by KMS command: %v", err)
+	}
+	return key, nil
+}
+
+func isEncryptedData(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedDataHeader)
+}
+
+func sealData(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	// The header and the key id are authenticated with the data
+	prefix_size := len(encryptedDataHeader) + encryptionKeyIdSize
+	sealed := make([]byte, prefix_size+gcm.NonceSize(), prefix_size+gcm.NonceSize()+len(data)+gcm.Overhead())
+	copy(sealed, encryptedDataHeader)
+	copy(sealed[len(encryptedDataHeader):], getEncryptionKeyId(key))
+	nonce := sealed[prefix_size:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(sealed, nonce, data, sealed[:prefix_size]), nil
+}
+
+// Open the data by the key of its id in the keys, the data without the header is returned as is
+func openData(keys [][]byte, data []byte) ([]byte, error) {
+	if !isEncryptedData(data) {
+		return data, nil
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("Data is encrypted but no encryption key is configured")
+	}
+	prefix_size := len(encryptedDataHeader) + encryptionKeyIdSize
+	if len(data) < prefix_size {
+		return nil, errors.New("Invalid encrypted data")
+	}
+	var key []byte
+	for _, k := range keys {
+		if bytes.Equal(getEncryptionKeyId(k), data[len(encryptedDataHeader):prefix_size]) {
+			key = k
+			break
+		}
+	}
+	if key == nil {
+		return nil, errors.New("Data is encrypted by a key which is neither the encryption key nor a previous one configured")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	additional, data := data[:prefix_size], data[prefix_size:]
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("Invalid encrypted data")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], additional)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decrypt data: %v", err)
+	}
+	return plain, nil
+}
+
+// Encrypt the data to store if the key is configured
+func encryptAtRest(data []byte) ([]byte, error) {
+	key, err := getEncryptionKey()
+	if err != nil || key == nil {
+		return data, err
+	}
+	return sealData(key, data)
+}
+
+// Decrypt the data stored if it is encrypted, by the key or a previous one
+func decryptAtRest(data []byte) ([]byte, error) {
+	if !isEncryptedData(data) {
+		return data, nil
+	}
+	keys, err := getDecryptionKeys()
+	if err != nil {
+		return nil, err
+	}
+	return openData(keys, data)
+}
+
+// Replace the stored output file or its compressed one with the encrypted one if the key is configured. The output
+// file of a running task is written in plaintext until the task ends and it is stored.
+func encryptOutputFile(file string) error {
+	key, err := getEncryptionKey()
+	if err != nil || key == nil {
+		return err
+	}
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		file += compressedOutputExt
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil || isEncryptedData(data) {
+		return err
+	}
+	sealed, err := sealData(key, data)
+	if err != nil {
+		return err
+	}
+	temp := file + ".tmp"
+	f, err := os.OpenFile(temp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(sealed); err == nil {
+		err = f.Sync()
+	}
+	if e := f.Close(); err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Rename(temp, file)
+	}
+	if err != nil {
+		_ = os.Remove(temp)
+	}
+	return err
+}
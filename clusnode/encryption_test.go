@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_sealData(t *testing.T) {
+	key, other := bytes.Repeat([]byte{1}, encryptionKeySize), bytes.Repeat([]byte{2}, encryptionKeySize)
+	data := []byte("password=secret")
+	sealed, err := sealData(key, data)
+	if err != nil {
+		t.Fatalf("Failed to seal data: %v", err)
+	}
+	if !isEncryptedData(sealed) || bytes.Contains(sealed, data) {
+		t.Errorf("Expected data encrypted, got %q", sealed)
+	}
+	if again, _ := sealData(key, data); bytes.Equal(again, sealed) {
+		t.Errorf("Expected different nonces of sealing")
+	}
+	if plain, err := openData([][]byte{key}, sealed); err != nil || !bytes.Equal(plain, data) {
+		t.Errorf("Expected data %q opened, got %q, %v", data, plain, err)
+	}
+	if _, err := openData([][]byte{other}, sealed); err == nil {
+		t.Errorf("Expected error opening data by another key")
+	}
+	if _, err := openData(nil, sealed); err == nil {
+		t.Errorf("Expected error opening data without key")
+	}
+	if plain, err := openData([][]byte{other, key}, sealed); err != nil || !bytes.Equal(plain, data) {
+		t.Errorf("Expected data %q opened by the key of its id, got %q, %v", data, plain, err)
+	}
+	tampered := append([]byte{}, sealed...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := openData([][]byte{key}, tampered); err == nil {
+		t.Errorf("Expected error opening tampered data")
+	}
+	if _, err := openData([][]byte{key}, sealed[:len(encryptedDataHeader)+encryptionKeyIdSize+1]); err == nil {
+		t.Errorf("Expected error opening truncated data")
+	}
+	// The data stored before encryption is enabled
+	if plain, err := openData(nil, data); err != nil || !bytes.Equal(plain, data) {
+		t.Errorf("Expected plaintext %q returned as is, got %q, %v", data, plain, err)
+	}
+}
+
+func Test_decryptAtRest(t *testing.T) {
+	defer Config_Headnode_EncryptionKey.Set(Config_Headnode_EncryptionKey.Value)
+	defer Config_Headnode_PreviousEncryptionKeys.Set(Config_Headnode_PreviousEncryptionKeys.Value)
+	old_key, new_key := strings.Repeat("0a", encryptionKeySize), strings.Repeat("0b", encryptionKeySize)
+	data := []byte("password=secret")
+	Config_Headnode_EncryptionKey.Set(old_key)
+	sealed, err := encryptAtRest(data)
+	if err != nil || !isEncryptedData(sealed) {
+		t.Fatalf("Expected data encrypted, got %q, %v", sealed, err)
+	}
+
+	// The data encrypted before the key is rotated is readable only if the old key is kept as a previous one
+	Config_Headnode_EncryptionKey.Set(new_key)
+	if _, err := decryptAtRest(sealed); err == nil {
+		t.Errorf("Expected error decrypting data of a rotated key not kept")
+	}
+	Config_Headnode_PreviousEncryptionKeys.Set(strings.Repeat("0c", encryptionKeySize) + EncryptionKey_Separator + old_key)
+	if plain, err := decryptAtRest(sealed); err != nil || !bytes.Equal(plain, data) {
+		t.Errorf("Expected data %q decrypted by the previous key, got %q, %v", data, plain, err)
+	}
+	resealed, err := encryptAtRest(data)
+	if err != nil || bytes.Equal(resealed[len(encryptedDataHeader):len(encryptedDataHeader)+encryptionKeyIdSize], sealed[len(encryptedDataHeader):len(encryptedDataHeader)+encryptionKeyIdSize]) {
+		t.Errorf("Expected data encrypted by the new key, got %v", err)
+	}
+
+	// The encryption is disabled, while the data encrypted before is still readable
+	Config_Headnode_EncryptionKey.Set("")
+	if plain, err := encryptAtRest(data); err != nil || !bytes.Equal(plain, data) {
+		t.Errorf("Expected data not encrypted, got %q, %v", plain, err)
+	}
+	if plain, err := decryptAtRest(sealed); err != nil || !bytes.Equal(plain, data) {
+		t.Errorf("Expected data %q decrypted by the previous key, got %q, %v", data, plain, err)
+	}
+	if err := previousEncryptionKeysValidator("0a0b" + EncryptionKey_Separator + old_key); err == nil {
+		t.Errorf("Expected invalid previous keys")
+	}
+	if err := previousEncryptionKeysValidator(old_key + EncryptionKey_Separator + " kms:cat key "); err != nil {
+		t.Errorf("Expected valid previous keys, got %v", err)
+	}
+}
+
+func Test_encryptionKeyValidator(t *testing.T) {
+	key := strings.Repeat("0a", encryptionKeySize)
+	for _, value := range []string{"", key, " " + key + "\n", "kms:vault read -field=key secret/clusrun"} {
+		if err := encryptionKeyValidator(value); err != nil {
+			t.Errorf("Expected valid key %q, got %v", value, err)
+		}
+	}
+	for _, value := range []interface{}{1, "0a0b", key + "0a", strings.Repeat("zz", encryptionKeySize), "kms:", "kms: "} {
+		if err := encryptionKeyValidator(value); err == nil {
+			t.Errorf("Expected invalid key %v", value)
+		}
+	}
+}
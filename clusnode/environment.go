@@ -0,0 +1,189 @@
+package main
+
+import (
+	"clusrun/clusnode/platform"
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	maskedEnvValue            = "******"
+	interpreterVersionTimeout = 5 * time.Second
+	EnvMode_Inherit           = "inherit"
+	EnvMode_Clean             = "clean"
+	EnvMode_Base              = "base"
+	BaseEnvNone               = "none"
+	baseEnvExample            = `{"PATH":"/usr/bin:/bin","LANG":"C.UTF-8"}`
+)
+
+var (
+	// The environment variables with names containing any of the words are masked
+	sensitiveEnvWords = []string{"TOKEN", "SECRET", "PASSWORD", "PASSWD", "KEY", "CREDENTIAL"}
+
+	envModes = []string{EnvMode_Inherit, EnvMode_Clean, EnvMode_Base}
+
+	envModeValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if !isValidEnvMode(v) {
+			return fmt.Errorf("Value should be one of: %v", strings.Join(envModes, ", "))
+		}
+		return nil
+	}
+
+	baseEnvValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		_, err := parseBaseEnvironment(v)
+		return err
+	}
+)
+
+func isValidEnvMode(mode string) bool {
+	for _, m := range envModes {
+		if mode == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse the base environment in JSON object of variable names and values, empty for none
+func parseBaseEnvironment(value string) (map[string]string, error) {
+	if len(strings.TrimSpace(value)) == 0 {
+		return nil, nil
+	}
+	var env map[string]string
+	if err := json.Unmarshal([]byte(value), &env); err != nil {
+		return nil, fmt.Errorf("Invalid base environment, expect JSON object like %v: %v", baseEnvExample, err)
+	}
+	for name := range env {
+		if len(name) == 0 || strings.ContainsAny(name, "=\x00") {
+			return nil, fmt.Errorf("Invalid environment variable name %q", name)
+		}
+	}
+	return env, nil
+}
+
+// Get the environment of a job in the mode (the configured mode if empty), nil for inheriting the environment of clusnode
+func getJobEnvironment(mode, base string) ([]string, error) {
+	if len(mode) == 0 {
+		mode = Config_Clusnode_EnvMode.GetString()
+	}
+	switch mode {
+	case EnvMode_Inherit:
+		return nil, nil
+	case EnvMode_Clean:
+		return platform.CleanEnvironment(), nil
+	case EnvMode_Base:
+		variables, err := parseBaseEnvironment(base)
+		if err != nil {
+			return nil, err
+		}
+		return mergeEnvironment(platform.CleanEnvironment(), variables), nil
+	}
+	return nil, fmt.Errorf("Invalid environment mode %q, should be one of: %v", mode, strings.Join(envModes, ", "))
+}
+
+// Override the environment variables in format "name=value" by the variables, in the order of names
+func mergeEnvironment(env []string, variables map[string]string) []string {
+	merged := make([]string, 0, len(env)+len(variables))
+	for _, item := range env {
+		name := item
+		if i := strings.Index(item, "="); i > 0 {
+			name = item[:i]
+		}
+		if _, ok := variables[name]; !ok {
+			merged = append(merged, item)
+		}
+	}
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		merged = append(merged, name+"="+variables[name])
+	}
+	return merged
+}
+
+// Capture the effective environment, user, working dir and interpreter version of the command to run
+func captureEnvironment(cmd *exec.Cmd, run_as string) *pb.TaskEnvironment {
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	dir := cmd.Dir
+	if len(dir) == 0 {
+		dir, _ = os.Getwd()
+	}
+	if len(run_as) == 0 {
+		if u, err := user.Current(); err == nil {
+			run_as = u.Username
+		}
+	}
+	return &pb.TaskEnvironment{
+		Variables:          maskEnvironment(env),
+		User:               run_as,
+		WorkingDir:         dir,
+		Interpreter:        cmd.Path,
+		InterpreterVersion: getInterpreterVersion(cmd.Path),
+		Os:                 runtime.GOOS + "/" + runtime.GOARCH,
+	}
+}
+
+// Convert the environment variables in format "name=value" to map, with the values of sensitive variables masked
+func maskEnvironment(env []string) map[string]string {
+	variables := make(map[string]string, len(env))
+	for _, item := range env {
+		i := strings.Index(item, "=")
+		if i <= 0 {
+			// Skip the special variables like "=C:=C:\" on Windows
+			continue
+		}
+		name, value := item[:i], item[i+1:]
+		upper := strings.ToUpper(name)
+		for _, word := range sensitiveEnvWords {
+			if strings.Contains(upper, word) {
+				value = maskedEnvValue
+				break
+			}
+		}
+		variables[name] = value
+	}
+	return variables
+}
+
+func getInterpreterVersion(interpreter string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), interpreterVersionTimeout)
+	defer cancel()
+	var cmd *exec.Cmd
+	if RunOnWindows {
+		cmd = exec.CommandContext(ctx, interpreter, "/c", "ver")
+	} else {
+		cmd = exec.CommandContext(ctx, interpreter, "--version")
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		LogWarning("Failed to get version of %v: %v", interpreter, err)
+		return ""
+	}
+	version := strings.TrimSpace(string(output))
+	if i := strings.IndexAny(version, "\r\n"); i >= 0 {
+		version = version[:i]
+	}
+	return version
+}
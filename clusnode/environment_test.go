@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_maskEnvironment(t *testing.T) {
+	env := []string{"PATH=/usr/bin:/bin", "CLUSRUN_TOKEN=abc", "db_password=p=1", "=C:=C:\\", "EMPTY=", "HOME=/root", "ApiKey=xyz"}
+	expected := map[string]string{
+		"PATH":          "/usr/bin:/bin",
+		"CLUSRUN_TOKEN": maskedEnvValue,
+		"db_password":   maskedEnvValue,
+		"EMPTY":         "",
+		"HOME":          "/root",
+		"ApiKey":        maskedEnvValue,
+	}
+	if actual := maskEnvironment(env); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("\nexpected: %v\n  actual: %v", expected, actual)
+	}
+}
+
+func Test_parseBaseEnvironment(t *testing.T) {
+	cases := []struct {
+		value    string
+		base     []string
+		expected []string
+		valid    bool
+	}{
+		{"", []string{"PATH=/bin"}, []string{"PATH=/bin"}, true},
+		{`{}`, []string{"PATH=/bin", "A"}, []string{"PATH=/bin", "A"}, true},
+		{`{"PATH":"/usr/bin","LANG":"C"}`, []string{"PATH=/bin", "HOME=/root"}, []string{"HOME=/root", "LANG=C", "PATH=/usr/bin"}, true},
+		{`{"EMPTY":""}`, nil, []string{"EMPTY="}, true},
+		{`{"A=B":"C"}`, nil, nil, false},
+		{`{"":"C"}`, nil, nil, false},
+		{`["PATH=/bin"]`, nil, nil, false},
+	}
+
+	for _, c := range cases {
+		var env []string
+		variables, err := parseBaseEnvironment(c.value)
+		if err == nil {
+			env = mergeEnvironment(c.base, variables)
+		}
+		if (err == nil) != c.valid || (err == nil && !reflect.DeepEqual(env, c.expected)) {
+			t.Errorf("\nvalue=%q, base=%v\nexpected=%v, valid=%v\n  actual=%v, error=%v", c.value, c.base, c.expected, c.valid, env, err)
+		}
+	}
+}
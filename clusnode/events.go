@@ -0,0 +1,154 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	nodeStateWatchInterval = time.Second
+	eventSubscriberBuffer  = 1024 // the events not sent to a subscriber yet, which is dropped once they are more
+)
+
+var (
+	eventSubscribers     = map[*eventSubscriber]bool{}
+	eventSubscribersLock sync.Mutex
+)
+
+// The client subscribing the cluster events, whose events channel is closed once it can not keep up with the events
+type eventSubscriber struct {
+	events chan *pb.ClusterEvent
+}
+
+func subscribeEvents() *eventSubscriber {
+	s := &eventSubscriber{events: make(chan *pb.ClusterEvent, eventSubscriberBuffer)}
+	eventSubscribersLock.Lock()
+	eventSubscribers[s] = true
+	eventSubscribersLock.Unlock()
+	return s
+}
+
+func unsubscribeEvents(s *eventSubscriber) {
+	eventSubscribersLock.Lock()
+	defer eventSubscribersLock.Unlock()
+	if eventSubscribers[s] {
+		delete(eventSubscribers, s)
+		close(s.events)
+	}
+}
+
+// Send the event to the subscribers without blocking, the subscriber which is too slow to receive it is dropped
+func publishEvent(event *pb.ClusterEvent) {
+	eventSubscribersLock.Lock()
+	defer eventSubscribersLock.Unlock()
+	for s := range eventSubscribers {
+		select {
+		case s.events <- event:
+		default:
+			LogWarning("Drop the event subscriber which has %v events not received", len(s.events))
+			delete(eventSubscribers, s)
+			close(s.events)
+		}
+	}
+}
+
+func publishJobEvent(job *pb.Job, from pb.JobState) {
+	publishEvent(&pb.ClusterEvent{
+		Time: time.Now().UnixNano(),
+		Job: &pb.JobEvent{
+			Id:          job.Id,
+			Name:        job.Name,
+			From:        from,
+			To:          job.State,
+			Nodes:       int32(len(job.Nodes)),
+			FailedNodes: int32(len(job.FailedNodes)),
+		},
+	})
+}
+
+func (s *headnode_server) SubscribeEvents(in *pb.SubscribeEventsRequest, out pb.Headnode_SubscribeEventsServer) error {
+	defer LogPanicBeforeExit()
+	pattern, err := regexp.Compile(in.GetNodePattern())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid node pattern: %v", err)
+	}
+	subscriber := subscribeEvents()
+	defer unsubscribeEvents(subscriber)
+	LogInfo("Start sending cluster events")
+	for {
+		select {
+		case <-out.Context().Done():
+			LogInfo("Stop sending cluster events: %v", out.Context().Err())
+			return nil
+		case event, ok := <-subscriber.events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "Too many events are not received in time")
+			}
+			if !matchEventFilters(event, in.GetExcludeNodes(), in.GetExcludeJobs(), pattern) {
+				continue
+			}
+			if err := out.Send(event); err != nil {
+				LogWarning("Failed to send cluster event: %v", err)
+				return err
+			}
+		}
+	}
+}
+
+// The node events are filtered by the node pattern, while the job events are not
+func matchEventFilters(event *pb.ClusterEvent, exclude_nodes, exclude_jobs bool, pattern *regexp.Regexp) bool {
+	if node := event.GetNode(); node != nil {
+		return !exclude_nodes && pattern.MatchString(node.Name)
+	}
+	return event.GetJob() != nil && !exclude_jobs
+}
+
+// Compare the node states with the last scan, a node appearing or disappearing changes from or to the unknown state
+func getNodeStateChanges(last, current map[string]pb.NodeState) []*pb.NodeEvent {
+	changes := []*pb.NodeEvent{}
+	for node, state := range current {
+		if from, ok := last[node]; !ok || from != state {
+			changes = append(changes, &pb.NodeEvent{Name: node, From: last[node], To: state})
+		}
+	}
+	for node, state := range last {
+		if _, ok := current[node]; !ok {
+			changes = append(changes, &pb.NodeEvent{Name: node, From: state, To: pb.NodeState_Unknown})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// Scan the node states periodically as they are not stored but decided by the last reports,
+// the changes are published to the event subscribers and the lost or recovered nodes are notified and recorded
+func watchNodeStatesPeriodically() {
+	var last map[string]pb.NodeState
+	for {
+		current := map[string]pb.NodeState{}
+		last_reports := map[string]time.Time{}
+		for _, record := range ReportedNodes.Snapshot() {
+			current[record.name] = getNodeState(record.name, record.reportedTime)
+			last_reports[record.name] = record.reportedTime
+		}
+		recordNodeAvailability(current, last_reports)
+		if last != nil {
+			for _, change := range getNodeStateChanges(last, current) {
+				publishEvent(&pb.ClusterEvent{Time: time.Now().UnixNano(), Node: change})
+				if change.To == pb.NodeState_Lost {
+					notifyNode(NotifyEvent_NodeLost, change.Name, last_reports[change.Name])
+				} else if change.From == pb.NodeState_Lost && change.To != pb.NodeState_Unknown {
+					notifyNode(NotifyEvent_NodeRecovered, change.Name, last_reports[change.Name])
+				}
+			}
+		}
+		last = current
+		time.Sleep(nodeStateWatchInterval)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"regexp"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func Test_getNodeStateChanges(t *testing.T) {
+	last := map[string]pb.NodeState{"a": pb.NodeState_Ready, "b": pb.NodeState_Ready, "c": pb.NodeState_Lost}
+	current := map[string]pb.NodeState{"a": pb.NodeState_Ready, "b": pb.NodeState_Lost, "d": pb.NodeState_Error}
+	expected := []*pb.NodeEvent{
+		{Name: "b", From: pb.NodeState_Ready, To: pb.NodeState_Lost},
+		{Name: "c", From: pb.NodeState_Lost, To: pb.NodeState_Unknown},
+		{Name: "d", From: pb.NodeState_Unknown, To: pb.NodeState_Error},
+	}
+	actual := getNodeStateChanges(last, current)
+	if len(actual) != len(expected) {
+		t.Fatalf("\nexpected=%v\n  actual=%v", expected, actual)
+	}
+	for i := range expected {
+		if !proto.Equal(actual[i], expected[i]) {
+			t.Errorf("\nexpected=%v\n  actual=%v", expected[i], actual[i])
+		}
+	}
+}
+
+func Test_matchEventFilters(t *testing.T) {
+	node := &pb.ClusterEvent{Node: &pb.NodeEvent{Name: "node-1"}}
+	job := &pb.ClusterEvent{Job: &pb.JobEvent{Id: 1}}
+	all, node_2 := regexp.MustCompile(""), regexp.MustCompile("node-2")
+	cases := []struct {
+		event                       *pb.ClusterEvent
+		exclude_nodes, exclude_jobs bool
+		pattern                     *regexp.Regexp
+		expected                    bool
+	}{
+		{node, false, false, all, true},
+		{node, true, false, all, false},
+		{node, false, true, all, true},
+		{node, false, false, node_2, false},
+		{job, false, false, node_2, true},
+		{job, false, true, all, false},
+		{&pb.ClusterEvent{}, false, false, all, false},
+	}
+	for i, c := range cases {
+		if actual := matchEventFilters(c.event, c.exclude_nodes, c.exclude_jobs, c.pattern); actual != c.expected {
+			t.Errorf("case %v: expected=%v, actual=%v", i, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	ExcludedNodesNone = "none"
+
+	exclusionSeparator        = ";"
+	exclusionPatternSeparator = ":"
+)
+
+var (
+	exclusionNameRegexp = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+
+	excludedNodesValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if _, err := parseNodeExclusions(v); err != nil {
+			return err
+		}
+		return nil
+	}
+)
+
+// A named list of nodes no job runs on, e.g. the database hosts, which are protected from the commands on many nodes
+type nodeExclusion struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// Parse the exclusion lists in format "name:pattern[;name:pattern...]", in which the pattern is a regular expression
+// matching the whole names of the nodes excluded
+func parseNodeExclusions(value string) ([]nodeExclusion, error) {
+	exclusions := []nodeExclusion{}
+	for _, item := range strings.Split(value, exclusionSeparator) {
+		if item = strings.TrimSpace(item); len(item) == 0 {
+			continue
+		}
+		i := strings.Index(item, exclusionPatternSeparator)
+		if i < 0 {
+			return nil, fmt.Errorf("Missing pattern of exclusion list, expect format: name%vpattern", exclusionPatternSeparator)
+		}
+		name := strings.TrimSpace(item[:i])
+		if !exclusionNameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("Invalid exclusion list name %q, which should consist of letters, digits, '.', '_' or '-'", name)
+		}
+		pattern, err := regexp.Compile("(?i)^(?:" + strings.TrimSpace(item[i+1:]) + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("Invalid pattern of exclusion list %v: %v", name, err)
+		}
+		exclusions = append(exclusions, nodeExclusion{name: name, pattern: pattern})
+	}
+	return exclusions, nil
+}
+
+func parseNodeExclusionsOrEmpty() []nodeExclusion {
+	exclusions, err := parseNodeExclusions(Config_Headnode_ExcludedNodes.GetString())
+	if err != nil {
+		LogError("Invalid excluded nodes: %v", err)
+	}
+	return exclusions
+}
+
+// Skip the nodes in the exclusion lists of headnode or matching the exclude pattern of the job, even if they are specified
+// by name
+func filterExcludedNodes(nodes []string, exclude string, skipped map[string]string) ([]string, map[string]string) {
+	exclusions := parseNodeExclusionsOrEmpty()
+	if len(exclusions) == 0 && len(exclude) == 0 {
+		return nodes, skipped
+	}
+	remain := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		reason := getNodeExclusion(exclusions, exclude, node)
+		if len(reason) == 0 {
+			remain = append(remain, node)
+			continue
+		}
+		if skipped == nil {
+			skipped = map[string]string{}
+		}
+		skipped[node] = reason
+	}
+	return remain, skipped
+}
+
+// Get the reason the node is excluded, or empty if it is not
+func getNodeExclusion(exclusions []nodeExclusion, exclude, node string) string {
+	for _, e := range exclusions {
+		if e.pattern.MatchString(node) {
+			return "excluded by list " + e.name
+		}
+	}
+	if len(exclude) > 0 {
+		if matched, _ := regexp.MatchString(exclude, node); matched {
+			return "excluded by pattern " + exclude
+		}
+	}
+	return ""
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_nodeExclusions(t *testing.T) {
+	for _, value := range []string{"db", "db:(", "db x:db-.*", ":db-.*"} {
+		if _, err := parseNodeExclusions(value); err == nil {
+			t.Errorf("Expected invalid exclusion lists %q", value)
+		}
+	}
+	exclusions, err := parseNodeExclusions(" db:db-[0-9]+ ; gateway:gw.* ;")
+	if err != nil || len(exclusions) != 2 {
+		t.Fatalf("Expected 2 exclusion lists, got %v, %v", len(exclusions), err)
+	}
+	cases := map[string]string{
+		"DB-01":     "excluded by list db",
+		"db-01-bak": "",
+		"gw1":       "excluded by list gateway",
+		"web-03":    "excluded by pattern ^web-0[1-3]$",
+		"web-04":    "",
+	}
+	for node, expected := range cases {
+		if reason := getNodeExclusion(exclusions, "^web-0[1-3]$", node); reason != expected {
+			t.Errorf("Expected node %v %q, got %q", node, expected, reason)
+		}
+	}
+}
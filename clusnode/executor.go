@@ -0,0 +1,327 @@
+package main
+
+import (
+	"clusrun/clusnode/platform"
+	pb "clusrun/protobuf"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	Executor_Exec         = "exec"
+	Executor_Docker       = "docker"
+	Executor_SshLocalhost = "ssh-localhost"
+
+	dockerPullTimeout         = 30 * time.Minute
+	dockerContainerPrefix     = "clusrun-"
+	dockerMountOptionReadOnly = "ro"
+)
+
+var (
+	jobExecutors  = []string{Executor_Exec, Executor_Docker, Executor_SshLocalhost}
+	taskExecutors sync.Map // job label -> the executor running the task
+
+	dockerNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+	executorsValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); !isValidJobExecutor(name) {
+				return fmt.Errorf("Invalid executor %q, should be one of: %v", name, strings.Join(jobExecutors, ", "))
+			}
+		}
+		return nil
+	}
+)
+
+// The task of a job run by an executor on this clusnode
+type executorTask struct {
+	label       string
+	startPoint  string
+	args        []string
+	commandLine string // the raw command line of the process on Windows, empty to build it from the args
+	workingDir  string
+	env         []string // the environment of the process, nil to inherit the clusnode
+	variables   []string // the variables of the job in format name=value, e.g. the scratch dir
+	dirs        []string // the dirs on this node the task accesses, e.g. the dir of command file and the scratch dir
+	runAs       string
+	limits      platform.ResourceLimits
+	gpus        []int32 // the indexes of GPUs assigned to the task
+	stdin       bool
+}
+
+// The backend running the tasks of jobs on this clusnode
+type jobExecutor interface {
+	// Prepare this node before the task starts, e.g. pull the image
+	Prepare(logger *Logger, task *executorTask) error
+	// Create the command of the task, whose process runs the task or waits for it
+	Command(task *executorTask) (*exec.Cmd, error)
+	// The task runs out of the process of the command, thus the resource limits are not applied to the process
+	Isolated() bool
+	// Stop the task in the grace period, which may keep running after the process of the command is killed
+	Kill(logger *Logger, task_label string, grace time.Duration)
+}
+
+func isValidJobExecutor(name string) bool {
+	for _, e := range jobExecutors {
+		if name == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate the executor of a job, on headnode before the job is created and on clusnode before the task starts
+func validateJobExecutor(executor *pb.JobExecutor) error {
+	name, image, mounts := executor.GetName(), executor.GetImage(), executor.GetMounts()
+	if len(name) > 0 && !isValidJobExecutor(name) {
+		return fmt.Errorf("Invalid executor %q, should be one of: %v", name, strings.Join(jobExecutors, ", "))
+	}
+	if name != Executor_Docker {
+		if len(image) > 0 || len(mounts) > 0 {
+			return fmt.Errorf("Image and mounts are only supported by executor %v", Executor_Docker)
+		}
+		return nil
+	}
+	if len(strings.TrimSpace(image)) == 0 || strings.HasPrefix(image, "-") {
+		return fmt.Errorf("Invalid image %q of executor %v", image, Executor_Docker)
+	}
+	for _, m := range mounts {
+		if err := validateDockerMount(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The mount is in format host_path:container_path[:ro], in which the paths are absolute
+func validateDockerMount(mount string) error {
+	parts := strings.Split(mount, ":")
+	if len(parts) == 3 && parts[2] == dockerMountOptionReadOnly {
+		parts = parts[:2]
+	}
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "/") || !strings.HasPrefix(parts[1], "/") {
+		return fmt.Errorf("Invalid mount %q, should be in format host_path:container_path[:%v] with absolute paths", mount, dockerMountOptionReadOnly)
+	}
+	return nil
+}
+
+// Get the executor of the job, which should be allowed on this clusnode
+func getJobExecutor(executor *pb.JobExecutor, goos string) (jobExecutor, error) {
+	if err := validateJobExecutor(executor); err != nil {
+		return nil, err
+	}
+	name := executor.GetName()
+	if len(name) == 0 {
+		name = Executor_Exec
+	}
+	allowed := false
+	for _, n := range strings.Split(Config_Clusnode_Executors.GetString(), ",") {
+		if strings.TrimSpace(n) == name {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("Executor %v is not allowed on node %v", name, NodeName)
+	}
+	if name != Executor_Exec && goos == "windows" {
+		return nil, fmt.Errorf("Executor %v is not supported on Windows", name)
+	}
+	switch name {
+	case Executor_Docker:
+		return &dockerExecutor{image: executor.GetImage(), mounts: executor.GetMounts()}, nil
+	case Executor_SshLocalhost:
+		return sshLocalhostExecutor{}, nil
+	}
+	return execExecutor{}, nil
+}
+
+// Stop the task by its executor once the process of the task is killed
+func killTaskExecutor(logger *Logger, job_label string, grace time.Duration) {
+	if e, ok := taskExecutors.Load(job_label); ok {
+		e.(jobExecutor).Kill(logger, job_label, grace)
+	}
+}
+
+// Run the command directly on this node, as the user and in the resource limits applied to its process
+type execExecutor struct{}
+
+func (execExecutor) Prepare(logger *Logger, task *executorTask) error {
+	return nil
+}
+
+func (execExecutor) Command(task *executorTask) (*exec.Cmd, error) {
+	cmd := exec.Command(task.startPoint, task.args...)
+	platform.SetSysProcAttr(cmd)
+	if len(task.commandLine) > 0 {
+		platform.SetCommandLine(cmd, task.commandLine)
+	}
+	cmd.Dir = task.workingDir
+	cmd.Env = task.env
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, task.variables...)
+	if len(task.runAs) > 0 {
+		if err := platform.SetRunAsUser(cmd, task.runAs); err != nil {
+			return nil, err
+		}
+	}
+	return cmd, nil
+}
+
+func (execExecutor) Isolated() bool {
+	return false
+}
+
+func (execExecutor) Kill(logger *Logger, task_label string, grace time.Duration) {}
+
+// Run the command in a container of the image, in which the dirs of the task are mounted at the same paths as on this
+// node, so that the command file and the variables of the job are valid in the container
+type dockerExecutor struct {
+	image  string
+	mounts []string
+}
+
+// Pull the image if it is not on this node
+func (e *dockerExecutor) Prepare(logger *Logger, task *executorTask) error {
+	if err := exec.Command("docker", "image", "inspect", e.image).Run(); err == nil {
+		return nil
+	}
+	logger.Info("Pulling image %v for job %v", e.image, task.label)
+	ctx, cancel := context.WithTimeout(context.Background(), dockerPullTimeout)
+	defer cancel()
+	if output, err := exec.CommandContext(ctx, "docker", "pull", e.image).CombinedOutput(); err != nil {
+		return fmt.Errorf("Failed to pull image %v: %v %v", e.image, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (e *dockerExecutor) Command(task *executorTask) (*exec.Cmd, error) {
+	if len(task.runAs) > 0 {
+		return nil, fmt.Errorf("Running as a user is not supported by executor %v", Executor_Docker)
+	}
+	cmd := exec.Command("docker", getDockerRunArgs(task, e.image, e.mounts, runtime.NumCPU())...)
+	platform.SetSysProcAttr(cmd)
+	// The variables are passed to the container by names, whose values are taken from the environment of docker client
+	cmd.Env = append(os.Environ(), task.variables...)
+	return cmd, nil
+}
+
+func (e *dockerExecutor) Isolated() bool {
+	return true
+}
+
+func (e *dockerExecutor) Kill(logger *Logger, task_label string, grace time.Duration) {
+	name := getDockerContainerName(task_label)
+	logger.Info("Stop container %v of job %v in %v", name, task_label, grace)
+	if output, err := exec.Command("docker", "stop", "--time", strconv.Itoa(int(grace.Seconds())), name).CombinedOutput(); err != nil {
+		logger.Warning("Failed to stop container %v of job %v: %v %v", name, task_label, err, strings.TrimSpace(string(output)))
+	}
+}
+
+func getDockerContainerName(task_label string) string {
+	return dockerContainerPrefix + dockerNameRegexp.ReplaceAllString(task_label, "_")
+}
+
+// Get the arguments of docker to run the task in a container removed once it exits, in which the resource limits are
+// applied to the container
+func getDockerRunArgs(task *executorTask, image string, mounts []string, cpus int) []string {
+	args := []string{"run", "--rm", "--name", getDockerContainerName(task.label)}
+	if task.stdin {
+		args = append(args, "-i")
+	}
+	if task.limits.CpuPercent > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(float64(cpus*task.limits.CpuPercent)/100, 'f', 2, 64))
+	}
+	if task.limits.MemoryBytes > 0 {
+		args = append(args, "--memory", strconv.FormatInt(task.limits.MemoryBytes, 10))
+	}
+	if len(task.gpus) > 0 {
+		args = append(args, "--gpus", `"device=`+formatGpuIndexes(task.gpus)+`"`)
+	}
+	dirs := append([]string{}, task.dirs...)
+	if len(task.workingDir) > 0 {
+		dirs = append(dirs, task.workingDir)
+	}
+	mounted := map[string]bool{}
+	for _, dir := range dirs {
+		if !mounted[dir] {
+			mounted[dir] = true
+			args = append(args, "-v", dir+":"+dir)
+		}
+	}
+	for _, m := range mounts {
+		args = append(args, "-v", m)
+	}
+	if len(task.workingDir) > 0 {
+		args = append(args, "-w", task.workingDir)
+	}
+	for _, v := range task.variables {
+		if i := strings.Index(v, "="); i > 0 {
+			args = append(args, "-e", v[:i])
+		}
+	}
+	args = append(args, image, task.startPoint)
+	return append(args, task.args...)
+}
+
+// Run the command in a login session of the user by ssh to this node, e.g. to get the environment and resource limits
+// of the session configured for the user, which requires the key of clusnode to be authorized by the user
+type sshLocalhostExecutor struct{}
+
+func (sshLocalhostExecutor) Prepare(logger *Logger, task *executorTask) error {
+	return nil
+}
+
+func (sshLocalhostExecutor) Command(task *executorTask) (*exec.Cmd, error) {
+	host := "localhost"
+	if len(task.runAs) > 0 {
+		host = task.runAs + "@" + host
+	}
+	cmd := exec.Command("ssh", "-T", "-o", "BatchMode=yes", host, getPosixCommandLine(task))
+	platform.SetSysProcAttr(cmd)
+	return cmd, nil
+}
+
+func (sshLocalhostExecutor) Isolated() bool {
+	return true
+}
+
+func (sshLocalhostExecutor) Kill(logger *Logger, task_label string, grace time.Duration) {}
+
+// Get the command line run by the POSIX shell of the login session, in which the variables of the job are set
+func getPosixCommandLine(task *executorTask) string {
+	var b strings.Builder
+	if len(task.workingDir) > 0 {
+		b.WriteString("cd " + quotePosixArgument(task.workingDir) + " && ")
+	}
+	for _, v := range task.variables {
+		if i := strings.Index(v, "="); i > 0 {
+			b.WriteString(v[:i+1] + quotePosixArgument(v[i+1:]) + " ")
+		}
+	}
+	b.WriteString("exec " + quotePosixArgument(task.startPoint))
+	for _, a := range task.args {
+		b.WriteString(" " + quotePosixArgument(a))
+	}
+	return b.String()
+}
+
+// Quote the argument in single quotes, in which a single quote is closed, escaped and reopened
+func quotePosixArgument(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"clusrun/clusnode/platform"
+	pb "clusrun/protobuf"
+	"reflect"
+	"testing"
+)
+
+func Test_validateJobExecutor(t *testing.T) {
+	valid := []*pb.JobExecutor{
+		nil,
+		{Name: Executor_Exec},
+		{Name: Executor_SshLocalhost},
+		{Name: Executor_Docker, Image: "ubuntu:22.04"},
+		{Name: Executor_Docker, Image: "ubuntu", Mounts: []string{"/data:/data:ro", "/opt/tools:/tools"}},
+	}
+	for _, executor := range valid {
+		if err := validateJobExecutor(executor); err != nil {
+			t.Errorf("Expected executor %v to be valid, got %v", executor, err)
+		}
+	}
+	invalid := []*pb.JobExecutor{
+		{Name: "podman"},
+		{Name: Executor_Docker},
+		{Name: Executor_Docker, Image: "--privileged"},
+		{Name: Executor_Exec, Image: "ubuntu"},
+		{Name: Executor_SshLocalhost, Mounts: []string{"/data:/data"}},
+		{Name: Executor_Docker, Image: "ubuntu", Mounts: []string{"data:/data"}},
+		{Name: Executor_Docker, Image: "ubuntu", Mounts: []string{"/data:/data:rw:ro"}},
+	}
+	for _, executor := range invalid {
+		if err := validateJobExecutor(executor); err == nil {
+			t.Errorf("Expected executor %v to be invalid", executor)
+		}
+	}
+	if _, err := getJobExecutor(&pb.JobExecutor{Name: Executor_Docker, Image: "ubuntu"}, "windows"); err == nil {
+		t.Errorf("Expected executor docker not supported on Windows")
+	}
+	if e, err := getJobExecutor(nil, "windows"); err != nil || e.Isolated() {
+		t.Errorf("Expected executor exec by default, got %v, %v", e, err)
+	}
+}
+
+func Test_executorCommands(t *testing.T) {
+	task := &executorTask{
+		label:      "headnode:1/job",
+		startPoint: "/bin/sh",
+		args:       []string{"/tmp/clusrun/job.sh", "it's"},
+		workingDir: "/work",
+		variables:  []string{"CLUSRUN_SCRATCH_DIR=/scratch/1"},
+		dirs:       []string{"/tmp/clusrun", "/scratch/1", "/work"},
+		limits:     platform.ResourceLimits{CpuPercent: 50, MemoryBytes: 1 << 20},
+		stdin:      true,
+	}
+	expected := []string{"run", "--rm", "--name", "clusrun-headnode_1_job", "-i", "--cpus", "2.00", "--memory", "1048576",
+		"-v", "/tmp/clusrun:/tmp/clusrun", "-v", "/scratch/1:/scratch/1", "-v", "/work:/work", "-v", "/data:/data:ro",
+		"-w", "/work", "-e", "CLUSRUN_SCRATCH_DIR", "ubuntu", "/bin/sh", "/tmp/clusrun/job.sh", "it's"}
+	if args := getDockerRunArgs(task, "ubuntu", []string{"/data:/data:ro"}, 4); !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected docker arguments %q, got %q", expected, args)
+	}
+	expected_line := `cd '/work' && CLUSRUN_SCRATCH_DIR='/scratch/1' exec '/bin/sh' '/tmp/clusrun/job.sh' 'it'\''s'`
+	if line := getPosixCommandLine(task); line != expected_line {
+		t.Errorf("Expected command line %v, got %v", expected_line, line)
+	}
+}
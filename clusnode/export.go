@@ -0,0 +1,324 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	pb "clusrun/protobuf"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	exportOutputChunkSize = 64 << 10
+)
+
+// Stream the finished jobs created in the time range, and the tar of their output files named <job id>/<file> if requested,
+// so that the job history can be archived or imported to another headnode
+func (s *headnode_server) ExportJobs(in *pb.ExportJobsRequest, out pb.Headnode_ExportJobsServer) error {
+	defer LogPanicBeforeExit()
+	var w *bufio.Writer
+	var tw *tar.Writer
+	if in.GetWithOutput() {
+		w = bufio.NewWriterSize(exportOutputWriter{out}, exportOutputChunkSize)
+		tw = tar.NewWriter(w)
+	}
+	count := 0
+	filter := &jobFilter{since: in.GetSince(), until: in.GetUntil(), cluster: getAuthCluster(out.Context())}
+	err := RangeJobs(filter, func(job *pb.Job) error {
+		if !isExportableState(job.State) {
+			return nil
+		}
+		if err := out.Send(&pb.ExportJobsReply{Job: job}); err != nil {
+			return err
+		}
+		count++
+		if tw != nil {
+			if err := writeOutputTar(tw, job.Id); err != nil {
+				return fmt.Errorf("Failed to export output of job %v: %v", job.Id, err)
+			}
+		}
+		return nil
+	})
+	if err == nil && tw != nil {
+		if err = tw.Close(); err == nil {
+			err = w.Flush()
+		}
+	}
+	if err != nil {
+		LogError("Failed to export jobs: %v", err)
+		return err
+	}
+	LogInfo("Exported %v jobs", count)
+	return nil
+}
+
+// Only the jobs which will not change any more are exported or imported
+func isExportableState(state pb.JobState) bool {
+	return !isActiveState(state) && state != pb.JobState_Created
+}
+
+// The writer sending the tar of output files in chunks
+type exportOutputWriter struct {
+	out pb.Headnode_ExportJobsServer
+}
+
+func (w exportOutputWriter) Write(p []byte) (int, error) {
+	if err := w.out.Send(&pb.ExportJobsReply{Output: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Write the output files of the job as they are read, the compressed or referred output files in the output dir are
+// written with their stored extensions removed, and the search index is left to be rebuilt
+func writeOutputTar(tw *tar.Writer, id int32) error {
+	dir := getOutputDir(id)
+	written := map[string]bool{}
+	return filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil || !info.Mode().IsRegular() || rel == outputIndexFile {
+			return err
+		}
+		if filepath.Dir(rel) == "." {
+			for _, ext := range []string{compressedOutputExt, outputRefExt, outputObjectExt} {
+				if strings.HasSuffix(rel, ext) {
+					rel, file = strings.TrimSuffix(rel, ext), strings.TrimSuffix(file, ext)
+					break
+				}
+			}
+		}
+		name := path.Join(strconv.Itoa(int(id)), filepath.ToSlash(rel))
+		if written[name] {
+			return nil
+		}
+		written[name] = true
+		f, size, err := openOutputFile(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		header := &tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0644, Size: size, ModTime: info.ModTime()}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+// Import the jobs and the tar of their output files, the jobs keep their ids unless taken, and the new id of each job
+// is replied
+func (s *headnode_server) ImportJobs(in pb.Headnode_ImportJobsServer) error {
+	defer LogPanicBeforeExit()
+
+	// Extract the output files into a staging dir while receiving
+	staging, err := ioutil.TempDir(db_uploadDir, "import")
+	if err != nil {
+		LogError("Failed to create staging dir for importing: %v", err)
+		return err
+	}
+	defer os.RemoveAll(staging)
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	extracted := make(chan error, 1)
+	output_files := 0
+	go func() {
+		defer LogPanicBeforeExit()
+		var err error
+		output_files, err = extractOutputTar(staging, pr)
+		if err != nil {
+			pr.CloseWithError(err)
+		} else {
+			_, _ = io.Copy(ioutil.Discard, pr)
+		}
+		extracted <- err
+	}()
+	jobs := []*pb.Job{}
+	ids := map[int32]bool{}
+	for {
+		req, err := in.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			LogError("Failed to receive jobs to import: %v", err)
+			return err
+		}
+		if job := req.GetJob(); job != nil {
+			if job.Id <= 0 || ids[job.Id] {
+				return status.Errorf(codes.InvalidArgument, "Invalid or duplicate job id %v", job.Id)
+			}
+			if !isExportableState(job.State) {
+				return status.Errorf(codes.InvalidArgument, "Job %v in state %v can not be imported", job.Id, job.State)
+			}
+			ids[job.Id] = true
+			jobs = append(jobs, job)
+		}
+		if output := req.GetOutput(); len(output) > 0 {
+			if _, err := pw.Write(output); err != nil {
+				return status.Errorf(codes.InvalidArgument, "Failed to extract output files: %v", <-extracted)
+			}
+		}
+	}
+	pw.Close()
+	if err := <-extracted; err != nil {
+		return status.Errorf(codes.InvalidArgument, "Failed to extract output files: %v", err)
+	}
+
+	// Add the jobs and move their output files into the output dirs of their ids
+	id_map, err := importJobs(jobs)
+	if err != nil {
+		LogError("Failed to import jobs: %v", err)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	for old_id, new_id := range id_map {
+		dir := filepath.Join(staging, strconv.Itoa(int(old_id)))
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+		// The output dir left by a job no longer recorded is replaced
+		if _, err := os.Stat(getOutputDir(new_id)); err == nil {
+			cleanupOutputDir(new_id)
+		}
+		if err := os.Rename(dir, getOutputDir(new_id)); err != nil {
+			LogError("Failed to move output files of imported job %v: %v", new_id, err)
+			continue
+		}
+		if items, err := ioutil.ReadDir(getOutputDir(new_id)); err == nil {
+			files := []string{}
+			for _, item := range items {
+				if item.Mode().IsRegular() {
+					files = append(files, filepath.Join(getOutputDir(new_id), item.Name()))
+				}
+			}
+			storeOutputFiles(files...)
+		}
+	}
+	LogInfo("Imported %v jobs with %v output files", len(id_map), output_files)
+	return in.SendAndClose(&pb.ImportJobsReply{JobIds: id_map, OutputFiles: int32(output_files)})
+}
+
+// Extract the output files named <job id>/<file> into the dir
+func extractOutputTar(dir string, r io.Reader) (int, error) {
+	tr := tar.NewReader(r)
+	count := 0
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return count, nil
+		} else if err != nil {
+			return count, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if !isValidOutputTarName(header.Name) {
+			return count, fmt.Errorf("Invalid file %q in output", header.Name)
+		}
+		file := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return count, err
+		}
+		f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return count, err
+		}
+		_, err = io.Copy(f, tr)
+		f.Close()
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+}
+
+// The output file should be in the output dir of a job
+func isValidOutputTarName(name string) bool {
+	if path.IsAbs(name) || strings.Contains(name, "\\") || path.Clean(name) != name {
+		return false
+	}
+	parts := strings.Split(name, "/")
+	if len(parts) < 2 {
+		return false
+	}
+	for _, part := range parts {
+		if part == ".." || part == "." || len(part) == 0 {
+			return false
+		}
+	}
+	id, err := strconv.Atoi(parts[0])
+	return err == nil && id > 0 && strconv.Itoa(id) == parts[0]
+}
+
+// Add the imported jobs to the job list and get the new id of each imported job
+func importJobs(imported []*pb.Job) (map[int32]int32, error) {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	jobs, err := LoadJobs()
+	if err != nil {
+		return nil, err
+	}
+	if max_job_count := Config_Headnode_MaxJobCount.GetInt(); max_job_count > 0 && len(jobs)+len(imported) > max_job_count {
+		return nil, fmt.Errorf("Job count would exceed the capacity %v with %v jobs imported to %v jobs", max_job_count, len(imported), len(jobs))
+	}
+	id_map := assignImportedJobIds(jobs, imported)
+	for _, job := range imported {
+		job.Id = id_map[job.Id]
+		if job.After != nil {
+			if id, ok := id_map[job.After.JobId]; ok {
+				job.After.JobId = id
+			}
+		}
+	}
+	jobs = append(jobs, imported...)
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Id < jobs[j].Id })
+	if err := saveJobs(jobs); err != nil {
+		return nil, err
+	}
+	return id_map, nil
+}
+
+// The imported jobs keep their ids if not taken by the existing jobs, or get the ids after all the others in order
+func assignImportedJobIds(jobs, imported []*pb.Job) map[int32]int32 {
+	taken := make(map[int32]bool, len(jobs))
+	var last_id int32
+	for _, job := range jobs {
+		taken[job.Id] = true
+		if job.Id > last_id {
+			last_id = job.Id
+		}
+	}
+	for _, job := range imported {
+		if !taken[job.Id] && job.Id > last_id {
+			last_id = job.Id
+		}
+	}
+	sorted := make([]*pb.Job, len(imported))
+	copy(sorted, imported)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Id < sorted[j].Id })
+	id_map := make(map[int32]int32, len(imported))
+	for _, job := range sorted {
+		if taken[job.Id] {
+			last_id++
+			id_map[job.Id] = last_id
+		} else {
+			id_map[job.Id] = job.Id
+		}
+	}
+	return id_map
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"testing"
+)
+
+func Test_importJobs(t *testing.T) {
+	jobs := []*pb.Job{{Id: 1}, {Id: 2}, {Id: 5}}
+	imported := []*pb.Job{{Id: 8}, {Id: 2}, {Id: 3}, {Id: 5}}
+	expected := map[int32]int32{2: 9, 3: 3, 5: 10, 8: 8}
+	if actual := assignImportedJobIds(jobs, imported); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected imported job ids %v, got %v", expected, actual)
+	}
+	if actual := assignImportedJobIds(nil, imported); len(actual) != 4 || actual[2] != 2 || actual[8] != 8 {
+		t.Errorf("Expected imported job ids kept without existing jobs, got %v", actual)
+	}
+
+	for _, name := range []string{"1/node1.out", "12/node1/dir/file"} {
+		if !isValidOutputTarName(name) {
+			t.Errorf("Expected valid output file %q", name)
+		}
+	}
+	for _, name := range []string{"1", "node1.out", "0/node1.out", "01/node1.out", "-1/node1.out", "/1/node1.out", "1/../2/node1.out", "1/./node1.out", "1//node1.out", "1/dir/", "1\\node1.out"} {
+		if isValidOutputTarName(name) {
+			t.Errorf("Expected invalid output file %q", name)
+		}
+	}
+}
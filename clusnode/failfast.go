@@ -0,0 +1,61 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"sync"
+	"sync/atomic"
+)
+
+// Abort a job by cancelling its running tasks once the failed tasks are more than the threshold
+type jobAborter struct {
+	id        int32
+	threshold int32
+	aborted   int32
+	once      sync.Once
+}
+
+func newJobAborter(id int32, fail_fast *pb.FailFast, nodes int) *jobAborter {
+	return &jobAborter{id: id, threshold: getFailureThreshold(fail_fast, nodes)}
+}
+
+// Get the max count of failed tasks before aborting the job, -1 for never aborting
+func getFailureThreshold(fail_fast *pb.FailFast, nodes int) int32 {
+	if fail_fast == nil {
+		return -1
+	}
+	if percent := fail_fast.GetMaxFailurePercent(); percent > 0 {
+		return int32(nodes) * percent / 100
+	}
+	return fail_fast.GetMaxFailures()
+}
+
+func (a *jobAborter) Aborted() bool {
+	return atomic.LoadInt32(&a.aborted) != 0
+}
+
+// Check the failed tasks of the job after a task ends
+func (a *jobAborter) Check(job_on_nodes *sync.Map) {
+	if a.threshold < 0 || a.Aborted() {
+		return
+	}
+	if failures := countFailedTasks(job_on_nodes); failures > a.threshold {
+		a.once.Do(func() {
+			atomic.StoreInt32(&a.aborted, 1)
+			LogWarning("Abort job %v since %v nodes failed, more than %v", a.id, failures, a.threshold)
+			go cancelRunningTasks(a.id, job_on_nodes)
+		})
+	}
+}
+
+func cancelRunningTasks(id int32, job_on_nodes *sync.Map) {
+	wg := sync.WaitGroup{}
+	result := sync.Map{}
+	job_on_nodes.Range(func(k, v interface{}) bool {
+		if v.(jobOnNode).state <= pb.JobState_Running {
+			wg.Add(1)
+			go cancelJobOnNode(id, k.(string), &wg, &result)
+		}
+		return true
+	})
+	wg.Wait()
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"testing"
+)
+
+func Test_getFailureThreshold(t *testing.T) {
+	cases := []struct {
+		failFast *pb.FailFast
+		nodes    int
+		expected int32
+	}{
+		{nil, 10, -1},
+		{&pb.FailFast{}, 10, 0},
+		{&pb.FailFast{MaxFailures: 3}, 10, 3},
+		{&pb.FailFast{MaxFailurePercent: 25}, 10, 2},
+		{&pb.FailFast{MaxFailurePercent: 10}, 5, 0},
+		{&pb.FailFast{MaxFailurePercent: 100}, 5, 5},
+	}
+
+	for _, c := range cases {
+		if actual := getFailureThreshold(c.failFast, c.nodes); actual != c.expected {
+			t.Errorf("\nfail fast=%v, nodes=%v\nexpected=%v\n  actual=%v", c.failFast, c.nodes, c.expected, actual)
+		}
+	}
+}
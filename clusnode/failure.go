@@ -0,0 +1,228 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	FailureKind_Stderr    = "stderr"
+	FailureKind_Group     = "group"
+	FailureKind_Zone      = "zone"
+	FailureKind_Label     = "label"
+	FailureKind_Os        = "os"
+	FailureKind_OsVersion = "os version"
+	FailureKind_Arch      = "arch"
+	FailureKind_Clusnode  = "clusnode version"
+
+	failureStderrTailSize    = 4096 // the bytes at the end of stderr of a failed task kept for analysis
+	failureStderrLines       = 3    // the last lines of stderr compared for similarity
+	failureStderrSimilarity  = 0.6  // the min similarity of stderr tokens for failed nodes in the same cluster
+	failureAttributeShare    = 0.8  // the min share of failed nodes with an attribute value to report
+	maxFailureStderrClusters = 5
+	maxFailureSignatureSize  = 200
+)
+
+var (
+	failureNumberRegexp = regexp.MustCompile(`0[xX][0-9a-fA-F]+|[0-9]+`)
+)
+
+// Keep the end of the output within the size
+func keepOutputTail(output string, size int) string {
+	if len(output) <= size {
+		return output
+	}
+	return output[len(output)-size:]
+}
+
+// Cluster the failed nodes of the job by the similarity of stderr and the attributes shared by most of them, after the job with many nodes fails
+func analyzeJobFailures(id int32, job_on_nodes *sync.Map) []*pb.FailureCluster {
+	min_nodes := Config_Headnode_FailureAnalysisMinNodes.GetInt()
+	if min_nodes <= 0 {
+		return nil
+	}
+	nodes := []string{}
+	failed := map[string]string{}
+	job_on_nodes.Range(func(key, val interface{}) bool {
+		node, j := key.(string), val.(jobOnNode)
+		nodes = append(nodes, node)
+		if j.state == pb.JobState_Failed && !j.rescheduled {
+			failed[node] = j.stderr
+		}
+		return true
+	})
+	if len(nodes) < min_nodes {
+		return nil
+	}
+	clusters := clusterFailures(nodes, failed, getNodeAttributes)
+	if len(clusters) > 0 {
+		LogInfo("Failure clusters of job %v: %v", id, clusters)
+	}
+	return clusters
+}
+
+// The attributes of node which failures may correlate with
+func getNodeAttributes(node string) map[string][]string {
+	attributes := map[string][]string{}
+	NodeGroups.Range(func(k, v interface{}) bool {
+		if _, ok := v.(*sync.Map).Load(node); ok {
+			attributes[FailureKind_Group] = append(attributes[FailureKind_Group], k.(string))
+		}
+		return true
+	})
+	if zone, ok := nodeZones.Load(node); ok && len(zone.(string)) > 0 {
+		attributes[FailureKind_Zone] = []string{zone.(string)}
+	}
+	for key, value := range getNodeLabels(node) {
+		attributes[FailureKind_Label] = append(attributes[FailureKind_Label], key+"="+value)
+	}
+	if s, ok := nodeSystems.Load(node); ok {
+		system := s.(*pb.NodeSystem)
+		if len(system.Os) > 0 {
+			attributes[FailureKind_Os] = []string{system.Os}
+		}
+		if len(system.Version) > 0 {
+			attributes[FailureKind_OsVersion] = []string{system.Version}
+		}
+		if len(system.Arch) > 0 {
+			attributes[FailureKind_Arch] = []string{system.Arch}
+		}
+	}
+	if b, ok := nodeBuilds.Load(node); ok && len(b.(*pb.NodeBuild).Version) > 0 {
+		attributes[FailureKind_Clusnode] = []string{b.(*pb.NodeBuild).Version}
+	}
+	return attributes
+}
+
+// Cluster the failed nodes with their stderr among the nodes of a job, there is no cluster unless at least 2 nodes fail
+func clusterFailures(nodes []string, failed map[string]string, attributes func(node string) map[string][]string) []*pb.FailureCluster {
+	if len(failed) < 2 {
+		return nil
+	}
+	return append(clusterFailuresByStderr(failed), clusterFailuresByAttributes(nodes, failed, attributes)...)
+}
+
+// Group the failed nodes whose last lines of stderr are similar after the numbers and node names are masked
+func clusterFailuresByStderr(failed map[string]string) []*pb.FailureCluster {
+	type stderrCluster struct {
+		signature string
+		tokens    map[string]bool
+		nodes     []string
+	}
+	nodes := make([]string, 0, len(failed))
+	for node := range failed {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	clusters := []*stderrCluster{}
+	for _, node := range nodes {
+		signature, tokens := normalizeFailureStderr(failed[node], node)
+		var cluster *stderrCluster
+		for _, c := range clusters {
+			if c.signature == signature || tokenSimilarity(c.tokens, tokens) >= failureStderrSimilarity {
+				cluster = c
+				break
+			}
+		}
+		if cluster == nil {
+			cluster = &stderrCluster{signature: signature, tokens: tokens}
+			clusters = append(clusters, cluster)
+		}
+		cluster.nodes = append(cluster.nodes, node)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool { return len(clusters[i].nodes) > len(clusters[j].nodes) })
+	result := []*pb.FailureCluster{}
+	for _, c := range clusters {
+		if len(c.nodes) < 2 || len(result) >= maxFailureStderrClusters {
+			break
+		}
+		result = append(result, &pb.FailureCluster{Kind: FailureKind_Stderr, Value: c.signature, Nodes: c.nodes, TotalNodes: int32(len(failed))})
+	}
+	return result
+}
+
+// Get the last non-empty line of stderr as the signature, and the tokens of the last lines for similarity
+func normalizeFailureStderr(stderr, node string) (signature string, tokens map[string]bool) {
+	name, _ := splitNodeDisplayName(node)
+	nodename := regexp.MustCompile("(?i)" + regexp.QuoteMeta(name))
+	lines := []string{}
+	for _, line := range strings.Split(strings.ReplaceAll(stderr, "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			line = failureNumberRegexp.ReplaceAllString(nodename.ReplaceAllString(line, "<node>"), "#")
+			lines = append(lines, strings.Join(strings.Fields(line), " "))
+		}
+	}
+	if len(lines) == 0 {
+		return "(empty stderr)", map[string]bool{}
+	}
+	if len(lines) > failureStderrLines {
+		lines = lines[len(lines)-failureStderrLines:]
+	}
+	tokens = map[string]bool{}
+	for _, line := range lines {
+		for _, token := range strings.Fields(line) {
+			tokens[token] = true
+		}
+	}
+	signature = lines[len(lines)-1]
+	if len(signature) > maxFailureSignatureSize {
+		signature = signature[:maxFailureSignatureSize] + "..."
+	}
+	return signature, tokens
+}
+
+// The Jaccard similarity of the token sets
+func tokenSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	common := 0
+	for token := range a {
+		if b[token] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// Find the attribute values shared by most failed nodes but not by all nodes of the job, e.g. all failures are on a certain OS version
+func clusterFailuresByAttributes(nodes []string, failed map[string]string, attributes func(node string) map[string][]string) []*pb.FailureCluster {
+	type attribute struct {
+		kind, value string
+	}
+	failed_nodes := map[attribute][]string{}
+	total := map[attribute]int{}
+	sorted := make([]string, len(nodes))
+	copy(sorted, nodes)
+	sort.Strings(sorted)
+	for _, node := range sorted {
+		for kind, values := range attributes(node) {
+			for _, value := range values {
+				a := attribute{kind, value}
+				total[a]++
+				if _, ok := failed[node]; ok {
+					failed_nodes[a] = append(failed_nodes[a], node)
+				}
+			}
+		}
+	}
+	result := []*pb.FailureCluster{}
+	for a, n := range failed_nodes {
+		if len(n) >= 2 && float64(len(n)) >= failureAttributeShare*float64(len(failed)) && total[a] < len(nodes) {
+			result = append(result, &pb.FailureCluster{Kind: a.kind, Value: a.value, Nodes: n, TotalNodes: int32(total[a])})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].Nodes) != len(result[j].Nodes) {
+			return len(result[i].Nodes) > len(result[j].Nodes)
+		}
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Value < result[j].Value
+	})
+	return result
+}
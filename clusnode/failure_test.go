@@ -0,0 +1,43 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"testing"
+)
+
+func Test_clusterFailures(t *testing.T) {
+	nodes := []string{"n1", "n2", "n3", "n4", "n5", "n6"}
+	failed := map[string]string{
+		"n1": "loading data\nerror: disk /dev/sda1 on N1 is full (code 28)\n",
+		"n2": "error: disk /dev/sdb2 on n2 is full (code 28)\r\n",
+		"n3": "loading data\nerror: disk /dev/sda1 on n3 is full (code 28)",
+		"n4": "",
+	}
+	attributes := func(node string) map[string][]string {
+		a := map[string][]string{FailureKind_OsVersion: {"5.4.0"}, FailureKind_Zone: {"rack1"}}
+		if node == "n1" || node == "n2" || node == "n3" || node == "n4" {
+			a[FailureKind_OsVersion] = []string{"5.8.0"}
+		}
+		if node == "n4" || node == "n5" {
+			a[FailureKind_Zone] = []string{"rack2"}
+		}
+		return a
+	}
+	expected := []*pb.FailureCluster{
+		{Kind: FailureKind_Stderr, Value: "error: disk /dev/sda# on <node> is full (code #)", Nodes: []string{"n1", "n2", "n3"}, TotalNodes: 4},
+		{Kind: FailureKind_OsVersion, Value: "5.8.0", Nodes: []string{"n1", "n2", "n3", "n4"}, TotalNodes: 4},
+	}
+	if actual := clusterFailures(nodes, failed, attributes); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("\nexpected=%v\n  actual=%v", expected, actual)
+	}
+
+	// The attribute shared by all nodes is not reported, and there is no cluster for a single failure
+	same := func(string) map[string][]string { return map[string][]string{FailureKind_Os: {"linux"}} }
+	if actual := clusterFailures(nodes, map[string]string{"n1": "a", "n2": "b"}, same); len(actual) != 0 {
+		t.Errorf("unexpected clusters: %v", actual)
+	}
+	if actual := clusterFailures(nodes, map[string]string{"n1": "a"}, attributes); len(actual) != 0 {
+		t.Errorf("unexpected clusters: %v", actual)
+	}
+}
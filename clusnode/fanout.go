@@ -0,0 +1,115 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	dispatchTrees sync.Map // job id -> *dispatchTree
+)
+
+// The tree of nodes in a job, in which the headnode only connects to the top nodes, and each node relays the tasks of its
+// children, so that the connections and output streams of a large job are spread over the nodes
+type dispatchTree struct {
+	parents map[string]string // node -> the node relaying its task, the top nodes have none
+}
+
+// The nodes form a complete tree with the fan-out in order, the first nodes of fan-out count are at the top, and the
+// children of node i are the nodes from (i+1)*fanout to (i+2)*fanout-1
+func newDispatchTree(nodes []string, fanout int) *dispatchTree {
+	tree := &dispatchTree{parents: map[string]string{}}
+	for i := fanout; i < len(nodes); i++ {
+		tree.parents[nodes[i]] = nodes[i/fanout-1]
+	}
+	return tree
+}
+
+// Get the hosts of the relays from the top and the host of the node at last
+func (t *dispatchTree) Path(node string) []string {
+	path := []string{parseHost(node)}
+	for parent, ok := t.parents[node]; ok; parent, ok = t.parents[parent] {
+		path = append([]string{parseHost(parent)}, path...)
+	}
+	return path
+}
+
+// Dispatch the job in a tree if the fan-out is configured and the job has more nodes than it
+func startDispatchTree(id int32, nodes []string) {
+	// The nodes connected through reverse tunnels can only be reached by the headnode, so they are dispatched directly
+	reachable := []string{}
+	for _, node := range nodes {
+		if !isNodeTunneled(parseHost(node)) {
+			reachable = append(reachable, node)
+		}
+	}
+	if fanout := Config_Headnode_DispatchFanout.GetInt(); fanout > 0 && len(reachable) > fanout {
+		getJobLogger(id).Info("Dispatch job %v on %v nodes in a tree with fan-out %v", id, len(reachable), fanout)
+		dispatchTrees.Store(id, newDispatchTree(reachable, fanout))
+	}
+}
+
+// Get the path of relays to dispatch the task on the node, which is only the node itself if it is dispatched directly
+func getDispatchPath(id int32, node string) []string {
+	if tree, ok := dispatchTrees.Load(id); ok {
+		return tree.(*dispatchTree).Path(node)
+	}
+	return []string{parseHost(node)}
+}
+
+// Start the task on the first node of the path, which relays it along the rest of the path with the signatures of
+// headnode for the calls to the nodes after it
+func startTaskStream(ctx context.Context, c pb.ClusnodeClient, request *pb.StartJobRequest, path []string, signatures []*pb.HeadnodeSignature) (taskOutputStream, error) {
+	if len(path) > 1 {
+		return c.RelayJob(ctx, &pb.RelayJobRequest{Path: path[1:], Request: request, Signatures: signatures}, getOutputStreamCallOptions()...)
+	}
+	return c.StartJob(ctx, request, getOutputStreamCallOptions()...)
+}
+
+// Relay the task to the next node in the path and its output back, the headnode resumes the output stream from the node
+// directly if the relay fails
+func (s *clusnode_server) RelayJob(in *pb.RelayJobRequest, out pb.Clusnode_RelayJobServer) error {
+	defer LogPanicBeforeExit()
+	path, request, signatures := in.GetPath(), in.GetRequest(), in.GetSignatures()
+	if len(path) == 0 || request == nil {
+		return status.Error(codes.InvalidArgument, "Missing path or request to relay")
+	}
+	if len(signatures) != len(path) {
+		return status.Error(codes.InvalidArgument, "Missing signatures of headnode to relay")
+	}
+	logger := LogContext(out.Context()).With(logFieldJob, request.GetJobId())
+	logger.Info("Relay job %v from headnode %v to %v", request.GetJobId(), request.GetHeadnode(), path[len(path)-1])
+	conn, release := GetNodeConnection(path[0], connectionLane_Output)
+	if conn == nil {
+		return status.Errorf(codes.Unavailable, "Failed to connect %v to relay", path[0])
+	}
+	defer release()
+
+	// The correlation id of the job is passed along, and the next node is called with the signature of headnode for it
+	ctx := withHeadnodeSignature(out.Context(), signatures[0])
+	if correlation_id := getCorrelationId(ctx); len(correlation_id) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, correlationIdMetadataKey, correlation_id)
+	}
+	stream, err := startTaskStream(ctx, pb.NewClusnodeClient(conn), request, path, signatures[1:])
+	if err != nil {
+		return err
+	}
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			logger.Warning("Failed to relay output of job %v from %v: %v", request.GetJobId(), path[0], err)
+			return err
+		}
+		if err := out.Send(reply); err != nil {
+			return err
+		}
+	}
+}
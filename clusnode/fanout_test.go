@@ -0,0 +1,42 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_dispatchTree(t *testing.T) {
+	nodes := []string{"N0", "N1", "N2", "N3", "N4", "N5", "N6", "N7(10.0.0.7:50505)"}
+	tree := newDispatchTree(nodes, 2)
+	cases := map[string][]string{
+		"N0":                 {"N0"},
+		"N1":                 {"N1"},
+		"N2":                 {"N0", "N2"},
+		"N5":                 {"N1", "N5"},
+		"N6":                 {"N0", "N2", "N6"},
+		"N7(10.0.0.7:50505)": {"N0", "N2", "10.0.0.7:50505"},
+	}
+	for node, expected := range cases {
+		for i := range expected {
+			if !strings.Contains(expected[i], ":") {
+				expected[i] += ":" + DefaultPort
+			}
+		}
+		if path := tree.Path(node); !reflect.DeepEqual(path, expected) {
+			t.Errorf("Expected path %v of node %v, got %v", expected, node, path)
+		}
+	}
+	children := map[string]int{}
+	for _, parent := range tree.parents {
+		children[parent]++
+	}
+	for parent, count := range children {
+		if count > 2 {
+			t.Errorf("Expected at most 2 children of node %v, got %v", parent, count)
+		}
+	}
+	if path := getDispatchPath(-1, "N0"); len(path) != 1 {
+		t.Errorf("Expected the task dispatched directly without a tree, got path %v", path)
+	}
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	PeerHeadnodesNone = "none"
+	PeersAll          = "*"
+
+	peerHeadnodeSeparator  = ";"
+	peerAddressSeparator   = "="
+	federatedNodeSeparator = "/" // the separator of peer and node in the nodes of a federated job, e.g. east/node1
+)
+
+var (
+	peerHeadnodesValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if _, err := parsePeerHeadnodes(v); err != nil {
+			return err
+		}
+		return nil
+	}
+)
+
+// A headnode managing the nodes of its region, to which a job can be forwarded in federation mode
+type peerHeadnode struct {
+	name string
+	host string
+}
+
+// The reply of a peer headnode in a federated job, the stream of the peer ends with a nil reply
+type peerJobReply struct {
+	peer  string
+	reply *pb.StartClusJobReply
+	err   error
+}
+
+// Parse the peer headnodes in format "name=host[:port][;name=host[:port]...]"
+func parsePeerHeadnodes(value string) ([]peerHeadnode, error) {
+	peers, added := []peerHeadnode{}, map[string]bool{}
+	for _, item := range strings.Split(value, peerHeadnodeSeparator) {
+		if item = strings.TrimSpace(item); len(item) == 0 {
+			continue
+		}
+		i := strings.Index(item, peerAddressSeparator)
+		if i < 0 {
+			return nil, fmt.Errorf("Missing address of peer headnode, expect format: name%vhost[:port]", peerAddressSeparator)
+		}
+		name, err := normalizeClusterName(item[:i])
+		if err != nil {
+			return nil, err
+		}
+		if added[name] {
+			return nil, fmt.Errorf("Duplicate peer headnode %v", name)
+		}
+		_, _, host, err := ParseHostAddress(item[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid address of peer headnode %v: %v", name, err)
+		}
+		peers, added[name] = append(peers, peerHeadnode{name: name, host: host}), true
+	}
+	return peers, nil
+}
+
+// Resolve the peers to forward a job to, "*" for all peers configured
+func resolvePeerHeadnodes(peers []peerHeadnode, names []string) ([]peerHeadnode, error) {
+	resolved, added := []peerHeadnode{}, map[string]bool{}
+	for _, name := range names {
+		found := false
+		for _, p := range peers {
+			if name == PeersAll || strings.EqualFold(strings.TrimSpace(name), p.name) {
+				found = true
+				if !added[p.name] {
+					resolved, added[p.name] = append(resolved, p), true
+				}
+			}
+		}
+		if !found && name != PeersAll {
+			return nil, fmt.Errorf("Unknown peer headnode %q", name)
+		}
+	}
+	if len(resolved) == 0 {
+		return nil, errors.New("No peer headnode is configured")
+	}
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i].name < resolved[j].name })
+	return resolved, nil
+}
+
+// Split the nodes qualified by their peers like "east/node1" to the nodes of each peer
+func splitFederatedNodes(nodes []string, peers []peerHeadnode) (map[string][]string, error) {
+	peer_nodes := map[string][]string{}
+	for _, node := range nodes {
+		i := strings.Index(node, federatedNodeSeparator)
+		if i < 0 {
+			return nil, fmt.Errorf("Node %q is not qualified by peer headnode in format peer%vnode", node, federatedNodeSeparator)
+		}
+		peer := strings.ToLower(strings.TrimSpace(node[:i]))
+		found := false
+		for _, p := range peers {
+			if p.name == peer {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Peer headnode of node %q is not selected", node)
+		}
+		peer_nodes[peer] = append(peer_nodes[peer], node[i+1:])
+	}
+	return peer_nodes, nil
+}
+
+func qualifyFederatedNode(peer, node string) string {
+	if len(node) == 0 {
+		return node
+	}
+	return peer + federatedNodeSeparator + node
+}
+
+func qualifyFederatedNodeValues(peer string, values map[string]string, qualified map[string]string) map[string]string {
+	for node, value := range values {
+		if qualified == nil {
+			qualified = map[string]string{}
+		}
+		qualified[qualifyFederatedNode(peer, node)] = value
+	}
+	return qualified
+}
+
+// Forward the job to the peer headnodes, each running it on the nodes of its region as a job of its own, and aggregate
+// their streams with the nodes qualified by the peers
+func (s *headnode_server) startFederatedJob(in *pb.StartClusJobRequest, out pb.Headnode_StartClusJobServer) error {
+	logger := LogContext(out.Context())
+	if client := getAuthClient(out.Context()); client != nil && len(client.scope.Cluster) > 0 {
+		return status.Errorf(codes.PermissionDenied, "Role %v is limited to cluster %v, which can not run jobs in federation mode", client.scope.Role, client.scope.Cluster)
+	}
+	if len(in.GetNodeCommands()) > 0 || len(in.GetSweepValues()) > 0 || in.GetAfter() != nil || in.GetRerunOf() > 0 || in.GetForwardStdin() {
+		return status.Error(codes.InvalidArgument, "Per-node commands, dependencies, reruns and forwarding stdin are not supported in federation mode")
+	}
+	configured, err := parsePeerHeadnodes(Config_Headnode_PeerHeadnodes.GetString())
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	peers, err := resolvePeerHeadnodes(configured, in.GetPeers())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	peer_nodes, err := splitFederatedNodes(in.GetNodes(), peers)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(in.GetNodes()) > 0 {
+		// Only the peers of the nodes specified run the job
+		selected := peers[:0]
+		for _, p := range peers {
+			if len(peer_nodes[p.name]) > 0 {
+				selected = append(selected, p)
+			}
+		}
+		peers = selected
+	}
+
+	// The client is authenticated by the peers with the same credential
+	ctx, cancel := context.WithCancel(out.Context())
+	defer cancel()
+	if md, ok := metadata.FromIncomingContext(out.Context()); ok {
+		for _, v := range md.Get(authMetadataKey) {
+			ctx = metadata.AppendToOutgoingContext(ctx, authMetadataKey, v)
+		}
+	}
+	logger.Info("Forwarding job to %v peer headnodes", len(peers))
+
+	// Start the job on each peer, the peers failing to start it are reported in the first reply
+	first := &pb.StartClusJobReply{PeerJobs: map[string]int32{}, PeerErrors: map[string]string{}}
+	replies := make(chan peerJobReply)
+	var lock sync.Mutex
+	started := 0
+	wg := sync.WaitGroup{}
+	for _, p := range peers {
+		wg.Add(1)
+		go func(p peerHeadnode) {
+			defer LogPanicBeforeExit()
+			request := proto.Clone(in).(*pb.StartClusJobRequest)
+			request.Peers, request.Nodes = nil, peer_nodes[p.name]
+			stream, reply, err := startJobOnPeer(ctx, p, request)
+			lock.Lock()
+			if err != nil {
+				logger.Warning("Failed to start job on peer headnode %v: %v", p.name, err)
+				first.PeerErrors[p.name] = err.Error()
+			} else {
+				mergeFederatedFirstReply(first, p.name, reply)
+				started++
+			}
+			lock.Unlock()
+			wg.Done()
+			if err != nil || stream == nil {
+				return
+			}
+			for {
+				reply, err := stream.Recv()
+				if err == io.EOF {
+					reply, err = nil, nil
+				}
+				select {
+				case replies <- peerJobReply{peer: p.name, reply: reply, err: err}:
+				case <-ctx.Done():
+					return
+				}
+				if reply == nil {
+					return
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+	if started == 0 {
+		return status.Errorf(codes.FailedPrecondition, "Job is not started on any peer headnode: %v", formatSkippedNodes(first.PeerErrors))
+	}
+	sort.Strings(first.Nodes)
+	if err := out.Send(first); err != nil || in.GetDryRun() {
+		return err
+	}
+
+	// Relay the output of each peer and aggregate the progress and summaries
+	progress, summaries, failed := map[string]*pb.JobProgress{}, map[string]*pb.JobSummary{}, map[string]string{}
+	for ended := 0; ended < started; {
+		r := <-replies
+		if r.err != nil || r.reply == nil {
+			if r.err != nil {
+				logger.Warning("Output stream of peer headnode %v is lost: %v", r.peer, r.err)
+				failed[r.peer] = status.Convert(r.err).Message()
+			}
+			ended++
+			continue
+		}
+		reply := r.reply
+		if s := reply.GetSummary(); s != nil {
+			summaries[r.peer] = s
+			continue
+		}
+		if p := reply.GetProgress(); p != nil {
+			progress[r.peer] = p
+			reply = &pb.StartClusJobReply{Progress: mergeJobProgress(progress)}
+		} else {
+			reply.Node, reply.RescheduledTo = qualifyFederatedNode(r.peer, reply.Node), qualifyFederatedNode(r.peer, reply.RescheduledTo)
+		}
+		if err := out.Send(reply); err != nil {
+			return err
+		}
+	}
+	if len(summaries) > 0 {
+		if err := out.Send(&pb.StartClusJobReply{Summary: mergeJobSummaries(summaries)}); err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		return status.Errorf(codes.Unavailable, "Output streams of peer headnodes are lost: %v", formatSkippedNodes(failed))
+	}
+	return nil
+}
+
+// Start the job on the peer headnode and get its first reply, the stream is nil in dry run
+func startJobOnPeer(ctx context.Context, p peerHeadnode, request *pb.StartClusJobRequest) (pb.Headnode_StartClusJobClient, *pb.StartClusJobReply, error) {
+	conn, release := GetNodeConnection(p.host, connectionLane_Output)
+	if conn == nil {
+		return nil, nil, errors.New("Failed to connect peer headnode")
+	}
+	stream, err := pb.NewHeadnodeClient(conn).StartClusJob(ctx, request)
+	if err != nil {
+		release()
+		return nil, nil, errors.New(status.Convert(err).Message())
+	}
+	reply, err := stream.Recv()
+	if err != nil {
+		release()
+		return nil, nil, errors.New(status.Convert(err).Message())
+	}
+	go func() {
+		<-ctx.Done()
+		release()
+	}()
+	if request.DryRun {
+		return nil, reply, nil
+	}
+	return stream, reply, nil
+}
+
+// Merge the first reply of the peer, which has the job created on the peer and its nodes
+func mergeFederatedFirstReply(first *pb.StartClusJobReply, peer string, reply *pb.StartClusJobReply) {
+	first.PeerJobs[peer] = reply.GetJobId()
+	for _, node := range reply.GetNodes() {
+		first.Nodes = append(first.Nodes, qualifyFederatedNode(peer, node))
+	}
+	first.SkippedNodes = qualifyFederatedNodeValues(peer, reply.GetSkippedNodes(), first.SkippedNodes)
+	first.NodeCommands = qualifyFederatedNodeValues(peer, reply.GetNodeCommands(), first.NodeCommands)
+	first.SweepValues = qualifyFederatedNodeValues(peer, reply.GetSweepValues(), first.SweepValues)
+	for _, w := range reply.GetWarnings() {
+		first.Warnings = append(first.Warnings, &pb.JobLintWarning{Rule: w.Rule, Message: fmt.Sprintf("[%v] %v", peer, w.Message)})
+	}
+}
+
+// Sum the latest progress of the job on each peer
+func mergeJobProgress(progress map[string]*pb.JobProgress) *pb.JobProgress {
+	merged := &pb.JobProgress{}
+	for _, p := range progress {
+		merged.Nodes += p.Nodes
+		merged.FinishedNodes += p.FinishedNodes
+		merged.FailedNodes += p.FailedNodes
+		merged.RunningNodes += p.RunningNodes
+	}
+	return merged
+}
+
+// Merge the summaries of the job on the peers, the wall time is the longest one and the dispatch overhead is averaged
+// by nodes
+func mergeJobSummaries(summaries map[string]*pb.JobSummary) *pb.JobSummary {
+	merged := &pb.JobSummary{}
+	exit_codes := map[int32]bool{}
+	var dispatch_overhead int64
+	for peer, s := range summaries {
+		merged.Nodes += s.Nodes
+		merged.SucceededNodes += s.SucceededNodes
+		merged.FailedNodes += s.FailedNodes
+		merged.UnreachableNodes += s.UnreachableNodes
+		merged.StdoutBytes += s.StdoutBytes
+		merged.StderrBytes += s.StderrBytes
+		dispatch_overhead += s.DispatchOverhead * int64(s.Nodes)
+		if s.WallTime > merged.WallTime {
+			merged.WallTime = s.WallTime
+		}
+		if s.SlowestDuration > merged.SlowestDuration {
+			merged.SlowestNode, merged.SlowestDuration = qualifyFederatedNode(peer, s.SlowestNode), s.SlowestDuration
+		}
+		if s.FailedNodes > 0 || s.UnreachableNodes > 0 {
+			exit_codes[s.ExitCode] = true
+		}
+	}
+	if merged.Nodes > 0 {
+		merged.DispatchOverhead = dispatch_overhead / int64(merged.Nodes)
+	}
+	merged.ExitCode = getAggregateExitCode(exit_codes)
+	return merged
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func Test_parsePeerHeadnodes(t *testing.T) {
+	peers, err := parsePeerHeadnodes("East=hn-east:50505; west=10.0.0.1")
+	if err != nil || len(peers) != 2 || peers[0] != (peerHeadnode{name: "east", host: "HN-EAST:50505"}) || peers[1] != (peerHeadnode{name: "west", host: "10.0.0.1:" + DefaultPort}) {
+		t.Errorf("Expected peers east and west, got %v, %v", peers, err)
+	}
+	for _, invalid := range []string{"east", "east=", "east=a;east=b", "e st=a"} {
+		if _, err := parsePeerHeadnodes(invalid); err == nil {
+			t.Errorf("Expected error to parse peer headnodes %q", invalid)
+		}
+	}
+	if resolved, err := resolvePeerHeadnodes(peers, []string{"WEST"}); err != nil || len(resolved) != 1 || resolved[0].name != "west" {
+		t.Errorf("Expected peer west resolved, got %v, %v", resolved, err)
+	}
+	if resolved, err := resolvePeerHeadnodes(peers, []string{"*", "east"}); err != nil || len(resolved) != 2 {
+		t.Errorf("Expected all peers resolved, got %v, %v", resolved, err)
+	}
+	if _, err := resolvePeerHeadnodes(peers, []string{"north"}); err == nil {
+		t.Errorf("Expected error to resolve unknown peer")
+	}
+	if _, err := resolvePeerHeadnodes(nil, []string{"*"}); err == nil {
+		t.Errorf("Expected error to resolve peers without any configured")
+	}
+	peer_nodes, err := splitFederatedNodes([]string{"east/n1", "West/n2", "east/n3"}, peers)
+	if err != nil || !reflect.DeepEqual(peer_nodes, map[string][]string{"east": {"n1", "n3"}, "west": {"n2"}}) {
+		t.Errorf("Expected nodes split to peers, got %v, %v", peer_nodes, err)
+	}
+	if _, err := splitFederatedNodes([]string{"n1"}, peers); err == nil {
+		t.Errorf("Expected error to split the node not qualified by peer")
+	}
+}
+
+func Test_mergeJobSummaries(t *testing.T) {
+	summaries := map[string]*pb.JobSummary{
+		"east": {Nodes: 3, SucceededNodes: 3, WallTime: 5, SlowestNode: "n1", SlowestDuration: 4, StdoutBytes: 10, DispatchOverhead: 2},
+		"west": {Nodes: 1, FailedNodes: 1, ExitCode: 2, WallTime: 7, SlowestNode: "n2", SlowestDuration: 6, StdoutBytes: 5, DispatchOverhead: 6},
+	}
+	expected := &pb.JobSummary{Nodes: 4, SucceededNodes: 3, FailedNodes: 1, ExitCode: 2, WallTime: 7, SlowestNode: "west/n2", SlowestDuration: 6, StdoutBytes: 15, DispatchOverhead: 3}
+	if merged := mergeJobSummaries(summaries); !proto.Equal(merged, expected) {
+		t.Errorf("Expected summary %v, got %v", expected, merged)
+	}
+	progress := mergeJobProgress(map[string]*pb.JobProgress{"east": {Nodes: 3, FinishedNodes: 1, RunningNodes: 2}, "west": {Nodes: 1, FinishedNodes: 1, FailedNodes: 1}})
+	if expected := (&pb.JobProgress{Nodes: 4, FinishedNodes: 2, FailedNodes: 1, RunningNodes: 2}); !proto.Equal(progress, expected) {
+		t.Errorf("Expected progress %v, got %v", expected, progress)
+	}
+}
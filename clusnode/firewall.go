@@ -2,12 +2,22 @@ package main
 
 import (
 	"fmt"
+	"net"
 	"os/exec"
 	"strings"
 )
 
 func SetupFireWall() {
-	_, port, _, _ := ParseHostAddress(NodeHost)
+	ports := map[string]bool{}
+	for _, address := range NodeListens {
+		if _, port, err := net.SplitHostPort(address); err == nil && !ports[port] {
+			ports[port] = true
+			setupFireWallPort(port)
+		}
+	}
+}
+
+func setupFireWallPort(port string) {
 	LogInfo("Setup firewall of port %v", port)
 	var cmds [][]string
 	if RunOnWindows {
@@ -0,0 +1,134 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	lostNodesForgetInterval = time.Minute
+)
+
+func (s *headnode_server) RemoveNodes(ctx context.Context, in *pb.RemoveNodesRequest) (*pb.RemoveNodesReply, error) {
+	defer LogPanicBeforeExit()
+	pattern := in.GetPattern()
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pattern %q: %v", pattern, err)
+	}
+	removed, not_lost := selectNodesToRemove(pattern, 0)
+	reply := &pb.RemoveNodesReply{RemovedNodes: removed, NotLostNodes: not_lost}
+	if len(removed) > 0 {
+		reply.UndoId = addRemovedNodesUndo(removeNodes(removed))
+	}
+	LogInfo("RemoveNodes result: removed %v, not lost %v", removed, not_lost)
+	return reply, nil
+}
+
+func forgetLostNodesPeriodically() {
+	for {
+		time.Sleep(lostNodesForgetInterval)
+		if hours := Config_Headnode_ForgetLostNodesHours.GetInt(); hours > 0 {
+			if nodes, _ := selectNodesToRemove("", time.Duration(hours)*time.Hour); len(nodes) > 0 {
+				LogInfo("Forget nodes lost for more than %v hours: %v", hours, nodes)
+				removeNodes(nodes)
+			}
+		}
+	}
+}
+
+// Select the nodes matching the pattern which are lost for more than the duration, and the matched nodes not lost
+func selectNodesToRemove(pattern string, lost_for time.Duration) (lost, not_lost []string) {
+	lost, not_lost = []string{}, []string{}
+	for _, record := range ReportedNodes.Snapshot() {
+		node, last_report := record.name, record.reportedTime
+		if matched, _ := regexp.MatchString(pattern, node); !matched {
+			continue
+		}
+		if !nodeLost(node, last_report) {
+			not_lost = append(not_lost, node)
+		} else if time.Since(last_report) > lost_for {
+			lost = append(lost, node)
+		}
+	}
+	sort.Strings(lost)
+	sort.Strings(not_lost)
+	return
+}
+
+// The states of a removed node to restore
+type removedNode struct {
+	name       string
+	record     nodeRecord
+	registered bool
+	states     map[*sync.Map]interface{}
+	groups     map[*sync.Map]interface{}
+}
+
+// The states kept in headnode for each node
+func getNodeStateMaps() []*sync.Map {
+	return []*sync.Map{&reportedTo, &nodeZones, &nodeLabels, &nodeAdminLabels, &nodeProbes, &nodeSystems, &nodeBuilds, &nodeResources, &nodeHosts, &nodeHeartbeatIntervals, &nodeDraining, &heartbeatDisconnected, &lastTaskDuration, &nodeStats}
+}
+
+// Forget the nodes in headnode, a removed node is added back if it reports again
+func removeNodes(nodes []string) []removedNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+	removed := make([]removedNode, 0, len(nodes))
+	for _, node := range nodes {
+		r := removedNode{name: node, states: map[*sync.Map]interface{}{}, groups: map[*sync.Map]interface{}{}}
+		r.record, r.registered = ReportedNodes.Remove(node)
+		for _, m := range getNodeStateMaps() {
+			if v, ok := m.Load(node); ok {
+				r.states[m] = v
+				m.Delete(node)
+			}
+		}
+		NodeGroups.Range(func(k, v interface{}) bool {
+			group := v.(*sync.Map)
+			if value, ok := group.Load(node); ok {
+				r.groups[group] = value
+				group.Delete(node)
+			}
+			return true
+		})
+		removed = append(removed, r)
+		LogInfo("Node %v is removed", node)
+	}
+	if err := SaveNodeGroups(); err != nil {
+		LogError("Failed to save node groups: %v", err)
+	}
+	MarkNodesChanged()
+	return removed
+}
+
+// Restore the removed nodes which are not reported again since removed
+func restoreNodes(removed []removedNode) (restored, reported []string) {
+	restored, reported = []string{}, []string{}
+	for _, r := range removed {
+		if _, ok := ReportedNodes.LastReport(r.name); ok || r.registered && !ReportedNodes.Restore(r.record) {
+			reported = append(reported, r.name)
+			continue
+		}
+		for m, v := range r.states {
+			m.Store(r.name, v)
+		}
+		for group, v := range r.groups {
+			group.Store(r.name, v)
+		}
+		restored = append(restored, r.name)
+		LogInfo("Node %v is restored", r.name)
+	}
+	if err := SaveNodeGroups(); err != nil {
+		LogError("Failed to save node groups: %v", err)
+	}
+	MarkNodesChanged()
+	return
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_selectNodesToRemove(t *testing.T) {
+	now := time.Now()
+	reported := map[string]time.Time{
+		"NODE1":              now,
+		"NODE2":              now.Add(-time.Minute),
+		"NODE3(node3:50506)": now.Add(-2 * time.Hour),
+		"OTHER(other:50505)": now.Add(-2 * time.Hour),
+	}
+	for node, last_report := range reported {
+		ReportedNodes.Touch(node, last_report)
+		defer ReportedNodes.Remove(node)
+	}
+	cases := []struct {
+		pattern  string
+		lost_for time.Duration
+		lost     []string
+		not_lost []string
+	}{
+		{"", 0, []string{"NODE2", "NODE3(node3:50506)", "OTHER(other:50505)"}, []string{"NODE1"}},
+		{"NODE", 0, []string{"NODE2", "NODE3(node3:50506)"}, []string{"NODE1"}},
+		{"NODE", time.Hour, []string{"NODE3(node3:50506)"}, []string{"NODE1"}},
+		{"^OTHER", 3 * time.Hour, []string{}, []string{}},
+	}
+
+	for _, c := range cases {
+		if lost, not_lost := selectNodesToRemove(c.pattern, c.lost_for); !reflect.DeepEqual(lost, c.lost) || !reflect.DeepEqual(not_lost, c.not_lost) {
+			t.Errorf("\npattern=%q, lost_for=%v\nexpected=%v, %v\n  actual=%v, %v", c.pattern, c.lost_for, c.lost, c.not_lost, lost, not_lost)
+		}
+	}
+}
+
+func Test_restoreNodes(t *testing.T) {
+	now := time.Now()
+	group := &sync.Map{}
+	NodeGroups.Store("Test_restoreNodes", group)
+	defer NodeGroups.Delete("Test_restoreNodes")
+	for _, node := range []string{"NODE1", "NODE2"} {
+		ReportedNodes.Touch(node, now)
+		nodeZones.Store(node, "zone1")
+		group.Store(node, false)
+		defer ReportedNodes.Remove(node)
+		defer nodeZones.Delete(node)
+	}
+	removed := removeNodes([]string{"NODE1", "NODE2"})
+	for _, node := range []string{"NODE1", "NODE2"} {
+		if _, ok := ReportedNodes.LastReport(node); ok {
+			t.Errorf("node %v is not removed", node)
+		}
+	}
+	ReportedNodes.Touch("NODE2", now.Add(time.Second))
+	restored, reported := restoreNodes(removed)
+	if !reflect.DeepEqual(restored, []string{"NODE1"}) || !reflect.DeepEqual(reported, []string{"NODE2"}) {
+		t.Errorf("\nexpected restored=[NODE1], reported=[NODE2]\n  actual restored=%v, reported=%v", restored, reported)
+	}
+	if v, _ := ReportedNodes.LastReport("NODE1"); v != now {
+		t.Errorf("reported time of NODE1 is not restored: %v", v)
+	}
+	if v, _ := nodeZones.Load("NODE1"); v != "zone1" {
+		t.Errorf("zone of NODE1 is not restored: %v", v)
+	}
+	if _, ok := group.Load("NODE1"); !ok {
+		t.Error("NODE1 is not restored to group")
+	}
+	if _, ok := nodeZones.Load("NODE2"); ok {
+		t.Error("zone of NODE2 reported again is restored")
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	gatherChunkSize = 1 << 20
+)
+
+func (s *headnode_server) GatherFiles(ctx context.Context, in *pb.GatherFilesRequest) (*pb.GatherFilesReply, error) {
+	defer LogPanicBeforeExit()
+	id, paths := in.GetJobId(), in.GetPaths()
+	if len(paths) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No files to gather")
+	}
+	job, err := GetJob(id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if isActiveState(job.State) || job.State == pb.JobState_Created {
+		return nil, status.Errorf(codes.FailedPrecondition, "Job %v is %v, files can be gathered after it finishes", id, job.State)
+	}
+
+	// Get nodes of the job, the nodes lost and rescheduled are excluded
+	rescheduled := map[string]bool{}
+	for _, r := range job.Reschedules {
+		rescheduled[r.FromNode] = true
+	}
+	job_nodes := map[string]string{}
+	for _, node := range job.Nodes {
+		if !rescheduled[node] {
+			job_nodes[strings.ToLower(node)] = node
+		}
+	}
+	nodes := []string{}
+	if len(in.GetNodes()) == 0 {
+		for _, node := range job_nodes {
+			nodes = append(nodes, node)
+		}
+	} else {
+		invalid_nodes := []string{}
+		for _, node := range in.GetNodes() {
+			if n, ok := job_nodes[strings.ToLower(node)]; ok {
+				nodes = append(nodes, n)
+			} else {
+				invalid_nodes = append(invalid_nodes, node)
+			}
+		}
+		if len(invalid_nodes) > 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Nodes not in job %v: %v", id, invalid_nodes)
+		}
+	}
+	LogInfo("Gathering files %v of job %v from nodes: %v", paths, id, nodes)
+
+	// Gather files from nodes into <job output dir>/<node>
+	dir := getOutputDir(id)
+	var results sync.Map
+	pool := newDispatchPool(Config_Headnode_MaxParallelDispatch.GetInt())
+	wg := sync.WaitGroup{}
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node string) {
+			defer wg.Done()
+			destination := filepath.Join(dir, FileNameFormatHost(node)+getSweepFileSuffix(job.SweepValues[node]))
+			if files, bytes, err := gatherFilesFromNode(node, destination, job.WorkingDir, paths, pool); err != nil {
+				LogError("Failed to gather files from node %v: %v", node, err)
+				results.Store(node, status.Convert(err).Message())
+			} else {
+				results.Store(node, fmt.Sprintf("Gathered %v files (%v bytes)", files, bytes))
+			}
+		}(node)
+	}
+	wg.Wait()
+	reply := &pb.GatherFilesReply{Results: map[string]string{}, Dir: dir}
+	results.Range(func(k, v interface{}) bool {
+		reply.Results[k.(string)] = v.(string)
+		return true
+	})
+	LogInfo("GatherFiles result: %v", reply.Results)
+	return reply, nil
+}
+
+func gatherFilesFromNode(node, destination, working_dir string, paths []string, pool dispatchPool) (int32, int64, error) {
+	pool.Acquire()
+	defer pool.Release()
+	conn, release := GetNodeConnection(parseHost(node), connectionLane_Output)
+	if conn == nil {
+		return 0, 0, errors.New("Failed to connect node")
+	}
+	defer release()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := pb.NewClusnodeClient(conn).SendFiles(ctx, &pb.SendFilesRequest{Headnode: NodeHost, Paths: paths, WorkingDir: working_dir})
+	if err != nil {
+		return 0, 0, err
+	}
+	receiver := &fileReceiver{destination: destination}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			receiver.Abort()
+			return 0, 0, err
+		}
+		if err := receiver.Add(chunk); err != nil {
+			receiver.Abort()
+			return 0, 0, err
+		}
+	}
+	if err := receiver.Close(); err != nil {
+		return 0, 0, err
+	}
+	return receiver.files, receiver.bytes, nil
+}
+
+// Send the files matching the paths, which should be under the allowed working dirs
+func (s *clusnode_server) SendFiles(in *pb.SendFilesRequest, out pb.Clusnode_SendFilesServer) (e error) {
+	defer LogPanicBeforeExit()
+	working_dir := in.GetWorkingDir()
+	LogInfo("Sending files %v to headnode %v", in.GetPaths(), in.GetHeadnode())
+	sent := map[string]bool{}
+	var files, bytes int64
+	defer func() {
+		result := fmt.Sprintf("Sent %v files (%v bytes)", files, bytes)
+		if e != nil {
+			result = formatAuditResult(e)
+		}
+		audit(in.GetHeadnode(), AuditAction_FilesSend, 0, "", strings.Join(in.GetPaths(), " "), result)
+	}()
+	for _, p := range in.GetPaths() {
+		if !filepath.IsAbs(p) {
+			if len(working_dir) == 0 {
+				return status.Errorf(codes.InvalidArgument, "Relative path %q is only allowed for job with working dir", p)
+			}
+			p = filepath.Join(working_dir, p)
+		}
+		matches, err := filepath.Glob(p)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "Invalid path %q: %v", p, err)
+		}
+		if len(matches) == 0 {
+			return status.Errorf(codes.NotFound, "No files match %q on node %v", p, NodeName)
+		}
+		for _, match := range matches {
+			if !isWorkingDirAllowed(match, Config_Clusnode_AllowedWorkingDirs.GetString()) {
+				return status.Errorf(codes.PermissionDenied, "Path %q is not allowed on node %v", match, NodeName)
+			}
+			err := filepath.Walk(match, func(file string, info os.FileInfo, err error) error {
+				if err != nil || sent[file] {
+					return err
+				}
+				sent[file] = true
+				rel := getGatheredPath(file, working_dir)
+				if info.IsDir() {
+					return out.Send(&pb.FileChunk{Path: rel, IsDir: true})
+				}
+				if !info.Mode().IsRegular() {
+					LogWarning("Skip sending %v which is not a regular file", file)
+					return nil
+				}
+				n, err := sendFile(file, rel, info.Mode().Perm(), out.Send)
+				bytes += n
+				if err == nil {
+					files++
+				}
+				return err
+			})
+			if err != nil {
+				LogError("Failed to send files to headnode %v: %v", in.GetHeadnode(), err)
+				return err
+			}
+		}
+	}
+	LogInfo("Sent %v files (%v bytes) to headnode %v", files, bytes, in.GetHeadnode())
+	return nil
+}
+
+// The path of a file under the working dir is relative to it, otherwise the absolute path without root is used, e.g. "C/logs/a.log" for "C:\logs\a.log"
+func getGatheredPath(file, working_dir string) string {
+	if len(working_dir) > 0 {
+		if rel, err := filepath.Rel(working_dir, file); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+			return path.Clean(filepath.ToSlash(rel))
+		}
+	}
+	volume := filepath.VolumeName(file)
+	rel := strings.Trim(filepath.ToSlash(volume), "/:") + "/" + filepath.ToSlash(file[len(volume):])
+	return path.Clean(strings.TrimLeft(rel, "/"))
+}
+
+func sendFile(file, rel string, mode os.FileMode, send func(*pb.FileChunk) error) (int64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	hasher := sha256.New()
+	buf := make([]byte, gatherChunkSize)
+	var bytes int64
+	for {
+		n, err := io.ReadFull(f, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return bytes, err
+		}
+		_, _ = hasher.Write(buf[:n])
+		chunk := &pb.FileChunk{Path: rel, Mode: uint32(mode), Data: buf[:n]}
+		if err != nil {
+			chunk.Eof = true
+			chunk.Sha256 = hex.EncodeToString(hasher.Sum(nil))
+		}
+		if err := send(chunk); err != nil {
+			return bytes, err
+		}
+		bytes += int64(n)
+		if chunk.Eof {
+			return bytes, nil
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	CudaVisibleDevicesEnv = "CUDA_VISIBLE_DEVICES"
+
+	gpuDetectInterval  = 30 * time.Second
+	gpuDetectTimeout   = 10 * time.Second
+	gpuIdleUtilization = 10 // the max utilization percent of an idle GPU
+	gpuQueryFields     = "index,uuid,name,driver_version,memory.total,memory.used,utilization.gpu"
+)
+
+var (
+	detectedGpus     []*pb.GpuInfo // the GPUs detected on this clusnode, nil if none or nvidia-smi is not found
+	detectedGpusLock sync.Mutex
+
+	gpuAssignments     = map[int32]gpuAssignment{} // GPU index -> the task it is assigned to
+	gpuAssignmentsLock sync.Mutex
+)
+
+type gpuAssignment struct {
+	jobLabel string
+	jobId    int32
+}
+
+// Detect the GPUs by nvidia-smi at each interval, which are reported to headnodes by heartbeats
+func detectGpusPeriodically() {
+	defer LogPanicBeforeExit()
+	if _, err := exec.LookPath("nvidia-smi"); err != nil {
+		LogInfo("No GPU is detected since nvidia-smi is not found")
+		return
+	}
+	for {
+		gpus, err := detectGpus()
+		if err != nil {
+			LogWarning("Failed to detect GPUs: %v", err)
+		}
+		detectedGpusLock.Lock()
+		detectedGpus = gpus
+		detectedGpusLock.Unlock()
+		time.Sleep(gpuDetectInterval)
+	}
+}
+
+func detectGpus() ([]*pb.GpuInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gpuDetectTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "nvidia-smi", "--query-gpu="+gpuQueryFields, "--format=csv,noheader,nounits")
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%v: %v", err, strings.TrimSpace(stderr.String()))
+	}
+	return parseNvidiaSmiOutput(stdout.String())
+}
+
+// Parse the output of nvidia-smi in csv without header and units, the unsupported values like "[N/A]" are taken as 0
+func parseNvidiaSmiOutput(output string) ([]*pb.GpuInfo, error) {
+	reader := csv.NewReader(strings.NewReader(output))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	expected := len(strings.Split(gpuQueryFields, ","))
+	gpus := make([]*pb.GpuInfo, 0, len(records))
+	for _, r := range records {
+		if len(r) != expected {
+			return nil, fmt.Errorf("Expect %v fields of GPU, got %q", expected, r)
+		}
+		index, err := strconv.Atoi(r[0])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid GPU index %q", r[0])
+		}
+		number := func(s string) int64 {
+			n, _ := strconv.ParseInt(s, 10, 64)
+			return n
+		}
+		gpus = append(gpus, &pb.GpuInfo{
+			Index:         int32(index),
+			Uuid:          r[1],
+			Model:         r[2],
+			Driver:        r[3],
+			MemoryTotalMb: number(r[4]),
+			MemoryUsedMb:  number(r[5]),
+			Utilization:   int32(number(r[6])),
+		})
+	}
+	return gpus, nil
+}
+
+// Get the GPUs detected with the jobs they are assigned to, and the number of idle ones
+func getNodeGpus() ([]*pb.GpuInfo, int32) {
+	detectedGpusLock.Lock()
+	gpus := detectedGpus
+	detectedGpusLock.Unlock()
+	gpuAssignmentsLock.Lock()
+	defer gpuAssignmentsLock.Unlock()
+	reported := make([]*pb.GpuInfo, 0, len(gpus))
+	var idle int32
+	for _, gpu := range gpus {
+		gpu := proto.Clone(gpu).(*pb.GpuInfo)
+		if a, ok := gpuAssignments[gpu.Index]; ok {
+			gpu.JobId = a.jobId
+		} else if gpu.Utilization < gpuIdleUtilization {
+			idle++
+		}
+		reported = append(reported, gpu)
+	}
+	return reported, idle
+}
+
+// Assign the idle GPUs with the lowest utilization to the task, which are released once it ends
+func assignGpus(job_label string, job_id int32, count int) ([]int32, error) {
+	detectedGpusLock.Lock()
+	gpus := detectedGpus
+	detectedGpusLock.Unlock()
+	gpuAssignmentsLock.Lock()
+	defer gpuAssignmentsLock.Unlock()
+	idle := selectIdleGpus(gpus, gpuAssignments, count)
+	if len(idle) < count {
+		return nil, fmt.Errorf("%v idle GPUs are required, but %v are available on node %v", count, len(idle), NodeName)
+	}
+	for _, index := range idle {
+		gpuAssignments[index] = gpuAssignment{jobLabel: job_label, jobId: job_id}
+	}
+	return idle, nil
+}
+
+// Select the GPUs not assigned and under the idle utilization, in order of utilization and index
+func selectIdleGpus(gpus []*pb.GpuInfo, assignments map[int32]gpuAssignment, count int) []int32 {
+	idle := []*pb.GpuInfo{}
+	for _, gpu := range gpus {
+		if _, ok := assignments[gpu.Index]; !ok && gpu.Utilization < gpuIdleUtilization {
+			idle = append(idle, gpu)
+		}
+	}
+	sort.SliceStable(idle, func(i, j int) bool {
+		if idle[i].Utilization != idle[j].Utilization {
+			return idle[i].Utilization < idle[j].Utilization
+		}
+		return idle[i].Index < idle[j].Index
+	})
+	if len(idle) > count {
+		idle = idle[:count]
+	}
+	indexes := make([]int32, 0, len(idle))
+	for _, gpu := range idle {
+		indexes = append(indexes, gpu.Index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes
+}
+
+func releaseGpus(job_label string) {
+	gpuAssignmentsLock.Lock()
+	defer gpuAssignmentsLock.Unlock()
+	for index, a := range gpuAssignments {
+		if a.jobLabel == job_label {
+			delete(gpuAssignments, index)
+		}
+	}
+}
+
+// Format the GPU indexes separated by comma, e.g. for CUDA_VISIBLE_DEVICES
+func formatGpuIndexes(indexes []int32) string {
+	items := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		items = append(items, strconv.Itoa(int(index)))
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func Test_parseNvidiaSmiOutput(t *testing.T) {
+	output := "0, GPU-1a2b, NVIDIA A100-SXM4-40GB, 535.104.05, 40960, 1024, 0\n1, GPU-3c4d, NVIDIA A100-SXM4-40GB, 535.104.05, 40960, [N/A], 87\n"
+	gpus, err := parseNvidiaSmiOutput(output)
+	if err != nil || len(gpus) != 2 {
+		t.Fatalf("Expected 2 GPUs, got %v, %v", gpus, err)
+	}
+	expected := &pb.GpuInfo{Index: 1, Uuid: "GPU-3c4d", Model: "NVIDIA A100-SXM4-40GB", Driver: "535.104.05", MemoryTotalMb: 40960, Utilization: 87}
+	if !proto.Equal(gpus[1], expected) {
+		t.Errorf("Expected GPU %v, got %v", expected, gpus[1])
+	}
+	if gpus, err := parseNvidiaSmiOutput(""); err != nil || len(gpus) != 0 {
+		t.Errorf("Expected no GPU, got %v, %v", gpus, err)
+	}
+	if _, err := parseNvidiaSmiOutput("0, GPU-1a2b, NVIDIA A100\n"); err == nil {
+		t.Errorf("Expected error to parse the output missing fields")
+	}
+}
+
+func Test_selectIdleGpus(t *testing.T) {
+	gpus := []*pb.GpuInfo{{Index: 0, Utilization: 5}, {Index: 1, Utilization: 0}, {Index: 2, Utilization: 0}, {Index: 3, Utilization: 90}}
+	assignments := map[int32]gpuAssignment{2: {jobLabel: "headnode:1", jobId: 1}}
+	if idle := selectIdleGpus(gpus, assignments, 1); !reflect.DeepEqual(idle, []int32{1}) {
+		t.Errorf("Expected the idle GPU with the lowest utilization, got %v", idle)
+	}
+	if idle := selectIdleGpus(gpus, assignments, 4); !reflect.DeepEqual(idle, []int32{0, 1}) {
+		t.Errorf("Expected GPUs 0 and 1 idle, got %v", idle)
+	}
+	if indexes := formatGpuIndexes([]int32{0, 1}); indexes != "0,1" {
+		t.Errorf("Expected GPU indexes 0,1, got %v", indexes)
+	}
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	grpc "google.golang.org/grpc"
+)
+
+const (
+	GrpcWebOriginsSeparator = ","
+	GrpcWebOriginsAny       = "*"
+	GrpcWebOriginsNone      = "none"
+
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+	grpcWebTrailerFlag     = 0x80
+	grpcWebShutdownTimeout = 5 * time.Second
+)
+
+var (
+	grpcWebServer     *http.Server
+	grpcWebServerLock sync.Mutex
+
+	portValidator = func(value interface{}) error {
+		if v, ok := value.(int); !ok {
+			return errors.New("Invalid type")
+		} else if v < 0 || v > 65535 {
+			return errors.New("Value should be between 0 and 65535")
+		}
+		return nil
+	}
+)
+
+// Serve the gRPC API for browsers by gRPC-Web, which is translated to gRPC and handled by the gRPC server,
+// so that the browser apps can call the API and stream job output without a proxy like Envoy
+type grpcWebHandler struct {
+	server *grpc.Server
+}
+
+func (h grpcWebHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer LogPanicBeforeExit()
+	origin := r.Header.Get("Origin")
+	if len(origin) > 0 {
+		if !isGrpcWebOriginAllowed(origin, r.Host) {
+			LogWarning("Reject gRPC-Web request of %v from origin %v", r.RemoteAddr, origin)
+			http.Error(w, "Origin is not allowed", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Expose-Headers", "grpc-status, grpc-message, grpc-status-details-bin")
+		w.Header().Add("Vary", "Origin")
+	}
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Allow-Methods", http.MethodPost)
+		w.Header().Set("Access-Control-Allow-Headers", r.Header.Get("Access-Control-Request-Headers"))
+		w.Header().Set("Access-Control-Max-Age", "600")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	content_type := r.Header.Get("Content-Type")
+	text, ok := parseGrpcWebContentType(content_type)
+	if r.Method != http.MethodPost || !ok {
+		http.Error(w, "Only gRPC-Web requests are served", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	// Make the request look like a gRPC one, the body in text format is decoded from base64
+	r.ProtoMajor, r.ProtoMinor, r.Proto = 2, 0, "HTTP/2.0"
+	r.Header.Set("Content-Type", "application/grpc+proto")
+	r.Header.Del("Content-Length")
+	if text {
+		r.Body = ioutil.NopCloser(base64.NewDecoder(base64.StdEncoding, r.Body))
+	}
+	ww := &grpcWebResponseWriter{w: w, header: http.Header{}, contentType: content_type, text: text, ctx: r.Context()}
+	h.server.ServeHTTP(ww, r)
+	ww.writeTrailers()
+}
+
+// The content type is application/grpc-web or application/grpc-web-text with an optional "+proto" suffix
+func parseGrpcWebContentType(content_type string) (text, ok bool) {
+	content_type = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(strings.Split(content_type, ";")[0])), "+proto")
+	switch content_type {
+	case grpcWebContentType:
+		return false, true
+	case grpcWebTextContentType:
+		return true, true
+	}
+	return false, false
+}
+
+// The same origin is always allowed, while the other origins should be configured
+func isGrpcWebOriginAllowed(origin, host string) bool {
+	if index := strings.Index(origin, "://"); index >= 0 && strings.EqualFold(origin[index+3:], host) {
+		return true
+	}
+	for _, allowed := range strings.Split(Config_Headnode_GrpcWebOrigins.GetString(), GrpcWebOriginsSeparator) {
+		if allowed = strings.TrimSpace(allowed); allowed == GrpcWebOriginsAny || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Write the gRPC response as gRPC-Web, in which the trailers are sent in a frame at the end of body
+type grpcWebResponseWriter struct {
+	w             http.ResponseWriter
+	header        http.Header
+	contentType   string
+	text          bool
+	ctx           context.Context
+	headerWritten bool
+}
+
+func (w *grpcWebResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *grpcWebResponseWriter) WriteHeader(code int) {
+	if w.headerWritten {
+		return
+	}
+	w.headerWritten = true
+	trailers := getGrpcWebTrailerNames(w.header)
+	for key, values := range w.header {
+		if key == "Trailer" || trailers[key] || strings.HasPrefix(key, http.TrailerPrefix) {
+			continue
+		}
+		w.w.Header()[key] = values
+	}
+	w.w.Header().Set("Content-Type", w.contentType)
+	w.w.WriteHeader(code)
+}
+
+func (w *grpcWebResponseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	if !w.text {
+		return w.w.Write(b)
+	}
+	if _, err := w.w.Write([]byte(base64.StdEncoding.EncodeToString(b))); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w *grpcWebResponseWriter) Flush() {
+	if f, ok := w.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// The gRPC server stops the stream once the client is gone
+func (w *grpcWebResponseWriter) CloseNotify() <-chan bool {
+	closed := make(chan bool, 1)
+	go func() {
+		<-w.ctx.Done()
+		closed <- true
+	}()
+	return closed
+}
+
+func (w *grpcWebResponseWriter) writeTrailers() {
+	if _, err := w.Write(formatGrpcWebTrailers(w.header)); err != nil {
+		LogWarning("Failed to write gRPC-Web trailers: %v", err)
+		return
+	}
+	w.Flush()
+}
+
+// The trailers are declared in header "Trailer" or prefixed by http.TrailerPrefix
+func getGrpcWebTrailerNames(header http.Header) map[string]bool {
+	names := map[string]bool{}
+	for _, values := range header["Trailer"] {
+		for _, name := range strings.Split(values, ",") {
+			names[http.CanonicalHeaderKey(strings.TrimSpace(name))] = true
+		}
+	}
+	return names
+}
+
+// Format the trailers in lines of "key: value" in a frame flagged as trailers
+func formatGrpcWebTrailers(header http.Header) []byte {
+	names := getGrpcWebTrailerNames(header)
+	lines := []string{}
+	for key, values := range header {
+		if !names[key] && !strings.HasPrefix(key, http.TrailerPrefix) {
+			continue
+		}
+		key = strings.ToLower(strings.TrimPrefix(key, http.TrailerPrefix))
+		for _, value := range values {
+			lines = append(lines, key+": "+value+"\r\n")
+		}
+	}
+	sort.Strings(lines)
+	var b bytes.Buffer
+	b.WriteByte(grpcWebTrailerFlag)
+	payload := strings.Join(lines, "")
+	_ = binary.Write(&b, binary.BigEndian, uint32(len(payload)))
+	b.WriteString(payload)
+	return b.Bytes()
+}
+
+// Start or restart the gRPC-Web server once the port is configured, which is stopped if the port is 0
+func watchGrpcWebConfigs(server *grpc.Server) {
+	NodeConfigs.Subscribe(func(changes map[*ConfigItem]interface{}) {
+		if v, ok := changes[&Config_Headnode_GrpcWebPort]; ok {
+			restartGrpcWebServer(server, v.(int))
+		}
+	}, &Config_Headnode_GrpcWebPort)
+}
+
+func restartGrpcWebServer(server *grpc.Server, port int) {
+	grpcWebServerLock.Lock()
+	defer grpcWebServerLock.Unlock()
+	if grpcWebServer != nil && grpcWebServer.Addr == ":"+strconv.Itoa(port) {
+		return
+	}
+	stopGrpcWebServerLocked()
+	if port <= 0 {
+		return
+	}
+	s := &http.Server{Addr: ":" + strconv.Itoa(port), Handler: grpcWebHandler{server: server}}
+	grpcWebServer = s
+	msg := "without TLS"
+	if Tls.Enabled {
+		msg = "with TLS"
+	}
+	LogInfo("Start gRPC-Web server on port %v %v", port, msg)
+	go func() {
+		var err error
+		if Tls.Enabled {
+			err = s.ListenAndServeTLS(Tls.CertFile, Tls.KeyFile)
+		} else {
+			err = s.ListenAndServe()
+		}
+		if err != http.ErrServerClosed {
+			LogError("Failed to serve gRPC-Web on port %v: %v", port, err)
+		}
+	}()
+}
+
+func stopGrpcWebServer() {
+	grpcWebServerLock.Lock()
+	defer grpcWebServerLock.Unlock()
+	stopGrpcWebServerLocked()
+}
+
+func stopGrpcWebServerLocked() {
+	if grpcWebServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), grpcWebShutdownTimeout)
+	defer cancel()
+	if err := grpcWebServer.Shutdown(ctx); err != nil {
+		// The streams like job output may not end in time
+		LogWarning("Close gRPC-Web server not shutdown in time: %v", err)
+		_ = grpcWebServer.Close()
+	}
+	grpcWebServer = nil
+	LogInfo("gRPC-Web server is stopped")
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_parseGrpcWebContentType(t *testing.T) {
+	cases := []struct {
+		content_type string
+		text, ok     bool
+	}{
+		{"application/grpc-web", false, true},
+		{"application/grpc-web+proto", false, true},
+		{"Application/gRPC-Web-Text+proto; charset=utf-8", true, true},
+		{"application/grpc", false, false},
+		{"application/json", false, false},
+		{"", false, false},
+	}
+	for _, c := range cases {
+		if text, ok := parseGrpcWebContentType(c.content_type); text != c.text || ok != c.ok {
+			t.Errorf("Expected %v, %v of %q, got %v, %v", c.text, c.ok, c.content_type, text, ok)
+		}
+	}
+}
+
+func Test_grpcWebResponseWriter(t *testing.T) {
+	for _, text := range []bool{false, true} {
+		recorder := httptest.NewRecorder()
+		w := &grpcWebResponseWriter{w: recorder, header: http.Header{}, contentType: "application/grpc-web+proto", text: text, ctx: context.Background()}
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Add("Trailer", "Grpc-Status")
+		w.Header().Add("Trailer", "Grpc-Message")
+		w.WriteHeader(http.StatusOK)
+		message := []byte{0, 0, 0, 0, 2, 8, 1}
+		_, _ = w.Write(message)
+		w.Header().Set("Grpc-Status", "0")
+		w.Header().Add(http.TrailerPrefix+"Request-Id", "abc")
+		w.writeTrailers()
+		trailers := "grpc-status: 0\r\nrequest-id: abc\r\n"
+		expected := append(append(message, grpcWebTrailerFlag, 0, 0, 0, byte(len(trailers))), trailers...)
+		body := recorder.Body.String()
+		if text {
+			// Each write is encoded separately
+			if expected := base64.StdEncoding.EncodeToString(message) + base64.StdEncoding.EncodeToString(expected[len(message):]); body != expected {
+				t.Errorf("Expected body %q, got %q", expected, body)
+			}
+		} else if body != string(expected) {
+			t.Errorf("Expected body %q, got %q", expected, body)
+		}
+		if content_type, trailer := recorder.Header().Get("Content-Type"), recorder.Header().Get("Trailer"); content_type != "application/grpc-web+proto" || len(trailer) > 0 {
+			t.Errorf("Unexpected header: %v", recorder.Header())
+		}
+	}
+}
@@ -6,29 +6,67 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"math"
 	"os"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
 	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 var (
-	// TODO: use a sync.Map from node to id and 2 arrays instead, only lock when appending
-	reportedTime   sync.Map
-	validateNumber sync.Map
-	NodeGroups     sync.Map
-	Jobs           sync.Map
+	reportedTo    sync.Map // the headnode address each clusnode reports to
+	nodeZones     sync.Map // the failure domain each clusnode reports
+	nodeSystems   sync.Map // the OS, architecture and OS version each clusnode reports
+	nodeBuilds    sync.Map // the version and protocol of clusnode each clusnode reports
+	nodeResources sync.Map // the latest resource usage each clusnode reports
+	nodeHosts     sync.Map // the host of each clusnode by its display name
+	hostNodes     sync.Map // the display name of the clusnode last reporting from each host
+
+	nodeHeartbeatIntervals sync.Map // the interval each clusnode reports it heartbeats at
+	NodeGroups             sync.Map
+	Jobs                   sync.Map
+
+	jobCorrelationIds sync.Map // the correlation id of each running job in logs of headnode and clusnodes
+
+	heartbeatTimeoutNs = int64(time.Duration(Config_Headnode_HeartbeatTimeoutSecond.Value.(int)) * time.Second) // updated once the config is set
+)
+
+const (
+	Capability_Tls         = "tls"
+	Capability_Auth        = "auth"
+	Capability_FileStaging = "file staging"
+	Capability_Containers  = "containers"
+	Capability_Groups      = "groups"
+	Capability_StoreOutput = "store output"
+	Capability_GrpcWeb     = "grpc-web"
+	Capability_ControlPort = "control port"
+	Capability_Federation  = "federation"
+)
+
+const (
+	jobSummaryCommandSize     = 256 // the max size of command in the job summary
+	heartbeatTimeoutIntervals = 5   // the heartbeats a clusnode misses before it is lost if its interval is longer than the default
 )
 
 type jobOnNode struct {
-	state    pb.JobState
-	exitCode int32
+	state       pb.JobState
+	exitCode    int32
+	rescheduled bool
+	unreachable bool // the node is lost and the task is not rescheduled
+	result      string
+	resultError string
+	stderr      string // the end of stderr of the failed task for failure analysis
+	timedOut    bool   // the task is killed by clusnode after its timeout
+	truncated   int64  // the bytes of output truncated by clusnode beyond the limit
 }
 
 type headnode_server struct {
@@ -37,126 +75,371 @@ type headnode_server struct {
 
 func (s *headnode_server) Heartbeat(ctx context.Context, in *pb.HeartbeatRequest) (*pb.Empty, error) {
 	defer LogPanicBeforeExit()
-	nodename, host := in.GetNodename(), in.GetHost()
-	if strings.ContainsAny(nodename, "()") {
-		// TODO: support nodename containing "(" or ")" when necessary by using other format like nodename@host as the node id
-		LogError("Invalid nodename in heartbeat: %v", nodename)
-		return &pb.Empty{}, errors.New("Invalid nodename: " + nodename)
+	if in.GetRelayed() {
+		return &pb.Empty{}, bufferHeartbeat(in)
 	}
-	hostname, port, host, err := ParseHostAddress(host)
+	_, err := receiveHeartbeat(in)
+	return &pb.Empty{}, err
+}
+
+// Receive the heartbeat and return the display name of the clusnode
+func receiveHeartbeat(in *pb.HeartbeatRequest) (string, error) {
+	nodename := canonicalNodename(in.GetNodename())
+	if len(nodename) == 0 {
+		LogError("Empty nodename in heartbeat from %v", in.GetHost())
+		return "", errors.New("Empty nodename")
+	}
+	display_name, host, err := getNodeDisplayName(nodename, in.GetHost())
 	if err != nil {
-		LogError("Invalid host format in heartbeat: %v", host)
-		return &pb.Empty{}, errors.New("Invalid host format: " + host)
+		LogError("Invalid host format in heartbeat: %v", in.GetHost())
+		return "", errors.New("Invalid host format: " + in.GetHost())
 	}
-	nodename = strings.ToUpper(nodename)
-	var display_name string
-	if hostname == nodename && port == DefaultPort {
-		display_name = nodename
-	} else {
-		display_name = nodename + "(" + host + ")"
+	if err := verifyHeartbeat(display_name, in.GetNodename(), in.GetHost(), in.GetHeadnode(), in.GetSignature(), in.GetTimestamp()); err != nil {
+		LogWarning("Rejected heartbeat from %v: %v", display_name, err)
+		return "", status.Error(codes.Unauthenticated, err.Error())
 	}
-	if last_report, ok := reportedTime.Load(display_name); !ok {
+	if _, ok := NodeKeys.Load(display_name); !ok {
+		if ReportedNodes.IsValidated(display_name) {
+			ReportedNodes.ResetValidation(display_name) // validate again to enroll the clusnode validated before having a key
+		}
+	}
+	heartbeatDisconnected.Delete(display_name)
+	storeNodeHost(display_name, host)
+	if last_report, ok := ReportedNodes.Touch(display_name, time.Now()); !ok {
 		LogInfo("First heartbeat from %v", display_name)
-	} else if heartbeatTimeout(last_report.(time.Time)) {
+		MarkNodesChanged()
+	} else if nodeLost(display_name, last_report) {
 		LogInfo("%v reconnected. Last report time: %v", display_name, last_report)
-		validateNumber.Delete(display_name)
+		ReportedNodes.ResetValidation(display_name)
+		resetConnectFailures(host)
 	}
-	reportedTime.Store(display_name, time.Now())
-	go validate(display_name, nodename, host)
-	return &pb.Empty{}, nil
+	if !in.GetRelayed() {
+		// The relayed heartbeats are delayed by the relay, so the skew is only measured by the direct ones
+		storeNodeClockSkew(display_name, in.GetTimestamp())
+	}
+	reportedTo.Store(display_name, in.GetHeadnode())
+	storeNodeHeartbeatInterval(display_name, in.GetHeartbeatIntervalSecond())
+	if zone, ok := nodeZones.Load(display_name); !ok && len(in.GetZone()) > 0 || ok && zone.(string) != in.GetZone() {
+		LogInfo("Zone of %v is changed to %q", display_name, in.GetZone())
+		nodeZones.Store(display_name, in.GetZone())
+		MarkNodesChanged()
+	}
+	storeNodeLabels(display_name, in.GetLabels())
+	storeNodeProbes(display_name, in.GetProbes())
+	if system := in.GetSystem(); system != nil {
+		storeNodeSystem(display_name, system)
+	}
+	if build := in.GetBuild(); build != nil {
+		storeNodeBuild(display_name, build)
+	}
+	if resources := in.GetResources(); resources != nil {
+		resources.SampleTime = time.Now().UnixNano()
+		nodeResources.Store(display_name, resources)
+	}
+	if ids := in.GetRestartLostJobs(); len(ids) > 0 {
+		receiveRestartLostJobs(display_name, ids)
+	}
+	if ids := in.GetOrphanedJobs(); len(ids) > 0 {
+		receiveOrphanedJobs(display_name, ids)
+	}
+	go validate(display_name, nodename, host, in.GetHeadnode())
+	return display_name, nil
+}
+
+// Get the display name of the clusnode and its host parsed, the display name is the nodename only if the clusnode is on
+// the default port of the host with the same name
+func getNodeDisplayName(nodename, host string) (string, string, error) {
+	hostname, port, host, err := ParseHostAddress(host)
+	if err != nil {
+		return "", "", err
+	}
+	if hostname == strings.ToUpper(nodename) && port == DefaultPort {
+		return nodename, host, nil
+	}
+	return nodename + "(" + host + ")", host, nil
+}
+
+// Map the display name of the clusnode to its host and back, a clusnode reporting from the host of another one with a
+// different nodename is warned as a collision, e.g. the host is renamed or the nodename is changed by the config
+func storeNodeHost(display_name, host string) {
+	if previous, ok := hostNodes.Load(host); ok && previous.(string) != display_name {
+		if _, reported := ReportedNodes.LastReport(previous.(string)); reported {
+			LogWarning("Host %v reported by %v is previously reported by %v", host, display_name, previous)
+		}
+	}
+	hostNodes.Store(host, display_name)
+	nodeHosts.Store(display_name, host)
 }
 
 func (s *headnode_server) GetNodes(ctx context.Context, in *pb.GetNodesRequest) (*pb.GetNodesReply, error) {
 	defer LogPanicBeforeExit()
-	pattern, state, groups, intersect := in.GetPattern(), in.GetState(), in.GetGroups(), in.GetGroupsIntersect()
+	reply, err := queryNodes(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	LogSampled("GetNodes", "returned %v nodes", len(reply.Nodes))
+	LogDebug("GetNodes result: %v", reply.Nodes)
+	return reply, nil
+}
+
+// Get the nodes matching the request from the cache, only the nodes changed since the version in request are returned if it is valid
+func queryNodes(ctx context.Context, in *pb.GetNodesRequest) (*pb.GetNodesReply, error) {
+	pattern, state, groups, intersect, since := in.GetPattern(), in.GetState(), in.GetGroups(), in.GetGroupsIntersect(), in.GetSinceVersion()
+	node_os, node_arch := in.GetOs(), in.GetArch()
+	selectors, err := parseNodeSelectors(in.GetLabels(), in.GetWhere())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cluster, err := getClientCluster(ctx, in.GetCluster())
+	if err != nil {
+		return nil, err
+	}
 	candidates := getNodesInGroups(groups, intersect)
+	cache := getNodesCache()
+	since_version, delta := parseNodesVersion(since, cache.version)
+	matched := cache.match(pattern)
 	nodes := []*pb.Node{}
-	reportedTime.Range(func(key interface{}, val interface{}) bool {
-		nodename := key.(string)
-		if _, ok := candidates[nodename]; len(groups) > 0 && !ok {
-			return true
-		}
-		if matched, _ := regexp.MatchString(pattern, nodename); !matched {
-			return true
+	removed_nodes := []string{}
+	for _, nodename := range cache.candidates(state, delta) {
+		if delta && cache.snapshots[nodename].version <= since_version {
+			continue
 		}
-		last_report := val.(time.Time)
-		node := pb.Node{Name: nodename}
-		if heartbeatTimeout(last_report) {
-			node.State = pb.NodeState_Lost
-		} else {
-			if number, ok := validateNumber.Load(nodename); ok && number.(int) < 0 {
-				node.State = pb.NodeState_Ready
-			} else {
-				node.State = pb.NodeState_Error
-			}
-		}
-		if state == pb.NodeState_Unknown || state == node.State {
-			nodes = append(nodes, &node)
+
+		// The nodes in other clusters are not even reported as removed
+		if len(cluster) > 0 && cache.snapshots[nodename].cluster != cluster {
+			continue
 		}
-		return true
-	})
-	NodeGroups.Range(func(k, v interface{}) bool {
-		group := k.(string)
-		n := v.(*sync.Map)
-		for _, node := range nodes {
-			if _, ok := n.Load(node.Name); ok {
-				node.Groups = append(node.Groups, group)
-			}
+		_, in_groups := candidates[nodename]
+		if node, ok := cache.nodes[nodename]; ok && (len(groups) == 0 || in_groups) && matched[nodename] && (state == pb.NodeState_Unknown || state == node.State) && matchNodeSystem(node.System, node_os, node_arch) && matchLabelSelectors(node.Labels, selectors) && matchProbeSelectors(node.Probes, selectors) {
+			nodes = append(nodes, node)
+		} else if delta {
+			removed_nodes = append(removed_nodes, nodename)
 		}
-		return true
-	})
-	for _, node := range nodes {
-		sort.Strings(node.Groups)
 	}
-	LogInfo("GetNodes result: %v", nodes)
-	return &pb.GetNodesReply{Nodes: nodes}, nil
+	return &pb.GetNodesReply{Nodes: nodes, Version: formatNodesVersion(cache.version), Delta: delta, RemovedNodes: removed_nodes}, nil
+}
+
+// Store the OS and architecture reported by the clusnode in heartbeats or validation
+func storeNodeSystem(display_name string, system *pb.NodeSystem) {
+	if s, ok := nodeSystems.Load(display_name); ok && proto.Equal(s.(*pb.NodeSystem), system) {
+		return
+	}
+	LogInfo("System of %v is %v %v (%v)", display_name, system.Os, system.Arch, system.Version)
+	nodeSystems.Store(display_name, system)
+	MarkNodesChanged()
+}
+
+// Store the build reported by the clusnode, and warn if it is not the same as the headnode
+func storeNodeBuild(display_name string, build *pb.NodeBuild) {
+	if b, ok := nodeBuilds.Load(display_name); ok && proto.Equal(b.(*pb.NodeBuild), build) {
+		return
+	}
+	LogInfo("Build of %v is %v", display_name, formatNodeBuild(build))
+	if warning, err := checkNodeBuild(build, Config_Headnode_RequireSameVersion.GetBool()); err != nil {
+		LogWarning("Clusnode %v is incompatible: %v", display_name, err)
+	} else if len(warning) > 0 {
+		LogWarning("Clusnode %v: %v", display_name, warning)
+	}
+	nodeBuilds.Store(display_name, build)
+	MarkNodesChanged()
+}
+
+// The node matches if the OS and architecture are not specified, or equal to the reported ones ignoring case
+func matchNodeSystem(system *pb.NodeSystem, node_os, node_arch string) bool {
+	return (len(node_os) == 0 || strings.EqualFold(node_os, system.GetOs())) && (len(node_arch) == 0 || strings.EqualFold(node_arch, system.GetArch()))
 }
 
 func (s *headnode_server) GetJobs(ctx context.Context, in *pb.GetJobsRequest) (*pb.GetJobsReply, error) {
 	defer LogPanicBeforeExit()
+	jobs := []*pb.Job{}
+	if err := rangeRequestedJobs(ctx, in, func(job *pb.Job) error {
+		jobs = append(jobs, job)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	LogSampled("GetJobs", "returned %v jobs", len(jobs))
+	LogDebug("GetJobs result:%v%v", LineEnding, jobs)
+	return &pb.GetJobsReply{Jobs: jobs}, nil
+}
+
+// Send the requested jobs one by one, so that the client can render them progressively without a giant reply
+func (s *headnode_server) StreamJobs(in *pb.GetJobsRequest, out pb.Headnode_StreamJobsServer) error {
+	defer LogPanicBeforeExit()
+	count := 0
+	if err := rangeRequestedJobs(out.Context(), in, func(job *pb.Job) error {
+		count++
+		return out.Send(job)
+	}); err != nil {
+		LogError("Failed to stream jobs: %v", err)
+		return err
+	}
+	LogSampled("StreamJobs", "sent %v jobs", count)
+	return nil
+}
+
+// Call f with each job filtered by the request, in which the progress and the selected results are filled
+func rangeRequestedJobs(ctx context.Context, in *pb.GetJobsRequest, f func(*pb.Job) error) error {
 	job_ids := in.GetJobIds()
-	loaded_jobs, err := LoadJobs()
+	var path []interface{}
+	if len(in.GetSelect()) > 0 {
+		var err error
+		if path, err = parseJsonPath(in.GetSelect()); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if job_ids == nil {
+		job_ids = map[int32]bool{}
+	}
+	if in.GetLimit() < 0 {
+		return status.Error(codes.InvalidArgument, "Limit should not be negative")
+	}
+	cluster, err := getClientCluster(ctx, in.GetCluster())
 	if err != nil {
-		return nil, err
+		return err
 	}
-	job_ids = NormalizeJobIds(job_ids, loaded_jobs)
-	get_all := false
-	if _, ok := job_ids[JobId_All]; ok {
-		get_all = true
+	filter := &jobFilter{ids: job_ids, since: in.GetSince(), until: in.GetUntil(), node: in.GetNode(), command: in.GetCommand(), cluster: cluster, offset: int(in.GetOffset()), limit: int(in.GetLimit())}
+	if states := in.GetStates(); len(states) > 0 {
+		filter.states = map[pb.JobState]bool{}
+		for _, state := range states {
+			filter.states[state] = true
+		}
 	}
-	jobs := []*pb.Job{}
-	for i := range loaded_jobs {
-		if _, ok := job_ids[loaded_jobs[i].Id]; ok || get_all {
-			jobs = append(jobs, loaded_jobs[i])
+	return RangeJobs(filter, func(job *pb.Job) error {
+		fillJobProgress(job)
+		fillJobTasks(job)
+		if in.GetSummary() {
+			return f(abridgeJob(job))
 		}
+		if path != nil {
+			selectJobResults(job, path)
+		}
+		return f(job)
+	})
+}
+
+// Keep the fields shown in the job table, the command is truncated since it can be huge
+func abridgeJob(job *pb.Job) *pb.Job {
+	command := job.Command
+	if len(command) > jobSummaryCommandSize {
+		command = strings.ToValidUTF8(command[:jobSummaryCommandSize], "") + "..."
 	}
-	for _, job := range jobs {
-		done, all := 0, len(job.Nodes)
-		if job.State == pb.JobState_Running {
-			if job_on_nodes, ok := Jobs.Load(job.Id); !ok {
-				continue
-			} else {
-				job_on_nodes.(*sync.Map).Range(func(k, v interface{}) bool {
-					if v.(jobOnNode).state > pb.JobState_Running {
-						done += 1
-					}
-					return true
-				})
+	return &pb.Job{Id: job.Id, Name: job.Name, State: job.State, Progress: job.Progress, CreateTime: job.CreateTime, EndTime: job.EndTime, Command: command}
+}
+
+func fillJobProgress(job *pb.Job) {
+	done, all := 0, len(job.Nodes)
+	if job.State == pb.JobState_Running {
+		job_on_nodes, ok := Jobs.Load(job.Id)
+		if !ok {
+			return
+		}
+		job_on_nodes.(*sync.Map).Range(func(k, v interface{}) bool {
+			if v.(jobOnNode).state > pb.JobState_Running {
+				done += 1
+			}
+			return true
+		})
+	} else if job.State > pb.JobState_Running && job.State != pb.JobState_Waiting {
+		done = all
+	}
+	job.Progress = fmt.Sprintf("%v/%v", done, all)
+}
+
+func selectJobResults(job *pb.Job, path []interface{}) {
+	for node, result := range job.Results {
+		if value, err := selectJsonResult(result, path); err != nil {
+			delete(job.Results, node)
+			if job.ResultErrors == nil {
+				job.ResultErrors = map[string]string{}
 			}
-		} else if job.State > pb.JobState_Running {
-			done = all
+			job.ResultErrors[node] = err.Error()
+		} else {
+			job.Results[node] = value
 		}
-		job.Progress = fmt.Sprintf("%v/%v", done, all)
 	}
-	LogInfo("GetJobs result:%v%v", LineEnding, jobs)
-	return &pb.GetJobsReply{Jobs: jobs}, nil
 }
 
 func (s *headnode_server) StartClusJob(in *pb.StartClusJobRequest, out pb.Headnode_StartClusJobServer) error {
 	defer LogPanicBeforeExit()
-	command, arguments, specifiedNodes, pattern, groups, intersect, sweep, name :=
-		in.GetCommand(), in.GetArguments(), in.GetNodes(), in.GetPattern(), in.GetGroups(), in.GetGroupsIntersect(), in.GetSweep(), in.GetName()
-	LogInfo("Creating new job with command: %v", command)
+	logger := LogContext(out.Context())
+	command, arguments, specifiedNodes, pattern, groups, intersect, sweep, name, max_reschedules, checkpoint, ship_checkpoint :=
+		in.GetCommand(), in.GetArguments(), in.GetNodes(), in.GetPattern(), in.GetGroups(), in.GetGroupsIntersect(), in.GetSweep(), in.GetName(), in.GetMaxReschedules(), in.GetCheckpoint(), in.GetShipCheckpoint()
+	bandwidth_limit_kb, working_dir, run_as, node_commands, json_output, dispatch_order := in.GetBandwidthLimitKb(), in.GetWorkingDir(), in.GetRunAs(), in.GetNodeCommands(), in.GetJsonOutput(), in.GetDispatchOrder()
+	capture_env, requirements, limits, env_mode, output_window, rolling, fail_fast := in.GetCaptureEnv(), normalizeRequirements(in.GetRequirements()), in.GetLimits(), in.GetEnvMode(), in.GetOutputWindow(), in.GetRolling(), in.GetFailFast()
+	after, forward_stdin, labels, where, shell, script_name, exclude, dry_run := in.GetAfter(), in.GetForwardStdin(), in.GetLabels(), in.GetWhere(), in.GetShell(), in.GetScriptName(), in.GetExclude(), in.GetDryRun()
+	rerun_sweep_values, executor := in.GetSweepValues(), in.GetExecutor()
+	end_stream, err := enterJobStream()
+	if err != nil {
+		logger.Warning("Job is not created: %v", status.Convert(err).Message())
+		return err
+	}
+	defer end_stream()
+	if len(in.GetPeers()) > 0 {
+		return s.startFederatedJob(in, out)
+	}
+	cluster, err := getClientCluster(out.Context(), in.GetCluster())
+	if err != nil {
+		return err
+	}
+
+	// Attach to the job created by the request before, e.g. the client retries the request after a network glitch
+	request_id, client := in.GetRequestId(), getClientName(out.Context())
+	if err := validateJobRequestId(request_id); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if jobs, err := LoadJobs(); err == nil && !dry_run {
+		if job := findRequestJob(jobs, request_id, client, time.Now()); job != nil {
+			return s.attachJobRequest(job.Id, request_id, out)
+		}
+	}
+
+	// Limit the jobs of each client, so that a runaway script can not flood the nodes with commands
+	if !dry_run {
+		release_quota, err := acquireClientQuota(out.Context())
+		if err != nil {
+			logger.Warning("Job is not created: %v", status.Convert(err).Message())
+			return err
+		}
+		defer release_quota()
+	}
+	if len(shell) > 0 && !isValidJobShell(shell) {
+		return status.Errorf(codes.InvalidArgument, "Invalid shell %q, should be one of: %v", shell, strings.Join(jobShells, ", "))
+	}
+	if err := validateScriptName(script_name); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validateJobExecutor(executor); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	os_commands, err := normalizeOsCommands(in.GetOsCommands())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(os_commands) > 0 && len(node_commands) > 0 {
+		return status.Error(codes.InvalidArgument, "Per-node commands and per-OS commands can not be specified at the same time")
+	}
+	if len(node_commands) > 0 {
+		logger.Info("Creating new job with commands for %v nodes", len(node_commands))
+		if len(command) > 0 || len(arguments) > 0 {
+			return errors.New("Command and per-node commands can not be specified at the same time")
+		}
+		if len(pattern) > 0 || len(groups) > 0 {
+			return errors.New("Nodes are specified by per-node commands, which can not be filtered by pattern or groups")
+		}
+		specifiedNodes = make([]string, 0, len(node_commands))
+		for node, c := range node_commands {
+			if len(strings.TrimSpace(c)) == 0 {
+				return fmt.Errorf("Empty command for node %v", node)
+			}
+			specifiedNodes = append(specifiedNodes, node)
+		}
+		sort.Strings(specifiedNodes)
+	} else if len(os_commands) > 0 {
+		logger.Info("Creating new job with commands for %v OS and default command: %v", len(os_commands), command)
+	} else {
+		logger.Info("Creating new job with command: %v", command)
+	}
 
 	// Validate groups
 	var invalid_groups []string
@@ -166,101 +449,437 @@ func (s *headnode_server) StartClusJob(in *pb.StartClusJobRequest, out pb.Headno
 		}
 	}
 	if len(invalid_groups) > 0 {
-		LogWarning("Invalid node groups to create job: %v", invalid_groups)
+		logger.Warning("Invalid node groups to create job: %v", invalid_groups)
 		return fmt.Errorf("Invalid groups: %v", invalid_groups)
 	}
 
 	// Get nodes
-	nodes, invalid_nodes := getValidNodes(specifiedNodes, pattern, groups, intersect)
-	sort.Strings(nodes)
+	if err := validateRequirements(requirements); err != nil {
+		return err
+	}
+	if _, err := regexp.Compile(exclude); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid exclude pattern: %v", err)
+	}
+	selectors, err := parseNodeSelectors(labels, where)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	nodes, invalid_nodes, skipped_nodes := getValidNodes(specifiedNodes, pattern, exclude, cluster, groups, intersect, selectors, requirements)
 	sort.Strings(invalid_nodes)
 	if len(invalid_nodes) > 0 {
-		LogWarning("Invalid nodes to create job: %v", invalid_nodes)
+		logger.Warning("Invalid nodes to create job: %v", invalid_nodes)
 		return fmt.Errorf("Invalid nodes: %v", invalid_nodes)
 	}
+	if len(skipped_nodes) > 0 {
+		logger.Info("Skipped %v nodes not satisfying resource requirements, draining or excluded: %v", len(skipped_nodes), skipped_nodes)
+	}
+	if len(node_commands) > 0 {
+		if len(skipped_nodes) > 0 {
+			return fmt.Errorf("Nodes in per-node commands do not satisfy resource requirements: %v", formatSkippedNodes(skipped_nodes))
+		}
+		// The valid nodes are in the same order of specified nodes, unless some of them are the same node
+		if len(nodes) != len(specifiedNodes) {
+			return errors.New("Duplicate nodes in per-node commands")
+		}
+		commands := make(map[string]string, len(nodes))
+		for i, node := range nodes {
+			commands[node] = node_commands[specifiedNodes[i]]
+		}
+		node_commands = commands
+	}
+	job_node_commands := node_commands
+	if len(os_commands) > 0 {
+		commands, skipped := resolveOsCommands(nodes, os_commands, command, getNodeOs)
+		if len(skipped) > 0 {
+			logger.Info("Skipped %v nodes without command for their OS: %v", len(skipped), skipped)
+			if skipped_nodes == nil {
+				skipped_nodes = map[string]string{}
+			}
+			nodes = nodes[:0]
+			for node, reason := range skipped {
+				skipped_nodes[node] = reason
+			}
+			for node := range commands {
+				nodes = append(nodes, node)
+			}
+		}
+		node_commands = commands
+	}
+	sort.Strings(nodes)
 	if len(nodes) == 0 {
 		message := "No valid nodes to create job"
-		LogWarning("%v", message)
+		if len(skipped_nodes) > 0 {
+			message += fmt.Sprintf(", skipped nodes not satisfying resource requirements, draining or excluded: %v", formatSkippedNodes(skipped_nodes))
+		}
+		logger.Warning("%v", message)
 		return errors.New(message)
 	}
 
 	// Parse sweep
-	placeholder, sweepSequence := parseSweep(sweep, len(nodes))
-	if !strings.Contains(command, placeholder) {
-		placeholder_in_args := false
-		for _, a := range arguments {
-			if strings.Contains(a, placeholder) {
-				placeholder_in_args = true
-				break
+	sweeps, err := parseSweeps(sweep, len(nodes))
+	if err != nil {
+		logger.Warning("Invalid sweep: %v", err)
+		return err
+	}
+	for _, s := range sweeps {
+		if !strings.Contains(command, s.placeholder) {
+			placeholder_found := false
+			for _, a := range arguments {
+				if strings.Contains(a, s.placeholder) {
+					placeholder_found = true
+					break
+				}
+			}
+			for _, c := range node_commands {
+				if strings.Contains(c, s.placeholder) {
+					placeholder_found = true
+					break
+				}
+			}
+			if !placeholder_found {
+				msg := fmt.Sprintf("Sweep placeholder %q has wrong format or is not in command and arguments", s.placeholder)
+				logger.Warning("%v", msg)
+				return errors.New(msg)
 			}
 		}
-		if !placeholder_in_args {
-			msg := fmt.Sprintf("Sweep placeholder %q has wrong format or is not in command and arguments", placeholder)
-			LogWarning("%v", msg)
-			return errors.New(msg)
+	}
+	sweep_values := getJobSweepValues(sweeps, nodes)
+	node_sweeps := make(map[string][]sweepParameter, len(rerun_sweep_values))
+	if len(rerun_sweep_values) > 0 {
+		// The nodes rerun with the sweep values they had in the job rerun
+		if len(sweeps) == 0 {
+			return status.Error(codes.InvalidArgument, "Sweep values are specified without sweep")
+		}
+		for _, node := range nodes {
+			values, ok := rerun_sweep_values[node]
+			if !ok {
+				return status.Errorf(codes.InvalidArgument, "No sweep values of node %v", node)
+			}
+			if node_sweeps[node], err = parseSweepValues(values); err != nil {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			sweep_values[node] = values
 		}
 	}
+	get_task_command := func(i int, node string) (string, []string) {
+		if s, ok := node_sweeps[node]; ok {
+			return getTaskCommand(0, node, command, arguments, node_commands, s)
+		}
+		return getTaskCommand(i, node, command, arguments, node_commands, sweeps)
+	}
 
 	// Create job
-	id, err := CreateNewJob(command, sweep, pattern, name, groups, specifiedNodes, nodes, arguments)
-	if err != nil {
-		LogError("Failed to create job: %v", err)
+	if max_reschedules < 0 {
+		return errors.New("Invalid max reschedules")
+	}
+	if err := ValidateCheckpointName(checkpoint); err != nil {
 		return err
 	}
-	if err := out.Send(&pb.StartClusJobReply{JobId: id, Nodes: nodes}); err != nil {
-		LogError("Failed to send job id of job %v to client: %v", id, err)
+	if ship_checkpoint && len(checkpoint) == 0 {
+		return errors.New("Checkpoint name is required to ship checkpoint")
+	}
+	if bandwidth_limit_kb < 0 {
+		return errors.New("Invalid bandwidth limit")
+	}
+	if max := int32(Config_Headnode_MaxJobBandwidthKb.GetInt()); max > 0 && (bandwidth_limit_kb == 0 || bandwidth_limit_kb > max) {
+		bandwidth_limit_kb = max
+	}
+	if limits.GetCpuPercent() < 0 || limits.GetCpuPercent() > 100 || limits.GetMemoryMb() < 0 {
+		return errors.New("Invalid limits, CPU percent should be between 0 and 100 and memory should not be negative")
+	}
+	if err := validateJobPriority(limits); err != nil {
+		return err
+	}
+	if output_window != nil {
+		if output_window.SizeKb <= 0 || output_window.IntervalSecond < 0 {
+			return errors.New("Invalid output window, size should be positive and interval should not be negative")
+		}
+		if json_output {
+			return errors.New("JSON output can not be parsed from the output in window")
+		}
+		if output_window.IntervalSecond == 0 {
+			output_window.IntervalSecond = defaultOutputWindowIntervalSecond
+		}
+	}
+	if rolling.GetBatchSize() < 0 || rolling.GetStopOnFailures() < 0 {
+		return errors.New("Invalid rolling policy, batch size and failures to stop should not be negative")
+	}
+	if fail_fast.GetMaxFailures() < 0 || fail_fast.GetMaxFailurePercent() < 0 || fail_fast.GetMaxFailurePercent() > 100 {
+		return errors.New("Invalid fail fast, max failures should not be negative and max failure percent should be between 0 and 100")
+	}
+	if len(env_mode) > 0 && !isValidEnvMode(env_mode) {
+		return fmt.Errorf("Invalid environment mode %q, should be one of: %v", env_mode, strings.Join(envModes, ", "))
+	}
+	if forward_stdin && (max_reschedules > 0 || rolling.GetBatchSize() > 0) {
+		return errors.New("Input can not be forwarded to a job rescheduled or rolling in batches, which starts on nodes at different time")
+	}
+	if after != nil {
+		if _, err := getClientJob(out.Context(), after.JobId); err != nil {
+			return fmt.Errorf("Invalid dependency: %v", err)
+		}
+	}
+	if len(dispatch_order) == 0 {
+		dispatch_order = Config_Headnode_DispatchOrder.GetString()
+	} else if !isValidDispatchOrder(dispatch_order) {
+		return fmt.Errorf("Invalid dispatch order %q, should be one of: %v", dispatch_order, strings.Join(dispatchOrders, ", "))
+	}
+
+	// Lint the job, which is rejected by the warnings of blocking rules
+	blocking, _ := parseLintRules(Config_Headnode_LintBlockingRules.GetString())
+	warnings, blocked := lintJob(&jobLintInput{
+		Command:        command,
+		Arguments:      arguments,
+		NodeCommands:   node_commands,
+		Sweeps:         sweeps,
+		Nodes:          len(nodes),
+		ShipCheckpoint: ship_checkpoint,
+		MaxReschedules: max_reschedules,
+		FailFast:       fail_fast,
+		Rolling:        rolling,
+	}, blocking)
+	if blocked {
+		msg := "Job is blocked by lint: " + formatLintWarnings(warnings)
+		logger.Warning("%v", msg)
+		return status.Error(codes.FailedPrecondition, msg)
+	} else if len(warnings) > 0 {
+		logger.Info("Lint warnings of new job: %v", formatLintWarnings(warnings))
+	}
+
+	// Check policy before creating job
+	input := &jobPolicyInput{
+		Headnode:       NodeHost,
+		Name:           name,
+		Command:        command,
+		Arguments:      arguments,
+		NodeCommands:   node_commands,
+		Nodes:          nodes,
+		Pattern:        pattern,
+		Cluster:        cluster,
+		Groups:         groups,
+		Sweep:          sweep,
+		WorkingDir:     working_dir,
+		RunAs:          run_as,
+		Checkpoint:     checkpoint,
+		MaxReschedules: max_reschedules,
+	}
+	if p, ok := peer.FromContext(out.Context()); ok {
+		input.Client = p.Addr.String()
+	}
+	if err := checkJobPolicy(input); err != nil {
+		logger.Warning("Job is not created: %v", err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// Reply the nodes and their commands in dry run without creating the job
+	if dry_run {
+		logger.Info("Resolved %v nodes of job in dry run", len(nodes))
+		reply := &pb.StartClusJobReply{Nodes: nodes, SkippedNodes: skipped_nodes, Warnings: warnings, NodeCommands: make(map[string]string, len(nodes)), SweepValues: sweep_values}
+		for i, node := range nodes {
+			reply.NodeCommands[node] = formatTaskCommand(get_task_command(i, node))
+		}
+		return out.Send(reply)
+	}
+	correlation_id := newLogId()
+	options := newJobOptions{
+		cluster:          cluster,
+		specifiedNodes:   specifiedNodes,
+		nodes:            nodes,
+		nodeCommands:     job_node_commands,
+		osCommands:       os_commands,
+		requirements:     requirements,
+		selectors:        selectors,
+		skippedNodes:     skipped_nodes,
+		sweepValues:      sweep_values,
+		bandwidthLimitKb: bandwidth_limit_kb,
+		dispatchOrder:    dispatch_order,
+		correlationId:    correlation_id,
+		client:           client,
+	}
+	id, err := CreateNewJob(in, options)
+	if err == errJobRequestExists {
+		return s.attachJobRequest(id, request_id, out)
+	} else if err != nil {
+		logger.Error("Failed to create job: %v", err)
+		return err
+	}
+	jobCorrelationIds.Store(id, correlation_id)
+	defer jobCorrelationIds.Delete(id)
+	logger = logger.With(logFieldJob, id).With(logFieldCorrelationId, correlation_id)
+	logger.Info("Job %v is created with correlation id %v", id, correlation_id)
+	job_span := startJobTrace(id, correlation_id, len(nodes))
+	defer endJobTrace(id, job_span)
+	if err := out.Send(&pb.StartClusJobReply{JobId: id, Nodes: nodes, SkippedNodes: skipped_nodes, Warnings: warnings, SweepValues: sweep_values}); err != nil {
+		logger.Error("Failed to send job id of job %v to client: %v", id, err)
 		return err
 	}
 
 	// Start job on nodes in the cluster
-	if err := UpdateJobState(id, pb.JobState_Created, pb.JobState_Dispatching); err != nil {
-		LogError("Failed to update state of job %v to %v: %v", id, pb.JobState_Dispatching, err)
+	if after != nil {
+		logger.Info("Job %v is waiting for job %v", id, after.JobId)
+		_, wait_span := StartSpan(getJobTraceContext(id), "job.wait_dependency", SpanKind_Internal)
+		wait_span.SetAttribute("job.after", after.JobId)
+		err := waitJobDependency(id, after)
+		wait_span.SetError(err)
+		wait_span.End()
+		if err != nil {
+			logger.Warning("Job %v is not started: %v", id, err)
+			job_span.SetError(err)
+			return status.Error(codes.Aborted, err.Error())
+		}
+	} else if err := UpdateJobState(id, pb.JobState_Created, pb.JobState_Dispatching); err != nil {
+		logger.Error("Failed to update state of job %v to %v: %v", id, pb.JobState_Dispatching, err)
 	}
+	dispatch_time := time.Now()
 	wg := sync.WaitGroup{}
 	var job_on_nodes sync.Map
 	Jobs.Store(id, &job_on_nodes)
+	startDispatchTree(id, nodes)
+	defer dispatchTrees.Delete(id)
+	timeline := newTaskTimeline(id)
+	sender := newJobOutputSender(out)
+	queue := newDispatchQueue(newDispatchPool(Config_Headnode_MaxParallelDispatch.GetInt()))
+	ordered, batches, batch_count := getDispatchPlan(dispatch_order, nodes, int(rolling.GetBatchSize()))
+	turns := map[string]int{}
+	for i, node := range ordered {
+		turns[node] = i
+	}
+	dispatch_batches := newDispatchBatches(batch_count, batches)
+	stop_progress := func() {}
+	if in.GetProgress() {
+		stop_progress = sendJobProgressPeriodically(&job_on_nodes, len(nodes), sender, jobProgressInterval)
+	}
+	rescheduler := newTaskRescheduler(id, in, options)
+	task := jobTask{
+		request: &pb.StartJobRequest{
+			JobId:           id,
+			Headnode:        NodeHost,
+			Checkpoint:      checkpoint,
+			ShipCheckpoint:  ship_checkpoint,
+			OutputRateLimit: getOutputRateLimit(bandwidth_limit_kb, len(nodes)),
+			OutputLimit:     getOutputLimit(Config_Headnode_MaxNodeOutputMb.GetInt(), Config_Headnode_MaxJobOutputMb.GetInt(), len(nodes)),
+			WorkingDir:      working_dir,
+			RunAs:           run_as,
+			CaptureEnv:      capture_env,
+			Limits:          limits,
+			EnvMode:         env_mode,
+			OutputWindow:    output_window,
+			ForwardStdin:    forward_stdin,
+			Shell:           shell,
+			ScriptName:      script_name,
+			Executor:        executor,
+			Gpus:            requirements.GetMinIdleGpus(),
+		},
+		checkpoint: newTaskCheckpoint(checkpoint, ship_checkpoint),
+		saveOutput: Config_Headnode_StoreOutput.GetBool(),
+		jsonOutput: json_output,
+	}
+	aborter := newJobAborter(id, fail_fast, len(nodes))
 	for i, node := range nodes {
 		wg.Add(1)
-		c := command
-		a := make([]string, len(arguments))
-		copy(a, arguments)
-		if len(sweep) > 0 {
-			s := strconv.Itoa(sweepSequence[i])
-			c = strings.ReplaceAll(command, placeholder, s)
-			for i, v := range arguments {
-				a[i] = strings.ReplaceAll(v, placeholder, s)
+		c, a := get_task_command(i, node)
+		go func(node string, turn *dispatchTurn, batch int) {
+			defer wg.Done()
+			defer dispatch_batches.Done(batch)
+			defer turn.Finish()
+			dispatch_batches.Wait(batch)
+			if aborter.Aborted() {
+				skipTaskOnNode(id, node, &job_on_nodes, sender, "Task is not started since the job is aborted by too many failed nodes")
+				return
 			}
-		}
-		go startJobOnNode(id, c, a, node, &job_on_nodes, out, &wg, Config_Headnode_StoreOutput.GetBool())
+			if stop := rolling.GetStopOnFailures(); stop > 0 {
+				if failures := countFailedTasks(&job_on_nodes); failures >= stop {
+					skipTaskOnNode(id, node, &job_on_nodes, sender, fmt.Sprintf("Task is not started since %v nodes failed, reaching the count %v to stop the rolling job", failures, stop))
+					return
+				}
+			}
+			request := proto.Clone(task.request).(*pb.StartJobRequest)
+			request.Command, request.Arguments = c, a
+			startTaskOnNode(task, request, node, &job_on_nodes, sender, turn, rescheduler)
+			aborter.Check(&job_on_nodes)
+		}(node, queue.Turn(turns[node]), batches[node])
 	}
 	if err := UpdateJobState(id, pb.JobState_Dispatching, pb.JobState_Running); err != nil {
-		LogError("Failed to update state of job %v to %v: %v", id, pb.JobState_Running, err)
+		logger.Error("Failed to update state of job %v to %v: %v", id, pb.JobState_Running, err)
 	}
 	wg.Wait()
+	stop_progress()
+	spans := timeline.Snapshot()
+	summary := summarizeJob(&job_on_nodes, spans, time.Since(dispatch_time))
+	if in.GetSummary() {
+		_ = sender.Send(&pb.StartClusJobReply{Summary: summary})
+	}
+	sender.Close()
 
 	// Update job in DB
 	failedNodes := map[string]int32{}
+	timed_out_nodes := []string{}
+	truncated_nodes := map[string]int64{}
+	results, result_errors := map[string]string{}, map[string]string{}
 	job_on_nodes.Range(func(key interface{}, val interface{}) bool {
 		nodename := key.(string)
 		j := val.(jobOnNode)
-		if j.state == pb.JobState_Failed {
+		if j.truncated > 0 {
+			truncated_nodes[nodename] = j.truncated
+		}
+		if j.state == pb.JobState_Failed && !j.rescheduled {
 			failedNodes[nodename] = j.exitCode
+			if j.timedOut {
+				timed_out_nodes = append(timed_out_nodes, nodename)
+			}
+		}
+		if len(j.result) > 0 {
+			results[nodename] = j.result
+		} else if len(j.resultError) > 0 {
+			result_errors[nodename] = j.resultError
 		}
 		return true
 	})
+	if json_output {
+		UpdateJobResults(id, results, result_errors)
+	}
+	UpdateJobTimeline(id, spans)
+	UpdateJobSummary(id, summary)
+	if len(timed_out_nodes) > 0 {
+		sort.Strings(timed_out_nodes)
+		UpdateJobTimedOutNodes(id, timed_out_nodes)
+	}
+	if len(truncated_nodes) > 0 {
+		UpdateJobTruncatedNodes(id, truncated_nodes)
+	}
+	job_span.SetAttribute("job.failed_nodes", len(failedNodes))
 	if len(failedNodes) > 0 {
-		UpdateFailedJob(id, failedNodes)
+		if clusters := analyzeJobFailures(id, &job_on_nodes); len(clusters) > 0 {
+			UpdateJobFailureClusters(id, clusters)
+		}
+		UpdateFailedJob(id, failedNodes, aborter.Aborted())
 	} else {
 		UpdateFinishedJob(id)
 	}
+	IndexJobOutput(id)
 	Jobs.Delete(id)
+	jobTimelines.Delete(id)
+	restartedTasks.Delete(id)
 	return nil
 }
 
 func (s *headnode_server) CancelClusJobs(ctx context.Context, in *pb.CancelClusJobsRequest) (*pb.CancelClusJobsReply, error) {
 	defer LogPanicBeforeExit()
-	job_ids := in.GetJobIds()
-	result, to_cancel, err := CancelJobs(job_ids)
+	job_ids, cluster := in.GetJobIds(), getAuthCluster(ctx)
+	if delay := Config_Headnode_CancelDelaySecond.GetInt(); delay > 0 {
+		result, active, err := SelectJobsToCancel(job_ids, cluster)
+		if err != nil {
+			LogError("Failed to select jobs to cancel: %v", err)
+			return nil, err
+		}
+		reply := &pb.CancelClusJobsReply{Result: result}
+		if len(active) > 0 {
+			reply.DelayedJobIds, reply.DelaySecond = active, int32(delay)
+			reply.UndoId = delayCancelJobs(active, time.Duration(delay)*time.Second)
+		}
+		LogInfo("CancelClusJobs result: %v, delayed %v seconds: %v", result, delay, active)
+		return reply, nil
+	}
+	result, to_cancel, err := CancelJobs(job_ids, cluster)
 	if err != nil {
 		LogError("Failed to cancel jobs: %v", err)
 		return nil, err
@@ -274,15 +893,25 @@ func (s *headnode_server) CancelClusJobs(ctx context.Context, in *pb.CancelClusJ
 
 func (s *headnode_server) SetConfigs(ctx context.Context, in *pb.SetConfigsRequest) (*pb.SetConfigsReply, error) {
 	defer LogPanicBeforeExit()
-	configs := in.GetConfigs()
-	results := SetNodeConfigs(Config_Headnode, configs)
+	configs, dry_run, rollback := in.GetConfigs(), in.GetDryRun(), in.GetRollback()
+	if nodes := in.GetNodes(); len(nodes) > 0 {
+		if err := validatePushConfigs(configs); err != nil && !rollback {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return &pb.SetConfigsReply{NodeResults: propagateConfigs(configs, nodes, dry_run, rollback)}, nil
+	}
+	configs, err := getRequestedConfigs(Config_Headnode, configs, rollback)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	results := SetNodeConfigs(Config_Headnode, configs, dry_run)
 	return &pb.SetConfigsReply{Results: results}, nil
 }
 
 func (s *headnode_server) GetConfigs(ctx context.Context, in *pb.Empty) (*pb.GetConfigsReply, error) {
 	defer LogPanicBeforeExit()
 	results := GetNodeConfigs(Config_Headnode)
-	return &pb.GetConfigsReply{Configs: results}, nil
+	return &pb.GetConfigsReply{Configs: results, Sensitive: getSensitiveConfigs(Config_Headnode)}, nil
 }
 
 func (s *headnode_server) SetNodeGroups(ctx context.Context, in *pb.SetNodeGroupsRequest) (*pb.Empty, error) {
@@ -333,6 +962,70 @@ func (s *headnode_server) SetNodeGroups(ctx context.Context, in *pb.SetNodeGroup
 	return &pb.Empty{}, nil
 }
 
+func (s *headnode_server) GetCapabilities(ctx context.Context, in *pb.Empty) (*pb.GetCapabilitiesReply, error) {
+	defer LogPanicBeforeExit()
+	capabilities := GetCapabilities()
+	LogSampled("GetCapabilities", "returned %v capabilities", len(capabilities))
+	LogDebug("GetCapabilities result: %v", capabilities)
+	return &pb.GetCapabilitiesReply{Capabilities: capabilities}, nil
+}
+
+func (s *headnode_server) GetClusterSummary(ctx context.Context, in *pb.Empty) (*pb.GetClusterSummaryReply, error) {
+	defer LogPanicBeforeExit()
+	reply := &pb.GetClusterSummaryReply{
+		NodeStates:  map[string]int32{},
+		NodeGroups:  map[string]int32{},
+		Uptime:      int64(time.Since(StartTime).Seconds()),
+		StdoutBytes: atomic.LoadInt64(&receivedStdoutBytes),
+		StderrBytes: atomic.LoadInt64(&receivedStderrBytes),
+	}
+	for _, record := range ReportedNodes.Snapshot() {
+		reply.Nodes++
+		reply.NodeStates[getNodeState(record.name, record.reportedTime).String()]++
+	}
+	NodeGroups.Range(func(k, v interface{}) bool {
+		var count int32
+		v.(*sync.Map).Range(func(node, _ interface{}) bool {
+			if _, ok := ReportedNodes.LastReport(node.(string)); ok {
+				count++
+			}
+			return true
+		})
+		reply.NodeGroups[k.(string)] = count
+		return true
+	})
+	jobs, err := LoadJobs()
+	if err != nil {
+		LogError("Failed to load jobs: %v", err)
+		return nil, err
+	}
+	for _, job := range jobs {
+		switch job.State {
+		case pb.JobState_Created, pb.JobState_Dispatching, pb.JobState_Waiting:
+			reply.QueuedJobs++
+		case pb.JobState_Running, pb.JobState_Canceling:
+			reply.RunningJobs++
+		}
+	}
+	LogSampled("GetClusterSummary", "returned %v nodes, %v queued jobs and %v running jobs", reply.Nodes, reply.QueuedJobs, reply.RunningJobs)
+	LogDebug("GetClusterSummary result: %v", reply)
+	return reply, nil
+}
+
+func GetCapabilities() map[string]bool {
+	return map[string]bool{
+		Capability_Tls:         Tls.Enabled,
+		Capability_Auth:        len(Config_Headnode_AuthTokens.GetString()) > 0 || isAuthProviderEnabled(),
+		Capability_FileStaging: true,
+		Capability_Containers:  true,
+		Capability_Groups:      true,
+		Capability_StoreOutput: Config_Headnode_StoreOutput.GetBool(),
+		Capability_GrpcWeb:     Config_Headnode_GrpcWebPort.GetInt() > 0,
+		Capability_ControlPort: Config_Headnode_ControlPort.GetInt() > 0,
+		Capability_Federation:  len(Config_Headnode_PeerHeadnodes.GetString()) > 0,
+	}
+}
+
 func getNodesInGroups(groups []string, intersect bool) map[string]bool {
 	candidates := map[string]bool{}
 	if intersect {
@@ -371,74 +1064,136 @@ func getNodesInGroups(groups []string, intersect bool) map[string]bool {
 	return candidates
 }
 
-func validate(display_name, nodename, host string) {
-	if number, ok := validateNumber.LoadOrStore(display_name, 0); !ok || number.(int) > 0 {
-		number := number.(int)
-		if ok { // validate immediately in the first time, otherwise double validating interval after every failure
-			validateNumber.Store(display_name, 0) // value 0 means validation is ongoing
-			delay := math.Pow(2, float64(number))
-			if delay > 60 {
-				delay = 60
-			}
-			time.Sleep(time.Duration(delay) * time.Second)
-		}
-		LogInfo("Start validating clusnode %v", display_name)
+// Enroll the clusnode with a new key if it doesn't have one, the reply should be signed by the key
+func getReportedHeadnode(node string) string {
+	if headnode, ok := reportedTo.Load(node); ok {
+		return headnode.(string)
+	}
+	return ""
+}
 
-		// Setup connection
-		conn, cancel := ConnectNode(host)
-		defer cancel()
-		if conn == nil {
-			LogError("Failed to validate %v", host)
-			validateNumber.Store(display_name, number+1)
-			return
+func validate(display_name, nodename, host, reported_headnode string) {
+	if number, first, started := ReportedNodes.StartValidation(display_name); started {
+		if !first { // validate immediately in the first time, otherwise double validating interval after every failure
+			time.Sleep(getNodeBackoff().delay(number))
 		}
-		defer conn.Close()
-		c := pb.NewClusnodeClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		defer cancel()
+		_ = validateNode(display_name, nodename, host, reported_headnode, number)
+	}
+}
+
+// Validate the clusnode immediately and record the result, the number is the count of failures before
+func validateNode(display_name, nodename, host, reported_headnode string, number int) error {
+	LogInfo("Start validating clusnode %v", display_name)
 
-		// Validate clusnode
-		reply, err := c.Validate(ctx, &pb.ValidateRequest{Headnode: NodeHost, Clusnode: host})
-		name := strings.ToUpper(reply.GetNodename())
+	// Setup connection
+	conn, release := GetNodeConnection(host, connectionLane_Control)
+	if conn == nil {
+		LogError("Failed to validate %v", host)
+		ReportedNodes.SetValidateNumber(display_name, number+1)
+		return fmt.Errorf("Failed to connect %v", host)
+	}
+	defer release()
+	c := pb.NewClusnodeClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Get the key of clusnode and a fresh nonce to be signed with it
+	nonce, err := newNodeNonce()
+	if err != nil {
+		LogError("Failed to generate nonce for %v: %v", display_name, err)
+		ReportedNodes.SetValidateNumber(display_name, number+1)
+		return fmt.Errorf("Failed to generate nonce: %v", err)
+	}
+	request := &pb.ValidateRequest{Headnode: NodeHost, Clusnode: host, ReportedHeadnode: reported_headnode, Nonce: nonce, Build: getNodeBuild(), HeartbeatIntervalSecond: int32(Config_Headnode_NodeHeartbeatIntervalSecond.GetInt())}
+	var key []byte
+	if k, ok := NodeKeys.Load(display_name); ok {
+		key = k.([]byte)
+	} else {
+		k, err := newNodeKey()
 		if err != nil {
-			LogError("Validation failed: %v", err)
-			validateNumber.Store(display_name, number+1)
-		} else if name != nodename { // in case a clusnode is started with a wrong but reachable host
-			LogError("Validation failed: expect nodename %v, replied nodename %v", nodename, name)
-			validateNumber.Store(display_name, 10)
-		} else {
-			LogInfo("Clusnode %v is validated that being hosted by %v", display_name, host)
-			validateNumber.Store(display_name, -1)
+			LogError("Failed to generate key for %v: %v", display_name, err)
+			ReportedNodes.SetValidateNumber(display_name, number+1)
+			return fmt.Errorf("Failed to generate key: %v", err)
+		}
+		key, request.Key = k, k
+		if secret := Config_ClusterSecret.GetString(); len(secret) > 0 {
+			request.KeySignature = signEnrollKey(secret, k, nonce, NodeHost, host)
 		}
 	}
+
+	// Validate clusnode
+	reply, err := c.Validate(ctx, request)
+	name := canonicalNodename(reply.GetNodename())
+	if err != nil {
+		LogError("Validation failed: %v", err)
+		ReportedNodes.SetValidateNumber(display_name, number+1)
+		return fmt.Errorf("Validation failed: %v", status.Convert(err).Message())
+	} else if name != nodename { // in case a clusnode is started with a wrong but reachable host
+		LogError("Validation failed: expect nodename %v, replied nodename %v", nodename, name)
+		ReportedNodes.SetValidateNumber(display_name, 10)
+		return fmt.Errorf("Validation failed: replied nodename %v", name)
+	} else if !verifyValidateReply(key, nonce, NodeHost, host, reply.GetNodename(), reply.GetSignature()) {
+		LogError("Validation failed: invalid signature from %v", display_name)
+		ReportedNodes.SetValidateNumber(display_name, 10)
+		return errors.New("Validation failed: invalid signature")
+	} else if secret := Config_ClusterSecret.GetString(); len(secret) > 0 && !verifyValidateSecret(secret, nonce, NodeHost, host, reply.GetNodename(), reply.GetSecretSignature()) {
+		// Checked before enrolling, in case a host reports as a node without key
+		LogError("Validation failed: invalid cluster secret signature from %v hosted by %v", display_name, host)
+		ReportedNodes.SetValidateNumber(display_name, 10)
+		return errors.New("Validation failed: invalid cluster secret signature")
+	}
+	if build := reply.GetBuild(); build != nil {
+		storeNodeBuild(display_name, build)
+	}
+	if system := reply.GetSystem(); system != nil {
+		storeNodeSystem(display_name, system)
+	}
+	if _, err := checkNodeBuild(reply.GetBuild(), Config_Headnode_RequireSameVersion.GetBool()); err != nil {
+		LogError("Validation failed: incompatible clusnode %v: %v", display_name, err)
+		ReportedNodes.SetValidateNumber(display_name, number+1)
+		return fmt.Errorf("Validation failed: incompatible clusnode: %v", err)
+	}
+	if request.Key != nil {
+		enrollNode(display_name, request.Key)
+	}
+	LogInfo("Clusnode %v is validated that being hosted by %v", display_name, host)
+	ReportedNodes.SetValidated(display_name)
+	MarkNodesChanged()
+	return nil
 }
 
-func getValidNodes(nodes []string, pattern string, groups []string, intersect bool) ([]string, []string) {
+// Get the valid nodes specified or matched in the cluster if it is not empty and satisfying the resource requirements, the invalid nodes in specified nodes, and the skipped nodes not satisfying the requirements or draining with reasons
+func getValidNodes(nodes []string, pattern, exclude, cluster string, groups []string, intersect bool, selectors []labelSelector, requirements *pb.ResourceRequirements) ([]string, []string, map[string]string) {
 	candidates := getNodesInGroups(groups, intersect)
+	clusters := parseNodeClustersOrEmpty()
 	ready_nodes := map[string]string{}
 	valid_nodes := []string{}
-	reportedTime.Range(func(key interface{}, val interface{}) bool {
-		node := key.(string)
-		last_report := val.(time.Time)
-		if number, ok := validateNumber.Load(node); ok && number.(int) < 0 && !heartbeatTimeout(last_report) {
+	for _, record := range ReportedNodes.Snapshot() {
+		node, last_report := record.name, record.reportedTime
+		if record.validated() && !nodeLost(node, last_report) && !isNodeClockSkewError(node) {
 			if _, ok := candidates[node]; len(groups) > 0 && !ok {
-				return true
+				continue
 			}
 			if matched, _ := regexp.MatchString(pattern, node); !matched {
-				return true
+				continue
+			}
+			if len(cluster) > 0 && getNodeCluster(clusters, node) != cluster {
+				continue
+			}
+			if !matchLabelSelectors(getNodeLabels(node), selectors) || !matchProbeSelectors(getNodeProbes(node), selectors) {
+				continue
 			}
 			ready_nodes[node] = node
 			ready_nodes[parseHost(node)] = node
 			valid_nodes = append(valid_nodes, node)
 		}
-		return true
-	})
+	}
 	invalid_nodes := []string{}
 	if len(nodes) > 0 {
 		valid_nodes = []string{}
 		added := map[string]bool{}
 		for _, node := range nodes {
-			if valid_node, ok := ready_nodes[strings.ToUpper(node)]; ok {
+			if valid_node, ok := ready_nodes[canonicalNodeId(node)]; ok {
 				if _, ok := added[valid_node]; !ok {
 					valid_nodes = append(valid_nodes, valid_node)
 					added[valid_node] = true
@@ -448,115 +1203,321 @@ func getValidNodes(nodes []string, pattern string, groups []string, intersect bo
 			}
 		}
 	}
-	return valid_nodes, invalid_nodes
+	valid_nodes, skipped_nodes := filterNodesByRequirements(valid_nodes, requirements)
+	valid_nodes, skipped_nodes = filterExcludedNodes(valid_nodes, exclude, skipped_nodes)
+	valid_nodes, skipped_nodes = filterDrainingNodes(valid_nodes, skipped_nodes)
+	return valid_nodes, invalid_nodes, skipped_nodes
 }
 
 func parseHost(display_name string) string {
-	segs := strings.Split(display_name, "(")
-	if len(segs) <= 1 {
-		return display_name + ":" + DefaultPort
-	} else {
-		return segs[1][:len(segs[1])-1]
+	if host, ok := nodeHosts.Load(display_name); ok {
+		return host.(string)
 	}
+	if _, host := splitNodeDisplayName(display_name); len(host) > 0 {
+		return host
+	}
+	return display_name + ":" + DefaultPort
 }
 
-func startJobOnNode(id int32, command string, args []string, node string, job_on_nodes *sync.Map, out pb.Headnode_StartClusJobServer, wg *sync.WaitGroup, save_output bool) {
-	defer wg.Done()
-	LogInfo("Start job %v on node %v", id, node)
+// The task of a job started on each node, the request is copied for each node with its command and arguments
+type jobTask struct {
+	request    *pb.StartJobRequest
+	checkpoint *taskCheckpoint
+	saveOutput bool
+	jsonOutput bool
+}
+
+// Start the task on the node by the request, in which the command, arguments and checkpoint data are of the node.
+// Return true if the node is lost before the job finishes on it
+func startJobOnNode(task jobTask, request *pb.StartJobRequest, node string, job_on_nodes *sync.Map, out jobReplySender, pool dispatcher) (lost bool) {
+	id, checkpoint, save_output, json_output := request.GetJobId(), task.checkpoint, task.saveOutput, task.jsonOutput
+	logger := getJobLogger(id).With(logFieldNode, node)
+	logger.Info("Start job %v on node %v", id, node)
+	span, update_span := addTaskSpan(id, node)
+	node_ctx, node_span := StartSpan(getJobTraceContext(id), "job.node", SpanKind_Internal)
+	node_span.SetAttribute(logFieldNode, node)
+	defer func() {
+		j, ok := job_on_nodes.Load(node)
+		update_span(func() {
+			span.EndTime, span.Lost = time.Now().UnixNano(), lost
+			if ok && !lost {
+				span.ExitCode, span.TimedOut = j.(jobOnNode).exitCode, j.(jobOnNode).timedOut
+			}
+			recordTaskOutputBytes(span)
+		})
+		node_span.SetAttribute("node.lost", lost)
+		if ok && !lost {
+			node_span.SetAttribute("node.exit_code", j.(jobOnNode).exitCode)
+		}
+		node_span.End()
+	}()
 
 	var f_out, f_err *os.File
 	if save_output {
 		// Create file to save output
-		stdout, stderr := GetOutputFile(id, node)
-		var err error
+		job, err := GetJob(id)
+		if err != nil {
+			job = &pb.Job{Id: id}
+		}
+		stdout, stderr := GetOutputFile(job, node)
 		if f_out, err = os.Create(stdout); err == nil {
 			f_err, err = os.Create(stderr)
 		}
 		if err != nil {
-			LogError("Failed to create output file for job %v node %v: %v", id, node, err)
+			logger.Error("Failed to create output file for job %v node %v: %v", id, node, err)
+			job_on_nodes.Store(node, jobOnNode{state: pb.JobState_Failed, exitCode: -1})
+			_ = out.Send(&pb.StartClusJobReply{Node: node, ExitCode: -1})
 			return
 		}
+		defer storeOutputFiles(stdout, stderr)
 		defer f_out.Close()
 		defer f_err.Close()
 	}
 	job_on_nodes.Store(node, jobOnNode{state: pb.JobState_Dispatching})
 
-	// Setup connection
-	conn, cancel := ConnectNode(parseHost(node))
-	defer cancel()
+	// Setup connection, the task is dispatched directly if the first relay in the path is not connected
+	_, dispatch_span := StartSpan(node_ctx, "job.node.dispatch", SpanKind_Client)
+	pool.Acquire()
+	path := getDispatchPath(id, node)
+	conn, release := GetNodeConnection(path[0], connectionLane_Output)
+	if conn == nil && len(path) > 1 {
+		logger.Warning("Failed to connect relay %v of job %v, dispatch to node %v directly", path[0], id, node)
+		path = []string{parseHost(node)}
+		conn, release = GetNodeConnection(path[0], connectionLane_Output)
+	}
+	for i, retries := 0, Config_Headnode_ConnectRetries.GetInt(); conn == nil && i < retries; i++ {
+		wait := getConnectBackoff(path[0])
+		logger.Warning("Retry connecting node %v of job %v in %v (%v/%v)", node, id, wait.Round(time.Millisecond), i+1, retries)
+		time.Sleep(wait)
+		conn, release = GetNodeConnection(path[0], connectionLane_Output)
+	}
 	if conn == nil {
-		LogError("Failed to start job %v on node %v", id, node)
-		return
+		pool.Release()
+		logger.Error("Failed to start job %v on node %v", id, node)
+		dispatch_span.SetError(errors.New("Failed to connect node"))
+		dispatch_span.End()
+		return true
 	}
-	defer conn.Close()
-	c := pb.NewClusnodeClient(conn)
-	ctx, cancel := context.WithCancel(context.Background())
+	defer func() { release() }() // the connection is replaced if the stream is resumed
+	ctx, cancel := context.WithCancel(withJobCorrelationId(node_ctx, id))
 	defer cancel()
 
 	// Start job on clusnode
-	stream, err := c.StartJob(ctx, &pb.StartJobRequest{JobId: id, Command: command, Arguments: args, Headnode: NodeHost})
+	request.ReportedHeadnode = getReportedHeadnode(node)
+	stream, err := startTaskStream(ctx, pb.NewClusnodeClient(conn), request, path, getRelaySignatures(path, request))
+	pool.Release()
+	dispatch_span.SetError(err)
+	dispatch_span.End()
 	if err != nil {
-		LogError("Failed to start job %v on node %v: %v", id, node, err)
-		job_on_nodes.Store(node, jobOnNode{state: pb.JobState_Failed})
-		return
+		logger.Error("Failed to start job %v on node %v: %v", id, node, err)
+		return true
 	} else {
 		job_on_nodes.Store(node, jobOnNode{state: pb.JobState_Running})
 	}
+	start_time := time.Now()
+	update_span(func() { span.StartTime = start_time.UnixNano() })
+	_, output_span := StartSpan(node_ctx, "job.node.output", SpanKind_Internal)
+	defer output_span.End()
 
 	// Save and redirect output
 	var exit_code int32 = -1
+	var timed_out bool
+	var truncated int64
+	var expected_checksum *pb.OutputChecksum
+	received := newOutputChecksum()
 	failing_to_redirect := false
+	var json_out strings.Builder
+	json_out_exceeded := false
+	max_json_out := Config_Headnode_OutputMaxSingleSizeKb.GetInt() << 10
+	var stderr_tail string
+	var stdout_sequence, stderr_sequence int64
+	resumed, resume_retries := false, jobStreamResumeRetries
 	for {
 		output, err := stream.Recv()
+		if err != nil {
+			output_span.SetAttribute("output.stdout_bytes", received.stdoutSize)
+			output_span.SetAttribute("output.stderr_bytes", received.stderrSize)
+			update_span(func() { span.StdoutBytes, span.StderrBytes = received.stdoutSize, received.stderrSize })
+		}
 		if err == io.EOF {
-			LogInfo("Job %v on node %v finished with exit code %v", id, node, exit_code)
-			if err := out.Send(&pb.StartClusJobReply{Node: node, ExitCode: exit_code}); err != nil {
-				LogWarning("Failed to redirect exit code of job %v on node %v: %v", id, node, err)
+			if timed_out {
+				logger.Warning("Job %v on node %v is timed out with exit code %v", id, node, exit_code)
+			} else {
+				logger.Info("Job %v on node %v finished with exit code %v", id, node, exit_code)
+			}
+			lastTaskDuration.Store(node, time.Since(start_time))
+			if expected_checksum != nil {
+				verifyTaskOutput(id, node, expected_checksum, received, f_out, f_err, func(checksum *pb.OutputChecksum, checksum_error string) {
+					update_span(func() { span.Checksum, span.ChecksumError = checksum, checksum_error })
+					if len(checksum_error) > 0 {
+						output_span.SetError(errors.New(checksum_error))
+					}
+				})
+			}
+			if err := out.Send(&pb.StartClusJobReply{Node: node, ExitCode: exit_code, TimedOut: timed_out}); err != nil {
+				logger.Warning("Failed to redirect exit code of job %v on node %v: %v", id, node, err)
 			}
 			break
 		}
 		if err != nil {
-			LogError("Failed to receive output of job %v on node %v: %v", id, node, err)
-			return
+			// The stream interrupted by a transient network failure is resumed from the output received
+			lost := isNodeLost(err)
+			if lost && resume_retries > 0 {
+				logger.Warning("Output stream of job %v on node %v is interrupted, try to resume it: %v", id, node, err)
+				if resumed_stream, resumed_release := resumeJobOnNode(ctx, id, node, received.stdoutSize, received.stderrSize, &resume_retries); resumed_stream != nil {
+					release()
+					stream, release, resumed = resumed_stream, resumed_release, true
+					continue
+				}
+			} else if resumed && isResumeRejected(err) {
+				// The task relayed is not started on the node if nothing is received before the relay fails
+				if relayed := len(path) > 1; relayed && received.stdoutSize+received.stderrSize == 0 && status.Code(err) == codes.NotFound {
+					logger.Warning("Job %v is not relayed to node %v, dispatch to it directly", id, node)
+					path = []string{parseHost(node)}
+					if conn, direct_release := GetNodeConnection(path[0], connectionLane_Output); conn != nil {
+						if direct_stream, err := startTaskStream(ctx, pb.NewClusnodeClient(conn), request, path, nil); err == nil {
+							release()
+							stream, release, resumed = direct_stream, direct_release, false
+							continue
+						}
+						direct_release()
+					}
+				}
+				lost = true
+			}
+			logger.Error("Failed to receive output of job %v on node %v: %v", id, node, err)
+			output_span.SetError(err)
+			if lost {
+				if !waitTaskRestartReport(id, node) {
+					return true
+				}
+				err = status.Error(codes.Aborted, taskLostByRestart)
+			}
+			job_on_nodes.Store(node, jobOnNode{state: pb.JobState_Failed, exitCode: -1, stderr: keepOutputTail(stderr_tail+status.Convert(err).Message(), failureStderrTailSize)})
+			recordNodeJobStats(node, id, false, time.Since(start_time))
+			if err := out.Send(&pb.StartClusJobReply{Node: node, Stderr: []byte(status.Convert(err).Message() + LineEnding)}); err != nil {
+				logger.Warning("Failed to redirect error of job %v on node %v: %v", id, node, err)
+			}
+			if err := out.Send(&pb.StartClusJobReply{Node: node, ExitCode: -1}); err != nil {
+				logger.Warning("Failed to redirect exit code of job %v on node %v: %v", id, node, err)
+			}
+			return false
 		} else {
+			resume_retries = jobStreamResumeRetries
+			if data := output.GetCheckpointData(); len(data) > 0 && checkpoint.ship {
+				checkpoint.snapshots.Store(node, data)
+			}
+			if environment := output.GetEnvironment(); environment != nil {
+				update_span(func() { span.Environment = environment })
+			}
+			if checksum := output.GetChecksum(); checksum != nil {
+				expected_checksum = checksum
+			}
+			// The output already received is dropped, each stream is kept in the order of its sequence numbers
 			stdout, stderr := output.GetStdout(), output.GetStderr()
-			if stdout != "" {
+			if !acceptOutputSequence(&stdout_sequence, output.GetStdoutSequence()) {
+				logger.Warning("Drop stdout of job %v on node %v with sequence %v out of order", id, node, output.GetStdoutSequence())
+				stdout = nil
+			}
+			if !acceptOutputSequence(&stderr_sequence, output.GetStderrSequence()) {
+				logger.Warning("Drop stderr of job %v on node %v with sequence %v out of order", id, node, output.GetStderrSequence())
+				stderr = nil
+			}
+			received.Add(stdout, stderr)
+			if len(stdout) > 0 {
+				if json_output && !json_out_exceeded {
+					if json_out.Len()+len(stdout) > max_json_out {
+						json_out_exceeded = true
+					} else {
+						json_out.Write(stdout)
+					}
+				}
 				if save_output {
-					if _, err := f_out.WriteString(stdout); err != nil {
-						LogError("Failed to save stdout of job %v on node %v: %v", id, node, err)
+					if _, err := f_out.Write(stdout); err != nil {
+						logger.Error("Failed to save stdout of job %v on node %v: %v", id, node, err)
 					}
 				}
 				if err := out.Send(&pb.StartClusJobReply{Node: node, Stdout: stdout}); err != nil {
 					if !failing_to_redirect {
-						LogWarning("Failed to redirect stdout of job %v on node %v: %v", id, node, err)
+						logger.Warning("Failed to redirect stdout of job %v on node %v: %v", id, node, err)
 					}
 					failing_to_redirect = true
 				} else {
 					failing_to_redirect = false
 				}
 			}
-			if stderr != "" {
+			if len(stderr) > 0 {
+				stderr_tail = keepOutputTail(stderr_tail+string(stderr), failureStderrTailSize)
 				if save_output {
-					if _, err := f_err.WriteString(stderr); err != nil {
-						LogError("Failed to save stderr of job %v on node %v: %v", id, node, err)
+					if _, err := f_err.Write(stderr); err != nil {
+						logger.Error("Failed to save stderr of job %v on node %v: %v", id, node, err)
 					}
 				}
 				if err := out.Send(&pb.StartClusJobReply{Node: node, Stderr: stderr}); err != nil {
 					if !failing_to_redirect {
-						LogWarning("Failed to redirect stderr of job %v on node %v: %v", id, node, err)
+						logger.Warning("Failed to redirect stderr of job %v on node %v: %v", id, node, err)
 					}
 					failing_to_redirect = true
 				} else {
 					failing_to_redirect = false
 				}
 			}
-			exit_code = output.GetExitCode()
+			exit_code, timed_out, truncated = output.GetExitCode(), output.GetTimedOut(), output.GetTruncatedSize()
+		}
+	}
+	j := jobOnNode{state: pb.JobState_Finished, truncated: truncated}
+	if exit_code != 0 || timed_out {
+		j = jobOnNode{state: pb.JobState_Failed, exitCode: exit_code, stderr: stderr_tail, timedOut: timed_out, truncated: truncated}
+	}
+	if truncated > 0 {
+		logger.Warning("Output of job %v on node %v is truncated by %v bytes", id, node, truncated)
+	}
+	if json_output {
+		if json_out_exceeded {
+			j.resultError = fmt.Sprintf("Output exceeds %v KB", Config_Headnode_OutputMaxSingleSizeKb.GetInt())
+		} else if result, err := parseJsonResult(json_out.String()); err != nil {
+			j.resultError = err.Error()
+		} else {
+			j.result = result
+		}
+		if len(j.resultError) > 0 {
+			logger.Warning("Failed to parse JSON output of job %v on node %v: %v", id, node, j.resultError)
+		}
+	}
+	job_on_nodes.Store(node, j)
+	recordNodeJobStats(node, id, exit_code == 0, time.Since(start_time))
+	return false
+}
+
+// Record the sequence number of the output received, return false if the output is received before. The output without
+// sequence number is from the clusnode not numbering it, which is always accepted.
+func acceptOutputSequence(last *int64, sequence int64) bool {
+	if sequence == 0 {
+		return true
+	}
+	if sequence <= *last {
+		return false
+	}
+	*last = sequence
+	return true
+}
+
+// Verify the output stored, or received if not stored, against the checksum reported by clusnode, and record the mismatch in the task
+func verifyTaskOutput(id int32, node string, expected *pb.OutputChecksum, received *outputChecksum, f_out, f_err *os.File, record func(*pb.OutputChecksum, string)) {
+	actual := received.Sum()
+	if f_out != nil && f_err != nil {
+		var err error
+		if actual, err = checksumOutputFiles(f_out, f_err); err != nil {
+			LogError("Failed to compute checksum of output of job %v on node %v: %v", id, node, err)
+			record(expected, fmt.Sprintf("Failed to compute checksum of output stored: %v", err))
+			return
 		}
 	}
-	if exit_code == 0 {
-		job_on_nodes.Store(node, jobOnNode{state: pb.JobState_Finished})
+	if err := verifyOutputChecksum(expected, actual); err != nil {
+		LogWarning("Output of job %v on node %v is not intact: %v", id, node, err)
+		record(expected, err.Error())
 	} else {
-		job_on_nodes.Store(node, jobOnNode{state: pb.JobState_Failed, exitCode: exit_code})
+		record(expected, "")
 	}
 }
 
@@ -580,31 +1541,110 @@ func cancelJob(id int32, nodes []string) {
 }
 
 func cancelJobOnNode(id int32, node string, wg *sync.WaitGroup, result *sync.Map) {
+	logger := getJobLogger(id).With(logFieldNode, node)
 	defer wg.Done()
 
 	// Setup connection
-	conn, cancel := ConnectNode(parseHost(node))
-	defer cancel()
+	conn, release := GetNodeConnection(parseHost(node), connectionLane_Control)
 	if conn == nil {
-		LogError("Can not cancel job %v on node %v", id, node)
+		logger.Error("Can not cancel job %v on node %v", id, node)
 		return
 	}
-	defer conn.Close()
+	defer release()
 	c := pb.NewClusnodeClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	trace_ctx, cancel_span := StartSpan(getJobTraceContext(id), "job.node.cancel", SpanKind_Client)
+	cancel_span.SetAttribute(logFieldNode, node)
+	defer cancel_span.End()
+	ctx, cancel := context.WithTimeout(withJobCorrelationId(trace_ctx, id), time.Second)
 	defer cancel()
 
 	// Cancel job on clusnode
 	_, err := c.CancelJob(ctx, &pb.CancelJobRequest{JobId: id, Headnode: NodeHost})
+	cancel_span.SetError(err)
 	if err != nil {
-		LogError("Failed to cancel job %v on node %v: %v", id, node, err)
+		logger.Error("Failed to cancel job %v on node %v: %v", id, node, err)
 	} else {
 		result.Store(node, true)
 	}
 }
 
+// The node is lost if it is not reachable, otherwise the error is from the job on it
+func isNodeLost(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+// Share the bandwidth limit of a job evenly among nodes, return bytes per second for each node or 0 for unlimited
+func getOutputRateLimit(bandwidth_limit_kb int32, nodes int) int64 {
+	if bandwidth_limit_kb <= 0 || nodes <= 0 {
+		return 0
+	}
+	limit := int64(bandwidth_limit_kb) * 1024 / int64(nodes)
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
+
+func getNodeState(nodename string, last_report time.Time) pb.NodeState {
+	if nodeLost(nodename, last_report) {
+		return pb.NodeState_Lost
+	}
+	if ReportedNodes.IsValidated(nodename) && !isNodeClockSkewError(nodename) {
+		if isNodeDraining(nodename) {
+			return pb.NodeState_Draining
+		}
+		return pb.NodeState_Ready
+	}
+	return pb.NodeState_Error
+}
+
 func heartbeatTimeout(last_report time.Time) bool {
-	return time.Since(last_report) > time.Duration(Config_Headnode_HeartbeatTimeoutSecond.GetInt())*time.Second
+	return time.Since(last_report) > getHeartbeatTimeout()
+}
+
+func getHeartbeatTimeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&heartbeatTimeoutNs))
+}
+
+// The heartbeat timeout is scaled to the interval the clusnode heartbeats at, so that a clusnode told to heartbeat less
+// often is not lost between its heartbeats
+func getNodeHeartbeatTimeout(display_name string) time.Duration {
+	timeout := getHeartbeatTimeout()
+	if interval, ok := nodeHeartbeatIntervals.Load(display_name); ok && interval.(time.Duration)*heartbeatTimeoutIntervals > timeout {
+		return interval.(time.Duration) * heartbeatTimeoutIntervals
+	}
+	return timeout
+}
+
+func storeNodeHeartbeatInterval(display_name string, seconds int32) {
+	if seconds <= 0 {
+		nodeHeartbeatIntervals.Delete(display_name) // the clusnode not reporting its interval
+		return
+	}
+	interval := time.Duration(seconds) * time.Second
+	if previous, ok := nodeHeartbeatIntervals.Load(display_name); !ok || previous.(time.Duration) != interval {
+		LogInfo("Heartbeat interval of %v is %v", display_name, interval)
+		nodeHeartbeatIntervals.Store(display_name, interval)
+	}
+}
+
+// Apply the heartbeat timeout to the states of nodes once it is set
+func watchHeartbeatTimeout() {
+	NodeConfigs.Subscribe(func(changes map[*ConfigItem]interface{}) {
+		timeout := time.Duration(changes[&Config_Headnode_HeartbeatTimeoutSecond].(int)) * time.Second
+		if previous := time.Duration(atomic.SwapInt64(&heartbeatTimeoutNs, int64(timeout))); previous != timeout {
+			LogInfo("Heartbeat timeout is changed from %v to %v", previous, timeout)
+		}
+	}, &Config_Headnode_HeartbeatTimeoutSecond)
+	interval := Config_Headnode_NodeHeartbeatIntervalSecond.GetInt()
+	NodeConfigs.Subscribe(func(changes map[*ConfigItem]interface{}) {
+		if seconds := changes[&Config_Headnode_NodeHeartbeatIntervalSecond].(int); seconds != interval {
+			LogInfo("Heartbeat interval of clusnodes is changed from %v to %v seconds", interval, seconds)
+			interval = seconds
+			broadcastHeartbeatControl(&pb.HeartbeatControl{Command: pb.HeartbeatCommand_SetHeartbeatInterval, HeartbeatIntervalSecond: int32(seconds)})
+		}
+	}, &Config_Headnode_NodeHeartbeatIntervalSecond)
 }
 
 // Valid format: placeholder[{[-]begin[-[-]end][,[-]step]}]
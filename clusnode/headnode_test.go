@@ -1,8 +1,13 @@
 package main
 
 import (
+	pb "clusrun/protobuf"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
 )
 
 func Test_parseSweep(t *testing.T) {
@@ -58,3 +63,57 @@ func Test_parseSweep(t *testing.T) {
 		}
 	}
 }
+
+func Test_getOutputRateLimit(t *testing.T) {
+	cases := []struct {
+		bandwidth int32
+		nodes     int
+		expected  int64
+	}{
+		{0, 10, 0},
+		{-1, 10, 0},
+		{100, 0, 0},
+		{100, 1, 102400},
+		{100, 4, 25600},
+		{1, 2048, 1},
+	}
+
+	for _, c := range cases {
+		if r := getOutputRateLimit(c.bandwidth, c.nodes); r != c.expected {
+			t.Errorf("\nbandwidth=%v\nnodes=%v\nexpected=%v\n  actual=%v", c.bandwidth, c.nodes, c.expected, r)
+		}
+	}
+}
+
+func Test_abridgeJob(t *testing.T) {
+	job := &pb.Job{Id: 1, Name: "job", State: pb.JobState_Running, Progress: "1/2", CreateTime: 100, Command: "hostname", Nodes: []string{"NODE1", "NODE2"}, Results: map[string]string{"NODE1": "{}"}}
+	expected := &pb.Job{Id: 1, Name: "job", State: pb.JobState_Running, Progress: "1/2", CreateTime: 100, Command: "hostname"}
+	if summary := abridgeJob(job); !proto.Equal(summary, expected) {
+		t.Errorf("Unexpected summary: %v", summary)
+	}
+	job.Command = strings.Repeat("a", jobSummaryCommandSize-1) + "中文"
+	if command := abridgeJob(job).Command; command != strings.Repeat("a", jobSummaryCommandSize-1)+"..." {
+		t.Errorf("Unexpected truncated command: %q", command)
+	}
+}
+
+func Test_getNodeHeartbeatTimeout(t *testing.T) {
+	node := "SLOW-HEARTBEAT"
+	defer nodeHeartbeatIntervals.Delete(node)
+	timeout := getHeartbeatTimeout()
+	storeNodeHeartbeatInterval(node, 1)
+	if result := getNodeHeartbeatTimeout(node); result != timeout {
+		t.Errorf("Expected the configured timeout %v for a short interval, got %v", timeout, result)
+	}
+	storeNodeHeartbeatInterval(node, 60)
+	if result := getNodeHeartbeatTimeout(node); result != 60*heartbeatTimeoutIntervals*time.Second {
+		t.Errorf("Expected the timeout scaled to the interval, got %v", result)
+	}
+	if nodeLost(node, time.Now().Add(-time.Minute)) {
+		t.Error("Expected the node not lost within the scaled timeout")
+	}
+	storeNodeHeartbeatInterval(node, 0)
+	if result := getNodeHeartbeatTimeout(node); result != timeout {
+		t.Errorf("Expected the configured timeout once the interval is not reported, got %v", result)
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	heartbeatStreams      = map[string]*heartbeatStreamSender{} // the heartbeat stream each clusnode keeps open to push controls
+	heartbeatStreamsLock  sync.Mutex
+	heartbeatDisconnected sync.Map // the time the heartbeat stream of each clusnode is disconnected
+)
+
+// The sending side of a heartbeat stream on headnode, the controls can be pushed concurrently
+type heartbeatStreamSender struct {
+	stream pb.Headnode_HeartbeatStreamServer
+	lock   sync.Mutex
+}
+
+func (s *heartbeatStreamSender) send(command pb.HeartbeatCommand) error {
+	return s.sendControl(&pb.HeartbeatControl{Command: command})
+}
+
+func (s *heartbeatStreamSender) sendControl(control *pb.HeartbeatControl) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.stream.Send(control)
+}
+
+// Receive the heartbeats of a clusnode over a stream, the clusnode is lost once the stream is disconnected without waiting for the heartbeat timeout
+func (s *headnode_server) HeartbeatStream(stream pb.Headnode_HeartbeatStreamServer) error {
+	defer LogPanicBeforeExit()
+	sender := &heartbeatStreamSender{stream: stream}
+	display_name := ""
+	defer func() {
+		if len(display_name) > 0 {
+			unregisterHeartbeatStream(display_name, sender)
+		}
+	}()
+	for {
+		in, err := stream.Recv()
+		if err != nil {
+			if len(display_name) > 0 && unregisterHeartbeatStream(display_name, sender) {
+				heartbeatDisconnected.Store(display_name, time.Now())
+				MarkNodesChanged()
+				if err == io.EOF {
+					LogWarning("Heartbeat stream of %v is closed", display_name)
+				} else {
+					LogWarning("Heartbeat stream of %v is disconnected: %v", display_name, err)
+				}
+			}
+			return nil
+		}
+		if in.GetRelayed() {
+			return status.Error(codes.InvalidArgument, "Relayed heartbeats should be sent to the relay by Heartbeat")
+		}
+		name, err := receiveHeartbeat(in)
+		if err != nil {
+			return err
+		}
+		if name != display_name {
+			if len(display_name) > 0 {
+				unregisterHeartbeatStream(display_name, sender)
+			}
+			display_name = name
+			registerHeartbeatStream(display_name, sender)
+		}
+	}
+}
+
+func registerHeartbeatStream(display_name string, sender *heartbeatStreamSender) {
+	heartbeatStreamsLock.Lock()
+	heartbeatStreams[display_name] = sender
+	heartbeatStreamsLock.Unlock()
+	LogInfo("Heartbeat stream of %v is opened", display_name)
+	if isNodeDraining(display_name) {
+		if err := sender.send(pb.HeartbeatCommand_DrainNode); err != nil {
+			LogWarning("Failed to push %v to %v: %v", pb.HeartbeatCommand_DrainNode, display_name, err)
+		}
+	}
+}
+
+// Unregister the stream of the clusnode if it is not replaced by a new one, return whether it is unregistered
+func unregisterHeartbeatStream(display_name string, sender *heartbeatStreamSender) bool {
+	heartbeatStreamsLock.Lock()
+	defer heartbeatStreamsLock.Unlock()
+	if heartbeatStreams[display_name] != sender {
+		return false
+	}
+	delete(heartbeatStreams, display_name)
+	return true
+}
+
+// Push the control to the clusnode over its heartbeat stream, return false if the clusnode doesn't keep a stream open
+func pushHeartbeatControl(display_name string, command pb.HeartbeatCommand) bool {
+	heartbeatStreamsLock.Lock()
+	sender, ok := heartbeatStreams[display_name]
+	heartbeatStreamsLock.Unlock()
+	if !ok {
+		return false
+	}
+	if err := sender.send(command); err != nil {
+		LogWarning("Failed to push %v to %v: %v", command, display_name, err)
+		return false
+	}
+	LogInfo("Pushed %v to %v", command, display_name)
+	return true
+}
+
+// The clusnode is lost if its heartbeat is timeout, or its heartbeat stream is disconnected since the last report
+func nodeLost(display_name string, last_report time.Time) bool {
+	if time.Since(last_report) > getNodeHeartbeatTimeout(display_name) {
+		return true
+	}
+	if disconnected, ok := heartbeatDisconnected.Load(display_name); ok && !disconnected.(time.Time).Before(last_report) {
+		return true
+	}
+	return false
+}
+
+// The receiving side of a heartbeat stream on clusnode
+type heartbeatStream struct {
+	conn   *grpc.ClientConn
+	cancel context.CancelFunc
+	stream pb.Headnode_HeartbeatStreamClient
+	done   chan struct{} // closed once the stream is broken
+	err    error
+}
+
+func openHeartbeatStream(headnode string) (*heartbeatStream, error) {
+	conn, cancelConn := ConnectNode(headnode)
+	cancelConn()
+	if conn == nil {
+		return nil, status.Errorf(codes.Unavailable, "Can not connect %v", headnode)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := pb.NewHeadnodeClient(conn).HeartbeatStream(ctx)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return nil, err
+	}
+	s := &heartbeatStream{conn: conn, cancel: cancel, stream: stream, done: make(chan struct{})}
+	go func() {
+		defer LogPanicBeforeExit()
+		defer close(s.done)
+		for {
+			control, err := stream.Recv()
+			if err != nil {
+				s.err = err
+				return
+			}
+			applyHeartbeatControl(headnode, control)
+		}
+	}()
+	return s, nil
+}
+
+// Send the heartbeat, the error which breaks the stream is returned if it is broken
+func (s *heartbeatStream) Send(request *pb.HeartbeatRequest) error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+	}
+	if err := s.stream.Send(request); err != nil {
+		if err == io.EOF {
+			<-s.done
+			return s.err
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *heartbeatStream) Close() {
+	s.stream.CloseSend()
+	s.cancel()
+	s.conn.Close()
+}
+
+// Apply the control pushed by the headnode
+func applyHeartbeatControl(headnode string, control *pb.HeartbeatControl) {
+	command := control.GetCommand()
+	LogInfo("Receive %v from headnode %v", command, headnode)
+	switch command {
+	case pb.HeartbeatCommand_DrainNode, pb.HeartbeatCommand_UndrainNode:
+		if state, ok := headnodesReporting.Load(headnode); ok {
+			state.(*heartbeat_state).Draining = command == pb.HeartbeatCommand_DrainNode
+		}
+	case pb.HeartbeatCommand_RefreshConfigs:
+		ReloadNodeConfigs()
+	case pb.HeartbeatCommand_HeadnodeStopping:
+		// The headnode validates and drains this clusnode again once it is back
+		if state, ok := headnodesReporting.Load(headnode); ok {
+			state.(*heartbeat_state).Validated = false
+			state.(*heartbeat_state).Draining = false
+		}
+	case pb.HeartbeatCommand_SetHeartbeatInterval:
+		setHeartbeatInterval(headnode, int(control.GetHeartbeatIntervalSecond()))
+	}
+}
+
+func GetDrainingHeadnodes() (draining []string) {
+	headnodesReporting.Range(func(key, val interface{}) bool {
+		if state := val.(*heartbeat_state); !state.Stopped && state.Connected && state.Draining {
+			draining = append(draining, key.(string))
+		}
+		return true
+	})
+	return
+}
+
+// Push the control to all the clusnodes keeping their heartbeat streams open
+func broadcastHeartbeatControl(control *pb.HeartbeatControl) {
+	heartbeatStreamsLock.Lock()
+	senders := make(map[string]*heartbeatStreamSender, len(heartbeatStreams))
+	for display_name, sender := range heartbeatStreams {
+		senders[display_name] = sender
+	}
+	heartbeatStreamsLock.Unlock()
+	for display_name, sender := range senders {
+		if err := sender.sendControl(control); err != nil {
+			LogWarning("Failed to push %v to %v: %v", control.Command, display_name, err)
+		}
+	}
+	if len(senders) > 0 {
+		LogInfo("Pushed %v to %v nodes", control.Command, len(senders))
+	}
+}
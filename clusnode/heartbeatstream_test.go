@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_nodeLost(t *testing.T) {
+	node := "test-node-lost"
+	defer heartbeatDisconnected.Delete(node)
+	now := time.Now()
+	if nodeLost(node, now) {
+		t.Errorf("node reported just now is lost")
+	}
+	if !nodeLost(node, now.Add(-getHeartbeatTimeout()-time.Second)) {
+		t.Errorf("node with heartbeat timeout is not lost")
+	}
+
+	// The node is lost once its heartbeat stream is disconnected, until it reports again
+	heartbeatDisconnected.Store(node, now)
+	if !nodeLost(node, now.Add(-time.Second)) || !nodeLost(node, now) {
+		t.Errorf("node with heartbeat stream disconnected is not lost")
+	}
+	if nodeLost(node, now.Add(time.Second)) {
+		t.Errorf("node reported after disconnected is lost")
+	}
+}
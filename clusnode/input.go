@@ -0,0 +1,251 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	jobInputWaitTimeout   = time.Minute
+	jobInputCheckInterval = 100 * time.Millisecond
+)
+
+var (
+	jobsStdin sync.Map // the stdin of tasks started with input forwarded from client, keyed by job label
+)
+
+// The stdin of a task is claimed by only one input stream, or closed if it is not claimed in time
+type jobStdin struct {
+	pipe    io.WriteCloser
+	claimed int32
+}
+
+func (s *jobStdin) Claim() bool {
+	return atomic.CompareAndSwapInt32(&s.claimed, 0, 1)
+}
+
+func registerJobStdin(job_label string, pipe io.WriteCloser) {
+	stdin := &jobStdin{pipe: pipe}
+	jobsStdin.Store(job_label, stdin)
+	time.AfterFunc(jobInputWaitTimeout, func() {
+		if stdin.Claim() {
+			LogWarning("Close stdin of job %v since no input is forwarded in %v", job_label, jobInputWaitTimeout)
+			pipe.Close()
+		}
+	})
+}
+
+// Wait for the task to start and claim its stdin
+func claimJobStdin(ctx context.Context, job_label string) (io.WriteCloser, error) {
+	deadline := time.Now().Add(jobInputWaitTimeout)
+	for time.Now().Before(deadline) {
+		if v, ok := jobsStdin.Load(job_label); ok {
+			if stdin := v.(*jobStdin); stdin.Claim() {
+				return stdin.pipe, nil
+			}
+			return nil, errors.New("Input of the job is already forwarded or closed")
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(jobInputCheckInterval):
+		}
+	}
+	return nil, errors.New("Job with input forwarded is not started")
+}
+
+// Write the input to stdin of the task and acknowledge each request after written, so that the client is slowed down by a task not reading
+func (s *clusnode_server) WriteJobInput(in pb.Clusnode_WriteJobInputServer) error {
+	defer LogPanicBeforeExit()
+	request, err := in.Recv()
+	if err != nil {
+		return err
+	}
+	headnode, job_id := request.GetHeadnode(), request.GetJobId()
+	if err := checkReportedHeadnode(in.Context(), request.GetReportedHeadnode()); err != nil {
+		LogWarning("Reject input of job %v from headnode %v: %v", job_id, headnode, status.Convert(err).Message())
+		return err
+	}
+	job_label := getJobLabel(headnode, int(job_id))
+	stdin, err := claimJobStdin(in.Context(), job_label)
+	if err != nil {
+		LogWarning("Failed to forward input to job %v: %v", job_label, err)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	defer stdin.Close()
+	LogInfo("Start forwarding input to job %v", job_label)
+	var written int64
+	for {
+		if data := request.GetData(); len(data) > 0 {
+			n, err := stdin.Write(data)
+			written += int64(n)
+			if err != nil {
+				// The task may exit without reading all the input
+				LogWarning("Failed to write input to job %v after %v bytes: %v", job_label, written, err)
+				return status.Errorf(codes.FailedPrecondition, "Failed to write input: %v", err)
+			}
+		}
+		if request.GetClose() {
+			LogInfo("Input of job %v is closed after %v bytes", job_label, written)
+			return in.Send(&pb.JobInputReply{Written: written})
+		}
+		if err := in.Send(&pb.JobInputReply{Written: written}); err != nil {
+			return err
+		}
+		if request, err = in.Recv(); err != nil {
+			LogInfo("Input of job %v ends after %v bytes: %v", job_label, written, err)
+			return nil
+		}
+	}
+}
+
+// Forward the input from client to the task on each node of the job, each request is acknowledged after all nodes written it
+func (s *headnode_server) ForwardJobInput(in pb.Headnode_ForwardJobInputServer) error {
+	defer LogPanicBeforeExit()
+	request, err := in.Recv()
+	if err != nil {
+		return err
+	}
+	id := request.GetJobId()
+	job, err := getClientJob(in.Context(), id)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if !job.ForwardStdin {
+		return status.Errorf(codes.FailedPrecondition, "Job %v is not started with input forwarded", id)
+	}
+	if err := waitJobDispatching(id); err != nil {
+		LogWarning("Failed to forward input to job %v: %v", id, err)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	ctx, cancel := context.WithCancel(in.Context())
+	defer cancel()
+	f := newJobInputForwarder(ctx, id, job.Nodes)
+	defer f.Close()
+	LogInfo("Start forwarding input to job %v on %v nodes", id, len(job.Nodes))
+	reply := &pb.JobInputReply{FailedNodes: f.failed}
+	for {
+		if err := in.Send(reply); err != nil {
+			return err
+		}
+		if request.GetClose() {
+			LogInfo("Input of job %v is closed", id)
+			return nil
+		}
+		if request, err = in.Recv(); err == io.EOF {
+			request = &pb.JobInputRequest{Close: true}
+		} else if err != nil {
+			LogWarning("Input of job %v ends: %v", id, err)
+			return err
+		}
+		reply = &pb.JobInputReply{Written: int64(len(request.Data)), FailedNodes: f.Forward(request)}
+	}
+}
+
+// Wait until the job is dispatched, e.g. after its dependency job ends
+func waitJobDispatching(id int32) error {
+	for {
+		state, err := GetJobState(id)
+		if err != nil {
+			return err
+		}
+		switch state {
+		case pb.JobState_Created, pb.JobState_Waiting:
+			time.Sleep(jobInputCheckInterval)
+		case pb.JobState_Dispatching, pb.JobState_Running:
+			return nil
+		default:
+			return fmt.Errorf("Job %v is %v", id, state)
+		}
+	}
+}
+
+type jobInputForwarder struct {
+	streams  map[string]pb.Clusnode_WriteJobInputClient
+	releases []func()
+	failed   map[string]string // the nodes failed when opening the streams
+}
+
+// Open the input stream to each node, which is acknowledged after the task on node started
+func newJobInputForwarder(ctx context.Context, id int32, nodes []string) *jobInputForwarder {
+	f := &jobInputForwarder{streams: map[string]pb.Clusnode_WriteJobInputClient{}, failed: map[string]string{}}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node string) {
+			defer wg.Done()
+			conn, release := GetNodeConnection(parseHost(node), connectionLane_Output)
+			if conn == nil {
+				lock.Lock()
+				defer lock.Unlock()
+				f.failed[node] = "Failed to connect node"
+				return
+			}
+			stream, err := pb.NewClusnodeClient(conn).WriteJobInput(ctx)
+			if err == nil {
+				_, err = sendJobInput(stream, &pb.JobInputRequest{JobId: id, Headnode: NodeHost, ReportedHeadnode: getReportedHeadnode(node)})
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			f.releases = append(f.releases, release)
+			if err != nil {
+				LogWarning("Failed to forward input to job %v on node %v: %v", id, node, err)
+				f.failed[node] = status.Convert(err).Message()
+			} else {
+				f.streams[node] = stream
+			}
+		}(node)
+	}
+	wg.Wait()
+	return f
+}
+
+// Send the request to all nodes and wait for their acknowledgements, return the nodes failed in this time
+func (f *jobInputForwarder) Forward(request *pb.JobInputRequest) map[string]string {
+	failed := map[string]string{}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for node, stream := range f.streams {
+		wg.Add(1)
+		go func(node string, stream pb.Clusnode_WriteJobInputClient) {
+			defer wg.Done()
+			if _, err := sendJobInput(stream, &pb.JobInputRequest{Data: request.GetData(), Close: request.GetClose()}); err != nil {
+				lock.Lock()
+				defer lock.Unlock()
+				failed[node] = status.Convert(err).Message()
+			}
+		}(node, stream)
+	}
+	wg.Wait()
+	for node := range failed {
+		delete(f.streams, node)
+	}
+	return failed
+}
+
+// Send the request and wait for the acknowledgement, the error of a stream closed by node is received instead of EOF
+func sendJobInput(stream pb.Clusnode_WriteJobInputClient, request *pb.JobInputRequest) (*pb.JobInputReply, error) {
+	if err := stream.Send(request); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return stream.Recv()
+}
+
+func (f *jobInputForwarder) Close() {
+	for _, stream := range f.streams {
+		_ = stream.CloseSend()
+	}
+	for _, release := range f.releases {
+		release()
+	}
+}
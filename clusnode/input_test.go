@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func Test_claimJobStdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+	job_label := getJobLabel("localhost:50505", 1)
+	registerJobStdin(job_label, w)
+	defer jobsStdin.Delete(job_label)
+
+	if stdin, err := claimJobStdin(context.Background(), job_label); err != nil || stdin != w {
+		t.Errorf("failed to claim the stdin: %v", err)
+	}
+	if _, err := claimJobStdin(context.Background(), job_label); err == nil {
+		t.Errorf("the stdin is claimed again")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := claimJobStdin(ctx, getJobLabel("localhost:50505", 2)); err == nil {
+		t.Errorf("the stdin of a job not started is claimed")
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+const (
+	DefaultPortEnv = "CLUSRUN_DEFAULT_PORT"
+)
+
+var (
+	// The name of the clusnode instance, whose config, log and data files are separated from the other instances on the same machine
+	NodeInstance string
+	DataDir      string
+
+	instanceNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// The default port is the same for all nodes of a cluster, since it is omitted in the display names of nodes
+func parseDefaultPort(s string) (string, error) {
+	if len(s) == 0 {
+		return defaultPort, nil
+	}
+	port, err := strconv.ParseUint(s, 10, 16)
+	if err != nil || port == 0 {
+		return "", fmt.Errorf("Incorrect port %q", s)
+	}
+	return strconv.Itoa(int(port)), nil
+}
+
+func validateInstanceName(name string) error {
+	if len(name) > 0 && !instanceNameRegexp.MatchString(name) {
+		return errors.New("Instance name should consist of letters, digits, \"_\" or \"-\"")
+	}
+	return nil
+}
+
+// The path of the default file of the instance next to the executable, e.g. clusnode.config or clusnode.tenant1.config
+func getInstancePath(executable, instance, suffix string) string {
+	if len(instance) == 0 {
+		return executable + suffix
+	}
+	return executable + "." + instance + suffix
+}
+
+func getInstanceServiceName(instance string) string {
+	if len(instance) == 0 {
+		return defaultServiceName
+	}
+	return defaultServiceName + "-" + instance
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_instance(t *testing.T) {
+	port_cases := []struct {
+		port     string
+		expected string
+		valid    bool
+	}{
+		{"", defaultPort, true},
+		{"50506", "50506", true},
+		{"050507", "50507", true},
+		{"0", "", false},
+		{"65536", "", false},
+		{"port", "", false},
+	}
+	for _, c := range port_cases {
+		if port, err := parseDefaultPort(c.port); c.valid != (err == nil) || port != c.expected {
+			t.Errorf("Expected default port %q, %v of %q, got %q, %v", c.expected, c.valid, c.port, port, err)
+		}
+	}
+	for name, valid := range map[string]bool{"": true, "tenant-1_a": true, "tenant.1": false, "a/b": false, "a b": false} {
+		if err := validateInstanceName(name); valid != (err == nil) {
+			t.Errorf("Expected valid %v of instance name %q, got %v", valid, name, err)
+		}
+	}
+	if path, name := getInstancePath("/opt/clusnode", "", ".config"), getInstanceServiceName(""); path != "/opt/clusnode.config" || name != "clusnode" {
+		t.Errorf("Unexpected default instance path %v or service name %v", path, name)
+	}
+	if path, name := getInstancePath("/opt/clusnode", "tenant1", ".db"), getInstanceServiceName("tenant1"); path != "/opt/clusnode.tenant1.db" || name != "clusnode-tenant1" {
+		t.Errorf("Unexpected instance path %v or service name %v", path, name)
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	JobShell_Cmd        = "cmd"
+	JobShell_PowerShell = "powershell"
+	JobShell_Pwsh       = "pwsh"
+	JobShell_Bash       = "bash"
+	JobShell_Sh         = "sh"
+)
+
+var (
+	jobShells        = []string{JobShell_Cmd, JobShell_PowerShell, JobShell_Pwsh, JobShell_Bash, JobShell_Sh}
+	windowsJobShells = []string{JobShell_Cmd, JobShell_PowerShell, JobShell_Pwsh}
+
+	scriptFileExtRegexp = regexp.MustCompile(`^\.[A-Za-z0-9]+$`)
+
+	// The characters quoted in the arguments of cmd, in which the quotes are doubled
+	cmdSpecialChars = " \t&|<>^(),;=%!\""
+
+	// PowerShell takes the typographic single quotes as single quotes, which are doubled in a single quoted string
+	powerShellQuoteReplacer = strings.NewReplacer("'", "''", "\u2018", "\u2018\u2018", "\u2019", "\u2019\u2019", "\u201a", "\u201a\u201a", "\u201b", "\u201b\u201b")
+
+	windowsShellValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if !isWindowsJobShell(v) {
+			return fmt.Errorf("Value should be one of: %v", strings.Join(windowsJobShells, ", "))
+		}
+		return nil
+	}
+)
+
+func isValidJobShell(shell string) bool {
+	for _, s := range jobShells {
+		if shell == s {
+			return true
+		}
+	}
+	return false
+}
+
+func isWindowsJobShell(shell string) bool {
+	for _, s := range windowsJobShells {
+		if shell == s {
+			return true
+		}
+	}
+	return false
+}
+
+// The default shell is configured on Windows, which is cmd by default, and is bash on the others
+func getDefaultJobShell(goos string) string {
+	if goos == "windows" {
+		return Config_Clusnode_WindowsShell.GetString()
+	}
+	return JobShell_Bash
+}
+
+// The extension of the command file, which is required by cmd and PowerShell to run it
+func getJobShellFileExt(shell string) string {
+	switch shell {
+	case JobShell_Cmd:
+		return ".cmd"
+	case JobShell_PowerShell, JobShell_Pwsh:
+		return ".ps1"
+	}
+	return ".sh"
+}
+
+// The program and its arguments to run the command file with the arguments of job by the shell on the OS, PowerShell is
+// pwsh on the OS other than Windows. The command file is called by cmd and PowerShell so that its exit code is propagated,
+// and cmd should be run with the command line from getCmdCommandLine since it does not parse the arguments quoted by Go.
+func getJobShellCommand(shell, goos, cmd_file string, arguments []string) (string, []string, error) {
+	windows := goos == "windows"
+	switch shell {
+	case JobShell_Cmd:
+		if !windows {
+			return "", nil, fmt.Errorf("Shell %v is not supported on %v", shell, goos)
+		}
+		return "cmd", append([]string{"/d", "/q", "/c", "call", cmd_file}, arguments...), nil
+	case JobShell_PowerShell, JobShell_Pwsh:
+		program := "pwsh"
+		if windows && shell == JobShell_PowerShell {
+			program = "powershell"
+		}
+		return program, []string{"-NoProfile", "-NonInteractive", "-ExecutionPolicy", "Bypass", "-Command", getPowerShellCommand(cmd_file, arguments)}, nil
+	case JobShell_Bash, JobShell_Sh:
+		args := append([]string{cmd_file}, arguments...)
+		if windows {
+			return shell, args, nil
+		}
+		return "/bin/" + shell, args, nil
+	}
+	return "", nil, fmt.Errorf("Invalid shell %q, should be one of: %v", shell, strings.Join(jobShells, ", "))
+}
+
+// The command line of cmd to call the command file, with /s the outer quotes are stripped and the rest is run as is
+func getCmdCommandLine(cmd_file string, arguments []string) string {
+	line := "call " + quoteCmdArgument(cmd_file)
+	for _, arg := range arguments {
+		line += " " + quoteCmdArgument(arg)
+	}
+	return `cmd /d /q /s /c "` + line + `"`
+}
+
+// Quote the argument of cmd if it has spaces or special characters, the environment variables in it are still expanded by cmd
+func quoteCmdArgument(arg string) string {
+	if len(arg) > 0 && !strings.ContainsAny(arg, cmdSpecialChars) {
+		return arg
+	}
+	return `"` + strings.ReplaceAll(arg, `"`, `""`) + `"`
+}
+
+// The PowerShell command to call the command file with the arguments, which exits with the exit code of the last program
+// run by the command file, or 1 if the command file fails without running any program
+func getPowerShellCommand(cmd_file string, arguments []string) string {
+	call := "& " + quotePowerShellArgument(cmd_file)
+	for _, arg := range arguments {
+		call += " " + quotePowerShellArgument(arg)
+	}
+	return "$global:LASTEXITCODE = 0; " + call + "; if (-not $? -and $LASTEXITCODE -eq 0) { exit 1 }; exit $LASTEXITCODE"
+}
+
+func quotePowerShellArgument(arg string) string {
+	return "'" + powerShellQuoteReplacer.Replace(arg) + "'"
+}
+
+// The name of the script file whose content is the command of job, which should be a file name without dir
+func validateScriptName(name string) error {
+	if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return fmt.Errorf("Invalid script name %q, which should be a file name without dir", name)
+	}
+	return nil
+}
+
+// A script starting with a shebang line is run directly on the OS other than Windows if no shell is specified,
+// so that it can be written in any language, e.g. "#!/usr/bin/env python3"
+func isShebangScript(script_name, shell, goos, command string) bool {
+	return len(script_name) > 0 && len(shell) == 0 && goos != "windows" && strings.HasPrefix(command, "#!")
+}
+
+// The extension of the script file is kept for the interpreters caring about it
+func getScriptFileExt(script_name string) string {
+	if ext := filepath.Ext(script_name); scriptFileExtRegexp.MatchString(ext) {
+		return ext
+	}
+	return ""
+}
+
+// Resolve the commands of nodes by their OS, the nodes without the command of their OS run the default command,
+// or are skipped if the default command is empty
+func resolveOsCommands(nodes []string, os_commands map[string]string, command string, get_os func(string) string) (map[string]string, map[string]string) {
+	commands, skipped := make(map[string]string, len(nodes)), map[string]string{}
+	for _, node := range nodes {
+		node_os := strings.ToLower(get_os(node))
+		if c, ok := os_commands[node_os]; ok {
+			commands[node] = c
+		} else if len(command) > 0 {
+			commands[node] = command
+		} else if len(node_os) == 0 {
+			skipped[node] = "No command for the node whose OS is unknown"
+		} else {
+			skipped[node] = fmt.Sprintf("No command for OS %v", node_os)
+		}
+	}
+	return commands, skipped
+}
+
+// The OS commands are keyed by OS in lower case like windows or linux
+func normalizeOsCommands(os_commands map[string]string) (map[string]string, error) {
+	normalized := make(map[string]string, len(os_commands))
+	for node_os, c := range os_commands {
+		node_os = strings.ToLower(strings.TrimSpace(node_os))
+		if len(node_os) == 0 {
+			return nil, fmt.Errorf("Empty OS of command %q", c)
+		}
+		if len(strings.TrimSpace(c)) == 0 {
+			return nil, fmt.Errorf("Empty command for OS %v", node_os)
+		}
+		if _, ok := normalized[node_os]; ok {
+			return nil, fmt.Errorf("Duplicate commands for OS %v", node_os)
+		}
+		normalized[node_os] = c
+	}
+	return normalized, nil
+}
+
+func getNodeOs(node string) string {
+	if s, ok := nodeSystems.Load(node); ok {
+		return s.(*pb.NodeSystem).GetOs()
+	}
+	return ""
+}
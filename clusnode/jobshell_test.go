@@ -0,0 +1,91 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_getJobShellCommand(t *testing.T) {
+	powershell_args := []string{"-NoProfile", "-NonInteractive", "-ExecutionPolicy", "Bypass", "-Command", "$global:LASTEXITCODE = 0; & 'job.sh' 'a b'; if (-not $? -and $LASTEXITCODE -eq 0) { exit 1 }; exit $LASTEXITCODE"}
+	cases := []struct {
+		shell, goos string
+		program     string
+		args        []string
+		ext         string
+		valid       bool
+	}{
+		{getDefaultJobShell("linux"), "linux", "/bin/bash", []string{"job.sh", "a b"}, ".sh", true},
+		{getDefaultJobShell("windows"), "windows", "cmd", []string{"/d", "/q", "/c", "call", "job.sh", "a b"}, ".cmd", true},
+		{JobShell_Sh, "linux", "/bin/sh", []string{"job.sh", "a b"}, ".sh", true},
+		{JobShell_Bash, "windows", "bash", []string{"job.sh", "a b"}, ".sh", true},
+		{JobShell_PowerShell, "linux", "pwsh", powershell_args, ".ps1", true},
+		{JobShell_PowerShell, "windows", "powershell", powershell_args, ".ps1", true},
+		{JobShell_Pwsh, "windows", "pwsh", powershell_args, ".ps1", true},
+		{JobShell_Cmd, "linux", "", nil, ".cmd", false},
+		{"zsh", "linux", "", nil, ".sh", false},
+	}
+	for _, c := range cases {
+		program, args, err := getJobShellCommand(c.shell, c.goos, "job.sh", []string{"a b"})
+		if c.valid != (err == nil) || program != c.program || !reflect.DeepEqual(args, c.args) || getJobShellFileExt(c.shell) != c.ext {
+			t.Errorf("Expected %v %v, %v of shell %v on %v, got %v %v, %v", c.program, c.args, c.valid, c.shell, c.goos, program, args, err)
+		}
+	}
+}
+
+func Test_resolveOsCommands(t *testing.T) {
+	systems := map[string]string{"node1": "windows", "node2": "linux", "node3": "darwin"}
+	get_os := func(node string) string { return systems[node] }
+	nodes := []string{"node1", "node2", "node3", "node4"}
+	os_commands, err := normalizeOsCommands(map[string]string{" Windows ": "dir", "linux": "ls"})
+	if err != nil {
+		t.Fatalf("Failed to normalize OS commands: %v", err)
+	}
+	commands, skipped := resolveOsCommands(nodes, os_commands, "", get_os)
+	if expected := map[string]string{"node1": "dir", "node2": "ls"}; !reflect.DeepEqual(commands, expected) || len(skipped) != 2 || len(skipped["node3"]) == 0 || len(skipped["node4"]) == 0 {
+		t.Errorf("Unexpected commands %v and skipped nodes %v", commands, skipped)
+	}
+	commands, skipped = resolveOsCommands(nodes, os_commands, "uname", get_os)
+	if expected := map[string]string{"node1": "dir", "node2": "ls", "node3": "uname", "node4": "uname"}; !reflect.DeepEqual(commands, expected) || len(skipped) != 0 {
+		t.Errorf("Unexpected commands %v and skipped nodes %v with default command", commands, skipped)
+	}
+	for _, invalid := range []map[string]string{{"linux": " "}, {"": "ls"}, {"linux": "ls", "LINUX": "ls -l"}} {
+		if _, err := normalizeOsCommands(invalid); err == nil {
+			t.Errorf("Expected invalid OS commands %v", invalid)
+		}
+	}
+}
+
+func Test_scriptFile(t *testing.T) {
+	for name, valid := range map[string]bool{"": true, "setup.sh": true, "a/setup.sh": false, `a\setup.sh`: false, "..": false} {
+		if err := validateScriptName(name); valid != (err == nil) {
+			t.Errorf("Expected valid %v of script name %q, got %v", valid, name, err)
+		}
+	}
+	script := "#!/usr/bin/env python3\nprint(1)"
+	if !isShebangScript("setup.py", "", "linux", script) {
+		t.Errorf("Expected script with shebang run directly")
+	}
+	if isShebangScript("setup.py", JobShell_Bash, "linux", script) || isShebangScript("setup.py", "", "windows", script) || isShebangScript("", "", "linux", script) || isShebangScript("setup.sh", "", "linux", "echo 1") {
+		t.Errorf("Expected script run by shell")
+	}
+	for name, ext := range map[string]string{"setup.py": ".py", "setup": "", "setup.p y": "", "setup.": ""} {
+		if e := getScriptFileExt(name); e != ext {
+			t.Errorf("Expected extension %q of script %q, got %q", ext, name, e)
+		}
+	}
+}
+
+func Test_quoteShellArguments(t *testing.T) {
+	if line := getCmdCommandLine(`C:\jobs\job 1.cmd`, []string{"a", "b c", "", `say "hi"`, "x&y"}); line != `cmd /d /q /s /c "call "C:\jobs\job 1.cmd" a "b c" "" "say ""hi""" "x&y""` {
+		t.Errorf("Unexpected command line of cmd: %v", line)
+	}
+	if command := getPowerShellCommand(`C:\jobs\job.ps1`, []string{"it's", "\u2019q", "$env:PATH"}); command != "$global:LASTEXITCODE = 0; & 'C:\\jobs\\job.ps1' 'it''s' '\u2019\u2019q' '$env:PATH'; if (-not $? -and $LASTEXITCODE -eq 0) { exit 1 }; exit $LASTEXITCODE" {
+		t.Errorf("Unexpected command of PowerShell: %v", command)
+	}
+	if err := windowsShellValidator(JobShell_Pwsh); err != nil {
+		t.Errorf("Expected %v valid on Windows, got %v", JobShell_Pwsh, err)
+	}
+	if err := windowsShellValidator(JobShell_Bash); err == nil {
+		t.Errorf("Expected %v invalid as the default shell on Windows", JobShell_Bash)
+	}
+}
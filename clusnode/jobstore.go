@@ -0,0 +1,234 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// The jobs are kept in memory with indexes after loaded from the jobs file, which is rewritten on each change or flushed in batch
+var (
+	db_jobsCache       []*pb.Job
+	db_jobsCacheLoaded bool
+	db_jobsById        map[int32]*pb.Job
+	db_jobsByState     map[pb.JobState]map[int32]bool
+	db_jobsByNode      map[string]map[int32]bool
+	db_jobsCacheLock   sync.RWMutex
+)
+
+type jobFilter struct {
+	ids     map[int32]bool // nil for all jobs
+	states  map[pb.JobState]bool
+	since   int64 // create time in unix seconds
+	until   int64
+	node    string
+	command string
+	cluster string // empty for the jobs in any cluster
+	offset  int    // the page of the matched jobs, a negative offset is counted from the end
+	limit   int    // 0 for no limit
+}
+
+// Replace the cached jobs, which should not be changed afterwards
+func setJobsCache(jobs []*pb.Job) {
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].Id < jobs[j].Id })
+	by_id := make(map[int32]*pb.Job, len(jobs))
+	by_state := map[pb.JobState]map[int32]bool{}
+	by_node := map[string]map[int32]bool{}
+	for _, job := range jobs {
+		by_id[job.Id] = job
+		if by_state[job.State] == nil {
+			by_state[job.State] = map[int32]bool{}
+		}
+		by_state[job.State][job.Id] = true
+		for _, node := range job.Nodes {
+			for _, key := range getNodeIndexKeys(node) {
+				if by_node[key] == nil {
+					by_node[key] = map[int32]bool{}
+				}
+				by_node[key][job.Id] = true
+			}
+		}
+	}
+	db_jobsCacheLock.Lock()
+	defer db_jobsCacheLock.Unlock()
+	db_jobsCache, db_jobsById, db_jobsByState, db_jobsByNode = jobs, by_id, by_state, by_node
+	db_jobsCacheLoaded = true
+}
+
+// A node in job can be found by either its display name or node name
+func getNodeIndexKeys(node string) []string {
+	node = canonicalNodeId(node)
+	if nodename, host := splitNodeDisplayName(node); len(host) > 0 {
+		return []string{node, nodename}
+	}
+	return []string{node}
+}
+
+func ensureJobsCache() error {
+	db_jobsCacheLock.RLock()
+	loaded := db_jobsCacheLoaded
+	db_jobsCacheLock.RUnlock()
+	if loaded {
+		return nil
+	}
+	jobs, err := readJobsFile()
+	if err != nil {
+		return err
+	}
+	setJobsCache(jobs)
+	return nil
+}
+
+func cloneJobs(jobs []*pb.Job) []*pb.Job {
+	clones := make([]*pb.Job, len(jobs))
+	for i, job := range jobs {
+		clones[i] = proto.Clone(job).(*pb.Job)
+	}
+	return clones
+}
+
+func getCachedJob(id int32) (*pb.Job, bool, error) {
+	if err := ensureJobsCache(); err != nil {
+		return nil, false, err
+	}
+	db_jobsCacheLock.RLock()
+	defer db_jobsCacheLock.RUnlock()
+	job, ok := db_jobsById[id]
+	if !ok {
+		return nil, false, nil
+	}
+	return proto.Clone(job).(*pb.Job), true, nil
+}
+
+// Query jobs matching the filter, the candidates are narrowed by the indexes of ids, states and node before checking other fields
+func QueryJobs(filter *jobFilter) ([]*pb.Job, error) {
+	jobs, err := matchJobs(filter)
+	if err != nil {
+		return nil, err
+	}
+	return cloneJobs(pageJobs(jobs, filter.offset, filter.limit)), nil
+}
+
+// Call f with a clone of each job matching the filter in order of id, and stop on the first error.
+// The cache is not locked when calling f, since the cached jobs are never changed but replaced.
+func RangeJobs(filter *jobFilter, f func(*pb.Job) error) error {
+	jobs, err := matchJobs(filter)
+	if err != nil {
+		return err
+	}
+	for _, job := range pageJobs(jobs, filter.offset, filter.limit) {
+		if err := f(proto.Clone(job).(*pb.Job)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the cached jobs matching the filter, which should be cloned before changed
+func matchJobs(filter *jobFilter) ([]*pb.Job, error) {
+	if err := ensureJobsCache(); err != nil {
+		return nil, err
+	}
+	db_jobsCacheLock.RLock()
+	defer db_jobsCacheLock.RUnlock()
+	var candidates map[int32]bool
+	narrow := func(ids map[int32]bool) {
+		if candidates == nil {
+			candidates = ids
+			return
+		}
+		intersection := map[int32]bool{}
+		for id := range ids {
+			if _, ok := candidates[id]; ok {
+				intersection[id] = true
+			}
+		}
+		candidates = intersection
+	}
+	if filter.ids != nil {
+		ids := NormalizeJobIds(filter.ids, db_jobsCache)
+		if _, ok := ids[JobId_All]; !ok {
+			narrow(ids)
+		}
+	}
+	if len(filter.states) > 0 {
+		ids := map[int32]bool{}
+		for state := range filter.states {
+			for id := range db_jobsByState[state] {
+				ids[id] = true
+			}
+		}
+		narrow(ids)
+	}
+	if len(filter.node) > 0 {
+		ids := db_jobsByNode[canonicalNodeId(filter.node)]
+		if ids == nil {
+			ids = map[int32]bool{}
+		}
+		narrow(ids)
+	}
+	jobs := []*pb.Job{}
+	if candidates == nil {
+		for _, job := range db_jobsCache {
+			if filter.match(job) {
+				jobs = append(jobs, job)
+			}
+		}
+	} else {
+		for id := range candidates {
+			if job, ok := db_jobsById[id]; ok && filter.match(job) {
+				jobs = append(jobs, job)
+			}
+		}
+		sort.Slice(jobs, func(i, j int) bool { return jobs[i].Id < jobs[j].Id })
+	}
+	return jobs, nil
+}
+
+// Get the page of jobs before cloning them, so that a small page of many jobs is cheap
+func pageJobs(jobs []*pb.Job, offset, limit int) []*pb.Job {
+	if offset < 0 {
+		offset += len(jobs)
+		if offset < 0 {
+			offset = 0
+		}
+	}
+	if offset >= len(jobs) {
+		return []*pb.Job{}
+	}
+	jobs = jobs[offset:]
+	if limit > 0 && limit < len(jobs) {
+		jobs = jobs[:limit]
+	}
+	return jobs
+}
+
+// Check the fields not indexed
+func (f *jobFilter) match(job *pb.Job) bool {
+	if len(f.cluster) > 0 && job.Cluster != f.cluster {
+		return false
+	}
+	if f.since > 0 && job.CreateTime < f.since {
+		return false
+	}
+	if f.until > 0 && job.CreateTime > f.until {
+		return false
+	}
+	if len(f.command) > 0 {
+		command := strings.ToLower(f.command)
+		found := strings.Contains(strings.ToLower(job.Command), command)
+		for _, c := range job.NodeCommands {
+			if found {
+				break
+			}
+			found = strings.Contains(strings.ToLower(c), command)
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
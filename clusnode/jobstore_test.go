@@ -0,0 +1,83 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_QueryJobs(t *testing.T) {
+	setJobsCache([]*pb.Job{
+		{Id: 3, State: pb.JobState_Finished, CreateTime: 300, Command: "hostname", Nodes: []string{"NODE1", "NODE2(node2:50505)"}},
+		{Id: 1, State: pb.JobState_Failed, CreateTime: 100, Command: "exit 1", Nodes: []string{"NODE1"}},
+		{Id: 2, State: pb.JobState_Failed, CreateTime: 200, NodeCommands: map[string]string{"NODE2": "echo Hello"}, Nodes: []string{"NODE2"}},
+	})
+	defer setJobsCache(nil)
+	cases := []struct {
+		filter   jobFilter
+		expected []int32
+	}{
+		{jobFilter{}, []int32{1, 2, 3}},
+		{jobFilter{ids: map[int32]bool{}}, []int32{}},
+		{jobFilter{ids: map[int32]bool{JobId_All: false}}, []int32{1, 2, 3}},
+		{jobFilter{ids: map[int32]bool{-1: false, 1: false}}, []int32{1, 3}},
+		{jobFilter{states: map[pb.JobState]bool{pb.JobState_Failed: true}}, []int32{1, 2}},
+		{jobFilter{states: map[pb.JobState]bool{pb.JobState_Running: true}}, []int32{}},
+		{jobFilter{since: 150}, []int32{2, 3}},
+		{jobFilter{since: 150, until: 250}, []int32{2}},
+		{jobFilter{node: "node2"}, []int32{2, 3}},
+		{jobFilter{node: "NODE2(node2:50505)"}, []int32{3}},
+		{jobFilter{node: "node3"}, []int32{}},
+		{jobFilter{command: "hello"}, []int32{2}},
+		{jobFilter{ids: map[int32]bool{2: false, 3: false}, states: map[pb.JobState]bool{pb.JobState_Failed: true}, node: "node2"}, []int32{2}},
+		{jobFilter{limit: 2}, []int32{1, 2}},
+		{jobFilter{offset: 1, limit: 1}, []int32{2}},
+		{jobFilter{offset: 3}, []int32{}},
+		{jobFilter{offset: -2}, []int32{2, 3}},
+		{jobFilter{offset: -5, limit: 1}, []int32{1}},
+		{jobFilter{states: map[pb.JobState]bool{pb.JobState_Failed: true}, offset: -1}, []int32{2}},
+	}
+
+	for _, c := range cases {
+		jobs, err := QueryJobs(&c.filter)
+		ids := []int32{}
+		for _, job := range jobs {
+			ids = append(ids, job.Id)
+		}
+		if err != nil || !reflect.DeepEqual(ids, c.expected) {
+			t.Errorf("\nfilter=%+v\nexpected=%v\n  actual=%v, error=%v", c.filter, c.expected, ids, err)
+		}
+	}
+}
+
+func Test_RangeJobs(t *testing.T) {
+	setJobsCache([]*pb.Job{
+		{Id: 2, State: pb.JobState_Failed, Command: "exit 1"},
+		{Id: 1, State: pb.JobState_Finished, Command: "hostname"},
+		{Id: 3, State: pb.JobState_Failed, Command: "exit 2"},
+	})
+	defer setJobsCache(nil)
+
+	ids := []int32{}
+	err := RangeJobs(&jobFilter{states: map[pb.JobState]bool{pb.JobState_Failed: true}}, func(job *pb.Job) error {
+		job.Command = "changed"
+		ids = append(ids, job.Id)
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(ids, []int32{2, 3}) {
+		t.Errorf("Unexpected jobs ranged: %v, error=%v", ids, err)
+	}
+	if job, _, _ := getCachedJob(2); job.Command != "exit 1" {
+		t.Errorf("Cached job is changed: %v", job.Command)
+	}
+
+	ids = []int32{}
+	stop := errors.New("stop")
+	if err := RangeJobs(&jobFilter{}, func(job *pb.Job) error {
+		ids = append(ids, job.Id)
+		return stop
+	}); err != stop || !reflect.DeepEqual(ids, []int32{1}) {
+		t.Errorf("Ranging jobs is not stopped on error: %v, error=%v", ids, err)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+const jobProgressInterval = 2 * time.Second
+
+var (
+	// The bytes of output received from clusnodes since the headnode started
+	receivedStdoutBytes, receivedStderrBytes int64
+)
+
+// Summarize the tasks of a job once they all end, in which the rescheduled ones are not counted
+// as they are taken over by other nodes
+func summarizeJob(job_on_nodes *sync.Map, spans []*pb.TaskSpan, wall_time time.Duration) *pb.JobSummary {
+	summary := &pb.JobSummary{WallTime: wall_time.Nanoseconds()}
+	exit_codes := map[int32]bool{}
+	job_on_nodes.Range(func(k, v interface{}) bool {
+		j := v.(jobOnNode)
+		if j.rescheduled {
+			return true
+		}
+		summary.Nodes++
+		if j.state == pb.JobState_Finished {
+			summary.SucceededNodes++
+			return true
+		} else if j.unreachable {
+			summary.UnreachableNodes++
+		} else {
+			summary.FailedNodes++
+		}
+		exit_codes[j.exitCode] = true
+		return true
+	})
+	var dispatch_overhead, started int64
+	for _, span := range spans {
+		summary.StdoutBytes += span.StdoutBytes
+		summary.StderrBytes += span.StderrBytes
+		if span.StartTime > 0 {
+			dispatch_overhead += span.StartTime - span.DispatchTime
+			started++
+		}
+		if span.StartTime == 0 || span.EndTime == 0 || span.Lost {
+			continue
+		}
+		if duration := span.EndTime - span.StartTime; duration > summary.SlowestDuration {
+			summary.SlowestNode, summary.SlowestDuration = span.Node, duration
+		}
+	}
+	if started > 0 {
+		summary.DispatchOverhead = dispatch_overhead / started
+	}
+	summary.ExitCode = getAggregateExitCode(exit_codes)
+	return summary
+}
+
+// Count the bytes of output received in the task once it ends
+func recordTaskOutputBytes(span *pb.TaskSpan) {
+	atomic.AddInt64(&receivedStdoutBytes, span.StdoutBytes)
+	atomic.AddInt64(&receivedStderrBytes, span.StderrBytes)
+}
+
+// The exit code shared by the failed tasks if it is positive, otherwise 1 for any failure
+func getAggregateExitCode(exit_codes map[int32]bool) int32 {
+	if len(exit_codes) == 0 {
+		return 0
+	}
+	if len(exit_codes) == 1 {
+		for exit_code := range exit_codes {
+			if exit_code > 0 {
+				return exit_code
+			}
+		}
+	}
+	return 1
+}
+
+// Get the progress of the tasks of a job, in which the rescheduled ones are not counted as in the summary
+func getJobProgress(job_on_nodes *sync.Map, nodes int) *pb.JobProgress {
+	progress := &pb.JobProgress{Nodes: int32(nodes)}
+	job_on_nodes.Range(func(k, v interface{}) bool {
+		j := v.(jobOnNode)
+		if j.rescheduled {
+			return true
+		}
+		switch {
+		case j.state == pb.JobState_Running:
+			progress.RunningNodes++
+		case j.state > pb.JobState_Running:
+			progress.FinishedNodes++
+			if j.state == pb.JobState_Failed {
+				progress.FailedNodes++
+			}
+		}
+		return true
+	})
+	return progress
+}
+
+// Send the progress of the job at each interval once it changes, until the returned function is called to send the last
+// progress and stop
+func sendJobProgressPeriodically(job_on_nodes *sync.Map, nodes int, out jobReplySender, interval time.Duration) func() {
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		var last *pb.JobProgress
+		send := func() {
+			if progress := getJobProgress(job_on_nodes, nodes); !proto.Equal(progress, last) {
+				_ = out.Send(&pb.StartClusJobReply{Progress: progress})
+				last = progress
+			}
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				send()
+			case <-stop:
+				send()
+				return
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-stopped
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func Test_summarizeJob(t *testing.T) {
+	job_on_nodes := sync.Map{}
+	job_on_nodes.Store("node1", jobOnNode{state: pb.JobState_Finished})
+	job_on_nodes.Store("node2", jobOnNode{state: pb.JobState_Failed, exitCode: 2})
+	job_on_nodes.Store("node3", jobOnNode{state: pb.JobState_Failed, exitCode: -1, rescheduled: true})
+	job_on_nodes.Store("node4", jobOnNode{state: pb.JobState_Finished})
+	job_on_nodes.Store("node5", jobOnNode{state: pb.JobState_Failed, exitCode: -1, unreachable: true})
+	spans := []*pb.TaskSpan{
+		{Node: "node1", DispatchTime: 50, StartTime: 100, EndTime: 300, StdoutBytes: 10},
+		{Node: "node2", DispatchTime: 80, StartTime: 100, EndTime: 200},
+		{Node: "node3", DispatchTime: 90, StartTime: 100, EndTime: 900, Lost: true, StdoutBytes: 5, StderrBytes: 3},
+		{Node: "node4", DispatchTime: 930, StartTime: 950, EndTime: 1500},
+		{Node: "node5", DispatchTime: 100},
+	}
+	expected := &pb.JobSummary{Nodes: 4, SucceededNodes: 2, FailedNodes: 1, UnreachableNodes: 1, SlowestNode: "node4", SlowestDuration: 550, WallTime: int64(time.Second), ExitCode: 1, StdoutBytes: 15, StderrBytes: 3, DispatchOverhead: 25}
+	if summary := summarizeJob(&job_on_nodes, spans, time.Second); !proto.Equal(summary, expected) {
+		t.Errorf("Expected summary %v, got %v", expected, summary)
+	}
+	cases := []struct {
+		exit_codes []int32
+		expected   int32
+	}{
+		{nil, 0},
+		{[]int32{3}, 3},
+		{[]int32{-1}, 1},
+		{[]int32{2, 3}, 1},
+	}
+	for _, c := range cases {
+		exit_codes := map[int32]bool{}
+		for _, exit_code := range c.exit_codes {
+			exit_codes[exit_code] = true
+		}
+		if exit_code := getAggregateExitCode(exit_codes); exit_code != c.expected {
+			t.Errorf("Expected aggregate exit code %v of %v, got %v", c.expected, c.exit_codes, exit_code)
+		}
+	}
+}
+
+type fakeJobReplySender struct {
+	lock    sync.Mutex
+	replies []*pb.StartClusJobReply
+}
+
+func (s *fakeJobReplySender) Send(reply *pb.StartClusJobReply) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.replies = append(s.replies, reply)
+	return nil
+}
+
+func Test_jobProgress(t *testing.T) {
+	var job_on_nodes sync.Map
+	job_on_nodes.Store("node1", jobOnNode{state: pb.JobState_Finished})
+	job_on_nodes.Store("node2", jobOnNode{state: pb.JobState_Failed, exitCode: 2})
+	job_on_nodes.Store("node3", jobOnNode{state: pb.JobState_Failed, exitCode: -1, rescheduled: true})
+	job_on_nodes.Store("node4", jobOnNode{state: pb.JobState_Running})
+	job_on_nodes.Store("node5", jobOnNode{state: pb.JobState_Dispatching})
+	expected := &pb.JobProgress{Nodes: 5, FinishedNodes: 2, FailedNodes: 1, RunningNodes: 1}
+	if progress := getJobProgress(&job_on_nodes, 5); !proto.Equal(progress, expected) {
+		t.Errorf("Expected progress %v, got %v", expected, progress)
+	}
+
+	out := &fakeJobReplySender{}
+	stop := sendJobProgressPeriodically(&job_on_nodes, 5, out, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	job_on_nodes.Store("node4", jobOnNode{state: pb.JobState_Finished})
+	stop()
+	if len(out.replies) != 2 {
+		t.Fatalf("Expected progress sent once it changes, got %v", out.replies)
+	}
+	if last := out.replies[1].Progress; last.FinishedNodes != 3 || last.RunningNodes != 0 {
+		t.Errorf("Expected the last progress sent when stopped, got %v", last)
+	}
+}
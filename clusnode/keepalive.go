@@ -0,0 +1,45 @@
+package main
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// The keepalive options of the gRPC server, which are applied when the server starts
+func getKeepaliveServerOptions() []grpc.ServerOption {
+	params := keepalive.ServerParameters{Timeout: time.Duration(Config_GrpcKeepaliveTimeoutSecond.GetInt()) * time.Second}
+	policy := keepalive.EnforcementPolicy{PermitWithoutStream: Config_GrpcKeepalivePermitWithoutStream.GetBool()}
+	if interval := Config_GrpcKeepaliveTimeSecond.GetInt(); interval > 0 {
+		// The peers pinging at the same interval are not regarded as misbehaving
+		params.Time = time.Duration(interval) * time.Second
+		policy.MinTime = params.Time
+	}
+	return []grpc.ServerOption{grpc.KeepaliveParams(params), grpc.KeepaliveEnforcementPolicy(policy)}
+}
+
+func getMessageSizeServerOptions() []grpc.ServerOption {
+	options := []grpc.ServerOption{grpc.MaxRecvMsgSize(Config_GrpcMaxRecvMsgSizeMb.GetInt() << 20)}
+	if size := Config_GrpcMaxSendMsgSizeMb.GetInt(); size > 0 {
+		options = append(options, grpc.MaxSendMsgSize(size<<20))
+	}
+	return options
+}
+
+// The keepalive and message size options of the connections to other nodes, which are applied to the new connections
+func getGrpcDialOptions() []grpc.DialOption {
+	call_options := []grpc.CallOption{grpc.MaxCallRecvMsgSize(Config_GrpcMaxRecvMsgSizeMb.GetInt() << 20)}
+	if size := Config_GrpcMaxSendMsgSizeMb.GetInt(); size > 0 {
+		call_options = append(call_options, grpc.MaxCallSendMsgSize(size<<20))
+	}
+	options := []grpc.DialOption{grpc.WithDefaultCallOptions(call_options...)}
+	if interval := Config_GrpcKeepaliveTimeSecond.GetInt(); interval > 0 {
+		options = append(options, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Duration(interval) * time.Second,
+			Timeout:             time.Duration(Config_GrpcKeepaliveTimeoutSecond.GetInt()) * time.Second,
+			PermitWithoutStream: Config_GrpcKeepalivePermitWithoutStream.GetBool(),
+		}))
+	}
+	return options
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	NodeLabelSeparator = ","
+	LabelsNone         = "none"
+
+	labelSelector_Equal     = "="
+	labelSelector_NotEqual  = "!="
+	labelSelector_Exists    = "exists"
+	labelSelector_NotExists = "!exists"
+)
+
+var (
+	nodeLabels          sync.Map // the labels each clusnode reports
+	nodeAdminLabels     sync.Map // the labels of each node set on headnode, which override the reported ones with the same keys
+	nodeAdminLabelsLock sync.Mutex
+
+	nodeLabelKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+
+	nodeLabelsValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if _, err := parseNodeLabels(v); err != nil {
+			return err
+		}
+		return nil
+	}
+)
+
+// A selector of node labels in format "key=value", "key!=value", "key" (having the label) or "!key" (not having the label),
+// or of probe values which can be compared as numbers
+type labelSelector struct {
+	key   string
+	op    string
+	value string
+	probe bool // selecting the probe value instead of the label
+}
+
+// Parse the labels in format "key=value[,key=value...]"
+func parseNodeLabels(s string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, label := range strings.Split(s, NodeLabelSeparator) {
+		if label = strings.TrimSpace(label); len(label) == 0 {
+			continue
+		}
+		index := strings.Index(label, "=")
+		if index < 0 {
+			return nil, fmt.Errorf("Invalid label %q, which should be in format key=value", label)
+		}
+		key, value := strings.TrimSpace(label[:index]), strings.TrimSpace(label[index+1:])
+		if err := validateNodeLabel(key, value); err != nil {
+			return nil, err
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+func validateNodeLabelKey(key string) error {
+	if !nodeLabelKeyRegexp.MatchString(key) {
+		return fmt.Errorf("Invalid label key %q, which should consist of letters, digits, \".\", \"_\", \"-\" or \"/\" and start and end with a letter or digit", key)
+	}
+	return nil
+}
+
+func validateNodeLabel(key, value string) error {
+	if err := validateNodeLabelKey(key); err != nil {
+		return err
+	}
+	if strings.ContainsAny(value, "=!"+NodeLabelSeparator) {
+		return fmt.Errorf("Invalid value %q of label %v, which should not contain \"=\", \"!\" or %q", value, key, NodeLabelSeparator)
+	}
+	return nil
+}
+
+func parseLabelSelectors(selectors []string) ([]labelSelector, error) {
+	parsed := make([]labelSelector, 0, len(selectors))
+	for _, s := range selectors {
+		s = strings.TrimSpace(s)
+		selector := labelSelector{key: s, op: labelSelector_Exists}
+		if index := strings.Index(s, labelSelector_NotEqual); index >= 0 {
+			selector = labelSelector{key: strings.TrimSpace(s[:index]), op: labelSelector_NotEqual, value: strings.TrimSpace(s[index+len(labelSelector_NotEqual):])}
+		} else if index := strings.Index(s, labelSelector_Equal); index >= 0 {
+			selector = labelSelector{key: strings.TrimSpace(s[:index]), op: labelSelector_Equal, value: strings.TrimSpace(s[index+len(labelSelector_Equal):])}
+		} else if strings.HasPrefix(s, "!") {
+			selector = labelSelector{key: strings.TrimSpace(s[1:]), op: labelSelector_NotExists}
+		}
+		if err := validateNodeLabel(selector.key, selector.value); err != nil {
+			return nil, fmt.Errorf("Invalid label selector %q: %v", s, err)
+		}
+		parsed = append(parsed, selector)
+	}
+	return parsed, nil
+}
+
+// The node matches if its labels satisfy all the selectors of labels, the values are compared ignoring case
+func matchLabelSelectors(labels map[string]string, selectors []labelSelector) bool {
+	for _, s := range selectors {
+		if s.probe {
+			continue
+		}
+		value, ok := labels[s.key]
+		switch s.op {
+		case labelSelector_Equal:
+			ok = ok && strings.EqualFold(value, s.value)
+		case labelSelector_NotEqual:
+			ok = !ok || !strings.EqualFold(value, s.value)
+		case labelSelector_NotExists:
+			ok = !ok
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func formatNodeLabels(labels map[string]string) string {
+	items := make([]string, 0, len(labels))
+	for key, value := range labels {
+		items = append(items, key+"="+value)
+	}
+	sort.Strings(items)
+	return strings.Join(items, NodeLabelSeparator)
+}
+
+// The labels reported by the node, overridden by the ones set on headnode
+func getNodeLabels(node string) map[string]string {
+	labels := map[string]string{}
+	for _, m := range []*sync.Map{&nodeLabels, &nodeAdminLabels} {
+		if l, ok := m.Load(node); ok {
+			for key, value := range l.(map[string]string) {
+				labels[key] = value
+			}
+		}
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+// Store the labels reported by the clusnode, the invalid ones are ignored
+func storeNodeLabels(display_name string, reported map[string]string) {
+	labels := map[string]string{}
+	for key, value := range reported {
+		if err := validateNodeLabel(key, value); err != nil {
+			LogWarning("Ignore the label reported by %v: %v", display_name, err)
+			continue
+		}
+		labels[key] = value
+	}
+	if l, ok := nodeLabels.Load(display_name); ok && reflect.DeepEqual(l, labels) || !ok && len(labels) == 0 {
+		return
+	}
+	LogInfo("Labels of %v are changed to %q", display_name, formatNodeLabels(labels))
+	if len(labels) == 0 {
+		nodeLabels.Delete(display_name)
+	} else {
+		nodeLabels.Store(display_name, labels)
+	}
+	MarkNodesChanged()
+}
+
+func (s *headnode_server) SetNodeLabels(ctx context.Context, in *pb.SetNodeLabelsRequest) (*pb.Empty, error) {
+	defer LogPanicBeforeExit()
+	nodes, labels, remove := in.GetNodes(), in.GetLabels(), in.GetRemoveLabels()
+	for key, value := range labels {
+		if err := validateNodeLabel(key, value); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	for _, key := range remove {
+		if err := validateNodeLabelKey(key); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	for _, node := range nodes {
+		if _, ok := ReportedNodes.LastReport(node); !ok {
+			return nil, status.Errorf(codes.NotFound, "Node %v is not found", node)
+		}
+	}
+	nodeAdminLabelsLock.Lock()
+	defer nodeAdminLabelsLock.Unlock()
+	for _, node := range nodes {
+		current := map[string]string{}
+		if l, ok := nodeAdminLabels.Load(node); ok {
+			for key, value := range l.(map[string]string) {
+				current[key] = value
+			}
+		}
+		for key, value := range labels {
+			current[key] = value
+		}
+		for _, key := range remove {
+			delete(current, key)
+		}
+		if len(current) == 0 {
+			nodeAdminLabels.Delete(node)
+		} else {
+			nodeAdminLabels.Store(node, current)
+		}
+	}
+	MarkNodesChanged()
+	LogInfo("Labels %q are set and labels %v are removed on nodes: %v", formatNodeLabels(labels), remove, nodes)
+	return &pb.Empty{}, nil
+}
+
+// The labels configured on this clusnode, which are validated when set
+func getConfiguredNodeLabels() map[string]string {
+	labels, _ := parseNodeLabels(Config_Clusnode_Labels.GetString())
+	return labels
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseNodeLabels(t *testing.T) {
+	cases := []struct {
+		labels   string
+		expected map[string]string
+		valid    bool
+	}{
+		{"", map[string]string{}, true},
+		{"os=windows, rack = r1,", map[string]string{"os": "windows", "rack": "r1"}, true},
+		{"gpu=", map[string]string{"gpu": ""}, true},
+		{"gpu", nil, false},
+		{"-os=windows", nil, false},
+		{"os=win=dows", nil, false},
+	}
+	for _, c := range cases {
+		labels, err := parseNodeLabels(c.labels)
+		if c.valid != (err == nil) || c.valid && !reflect.DeepEqual(labels, c.expected) {
+			t.Errorf("Expected %v, %v of %q, got %v, %v", c.expected, c.valid, c.labels, labels, err)
+		}
+	}
+}
+
+func Test_matchLabelSelectors(t *testing.T) {
+	labels := map[string]string{"os": "Windows", "rack": "r1", "gpu": ""}
+	cases := []struct {
+		selectors []string
+		expected  bool
+		valid     bool
+	}{
+		{nil, true, true},
+		{[]string{"os=windows"}, true, true},
+		{[]string{"os=windows", "rack!=r1"}, false, true},
+		{[]string{"rack!=r2", "gpu"}, true, true},
+		{[]string{"zone!=z1"}, true, true},
+		{[]string{"!spot"}, true, true},
+		{[]string{"!gpu"}, false, true},
+		{[]string{"zone"}, false, true},
+		{[]string{"os=="}, false, false},
+		{[]string{"!"}, false, false},
+	}
+	for _, c := range cases {
+		selectors, err := parseLabelSelectors(c.selectors)
+		if c.valid != (err == nil) {
+			t.Errorf("Expected valid %v of %q, got %v", c.valid, c.selectors, err)
+		} else if c.valid && matchLabelSelectors(labels, selectors) != c.expected {
+			t.Errorf("Expected %v of %q matching %v", c.expected, c.selectors, labels)
+		}
+	}
+}
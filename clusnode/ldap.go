@@ -0,0 +1,367 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	ldapTimeout         = 5 * time.Second
+	ldapUserPlaceholder = "{user}"
+	ldapGroupAttribute  = "memberOf"
+
+	// The BER tags of the LDAP messages used
+	berTagInteger        = 0x02
+	berTagOctetString    = 0x04
+	berTagEnumerated     = 0x0a
+	berTagBoolean        = 0x01
+	berTagSequence       = 0x30
+	ldapTagBindRequest   = 0x60
+	ldapTagBindResponse  = 0x61
+	ldapTagSearchRequest = 0x63
+	ldapTagSearchEntry   = 0x64
+	ldapTagSearchDone    = 0x65
+	ldapTagSimpleAuth    = 0x80
+	ldapTagFilterPresent = 0x87
+)
+
+var (
+	ldapUrlValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		_, _, err := parseLdapUrl(v)
+		return err
+	}
+
+	ldapUserDnValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		if len(v) > 0 && !strings.Contains(v, ldapUserPlaceholder) {
+			return fmt.Errorf("Value should contain the placeholder %v", ldapUserPlaceholder)
+		}
+		return nil
+	}
+)
+
+// Authenticate the user by binding to the LDAP server with the password, and get its groups from memberOf attribute
+type ldapAuthProvider struct{}
+
+func (p *ldapAuthProvider) Name() string {
+	return "LDAP"
+}
+
+func (p *ldapAuthProvider) Enabled() bool {
+	return len(Config_Headnode_AuthLdapUrl.GetString()) > 0 && len(Config_Headnode_AuthLdapUserDn.GetString()) > 0
+}
+
+func (p *ldapAuthProvider) Scheme() string {
+	return authBasicPrefix
+}
+
+func (p *ldapAuthProvider) Authenticate(credential string) (*authIdentity, error) {
+	decoded, err := base64.StdEncoding.DecodeString(credential)
+	if err != nil {
+		return nil, errors.New("Invalid basic credential")
+	}
+	i := strings.Index(string(decoded), ":")
+	if i <= 0 {
+		return nil, errors.New("Invalid basic credential")
+	}
+	user, password := string(decoded[:i]), string(decoded[i+1:])
+	if len(password) == 0 {
+		// An LDAP server accepts the bind without password as anonymous
+		return nil, errors.New("Empty password")
+	}
+	dn := strings.ReplaceAll(Config_Headnode_AuthLdapUserDn.GetString(), ldapUserPlaceholder, escapeLdapDnValue(user))
+	groups, err := ldapBindAndGetGroups(Config_Headnode_AuthLdapUrl.GetString(), dn, password)
+	if err != nil {
+		return nil, err
+	}
+	return &authIdentity{User: user, Groups: groups}, nil
+}
+
+// Return the address to dial and whether to use TLS
+func parseLdapUrl(value string) (string, bool, error) {
+	u, err := url.Parse(value)
+	if err != nil || len(u.Hostname()) == 0 {
+		return "", false, errors.New("Value should be an ldap or ldaps URL")
+	}
+	switch u.Scheme {
+	case "ldap":
+		if len(u.Port()) == 0 {
+			return net.JoinHostPort(u.Hostname(), "389"), false, nil
+		}
+		return u.Host, false, nil
+	case "ldaps":
+		if len(u.Port()) == 0 {
+			return net.JoinHostPort(u.Hostname(), "636"), true, nil
+		}
+		return u.Host, true, nil
+	}
+	return "", false, errors.New("Value should be an ldap or ldaps URL")
+}
+
+// Escape the special characters in an attribute value of DN as RFC 4514
+func escapeLdapDnValue(value string) string {
+	var b strings.Builder
+	for i, c := range value {
+		switch {
+		case strings.ContainsRune(`,+"\<>;=`, c),
+			i == 0 && (c == ' ' || c == '#'),
+			i == len(value)-1 && c == ' ':
+			b.WriteRune('\\')
+			b.WriteRune(c)
+		case c == 0:
+			b.WriteString(`\00`)
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// Get the common names of groups from their DNs, e.g. "admins" from "cn=admins,ou=groups,dc=example,dc=com"
+func getLdapGroupNames(dns []string) []string {
+	groups := make([]string, 0, len(dns))
+	for _, dn := range dns {
+		rdn := strings.SplitN(dn, ",", 2)[0]
+		if i := strings.Index(rdn, "="); i >= 0 {
+			rdn = rdn[i+1:]
+		}
+		if rdn = strings.TrimSpace(rdn); len(rdn) > 0 {
+			groups = append(groups, rdn)
+		}
+	}
+	return groups
+}
+
+func ldapBindAndGetGroups(ldap_url, dn, password string) ([]string, error) {
+	address, use_tls, err := parseLdapUrl(ldap_url)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: ldapTimeout}
+	var conn net.Conn
+	if use_tls {
+		server_name, _, _ := net.SplitHostPort(address)
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: server_name})
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to connect LDAP server: %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(ldapTimeout))
+	r := bufio.NewReader(conn)
+
+	// Bind as the user
+	bind := berEncode(ldapTagBindRequest, berInt(berTagInteger, 3), berEncode(berTagOctetString, []byte(dn)), berEncode(ldapTagSimpleAuth, []byte(password)))
+	if _, err := conn.Write(ldapMessage(1, bind)); err != nil {
+		return nil, err
+	}
+	tag, op, err := readLdapMessage(r)
+	if err != nil {
+		return nil, err
+	}
+	if tag != ldapTagBindResponse {
+		return nil, fmt.Errorf("Unexpected LDAP response 0x%x", tag)
+	}
+	if code, message, err := parseLdapResult(op); err != nil {
+		return nil, err
+	} else if code != 0 {
+		return nil, fmt.Errorf("LDAP bind failed with code %v: %v", code, message)
+	}
+
+	// Search the groups of the user in its own entry
+	search := berEncode(ldapTagSearchRequest,
+		berEncode(berTagOctetString, []byte(dn)),
+		berInt(berTagEnumerated, 0), // base object
+		berInt(berTagEnumerated, 0), // never deref aliases
+		berInt(berTagInteger, 0),
+		berInt(berTagInteger, 0),
+		berEncode(berTagBoolean, []byte{0}),
+		berEncode(ldapTagFilterPresent, []byte("objectClass")),
+		berEncode(berTagSequence, berEncode(berTagOctetString, []byte(ldapGroupAttribute))))
+	if _, err := conn.Write(ldapMessage(2, search)); err != nil {
+		return nil, err
+	}
+	var dns []string
+	for {
+		tag, op, err := readLdapMessage(r)
+		if err != nil {
+			return nil, err
+		}
+		switch tag {
+		case ldapTagSearchEntry:
+			values, err := parseLdapEntryAttribute(op, ldapGroupAttribute)
+			if err != nil {
+				return nil, err
+			}
+			dns = append(dns, values...)
+		case ldapTagSearchDone:
+			if code, message, err := parseLdapResult(op); err != nil {
+				return nil, err
+			} else if code != 0 {
+				return nil, fmt.Errorf("LDAP search failed with code %v: %v", code, message)
+			}
+			return getLdapGroupNames(dns), nil
+		}
+	}
+}
+
+func ldapMessage(id int, op []byte) []byte {
+	return berEncode(berTagSequence, berInt(berTagInteger, id), op)
+}
+
+// Read an LDAP message and return the tag and content of its protocol operation
+func readLdapMessage(r *bufio.Reader) (byte, []byte, error) {
+	tag, content, err := berRead(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	if tag != berTagSequence {
+		return 0, nil, errors.New("Invalid LDAP message")
+	}
+	items, err := berSplit(content)
+	if err != nil || len(items) < 2 {
+		return 0, nil, errors.New("Invalid LDAP message")
+	}
+	return items[1].tag, items[1].content, nil
+}
+
+// Parse the result code and diagnostic message of LDAPResult
+func parseLdapResult(content []byte) (int, string, error) {
+	items, err := berSplit(content)
+	if err != nil || len(items) < 3 || items[0].tag != berTagEnumerated {
+		return 0, "", errors.New("Invalid LDAP result")
+	}
+	code := 0
+	for _, b := range items[0].content {
+		code = code<<8 | int(b)
+	}
+	return code, string(items[2].content), nil
+}
+
+// Get the values of the attribute in a search result entry
+func parseLdapEntryAttribute(content []byte, attribute string) ([]string, error) {
+	items, err := berSplit(content)
+	if err != nil || len(items) < 2 {
+		return nil, errors.New("Invalid LDAP search entry")
+	}
+	attributes, err := berSplit(items[1].content)
+	if err != nil {
+		return nil, errors.New("Invalid LDAP search entry")
+	}
+	var values []string
+	for _, a := range attributes {
+		fields, err := berSplit(a.content)
+		if err != nil || len(fields) < 2 {
+			return nil, errors.New("Invalid LDAP attribute")
+		}
+		if !strings.EqualFold(string(fields[0].content), attribute) {
+			continue
+		}
+		vals, err := berSplit(fields[1].content)
+		if err != nil {
+			return nil, errors.New("Invalid LDAP attribute values")
+		}
+		for _, v := range vals {
+			values = append(values, string(v.content))
+		}
+	}
+	return values, nil
+}
+
+type berItem struct {
+	tag     byte
+	content []byte
+}
+
+func berEncode(tag byte, contents ...[]byte) []byte {
+	var content []byte
+	for _, c := range contents {
+		content = append(content, c...)
+	}
+	b := []byte{tag}
+	if n := len(content); n < 0x80 {
+		b = append(b, byte(n))
+	} else {
+		var length []byte
+		for ; n > 0; n >>= 8 {
+			length = append([]byte{byte(n)}, length...)
+		}
+		b = append(b, 0x80|byte(len(length)))
+		b = append(b, length...)
+	}
+	return append(b, content...)
+}
+
+// Encode a non-negative integer in the minimal two's complement form
+func berInt(tag byte, value int) []byte {
+	b := []byte{byte(value)}
+	for value >>= 8; value > 0; value >>= 8 {
+		b = append([]byte{byte(value)}, b...)
+	}
+	if b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return berEncode(tag, b)
+}
+
+func berRead(r io.Reader) (byte, []byte, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+	length := int(header[1])
+	if length&0x80 != 0 {
+		size := length & 0x7f
+		if size == 0 || size > 4 {
+			return 0, nil, errors.New("Unsupported BER length")
+		}
+		b := make([]byte, size)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return 0, nil, err
+		}
+		length = 0
+		for _, c := range b {
+			length = length<<8 | int(c)
+		}
+	}
+	content := make([]byte, length)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return 0, nil, err
+	}
+	return header[0], content, nil
+}
+
+// Split the content of a constructed BER item to its elements
+func berSplit(content []byte) ([]berItem, error) {
+	var items []berItem
+	r := bytes.NewReader(content)
+	for r.Len() > 0 {
+		tag, c, err := berRead(r)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, berItem{tag, c})
+	}
+	return items, nil
+}
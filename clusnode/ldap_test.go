@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_escapeLdapDnValue(t *testing.T) {
+	cases := map[string]string{
+		"alice":        "alice",
+		"a,b=c":        `a\,b\=c`,
+		" #bob ":       `\ #bob\ `,
+		`x+y"z\<>;`:    `x\+y\"z\\\<\>\;`,
+		"#":            `\#`,
+		"cn=admins,dc": `cn\=admins\,dc`,
+	}
+	for value, expected := range cases {
+		if escaped := escapeLdapDnValue(value); escaped != expected {
+			t.Errorf("\nvalue=%q\nexpected=%q\n  actual=%q", value, expected, escaped)
+		}
+	}
+	if groups := getLdapGroupNames([]string{"cn=Admins,ou=groups,dc=example,dc=com", "ops", "cn=,dc=com"}); !reflect.DeepEqual(groups, []string{"Admins", "ops"}) {
+		t.Errorf("unexpected group names: %v", groups)
+	}
+}
+
+func Test_berEncode(t *testing.T) {
+	message := ldapMessage(1, berEncode(ldapTagBindResponse, berInt(berTagEnumerated, 49), berEncode(berTagOctetString), berEncode(berTagOctetString, []byte(strings.Repeat("x", 200)))))
+	tag, op, err := readLdapMessage(bufio.NewReader(bytes.NewReader(message)))
+	if err != nil || tag != ldapTagBindResponse {
+		t.Fatalf("failed to read message: %v, 0x%x", err, tag)
+	}
+	if code, diagnostic, err := parseLdapResult(op); err != nil || code != 49 || len(diagnostic) != 200 {
+		t.Errorf("unexpected result: %v, %v, %v", code, len(diagnostic), err)
+	}
+	if b := berInt(berTagInteger, 128); !reflect.DeepEqual(b, []byte{berTagInteger, 2, 0, 128}) {
+		t.Errorf("unexpected encoded integer: %v", b)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"clusrun/clusnode/platform"
+	pb "clusrun/protobuf"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+const (
+	TimeoutExitCode = 124 // the exit code of the job killed by timeout, which is the same as the one of GNU timeout
+
+	reservationGroup = "clusrun"
+	maxJobNice       = 19
+)
+
+// Get the limits of resources shared by all jobs, which are the remainder after reserving for the host
+func getReservationLimits(cpu_percent, memory_mb int, memory_total int64) (platform.ResourceLimits, error) {
+	limits := platform.ResourceLimits{}
+	if cpu_percent > 0 {
+		limits.CpuPercent = 100 - cpu_percent
+	}
+	if memory_mb > 0 {
+		if memory_total <= 0 {
+			return limits, errors.New("Total memory is unknown")
+		}
+		reserved := int64(memory_mb) << 20
+		if reserved >= memory_total {
+			return limits, fmt.Errorf("Reserved memory %v MB is not less than total memory %v MB", memory_mb, memory_total>>20)
+		}
+		limits.MemoryBytes = memory_total - reserved
+	}
+	return limits, nil
+}
+
+// The holder keeps the bash command waiting until its process is capped, so that the children of it are created in the cgroup
+type processHolder struct {
+	reader, writer *os.File
+}
+
+// Get the limits of the job, the limits not specified in the request are the defaults of this node
+func getJobLimits(in *pb.JobLimits) platform.ResourceLimits {
+	return mergeJobLimits(in, Config_Clusnode_JobCpuPercent.GetInt(), Config_Clusnode_JobMemoryMb.GetInt())
+}
+
+func mergeJobLimits(in *pb.JobLimits, default_cpu_percent, default_memory_mb int) platform.ResourceLimits {
+	limits := platform.ResourceLimits{CpuPercent: int(in.GetCpuPercent()), MemoryBytes: in.GetMemoryMb() << 20}
+	if limits.CpuPercent <= 0 {
+		limits.CpuPercent = default_cpu_percent
+	} else if limits.CpuPercent > 100 {
+		limits.CpuPercent = 100
+	}
+	if limits.MemoryBytes <= 0 {
+		limits.MemoryBytes = int64(default_memory_mb) << 20
+	}
+	return limits
+}
+
+// The job is killed after its timeout, or the default timeout of this node if not specified
+func getJobTimeout(in *pb.JobLimits) time.Duration {
+	return mergeJobTimeout(in, Config_Clusnode_JobTimeoutSecond.GetInt())
+}
+
+func mergeJobTimeout(in *pb.JobLimits, default_timeout_second int) time.Duration {
+	if timeout := in.GetTimeoutSecond(); timeout > 0 {
+		return time.Duration(timeout) * time.Second
+	}
+	return time.Duration(default_timeout_second) * time.Second
+}
+
+// The priority of a job can only be lowered, so that it does not take precedence over the other processes on the nodes
+func validateJobPriority(in *pb.JobLimits) error {
+	if nice := in.GetNice(); nice < 0 || nice > maxJobNice {
+		return fmt.Errorf("Invalid nice level %v, should be between 0 and %v", nice, maxJobNice)
+	}
+	if io := in.GetIoPriority(); len(io) > 0 && io != platform.IoPriority_Low && io != platform.IoPriority_Idle {
+		return fmt.Errorf("Invalid I/O priority %q, should be %v or %v", io, platform.IoPriority_Low, platform.IoPriority_Idle)
+	}
+	return nil
+}
+
+// Get the priority of the processes of job, the invalid values from a headnode not validating them are ignored
+func getJobPriority(in *pb.JobLimits) platform.ProcessPriority {
+	if validateJobPriority(in) != nil {
+		return platform.ProcessPriority{}
+	}
+	return platform.ProcessPriority{Nice: int(in.GetNice()), Io: in.GetIoPriority()}
+}
+
+// Lower the priority of the process of job, which is inherited by the processes it creates
+func prioritizeJobProcess(job_label string, pid int, priority platform.ProcessPriority) error {
+	if err := platform.SetProcessPriority(pid, priority); err != nil {
+		LogError("Failed to set priority of job %v: %v", job_label, err)
+		return err
+	}
+	LogInfo("Job %v is set to nice level %v and I/O priority %q", job_label, priority.Nice, priority.Io)
+	return nil
+}
+
+// Check if the process of job should be capped by the resources reserved for the host or the limits of the job
+func isJobLimited(job_limits platform.ResourceLimits) bool {
+	return Config_Clusnode_ReservedCpuPercent.GetInt() > 0 || Config_Clusnode_ReservedMemoryMb.GetInt() > 0 || job_limits.CpuPercent > 0 || job_limits.MemoryBytes > 0
+}
+
+// Make the bash command read from an extra pipe before executing itself in the same process
+func holdCommand(cmd *exec.Cmd) (*processHolder, error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Args = append([]string{cmd.Args[0], "-c", `read -u 3; exec 3<&- "$0" "$@"`, cmd.Path}, cmd.Args[1:]...)
+	cmd.ExtraFiles = append(cmd.ExtraFiles, reader)
+	return &processHolder{reader, writer}, nil
+}
+
+func (h *processHolder) Started() {
+	h.reader.Close()
+}
+
+func (h *processHolder) Release() {
+	h.writer.Close()
+}
+
+// Cap the process of job to its limits within the resources not reserved for the host, which are shared by all jobs
+func limitJobProcess(job_label string, pid int, job_limits platform.ResourceLimits) error {
+	cpu_percent, memory_mb := Config_Clusnode_ReservedCpuPercent.GetInt(), Config_Clusnode_ReservedMemoryMb.GetInt()
+	limits, err := getReservationLimits(cpu_percent, memory_mb, platform.GetResourceUsage(filepath.Dir(ExecutablePath)).MemoryTotal)
+	if err == nil {
+		// The CPU of a cgroup can not exceed the one of its parent in cgroup v1
+		if limits.CpuPercent > 0 && (job_limits.CpuPercent == 0 || job_limits.CpuPercent > limits.CpuPercent) {
+			job_limits.CpuPercent = limits.CpuPercent
+		}
+		err = platform.LimitProcess(reservationGroup, job_label, limits, job_limits, pid)
+	}
+	if err != nil {
+		LogError("Failed to cap job %v: %v", job_label, err)
+		return err
+	}
+	LogInfo("Job %v is capped to %v%% CPU and %v bytes memory, and with all jobs to %v%% CPU and %v bytes memory (0 for unlimited)", job_label, job_limits.CpuPercent, job_limits.MemoryBytes, limits.CpuPercent, limits.MemoryBytes)
+	return nil
+}
+
+func releaseJobProcessLimit(job_label string) {
+	if err := platform.ReleaseProcessLimit(reservationGroup, job_label); err != nil {
+		LogWarning("Failed to release limits of job %v: %v", job_label, err)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"clusrun/clusnode/platform"
+	pb "clusrun/protobuf"
+	"testing"
+	"time"
+)
+
+func Test_getReservationLimits(t *testing.T) {
+	cases := []struct {
+		cpu_percent  int
+		memory_mb    int
+		memory_total int64
+		cpu_limit    int
+		memory_limit int64
+		valid        bool
+	}{
+		{0, 0, 8 << 30, 0, 0, true},
+		{20, 0, 8 << 30, 80, 0, true},
+		{0, 1024, 8 << 30, 0, 7 << 30, true},
+		{50, 512, 1 << 30, 50, 512 << 20, true},
+		{10, 0, -1, 90, 0, true},
+		{0, 1024, -1, 0, 0, false},
+		{0, 8192, 8 << 30, 0, 0, false},
+	}
+
+	for _, c := range cases {
+		limits, err := getReservationLimits(c.cpu_percent, c.memory_mb, c.memory_total)
+		if (err == nil) != c.valid {
+			t.Errorf("\ncpu=%v, memory=%v, total=%v\nexpected valid=%v\n  actual error=%v", c.cpu_percent, c.memory_mb, c.memory_total, c.valid, err)
+		} else if c.valid && (limits.CpuPercent != c.cpu_limit || limits.MemoryBytes != c.memory_limit) {
+			t.Errorf("\ncpu=%v, memory=%v, total=%v\nexpected: %v%%, %v bytes\n  actual: %v%%, %v bytes", c.cpu_percent, c.memory_mb, c.memory_total, c.cpu_limit, c.memory_limit, limits.CpuPercent, limits.MemoryBytes)
+		}
+	}
+}
+
+func Test_mergeJobLimits(t *testing.T) {
+	cases := []struct {
+		limits         *pb.JobLimits
+		default_cpu    int
+		default_memory int
+		expected       platform.ResourceLimits
+	}{
+		{nil, 0, 0, platform.ResourceLimits{}},
+		{nil, 50, 1024, platform.ResourceLimits{CpuPercent: 50, MemoryBytes: 1 << 30}},
+		{&pb.JobLimits{CpuPercent: 20}, 50, 1024, platform.ResourceLimits{CpuPercent: 20, MemoryBytes: 1 << 30}},
+		{&pb.JobLimits{MemoryMb: 2048}, 50, 1024, platform.ResourceLimits{CpuPercent: 50, MemoryBytes: 2 << 30}},
+		{&pb.JobLimits{CpuPercent: 200, MemoryMb: 1}, 0, 0, platform.ResourceLimits{CpuPercent: 100, MemoryBytes: 1 << 20}},
+	}
+
+	for _, c := range cases {
+		if actual := mergeJobLimits(c.limits, c.default_cpu, c.default_memory); actual != c.expected {
+			t.Errorf("\nlimits=%v, default cpu=%v, default memory=%v\nexpected: %+v\n  actual: %+v", c.limits, c.default_cpu, c.default_memory, c.expected, actual)
+		}
+	}
+}
+
+func Test_mergeJobTimeout(t *testing.T) {
+	cases := []struct {
+		limits          *pb.JobLimits
+		default_timeout int
+		expected        time.Duration
+	}{
+		{nil, 0, 0},
+		{nil, 60, time.Minute},
+		{&pb.JobLimits{CpuPercent: 20}, 60, time.Minute},
+		{&pb.JobLimits{TimeoutSecond: 10}, 60, 10 * time.Second},
+		{&pb.JobLimits{TimeoutSecond: 10}, 0, 10 * time.Second},
+	}
+	for _, c := range cases {
+		if actual := mergeJobTimeout(c.limits, c.default_timeout); actual != c.expected {
+			t.Errorf("Expected timeout %v of limits %v with default %v, got %v", c.expected, c.limits, c.default_timeout, actual)
+		}
+	}
+}
+
+func Test_getJobPriority(t *testing.T) {
+	cases := []struct {
+		limits      *pb.JobLimits
+		expected    platform.ProcessPriority
+		expectError bool
+	}{
+		{nil, platform.ProcessPriority{}, false},
+		{&pb.JobLimits{Nice: 10}, platform.ProcessPriority{Nice: 10}, false},
+		{&pb.JobLimits{Nice: 19, IoPriority: "idle"}, platform.ProcessPriority{Nice: 19, Io: platform.IoPriority_Idle}, false},
+		{&pb.JobLimits{IoPriority: "low"}, platform.ProcessPriority{Io: platform.IoPriority_Low}, false},
+		{&pb.JobLimits{Nice: -1}, platform.ProcessPriority{}, true},
+		{&pb.JobLimits{Nice: 20}, platform.ProcessPriority{}, true},
+		{&pb.JobLimits{IoPriority: "realtime"}, platform.ProcessPriority{}, true},
+	}
+	for _, c := range cases {
+		if err := validateJobPriority(c.limits); (err != nil) != c.expectError {
+			t.Errorf("Expected error %v of limits %v, got %v", c.expectError, c.limits, err)
+		}
+		if priority := getJobPriority(c.limits); priority != c.expected {
+			t.Errorf("Expected priority %+v of limits %v, got %+v", c.expected, c.limits, priority)
+		}
+	}
+}
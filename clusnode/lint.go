@@ -0,0 +1,181 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	LintRuleSweepCollision   = "sweep-collision"
+	LintRuleLargeJob         = "large-job"
+	LintRuleCheckpointUnused = "checkpoint-unused"
+	LintRulesAll             = "all"
+	LintRulesNone            = "none"
+	LintRulesSeparator       = ","
+)
+
+// The spec of a job to lint before it is created
+type jobLintInput struct {
+	Command        string
+	Arguments      []string
+	NodeCommands   map[string]string
+	Sweeps         []sweepParameter
+	Nodes          int
+	ShipCheckpoint bool
+	MaxReschedules int32
+	FailFast       *pb.FailFast
+	Rolling        *pb.RollingPolicy
+}
+
+type jobLintRule struct {
+	name  string
+	check func(input *jobLintInput) []string
+}
+
+var (
+	jobLintRules = []jobLintRule{
+		{LintRuleSweepCollision, lintSweepCollision},
+		{LintRuleLargeJob, lintLargeJob},
+		{LintRuleCheckpointUnused, lintCheckpointUnused},
+	}
+
+	lintRulesValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		_, err := parseLintRules(v)
+		return err
+	}
+)
+
+func parseLintRules(value string) (map[string]bool, error) {
+	rules := map[string]bool{}
+	for _, rule := range strings.Split(value, LintRulesSeparator) {
+		if rule = strings.ToLower(strings.TrimSpace(rule)); len(rule) == 0 {
+			continue
+		}
+		if rule == LintRulesAll {
+			for _, r := range jobLintRules {
+				rules[r.name] = true
+			}
+			continue
+		}
+		valid := false
+		for _, r := range jobLintRules {
+			valid = valid || r.name == rule
+		}
+		if !valid {
+			return nil, fmt.Errorf("Invalid lint rule %q, should be one of: %v", rule, strings.Join(getLintRuleNames(), ", "))
+		}
+		rules[rule] = true
+	}
+	return rules, nil
+}
+
+func getLintRuleNames() []string {
+	names := []string{LintRulesAll}
+	for _, r := range jobLintRules {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+// Check the job against all lint rules, and return the warnings and whether any of them blocks the job
+func lintJob(input *jobLintInput, blocking map[string]bool) ([]*pb.JobLintWarning, bool) {
+	var warnings []*pb.JobLintWarning
+	blocked := false
+	for _, rule := range jobLintRules {
+		for _, message := range rule.check(input) {
+			warnings = append(warnings, &pb.JobLintWarning{Rule: rule.name, Message: message})
+			blocked = blocked || blocking[rule.name]
+		}
+	}
+	return warnings, blocked
+}
+
+// A placeholder made of word characters is replaced even inside a longer word, e.g. "n" in "echo node-n" replaces "node" too
+func lintSweepCollision(input *jobLintInput) []string {
+	texts := append([]string{input.Command}, input.Arguments...)
+	for _, c := range input.NodeCommands {
+		texts = append(texts, c)
+	}
+	var messages []string
+	for _, s := range input.Sweeps {
+		if strings.IndexFunc(s.placeholder, func(r rune) bool { return !isWordRune(r) }) >= 0 {
+			continue
+		}
+		words := map[string]bool{}
+		for _, text := range texts {
+			for _, word := range findWordsContaining(text, s.placeholder) {
+				words[word] = true
+			}
+		}
+		if len(words) == 0 {
+			continue
+		}
+		collisions := make([]string, 0, len(words))
+		for word := range words {
+			collisions = append(collisions, fmt.Sprintf("%q", word))
+		}
+		sort.Strings(collisions)
+		messages = append(messages, fmt.Sprintf("Sweep placeholder %q is also replaced inside %v, use a distinct placeholder like \"{%v}\"", s.placeholder, strings.Join(collisions, ", "), s.placeholder))
+	}
+	return messages
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Find the words longer than the placeholder in which it occurs
+func findWordsContaining(text, placeholder string) []string {
+	var words []string
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], placeholder)
+		if i < 0 {
+			return words
+		}
+		begin, end := offset+i, offset+i+len(placeholder)
+		for r, size := utf8.DecodeLastRuneInString(text[:begin]); size > 0 && isWordRune(r); r, size = utf8.DecodeLastRuneInString(text[:begin]) {
+			begin -= size
+		}
+		for r, size := utf8.DecodeRuneInString(text[end:]); size > 0 && isWordRune(r); r, size = utf8.DecodeRuneInString(text[end:]) {
+			end += size
+		}
+		if end-begin > len(placeholder) {
+			words = append(words, text[begin:end])
+		}
+		offset += i + len(placeholder)
+	}
+}
+
+// A wrong command fails on all nodes of a large job, unless the job stops by fail fast or rolls in batches
+func lintLargeJob(input *jobLintInput) []string {
+	max := Config_Headnode_LintLargeJobNodes.GetInt()
+	if input.Nodes <= max || input.FailFast.GetMaxFailures() > 0 || input.FailFast.GetMaxFailurePercent() > 0 || input.Rolling.GetBatchSize() > 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("Job targets %v nodes (more than %v) without fail fast or rolling batches, a wrong command fails on all of them", input.Nodes, max)}
+}
+
+// The checkpoint snapshots are only shipped to the nodes which tasks are rescheduled to
+func lintCheckpointUnused(input *jobLintInput) []string {
+	if input.ShipCheckpoint && input.MaxReschedules == 0 {
+		return []string{"Checkpoint is shipped only when a task is rescheduled, but max reschedules is 0"}
+	}
+	return nil
+}
+
+func formatLintWarnings(warnings []*pb.JobLintWarning) string {
+	messages := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		messages = append(messages, fmt.Sprintf("[%v] %v", w.Rule, w.Message))
+	}
+	return strings.Join(messages, "; ")
+}
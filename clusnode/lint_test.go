@@ -0,0 +1,46 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"testing"
+)
+
+func Test_lintJob(t *testing.T) {
+	sweeps, err := parseSweeps("n{1,2};{i}", 2)
+	if err != nil {
+		t.Fatalf("failed to parse sweeps: %v", err)
+	}
+	words := findWordsContaining("echo node-n n_1 ñn n", "n")
+	if !reflect.DeepEqual(words, []string{"node", "n_1", "ñn"}) {
+		t.Errorf("unexpected words containing placeholder: %v", words)
+	}
+
+	input := &jobLintInput{Command: "echo {i} node-n", Sweeps: sweeps, Nodes: Config_Headnode_LintLargeJobNodes.GetInt() + 1, ShipCheckpoint: true}
+	warnings, blocked := lintJob(input, nil)
+	rules := []string{}
+	for _, w := range warnings {
+		rules = append(rules, w.Rule)
+	}
+	if expected := []string{LintRuleSweepCollision, LintRuleLargeJob, LintRuleCheckpointUnused}; !reflect.DeepEqual(rules, expected) || blocked {
+		t.Errorf("\nexpected=%v, false\n  actual=%v, %v", expected, rules, blocked)
+	}
+	blocking, err := parseLintRules(LintRuleLargeJob)
+	if err != nil {
+		t.Fatalf("failed to parse lint rules: %v", err)
+	}
+	if _, blocked := lintJob(input, blocking); !blocked {
+		t.Errorf("job is not blocked by rule %v", LintRuleLargeJob)
+	}
+
+	input = &jobLintInput{Command: "echo {i} n", Sweeps: sweeps, Nodes: input.Nodes, FailFast: &pb.FailFast{MaxFailures: 1}, ShipCheckpoint: true, MaxReschedules: 1}
+	if warnings, _ := lintJob(input, blocking); len(warnings) > 0 {
+		t.Errorf("unexpected warnings: %v", formatLintWarnings(warnings))
+	}
+	if rules, err := parseLintRules(" All "); err != nil || len(rules) != len(jobLintRules) {
+		t.Errorf("failed to parse all lint rules: %v, %v", rules, err)
+	}
+	if _, err := parseLintRules("unknown"); err == nil {
+		t.Errorf("unknown lint rule is parsed")
+	}
+}
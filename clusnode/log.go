@@ -1,23 +1,43 @@
 package main
 
 import (
+	"clusrun/clusnode/platform"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"runtime"
+	"os"
+	"path/filepath"
 	"runtime/debug"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func LogInfo(format string, v ...interface{}) {
-	writeLog(logLevel_Info, format, v...)
+	rootLogger.Info(format, v...)
+}
+
+func LogDebug(format string, v ...interface{}) {
+	rootLogger.Debug(format, v...)
 }
 
 func LogWarning(format string, v ...interface{}) {
-	writeLog(logLevel_Warning, format, v...)
+	rootLogger.Warning(format, v...)
 }
 
 func LogError(format string, v ...interface{}) {
-	writeLog(logLevel_Error, format, v...)
+	rootLogger.Error(format, v...)
 }
 
 func LogFatality(format string, v ...interface{}) {
@@ -35,26 +55,428 @@ func LogPanicBeforeExit() {
 type logLevel string
 
 const (
+	logLevel_Debug   = "Debug"
 	logLevel_Info    = "Info"
 	logLevel_Warning = "Warning"
 	logLevel_Error   = "Error"
 )
 
-func writeLog(level logLevel, format string, v ...interface{}) {
-	prefix := fmt.Sprintf("| %v | ", level)
-	if Config_LogGoId.GetBool() {
-		prefix += fmt.Sprintf("%v | ", currentGoId())
+const (
+	systemLogLevel_None = "none"
+	systemLogSource     = "clusnode"
+
+	LogFormat_Text     = "text"
+	LogFormat_KeyValue = "keyvalue"
+	LogFormat_Json     = "json"
+
+	logTimeFormat            = "2006/01/02 15:04:05"
+	logRotateTimeFormat      = "20060102150405"
+	requestIdMetadataKey     = "x-request-id"
+	correlationIdMetadataKey = "x-correlation-id"
+	logIdSize                = 8
+	logIdMaxLength           = 64
+	logFieldRequestId        = "request"
+	logFieldCorrelationId    = "correlation"
+	logFieldJob              = "job"
+	logFieldNode             = "node"
+)
+
+var (
+	systemLogLevels  = []string{systemLogLevel_None, strings.ToLower(logLevel_Error), strings.ToLower(logLevel_Warning), strings.ToLower(logLevel_Info), strings.ToLower(logLevel_Debug)}
+	logLevels        = systemLogLevels[1:]
+	logFormats       = []string{LogFormat_Text, LogFormat_KeyValue, LogFormat_Json}
+	systemLogger     platform.SystemLogger
+	systemLoggerOnce sync.Once
+
+	rootLogger  = &Logger{}
+	logOutput   = &rotatingLogFile{}
+	logSamplers sync.Map // the sampler of each frequently polled RPC
+
+	// The min levels of logs to write, which are updated once the configs are set instead of parsed for each log
+	minLogLevel       = int32(getSystemLogLevel(logLevel_Info))
+	minSystemLogLevel = int32(getSystemLogLevel(systemLogLevel_None))
+
+	logLevelValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if getSystemLogLevel(v) <= 0 {
+			return fmt.Errorf("Value should be one of: %v", strings.Join(logLevels, ", "))
+		}
+		return nil
+	}
+	logFormatValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if !isInList(v, logFormats) {
+			return fmt.Errorf("Value should be one of: %v", strings.Join(logFormats, ", "))
+		}
+		return nil
+	}
+)
+
+type logField struct {
+	key   string
+	value interface{}
+}
+
+// A logger writes structured logs with the fields attached to it, e.g. the correlation id of a request
+type Logger struct {
+	fields []logField
+}
+
+// Return a new logger with the field attached in addition to the fields of this logger
+func (l *Logger) With(key string, value interface{}) *Logger {
+	fields := make([]logField, len(l.fields), len(l.fields)+1)
+	copy(fields, l.fields)
+	return &Logger{fields: append(fields, logField{key, value})}
+}
+
+func (l *Logger) Debug(format string, v ...interface{}) {
+	writeLog(logLevel_Debug, l.fields, format, v...)
+}
+
+func (l *Logger) Info(format string, v ...interface{}) {
+	writeLog(logLevel_Info, l.fields, format, v...)
+}
+
+func (l *Logger) Warning(format string, v ...interface{}) {
+	writeLog(logLevel_Warning, l.fields, format, v...)
+}
+
+func (l *Logger) Error(format string, v ...interface{}) {
+	writeLog(logLevel_Error, l.fields, format, v...)
+}
+
+// Open the log file to write logs, which is rotated by size or time as configured, logs are written to stderr before it is opened
+func OpenLogFile(path string) error {
+	if err := logOutput.open(path); err != nil {
+		return err
 	}
-	log.Printf(prefix+format+LineEnding, v...)
+	log.SetOutput(logOutput)
+	return nil
 }
 
-// Low performance
-func currentGoId() string {
-	buf := make([]byte, 32)
-	runtime.Stack(buf, false)
-	s := strings.Fields(string(buf))
-	if len(s) > 1 {
-		return s[1]
+func CloseLogFile() {
+	logOutput.close()
+}
+
+// The message is not formatted unless it is written, so that the large payloads logged at debug level cost nothing by default
+func writeLog(level logLevel, fields []logField, format string, v ...interface{}) {
+	l := int32(getSystemLogLevel(string(level)))
+	to_file, to_system := l <= atomic.LoadInt32(&minLogLevel), l <= atomic.LoadInt32(&minSystemLogLevel)
+	if !to_file && !to_system {
+		return
+	}
+	message := fmt.Sprintf(format, v...)
+	if to_file {
+		_, _ = logOutput.Write([]byte(formatLog(time.Now(), level, fields, message, Config_LogFormat.GetString())))
+	}
+	if to_system {
+		writeSystemLog(level, message)
+	}
+}
+
+// Count the calls of a frequently polled RPC, whose summary is logged at most once in the sample interval instead of on each call
+type logSampler struct {
+	lock  sync.Mutex
+	last  time.Time
+	calls int
+}
+
+// Return the count of calls since the last sampled one if this call is sampled
+func (s *logSampler) sample(now time.Time, interval time.Duration) (int, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.calls++
+	if !s.last.IsZero() && now.Sub(s.last) < interval {
+		return 0, false
+	}
+	calls := s.calls
+	s.last, s.calls = now, 0
+	return calls, true
+}
+
+// Log the summary of the RPC call at info level if it is sampled, with the count of calls since the last summary
+func LogSampled(rpc, format string, v ...interface{}) {
+	s, _ := logSamplers.LoadOrStore(rpc, &logSampler{})
+	interval := time.Duration(Config_LogSampleIntervalSecond.GetInt()) * time.Second
+	if calls, ok := s.(*logSampler).sample(time.Now(), interval); !ok {
+		return
+	} else if calls > 1 {
+		LogInfo("%v %v (%v calls since the last summary)", rpc, fmt.Sprintf(format, v...), calls)
+	} else {
+		LogInfo("%v %v", rpc, fmt.Sprintf(format, v...))
+	}
+}
+
+func watchLogConfigs() {
+	NodeConfigs.Subscribe(func(changes map[*ConfigItem]interface{}) {
+		if v, ok := changes[&Config_LogLevel]; ok {
+			atomic.StoreInt32(&minLogLevel, int32(getSystemLogLevel(v.(string))))
+		}
+		if v, ok := changes[&Config_SystemLogLevel]; ok {
+			atomic.StoreInt32(&minSystemLogLevel, int32(getSystemLogLevel(v.(string))))
+		}
+	}, &Config_LogLevel, &Config_SystemLogLevel)
+}
+
+// Format a log line in text like "2020/04/15 18:26:22 | Info | message | key=value", or key=value pairs, or JSON
+func formatLog(t time.Time, level logLevel, fields []logField, message, format string) string {
+	switch format {
+	case LogFormat_Json:
+		entry := map[string]interface{}{"time": t.Format(time.RFC3339Nano), "level": strings.ToLower(string(level)), "msg": message}
+		for _, f := range fields {
+			entry[f.key] = f.value
+		}
+		b, err := json.Marshal(entry)
+		if err != nil {
+			b, _ = json.Marshal(map[string]interface{}{"time": entry["time"], "level": entry["level"], "msg": message})
+		}
+		return string(b) + LineEnding
+	case LogFormat_KeyValue:
+		var b strings.Builder
+		b.WriteString("time=" + t.Format(time.RFC3339Nano))
+		b.WriteString(" level=" + strings.ToLower(string(level)))
+		b.WriteString(" msg=" + formatLogValue(message))
+		for _, f := range fields {
+			b.WriteString(" " + f.key + "=" + formatLogValue(fmt.Sprintf("%v", f.value)))
+		}
+		return b.String() + LineEnding
+	default:
+		line := fmt.Sprintf("%v | %v | %v", t.Format(logTimeFormat), level, message)
+		for _, f := range fields {
+			line += fmt.Sprintf(" | %v=%v", f.key, f.value)
+		}
+		return line + LineEnding
+	}
+}
+
+// The value is quoted if it is empty or contains spaces, quotes, equal signs or control characters
+func formatLogValue(value string) string {
+	if len(value) == 0 || strings.IndexFunc(value, func(r rune) bool { return r == '"' || r == '=' || unicode.IsSpace(r) || !unicode.IsPrint(r) }) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// Return the index in systemLogLevels, -1 for invalid level
+func getSystemLogLevel(level string) int {
+	for i, l := range systemLogLevels {
+		if strings.EqualFold(l, level) {
+			return i
+		}
+	}
+	return -1
+}
+
+// The system logger is opened when it is used at the first time, failures are written to the log file only
+func writeSystemLog(level logLevel, message string) {
+	systemLoggerOnce.Do(func() {
+		var err error
+		if systemLogger, err = platform.NewSystemLogger(systemLogSource); err != nil {
+			_, _ = logOutput.Write([]byte(formatLog(time.Now(), logLevel_Error, nil, fmt.Sprintf("Failed to open system logger: %v", err), Config_LogFormat.GetString())))
+		}
+	})
+	if systemLogger == nil {
+		return
+	}
+	var err error
+	switch level {
+	case logLevel_Info, logLevel_Debug:
+		err = systemLogger.Info(message)
+	case logLevel_Warning:
+		err = systemLogger.Warning(message)
+	default:
+		err = systemLogger.Error(message)
+	}
+	if err != nil {
+		_, _ = logOutput.Write([]byte(formatLog(time.Now(), logLevel_Error, nil, fmt.Sprintf("Failed to write system log: %v", err), Config_LogFormat.GetString())))
 	}
-	return "Unknown"
+}
+
+// The log file is renamed with the rotation time as suffix when it exceeds the max size or age configured,
+// and the oldest rotated files are removed if more than the max count to keep
+type rotatingLogFile struct {
+	lock   sync.Mutex
+	path   string
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+func (r *rotatingLogFile) open(path string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if r.file != nil {
+		r.file.Close()
+	}
+	r.path, r.file, r.size, r.opened = path, f, info.Size(), time.Now()
+	return nil
+}
+
+func (r *rotatingLogFile) close() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.file != nil {
+		r.file.Close()
+		r.file = nil
+	}
+}
+
+func (r *rotatingLogFile) Write(p []byte) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.file == nil {
+		return os.Stderr.Write(p)
+	}
+	now := time.Now()
+	max_size := int64(Config_LogMaxSizeMb.GetInt()) * 1024 * 1024
+	max_age := time.Duration(Config_LogRotateHours.GetInt()) * time.Hour
+	if r.size > 0 && (max_size > 0 && r.size+int64(len(p)) > max_size || max_age > 0 && now.Sub(r.opened) >= max_age) {
+		if err := r.rotate(now); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to rotate log file %v: %v%v", r.path, err, LineEnding)
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingLogFile) rotate(now time.Time) error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	rotated := r.path + "." + now.Format(logRotateTimeFormat)
+	rename_err := os.Rename(r.path, rotated)
+	f, err := os.OpenFile(r.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		// Keep writing to the original file if the new one can't be opened
+		if f, err = os.OpenFile(rotated, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644); err != nil {
+			return err
+		}
+	}
+	r.file, r.opened = f, now
+	if info, err := f.Stat(); err == nil {
+		r.size = info.Size()
+	}
+	if rename_err != nil {
+		return rename_err
+	}
+	removeRotatedLogFiles(r.path, Config_LogMaxFiles.GetInt())
+	return nil
+}
+
+// Remove the oldest rotated files of the log file if more than the count to keep, 0 for keeping all
+func removeRotatedLogFiles(path string, keep int) {
+	if keep <= 0 {
+		return
+	}
+	files, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return
+	}
+	rotated := []string{}
+	for _, f := range files {
+		if _, err := time.Parse(logRotateTimeFormat, strings.TrimPrefix(f, path+".")); err == nil {
+			rotated = append(rotated, f)
+		}
+	}
+	// The rotation time suffix sorts in time order
+	sort.Strings(rotated)
+	for i := 0; i < len(rotated)-keep; i++ {
+		_ = os.Remove(rotated[i])
+	}
+}
+
+type requestIdContextKey struct{}
+
+// Return the logger with the correlation id of the request, which is returned to the client in header,
+// and the correlation id of the job if the request is about a job
+func LogContext(ctx context.Context) *Logger {
+	logger := rootLogger
+	if id, ok := ctx.Value(requestIdContextKey{}).(string); ok {
+		logger = logger.With(logFieldRequestId, id)
+	}
+	if id := getCorrelationId(ctx); len(id) > 0 {
+		logger = logger.With(logFieldCorrelationId, id)
+	}
+	return logger
+}
+
+// Return the logger of the job with its correlation id, which is generated at job creation
+func getJobLogger(id int32) *Logger {
+	logger := rootLogger.With(logFieldJob, id)
+	if correlation_id, ok := jobCorrelationIds.Load(id); ok {
+		logger = logger.With(logFieldCorrelationId, correlation_id)
+	}
+	return logger
+}
+
+// Attach the correlation id of the job to the outgoing context of requests to clusnodes
+func withJobCorrelationId(ctx context.Context, id int32) context.Context {
+	if correlation_id, ok := jobCorrelationIds.Load(id); ok {
+		return metadata.AppendToOutgoingContext(ctx, correlationIdMetadataKey, correlation_id.(string))
+	}
+	return ctx
+}
+
+func getCorrelationId(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(correlationIdMetadataKey); len(ids) > 0 && isValidLogId(ids[0]) {
+		return ids[0]
+	}
+	return ""
+}
+
+// Use the request id from the client if it is valid, otherwise generate one
+func getRequestId(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(requestIdMetadataKey); len(ids) > 0 && isValidLogId(ids[0]) {
+		return ids[0]
+	}
+	return newLogId()
+}
+
+func newLogId() string {
+	b := make([]byte, logIdSize)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
+func isValidLogId(id string) bool {
+	return len(id) > 0 && len(id) <= logIdMaxLength && strings.IndexFunc(id, func(r rune) bool {
+		return r > unicode.MaxASCII || !(r == '-' || r == '_' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r))
+	}) < 0
+}
+
+func requestIdUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	id := getRequestId(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIdMetadataKey, id))
+	return handler(context.WithValue(ctx, requestIdContextKey{}, id), req)
+}
+
+type requestIdServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIdServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func requestIdStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	id := getRequestId(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(requestIdMetadataKey, id))
+	return handler(srv, &requestIdServerStream{ss, context.WithValue(ss.Context(), requestIdContextKey{}, id)})
 }
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func Test_formatLog(t *testing.T) {
+	now := time.Date(2020, 4, 15, 18, 26, 22, 0, time.UTC)
+	fields := []logField{{logFieldRequestId, "0a1b"}}
+	cases := []struct {
+		format   string
+		fields   []logField
+		expected string
+	}{
+		{LogFormat_Text, nil, "2020/04/15 18:26:22 | Info | Job 1 started" + LineEnding},
+		{LogFormat_Text, fields, "2020/04/15 18:26:22 | Info | Job 1 started | request=0a1b" + LineEnding},
+		{LogFormat_KeyValue, fields, `time=2020-04-15T18:26:22Z level=info msg="Job 1 started" request=0a1b` + LineEnding},
+		{LogFormat_Json, fields, `{"level":"info","msg":"Job 1 started","request":"0a1b","time":"2020-04-15T18:26:22Z"}` + LineEnding},
+	}
+
+	for _, c := range cases {
+		if result := formatLog(now, logLevel_Info, c.fields, "Job 1 started", c.format); result != c.expected {
+			t.Errorf("\nformat=%v, fields=%v\nexpected=%q\n  actual=%q", c.format, c.fields, c.expected, result)
+		}
+	}
+	for id, valid := range map[string]bool{"0a1b": true, "req-1.a_b": true, "": false, "a b": false, "a\nb": false, "é": false, strings.Repeat("a", logIdMaxLength+1): false} {
+		if isValidLogId(id) != valid {
+			t.Errorf("log id %q is expected valid=%v", id, valid)
+		}
+	}
+}
+
+func Test_removeRotatedLogFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusnode-log")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "node.log")
+	names := []string{"node.log", "node.log.20200415000000", "node.log.20200416000000", "node.log.20200417000000", "node.log.other"}
+	for _, name := range names {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatalf("failed to create file: %v", err)
+		}
+	}
+	removeRotatedLogFiles(path, 2)
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	remained := []string{}
+	for _, f := range files {
+		remained = append(remained, filepath.Base(f))
+	}
+	if expected := []string{"node.log", "node.log.20200416000000", "node.log.20200417000000", "node.log.other"}; !reflect.DeepEqual(remained, expected) {
+		t.Errorf("\nexpected=%v\n  actual=%v", expected, remained)
+	}
+}
+
+func Test_withJobCorrelationId(t *testing.T) {
+	var id int32 = -1
+	if md, _ := metadata.FromOutgoingContext(withJobCorrelationId(context.Background(), id)); len(md.Get(correlationIdMetadataKey)) > 0 {
+		t.Errorf("unexpected correlation id of job without it: %v", md)
+	}
+	correlation_id := newLogId()
+	jobCorrelationIds.Store(id, correlation_id)
+	defer jobCorrelationIds.Delete(id)
+	md, _ := metadata.FromOutgoingContext(withJobCorrelationId(context.Background(), id))
+	if ids := md.Get(correlationIdMetadataKey); len(ids) != 1 || ids[0] != correlation_id {
+		t.Errorf("expected correlation id %v in metadata: %v", correlation_id, md)
+	}
+	if result := getCorrelationId(metadata.NewIncomingContext(context.Background(), md)); result != correlation_id {
+		t.Errorf("\nexpected=%q\n  actual=%q", correlation_id, result)
+	}
+	if result := getCorrelationId(metadata.NewIncomingContext(context.Background(), metadata.Pairs(correlationIdMetadataKey, "a b"))); len(result) > 0 {
+		t.Errorf("invalid correlation id is accepted: %q", result)
+	}
+	logger := getJobLogger(id)
+	if expected := []logField{{logFieldJob, id}, {logFieldCorrelationId, correlation_id}}; !reflect.DeepEqual(logger.fields, expected) {
+		t.Errorf("\nexpected=%v\n  actual=%v", expected, logger.fields)
+	}
+}
+
+func Test_logSampler(t *testing.T) {
+	s := &logSampler{}
+	now := time.Now()
+	cases := []struct {
+		after         time.Duration
+		expectedCalls int
+		expectedOk    bool
+	}{
+		{0, 1, true},
+		{time.Second, 0, false},
+		{30 * time.Second, 0, false},
+		{time.Minute, 3, true},
+		{time.Minute + time.Second, 0, false},
+		{2*time.Minute + time.Second, 2, true},
+	}
+	for _, c := range cases {
+		if calls, ok := s.sample(now.Add(c.after), time.Minute); calls != c.expectedCalls || ok != c.expectedOk {
+			t.Errorf("after %v: expected=(%v, %v), actual=(%v, %v)", c.after, c.expectedCalls, c.expectedOk, calls, ok)
+		}
+	}
+
+	// Each call is sampled without interval
+	s = &logSampler{}
+	for i := 0; i < 3; i++ {
+		if calls, ok := s.sample(now, 0); calls != 1 || !ok {
+			t.Errorf("expected=(1, true), actual=(%v, %v)", calls, ok)
+		}
+	}
+}
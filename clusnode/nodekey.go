@@ -0,0 +1,445 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	nodeKeySize   = 32
+	nodeNonceSize = 16
+
+	minClusterSecretLength = 16
+	ClusterSecretNone      = "none"
+	ClusterSecretStdin     = "-"
+
+	headnodeMetadataKey          = "x-clusrun-headnode"
+	headnodeTimestampMetadataKey = "x-clusrun-timestamp"
+	headnodeSignatureMetadataKey = "x-clusrun-signature"
+	headnodeSignatureWindow      = 5 * time.Minute
+)
+
+var (
+	NodeKeys           sync.Map // display name of clusnode -> key issued by this headnode
+	HeadnodeKeys       sync.Map // headnode reported to -> key issued by the headnode to this clusnode
+	heartbeatTimestamp = map[string]int64{}
+	heartbeatLock      sync.Mutex
+	headnodeSignatures = map[string]int64{} // signature of headnode accepted -> its timestamp
+	headnodeSignLock   sync.Mutex
+
+	clusterSecretValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if len(v) > 0 && len(v) < minClusterSecretLength {
+			return fmt.Errorf("Secret should be at least %v characters", minClusterSecretLength)
+		}
+		return nil
+	}
+)
+
+// Read the cluster secret from a file or stdin instead of the command line, so that it is not left in the shell history
+func readClusterSecret(file string) (string, error) {
+	var content []byte
+	var err error
+	if file == ClusterSecretStdin {
+		content, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		content, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+func newNodeKey() ([]byte, error) {
+	return newRandomBytes(nodeKeySize)
+}
+
+// A fresh nonce is sent in each validation, so that a reply signed before can't be replayed
+func newNodeNonce() ([]byte, error) {
+	return newRandomBytes(nodeNonceSize)
+}
+
+func newRandomBytes(size int) ([]byte, error) {
+	b := make([]byte, size)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func signWithNodeKey(key []byte, fields ...string) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(strings.Join(fields, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifyNodeKeySignature(key []byte, signature string, fields ...string) bool {
+	s, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(strings.Join(fields, "\n")))
+	return hmac.Equal(s, mac.Sum(nil))
+}
+
+func signHeartbeat(key []byte, nodename, host, headnode string, timestamp int64) string {
+	return signWithNodeKey(key, nodename, host, headnode, strconv.FormatInt(timestamp, 10))
+}
+
+// A heartbeat from an enrolled clusnode should be signed by its key with a timestamp later than the last one, so that it can't be replayed
+func verifyHeartbeat(display_name, nodename, host, headnode, signature string, timestamp int64) error {
+	key, ok := NodeKeys.Load(display_name)
+	if !ok {
+		return nil
+	}
+	if len(signature) == 0 {
+		return errors.New("Heartbeat is not signed")
+	}
+	if !verifyNodeKeySignature(key.([]byte), signature, nodename, host, headnode, strconv.FormatInt(timestamp, 10)) {
+		return errors.New("Invalid signature of heartbeat")
+	}
+	heartbeatLock.Lock()
+	defer heartbeatLock.Unlock()
+	if timestamp <= heartbeatTimestamp[display_name] {
+		return errors.New("Heartbeat is replayed")
+	}
+	heartbeatTimestamp[display_name] = timestamp
+	return nil
+}
+
+func signValidateReply(key, nonce []byte, headnode, clusnode, nodename string) string {
+	return signWithNodeKey(key, headnode, clusnode, nodename, hex.EncodeToString(nonce))
+}
+
+func verifyValidateReply(key, nonce []byte, headnode, clusnode, nodename, signature string) bool {
+	return len(nonce) == nodeNonceSize && verifyNodeKeySignature(key, signature, headnode, clusnode, nodename, hex.EncodeToString(nonce))
+}
+
+// The nonce signed with the cluster secret proves the clusnode is in the cluster, so that an arbitrary host can't be
+// validated and enrolled as a node which has no key yet
+func signValidateSecret(secret string, nonce []byte, headnode, clusnode, nodename string) string {
+	return signWithNodeKey([]byte(secret), "secret", headnode, clusnode, nodename, hex.EncodeToString(nonce))
+}
+
+func verifyValidateSecret(secret string, nonce []byte, headnode, clusnode, nodename, signature string) bool {
+	return len(nonce) == nodeNonceSize && verifyNodeKeySignature([]byte(secret), signature, "secret", headnode, clusnode, nodename, hex.EncodeToString(nonce))
+}
+
+// The key signed with the cluster secret proves it is issued by a headnode in the cluster, so that a caller can't
+// enroll or replace the key of this clusnode with its own one
+func signEnrollKey(secret string, key, nonce []byte, headnode, clusnode string) string {
+	return signWithNodeKey([]byte(secret), "key", headnode, clusnode, hex.EncodeToString(key), hex.EncodeToString(nonce))
+}
+
+func verifyEnrollKey(secret string, key, nonce []byte, headnode, clusnode, signature string) bool {
+	return len(nonce) == nodeNonceSize && verifyNodeKeySignature([]byte(secret), signature, "key", headnode, clusnode, hex.EncodeToString(key), hex.EncodeToString(nonce))
+}
+
+// Since the validation is not authenticated, a key is accepted only if it is signed with the cluster secret, or if it
+// is the first key from the headnode when the secret is not configured
+func acceptHeadnodeKey(reported string, in *pb.ValidateRequest) error {
+	key := in.GetKey()
+	if secret := Config_ClusterSecret.GetString(); len(secret) > 0 {
+		if !verifyEnrollKey(secret, key, in.GetNonce(), in.GetHeadnode(), in.GetClusnode(), in.GetKeySignature()) {
+			return errors.New("Key is not signed with the cluster secret")
+		}
+		HeadnodeKeys.Store(reported, key)
+	} else if _, loaded := HeadnodeKeys.LoadOrStore(reported, key); loaded {
+		return fmt.Errorf("Already enrolled by headnode %v, remove and add the headnode again to enroll with a new key", reported)
+	}
+	if err := saveKeys(db_headnodeKeys, &HeadnodeKeys); err != nil {
+		LogError("Failed to save headnode keys: %v", err)
+	}
+	return nil
+}
+
+// The key of a removed headnode is forgotten, so that it can enroll this clusnode again when it is added back
+func forgetHeadnodeKey(headnode string) {
+	if _, ok := HeadnodeKeys.Load(headnode); !ok {
+		return
+	}
+	HeadnodeKeys.Delete(headnode)
+	if err := saveKeys(db_headnodeKeys, &HeadnodeKeys); err != nil {
+		LogError("Failed to save headnode keys: %v", err)
+	}
+}
+
+// The digest of the request, which is signed with the call, so that the signature can't be used for another request
+func digestHeadnodeCall(request interface{}) string {
+	m, ok := request.(proto.Message)
+	if !ok || m == nil {
+		return ""
+	}
+	b := proto.NewBuffer(nil)
+	b.SetDeterministic(true)
+	if err := b.Marshal(m); err != nil {
+		return ""
+	}
+	digest := sha256.Sum256(b.Bytes())
+	return hex.EncodeToString(digest[:])
+}
+
+func signHeadnodeCall(key []byte, method, headnode, digest string, timestamp int64) string {
+	return signWithNodeKey(key, "rpc", method, headnode, digest, strconv.FormatInt(timestamp, 10))
+}
+
+// Sign the call with the request to the clusnode by the key issued to it, nil if it is not enrolled
+func getHeadnodeSignature(display_name, method string, request interface{}) *pb.HeadnodeSignature {
+	key, ok := NodeKeys.Load(display_name)
+	if !ok {
+		return nil
+	}
+	headnode, timestamp := getReportedHeadnode(display_name), time.Now().UnixNano()
+	signature := signHeadnodeCall(key.([]byte), method, headnode, digestHeadnodeCall(request), timestamp)
+	return &pb.HeadnodeSignature{Headnode: headnode, Timestamp: timestamp, Signature: signature}
+}
+
+// Sign the calls to the nodes in the relay path after the first one, which is signed when it is called. The request
+// relayed to a node has the signatures of the calls after it, so they are signed from the last one.
+func getRelaySignatures(path []string, request *pb.StartJobRequest) []*pb.HeadnodeSignature {
+	if len(path) < 2 {
+		return nil
+	}
+	signatures := make([]*pb.HeadnodeSignature, len(path)-1)
+	for i := len(path) - 1; i > 0; i-- {
+		method, relayed := "/clusrun.Clusnode/StartJob", proto.Message(request)
+		if i < len(path)-1 {
+			method, relayed = "/clusrun.Clusnode/RelayJob", &pb.RelayJobRequest{Path: path[i+1:], Request: request, Signatures: signatures[i:]}
+		}
+		display_name, _ := hostNodes.Load(path[i])
+		name, _ := display_name.(string)
+		signature := getHeadnodeSignature(name, method, relayed)
+		if signature == nil {
+			signature = &pb.HeadnodeSignature{}
+		}
+		signatures[i-1] = signature
+	}
+	return signatures
+}
+
+func withHeadnodeSignature(ctx context.Context, signature *pb.HeadnodeSignature) context.Context {
+	if signature == nil {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx,
+		headnodeMetadataKey, signature.GetHeadnode(),
+		headnodeTimestampMetadataKey, strconv.FormatInt(signature.GetTimestamp(), 10),
+		headnodeSignatureMetadataKey, signature.GetSignature())
+}
+
+// Get the clusnode to sign the call to the host, empty if the call needs no signing or is already signed, such as the
+// calls relayed with the signatures of headnode
+func getHeadnodeSignedNode(ctx context.Context, host, method string) string {
+	if !headnodeSignedRpcs[method] {
+		return ""
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(headnodeSignatureMetadataKey)) > 0 {
+		return ""
+	}
+	display_name, _ := hostNodes.Load(host)
+	name, _ := display_name.(string)
+	return name
+}
+
+func signHeadnodeUnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if display_name := getHeadnodeSignedNode(ctx, cc.Target(), method); len(display_name) > 0 {
+		ctx = withHeadnodeSignature(ctx, getHeadnodeSignature(display_name, method, req))
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func signHeadnodeStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	display_name := getHeadnodeSignedNode(ctx, cc.Target(), method)
+	if len(display_name) == 0 {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	open := func(request interface{}) (grpc.ClientStream, error) {
+		return streamer(withHeadnodeSignature(ctx, getHeadnodeSignature(display_name, method, request)), desc, cc, method, opts...)
+	}
+	return &headnodeClientStream{ctx: ctx, open: open, opened: make(chan struct{})}, nil
+}
+
+// The stream to the clusnode is opened when the first request is sent, so that the call is signed over the request
+type headnodeClientStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	open   func(request interface{}) (grpc.ClientStream, error)
+	once   sync.Once
+	opened chan struct{}
+	err    error
+}
+
+func (s *headnodeClientStream) start(request interface{}) error {
+	s.once.Do(func() {
+		s.ClientStream, s.err = s.open(request)
+		close(s.opened)
+	})
+	return s.err
+}
+
+// Wait for the stream to be opened by the first request
+func (s *headnodeClientStream) wait() error {
+	select {
+	case <-s.opened:
+		return s.err
+	case <-s.ctx.Done():
+		return status.Error(codes.Canceled, s.ctx.Err().Error())
+	}
+}
+
+func (s *headnodeClientStream) SendMsg(m interface{}) error {
+	if err := s.start(m); err != nil {
+		return err
+	}
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *headnodeClientStream) CloseSend() error {
+	if err := s.start(nil); err != nil {
+		return err
+	}
+	return s.ClientStream.CloseSend()
+}
+
+func (s *headnodeClientStream) RecvMsg(m interface{}) error {
+	if err := s.wait(); err != nil {
+		return err
+	}
+	return s.ClientStream.RecvMsg(m)
+}
+
+func (s *headnodeClientStream) Header() (metadata.MD, error) {
+	if err := s.wait(); err != nil {
+		return nil, err
+	}
+	return s.ClientStream.Header()
+}
+
+func (s *headnodeClientStream) Trailer() metadata.MD {
+	if err := s.wait(); err != nil {
+		return nil
+	}
+	return s.ClientStream.Trailer()
+}
+
+func (s *headnodeClientStream) Context() context.Context {
+	select {
+	case <-s.opened:
+		if s.err == nil {
+			return s.ClientStream.Context()
+		}
+	default:
+	}
+	return s.ctx
+}
+
+// The call from a headnode should be signed over the request by the key issued by it to this clusnode, and each
+// signature is accepted only once in the window, so that a call can't be made by others, replayed or changed
+func verifyHeadnodeCall(ctx context.Context, method string, request interface{}) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	headnodes, timestamps, signatures := md.Get(headnodeMetadataKey), md.Get(headnodeTimestampMetadataKey), md.Get(headnodeSignatureMetadataKey)
+	if len(headnodes) == 0 || len(timestamps) == 0 || len(signatures) == 0 {
+		return "", errors.New("Call is not signed by headnode")
+	}
+	headnode, signature := headnodes[0], signatures[0]
+	timestamp, err := strconv.ParseInt(timestamps[0], 10, 64)
+	if err != nil {
+		return "", errors.New("Invalid timestamp of signature")
+	}
+	if state, ok := headnodesReporting.Load(headnode); !ok || state.(*heartbeat_state).Stopped {
+		return "", fmt.Errorf("Not reporting to headnode %v", headnode)
+	}
+	key, ok := HeadnodeKeys.Load(headnode)
+	if !ok {
+		return "", fmt.Errorf("Not enrolled by headnode %v", headnode)
+	}
+	if !verifyNodeKeySignature(key.([]byte), signature, "rpc", method, headnode, digestHeadnodeCall(request), strconv.FormatInt(timestamp, 10)) {
+		return "", errors.New("Invalid signature of headnode")
+	}
+	now := time.Now().UnixNano()
+	if d := time.Duration(now - timestamp); d > headnodeSignatureWindow || d < -headnodeSignatureWindow {
+		return "", errors.New("Signature of headnode is expired")
+	}
+	headnodeSignLock.Lock()
+	defer headnodeSignLock.Unlock()
+	if _, ok := headnodeSignatures[signature]; ok {
+		return "", errors.New("Signature of headnode is replayed")
+	}
+	for s, t := range headnodeSignatures {
+		if time.Duration(now-t) > headnodeSignatureWindow {
+			delete(headnodeSignatures, s)
+		}
+	}
+	headnodeSignatures[signature] = timestamp
+	return headnode, nil
+}
+
+func enrollNode(display_name string, key []byte) {
+	NodeKeys.Store(display_name, key)
+	if err := saveKeys(db_nodeKeys, &NodeKeys); err != nil {
+		LogError("Failed to save node keys: %v", err)
+	}
+	LogInfo("Clusnode %v is enrolled with a new key", display_name)
+}
+
+// The clusnode will be enrolled with a new key in the next validation, which is accepted if it is signed with the
+// cluster secret, or after the headnode is removed and added again on the clusnode
+func resetNodeKey(display_name string) bool {
+	if _, ok := NodeKeys.Load(display_name); !ok {
+		return false
+	}
+	NodeKeys.Delete(display_name)
+	if err := saveKeys(db_nodeKeys, &NodeKeys); err != nil {
+		LogError("Failed to save node keys: %v", err)
+	}
+	heartbeatLock.Lock()
+	delete(heartbeatTimestamp, display_name)
+	heartbeatLock.Unlock()
+	ReportedNodes.ResetValidation(display_name)
+	LogInfo("Key of clusnode %v is reset", display_name)
+	return true
+}
+
+func (s *headnode_server) ResetNodeKeys(ctx context.Context, in *pb.ResetNodeKeysRequest) (*pb.ResetNodeKeysReply, error) {
+	defer LogPanicBeforeExit()
+	results := map[string]string{}
+	for _, node := range in.GetNodes() {
+		display_name := node
+		NodeKeys.Range(func(k, v interface{}) bool {
+			if strings.EqualFold(k.(string), node) {
+				display_name = k.(string)
+				return false
+			}
+			return true
+		})
+		if resetNodeKey(display_name) {
+			results[node] = "Reset"
+		} else {
+			results[node] = "No key"
+		}
+	}
+	LogInfo("ResetNodeKeys result: %v", results)
+	return &pb.ResetNodeKeysReply{Results: results}, nil
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func Test_verifyHeartbeat(t *testing.T) {
+	key := make([]byte, nodeKeySize)
+	NodeKeys.Store("NODE", key)
+	defer NodeKeys.Delete("NODE")
+	cases := []struct {
+		display_name string
+		signature    string
+		timestamp    int64
+		valid        bool
+	}{
+		{"OTHER", "", 1, true},
+		{"NODE", "", 1, false},
+		{"NODE", signHeartbeat(key, "node", "node:50505", "headnode:50505", 1), 2, false},
+		{"NODE", signHeartbeat(key, "node", "node:50505", "headnode:50505", 2), 2, true},
+		{"NODE", signHeartbeat(key, "node", "node:50505", "headnode:50505", 2), 2, false},
+		{"NODE", signHeartbeat(key, "node", "node:50505", "headnode:50505", 1), 1, false},
+		{"NODE", signHeartbeat(key, "node", "node:50505", "headnode:50505", 3), 3, true},
+	}
+
+	for _, c := range cases {
+		if err := verifyHeartbeat(c.display_name, "node", "node:50505", "headnode:50505", c.signature, c.timestamp); (err == nil) != c.valid {
+			t.Errorf("\ndisplay name=%v\nsignature=%v\ntimestamp=%v\nexpected valid=%v\n  actual error=%v", c.display_name, c.signature, c.timestamp, c.valid, err)
+		}
+	}
+}
+
+func Test_verifyValidateReply(t *testing.T) {
+	key, other_key := make([]byte, nodeKeySize), make([]byte, nodeKeySize)
+	other_key[0] = 1
+	nonce, other_nonce := make([]byte, nodeNonceSize), make([]byte, nodeNonceSize)
+	other_nonce[0] = 1
+	cases := []struct {
+		signature string
+		nonce     []byte
+		valid     bool
+	}{
+		{signValidateReply(key, nonce, "headnode:50505", "node:50505", "NODE"), nonce, true},
+		{signValidateReply(key, other_nonce, "headnode:50505", "node:50505", "NODE"), nonce, false},
+		{signValidateReply(other_key, nonce, "headnode:50505", "node:50505", "NODE"), nonce, false},
+		{signValidateReply(key, nonce, "headnode:50505", "node:50505", "OTHER"), nonce, false},
+		{signValidateReply(key, nil, "headnode:50505", "node:50505", "NODE"), nil, false},
+		{"", nonce, false},
+	}
+
+	for _, c := range cases {
+		if valid := verifyValidateReply(key, c.nonce, "headnode:50505", "node:50505", "NODE", c.signature); valid != c.valid {
+			t.Errorf("\nsignature=%v\nnonce=%v\nexpected valid=%v\n  actual valid=%v", c.signature, c.nonce, c.valid, valid)
+		}
+	}
+}
+
+func Test_verifyValidateSecret(t *testing.T) {
+	secret, nonce := "cluster-secret-0123", make([]byte, nodeNonceSize)
+	other_nonce := make([]byte, nodeNonceSize)
+	other_nonce[0] = 1
+	key := []byte(secret)
+	cases := []struct {
+		signature string
+		nonce     []byte
+		valid     bool
+	}{
+		{signValidateSecret(secret, nonce, "headnode:50505", "node:50505", "NODE"), nonce, true},
+		{signValidateSecret(secret, other_nonce, "headnode:50505", "node:50505", "NODE"), nonce, false},
+		{signValidateSecret("other-secret-0123", nonce, "headnode:50505", "node:50505", "NODE"), nonce, false},
+		{signValidateSecret(secret, nonce, "headnode:50505", "spoofer:50505", "NODE"), nonce, false},
+		{signValidateSecret(secret, nil, "headnode:50505", "node:50505", "NODE"), nil, false},
+		{signValidateReply(key, nonce, "headnode:50505", "node:50505", "NODE"), nonce, false}, // not interchangeable with the node key signature
+		{"", nonce, false},
+	}
+
+	for _, c := range cases {
+		if valid := verifyValidateSecret(secret, c.nonce, "headnode:50505", "node:50505", "NODE", c.signature); valid != c.valid {
+			t.Errorf("\nsignature=%v\nnonce=%v\nexpected valid=%v\n  actual valid=%v", c.signature, c.nonce, c.valid, valid)
+		}
+	}
+	for value, valid := range map[interface{}]bool{"": true, secret: true, "short": false, 1: false} {
+		if err := clusterSecretValidator(value); (err == nil) != valid {
+			t.Errorf("Expected valid %v of secret %v, got %v", valid, value, err)
+		}
+	}
+}
+
+func Test_readClusterSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusrun")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "secret")
+	if err := ioutil.WriteFile(file, []byte("cluster-secret-0123\n"), 0600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+
+	if secret, err := readClusterSecret(file); err != nil || secret != "cluster-secret-0123" {
+		t.Errorf("Expected secret cluster-secret-0123 without the line break, got %q, %v", secret, err)
+	}
+	if _, err := readClusterSecret(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("Expected error of missing secret file")
+	}
+}
+
+func Test_verifyHeadnodeCall(t *testing.T) {
+	key, other_key := make([]byte, nodeKeySize), make([]byte, nodeKeySize)
+	other_key[0] = 1
+	headnodesReporting.Store("headnode:50505", &heartbeat_state{})
+	headnodesReporting.Store("stopped:50505", &heartbeat_state{Stopped: true})
+	HeadnodeKeys.Store("headnode:50505", key)
+	HeadnodeKeys.Store("stopped:50505", key)
+	defer func() {
+		for _, headnode := range []string{"headnode:50505", "stopped:50505"} {
+			headnodesReporting.Delete(headnode)
+			HeadnodeKeys.Delete(headnode)
+		}
+	}()
+	method, now := "/clusrun.Clusnode/StartJob", time.Now().UnixNano()
+	request, other_request := &pb.StartJobRequest{JobId: 1, Command: "echo"}, &pb.StartJobRequest{JobId: 1, Command: "reboot"}
+	signed := func(key []byte, method, headnode string, request *pb.StartJobRequest, timestamp int64) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			headnodeMetadataKey, headnode,
+			headnodeTimestampMetadataKey, strconv.FormatInt(timestamp, 10),
+			headnodeSignatureMetadataKey, signHeadnodeCall(key, method, headnode, digestHeadnodeCall(request), timestamp)))
+	}
+	cases := []struct {
+		ctx   context.Context
+		valid bool
+	}{
+		{context.Background(), false},
+		{signed(key, method, "headnode:50505", request, now), true},
+		{signed(key, method, "headnode:50505", request, now), false}, // replayed
+		{signed(key, method, "headnode:50505", request, now+1), true},
+		{signed(key, method, "headnode:50505", other_request, now+2), false}, // signed for another request
+		{signed(other_key, method, "headnode:50505", request, now+3), false},
+		{signed(key, "/clusrun.Clusnode/Shell", "headnode:50505", request, now+4), false},
+		{signed(key, method, "stopped:50505", request, now+5), false},
+		{signed(key, method, "other:50505", request, now+6), false},
+		{signed(key, method, "headnode:50505", request, now-int64(2*headnodeSignatureWindow)), false},
+	}
+
+	for i, c := range cases {
+		if headnode, err := verifyHeadnodeCall(c.ctx, method, request); (err == nil) != c.valid {
+			t.Errorf("Case %v: expected valid %v, got %v", i, c.valid, err)
+		} else if c.valid && headnode != "headnode:50505" {
+			t.Errorf("Case %v: expected headnode:50505 verified, got %v", i, headnode)
+		}
+	}
+
+	// The calls of headnode are rejected without a signature even if no token is configured
+	if _, err := authorize(context.Background(), method); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected unsigned %v unauthenticated, got %v", method, err)
+	}
+	ctx := withHeadnodeVerification(context.Background())
+	setVerifiedHeadnode(ctx, "headnode:50505")
+	if _, err := authorize(ctx, method); err != nil {
+		t.Errorf("Expected signed %v authorized, got %v", method, err)
+	}
+}
+
+// A server stream receiving the requests in order
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	received []proto.Message
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	if len(s.received) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), s.received[0])
+	s.received = s.received[1:]
+	return nil
+}
+
+func (s *fakeServerStream) SendMsg(m interface{}) error {
+	return nil
+}
+
+// The stream is verified by the first request received, and can't be replied before it
+func Test_headnodeServerStream(t *testing.T) {
+	key := make([]byte, nodeKeySize)
+	headnodesReporting.Store("headnode:50505", &heartbeat_state{})
+	HeadnodeKeys.Store("headnode:50505", key)
+	defer headnodesReporting.Delete("headnode:50505")
+	defer HeadnodeKeys.Delete("headnode:50505")
+	method, timestamp := "/clusrun.Clusnode/StartJob", time.Now().UnixNano()
+	request := &pb.StartJobRequest{JobId: 1, Command: "echo"}
+	md := metadata.Pairs(
+		headnodeMetadataKey, "headnode:50505",
+		headnodeTimestampMetadataKey, strconv.FormatInt(timestamp, 10),
+		headnodeSignatureMetadataKey, signHeadnodeCall(key, method, "headnode:50505", digestHeadnodeCall(request), timestamp))
+	for _, c := range []struct {
+		received *pb.StartJobRequest
+		valid    bool
+	}{
+		{&pb.StartJobRequest{JobId: 1, Command: "reboot"}, false},
+		{request, true},
+	} {
+		inner := &fakeServerStream{ctx: metadata.NewIncomingContext(context.Background(), md), received: []proto.Message{c.received}}
+		ss := &headnodeServerStream{ServerStream: inner, ctx: withHeadnodeVerification(inner.ctx), method: method}
+		if err := ss.SendMsg(&pb.StartJobReply{}); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Expected reply before the request unauthenticated, got %v", err)
+		}
+		if err := ss.RecvMsg(&pb.StartJobRequest{}); (err == nil) != c.valid {
+			t.Errorf("Expected request %v valid %v, got %v", c.received, c.valid, err)
+		}
+		if verified := getVerifiedHeadnode(ss.Context()); (verified == "headnode:50505") != c.valid {
+			t.Errorf("Expected request %v verified %v, got headnode %v", c.received, c.valid, verified)
+		}
+	}
+}
+
+// Each node in the relay path can verify the call of the node before it, which is signed over the request relayed
+func Test_getRelaySignatures(t *testing.T) {
+	path := []string{"relay1:50505", "relay2:50505", "node:50505"}
+	for i, host := range path {
+		display_name := fmt.Sprintf("RELAY-NODE%v", i)
+		hostNodes.Store(host, display_name)
+		reportedTo.Store(display_name, "headnode:50505")
+		NodeKeys.Store(display_name, make([]byte, nodeKeySize))
+		defer hostNodes.Delete(host)
+		defer reportedTo.Delete(display_name)
+		defer NodeKeys.Delete(display_name)
+	}
+	key := make([]byte, nodeKeySize)
+	headnodesReporting.Store("headnode:50505", &heartbeat_state{})
+	HeadnodeKeys.Store("headnode:50505", key)
+	defer headnodesReporting.Delete("headnode:50505")
+	defer HeadnodeKeys.Delete("headnode:50505")
+	request := &pb.StartJobRequest{JobId: 1, Command: "echo", Headnode: "headnode:50505"}
+	signatures := getRelaySignatures(path, request)
+	if len(signatures) != len(path)-1 {
+		t.Fatalf("Expected %v signatures, got %v", len(path)-1, len(signatures))
+	}
+	incoming := func(signature *pb.HeadnodeSignature) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			headnodeMetadataKey, signature.GetHeadnode(),
+			headnodeTimestampMetadataKey, strconv.FormatInt(signature.GetTimestamp(), 10),
+			headnodeSignatureMetadataKey, signature.GetSignature()))
+	}
+
+	// The first relay calls relay2 with the rest of path, and relay2 calls the node with the job
+	relayed := &pb.RelayJobRequest{Path: path[2:], Request: request, Signatures: signatures[1:]}
+	if _, err := verifyHeadnodeCall(incoming(signatures[0]), "/clusrun.Clusnode/RelayJob", relayed); err != nil {
+		t.Errorf("Expected relayed call to relay2 verified, got %v", err)
+	}
+	if _, err := verifyHeadnodeCall(incoming(signatures[1]), "/clusrun.Clusnode/StartJob", request); err != nil {
+		t.Errorf("Expected relayed call to node verified, got %v", err)
+	}
+	changed := &pb.RelayJobRequest{Path: []string{"other:50505"}, Request: request, Signatures: signatures[1:]}
+	if _, err := verifyHeadnodeCall(incoming(signatures[0]), "/clusrun.Clusnode/RelayJob", changed); err == nil {
+		t.Error("Expected relayed call with another path rejected")
+	}
+}
+
+func Test_acceptHeadnodeKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusrun")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	db_headnodeKeys = filepath.Join(dir, "headnode.headnodekeys")
+	defer Config_ClusterSecret.Set(Config_ClusterSecret.Value)
+	defer HeadnodeKeys.Delete("headnode:50505")
+	key, other_key, nonce := make([]byte, nodeKeySize), make([]byte, nodeKeySize), make([]byte, nodeNonceSize)
+	other_key[0] = 1
+	request := func(key []byte, signature string) *pb.ValidateRequest {
+		return &pb.ValidateRequest{Headnode: "headnode:50505", Clusnode: "node:50505", Key: key, Nonce: nonce, KeySignature: signature}
+	}
+
+	// Without the cluster secret, only the first key is accepted
+	Config_ClusterSecret.Set("")
+	if err := acceptHeadnodeKey("headnode:50505", request(key, "")); err != nil {
+		t.Errorf("Expected the first key accepted, got %v", err)
+	}
+	if err := acceptHeadnodeKey("headnode:50505", request(other_key, "")); err == nil {
+		t.Error("Expected the key of an enrolled headnode not replaced")
+	}
+	if k, _ := HeadnodeKeys.Load("headnode:50505"); !bytes.Equal(k.([]byte), key) {
+		t.Errorf("Expected key %v kept, got %v", key, k)
+	}
+
+	// With the cluster secret, the key should be signed with it
+	secret := "cluster-secret-0123"
+	Config_ClusterSecret.Set(secret)
+	cases := []struct {
+		signature string
+		valid     bool
+	}{
+		{"", false},
+		{signEnrollKey("other-secret-0123", other_key, nonce, "headnode:50505", "node:50505"), false},
+		{signEnrollKey(secret, key, nonce, "headnode:50505", "node:50505"), false},
+		{signEnrollKey(secret, other_key, nonce, "headnode:50505", "spoofer:50505"), false},
+		{signEnrollKey(secret, other_key, nonce, "headnode:50505", "node:50505"), true},
+	}
+	for i, c := range cases {
+		if err := acceptHeadnodeKey("headnode:50505", request(other_key, c.signature)); (err == nil) != c.valid {
+			t.Errorf("Case %v: expected valid %v, got %v", i, c.valid, err)
+		}
+	}
+	if k, _ := HeadnodeKeys.Load("headnode:50505"); !bytes.Equal(k.([]byte), other_key) {
+		t.Errorf("Expected key %v replaced, got %v", other_key, k)
+	}
+}
+
+type signedCallServer struct {
+	pb.UnimplementedClusnodeServer
+	verified chan string
+}
+
+func (s *signedCallServer) StartJob(in *pb.StartJobRequest, out pb.Clusnode_StartJobServer) error {
+	s.verified <- getVerifiedHeadnode(out.Context())
+	return out.Send(&pb.StartJobReply{})
+}
+
+func (s *signedCallServer) QueryJob(ctx context.Context, in *pb.QueryJobRequest) (*pb.QueryJobReply, error) {
+	s.verified <- getVerifiedHeadnode(ctx)
+	return &pb.QueryJobReply{}, nil
+}
+
+// The calls of headnode to a clusnode are signed over the requests and verified by the clusnode
+func Test_signHeadnodeCall(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	host, key := lis.Addr().String(), make([]byte, nodeKeySize)
+	hostNodes.Store(host, "SIGNED-NODE")
+	reportedTo.Store("SIGNED-NODE", "headnode:50505")
+	NodeKeys.Store("SIGNED-NODE", key)
+	headnodesReporting.Store("headnode:50505", &heartbeat_state{})
+	HeadnodeKeys.Store("headnode:50505", key)
+	defer func() {
+		hostNodes.Delete(host)
+		reportedTo.Delete("SIGNED-NODE")
+		NodeKeys.Delete("SIGNED-NODE")
+		headnodesReporting.Delete("headnode:50505")
+		HeadnodeKeys.Delete("headnode:50505")
+	}()
+	node := &signedCallServer{verified: make(chan string, 2)}
+	server := grpc.NewServer(grpc.UnaryInterceptor(authUnaryInterceptor), grpc.StreamInterceptor(authStreamInterceptor))
+	pb.RegisterClusnodeServer(server, node)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+	conn, cancel := ConnectNode(host)
+	defer cancel()
+	if conn == nil {
+		t.Fatalf("Failed to connect %v", host)
+	}
+	defer conn.Close()
+	c := pb.NewClusnodeClient(conn)
+
+	stream, err := c.StartJob(context.Background(), &pb.StartJobRequest{JobId: 1, Command: "echo", ReportedHeadnode: "headnode:50505"})
+	if err != nil {
+		t.Fatalf("Failed to start job: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Errorf("Expected signed stream accepted, got %v", err)
+	} else if verified := <-node.verified; verified != "headnode:50505" {
+		t.Errorf("Expected stream signed by headnode:50505, got %v", verified)
+	}
+	if _, err := c.QueryJob(context.Background(), &pb.QueryJobRequest{}); err != nil {
+		t.Errorf("Expected signed call accepted, got %v", err)
+	} else if verified := <-node.verified; verified != "headnode:50505" {
+		t.Errorf("Expected call signed by headnode:50505, got %v", verified)
+	}
+
+	// The calls are rejected if the clusnode is not enrolled by the headnode
+	NodeKeys.Delete("SIGNED-NODE")
+	if _, err := c.QueryJob(context.Background(), &pb.QueryJobRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected unsigned call unauthenticated, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	NodenameCase_Upper    = "upper"
+	NodenameCase_Lower    = "lower"
+	NodenameCase_Preserve = "preserve"
+
+	NodenameNormalization_None = "none"
+	NodenameNormalization_Nfc  = "nfc"
+	NodenameNormalization_Nfkc = "nfkc"
+)
+
+var (
+	nodenameCases          = []string{NodenameCase_Upper, NodenameCase_Lower, NodenameCase_Preserve}
+	nodenameNormalizations = []string{NodenameNormalization_None, NodenameNormalization_Nfc, NodenameNormalization_Nfkc}
+
+	nodenameCaseValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if !isInList(v, nodenameCases) {
+			return fmt.Errorf("Value should be one of: %v", strings.Join(nodenameCases, ", "))
+		}
+		return nil
+	}
+	nodenameNormalizationValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if !isInList(v, nodenameNormalizations) {
+			return fmt.Errorf("Value should be one of: %v", strings.Join(nodenameNormalizations, ", "))
+		}
+		return nil
+	}
+)
+
+func isInList(value string, list []string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Canonicalize the nodename reported by clusnode or specified by user by the rules configured on the headnode,
+// the unicode normalization is applied before case folding so that composed and decomposed names fold the same
+func canonicalNodename(name string) string {
+	return canonicalizeNodename(name, Config_Headnode_NodenameCase.GetString(), Config_Headnode_NodenameNormalization.GetString())
+}
+
+func canonicalizeNodename(name, name_case, normalization string) string {
+	name = strings.TrimSpace(name)
+	switch normalization {
+	case NodenameNormalization_Nfc:
+		name = norm.NFC.String(name)
+	case NodenameNormalization_Nfkc:
+		name = norm.NFKC.String(name)
+	}
+	switch name_case {
+	case NodenameCase_Upper:
+		name = strings.ToUpper(name)
+	case NodenameCase_Lower:
+		name = strings.ToLower(name)
+	}
+	return name
+}
+
+// Canonicalize a node specified by nodename, host address or display name like "nodename(host:port)",
+// in which the host is case insensitive and always in upper case as parsed by ParseHostAddress
+func canonicalNodeId(node string) string {
+	node = strings.TrimSpace(node)
+	if nodename, host := splitNodeDisplayName(node); len(host) > 0 {
+		return canonicalNodename(nodename) + "(" + strings.ToUpper(host) + ")"
+	} else if strings.Contains(node, ":") {
+		return strings.ToUpper(node)
+	}
+	return canonicalNodename(node)
+}
+
+// Split the display name like "nodename(host:port)" into the nodename and the host, the host is empty if the display name
+// is the nodename only. The host is in the last parentheses and always has a port, so that the nodename may contain "("
+// or ")" as well, e.g. "node(1)(host:port)".
+func splitNodeDisplayName(display_name string) (nodename, host string) {
+	if strings.HasSuffix(display_name, ")") {
+		if i := strings.LastIndex(display_name, "("); i > 0 {
+			if host = display_name[i+1 : len(display_name)-1]; strings.Contains(host, ":") {
+				return display_name[:i], host
+			}
+		}
+	}
+	return display_name, ""
+}
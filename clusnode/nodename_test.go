@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_canonicalizeNodename(t *testing.T) {
+	decomposed, composed := "Jose\u0301-pc", "Jos\u00e9-pc"
+	cases := []struct {
+		name          string
+		name_case     string
+		normalization string
+		expected      string
+	}{
+		{" node1 ", NodenameCase_Upper, NodenameNormalization_None, "NODE1"},
+		{"Node1", NodenameCase_Lower, NodenameNormalization_None, "node1"},
+		{"Node1", NodenameCase_Preserve, NodenameNormalization_None, "Node1"},
+		{decomposed, NodenameCase_Preserve, NodenameNormalization_None, decomposed},
+		{decomposed, NodenameCase_Preserve, NodenameNormalization_Nfc, composed},
+		{decomposed, NodenameCase_Upper, NodenameNormalization_Nfc, "JOS\u00c9-PC"},
+		{"Ｎｏｄｅ", NodenameCase_Lower, NodenameNormalization_Nfkc, "node"},
+	}
+
+	for _, c := range cases {
+		if result := canonicalizeNodename(c.name, c.name_case, c.normalization); result != c.expected {
+			t.Errorf("\nname=%q, case=%v, normalization=%v\nexpected=%q\n  actual=%q", c.name, c.name_case, c.normalization, c.expected, result)
+		}
+	}
+}
+
+func Test_splitNodeDisplayName(t *testing.T) {
+	cases := []struct {
+		display_name string
+		nodename     string
+		host         string
+	}{
+		{"node1", "node1", ""},
+		{"node1(HOST1:50505)", "node1", "HOST1:50505"},
+		{"node(1)(HOST1:50505)", "node(1)", "HOST1:50505"},
+		{"node(1)", "node(1)", ""},
+		{"node1([::1]:50505)", "node1", "[::1]:50505"},
+	}
+	for _, c := range cases {
+		if nodename, host := splitNodeDisplayName(c.display_name); nodename != c.nodename || host != c.host {
+			t.Errorf("Expected %q split into %q and %q, got %q and %q", c.display_name, c.nodename, c.host, nodename, host)
+		}
+	}
+	if host := parseHost("node(1)"); host != "node(1):"+DefaultPort {
+		t.Errorf("Expected default port for nodename only, got %v", host)
+	}
+	storeNodeHost("node(2)", "HOST2:50505")
+	defer nodeHosts.Delete("node(2)")
+	defer hostNodes.Delete("HOST2:50505")
+	if host := parseHost("node(2)"); host != "HOST2:50505" {
+		t.Errorf("Expected host mapped from display name, got %v", host)
+	}
+}
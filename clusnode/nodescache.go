@@ -0,0 +1,174 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"regexp"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	nodesCacheTtl      = time.Second // the states of nodes are decided by the time they report, so the cache expires soon
+	nodesWatchInterval = time.Second
+	nodesCachePatterns = 64 // the max count of patterns whose matched nodes are kept in the cache
+)
+
+var (
+	nodesGeneration int64 // increased once the nodes change, which invalidates the cache
+	nodesCacheLock  sync.Mutex
+	nodesCacheLast  *nodesCache
+)
+
+// The nodes built for a generation, shared by the GetNodes calls until the nodes change or it expires, which should not
+// be modified once built
+type nodesCache struct {
+	built      time.Time
+	generation int64
+	nodes      map[string]*pb.Node
+	snapshots  map[string]nodeSnapshot
+	version    int64
+	names      []string                  // the sorted names of nodes, including the removed ones in snapshots
+	byState    map[pb.NodeState][]string // the sorted names of nodes in each state
+
+	matchesLock sync.Mutex
+	matches     map[string]map[string]bool // the names of nodes matching each pattern queried
+}
+
+func getNodesCache() *nodesCache {
+	generation := atomic.LoadInt64(&nodesGeneration)
+	nodesCacheLock.Lock()
+	defer nodesCacheLock.Unlock()
+	if c := nodesCacheLast; c != nil && c.generation == generation && time.Since(c.built) < nodesCacheTtl {
+		return c
+	}
+	nodesCacheLast = buildNodesCache(generation)
+	return nodesCacheLast
+}
+
+func buildNodesCache(generation int64) *nodesCache {
+	nodes := getAllNodes()
+	snapshots, version := updateNodeVersions(nodes)
+	c := &nodesCache{
+		built:      time.Now(),
+		generation: generation,
+		nodes:      nodes,
+		snapshots:  snapshots,
+		version:    version,
+		names:      make([]string, 0, len(snapshots)),
+		byState:    map[pb.NodeState][]string{},
+		matches:    map[string]map[string]bool{},
+	}
+	for name := range snapshots {
+		c.names = append(c.names, name)
+	}
+	sort.Strings(c.names)
+	for _, name := range c.names {
+		if node, ok := nodes[name]; ok {
+			c.byState[node.State] = append(c.byState[node.State], name)
+		}
+	}
+	return c
+}
+
+// Get the names of nodes to filter, which are the nodes in the state unless the removed nodes are needed in the delta
+func (c *nodesCache) candidates(state pb.NodeState, delta bool) []string {
+	if state == pb.NodeState_Unknown || delta {
+		return c.names
+	}
+	return c.byState[state]
+}
+
+// Get the names of nodes matching the pattern, no node matches an invalid pattern
+func (c *nodesCache) match(pattern string) map[string]bool {
+	c.matchesLock.Lock()
+	defer c.matchesLock.Unlock()
+	if matched, ok := c.matches[pattern]; ok {
+		return matched
+	}
+	matched := map[string]bool{}
+	if r, err := regexp.Compile(pattern); err == nil {
+		for _, name := range c.names {
+			if r.MatchString(name) {
+				matched[name] = true
+			}
+		}
+	}
+	if len(c.matches) < nodesCachePatterns {
+		c.matches[pattern] = matched
+	}
+	return matched
+}
+
+// Get the current information of all nodes reported
+func getAllNodes() map[string]*pb.Node {
+	clusters := parseNodeClustersOrEmpty()
+	all_nodes := map[string]*pb.Node{}
+	for _, record := range ReportedNodes.Snapshot() {
+		nodename := record.name
+		node := &pb.Node{Name: nodename, State: getNodeState(nodename, record.reportedTime), Cluster: getNodeCluster(clusters, nodename)}
+		if zone, ok := nodeZones.Load(nodename); ok {
+			node.Zone = zone.(string)
+		}
+		node.Labels = getNodeLabels(nodename)
+		node.Probes = getNodeProbes(nodename)
+		if system, ok := nodeSystems.Load(nodename); ok {
+			node.System = system.(*pb.NodeSystem)
+		}
+		if build, ok := nodeBuilds.Load(nodename); ok {
+			node.Build = build.(*pb.NodeBuild)
+		}
+		if resources, ok := nodeResources.Load(nodename); ok {
+			node.Resources = resources.(*pb.NodeResources)
+		}
+		if stats, ok := nodeStats.Load(nodename); ok {
+			node.Stats = stats.(*pb.NodeStats)
+		}
+		skew, skewed := getNodeClockSkew(nodename)
+		node.ClockSkewMs, node.ClockSkewed = int64(skew/time.Millisecond), skewed
+		all_nodes[nodename] = node
+	}
+	NodeGroups.Range(func(k, v interface{}) bool {
+		group := k.(string)
+		n := v.(*sync.Map)
+		for _, node := range all_nodes {
+			if _, ok := n.Load(node.Name); ok {
+				node.Groups = append(node.Groups, group)
+			}
+		}
+		return true
+	})
+	for _, node := range all_nodes {
+		sort.Strings(node.Groups)
+	}
+	return all_nodes
+}
+
+// Send the nodes matching the request, then the nodes changed or removed since the last sent once they change
+func (s *headnode_server) WatchNodes(in *pb.GetNodesRequest, out pb.Headnode_WatchNodesServer) error {
+	defer LogPanicBeforeExit()
+	request := proto.Clone(in).(*pb.GetNodesRequest)
+	LogInfo("Start watching nodes matching %q", in.GetPattern())
+	for {
+		reply, err := queryNodes(out.Context(), request)
+		if err != nil {
+			return err
+		}
+		if !reply.Delta || len(reply.Nodes) > 0 || len(reply.RemovedNodes) > 0 {
+			if err := out.Send(reply); err != nil {
+				LogWarning("Failed to send nodes: %v", err)
+				return err
+			}
+		}
+		request.SinceVersion = reply.Version
+		select {
+		case <-out.Context().Done():
+			LogInfo("Stop watching nodes: %v", out.Context().Err())
+			return nil
+		case <-time.After(nodesWatchInterval):
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_nodesCache(t *testing.T) {
+	now := time.Now()
+	for node, last_report := range map[string]time.Time{"CACHED1": now, "CACHED2": now.Add(-time.Hour)} {
+		ReportedNodes.Touch(node, last_report)
+		defer ReportedNodes.Remove(node)
+	}
+	c := buildNodesCache(atomic.LoadInt64(&nodesGeneration))
+	if lost := c.candidates(pb.NodeState_Lost, false); !reflect.DeepEqual(lost, []string{"CACHED2"}) {
+		t.Errorf("Expected CACHED2 indexed as lost, got %v", lost)
+	}
+	if all := c.candidates(pb.NodeState_Lost, true); len(all) < 2 {
+		t.Errorf("Expected all nodes as candidates of delta, got %v", all)
+	}
+	if matched := c.match("^CACHED[12]$"); len(matched) != 2 || !matched["CACHED1"] || !matched["CACHED2"] {
+		t.Errorf("Expected both nodes matched, got %v", matched)
+	}
+	if matched := c.match("("); len(matched) != 0 {
+		t.Errorf("Expected no node matching invalid pattern, got %v", matched)
+	}
+	if cached := getNodesCache(); cached != getNodesCache() {
+		t.Error("Expected the cache reused before the nodes change")
+	}
+	cached := getNodesCache()
+	MarkNodesChanged()
+	if getNodesCache() == cached {
+		t.Error("Expected the cache rebuilt once the nodes change")
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	nodeStats     sync.Map // the aggregate stats of jobs run on each clusnode
+	nodeStatsLock sync.Mutex
+)
+
+// Record the task of a job ended on the clusnode, the stored stats are replaced instead of modified for concurrent readers
+func recordNodeJobStats(node string, job_id int32, succeeded bool, duration time.Duration) {
+	nodeStatsLock.Lock()
+	defer nodeStatsLock.Unlock()
+	stats := &pb.NodeStats{}
+	if s, ok := nodeStats.Load(node); ok {
+		stats = proto.Clone(s.(*pb.NodeStats)).(*pb.NodeStats)
+	}
+	stats.Jobs++
+	if succeeded {
+		stats.SucceededJobs++
+	}
+	stats.TotalDurationMs += int64(duration / time.Millisecond)
+	stats.LastJobId = job_id
+	stats.LastJobTime = time.Now().UnixNano()
+	stats.LastJobSucceeded = succeeded
+	nodeStats.Store(node, stats)
+	MarkNodesChanged()
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"os/exec"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	nodeTestProbes     = 3 // the clock skew is measured by the probe with the least round trip
+	nodeTestTimeout    = 10 * time.Second
+	nodeTestJobTimeout = 30 * time.Second
+)
+
+// Measure the heartbeat freshness, round trip, clock skew and time of running a no-op job of the nodes matching the
+// pattern, so that the network or time sync problems of nodes can be found
+func (s *headnode_server) TestNodes(ctx context.Context, in *pb.TestNodesRequest) (*pb.TestNodesReply, error) {
+	defer LogPanicBeforeExit()
+	pattern, concurrency := in.GetPattern(), int(in.GetConcurrency())
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pattern %q: %v", pattern, err)
+	}
+	if concurrency < 0 || concurrency > maxRevalidateConcurrency {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid concurrency %v, should be between 1 and %v", concurrency, maxRevalidateConcurrency)
+	} else if concurrency == 0 {
+		concurrency = defaultRevalidateConcurrency
+	}
+	cluster := getAuthCluster(ctx)
+	clusters := parseNodeClustersOrEmpty()
+	nodes := map[string]time.Time{}
+	for _, record := range ReportedNodes.Snapshot() {
+		if len(cluster) > 0 && getNodeCluster(clusters, record.name) != cluster {
+			continue
+		}
+		if matched, _ := regexp.MatchString(pattern, record.name); matched {
+			nodes[record.name] = record.reportedTime
+		}
+	}
+	LogInfo("Testing %v nodes with concurrency %v", len(nodes), concurrency)
+	reply := &pb.TestNodesReply{Results: []*pb.NodeTestResult{}}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	pool := newDispatchPool(concurrency)
+	for node, last_report := range nodes {
+		wg.Add(1)
+		pool.Acquire()
+		go func(node string, last_report time.Time) {
+			defer wg.Done()
+			defer pool.Release()
+			result := testNode(node, last_report, !in.GetSkipJob())
+			lock.Lock()
+			defer lock.Unlock()
+			reply.Results = append(reply.Results, result)
+		}(node, last_report)
+	}
+	wg.Wait()
+	sort.Slice(reply.Results, func(i, j int) bool { return reply.Results[i].Node < reply.Results[j].Node })
+	return reply, nil
+}
+
+func testNode(node string, last_report time.Time, run_job bool) *pb.NodeTestResult {
+	result := &pb.NodeTestResult{Node: node, HeartbeatAgeMs: int64(time.Since(last_report) / time.Millisecond)}
+	conn, release := GetNodeConnection(parseHost(node), connectionLane_Control)
+	if conn == nil {
+		result.Error = "Failed to connect"
+		return result
+	}
+	defer release()
+	c := pb.NewClusnodeClient(conn)
+	best := time.Duration(-1)
+	for i := 0; i < nodeTestProbes; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), nodeTestTimeout)
+		start := time.Now()
+		reply, err := c.Probe(ctx, &pb.ProbeRequest{Headnode: NodeHost})
+		round_trip := time.Since(start)
+		cancel()
+		if err != nil {
+			result.Error = "Failed to probe: " + status.Convert(err).Message()
+			return result
+		}
+		if best < 0 || round_trip < best {
+			// The clock of node is read around the middle of the round trip
+			best = round_trip
+			skew := time.Unix(0, reply.GetTimeUnixNano()).Sub(start.Add(round_trip / 2))
+			result.RoundTripMs, result.ClockSkewMs = int64(round_trip/time.Millisecond), int64(skew/time.Millisecond)
+		}
+	}
+	if run_job {
+		ctx, cancel := context.WithTimeout(context.Background(), nodeTestJobTimeout)
+		defer cancel()
+		reply, err := c.Probe(ctx, &pb.ProbeRequest{Headnode: NodeHost, RunJob: true})
+		if err != nil {
+			result.Error = "Failed to run no-op job: " + status.Convert(err).Message()
+		} else if len(reply.GetJobError()) > 0 {
+			result.Error = "Failed to run no-op job: " + reply.GetJobError()
+		} else {
+			result.JobMs = reply.GetJobMs()
+		}
+	}
+	return result
+}
+
+// Reply the time of this clusnode, and the time of starting and waiting a no-op command if requested
+func (s *clusnode_server) Probe(ctx context.Context, in *pb.ProbeRequest) (*pb.ProbeReply, error) {
+	defer LogPanicBeforeExit()
+	reply := &pb.ProbeReply{TimeUnixNano: time.Now().UnixNano()}
+	if in.GetRunJob() {
+		LogInfo("Run no-op job to probe from headnode %v", in.GetHeadnode())
+		cmd := exec.CommandContext(ctx, "true")
+		if RunOnWindows {
+			cmd = exec.CommandContext(ctx, "cmd", "/q", "/c", "exit 0")
+		}
+		start := time.Now()
+		if err := cmd.Run(); err != nil {
+			reply.JobError = err.Error()
+		}
+		reply.JobMs = int64(time.Since(start) / time.Millisecond)
+	}
+	return reply, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	nodesVersion      int64
+	nodesVersionEpoch = strconv.FormatInt(time.Now().UnixNano(), 36) // a token from a previous run of headnode is not valid
+	nodesVersionLock  sync.Mutex
+	nodeSnapshots     = map[string]*nodeSnapshot{}
+)
+
+// The last known state of a node and the version when it changed
+type nodeSnapshot struct {
+	state   pb.NodeState
+	groups  string
+	zone    string
+	labels  string
+	cluster string
+	system  string
+	build   string
+	version int64
+	removed bool
+}
+
+func formatNodesVersion(version int64) string {
+	return fmt.Sprintf("%v.%v", nodesVersionEpoch, version)
+}
+
+// Return false if the token is not issued by this run of headnode
+func parseNodesVersion(token string, current int64) (int64, bool) {
+	segs := strings.Split(token, ".")
+	if len(segs) != 2 || segs[0] != nodesVersionEpoch {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(segs[1], 10, 64)
+	if err != nil || version < 0 || version > current {
+		return 0, false
+	}
+	return version, true
+}
+
+// Compare the current nodes with the snapshots, bump the version of changed nodes and return the snapshots with current version
+func updateNodeVersions(nodes map[string]*pb.Node) (map[string]nodeSnapshot, int64) {
+	nodesVersionLock.Lock()
+	defer nodesVersionLock.Unlock()
+	for name, node := range nodes {
+		groups, system := strings.Join(node.Groups, ","), fmt.Sprintf("%v/%v/%v", node.System.GetOs(), node.System.GetArch(), node.System.GetVersion())
+		build, labels := fmt.Sprintf("%v/%v", node.Build.GetVersion(), node.Build.GetProtocol()), formatNodeLabels(node.Labels)
+		if s, ok := nodeSnapshots[name]; !ok || s.removed || s.state != node.State || s.groups != groups || s.zone != node.Zone || s.labels != labels || s.cluster != node.Cluster || s.system != system || s.build != build {
+			nodesVersion++
+			nodeSnapshots[name] = &nodeSnapshot{state: node.State, groups: groups, zone: node.Zone, labels: labels, cluster: node.Cluster, system: system, build: build, version: nodesVersion}
+		}
+	}
+	for name, s := range nodeSnapshots {
+		if _, ok := nodes[name]; !ok && !s.removed {
+			nodesVersion++
+			s.removed = true
+			s.version = nodesVersion
+		}
+	}
+	snapshots := make(map[string]nodeSnapshot, len(nodeSnapshots))
+	for name, s := range nodeSnapshots {
+		snapshots[name] = *s
+	}
+	return snapshots, nodesVersion
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bytes"
+	pb "clusrun/protobuf"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	NotifyEvent_JobFinished   = "job.finished"
+	NotifyEvent_JobFailed     = "job.failed"
+	NotifyEvent_JobCanceled   = "job.canceled"
+	NotifyEvent_NodeLost      = "node.lost"
+	NotifyEvent_NodeRecovered = "node.recovered"
+	NotifyEventsAll           = "all"
+	NotifyNone                = "none"
+	NotifyListSeparator       = ","
+
+	notifyTimeout       = 10 * time.Second
+	notifyRetryInterval = time.Second
+)
+
+var (
+	notifyEvents = []string{NotifyEvent_JobFinished, NotifyEvent_JobFailed, NotifyEvent_JobCanceled, NotifyEvent_NodeLost, NotifyEvent_NodeRecovered}
+
+	notifyWebhooksValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		_, err := parseNotifyWebhooks(v)
+		return err
+	}
+	notifySmtpValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		_, err := parseNotifySmtp(v)
+		return err
+	}
+	notifyEventsValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		_, err := parseNotifyEvents(v)
+		return err
+	}
+	notifyPatternValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		if _, err := regexp.Compile(v); err != nil {
+			return fmt.Errorf("Invalid pattern: %v", err)
+		}
+		return nil
+	}
+)
+
+// The event posted to the webhooks as JSON and mailed to the SMTP recipients
+type notifyEvent struct {
+	Type     string          `json:"type"`
+	Time     time.Time       `json:"time"`
+	Headnode string          `json:"headnode"`
+	Job      *notifyJobInfo  `json:"job,omitempty"`
+	Node     *notifyNodeInfo `json:"node,omitempty"`
+}
+
+type notifyJobInfo struct {
+	Id            int32            `json:"id"`
+	Name          string           `json:"name"`
+	Command       string           `json:"command"`
+	State         string           `json:"state"`
+	Nodes         int              `json:"nodes"`
+	FailedNodes   map[string]int32 `json:"failed_nodes"`
+	CorrelationId string           `json:"correlation_id"`
+}
+
+type notifyNodeInfo struct {
+	Name       string    `json:"name"`
+	LastReport time.Time `json:"last_report"`
+}
+
+// The SMTP target in format smtp://[user:password@]host:port?from=sender&to=recipient1,recipient2
+type notifySmtpTarget struct {
+	addr     string
+	host     string
+	user     string
+	password string
+	from     string
+	to       []string
+}
+
+func parseNotifyWebhooks(value string) ([]string, error) {
+	webhooks := []string{}
+	for _, webhook := range strings.Split(value, NotifyListSeparator) {
+		if webhook = strings.TrimSpace(webhook); len(webhook) == 0 {
+			continue
+		}
+		if u, err := url.Parse(webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return nil, fmt.Errorf("%q should be an http or https URL", webhook)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, nil
+}
+
+func parseNotifySmtp(value string) (*notifySmtpTarget, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != "smtp" || len(u.Hostname()) == 0 {
+		return nil, errors.New("Value should be in format smtp://[user:password@]host:port?from=sender&to=recipient1,recipient2")
+	}
+	target := &notifySmtpTarget{addr: u.Host, host: u.Hostname(), from: u.Query().Get("from")}
+	if len(u.Port()) == 0 {
+		target.addr = net.JoinHostPort(target.host, "25")
+	}
+	if u.User != nil {
+		target.user = u.User.Username()
+		target.password, _ = u.User.Password()
+	}
+	for _, to := range strings.Split(u.Query().Get("to"), NotifyListSeparator) {
+		if to = strings.TrimSpace(to); len(to) > 0 {
+			target.to = append(target.to, to)
+		}
+	}
+	if len(target.from) == 0 || len(target.to) == 0 {
+		return nil, errors.New("The sender and recipients should be specified by from and to")
+	}
+	return target, nil
+}
+
+func parseNotifyEvents(value string) (map[string]bool, error) {
+	events := map[string]bool{}
+	for _, event := range strings.Split(value, NotifyListSeparator) {
+		if event = strings.ToLower(strings.TrimSpace(event)); len(event) == 0 {
+			continue
+		}
+		if event == NotifyEventsAll {
+			for _, e := range notifyEvents {
+				events[e] = true
+			}
+			continue
+		}
+		valid := false
+		for _, e := range notifyEvents {
+			if event == e {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("Invalid event %q, valid events are: %v", event, strings.Join(notifyEvents, ", "))
+		}
+		events[event] = true
+	}
+	return events, nil
+}
+
+// The event of a job in the final state, empty if the state is not notified
+func getJobNotifyEvent(state pb.JobState) string {
+	switch state {
+	case pb.JobState_Finished:
+		return NotifyEvent_JobFinished
+	case pb.JobState_Failed, pb.JobState_Aborted, pb.JobState_CancelFailed, pb.JobState_Untracked:
+		return NotifyEvent_JobFailed
+	case pb.JobState_Canceled:
+		return NotifyEvent_JobCanceled
+	}
+	return ""
+}
+
+func notifyJob(job *pb.Job) {
+	event := getJobNotifyEvent(job.State)
+	if len(event) == 0 {
+		return
+	}
+	failed_nodes := make(map[string]int32, len(job.FailedNodes))
+	for node, exit_code := range job.FailedNodes {
+		failed_nodes[node] = exit_code
+	}
+	notify(&notifyEvent{
+		Type:     event,
+		Time:     time.Now(),
+		Headnode: NodeHost,
+		Job: &notifyJobInfo{
+			Id:            job.Id,
+			Name:          job.Name,
+			Command:       job.Command,
+			State:         job.State.String(),
+			Nodes:         len(job.Nodes),
+			FailedNodes:   failed_nodes,
+			CorrelationId: job.CorrelationId,
+		},
+	})
+}
+
+func notifyNode(event, node string, last_report time.Time) {
+	notify(&notifyEvent{Type: event, Time: time.Now(), Headnode: NodeHost, Node: &notifyNodeInfo{Name: node, LastReport: last_report}})
+}
+
+// Send the event to the webhooks and the SMTP target in background if it passes the filters
+func notify(event *notifyEvent) {
+	webhooks, _ := parseNotifyWebhooks(Config_Headnode_NotifyWebhooks.GetString())
+	target, _ := parseNotifySmtp(Config_Headnode_NotifySmtp.GetString())
+	if len(webhooks) == 0 && target == nil {
+		return
+	}
+	if !matchNotifyFilters(event, Config_Headnode_NotifyEvents.GetString(), Config_Headnode_NotifyPattern.GetString()) {
+		return
+	}
+	retries := Config_Headnode_NotifyRetries.GetInt()
+	for _, webhook := range webhooks {
+		go func(webhook string) {
+			defer LogPanicBeforeExit()
+			sendNotifyWithRetry(event, "webhook "+redactUrl(webhook), retries, func() error {
+				return postNotifyWebhook(webhook, event)
+			})
+		}(webhook)
+	}
+	if target != nil {
+		go func() {
+			defer LogPanicBeforeExit()
+			sendNotifyWithRetry(event, "SMTP server "+target.addr, retries, func() error {
+				return mailNotify(target, event)
+			})
+		}()
+	}
+}
+
+// The event is notified if its type is in the events, and the job name or node name matches the pattern
+func matchNotifyFilters(event *notifyEvent, events, pattern string) bool {
+	if types, err := parseNotifyEvents(events); err != nil || !types[event.Type] {
+		return false
+	}
+	name := ""
+	if event.Job != nil {
+		name = event.Job.Name
+	} else if event.Node != nil {
+		name = event.Node.Name
+	}
+	matched, _ := regexp.MatchString(pattern, name)
+	return matched
+}
+
+// Retry with exponential backoff since the notification is not sent
+func sendNotifyWithRetry(event *notifyEvent, target string, retries int, send func() error) {
+	interval := notifyRetryInterval
+	for i := 0; ; i++ {
+		err := send()
+		if err == nil {
+			LogInfo("Notified %v of %v", target, event.Type)
+			return
+		}
+		if i >= retries {
+			LogError("Failed to notify %v of %v after %v retries: %v", target, event.Type, retries, err)
+			return
+		}
+		LogWarning("Failed to notify %v of %v, retry in %v: %v", target, event.Type, interval, err)
+		time.Sleep(interval)
+		interval *= 2
+	}
+}
+
+func postNotifyWebhook(webhook string, event *notifyEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: notifyTimeout}
+	resp, err := client.Post(webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Unexpected status %v", resp.Status)
+	}
+	return nil
+}
+
+func mailNotify(target *notifySmtpTarget, event *notifyEvent) error {
+	var auth smtp.Auth
+	if len(target.user) > 0 {
+		auth = smtp.PlainAuth("", target.user, target.password, target.host)
+	}
+	return smtp.SendMail(target.addr, auth, target.from, target.to, formatNotifyMail(target, event))
+}
+
+func formatNotifyMail(target *notifySmtpTarget, event *notifyEvent) []byte {
+	var subject string
+	if event.Job != nil {
+		subject = fmt.Sprintf("[clusrun] %v: job %v %q on %v", event.Type, event.Job.Id, event.Job.Name, event.Headnode)
+	} else if event.Node != nil {
+		subject = fmt.Sprintf("[clusrun] %v: node %v on %v", event.Type, event.Node.Name, event.Headnode)
+	}
+	body, _ := json.MarshalIndent(event, "", "  ")
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %v\r\n", target.from)
+	fmt.Fprintf(&b, "To: %v\r\n", strings.Join(target.to, ", "))
+	fmt.Fprintf(&b, "Subject: %v\r\n", subject)
+	fmt.Fprintf(&b, "Date: %v\r\n", event.Time.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: application/json; charset=utf-8\r\n\r\n")
+	b.Write(bytes.ReplaceAll(body, []byte("\n"), []byte("\r\n")))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+// Hide the credentials in the URL of webhook in logs
+func redactUrl(webhook string) string {
+	u, err := url.Parse(webhook)
+	if err != nil {
+		return webhook
+	}
+	u.User = nil
+	u.RawQuery = ""
+	return u.String()
+}
@@ -0,0 +1,436 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	OutputObjectStoreNone = "none"
+
+	outputObjectExt     = ".obj"
+	objectStoreTimeout  = 30 * time.Minute
+	s3UnsignedPayload   = "UNSIGNED-PAYLOAD"
+	azureBlobApiVersion = "2020-04-08" // from which a blob up to 5000 MiB can be put at once
+	s3AccessKeyEnv      = "AWS_ACCESS_KEY_ID"
+	s3SecretKeyEnv      = "AWS_SECRET_ACCESS_KEY"
+	s3SessionTokenEnv   = "AWS_SESSION_TOKEN"
+	s3DefaultRegion     = "us-east-1"
+	s3AmzDateFormat     = "20060102T150405Z"
+	s3DateFormat        = "20060102"
+)
+
+var (
+	outputObjectStoreValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if len(v) > 0 {
+			if _, _, err := parseOutputObjectStore(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+)
+
+// The objects in a bucket of S3 or a container of Azure Blob, the keys are the full names of objects in it
+type objectStore interface {
+	Kind() string // the output storage storing in it
+	Put(key string, r io.Reader, size int64) error
+	Get(key string) ([]byte, error)
+	Delete(key string) error
+}
+
+// Parse the object store in format s3://bucket[/prefix][?region=region&endpoint=url] or
+// https://account.blob.core.windows.net/container[/prefix]?sas, and get the prefix of object keys
+func parseOutputObjectStore(value string) (objectStore, string, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, "", err
+	}
+	prefix := strings.Trim(u.Path, "/")
+	switch u.Scheme {
+	case "s3":
+		if len(u.Host) == 0 {
+			return nil, "", errors.New("Missing bucket of S3")
+		}
+		store := &s3ObjectStore{
+			bucket:        u.Host,
+			region:        u.Query().Get("region"),
+			access_key:    os.Getenv(s3AccessKeyEnv),
+			secret_key:    os.Getenv(s3SecretKeyEnv),
+			session_token: os.Getenv(s3SessionTokenEnv),
+		}
+		if len(store.region) == 0 {
+			store.region = s3DefaultRegion
+		}
+		if endpoint := u.Query().Get("endpoint"); len(endpoint) > 0 {
+			// The S3 compatible storage, e.g. MinIO, is accessed in path style
+			if store.endpoint, err = url.Parse(endpoint); err != nil || (store.endpoint.Scheme != "http" && store.endpoint.Scheme != "https") || len(store.endpoint.Host) == 0 {
+				return nil, "", errors.New("Endpoint of S3 should be an http or https URL")
+			}
+			store.path_style = true
+		} else {
+			store.endpoint = &url.URL{Scheme: "https", Host: fmt.Sprintf("%v.s3.%v.amazonaws.com", store.bucket, store.region)}
+		}
+		return store, prefix, nil
+	case "https":
+		parts := strings.SplitN(prefix, "/", 2)
+		if len(u.Host) == 0 || len(parts[0]) == 0 {
+			return nil, "", errors.New("Missing container of Azure Blob")
+		}
+		prefix = ""
+		if len(parts) > 1 {
+			prefix = parts[1]
+		}
+		store := &azureBlobObjectStore{
+			container: &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/" + parts[0]},
+			sas:       u.RawQuery,
+		}
+		return store, prefix, nil
+	default:
+		return nil, "", errors.New("Value should be in format s3://bucket[/prefix] or https://account.blob.core.windows.net/container[/prefix]?sas")
+	}
+}
+
+func getOutputObjectStore() (objectStore, string, error) {
+	value := Config_Headnode_OutputObjectStore.GetString()
+	if len(value) == 0 {
+		return nil, "", errors.New("Object store of output is not configured")
+	}
+	return parseOutputObjectStore(value)
+}
+
+// Upload the output file to the object store, in which the output of a job is under the prefix of the job id, and
+// replace the file with a ref to the object
+type objectOutputStorage struct {
+	kind string
+}
+
+func (s objectOutputStorage) Store(file string) error {
+	store, prefix, err := getOutputObjectStore()
+	if err != nil {
+		return err
+	} else if store.Kind() != s.kind {
+		return fmt.Errorf("Object store of output is not for storage %v", s.kind)
+	}
+	if Config_Headnode_OutputCompression.GetString() == OutputCompression_Gzip {
+		if err := compressOutputFile(file); err != nil {
+			return err
+		}
+	}
+	if err := encryptOutputFile(file); err != nil {
+		return err
+	}
+	local := file
+	if _, err := os.Stat(local); os.IsNotExist(err) {
+		local = file + compressedOutputExt
+	}
+	rel, err := filepath.Rel(db_outputDir, local)
+	if err != nil {
+		return err
+	}
+	key := path.Join(prefix, filepath.ToSlash(rel))
+	f, err := os.Open(local)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err == nil {
+		err = store.Put(key, f, info.Size())
+	}
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("Failed to upload to object %v: %v", key, err)
+	}
+
+	// The ref is written before the file is removed, so that the output is readable all the time
+	if err := writeOutputRef(file+outputObjectExt, key); err != nil {
+		return err
+	}
+	if err := os.Remove(local); err != nil {
+		LogWarning("Failed to remove output file %v stored in object %v: %v", local, key, err)
+	}
+	return nil
+}
+
+func readOutputObjectRef(ref string) (string, error) {
+	b, err := ioutil.ReadFile(ref)
+	if err != nil {
+		return "", err
+	}
+	key := strings.TrimSpace(string(b))
+	if len(key) == 0 {
+		return "", fmt.Errorf("Invalid output object ref %v", ref)
+	}
+	return key, nil
+}
+
+// Download the object in memory, the object store should be kept configured to read the output stored in it
+func openOutputObject(key string) (outputFile, int64, error) {
+	store, _, err := getOutputObjectStore()
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err := store.Get(key)
+	if err == nil {
+		data, err = decryptAtRest(data)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if strings.HasSuffix(key, compressedOutputExt) {
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, 0, err
+		}
+		if data, err = ioutil.ReadAll(r); err != nil {
+			return nil, 0, err
+		}
+	}
+	return memoryOutputFile{bytes.NewReader(data)}, int64(len(data)), nil
+}
+
+// Delete the objects referred by the output files in the dir, before it is removed
+func deleteOutputObjects(dir string) {
+	keys := []string{}
+	_ = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() && strings.HasSuffix(path, outputObjectExt) {
+			if key, err := readOutputObjectRef(path); err == nil {
+				keys = append(keys, key)
+			}
+		}
+		return nil
+	})
+	if len(keys) == 0 {
+		return
+	}
+	store, _, err := getOutputObjectStore()
+	if err != nil {
+		LogWarning("Failed to delete %v output objects in %v: %v", len(keys), dir, err)
+		return
+	}
+	for _, key := range keys {
+		if err := store.Delete(key); err != nil {
+			LogWarning("Failed to delete output object %v: %v", key, err)
+		}
+	}
+}
+
+func doObjectRequest(req *http.Request, expected ...int) (*http.Response, error) {
+	client := &http.Client{Timeout: objectStoreTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range expected {
+		if resp.StatusCode == code {
+			return resp, nil
+		}
+	}
+	content, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	resp.Body.Close()
+	return nil, fmt.Errorf("%v %v: %v", req.Method, resp.Status, strings.TrimSpace(string(content)))
+}
+
+func readObjectResponse(resp *http.Response, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
+}
+
+func closeObjectResponse(resp *http.Response, err error) error {
+	if err == nil {
+		resp.Body.Close()
+	}
+	return err
+}
+
+// A bucket of S3 or S3 compatible storage accessed by signature version 4 with the credentials in environment variables
+type s3ObjectStore struct {
+	endpoint      *url.URL
+	bucket        string
+	region        string
+	path_style    bool
+	access_key    string
+	secret_key    string
+	session_token string
+}
+
+func (s *s3ObjectStore) Kind() string {
+	return OutputStorage_S3
+}
+
+func (s *s3ObjectStore) newRequest(method, key string, body io.Reader, size int64) (*http.Request, error) {
+	if len(s.access_key) == 0 || len(s.secret_key) == 0 {
+		return nil, fmt.Errorf("Missing credentials of S3 in environment variables %v and %v", s3AccessKeyEnv, s3SecretKeyEnv)
+	}
+	u := *s.endpoint
+	u.Path, u.RawPath = "/"+key, "/"+escapeS3Path(key)
+	if s.path_style {
+		u.Path, u.RawPath = "/"+s.bucket+u.Path, "/"+escapeS3Path(s.bucket)+u.RawPath
+	}
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	req.Header.Set("x-amz-content-sha256", s3UnsignedPayload)
+	if len(s.session_token) > 0 {
+		req.Header.Set("x-amz-security-token", s.session_token)
+	}
+	signAwsRequest(req, s.region, "s3", s.access_key, s.secret_key, time.Now())
+	return req, nil
+}
+
+func (s *s3ObjectStore) Put(key string, r io.Reader, size int64) error {
+	req, err := s.newRequest(http.MethodPut, key, r, size)
+	if err != nil {
+		return err
+	}
+	return closeObjectResponse(doObjectRequest(req, http.StatusOK))
+}
+
+func (s *s3ObjectStore) Get(key string) ([]byte, error) {
+	req, err := s.newRequest(http.MethodGet, key, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return readObjectResponse(doObjectRequest(req, http.StatusOK))
+}
+
+func (s *s3ObjectStore) Delete(key string) error {
+	req, err := s.newRequest(http.MethodDelete, key, nil, 0)
+	if err != nil {
+		return err
+	}
+	return closeObjectResponse(doObjectRequest(req, http.StatusNoContent, http.StatusOK, http.StatusNotFound))
+}
+
+// Escape the path as S3 expects, in which only the unreserved characters and '/' are not escaped
+func escapeS3Path(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte("-._~/", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// Sign the request to the service by AWS signature version 4, the host and the headers already set are signed, and the
+// hash of payload is from the header x-amz-content-sha256
+func signAwsRequest(req *http.Request, region, service, access_key, secret_key string, now time.Time) {
+	now = now.UTC()
+	amz_date, date := now.Format(s3AmzDateFormat), now.Format(s3DateFormat)
+	req.Header.Set("x-amz-date", amz_date)
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonical_headers strings.Builder
+	for _, name := range names {
+		canonical_headers.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signed_headers := strings.Join(names, ";")
+	canonical_request := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonical_headers.String(),
+		signed_headers,
+		req.Header.Get("x-amz-content-sha256"),
+	}, "\n")
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	hash := sha256.Sum256([]byte(canonical_request))
+	string_to_sign := strings.Join([]string{"AWS4-HMAC-SHA256", amz_date, scope, hex.EncodeToString(hash[:])}, "\n")
+	key := hmacSha256([]byte("AWS4"+secret_key), date)
+	for _, s := range []string{region, service, "aws4_request"} {
+		key = hmacSha256(key, s)
+	}
+	signature := hex.EncodeToString(hmacSha256(key, string_to_sign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%v/%v, SignedHeaders=%v, Signature=%v", access_key, scope, signed_headers, signature))
+}
+
+// A container of Azure Blob accessed by the shared access signature in the query of its URL
+type azureBlobObjectStore struct {
+	container *url.URL
+	sas       string
+}
+
+func (s *azureBlobObjectStore) Kind() string {
+	return OutputStorage_AzureBlob
+}
+
+func (s *azureBlobObjectStore) newRequest(method, key string, body io.Reader, size int64) (*http.Request, error) {
+	u := *s.container
+	u.Path += "/" + key
+	u.RawQuery = s.sas
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	req.Header.Set("x-ms-version", azureBlobApiVersion)
+	return req, nil
+}
+
+func (s *azureBlobObjectStore) Put(key string, r io.Reader, size int64) error {
+	req, err := s.newRequest(http.MethodPut, key, r, size)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	return closeObjectResponse(doObjectRequest(req, http.StatusCreated))
+}
+
+func (s *azureBlobObjectStore) Get(key string) ([]byte, error) {
+	req, err := s.newRequest(http.MethodGet, key, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return readObjectResponse(doObjectRequest(req, http.StatusOK))
+}
+
+func (s *azureBlobObjectStore) Delete(key string) error {
+	req, err := s.newRequest(http.MethodDelete, key, nil, 0)
+	if err != nil {
+		return err
+	}
+	return closeObjectResponse(doObjectRequest(req, http.StatusAccepted, http.StatusNotFound))
+}
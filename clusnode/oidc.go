@@ -0,0 +1,244 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	oidcTimeout       = 10 * time.Second
+	oidcKeysTtl       = time.Hour
+	oidcKeysRefetch   = time.Minute // the min interval to fetch the keys, so that tokens of unknown kids can't flood the issuer
+	oidcClockSkew     = time.Minute
+)
+
+var (
+	oidcIssuerValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		if u, err := url.Parse(v); err != nil || (u.Scheme != "https" && u.Scheme != "http") || len(u.Host) == 0 {
+			return errors.New("Value should be an http or https URL")
+		}
+		return nil
+	}
+)
+
+// Authenticate the user by the ID token issued by the OIDC provider for the client id, and get its groups from the groups claim
+type oidcAuthProvider struct {
+	lock      sync.Mutex
+	issuer    string
+	keys      map[string]*rsa.PublicKey // keyed by kid
+	fetched   time.Time
+	attempted time.Time // the last fetch whether it succeeded or not
+}
+
+func (p *oidcAuthProvider) Name() string {
+	return "OIDC"
+}
+
+func (p *oidcAuthProvider) Enabled() bool {
+	return len(Config_Headnode_AuthOidcIssuer.GetString()) > 0 && len(Config_Headnode_AuthOidcClientId.GetString()) > 0
+}
+
+func (p *oidcAuthProvider) Scheme() string {
+	return authTokenPrefix
+}
+
+func (p *oidcAuthProvider) Authenticate(credential string) (*authIdentity, error) {
+	if strings.Count(credential, ".") != 2 {
+		// Not a JWT, e.g. a static token not matched
+		return nil, errors.New("Invalid ID token")
+	}
+	issuer := strings.TrimSuffix(Config_Headnode_AuthOidcIssuer.GetString(), "/")
+	return verifyIdToken(credential, issuer, Config_Headnode_AuthOidcClientId.GetString(), time.Now(), func(kid string) (*rsa.PublicKey, error) {
+		return p.getKey(issuer, kid)
+	})
+}
+
+// Get the signing key of the issuer, the keys are fetched again if expired or the kid is unknown after key rotation,
+// but not more often than the refetch interval, in which an unknown kid is rejected without fetching
+func (p *oidcAuthProvider) getKey(issuer, kid string) (*rsa.PublicKey, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.issuer != issuer {
+		p.issuer, p.keys, p.fetched, p.attempted = issuer, nil, time.Time{}, time.Time{}
+	}
+	key, ok := p.keys[kid]
+	if ok && time.Since(p.fetched) < oidcKeysTtl {
+		return key, nil
+	}
+	if time.Since(p.attempted) < oidcKeysRefetch {
+		if ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("Unknown signing key %q", kid)
+	}
+	p.attempted = time.Now()
+	keys, err := fetchOidcKeys(issuer)
+	if err != nil {
+		return nil, err
+	}
+	p.keys, p.fetched = keys, time.Now()
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("Unknown signing key %q", kid)
+}
+
+func fetchOidcKeys(issuer string) (map[string]*rsa.PublicKey, error) {
+	client := &http.Client{Timeout: oidcTimeout}
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JwksUri string `json:"jwks_uri"`
+	}
+	if err := getJson(client, issuer+oidcDiscoveryPath, &discovery); err != nil {
+		return nil, fmt.Errorf("Failed to get OIDC discovery document: %v", err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer || len(discovery.JwksUri) == 0 {
+		return nil, fmt.Errorf("Invalid OIDC discovery document of issuer %v", issuer)
+	}
+	var jwks struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := getJson(client, discovery.JwksUri, &jwks); err != nil {
+		return nil, fmt.Errorf("Failed to get OIDC signing keys: %v", err)
+	}
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (len(k.Use) > 0 && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys, nil
+}
+
+func getJson(client *http.Client, address string, v interface{}) error {
+	resp, err := client.Get(address)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%v returns %v", address, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// Verify the signature and claims of the ID token signed by RS256, and return the identity in it
+func verifyIdToken(token, issuer, client_id string, now time.Time, getKey func(kid string) (*rsa.PublicKey, error)) (*authIdentity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("Invalid ID token")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJwtPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("Invalid header of ID token: %v", err)
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("Unsupported signing algorithm %q", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("Invalid signature of ID token")
+	}
+	key, err := getKey(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, errors.New("Invalid signature of ID token")
+	}
+
+	var claims struct {
+		Iss               string          `json:"iss"`
+		Sub               string          `json:"sub"`
+		Aud               json.RawMessage `json:"aud"`
+		Exp               int64           `json:"exp"`
+		Nbf               int64           `json:"nbf"`
+		PreferredUsername string          `json:"preferred_username"`
+		Email             string          `json:"email"`
+		Groups            []string        `json:"groups"`
+	}
+	if err := decodeJwtPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("Invalid claims of ID token: %v", err)
+	}
+	if strings.TrimSuffix(claims.Iss, "/") != issuer {
+		return nil, fmt.Errorf("ID token is issued by %v", claims.Iss)
+	}
+	var audiences []string
+	if err := json.Unmarshal(claims.Aud, &audiences); err != nil {
+		var audience string
+		if err := json.Unmarshal(claims.Aud, &audience); err != nil {
+			return nil, errors.New("Invalid audience of ID token")
+		}
+		audiences = []string{audience}
+	}
+	matched := false
+	for _, audience := range audiences {
+		matched = matched || audience == client_id
+	}
+	if !matched {
+		return nil, errors.New("ID token is not issued for the client id")
+	}
+	expire := time.Unix(claims.Exp, 0)
+	if claims.Exp == 0 || now.After(expire.Add(oidcClockSkew)) {
+		return nil, errors.New("ID token is expired")
+	}
+	if claims.Nbf != 0 && now.Add(oidcClockSkew).Before(time.Unix(claims.Nbf, 0)) {
+		return nil, errors.New("ID token is not valid yet")
+	}
+	identity := &authIdentity{User: claims.Sub, Groups: claims.Groups, Expire: expire}
+	if len(claims.PreferredUsername) > 0 {
+		identity.User = claims.PreferredUsername
+	} else if len(claims.Email) > 0 {
+		identity.User = claims.Email
+	}
+	if len(identity.User) == 0 {
+		return nil, errors.New("Missing user in ID token")
+	}
+	return identity, nil
+}
+
+func decodeJwtPart(part string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_verifyIdToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.New(rand.NewSource(1)), 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	issuer, client_id, now := "https://login.example.com", "clusrun", time.Unix(1600000000, 0)
+	sign := func(alg string, claims map[string]interface{}) string {
+		header, _ := json.Marshal(map[string]string{"alg": alg, "kid": "k1"})
+		payload, _ := json.Marshal(claims)
+		signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+		digest := sha256.Sum256([]byte(signed))
+		signature, _ := rsa.SignPKCS1v15(nil, key, crypto.SHA256, digest[:])
+		return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+	}
+	getKey := func(kid string) (*rsa.PublicKey, error) {
+		if kid != "k1" {
+			return nil, errors.New("unknown key")
+		}
+		return &key.PublicKey, nil
+	}
+	claims := func(modify func(map[string]interface{})) map[string]interface{} {
+		c := map[string]interface{}{"iss": issuer, "aud": client_id, "sub": "id1", "exp": now.Unix() + 60, "groups": []string{"ops"}}
+		modify(c)
+		return c
+	}
+
+	identity, err := verifyIdToken(sign("RS256", claims(func(c map[string]interface{}) { c["email"] = "alice@example.com" })), issuer, client_id, now, getKey)
+	if err != nil {
+		t.Fatalf("failed to verify token: %v", err)
+	}
+	if identity.User != "alice@example.com" || !reflect.DeepEqual(identity.Groups, []string{"ops"}) || identity.Expire.Unix() != now.Unix()+60 {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+	identity, err = verifyIdToken(sign("RS256", claims(func(c map[string]interface{}) { c["aud"] = []string{"other", client_id} })), issuer, client_id, now, getKey)
+	if err != nil || identity.User != "id1" {
+		t.Errorf("failed to verify token with audiences: %v", err)
+	}
+
+	invalid := map[string]string{
+		"algorithm": sign("HS256", claims(func(map[string]interface{}) {})),
+		"issuer":    sign("RS256", claims(func(c map[string]interface{}) { c["iss"] = "https://other.example.com" })),
+		"audience":  sign("RS256", claims(func(c map[string]interface{}) { c["aud"] = "other" })),
+		"expired":   sign("RS256", claims(func(c map[string]interface{}) { c["exp"] = now.Unix() - 3600 })),
+		"not valid": sign("RS256", claims(func(c map[string]interface{}) { c["nbf"] = now.Unix() + 3600 })),
+		"signature": sign("RS256", claims(func(map[string]interface{}) {})) + "x",
+		"malformed": "a.b",
+	}
+	for name, token := range invalid {
+		if _, err := verifyIdToken(token, issuer, client_id, now, getKey); err == nil {
+			t.Errorf("token with invalid %v is verified", name)
+		}
+	}
+}
+
+func Test_oidcAuthProvider_getKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.New(rand.NewSource(1)), 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	fetches := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == oidcDiscoveryPath {
+			_ = json.NewEncoder(w).Encode(map[string]string{"issuer": server.URL, "jwks_uri": server.URL + "/keys"})
+			return
+		}
+		fetches++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kid": "k1",
+			"kty": "RSA",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	defer server.Close()
+
+	p := &oidcAuthProvider{}
+	if k, err := p.getKey(server.URL, "k1"); err != nil || k.N.Cmp(key.N) != 0 {
+		t.Fatalf("failed to get key: %v", err)
+	}
+	// The unknown kids are rejected without fetching the keys again in the refetch interval
+	for i := 0; i < 3; i++ {
+		if _, err := p.getKey(server.URL, "forged"); err == nil {
+			t.Errorf("unknown key is got")
+		}
+	}
+	if _, err := p.getKey(server.URL, "k1"); err != nil || fetches != 1 {
+		t.Errorf("expected keys fetched once, got %v fetches: %v", fetches, err)
+	}
+	// The keys are fetched again for an unknown kid after the refetch interval, e.g. the keys are rotated
+	p.attempted = p.attempted.Add(-oidcKeysRefetch)
+	if _, err := p.getKey(server.URL, "rotated"); err == nil || fetches != 2 {
+		t.Errorf("expected keys fetched again for unknown key, got %v fetches: %v", fetches, err)
+	}
+	// The expired keys are used until they can be fetched again
+	p.fetched = p.fetched.Add(-oidcKeysTtl)
+	if _, err := p.getKey(server.URL, "k1"); err != nil || fetches != 2 {
+		t.Errorf("expected expired key used in the refetch interval, got %v fetches: %v", fetches, err)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DispatchOrder_Alphabetical = "alphabetical"
+	DispatchOrder_Random       = "random"
+	DispatchOrder_Group        = "group"
+	DispatchOrder_Duration     = "duration"
+	DispatchOrder_Zone         = "zone"
+
+	ZoneNone = "none"
+)
+
+var (
+	dispatchOrders = []string{DispatchOrder_Alphabetical, DispatchOrder_Random, DispatchOrder_Group, DispatchOrder_Duration, DispatchOrder_Zone}
+
+	dispatchOrderValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if !isValidDispatchOrder(v) {
+			return fmt.Errorf("Value should be one of: %v", strings.Join(dispatchOrders, ", "))
+		}
+		return nil
+	}
+
+	// The duration of the last task finished on each node
+	lastTaskDuration sync.Map
+)
+
+func isValidDispatchOrder(order string) bool {
+	for _, o := range dispatchOrders {
+		if o == order {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the nodes in the order to dispatch with the current groups, zones and task durations of nodes, and the batch of each node
+func getDispatchPlan(order string, nodes []string, batch_size int) ([]string, map[string]int, int) {
+	groups := map[string]string{}
+	durations := map[string]time.Duration{}
+	switch order {
+	case DispatchOrder_Zone:
+		groups = getNodeZones(nodes)
+	case DispatchOrder_Group:
+		NodeGroups.Range(func(k, v interface{}) bool {
+			group := k.(string)
+			for _, node := range nodes {
+				if _, ok := v.(*sync.Map).Load(node); ok {
+					if g, ok := groups[node]; !ok || group < g {
+						groups[node] = group
+					}
+				}
+			}
+			return true
+		})
+	case DispatchOrder_Duration:
+		for _, node := range nodes {
+			if d, ok := lastTaskDuration.Load(node); ok {
+				durations[node] = d.(time.Duration)
+			}
+		}
+	}
+	ordered := orderNodes(order, nodes, groups, durations, rand.New(rand.NewSource(time.Now().UnixNano())))
+	batches, count := getDispatchBatches(order, ordered, groups, batch_size)
+	return ordered, batches, count
+}
+
+// Order the sorted nodes:
+// alphabetical keeps the order,
+// random shuffles the nodes,
+// group takes nodes from each group (the first one of a node in alphabetical order) in turn to spread the risk,
+// duration puts the nodes with shorter last task duration first and the nodes without duration last,
+// zone puts the nodes in the same zone (specified in groups) together and the nodes without zone last.
+func orderNodes(order string, nodes []string, groups map[string]string, durations map[string]time.Duration, r *rand.Rand) []string {
+	ordered := make([]string, len(nodes))
+	copy(ordered, nodes)
+	switch order {
+	case DispatchOrder_Random:
+		r.Shuffle(len(ordered), func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] })
+	case DispatchOrder_Group:
+		var names []string
+		buckets := map[string][]string{}
+		for _, node := range nodes {
+			group := groups[node]
+			if _, ok := buckets[group]; !ok {
+				names = append(names, group)
+			}
+			buckets[group] = append(buckets[group], node)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			// The nodes not in any group are the last bucket
+			return names[j] == "" || names[i] != "" && names[i] < names[j]
+		})
+		ordered = ordered[:0]
+		for i := 0; len(ordered) < len(nodes); i++ {
+			for _, name := range names {
+				if i < len(buckets[name]) {
+					ordered = append(ordered, buckets[name][i])
+				}
+			}
+		}
+	case DispatchOrder_Zone:
+		sort.SliceStable(ordered, func(i, j int) bool {
+			z1, z2 := groups[ordered[i]], groups[ordered[j]]
+			return z1 != z2 && (z2 == "" || z1 != "" && z1 < z2)
+		})
+	case DispatchOrder_Duration:
+		sort.SliceStable(ordered, func(i, j int) bool {
+			d1, ok1 := durations[ordered[i]]
+			d2, ok2 := durations[ordered[j]]
+			return ok1 && (!ok2 || d1 < d2)
+		})
+	}
+	return ordered
+}
+
+func getNodeZones(nodes []string) map[string]string {
+	zones := map[string]string{}
+	for _, node := range nodes {
+		if zone, ok := nodeZones.Load(node); ok {
+			zones[node] = zone.(string)
+		}
+	}
+	return zones
+}
+
+// Get the batch of each node in dispatch order, the nodes in a batch are dispatched after the previous batch finishes.
+// All nodes are in one batch unless dispatching by zone, in which each zone is a batch so that at most one zone runs the job at a time.
+// A batch is split further to have at most the batch size of nodes if it is positive, so that a rolling job runs on them in turn.
+func getDispatchBatches(order string, ordered []string, zones map[string]string, batch_size int) (map[string]int, int) {
+	batches := make(map[string]int, len(ordered))
+	count, size := 0, 0
+	for i, node := range ordered {
+		if i == 0 || order == DispatchOrder_Zone && zones[node] != zones[ordered[i-1]] || batch_size > 0 && size == batch_size {
+			count++
+			size = 0
+		}
+		size++
+		batches[node] = count - 1
+	}
+	return batches, count
+}
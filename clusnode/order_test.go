@@ -0,0 +1,63 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func Test_orderNodes(t *testing.T) {
+	nodes := []string{"A1", "A2", "A3", "B1", "B2", "C1"}
+	groups := map[string]string{"A1": "rack1", "A2": "rack1", "A3": "rack1", "B1": "rack2", "B2": "rack2"}
+	durations := map[string]time.Duration{"A1": 3 * time.Second, "A3": time.Second, "B2": time.Second, "C1": 2 * time.Second}
+	cases := []struct {
+		order    string
+		expected []string
+	}{
+		{DispatchOrder_Alphabetical, []string{"A1", "A2", "A3", "B1", "B2", "C1"}},
+		{DispatchOrder_Group, []string{"A1", "B1", "C1", "A2", "B2", "A3"}},
+		{DispatchOrder_Duration, []string{"A3", "B2", "C1", "A1", "A2", "B1"}},
+	}
+
+	for _, c := range cases {
+		if ordered := orderNodes(c.order, nodes, groups, durations, rand.New(rand.NewSource(1))); !reflect.DeepEqual(ordered, c.expected) {
+			t.Errorf("\norder=%v\nexpected=%v\n  actual=%v", c.order, c.expected, ordered)
+		}
+	}
+
+	ordered := orderNodes(DispatchOrder_Random, nodes, nil, nil, rand.New(rand.NewSource(1)))
+	sorted := append([]string{}, ordered...)
+	sort.Strings(sorted)
+	if !reflect.DeepEqual(sorted, nodes) {
+		t.Errorf("\norder=%v\nexpected a permutation of %v\n  actual=%v", DispatchOrder_Random, nodes, ordered)
+	}
+}
+
+func Test_getDispatchBatches(t *testing.T) {
+	nodes := []string{"A1", "A2", "B1", "B2", "C1"}
+	zones := map[string]string{"A1": "zone2", "A2": "zone1", "B1": "zone2", "B2": "zone1"}
+	cases := []struct {
+		order           string
+		batchSize       int
+		expectedOrder   []string
+		expectedBatches map[string]int
+		expectedCount   int
+	}{
+		{DispatchOrder_Alphabetical, 0, nodes, map[string]int{"A1": 0, "A2": 0, "B1": 0, "B2": 0, "C1": 0}, 1},
+		{DispatchOrder_Zone, 0, []string{"A2", "B2", "A1", "B1", "C1"}, map[string]int{"A2": 0, "B2": 0, "A1": 1, "B1": 1, "C1": 2}, 3},
+		{DispatchOrder_Alphabetical, 1, nodes, map[string]int{"A1": 0, "A2": 1, "B1": 2, "B2": 3, "C1": 4}, 5},
+		{DispatchOrder_Alphabetical, 2, nodes, map[string]int{"A1": 0, "A2": 0, "B1": 1, "B2": 1, "C1": 2}, 3},
+		{DispatchOrder_Zone, 3, []string{"A2", "B2", "A1", "B1", "C1"}, map[string]int{"A2": 0, "B2": 0, "A1": 1, "B1": 1, "C1": 2}, 3},
+		{DispatchOrder_Zone, 1, []string{"A2", "B2", "A1", "B1", "C1"}, map[string]int{"A2": 0, "B2": 1, "A1": 2, "B1": 3, "C1": 4}, 5},
+	}
+
+	for _, c := range cases {
+		ordered := orderNodes(c.order, nodes, zones, nil, nil)
+		batches, count := getDispatchBatches(c.order, ordered, zones, c.batchSize)
+		if !reflect.DeepEqual(ordered, c.expectedOrder) || !reflect.DeepEqual(batches, c.expectedBatches) || count != c.expectedCount {
+			t.Errorf("\norder=%v, batch size=%v\nexpected order=%v, batches=%v, count=%v\n  actual order=%v, batches=%v, count=%v", c.order, c.batchSize, c.expectedOrder, c.expectedBatches, c.expectedCount, ordered, batches, count)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	OrphanAction_Kill = "kill"
+	OrphanAction_Keep = "keep"
+
+	orphanCheckPeriod = 10 * time.Second
+)
+
+var (
+	orphanActions = []string{OrphanAction_Kill, OrphanAction_Keep}
+
+	orphanActionValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if v != OrphanAction_Kill && v != OrphanAction_Keep {
+			return fmt.Errorf("Value should be one of: %v", strings.Join(orphanActions, ", "))
+		}
+		return nil
+	}
+
+	runningJobs      sync.Map               // job label -> *runningJob
+	orphanedJobs     = map[string][]int32{} // headnode -> ids of the orphaned jobs not reported yet
+	orphanedJobsLock sync.Mutex
+)
+
+// A job running on this clusnode with the stream to its headnode
+type runningJob struct {
+	headnode  string
+	jobId     int32
+	pid       int
+	ctx       context.Context // the context of the job stream, which is done once the stream is broken
+	logger    *Logger
+	lostSince time.Time
+	orphaned  bool
+}
+
+func trackRunningJob(job_label, headnode string, job_id int32, pid int, ctx context.Context, logger *Logger) {
+	runningJobs.Store(job_label, &runningJob{headnode: headnode, jobId: job_id, pid: pid, ctx: ctx, logger: logger})
+}
+
+// Track the job with the stream resumed by its headnode
+func resumeRunningJob(job_label string, ctx context.Context) {
+	if val, ok := runningJobs.Load(job_label); ok {
+		job := *val.(*runningJob)
+		job.ctx = ctx
+		runningJobs.Store(job_label, &job)
+	}
+}
+
+func untrackRunningJob(job_label string) {
+	runningJobs.Delete(job_label)
+}
+
+// The headnode of job is gone if the job stream is broken, or the heartbeats to it fail while it is not removed
+func isJobHeadnodeGone(job *runningJob) bool {
+	if job.ctx.Err() != nil {
+		return true
+	}
+	if state, ok := headnodesReporting.Load(job.headnode); ok {
+		s := state.(*heartbeat_state)
+		return !s.Stopped && !s.Connected
+	}
+	return false
+}
+
+// Update the time since which the headnode of job is gone, return true once the job is orphaned longer than the timeout
+func checkOrphanedJob(job *runningJob, gone bool, now time.Time, timeout time.Duration) bool {
+	if !gone {
+		job.lostSince = time.Time{}
+		return false
+	}
+	if job.lostSince.IsZero() {
+		job.lostSince = now
+	}
+	if job.orphaned || timeout <= 0 || now.Sub(job.lostSince) < timeout {
+		return false
+	}
+	job.orphaned = true
+	return true
+}
+
+// Check the running jobs periodically, the orphaned ones are killed or kept by the config and reported to their headnodes
+func reapOrphanedJobs() {
+	defer LogPanicBeforeExit()
+	for {
+		time.Sleep(orphanCheckPeriod)
+		timeout := time.Duration(Config_Clusnode_OrphanTimeoutSecond.GetInt()) * time.Second
+		now := time.Now()
+		runningJobs.Range(func(key, val interface{}) bool {
+			job_label, job := key.(string), val.(*runningJob)
+			lost := !job.lostSince.IsZero()
+			gone := isJobHeadnodeGone(job)
+			if gone && !lost {
+				job.logger.Warning("Headnode %v of job %v is gone", job.headnode, job_label)
+			} else if !gone && lost && !job.orphaned {
+				job.logger.Info("Headnode %v of job %v is back", job.headnode, job_label)
+			}
+			if checkOrphanedJob(job, gone, now, timeout) {
+				handleOrphanedJob(job_label, job)
+			}
+			return true
+		})
+	}
+}
+
+func handleOrphanedJob(job_label string, job *runningJob) {
+	action := Config_Clusnode_OrphanAction.GetString()
+	job.logger.Warning("Job %v is orphaned since its headnode %v is gone at %v, %v it", job_label, job.headnode, job.lostSince.Format(time.RFC3339), action)
+	result := "Kept process " + strconv.Itoa(job.pid)
+	if action == OrphanAction_Kill {
+		killJobProcess(job.logger, job_label, job.pid)
+		result = "Killed process " + strconv.Itoa(job.pid)
+	}
+	audit(job.headnode, AuditAction_JobOrphan, job.jobId, "", "", result)
+	orphanedJobsLock.Lock()
+	defer orphanedJobsLock.Unlock()
+	orphanedJobs[job.headnode] = append(orphanedJobs[job.headnode], job.jobId)
+}
+
+// The orphaned jobs are reported in the heartbeats to their headnode until sent successfully
+func getOrphanedJobs(headnode string) []int32 {
+	orphanedJobsLock.Lock()
+	defer orphanedJobsLock.Unlock()
+	return append([]int32(nil), orphanedJobs[headnode]...)
+}
+
+func removeReportedOrphanedJobs(headnode string, reported []int32) {
+	orphanedJobsLock.Lock()
+	defer orphanedJobsLock.Unlock()
+	removed := make(map[int32]bool, len(reported))
+	for _, id := range reported {
+		removed[id] = true
+	}
+	ids := []int32{}
+	for _, id := range orphanedJobs[headnode] {
+		if !removed[id] {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		delete(orphanedJobs, headnode)
+	} else {
+		orphanedJobs[headnode] = ids
+	}
+}
+
+// Record the nodes of the jobs reported orphaned, whose headnode was gone while they were running
+func receiveOrphanedJobs(display_name string, ids []int32) {
+	for _, id := range ids {
+		LogWarning("Task of job %v on node %v was orphaned after headnode was gone", id, display_name)
+		UpdateJobOrphanedNodes(id, display_name)
+	}
+}
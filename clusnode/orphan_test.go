@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_orphanedJobs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &runningJob{headnode: "head1:50505", jobId: 1, pid: 100, ctx: ctx}
+	if isJobHeadnodeGone(job) {
+		t.Errorf("Expected headnode not gone with job stream open")
+	}
+	cancel()
+	if !isJobHeadnodeGone(job) {
+		t.Errorf("Expected headnode gone with job stream broken")
+	}
+
+	// The job is orphaned once after the headnode is gone longer than the timeout, unless it is back in time
+	now := time.Now()
+	if checkOrphanedJob(job, true, now, time.Minute) || checkOrphanedJob(job, false, now.Add(30*time.Second), time.Minute) {
+		t.Errorf("Expected job not orphaned before timeout")
+	}
+	if checkOrphanedJob(job, true, now.Add(time.Minute), time.Minute) {
+		t.Errorf("Expected lost time reset after headnode is back")
+	}
+	if !checkOrphanedJob(job, true, now.Add(2*time.Minute), time.Minute) || checkOrphanedJob(job, true, now.Add(3*time.Minute), time.Minute) {
+		t.Errorf("Expected job orphaned once after timeout")
+	}
+	if checkOrphanedJob(&runningJob{}, true, now.Add(time.Hour), 0) {
+		t.Errorf("Expected job never orphaned without timeout")
+	}
+
+	orphanedJobs = map[string][]int32{"head1:50505": {1, 2}}
+	reported := getOrphanedJobs("head1:50505")
+	orphanedJobs["head1:50505"] = append(orphanedJobs["head1:50505"], 3)
+	removeReportedOrphanedJobs("head1:50505", reported)
+	if ids := getOrphanedJobs("head1:50505"); !reflect.DeepEqual(ids, []int32{3}) {
+		t.Errorf("Expected job 3 not reported yet, got %v", ids)
+	}
+	removeReportedOrphanedJobs("head1:50505", []int32{3})
+	if _, ok := orphanedJobs["head1:50505"]; ok {
+		t.Errorf("Expected no orphaned jobs left")
+	}
+}
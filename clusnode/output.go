@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bytes"
+	pb "clusrun/protobuf"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	outputChunkSize  = 64 << 10
+	watchJobInterval = 500 * time.Millisecond
+)
+
+// Stream the saved stdout and stderr of a job, either from an offset or the last lines of them
+func (s *headnode_server) GetOutput(in *pb.GetOutputRequest, out pb.Headnode_GetOutputServer) error {
+	defer LogPanicBeforeExit()
+	id, offset, tail := in.GetJobId(), in.GetOffset(), in.GetTail()
+	if offset < 0 || tail < 0 {
+		return status.Error(codes.InvalidArgument, "Offset and tail should not be negative")
+	}
+	if offset > 0 && tail > 0 {
+		return status.Error(codes.InvalidArgument, "Offset and tail can't be specified together")
+	}
+	job, err := getClientJob(out.Context(), id)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	nodes := job.Nodes
+	if node := in.GetNode(); len(node) > 0 {
+		nodes = nil
+		for _, n := range job.Nodes {
+			if strings.EqualFold(n, node) {
+				nodes = []string{n}
+				break
+			}
+		}
+		if len(nodes) == 0 {
+			return status.Errorf(codes.InvalidArgument, "Node %v is not in job %v", node, id)
+		}
+	}
+	LogInfo("Getting output of job %v on nodes %v", id, nodes)
+	if in.GetTiming() {
+		fillJobTasks(job)
+	}
+	found := false
+	for _, node := range nodes {
+		stdout, stderr := GetOutputFile(job, node)
+		if !outputFileExists(stdout) {
+			continue
+		}
+		found = true
+		if in.GetTiming() {
+			if task := getNodeTask(job.Tasks, node); task != nil {
+				if err := out.Send(&pb.GetOutputReply{Node: node, Task: task}); err != nil {
+					return err
+				}
+			}
+		}
+		send_stdout := func(data string) error { return out.Send(&pb.GetOutputReply{Node: node, Stdout: []byte(data)}) }
+		send_stderr := func(data string) error { return out.Send(&pb.GetOutputReply{Node: node, Stderr: []byte(data)}) }
+		if err := sendOutputFile(stdout, offset, tail, send_stdout); err != nil {
+			LogError("Failed to send output %v: %v", stdout, err)
+			return status.Errorf(codes.Internal, "Failed to send output of job %v on node %v: %v", id, node, err)
+		}
+		if err := sendOutputFile(stderr, offset, tail, send_stderr); err != nil {
+			LogError("Failed to send output %v: %v", stderr, err)
+			return status.Errorf(codes.Internal, "Failed to send output of job %v on node %v: %v", id, node, err)
+		}
+	}
+	if !found {
+		return status.Errorf(codes.NotFound, "No output of job %v is saved", id)
+	}
+	return nil
+}
+
+func sendOutputFile(file string, offset int64, tail int32, send func(string) error) error {
+	f, size, err := openOutputFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	if tail > 0 {
+		if offset, err = getTailOffset(f, size, int(tail)); err != nil {
+			return err
+		}
+	}
+	if offset >= size {
+		return nil
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	buf := make([]byte, outputChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if err := send(string(buf[:n])); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// Get the offset of the last lines, in which a newline at the end doesn't start a new line
+func getTailOffset(r io.ReaderAt, size int64, lines int) (int64, error) {
+	end := size
+	if end > 0 {
+		last := make([]byte, 1)
+		if _, err := r.ReadAt(last, end-1); err != nil {
+			return 0, err
+		}
+		if last[0] == '\n' {
+			end--
+		}
+	}
+	buf := make([]byte, outputChunkSize)
+	for end > 0 {
+		begin := end - int64(len(buf))
+		if begin < 0 {
+			begin = 0
+		}
+		chunk := buf[:end-begin]
+		if _, err := r.ReadAt(chunk, begin); err != nil && err != io.EOF {
+			return 0, err
+		}
+		for i := bytes.LastIndexByte(chunk, '\n'); i >= 0; i = bytes.LastIndexByte(chunk[:i], '\n') {
+			if lines--; lines == 0 {
+				return begin + int64(i) + 1, nil
+			}
+		}
+		end = begin
+	}
+	return 0, nil
+}
+
+// Stream the output of a job after the offsets received by the client, and the end of the task on each node,
+// in the same way as StartClusJob so that the client can reattach to the job once the stream is lost
+func (s *headnode_server) WatchClusJob(in *pb.WatchClusJobRequest, out pb.Headnode_WatchClusJobServer) error {
+	defer LogPanicBeforeExit()
+	id := in.GetJobId()
+	job, err := getClientJob(out.Context(), id)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if err := out.Send(&pb.StartClusJobReply{JobId: id, Nodes: job.Nodes}); err != nil {
+		return err
+	}
+	LogInfo("Start watching job %v", id)
+	stdout_offsets, stderr_offsets, ended := map[string]int64{}, map[string]int64{}, map[string]bool{}
+	for node, offset := range in.GetStdoutOffsets() {
+		stdout_offsets[node] = offset
+	}
+	for node, offset := range in.GetStderrOffsets() {
+		stderr_offsets[node] = offset
+	}
+	for _, node := range in.GetEndedNodes() {
+		ended[node] = true
+	}
+	for {
+		// The ended tasks are got before sending output, so that no output is left after the end is sent
+		exit_codes, job_ended := getEndedTasks(job)
+		rescheduled := map[string]string{}
+		for _, r := range job.Reschedules {
+			rescheduled[r.FromNode] = r.ToNode
+		}
+		for _, node := range job.Nodes {
+			if ended[node] {
+				continue
+			}
+			stdout, stderr := GetOutputFile(job, node)
+			send_stdout := func(data string) error {
+				stdout_offsets[node] += int64(len(data))
+				return out.Send(&pb.StartClusJobReply{Node: node, Stdout: []byte(data)})
+			}
+			send_stderr := func(data string) error {
+				stderr_offsets[node] += int64(len(data))
+				return out.Send(&pb.StartClusJobReply{Node: node, Stderr: []byte(data)})
+			}
+			if err := sendOutputFile(stdout, stdout_offsets[node], 0, send_stdout); err != nil {
+				LogWarning("Failed to send output %v: %v", stdout, err)
+				return status.Errorf(codes.Internal, "Failed to send output of job %v on node %v: %v", id, node, err)
+			}
+			if err := sendOutputFile(stderr, stderr_offsets[node], 0, send_stderr); err != nil {
+				LogWarning("Failed to send output %v: %v", stderr, err)
+				return status.Errorf(codes.Internal, "Failed to send output of job %v on node %v: %v", id, node, err)
+			}
+			reply := &pb.StartClusJobReply{Node: node}
+			if to, ok := rescheduled[node]; ok {
+				reply.RescheduledTo = to
+			} else if exit_code, ok := exit_codes[node]; ok {
+				reply.ExitCode = exit_code
+			} else {
+				continue
+			}
+			ended[node] = true
+			if err := out.Send(reply); err != nil {
+				return err
+			}
+		}
+		if job_ended {
+			if job.Summary != nil {
+				if err := out.Send(&pb.StartClusJobReply{Summary: job.Summary}); err != nil {
+					return err
+				}
+			}
+			LogInfo("Stop watching job %v which ended", id)
+			return nil
+		}
+		select {
+		case <-out.Context().Done():
+			LogInfo("Stop watching job %v: %v", id, out.Context().Err())
+			return nil
+		case <-time.After(watchJobInterval):
+		}
+		if job, err = GetJob(id); err != nil {
+			return status.Error(codes.NotFound, err.Error())
+		}
+	}
+}
+
+// Get the exit codes of the tasks ended on nodes, and whether the job ends
+func getEndedTasks(job *pb.Job) (map[string]int32, bool) {
+	exit_codes := map[string]int32{}
+	if job.State > pb.JobState_Canceling && job.State != pb.JobState_Waiting {
+		for _, node := range job.Nodes {
+			exit_codes[node] = job.FailedNodes[node]
+		}
+		return exit_codes, true
+	}
+	if job_on_nodes, ok := Jobs.Load(job.Id); ok {
+		job_on_nodes.(*sync.Map).Range(func(k, v interface{}) bool {
+			if j := v.(jobOnNode); j.state > pb.JobState_Running {
+				exit_codes[k.(string)] = j.exitCode
+			}
+			return true
+		})
+	}
+	return exit_codes, false
+}
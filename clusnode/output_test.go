@@ -0,0 +1,57 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func Test_getTailOffset(t *testing.T) {
+	cases := []struct {
+		content string
+		lines   int
+		offset  int64
+	}{
+		{"", 1, 0},
+		{"a", 1, 0},
+		{"a\n", 1, 0},
+		{"a\nb\n", 1, 2},
+		{"a\nb", 1, 2},
+		{"a\nb\nc\n", 2, 2},
+		{"a\nb\nc\n", 5, 0},
+		{"a\n\n\n", 1, 3},
+		{"a\n\n\n", 2, 2},
+	}
+
+	for _, c := range cases {
+		if offset, err := getTailOffset(strings.NewReader(c.content), int64(len(c.content)), c.lines); err != nil || offset != c.offset {
+			t.Errorf("\ncontent=%q\nlines=%v\nexpected offset=%v\n  actual offset=%v, error=%v", c.content, c.lines, c.offset, offset, err)
+		}
+	}
+}
+
+func Test_getEndedTasks(t *testing.T) {
+	job_on_nodes := sync.Map{}
+	job_on_nodes.Store("node1", jobOnNode{state: pb.JobState_Running})
+	job_on_nodes.Store("node2", jobOnNode{state: pb.JobState_Finished})
+	job_on_nodes.Store("node3", jobOnNode{state: pb.JobState_Failed, exitCode: 2})
+	Jobs.Store(int32(-1), &job_on_nodes)
+	defer Jobs.Delete(int32(-1))
+	job := &pb.Job{Id: -1, Nodes: []string{"node1", "node2", "node3"}, State: pb.JobState_Running}
+	exit_codes, ended := getEndedTasks(job)
+	if expected := map[string]int32{"node2": 0, "node3": 2}; ended || !reflect.DeepEqual(exit_codes, expected) {
+		t.Errorf("Expected %v of running job, got %v, ended %v", expected, exit_codes, ended)
+	}
+	job.State, job.FailedNodes = pb.JobState_Failed, map[string]int32{"node1": -1, "node3": 2}
+	exit_codes, ended = getEndedTasks(job)
+	if expected := map[string]int32{"node1": -1, "node2": 0, "node3": 2}; !ended || !reflect.DeepEqual(exit_codes, expected) {
+		t.Errorf("Expected %v of failed job, got %v, ended %v", expected, exit_codes, ended)
+	}
+	Jobs.Delete(int32(-1))
+	job.State = pb.JobState_Waiting
+	if exit_codes, ended = getEndedTasks(job); ended || len(exit_codes) != 0 {
+		t.Errorf("Expected no ended tasks of waiting job, got %v, ended %v", exit_codes, ended)
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	OutputStorage_Files     = "files"
+	OutputStorage_Dedup     = "dedup"
+	OutputStorage_S3        = "s3"
+	OutputStorage_AzureBlob = "azblob"
+
+	outputRefExt = ".ref"
+)
+
+var (
+	outputStorages = map[string]outputStorage{
+		OutputStorage_Files:     fileOutputStorage{},
+		OutputStorage_Dedup:     dedupOutputStorage{},
+		OutputStorage_S3:        objectOutputStorage{kind: OutputStorage_S3},
+		OutputStorage_AzureBlob: objectOutputStorage{kind: OutputStorage_AzureBlob},
+	}
+
+	outputBlobRefs     = map[string]int{} // the count of output files referring to each blob
+	outputBlobRefsLock sync.Mutex
+
+	outputStorageValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if _, ok := outputStorages[v]; !ok {
+			return fmt.Errorf("Value should be one of: %v", strings.Join([]string{OutputStorage_Files, OutputStorage_Dedup, OutputStorage_S3, OutputStorage_AzureBlob}, ", "))
+		}
+		return nil
+	}
+)
+
+// The storage of the output file of a task, which is written in place while the task is running and stored once it ends,
+// the stored output is read by openOutputFile no matter which storage it is stored by
+type outputStorage interface {
+	Store(file string) error
+}
+
+// Keep the output file in place, which is compressed and encrypted if configured
+type fileOutputStorage struct{}
+
+func (fileOutputStorage) Store(file string) error {
+	if Config_Headnode_OutputCompression.GetString() == OutputCompression_Gzip {
+		if err := compressOutputFile(file); err != nil {
+			return err
+		}
+	}
+	return encryptOutputFile(file)
+}
+
+// Store the output in a blob named by its hash, which is shared by the identical output files referring to it,
+// e.g. the same output of a command on many nodes is stored only once
+type dedupOutputStorage struct{}
+
+func (dedupOutputStorage) Store(file string) error {
+	hash, err := hashOutputFile(file)
+	if err != nil {
+		return err
+	}
+	blob := getOutputBlobPath(hash)
+	ref := file + outputRefExt
+	outputBlobRefsLock.Lock()
+	old, _ := readOutputRef(ref)
+
+	// The ref is written before the file is removed, so that the output is readable all the time
+	if err := writeOutputRef(ref, hash); err != nil {
+		outputBlobRefsLock.Unlock()
+		return err
+	}
+	created := false
+	if outputBlobRefs[hash] == 0 && !outputFileExists(blob) {
+		if err = os.MkdirAll(filepath.Dir(blob), 0644); err == nil {
+			err = os.Rename(file, blob)
+		}
+		if err != nil {
+			_ = os.Remove(ref)
+			outputBlobRefsLock.Unlock()
+			return err
+		}
+		created = true
+	} else if err := os.Remove(file); err != nil {
+		LogWarning("Failed to remove output file %v stored in blob %v: %v", file, hash, err)
+	}
+	outputBlobRefs[hash]++
+	if len(old) > 0 {
+		releaseOutputBlob(old)
+	}
+	outputBlobRefsLock.Unlock()
+
+	// The new blob is referred by the file, so it is not removed during compression and encryption
+	if !created {
+		return nil
+	}
+	if Config_Headnode_OutputCompression.GetString() == OutputCompression_Gzip {
+		if err := compressOutputFile(blob); err != nil {
+			return err
+		}
+	}
+	return encryptOutputFile(blob)
+}
+
+// Store the output files of a task by the configured storage
+func storeOutputFiles(files ...string) {
+	storage, ok := outputStorages[Config_Headnode_OutputStorage.GetString()]
+	if !ok {
+		storage = fileOutputStorage{}
+	}
+	for _, file := range files {
+		if err := storage.Store(file); err != nil {
+			LogError("Failed to store output file %v: %v", file, err)
+		}
+	}
+}
+
+func hashOutputFile(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// The blobs are spread in sub dirs by the first 2 characters of their hashes
+func getOutputBlobPath(hash string) string {
+	return filepath.Join(db_outputBlobDir, hash[:2], hash)
+}
+
+func isValidOutputHash(hash string) bool {
+	b, err := hex.DecodeString(hash)
+	return err == nil && len(b) == sha256.Size
+}
+
+func readOutputRef(ref string) (string, error) {
+	b, err := ioutil.ReadFile(ref)
+	if err != nil {
+		return "", err
+	}
+	hash := strings.TrimSpace(string(b))
+	if !isValidOutputHash(hash) {
+		return "", fmt.Errorf("Invalid output ref %v", ref)
+	}
+	return hash, nil
+}
+
+func writeOutputRef(ref, hash string) error {
+	temp := ref + ".tmp"
+	if err := ioutil.WriteFile(temp, []byte(hash), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(temp, ref); err != nil {
+		_ = os.Remove(temp)
+		return err
+	}
+	return nil
+}
+
+// Decrease the refs of the blob and remove it once no output file refers to it, the lock should be held
+func releaseOutputBlob(hash string) {
+	refs, ok := outputBlobRefs[hash]
+	if !ok {
+		return
+	} else if refs > 1 {
+		outputBlobRefs[hash] = refs - 1
+		return
+	}
+	delete(outputBlobRefs, hash)
+	blob := getOutputBlobPath(hash)
+	for _, f := range []string{blob, blob + compressedOutputExt} {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			LogWarning("Failed to remove output blob %v: %v", f, err)
+		}
+	}
+}
+
+// Release the blobs referred by the output files in the dir, before it is removed
+func releaseOutputRefs(dir string) {
+	outputBlobRefsLock.Lock()
+	defer outputBlobRefsLock.Unlock()
+	for _, hash := range getOutputRefs(dir) {
+		releaseOutputBlob(hash)
+	}
+}
+
+func getOutputRefs(dir string) (hashes []string) {
+	_ = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() && strings.HasSuffix(path, outputRefExt) {
+			if hash, err := readOutputRef(path); err == nil {
+				hashes = append(hashes, hash)
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// Count the refs of blobs in the output dir, and remove the blobs not referred, e.g. left by a crash
+func loadOutputBlobRefs() {
+	outputBlobRefsLock.Lock()
+	defer outputBlobRefsLock.Unlock()
+	for _, hash := range getOutputRefs(db_outputDir) {
+		outputBlobRefs[hash]++
+	}
+	removed := 0
+	_ = filepath.Walk(db_outputBlobDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		if hash := strings.TrimSuffix(info.Name(), compressedOutputExt); outputBlobRefs[hash] == 0 {
+			if err := os.Remove(path); err == nil {
+				removed++
+			}
+		}
+		return nil
+	})
+	LogInfo("Loaded %v output blobs, removed %v blobs not referred", len(outputBlobRefs), removed)
+}
+
+// The size of the blob shared by the output files referring to it, so that the sizes of output dirs sum to the disk usage
+func getOutputRefSize(ref string) int64 {
+	hash, err := readOutputRef(ref)
+	if err != nil {
+		return 0
+	}
+	outputBlobRefsLock.Lock()
+	refs := outputBlobRefs[hash]
+	outputBlobRefsLock.Unlock()
+	if refs <= 0 {
+		return 0
+	}
+	blob := getOutputBlobPath(hash)
+	for _, f := range []string{blob, blob + compressedOutputExt} {
+		if info, err := os.Stat(f); err == nil {
+			return info.Size() / int64(refs)
+		}
+	}
+	return 0
+}
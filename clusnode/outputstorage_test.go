@@ -0,0 +1,76 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_dedupOutputStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusnode-output")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db_outputDir, db_outputBlobDir = filepath.Join(dir, "output"), filepath.Join(dir, "blobs")
+	outputBlobRefs = map[string]int{}
+	write := func(job, node, content string) string {
+		file := filepath.Join(db_outputDir, job, node)
+		if err := os.MkdirAll(filepath.Dir(file), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := (dedupOutputStorage{}).Store(file); err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	read := func(file string) string {
+		f, _, err := openOutputFile(file)
+		if err != nil {
+			return err.Error()
+		}
+		defer f.Close()
+		b, _ := ioutil.ReadAll(f)
+		return string(b)
+	}
+	blobs := func() int {
+		count := 0
+		_ = filepath.Walk(db_outputBlobDir, func(path string, info os.FileInfo, err error) error {
+			if err == nil && info.Mode().IsRegular() {
+				count++
+			}
+			return nil
+		})
+		return count
+	}
+
+	// The identical output of nodes is stored once
+	files := []string{write("1", "a.out", "same"), write("1", "b.out", "same"), write("2", "a.out", "same"), write("2", "b.out", "other")}
+	for i, expected := range []string{"same", "same", "same", "other"} {
+		if actual := read(files[i]); actual != expected {
+			t.Errorf("output of %v: expected=%q, actual=%q", files[i], expected, actual)
+		}
+		if _, err := os.Stat(files[i]); !os.IsNotExist(err) {
+			t.Errorf("output file %v is not removed after stored", files[i])
+		}
+	}
+	if count := blobs(); count != 2 {
+		t.Errorf("expected 2 blobs, actual %v", count)
+	}
+
+	// The refs are counted again after restart, and a blob is removed once it is not referred
+	outputBlobRefs = map[string]int{}
+	loadOutputBlobRefs()
+	releaseOutputRefs(filepath.Join(db_outputDir, "2"))
+	if count := blobs(); count != 1 || read(files[0]) != "same" {
+		t.Errorf("expected the shared blob is kept, actual %v blobs", count)
+	}
+	releaseOutputRefs(filepath.Join(db_outputDir, "1"))
+	if count := blobs(); count != 0 || len(outputBlobRefs) != 0 {
+		t.Errorf("expected no blobs, actual %v blobs, refs %v", count, outputBlobRefs)
+	}
+}
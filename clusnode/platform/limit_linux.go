@@ -0,0 +1,157 @@
+// +build linux
+
+package platform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"syscall"
+)
+
+const (
+	cgroupRoot      = "/sys/fs/cgroup"
+	cgroupCpuPeriod = 100000 // in microseconds
+)
+
+// Move the process into the cgroup of the job nested in the cgroup of the group under the root cgroup,
+// the limits of the group are updated and shared by all jobs in it.
+// Cgroup v2 is used if available, otherwise the cpu and memory hierarchies of cgroup v1.
+func LimitProcess(group, job string, group_limits, job_limits ResourceLimits, pid int) error {
+	if isCgroupV2() {
+		return limitProcessV2(group, job, group_limits, job_limits, pid)
+	}
+	return limitProcessV1(group, job, group_limits, job_limits, pid)
+}
+
+// Remove the cgroup of the job after all processes in it exit
+func ReleaseProcessLimit(group, job string) error {
+	dirs := []string{filepath.Join(cgroupRoot, group, job)}
+	if !isCgroupV2() {
+		dirs = []string{filepath.Join(cgroupRoot, "cpu", group, job), filepath.Join(cgroupRoot, "memory", group, job)}
+	}
+	for _, dir := range dirs {
+		if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func isCgroupV2() bool {
+	_, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers"))
+	return err == nil
+}
+
+func limitProcessV2(group, job string, group_limits, job_limits ResourceLimits, pid int) error {
+	// The controllers may have been enabled for the children of root cgroup
+	_ = ioutil.WriteFile(filepath.Join(cgroupRoot, "cgroup.subtree_control"), []byte("+cpu +memory"), 0644)
+	group_dir := filepath.Join(cgroupRoot, group)
+	job_dir := filepath.Join(group_dir, job)
+	if err := os.MkdirAll(job_dir, 0755); err != nil {
+		return err
+	}
+	if err := writeCgroupFile(group_dir, "cgroup.subtree_control", "+cpu +memory"); err != nil {
+		return err
+	}
+	for dir, limits := range map[string]ResourceLimits{group_dir: group_limits, job_dir: job_limits} {
+		cpu, memory := "max", "max"
+		if limits.CpuPercent > 0 {
+			cpu = strconv.FormatInt(getCpuQuota(limits.CpuPercent, runtime.NumCPU()), 10)
+		}
+		if limits.MemoryBytes > 0 {
+			memory = strconv.FormatInt(limits.MemoryBytes, 10)
+		}
+		if err := writeCgroupFile(dir, "cpu.max", fmt.Sprintf("%v %v", cpu, cgroupCpuPeriod)); err != nil {
+			return err
+		}
+		if err := writeCgroupFile(dir, "memory.max", memory); err != nil {
+			return err
+		}
+	}
+	return writeCgroupFile(job_dir, "cgroup.procs", strconv.Itoa(pid))
+}
+
+func limitProcessV1(group, job string, group_limits, job_limits ResourceLimits, pid int) error {
+	for _, controller := range []string{"cpu", "memory"} {
+		group_dir := filepath.Join(cgroupRoot, controller, group)
+		job_dir := filepath.Join(group_dir, job)
+		if err := os.MkdirAll(job_dir, 0755); err != nil {
+			return err
+		}
+		for _, dir := range []string{group_dir, job_dir} {
+			limits := group_limits
+			if dir == job_dir {
+				limits = job_limits
+			}
+			var err error
+			if controller == "cpu" {
+				cpu := "-1"
+				if limits.CpuPercent > 0 {
+					cpu = strconv.FormatInt(getCpuQuota(limits.CpuPercent, runtime.NumCPU()), 10)
+				}
+				if err = writeCgroupFile(dir, "cpu.cfs_period_us", strconv.Itoa(cgroupCpuPeriod)); err == nil {
+					err = writeCgroupFile(dir, "cpu.cfs_quota_us", cpu)
+				}
+			} else {
+				memory := "-1"
+				if limits.MemoryBytes > 0 {
+					memory = strconv.FormatInt(limits.MemoryBytes, 10)
+				}
+				err = writeCgroupFile(dir, "memory.limit_in_bytes", memory)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := writeCgroupFile(job_dir, "cgroup.procs", strconv.Itoa(pid)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCgroupFile(dir, name, value string) error {
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(value), 0644); err != nil {
+		return fmt.Errorf("Failed to write %q to %v: %v", value, name, err)
+	}
+	return nil
+}
+
+// The CPU time in microseconds in each period for the percent of all CPUs
+func getCpuQuota(percent, cpus int) int64 {
+	return int64(cgroupCpuPeriod) * int64(cpus) * int64(percent) / 100
+}
+
+const (
+	ioprioWhoProcess      = 1
+	ioprioClassShift      = 13
+	ioprioClassBestEffort = 2
+	ioprioClassIdle       = 3
+	ioprioLowestLevel     = 7
+)
+
+// Set the nice level and the I/O priority of the process
+func SetProcessPriority(pid int, priority ProcessPriority) error {
+	if priority.Nice != 0 {
+		if err := syscall.Setpriority(syscall.PRIO_PROCESS, pid, priority.Nice); err != nil {
+			return fmt.Errorf("Failed to set nice level %v: %v", priority.Nice, err)
+		}
+	}
+	ioprio := 0
+	switch priority.Io {
+	case IoPriority_Low:
+		ioprio = ioprioClassBestEffort<<ioprioClassShift | ioprioLowestLevel
+	case IoPriority_Idle:
+		ioprio = ioprioClassIdle << ioprioClassShift
+	}
+	if ioprio != 0 {
+		if _, _, errno := syscall.Syscall(syscall.SYS_IOPRIO_SET, ioprioWhoProcess, uintptr(pid), uintptr(ioprio)); errno != 0 {
+			return fmt.Errorf("Failed to set I/O priority %v: %v", priority.Io, errno)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+// +build !linux,!windows
+
+package platform
+
+import (
+	"errors"
+)
+
+func LimitProcess(group, job string, group_limits, job_limits ResourceLimits, pid int) error {
+	_, _, _, _, _ = group, job, group_limits, job_limits, pid
+	return errors.New("Limiting resources of processes is not supported on this platform")
+}
+
+func ReleaseProcessLimit(group, job string) error {
+	_, _ = group, job
+	return nil
+}
+
+func SetProcessPriority(pid int, priority ProcessPriority) error {
+	_, _ = pid, priority
+	return errors.New("Setting priority of processes is not supported on this platform")
+}
@@ -0,0 +1,173 @@
+// +build windows
+
+package platform
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	jobObjectExtendedLimitInformation  = 9
+	jobObjectCpuRateControlInformation = 15
+	jobObjectLimitJobMemory            = 0x200
+	jobObjectCpuRateControlEnable      = 0x1
+	jobObjectCpuRateControlHardCap     = 0x4
+	processSetQuota                    = 0x0100
+	processTerminate                   = 0x0001
+	processSetInformation              = 0x0200
+	idlePriorityClass                  = 0x40
+	belowNormalPriorityClass           = 0x4000
+	processIoPriority                  = 33 // the class of NtSetInformationProcess
+	ioPriorityVeryLow                  = 0
+	ioPriorityLow                      = 1
+)
+
+var (
+	procCreateJobObjectW         = kernel32.NewProc("CreateJobObjectW")
+	procSetInformationJobObject  = kernel32.NewProc("SetInformationJobObject")
+	procAssignProcessToJobObject = kernel32.NewProc("AssignProcessToJobObject")
+	procSetPriorityClass         = kernel32.NewProc("SetPriorityClass")
+
+	ntdll                       = windows.NewLazySystemDLL("ntdll.dll")
+	procNtSetInformationProcess = ntdll.NewProc("NtSetInformationProcess")
+)
+
+// The JOBOBJECT_BASIC_LIMIT_INFORMATION structure
+type jobObjectBasicLimitInformation struct {
+	perProcessUserTimeLimit int64
+	perJobUserTimeLimit     int64
+	limitFlags              uint32
+	minimumWorkingSetSize   uintptr
+	maximumWorkingSetSize   uintptr
+	activeProcessLimit      uint32
+	affinity                uintptr
+	priorityClass           uint32
+	schedulingClass         uint32
+}
+
+// The IO_COUNTERS structure
+type ioCounters struct {
+	readOperationCount  uint64
+	writeOperationCount uint64
+	otherOperationCount uint64
+	readTransferCount   uint64
+	writeTransferCount  uint64
+	otherTransferCount  uint64
+}
+
+// The JOBOBJECT_EXTENDED_LIMIT_INFORMATION structure
+type jobObjectExtendedLimitInfo struct {
+	basicLimitInformation jobObjectBasicLimitInformation
+	ioInfo                ioCounters
+	processMemoryLimit    uintptr
+	jobMemoryLimit        uintptr
+	peakProcessMemoryUsed uintptr
+	peakJobMemoryUsed     uintptr
+}
+
+// The JOBOBJECT_CPU_RATE_CONTROL_INFORMATION structure
+type jobObjectCpuRateControlInfo struct {
+	controlFlags uint32
+	cpuRate      uint32 // in 1/100 of percent of all CPUs
+}
+
+// Assign the process to the job object of the group, and then to the job object of the job nested in it.
+// The limits of the named job object of the group are updated and shared by all jobs in it.
+// The process is running before being assigned, so that the processes it creates immediately may escape from the limits.
+func LimitProcess(group, job string, group_limits, job_limits ResourceLimits, pid int) error {
+	process, err := windows.OpenProcess(processSetQuota|processTerminate, false, uint32(pid))
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(process)
+	for _, name := range []string{group, ""} {
+		limits := group_limits
+		if len(name) == 0 {
+			limits = job_limits
+		}
+		if err := assignJobObject(name, limits, process); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The anonymous job object of the job is destroyed after all processes in it exit
+func ReleaseProcessLimit(group, job string) error {
+	_, _ = group, job
+	return nil
+}
+
+// Create or open the job object with the name (anonymous if empty), set its limits and assign the process to it
+func assignJobObject(name string, limits ResourceLimits, process windows.Handle) error {
+	var p *uint16
+	if len(name) > 0 {
+		var err error
+		if p, err = windows.UTF16PtrFromString(name); err != nil {
+			return err
+		}
+	}
+	r, _, err := procCreateJobObjectW.Call(0, uintptr(unsafe.Pointer(p)))
+	if r == 0 {
+		return err
+	}
+	job := windows.Handle(r)
+	defer windows.CloseHandle(job)
+	cpu := jobObjectCpuRateControlInfo{}
+	if limits.CpuPercent > 0 {
+		cpu.controlFlags = jobObjectCpuRateControlEnable | jobObjectCpuRateControlHardCap
+		cpu.cpuRate = uint32(limits.CpuPercent * 100)
+	}
+	if r, _, err := procSetInformationJobObject.Call(uintptr(job), jobObjectCpuRateControlInformation, uintptr(unsafe.Pointer(&cpu)), unsafe.Sizeof(cpu)); r == 0 {
+		return err
+	}
+	memory := jobObjectExtendedLimitInfo{}
+	if limits.MemoryBytes > 0 {
+		memory.basicLimitInformation.limitFlags = jobObjectLimitJobMemory
+		memory.jobMemoryLimit = uintptr(limits.MemoryBytes)
+	}
+	if r, _, err := procSetInformationJobObject.Call(uintptr(job), jobObjectExtendedLimitInformation, uintptr(unsafe.Pointer(&memory)), unsafe.Sizeof(memory)); r == 0 {
+		return err
+	}
+	if r, _, err := procAssignProcessToJobObject.Call(uintptr(job), uintptr(process)); r == 0 {
+		return err
+	}
+	return nil
+}
+
+// Set the priority class mapped from the nice level and the I/O priority of the process.
+// The process is running before being set, so that the processes it creates immediately may keep the normal priority.
+func SetProcessPriority(pid int, priority ProcessPriority) error {
+	process, err := windows.OpenProcess(processSetInformation, false, uint32(pid))
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(process)
+	class := uint32(0)
+	if priority.Nice >= 15 {
+		class = idlePriorityClass
+	} else if priority.Nice > 0 {
+		class = belowNormalPriorityClass
+	}
+	if class != 0 {
+		if r, _, err := procSetPriorityClass.Call(uintptr(process), uintptr(class)); r == 0 {
+			return fmt.Errorf("Failed to set priority class of nice level %v: %v", priority.Nice, err)
+		}
+	}
+	io := int32(-1)
+	switch priority.Io {
+	case IoPriority_Low:
+		io = ioPriorityLow
+	case IoPriority_Idle:
+		io = ioPriorityVeryLow
+	}
+	if io >= 0 {
+		if r, _, _ := procNtSetInformationProcess.Call(uintptr(process), processIoPriority, uintptr(unsafe.Pointer(&io)), unsafe.Sizeof(io)); r != 0 {
+			return fmt.Errorf("Failed to set I/O priority %v: NTSTATUS 0x%x", priority.Io, r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package platform
+
+import (
+	"errors"
+)
+
+var ErrPtyNotSupported = errors.New("Pseudo terminal is not supported on this platform")
+
+// The logger writing to syslog on Linux and Event Log on Windows
+type SystemLogger interface {
+	Info(message string) error
+	Warning(message string) error
+	Error(message string) error
+	Close() error
+}
+
+// The resource usage of the node, the values unavailable on the platform are negative
+type ResourceUsage struct {
+	CpuLoad         float64 // the average number of runnable processes in the last minute
+	MemoryTotal     int64
+	MemoryAvailable int64
+	DiskTotal       int64
+	DiskFree        int64
+}
+
+// A process with its parent and start time, which identifies the process in case its pid is reused
+type ProcessEntry struct {
+	Ppid      int
+	StartTime uint64 // in clock ticks since boot
+}
+
+// The limits of resources shared by the processes in a group, 0 for unlimited
+type ResourceLimits struct {
+	CpuPercent  int // the percent of all CPUs
+	MemoryBytes int64
+}
+
+const (
+	IoPriority_Low  = "low"  // the lowest level of the best-effort class on Linux
+	IoPriority_Idle = "idle" // only when no other process does I/O on Linux
+)
+
+// The scheduling priority of a process lowered from normal, which is inherited by the processes it creates afterwards
+type ProcessPriority struct {
+	Nice int    // the nice level from 0 (normal) to 19 (lowest), mapped to the priority class on Windows
+	Io   string // the I/O priority, empty for normal
+}
+
+var ErrServiceNotSupported = errors.New("Service is not supported on this platform")
+
+// The service to run the node in background, which is restarted automatically after exiting
+type ServiceConfig struct {
+	Name        string
+	Description string
+	Executable  string
+	Args        []string
+	OutputFile  string // the file to redirect stdout and stderr of the service, if supported on the platform
+}
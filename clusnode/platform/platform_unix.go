@@ -3,7 +3,13 @@
 package platform
 
 import (
+	"io/ioutil"
+	"log/syslog"
+	"os"
 	"os/exec"
+	"os/user"
+	"strconv"
+	"strings"
 	"syscall"
 )
 
@@ -11,6 +17,189 @@ func SetSysProcAttr(cmd *exec.Cmd) {
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 }
 
+// The arguments are passed to the command as is on Unix
+func SetCommandLine(cmd *exec.Cmd, command_line string) {
+	_, _ = cmd, command_line
+}
+
 func KillProcessGroup(pid int) {
 	syscall.Kill(-pid, syscall.SIGKILL)
 }
+
+func InterruptProcessGroup(pid int) error {
+	return syscall.Kill(-pid, syscall.SIGINT)
+}
+
+func TerminateProcessGroup(pid int) error {
+	return syscall.Kill(-pid, syscall.SIGTERM)
+}
+
+func IsProcessGroupAlive(pid int) bool {
+	return syscall.Kill(-pid, 0) == nil
+}
+
+func TerminateProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
+
+func KillProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGKILL)
+}
+
+// Get the processes from /proc which is missing on macOS
+func GetProcesses() map[int]ProcessEntry {
+	dirs, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+	processes := make(map[int]ProcessEntry, len(dirs))
+	for _, dir := range dirs {
+		pid, err := strconv.Atoi(dir.Name())
+		if err != nil {
+			continue
+		}
+		stat, err := ioutil.ReadFile("/proc/" + dir.Name() + "/stat")
+		if err != nil {
+			continue
+		}
+		// The fields after the command in parentheses, which may contain spaces and parentheses
+		index := strings.LastIndexByte(string(stat), ')')
+		if index < 0 {
+			continue
+		}
+		// The zombies are exited and can not be signaled
+		fields := strings.Fields(string(stat[index+1:]))
+		if len(fields) < 20 || fields[0] == "Z" {
+			continue
+		}
+		ppid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		start, _ := strconv.ParseUint(fields[19], 10, 64)
+		processes[pid] = ProcessEntry{Ppid: ppid, StartTime: start}
+	}
+	return processes
+}
+
+func SetRunAsUser(cmd *exec.Cmd, username string) error {
+	u, uid, gid, err := lookupUser(username)
+	if err != nil {
+		return err
+	}
+	var groups []uint32
+	if group_ids, err := u.GroupIds(); err == nil {
+		for _, id := range group_ids {
+			if g, err := strconv.ParseUint(id, 10, 32); err == nil {
+				groups = append(groups, uint32(g))
+			}
+		}
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Credential = &syscall.Credential{Uid: uid, Gid: gid, Groups: groups}
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, "USER="+u.Username, "LOGNAME="+u.Username, "HOME="+u.HomeDir)
+	return nil
+}
+
+// The minimal environment for a job not inheriting the environment of clusnode
+func CleanEnvironment() []string {
+	return []string{"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"}
+}
+
+func ChownToUser(path, username string) error {
+	_, uid, gid, err := lookupUser(username)
+	if err != nil {
+		return err
+	}
+	return os.Chown(path, int(uid), int(gid))
+}
+
+func lookupUser(username string) (u *user.User, uid, gid uint32, err error) {
+	if u, err = user.Lookup(username); err != nil {
+		return
+	}
+	var id uint64
+	if id, err = strconv.ParseUint(u.Uid, 10, 32); err != nil {
+		return
+	}
+	uid = uint32(id)
+	if id, err = strconv.ParseUint(u.Gid, 10, 32); err != nil {
+		return
+	}
+	gid = uint32(id)
+	return
+}
+
+type syslogLogger struct {
+	*syslog.Writer
+}
+
+func (l syslogLogger) Error(message string) error {
+	return l.Err(message)
+}
+
+func NewSystemLogger(source string) (SystemLogger, error) {
+	w, err := syslog.New(syslog.LOG_DAEMON|syslog.LOG_INFO, source)
+	if err != nil {
+		return nil, err
+	}
+	return syslogLogger{w}, nil
+}
+
+// Get the resource usage with the disk containing the path, the load and memory are read from /proc which is missing on macOS
+func GetResourceUsage(path string) ResourceUsage {
+	usage := ResourceUsage{CpuLoad: -1, MemoryTotal: -1, MemoryAvailable: -1, DiskTotal: -1, DiskFree: -1}
+	if data, err := ioutil.ReadFile("/proc/loadavg"); err == nil {
+		if fields := strings.Fields(string(data)); len(fields) > 0 {
+			if load, err := strconv.ParseFloat(fields[0], 64); err == nil {
+				usage.CpuLoad = load
+			}
+		}
+	}
+	if data, err := ioutil.ReadFile("/proc/meminfo"); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			kb, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				continue
+			}
+			switch fields[0] {
+			case "MemTotal:":
+				usage.MemoryTotal = kb << 10
+			case "MemAvailable:":
+				usage.MemoryAvailable = kb << 10
+			}
+		}
+	}
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err == nil {
+		usage.DiskTotal = int64(stat.Blocks) * int64(stat.Bsize)
+		usage.DiskFree = int64(stat.Bavail) * int64(stat.Bsize)
+	}
+	return usage
+}
+
+// Get the distribution name from /etc/os-release, or the kernel name and release if it is missing (e.g. on macOS)
+func GetOSVersion() string {
+	if data, err := ioutil.ReadFile("/etc/os-release"); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if strings.HasPrefix(line, "PRETTY_NAME=") {
+				if name := strings.Trim(strings.TrimPrefix(line, "PRETTY_NAME="), `"'`); len(name) > 0 {
+					return name
+				}
+			}
+		}
+	}
+	if output, err := exec.Command("uname", "-sr").Output(); err == nil {
+		return strings.TrimSpace(string(output))
+	}
+	return ""
+}
@@ -3,13 +3,166 @@
 package platform
 
 import (
+	"errors"
+	"os"
 	"os/exec"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+	"golang.org/x/sys/windows/svc/eventlog"
 )
 
+var errRunAsUserNotSupported = errors.New("Running job as another user is not supported on Windows")
+
 func SetSysProcAttr(cmd *exec.Cmd) {
 	_ = cmd
 }
 
+// Run the command with the command line as is instead of the one quoted from its arguments, for the programs like cmd
+// parsing the command line by themselves
+func SetCommandLine(cmd *exec.Cmd, command_line string) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CmdLine = command_line
+}
+
 func KillProcessGroup(pid int) {
 	_ = pid
 }
+
+func InterruptProcessGroup(pid int) error {
+	_ = pid
+	return errors.New("Interrupting processes is not supported on Windows")
+}
+
+// The process tree is terminated by TASKKILL on Windows
+func TerminateProcessGroup(pid int) error {
+	_ = pid
+	return errors.New("Terminating process group is not supported on Windows")
+}
+
+func IsProcessGroupAlive(pid int) bool {
+	_ = pid
+	return false
+}
+
+func TerminateProcess(pid int) error {
+	_ = pid
+	return errors.New("Terminating process is not supported on Windows")
+}
+
+func KillProcess(pid int) error {
+	_ = pid
+	return errors.New("Killing process is not supported on Windows")
+}
+
+func GetProcesses() map[int]ProcessEntry {
+	return nil
+}
+
+func SetRunAsUser(cmd *exec.Cmd, username string) error {
+	_, _ = cmd, username
+	return errRunAsUserNotSupported
+}
+
+// The minimal environment for a job not inheriting the environment of clusnode, which cmd needs to work
+func CleanEnvironment() []string {
+	env := []string{}
+	for _, name := range []string{"SystemRoot", "SystemDrive", "windir", "ComSpec", "PATHEXT"} {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	root := os.Getenv("SystemRoot")
+	return append(env, "PATH="+root+`\system32;`+root+";"+root+`\System32\Wbem`)
+}
+
+func ChownToUser(path, username string) error {
+	_, _ = path, username
+	return errRunAsUserNotSupported
+}
+
+const eventId = 1
+
+var (
+	kernel32                 = windows.NewLazySystemDLL("kernel32.dll")
+	procGlobalMemoryStatusEx = kernel32.NewProc("GlobalMemoryStatusEx")
+	procGetDiskFreeSpaceExW  = kernel32.NewProc("GetDiskFreeSpaceExW")
+)
+
+// The MEMORYSTATUSEX structure
+type memoryStatusEx struct {
+	length               uint32
+	memoryLoad           uint32
+	totalPhys            uint64
+	availPhys            uint64
+	totalPageFile        uint64
+	availPageFile        uint64
+	totalVirtual         uint64
+	availVirtual         uint64
+	availExtendedVirtual uint64
+}
+
+type eventLogger struct {
+	*eventlog.Log
+}
+
+func (l eventLogger) Info(message string) error {
+	return l.Log.Info(eventId, message)
+}
+
+func (l eventLogger) Warning(message string) error {
+	return l.Log.Warning(eventId, message)
+}
+
+func (l eventLogger) Error(message string) error {
+	return l.Log.Error(eventId, message)
+}
+
+// The event source is registered if not yet, which requires administrator privilege
+func NewSystemLogger(source string) (SystemLogger, error) {
+	err := eventlog.InstallAsEventCreate(source, eventlog.Error|eventlog.Warning|eventlog.Info)
+	if err != nil && !strings.Contains(err.Error(), "registry key already exists") {
+		return nil, err
+	}
+	l, err := eventlog.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	return eventLogger{l}, nil
+}
+
+// Get the resource usage with the disk containing the path, the load is not available on Windows
+func GetResourceUsage(path string) ResourceUsage {
+	usage := ResourceUsage{CpuLoad: -1, MemoryTotal: -1, MemoryAvailable: -1, DiskTotal: -1, DiskFree: -1}
+	status := memoryStatusEx{}
+	status.length = uint32(unsafe.Sizeof(status))
+	if r, _, _ := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&status))); r != 0 {
+		usage.MemoryTotal, usage.MemoryAvailable = int64(status.totalPhys), int64(status.availPhys)
+	}
+	if p, err := windows.UTF16PtrFromString(path); err == nil {
+		var free, total, total_free uint64
+		if r, _, _ := procGetDiskFreeSpaceExW.Call(uintptr(unsafe.Pointer(p)), uintptr(unsafe.Pointer(&free)), uintptr(unsafe.Pointer(&total)), uintptr(unsafe.Pointer(&total_free))); r != 0 {
+			usage.DiskTotal, usage.DiskFree = int64(total), int64(free)
+		}
+	}
+	return usage
+}
+
+// Get the product name and build number of Windows from registry
+func GetOSVersion() string {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Windows NT\CurrentVersion`, registry.QUERY_VALUE)
+	if err != nil {
+		return ""
+	}
+	defer k.Close()
+	name, _, _ := k.GetStringValue("ProductName")
+	if build, _, err := k.GetStringValue("CurrentBuildNumber"); err == nil && len(build) > 0 {
+		name = strings.TrimSpace(name + " (build " + build + ")")
+	}
+	return name
+}
@@ -0,0 +1,18 @@
+// +build !linux
+
+package platform
+
+import (
+	"os"
+	"os/exec"
+)
+
+func StartWithPty(cmd *exec.Cmd, rows, cols int) (*os.File, error) {
+	_, _, _ = cmd, rows, cols
+	return nil, ErrPtyNotSupported
+}
+
+func ResizePty(pty *os.File, rows, cols int) error {
+	_, _, _ = pty, rows, cols
+	return ErrPtyNotSupported
+}
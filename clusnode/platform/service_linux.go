@@ -0,0 +1,103 @@
+// +build linux
+
+package platform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	systemdUnitDir = "/etc/systemd/system"
+)
+
+// Generate the systemd unit of the service, which is restarted in 5 seconds after exiting
+func GetSystemdUnit(config ServiceConfig) string {
+	args := make([]string, 0, len(config.Args)+1)
+	for _, arg := range append([]string{config.Executable}, config.Args...) {
+		args = append(args, quoteSystemdArg(arg))
+	}
+	unit := fmt.Sprintf(`[Unit]
+Description=%v
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+User=root
+ExecStart=%v
+Restart=always
+RestartSec=5
+LimitNOFILE=65536
+`, config.Description, strings.Join(args, " "))
+	if len(config.OutputFile) > 0 {
+		unit += fmt.Sprintf("StandardOutput=append:%v\nStandardError=append:%v\n", config.OutputFile, config.OutputFile)
+	}
+	return unit + `
+[Install]
+WantedBy=multi-user.target
+`
+}
+
+// Quote the argument in ExecStart if needed, and escape the specifiers of systemd
+func quoteSystemdArg(arg string) string {
+	arg = strings.ReplaceAll(arg, "%", "%%")
+	if len(arg) > 0 && !strings.ContainsAny(arg, " \t\"'\\;$") {
+		return arg
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", "$$").Replace(arg) + `"`
+}
+
+func getSystemdUnitFile(name string) string {
+	return filepath.Join(systemdUnitDir, name+".service")
+}
+
+func InstallService(config ServiceConfig) error {
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		return fmt.Errorf("%v: systemd is not found", ErrServiceNotSupported)
+	}
+	if len(config.OutputFile) > 0 {
+		if err := os.MkdirAll(filepath.Dir(config.OutputFile), 0755); err != nil {
+			return err
+		}
+	}
+	if err := ioutil.WriteFile(getSystemdUnitFile(config.Name), []byte(GetSystemdUnit(config)), 0644); err != nil {
+		return err
+	}
+	if err := systemctl("daemon-reload"); err != nil {
+		return err
+	}
+	return systemctl("enable", config.Name)
+}
+
+func UninstallService(name string) error {
+	if _, err := os.Stat(getSystemdUnitFile(name)); os.IsNotExist(err) {
+		return fmt.Errorf("Service %v is not installed", name)
+	}
+	_ = systemctl("stop", name)
+	if err := systemctl("disable", name); err != nil {
+		return err
+	}
+	if err := os.Remove(getSystemdUnitFile(name)); err != nil {
+		return err
+	}
+	return systemctl("daemon-reload")
+}
+
+func StartService(name string) error {
+	return systemctl("start", name)
+}
+
+func StopService(name string) error {
+	return systemctl("stop", name)
+}
+
+func systemctl(args ...string) error {
+	if output, err := exec.Command("systemctl", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("systemctl %v failed: %v %v", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
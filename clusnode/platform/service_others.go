@@ -0,0 +1,23 @@
+// +build !linux,!windows
+
+package platform
+
+func InstallService(config ServiceConfig) error {
+	_ = config
+	return ErrServiceNotSupported
+}
+
+func UninstallService(name string) error {
+	_ = name
+	return ErrServiceNotSupported
+}
+
+func StartService(name string) error {
+	_ = name
+	return ErrServiceNotSupported
+}
+
+func StopService(name string) error {
+	_ = name
+	return ErrServiceNotSupported
+}
@@ -0,0 +1,108 @@
+// +build windows
+
+package platform
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+const (
+	serviceRestartDelayMillisecond = 5000
+	serviceStopTimeout             = 30 * time.Second
+)
+
+// Create the service started automatically, and set the recovery actions to restart it in 5 seconds after failures.
+// The output file is not supported since the stdout and stderr of a service are discarded on Windows.
+func InstallService(config ServiceConfig) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+	if s, err := m.OpenService(config.Name); err == nil {
+		s.Close()
+		return fmt.Errorf("Service %v is already installed", config.Name)
+	}
+	s, err := m.CreateService(config.Name, config.Executable, mgr.Config{
+		StartType:   mgr.StartAutomatic,
+		DisplayName: config.Name,
+		Description: config.Description,
+	}, config.Args...)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	restart := fmt.Sprintf("restart/%[1]v/restart/%[1]v/restart/%[1]v", serviceRestartDelayMillisecond)
+	if output, err := exec.Command("sc.exe", "failure", config.Name, "reset=", "86400", "actions=", restart).CombinedOutput(); err != nil {
+		return fmt.Errorf("Failed to set recovery actions: %v %v", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func UninstallService(name string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+	s, err := m.OpenService(name)
+	if err != nil {
+		return fmt.Errorf("Service %v is not installed", name)
+	}
+	defer s.Close()
+	_ = stopService(s)
+	return s.Delete()
+}
+
+func StartService(name string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+	s, err := m.OpenService(name)
+	if err != nil {
+		return fmt.Errorf("Service %v is not installed", name)
+	}
+	defer s.Close()
+	return s.Start()
+}
+
+func StopService(name string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+	s, err := m.OpenService(name)
+	if err != nil {
+		return fmt.Errorf("Service %v is not installed", name)
+	}
+	defer s.Close()
+	return stopService(s)
+}
+
+// Stop the service and wait until it is stopped
+func stopService(s *mgr.Service) error {
+	status, err := s.Control(svc.Stop)
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(serviceStopTimeout)
+	for status.State != svc.Stopped {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Service is not stopped in %v", serviceStopTimeout)
+		}
+		time.Sleep(300 * time.Millisecond)
+		if status, err = s.Query(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	PolicyWebhookNone = "none"
+)
+
+var (
+	policyWebhookValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		if u, err := url.Parse(v); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return errors.New("Value should be an http or https URL")
+		}
+		return nil
+	}
+)
+
+// The job spec posted to the policy webhook as {"input": <spec>}
+type jobPolicyInput struct {
+	Headnode       string            `json:"headnode"`
+	Client         string            `json:"client"`
+	Name           string            `json:"name"`
+	Command        string            `json:"command"`
+	Arguments      []string          `json:"arguments"`
+	NodeCommands   map[string]string `json:"node_commands"`
+	Nodes          []string          `json:"nodes"`
+	Pattern        string            `json:"pattern"`
+	Cluster        string            `json:"cluster"`
+	Groups         []string          `json:"groups"`
+	Sweep          string            `json:"sweep"`
+	WorkingDir     string            `json:"working_dir"`
+	RunAs          string            `json:"run_as"`
+	Checkpoint     string            `json:"checkpoint"`
+	MaxReschedules int32             `json:"max_reschedules"`
+}
+
+// The policy webhook replies {"result": true|false} or {"result": {"allow": true|false, "reasons": [...]}}
+type jobPolicyDecision struct {
+	Allow   bool     `json:"allow"`
+	Reasons []string `json:"reasons"`
+}
+
+// Ask the policy webhook whether the job is allowed, the job is denied if the webhook fails to decide
+func checkJobPolicy(input *jobPolicyInput) error {
+	webhook := Config_Headnode_PolicyWebhook.GetString()
+	if len(webhook) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(map[string]interface{}{"input": input})
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: time.Duration(Config_Headnode_PolicyWebhookTimeoutSecond.GetInt()) * time.Second}
+	resp, err := client.Post(webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		LogError("Failed to request policy webhook: %v", err)
+		return errors.New("Job is denied since the policy webhook is unavailable")
+	}
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		LogError("Failed to get decision from policy webhook: status %v, error %v", resp.Status, err)
+		return errors.New("Job is denied since the policy webhook fails to decide")
+	}
+	decision, err := parseJobPolicyDecision(content)
+	if err != nil {
+		LogError("Invalid decision from policy webhook: %v", err)
+		return errors.New("Job is denied since the policy webhook replies an invalid decision")
+	}
+	if !decision.Allow {
+		if len(decision.Reasons) == 0 {
+			return errors.New("Job is denied by policy")
+		}
+		return fmt.Errorf("Job is denied by policy: %v", strings.Join(decision.Reasons, "; "))
+	}
+	return nil
+}
+
+func parseJobPolicyDecision(content []byte) (*jobPolicyDecision, error) {
+	var reply struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(content, &reply); err != nil {
+		return nil, err
+	}
+	if len(reply.Result) == 0 {
+		return nil, errors.New("No result")
+	}
+	decision := &jobPolicyDecision{}
+	if err := json.Unmarshal(reply.Result, &decision.Allow); err == nil {
+		return decision, nil
+	}
+	if err := json.Unmarshal(reply.Result, decision); err != nil {
+		return nil, err
+	}
+	return decision, nil
+}
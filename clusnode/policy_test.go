@@ -0,0 +1,28 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseJobPolicyDecision(t *testing.T) {
+	cases := []struct {
+		content  string
+		decision *jobPolicyDecision
+	}{
+		{`{"result": true}`, &jobPolicyDecision{Allow: true}},
+		{`{"result": false}`, &jobPolicyDecision{Allow: false}},
+		{`{"result": {"allow": true}}`, &jobPolicyDecision{Allow: true}},
+		{`{"result": {"allow": false, "reasons": ["a", "b"]}}`, &jobPolicyDecision{Allow: false, Reasons: []string{"a", "b"}}},
+		{`{"result": {}}`, &jobPolicyDecision{Allow: false}},
+		{`{"result": "allow"}`, nil},
+		{`{}`, nil},
+		{`allow`, nil},
+	}
+
+	for _, c := range cases {
+		if decision, err := parseJobPolicyDecision([]byte(c.content)); (err != nil) != (c.decision == nil) || (err == nil && !reflect.DeepEqual(decision, c.decision)) {
+			t.Errorf("\ncontent=%v\nexpected decision=%v\n  actual decision=%v, error=%v", c.content, c.decision, decision, err)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ProbesNone      = "none"
+	probesExample   = `{"gpu":"nvidia-smi -L >/dev/null 2>&1 && echo true || echo false"}`
+	probeTimeout    = 30 * time.Second
+	probeValueLimit = 256 // the max length of a probe value, which is the first line of its output
+
+	probeSelector_GreaterEqual = ">="
+	probeSelector_LessEqual    = "<="
+	probeSelector_Greater      = ">"
+	probeSelector_Less         = "<"
+)
+
+var (
+	nodeProbes sync.Map // the latest probe values each clusnode reports
+
+	probeValues     map[string]string // the latest probe values of this clusnode
+	probeValuesLock sync.Mutex
+
+	// The operators are matched in order, so that ">=" is not taken as ">"
+	probeSelectorOps = []string{labelSelector_NotEqual, probeSelector_GreaterEqual, probeSelector_LessEqual, labelSelector_Equal, probeSelector_Greater, probeSelector_Less}
+
+	probesValidator = func(value interface{}) error {
+		if v, ok := value.(string); !ok {
+			return errors.New("Invalid type")
+		} else if _, err := parseProbes(v); err != nil {
+			return err
+		}
+		return nil
+	}
+)
+
+// Parse the probes in JSON object of names and commands, empty for none
+func parseProbes(value string) (map[string]string, error) {
+	if len(strings.TrimSpace(value)) == 0 {
+		return nil, nil
+	}
+	var probes map[string]string
+	if err := json.Unmarshal([]byte(value), &probes); err != nil {
+		return nil, fmt.Errorf("Invalid probes, expect JSON object like %v: %v", probesExample, err)
+	}
+	for name, command := range probes {
+		if err := validateNodeLabelKey(name); err != nil {
+			return nil, fmt.Errorf("Invalid probe name: %v", err)
+		}
+		if len(strings.TrimSpace(command)) == 0 {
+			return nil, fmt.Errorf("Empty command of probe %v", name)
+		}
+	}
+	return probes, nil
+}
+
+// Run the probes configured at each interval, the values are reported to headnodes by heartbeats
+func runProbesPeriodically() {
+	defer LogPanicBeforeExit()
+	for {
+		probes, _ := parseProbes(Config_Clusnode_Probes.GetString())
+		values := runProbes(probes, runProbe)
+		probeValuesLock.Lock()
+		probeValues = values
+		probeValuesLock.Unlock()
+		time.Sleep(time.Duration(Config_Clusnode_ProbeIntervalSecond.GetInt()) * time.Second)
+	}
+}
+
+// Run the probes in parallel, the failed ones are not reported
+func runProbes(probes map[string]string, run func(string) (string, error)) map[string]string {
+	values := map[string]string{}
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for name, command := range probes {
+		wg.Add(1)
+		go func(name, command string) {
+			defer wg.Done()
+			value, err := run(command)
+			if err != nil {
+				LogWarning("Failed to run probe %v: %v", name, err)
+				return
+			}
+			lock.Lock()
+			values[name] = value
+			lock.Unlock()
+		}(name, command)
+	}
+	wg.Wait()
+	return values
+}
+
+// Run the probe command by the shell, and take the first line of its output as the value
+func runProbe(command string) (string, error) {
+	cmd := getShellCommand(command)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Start(); err != nil {
+		return "", err
+	}
+	timer := time.AfterFunc(probeTimeout, func() { _ = cmd.Process.Kill() })
+	err := cmd.Wait()
+	if !timer.Stop() {
+		return "", fmt.Errorf("Timed out after %v", probeTimeout)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%v: %v", err, strings.TrimSpace(stderr.String()))
+	}
+	return parseProbeOutput(stdout.String()), nil
+}
+
+func parseProbeOutput(output string) string {
+	line, _ := bufio.NewReader(strings.NewReader(output)).ReadString('\n')
+	if line = strings.TrimSpace(line); len(line) > probeValueLimit {
+		line = line[:probeValueLimit]
+	}
+	return line
+}
+
+func getProbeValues() map[string]string {
+	probeValuesLock.Lock()
+	defer probeValuesLock.Unlock()
+	return probeValues
+}
+
+// Store the probe values reported by the clusnode
+func storeNodeProbes(display_name string, probes map[string]string) {
+	if p, ok := nodeProbes.Load(display_name); ok && reflect.DeepEqual(p, probes) || !ok && len(probes) == 0 {
+		return
+	}
+	if len(probes) == 0 {
+		nodeProbes.Delete(display_name)
+	} else {
+		nodeProbes.Store(display_name, probes)
+	}
+	MarkNodesChanged()
+}
+
+func getNodeProbes(node string) map[string]string {
+	if p, ok := nodeProbes.Load(node); ok {
+		return p.(map[string]string)
+	}
+	return nil
+}
+
+// Parse the selectors of probe values in format "name<op>value", "name" (having the value) or "!name" (not having the
+// value), op is one of =, !=, >, >=, < and <=
+func parseProbeSelectors(selectors []string) ([]labelSelector, error) {
+	parsed := make([]labelSelector, 0, len(selectors))
+	for _, s := range selectors {
+		s = strings.TrimSpace(s)
+		selector := labelSelector{key: s, op: labelSelector_Exists, probe: true}
+		if strings.HasPrefix(s, "!") && !strings.Contains(s, labelSelector_Equal) {
+			selector = labelSelector{key: strings.TrimSpace(s[1:]), op: labelSelector_NotExists, probe: true}
+		} else {
+			for _, op := range probeSelectorOps {
+				if index := strings.Index(s, op); index >= 0 {
+					selector = labelSelector{key: strings.TrimSpace(s[:index]), op: op, value: strings.TrimSpace(s[index+len(op):]), probe: true}
+					break
+				}
+			}
+		}
+		if err := validateNodeLabelKey(selector.key); err != nil {
+			return nil, fmt.Errorf("Invalid probe selector %q: %v", s, err)
+		}
+		if isProbeOrderOp(selector.op) {
+			if _, err := strconv.ParseFloat(selector.value, 64); err != nil {
+				return nil, fmt.Errorf("Invalid probe selector %q: %v should be compared with a number", s, selector.op)
+			}
+		}
+		parsed = append(parsed, selector)
+	}
+	return parsed, nil
+}
+
+// Parse the selectors of labels and probes, which are matched by matchLabelSelectors and matchProbeSelectors respectively
+func parseNodeSelectors(labels, probes []string) ([]labelSelector, error) {
+	label_selectors, err := parseLabelSelectors(labels)
+	if err != nil {
+		return nil, err
+	}
+	probe_selectors, err := parseProbeSelectors(probes)
+	if err != nil {
+		return nil, err
+	}
+	return append(label_selectors, probe_selectors...), nil
+}
+
+func isProbeOrderOp(op string) bool {
+	return op == probeSelector_Greater || op == probeSelector_GreaterEqual || op == probeSelector_Less || op == probeSelector_LessEqual
+}
+
+// The node matches if its probe values satisfy all the selectors of probes, the values are compared as numbers if both
+// are numbers, otherwise as strings ignoring case, and a value not a number does not satisfy the order operators
+func matchProbeSelectors(probes map[string]string, selectors []labelSelector) bool {
+	for _, s := range selectors {
+		if !s.probe {
+			continue
+		}
+		value, ok := probes[s.key]
+		switch s.op {
+		case labelSelector_Exists:
+		case labelSelector_NotExists:
+			ok = !ok
+		case labelSelector_Equal, labelSelector_NotEqual:
+			equal := ok && equalProbeValues(value, s.value)
+			ok = equal == (s.op == labelSelector_Equal)
+		default:
+			a, err_a := strconv.ParseFloat(value, 64)
+			b, err_b := strconv.ParseFloat(s.value, 64)
+			ok = ok && err_a == nil && err_b == nil
+			switch s.op {
+			case probeSelector_Greater:
+				ok = ok && a > b
+			case probeSelector_GreaterEqual:
+				ok = ok && a >= b
+			case probeSelector_Less:
+				ok = ok && a < b
+			case probeSelector_LessEqual:
+				ok = ok && a <= b
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// The values are equal as numbers, e.g. 1 and 1.0, or as strings ignoring case
+func equalProbeValues(a, b string) bool {
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			return x == y
+		}
+	}
+	return strings.EqualFold(a, b)
+}
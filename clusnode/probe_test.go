@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_probeSelectors(t *testing.T) {
+	selectors, err := parseProbeSelectors([]string{"gpu=true", "disk_free_gb>=100", "cores>8", "load<=1.5", "os!=linux", "docker", "!fpga"})
+	if err != nil {
+		t.Fatalf("Failed to parse probe selectors: %v", err)
+	}
+	for _, c := range []struct {
+		probes  map[string]string
+		matched bool
+	}{
+		{map[string]string{"gpu": "TRUE", "disk_free_gb": "100", "cores": "16", "load": "1.50", "os": "windows", "docker": ""}, true},
+		{map[string]string{"gpu": "true", "disk_free_gb": "99.9", "cores": "16", "load": "1", "docker": "24.0"}, false},
+		{map[string]string{"gpu": "true", "disk_free_gb": "n/a", "cores": "16", "load": "1", "docker": "24.0"}, false},
+		{map[string]string{"gpu": "true", "disk_free_gb": "200", "cores": "8", "load": "1", "docker": "24.0"}, false},
+		{map[string]string{"gpu": "true", "disk_free_gb": "200", "cores": "16", "load": "1", "os": "Linux", "docker": "24.0"}, false},
+		{map[string]string{"gpu": "true", "disk_free_gb": "200", "cores": "16", "load": "1"}, false},
+		{map[string]string{"gpu": "true", "disk_free_gb": "200", "cores": "16", "load": "1", "docker": "24.0", "fpga": "1"}, false},
+		{nil, false},
+	} {
+		if matched := matchProbeSelectors(c.probes, selectors); matched != c.matched {
+			t.Errorf("Expected probes %v matched: %v, got %v", c.probes, c.matched, matched)
+		}
+	}
+	if !matchProbeSelectors(nil, []labelSelector{{key: "os", op: labelSelector_Equal, value: "linux"}}) {
+		t.Errorf("Expected selectors of labels ignored when matching probes")
+	}
+	for _, s := range []string{"disk_free_gb>=many", "=1", "bad key=1"} {
+		if _, err := parseProbeSelectors([]string{s}); err == nil {
+			t.Errorf("Expected probe selector %q invalid", s)
+		}
+	}
+
+	if probes, err := parseProbes(`{"gpu":"nvidia-smi -L","cores":"nproc"}`); err != nil || len(probes) != 2 {
+		t.Errorf("Expected 2 probes parsed, got %v, %v", probes, err)
+	}
+	for _, v := range []string{`["nproc"]`, `{"bad name":"nproc"}`, `{"cores":" "}`} {
+		if _, err := parseProbes(v); err == nil {
+			t.Errorf("Expected probes %v invalid", v)
+		}
+	}
+	values := runProbes(map[string]string{"cores": "nproc", "gpu": "nvidia-smi"}, func(command string) (string, error) {
+		if command == "nproc" {
+			return "16", nil
+		}
+		return "", errors.New("not found")
+	})
+	if !reflect.DeepEqual(values, map[string]string{"cores": "16"}) {
+		t.Errorf("Expected only the succeeded probe reported, got %v", values)
+	}
+	if value := parseProbeOutput("  24.0.7 \nbuild afdd53b\n"); value != "24.0.7" {
+		t.Errorf("Expected the first line of output as the probe value, got %q", value)
+	}
+	if value := parseProbeOutput(strings.Repeat("x", probeValueLimit+1)); len(value) != probeValueLimit {
+		t.Errorf("Expected probe value truncated to %v, got %v", probeValueLimit, len(value))
+	}
+}
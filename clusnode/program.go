@@ -2,7 +2,11 @@ package main
 
 import (
 	pb "clusrun/protobuf"
+	"fmt"
 	"net"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -25,32 +29,52 @@ func (program) Init(env svc.Environment) error {
 
 func (p *program) Start() error {
 	go p.startNodeService()
+	go evictNodeConnections()
+	go cleanupJobFilesPeriodically()
+	go runProbesPeriodically()
+	go detectGpusPeriodically()
+	go reapOrphanedJobs()
 	Printlnf("Service started with pid %v", syscall.Getpid())
 	return nil
 }
 
 func (p *program) Stop() error {
 	Printlnf("Service is stopping")
+	drainHeadnode(time.Duration(Config_Headnode_ShutdownTimeoutSecond.GetInt()) * time.Second)
 	go func() {
 		time.Sleep(10 * time.Second)
 		Printlnf("Force stop service")
 		p.grpc_server.Stop()
 	}()
+	stopGrpcWebServer()
+	stopControlServer()
 	p.grpc_server.GracefulStop()
+	// Flush the changes of the jobs ended while stopping
+	if err := flushJobs(); err != nil {
+		LogError("Failed to flush jobs: %v", err)
+	}
+	FlushTraces()
 	Printlnf("Service stopped")
 	return nil
 }
 
 func (p *program) startNodeService() {
-	_, port, _, err := ParseHostAddress(NodeHost)
-	if err != nil {
-		LogFatality("Failed to parse node host address: %v", err)
+	listeners := []net.Listener{}
+	for _, address := range NodeListens {
+		lis, err := net.Listen("tcp", address)
+		if err != nil {
+			LogFatality("Failed to listen on %v: %v", address, err)
+		}
+		listeners = append(listeners, lis)
 	}
-	lis, err := net.Listen("tcp", ":"+port)
-	if err != nil {
-		LogFatality("Failed to listen: %v", err)
+	// The headnodes connect through the tunnels opened by this clusnode if it is behind NAT
+	listeners = append(listeners, nodeTunnelListener)
+	// The request id is attached before authorization, so that the logs of rejected requests can be correlated
+	options := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(requestIdUnaryInterceptor, authUnaryInterceptor, auditUnaryInterceptor, traceUnaryInterceptor),
+		grpc.ChainStreamInterceptor(requestIdStreamInterceptor, authStreamInterceptor, auditStreamInterceptor, traceStreamInterceptor),
 	}
-	options := []grpc.ServerOption{}
+	options = append(options, getKeepaliveServerOptions()...)
 	msg := "without TLS"
 	if Tls.Enabled {
 		creds, err := credentials.NewServerTLSFromFile(Tls.CertFile, Tls.KeyFile)
@@ -60,11 +84,53 @@ func (p *program) startNodeService() {
 		options = append(options, grpc.Creds(creds))
 		msg = "with TLS"
 	}
-	p.grpc_server = grpc.NewServer(options...)
+	// The control server keeps its own message size limit
+	p.grpc_server = grpc.NewServer(append(getMessageSizeServerOptions(), options...)...)
 	pb.RegisterClusnodeServer(p.grpc_server, &clusnode_server{})
 	pb.RegisterHeadnodeServer(p.grpc_server, &headnode_server{})
-	LogInfo("Node %v starts listening on %v %v", NodeName, NodeHost, msg)
-	if err := p.grpc_server.Serve(lis); err != nil {
-		LogFatality("Failed to serve: %v", err)
+	watchGrpcWebConfigs(p.grpc_server)
+	watchControlConfigs(options)
+	LogInfo("Node %v with host %v starts listening on %v %v", NodeName, NodeHost, strings.Join(NodeListens, ", "), msg)
+	var wg sync.WaitGroup
+	for _, lis := range listeners {
+		wg.Add(1)
+		go func(lis net.Listener) {
+			defer wg.Done()
+			if err := p.grpc_server.Serve(lis); err != nil {
+				LogFatality("Failed to serve on %v: %v", lis.Addr(), err)
+			}
+		}(lis)
+	}
+	wg.Wait()
+}
+
+// Parse the addresses separated by comma to listen on, the port of host is used if not specified, and all interfaces are
+// listened on if no address is specified
+func parseListenAddresses(value, host string) ([]string, error) {
+	_, port, _, err := ParseHostAddress(host)
+	if err != nil {
+		return nil, err
+	}
+	addresses := []string{}
+	for _, address := range strings.Split(value, ",") {
+		if address = strings.TrimSpace(address); len(address) == 0 {
+			continue
+		}
+		listen_host, listen_port, err := net.SplitHostPort(address)
+		if err != nil {
+			// The address without port, which may be an IPv6 address in brackets
+			listen_host, listen_port = strings.TrimSuffix(strings.TrimPrefix(address, "["), "]"), port
+		}
+		if _, err := strconv.ParseUint(listen_port, 10, 16); err != nil {
+			return nil, fmt.Errorf("Incorrect port of listen address %v", address)
+		}
+		if len(listen_host) > 0 && strings.Contains(listen_host, ":") && net.ParseIP(listen_host) == nil {
+			return nil, fmt.Errorf("Incorrect IPv6 address of listen address %v", address)
+		}
+		addresses = append(addresses, net.JoinHostPort(listen_host, listen_port))
+	}
+	if len(addresses) == 0 {
+		addresses = append(addresses, ":"+port)
 	}
+	return addresses, nil
 }
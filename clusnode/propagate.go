@@ -0,0 +1,179 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	PushNodesAll = "*"
+
+	propagateConfigsTimeout  = 10 * time.Second
+	propagateConfigsParallel = 64
+)
+
+var (
+	// The clusnode configs a headnode can push to its clusnodes, the ones guarding the clusnode like the allowed
+	// working dirs, run as users and command rules can only be set on the clusnode itself
+	propagatableConfigs = []*ConfigItem{
+		&Config_Clusnode_HeartbeatIntervalSecond,
+		&Config_Clusnode_JobTimeoutSecond,
+		&Config_Clusnode_KillGraceSecond,
+		&Config_Clusnode_OrphanTimeoutSecond,
+		&Config_Clusnode_OrphanAction,
+		&Config_Clusnode_CleanupRetentionHours,
+		&Config_Clusnode_CleanupMinFreeDisk,
+		&Config_LogLevel,
+		&Config_LogFormat,
+		&Config_LogMaxSizeMb,
+		&Config_LogRotateHours,
+		&Config_LogMaxFiles,
+		&Config_LogSampleIntervalSecond,
+		&Config_SystemLogLevel,
+		&Config_TraceSamplePercent,
+	}
+)
+
+// Return the configs not allowed to be pushed by headnode with the reasons
+func checkPropagatableConfigs(configs map[string]string) map[string]string {
+	rejected := map[string]string{}
+	for k := range configs {
+		allowed := false
+		for _, c := range propagatableConfigs {
+			if c.Name == k {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			rejected[k] = "Not allowed to set by headnode"
+		}
+	}
+	return rejected
+}
+
+func (s *clusnode_server) ApplyConfigs(ctx context.Context, in *pb.ApplyConfigsRequest) (*pb.SetConfigsReply, error) {
+	defer LogPanicBeforeExit()
+	headnode, dry_run, rollback := in.GetHeadnode(), in.GetDryRun(), in.GetRollback()
+	if err := checkReportedHeadnode(ctx, headnode); err != nil {
+		LogWarning("Reject configs from headnode %v: %v", headnode, status.Convert(err).Message())
+		return nil, err
+	}
+	LogInfo("Receive ApplyConfigs from headnode %v, dry run: %v, rollback: %v", headnode, dry_run, rollback)
+
+	// The last set is rolled back only if all its configs can be set by headnode
+	configs, err := getRequestedConfigs(Config_Clusnode, in.GetConfigs(), rollback)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	results := checkPropagatableConfigs(configs)
+	if len(results) > 0 {
+		for k := range configs {
+			if _, ok := results[k]; !ok {
+				results[k] = "Not set since other configs are invalid"
+			}
+		}
+	} else {
+		results = SetNodeConfigs(Config_Clusnode, configs, dry_run)
+	}
+	if !dry_run {
+		action := AuditAction_SetConfigs
+		if rollback {
+			action = AuditAction_RollbackConfigs
+		}
+		audit(headnode, action, 0, "", formatAuditConfigs(maskConfigs(configs, getRoleConfigs(Config_Clusnode))), formatAuditConfigs(results))
+	}
+	return &pb.SetConfigsReply{Results: results}, nil
+}
+
+// Resolve the nodes to push configs to, "*" for all nodes not lost, the nodes not found are returned with the error
+func resolvePushNodes(nodes []string) ([]string, map[string]*pb.NodeConfigsResult) {
+	resolved, added, failed := []string{}, map[string]bool{}, map[string]*pb.NodeConfigsResult{}
+	for _, node := range nodes {
+		if node == PushNodesAll {
+			for _, record := range ReportedNodes.Snapshot() {
+				if name := record.name; !added[name] && !nodeLost(name, record.reportedTime) {
+					resolved, added[name] = append(resolved, name), true
+				}
+			}
+		} else if name, ok := findReportedNode(node); !ok {
+			failed[node] = &pb.NodeConfigsResult{Error: "Not found"}
+		} else if !added[name] {
+			resolved, added[name] = append(resolved, name), true
+		}
+	}
+	sort.Strings(resolved)
+	return resolved, failed
+}
+
+// Push the clusnode configs to the nodes in parallel, or roll back the last set on them, and return the result on each node
+func propagateConfigs(configs map[string]string, nodes []string, dry_run, rollback bool) map[string]*pb.NodeConfigsResult {
+	resolved, results := resolvePushNodes(nodes)
+	if rollback {
+		LogInfo("Roll back configs on %v nodes, dry run: %v", len(resolved), dry_run)
+	} else {
+		LogInfo("Push configs %v to %v nodes, dry run: %v", maskConfigs(configs, getRoleConfigs(Config_Clusnode)), len(resolved), dry_run)
+	}
+	var lock sync.Mutex
+	forEachNodeParallel(resolved, func(node string) {
+		result := applyConfigsOnNode(&pb.ApplyConfigsRequest{Configs: configs, DryRun: dry_run, Rollback: rollback}, node)
+		lock.Lock()
+		defer lock.Unlock()
+		results[node] = result
+	})
+	return results
+}
+
+// Call the function for each node in parallel, at most propagateConfigsParallel nodes at a time
+func forEachNodeParallel(nodes []string, f func(node string)) {
+	wg := sync.WaitGroup{}
+	parallel := make(chan struct{}, propagateConfigsParallel)
+	for _, node := range nodes {
+		wg.Add(1)
+		parallel <- struct{}{}
+		go func(node string) {
+			defer LogPanicBeforeExit()
+			defer wg.Done()
+			defer func() { <-parallel }()
+			f(node)
+		}(node)
+	}
+	wg.Wait()
+}
+
+func applyConfigsOnNode(request *pb.ApplyConfigsRequest, node string) *pb.NodeConfigsResult {
+	conn, release := GetNodeConnection(parseHost(node), connectionLane_Control)
+	if conn == nil {
+		return &pb.NodeConfigsResult{Error: "Failed to connect node"}
+	}
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), propagateConfigsTimeout)
+	defer cancel()
+	reply, err := pb.NewClusnodeClient(conn).ApplyConfigs(ctx, &pb.ApplyConfigsRequest{Headnode: getReportedHeadnode(node), Configs: request.Configs, DryRun: request.DryRun, Rollback: request.Rollback})
+	if err != nil {
+		LogWarning("Failed to push configs to node %v: %v", node, err)
+		return &pb.NodeConfigsResult{Error: status.Convert(err).Message()}
+	}
+	return &pb.NodeConfigsResult{Results: reply.GetResults()}
+}
+
+// The configs are checked before pushing, so that the nodes are not called for the configs they reject anyway
+func validatePushConfigs(configs map[string]string) error {
+	rejected := checkPropagatableConfigs(configs)
+	if len(rejected) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(rejected))
+	for k := range rejected {
+		names = append(names, fmt.Sprintf("%q", k))
+	}
+	sort.Strings(names)
+	return fmt.Errorf("Configs can not be pushed to clusnodes: %v", names)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_propagateConfigs(t *testing.T) {
+	configs := map[string]string{Config_LogLevel.Name: "debug", Config_Clusnode_HeartbeatIntervalSecond.Name: "5"}
+	if rejected := checkPropagatableConfigs(configs); len(rejected) != 0 {
+		t.Errorf("Expected configs allowed to push, got rejected %v", rejected)
+	}
+	if err := validatePushConfigs(configs); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	configs[Config_Clusnode_RunAsUsers.Name] = "*"
+	configs[Config_Clusnode_CommandRules.Name] = ""
+	if rejected := checkPropagatableConfigs(configs); len(rejected) != 2 || len(rejected[Config_Clusnode_RunAsUsers.Name]) == 0 || len(rejected[Config_Clusnode_CommandRules.Name]) == 0 {
+		t.Errorf("Expected configs guarding clusnode rejected, got %v", rejected)
+	}
+	if err := validatePushConfigs(configs); err == nil {
+		t.Errorf("Expected error for configs guarding clusnode")
+	}
+
+	_, err := (&clusnode_server{}).ApplyConfigs(context.Background(), &pb.ApplyConfigsRequest{Headnode: "unknown:50505", Configs: map[string]string{Config_LogLevel.Name: "debug"}})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected configs from headnode not reported to rejected, got %v", err)
+	}
+	if nodes, failed := resolvePushNodes([]string{"unknown"}); len(nodes) != 0 || failed["unknown"].GetError() != "Not found" {
+		t.Errorf("Expected node not found, got %v, %v", nodes, failed)
+	}
+}
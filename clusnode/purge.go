@@ -0,0 +1,127 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	jobsPurgeInterval = 10 * time.Minute
+)
+
+func (s *headnode_server) PurgeJobs(ctx context.Context, in *pb.PurgeJobsRequest) (*pb.PurgeJobsReply, error) {
+	defer LogPanicBeforeExit()
+	ids, freed, err := purgeJobs(in.GetDryRun())
+	if err != nil {
+		LogError("Failed to purge jobs: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.PurgeJobsReply{JobIds: ids, FreedBytes: freed}, nil
+}
+
+func purgeJobsPeriodically() {
+	for {
+		time.Sleep(jobsPurgeInterval)
+		if _, _, err := purgeJobs(false); err != nil {
+			LogError("Failed to purge jobs: %v", err)
+		}
+	}
+}
+
+// Remove the records and output of finished jobs exceeding the retention limits
+func purgeJobs(dry_run bool) ([]int32, int64, error) {
+	db_jobsLock.Lock()
+	defer db_jobsLock.Unlock()
+	jobs, err := LoadJobs()
+	if err != nil {
+		return nil, 0, err
+	}
+	sizes := make(map[int32]int64, len(jobs))
+	for _, job := range jobs {
+		sizes[job.Id] = getOutputDirSize(job.Id)
+	}
+	max_age := time.Duration(Config_Headnode_MaxJobAgeHours.GetInt()) * time.Hour
+	max_bytes := int64(Config_Headnode_OutputMaxTotalSizeMb.GetInt()) << 20
+	to_purge := selectJobsToPurge(jobs, sizes, Config_Headnode_MaxJobCount.GetInt(), max_age, max_bytes, time.Now())
+	if len(to_purge) == 0 {
+		return nil, 0, nil
+	}
+	var freed int64
+	purged := make(map[int32]bool, len(to_purge))
+	for _, id := range to_purge {
+		purged[id] = true
+		freed += sizes[id]
+	}
+	if dry_run {
+		return to_purge, freed, nil
+	}
+	remain := make([]*pb.Job, 0, len(jobs)-len(to_purge))
+	for _, job := range jobs {
+		if !purged[job.Id] {
+			remain = append(remain, job)
+		}
+	}
+	if err := saveJobs(remain); err != nil {
+		return nil, 0, err
+	}
+	for _, id := range to_purge {
+		cleanupOutputDir(id)
+	}
+	LogInfo("Purged %v jobs (%v bytes of output): %v", len(to_purge), freed, to_purge)
+	return to_purge, freed, nil
+}
+
+// Select the oldest finished jobs beyond max count, older than max age, or making the total output size beyond max bytes.
+// The last job is always kept so that the job id keeps increasing, and limits of 0 mean unlimited.
+func selectJobsToPurge(jobs []*pb.Job, sizes map[int32]int64, max_count int, max_age time.Duration, max_bytes int64, now time.Time) []int32 {
+	if len(jobs) <= 1 {
+		return nil
+	}
+	var total_bytes int64
+	for _, job := range jobs {
+		total_bytes += sizes[job.Id]
+	}
+	count := len(jobs)
+	to_purge := []int32{}
+	for _, job := range jobs[:len(jobs)-1] {
+		if isActiveState(job.State) || job.State == pb.JobState_Created {
+			continue
+		}
+		end_time := job.EndTime
+		if end_time == 0 {
+			end_time = job.CreateTime
+		}
+		if (max_count > 0 && count > max_count) ||
+			(max_age > 0 && now.Sub(time.Unix(end_time, 0)) > max_age) ||
+			(max_bytes > 0 && total_bytes > max_bytes) {
+			to_purge = append(to_purge, job.Id)
+			total_bytes -= sizes[job.Id]
+			count--
+		}
+	}
+	sort.Slice(to_purge, func(i, j int) bool { return to_purge[i] < to_purge[j] })
+	return to_purge
+}
+
+func getOutputDirSize(id int32) int64 {
+	var size int64
+	_ = filepath.Walk(getOutputDir(id), func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			if strings.HasSuffix(path, outputRefExt) {
+				size += getOutputRefSize(path)
+			} else {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_selectJobsToPurge(t *testing.T) {
+	now := time.Unix(100000, 0)
+	jobs := []*pb.Job{
+		{Id: 1, State: pb.JobState_Finished, CreateTime: 1000, EndTime: 2000},
+		{Id: 2, State: pb.JobState_Running, CreateTime: 1000},
+		{Id: 3, State: pb.JobState_Failed, CreateTime: 90000},
+		{Id: 4, State: pb.JobState_Canceled, CreateTime: 95000, EndTime: 99000},
+		{Id: 5, State: pb.JobState_Finished, CreateTime: 1000, EndTime: 2000},
+	}
+	sizes := map[int32]int64{1: 100, 2: 100, 3: 100, 4: 100, 5: 100}
+	cases := []struct {
+		max_count int
+		max_age   time.Duration
+		max_bytes int64
+		expected  []int32
+	}{
+		{0, 0, 0, []int32{}},
+		{5, 0, 0, []int32{}},
+		{3, 0, 0, []int32{1, 3}},
+		{1, 0, 0, []int32{1, 3, 4}},
+		{0, time.Hour, 0, []int32{1, 3}},
+		{0, 3 * time.Hour, 0, []int32{1}},
+		{0, 0, 300, []int32{1, 3}},
+		{0, 0, 1, []int32{1, 3, 4}},
+		{4, 3 * time.Hour, 400, []int32{1}},
+	}
+
+	for _, c := range cases {
+		if result := selectJobsToPurge(jobs, sizes, c.max_count, c.max_age, c.max_bytes, now); !reflect.DeepEqual(result, c.expected) {
+			t.Errorf("\nmax count=%v\nmax age=%v\nmax bytes=%v\nexpected=%v\n  actual=%v", c.max_count, c.max_age, c.max_bytes, c.expected, result)
+		}
+	}
+	if result := selectJobsToPurge(jobs[4:], sizes, 1, 0, 0, now); result != nil {
+		t.Errorf("The last job should be kept, actual=%v", result)
+	}
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	queryKeywordWhere = "where"
+	queryKeywordAnd   = "and"
+	queryOperators    = "=!<>~"
+)
+
+// A query over the JSON results of a job on nodes, in format: [path[, path...]] [where path op value [and path op value...]]
+// in which op is one of ==, !=, >, >=, <, <=, ~ (contains), and value is a JSON literal or a bare word as string
+type resultQuery struct {
+	columns    []string
+	paths      [][]interface{}
+	conditions []resultCondition
+}
+
+type resultCondition struct {
+	path  []interface{}
+	op    string
+	value interface{}
+}
+
+type queryToken struct {
+	text   string
+	quoted bool
+}
+
+func (s *headnode_server) QueryResults(ctx context.Context, in *pb.QueryResultsRequest) (*pb.QueryResultsReply, error) {
+	defer LogPanicBeforeExit()
+	id := in.GetJobId()
+	query, err := parseResultQuery(in.GetExpression())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	job, err := GetJob(id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if !job.JsonOutput {
+		return nil, status.Errorf(codes.FailedPrecondition, "Job %v is not run in JSON mode", id)
+	}
+	reply := &pb.QueryResultsReply{Columns: query.columns, Nodes: int32(len(job.Results) + len(job.ResultErrors))}
+	for node, result := range job.Results {
+		values, matched, err := query.Evaluate(result)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Failed to query result of node %v: %v", node, err)
+		}
+		if matched {
+			reply.Rows = append(reply.Rows, &pb.QueryResultsRow{Node: node, Values: values})
+		}
+	}
+	sort.Slice(reply.Rows, func(i, j int) bool { return reply.Rows[i].Node < reply.Rows[j].Node })
+	LogInfo("QueryResults of job %v matched %v of %v nodes", id, len(reply.Rows), reply.Nodes)
+	return reply, nil
+}
+
+func parseResultQuery(expression string) (*resultQuery, error) {
+	tokens, err := tokenizeQuery(expression)
+	if err != nil {
+		return nil, err
+	}
+	query := &resultQuery{}
+	i := 0
+	for ; i < len(tokens) && !isQueryKeyword(tokens[i], queryKeywordWhere); i++ {
+		if i%2 == 1 {
+			if tokens[i].text != "," || tokens[i].quoted {
+				return nil, fmt.Errorf("Expect , between columns but got %q", tokens[i].text)
+			}
+			continue
+		}
+		path, err := parseJsonPath(tokens[i].text)
+		if err != nil || tokens[i].quoted {
+			return nil, fmt.Errorf("Invalid column %q", tokens[i].text)
+		}
+		query.columns = append(query.columns, tokens[i].text)
+		query.paths = append(query.paths, path)
+	}
+	if i > 0 && i%2 == 0 {
+		return nil, errors.New("Missing column after ,")
+	}
+	if len(query.columns) == 0 {
+		query.columns, query.paths = []string{"."}, [][]interface{}{{}}
+	}
+	if i == len(tokens) {
+		return query, nil
+	}
+	for i++; ; i += 4 {
+		if i+2 >= len(tokens) {
+			return nil, errors.New("Incomplete condition, expect: path op value")
+		}
+		path, err := parseJsonPath(tokens[i].text)
+		if err != nil || tokens[i].quoted {
+			return nil, fmt.Errorf("Invalid path %q in condition", tokens[i].text)
+		}
+		op := tokens[i+1].text
+		switch op {
+		case "==", "!=", ">", ">=", "<", "<=", "~":
+		default:
+			return nil, fmt.Errorf("Invalid operator %q in condition", op)
+		}
+		var value interface{} = tokens[i+2].text
+		if !tokens[i+2].quoted {
+			if v, err := decodeJsonResult(tokens[i+2].text); err == nil {
+				value = v
+			}
+		}
+		query.conditions = append(query.conditions, resultCondition{path: path, op: op, value: value})
+		if i+3 == len(tokens) {
+			return query, nil
+		}
+		if !isQueryKeyword(tokens[i+3], queryKeywordAnd) {
+			return nil, fmt.Errorf("Expect %q between conditions but got %q", queryKeywordAnd, tokens[i+3].text)
+		}
+	}
+}
+
+func isQueryKeyword(token queryToken, keyword string) bool {
+	return !token.quoted && strings.EqualFold(token.text, keyword)
+}
+
+// Split the expression into words, quoted strings, operators and commas
+func tokenizeQuery(expression string) ([]queryToken, error) {
+	tokens := []queryToken{}
+	for s := expression; len(s) > 0; {
+		switch c := s[0]; {
+		case c == ' ' || c == '\t':
+			s = s[1:]
+		case c == ',':
+			tokens = append(tokens, queryToken{text: ","})
+			s = s[1:]
+		case c == '"':
+			end := 1
+			for ; end < len(s) && s[end] != '"'; end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("Unterminated string %v", s)
+			}
+			text, err := strconv.Unquote(s[:end+1])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid string %v", s[:end+1])
+			}
+			tokens = append(tokens, queryToken{text: text, quoted: true})
+			s = s[end+1:]
+		case strings.IndexByte(queryOperators, c) >= 0:
+			end := 1
+			for end < len(s) && strings.IndexByte(queryOperators, s[end]) >= 0 {
+				end++
+			}
+			tokens = append(tokens, queryToken{text: s[:end]})
+			s = s[end:]
+		default:
+			end := strings.IndexAny(s, " \t,\""+queryOperators)
+			if end < 0 {
+				end = len(s)
+			}
+			tokens = append(tokens, queryToken{text: s[:end]})
+			s = s[end:]
+		}
+	}
+	return tokens, nil
+}
+
+// Get the values of columns of the result if it matches all conditions
+func (q *resultQuery) Evaluate(result string) ([]string, bool, error) {
+	doc, err := decodeJsonResult(result)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, c := range q.conditions {
+		value, err := selectJsonValue(doc, c.path)
+		if err != nil {
+			return nil, false, nil
+		}
+		if !compareQueryValue(value, c.op, c.value) {
+			return nil, false, nil
+		}
+	}
+	values := make([]string, len(q.paths))
+	for i, path := range q.paths {
+		value, err := selectJsonValue(doc, path)
+		if err != nil {
+			return nil, false, err
+		}
+		if s, ok := value.(string); ok {
+			values[i] = s
+		} else if b, err := json.Marshal(value); err != nil {
+			return nil, false, err
+		} else {
+			values[i] = string(b)
+		}
+	}
+	return values, true, nil
+}
+
+func compareQueryValue(value interface{}, op string, target interface{}) bool {
+	if op == "~" {
+		s, ok1 := value.(string)
+		t, ok2 := target.(string)
+		return ok1 && ok2 && strings.Contains(s, t)
+	}
+	cmp, comparable := 0, false
+	if n1, ok := value.(json.Number); ok {
+		if n2, ok := target.(json.Number); ok {
+			f1, err1 := n1.Float64()
+			f2, err2 := n2.Float64()
+			if comparable = err1 == nil && err2 == nil; f1 < f2 {
+				cmp = -1
+			} else if f1 > f2 {
+				cmp = 1
+			}
+		}
+	} else if s1, ok := value.(string); ok {
+		if s2, ok := target.(string); ok {
+			cmp, comparable = strings.Compare(s1, s2), true
+		}
+	}
+	switch op {
+	case "==", "!=":
+		equal := comparable && cmp == 0
+		if !comparable {
+			b1, _ := json.Marshal(value)
+			b2, _ := json.Marshal(target)
+			equal = string(b1) == string(b2)
+		}
+		return equal == (op == "==")
+	case ">":
+		return comparable && cmp > 0
+	case ">=":
+		return comparable && cmp >= 0
+	case "<":
+		return comparable && cmp < 0
+	case "<=":
+		return comparable && cmp <= 0
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_resultQuery(t *testing.T) {
+	result := `{"version":"1.2","cpus":8,"os":{"name":"ubuntu linux"},"disks":[{"size":100}],"gpu":null}`
+	sorted := `{"cpus":8,"disks":[{"size":100}],"gpu":null,"os":{"name":"ubuntu linux"},"version":"1.2"}`
+	cases := []struct {
+		expression string
+		columns    []string
+		values     []string
+		matched    bool
+		valid      bool
+	}{
+		{"", []string{"."}, []string{sorted}, true, true},
+		{".version", []string{".version"}, []string{"1.2"}, true, true},
+		{".version, .cpus,.disks[0].size", []string{".version", ".cpus", ".disks[0].size"}, []string{"1.2", "8", "100"}, true, true},
+		{".missing", []string{".missing"}, []string{"null"}, true, true},
+		{".version where .cpus >= 8", []string{".version"}, []string{"1.2"}, true, true},
+		{".version where .cpus > 8", []string{".version"}, nil, false, true},
+		{".version WHERE .cpus<9 AND .os.name ~ linux", []string{".version"}, []string{"1.2"}, true, true},
+		{`.version where .os.name == "ubuntu linux"`, []string{".version"}, []string{"1.2"}, true, true},
+		{`.version where .version != "1.2"`, []string{".version"}, nil, false, true},
+		{`.version where .version == 1.2`, []string{".version"}, nil, false, true},
+		{`.version where .gpu == null`, []string{".version"}, []string{"1.2"}, true, true},
+		{`.version where .version.major == 1`, []string{".version"}, nil, false, true},
+		{"where .cpus == 8", []string{"."}, []string{sorted}, true, true},
+		{".version,", nil, nil, false, false},
+		{".version .cpus", nil, nil, false, false},
+		{".version where .cpus", nil, nil, false, false},
+		{".version where .cpus = 8", nil, nil, false, false},
+		{".version where .cpus == 8 or .cpus == 4", nil, nil, false, false},
+		{`.version where .os.name == "linux`, nil, nil, false, false},
+	}
+
+	for _, c := range cases {
+		query, err := parseResultQuery(c.expression)
+		if (err == nil) != c.valid {
+			t.Errorf("\nexpression=%q\nexpected valid=%v\n  actual error=%v", c.expression, c.valid, err)
+			continue
+		} else if err != nil {
+			continue
+		}
+		values, matched, err := query.Evaluate(result)
+		if err != nil || matched != c.matched || !reflect.DeepEqual(values, c.values) || !reflect.DeepEqual(query.columns, c.columns) {
+			t.Errorf("\nexpression=%q\nexpected columns=%v, values=%v, matched=%v\n  actual columns=%v, values=%v, matched=%v, error=%v", c.expression, c.columns, c.values, c.matched, query.columns, values, matched, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	clientQuotaWindow = time.Minute
+)
+
+var (
+	clientQuotas     = map[string]*clientQuota{}
+	clientQuotasLock sync.Mutex
+)
+
+// The jobs submitted by a client in the last minute and the jobs of it running
+type clientQuota struct {
+	submissions []time.Time
+	running     int
+}
+
+// The client is known by its user if authenticated by user, or by its IP address otherwise, since a static token may
+// be shared by many clients
+func getClientName(ctx context.Context) string {
+	if client := getAuthClient(ctx); client != nil && len(client.user) > 0 {
+		return "user " + client.user
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return "address " + host
+		}
+		return "address " + p.Addr.String()
+	}
+	return "unknown client"
+}
+
+// Count a job submitted by the client if it is within the limits of submissions per minute and running jobs, and return
+// the function to call once the job ends
+func acquireClientQuota(ctx context.Context) (func(), error) {
+	return acquireClientQuotaAt(getClientName(ctx), Config_Headnode_ClientJobsPerMinute.GetInt(), Config_Headnode_ClientMaxRunningJobs.GetInt(), time.Now())
+}
+
+func acquireClientQuotaAt(key string, per_minute, max_running int, now time.Time) (func(), error) {
+	clientQuotasLock.Lock()
+	defer clientQuotasLock.Unlock()
+	for k, q := range clientQuotas {
+		if q.running == 0 && (len(q.submissions) == 0 || now.Sub(q.submissions[len(q.submissions)-1]) >= clientQuotaWindow) {
+			delete(clientQuotas, k)
+		}
+	}
+	quota, ok := clientQuotas[key]
+	if !ok {
+		quota = &clientQuota{}
+		clientQuotas[key] = quota
+	}
+	i := 0
+	for i < len(quota.submissions) && now.Sub(quota.submissions[i]) >= clientQuotaWindow {
+		i++
+	}
+	quota.submissions = quota.submissions[i:]
+	if per_minute > 0 && len(quota.submissions) >= per_minute {
+		retry := quota.submissions[0].Add(clientQuotaWindow).Sub(now)
+		return nil, status.Errorf(codes.ResourceExhausted, "Jobs submitted by %v exceed the limit %v per minute, please retry in %v", key, per_minute, retry.Round(time.Second))
+	}
+	if max_running > 0 && quota.running >= max_running {
+		return nil, status.Errorf(codes.ResourceExhausted, "Running jobs submitted by %v reach the limit %v, please retry after some of them end", key, max_running)
+	}
+	quota.submissions = append(quota.submissions, now)
+	quota.running++
+	released := false
+	return func() {
+		clientQuotasLock.Lock()
+		defer clientQuotasLock.Unlock()
+		if released {
+			return
+		}
+		released = true
+		quota.running--
+	}, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_clientQuota(t *testing.T) {
+	now := time.Now()
+	var releases []func()
+	for i := 0; i < 3; i++ {
+		release, err := acquireClientQuotaAt("user alice", 3, 0, now.Add(time.Duration(i)*time.Second))
+		if err != nil {
+			t.Fatalf("Expected job %v within the limit per minute, got %v", i, err)
+		}
+		releases = append(releases, release)
+	}
+	if _, err := acquireClientQuotaAt("user alice", 3, 0, now.Add(30*time.Second)); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted over the limit per minute, got %v", err)
+	}
+	if _, err := acquireClientQuotaAt("user bob", 3, 0, now.Add(30*time.Second)); err != nil {
+		t.Errorf("Expected other client not limited, got %v", err)
+	}
+	if release, err := acquireClientQuotaAt("user alice", 3, 0, now.Add(time.Minute)); err != nil {
+		t.Errorf("Expected the first job out of the last minute, got %v", err)
+	} else {
+		releases = append(releases, release)
+	}
+
+	// The running jobs are limited until they end
+	if _, err := acquireClientQuotaAt("user alice", 0, 4, now.Add(2*time.Minute)); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted over the max running jobs, got %v", err)
+	}
+	releases[0]()
+	releases[0]()
+	if _, err := acquireClientQuotaAt("user alice", 0, 4, now.Add(2*time.Minute)); err != nil {
+		t.Errorf("Expected job within the max running jobs after one ended, got %v", err)
+	}
+	if _, err := acquireClientQuotaAt("user alice", 0, 4, now.Add(2*time.Minute)); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected the release of a job counted once, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// Token bucket limiting the bytes per second of output sent to headnode, nil means unlimited
+type outputRateLimiter struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	lock   sync.Mutex
+}
+
+func newOutputRateLimiter(bytes_per_second int64, burst int) *outputRateLimiter {
+	if bytes_per_second <= 0 {
+		return nil
+	}
+	b := float64(bytes_per_second)
+	if b < float64(burst) {
+		b = float64(burst)
+	}
+	return &outputRateLimiter{
+		rate:   float64(bytes_per_second),
+		burst:  b,
+		tokens: b,
+		last:   time.Now(),
+	}
+}
+
+// Block until n bytes can be sent
+func (l *outputRateLimiter) Wait(n int) {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens -= float64(n)
+	if l.tokens < 0 {
+		// Hold the lock while sleeping so that stdout and stderr share the bandwidth
+		time.Sleep(time.Duration(-l.tokens / l.rate * float64(time.Second)))
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	endedJobsRetention    = 24 * time.Hour
+	endedJobsMaxCount     = 1000
+	reconcileQueryTimeout = 10 * time.Second
+)
+
+var (
+	// The results of the jobs ended recently on this clusnode, for the headnode restarted to reconcile its jobs
+	endedJobs      = map[string]*endedJob{} // job label -> result
+	endedJobsOrder []string                 // job labels from the earliest ended
+	endedJobsLock  sync.Mutex
+)
+
+type endedJob struct {
+	exitCode int32
+	timedOut bool
+	ended    time.Time
+}
+
+func recordEndedJob(job_label string, exit_code int32, timed_out bool, now time.Time) {
+	endedJobsLock.Lock()
+	defer endedJobsLock.Unlock()
+	if _, ok := endedJobs[job_label]; !ok {
+		endedJobsOrder = append(endedJobsOrder, job_label)
+	}
+	endedJobs[job_label] = &endedJob{exitCode: exit_code, timedOut: timed_out, ended: now}
+	pruneEndedJobs(now)
+}
+
+// The result of a job started again, e.g. by reschedule, is outdated
+func forgetEndedJob(job_label string) {
+	endedJobsLock.Lock()
+	defer endedJobsLock.Unlock()
+	if _, ok := endedJobs[job_label]; !ok {
+		return
+	}
+	delete(endedJobs, job_label)
+	for i, label := range endedJobsOrder {
+		if label == job_label {
+			endedJobsOrder = append(endedJobsOrder[:i], endedJobsOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+// Keep the results within the retention and count
+func pruneEndedJobs(now time.Time) {
+	for len(endedJobsOrder) > 0 {
+		label := endedJobsOrder[0]
+		if len(endedJobsOrder) <= endedJobsMaxCount && now.Sub(endedJobs[label].ended) < endedJobsRetention {
+			break
+		}
+		delete(endedJobs, label)
+		endedJobsOrder = endedJobsOrder[1:]
+	}
+}
+
+// The task is ended if its result is recorded, running if its process is tracked, otherwise unknown
+func queryJob(job_label string) *pb.QueryJobReply {
+	endedJobsLock.Lock()
+	ended, ok := endedJobs[job_label]
+	endedJobsLock.Unlock()
+	if ok {
+		return &pb.QueryJobReply{State: pb.TaskState_TaskEnded, ExitCode: ended.exitCode, TimedOut: ended.timedOut}
+	}
+	if _, ok := jobsPid.Load(job_label); ok {
+		return &pb.QueryJobReply{State: pb.TaskState_TaskRunning}
+	}
+	return &pb.QueryJobReply{State: pb.TaskState_TaskUnknown}
+}
+
+func (s *clusnode_server) QueryJob(ctx context.Context, in *pb.QueryJobRequest) (*pb.QueryJobReply, error) {
+	defer LogPanicBeforeExit()
+	headnode, job_id := in.GetHeadnode(), in.GetJobId()
+	reply := queryJob(getJobLabel(headnode, int(job_id)))
+	LogContext(ctx).With(logFieldJob, job_id).Info("Receive QueryJob from headnode %v, job %v is %v", headnode, job_id, reply.State)
+	return reply, nil
+}
+
+// Reconcile the jobs left active by the last run of headnode with their clusnodes in background,
+// since their tasks are not followed any more
+func reconcileJobs(jobs []*pb.Job) {
+	for _, job := range jobs {
+		go reconcileJob(job)
+	}
+}
+
+func reconcileJob(job *pb.Job) {
+	defer LogPanicBeforeExit()
+	logger := getJobLogger(job.Id)
+	if job.State == pb.JobState_Canceling {
+		logger.Info("Continue to cancel job %v left by the last run of headnode", job.Id)
+		cancelJob(job.Id, job.Nodes)
+		return
+	}
+	logger.Info("Reconcile job %v left %v by the last run of headnode on %v nodes", job.Id, job.State, len(job.Nodes))
+	replies := queryJobOnNodes(job.Id, job.Nodes)
+
+	// The tasks still running are canceled, since their output is not received any more
+	wg, canceled := sync.WaitGroup{}, sync.Map{}
+	for node, reply := range replies {
+		if reply != nil && reply.State == pb.TaskState_TaskRunning {
+			wg.Add(1)
+			go cancelJobOnNode(job.Id, node, &wg, &canceled)
+		}
+	}
+	wg.Wait()
+	state, failed_nodes, lost_nodes := reconcileJobResults(replies)
+	UpdateReconciledJob(job.Id, job.State, state, failed_nodes, lost_nodes)
+}
+
+// Query the task of job on the nodes, the reply is nil for the node not reachable
+func queryJobOnNodes(id int32, nodes []string) map[string]*pb.QueryJobReply {
+	replies := make(map[string]*pb.QueryJobReply, len(nodes))
+	var lock sync.Mutex
+	wg := sync.WaitGroup{}
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node string) {
+			defer wg.Done()
+			reply := queryJobOnNode(id, node)
+			lock.Lock()
+			defer lock.Unlock()
+			replies[node] = reply
+		}(node)
+	}
+	wg.Wait()
+	return replies
+}
+
+func queryJobOnNode(id int32, node string) *pb.QueryJobReply {
+	logger := getJobLogger(id).With(logFieldNode, node)
+	conn, release := GetNodeConnection(parseHost(node), connectionLane_Control)
+	if conn == nil {
+		logger.Warning("Can not query job %v on node %v", id, node)
+		return nil
+	}
+	defer release()
+	ctx, cancel := context.WithTimeout(withJobCorrelationId(context.Background(), id), reconcileQueryTimeout)
+	defer cancel()
+	reply, err := pb.NewClusnodeClient(conn).QueryJob(ctx, &pb.QueryJobRequest{Headnode: NodeHost, JobId: id})
+	if err != nil {
+		logger.Warning("Failed to query job %v on node %v: %v", id, node, err)
+		return nil
+	}
+	return reply
+}
+
+// The job is untracked if its result on any node is lost, failed if it failed on any node, otherwise finished.
+// The result is lost on the node not reachable, not knowing the task, or still running the task.
+func reconcileJobResults(replies map[string]*pb.QueryJobReply) (pb.JobState, map[string]int32, []string) {
+	failed_nodes, lost_nodes := map[string]int32{}, []string{}
+	for node, reply := range replies {
+		if reply == nil || reply.State != pb.TaskState_TaskEnded {
+			lost_nodes = append(lost_nodes, node)
+		} else if reply.ExitCode != 0 {
+			failed_nodes[node] = reply.ExitCode
+		}
+	}
+	if len(lost_nodes) > 0 || len(replies) == 0 {
+		return pb.JobState_Untracked, failed_nodes, lost_nodes
+	} else if len(failed_nodes) > 0 {
+		return pb.JobState_Failed, failed_nodes, lost_nodes
+	}
+	return pb.JobState_Finished, failed_nodes, lost_nodes
+}
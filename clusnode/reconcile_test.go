@@ -0,0 +1,59 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func Test_reconcileJob(t *testing.T) {
+	now := time.Now()
+	recordEndedJob("head1.1", 0, false, now.Add(-25*time.Hour))
+	recordEndedJob("head1.2", 2, true, now)
+	if reply := queryJob("head1.1"); reply.State != pb.TaskState_TaskUnknown {
+		t.Errorf("Expected result out of retention pruned, got %v", reply)
+	}
+	if reply := queryJob("head1.2"); reply.State != pb.TaskState_TaskEnded || reply.ExitCode != 2 || !reply.TimedOut {
+		t.Errorf("Unexpected result of ended job: %v", reply)
+	}
+	jobsPid.Store("head1.3", 100)
+	defer jobsPid.Delete("head1.3")
+	if reply := queryJob("head1.3"); reply.State != pb.TaskState_TaskRunning {
+		t.Errorf("Expected job running, got %v", reply)
+	}
+	forgetEndedJob("head1.2")
+	if reply := queryJob("head1.2"); reply.State != pb.TaskState_TaskUnknown || len(endedJobsOrder) != 0 {
+		t.Errorf("Expected result of job started again forgotten, got %v", reply)
+	}
+	for i := 0; i <= endedJobsMaxCount; i++ {
+		recordEndedJob("head1."+strconv.Itoa(i), 0, false, now)
+	}
+	if len(endedJobs) != endedJobsMaxCount || queryJob("head1.0").State != pb.TaskState_TaskUnknown {
+		t.Errorf("Expected %v results kept without the earliest one, got %v", endedJobsMaxCount, len(endedJobs))
+	}
+	endedJobs, endedJobsOrder = map[string]*endedJob{}, nil
+
+	ended := func(exit_code int32) *pb.QueryJobReply {
+		return &pb.QueryJobReply{State: pb.TaskState_TaskEnded, ExitCode: exit_code}
+	}
+	tests := []struct {
+		replies map[string]*pb.QueryJobReply
+		state   pb.JobState
+		failed  map[string]int32
+		lost    int
+	}{
+		{map[string]*pb.QueryJobReply{"n1": ended(0), "n2": ended(0)}, pb.JobState_Finished, map[string]int32{}, 0},
+		{map[string]*pb.QueryJobReply{"n1": ended(0), "n2": ended(3)}, pb.JobState_Failed, map[string]int32{"n2": 3}, 0},
+		{map[string]*pb.QueryJobReply{"n1": ended(3), "n2": nil}, pb.JobState_Untracked, map[string]int32{"n1": 3}, 1},
+		{map[string]*pb.QueryJobReply{"n1": {State: pb.TaskState_TaskRunning}, "n2": {State: pb.TaskState_TaskUnknown}}, pb.JobState_Untracked, map[string]int32{}, 2},
+		{map[string]*pb.QueryJobReply{}, pb.JobState_Untracked, map[string]int32{}, 0},
+	}
+	for _, test := range tests {
+		state, failed, lost := reconcileJobResults(test.replies)
+		if state != test.state || !reflect.DeepEqual(failed, test.failed) || len(lost) != test.lost {
+			t.Errorf("Expected %v with failed nodes %v and %v lost nodes for %v, got %v, %v, %v", test.state, test.failed, test.lost, test.replies, state, failed, lost)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// The clusnodes reported to the headnode by their display names, with the last time each one reports and the state of
+// validating it. The registry is only locked for writing when a node is added or removed, the heartbeats of the
+// registered nodes lock their own entries only.
+type NodeRegistry struct {
+	lock  sync.RWMutex
+	nodes map[string]*registeredNode
+}
+
+type registeredNode struct {
+	lock         sync.Mutex
+	reportedTime time.Time
+	validate     int  // the count of validation failures if positive, 0 when validating and -1 once validated
+	validateSet  bool // the node has started validation
+}
+
+// A copy of the entry of a node in the registry
+type nodeRecord struct {
+	name           string
+	reportedTime   time.Time
+	validateNumber int
+	validateSet    bool
+}
+
+var ReportedNodes = newNodeRegistry()
+
+func (n nodeRecord) validated() bool {
+	return n.validateSet && n.validateNumber < 0
+}
+
+func newNodeRegistry() *NodeRegistry {
+	return &NodeRegistry{nodes: map[string]*registeredNode{}}
+}
+
+func (r *NodeRegistry) get(node string) *registeredNode {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.nodes[node]
+}
+
+func (r *NodeRegistry) getOrAdd(node string) *registeredNode {
+	if n := r.get(node); n != nil {
+		return n
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	n, ok := r.nodes[node]
+	if !ok {
+		n = &registeredNode{}
+		r.nodes[node] = n
+	}
+	return n
+}
+
+// Add the node with the time it reports if it is not registered, return false if it is
+func (r *NodeRegistry) Register(node string, reported_time time.Time) bool {
+	n := r.getOrAdd(node)
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if !n.reportedTime.IsZero() {
+		return false
+	}
+	n.reportedTime = reported_time
+	return true
+}
+
+// Record the time the node reports, and return the time it reported last if it is registered
+func (r *NodeRegistry) Touch(node string, reported_time time.Time) (time.Time, bool) {
+	n := r.getOrAdd(node)
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	last, ok := n.reportedTime, !n.reportedTime.IsZero()
+	n.reportedTime = reported_time
+	return last, ok
+}
+
+// Get the last time the node reports, return false if it is not registered
+func (r *NodeRegistry) LastReport(node string) (time.Time, bool) {
+	n := r.get(node)
+	if n == nil {
+		return time.Time{}, false
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.reportedTime, !n.reportedTime.IsZero()
+}
+
+// Get the validate number of the node, return false if it has not started validation
+func (r *NodeRegistry) ValidateNumber(node string) (int, bool) {
+	n := r.get(node)
+	if n == nil {
+		return 0, false
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.validate, n.validateSet
+}
+
+func (r *NodeRegistry) IsValidated(node string) bool {
+	number, ok := r.ValidateNumber(node)
+	return nodeRecord{validateNumber: number, validateSet: ok}.validated()
+}
+
+func (r *NodeRegistry) SetValidateNumber(node string, number int) {
+	n := r.getOrAdd(node)
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.validate, n.validateSet = number, true
+}
+
+func (r *NodeRegistry) SetValidated(node string) {
+	r.SetValidateNumber(node, -1)
+}
+
+// Forget the validation of the node, so that it is validated again on the next heartbeat
+func (r *NodeRegistry) ResetValidation(node string) {
+	if n := r.get(node); n != nil {
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		n.validate, n.validateSet = 0, false
+	}
+}
+
+// Mark the validation of the node ongoing if it has not started or has failed, and return the count of failures before.
+// Return false if it is being validated or validated.
+func (r *NodeRegistry) StartValidation(node string) (number int, first bool, started bool) {
+	n := r.getOrAdd(node)
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.validateSet && n.validate <= 0 {
+		return n.validate, false, false
+	}
+	number, first = n.validate, !n.validateSet
+	n.validate, n.validateSet = 0, true
+	return number, first, true
+}
+
+// Get the copies of the nodes reported, sorted by name
+func (r *NodeRegistry) Snapshot() []nodeRecord {
+	r.lock.RLock()
+	nodes := make(map[string]*registeredNode, len(r.nodes))
+	for name, n := range r.nodes {
+		nodes[name] = n
+	}
+	r.lock.RUnlock()
+	records := make([]nodeRecord, 0, len(nodes))
+	for name, n := range nodes {
+		n.lock.Lock()
+		if !n.reportedTime.IsZero() {
+			records = append(records, nodeRecord{name: name, reportedTime: n.reportedTime, validateNumber: n.validate, validateSet: n.validateSet})
+		}
+		n.lock.Unlock()
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].name < records[j].name })
+	return records
+}
+
+// Remove the node and return its entry, which can be restored unless the node reports again
+func (r *NodeRegistry) Remove(node string) (nodeRecord, bool) {
+	r.lock.Lock()
+	n, ok := r.nodes[node]
+	delete(r.nodes, node)
+	r.lock.Unlock()
+	if !ok {
+		return nodeRecord{}, false
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return nodeRecord{name: node, reportedTime: n.reportedTime, validateNumber: n.validate, validateSet: n.validateSet}, true
+}
+
+// Restore the removed node, return false if it is reported again since removed
+func (r *NodeRegistry) Restore(record nodeRecord) bool {
+	n := r.getOrAdd(record.name)
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if !n.reportedTime.IsZero() {
+		return false
+	}
+	n.reportedTime, n.validate, n.validateSet = record.reportedTime, record.validateNumber, record.validateSet
+	return true
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_NodeRegistry(t *testing.T) {
+	r, now := newNodeRegistry(), time.Now()
+	if !r.Register("NODE1", now) || r.Register("NODE1", now.Add(time.Second)) {
+		t.Error("Expected node registered only once")
+	}
+	if last, ok := r.Touch("NODE1", now.Add(time.Minute)); !ok || last != now {
+		t.Errorf("Expected last report time %v, got %v", now, last)
+	}
+	if _, ok := r.Touch("NODE2", now); ok {
+		t.Error("Expected no last report time of a new node")
+	}
+	if number, first, started := r.StartValidation("NODE1"); number != 0 || !first || !started {
+		t.Errorf("Expected first validation started, got %v %v %v", number, first, started)
+	}
+	if _, _, started := r.StartValidation("NODE1"); started {
+		t.Error("Expected validation not started again while validating")
+	}
+	r.SetValidateNumber("NODE1", 2)
+	if number, first, started := r.StartValidation("NODE1"); number != 2 || first || !started {
+		t.Errorf("Expected validation retried after 2 failures, got %v %v %v", number, first, started)
+	}
+	r.SetValidated("NODE1")
+	if !r.IsValidated("NODE1") || r.IsValidated("NODE2") {
+		t.Error("Expected only NODE1 validated")
+	}
+	r.SetValidated("NODE3") // validated before reporting is not listed
+	records := r.Snapshot()
+	if len(records) != 2 || records[0].name != "NODE1" || !records[0].validated() || records[1].name != "NODE2" || records[1].validated() {
+		t.Errorf("Unexpected snapshot: %+v", records)
+	}
+	r.ResetValidation("NODE1")
+	if _, ok := r.ValidateNumber("NODE1"); ok {
+		t.Error("Expected validation of NODE1 reset")
+	}
+	record, ok := r.Remove("NODE2")
+	if _, reported := r.LastReport("NODE2"); !ok || reported {
+		t.Error("Expected NODE2 removed")
+	}
+	if !r.Restore(record) {
+		t.Error("Expected NODE2 restored")
+	}
+	if last, ok := r.LastReport("NODE2"); !ok || last != now {
+		t.Errorf("Expected report time of NODE2 restored, got %v", last)
+	}
+	if r.Restore(record) {
+		t.Error("Expected NODE2 not restored once reported")
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	RelayNone        = "none"
+	relayTimeout     = 5 * time.Second
+	relayIdleTimeout = 10 * time.Minute
+)
+
+var (
+	relayBuffers sync.Map // headnode -> *relayBuffer
+
+	relayValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		if len(v) > 0 {
+			if _, _, _, err := ParseHostAddress(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+)
+
+// The heartbeats received by this relay to send to a headnode in batches
+type relayBuffer struct {
+	heartbeats map[string]*pb.HeartbeatRequest // the latest heartbeat of each clusnode
+	errors     map[string]string               // the error of the last relayed heartbeat of each clusnode
+	received   map[string]time.Time            // the time of the last heartbeat received from each clusnode
+	lastError  error                           // the error of the last batch
+	stopped    bool                            // the buffer is removed after being idle
+	lock       sync.Mutex
+}
+
+func (s *headnode_server) BatchHeartbeat(ctx context.Context, in *pb.BatchHeartbeatRequest) (*pb.BatchHeartbeatReply, error) {
+	defer LogPanicBeforeExit()
+	heartbeats := in.GetHeartbeats()
+	errs := make([]string, len(heartbeats))
+	for i, heartbeat := range heartbeats {
+		if _, err := receiveHeartbeat(heartbeat); err != nil {
+			errs[i] = status.Convert(err).Message()
+		}
+	}
+	return &pb.BatchHeartbeatReply{Errors: errs}, nil
+}
+
+// Buffer the heartbeat to relay to its headnode, and return the error of relaying the last one
+func bufferHeartbeat(in *pb.HeartbeatRequest) error {
+	if !Config_Headnode_RelayHeartbeats.GetBool() {
+		return status.Errorf(codes.FailedPrecondition, "Relaying heartbeats is not enabled on %v", NodeHost)
+	}
+	// Only relay to the headnodes this node reports to, so that the relay can't be used to connect arbitrary hosts
+	headnode := in.GetHeadnode()
+	if state, ok := headnodesReporting.Load(headnode); !ok || state.(*heartbeat_state).Stopped {
+		return status.Errorf(codes.PermissionDenied, "Headnode %v is not reported to by %v", headnode, NodeHost)
+	}
+	buffer := lockRelayBuffer(headnode)
+	defer buffer.lock.Unlock()
+	key := in.GetNodename() + "@" + in.GetHost()
+	buffer.heartbeats[key] = in
+	buffer.received[key] = time.Now()
+	if buffer.lastError != nil {
+		return status.Errorf(codes.Unavailable, "Failed to relay heartbeats to headnode %v: %v", headnode, status.Convert(buffer.lastError).Message())
+	}
+	if err, ok := buffer.errors[key]; ok {
+		return errors.New(err)
+	}
+	return nil
+}
+
+// Get the buffer of the headnode locked, which starts relaying if it is new
+func lockRelayBuffer(headnode string) *relayBuffer {
+	for {
+		b, loaded := relayBuffers.LoadOrStore(headnode, &relayBuffer{heartbeats: map[string]*pb.HeartbeatRequest{}, errors: map[string]string{}, received: map[string]time.Time{}})
+		buffer := b.(*relayBuffer)
+		if !loaded {
+			LogInfo("Start relaying heartbeats to headnode %v", headnode)
+			go buffer.Relay(headnode)
+		}
+		buffer.lock.Lock()
+		if !buffer.stopped {
+			return buffer
+		}
+		buffer.lock.Unlock()
+	}
+}
+
+// Forget the clusnodes without heartbeat in the idle timeout, and stop the buffer if no clusnode is left
+func (b *relayBuffer) prune(headnode string, now time.Time) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.stopped {
+		return true
+	}
+	for key, t := range b.received {
+		if now.Sub(t) > relayIdleTimeout {
+			delete(b.received, key)
+			delete(b.errors, key)
+		}
+	}
+	if len(b.received) == 0 && len(b.heartbeats) == 0 {
+		b.stopped = true
+		relayBuffers.Delete(headnode)
+	}
+	return b.stopped
+}
+
+// Send the buffered heartbeats to the headnode in a batch at each interval, until it is idle
+func (b *relayBuffer) Relay(headnode string) {
+	for {
+		sleepConfigInterval(&Config_Headnode_RelayIntervalSecond, time.Second)
+		if b.prune(headnode, time.Now()) {
+			LogInfo("Stop relaying heartbeats to headnode %v which is idle", headnode)
+			return
+		}
+		b.lock.Lock()
+		keys := make([]string, 0, len(b.heartbeats))
+		for key := range b.heartbeats {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		heartbeats := make([]*pb.HeartbeatRequest, len(keys))
+		for i, key := range keys {
+			heartbeats[i] = b.heartbeats[key]
+		}
+		b.heartbeats = map[string]*pb.HeartbeatRequest{}
+		b.lock.Unlock()
+		if len(heartbeats) == 0 {
+			continue
+		}
+		errs, err := sendHeartbeats(headnode, heartbeats)
+		b.lock.Lock()
+		b.lastError = err
+		for i, key := range keys {
+			if i < len(errs) && len(errs[i]) > 0 {
+				b.errors[key] = errs[i]
+			} else {
+				delete(b.errors, key)
+			}
+		}
+		b.lock.Unlock()
+	}
+}
+
+func sendHeartbeats(headnode string, heartbeats []*pb.HeartbeatRequest) ([]string, error) {
+	conn, cancel := ConnectNode(headnode)
+	defer cancel()
+	if conn == nil {
+		return nil, errors.New("Failed to connect")
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), relayTimeout)
+	defer cancel()
+	reply, err := pb.NewHeadnodeClient(conn).BatchHeartbeat(ctx, &pb.BatchHeartbeatRequest{Heartbeats: heartbeats, Relay: NodeHost})
+	if err != nil {
+		LogError("Failed to relay %v heartbeats to headnode %v: %v", len(heartbeats), headnode, err)
+		return nil, err
+	}
+	return reply.GetErrors(), nil
+}
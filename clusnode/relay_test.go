@@ -0,0 +1,55 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_bufferHeartbeat(t *testing.T) {
+	defer func() {
+		_ = Config_Headnode_RelayHeartbeats.Set(Config_Headnode_RelayHeartbeats.Value)
+	}()
+	if err := Config_Headnode_RelayHeartbeats.Set(true); err != nil {
+		t.Fatalf("Failed to enable relaying heartbeats: %v", err)
+	}
+	headnodesReporting.Store("headnode:50505", &heartbeat_state{})
+	defer headnodesReporting.Delete("headnode:50505")
+
+	// The heartbeats are only relayed to the headnodes reported to
+	if err := bufferHeartbeat(&pb.HeartbeatRequest{Nodename: "NODE1", Host: "node1:50505", Headnode: "other:50505"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected heartbeat to other headnode rejected, got %v", err)
+	}
+	if _, ok := relayBuffers.Load("other:50505"); ok {
+		t.Errorf("Expected no buffer for other headnode")
+	}
+	if err := bufferHeartbeat(&pb.HeartbeatRequest{Nodename: "NODE1", Host: "node1:50505", Headnode: "headnode:50505"}); err != nil {
+		t.Errorf("Expected heartbeat to headnode buffered, got %v", err)
+	}
+	b, ok := relayBuffers.Load("headnode:50505")
+	if !ok {
+		t.Fatalf("Expected buffer for headnode")
+	}
+
+	// The clusnodes without heartbeats are forgotten, and the buffer is removed once it is idle
+	buffer := b.(*relayBuffer)
+	buffer.lock.Lock()
+	buffer.heartbeats = map[string]*pb.HeartbeatRequest{}
+	buffer.errors["NODE1@node1:50505"] = "Invalid signature"
+	buffer.lock.Unlock()
+	if buffer.prune("headnode:50505", time.Now()) {
+		t.Errorf("Expected buffer with recent heartbeats kept")
+	}
+	if !buffer.prune("headnode:50505", time.Now().Add(relayIdleTimeout+time.Second)) {
+		t.Errorf("Expected idle buffer stopped")
+	}
+	if len(buffer.errors) > 0 || len(buffer.received) > 0 {
+		t.Errorf("Expected idle clusnodes forgotten, got errors %v", buffer.errors)
+	}
+	if _, ok := relayBuffers.Load("headnode:50505"); ok {
+		t.Errorf("Expected idle buffer removed")
+	}
+}
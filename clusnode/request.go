@@ -0,0 +1,49 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	maxJobRequestIdLength = 128
+	jobRequestRetention   = 24 * time.Hour // the retried request attaches to the job created within the retention
+)
+
+var errJobRequestExists = errors.New("Job of the request exists")
+
+func validateJobRequestId(request_id string) error {
+	if len(request_id) > maxJobRequestIdLength {
+		return fmt.Errorf("Request id should be no longer than %v characters", maxJobRequestIdLength)
+	}
+	for _, c := range request_id {
+		if c < ' ' || c == 0x7f {
+			return errors.New("Request id should not contain control characters")
+		}
+	}
+	return nil
+}
+
+// Find the job created by the request of the client within the retention, the latest one is found if the client reuses
+// the request id
+func findRequestJob(jobs []*pb.Job, request_id, client string, now time.Time) *pb.Job {
+	if len(request_id) == 0 {
+		return nil
+	}
+	for i := len(jobs) - 1; i >= 0; i-- {
+		job := jobs[i]
+		if job.RequestId == request_id && job.Client == client && now.Sub(time.Unix(job.CreateTime, 0)) < jobRequestRetention {
+			return job
+		}
+	}
+	return nil
+}
+
+// Attach the retried request to the job created by it, the output is sent from the beginning since the client has not got
+// the job before
+func (s *headnode_server) attachJobRequest(id int32, request_id string, out pb.Headnode_StartClusJobServer) error {
+	LogContext(out.Context()).Info("Request %q attaches to job %v created by it", request_id, id)
+	return s.WatchClusJob(&pb.WatchClusJobRequest{JobId: id}, out)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_findRequestJob(t *testing.T) {
+	now := time.Now()
+	jobs := []*pb.Job{
+		{Id: 1, RequestId: "r1", Client: "user alice", CreateTime: now.Add(-2 * jobRequestRetention).Unix()},
+		{Id: 2, RequestId: "r1", Client: "user alice", CreateTime: now.Add(-time.Hour).Unix()},
+		{Id: 3, RequestId: "r1", Client: "user bob", CreateTime: now.Unix()},
+		{Id: 4, Client: "user alice", CreateTime: now.Unix()},
+		{Id: 5, RequestId: "r2", Client: "user alice", CreateTime: now.Add(-2 * jobRequestRetention).Unix()},
+	}
+	if job := findRequestJob(jobs, "r1", "user alice", now); job == nil || job.Id != 2 {
+		t.Errorf("Expected job 2 created by the request of the client, got %v", job)
+	}
+	if job := findRequestJob(jobs, "r1", "user carol", now); job != nil {
+		t.Errorf("Expected no job for the request of another client, got %v", job.Id)
+	}
+	if job := findRequestJob(jobs, "r2", "user alice", now); job != nil {
+		t.Errorf("Expected no job created out of the retention, got %v", job.Id)
+	}
+	if job := findRequestJob(jobs, "", "user alice", now); job != nil {
+		t.Errorf("Expected no job for request without id, got %v", job.Id)
+	}
+	for _, id := range []string{"", "2f0c", "deploy nightly"} {
+		if err := validateJobRequestId(id); err != nil {
+			t.Errorf("Expected request id %q valid, got %v", id, err)
+		}
+	}
+	for _, id := range []string{strings.Repeat("x", maxJobRequestIdLength+1), "a\nb"} {
+		if err := validateJobRequestId(id); err == nil {
+			t.Errorf("Expected request id %q invalid", id)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Get nil for no requirements if all of them are zero
+func normalizeRequirements(req *pb.ResourceRequirements) *pb.ResourceRequirements {
+	if req.GetMinFreeMemoryMb() == 0 && req.GetMinFreeDiskMb() == 0 && req.GetMaxCpuLoad() == 0 && req.GetMaxRunningJobs() == 0 && req.GetMinIdleGpus() == 0 {
+		return nil
+	}
+	return req
+}
+
+func validateRequirements(req *pb.ResourceRequirements) error {
+	if req.GetMinFreeMemoryMb() < 0 || req.GetMinFreeDiskMb() < 0 || req.GetMaxCpuLoad() < 0 || req.GetMaxRunningJobs() < 0 || req.GetMinIdleGpus() < 0 {
+		return errors.New("Invalid resource requirements, which should not be negative")
+	}
+	return nil
+}
+
+// Check the latest resource usage reported by the node against the requirements, the zero requirements are not checked
+func checkRequirements(r *pb.NodeResources, req *pb.ResourceRequirements) error {
+	if req == nil {
+		return nil
+	}
+	if r == nil {
+		return errors.New("no resource usage reported")
+	}
+	if min := req.MinFreeMemoryMb; min > 0 {
+		if r.MemoryAvailable < 0 {
+			return errors.New("available memory is unknown")
+		} else if free := r.MemoryAvailable >> 20; free < min {
+			return fmt.Errorf("available memory %v MB is less than %v MB", free, min)
+		}
+	}
+	if min := req.MinFreeDiskMb; min > 0 {
+		if r.DiskFree < 0 {
+			return errors.New("free disk is unknown")
+		} else if free := r.DiskFree >> 20; free < min {
+			return fmt.Errorf("free disk %v MB is less than %v MB", free, min)
+		}
+	}
+	if max := req.MaxCpuLoad; max > 0 {
+		if r.CpuLoad < 0 {
+			return errors.New("CPU load is unknown")
+		} else if r.CpuLoad > max {
+			return fmt.Errorf("CPU load %.2f is more than %.2f", r.CpuLoad, max)
+		}
+	}
+	if max := req.MaxRunningJobs; max > 0 && r.RunningJobs > max {
+		return fmt.Errorf("running jobs %v are more than %v", r.RunningJobs, max)
+	}
+	if min := req.MinIdleGpus; min > 0 && r.IdleGpus < min {
+		return fmt.Errorf("idle GPUs %v are less than %v", r.IdleGpus, min)
+	}
+	return nil
+}
+
+// Split the nodes into the ones satisfying the requirements and the skipped ones with reasons
+func filterNodesByRequirements(nodes []string, req *pb.ResourceRequirements) ([]string, map[string]string) {
+	if req == nil {
+		return nodes, nil
+	}
+	satisfied := make([]string, 0, len(nodes))
+	skipped := map[string]string{}
+	for _, node := range nodes {
+		var resources *pb.NodeResources
+		if r, ok := nodeResources.Load(node); ok {
+			resources = r.(*pb.NodeResources)
+		}
+		if err := checkRequirements(resources, req); err != nil {
+			skipped[node] = err.Error()
+		} else {
+			satisfied = append(satisfied, node)
+		}
+	}
+	return satisfied, skipped
+}
+
+func formatSkippedNodes(skipped map[string]string) string {
+	nodes := make([]string, 0, len(skipped))
+	for node, reason := range skipped {
+		nodes = append(nodes, fmt.Sprintf("%v (%v)", node, reason))
+	}
+	sort.Strings(nodes)
+	return strings.Join(nodes, ", ")
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"testing"
+)
+
+func Test_checkRequirements(t *testing.T) {
+	resources := &pb.NodeResources{CpuLoad: 1.5, MemoryTotal: 8 << 30, MemoryAvailable: 2 << 30, DiskTotal: 100 << 30, DiskFree: 10 << 30, RunningJobs: 2, IdleGpus: 2}
+	unknown := &pb.NodeResources{CpuLoad: -1, MemoryTotal: -1, MemoryAvailable: -1, DiskTotal: -1, DiskFree: -1}
+	cases := []struct {
+		resources    *pb.NodeResources
+		requirements *pb.ResourceRequirements
+		satisfied    bool
+	}{
+		{resources, nil, true},
+		{nil, nil, true},
+		{nil, &pb.ResourceRequirements{MaxCpuLoad: 2}, false},
+		{resources, &pb.ResourceRequirements{MinFreeMemoryMb: 2048, MinFreeDiskMb: 10240, MaxCpuLoad: 1.5, MaxRunningJobs: 2}, true},
+		{resources, &pb.ResourceRequirements{MinFreeMemoryMb: 2049}, false},
+		{resources, &pb.ResourceRequirements{MinFreeDiskMb: 10241}, false},
+		{resources, &pb.ResourceRequirements{MaxCpuLoad: 1.4}, false},
+		{resources, &pb.ResourceRequirements{MaxRunningJobs: 1}, false},
+		{resources, &pb.ResourceRequirements{MinIdleGpus: 2}, true},
+		{resources, &pb.ResourceRequirements{MinIdleGpus: 3}, false},
+		{unknown, &pb.ResourceRequirements{MaxRunningJobs: 1}, true},
+		{unknown, &pb.ResourceRequirements{MaxCpuLoad: 4}, false},
+		{unknown, &pb.ResourceRequirements{MinFreeMemoryMb: 1}, false},
+	}
+
+	for _, c := range cases {
+		if err := checkRequirements(c.resources, c.requirements); (err == nil) != c.satisfied {
+			t.Errorf("\nresources=%v, requirements=%v\nexpected satisfied=%v\n  actual error=%v", c.resources, c.requirements, c.satisfied, err)
+		}
+	}
+}
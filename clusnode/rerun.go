@@ -0,0 +1,137 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Rerun the job as a new job linked to it in job history, on all nodes selected as the job did or on its failed and lost
+// nodes only, in which each node runs the same command with the same sweep values as before
+func (s *headnode_server) RerunJob(in *pb.RerunJobRequest, out pb.Headnode_RerunJobServer) error {
+	job, err := getClientJob(out.Context(), in.GetJobId())
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	request, err := getRerunJobRequest(job, in.GetFailedOnly())
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if len(in.GetName()) > 0 {
+		request.Name = in.GetName()
+	}
+	request.RequestId, request.Progress = in.GetRequestId(), in.GetProgress()
+	LogContext(out.Context()).Info("Rerunning job %v, failed nodes only: %v", job.Id, in.GetFailedOnly())
+	return s.StartClusJob(request, out)
+}
+
+// Get the request to rerun the job, the failed nodes are not known until the job ends
+func getRerunJobRequest(job *pb.Job, failed_only bool) (*pb.StartClusJobRequest, error) {
+	request := &pb.StartClusJobRequest{
+		Command:          job.Command,
+		Arguments:        job.Arguments,
+		Sweep:            job.Sweep,
+		Pattern:          job.NodePattern,
+		Exclude:          job.Exclude,
+		Groups:           job.NodeGroups,
+		Nodes:            job.SpecifiedNodes,
+		Labels:           job.Labels,
+		Where:            job.Where,
+		Shell:            job.Shell,
+		ScriptName:       job.ScriptName,
+		OsCommands:       job.OsCommands,
+		NodeCommands:     job.NodeCommands,
+		Name:             strings.TrimSpace(fmt.Sprintf("[Rerun job %v] %v", job.Id, job.Name)),
+		MaxReschedules:   job.MaxReschedules,
+		Checkpoint:       job.Checkpoint,
+		ShipCheckpoint:   job.ShipCheckpoint,
+		BandwidthLimitKb: job.BandwidthLimitKb,
+		WorkingDir:       job.WorkingDir,
+		RunAs:            job.RunAs,
+		JsonOutput:       job.JsonOutput,
+		DispatchOrder:    job.DispatchOrder,
+		Cluster:          job.Cluster,
+		CaptureEnv:       job.CaptureEnv,
+		Requirements:     job.Requirements,
+		Limits:           job.Limits,
+		EnvMode:          job.EnvMode,
+		OutputWindow:     job.OutputWindow,
+		Rolling:          job.Rolling,
+		FailFast:         job.FailFast,
+		Executor:         job.Executor,
+		RerunOf:          job.Id,
+	}
+	if len(job.NodeCommands) > 0 {
+		// The nodes are specified by the per-node commands
+		request.Nodes = nil
+	}
+	if !failed_only {
+		return request, nil
+	}
+
+	// Only the failed and lost nodes are specified, with their commands and sweep values in the job
+	if isActiveState(job.State) || job.State == pb.JobState_Created {
+		return nil, fmt.Errorf("Job %v is still %v", job.Id, job.State)
+	}
+	nodes := getRerunNodes(job)
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("No failed nodes of job %v to rerun", job.Id)
+	}
+	request.Pattern, request.Exclude, request.Groups, request.Labels, request.Where, request.Nodes = "", "", nil, nil, nil, nodes
+	if len(job.NodeCommands) > 0 {
+		request.Nodes = nil
+		request.NodeCommands = make(map[string]string, len(nodes))
+		for _, node := range nodes {
+			command, ok := getRescheduledNodeValue(job, node, job.NodeCommands)
+			if !ok {
+				return nil, fmt.Errorf("No command of node %v in job %v", node, job.Id)
+			}
+			request.NodeCommands[node] = command
+		}
+	}
+	if len(job.Sweep) > 0 {
+		request.SweepValues = make(map[string]string, len(nodes))
+		for _, node := range nodes {
+			values, ok := job.SweepValues[node]
+			if !ok {
+				return nil, fmt.Errorf("No sweep values of node %v in job %v", node, job.Id)
+			}
+			request.SweepValues[node] = values
+		}
+	}
+	return request, nil
+}
+
+// The failed nodes include the unreachable ones, and the lost nodes are those whose result is lost after headnode restarted
+func getRerunNodes(job *pb.Job) []string {
+	nodes := make([]string, 0, len(job.FailedNodes)+len(job.LostNodes))
+	for node := range job.FailedNodes {
+		nodes = append(nodes, node)
+	}
+	for _, node := range job.LostNodes {
+		if _, ok := job.FailedNodes[node]; !ok {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// Get the value of the node, or of the node whose task is rescheduled to it
+func getRescheduledNodeValue(job *pb.Job, node string, values map[string]string) (string, bool) {
+	rescheduled_from := map[string]string{}
+	for _, r := range job.Reschedules {
+		rescheduled_from[r.ToNode] = r.FromNode
+	}
+	for i := 0; i <= len(job.Reschedules); i++ {
+		if value, ok := values[node]; ok {
+			return value, true
+		}
+		node = rescheduled_from[node]
+	}
+	return "", false
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"reflect"
+	"testing"
+)
+
+func Test_getRerunJobRequest(t *testing.T) {
+	job := &pb.Job{
+		Id:          7,
+		Name:        "train",
+		Command:     "run.sh {x}",
+		Arguments:   []string{"--seed={x}"},
+		Sweep:       "{x}{1-4}",
+		NodePattern: "node.*",
+		Labels:      []string{"gpu"},
+		State:       pb.JobState_Failed,
+		Nodes:       []string{"n1", "n2", "n3", "n4"},
+		FailedNodes: map[string]int32{"n2": 1, "n4": -1},
+		LostNodes:   []string{"n3"},
+		SweepValues: map[string]string{"n1": "{x}=1", "n2": "{x}=2", "n3": "{x}=3", "n4": "{x}=4"},
+	}
+	request, err := getRerunJobRequest(job, false)
+	if err != nil {
+		t.Fatalf("Failed to get request to rerun job: %v", err)
+	}
+	if request.Pattern != "node.*" || len(request.Nodes) > 0 || len(request.SweepValues) > 0 || request.RerunOf != 7 || request.Name != "[Rerun job 7] train" {
+		t.Errorf("Expected the job rerun on nodes selected as it did, got %v", request)
+	}
+	if request, err = getRerunJobRequest(job, true); err != nil {
+		t.Fatalf("Failed to get request to rerun failed nodes of job: %v", err)
+	}
+	if !reflect.DeepEqual(request.Nodes, []string{"n2", "n3", "n4"}) || len(request.Pattern) > 0 || len(request.Labels) > 0 {
+		t.Errorf("Expected the failed and lost nodes specified only, got %v", request)
+	}
+	if !reflect.DeepEqual(request.SweepValues, map[string]string{"n2": "{x}=2", "n3": "{x}=3", "n4": "{x}=4"}) {
+		t.Errorf("Expected the sweep values of the failed nodes kept, got %v", request.SweepValues)
+	}
+
+	job = &pb.Job{
+		Id:           8,
+		State:        pb.JobState_Failed,
+		NodeCommands: map[string]string{"n1": "ls", "n2": "pwd"},
+		FailedNodes:  map[string]int32{"n3": 1},
+		Reschedules:  []*pb.Reschedule{{FromNode: "n2", ToNode: "n3"}},
+	}
+	if request, err = getRerunJobRequest(job, true); err != nil {
+		t.Fatalf("Failed to get request to rerun failed nodes of job: %v", err)
+	}
+	if len(request.Nodes) > 0 || !reflect.DeepEqual(request.NodeCommands, map[string]string{"n3": "pwd"}) {
+		t.Errorf("Expected the command rescheduled to the failed node, got %v", request.NodeCommands)
+	}
+	job.FailedNodes = nil
+	if _, err = getRerunJobRequest(job, true); err == nil {
+		t.Errorf("Expected error to rerun a job without failed nodes")
+	}
+	job.FailedNodes, job.State = map[string]int32{"n1": 1}, pb.JobState_Running
+	if _, err = getRerunJobRequest(job, true); err == nil {
+		t.Errorf("Expected error to rerun failed nodes of a running job")
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Pick alternative nodes for tasks on lost nodes of a job, a node never runs the same job twice
+type taskRescheduler struct {
+	id             int32
+	remaining      int
+	specifiedNodes []string
+	pattern        string
+	exclude        string
+	cluster        string
+	groups         []string
+	intersect      bool
+	selectors      []labelSelector
+	requirements   *pb.ResourceRequirements
+	sameOs         bool // the task is rescheduled to the node with the same OS, since its command is for the OS
+	used           map[string]bool
+	lock           sync.Mutex
+}
+
+func newTaskRescheduler(id int32, request *pb.StartClusJobRequest, options newJobOptions) *taskRescheduler {
+	used := make(map[string]bool, len(options.nodes))
+	for _, node := range options.nodes {
+		used[node] = true
+	}
+	return &taskRescheduler{
+		id:             id,
+		remaining:      int(request.GetMaxReschedules()),
+		specifiedNodes: options.specifiedNodes,
+		pattern:        request.GetPattern(),
+		exclude:        request.GetExclude(),
+		cluster:        options.cluster,
+		groups:         request.GetGroups(),
+		intersect:      request.GetGroupsIntersect(),
+		selectors:      options.selectors,
+		requirements:   options.requirements,
+		sameOs:         len(options.osCommands) > 0,
+		used:           used,
+	}
+}
+
+// Return empty string if the task on the lost node can not be rescheduled
+func (r *taskRescheduler) Next(lost string) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.remaining <= 0 {
+		return ""
+	}
+	if state, err := GetJobState(r.id); err != nil || (state != pb.JobState_Dispatching && state != pb.JobState_Running) {
+		return ""
+	}
+	candidates, _, _ := getValidNodes(r.specifiedNodes, r.pattern, r.exclude, r.cluster, r.groups, r.intersect, r.selectors, r.requirements)
+	sort.Strings(candidates)
+	for _, node := range candidates {
+		if r.used[node] || r.sameOs && !strings.EqualFold(getNodeOs(node), getNodeOs(lost)) {
+			continue
+		}
+		r.used[node] = true
+		r.remaining--
+		reschedule := &pb.Reschedule{FromNode: lost, ToNode: node, Time: time.Now().Unix()}
+		if err := AddJobReschedule(r.id, reschedule); err != nil {
+			getJobLogger(r.id).Error("Failed to save rescheduling of job %v from node %v to node %v: %v", r.id, lost, node, err)
+		}
+		getJobLogger(r.id).Info("Reschedule task of job %v from lost node %v to node %v", r.id, lost, node)
+		return node
+	}
+	getJobLogger(r.id).Warning("No alternative node to reschedule task of job %v on lost node %v", r.id, lost)
+	return ""
+}
+
+// Start the task on the node by the request, and reschedule it with the checkpoint shipped to another node if the node is lost
+func startTaskOnNode(task jobTask, request *pb.StartJobRequest, node string, job_on_nodes *sync.Map, out jobReplySender, pool dispatcher, rescheduler *taskRescheduler) {
+	id, checkpoint := request.GetJobId(), task.checkpoint
+	for {
+		logger := getJobLogger(id).With(logFieldNode, node)
+		if lost := startJobOnNode(task, request, node, job_on_nodes, out, pool); !lost {
+			return
+		}
+		next := rescheduler.Next(node)
+		if len(next) == 0 {
+			job_on_nodes.Store(node, jobOnNode{state: pb.JobState_Failed, exitCode: -1, unreachable: true, stderr: "Node is lost"})
+			if err := out.Send(&pb.StartClusJobReply{Node: node, ExitCode: -1}); err != nil {
+				logger.Warning("Failed to redirect exit code of job %v on lost node %v: %v", id, node, err)
+			}
+			return
+		}
+		job_on_nodes.Store(node, jobOnNode{state: pb.JobState_Failed, exitCode: -1, rescheduled: true})
+		if err := out.Send(&pb.StartClusJobReply{Node: node, RescheduledTo: next}); err != nil {
+			logger.Warning("Failed to redirect rescheduling of job %v on lost node %v: %v", id, node, err)
+		}
+		if data := checkpoint.Snapshot(node); len(data) > 0 {
+			logger.Info("Ship checkpoint of job %v from lost node %v to node %v", id, node, next)
+			request.CheckpointData = data
+		}
+		node = next
+	}
+}
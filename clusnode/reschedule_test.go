@@ -0,0 +1,76 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_taskRescheduler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusrun")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	db_jobs = filepath.Join(dir, "jobs")
+	setJobsCache([]*pb.Job{{Id: 1, State: pb.JobState_Running, Nodes: []string{"RESCHEDULE-NODE1", "RESCHEDULE-NODE2"}}})
+	defer setJobsCache(nil)
+	nodes := map[string]string{"RESCHEDULE-NODE1": "linux", "RESCHEDULE-NODE2": "linux", "RESCHEDULE-NODE3": "windows", "RESCHEDULE-NODE4": "linux", "RESCHEDULE-NODE5": "linux"}
+	for node, node_os := range nodes {
+		ReportedNodes.Touch(node, time.Now())
+		ReportedNodes.SetValidated(node)
+		nodeSystems.Store(node, &pb.NodeSystem{Os: node_os})
+	}
+	defer func() {
+		for node := range nodes {
+			ReportedNodes.Remove(node)
+			nodeSystems.Delete(node)
+		}
+	}()
+
+	// The task is rescheduled to a node with the same OS which is not used by the job, for 2 times at most
+	request, os_commands := &pb.StartClusJobRequest{Pattern: "RESCHEDULE-NODE.*", MaxReschedules: 2}, map[string]string{"linux": "ls"}
+	r := newTaskRescheduler(1, request, newJobOptions{nodes: []string{"RESCHEDULE-NODE1", "RESCHEDULE-NODE2"}, osCommands: os_commands})
+	if node := r.Next("RESCHEDULE-NODE1"); node != "RESCHEDULE-NODE4" {
+		t.Errorf("Expected task rescheduled to RESCHEDULE-NODE4, got %q", node)
+	}
+	if node := r.Next("RESCHEDULE-NODE4"); node != "RESCHEDULE-NODE5" {
+		t.Errorf("Expected task rescheduled to RESCHEDULE-NODE5, got %q", node)
+	}
+	if node := r.Next("RESCHEDULE-NODE2"); node != "" {
+		t.Errorf("Expected no reschedule after the max reschedules, got %q", node)
+	}
+	job, err := GetJob(1)
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if len(job.Reschedules) != 2 || job.Reschedules[0].FromNode != "RESCHEDULE-NODE1" || job.Reschedules[1].ToNode != "RESCHEDULE-NODE5" {
+		t.Errorf("Unexpected reschedules saved: %v", job.Reschedules)
+	}
+	if expected := []string{"RESCHEDULE-NODE1", "RESCHEDULE-NODE2", "RESCHEDULE-NODE4", "RESCHEDULE-NODE5"}; !reflect.DeepEqual(job.Nodes, expected) {
+		t.Errorf("Expected nodes of job %v, got %v", expected, job.Nodes)
+	}
+
+	// No node with the same OS is left
+	r = newTaskRescheduler(1, request, newJobOptions{nodes: job.Nodes, osCommands: os_commands})
+	if node := r.Next("RESCHEDULE-NODE2"); node != "" {
+		t.Errorf("Expected no node with the same OS, got %q", node)
+	}
+	r = newTaskRescheduler(1, request, newJobOptions{nodes: job.Nodes})
+	if node := r.Next("RESCHEDULE-NODE2"); node != "RESCHEDULE-NODE3" {
+		t.Errorf("Expected task rescheduled to RESCHEDULE-NODE3 of any OS, got %q", node)
+	}
+
+	// The task of an ended job is not rescheduled
+	if err := UpdateJobState(1, pb.JobState_Running, pb.JobState_Finished); err != nil {
+		t.Fatalf("Failed to update job state: %v", err)
+	}
+	r = newTaskRescheduler(1, request, newJobOptions{})
+	if node := r.Next("RESCHEDULE-NODE1"); node != "" {
+		t.Errorf("Expected no reschedule of an ended job, got %q", node)
+	}
+}
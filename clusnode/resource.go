@@ -0,0 +1,48 @@
+package main
+
+import (
+	"clusrun/clusnode/platform"
+	pb "clusrun/protobuf"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+)
+
+var (
+	osVersion     string
+	osVersionOnce sync.Once
+)
+
+// Sample the resource usage of the node with the disk containing the clusnode, the number of jobs running on it, and the
+// GPUs detected
+func getNodeResources() *pb.NodeResources {
+	usage := platform.GetResourceUsage(filepath.Dir(ExecutablePath))
+	var running int32
+	jobsPid.Range(func(_, _ interface{}) bool {
+		running++
+		return true
+	})
+	gpus, idle_gpus := getNodeGpus()
+	return &pb.NodeResources{
+		CpuLoad:         usage.CpuLoad,
+		MemoryTotal:     usage.MemoryTotal,
+		MemoryAvailable: usage.MemoryAvailable,
+		DiskTotal:       usage.DiskTotal,
+		DiskFree:        usage.DiskFree,
+		RunningJobs:     running,
+		Gpus:            gpus,
+		IdleGpus:        idle_gpus,
+	}
+}
+
+// The OS and architecture the clusnode is built for, and the OS version which is only read once
+func getNodeSystem() *pb.NodeSystem {
+	osVersionOnce.Do(func() { osVersion = platform.GetOSVersion() })
+	return &pb.NodeSystem{Os: runtime.GOOS, Arch: runtime.GOARCH, Version: osVersion, TimeZone: formatTimeZone(time.Now())}
+}
+
+// Format the zone of the time with its offset, which changes with DST in the same location
+func formatTimeZone(t time.Time) string {
+	return t.Format("MST -0700")
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	taskLostByRestart     = "Task lost due to restart of clusnode"
+	restartReportDuration = time.Minute
+	restartReportPoll     = 200 * time.Millisecond
+)
+
+var (
+	restartLostJobs sync.Map // headnode -> ids of the jobs lost due to restart of this clusnode
+	restartedTasks  sync.Map // job id -> *sync.Map of the nodes reporting the task lost due to restart
+)
+
+// The task persisted by clusnode when it starts, which is left if the clusnode restarts before the task ends
+type taskIntent struct {
+	Headnode  string
+	JobId     int32
+	Pid       int
+	StartTime int64
+}
+
+func saveTaskIntent(job_label, headnode string, job_id int32, pid int) error {
+	intent := taskIntent{Headnode: headnode, JobId: job_id, Pid: pid, StartTime: time.Now().Unix()}
+	json_string, err := json.Marshal(intent)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(db_taskDir, job_label), json_string, 0644)
+}
+
+func removeTaskIntent(job_label string) {
+	if err := os.Remove(filepath.Join(db_taskDir, job_label)); err != nil && !os.IsNotExist(err) {
+		LogError("Failed to remove task intent of job %v: %v", job_label, err)
+	}
+}
+
+// Load the tasks left by the last run of clusnode, which are reported to headnodes in heartbeats for a while
+func loadTaskIntents() error {
+	files, err := ioutil.ReadDir(db_taskDir)
+	if err != nil {
+		return err
+	}
+	lost := map[string][]int32{}
+	for _, f := range files {
+		file := filepath.Join(db_taskDir, f.Name())
+		var intent taskIntent
+		if json_string, err := ioutil.ReadFile(file); err != nil {
+			LogError("Failed to read task intent %v: %v", f.Name(), err)
+		} else if err := json.Unmarshal(json_string, &intent); err != nil {
+			LogError("Failed to parse task intent %v: %v", f.Name(), err)
+		} else {
+			LogWarning("Job %v from headnode %v started at %v is lost due to restart", intent.JobId, intent.Headnode, time.Unix(intent.StartTime, 0))
+			lost[intent.Headnode] = append(lost[intent.Headnode], intent.JobId)
+		}
+		if err := os.Remove(file); err != nil {
+			LogError("Failed to remove task intent %v: %v", f.Name(), err)
+		}
+	}
+	if len(lost) == 0 {
+		return nil
+	}
+	for headnode, ids := range lost {
+		restartLostJobs.Store(headnode, ids)
+	}
+	go func() {
+		time.Sleep(restartReportDuration)
+		for headnode := range lost {
+			restartLostJobs.Delete(headnode)
+		}
+	}()
+	return nil
+}
+
+func getRestartLostJobs(headnode string) []int32 {
+	if ids, ok := restartLostJobs.Load(headnode); ok {
+		return ids.([]int32)
+	}
+	return nil
+}
+
+// Record the tasks of running jobs reported lost due to restart of the clusnode
+func receiveRestartLostJobs(display_name string, ids []int32) {
+	for _, id := range ids {
+		if _, ok := Jobs.Load(id); !ok {
+			continue
+		}
+		nodes, _ := restartedTasks.LoadOrStore(id, &sync.Map{})
+		if _, loaded := nodes.(*sync.Map).LoadOrStore(display_name, true); !loaded {
+			LogWarning("Task of job %v on node %v is lost due to restart of clusnode", id, display_name)
+		}
+	}
+}
+
+// Wait for the clusnode to report the disconnected task lost due to restart, return false if not reported in time
+func waitTaskRestartReport(id int32, node string) bool {
+	deadline := time.Now().Add(time.Duration(Config_Headnode_RestartReportTimeoutSecond.GetInt()) * time.Second)
+	for {
+		if nodes, ok := restartedTasks.Load(id); ok {
+			if _, ok := nodes.(*sync.Map).Load(node); ok {
+				return true
+			}
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(restartReportPoll)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func Test_loadTaskIntents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusrun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db_taskDir = dir
+	for _, intent := range []taskIntent{{"head1:50505", 1, 100, 0}, {"head1:50505", 2, 101, 0}, {"head2:50505", 3, 102, 0}, {"head2:50505", 4, 103, 0}} {
+		job_label := getJobLabel(intent.Headnode, int(intent.JobId))
+		if err := saveTaskIntent(job_label, intent.Headnode, intent.JobId, intent.Pid); err != nil {
+			t.Fatal(err)
+		}
+	}
+	removeTaskIntent(getJobLabel("head2:50505", 4))
+	if err := loadTaskIntents(); err != nil {
+		t.Fatal(err)
+	}
+	defer restartLostJobs.Delete("head1:50505")
+	defer restartLostJobs.Delete("head2:50505")
+
+	expected := map[string][]int32{"head1:50505": {1, 2}, "head2:50505": {3}, "head3:50505": nil}
+	for headnode, ids := range expected {
+		if actual := getRestartLostJobs(headnode); !reflect.DeepEqual(actual, ids) {
+			t.Errorf("\nheadnode=%v\nexpected=%v\n  actual=%v", headnode, ids, actual)
+		}
+	}
+	if files, err := ioutil.ReadDir(dir); err != nil || len(files) > 0 {
+		t.Errorf("task intents are not removed after loaded: %v, %v", len(files), err)
+	}
+}
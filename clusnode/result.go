@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Parse the stdout of a job in JSON mode, which should be a single JSON document, into compact JSON
+func parseJsonResult(output string) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(output))
+	var doc json.RawMessage
+	if err := decoder.Decode(&doc); err == io.EOF {
+		return "", errors.New("Empty output")
+	} else if err != nil {
+		return "", fmt.Errorf("Invalid JSON output: %v", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return "", errors.New("Invalid JSON output: more than one JSON document")
+	}
+	var b bytes.Buffer
+	if err := json.Compact(&b, doc); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Valid format: [.]key[.key...] or with index like .key[0].key, "." for the whole document
+func parseJsonPath(path string) ([]interface{}, error) {
+	p := path
+	if strings.HasPrefix(p, ".") {
+		p = p[1:]
+	}
+	segments := []interface{}{}
+	for len(p) > 0 {
+		if p[0] == '[' {
+			end := strings.IndexByte(p, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("Missing ] in path %q", path)
+			}
+			index, err := strconv.Atoi(p[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("Invalid index %q in path %q", p[1:end], path)
+			}
+			segments = append(segments, index)
+			p = p[end+1:]
+		} else {
+			end := strings.IndexAny(p, ".[")
+			if end < 0 {
+				end = len(p)
+			} else if end == 0 {
+				return nil, fmt.Errorf("Empty key in path %q", path)
+			}
+			segments = append(segments, p[:end])
+			p = p[end:]
+		}
+		if strings.HasPrefix(p, ".") {
+			if p = p[1:]; len(p) == 0 {
+				return nil, fmt.Errorf("Empty key in path %q", path)
+			}
+		}
+	}
+	return segments, nil
+}
+
+// Select the value in the path from the JSON result, a missing key or index selects null
+func selectJsonResult(result string, path []interface{}) (string, error) {
+	if len(path) == 0 {
+		return result, nil
+	}
+	doc, err := decodeJsonResult(result)
+	if err != nil {
+		return "", err
+	}
+	value, err := selectJsonValue(doc, path)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func decodeJsonResult(result string) (interface{}, error) {
+	var doc interface{}
+	decoder := json.NewDecoder(strings.NewReader(result))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func selectJsonValue(value interface{}, path []interface{}) (interface{}, error) {
+	for _, segment := range path {
+		if value == nil {
+			break
+		}
+		switch s := segment.(type) {
+		case string:
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("Can not select key %q in a non-object", s)
+			}
+			value = object[s]
+		case int:
+			array, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("Can not select index %v in a non-array", s)
+			}
+			if s < len(array) {
+				value = array[s]
+			} else {
+				value = nil
+			}
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_parseJsonResult(t *testing.T) {
+	cases := []struct {
+		output string
+		result string
+		valid  bool
+	}{
+		{`{"a": 1, "b": [1, 2]}`, `{"a":1,"b":[1,2]}`, true},
+		{"  [1,\n2]\n\n", `[1,2]`, true},
+		{`"text"`, `"text"`, true},
+		{``, ``, false},
+		{`{"a": 1`, ``, false},
+		{`{"a": 1} {"b": 2}`, ``, false},
+		{`text`, ``, false},
+	}
+
+	for _, c := range cases {
+		if result, err := parseJsonResult(c.output); result != c.result || (err == nil) != c.valid {
+			t.Errorf("\noutput=%q\nexpected result=%v, valid=%v\n  actual result=%v, error=%v", c.output, c.result, c.valid, result, err)
+		}
+	}
+}
+
+func Test_selectJsonResult(t *testing.T) {
+	doc := `{"version":"1.2","disks":[{"size":12345678901234567890},{"size":2}],"os":{"name":"linux"}}`
+	cases := []struct {
+		path  string
+		value string
+		valid bool
+	}{
+		{".", doc, true},
+		{"", doc, true},
+		{".version", `"1.2"`, true},
+		{"version", `"1.2"`, true},
+		{".os.name", `"linux"`, true},
+		{".disks[0].size", `12345678901234567890`, true},
+		{".disks[1]", `{"size":2}`, true},
+		{".disks[2].size", `null`, true},
+		{".missing.key", `null`, true},
+		{".version.major", ``, false},
+		{".os[0]", ``, false},
+		{"..version", ``, false},
+		{".version.", ``, false},
+		{".disks[x]", ``, false},
+		{".disks[0", ``, false},
+	}
+
+	for _, c := range cases {
+		var value string
+		path, err := parseJsonPath(c.path)
+		if err == nil {
+			value, err = selectJsonResult(doc, path)
+		}
+		if value != c.value || (err == nil) != c.valid {
+			t.Errorf("\npath=%q\nexpected value=%v, valid=%v\n  actual value=%v, error=%v", c.path, c.value, c.valid, value, err)
+		}
+	}
+}
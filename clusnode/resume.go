@@ -0,0 +1,270 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The output kept for resuming of each stream of a task, the stream can not be resumed from the output dropped
+	taskOutputBacklogSize = 1 << 20
+	// The time to wait for the headnode to resume the stream to receive the exit code of a task ended while the stream is broken
+	taskResumeTimeout = time.Minute
+
+	jobStreamResumeRetries  = 5
+	jobStreamResumeInterval = 2 * time.Second
+)
+
+var (
+	taskOutputRelays sync.Map // job label -> *taskOutputRelay
+)
+
+// The last output of a stream with the offset of its first byte
+type outputBacklog struct {
+	data []byte
+	base int64
+}
+
+func (b *outputBacklog) Add(output []byte) {
+	b.data = append(b.data, output...)
+	if drop := len(b.data) - taskOutputBacklogSize; drop > 0 {
+		b.data, b.base = b.data[drop:], b.base+int64(drop)
+	}
+}
+
+// Get the output from the offset, false if the output is dropped or not produced yet
+func (b *outputBacklog) From(offset int64) ([]byte, bool) {
+	if offset < b.base || offset > b.base+int64(len(b.data)) {
+		return nil, false
+	}
+	return append([]byte{}, b.data[offset-b.base:]...), true
+}
+
+// Relay the output of a task to the stream of headnode, the output is kept while the stream is broken, so that the
+// headnode can resume the stream from the output it received
+type taskOutputRelay struct {
+	lock           sync.Mutex
+	out            pb.Clusnode_StartJobServer // nil while the stream is broken
+	stdout         outputBacklog
+	stderr         outputBacklog
+	stdoutSequence int64 // the sequence number of the last stdout sent
+	stderrSequence int64
+	checksum       *outputChecksum // the checksum of all output sent
+	truncate       map[string]*outputTruncator
+	final          *pb.StartJobReply
+	closed         bool
+	done           chan struct{}
+}
+
+func newTaskOutputRelay(out pb.Clusnode_StartJobServer, output_limit int64) *taskOutputRelay {
+	return &taskOutputRelay{
+		out:      out,
+		checksum: newOutputChecksum(),
+		truncate: map[string]*outputTruncator{"stdout": newOutputTruncator("stdout", output_limit), "stderr": newOutputTruncator("stderr", output_limit)},
+		done:     make(chan struct{}),
+	}
+}
+
+// Send the reply if the stream is not broken, the output in it is kept for resuming anyway
+func (r *taskOutputRelay) Send(reply *pb.StartJobReply) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return errors.New("Output stream is closed")
+	}
+	has_output := len(reply.Stdout)+len(reply.Stderr) > 0
+	reply.Stdout, reply.Stderr = r.truncate["stdout"].Write(reply.Stdout), r.truncate["stderr"].Write(reply.Stderr)
+	if has_output && len(reply.Stdout)+len(reply.Stderr) == 0 && reply.Environment == nil && len(reply.CheckpointData) == 0 {
+		return nil
+	}
+	r.sendLocked(reply)
+	return nil
+}
+
+// Send the tails of output truncated after the output ends, return the bytes truncated
+func (r *taskOutputRelay) FlushTruncated() int64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	stdout, stderr := r.truncate["stdout"].Flush(), r.truncate["stderr"].Flush()
+	if len(stdout)+len(stderr) > 0 && !r.closed {
+		r.sendLocked(&pb.StartJobReply{Stdout: stdout, Stderr: stderr})
+	}
+	return r.truncate["stdout"].dropped + r.truncate["stderr"].dropped
+}
+
+func (r *taskOutputRelay) sendLocked(reply *pb.StartJobReply) {
+	if len(reply.Stdout) > 0 {
+		r.stdoutSequence++
+		reply.StdoutSequence = r.stdoutSequence
+	}
+	if len(reply.Stderr) > 0 {
+		r.stderrSequence++
+		reply.StderrSequence = r.stderrSequence
+	}
+	r.stdout.Add(reply.Stdout)
+	r.stderr.Add(reply.Stderr)
+	r.checksum.Add(reply.Stdout, reply.Stderr)
+	if r.out != nil {
+		if err := r.out.Send(reply); err != nil {
+			LogWarning("Output stream is broken, keep the output for resuming: %v", err)
+			r.out = nil
+		}
+	}
+}
+
+// Send the last reply with the exit code, wait for the headnode to resume the stream if it is broken
+func (r *taskOutputRelay) Finish(reply *pb.StartJobReply, timeout time.Duration) error {
+	r.lock.Lock()
+	r.final = reply
+	if r.out != nil {
+		err := r.out.Send(reply)
+		if err == nil {
+			r.closeLocked()
+			r.lock.Unlock()
+			return nil
+		}
+		LogWarning("Output stream is broken when sending exit code, wait for resuming: %v", err)
+		r.out = nil
+	}
+	r.lock.Unlock()
+	select {
+	case <-r.done:
+		return nil
+	case <-time.After(timeout):
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closeLocked()
+	return fmt.Errorf("Output stream is not resumed in %v", timeout)
+}
+
+// Switch to the stream of resuming after sending the output from the offsets, and the exit code if the task ended
+func (r *taskOutputRelay) Resume(out pb.Clusnode_StartJobServer, stdout_offset, stderr_offset int64) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return status.Error(codes.NotFound, "Output stream is closed")
+	}
+	stdout, ok := r.stdout.From(stdout_offset)
+	if !ok {
+		return status.Errorf(codes.OutOfRange, "Stdout from offset %v is not kept", stdout_offset)
+	}
+	stderr, ok := r.stderr.From(stderr_offset)
+	if !ok {
+		return status.Errorf(codes.OutOfRange, "Stderr from offset %v is not kept", stderr_offset)
+	}
+	// The output from the offsets is sent in one reply with the sequence numbers of the last output sent
+	if len(stdout)+len(stderr) > 0 {
+		reply := &pb.StartJobReply{Stdout: stdout, Stderr: stderr}
+		if len(stdout) > 0 {
+			reply.StdoutSequence = r.stdoutSequence
+		}
+		if len(stderr) > 0 {
+			reply.StderrSequence = r.stderrSequence
+		}
+		if err := out.Send(reply); err != nil {
+			return err
+		}
+	}
+	if r.final != nil {
+		if err := out.Send(r.final); err != nil {
+			return err
+		}
+		r.closeLocked()
+		return nil
+	}
+	r.out = out
+	return nil
+}
+
+// Stop sending to the stream, which is not usable after its handler returns
+func (r *taskOutputRelay) Detach(out pb.Clusnode_StartJobServer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.out == out {
+		r.out = nil
+	}
+}
+
+func (r *taskOutputRelay) Checksum() *pb.OutputChecksum {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.checksum.Sum()
+}
+
+func (r *taskOutputRelay) closeLocked() {
+	r.closed = true
+	close(r.done)
+}
+
+func (s *clusnode_server) ResumeJob(in *pb.ResumeJobRequest, out pb.Clusnode_ResumeJobServer) error {
+	defer LogPanicBeforeExit()
+	headnode, job_id := in.GetHeadnode(), in.GetJobId()
+	job_label := getJobLabel(headnode, int(job_id))
+	logger := LogContext(out.Context()).With(logFieldJob, job_id)
+	logger.Info("Receive ResumeJob from headnode %v to resume job %v from stdout offset %v and stderr offset %v", headnode, job_id, in.GetStdoutOffset(), in.GetStderrOffset())
+	val, ok := taskOutputRelays.Load(job_label)
+	if !ok {
+		return status.Errorf(codes.NotFound, "Job %v is not running", job_label)
+	}
+	relay := val.(*taskOutputRelay)
+	if err := relay.Resume(out, in.GetStdoutOffset(), in.GetStderrOffset()); err != nil {
+		logger.Warning("Failed to resume job %v: %v", job_label, err)
+		return err
+	}
+	resumeRunningJob(job_label, out.Context())
+	select {
+	case <-relay.done:
+	case <-out.Context().Done():
+		relay.Detach(out)
+	}
+	return nil
+}
+
+// The output stream of a task, either started or resumed
+type taskOutputStream interface {
+	Recv() (*pb.StartJobReply, error)
+}
+
+// Resume the output stream of the task from the offsets, the retries left are decreased by the failed attempts
+func resumeJobOnNode(ctx context.Context, id int32, node string, stdout_offset, stderr_offset int64, retries *int) (taskOutputStream, func()) {
+	logger := getJobLogger(id).With(logFieldNode, node)
+	for ; *retries > 0; *retries-- {
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case <-time.After(jobStreamResumeInterval):
+		}
+		conn, release := GetNodeConnection(parseHost(node), connectionLane_Output)
+		if conn == nil {
+			continue
+		}
+		request := &pb.ResumeJobRequest{Headnode: NodeHost, JobId: id, StdoutOffset: stdout_offset, StderrOffset: stderr_offset}
+		stream, err := pb.NewClusnodeClient(conn).ResumeJob(ctx, request, getOutputStreamCallOptions()...)
+		if err != nil {
+			release()
+			logger.Warning("Failed to resume output stream of job %v on node %v: %v", id, node, err)
+			continue
+		}
+		*retries--
+		logger.Info("Resume output stream of job %v on node %v from stdout offset %v and stderr offset %v", id, node, stdout_offset, stderr_offset)
+		return stream, release
+	}
+	return nil, nil
+}
+
+// The task can not be resumed if it is not running on the node or its output is dropped
+func isResumeRejected(err error) bool {
+	code := status.Code(err)
+	return code == codes.NotFound || code == codes.OutOfRange
+}
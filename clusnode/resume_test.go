@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	pb "clusrun/protobuf"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeStartJobServer struct {
+	grpc.ServerStream
+	replies []*pb.StartJobReply
+	err     error
+}
+
+func (s *fakeStartJobServer) Send(reply *pb.StartJobReply) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.replies = append(s.replies, reply)
+	return nil
+}
+
+func Test_taskOutputRelay(t *testing.T) {
+	broken := &fakeStartJobServer{}
+	relay := newTaskOutputRelay(broken, 0)
+	_ = relay.Send(&pb.StartJobReply{Stdout: []byte("abc"), Stderr: []byte("x")})
+	broken.err = status.Error(codes.Unavailable, "broken")
+	_ = relay.Send(&pb.StartJobReply{Stdout: []byte("def")})
+	if len(broken.replies) != 1 {
+		t.Errorf("Expected 1 reply sent before the stream is broken, got %v", len(broken.replies))
+	}
+	if err := relay.Resume(&fakeStartJobServer{}, 10, 0); status.Code(err) != codes.OutOfRange {
+		t.Errorf("Expected resuming from the output not produced rejected, got %v", err)
+	}
+
+	resumed := &fakeStartJobServer{}
+	if err := relay.Resume(resumed, 3, 1); err != nil {
+		t.Fatalf("failed to resume: %v", err)
+	}
+	_ = relay.Send(&pb.StartJobReply{Stderr: []byte("y")})
+	if err := relay.Finish(&pb.StartJobReply{ExitCode: 1}, time.Second); err != nil {
+		t.Errorf("Expected exit code sent, got %v", err)
+	}
+	var stdout, stderr string
+	for _, r := range resumed.replies {
+		stdout, stderr = stdout+string(r.Stdout), stderr+string(r.Stderr)
+	}
+	if stdout != "def" || stderr != "y" || resumed.replies[len(resumed.replies)-1].ExitCode != 1 {
+		t.Errorf("Unexpected replies after resuming: %v", resumed.replies)
+	}
+	if sum := relay.Checksum(); sum.StdoutSize != 6 || sum.StderrSize != 2 {
+		t.Errorf("Expected checksum of all output, got %v", sum)
+	}
+	if err := relay.Resume(&fakeStartJobServer{}, 6, 2); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected resuming the finished relay rejected, got %v", err)
+	}
+
+	backlog := outputBacklog{}
+	backlog.Add([]byte(strings.Repeat("a", taskOutputBacklogSize)))
+	backlog.Add([]byte("bc"))
+	if _, ok := backlog.From(1); ok {
+		t.Errorf("Expected the dropped output not kept")
+	}
+	if output, ok := backlog.From(taskOutputBacklogSize); !ok || string(output) != "bc" {
+		t.Errorf("Expected the last output kept, got %q", output)
+	}
+}
+
+func Test_outputSequence(t *testing.T) {
+	out := &fakeStartJobServer{}
+	relay := newTaskOutputRelay(out, 0)
+	binary := []byte{0xff, 0xfe, 0x00, '\n', 0xc3}
+	_ = relay.Send(&pb.StartJobReply{Stdout: binary})
+	_ = relay.Send(&pb.StartJobReply{Stderr: []byte("e")})
+	_ = relay.Send(&pb.StartJobReply{Stdout: []byte{0x80}, Stderr: []byte("f")})
+	expected := [][2]int64{{1, 0}, {0, 1}, {2, 2}}
+	if len(out.replies) != len(expected) {
+		t.Fatalf("Expected %v replies, got %v", len(expected), len(out.replies))
+	}
+	for i, r := range out.replies {
+		if r.StdoutSequence != expected[i][0] || r.StderrSequence != expected[i][1] {
+			t.Errorf("Expected sequence numbers %v of reply %v, got %v, %v", expected[i], i, r.StdoutSequence, r.StderrSequence)
+		}
+	}
+	if !bytes.Equal(out.replies[0].Stdout, binary) {
+		t.Errorf("Expected binary output sent as is, got %v", out.replies[0].Stdout)
+	}
+
+	resumed := &fakeStartJobServer{}
+	if err := relay.Resume(resumed, 0, 1); err != nil {
+		t.Fatalf("failed to resume: %v", err)
+	}
+	if r := resumed.replies[0]; !bytes.Equal(r.Stdout, append(binary, 0x80)) || string(r.Stderr) != "f" || r.StdoutSequence != 2 || r.StderrSequence != 2 {
+		t.Errorf("Unexpected reply after resuming: %v", r)
+	}
+
+	var last int64
+	for _, c := range []struct {
+		sequence int64
+		accepted bool
+	}{{1, true}, {0, true}, {3, true}, {2, false}, {3, false}, {4, true}} {
+		if accepted := acceptOutputSequence(&last, c.sequence); accepted != c.accepted {
+			t.Errorf("Expected output with sequence %v accepted: %v, got %v", c.sequence, c.accepted, accepted)
+		}
+	}
+	if last != 4 {
+		t.Errorf("Expected last sequence 4, got %v", last)
+	}
+}
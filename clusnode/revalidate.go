@@ -0,0 +1,80 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"regexp"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRevalidateConcurrency = 16
+	maxRevalidateConcurrency     = 256
+)
+
+// Validate the nodes matching the pattern immediately regardless of the backoff after failures, e.g. after a network incident
+func (s *headnode_server) RevalidateNodes(ctx context.Context, in *pb.RevalidateNodesRequest) (*pb.RevalidateNodesReply, error) {
+	defer LogPanicBeforeExit()
+	pattern, concurrency := in.GetPattern(), int(in.GetConcurrency())
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pattern %q: %v", pattern, err)
+	}
+	if concurrency < 0 || concurrency > maxRevalidateConcurrency {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid concurrency %v, should be between 1 and %v", concurrency, maxRevalidateConcurrency)
+	} else if concurrency == 0 {
+		concurrency = defaultRevalidateConcurrency
+	}
+	var nodes []string
+	for _, record := range ReportedNodes.Snapshot() {
+		if matched, _ := regexp.MatchString(pattern, record.name); matched {
+			nodes = append(nodes, record.name)
+		}
+	}
+	LogInfo("Revalidating %v nodes with concurrency %v", len(nodes), concurrency)
+	reply := &pb.RevalidateNodesReply{ValidatedNodes: []string{}, FailedNodes: map[string]string{}}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	pool := newDispatchPool(concurrency)
+	for _, node := range nodes {
+		wg.Add(1)
+		pool.Acquire()
+		go func(node string) {
+			defer wg.Done()
+			defer pool.Release()
+			err := revalidateNode(node)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				reply.FailedNodes[node] = err.Error()
+			} else {
+				reply.ValidatedNodes = append(reply.ValidatedNodes, node)
+			}
+		}(node)
+	}
+	wg.Wait()
+	sort.Strings(reply.ValidatedNodes)
+	LogInfo("RevalidateNodes result: validated %v, failed %v", reply.ValidatedNodes, reply.FailedNodes)
+	return reply, nil
+}
+
+// The count of failures before is kept, so that the backoff continues if the revalidation fails,
+// and the clusnode keeping a heartbeat stream open is told to reload its configs
+func revalidateNode(display_name string) error {
+	pushHeartbeatControl(display_name, pb.HeartbeatCommand_RefreshConfigs)
+	resetConnectFailures(parseHost(display_name))
+	number := 0
+	if n, ok := ReportedNodes.ValidateNumber(display_name); ok && n > 0 {
+		number = n
+	}
+	ReportedNodes.SetValidateNumber(display_name, 0)
+	reported_headnode := ""
+	if headnode, ok := reportedTo.Load(display_name); ok {
+		reported_headnode = headnode.(string)
+	}
+	nodename, _ := splitNodeDisplayName(display_name)
+	return validateNode(display_name, nodename, parseHost(display_name), reported_headnode, number)
+}
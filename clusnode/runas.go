@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	RunAsListSeparator = ";"
+)
+
+// Check if the headnode is allowed to request running job as the user on this clusnode
+func checkRunAs(headnode, user string) error {
+	if !isInRunAsList(user, Config_Clusnode_RunAsUsers.GetString(), strings.EqualFold) {
+		return fmt.Errorf("Running job as user %q is not allowed on node %v", user, NodeName)
+	}
+	if !isInRunAsList(headnode, Config_Clusnode_RunAsHeadnodes.GetString(), isSameHost) {
+		return fmt.Errorf("Headnode %v is not allowed to run job as other users on node %v", headnode, NodeName)
+	}
+	return nil
+}
+
+func isSameHost(a, b string) bool {
+	_, _, a_host, err := ParseHostAddress(a)
+	if err != nil {
+		return false
+	}
+	_, _, b_host, err := ParseHostAddress(b)
+	return err == nil && a_host == b_host
+}
+
+func isInRunAsList(item, list string, equal func(a, b string) bool) bool {
+	for _, allowed := range strings.Split(list, RunAsListSeparator) {
+		if allowed = strings.TrimSpace(allowed); len(allowed) > 0 && equal(item, allowed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	jobSchedulesCheckInterval = 10 * time.Second
+	cronSearchYears           = 5
+)
+
+var (
+	// The ranges of minute, hour, day of month, month and day of week in cron expression
+	cronFieldRanges = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+	cronAliases     = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+	runningSchedules sync.Map
+)
+
+type cronSchedule struct {
+	every                time.Duration
+	fields               [5]uint64
+	anyDay, anyDayOfWeek bool
+}
+
+// Parse cron expression with 5 fields (minute, hour, day of month, month and day of week),
+// the aliases like "@daily" or "@every <duration>"
+func parseCron(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(expr, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid duration in cron expression %q: %v", expr, err)
+		}
+		if d < time.Minute {
+			return nil, fmt.Errorf("Invalid duration in cron expression %q, which should be at least 1 minute", expr)
+		}
+		return &cronSchedule{every: d}, nil
+	}
+	if alias, ok := cronAliases[expr]; ok {
+		expr = alias
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFieldRanges) {
+		return nil, fmt.Errorf("Invalid cron expression %q, which should have 5 fields: minute, hour, day of month, month and day of week", expr)
+	}
+	c := &cronSchedule{}
+	for i, field := range fields {
+		bits, err := parseCronField(field, cronFieldRanges[i][0], cronFieldRanges[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid field %q in cron expression %q: %v", field, expr, err)
+		}
+		c.fields[i] = bits
+	}
+	c.anyDay, c.anyDayOfWeek = strings.HasPrefix(fields[2], "*"), strings.HasPrefix(fields[4], "*")
+	return c, nil
+}
+
+// Parse the comma separated list of "*", "n" or "n-m" with optional step "/s"
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		start, end, step := min, max, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			s, err := strconv.Atoi(item[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step %q", item[i+1:])
+			}
+			step, item = s, item[:i]
+		}
+		if item != "*" {
+			bounds := strings.SplitN(item, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value %q", bounds[0])
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value %q", bounds[1])
+				}
+			} else if step > 1 {
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return 0, fmt.Errorf("value out of range [%v, %v]", min, max)
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (c *cronSchedule) match(field int, v int) bool {
+	return c.fields[field]&(1<<uint(v)) != 0
+}
+
+func (c *cronSchedule) matchDay(t time.Time) bool {
+	day, weekday := c.match(2, t.Day()), c.match(4, int(t.Weekday()))
+	// Either day of month or day of week matches if both are restricted, as the behavior of cron
+	if !c.anyDay && !c.anyDayOfWeek {
+		return day || weekday
+	}
+	return day && weekday
+}
+
+// Get the first time after t matching the schedule, or zero time if not found in a few years
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	if c.every > 0 {
+		return t.Add(c.every)
+	}
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+	for t.Before(limit) {
+		if !c.match(3, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		} else if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		} else if !c.match(1, t.Hour()) {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		} else if !c.match(0, t.Minute()) {
+			t = t.Add(time.Minute)
+		} else {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *headnode_server) SaveJobSchedule(ctx context.Context, in *pb.JobSchedule) (*pb.Empty, error) {
+	defer LogPanicBeforeExit()
+	cron, err := validateJobSchedule(in)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	schedule := &pb.JobSchedule{
+		Name:        in.Name,
+		Cron:        in.Cron,
+		Job:         in.Job,
+		Disabled:    in.Disabled,
+		NextRunTime: cron.Next(time.Now()).Unix(),
+	}
+	db_schedulesLock.Lock()
+	defer db_schedulesLock.Unlock()
+	schedules, err := loadJobSchedules()
+	if err != nil {
+		LogError("Failed to load job schedules: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if old, ok := schedules[in.Name]; ok {
+		schedule.LastRunTime, schedule.LastJobId, schedule.LastError = old.LastRunTime, old.LastJobId, old.LastError
+	}
+	schedules[in.Name] = schedule
+	if err := saveJobSchedules(schedules); err != nil {
+		LogError("Failed to save job schedules: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	LogInfo("Job schedule %v is saved: %v", in.Name, schedule)
+	return &pb.Empty{}, nil
+}
+
+func (s *headnode_server) GetJobSchedules(ctx context.Context, in *pb.GetJobSchedulesRequest) (*pb.GetJobSchedulesReply, error) {
+	defer LogPanicBeforeExit()
+	db_schedulesLock.Lock()
+	defer db_schedulesLock.Unlock()
+	schedules, err := loadJobSchedules()
+	if err != nil {
+		LogError("Failed to load job schedules: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	reply := &pb.GetJobSchedulesReply{}
+	if names := in.GetNames(); len(names) > 0 {
+		var not_found []string
+		for _, name := range names {
+			if s, ok := schedules[name]; ok {
+				reply.Schedules = append(reply.Schedules, s)
+			} else {
+				not_found = append(not_found, name)
+			}
+		}
+		if len(not_found) > 0 {
+			return nil, status.Errorf(codes.NotFound, "Job schedules not found: %v", not_found)
+		}
+	} else {
+		for _, s := range schedules {
+			reply.Schedules = append(reply.Schedules, s)
+		}
+		sort.Slice(reply.Schedules, func(i, j int) bool { return reply.Schedules[i].Name < reply.Schedules[j].Name })
+	}
+	return reply, nil
+}
+
+func (s *headnode_server) SetJobSchedulesEnabled(ctx context.Context, in *pb.SetJobSchedulesEnabledRequest) (*pb.SetJobSchedulesEnabledReply, error) {
+	defer LogPanicBeforeExit()
+	db_schedulesLock.Lock()
+	defer db_schedulesLock.Unlock()
+	schedules, err := loadJobSchedules()
+	if err != nil {
+		LogError("Failed to load job schedules: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	reply := &pb.SetJobSchedulesEnabledReply{}
+	now := time.Now()
+	for _, name := range in.GetNames() {
+		s, ok := schedules[name]
+		if !ok {
+			reply.NotFound = append(reply.NotFound, name)
+			continue
+		}
+		if s.Disabled && in.GetEnabled() {
+			// Not to run the missed schedules during disabled
+			if cron, err := parseCron(s.Cron); err == nil {
+				s.NextRunTime = cron.Next(now).Unix()
+			}
+		}
+		s.Disabled = !in.GetEnabled()
+		reply.Updated = append(reply.Updated, name)
+	}
+	if len(reply.Updated) > 0 {
+		if err := saveJobSchedules(schedules); err != nil {
+			LogError("Failed to save job schedules: %v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	LogInfo("SetJobSchedulesEnabled %v result: updated %v, not found %v", in.GetEnabled(), reply.Updated, reply.NotFound)
+	return reply, nil
+}
+
+func (s *headnode_server) DeleteJobSchedules(ctx context.Context, in *pb.DeleteJobSchedulesRequest) (*pb.DeleteJobSchedulesReply, error) {
+	defer LogPanicBeforeExit()
+	db_schedulesLock.Lock()
+	defer db_schedulesLock.Unlock()
+	schedules, err := loadJobSchedules()
+	if err != nil {
+		LogError("Failed to load job schedules: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	reply := &pb.DeleteJobSchedulesReply{}
+	for _, name := range in.GetNames() {
+		if _, ok := schedules[name]; ok {
+			delete(schedules, name)
+			reply.Deleted = append(reply.Deleted, name)
+		} else {
+			reply.NotFound = append(reply.NotFound, name)
+		}
+	}
+	if len(reply.Deleted) > 0 {
+		if err := saveJobSchedules(schedules); err != nil {
+			LogError("Failed to save job schedules: %v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	LogInfo("DeleteJobSchedules result: deleted %v, not found %v", reply.Deleted, reply.NotFound)
+	return reply, nil
+}
+
+func validateJobSchedule(s *pb.JobSchedule) (*cronSchedule, error) {
+	if !templateNamePattern.MatchString(s.GetName()) {
+		return nil, fmt.Errorf("Invalid schedule name %q, which should only contain letters, digits, '_', '-' and '.'", s.GetName())
+	}
+	cron, err := parseCron(s.GetCron())
+	if err != nil {
+		return nil, err
+	}
+	if cron.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("No time matches cron expression %q in %v years", s.GetCron(), cronSearchYears)
+	}
+	job := s.GetJob()
+	if len(strings.TrimSpace(job.GetCommand())) == 0 && len(job.GetNodeCommands()) == 0 {
+		return nil, errors.New("Command is required in job schedule")
+	}
+	if len(job.GetEnvMode()) > 0 && !isValidEnvMode(job.GetEnvMode()) {
+		return nil, fmt.Errorf("Invalid environment mode %q, should be one of: %v", job.GetEnvMode(), strings.Join(envModes, ", "))
+	}
+	return cron, nil
+}
+
+func runJobSchedulesPeriodically() {
+	for {
+		time.Sleep(jobSchedulesCheckInterval)
+		if err := runDueJobSchedules(time.Now()); err != nil {
+			LogError("Failed to run job schedules: %v", err)
+		}
+	}
+}
+
+// Start the jobs of enabled schedules whose next run time is reached, and move their next run time forward
+func runDueJobSchedules(now time.Time) error {
+	db_schedulesLock.Lock()
+	defer db_schedulesLock.Unlock()
+	schedules, err := loadJobSchedules()
+	if err != nil {
+		return err
+	}
+	changed := false
+	for _, s := range schedules {
+		if s.Disabled || s.NextRunTime > now.Unix() {
+			continue
+		}
+		changed = true
+		cron, err := parseCron(s.Cron)
+		var next time.Time
+		if err == nil {
+			next = cron.Next(now)
+		}
+		if next.IsZero() {
+			s.Disabled, s.LastError = true, fmt.Sprintf("Disabled since no time matches cron expression %q", s.Cron)
+			LogError("Job schedule %v: %v", s.Name, s.LastError)
+			continue
+		}
+		// The runs missed when headnode is down are skipped
+		s.NextRunTime = next.Unix()
+		s.LastRunTime = now.Unix()
+		if _, running := runningSchedules.Load(s.Name); running {
+			s.LastError = fmt.Sprintf("Skipped at %v since job %v is still running", now.Format(time.RFC3339), s.LastJobId)
+			LogWarning("Job schedule %v: %v", s.Name, s.LastError)
+			continue
+		}
+		runningSchedules.Store(s.Name, true)
+		go runJobSchedule(s.Name, proto.Clone(s.Job).(*pb.StartClusJobRequest))
+	}
+	if !changed {
+		return nil
+	}
+	return saveJobSchedules(schedules)
+}
+
+// Start the job of schedule and wait until it finishes, the job is recorded in job history as other jobs
+func runJobSchedule(name string, request *pb.StartClusJobRequest) {
+	defer LogPanicBeforeExit()
+	defer runningSchedules.Delete(name)
+	if len(request.Name) > 0 {
+		request.Name = fmt.Sprintf("[Schedule %v] %v", name, request.Name)
+	} else {
+		request.Name = fmt.Sprintf("[Schedule %v]", name)
+	}
+	LogInfo("Starting job of schedule %v", name)
+	out := &scheduledJobStream{started: func(id int32) {
+		LogInfo("Job %v is started by schedule %v", id, name)
+		updateJobScheduleResult(name, id, "")
+	}}
+	if err := (&headnode_server{}).StartClusJob(request, out); err != nil && out.id == 0 {
+		LogError("Failed to start job of schedule %v: %v", name, err)
+		updateJobScheduleResult(name, 0, err.Error())
+	}
+}
+
+func updateJobScheduleResult(name string, id int32, message string) {
+	db_schedulesLock.Lock()
+	defer db_schedulesLock.Unlock()
+	schedules, err := loadJobSchedules()
+	if err != nil {
+		LogError("Failed to load job schedules: %v", err)
+		return
+	}
+	s, ok := schedules[name]
+	if !ok {
+		return
+	}
+	s.LastJobId, s.LastError = id, message
+	if err := saveJobSchedules(schedules); err != nil {
+		LogError("Failed to save job schedules: %v", err)
+	}
+}
+
+// The stream of scheduled job without client, which only records the job id and discards the output
+type scheduledJobStream struct {
+	grpc.ServerStream
+	id      int32
+	started func(int32)
+}
+
+func (s *scheduledJobStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *scheduledJobStream) Send(reply *pb.StartClusJobReply) error {
+	if s.id == 0 && reply.JobId > 0 {
+		s.id = reply.JobId
+		s.started(s.id)
+	}
+	return nil
+}
+
+// The schedules file is created when the first schedule is saved
+func loadJobSchedules() (map[string]*pb.JobSchedule, error) {
+	schedules := map[string]*pb.JobSchedule{}
+	json_string, err := ioutil.ReadFile(db_schedules)
+	if os.IsNotExist(err) {
+		return schedules, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(json_string, &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func saveJobSchedules(schedules map[string]*pb.JobSchedule) error {
+	json_string, err := json.MarshalIndent(schedules, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(db_schedules, json_string, 0644)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_parseCron(t *testing.T) {
+	now := time.Date(2020, 1, 15, 10, 7, 30, 0, time.UTC) // Wednesday
+	cases := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"*/15 * * * *", time.Date(2020, 1, 15, 10, 15, 0, 0, time.UTC)},
+		{"0 2 * * 1-5", time.Date(2020, 1, 16, 2, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2020, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * 0", time.Date(2020, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"30 9 * 3,6 *", time.Date(2020, 3, 1, 9, 30, 0, 0, time.UTC)},
+		{"7 10 15 1 *", time.Date(2021, 1, 15, 10, 7, 0, 0, time.UTC)},
+		{"@every 90m", time.Date(2020, 1, 15, 11, 37, 30, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		cron, err := parseCron(c.expr)
+		if err != nil {
+			t.Errorf("failed to parse %q: %v", c.expr, err)
+		} else if actual := cron.Next(now); !actual.Equal(c.expected) {
+			t.Errorf("\nexpr=%q\nexpected=%v\n  actual=%v", c.expr, c.expected, actual)
+		}
+	}
+
+	for _, expr := range []string{"", "* * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@every 10s", "@every x"} {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf("invalid expression %q is parsed", expr)
+		}
+	}
+	if cron, err := parseCron("0 0 31 2 *"); err != nil || !cron.Next(now).IsZero() {
+		t.Errorf("unexpected next time of expression never matching: %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	pb "clusrun/protobuf"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	outputIndexFile         = ".index"
+	searchMaxLineLength     = 1000
+	searchDefaultMaxMatches = 100
+)
+
+var (
+	outputIndexLock sync.Mutex
+)
+
+// The full-text index of the output of a job, which maps each word to the lines containing it. It is a gzipped file in
+// the output dir of the job rather than a SQLite database, so that clusnode builds without cgo for every platform, and
+// the index is encrypted at rest and cleaned up with the output of the job.
+type outputIndex struct {
+	Files []indexedFile
+	Words map[string][][2]int32 // word -> [file index, line number]
+}
+
+type indexedFile struct {
+	Node   string
+	Stderr bool
+}
+
+func (s *headnode_server) SearchOutput(ctx context.Context, in *pb.SearchOutputRequest) (*pb.SearchOutputReply, error) {
+	defer LogPanicBeforeExit()
+	if !Config_Headnode_IndexOutput.GetBool() {
+		return nil, status.Error(codes.FailedPrecondition, "Output index is not enabled on headnode")
+	}
+	text := in.GetText()
+	words := getIndexWords(text)
+	if len(words) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No words to search")
+	}
+	max_matches := int(in.GetMaxMatches())
+	if max_matches <= 0 {
+		max_matches = searchDefaultMaxMatches
+	}
+	job_ids := in.GetJobIds()
+	if len(job_ids) == 0 {
+		job_ids = map[int32]bool{JobId_All: false}
+	}
+	jobs, err := QueryJobs(&jobFilter{ids: job_ids})
+	if err != nil {
+		return nil, err
+	}
+	LogInfo("Searching %q in output of %v jobs", text, len(jobs))
+
+	// Search from the latest job
+	reply := &pb.SearchOutputReply{}
+	for i := len(jobs) - 1; i >= 0 && !reply.Truncated; i-- {
+		job := jobs[i]
+		if isActiveState(job.State) || job.State == pb.JobState_Created {
+			continue
+		}
+		index, err := loadOutputIndex(job)
+		if err != nil {
+			LogError("Failed to load output index of job %v: %v", job.Id, err)
+			continue
+		}
+		for _, match := range index.Search(job, text, words) {
+			if len(reply.Matches) >= max_matches {
+				reply.Truncated = true
+				break
+			}
+			reply.Matches = append(reply.Matches, match)
+		}
+	}
+	return reply, nil
+}
+
+// Load the output index of a finished job, which is built if missing
+func loadOutputIndex(job *pb.Job) (*outputIndex, error) {
+	outputIndexLock.Lock()
+	defer outputIndexLock.Unlock()
+	file := filepath.Join(getOutputDir(job.Id), outputIndexFile)
+	if b, err := ioutil.ReadFile(file); err == nil {
+		if b, err = decryptAtRest(b); err != nil {
+			return nil, err
+		}
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		index := &outputIndex{}
+		if err := json.NewDecoder(r).Decode(index); err != nil {
+			return nil, err
+		}
+		return index, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	index, err := buildOutputIndex(job)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(getOutputDir(job.Id)); os.IsNotExist(err) {
+		return index, nil
+	}
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	if err := json.NewEncoder(gz).Encode(index); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	data, err := encryptAtRest(b.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		return nil, err
+	}
+	LogInfo("Output index of job %v is built with %v words", job.Id, len(index.Words))
+	return index, nil
+}
+
+// Index the output of a finished job in background
+func IndexJobOutput(id int32) {
+	if !Config_Headnode_IndexOutput.GetBool() || !Config_Headnode_StoreOutput.GetBool() {
+		return
+	}
+	go func() {
+		if job, err := GetJob(id); err != nil {
+			LogError("Failed to get job %v to index output: %v", id, err)
+		} else if _, err := loadOutputIndex(job); err != nil {
+			LogError("Failed to index output of job %v: %v", id, err)
+		}
+	}()
+}
+
+func buildOutputIndex(job *pb.Job) (*outputIndex, error) {
+	index := &outputIndex{Words: map[string][][2]int32{}}
+	for _, node := range job.Nodes {
+		stdout, stderr := GetOutputFile(job, node)
+		for _, f := range []indexedFile{{Node: node}, {Node: node, Stderr: true}} {
+			file := stdout
+			if f.Stderr {
+				file = stderr
+			}
+			lines, err := readOutputLines(file)
+			if os.IsNotExist(err) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			file_index := int32(len(index.Files))
+			index.Files = append(index.Files, f)
+			for i, line := range lines {
+				for _, word := range getIndexWords(line) {
+					postings := index.Words[word]
+					if n := len(postings); n == 0 || postings[n-1] != [2]int32{file_index, int32(i + 1)} {
+						index.Words[word] = append(postings, [2]int32{file_index, int32(i + 1)})
+					}
+				}
+			}
+		}
+	}
+	return index, nil
+}
+
+// Get the lines containing the text, which are found by the lines containing all the words of text in index
+func (index *outputIndex) Search(job *pb.Job, text string, words []string) []*pb.SearchOutputMatch {
+	var candidates [][2]int32
+	for i, word := range words {
+		postings := index.Words[word]
+		if i == 0 {
+			candidates = postings
+			continue
+		}
+		found := make(map[[2]int32]bool, len(postings))
+		for _, p := range postings {
+			found[p] = true
+		}
+		intersection := [][2]int32{}
+		for _, c := range candidates {
+			if found[c] {
+				intersection = append(intersection, c)
+			}
+		}
+		candidates = intersection
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i][0] < candidates[j][0] || candidates[i][0] == candidates[j][0] && candidates[i][1] < candidates[j][1]
+	})
+	matches := []*pb.SearchOutputMatch{}
+	lines := map[int32][]string{}
+	lower_text := strings.ToLower(text)
+	for _, c := range candidates {
+		if _, ok := lines[c[0]]; !ok {
+			f := index.Files[c[0]]
+			stdout, stderr := GetOutputFile(job, f.Node)
+			file := stdout
+			if f.Stderr {
+				file = stderr
+			}
+			l, err := readOutputLines(file)
+			if err != nil {
+				LogWarning("Failed to read output file %v: %v", file, err)
+			}
+			lines[c[0]] = l
+		}
+		if l := lines[c[0]]; int(c[1]) <= len(l) && strings.Contains(strings.ToLower(l[c[1]-1]), lower_text) {
+			line := l[c[1]-1]
+			if len(line) > searchMaxLineLength {
+				line = line[:searchMaxLineLength] + "..."
+			}
+			f := index.Files[c[0]]
+			matches = append(matches, &pb.SearchOutputMatch{JobId: job.Id, Node: f.Node, Stderr: f.Stderr, Line: c[1], Text: line})
+		}
+	}
+	return matches
+}
+
+func readOutputLines(file string) ([]string, error) {
+	f, _, err := openOutputFile(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	lines := []string{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64<<10), 1<<30)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	return lines, scanner.Err()
+}
+
+// Words are the lower case runs of letters and digits
+func getIndexWords(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	unique := make([]string, 0, len(words))
+	seen := map[string]bool{}
+	for _, word := range words {
+		if !seen[word] {
+			seen[word] = true
+			unique = append(unique, word)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_getIndexWords(t *testing.T) {
+	cases := []struct {
+		text     string
+		expected []string
+	}{
+		{"", []string{}},
+		{"Connection refused", []string{"connection", "refused"}},
+		{"dial tcp 10.0.0.1:443: connect: connection refused", []string{"dial", "tcp", "10", "0", "1", "443", "connect", "connection", "refused"}},
+		{"Error: error ERROR", []string{"error"}},
+		{"  --- ", []string{}},
+		{"résumé_2020", []string{"résumé", "2020"}},
+	}
+
+	for _, c := range cases {
+		if words := getIndexWords(c.text); !reflect.DeepEqual(words, c.expected) {
+			t.Errorf("\ntext=%q\nexpected=%q\n  actual=%q", c.text, c.expected, words)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	encryptedConfigPrefix = "encrypted:"
+	maskedConfigValue     = "******"
+	configKeySize         = 32
+)
+
+var (
+	configKey     []byte
+	configKeyLock sync.Mutex
+)
+
+// The key is kept in a file next to the config file and readable by the service account only
+func getConfigKeyFile() string {
+	return NodeConfigFile + ".key"
+}
+
+func loadConfigKey() ([]byte, error) {
+	configKeyLock.Lock()
+	defer configKeyLock.Unlock()
+	if configKey != nil {
+		return configKey, nil
+	}
+	key_file := getConfigKeyFile()
+	if content, err := ioutil.ReadFile(key_file); err == nil {
+		key, err := hex.DecodeString(strings.TrimSpace(string(content)))
+		if err != nil || len(key) != configKeySize {
+			return nil, errors.New("Invalid config key in " + key_file)
+		}
+		configKey = key
+		return configKey, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	LogInfo("Config key file doesn't exist, generate it: %v", key_file)
+	key := make([]byte, configKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(key_file, []byte(hex.EncodeToString(key)), 0600); err != nil {
+		return nil, err
+	}
+	configKey = key
+	return configKey, nil
+}
+
+func newConfigCipher() (cipher.AEAD, error) {
+	key, err := loadConfigKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func EncryptConfigValue(value string) (string, error) {
+	gcm, err := newConfigCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedConfigPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Values without the encrypted prefix are treated as plaintext, they will be encrypted when the config file is saved next time
+func DecryptConfigValue(value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedConfigPrefix) {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(value[len(encryptedConfigPrefix):])
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newConfigCipher()
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("Invalid encrypted value")
+	}
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+func MaskConfigValue(value interface{}) string {
+	if s, ok := value.(string); ok && len(s) == 0 {
+		return ""
+	}
+	return maskedConfigValue
+}
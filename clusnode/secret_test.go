@@ -0,0 +1,64 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_encryptConfigValue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusrun")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	config_file, key := NodeConfigFile, configKey
+	defer func() {
+		NodeConfigFile, configKey = config_file, key
+	}()
+	NodeConfigFile, configKey = filepath.Join(dir, "clusnode.config"), nil
+
+	value := "admin:token"
+	encrypted, err := EncryptConfigValue(value)
+	if err != nil {
+		t.Fatalf("Failed to encrypt config value: %v", err)
+	}
+	if !strings.HasPrefix(encrypted, encryptedConfigPrefix) || strings.Contains(encrypted, value) {
+		t.Errorf("Expected config value encrypted, got %q", encrypted)
+	}
+	if again, _ := EncryptConfigValue(value); again == encrypted {
+		t.Errorf("Expected different nonces of encrypting")
+	}
+	if content, err := ioutil.ReadFile(getConfigKeyFile()); err != nil || len(strings.TrimSpace(string(content))) != configKeySize*2 {
+		t.Errorf("Expected config key generated in file, got %q, %v", content, err)
+	}
+
+	// The key is loaded from the file once the node restarts
+	configKey = nil
+	if plain, err := DecryptConfigValue(encrypted); err != nil || plain != value {
+		t.Errorf("Expected config value %q decrypted, got %q, %v", value, plain, err)
+	}
+	if plain, err := DecryptConfigValue(value); err != nil || plain != value {
+		t.Errorf("Expected plaintext config value %q returned as is, got %q, %v", value, plain, err)
+	}
+	tampered := encrypted[:len(encrypted)-2] + "AA"
+	if tampered == encrypted {
+		tampered = encrypted[:len(encrypted)-2] + "BB"
+	}
+	for _, v := range []string{tampered, encryptedConfigPrefix + "!", encryptedConfigPrefix + "AAAA"} {
+		if _, err := DecryptConfigValue(v); err == nil {
+			t.Errorf("Expected error decrypting %q", v)
+		}
+	}
+
+	// Another key can't decrypt the value
+	configKey = make([]byte, configKeySize)
+	if _, err := DecryptConfigValue(encrypted); err == nil {
+		t.Errorf("Expected error decrypting by another key")
+	}
+	if masked := MaskConfigValue(value); masked != maskedConfigValue || MaskConfigValue("") != "" {
+		t.Errorf("Unexpected masked config value %q", masked)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"clusrun/clusnode/platform"
+	"flag"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	defaultServiceName  = "clusnode"
+	serviceOutputSuffix = ".service.out"
+)
+
+// Register and control clusnode as a systemd unit on Linux or a Windows service, which runs "clusnode start" and restarts automatically
+func service(args []string) {
+	if len(args) == 0 {
+		displayServiceUsage()
+		return
+	}
+	command := strings.ToLower(args[0])
+	fs := flag.NewFlagSet("clusnode service options", flag.ExitOnError)
+	name := fs.String("name", "", "specify the name of the service, default is "+defaultServiceName+" or "+getInstanceServiceName("<instance>")+" for an instance")
+	instance := fs.String("instance", "", "specify the name of the clusnode instance run by the service")
+	var config_file, data_dir, headnodes, host, listen, advertise, log_file *string
+	var no_start *bool
+	if command == "install" {
+		config_file = fs.String("config-file", "", "specify the config file for saving and loading settings of the service")
+		data_dir = fs.String("data-dir", "", "specify the dir for storing jobs, output and other data of the service")
+		headnodes = fs.String("headnodes", "", "specify the host addresses of headnodes for the service to join in")
+		host = fs.String("host", "", "specify the host address of the service")
+		listen = fs.String("listen", "", "specify the addresses separated by comma for the service to listen on")
+		advertise = fs.String("advertise", "", "specify the host address advertised to headnodes by the service, e.g. the external address behind NAT")
+		log_file = fs.String("log-file", "", "specify the file for logging of the service")
+		no_start = fs.Bool("no-start", false, "not to start the service after installed")
+	}
+	_ = fs.Parse(args[1:])
+	if err := validateInstanceName(*instance); err != nil {
+		Fatallnf("Invalid instance name %q: %v", *instance, err)
+	}
+	if len(*name) == 0 {
+		*name = getInstanceServiceName(*instance)
+	}
+
+	var err error
+	switch command {
+	case "install":
+		config := getServiceConfig(*name, *instance, *config_file, *data_dir, *headnodes, *host, *listen, *advertise, *log_file)
+		if err = platform.InstallService(config); err == nil {
+			Printlnf("Service %v is installed: %v %v", *name, config.Executable, strings.Join(config.Args, " "))
+			if len(config.OutputFile) > 0 && !RunOnWindows {
+				Printlnf("Output of the service is redirected to %v", config.OutputFile)
+			}
+			if !*no_start {
+				err = platform.StartService(*name)
+			}
+		}
+	case "uninstall":
+		err = platform.UninstallService(*name)
+	case "start":
+		err = platform.StartService(*name)
+	case "stop":
+		err = platform.StopService(*name)
+	default:
+		displayServiceUsage()
+		return
+	}
+	if err != nil {
+		Fatallnf("Failed to %v service %v: %v", command, *name, err)
+	}
+	Printlnf("Service %v is %v.", *name, map[string]string{"install": "installed", "uninstall": "uninstalled", "start": "started", "stop": "stopped"}[command])
+}
+
+// The service runs "clusnode start" with the options in absolute paths, since its working dir is not the current one
+func getServiceConfig(name, instance, config_file, data_dir, headnodes, host, listen, advertise, log_file string) platform.ServiceConfig {
+	args := []string{"start"}
+	for _, option := range []struct{ name, value string }{
+		{"-instance", instance},
+		{"-config-file", absPath(config_file)},
+		{"-data-dir", absPath(data_dir)},
+		{"-headnodes", headnodes},
+		{"-host", host},
+		{"-listen", listen},
+		{"-advertise", advertise},
+		{"-log-file", absPath(log_file)},
+	} {
+		if len(option.value) > 0 {
+			args = append(args, option.name, option.value)
+		}
+	}
+	description := "clusnode service"
+	if len(instance) > 0 {
+		description += " of instance " + instance
+	}
+	return platform.ServiceConfig{
+		Name:        name,
+		Description: description,
+		Executable:  ExecutablePath,
+		Args:        args,
+		OutputFile:  getInstancePath(ExecutablePath, instance, serviceOutputSuffix),
+	}
+}
+
+func absPath(path string) string {
+	if len(path) == 0 {
+		return path
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+func displayServiceUsage() {
+	Printlnf(`
+Usage:
+	clusnode service <command> [options]
+
+The commands are:
+	install         - register clusnode as a systemd unit on Linux or a Windows service, which is started on boot and restarted after exiting
+	uninstall       - stop and remove the service
+	start           - start the service
+	stop            - stop the service
+
+Usage of install:
+	clusnode service install [options]
+	clusnode service install -h
+
+`)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	sessionTokenPrefix = "session."
+	sessionTtl         = time.Hour
+	sessionMaxAge      = 24 * time.Hour // a session can be refreshed until it is older than this, then the user should log in again
+	sessionStaticUser  = "token"
+)
+
+// The session issued by this headnode, which is signed by the key derived from the config key so that it is valid after restart
+type authSession struct {
+	User    string   `json:"u"`
+	Role    authRole `json:"r"`
+	Cluster string   `json:"c,omitempty"` // the cluster the user is limited to, empty for all clusters
+	Login   int64    `json:"l"`           // the unix time of login, kept in refreshed sessions
+	Expire  int64    `json:"e"`
+}
+
+func getSessionKey() ([]byte, error) {
+	key, err := loadConfigKey()
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte("clusrun session"))
+	return mac.Sum(nil), nil
+}
+
+func newSessionToken(key []byte, session authSession) string {
+	payload, _ := json.Marshal(session)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(encoded))
+	return sessionTokenPrefix + encoded + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func parseSessionToken(key []byte, token string, now time.Time) (*authSession, error) {
+	parts := strings.Split(strings.TrimPrefix(token, sessionTokenPrefix), ".")
+	if !strings.HasPrefix(token, sessionTokenPrefix) || len(parts) != 2 {
+		return nil, errors.New("Invalid session token")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New("Invalid session token")
+	}
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(parts[0]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("Invalid signature of session token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errors.New("Invalid session token")
+	}
+	var session authSession
+	if err := json.Unmarshal(payload, &session); err != nil {
+		return nil, errors.New("Invalid session token")
+	}
+	if now.Unix() >= session.Expire {
+		return nil, errors.New("Session is expired")
+	}
+	return &session, nil
+}
+
+// Get the session in the incoming metadata
+func getAuthSession(ctx context.Context) (*authSession, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(authMetadataKey) {
+		if !strings.HasPrefix(v, authTokenPrefix+sessionTokenPrefix) {
+			continue
+		}
+		key, err := getSessionKey()
+		if err != nil {
+			LogError("Failed to get session key: %v", err)
+			return nil, false
+		}
+		if session, err := parseSessionToken(key, strings.TrimPrefix(v, authTokenPrefix), time.Now()); err == nil {
+			return session, true
+		}
+	}
+	return nil, false
+}
+
+// Return how the clients can log in, which is called without authentication
+func (s *headnode_server) GetLoginOptions(ctx context.Context, in *pb.Empty) (*pb.GetLoginOptionsReply, error) {
+	defer LogPanicBeforeExit()
+	reply := &pb.GetLoginOptionsReply{}
+	for _, p := range authProviders {
+		if !p.Enabled() {
+			continue
+		}
+		switch p.(type) {
+		case *ldapAuthProvider:
+			reply.Password = true
+		case *oidcAuthProvider:
+			reply.OidcIssuer = Config_Headnode_AuthOidcIssuer.GetString()
+			reply.OidcClientId = Config_Headnode_AuthOidcClientId.GetString()
+		}
+	}
+	return reply, nil
+}
+
+// Issue a session token to the authenticated client, a session is refreshed by logging in with it
+func (s *headnode_server) Login(ctx context.Context, in *pb.Empty) (*pb.LoginReply, error) {
+	defer LogPanicBeforeExit()
+	tokens, err := parseAuthTokens(Config_Headnode_AuthTokens.GetString())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Invalid auth tokens")
+	}
+	if len(tokens) == 0 && !isAuthProviderEnabled() {
+		return nil, status.Error(codes.FailedPrecondition, "Authentication is not enabled on the headnode")
+	}
+	now := time.Now()
+	session := authSession{Login: now.Unix()}
+	if previous, ok := getAuthSession(ctx); ok {
+		session = *previous
+	} else {
+		var role authScope
+		if session.User, role, err = authenticate(ctx, tokens); err != nil {
+			return nil, err
+		}
+		session.Role, session.Cluster = role.Role, role.Cluster
+	}
+	if len(session.User) == 0 {
+		session.User = sessionStaticUser
+	}
+	max_expire := time.Unix(session.Login, 0).Add(sessionMaxAge)
+	if !now.Before(max_expire) {
+		return nil, status.Error(codes.Unauthenticated, "Session is too old to refresh, please log in again")
+	}
+	expire := now.Add(sessionTtl)
+	if expire.After(max_expire) {
+		expire = max_expire
+	}
+	session.Expire = expire.Unix()
+	key, err := getSessionKey()
+	if err != nil {
+		LogError("Failed to get session key: %v", err)
+		return nil, status.Error(codes.Internal, "Failed to issue session")
+	}
+	LogInfo("Issue session of user %v with role %v until %v", session.User, authScope{Role: session.Role, Cluster: session.Cluster}, expire)
+	return &pb.LoginReply{
+		Token:     newSessionToken(key, session),
+		User:      session.User,
+		Role:      session.Role.String(),
+		Cluster:   session.Cluster,
+		Expire:    session.Expire,
+		MaxExpire: max_expire.Unix(),
+	}, nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseSessionToken(t *testing.T) {
+	key, other := []byte("session key"), []byte("other key")
+	now := time.Unix(1600000000, 0)
+	session := authSession{User: "alice", Role: authRole_Operator, Login: now.Unix(), Expire: now.Unix() + 60}
+	token := newSessionToken(key, session)
+	if parsed, err := parseSessionToken(key, token, now); err != nil || *parsed != session {
+		t.Errorf("failed to parse session token: %v, %v", parsed, err)
+	}
+	if _, err := parseSessionToken(key, token, now.Add(time.Minute)); err == nil {
+		t.Errorf("expired session token is parsed")
+	}
+	if _, err := parseSessionToken(other, token, now); err == nil {
+		t.Errorf("session token signed by another key is parsed")
+	}
+	parts := strings.Split(token, ".")
+	forged := newSessionToken(other, authSession{User: "alice", Role: authRole_Admin, Login: now.Unix(), Expire: now.Unix() + 60})
+	if _, err := parseSessionToken(key, strings.Join([]string{parts[0], strings.Split(forged, ".")[1], parts[2]}, "."), now); err == nil {
+		t.Errorf("session token with forged payload is parsed")
+	}
+}
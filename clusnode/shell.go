@@ -0,0 +1,298 @@
+package main
+
+import (
+	"clusrun/clusnode/platform"
+	pb "clusrun/protobuf"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	shellOutputBufferSize = 32 * 1024
+	defaultShellTerm      = "xterm"
+)
+
+// Route the interactive shell session between client and the node, the first request specifies the node
+func (s *headnode_server) Shell(in pb.Headnode_ShellServer) error {
+	defer LogPanicBeforeExit()
+	request, err := in.Recv()
+	if err != nil {
+		return err
+	}
+	node, err := getShellNode(request.GetNode())
+	if err != nil {
+		LogWarning("Failed to open shell: %v", err)
+		return status.Error(codes.NotFound, err.Error())
+	}
+	conn, release := GetNodeConnection(parseHost(node), connectionLane_Output)
+	if conn == nil {
+		return status.Errorf(codes.Unavailable, "Failed to connect node %v", node)
+	}
+	defer release()
+	ctx, cancel := context.WithCancel(in.Context())
+	defer cancel()
+	out, err := pb.NewClusnodeClient(conn).Shell(ctx)
+	if err != nil {
+		LogError("Failed to open shell on node %v: %v", node, err)
+		return err
+	}
+	request.Headnode, request.ReportedHeadnode = NodeHost, getReportedHeadnode(node)
+	if err := out.Send(request); err != nil {
+		return err
+	}
+	LogInfo("Shell session on node %v is opened with command %q", node, request.GetCommand())
+	defer LogInfo("Shell session on node %v is closed", node)
+
+	// Forward input to node until the client leaves, which ends the session
+	go func() {
+		defer cancel()
+		for {
+			request, err := in.Recv()
+			if err != nil {
+				return
+			}
+			if err := out.Send(request); err != nil {
+				return
+			}
+		}
+	}()
+
+	// Forward output to client until the shell exits
+	for {
+		reply, err := out.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := in.Send(reply); err != nil {
+			return err
+		}
+	}
+}
+
+// Get the display name of a ready node, including the draining one
+func getShellNode(node string) (string, error) {
+	if len(node) == 0 {
+		return "", errors.New("Node is required to open shell")
+	}
+	nodes, _, skipped_nodes := getValidNodes([]string{node}, "", "", "", nil, false, nil, nil)
+	if len(nodes) > 0 {
+		return nodes[0], nil
+	}
+	for n := range skipped_nodes {
+		return n, nil
+	}
+	return "", fmt.Errorf("Node %v is not ready", node)
+}
+
+func (s *clusnode_server) Shell(in pb.Clusnode_ShellServer) error {
+	defer LogPanicBeforeExit()
+	request, err := in.Recv()
+	if err != nil {
+		return err
+	}
+	headnode := request.GetHeadnode()
+	if err := checkReportedHeadnode(in.Context(), request.GetReportedHeadnode()); err != nil {
+		LogWarning("Reject shell from headnode %v: %v", headnode, status.Convert(err).Message())
+		return err
+	}
+	if !Config_Clusnode_AllowShell.GetBool() {
+		LogWarning("Reject shell from headnode %v since it is not allowed", headnode)
+		return status.Errorf(codes.PermissionDenied, "Interactive shell is not allowed on node %v, which can be enabled by \"clusnode config set -allow-shell true\" on it", NodeName)
+	}
+	cmd := getShellCommand(request.GetCommand())
+	run_as, audit_detail := request.GetRunAs(), strings.Join(cmd.Args, " ")
+	if err := checkCommand(headnode, audit_detail, nil); err != nil {
+		LogWarning("Reject shell from headnode %v: %v", headnode, err)
+		audit(headnode, AuditAction_ShellStart, 0, run_as, audit_detail, "Rejected: "+err.Error())
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if len(run_as) > 0 {
+		if err := checkRunAs(headnode, run_as); err != nil {
+			audit(headnode, AuditAction_ShellStart, 0, run_as, audit_detail, "Rejected: "+err.Error())
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+		if err := platform.SetRunAsUser(cmd, run_as); err != nil {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	term := request.GetTerm()
+	if len(term) == 0 {
+		term = defaultShellTerm
+	}
+	cmd.Env = append(cmd.Env, "TERM="+term)
+
+	// Start the shell with a pseudo terminal if requested and supported, otherwise with pipes
+	session, err := startShellSession(cmd, request.GetPty(), request.GetSize())
+	if err != nil {
+		LogError("Failed to start shell from headnode %v: %v", headnode, err)
+		audit(headnode, AuditAction_ShellStart, 0, run_as, audit_detail, formatAuditResult(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+	LogInfo("Shell session from headnode %v is started with command %q (pty: %v)", headnode, cmd.Args, session.pty != nil)
+	audit(headnode, AuditAction_ShellStart, 0, run_as, audit_detail, "Started")
+	if err := in.Send(&pb.ShellReply{Pty: session.pty != nil}); err != nil {
+		session.Kill()
+		return err
+	}
+
+	// Handle input, resizing and interruption until the session is left
+	go func() {
+		for {
+			request, err := in.Recv()
+			if err != nil {
+				// The shell is killed when client leaves
+				session.Kill()
+				return
+			}
+			session.Handle(request)
+		}
+	}()
+
+	// Send output until the shell exits
+	buffer := make([]byte, shellOutputBufferSize)
+	for {
+		n, err := session.output.Read(buffer)
+		if n > 0 {
+			if err := in.Send(&pb.ShellReply{Output: append([]byte{}, buffer[:n]...)}); err != nil {
+				session.Kill()
+				break
+			}
+		}
+		if err != nil {
+			// The pseudo terminal returns EIO instead of EOF after the shell exits
+			break
+		}
+	}
+	exit_code := session.Wait()
+	LogInfo("Shell session from headnode %v exited with code %v", headnode, exit_code)
+	audit(headnode, AuditAction_ShellEnd, 0, run_as, audit_detail, "Exit code "+strconv.Itoa(exit_code))
+	return in.Send(&pb.ShellReply{Exited: true, ExitCode: int32(exit_code)})
+}
+
+// The default shell is used if no command is specified
+func getShellCommand(command string) *exec.Cmd {
+	if RunOnWindows {
+		if len(command) == 0 {
+			return exec.Command("cmd")
+		}
+		return exec.Command("cmd", "/q", "/c", command)
+	}
+	shell := "/bin/bash"
+	if _, err := os.Stat(shell); err != nil {
+		shell = "/bin/sh"
+	}
+	if len(command) == 0 {
+		return exec.Command(shell, "-l")
+	}
+	return exec.Command(shell, "-c", command)
+}
+
+type shellSession struct {
+	cmd    *exec.Cmd
+	pty    *os.File
+	input  io.WriteCloser
+	output io.ReadCloser
+	lock   sync.Mutex
+}
+
+func startShellSession(cmd *exec.Cmd, pty bool, size *pb.TerminalSize) (*shellSession, error) {
+	if pty {
+		f, err := platform.StartWithPty(cmd, int(size.GetRows()), int(size.GetCols()))
+		if err == nil {
+			return &shellSession{cmd: cmd, pty: f, input: f, output: f}, nil
+		} else if err != platform.ErrPtyNotSupported {
+			return nil, err
+		}
+	}
+	platform.SetSysProcAttr(cmd)
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+	cmd.Stdout, cmd.Stderr = w, w
+	input, err := cmd.StdinPipe()
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		r.Close()
+		return nil, err
+	}
+	return &shellSession{cmd: cmd, input: input, output: r}, nil
+}
+
+func (s *shellSession) Handle(request *pb.ShellRequest) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if size := request.GetSize(); size != nil && s.pty != nil {
+		if err := platform.ResizePty(s.pty, int(size.Rows), int(size.Cols)); err != nil {
+			LogWarning("Failed to resize shell terminal: %v", err)
+		}
+	}
+	if input := request.GetInput(); len(input) > 0 {
+		if _, err := s.input.Write(input); err != nil {
+			LogWarning("Failed to write shell input: %v", err)
+		}
+	}
+	if request.GetInterrupt() {
+		if s.pty != nil {
+			// The terminal sends SIGINT to the foreground processes
+			_, _ = s.pty.Write([]byte{3})
+		} else if err := platform.InterruptProcessGroup(s.cmd.Process.Pid); err != nil {
+			LogWarning("Failed to interrupt shell: %v", err)
+		}
+	}
+	if request.GetCloseInput() {
+		if s.pty != nil {
+			// End of file in terminal
+			_, _ = s.pty.Write([]byte{4})
+		} else {
+			s.input.Close()
+		}
+	}
+}
+
+func (s *shellSession) Kill() {
+	platform.KillProcessGroup(s.cmd.Process.Pid)
+	_ = s.cmd.Process.Kill()
+}
+
+// Wait for the shell to exit and release the terminal or pipes
+func (s *shellSession) Wait() int {
+	done := make(chan error, 1)
+	go func() { done <- s.cmd.Wait() }()
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(ConnectTimeout):
+		// The output is closed while the shell is still running
+		s.Kill()
+		err = <-done
+	}
+	s.output.Close()
+	if err != nil {
+		if exit_err, ok := err.(*exec.ExitError); ok {
+			return exit_err.ExitCode()
+		}
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	headnodeStopping int32 // set once the headnode starts stopping, after which no new job is accepted
+	activeJobStreams int32 // the count of StartClusJob streams dispatching and waiting for their jobs
+)
+
+// Count a job stream unless the headnode is stopping, the returned function should be called once the stream ends
+func enterJobStream() (func(), error) {
+	atomic.AddInt32(&activeJobStreams, 1)
+	if atomic.LoadInt32(&headnodeStopping) != 0 {
+		atomic.AddInt32(&activeJobStreams, -1)
+		return nil, status.Error(codes.Unavailable, "Headnode is stopping, no new job is accepted")
+	}
+	return func() { atomic.AddInt32(&activeJobStreams, -1) }, nil
+}
+
+// Stop accepting new jobs, wait for the active job streams to end within the timeout, then flush the jobs and tell the
+// clusnodes that the headnode is stopping. The jobs still running after the timeout are reconciled when it restarts.
+func drainHeadnode(timeout time.Duration) {
+	atomic.StoreInt32(&headnodeStopping, 1)
+	if active := atomic.LoadInt32(&activeJobStreams); active > 0 {
+		LogInfo("Waiting %v for %v active jobs to end", timeout, active)
+		deadline := time.Now().Add(timeout)
+		for atomic.LoadInt32(&activeJobStreams) > 0 && time.Now().Before(deadline) {
+			time.Sleep(100 * time.Millisecond)
+		}
+		if active = atomic.LoadInt32(&activeJobStreams); active > 0 {
+			LogWarning("%v active jobs do not end in %v", active, timeout)
+		}
+	}
+	if err := flushJobs(); err != nil {
+		LogError("Failed to flush jobs: %v", err)
+	}
+	notifyHeadnodeStopping()
+}
+
+// Push the stopping to the clusnodes over their heartbeat streams, so that they know the headnode is gone on purpose
+func notifyHeadnodeStopping() {
+	broadcastHeartbeatControl(&pb.HeartbeatControl{Command: pb.HeartbeatCommand_HeadnodeStopping})
+}
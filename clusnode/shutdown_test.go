@@ -0,0 +1,46 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_drainHeadnode(t *testing.T) {
+	defer atomic.StoreInt32(&headnodeStopping, 0)
+	end_stream, err := enterJobStream()
+	if err != nil {
+		t.Fatalf("Expected job accepted before stopping, got %v", err)
+	}
+	ended := make(chan time.Time, 1)
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		ended <- time.Now()
+		end_stream()
+	}()
+	drainHeadnode(10 * time.Second)
+	if len(ended) == 0 {
+		t.Errorf("Expected stopping to wait for the active job")
+	}
+	if _, err := enterJobStream(); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable for job when stopping, got %v", err)
+	}
+	if n := atomic.LoadInt32(&activeJobStreams); n != 0 {
+		t.Errorf("Expected no active job stream, got %v", n)
+	}
+
+	// The jobs not ending in time are left
+	atomic.StoreInt32(&headnodeStopping, 0)
+	if _, err = enterJobStream(); err != nil {
+		t.Fatalf("Expected job accepted before stopping, got %v", err)
+	}
+	start := time.Now()
+	drainHeadnode(200 * time.Millisecond)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected stopping not to wait after the timeout, waited %v", elapsed)
+	}
+	atomic.StoreInt32(&activeJobStreams, 0)
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// A simulated clusnode serves on its own port and runs jobs with scripted behavior instead of executing commands
+type simulated_clusnode struct {
+	pb.UnimplementedClusnodeServer
+	name     string
+	host     string
+	behavior *simulationBehavior
+	jobs     sync.Map
+	key      []byte // the key issued by the headnode, which is kept in memory only
+	lock     sync.Mutex
+}
+
+type simulatedJob struct {
+	canceled chan struct{}
+	once     sync.Once
+}
+
+type simulationBehavior struct {
+	minDuration time.Duration
+	maxDuration time.Duration
+	failRate    float64
+	outputLines int
+}
+
+func simulate(args []string) {
+	fs := flag.NewFlagSet("clusnode simulate options", flag.ExitOnError)
+	headnode := fs.String("headnode", localHost, "specify the host address of the headnode to register simulated clusnodes")
+	count := fs.Int("count", 100, "specify the number of simulated clusnodes")
+	port := fs.Int("port", 60000, "specify the first port of simulated clusnodes, each one listens on the next port")
+	prefix := fs.String("prefix", "SIMNODE", "specify the name prefix of simulated clusnodes")
+	interval := fs.Int("heartbeat-interval", 1, "specify the heartbeat interval in seconds of simulated clusnodes")
+	min_duration := fs.Duration("min-duration", 0, "specify the min duration of a job on a simulated clusnode")
+	max_duration := fs.Duration("max-duration", time.Second, "specify the max duration of a job on a simulated clusnode")
+	fail_rate := fs.Float64("fail-rate", 0, "specify the rate (0 to 1) of jobs failing on simulated clusnodes")
+	output_lines := fs.Int("output-lines", 1, "specify the lines of output of a job on a simulated clusnode")
+	_ = fs.Parse(args)
+	if *count <= 0 || *port <= 0 || *port+*count > 65536 || *interval <= 0 {
+		Fatallnf("Invalid count, port or heartbeat interval.")
+	}
+	if *max_duration < *min_duration || *fail_rate < 0 || *fail_rate > 1 || *output_lines < 0 {
+		Fatallnf("Invalid job behavior.")
+	}
+	_, _, headnode_host, err := ParseHostAddress(*headnode)
+	if err != nil {
+		Fatallnf("Failed to parse headnode host address: %v", err)
+	}
+	behavior := &simulationBehavior{
+		minDuration: *min_duration,
+		maxDuration: *max_duration,
+		failRate:    *fail_rate,
+		outputLines: *output_lines,
+	}
+
+	// Start simulated clusnodes
+	nodes := make([]*simulated_clusnode, 0, *count)
+	for i := 0; i < *count; i++ {
+		p := *port + i
+		node := &simulated_clusnode{
+			name:     fmt.Sprintf("%v-%05d", strings.ToUpper(*prefix), i),
+			host:     NodeName + ":" + strconv.Itoa(p),
+			behavior: behavior,
+		}
+		lis, err := net.Listen("tcp", ":"+strconv.Itoa(p))
+		if err != nil {
+			Fatallnf("Failed to listen on port %v: %v", p, err)
+		}
+		server := grpc.NewServer()
+		pb.RegisterClusnodeServer(server, node)
+		go func() {
+			if err := server.Serve(lis); err != nil {
+				LogError("Simulated clusnode %v stopped serving: %v", node.name, err)
+			}
+		}()
+		nodes = append(nodes, node)
+	}
+	Printlnf("Started %v simulated clusnodes on port %v to %v, reporting to headnode %v", *count, *port, *port+*count-1, headnode_host)
+
+	// Send heartbeats of all simulated clusnodes through one connection
+	go func() {
+		for {
+			conn, cancel := ConnectNode(headnode_host)
+			if conn != nil {
+				c := pb.NewHeadnodeClient(conn)
+				for {
+					start := time.Now()
+					failed := 0
+					for _, node := range nodes {
+						ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+						if _, err := c.Heartbeat(ctx, node.heartbeat(headnode_host)); err != nil {
+							failed++
+						}
+						cancel()
+					}
+					if failed == len(nodes) {
+						LogError("All heartbeats of simulated clusnodes failed, reconnect headnode %v", headnode_host)
+						break
+					} else if failed > 0 {
+						LogWarning("%v of %v heartbeats of simulated clusnodes failed", failed, len(nodes))
+					}
+					time.Sleep(time.Duration(*interval)*time.Second - time.Since(start))
+				}
+				conn.Close()
+			}
+			cancel()
+			time.Sleep(time.Duration(*interval) * time.Second)
+		}
+	}()
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt)
+	<-ch
+	Printlnf("Simulation stopped")
+}
+
+// The heartbeat is signed once the simulated clusnode is enrolled, as a real one does
+func (s *simulated_clusnode) heartbeat(headnode string) *pb.HeartbeatRequest {
+	request := &pb.HeartbeatRequest{Nodename: s.name, Host: s.host, Headnode: headnode, Timestamp: time.Now().UnixNano()}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.key != nil {
+		request.Signature = signHeartbeat(s.key, s.name, s.host, headnode, request.Timestamp)
+	}
+	return request
+}
+
+func (s *simulated_clusnode) Validate(ctx context.Context, in *pb.ValidateRequest) (*pb.ValidateReply, error) {
+	if len(in.GetNonce()) != nodeNonceSize {
+		return nil, status.Error(codes.InvalidArgument, "Invalid nonce size")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if key := in.GetKey(); len(key) > 0 {
+		if len(key) != nodeKeySize {
+			return nil, status.Error(codes.InvalidArgument, "Invalid key size")
+		}
+		if secret := Config_ClusterSecret.GetString(); len(secret) > 0 {
+			if !verifyEnrollKey(secret, key, in.GetNonce(), in.GetHeadnode(), in.GetClusnode(), in.GetKeySignature()) {
+				return nil, status.Error(codes.PermissionDenied, "Key is not signed with the cluster secret")
+			}
+		} else if s.key != nil {
+			return nil, status.Error(codes.PermissionDenied, "Already enrolled")
+		}
+		s.key = key
+	}
+	reply := &pb.ValidateReply{Nodename: s.name}
+	if s.key != nil {
+		reply.Signature = signValidateReply(s.key, in.GetNonce(), in.GetHeadnode(), in.GetClusnode(), s.name)
+	}
+	if secret := Config_ClusterSecret.GetString(); len(secret) > 0 {
+		reply.SecretSignature = signValidateSecret(secret, in.GetNonce(), in.GetHeadnode(), in.GetClusnode(), s.name)
+	}
+	return reply, nil
+}
+
+func (s *simulated_clusnode) StartJob(in *pb.StartJobRequest, out pb.Clusnode_StartJobServer) error {
+	job_label := getJobLabel(in.GetHeadnode(), int(in.GetJobId()))
+	job := &simulatedJob{canceled: make(chan struct{})}
+	if _, loaded := s.jobs.LoadOrStore(job_label, job); loaded {
+		return errors.New("Job is already running")
+	}
+	defer s.jobs.Delete(job_label)
+
+	b := s.behavior
+	duration := b.minDuration
+	if b.maxDuration > b.minDuration {
+		duration += time.Duration(rand.Int63n(int64(b.maxDuration - b.minDuration)))
+	}
+	for i := 0; i < b.outputLines; i++ {
+		output := fmt.Sprintf("Simulated output %v of job %v on %v%v", i, in.GetJobId(), s.name, LineEnding)
+		if err := out.Send(&pb.StartJobReply{Stdout: []byte(output), StdoutSequence: int64(i + 1)}); err != nil {
+			return err
+		}
+	}
+	var exit_code int32
+	select {
+	case <-time.After(duration):
+		if rand.Float64() < b.failRate {
+			exit_code = 1
+		}
+	case <-job.canceled:
+		exit_code = -1
+	}
+	return out.Send(&pb.StartJobReply{ExitCode: exit_code})
+}
+
+func (s *simulated_clusnode) CancelJob(ctx context.Context, in *pb.CancelJobRequest) (*pb.Empty, error) {
+	job_label := getJobLabel(in.GetHeadnode(), int(in.GetJobId()))
+	if job, ok := s.jobs.Load(job_label); ok {
+		job := job.(*simulatedJob)
+		job.once.Do(func() { close(job.canceled) })
+	}
+	return &pb.Empty{}, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func Test_simulatedClusnode(t *testing.T) {
+	defer func() {
+		_ = Config_ClusterSecret.Set(Config_ClusterSecret.Value)
+	}()
+	if err := Config_ClusterSecret.Set("simulated-cluster-secret"); err != nil {
+		t.Fatalf("Failed to set cluster secret: %v", err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	node := &simulated_clusnode{name: "SIMNODE-TEST", host: lis.Addr().String(), behavior: &simulationBehavior{}}
+	server := grpc.NewServer()
+	pb.RegisterClusnodeServer(server, node)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+
+	// The first heartbeat starts validating and enrolling the simulated clusnode
+	display_name, err := receiveHeartbeat(node.heartbeat("headnode:50505"))
+	if err != nil {
+		t.Fatalf("Failed to receive heartbeat: %v", err)
+	}
+	defer func() {
+		resetNodeKey(display_name)
+		ReportedNodes.Remove(display_name)
+	}()
+	for start := time.Now(); !ReportedNodes.IsValidated(display_name) && time.Since(start) < 5*time.Second; {
+		time.Sleep(10 * time.Millisecond)
+	}
+	last_report, _ := ReportedNodes.LastReport(display_name)
+	if state := getNodeState(display_name, last_report); state != pb.NodeState_Ready {
+		t.Fatalf("Expected simulated clusnode ready, got %v", state)
+	}
+	if _, ok := NodeKeys.Load(display_name); !ok {
+		t.Errorf("Expected simulated clusnode enrolled")
+	}
+
+	// The heartbeats are signed by the key enrolled
+	heartbeat := node.heartbeat("headnode:50505")
+	if len(heartbeat.Signature) == 0 {
+		t.Errorf("Expected heartbeat signed")
+	}
+	if _, err := receiveHeartbeat(heartbeat); err != nil {
+		t.Errorf("Expected signed heartbeat received, got %v", err)
+	}
+	unsigned := &pb.HeartbeatRequest{Nodename: node.name, Host: node.host, Headnode: "headnode:50505", Timestamp: time.Now().UnixNano()}
+	if _, err := receiveHeartbeat(unsigned); err == nil {
+		t.Errorf("Expected unsigned heartbeat of enrolled node rejected")
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	SweepSeparator     = ";"
+	SweepListSeparator = ","
+
+	sweepValueSeparator      = "="
+	maxSweepFileSuffixLength = 64
+)
+
+type sweepParameter struct {
+	placeholder string
+	values      []string
+}
+
+// Valid format: sweep[;sweep...], in which sweep is placeholder[{[-]begin[-[-]end][:[-]step]}] or placeholder{value,value[,value...]}
+// The combinations of values of all sweeps are distributed to nodes in turn, with the first sweep changing fastest
+func parseSweeps(sweep string, count int) ([]sweepParameter, error) {
+	if len(sweep) == 0 {
+		return nil, nil
+	}
+	params := []sweepParameter{}
+	for _, s := range strings.Split(sweep, SweepSeparator) {
+		var param sweepParameter
+		if index := strings.LastIndex(s, "{"); index > 0 && strings.HasSuffix(s, "}") && strings.Contains(s[index:], SweepListSeparator) {
+			param.placeholder = s[:index]
+			param.values = strings.Split(s[index+1:len(s)-1], SweepListSeparator)
+			for _, v := range param.values {
+				if len(v) == 0 {
+					return nil, fmt.Errorf("Empty value in sweep %q", s)
+				}
+			}
+		} else {
+			placeholder, sequence := parseSweep(s, count)
+			param.placeholder = placeholder
+			for i, n := range sequence {
+				if i > 0 && n == sequence[0] {
+					break
+				}
+				param.values = append(param.values, strconv.Itoa(n))
+			}
+		}
+		if len(param.placeholder) == 0 {
+			return nil, fmt.Errorf("Empty placeholder in sweep %q", sweep)
+		}
+		for _, p := range params {
+			if strings.Contains(p.placeholder, param.placeholder) || strings.Contains(param.placeholder, p.placeholder) {
+				return nil, fmt.Errorf("Sweep placeholders %q and %q overlap", p.placeholder, param.placeholder)
+			}
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// Get the replacer of the placeholders to the values of the ith combination
+func getSweepReplacer(params []sweepParameter, i int) *strings.Replacer {
+	pairs := make([]string, 0, 2*len(params))
+	for _, p := range params {
+		pairs = append(pairs, p.placeholder, p.values[i%len(p.values)])
+		i /= len(p.values)
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// Get the command and arguments of the task on the ith node of a job, in which the sweep placeholders are replaced
+func getTaskCommand(i int, node, command string, arguments []string, node_commands map[string]string, sweeps []sweepParameter) (string, []string) {
+	c := command
+	if len(node_commands) > 0 {
+		c = node_commands[node]
+	}
+	a := make([]string, len(arguments))
+	copy(a, arguments)
+	if len(sweeps) > 0 {
+		r := getSweepReplacer(sweeps, i)
+		c = r.Replace(c)
+		for i, v := range arguments {
+			a[i] = r.Replace(v)
+		}
+	}
+	return c, a
+}
+
+// Format the command with its arguments quoted
+func formatTaskCommand(command string, arguments []string) string {
+	for _, a := range arguments {
+		command += " " + strconv.Quote(a)
+	}
+	return command
+}
+
+// Get the values of the placeholders in the ith combination in format placeholder=value[;placeholder=value...]
+func getSweepValues(params []sweepParameter, i int) string {
+	values := make([]string, 0, len(params))
+	for _, p := range params {
+		values = append(values, p.placeholder+sweepValueSeparator+p.values[i%len(p.values)])
+		i /= len(p.values)
+	}
+	return strings.Join(values, SweepSeparator)
+}
+
+// Get the values of the placeholders in the command of each node
+func getJobSweepValues(params []sweepParameter, nodes []string) map[string]string {
+	if len(params) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(nodes))
+	for i, node := range nodes {
+		values[node] = getSweepValues(params, i)
+	}
+	return values
+}
+
+// Parse the values of placeholders in format placeholder=value[;placeholder=value...] to the sweeps of single value
+func parseSweepValues(values string) ([]sweepParameter, error) {
+	params := []sweepParameter{}
+	for _, s := range strings.Split(values, SweepSeparator) {
+		i := strings.Index(s, sweepValueSeparator)
+		if i <= 0 {
+			return nil, fmt.Errorf("Invalid sweep values %q", values)
+		}
+		params = append(params, sweepParameter{placeholder: s[:i], values: []string{s[i+1:]}})
+	}
+	return params, nil
+}
+
+// The suffix of the output file names of a node with the sweep values, e.g. ".a_3" for "{x}=a;{n}=3", in which the
+// characters not allowed in file names are replaced
+func getSweepFileSuffix(values string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	parts := []string{}
+	for _, s := range strings.Split(values, SweepSeparator) {
+		if i := strings.LastIndex(s, sweepValueSeparator); i >= 0 {
+			s = s[i+1:]
+		}
+		parts = append(parts, s)
+	}
+	suffix := []rune(strings.Join(parts, "_"))
+	for i, c := range suffix {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '-' && c != '.' && c != '_' {
+			suffix[i] = '_'
+		}
+	}
+	if len(suffix) > maxSweepFileSuffixLength {
+		suffix = suffix[:maxSweepFileSuffixLength]
+	}
+	return "." + string(suffix)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_parseSweeps(t *testing.T) {
+	cases := []struct {
+		sweep    string
+		count    int
+		command  string
+		expected []string
+		valid    bool
+	}{
+		{"", 3, "cmd", []string{"cmd", "cmd", "cmd"}, true},
+		{"*{1-2}", 3, "cmd *", []string{"cmd 1", "cmd 2", "cmd 1"}, true},
+		{"*", 3, "cmd *", []string{"cmd 0", "cmd 1", "cmd 2"}, true},
+		{"%p%{a,b,c}", 4, "cmd %p%", []string{"cmd a", "cmd b", "cmd c", "cmd a"}, true},
+		{"x{a,b};y{1-3}", 7, "x-y", []string{"a-1", "b-1", "a-2", "b-2", "a-3", "b-3", "a-1"}, true},
+		{"x{1-2};y{c,d}", 4, "xy", []string{"1c", "2c", "1d", "2d"}, true},
+		{"x{y,x};y{1,2}", 2, "xy", []string{"y1", "x1"}, true},
+		{"x{a,b};x{1,2}", 2, "x", nil, false},
+		{"x{a,,b}", 2, "x", nil, false},
+		{"{a,b}", 2, "{a,b}", []string{"0", "1"}, true},
+		{"x{a,b};", 2, "x", nil, false},
+	}
+
+	for _, c := range cases {
+		sweeps, err := parseSweeps(c.sweep, c.count)
+		if (err == nil) != c.valid {
+			t.Errorf("\nsweep=%v\nexpected valid=%v\n  actual error=%v", c.sweep, c.valid, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		commands := make([]string, c.count)
+		for i := range commands {
+			commands[i] = getSweepReplacer(sweeps, i).Replace(c.command)
+		}
+		if !reflect.DeepEqual(commands, c.expected) {
+			t.Errorf("\nsweep=%v\ncount=%v\nexpected=%v\n  actual=%v", c.sweep, c.count, c.expected, commands)
+		}
+	}
+}
+
+func Test_getTaskCommand(t *testing.T) {
+	sweeps, err := parseSweeps("{x}{a,b}", 2)
+	if err != nil {
+		t.Fatalf("Expected valid sweeps, got %v", err)
+	}
+	arguments := []string{"-v", "{x} y"}
+	c, a := getTaskCommand(1, "node2", "echo {x}", arguments, nil, sweeps)
+	if c != "echo b" || !reflect.DeepEqual(a, []string{"-v", "b y"}) || arguments[1] != "{x} y" {
+		t.Errorf("Expected sweep replaced in command and arguments, got %q %q", c, a)
+	}
+	if command := formatTaskCommand(c, a); command != `echo b "-v" "b y"` {
+		t.Errorf("Expected arguments quoted, got %v", command)
+	}
+	c, _ = getTaskCommand(0, "node1", "", nil, map[string]string{"node1": "ls {x}", "node2": "pwd"}, sweeps)
+	if c != "ls a" {
+		t.Errorf("Expected per-node command with sweep replaced, got %q", c)
+	}
+}
+
+func Test_getJobSweepValues(t *testing.T) {
+	sweeps, err := parseSweeps("{x}{a,b};{n}{1,3}", 4)
+	if err != nil {
+		t.Fatalf("Expected valid sweeps, got %v", err)
+	}
+	values := getJobSweepValues(sweeps, []string{"n1", "n2", "n3", "n4"})
+	expected := map[string]string{"n1": "{x}=a;{n}=1", "n2": "{x}=b;{n}=1", "n3": "{x}=a;{n}=3", "n4": "{x}=b;{n}=3"}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("\nexpected=%v\n  actual=%v", expected, values)
+	}
+	if values := getJobSweepValues(nil, []string{"n1"}); values != nil {
+		t.Errorf("Expected no sweep values without sweep, got %v", values)
+	}
+	job := &pb.Job{Id: 7, SweepValues: values}
+	if stdout, stderr := GetOutputFile(job, "n3"); filepath.Base(stdout) != "n3.a_3.out" || filepath.Base(stderr) != "n3.a_3.err" {
+		t.Errorf("Expected output files named with sweep values, got %v %v", stdout, stderr)
+	}
+	if stdout, _ := GetOutputFile(&pb.Job{Id: 7}, "n3:50505"); filepath.Base(stdout) != "n3.50505.out" {
+		t.Errorf("Expected output files named by node without sweep, got %v", stdout)
+	}
+}
+
+func Test_parseSweepValues(t *testing.T) {
+	sweeps, err := parseSweepValues("{x}=3;{name}=a=b")
+	if err != nil {
+		t.Fatalf("Failed to parse sweep values: %v", err)
+	}
+	c, a := getTaskCommand(0, "node1", "echo {x} {name}", []string{"{x}"}, nil, sweeps)
+	if c != "echo 3 a=b" {
+		t.Errorf("Unexpected command with sweep values: %v", c)
+	}
+	if len(a) != 1 || a[0] != "3" {
+		t.Errorf("Unexpected arguments with sweep values: %v", a)
+	}
+	if _, err := parseSweepValues("{x}"); err == nil {
+		t.Errorf("Expected error to parse sweep values without value")
+	}
+}
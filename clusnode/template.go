@@ -0,0 +1,140 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	templateNamePattern = regexp.MustCompile(`^[\w.-]+$`)
+)
+
+func (s *headnode_server) SaveJobTemplate(ctx context.Context, in *pb.JobTemplate) (*pb.Empty, error) {
+	defer LogPanicBeforeExit()
+	if err := validateJobTemplate(in); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	db_templatesLock.Lock()
+	defer db_templatesLock.Unlock()
+	templates, err := loadJobTemplates()
+	if err != nil {
+		LogError("Failed to load job templates: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	templates[in.Name] = in
+	if err := saveJobTemplates(templates); err != nil {
+		LogError("Failed to save job templates: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	LogInfo("Job template %v is saved: %v", in.Name, in)
+	return &pb.Empty{}, nil
+}
+
+func (s *headnode_server) GetJobTemplates(ctx context.Context, in *pb.GetJobTemplatesRequest) (*pb.GetJobTemplatesReply, error) {
+	defer LogPanicBeforeExit()
+	db_templatesLock.Lock()
+	defer db_templatesLock.Unlock()
+	templates, err := loadJobTemplates()
+	if err != nil {
+		LogError("Failed to load job templates: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	reply := &pb.GetJobTemplatesReply{}
+	if names := in.GetNames(); len(names) > 0 {
+		var not_found []string
+		for _, name := range names {
+			if t, ok := templates[name]; ok {
+				reply.Templates = append(reply.Templates, t)
+			} else {
+				not_found = append(not_found, name)
+			}
+		}
+		if len(not_found) > 0 {
+			return nil, status.Errorf(codes.NotFound, "Job templates not found: %v", not_found)
+		}
+	} else {
+		for _, t := range templates {
+			reply.Templates = append(reply.Templates, t)
+		}
+		sort.Slice(reply.Templates, func(i, j int) bool { return reply.Templates[i].Name < reply.Templates[j].Name })
+	}
+	return reply, nil
+}
+
+func (s *headnode_server) DeleteJobTemplates(ctx context.Context, in *pb.DeleteJobTemplatesRequest) (*pb.DeleteJobTemplatesReply, error) {
+	defer LogPanicBeforeExit()
+	db_templatesLock.Lock()
+	defer db_templatesLock.Unlock()
+	templates, err := loadJobTemplates()
+	if err != nil {
+		LogError("Failed to load job templates: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	reply := &pb.DeleteJobTemplatesReply{}
+	for _, name := range in.GetNames() {
+		if _, ok := templates[name]; ok {
+			delete(templates, name)
+			reply.Deleted = append(reply.Deleted, name)
+		} else {
+			reply.NotFound = append(reply.NotFound, name)
+		}
+	}
+	if len(reply.Deleted) > 0 {
+		if err := saveJobTemplates(templates); err != nil {
+			LogError("Failed to save job templates: %v", err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	LogInfo("DeleteJobTemplates result: deleted %v, not found %v", reply.Deleted, reply.NotFound)
+	return reply, nil
+}
+
+func validateJobTemplate(t *pb.JobTemplate) error {
+	if !templateNamePattern.MatchString(t.GetName()) {
+		return fmt.Errorf("Invalid template name %q, which should only contain letters, digits, '_', '-' and '.'", t.GetName())
+	}
+	if len(strings.TrimSpace(t.GetCommand())) == 0 {
+		return errors.New("Command is required in job template")
+	}
+	if _, err := regexp.Compile(t.GetPattern()); err != nil {
+		return fmt.Errorf("Invalid pattern %q: %v", t.GetPattern(), err)
+	}
+	if len(t.GetEnvMode()) > 0 && !isValidEnvMode(t.GetEnvMode()) {
+		return fmt.Errorf("Invalid environment mode %q, should be one of: %v", t.GetEnvMode(), strings.Join(envModes, ", "))
+	}
+	return nil
+}
+
+// The templates file is created when the first template is saved
+func loadJobTemplates() (map[string]*pb.JobTemplate, error) {
+	templates := map[string]*pb.JobTemplate{}
+	json_string, err := ioutil.ReadFile(db_templates)
+	if os.IsNotExist(err) {
+		return templates, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(json_string, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func saveJobTemplates(templates map[string]*pb.JobTemplate) error {
+	json_string, err := json.MarshalIndent(templates, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(db_templates, json_string, 0644)
+}
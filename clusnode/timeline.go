@@ -0,0 +1,173 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	jobTimelines sync.Map // job id -> *taskTimeline of running job
+)
+
+// The spans of tasks of a running job
+type taskTimeline struct {
+	spans []*pb.TaskSpan
+	lock  sync.Mutex
+}
+
+// An event in Chrome trace format
+type traceEvent struct {
+	Name     string                 `json:"name"`
+	Category string                 `json:"cat,omitempty"`
+	Phase    string                 `json:"ph"`
+	Time     int64                  `json:"ts"`
+	Duration int64                  `json:"dur"`
+	Pid      int32                  `json:"pid"`
+	Tid      int                    `json:"tid"`
+	Args     map[string]interface{} `json:"args,omitempty"`
+}
+
+func (s *headnode_server) ExportTimeline(ctx context.Context, in *pb.ExportTimelineRequest) (*pb.ExportTimelineReply, error) {
+	defer LogPanicBeforeExit()
+	id := in.GetJobId()
+	job, err := GetJob(id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	fillJobTasks(job)
+	if len(job.Tasks) == 0 {
+		return nil, status.Errorf(codes.NotFound, "No timeline recorded for job %v", id)
+	}
+	trace, err := buildChromeTrace(job, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ExportTimelineReply{Trace: string(trace)}, nil
+}
+
+// The tasks of a running job are got from its timeline, which is saved in the job once it ends
+func fillJobTasks(job *pb.Job) {
+	if timeline, ok := jobTimelines.Load(job.Id); ok {
+		job.Tasks = timeline.(*taskTimeline).Snapshot()
+	}
+}
+
+// Get the last task on the node, which is dispatched to the node once unless the job is rerun
+func getNodeTask(tasks []*pb.TaskSpan, node string) *pb.TaskSpan {
+	for i := len(tasks) - 1; i >= 0; i-- {
+		if tasks[i].Node == node {
+			return tasks[i]
+		}
+	}
+	return nil
+}
+
+func newTaskTimeline(id int32) *taskTimeline {
+	timeline := &taskTimeline{}
+	jobTimelines.Store(id, timeline)
+	return timeline
+}
+
+// Add a span of the task dispatched to the node, and return the function to update it with the timeline locked
+func addTaskSpan(id int32, node string) (*pb.TaskSpan, func(func())) {
+	span := &pb.TaskSpan{Node: node, DispatchTime: time.Now().UnixNano()}
+	timeline, ok := jobTimelines.Load(id)
+	if !ok {
+		return span, func(update func()) { update() }
+	}
+	t := timeline.(*taskTimeline)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.spans = append(t.spans, span)
+	return span, func(update func()) {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		update()
+	}
+}
+
+func (t *taskTimeline) Snapshot() []*pb.TaskSpan {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	spans := make([]*pb.TaskSpan, len(t.spans))
+	for i, span := range t.spans {
+		spans[i] = proto.Clone(span).(*pb.TaskSpan)
+	}
+	return spans
+}
+
+// Build the trace with a thread for each node, in which the dispatching and running of a task are two events.
+// The time is in microseconds since the first dispatching, and the unfinished tasks end at now.
+func buildChromeTrace(job *pb.Job, now time.Time) ([]byte, error) {
+	spans := make([]*pb.TaskSpan, len(job.Tasks))
+	copy(spans, job.Tasks)
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].DispatchTime < spans[j].DispatchTime })
+	name := fmt.Sprintf("Job %v", job.Id)
+	if len(job.Name) > 0 {
+		name += ": " + job.Name
+	}
+	events := []traceEvent{{Name: "process_name", Phase: "M", Pid: job.Id, Args: map[string]interface{}{"name": name}}}
+	if len(spans) == 0 {
+		return json.Marshal(map[string]interface{}{"traceEvents": events, "displayTimeUnit": "ms"})
+	}
+	base := spans[0].DispatchTime
+	micro := func(t int64) int64 {
+		if t == 0 {
+			t = now.UnixNano()
+		}
+		return (t - base) / int64(time.Microsecond)
+	}
+	tids := map[string]int{}
+	for _, span := range spans {
+		tid, ok := tids[span.Node]
+		if !ok {
+			tid = len(tids) + 1
+			tids[span.Node] = tid
+			events = append(events, traceEvent{Name: "thread_name", Phase: "M", Pid: job.Id, Tid: tid, Args: map[string]interface{}{"name": span.Node}})
+		}
+		dispatched := span.StartTime
+		if dispatched == 0 {
+			dispatched = span.EndTime
+		}
+		events = append(events, traceEvent{
+			Name:     "dispatch",
+			Category: "dispatch",
+			Phase:    "X",
+			Time:     micro(span.DispatchTime),
+			Duration: micro(dispatched) - micro(span.DispatchTime),
+			Pid:      job.Id,
+			Tid:      tid,
+		})
+		if span.StartTime == 0 {
+			continue
+		}
+		state := "running"
+		if span.Lost {
+			state = "lost"
+		} else if span.EndTime > 0 && span.ExitCode == 0 {
+			state = "finished"
+		} else if span.EndTime > 0 {
+			state = fmt.Sprintf("failed (exit code %v)", span.ExitCode)
+		}
+		events = append(events, traceEvent{
+			Name:     state,
+			Category: "task",
+			Phase:    "X",
+			Time:     micro(span.StartTime),
+			Duration: micro(span.EndTime) - micro(span.StartTime),
+			Pid:      job.Id,
+			Tid:      tid,
+			Args:     map[string]interface{}{"node": span.Node, "exit_code": span.ExitCode},
+		})
+	}
+	return json.Marshal(map[string]interface{}{"traceEvents": events, "displayTimeUnit": "ms"})
+}
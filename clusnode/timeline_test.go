@@ -0,0 +1,57 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"testing"
+	"time"
+)
+
+func Test_buildChromeTrace(t *testing.T) {
+	base := time.Unix(1600000000, 0)
+	at := func(ms int) int64 { return base.Add(time.Duration(ms) * time.Millisecond).UnixNano() }
+	job := &pb.Job{Id: 3, Tasks: []*pb.TaskSpan{
+		{Node: "B", DispatchTime: at(1), StartTime: at(5), EndTime: at(20), ExitCode: 2},
+		{Node: "A", DispatchTime: at(0), StartTime: at(2), EndTime: at(10)},
+		{Node: "C", DispatchTime: at(2), StartTime: at(3), EndTime: at(4), Lost: true},
+		{Node: "D", DispatchTime: at(3), StartTime: at(6)},
+	}}
+	expected := `{"displayTimeUnit":"ms","traceEvents":[` +
+		`{"name":"process_name","ph":"M","ts":0,"dur":0,"pid":3,"tid":0,"args":{"name":"Job 3"}},` +
+		`{"name":"thread_name","ph":"M","ts":0,"dur":0,"pid":3,"tid":1,"args":{"name":"A"}},` +
+		`{"name":"dispatch","cat":"dispatch","ph":"X","ts":0,"dur":2000,"pid":3,"tid":1},` +
+		`{"name":"finished","cat":"task","ph":"X","ts":2000,"dur":8000,"pid":3,"tid":1,"args":{"exit_code":0,"node":"A"}},` +
+		`{"name":"thread_name","ph":"M","ts":0,"dur":0,"pid":3,"tid":2,"args":{"name":"B"}},` +
+		`{"name":"dispatch","cat":"dispatch","ph":"X","ts":1000,"dur":4000,"pid":3,"tid":2},` +
+		`{"name":"failed (exit code 2)","cat":"task","ph":"X","ts":5000,"dur":15000,"pid":3,"tid":2,"args":{"exit_code":2,"node":"B"}},` +
+		`{"name":"thread_name","ph":"M","ts":0,"dur":0,"pid":3,"tid":3,"args":{"name":"C"}},` +
+		`{"name":"dispatch","cat":"dispatch","ph":"X","ts":2000,"dur":1000,"pid":3,"tid":3},` +
+		`{"name":"lost","cat":"task","ph":"X","ts":3000,"dur":1000,"pid":3,"tid":3,"args":{"exit_code":0,"node":"C"}},` +
+		`{"name":"thread_name","ph":"M","ts":0,"dur":0,"pid":3,"tid":4,"args":{"name":"D"}},` +
+		`{"name":"dispatch","cat":"dispatch","ph":"X","ts":3000,"dur":3000,"pid":3,"tid":4},` +
+		`{"name":"running","cat":"task","ph":"X","ts":6000,"dur":24000,"pid":3,"tid":4,"args":{"exit_code":0,"node":"D"}}]}`
+	trace, err := buildChromeTrace(job, time.Unix(0, at(30)))
+	if err != nil || string(trace) != expected {
+		t.Errorf("\nexpected=%v\n  actual=%v, error=%v", expected, string(trace), err)
+	}
+}
+
+func Test_fillJobTasks(t *testing.T) {
+	job := &pb.Job{Id: 1, Tasks: []*pb.TaskSpan{{Node: "n1", ExitCode: 1}}}
+	fillJobTasks(job)
+	if len(job.Tasks) != 1 {
+		t.Errorf("Expected saved tasks kept for ended job, got %v", job.Tasks)
+	}
+	timeline := newTaskTimeline(1)
+	defer jobTimelines.Delete(int32(1))
+	timeline.spans = []*pb.TaskSpan{{Node: "n1", ExitCode: 1}, {Node: "n2"}, {Node: "n1", StartTime: 100}}
+	fillJobTasks(job)
+	if len(job.Tasks) != 3 {
+		t.Errorf("Expected tasks got from timeline of running job, got %v", job.Tasks)
+	}
+	if task := getNodeTask(job.Tasks, "n1"); task == nil || task.StartTime != 100 {
+		t.Errorf("Expected the last task on node, got %v", task)
+	}
+	if task := getNodeTask(job.Tasks, "n3"); task != nil {
+		t.Errorf("Expected no task on node, got %v", task)
+	}
+}
@@ -0,0 +1,422 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	TraceEndpointNone = "none"
+
+	traceparentMetadataKey = "traceparent"
+	traceExportPath        = "/v1/traces"
+	traceExportInterval    = 5 * time.Second
+	traceExportTimeout     = 10 * time.Second
+	traceExportBatchSize   = 512
+	traceQueueSize         = 4096
+	traceScopeName         = "clusrun"
+
+	// The span kinds and status codes defined by OTLP
+	SpanKind_Internal = 1
+	SpanKind_Server   = 2
+	SpanKind_Client   = 3
+	spanStatus_Error  = 2
+)
+
+var (
+	traceEndpointValidator = func(value interface{}) error {
+		v, ok := value.(string)
+		if !ok {
+			return errors.New("Invalid type")
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		if u, err := url.Parse(v); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return errors.New("Value should be an http or https URL")
+		}
+		return nil
+	}
+
+	// The trace of each running job, in which the spans of dispatching, executing, streaming output and cancelling on nodes are created
+	jobTraces sync.Map
+
+	traceQueue      = make(chan *Span, traceQueueSize)
+	traceExportOnce sync.Once
+	traceFlush      = make(chan chan struct{})
+)
+
+type traceContextKey struct{}
+
+type spanContext struct {
+	traceId [16]byte
+	spanId  [8]byte
+	sampled bool
+}
+
+// A span of the work on headnode or clusnode, which is exported to the OTLP endpoint when ended if sampled,
+// the methods of nil span do nothing so that the callers don't need to check whether tracing is enabled
+type Span struct {
+	context    spanContext
+	parentId   [8]byte
+	name       string
+	kind       int
+	start, end time.Time
+	lock       sync.Mutex
+	attributes map[string]interface{}
+	errMessage string
+	ended      bool
+}
+
+func isTracingEnabled() bool {
+	return len(Config_TraceOtlpEndpoint.GetString()) > 0
+}
+
+// Start a span as child of the span in context, or of the remote span in incoming metadata for server spans,
+// otherwise a new trace is started and sampled by the configured percent
+func StartSpan(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	if !isTracingEnabled() {
+		return ctx, nil
+	}
+	span := &Span{name: name, kind: kind, start: time.Now(), attributes: map[string]interface{}{}}
+	if parent, ok := spanContextFromContext(ctx, kind == SpanKind_Server); ok {
+		span.context.traceId, span.parentId, span.context.sampled = parent.traceId, parent.spanId, parent.sampled
+	} else {
+		_, _ = rand.Read(span.context.traceId[:])
+		span.context.sampled = isTraceSampled(Config_TraceSamplePercent.GetInt())
+	}
+	_, _ = rand.Read(span.context.spanId[:])
+	return context.WithValue(ctx, traceContextKey{}, span), span
+}
+
+// Start a span only if the context is in a trace, for the work traced as part of the work on other nodes
+func startChildSpan(ctx context.Context, name string) (context.Context, *Span) {
+	if _, ok := spanContextFromContext(ctx, false); !ok {
+		return ctx, nil
+	}
+	return StartSpan(ctx, name, SpanKind_Internal)
+}
+
+func spanContextFromContext(ctx context.Context, remote bool) (spanContext, bool) {
+	if span, ok := ctx.Value(traceContextKey{}).(*Span); ok && span != nil {
+		return span.context, true
+	}
+	if remote {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(traceparentMetadataKey); len(values) > 0 {
+			return parseTraceparent(values[0])
+		}
+	}
+	return spanContext{}, false
+}
+
+func isTraceSampled(percent int) bool {
+	if percent >= 100 {
+		return true
+	} else if percent <= 0 {
+		return false
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(100))
+	return err == nil && int(n.Int64()) < percent
+}
+
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.attributes[key] = value
+}
+
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.errMessage = status.Convert(err).Message()
+}
+
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	ended := s.ended
+	s.ended, s.end = true, time.Now()
+	s.lock.Unlock()
+	if ended || !s.context.sampled {
+		return
+	}
+	traceExportOnce.Do(func() { go exportTraces() })
+	select {
+	case traceQueue <- s:
+	default:
+		LogWarning("Drop span %v since the trace queue is full", s.name)
+	}
+}
+
+// The trace context propagated in gRPC metadata in the format of W3C traceparent header
+func formatTraceparent(c spanContext) string {
+	flags := "00"
+	if c.sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%v-%v-%v", hex.EncodeToString(c.traceId[:]), hex.EncodeToString(c.spanId[:]), flags)
+}
+
+func parseTraceparent(value string) (c spanContext, ok bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return c, false
+	}
+	if _, err := hex.Decode(c.traceId[:], []byte(parts[1])); err != nil || c.traceId == [16]byte{} {
+		return c, false
+	}
+	if _, err := hex.Decode(c.spanId[:], []byte(parts[2])); err != nil || c.spanId == [8]byte{} {
+		return c, false
+	}
+	flags, err := strconv.ParseUint(parts[3], 16, 8)
+	if err != nil {
+		return c, false
+	}
+	c.sampled = flags&1 == 1
+	return c, true
+}
+
+func startJobTrace(id int32, correlation_id string, nodes int) *Span {
+	ctx, span := StartSpan(context.Background(), "job", SpanKind_Internal)
+	if span != nil {
+		span.SetAttribute("job.id", id)
+		span.SetAttribute("job.correlation_id", correlation_id)
+		span.SetAttribute("job.nodes", nodes)
+		jobTraces.Store(id, ctx)
+	}
+	return span
+}
+
+func endJobTrace(id int32, span *Span) {
+	jobTraces.Delete(id)
+	span.End()
+}
+
+// Get the context to start spans in the trace of a job, or a new trace if the job is not traced
+func getJobTraceContext(id int32) context.Context {
+	if ctx, ok := jobTraces.Load(id); ok {
+		return ctx.(context.Context)
+	}
+	return context.Background()
+}
+
+// Record the server spans of RPCs in traces started by other nodes, the RPCs not traced like heartbeats are not recorded
+func traceUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !hasRemoteTrace(ctx) {
+		return handler(ctx, req)
+	}
+	ctx, span := StartSpan(ctx, info.FullMethod, SpanKind_Server)
+	defer span.End()
+	reply, err := handler(ctx, req)
+	span.SetError(err)
+	return reply, err
+}
+
+type traceServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *traceServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func traceStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !hasRemoteTrace(ss.Context()) {
+		return handler(srv, ss)
+	}
+	ctx, span := StartSpan(ss.Context(), info.FullMethod, SpanKind_Server)
+	defer span.End()
+	err := handler(srv, &traceServerStream{ss, ctx})
+	span.SetError(err)
+	return err
+}
+
+func hasRemoteTrace(ctx context.Context) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return len(md.Get(traceparentMetadataKey)) > 0
+}
+
+// Propagate the span in context to the node called
+func traceUnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(withTraceparent(ctx), method, req, reply, cc, opts...)
+}
+
+func traceStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(withTraceparent(ctx), desc, cc, method, opts...)
+}
+
+func withTraceparent(ctx context.Context) context.Context {
+	if c, ok := spanContextFromContext(ctx, false); ok {
+		return metadata.AppendToOutgoingContext(ctx, traceparentMetadataKey, formatTraceparent(c))
+	}
+	return ctx
+}
+
+// Export the ended spans in batches periodically, the spans are dropped if the endpoint is unavailable
+func exportTraces() {
+	defer LogPanicBeforeExit()
+	var batch []*Span
+	ticker := time.NewTicker(traceExportInterval)
+	defer ticker.Stop()
+	export := func() {
+		if len(batch) > 0 {
+			if err := postSpans(Config_TraceOtlpEndpoint.GetString(), batch); err != nil {
+				LogWarning("Failed to export %v spans: %v", len(batch), err)
+			}
+			batch = nil
+		}
+	}
+	for {
+		select {
+		case span := <-traceQueue:
+			if batch = append(batch, span); len(batch) >= traceExportBatchSize {
+				export()
+			}
+		case <-ticker.C:
+			export()
+		case done := <-traceFlush:
+			for len(traceQueue) > 0 {
+				batch = append(batch, <-traceQueue)
+			}
+			export()
+			close(done)
+		}
+	}
+}
+
+// Export the spans ended before the service stops
+func FlushTraces() {
+	done := make(chan struct{})
+	select {
+	case traceFlush <- done:
+		select {
+		case <-done:
+		case <-time.After(traceExportTimeout):
+		}
+	default:
+		// The exporter is not started since no span is ended
+	}
+}
+
+func postSpans(endpoint string, spans []*Span) error {
+	if len(endpoint) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(encodeOtlpSpans(NodeHost, spans))
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: traceExportTimeout}
+	resp, err := client.Post(strings.TrimSuffix(endpoint, "/")+traceExportPath, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		content, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%v %v", resp.Status, strings.TrimSpace(string(content)))
+	}
+	return nil
+}
+
+// Encode the spans in the JSON format of OTLP/HTTP ExportTraceServiceRequest
+func encodeOtlpSpans(host string, spans []*Span) map[string]interface{} {
+	encoded := make([]map[string]interface{}, 0, len(spans))
+	for _, s := range spans {
+		s.lock.Lock()
+		span := map[string]interface{}{
+			"traceId":           hex.EncodeToString(s.context.traceId[:]),
+			"spanId":            hex.EncodeToString(s.context.spanId[:]),
+			"name":              s.name,
+			"kind":              s.kind,
+			"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+			"attributes":        encodeOtlpAttributes(s.attributes),
+		}
+		if s.parentId != [8]byte{} {
+			span["parentSpanId"] = hex.EncodeToString(s.parentId[:])
+		}
+		if len(s.errMessage) > 0 {
+			span["status"] = map[string]interface{}{"code": spanStatus_Error, "message": s.errMessage}
+		}
+		s.lock.Unlock()
+		encoded = append(encoded, span)
+	}
+	resource := encodeOtlpAttributes(map[string]interface{}{
+		"service.name":    "clusrun",
+		"service.version": ClusnodeVersion,
+		"host.name":       NodeName,
+		"clusrun.node":    host,
+	})
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{"attributes": resource},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": traceScopeName},
+						"spans": encoded,
+					},
+				},
+			},
+		},
+	}
+}
+
+func encodeOtlpAttributes(attributes map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	encoded := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		var value map[string]interface{}
+		switch v := attributes[k].(type) {
+		case string:
+			value = map[string]interface{}{"stringValue": v}
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		case int:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(int64(v), 10)}
+		case int32:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(int64(v), 10)}
+		case int64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]interface{}{"doubleValue": v}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		encoded = append(encoded, map[string]interface{}{"key": k, "value": value})
+	}
+	return encoded
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_parseTraceparent(t *testing.T) {
+	c := spanContext{sampled: true}
+	for i := range c.traceId {
+		c.traceId[i] = byte(i + 1)
+	}
+	for i := range c.spanId {
+		c.spanId[i] = byte(i + 1)
+	}
+	value := formatTraceparent(c)
+	if expected := "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01"; value != expected {
+		t.Errorf("\nexpected=%v\n  actual=%v", expected, value)
+	}
+	if parsed, ok := parseTraceparent(value); !ok || parsed != c {
+		t.Errorf("traceparent %v is parsed to %v, %v", value, parsed, ok)
+	}
+	if parsed, ok := parseTraceparent("00-0102030405060708090a0b0c0d0e0f10-0102030405060708-00"); !ok || parsed.sampled {
+		t.Errorf("unexpected sampled trace: %v, %v", parsed, ok)
+	}
+	for _, invalid := range []string{
+		"",
+		"00-0102030405060708090a0b0c0d0e0f10-0102030405060708",
+		"ff-0102030405060708090a0b0c0d0e0f10-0102030405060708-01",
+		"00-00000000000000000000000000000000-0102030405060708-01",
+		"00-0102030405060708090a0b0c0d0e0f10-0000000000000000-01",
+		"00-0102030405060708090a0b0c0d0e0f1g-0102030405060708-01",
+		"00-0102030405060708090a0b0c0d0e0f10-0102030405060708-1",
+	} {
+		if parsed, ok := parseTraceparent(invalid); ok {
+			t.Errorf("invalid traceparent %q is parsed to %v", invalid, parsed)
+		}
+	}
+}
+
+func Test_encodeOtlpSpans(t *testing.T) {
+	parent := &Span{name: "job", kind: SpanKind_Internal, start: time.Unix(1, 0), end: time.Unix(3, 0), attributes: map[string]interface{}{"job.id": int32(7)}}
+	parent.context.traceId[0], parent.context.spanId[0] = 1, 1
+	child := &Span{name: "job.node", kind: SpanKind_Client, start: time.Unix(2, 0), end: time.Unix(3, 0), attributes: map[string]interface{}{"node": "N1", "node.lost": true}}
+	child.context.traceId, child.context.spanId[0], child.parentId = parent.context.traceId, 2, parent.context.spanId
+	child.SetError(errors.New("Failed to connect node"))
+	b, err := json.Marshal(encodeOtlpSpans("N0:50505", []*Span{parent, child}))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	var request struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceId           string `json:"traceId"`
+					SpanId            string `json:"spanId"`
+					ParentSpanId      string `json:"parentSpanId"`
+					Name              string `json:"name"`
+					Kind              int    `json:"kind"`
+					StartTimeUnixNano string `json:"startTimeUnixNano"`
+					Attributes        []struct {
+						Key   string                 `json:"key"`
+						Value map[string]interface{} `json:"value"`
+					} `json:"attributes"`
+					Status *struct {
+						Code    int    `json:"code"`
+						Message string `json:"message"`
+					} `json:"status"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.Unmarshal(b, &request); err != nil || len(request.ResourceSpans) != 1 || len(request.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("unexpected request %s: %v", b, err)
+	}
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("unexpected spans: %s", b)
+	}
+	if spans[0].ParentSpanId != "" || spans[0].Status != nil || spans[0].StartTimeUnixNano != "1000000000" || spans[0].Attributes[0].Value["intValue"] != "7" {
+		t.Errorf("unexpected parent span: %+v", spans[0])
+	}
+	if spans[1].TraceId != spans[0].TraceId || spans[1].ParentSpanId != spans[0].SpanId || spans[1].Kind != SpanKind_Client {
+		t.Errorf("unexpected child span: %+v", spans[1])
+	}
+	if spans[1].Status == nil || spans[1].Status.Code != spanStatus_Error || spans[1].Status.Message != "Failed to connect node" {
+		t.Errorf("unexpected status of child span: %+v", spans[1].Status)
+	}
+	if a := spans[1].Attributes; len(a) != 2 || a[0].Key != "node" || a[0].Value["stringValue"] != "N1" || a[1].Value["boolValue"] != true {
+		t.Errorf("unexpected attributes of child span: %+v", a)
+	}
+}
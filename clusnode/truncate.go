@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+)
+
+// Truncate the middle of a stream of output beyond the limit, the head is passed through while the tail is kept until
+// the output ends, so that a runaway command can not fill the disk of headnode
+type outputTruncator struct {
+	name    string // stdout or stderr
+	limit   int64  // 0 for unlimited
+	passed  int64
+	tail    []byte
+	dropped int64
+}
+
+func newOutputTruncator(name string, limit int64) *outputTruncator {
+	return &outputTruncator{name: name, limit: limit}
+}
+
+// Return the part of output to send now
+func (t *outputTruncator) Write(output []byte) []byte {
+	if t.limit <= 0 || len(output) == 0 {
+		return output
+	}
+	tail_size := t.limit / 2
+	var pass []byte
+	if head_size := t.limit - tail_size; t.passed < head_size {
+		n := head_size - t.passed
+		if n > int64(len(output)) {
+			n = int64(len(output))
+		}
+		pass, output = output[:n], output[n:]
+		t.passed += n
+	}
+	if len(output) > 0 {
+		t.tail = append(t.tail, output...)
+		if drop := int64(len(t.tail)) - tail_size; drop > 0 {
+			t.tail, t.dropped = t.tail[drop:], t.dropped+drop
+		}
+	}
+	return pass
+}
+
+// Return the tail kept after the marker of the output truncated, which is sent after the output ends
+func (t *outputTruncator) Flush() []byte {
+	tail := t.tail
+	t.tail = nil
+	if t.dropped == 0 {
+		return tail
+	}
+	return append([]byte(fmt.Sprintf("%v... %v bytes of %v truncated ...%v", LineEnding, t.dropped, t.name, LineEnding)), tail...)
+}
+
+// Get the output limit of each stream of a task, which is the smaller one of the node limit and the share of the job limit
+func getOutputLimit(node_limit_mb, job_limit_mb, nodes int) int64 {
+	limit := int64(node_limit_mb) << 20
+	if job_limit_mb > 0 && nodes > 0 {
+		share := (int64(job_limit_mb) << 20) / int64(nodes)
+		if share < 1 {
+			share = 1
+		}
+		if limit <= 0 || share < limit {
+			limit = share
+		}
+	}
+	return limit
+}
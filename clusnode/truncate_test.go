@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_outputTruncator(t *testing.T) {
+	truncator := newOutputTruncator("stdout", 10)
+	var sent string
+	for _, output := range []string{"0123", "4567", "89abcdef", "ghij"} {
+		sent += string(truncator.Write([]byte(output)))
+	}
+	if sent != "01234" {
+		t.Errorf("Expected the head sent, got %q", sent)
+	}
+	if tail := string(truncator.Flush()); truncator.dropped != 10 || !strings.HasSuffix(tail, "fghij") || !strings.Contains(tail, "10 bytes of stdout truncated") {
+		t.Errorf("Unexpected tail %q with %v bytes dropped", tail, truncator.dropped)
+	}
+
+	unlimited := newOutputTruncator("stderr", 0)
+	if output := string(unlimited.Write([]byte("0123456789"))); output != "0123456789" || len(unlimited.Flush()) > 0 {
+		t.Errorf("Expected output not truncated without limit, got %q", output)
+	}
+	short := newOutputTruncator("stderr", 10)
+	if output := string(short.Write([]byte("0123456"))); output != "01234" || string(short.Flush()) != "56" {
+		t.Errorf("Expected output within limit kept, got %q", output)
+	}
+
+	cases := []struct {
+		nodeLimit, jobLimit, nodes int
+		expected                   int64
+	}{
+		{0, 0, 10, 0},
+		{2, 0, 10, 2 << 20},
+		{0, 10, 4, 10 << 20 / 4},
+		{2, 10, 4, 2 << 20},
+		{0, 1, 1 << 21, 1},
+	}
+	for _, c := range cases {
+		if limit := getOutputLimit(c.nodeLimit, c.jobLimit, c.nodes); limit != c.expected {
+			t.Errorf("Expected output limit %v for %+v, got %v", c.expected, c, limit)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	tunnelChunkSize = 32 << 10
+	maxIdleTunnels  = 16 // the idle tunnels kept on headnode for each clusnode
+)
+
+var (
+	errTunnelClosed = errors.New("Tunnel is closed")
+
+	nodeTunnels        sync.Map              // display name -> *reverseTunnels, the tunnels from each clusnode on headnode
+	openTunnels        sync.Map              // headnode -> *int32, the count of tunnels open to each headnode on clusnode
+	nodeTunnelListener = newTunnelListener() // the tunnels accepted by the gRPC server of clusnode
+)
+
+// The stream of a tunnel, which is either the client or the server side
+type tunnelStream interface {
+	Send(*pb.TunnelData) error
+	Recv() (*pb.TunnelData, error)
+}
+
+type tunnelAddr string
+
+func (a tunnelAddr) Network() string { return "tunnel" }
+func (a tunnelAddr) String() string  { return string(a) }
+
+// A connection over a tunnel stream, in which a gRPC connection from the headnode to the clusnode is carried
+type tunnelConn struct {
+	stream    tunnelStream
+	onClose   func()
+	remote    tunnelAddr
+	buffer    []byte
+	sendLock  sync.Mutex
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newTunnelConn(stream tunnelStream, remote string, on_close func()) *tunnelConn {
+	return &tunnelConn{stream: stream, onClose: on_close, remote: tunnelAddr(remote), done: make(chan struct{})}
+}
+
+func (c *tunnelConn) Read(p []byte) (int, error) {
+	for len(c.buffer) == 0 {
+		data, err := c.stream.Recv()
+		if err != nil {
+			c.Close()
+			if err == io.EOF {
+				return 0, io.EOF
+			}
+			return 0, err
+		}
+		c.buffer = data.GetData()
+	}
+	n := copy(p, c.buffer)
+	c.buffer = c.buffer[n:]
+	return n, nil
+}
+
+func (c *tunnelConn) Write(p []byte) (int, error) {
+	c.sendLock.Lock()
+	defer c.sendLock.Unlock()
+	written := 0
+	for written < len(p) {
+		select {
+		case <-c.done:
+			return written, errTunnelClosed
+		default:
+		}
+		end := written + tunnelChunkSize
+		if end > len(p) {
+			end = len(p)
+		}
+		if err := c.stream.Send(&pb.TunnelData{Data: p[written:end]}); err != nil {
+			c.Close()
+			return written, err
+		}
+		written = end
+	}
+	return written, nil
+}
+
+func (c *tunnelConn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		if c.onClose != nil {
+			c.onClose()
+		}
+	})
+	return nil
+}
+
+func (c *tunnelConn) Closed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *tunnelConn) LocalAddr() net.Addr                { return tunnelAddr(NodeHost) }
+func (c *tunnelConn) RemoteAddr() net.Addr               { return c.remote }
+func (c *tunnelConn) SetDeadline(t time.Time) error      { return nil }
+func (c *tunnelConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *tunnelConn) SetWriteDeadline(t time.Time) error { return nil }
+
+func signTunnel(key []byte, nodename, host, headnode string, nonce []byte) string {
+	return signWithNodeKey(key, "tunnel", nodename, host, headnode, hex.EncodeToString(nonce))
+}
+
+// The tunnel from a clusnode not enrolled yet is signed by the cluster secret, so that it can be validated and enrolled
+// through the tunnel
+func signTunnelSecret(secret, nodename, host, headnode string, nonce []byte) string {
+	return signWithNodeKey([]byte(secret), "tunnel-secret", nodename, host, headnode, hex.EncodeToString(nonce))
+}
+
+// Verify the tunnel is opened by the clusnode, which is signed by its key if enrolled, or by the cluster secret if not,
+// and the clusnode not enrolled can't open tunnels for the host of an enrolled one
+func verifyTunnel(display_name, host string, hello *pb.TunnelData, nonce []byte) error {
+	nodename, headnode := hello.GetNodename(), hello.GetHeadnode()
+	if key, ok := NodeKeys.Load(display_name); ok {
+		if !verifyNodeKeySignature(key.([]byte), hello.GetSignature(), "tunnel", nodename, hello.GetHost(), headnode, hex.EncodeToString(nonce)) {
+			return errors.New("Invalid signature of tunnel")
+		}
+		return nil
+	}
+	secret := Config_ClusterSecret.GetString()
+	if len(secret) == 0 {
+		return errors.New("Clusnode is not enrolled and no cluster secret is configured to sign its tunnel")
+	}
+	if !verifyNodeKeySignature([]byte(secret), hello.GetSecretSignature(), "tunnel-secret", nodename, hello.GetHost(), headnode, hex.EncodeToString(nonce)) {
+		return errors.New("Invalid secret signature of tunnel")
+	}
+	if owner, ok := hostNodes.Load(host); ok && owner.(string) != display_name {
+		if _, enrolled := NodeKeys.Load(owner.(string)); enrolled {
+			return fmt.Errorf("Host %v belongs to enrolled clusnode %v", host, owner)
+		}
+	}
+	return nil
+}
+
+// The tunnels from a clusnode on headnode, each of which carries a connection to the clusnode
+type reverseTunnels struct {
+	idle       chan *tunnelConn
+	open       int32
+	lastClosed atomic.Value // time.Time
+}
+
+func getReverseTunnels(display_name string) *reverseTunnels {
+	val, _ := nodeTunnels.LoadOrStore(display_name, &reverseTunnels{idle: make(chan *tunnelConn, maxIdleTunnels)})
+	return val.(*reverseTunnels)
+}
+
+// The tunnels are found by the clusnode reporting from the host parsed, since the host of a clusnode in its display
+// name may be in other case
+func getTunnelNode(host string) string {
+	if _, _, parsed, err := ParseHostAddress(host); err == nil {
+		host = parsed
+	}
+	if display_name, ok := hostNodes.Load(host); ok {
+		return display_name.(string)
+	}
+	return ""
+}
+
+// The clusnode is connected through its tunnels if it has tunnels open, or had them until a moment ago and is reopening
+func isNodeTunneled(host string) bool {
+	val, ok := nodeTunnels.Load(getTunnelNode(host))
+	if !ok {
+		return false
+	}
+	tunnels := val.(*reverseTunnels)
+	if atomic.LoadInt32(&tunnels.open) > 0 {
+		return true
+	}
+	last_closed, ok := tunnels.lastClosed.Load().(time.Time)
+	return ok && !heartbeatTimeout(last_closed)
+}
+
+// Dial the clusnode by taking an idle tunnel from it
+func (t *reverseTunnels) Dial(ctx context.Context, address string) (net.Conn, error) {
+	for {
+		select {
+		case c := <-t.idle:
+			if !c.Closed() {
+				return c, nil
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Accept a tunnel from the clusnode, which is usable for dialing once the clusnode answers the nonce with the signature
+// of its key, or of the cluster secret if it is not enrolled yet
+func (s *headnode_server) Tunnel(stream pb.Headnode_TunnelServer) error {
+	defer LogPanicBeforeExit()
+	nonce, err := newNodeNonce()
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to generate nonce: %v", err)
+	}
+	if err := stream.Send(&pb.TunnelData{Nonce: nonce}); err != nil {
+		return err
+	}
+	hello, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	display_name, host, err := getNodeDisplayName(canonicalNodename(hello.GetNodename()), hello.GetHost())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid host format: %v", hello.GetHost())
+	}
+	if err := verifyTunnel(display_name, host, hello, nonce); err != nil {
+		LogWarning("Rejected tunnel from %v: %v", display_name, err)
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	tunnels := getReverseTunnels(display_name)
+	if atomic.AddInt32(&tunnels.open, 1) == 1 {
+		LogInfo("Clusnode %v is connected through reverse tunnels", display_name)
+	}
+	defer func() {
+		tunnels.lastClosed.Store(time.Now())
+		atomic.AddInt32(&tunnels.open, -1)
+	}()
+	c := newTunnelConn(stream, hello.GetHost(), nil)
+	select {
+	case tunnels.idle <- c:
+	default:
+		return status.Errorf(codes.ResourceExhausted, "Too many idle tunnels from %v", display_name)
+	}
+	select {
+	case <-c.done:
+	case <-stream.Context().Done():
+		c.Close()
+	}
+	return nil
+}
+
+// Keep the configured count of tunnels open to the headnode, so that it can call this clusnode without connecting to it
+func keepReverseTunnels(from, headnode string) {
+	count := int32(Config_Clusnode_ReverseTunnels.GetInt())
+	val, _ := openTunnels.LoadOrStore(headnode, new(int32))
+	open := val.(*int32)
+	for n := atomic.LoadInt32(open); n < count; n = atomic.LoadInt32(open) {
+		if atomic.CompareAndSwapInt32(open, n, n+1) {
+			go func() {
+				defer LogPanicBeforeExit()
+				defer atomic.AddInt32(open, -1)
+				if err := openReverseTunnel(from, headnode); err != nil {
+					LogError("Failed to open tunnel to headnode %v: %v", headnode, err)
+				}
+			}()
+		}
+	}
+}
+
+// Open a tunnel to the headnode and serve the connection in it until it is closed
+func openReverseTunnel(from, headnode string) error {
+	conn, cancelConn := ConnectNode(headnode)
+	cancelConn()
+	if conn == nil {
+		return status.Errorf(codes.Unavailable, "Can not connect %v", headnode)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := pb.NewHeadnodeClient(conn).Tunnel(ctx)
+	if err != nil {
+		return err
+	}
+	challenge, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	hello := &pb.TunnelData{Nodename: NodeName, Host: from, Headnode: headnode}
+	if key, ok := HeadnodeKeys.Load(headnode); ok {
+		hello.Signature = signTunnel(key.([]byte), NodeName, from, headnode, challenge.GetNonce())
+	}
+	if secret := Config_ClusterSecret.GetString(); len(secret) > 0 {
+		hello.SecretSignature = signTunnelSecret(secret, NodeName, from, headnode, challenge.GetNonce())
+	}
+	if err := stream.Send(hello); err != nil {
+		return err
+	}
+	c := newTunnelConn(stream, headnode, func() {
+		_ = stream.CloseSend()
+		cancel()
+	})
+	if err := nodeTunnelListener.Push(c); err != nil {
+		return err
+	}
+	<-c.done
+	return nil
+}
+
+// The listener of the tunnels opened by clusnode, on which its gRPC server serves the headnodes connecting through them
+type tunnelListener struct {
+	conns     chan net.Conn
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newTunnelListener() *tunnelListener {
+	return &tunnelListener{conns: make(chan net.Conn), done: make(chan struct{})}
+}
+
+func (l *tunnelListener) Push(c net.Conn) error {
+	select {
+	case l.conns <- c:
+		return nil
+	case <-l.done:
+		c.Close()
+		return errTunnelClosed
+	}
+}
+
+func (l *tunnelListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.done:
+		return nil, errTunnelClosed
+	}
+}
+
+func (l *tunnelListener) Close() error {
+	l.closeOnce.Do(func() { close(l.done) })
+	return nil
+}
+
+func (l *tunnelListener) Addr() net.Addr {
+	return tunnelAddr("tunnel")
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	pb "clusrun/protobuf"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// One side of an in-memory tunnel stream
+type fakeTunnelStream struct {
+	in  chan *pb.TunnelData
+	out chan *pb.TunnelData
+}
+
+func (s *fakeTunnelStream) Send(data *pb.TunnelData) error {
+	s.out <- &pb.TunnelData{Data: append([]byte{}, data.GetData()...), Nonce: data.GetNonce()}
+	return nil
+}
+
+func (s *fakeTunnelStream) Recv() (*pb.TunnelData, error) {
+	data, ok := <-s.in
+	if !ok {
+		return nil, io.EOF
+	}
+	return data, nil
+}
+
+func Test_tunnelConn(t *testing.T) {
+	a_to_b, b_to_a := make(chan *pb.TunnelData, 16), make(chan *pb.TunnelData, 16)
+	a := newTunnelConn(&fakeTunnelStream{in: b_to_a, out: a_to_b}, "B", nil)
+	closed := false
+	b := newTunnelConn(&fakeTunnelStream{in: a_to_b, out: b_to_a}, "A", func() { closed = true })
+	listener := newTunnelListener()
+	go func() { _ = listener.Push(b) }()
+	accepted, err := listener.Accept()
+	if err != nil || accepted != b {
+		t.Fatalf("Expected the tunnel accepted, got %v: %v", accepted, err)
+	}
+
+	data := bytes.Repeat([]byte("0123456789"), tunnelChunkSize/5)
+	go func() {
+		if _, err := a.Write(data); err != nil {
+			t.Errorf("Failed to write to tunnel: %v", err)
+		}
+		close(a_to_b)
+	}()
+	received, err := ioutil.ReadAll(accepted)
+	if err != nil || !bytes.Equal(received, data) {
+		t.Errorf("Expected %v bytes received through tunnel, got %v: %v", len(data), len(received), err)
+	}
+	if !closed || !b.Closed() {
+		t.Errorf("Expected the tunnel closed at the end of stream")
+	}
+	if _, err := b.Write([]byte("x")); err != errTunnelClosed {
+		t.Errorf("Expected error writing to closed tunnel, got %v", err)
+	}
+	listener.Close()
+	if _, err := listener.Accept(); err == nil {
+		t.Errorf("Expected error accepting from closed listener")
+	}
+
+	host := "TUNNELED-NODE:" + DefaultPort
+	storeNodeHost("TUNNELED-NODE", host)
+	defer hostNodes.Delete(host)
+	defer nodeHosts.Delete("TUNNELED-NODE")
+	if isNodeTunneled(host) {
+		t.Errorf("Expected node %v not tunneled", host)
+	}
+	tunnels := getReverseTunnels("TUNNELED-NODE")
+	atomic.AddInt32(&tunnels.open, 1)
+	if !isNodeTunneled("tunneled-node") {
+		t.Errorf("Expected node %v tunneled", host)
+	}
+	tunnels.idle <- b
+	tunnels.idle <- a
+	if conn, err := tunnels.Dial(context.Background(), host); err != nil || conn != a {
+		t.Errorf("Expected the closed tunnel skipped when dialing, got %v: %v", conn, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := tunnels.Dial(ctx, host); err == nil {
+		t.Errorf("Expected error dialing without idle tunnels")
+	}
+	atomic.AddInt32(&tunnels.open, -1)
+	nodeTunnels.Delete("TUNNELED-NODE")
+}
+
+// The server side of an in-memory tunnel stream
+type fakeTunnelServer struct {
+	grpc.ServerStream
+	fakeTunnelStream
+	ctx context.Context
+}
+
+func (s *fakeTunnelServer) Context() context.Context {
+	return s.ctx
+}
+
+func Test_verifyTunnel(t *testing.T) {
+	defer Config_ClusterSecret.Set(Config_ClusterSecret.Value)
+	key, secret := make([]byte, nodeKeySize), "cluster-secret-0123"
+	host := "TUNNEL-NODE1:" + DefaultPort
+	NodeKeys.Store("TUNNEL-NODE1", key)
+	storeNodeHost("TUNNEL-NODE1", host)
+	defer func() {
+		NodeKeys.Delete("TUNNEL-NODE1")
+		hostNodes.Delete(host)
+		nodeHosts.Delete("TUNNEL-NODE1")
+		nodeTunnels.Delete("TUNNEL-NODE1")
+	}()
+
+	// Open a tunnel with the signatures of the nonce, and return the error if it is rejected
+	open := func(nodename string, sign func(nonce []byte) (string, string)) error {
+		in, out := make(chan *pb.TunnelData, 1), make(chan *pb.TunnelData, 1)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		done := make(chan error, 1)
+		go func() {
+			done <- (&headnode_server{}).Tunnel(&fakeTunnelServer{fakeTunnelStream: fakeTunnelStream{in: in, out: out}, ctx: ctx})
+		}()
+		nonce := (<-out).GetNonce()
+		signature, secret_signature := sign(nonce)
+		in <- &pb.TunnelData{Nodename: nodename, Host: host, Headnode: "headnode:50505", Signature: signature, SecretSignature: secret_signature}
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(100 * time.Millisecond):
+			cancel()
+			return <-done
+		}
+	}
+	unsigned := func(nonce []byte) (string, string) { return "", "" }
+	signed_by_key := func(nonce []byte) (string, string) {
+		return signTunnel(key, "TUNNEL-NODE1", host, "headnode:50505", nonce), ""
+	}
+	signed_by_secret := func(nodename string) func(nonce []byte) (string, string) {
+		return func(nonce []byte) (string, string) {
+			return "", signTunnelSecret(secret, nodename, host, "headnode:50505", nonce)
+		}
+	}
+
+	// A second nodename claiming the host of the enrolled node is rejected, with or without the cluster secret
+	Config_ClusterSecret.Set("")
+	if err := open("EVIL", unsigned); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected unsigned tunnel of other nodename rejected, got %v", err)
+	}
+	Config_ClusterSecret.Set(secret)
+	if err := open("EVIL", signed_by_secret("EVIL")); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected tunnel of other nodename for the host of enrolled node rejected, got %v", err)
+	}
+	if err := open("EVIL", signed_by_key); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected tunnel of other nodename signed by the key of enrolled node rejected, got %v", err)
+	}
+	if isNodeTunneled(host) {
+		t.Errorf("Expected node %v not tunneled by the rejected tunnels", host)
+	}
+	if _, ok := nodeTunnels.Load("EVIL(" + host + ")"); ok {
+		t.Errorf("Expected no tunnels registered for the rejected nodename")
+	}
+
+	// The enrolled node should sign by its key instead of the cluster secret
+	if err := open("TUNNEL-NODE1", signed_by_secret("TUNNEL-NODE1")); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected tunnel of enrolled node signed by the cluster secret rejected, got %v", err)
+	}
+	if err := open("TUNNEL-NODE1", signed_by_key); err != nil {
+		t.Errorf("Expected tunnel of enrolled node accepted, got %v", err)
+	}
+	if _, ok := nodeTunnels.Load("TUNNEL-NODE1"); !ok || !isNodeTunneled(host) {
+		t.Errorf("Expected node %v tunneled", host)
+	}
+
+	// A node not enrolled yet opens tunnels for its own host by the cluster secret
+	other_host := "TUNNEL-NODE2:" + DefaultPort
+	hello := &pb.TunnelData{Nodename: "TUNNEL-NODE2", Host: other_host, Headnode: "headnode:50505"}
+	nonce := make([]byte, nodeNonceSize)
+	hello.SecretSignature = signTunnelSecret(secret, "TUNNEL-NODE2", other_host, "headnode:50505", nonce)
+	if err := verifyTunnel("TUNNEL-NODE2", other_host, hello, nonce); err != nil {
+		t.Errorf("Expected tunnel of node not enrolled accepted by the cluster secret, got %v", err)
+	}
+}
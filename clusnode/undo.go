@@ -0,0 +1,124 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	removedNodesUndoDuration = time.Hour
+)
+
+var (
+	undoOperations = map[int32]*undoOperation{}
+	undoLastId     int32
+	undoLock       sync.Mutex
+)
+
+// A destructive operation which can be undone before it expires, and the delayed part of it is done when it expires
+type undoOperation struct {
+	description string
+	expire      time.Time
+	undo        func() string
+	expired     func()
+}
+
+func (s *headnode_server) Undo(ctx context.Context, in *pb.UndoRequest) (*pb.UndoReply, error) {
+	defer LogPanicBeforeExit()
+	id := in.GetId()
+	if id == 0 {
+		return &pb.UndoReply{Operations: listUndoOperations()}, nil
+	}
+	op := takeUndoOperation(id)
+	if op == nil {
+		return nil, status.Errorf(codes.NotFound, "Operation %v is not found or can not be undone any more", id)
+	}
+	result := op.undo()
+	LogInfo("Undo operation %v (%v): %v", id, op.description, result)
+	return &pb.UndoReply{Result: result}, nil
+}
+
+// Add an operation which can be undone in the duration, and get the id of it
+func addUndoOperation(description string, duration time.Duration, undo func() string, expired func()) int32 {
+	undoLock.Lock()
+	defer undoLock.Unlock()
+	undoLastId++
+	id := undoLastId
+	undoOperations[id] = &undoOperation{description: description, expire: time.Now().Add(duration), undo: undo, expired: expired}
+	time.AfterFunc(duration, func() {
+		defer LogPanicBeforeExit()
+		if op := takeUndoOperation(id); op != nil && op.expired != nil {
+			op.expired()
+		}
+	})
+	return id
+}
+
+// Remove the operation so that it is either undone or expired once, return nil if it is removed already
+func takeUndoOperation(id int32) *undoOperation {
+	undoLock.Lock()
+	defer undoLock.Unlock()
+	op, ok := undoOperations[id]
+	if !ok {
+		return nil
+	}
+	delete(undoOperations, id)
+	return op
+}
+
+func listUndoOperations() []*pb.UndoOperation {
+	undoLock.Lock()
+	defer undoLock.Unlock()
+	ops := make([]*pb.UndoOperation, 0, len(undoOperations))
+	for id, op := range undoOperations {
+		ops = append(ops, &pb.UndoOperation{Id: id, Description: op.description, ExpireTime: op.expire.Unix()})
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].Id < ops[j].Id })
+	return ops
+}
+
+// Cancel the jobs after the delay unless it is undone
+func delayCancelJobs(job_ids []int32, delay time.Duration) int32 {
+	undo := func() string {
+		return fmt.Sprintf("Cancellation of jobs %v is undone", job_ids)
+	}
+	return addUndoOperation(fmt.Sprintf("Cancel jobs %v", job_ids), delay, undo, func() {
+		ids := map[int32]bool{}
+		for _, id := range job_ids {
+			ids[id] = true
+		}
+		result, to_cancel, err := CancelJobs(ids, "")
+		if err != nil {
+			LogError("Failed to cancel jobs %v after delay: %v", job_ids, err)
+			return
+		}
+		for id, nodes := range to_cancel {
+			go cancelJob(id, nodes)
+		}
+		LogInfo("Delayed cancellation result: %v", result)
+	})
+}
+
+// Keep the removed nodes restorable for a while
+func addRemovedNodesUndo(removed []removedNode) int32 {
+	names := make([]string, 0, len(removed))
+	for _, node := range removed {
+		names = append(names, node.name)
+	}
+	undo := func() string {
+		restored, reported := restoreNodes(removed)
+		result := fmt.Sprintf("Restored %v nodes: %v", len(restored), restored)
+		if len(reported) > 0 {
+			result += fmt.Sprintf(", skipped %v nodes reported again: %v", len(reported), reported)
+		}
+		return result
+	}
+	return addUndoOperation(fmt.Sprintf("Remove nodes %v", names), removedNodesUndoDuration, undo, nil)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_undoOperation(t *testing.T) {
+	expired := make(chan bool, 1)
+	undone := addUndoOperation("undone", time.Minute, func() string { return "undone" }, func() { expired <- true })
+	kept := addUndoOperation("kept", 10*time.Millisecond, func() string { return "kept" }, func() { expired <- true })
+	if ops := listUndoOperations(); len(ops) != 2 || ops[0].Id != undone || ops[1].Id != kept {
+		t.Errorf("unexpected operations: %v", ops)
+	}
+	if op := takeUndoOperation(undone); op == nil || op.undo() != "undone" {
+		t.Errorf("operation %v is not undone", undone)
+	}
+	if op := takeUndoOperation(undone); op != nil {
+		t.Errorf("operation %v is undone twice", undone)
+	}
+	select {
+	case <-expired:
+	case <-time.After(time.Second):
+		t.Errorf("operation %v is not done when expired", kept)
+	}
+	if ops := listUndoOperations(); len(ops) != 0 {
+		t.Errorf("operations are left: %v", ops)
+	}
+}
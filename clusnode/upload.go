@@ -0,0 +1,331 @@
+package main
+
+import (
+	"bufio"
+	pb "clusrun/protobuf"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *headnode_server) UploadFiles(in pb.Headnode_UploadFilesServer) error {
+	defer LogPanicBeforeExit()
+	first, err := in.Recv()
+	if err != nil {
+		LogError("Failed to receive upload request: %v", err)
+		return err
+	}
+	destination := first.GetDestination()
+	if len(destination) == 0 {
+		return status.Error(codes.InvalidArgument, "Destination is not specified")
+	}
+
+	// Get nodes
+	nodes, invalid_nodes, _ := getValidNodes(first.GetNodes(), first.GetPattern(), "", "", first.GetGroups(), first.GetGroupsIntersect(), nil, nil)
+	if len(invalid_nodes) > 0 {
+		LogWarning("Invalid nodes to upload files: %v", invalid_nodes)
+		return status.Errorf(codes.InvalidArgument, "Invalid nodes: %v", invalid_nodes)
+	}
+	if len(nodes) == 0 {
+		return status.Error(codes.InvalidArgument, "No valid nodes to upload files")
+	}
+	LogInfo("Uploading files to %v on nodes: %v", destination, nodes)
+
+	// Buffer the files in a temp file with checksum verified
+	f, err := ioutil.TempFile(db_uploadDir, "upload")
+	if err != nil {
+		LogError("Failed to create temp file for uploading: %v", err)
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	verifier := chunkVerifier{}
+	for req := first; ; {
+		if chunk := req.GetChunk(); chunk != nil {
+			if err := verifier.Add(chunk); err != nil {
+				LogError("Invalid uploaded file chunk: %v", err)
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			if err := writeFileChunk(w, chunk); err != nil {
+				LogError("Failed to buffer uploaded file chunk: %v", err)
+				return err
+			}
+		}
+		if req, err = in.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			LogError("Failed to receive uploaded file chunk: %v", err)
+			return err
+		}
+	}
+	if err := verifier.Close(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := w.Flush(); err != nil {
+		LogError("Failed to buffer uploaded files: %v", err)
+		return err
+	}
+
+	// Distribute the files to nodes
+	var results sync.Map
+	pool := newDispatchPool(Config_Headnode_MaxParallelDispatch.GetInt())
+	wg := sync.WaitGroup{}
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node string) {
+			defer wg.Done()
+			if reply, err := uploadFilesToNode(node, destination, f.Name(), pool); err != nil {
+				LogError("Failed to upload files to node %v: %v", node, err)
+				results.Store(node, status.Convert(err).Message())
+			} else {
+				results.Store(node, fmt.Sprintf("Uploaded %v files (%v bytes)", reply.GetFiles(), reply.GetBytes()))
+			}
+		}(node)
+	}
+	wg.Wait()
+	reply := &pb.UploadFilesReply{Results: map[string]string{}}
+	results.Range(func(k, v interface{}) bool {
+		reply.Results[k.(string)] = v.(string)
+		return true
+	})
+	LogInfo("UploadFiles result: %v", reply.Results)
+	return in.SendAndClose(reply)
+}
+
+func uploadFilesToNode(node, destination, file string, pool dispatchPool) (*pb.ReceiveFilesReply, error) {
+	pool.Acquire()
+	defer pool.Release()
+	conn, release := GetNodeConnection(parseHost(node), connectionLane_Output)
+	if conn == nil {
+		return nil, errors.New("Failed to connect node")
+	}
+	defer release()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := pb.NewClusnodeClient(conn).ReceiveFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := stream.Send(&pb.ReceiveFilesRequest{Headnode: NodeHost, Destination: destination}); err != nil {
+		return nil, err
+	}
+	err = readFileChunks(bufio.NewReader(f), func(chunk *pb.FileChunk) error {
+		return stream.Send(&pb.ReceiveFilesRequest{Chunk: chunk})
+	})
+	if err != nil && err != io.EOF { // the actual error of io.EOF from Send is returned by CloseAndRecv
+		return nil, err
+	}
+	return stream.CloseAndRecv()
+}
+
+func (s *clusnode_server) ReceiveFiles(in pb.Clusnode_ReceiveFilesServer) error {
+	defer LogPanicBeforeExit()
+	var receiver *fileReceiver
+	var headnode string
+	for {
+		req, err := in.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			LogError("Failed to receive files: %v", err)
+			if receiver != nil {
+				receiver.Abort()
+			}
+			return err
+		}
+		if receiver == nil {
+			headnode = req.GetHeadnode()
+			destination, err := prepareWorkingDir(req.GetDestination(), "")
+			if err != nil {
+				LogError("Failed to receive files from headnode %v: %v", req.GetHeadnode(), err)
+				audit(headnode, AuditAction_FilesReceive, 0, "", req.GetDestination(), "Rejected: "+err.Error())
+				return status.Error(codes.PermissionDenied, err.Error())
+			}
+			LogInfo("Receiving files from headnode %v to %v", req.GetHeadnode(), destination)
+			receiver = &fileReceiver{destination: destination}
+		}
+		if chunk := req.GetChunk(); chunk != nil {
+			if err := receiver.Add(chunk); err != nil {
+				LogError("Failed to receive file chunk: %v", err)
+				receiver.Abort()
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+	}
+	if receiver == nil {
+		return status.Error(codes.InvalidArgument, "No files received")
+	}
+	if err := receiver.Close(); err != nil {
+		LogError("Failed to receive files: %v", err)
+		audit(headnode, AuditAction_FilesReceive, 0, "", receiver.destination, formatAuditResult(err))
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	LogInfo("Received %v files (%v bytes) to %v", receiver.files, receiver.bytes, receiver.destination)
+	audit(headnode, AuditAction_FilesReceive, 0, "", receiver.destination, fmt.Sprintf("Received %v files (%v bytes)", receiver.files, receiver.bytes))
+	return in.SendAndClose(&pb.ReceiveFilesReply{Files: receiver.files, Bytes: receiver.bytes})
+}
+
+// Verify the path of each chunk and the checksum of each file, chunks of a file should be consecutive
+type chunkVerifier struct {
+	file   string
+	hasher hash.Hash
+}
+
+func (v *chunkVerifier) Add(chunk *pb.FileChunk) error {
+	p := chunk.GetPath()
+	if len(p) == 0 || path.IsAbs(p) || strings.Contains(p, "\\") || path.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../") {
+		return fmt.Errorf("Invalid file path %q", p)
+	}
+	if chunk.GetIsDir() {
+		if v.hasher != nil {
+			return fmt.Errorf("File %q is incomplete", v.file)
+		}
+		return nil
+	}
+	if v.hasher == nil {
+		v.file, v.hasher = p, sha256.New()
+	} else if v.file != p {
+		return fmt.Errorf("File %q is incomplete", v.file)
+	}
+	_, _ = v.hasher.Write(chunk.GetData())
+	if chunk.GetEof() {
+		sum := hex.EncodeToString(v.hasher.Sum(nil))
+		v.hasher = nil
+		if !strings.EqualFold(sum, chunk.GetSha256()) {
+			return fmt.Errorf("Checksum mismatch of file %q: expected %v, actual %v", p, chunk.GetSha256(), sum)
+		}
+	}
+	return nil
+}
+
+func (v *chunkVerifier) Close() error {
+	if v.hasher != nil {
+		return fmt.Errorf("File %q is incomplete", v.file)
+	}
+	return nil
+}
+
+// Write files to a temp file beside the target and rename it after the checksum is verified
+type fileReceiver struct {
+	destination string
+	verifier    chunkVerifier
+	current     *os.File
+	files       int32
+	bytes       int64
+}
+
+func (r *fileReceiver) Add(chunk *pb.FileChunk) error {
+	if err := r.verifier.Add(chunk); err != nil {
+		return err
+	}
+	target := filepath.Join(r.destination, filepath.FromSlash(chunk.GetPath()))
+	if chunk.GetIsDir() {
+		return os.MkdirAll(target, 0755)
+	}
+	if r.current == nil {
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		f, err := os.Create(target + ".clusrun.tmp")
+		if err != nil {
+			return err
+		}
+		r.current = f
+	}
+	n, err := r.current.Write(chunk.GetData())
+	r.bytes += int64(n)
+	if err != nil {
+		return err
+	}
+	if chunk.GetEof() {
+		temp := r.current.Name()
+		err := r.current.Close()
+		r.current = nil
+		if err == nil {
+			if mode := os.FileMode(chunk.GetMode()) & os.ModePerm; mode != 0 {
+				err = os.Chmod(temp, mode)
+			}
+		}
+		if err == nil {
+			err = os.Rename(temp, target)
+		}
+		if err != nil {
+			os.Remove(temp)
+			return err
+		}
+		r.files++
+	}
+	return nil
+}
+
+func (r *fileReceiver) Close() error {
+	if err := r.verifier.Close(); err != nil {
+		r.Abort()
+		return err
+	}
+	return nil
+}
+
+func (r *fileReceiver) Abort() {
+	if r.current != nil {
+		r.current.Close()
+		os.Remove(r.current.Name())
+		r.current = nil
+	}
+}
+
+func writeFileChunk(w io.Writer, chunk *pb.FileChunk) error {
+	data, err := proto.Marshal(chunk)
+	if err != nil {
+		return err
+	}
+	size := make([]byte, binary.MaxVarintLen64)
+	if _, err := w.Write(size[:binary.PutUvarint(size, uint64(len(data)))]); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func readFileChunks(r *bufio.Reader, handle func(*pb.FileChunk) error) error {
+	for {
+		size, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return err
+		}
+		chunk := &pb.FileChunk{}
+		if err := proto.Unmarshal(data, chunk); err != nil {
+			return err
+		}
+		if err := handle(chunk); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	pb "clusrun/protobuf"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultOutputWindowIntervalSecond = 1
+)
+
+// The last bytes of output within the window size to send at each interval, the earlier ones are skipped
+type outputWindow struct {
+	name    string
+	size    int
+	buf     []byte
+	skipped int64
+	lock    sync.Mutex
+}
+
+func newOutputWindow(name string, size_kb int32) *outputWindow {
+	return &outputWindow{name: name, size: int(size_kb) << 10}
+}
+
+func (w *outputWindow) Write(p []byte) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.buf = append(w.buf, p...)
+	if over := len(w.buf) - w.size; over > 0 {
+		w.skipped += int64(over)
+		w.buf = append(w.buf[:0], w.buf[over:]...)
+	}
+}
+
+// Take the output in window with a line noting the skipped bytes since last flush
+func (w *outputWindow) Flush() []byte {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if len(w.buf) == 0 && w.skipped == 0 {
+		return nil
+	}
+	output := append([]byte{}, w.buf...)
+	if w.skipped > 0 {
+		output = append([]byte(fmt.Sprintf("[%v bytes of %v skipped]%v", w.skipped, w.name, LineEnding)), output...)
+	}
+	w.buf, w.skipped = w.buf[:0], 0
+	return output
+}
+
+// Send the output in windows of stdout and stderr at each interval until stopped
+func sendOutputWindows(window *pb.OutputWindow, stdout, stderr *outputWindow, send func(stdout, stderr []byte) error, stop <-chan struct{}) {
+	interval := time.Duration(window.GetIntervalSecond()) * time.Second
+	if interval <= 0 {
+		interval = defaultOutputWindowIntervalSecond * time.Second
+	}
+	for {
+		stopped := false
+		select {
+		case <-stop:
+			stopped = true
+		case <-time.After(interval):
+		}
+		if err := send(stdout.Flush(), stderr.Flush()); err != nil || stopped {
+			return
+		}
+	}
+}
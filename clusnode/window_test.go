@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_outputWindow(t *testing.T) {
+	LineEnding = "\n"
+	a, b := strings.Repeat("a", 1000), strings.Repeat("b", 100)
+	cases := []struct {
+		writes   [][]string // the writes before each flush
+		expected []string   // the output of each flush
+	}{
+		{[][]string{nil}, []string{""}},
+		{[][]string{{"hello"}, nil}, []string{"hello", ""}},
+		{[][]string{{a}, {b}}, []string{a, b}},
+		{[][]string{{a, b}, nil}, []string{"[76 bytes of stdout skipped]\n" + a[76:] + b, ""}},
+		{[][]string{{strings.Repeat("c", 3000)}, {"d"}}, []string{"[1976 bytes of stdout skipped]\n" + strings.Repeat("c", 1024), "d"}},
+	}
+
+	for _, c := range cases {
+		w := newOutputWindow("stdout", 1)
+		for i, writes := range c.writes {
+			for _, s := range writes {
+				w.Write([]byte(s))
+			}
+			if output := string(w.Flush()); output != c.expected[i] {
+				t.Errorf("\nwrites=%v, flush=%v\nexpected=%q\n  actual=%q", c.writes, i, c.expected[i], output)
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"clusrun/clusnode/platform"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	WorkingDirsSeparator = ";"
+	WorkingDirsAny       = "*"
+)
+
+// Check the working dir against the allowed dirs in config and create it if missing, which is owned by the user to run job as
+func prepareWorkingDir(dir, run_as string) (string, error) {
+	if !filepath.IsAbs(dir) {
+		return "", fmt.Errorf("Working dir %q is not an absolute path", dir)
+	}
+	dir = filepath.Clean(dir)
+	if !isWorkingDirAllowed(dir, Config_Clusnode_AllowedWorkingDirs.GetString()) {
+		return "", fmt.Errorf("Working dir %q is not allowed on node %v", dir, NodeName)
+	}
+	_, err := os.Stat(dir)
+	missing := os.IsNotExist(err)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("Failed to create working dir %q: %v", dir, err)
+	}
+	if missing && len(run_as) > 0 {
+		if err := platform.ChownToUser(dir, run_as); err != nil {
+			return "", fmt.Errorf("Failed to change owner of working dir %q to %v: %v", dir, run_as, err)
+		}
+	}
+	return dir, nil
+}
+
+func chownTree(dir, user string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return platform.ChownToUser(path, user)
+	})
+}
+
+// A dir is allowed if it is one of or under one of the allowed dirs
+func isWorkingDirAllowed(dir, allowed_dirs string) bool {
+	for _, allowed := range strings.Split(allowed_dirs, WorkingDirsSeparator) {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == WorkingDirsAny {
+			return true
+		}
+		if len(allowed) == 0 || !filepath.IsAbs(allowed) {
+			continue
+		}
+		allowed = filepath.Clean(allowed)
+		if RunOnWindows {
+			dir, allowed = strings.ToLower(dir), strings.ToLower(allowed)
+		}
+		if dir == allowed || strings.HasPrefix(dir, strings.TrimSuffix(allowed, string(os.PathSeparator))+string(os.PathSeparator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,10 +2,8 @@
 
 IF "%1"=="" ( SET "port=50505" ) ELSE ( SET "port=%1" )
 
-sc stop clusnode >nul
-sc delete clusnode >nul
-sc create clusnode binpath= "%~dp0clusnode.exe start -host localhost:%port%" start= auto
-sc start clusnode
+"%~dp0clusnode.exe" service uninstall >nul 2>&1
+"%~dp0clusnode.exe" service install -host localhost:%port%
 
 mklink C:\Windows\clus.exe "%~dp0clus.exe"
 mklink C:\Windows\clusnode.exe "%~dp0clusnode.exe"
\ No newline at end of file
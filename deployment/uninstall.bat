@@ -1,7 +1,6 @@
 @echo off
 
-sc stop clusnode
-sc delete clusnode
+"%~dp0clusnode.exe" service uninstall
 
 IF /I "%1"=="-cleanup" (
     rmdir /Q /S "%~dp0clusnode.exe.db"
@@ -1,14 +1,12 @@
 #!/bin/bash
 
-systemctl daemon-reload
-systemctl stop clusnode
-rm -f /etc/systemd/system/clusnode.service
-
 dir=$(dirname "$0")
 
+"$dir/clusnode" service uninstall
+
 if [ "${1,,}" == "-cleanup" ]; then
     rm -rf "$dir/clusnode.db" "$dir/clusnode.logs"
-    rm -f "$dir/clusnode.config" "$dir/cert.pem" "$dir/key.pem"
+    rm -f "$dir/clusnode.config" "$dir/clusnode.service.out" "$dir/cert.pem" "$dir/key.pem"
 fi
 
 rm -f "$dir/clusnode" "$dir/clus"
@@ -8,6 +8,8 @@ require (
 	github.com/juju/fslock v0.0.0-20160525022230-4d5c94c67b4b
 	golang.org/x/crypto v0.0.0-20200406173513-056763e48d71
 	golang.org/x/net v0.0.0-20190404232315-eb5bcb51f2a3
+	golang.org/x/sys v0.0.0-20190412213103-97732733099d
+	golang.org/x/text v0.3.0
 	google.golang.org/grpc v1.28.1
 	google.golang.org/protobuf v1.22.0
 )
@@ -43,13 +43,72 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type HeartbeatCommand int32
+
+const (
+	HeartbeatCommand_NoCommand            HeartbeatCommand = 0
+	HeartbeatCommand_DrainNode            HeartbeatCommand = 1
+	HeartbeatCommand_UndrainNode          HeartbeatCommand = 2
+	HeartbeatCommand_RefreshConfigs       HeartbeatCommand = 3
+	HeartbeatCommand_HeadnodeStopping     HeartbeatCommand = 4
+	HeartbeatCommand_SetHeartbeatInterval HeartbeatCommand = 5
+)
+
+// Enum value maps for HeartbeatCommand.
+var (
+	HeartbeatCommand_name = map[int32]string{
+		0: "NoCommand",
+		1: "DrainNode",
+		2: "UndrainNode",
+		3: "RefreshConfigs",
+		4: "HeadnodeStopping",
+		5: "SetHeartbeatInterval",
+	}
+	HeartbeatCommand_value = map[string]int32{
+		"NoCommand":            0,
+		"DrainNode":            1,
+		"UndrainNode":          2,
+		"RefreshConfigs":       3,
+		"HeadnodeStopping":     4,
+		"SetHeartbeatInterval": 5,
+	}
+)
+
+func (x HeartbeatCommand) Enum() *HeartbeatCommand {
+	p := new(HeartbeatCommand)
+	*p = x
+	return p
+}
+
+func (x HeartbeatCommand) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HeartbeatCommand) Descriptor() protoreflect.EnumDescriptor {
+	return file_protobuf_clusrun_proto_enumTypes[0].Descriptor()
+}
+
+func (HeartbeatCommand) Type() protoreflect.EnumType {
+	return &file_protobuf_clusrun_proto_enumTypes[0]
+}
+
+func (x HeartbeatCommand) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HeartbeatCommand.Descriptor instead.
+func (HeartbeatCommand) EnumDescriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{0}
+}
+
 type NodeState int32
 
 const (
-	NodeState_Unknown NodeState = 0
-	NodeState_Ready   NodeState = 1
-	NodeState_Error   NodeState = 2
-	NodeState_Lost    NodeState = 3
+	NodeState_Unknown  NodeState = 0
+	NodeState_Ready    NodeState = 1
+	NodeState_Error    NodeState = 2
+	NodeState_Lost     NodeState = 3
+	NodeState_Draining NodeState = 4
 )
 
 // Enum value maps for NodeState.
@@ -59,12 +118,14 @@ var (
 		1: "Ready",
 		2: "Error",
 		3: "Lost",
+		4: "Draining",
 	}
 	NodeState_value = map[string]int32{
-		"Unknown": 0,
-		"Ready":   1,
-		"Error":   2,
-		"Lost":    3,
+		"Unknown":  0,
+		"Ready":    1,
+		"Error":    2,
+		"Lost":     3,
+		"Draining": 4,
 	}
 )
 
@@ -79,11 +140,11 @@ func (x NodeState) String() string {
 }
 
 func (NodeState) Descriptor() protoreflect.EnumDescriptor {
-	return file_protobuf_clusrun_proto_enumTypes[0].Descriptor()
+	return file_protobuf_clusrun_proto_enumTypes[1].Descriptor()
 }
 
 func (NodeState) Type() protoreflect.EnumType {
-	return &file_protobuf_clusrun_proto_enumTypes[0]
+	return &file_protobuf_clusrun_proto_enumTypes[1]
 }
 
 func (x NodeState) Number() protoreflect.EnumNumber {
@@ -92,7 +153,7 @@ func (x NodeState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use NodeState.Descriptor instead.
 func (NodeState) EnumDescriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{0}
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{1}
 }
 
 type JobState int32
@@ -106,19 +167,25 @@ const (
 	JobState_Failed       JobState = 5
 	JobState_Canceled     JobState = 6
 	JobState_CancelFailed JobState = 7
+	JobState_Aborted      JobState = 8
+	JobState_Waiting      JobState = 9
+	JobState_Untracked    JobState = 10
 )
 
 // Enum value maps for JobState.
 var (
 	JobState_name = map[int32]string{
-		0: "Created",
-		1: "Dispatching",
-		2: "Running",
-		3: "Canceling",
-		4: "Finished",
-		5: "Failed",
-		6: "Canceled",
-		7: "CancelFailed",
+		0:  "Created",
+		1:  "Dispatching",
+		2:  "Running",
+		3:  "Canceling",
+		4:  "Finished",
+		5:  "Failed",
+		6:  "Canceled",
+		7:  "CancelFailed",
+		8:  "Aborted",
+		9:  "Waiting",
+		10: "Untracked",
 	}
 	JobState_value = map[string]int32{
 		"Created":      0,
@@ -129,6 +196,9 @@ var (
 		"Failed":       5,
 		"Canceled":     6,
 		"CancelFailed": 7,
+		"Aborted":      8,
+		"Waiting":      9,
+		"Untracked":    10,
 	}
 )
 
@@ -143,11 +213,11 @@ func (x JobState) String() string {
 }
 
 func (JobState) Descriptor() protoreflect.EnumDescriptor {
-	return file_protobuf_clusrun_proto_enumTypes[1].Descriptor()
+	return file_protobuf_clusrun_proto_enumTypes[2].Descriptor()
 }
 
 func (JobState) Type() protoreflect.EnumType {
-	return &file_protobuf_clusrun_proto_enumTypes[1]
+	return &file_protobuf_clusrun_proto_enumTypes[2]
 }
 
 func (x JobState) Number() protoreflect.EnumNumber {
@@ -156,7 +226,56 @@ func (x JobState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use JobState.Descriptor instead.
 func (JobState) EnumDescriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{1}
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{2}
+}
+
+type TaskState int32
+
+const (
+	TaskState_TaskUnknown TaskState = 0
+	TaskState_TaskRunning TaskState = 1
+	TaskState_TaskEnded   TaskState = 2
+)
+
+// Enum value maps for TaskState.
+var (
+	TaskState_name = map[int32]string{
+		0: "TaskUnknown",
+		1: "TaskRunning",
+		2: "TaskEnded",
+	}
+	TaskState_value = map[string]int32{
+		"TaskUnknown": 0,
+		"TaskRunning": 1,
+		"TaskEnded":   2,
+	}
+)
+
+func (x TaskState) Enum() *TaskState {
+	p := new(TaskState)
+	*p = x
+	return p
+}
+
+func (x TaskState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskState) Descriptor() protoreflect.EnumDescriptor {
+	return file_protobuf_clusrun_proto_enumTypes[3].Descriptor()
+}
+
+func (TaskState) Type() protoreflect.EnumType {
+	return &file_protobuf_clusrun_proto_enumTypes[3]
+}
+
+func (x TaskState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskState.Descriptor instead.
+func (TaskState) EnumDescriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{3}
 }
 
 type SetHeadnodesMode int32
@@ -192,11 +311,11 @@ func (x SetHeadnodesMode) String() string {
 }
 
 func (SetHeadnodesMode) Descriptor() protoreflect.EnumDescriptor {
-	return file_protobuf_clusrun_proto_enumTypes[2].Descriptor()
+	return file_protobuf_clusrun_proto_enumTypes[4].Descriptor()
 }
 
 func (SetHeadnodesMode) Type() protoreflect.EnumType {
-	return &file_protobuf_clusrun_proto_enumTypes[2]
+	return &file_protobuf_clusrun_proto_enumTypes[4]
 }
 
 func (x SetHeadnodesMode) Number() protoreflect.EnumNumber {
@@ -205,7 +324,7 @@ func (x SetHeadnodesMode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SetHeadnodesMode.Descriptor instead.
 func (SetHeadnodesMode) EnumDescriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{2}
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{4}
 }
 
 type HeartbeatRequest struct {
@@ -213,8 +332,21 @@ type HeartbeatRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Nodename string `protobuf:"bytes,1,opt,name=nodename,proto3" json:"nodename,omitempty"`
-	Host     string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Nodename                string            `protobuf:"bytes,1,opt,name=nodename,proto3" json:"nodename,omitempty"`
+	Host                    string            `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Headnode                string            `protobuf:"bytes,3,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	Timestamp               int64             `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Signature               string            `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Zone                    string            `protobuf:"bytes,6,opt,name=zone,proto3" json:"zone,omitempty"`
+	Resources               *NodeResources    `protobuf:"bytes,7,opt,name=resources,proto3" json:"resources,omitempty"`
+	Relayed                 bool              `protobuf:"varint,8,opt,name=relayed,proto3" json:"relayed,omitempty"`
+	RestartLostJobs         []int32           `protobuf:"varint,9,rep,packed,name=restart_lost_jobs,json=restartLostJobs,proto3" json:"restart_lost_jobs,omitempty"`
+	System                  *NodeSystem       `protobuf:"bytes,10,opt,name=system,proto3" json:"system,omitempty"`
+	Build                   *NodeBuild        `protobuf:"bytes,11,opt,name=build,proto3" json:"build,omitempty"`
+	Labels                  map[string]string `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	OrphanedJobs            []int32           `protobuf:"varint,13,rep,packed,name=orphaned_jobs,json=orphanedJobs,proto3" json:"orphaned_jobs,omitempty"`
+	HeartbeatIntervalSecond int32             `protobuf:"varint,14,opt,name=heartbeat_interval_second,json=heartbeatIntervalSecond,proto3" json:"heartbeat_interval_second,omitempty"`
+	Probes                  map[string]string `protobuf:"bytes,15,rep,name=probes,proto3" json:"probes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *HeartbeatRequest) Reset() {
@@ -263,14 +395,108 @@ func (x *HeartbeatRequest) GetHost() string {
 	return ""
 }
 
-type Empty struct {
+func (x *HeartbeatRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetResources() *NodeResources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetRelayed() bool {
+	if x != nil {
+		return x.Relayed
+	}
+	return false
+}
+
+func (x *HeartbeatRequest) GetRestartLostJobs() []int32 {
+	if x != nil {
+		return x.RestartLostJobs
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetSystem() *NodeSystem {
+	if x != nil {
+		return x.System
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetBuild() *NodeBuild {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetOrphanedJobs() []int32 {
+	if x != nil {
+		return x.OrphanedJobs
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetHeartbeatIntervalSecond() int32 {
+	if x != nil {
+		return x.HeartbeatIntervalSecond
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetProbes() map[string]string {
+	if x != nil {
+		return x.Probes
+	}
+	return nil
+}
+
+type HeartbeatControl struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Command                 HeartbeatCommand `protobuf:"varint,1,opt,name=command,proto3,enum=clusrun.HeartbeatCommand" json:"command,omitempty"`
+	HeartbeatIntervalSecond int32            `protobuf:"varint,2,opt,name=heartbeat_interval_second,json=heartbeatIntervalSecond,proto3" json:"heartbeat_interval_second,omitempty"`
 }
 
-func (x *Empty) Reset() {
-	*x = Empty{}
+func (x *HeartbeatControl) Reset() {
+	*x = HeartbeatControl{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -278,13 +504,13 @@ func (x *Empty) Reset() {
 	}
 }
 
-func (x *Empty) String() string {
+func (x *HeartbeatControl) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Empty) ProtoMessage() {}
+func (*HeartbeatControl) ProtoMessage() {}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
+func (x *HeartbeatControl) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -296,24 +522,41 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
+// Deprecated: Use HeartbeatControl.ProtoReflect.Descriptor instead.
+func (*HeartbeatControl) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{1}
 }
 
-type GetNodesRequest struct {
+func (x *HeartbeatControl) GetCommand() HeartbeatCommand {
+	if x != nil {
+		return x.Command
+	}
+	return HeartbeatCommand_NoCommand
+}
+
+func (x *HeartbeatControl) GetHeartbeatIntervalSecond() int32 {
+	if x != nil {
+		return x.HeartbeatIntervalSecond
+	}
+	return 0
+}
+
+type TunnelData struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pattern         string    `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
-	Groups          []string  `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
-	GroupsIntersect bool      `protobuf:"varint,3,opt,name=groups_intersect,json=groupsIntersect,proto3" json:"groups_intersect,omitempty"`
-	State           NodeState `protobuf:"varint,4,opt,name=state,proto3,enum=clusrun.NodeState" json:"state,omitempty"`
+	Data            []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Nonce           []byte `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Nodename        string `protobuf:"bytes,3,opt,name=nodename,proto3" json:"nodename,omitempty"`
+	Host            string `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"`
+	Headnode        string `protobuf:"bytes,5,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	Signature       string `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	SecretSignature string `protobuf:"bytes,7,opt,name=secret_signature,json=secretSignature,proto3" json:"secret_signature,omitempty"`
 }
 
-func (x *GetNodesRequest) Reset() {
-	*x = GetNodesRequest{}
+func (x *TunnelData) Reset() {
+	*x = TunnelData{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -321,13 +564,13 @@ func (x *GetNodesRequest) Reset() {
 	}
 }
 
-func (x *GetNodesRequest) String() string {
+func (x *TunnelData) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNodesRequest) ProtoMessage() {}
+func (*TunnelData) ProtoMessage() {}
 
-func (x *GetNodesRequest) ProtoReflect() protoreflect.Message {
+func (x *TunnelData) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -339,52 +582,71 @@ func (x *GetNodesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNodesRequest.ProtoReflect.Descriptor instead.
-func (*GetNodesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TunnelData.ProtoReflect.Descriptor instead.
+func (*TunnelData) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetNodesRequest) GetPattern() string {
+func (x *TunnelData) GetData() []byte {
 	if x != nil {
-		return x.Pattern
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-func (x *GetNodesRequest) GetGroups() []string {
+func (x *TunnelData) GetNonce() []byte {
 	if x != nil {
-		return x.Groups
+		return x.Nonce
 	}
 	return nil
 }
 
-func (x *GetNodesRequest) GetGroupsIntersect() bool {
+func (x *TunnelData) GetNodename() string {
 	if x != nil {
-		return x.GroupsIntersect
+		return x.Nodename
 	}
-	return false
+	return ""
 }
 
-func (x *GetNodesRequest) GetState() NodeState {
+func (x *TunnelData) GetHost() string {
 	if x != nil {
-		return x.State
+		return x.Host
 	}
-	return NodeState_Unknown
+	return ""
 }
 
-type Node struct {
+func (x *TunnelData) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *TunnelData) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *TunnelData) GetSecretSignature() string {
+	if x != nil {
+		return x.SecretSignature
+	}
+	return ""
+}
+
+type BatchHeartbeatRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name   string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	State  NodeState `protobuf:"varint,2,opt,name=state,proto3,enum=clusrun.NodeState" json:"state,omitempty"`
-	Jobs   []int32   `protobuf:"varint,3,rep,packed,name=jobs,proto3" json:"jobs,omitempty"`
-	Groups []string  `protobuf:"bytes,4,rep,name=groups,proto3" json:"groups,omitempty"`
+	Heartbeats []*HeartbeatRequest `protobuf:"bytes,1,rep,name=heartbeats,proto3" json:"heartbeats,omitempty"`
+	Relay      string              `protobuf:"bytes,2,opt,name=relay,proto3" json:"relay,omitempty"`
 }
 
-func (x *Node) Reset() {
-	*x = Node{}
+func (x *BatchHeartbeatRequest) Reset() {
+	*x = BatchHeartbeatRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -392,13 +654,13 @@ func (x *Node) Reset() {
 	}
 }
 
-func (x *Node) String() string {
+func (x *BatchHeartbeatRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Node) ProtoMessage() {}
+func (*BatchHeartbeatRequest) ProtoMessage() {}
 
-func (x *Node) ProtoReflect() protoreflect.Message {
+func (x *BatchHeartbeatRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -410,49 +672,35 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Node.ProtoReflect.Descriptor instead.
-func (*Node) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchHeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*BatchHeartbeatRequest) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Node) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Node) GetState() NodeState {
-	if x != nil {
-		return x.State
-	}
-	return NodeState_Unknown
-}
-
-func (x *Node) GetJobs() []int32 {
+func (x *BatchHeartbeatRequest) GetHeartbeats() []*HeartbeatRequest {
 	if x != nil {
-		return x.Jobs
+		return x.Heartbeats
 	}
 	return nil
 }
 
-func (x *Node) GetGroups() []string {
+func (x *BatchHeartbeatRequest) GetRelay() string {
 	if x != nil {
-		return x.Groups
+		return x.Relay
 	}
-	return nil
+	return ""
 }
 
-type GetNodesReply struct {
+type BatchHeartbeatReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Nodes []*Node `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Errors []string `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
 }
 
-func (x *GetNodesReply) Reset() {
-	*x = GetNodesReply{}
+func (x *BatchHeartbeatReply) Reset() {
+	*x = BatchHeartbeatReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -460,13 +708,13 @@ func (x *GetNodesReply) Reset() {
 	}
 }
 
-func (x *GetNodesReply) String() string {
+func (x *BatchHeartbeatReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNodesReply) ProtoMessage() {}
+func (*BatchHeartbeatReply) ProtoMessage() {}
 
-func (x *GetNodesReply) ProtoReflect() protoreflect.Message {
+func (x *BatchHeartbeatReply) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -478,28 +726,36 @@ func (x *GetNodesReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNodesReply.ProtoReflect.Descriptor instead.
-func (*GetNodesReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchHeartbeatReply.ProtoReflect.Descriptor instead.
+func (*BatchHeartbeatReply) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetNodesReply) GetNodes() []*Node {
+func (x *BatchHeartbeatReply) GetErrors() []string {
 	if x != nil {
-		return x.Nodes
+		return x.Errors
 	}
 	return nil
 }
 
-type GetJobsRequest struct {
+type NodeResources struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	JobIds map[int32]bool `protobuf:"bytes,1,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	CpuLoad         float64    `protobuf:"fixed64,1,opt,name=cpu_load,json=cpuLoad,proto3" json:"cpu_load,omitempty"`
+	MemoryTotal     int64      `protobuf:"varint,2,opt,name=memory_total,json=memoryTotal,proto3" json:"memory_total,omitempty"`
+	MemoryAvailable int64      `protobuf:"varint,3,opt,name=memory_available,json=memoryAvailable,proto3" json:"memory_available,omitempty"`
+	DiskTotal       int64      `protobuf:"varint,4,opt,name=disk_total,json=diskTotal,proto3" json:"disk_total,omitempty"`
+	DiskFree        int64      `protobuf:"varint,5,opt,name=disk_free,json=diskFree,proto3" json:"disk_free,omitempty"`
+	RunningJobs     int32      `protobuf:"varint,6,opt,name=running_jobs,json=runningJobs,proto3" json:"running_jobs,omitempty"`
+	SampleTime      int64      `protobuf:"varint,7,opt,name=sample_time,json=sampleTime,proto3" json:"sample_time,omitempty"`
+	Gpus            []*GpuInfo `protobuf:"bytes,8,rep,name=gpus,proto3" json:"gpus,omitempty"`
+	IdleGpus        int32      `protobuf:"varint,9,opt,name=idle_gpus,json=idleGpus,proto3" json:"idle_gpus,omitempty"`
 }
 
-func (x *GetJobsRequest) Reset() {
-	*x = GetJobsRequest{}
+func (x *NodeResources) Reset() {
+	*x = NodeResources{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -507,13 +763,13 @@ func (x *GetJobsRequest) Reset() {
 	}
 }
 
-func (x *GetJobsRequest) String() string {
+func (x *NodeResources) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobsRequest) ProtoMessage() {}
+func (*NodeResources) ProtoMessage() {}
 
-func (x *GetJobsRequest) ProtoReflect() protoreflect.Message {
+func (x *NodeResources) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -525,187 +781,190 @@ func (x *GetJobsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobsRequest.ProtoReflect.Descriptor instead.
-func (*GetJobsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use NodeResources.ProtoReflect.Descriptor instead.
+func (*NodeResources) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetJobsRequest) GetJobIds() map[int32]bool {
+func (x *NodeResources) GetCpuLoad() float64 {
 	if x != nil {
-		return x.JobIds
+		return x.CpuLoad
 	}
-	return nil
+	return 0
 }
 
-type Job struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Id                int32            `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Command           string           `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
-	Sweep             string           `protobuf:"bytes,3,opt,name=sweep,proto3" json:"sweep,omitempty"`
-	Nodes             []string         `protobuf:"bytes,4,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	CreateTime        int64            `protobuf:"varint,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
-	EndTime           int64            `protobuf:"varint,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	State             JobState         `protobuf:"varint,7,opt,name=state,proto3,enum=clusrun.JobState" json:"state,omitempty"`
-	FailedNodes       map[string]int32 `protobuf:"bytes,8,rep,name=failed_nodes,json=failedNodes,proto3" json:"failed_nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	CancelFailedNodes []string         `protobuf:"bytes,9,rep,name=cancel_failed_nodes,json=cancelFailedNodes,proto3" json:"cancel_failed_nodes,omitempty"`
-	SpecifiedNodes    []string         `protobuf:"bytes,10,rep,name=specified_nodes,json=specifiedNodes,proto3" json:"specified_nodes,omitempty"`
-	NodeGroups        []string         `protobuf:"bytes,11,rep,name=node_groups,json=nodeGroups,proto3" json:"node_groups,omitempty"`
-	NodePattern       string           `protobuf:"bytes,12,opt,name=node_pattern,json=nodePattern,proto3" json:"node_pattern,omitempty"`
-	Progress          string           `protobuf:"bytes,13,opt,name=progress,proto3" json:"progress,omitempty"`
-	Arguments         []string         `protobuf:"bytes,14,rep,name=arguments,proto3" json:"arguments,omitempty"`
-	Name              string           `protobuf:"bytes,15,opt,name=name,proto3" json:"name,omitempty"`
-}
-
-func (x *Job) Reset() {
-	*x = Job{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_clusrun_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *NodeResources) GetMemoryTotal() int64 {
+	if x != nil {
+		return x.MemoryTotal
 	}
+	return 0
 }
 
-func (x *Job) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Job) ProtoMessage() {}
-
-func (x *Job) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_clusrun_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *NodeResources) GetMemoryAvailable() int64 {
+	if x != nil {
+		return x.MemoryAvailable
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use Job.ProtoReflect.Descriptor instead.
-func (*Job) Descriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{6}
+func (x *NodeResources) GetDiskTotal() int64 {
+	if x != nil {
+		return x.DiskTotal
+	}
+	return 0
 }
 
-func (x *Job) GetId() int32 {
+func (x *NodeResources) GetDiskFree() int64 {
 	if x != nil {
-		return x.Id
+		return x.DiskFree
 	}
 	return 0
 }
 
-func (x *Job) GetCommand() string {
+func (x *NodeResources) GetRunningJobs() int32 {
 	if x != nil {
-		return x.Command
+		return x.RunningJobs
 	}
-	return ""
+	return 0
 }
 
-func (x *Job) GetSweep() string {
+func (x *NodeResources) GetSampleTime() int64 {
 	if x != nil {
-		return x.Sweep
+		return x.SampleTime
 	}
-	return ""
+	return 0
 }
 
-func (x *Job) GetNodes() []string {
+func (x *NodeResources) GetGpus() []*GpuInfo {
 	if x != nil {
-		return x.Nodes
+		return x.Gpus
 	}
 	return nil
 }
 
-func (x *Job) GetCreateTime() int64 {
+func (x *NodeResources) GetIdleGpus() int32 {
 	if x != nil {
-		return x.CreateTime
+		return x.IdleGpus
 	}
 	return 0
 }
 
-func (x *Job) GetEndTime() int64 {
-	if x != nil {
-		return x.EndTime
+type GpuInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index         int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Uuid          string `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Model         string `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	Driver        string `protobuf:"bytes,4,opt,name=driver,proto3" json:"driver,omitempty"`
+	MemoryTotalMb int64  `protobuf:"varint,5,opt,name=memory_total_mb,json=memoryTotalMb,proto3" json:"memory_total_mb,omitempty"`
+	MemoryUsedMb  int64  `protobuf:"varint,6,opt,name=memory_used_mb,json=memoryUsedMb,proto3" json:"memory_used_mb,omitempty"`
+	Utilization   int32  `protobuf:"varint,7,opt,name=utilization,proto3" json:"utilization,omitempty"`
+	JobId         int32  `protobuf:"varint,8,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+}
+
+func (x *GpuInfo) Reset() {
+	*x = GpuInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *Job) GetState() JobState {
-	if x != nil {
-		return x.State
+func (x *GpuInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GpuInfo) ProtoMessage() {}
+
+func (x *GpuInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return JobState_Created
+	return mi.MessageOf(x)
 }
 
-func (x *Job) GetFailedNodes() map[string]int32 {
+// Deprecated: Use GpuInfo.ProtoReflect.Descriptor instead.
+func (*GpuInfo) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GpuInfo) GetIndex() int32 {
 	if x != nil {
-		return x.FailedNodes
+		return x.Index
 	}
-	return nil
+	return 0
 }
 
-func (x *Job) GetCancelFailedNodes() []string {
+func (x *GpuInfo) GetUuid() string {
 	if x != nil {
-		return x.CancelFailedNodes
+		return x.Uuid
 	}
-	return nil
+	return ""
 }
 
-func (x *Job) GetSpecifiedNodes() []string {
+func (x *GpuInfo) GetModel() string {
 	if x != nil {
-		return x.SpecifiedNodes
+		return x.Model
 	}
-	return nil
+	return ""
 }
 
-func (x *Job) GetNodeGroups() []string {
+func (x *GpuInfo) GetDriver() string {
 	if x != nil {
-		return x.NodeGroups
+		return x.Driver
 	}
-	return nil
+	return ""
 }
 
-func (x *Job) GetNodePattern() string {
+func (x *GpuInfo) GetMemoryTotalMb() int64 {
 	if x != nil {
-		return x.NodePattern
+		return x.MemoryTotalMb
 	}
-	return ""
+	return 0
 }
 
-func (x *Job) GetProgress() string {
+func (x *GpuInfo) GetMemoryUsedMb() int64 {
 	if x != nil {
-		return x.Progress
+		return x.MemoryUsedMb
 	}
-	return ""
+	return 0
 }
 
-func (x *Job) GetArguments() []string {
+func (x *GpuInfo) GetUtilization() int32 {
 	if x != nil {
-		return x.Arguments
+		return x.Utilization
 	}
-	return nil
+	return 0
 }
 
-func (x *Job) GetName() string {
+func (x *GpuInfo) GetJobId() int32 {
 	if x != nil {
-		return x.Name
+		return x.JobId
 	}
-	return ""
+	return 0
 }
 
-type GetJobsReply struct {
+type NodeSystem struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Jobs []*Job `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Os       string `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
+	Arch     string `protobuf:"bytes,2,opt,name=arch,proto3" json:"arch,omitempty"`
+	Version  string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	TimeZone string `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 }
 
-func (x *GetJobsReply) Reset() {
-	*x = GetJobsReply{}
+func (x *NodeSystem) Reset() {
+	*x = NodeSystem{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -713,13 +972,13 @@ func (x *GetJobsReply) Reset() {
 	}
 }
 
-func (x *GetJobsReply) String() string {
+func (x *NodeSystem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobsReply) ProtoMessage() {}
+func (*NodeSystem) ProtoMessage() {}
 
-func (x *GetJobsReply) ProtoReflect() protoreflect.Message {
+func (x *NodeSystem) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -731,29 +990,50 @@ func (x *GetJobsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobsReply.ProtoReflect.Descriptor instead.
-func (*GetJobsReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use NodeSystem.ProtoReflect.Descriptor instead.
+func (*NodeSystem) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetJobsReply) GetJobs() []*Job {
+func (x *NodeSystem) GetOs() string {
 	if x != nil {
-		return x.Jobs
+		return x.Os
 	}
-	return nil
+	return ""
 }
 
-type GetOutputRequest struct {
+func (x *NodeSystem) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *NodeSystem) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *NodeSystem) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type NodeBuild struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	JobId int32  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Node  string `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	Version  string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Protocol int32  `protobuf:"varint,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
 }
 
-func (x *GetOutputRequest) Reset() {
-	*x = GetOutputRequest{}
+func (x *NodeBuild) Reset() {
+	*x = NodeBuild{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -761,13 +1041,13 @@ func (x *GetOutputRequest) Reset() {
 	}
 }
 
-func (x *GetOutputRequest) String() string {
+func (x *NodeBuild) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOutputRequest) ProtoMessage() {}
+func (*NodeBuild) ProtoMessage() {}
 
-func (x *GetOutputRequest) ProtoReflect() protoreflect.Message {
+func (x *NodeBuild) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -779,37 +1059,39 @@ func (x *GetOutputRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOutputRequest.ProtoReflect.Descriptor instead.
-func (*GetOutputRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use NodeBuild.ProtoReflect.Descriptor instead.
+func (*NodeBuild) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetOutputRequest) GetJobId() int32 {
+func (x *NodeBuild) GetVersion() string {
 	if x != nil {
-		return x.JobId
+		return x.Version
 	}
-	return 0
+	return ""
 }
 
-func (x *GetOutputRequest) GetNode() string {
+func (x *NodeBuild) GetProtocol() int32 {
 	if x != nil {
-		return x.Node
+		return x.Protocol
 	}
-	return ""
+	return 0
 }
 
-type GetOutputReply struct {
+type ResourceRequirements struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Node   string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
-	Stdout string `protobuf:"bytes,2,opt,name=stdout,proto3" json:"stdout,omitempty"`
-	Stderr string `protobuf:"bytes,3,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	MinFreeMemoryMb int64   `protobuf:"varint,1,opt,name=min_free_memory_mb,json=minFreeMemoryMb,proto3" json:"min_free_memory_mb,omitempty"`
+	MinFreeDiskMb   int64   `protobuf:"varint,2,opt,name=min_free_disk_mb,json=minFreeDiskMb,proto3" json:"min_free_disk_mb,omitempty"`
+	MaxCpuLoad      float64 `protobuf:"fixed64,3,opt,name=max_cpu_load,json=maxCpuLoad,proto3" json:"max_cpu_load,omitempty"`
+	MaxRunningJobs  int32   `protobuf:"varint,4,opt,name=max_running_jobs,json=maxRunningJobs,proto3" json:"max_running_jobs,omitempty"`
+	MinIdleGpus     int32   `protobuf:"varint,5,opt,name=min_idle_gpus,json=minIdleGpus,proto3" json:"min_idle_gpus,omitempty"`
 }
 
-func (x *GetOutputReply) Reset() {
-	*x = GetOutputReply{}
+func (x *ResourceRequirements) Reset() {
+	*x = ResourceRequirements{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -817,13 +1099,13 @@ func (x *GetOutputReply) Reset() {
 	}
 }
 
-func (x *GetOutputReply) String() string {
+func (x *ResourceRequirements) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOutputReply) ProtoMessage() {}
+func (*ResourceRequirements) ProtoMessage() {}
 
-func (x *GetOutputReply) ProtoReflect() protoreflect.Message {
+func (x *ResourceRequirements) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -835,49 +1117,61 @@ func (x *GetOutputReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOutputReply.ProtoReflect.Descriptor instead.
-func (*GetOutputReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResourceRequirements.ProtoReflect.Descriptor instead.
+func (*ResourceRequirements) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GetOutputReply) GetNode() string {
+func (x *ResourceRequirements) GetMinFreeMemoryMb() int64 {
 	if x != nil {
-		return x.Node
+		return x.MinFreeMemoryMb
 	}
-	return ""
+	return 0
 }
 
-func (x *GetOutputReply) GetStdout() string {
+func (x *ResourceRequirements) GetMinFreeDiskMb() int64 {
 	if x != nil {
-		return x.Stdout
+		return x.MinFreeDiskMb
 	}
-	return ""
+	return 0
 }
 
-func (x *GetOutputReply) GetStderr() string {
+func (x *ResourceRequirements) GetMaxCpuLoad() float64 {
 	if x != nil {
-		return x.Stderr
+		return x.MaxCpuLoad
 	}
-	return ""
+	return 0
 }
 
-type StartClusJobRequest struct {
+func (x *ResourceRequirements) GetMaxRunningJobs() int32 {
+	if x != nil {
+		return x.MaxRunningJobs
+	}
+	return 0
+}
+
+func (x *ResourceRequirements) GetMinIdleGpus() int32 {
+	if x != nil {
+		return x.MinIdleGpus
+	}
+	return 0
+}
+
+type JobLimits struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Command         string   `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
-	Nodes           []string `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	Pattern         string   `protobuf:"bytes,3,opt,name=pattern,proto3" json:"pattern,omitempty"`
-	Groups          []string `protobuf:"bytes,4,rep,name=groups,proto3" json:"groups,omitempty"`
-	GroupsIntersect bool     `protobuf:"varint,5,opt,name=groups_intersect,json=groupsIntersect,proto3" json:"groups_intersect,omitempty"`
-	Sweep           string   `protobuf:"bytes,6,opt,name=sweep,proto3" json:"sweep,omitempty"`
-	Arguments       []string `protobuf:"bytes,7,rep,name=arguments,proto3" json:"arguments,omitempty"`
-	Name            string   `protobuf:"bytes,8,opt,name=name,proto3" json:"name,omitempty"`
+	CpuPercent      int32  `protobuf:"varint,1,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	MemoryMb        int64  `protobuf:"varint,2,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	TimeoutSecond   int32  `protobuf:"varint,3,opt,name=timeout_second,json=timeoutSecond,proto3" json:"timeout_second,omitempty"`
+	RetentionSecond int32  `protobuf:"varint,4,opt,name=retention_second,json=retentionSecond,proto3" json:"retention_second,omitempty"`
+	Nice            int32  `protobuf:"varint,5,opt,name=nice,proto3" json:"nice,omitempty"`
+	IoPriority      string `protobuf:"bytes,6,opt,name=io_priority,json=ioPriority,proto3" json:"io_priority,omitempty"`
 }
 
-func (x *StartClusJobRequest) Reset() {
-	*x = StartClusJobRequest{}
+func (x *JobLimits) Reset() {
+	*x = JobLimits{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -885,13 +1179,13 @@ func (x *StartClusJobRequest) Reset() {
 	}
 }
 
-func (x *StartClusJobRequest) String() string {
+func (x *JobLimits) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartClusJobRequest) ProtoMessage() {}
+func (*JobLimits) ProtoMessage() {}
 
-func (x *StartClusJobRequest) ProtoReflect() protoreflect.Message {
+func (x *JobLimits) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -903,82 +1197,64 @@ func (x *StartClusJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartClusJobRequest.ProtoReflect.Descriptor instead.
-func (*StartClusJobRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use JobLimits.ProtoReflect.Descriptor instead.
+func (*JobLimits) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *StartClusJobRequest) GetCommand() string {
-	if x != nil {
-		return x.Command
-	}
-	return ""
-}
-
-func (x *StartClusJobRequest) GetNodes() []string {
+func (x *JobLimits) GetCpuPercent() int32 {
 	if x != nil {
-		return x.Nodes
-	}
-	return nil
-}
-
-func (x *StartClusJobRequest) GetPattern() string {
-	if x != nil {
-		return x.Pattern
+		return x.CpuPercent
 	}
-	return ""
+	return 0
 }
 
-func (x *StartClusJobRequest) GetGroups() []string {
+func (x *JobLimits) GetMemoryMb() int64 {
 	if x != nil {
-		return x.Groups
+		return x.MemoryMb
 	}
-	return nil
+	return 0
 }
 
-func (x *StartClusJobRequest) GetGroupsIntersect() bool {
+func (x *JobLimits) GetTimeoutSecond() int32 {
 	if x != nil {
-		return x.GroupsIntersect
+		return x.TimeoutSecond
 	}
-	return false
+	return 0
 }
 
-func (x *StartClusJobRequest) GetSweep() string {
+func (x *JobLimits) GetRetentionSecond() int32 {
 	if x != nil {
-		return x.Sweep
+		return x.RetentionSecond
 	}
-	return ""
+	return 0
 }
 
-func (x *StartClusJobRequest) GetArguments() []string {
+func (x *JobLimits) GetNice() int32 {
 	if x != nil {
-		return x.Arguments
+		return x.Nice
 	}
-	return nil
+	return 0
 }
 
-func (x *StartClusJobRequest) GetName() string {
+func (x *JobLimits) GetIoPriority() string {
 	if x != nil {
-		return x.Name
+		return x.IoPriority
 	}
 	return ""
 }
 
-type StartClusJobReply struct {
+type OutputWindow struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	JobId    int32    `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Nodes    []string `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	Node     string   `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
-	Stdout   string   `protobuf:"bytes,4,opt,name=stdout,proto3" json:"stdout,omitempty"`
-	Stderr   string   `protobuf:"bytes,5,opt,name=stderr,proto3" json:"stderr,omitempty"`
-	ExitCode int32    `protobuf:"zigzag32,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	SizeKb         int32 `protobuf:"varint,1,opt,name=size_kb,json=sizeKb,proto3" json:"size_kb,omitempty"`
+	IntervalSecond int32 `protobuf:"varint,2,opt,name=interval_second,json=intervalSecond,proto3" json:"interval_second,omitempty"`
 }
 
-func (x *StartClusJobReply) Reset() {
-	*x = StartClusJobReply{}
+func (x *OutputWindow) Reset() {
+	*x = OutputWindow{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -986,13 +1262,13 @@ func (x *StartClusJobReply) Reset() {
 	}
 }
 
-func (x *StartClusJobReply) String() string {
+func (x *OutputWindow) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartClusJobReply) ProtoMessage() {}
+func (*OutputWindow) ProtoMessage() {}
 
-func (x *StartClusJobReply) ProtoReflect() protoreflect.Message {
+func (x *OutputWindow) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1004,63 +1280,36 @@ func (x *StartClusJobReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartClusJobReply.ProtoReflect.Descriptor instead.
-func (*StartClusJobReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use OutputWindow.ProtoReflect.Descriptor instead.
+func (*OutputWindow) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *StartClusJobReply) GetJobId() int32 {
+func (x *OutputWindow) GetSizeKb() int32 {
 	if x != nil {
-		return x.JobId
+		return x.SizeKb
 	}
 	return 0
 }
 
-func (x *StartClusJobReply) GetNodes() []string {
+func (x *OutputWindow) GetIntervalSecond() int32 {
 	if x != nil {
-		return x.Nodes
+		return x.IntervalSecond
 	}
-	return nil
+	return 0
 }
 
-func (x *StartClusJobReply) GetNode() string {
-	if x != nil {
-		return x.Node
-	}
-	return ""
-}
+type FailFast struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *StartClusJobReply) GetStdout() string {
-	if x != nil {
-		return x.Stdout
-	}
-	return ""
+	MaxFailures       int32 `protobuf:"varint,1,opt,name=max_failures,json=maxFailures,proto3" json:"max_failures,omitempty"`
+	MaxFailurePercent int32 `protobuf:"varint,2,opt,name=max_failure_percent,json=maxFailurePercent,proto3" json:"max_failure_percent,omitempty"`
 }
 
-func (x *StartClusJobReply) GetStderr() string {
-	if x != nil {
-		return x.Stderr
-	}
-	return ""
-}
-
-func (x *StartClusJobReply) GetExitCode() int32 {
-	if x != nil {
-		return x.ExitCode
-	}
-	return 0
-}
-
-type CancelClusJobsRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	JobIds map[int32]bool `protobuf:"bytes,1,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-}
-
-func (x *CancelClusJobsRequest) Reset() {
-	*x = CancelClusJobsRequest{}
+func (x *FailFast) Reset() {
+	*x = FailFast{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1068,13 +1317,13 @@ func (x *CancelClusJobsRequest) Reset() {
 	}
 }
 
-func (x *CancelClusJobsRequest) String() string {
+func (x *FailFast) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelClusJobsRequest) ProtoMessage() {}
+func (*FailFast) ProtoMessage() {}
 
-func (x *CancelClusJobsRequest) ProtoReflect() protoreflect.Message {
+func (x *FailFast) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1086,28 +1335,36 @@ func (x *CancelClusJobsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelClusJobsRequest.ProtoReflect.Descriptor instead.
-func (*CancelClusJobsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FailFast.ProtoReflect.Descriptor instead.
+func (*FailFast) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *CancelClusJobsRequest) GetJobIds() map[int32]bool {
+func (x *FailFast) GetMaxFailures() int32 {
 	if x != nil {
-		return x.JobIds
+		return x.MaxFailures
 	}
-	return nil
+	return 0
 }
 
-type CancelClusJobsReply struct {
+func (x *FailFast) GetMaxFailurePercent() int32 {
+	if x != nil {
+		return x.MaxFailurePercent
+	}
+	return 0
+}
+
+type RollingPolicy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Result map[int32]JobState `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=clusrun.JobState"`
+	BatchSize      int32 `protobuf:"varint,1,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	StopOnFailures int32 `protobuf:"varint,2,opt,name=stop_on_failures,json=stopOnFailures,proto3" json:"stop_on_failures,omitempty"`
 }
 
-func (x *CancelClusJobsReply) Reset() {
-	*x = CancelClusJobsReply{}
+func (x *RollingPolicy) Reset() {
+	*x = RollingPolicy{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1115,13 +1372,13 @@ func (x *CancelClusJobsReply) Reset() {
 	}
 }
 
-func (x *CancelClusJobsReply) String() string {
+func (x *RollingPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelClusJobsReply) ProtoMessage() {}
+func (*RollingPolicy) ProtoMessage() {}
 
-func (x *CancelClusJobsReply) ProtoReflect() protoreflect.Message {
+func (x *RollingPolicy) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1133,31 +1390,33 @@ func (x *CancelClusJobsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelClusJobsReply.ProtoReflect.Descriptor instead.
-func (*CancelClusJobsReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use RollingPolicy.ProtoReflect.Descriptor instead.
+func (*RollingPolicy) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *CancelClusJobsReply) GetResult() map[int32]JobState {
+func (x *RollingPolicy) GetBatchSize() int32 {
 	if x != nil {
-		return x.Result
+		return x.BatchSize
 	}
-	return nil
+	return 0
 }
 
-type StartJobRequest struct {
+func (x *RollingPolicy) GetStopOnFailures() int32 {
+	if x != nil {
+		return x.StopOnFailures
+	}
+	return 0
+}
+
+type Empty struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Headnode  string   `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
-	JobId     int32    `protobuf:"varint,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Command   string   `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
-	Arguments []string `protobuf:"bytes,4,rep,name=arguments,proto3" json:"arguments,omitempty"`
 }
 
-func (x *StartJobRequest) Reset() {
-	*x = StartJobRequest{}
+func (x *Empty) Reset() {
+	*x = Empty{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1165,13 +1424,13 @@ func (x *StartJobRequest) Reset() {
 	}
 }
 
-func (x *StartJobRequest) String() string {
+func (x *Empty) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartJobRequest) ProtoMessage() {}
+func (*Empty) ProtoMessage() {}
 
-func (x *StartJobRequest) ProtoReflect() protoreflect.Message {
+func (x *Empty) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1183,51 +1442,30 @@ func (x *StartJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartJobRequest.ProtoReflect.Descriptor instead.
-func (*StartJobRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *StartJobRequest) GetHeadnode() string {
-	if x != nil {
-		return x.Headnode
-	}
-	return ""
-}
-
-func (x *StartJobRequest) GetJobId() int32 {
-	if x != nil {
-		return x.JobId
-	}
-	return 0
-}
-
-func (x *StartJobRequest) GetCommand() string {
-	if x != nil {
-		return x.Command
-	}
-	return ""
-}
-
-func (x *StartJobRequest) GetArguments() []string {
-	if x != nil {
-		return x.Arguments
-	}
-	return nil
-}
-
-type StartJobReply struct {
+type GetNodesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Stdout   string `protobuf:"bytes,1,opt,name=stdout,proto3" json:"stdout,omitempty"`
-	Stderr   string `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
-	ExitCode int32  `protobuf:"zigzag32,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Pattern         string    `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Groups          []string  `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	GroupsIntersect bool      `protobuf:"varint,3,opt,name=groups_intersect,json=groupsIntersect,proto3" json:"groups_intersect,omitempty"`
+	State           NodeState `protobuf:"varint,4,opt,name=state,proto3,enum=clusrun.NodeState" json:"state,omitempty"`
+	SinceVersion    string    `protobuf:"bytes,5,opt,name=since_version,json=sinceVersion,proto3" json:"since_version,omitempty"`
+	Os              string    `protobuf:"bytes,6,opt,name=os,proto3" json:"os,omitempty"`
+	Arch            string    `protobuf:"bytes,7,opt,name=arch,proto3" json:"arch,omitempty"`
+	Labels          []string  `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty"`
+	Cluster         string    `protobuf:"bytes,9,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Where           []string  `protobuf:"bytes,10,rep,name=where,proto3" json:"where,omitempty"`
 }
 
-func (x *StartJobReply) Reset() {
-	*x = StartJobReply{}
+func (x *GetNodesRequest) Reset() {
+	*x = GetNodesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protobuf_clusrun_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1235,13 +1473,13 @@ func (x *StartJobReply) Reset() {
 	}
 }
 
-func (x *StartJobReply) String() string {
+func (x *GetNodesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartJobReply) ProtoMessage() {}
+func (*GetNodesRequest) ProtoMessage() {}
 
-func (x *StartJobReply) ProtoReflect() protoreflect.Message {
+func (x *GetNodesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_protobuf_clusrun_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1253,121 +1491,119 @@ func (x *StartJobReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartJobReply.ProtoReflect.Descriptor instead.
-func (*StartJobReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetNodesRequest.ProtoReflect.Descriptor instead.
+func (*GetNodesRequest) Descriptor() ([]byte, []int) {
 	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *StartJobReply) GetStdout() string {
+func (x *GetNodesRequest) GetPattern() string {
 	if x != nil {
-		return x.Stdout
+		return x.Pattern
 	}
 	return ""
 }
 
-func (x *StartJobReply) GetStderr() string {
+func (x *GetNodesRequest) GetGroups() []string {
 	if x != nil {
-		return x.Stderr
+		return x.Groups
 	}
-	return ""
+	return nil
 }
 
-func (x *StartJobReply) GetExitCode() int32 {
+func (x *GetNodesRequest) GetGroupsIntersect() bool {
 	if x != nil {
-		return x.ExitCode
+		return x.GroupsIntersect
 	}
-	return 0
-}
-
-type CancelJobRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Headnode string `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
-	JobId    int32  `protobuf:"varint,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Force    bool   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	return false
 }
 
-func (x *CancelJobRequest) Reset() {
-	*x = CancelJobRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_clusrun_proto_msgTypes[16]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *GetNodesRequest) GetState() NodeState {
+	if x != nil {
+		return x.State
 	}
+	return NodeState_Unknown
 }
 
-func (x *CancelJobRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetNodesRequest) GetSinceVersion() string {
+	if x != nil {
+		return x.SinceVersion
+	}
+	return ""
 }
 
-func (*CancelJobRequest) ProtoMessage() {}
-
-func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_clusrun_proto_msgTypes[16]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *GetNodesRequest) GetOs() string {
+	if x != nil {
+		return x.Os
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
-func (*CancelJobRequest) Descriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{16}
+func (x *GetNodesRequest) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
 }
 
-func (x *CancelJobRequest) GetHeadnode() string {
+func (x *GetNodesRequest) GetLabels() []string {
 	if x != nil {
-		return x.Headnode
+		return x.Labels
 	}
-	return ""
+	return nil
 }
 
-func (x *CancelJobRequest) GetJobId() int32 {
+func (x *GetNodesRequest) GetCluster() string {
 	if x != nil {
-		return x.JobId
+		return x.Cluster
 	}
-	return 0
+	return ""
 }
 
-func (x *CancelJobRequest) GetForce() bool {
+func (x *GetNodesRequest) GetWhere() []string {
 	if x != nil {
-		return x.Force
+		return x.Where
 	}
-	return false
+	return nil
 }
 
-type ValidateRequest struct {
+type Node struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Headnode string `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
-	Clusnode string `protobuf:"bytes,2,opt,name=clusnode,proto3" json:"clusnode,omitempty"`
+	Name        string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State       NodeState         `protobuf:"varint,2,opt,name=state,proto3,enum=clusrun.NodeState" json:"state,omitempty"`
+	Jobs        []int32           `protobuf:"varint,3,rep,packed,name=jobs,proto3" json:"jobs,omitempty"`
+	Groups      []string          `protobuf:"bytes,4,rep,name=groups,proto3" json:"groups,omitempty"`
+	Zone        string            `protobuf:"bytes,5,opt,name=zone,proto3" json:"zone,omitempty"`
+	Resources   *NodeResources    `protobuf:"bytes,6,opt,name=resources,proto3" json:"resources,omitempty"`
+	System      *NodeSystem       `protobuf:"bytes,7,opt,name=system,proto3" json:"system,omitempty"`
+	Build       *NodeBuild        `protobuf:"bytes,8,opt,name=build,proto3" json:"build,omitempty"`
+	Stats       *NodeStats        `protobuf:"bytes,9,opt,name=stats,proto3" json:"stats,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Cluster     string            `protobuf:"bytes,11,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	ClockSkewMs int64             `protobuf:"varint,12,opt,name=clock_skew_ms,json=clockSkewMs,proto3" json:"clock_skew_ms,omitempty"`
+	ClockSkewed bool              `protobuf:"varint,13,opt,name=clock_skewed,json=clockSkewed,proto3" json:"clock_skewed,omitempty"`
+	Probes      map[string]string `protobuf:"bytes,14,rep,name=probes,proto3" json:"probes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *ValidateRequest) Reset() {
-	*x = ValidateRequest{}
+func (x *Node) Reset() {
+	*x = Node{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_clusrun_proto_msgTypes[17]
+		mi := &file_protobuf_clusrun_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ValidateRequest) String() string {
+func (x *Node) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateRequest) ProtoMessage() {}
+func (*Node) ProtoMessage() {}
 
-func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_clusrun_proto_msgTypes[17]
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1378,99 +1614,139 @@ func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
-func (*ValidateRequest) Descriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ValidateRequest) GetHeadnode() string {
+func (x *Node) GetName() string {
 	if x != nil {
-		return x.Headnode
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ValidateRequest) GetClusnode() string {
+func (x *Node) GetState() NodeState {
 	if x != nil {
-		return x.Clusnode
+		return x.State
 	}
-	return ""
+	return NodeState_Unknown
 }
 
-type ValidateReply struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Node) GetJobs() []int32 {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
 
-	Nodename string `protobuf:"bytes,1,opt,name=nodename,proto3" json:"nodename,omitempty"`
+func (x *Node) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
 }
 
-func (x *ValidateReply) Reset() {
-	*x = ValidateReply{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_clusrun_proto_msgTypes[18]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Node) GetZone() string {
+	if x != nil {
+		return x.Zone
 	}
+	return ""
 }
 
-func (x *ValidateReply) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Node) GetResources() *NodeResources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
 }
 
-func (*ValidateReply) ProtoMessage() {}
+func (x *Node) GetSystem() *NodeSystem {
+	if x != nil {
+		return x.System
+	}
+	return nil
+}
 
-func (x *ValidateReply) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_clusrun_proto_msgTypes[18]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Node) GetBuild() *NodeBuild {
+	if x != nil {
+		return x.Build
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ValidateReply.ProtoReflect.Descriptor instead.
-func (*ValidateReply) Descriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{18}
+func (x *Node) GetStats() *NodeStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
 }
 
-func (x *ValidateReply) GetNodename() string {
+func (x *Node) GetLabels() map[string]string {
 	if x != nil {
-		return x.Nodename
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Node) GetCluster() string {
+	if x != nil {
+		return x.Cluster
 	}
 	return ""
 }
 
-type SetNodeGroupsRequest struct {
+func (x *Node) GetClockSkewMs() int64 {
+	if x != nil {
+		return x.ClockSkewMs
+	}
+	return 0
+}
+
+func (x *Node) GetClockSkewed() bool {
+	if x != nil {
+		return x.ClockSkewed
+	}
+	return false
+}
+
+func (x *Node) GetProbes() map[string]string {
+	if x != nil {
+		return x.Probes
+	}
+	return nil
+}
+
+type NodeStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Groups []string `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
-	Nodes  []*Node  `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	Remove bool     `protobuf:"varint,3,opt,name=remove,proto3" json:"remove,omitempty"`
+	Jobs             int64 `protobuf:"varint,1,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	SucceededJobs    int64 `protobuf:"varint,2,opt,name=succeeded_jobs,json=succeededJobs,proto3" json:"succeeded_jobs,omitempty"`
+	TotalDurationMs  int64 `protobuf:"varint,3,opt,name=total_duration_ms,json=totalDurationMs,proto3" json:"total_duration_ms,omitempty"`
+	LastJobId        int32 `protobuf:"varint,4,opt,name=last_job_id,json=lastJobId,proto3" json:"last_job_id,omitempty"`
+	LastJobTime      int64 `protobuf:"varint,5,opt,name=last_job_time,json=lastJobTime,proto3" json:"last_job_time,omitempty"`
+	LastJobSucceeded bool  `protobuf:"varint,6,opt,name=last_job_succeeded,json=lastJobSucceeded,proto3" json:"last_job_succeeded,omitempty"`
 }
 
-func (x *SetNodeGroupsRequest) Reset() {
-	*x = SetNodeGroupsRequest{}
+func (x *NodeStats) Reset() {
+	*x = NodeStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_clusrun_proto_msgTypes[19]
+		mi := &file_protobuf_clusrun_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetNodeGroupsRequest) String() string {
+func (x *NodeStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetNodeGroupsRequest) ProtoMessage() {}
+func (*NodeStats) ProtoMessage() {}
 
-func (x *SetNodeGroupsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_clusrun_proto_msgTypes[19]
+func (x *NodeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1481,58 +1757,81 @@ func (x *SetNodeGroupsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetNodeGroupsRequest.ProtoReflect.Descriptor instead.
-func (*SetNodeGroupsRequest) Descriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use NodeStats.ProtoReflect.Descriptor instead.
+func (*NodeStats) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *SetNodeGroupsRequest) GetGroups() []string {
+func (x *NodeStats) GetJobs() int64 {
 	if x != nil {
-		return x.Groups
+		return x.Jobs
 	}
-	return nil
+	return 0
 }
 
-func (x *SetNodeGroupsRequest) GetNodes() []*Node {
+func (x *NodeStats) GetSucceededJobs() int64 {
 	if x != nil {
-		return x.Nodes
+		return x.SucceededJobs
 	}
-	return nil
+	return 0
 }
 
-func (x *SetNodeGroupsRequest) GetRemove() bool {
+func (x *NodeStats) GetTotalDurationMs() int64 {
 	if x != nil {
-		return x.Remove
+		return x.TotalDurationMs
+	}
+	return 0
+}
+
+func (x *NodeStats) GetLastJobId() int32 {
+	if x != nil {
+		return x.LastJobId
+	}
+	return 0
+}
+
+func (x *NodeStats) GetLastJobTime() int64 {
+	if x != nil {
+		return x.LastJobTime
+	}
+	return 0
+}
+
+func (x *NodeStats) GetLastJobSucceeded() bool {
+	if x != nil {
+		return x.LastJobSucceeded
 	}
 	return false
 }
 
-type SetHeadnodesRequest struct {
+type GetNodesReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Headnodes []string         `protobuf:"bytes,1,rep,name=headnodes,proto3" json:"headnodes,omitempty"`
-	Mode      SetHeadnodesMode `protobuf:"varint,2,opt,name=mode,proto3,enum=clusrun.SetHeadnodesMode" json:"mode,omitempty"`
+	Nodes        []*Node  `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Version      string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Delta        bool     `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	RemovedNodes []string `protobuf:"bytes,4,rep,name=removed_nodes,json=removedNodes,proto3" json:"removed_nodes,omitempty"`
 }
 
-func (x *SetHeadnodesRequest) Reset() {
-	*x = SetHeadnodesRequest{}
+func (x *GetNodesReply) Reset() {
+	*x = GetNodesReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_clusrun_proto_msgTypes[20]
+		mi := &file_protobuf_clusrun_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetHeadnodesRequest) String() string {
+func (x *GetNodesReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetHeadnodesRequest) ProtoMessage() {}
+func (*GetNodesReply) ProtoMessage() {}
 
-func (x *SetHeadnodesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_clusrun_proto_msgTypes[20]
+func (x *GetNodesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1543,50 +1842,74 @@ func (x *SetHeadnodesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetHeadnodesRequest.ProtoReflect.Descriptor instead.
-func (*SetHeadnodesRequest) Descriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use GetNodesReply.ProtoReflect.Descriptor instead.
+func (*GetNodesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *SetHeadnodesRequest) GetHeadnodes() []string {
+func (x *GetNodesReply) GetNodes() []*Node {
 	if x != nil {
-		return x.Headnodes
+		return x.Nodes
 	}
 	return nil
 }
 
-func (x *SetHeadnodesRequest) GetMode() SetHeadnodesMode {
+func (x *GetNodesReply) GetVersion() string {
 	if x != nil {
-		return x.Mode
+		return x.Version
 	}
-	return SetHeadnodesMode_Default
+	return ""
 }
 
-type SetHeadnodesReply struct {
+func (x *GetNodesReply) GetDelta() bool {
+	if x != nil {
+		return x.Delta
+	}
+	return false
+}
+
+func (x *GetNodesReply) GetRemovedNodes() []string {
+	if x != nil {
+		return x.RemovedNodes
+	}
+	return nil
+}
+
+type GetJobsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Results map[string]string `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	JobIds  map[int32]bool `protobuf:"bytes,1,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Select  string         `protobuf:"bytes,2,opt,name=select,proto3" json:"select,omitempty"`
+	States  []JobState     `protobuf:"varint,3,rep,packed,name=states,proto3,enum=clusrun.JobState" json:"states,omitempty"`
+	Since   int64          `protobuf:"varint,4,opt,name=since,proto3" json:"since,omitempty"`
+	Until   int64          `protobuf:"varint,5,opt,name=until,proto3" json:"until,omitempty"`
+	Node    string         `protobuf:"bytes,6,opt,name=node,proto3" json:"node,omitempty"`
+	Command string         `protobuf:"bytes,7,opt,name=command,proto3" json:"command,omitempty"`
+	Limit   int32          `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset  int32          `protobuf:"varint,9,opt,name=offset,proto3" json:"offset,omitempty"`
+	Summary bool           `protobuf:"varint,10,opt,name=summary,proto3" json:"summary,omitempty"`
+	Cluster string         `protobuf:"bytes,11,opt,name=cluster,proto3" json:"cluster,omitempty"`
 }
 
-func (x *SetHeadnodesReply) Reset() {
-	*x = SetHeadnodesReply{}
+func (x *GetJobsRequest) Reset() {
+	*x = GetJobsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_clusrun_proto_msgTypes[21]
+		mi := &file_protobuf_clusrun_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetHeadnodesReply) String() string {
+func (x *GetJobsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetHeadnodesReply) ProtoMessage() {}
+func (*GetJobsRequest) ProtoMessage() {}
 
-func (x *SetHeadnodesReply) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_clusrun_proto_msgTypes[21]
+func (x *GetJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1597,137 +1920,170 @@ func (x *SetHeadnodesReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetHeadnodesReply.ProtoReflect.Descriptor instead.
-func (*SetHeadnodesReply) Descriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GetJobsRequest.ProtoReflect.Descriptor instead.
+func (*GetJobsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *SetHeadnodesReply) GetResults() map[string]string {
+func (x *GetJobsRequest) GetJobIds() map[int32]bool {
 	if x != nil {
-		return x.Results
+		return x.JobIds
 	}
 	return nil
 }
 
-type SetConfigsRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Configs map[string]string `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-}
-
-func (x *SetConfigsRequest) Reset() {
-	*x = SetConfigsRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_clusrun_proto_msgTypes[22]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *GetJobsRequest) GetSelect() string {
+	if x != nil {
+		return x.Select
 	}
+	return ""
 }
 
-func (x *SetConfigsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*SetConfigsRequest) ProtoMessage() {}
-
-func (x *SetConfigsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_clusrun_proto_msgTypes[22]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *GetJobsRequest) GetStates() []JobState {
+	if x != nil {
+		return x.States
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use SetConfigsRequest.ProtoReflect.Descriptor instead.
-func (*SetConfigsRequest) Descriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{22}
+func (x *GetJobsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
 }
 
-func (x *SetConfigsRequest) GetConfigs() map[string]string {
+func (x *GetJobsRequest) GetUntil() int64 {
 	if x != nil {
-		return x.Configs
+		return x.Until
 	}
-	return nil
+	return 0
 }
 
-type SetConfigsReply struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Results map[string]string `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+func (x *GetJobsRequest) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
 }
 
-func (x *SetConfigsReply) Reset() {
-	*x = SetConfigsReply{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_clusrun_proto_msgTypes[23]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *GetJobsRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
 	}
+	return ""
 }
 
-func (x *SetConfigsReply) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetJobsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
 }
 
-func (*SetConfigsReply) ProtoMessage() {}
-
-func (x *SetConfigsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_clusrun_proto_msgTypes[23]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *GetJobsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use SetConfigsReply.ProtoReflect.Descriptor instead.
-func (*SetConfigsReply) Descriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{23}
+func (x *GetJobsRequest) GetSummary() bool {
+	if x != nil {
+		return x.Summary
+	}
+	return false
 }
 
-func (x *SetConfigsReply) GetResults() map[string]string {
+func (x *GetJobsRequest) GetCluster() string {
 	if x != nil {
-		return x.Results
+		return x.Cluster
 	}
-	return nil
+	return ""
 }
 
-type GetConfigsReply struct {
+type Job struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Configs map[string]string `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Id                int32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Command           string                `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	Sweep             string                `protobuf:"bytes,3,opt,name=sweep,proto3" json:"sweep,omitempty"`
+	Nodes             []string              `protobuf:"bytes,4,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	CreateTime        int64                 `protobuf:"varint,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	EndTime           int64                 `protobuf:"varint,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	State             JobState              `protobuf:"varint,7,opt,name=state,proto3,enum=clusrun.JobState" json:"state,omitempty"`
+	FailedNodes       map[string]int32      `protobuf:"bytes,8,rep,name=failed_nodes,json=failedNodes,proto3" json:"failed_nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	CancelFailedNodes []string              `protobuf:"bytes,9,rep,name=cancel_failed_nodes,json=cancelFailedNodes,proto3" json:"cancel_failed_nodes,omitempty"`
+	SpecifiedNodes    []string              `protobuf:"bytes,10,rep,name=specified_nodes,json=specifiedNodes,proto3" json:"specified_nodes,omitempty"`
+	NodeGroups        []string              `protobuf:"bytes,11,rep,name=node_groups,json=nodeGroups,proto3" json:"node_groups,omitempty"`
+	NodePattern       string                `protobuf:"bytes,12,opt,name=node_pattern,json=nodePattern,proto3" json:"node_pattern,omitempty"`
+	Progress          string                `protobuf:"bytes,13,opt,name=progress,proto3" json:"progress,omitempty"`
+	Arguments         []string              `protobuf:"bytes,14,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	Name              string                `protobuf:"bytes,15,opt,name=name,proto3" json:"name,omitempty"`
+	MaxReschedules    int32                 `protobuf:"varint,16,opt,name=max_reschedules,json=maxReschedules,proto3" json:"max_reschedules,omitempty"`
+	Reschedules       []*Reschedule         `protobuf:"bytes,17,rep,name=reschedules,proto3" json:"reschedules,omitempty"`
+	Checkpoint        string                `protobuf:"bytes,18,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	ShipCheckpoint    bool                  `protobuf:"varint,19,opt,name=ship_checkpoint,json=shipCheckpoint,proto3" json:"ship_checkpoint,omitempty"`
+	BandwidthLimitKb  int32                 `protobuf:"varint,20,opt,name=bandwidth_limit_kb,json=bandwidthLimitKb,proto3" json:"bandwidth_limit_kb,omitempty"`
+	WorkingDir        string                `protobuf:"bytes,21,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	RunAs             string                `protobuf:"bytes,22,opt,name=run_as,json=runAs,proto3" json:"run_as,omitempty"`
+	NodeCommands      map[string]string     `protobuf:"bytes,23,rep,name=node_commands,json=nodeCommands,proto3" json:"node_commands,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	JsonOutput        bool                  `protobuf:"varint,24,opt,name=json_output,json=jsonOutput,proto3" json:"json_output,omitempty"`
+	Results           map[string]string     `protobuf:"bytes,25,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ResultErrors      map[string]string     `protobuf:"bytes,26,rep,name=result_errors,json=resultErrors,proto3" json:"result_errors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DispatchOrder     string                `protobuf:"bytes,27,opt,name=dispatch_order,json=dispatchOrder,proto3" json:"dispatch_order,omitempty"`
+	Tasks             []*TaskSpan           `protobuf:"bytes,28,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	CaptureEnv        bool                  `protobuf:"varint,29,opt,name=capture_env,json=captureEnv,proto3" json:"capture_env,omitempty"`
+	Requirements      *ResourceRequirements `protobuf:"bytes,30,opt,name=requirements,proto3" json:"requirements,omitempty"`
+	SkippedNodes      map[string]string     `protobuf:"bytes,31,rep,name=skipped_nodes,json=skippedNodes,proto3" json:"skipped_nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Limits            *JobLimits            `protobuf:"bytes,32,opt,name=limits,proto3" json:"limits,omitempty"`
+	EnvMode           string                `protobuf:"bytes,33,opt,name=env_mode,json=envMode,proto3" json:"env_mode,omitempty"`
+	OutputWindow      *OutputWindow         `protobuf:"bytes,34,opt,name=output_window,json=outputWindow,proto3" json:"output_window,omitempty"`
+	Rolling           *RollingPolicy        `protobuf:"bytes,35,opt,name=rolling,proto3" json:"rolling,omitempty"`
+	FailFast          *FailFast             `protobuf:"bytes,36,opt,name=fail_fast,json=failFast,proto3" json:"fail_fast,omitempty"`
+	After             *JobDependency        `protobuf:"bytes,37,opt,name=after,proto3" json:"after,omitempty"`
+	ForwardStdin      bool                  `protobuf:"varint,38,opt,name=forward_stdin,json=forwardStdin,proto3" json:"forward_stdin,omitempty"`
+	CorrelationId     string                `protobuf:"bytes,39,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	FailureClusters   []*FailureCluster     `protobuf:"bytes,40,rep,name=failure_clusters,json=failureClusters,proto3" json:"failure_clusters,omitempty"`
+	Summary           *JobSummary           `protobuf:"bytes,41,opt,name=summary,proto3" json:"summary,omitempty"`
+	Labels            []string              `protobuf:"bytes,42,rep,name=labels,proto3" json:"labels,omitempty"`
+	Shell             string                `protobuf:"bytes,43,opt,name=shell,proto3" json:"shell,omitempty"`
+	OsCommands        map[string]string     `protobuf:"bytes,44,rep,name=os_commands,json=osCommands,proto3" json:"os_commands,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ScriptName        string                `protobuf:"bytes,45,opt,name=script_name,json=scriptName,proto3" json:"script_name,omitempty"`
+	TimedOutNodes     []string              `protobuf:"bytes,46,rep,name=timed_out_nodes,json=timedOutNodes,proto3" json:"timed_out_nodes,omitempty"`
+	OrphanedNodes     []string              `protobuf:"bytes,47,rep,name=orphaned_nodes,json=orphanedNodes,proto3" json:"orphaned_nodes,omitempty"`
+	LostNodes         []string              `protobuf:"bytes,48,rep,name=lost_nodes,json=lostNodes,proto3" json:"lost_nodes,omitempty"`
+	Cluster           string                `protobuf:"bytes,49,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	TruncatedNodes    map[string]int64      `protobuf:"bytes,50,rep,name=truncated_nodes,json=truncatedNodes,proto3" json:"truncated_nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	RequestId         string                `protobuf:"bytes,51,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Client            string                `protobuf:"bytes,52,opt,name=client,proto3" json:"client,omitempty"`
+	Exclude           string                `protobuf:"bytes,53,opt,name=exclude,proto3" json:"exclude,omitempty"`
+	SweepValues       map[string]string     `protobuf:"bytes,54,rep,name=sweep_values,json=sweepValues,proto3" json:"sweep_values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Where             []string              `protobuf:"bytes,55,rep,name=where,proto3" json:"where,omitempty"`
+	RerunOf           int32                 `protobuf:"varint,56,opt,name=rerun_of,json=rerunOf,proto3" json:"rerun_of,omitempty"`
+	Executor          *JobExecutor          `protobuf:"bytes,57,opt,name=executor,proto3" json:"executor,omitempty"`
+	TimeZone          string                `protobuf:"bytes,58,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 }
 
-func (x *GetConfigsReply) Reset() {
-	*x = GetConfigsReply{}
+func (x *Job) Reset() {
+	*x = Job{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_clusrun_proto_msgTypes[24]
+		mi := &file_protobuf_clusrun_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetConfigsReply) String() string {
+func (x *Job) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConfigsReply) ProtoMessage() {}
+func (*Job) ProtoMessage() {}
 
-func (x *GetConfigsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_clusrun_proto_msgTypes[24]
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1738,568 +2094,12684 @@ func (x *GetConfigsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConfigsReply.ProtoReflect.Descriptor instead.
-func (*GetConfigsReply) Descriptor() ([]byte, []int) {
-	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *GetConfigsReply) GetConfigs() map[string]string {
+func (x *Job) GetId() int32 {
 	if x != nil {
-		return x.Configs
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Job) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *Job) GetSweep() string {
+	if x != nil {
+		return x.Sweep
+	}
+	return ""
+}
+
+func (x *Job) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
 	}
 	return nil
 }
 
-var File_protobuf_clusrun_proto protoreflect.FileDescriptor
+func (x *Job) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
 
-var file_protobuf_clusrun_proto_rawDesc = []byte{
-	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x63, 0x6c, 0x75, 0x73, 0x72,
-	0x75, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
-	0x6e, 0x22, 0x42, 0x0a, 0x10, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x68, 0x6f, 0x73, 0x74, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x98,
-	0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x16, 0x0a, 0x06,
-	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x5f, 0x69,
-	0x6e, 0x74, 0x65, 0x72, 0x73, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f,
-	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x65, 0x63, 0x74, 0x12,
-	0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12,
-	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x70, 0x0a, 0x04, 0x4e, 0x6f, 0x64,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e,
-	0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x04, 0x6a,
-	0x6f, 0x62, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x34, 0x0a, 0x0d, 0x47,
-	0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x23, 0x0a, 0x05,
-	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x63, 0x6c,
-	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65,
-	0x73, 0x22, 0x89, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x07, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
-	0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4a,
-	0x6f, 0x62, 0x49, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6a, 0x6f, 0x62, 0x49,
-	0x64, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xad, 0x04,
-	0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12,
-	0x14, 0x0a, 0x05, 0x73, 0x77, 0x65, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x73, 0x77, 0x65, 0x65, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08,
-	0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
-	0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
-	0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x12, 0x40, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73,
-	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
-	0x2e, 0x4a, 0x6f, 0x62, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64,
-	0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x66, 0x61, 0x69,
-	0x6c, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x11, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64,
-	0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f,
-	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x70, 0x65,
-	0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e,
-	0x6f, 0x64, 0x65, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x21, 0x0a, 0x0c,
-	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x0c, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12,
-	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61,
-	0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
-	0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x3e, 0x0a,
-	0x10, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x30, 0x0a,
-	0x0c, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x20, 0x0a,
-	0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x6c,
-	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x22,
-	0x3d, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f,
-	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x54,
-	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
-	0x64, 0x65, 0x72, 0x72, 0x22, 0xea, 0x01, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6c,
-	0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
-	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63,
-	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07,
-	0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70,
-	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73,
-	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x29,
-	0x0a, 0x10, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x65,
-	0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73,
-	0x49, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x65, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x77, 0x65,
-	0x65, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x77, 0x65, 0x65, 0x70, 0x12,
-	0x1c, 0x0a, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x22, 0xa1, 0x01, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x4a,
-	0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x14,
-	0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e,
-	0x6f, 0x64, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f,
-	0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74,
-	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x11, 0x52, 0x08, 0x65, 0x78, 0x69,
-	0x74, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x97, 0x01, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
-	0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x43, 0x0a, 0x07, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x2a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65,
-	0x6c, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6a, 0x6f,
-	0x62, 0x49, 0x64, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0xa5, 0x01, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f,
-	0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x40, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
-	0x6e, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x73,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x1a, 0x4c, 0x0a, 0x0b, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x63, 0x6c, 0x75, 0x73,
-	0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x7c, 0x0a, 0x0f, 0x53, 0x74, 0x61, 0x72, 0x74,
-	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65,
-	0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65,
-	0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x18, 0x0a,
-	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x67, 0x75,
-	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x5c, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f,
-	0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63,
-	0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x11, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43,
-	0x6f, 0x64, 0x65, 0x22, 0x5b, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e,
-	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e,
-	0x6f, 0x64, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f,
-	0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65,
-	0x22, 0x49, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12,
-	0x1a, 0x0a, 0x08, 0x63, 0x6c, 0x75, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x63, 0x6c, 0x75, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x2b, 0x0a, 0x0d, 0x56,
-	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08,
-	0x6e, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x6e, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x6b, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x4e,
-	0x6f, 0x64, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
-	0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x16, 0x0a,
-	0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72,
-	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0x62, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64,
-	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09,
-	0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x09, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x6d, 0x6f,
-	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
-	0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x4d,
-	0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x92, 0x01, 0x0a, 0x11, 0x53, 0x65,
-	0x74, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x41, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x27, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x65,
-	0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x92,
-	0x01, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
-	0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x22, 0x8e, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3f, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
-	0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x22, 0x8e, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3f, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x6c, 0x75, 0x73,
-	0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x38, 0x0a, 0x09, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12,
-	0x09, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x79, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x6f, 0x73, 0x74, 0x10, 0x03, 0x2a,
-	0x7e, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x69, 0x73, 0x70,
-	0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x75, 0x6e,
-	0x6e, 0x69, 0x6e, 0x67, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
-	0x69, 0x6e, 0x67, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65,
-	0x64, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x05, 0x12,
-	0x0c, 0x0a, 0x08, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x10, 0x06, 0x12, 0x10, 0x0a,
-	0x0c, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x07, 0x2a,
-	0x34, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x4d,
-	0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x10, 0x00,
-	0x12, 0x07, 0x0a, 0x03, 0x41, 0x64, 0x64, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x65, 0x6d,
-	0x6f, 0x76, 0x65, 0x10, 0x02, 0x32, 0xe8, 0x04, 0x0a, 0x08, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f,
-	0x64, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12,
-	0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62,
-	0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x63, 0x6c, 0x75,
-	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x08,
-	0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
-	0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74,
-	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x07,
-	0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x17, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
-	0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f,
-	0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x09, 0x47, 0x65, 0x74,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
-	0x2e, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x17, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4c,
-	0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x12, 0x1c,
-	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6c,
-	0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63,
-	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6c, 0x75, 0x73,
-	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x0e,
-	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x1e,
-	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43,
-	0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
-	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43,
-	0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44,
-	0x0a, 0x0a, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x1a, 0x2e, 0x63,
-	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
-	0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x73, 0x12, 0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x40,
-	0x0a, 0x0d, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12,
-	0x1d, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64,
-	0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e,
-	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00,
-	0x32, 0x92, 0x03, 0x0a, 0x08, 0x43, 0x6c, 0x75, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x40, 0x0a,
-	0x08, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73,
-	0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74,
-	0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12,
-	0x38, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x12, 0x19, 0x2e, 0x63,
-	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
-	0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x08, 0x56, 0x61, 0x6c,
-	0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
-	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0c, 0x53, 0x65, 0x74,
-	0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x63, 0x6c, 0x75, 0x73,
-	0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
-	0x6e, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65,
-	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0a, 0x47,
-	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73,
-	0x72, 0x75, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73,
-	0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x12, 0x5a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x3b, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+func (x *Job) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
 }
 
-var (
-	file_protobuf_clusrun_proto_rawDescOnce sync.Once
-	file_protobuf_clusrun_proto_rawDescData = file_protobuf_clusrun_proto_rawDesc
-)
+func (x *Job) GetState() JobState {
+	if x != nil {
+		return x.State
+	}
+	return JobState_Created
+}
 
-func file_protobuf_clusrun_proto_rawDescGZIP() []byte {
-	file_protobuf_clusrun_proto_rawDescOnce.Do(func() {
-		file_protobuf_clusrun_proto_rawDescData = protoimpl.X.CompressGZIP(file_protobuf_clusrun_proto_rawDescData)
-	})
-	return file_protobuf_clusrun_proto_rawDescData
+func (x *Job) GetFailedNodes() map[string]int32 {
+	if x != nil {
+		return x.FailedNodes
+	}
+	return nil
 }
 
-var file_protobuf_clusrun_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_protobuf_clusrun_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
-var file_protobuf_clusrun_proto_goTypes = []interface{}{
-	(NodeState)(0),                // 0: clusrun.NodeState
-	(JobState)(0),                 // 1: clusrun.JobState
-	(SetHeadnodesMode)(0),         // 2: clusrun.SetHeadnodesMode
-	(*HeartbeatRequest)(nil),      // 3: clusrun.HeartbeatRequest
-	(*Empty)(nil),                 // 4: clusrun.Empty
-	(*GetNodesRequest)(nil),       // 5: clusrun.GetNodesRequest
-	(*Node)(nil),                  // 6: clusrun.Node
-	(*GetNodesReply)(nil),         // 7: clusrun.GetNodesReply
-	(*GetJobsRequest)(nil),        // 8: clusrun.GetJobsRequest
-	(*Job)(nil),                   // 9: clusrun.Job
-	(*GetJobsReply)(nil),          // 10: clusrun.GetJobsReply
-	(*GetOutputRequest)(nil),      // 11: clusrun.GetOutputRequest
-	(*GetOutputReply)(nil),        // 12: clusrun.GetOutputReply
-	(*StartClusJobRequest)(nil),   // 13: clusrun.StartClusJobRequest
-	(*StartClusJobReply)(nil),     // 14: clusrun.StartClusJobReply
-	(*CancelClusJobsRequest)(nil), // 15: clusrun.CancelClusJobsRequest
-	(*CancelClusJobsReply)(nil),   // 16: clusrun.CancelClusJobsReply
-	(*StartJobRequest)(nil),       // 17: clusrun.StartJobRequest
-	(*StartJobReply)(nil),         // 18: clusrun.StartJobReply
-	(*CancelJobRequest)(nil),      // 19: clusrun.CancelJobRequest
-	(*ValidateRequest)(nil),       // 20: clusrun.ValidateRequest
-	(*ValidateReply)(nil),         // 21: clusrun.ValidateReply
-	(*SetNodeGroupsRequest)(nil),  // 22: clusrun.SetNodeGroupsRequest
-	(*SetHeadnodesRequest)(nil),   // 23: clusrun.SetHeadnodesRequest
-	(*SetHeadnodesReply)(nil),     // 24: clusrun.SetHeadnodesReply
-	(*SetConfigsRequest)(nil),     // 25: clusrun.SetConfigsRequest
-	(*SetConfigsReply)(nil),       // 26: clusrun.SetConfigsReply
-	(*GetConfigsReply)(nil),       // 27: clusrun.GetConfigsReply
-	nil,                           // 28: clusrun.GetJobsRequest.JobIdsEntry
-	nil,                           // 29: clusrun.Job.FailedNodesEntry
-	nil,                           // 30: clusrun.CancelClusJobsRequest.JobIdsEntry
-	nil,                           // 31: clusrun.CancelClusJobsReply.ResultEntry
-	nil,                           // 32: clusrun.SetHeadnodesReply.ResultsEntry
-	nil,                           // 33: clusrun.SetConfigsRequest.ConfigsEntry
-	nil,                           // 34: clusrun.SetConfigsReply.ResultsEntry
-	nil,                           // 35: clusrun.GetConfigsReply.ConfigsEntry
+func (x *Job) GetCancelFailedNodes() []string {
+	if x != nil {
+		return x.CancelFailedNodes
+	}
+	return nil
 }
-var file_protobuf_clusrun_proto_depIdxs = []int32{
-	0,  // 0: clusrun.GetNodesRequest.state:type_name -> clusrun.NodeState
-	0,  // 1: clusrun.Node.state:type_name -> clusrun.NodeState
-	6,  // 2: clusrun.GetNodesReply.nodes:type_name -> clusrun.Node
-	28, // 3: clusrun.GetJobsRequest.job_ids:type_name -> clusrun.GetJobsRequest.JobIdsEntry
-	1,  // 4: clusrun.Job.state:type_name -> clusrun.JobState
-	29, // 5: clusrun.Job.failed_nodes:type_name -> clusrun.Job.FailedNodesEntry
-	9,  // 6: clusrun.GetJobsReply.jobs:type_name -> clusrun.Job
-	30, // 7: clusrun.CancelClusJobsRequest.job_ids:type_name -> clusrun.CancelClusJobsRequest.JobIdsEntry
-	31, // 8: clusrun.CancelClusJobsReply.result:type_name -> clusrun.CancelClusJobsReply.ResultEntry
-	6,  // 9: clusrun.SetNodeGroupsRequest.nodes:type_name -> clusrun.Node
-	2,  // 10: clusrun.SetHeadnodesRequest.mode:type_name -> clusrun.SetHeadnodesMode
-	32, // 11: clusrun.SetHeadnodesReply.results:type_name -> clusrun.SetHeadnodesReply.ResultsEntry
-	33, // 12: clusrun.SetConfigsRequest.configs:type_name -> clusrun.SetConfigsRequest.ConfigsEntry
-	34, // 13: clusrun.SetConfigsReply.results:type_name -> clusrun.SetConfigsReply.ResultsEntry
-	35, // 14: clusrun.GetConfigsReply.configs:type_name -> clusrun.GetConfigsReply.ConfigsEntry
-	1,  // 15: clusrun.CancelClusJobsReply.ResultEntry.value:type_name -> clusrun.JobState
-	3,  // 16: clusrun.Headnode.Heartbeat:input_type -> clusrun.HeartbeatRequest
-	5,  // 17: clusrun.Headnode.GetNodes:input_type -> clusrun.GetNodesRequest
-	8,  // 18: clusrun.Headnode.GetJobs:input_type -> clusrun.GetJobsRequest
-	11, // 19: clusrun.Headnode.GetOutput:input_type -> clusrun.GetOutputRequest
-	13, // 20: clusrun.Headnode.StartClusJob:input_type -> clusrun.StartClusJobRequest
-	15, // 21: clusrun.Headnode.CancelClusJobs:input_type -> clusrun.CancelClusJobsRequest
-	25, // 22: clusrun.Headnode.SetConfigs:input_type -> clusrun.SetConfigsRequest
-	4,  // 23: clusrun.Headnode.GetConfigs:input_type -> clusrun.Empty
-	22, // 24: clusrun.Headnode.SetNodeGroups:input_type -> clusrun.SetNodeGroupsRequest
-	17, // 25: clusrun.Clusnode.StartJob:input_type -> clusrun.StartJobRequest
-	19, // 26: clusrun.Clusnode.CancelJob:input_type -> clusrun.CancelJobRequest
-	20, // 27: clusrun.Clusnode.Validate:input_type -> clusrun.ValidateRequest
-	23, // 28: clusrun.Clusnode.SetHeadnodes:input_type -> clusrun.SetHeadnodesRequest
-	25, // 29: clusrun.Clusnode.SetConfigs:input_type -> clusrun.SetConfigsRequest
-	4,  // 30: clusrun.Clusnode.GetConfigs:input_type -> clusrun.Empty
-	4,  // 31: clusrun.Headnode.Heartbeat:output_type -> clusrun.Empty
-	7,  // 32: clusrun.Headnode.GetNodes:output_type -> clusrun.GetNodesReply
-	10, // 33: clusrun.Headnode.GetJobs:output_type -> clusrun.GetJobsReply
-	12, // 34: clusrun.Headnode.GetOutput:output_type -> clusrun.GetOutputReply
-	14, // 35: clusrun.Headnode.StartClusJob:output_type -> clusrun.StartClusJobReply
-	16, // 36: clusrun.Headnode.CancelClusJobs:output_type -> clusrun.CancelClusJobsReply
-	26, // 37: clusrun.Headnode.SetConfigs:output_type -> clusrun.SetConfigsReply
-	27, // 38: clusrun.Headnode.GetConfigs:output_type -> clusrun.GetConfigsReply
-	4,  // 39: clusrun.Headnode.SetNodeGroups:output_type -> clusrun.Empty
-	18, // 40: clusrun.Clusnode.StartJob:output_type -> clusrun.StartJobReply
-	4,  // 41: clusrun.Clusnode.CancelJob:output_type -> clusrun.Empty
-	21, // 42: clusrun.Clusnode.Validate:output_type -> clusrun.ValidateReply
-	24, // 43: clusrun.Clusnode.SetHeadnodes:output_type -> clusrun.SetHeadnodesReply
-	26, // 44: clusrun.Clusnode.SetConfigs:output_type -> clusrun.SetConfigsReply
-	27, // 45: clusrun.Clusnode.GetConfigs:output_type -> clusrun.GetConfigsReply
-	31, // [31:46] is the sub-list for method output_type
-	16, // [16:31] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+
+func (x *Job) GetSpecifiedNodes() []string {
+	if x != nil {
+		return x.SpecifiedNodes
+	}
+	return nil
 }
 
-func init() { file_protobuf_clusrun_proto_init() }
-func file_protobuf_clusrun_proto_init() {
-	if File_protobuf_clusrun_proto != nil {
-		return
+func (x *Job) GetNodeGroups() []string {
+	if x != nil {
+		return x.NodeGroups
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_protobuf_clusrun_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HeartbeatRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protobuf_clusrun_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protobuf_clusrun_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetNodesRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protobuf_clusrun_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Node); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protobuf_clusrun_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetNodesReply); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protobuf_clusrun_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetJobsRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protobuf_clusrun_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Job); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protobuf_clusrun_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetJobsReply); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protobuf_clusrun_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetOutputRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protobuf_clusrun_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetOutputReply); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protobuf_clusrun_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartClusJobRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_protobuf_clusrun_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartClusJobReply); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
+	return nil
+}
+
+func (x *Job) GetNodePattern() string {
+	if x != nil {
+		return x.NodePattern
+	}
+	return ""
+}
+
+func (x *Job) GetProgress() string {
+	if x != nil {
+		return x.Progress
+	}
+	return ""
+}
+
+func (x *Job) GetArguments() []string {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *Job) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Job) GetMaxReschedules() int32 {
+	if x != nil {
+		return x.MaxReschedules
+	}
+	return 0
+}
+
+func (x *Job) GetReschedules() []*Reschedule {
+	if x != nil {
+		return x.Reschedules
+	}
+	return nil
+}
+
+func (x *Job) GetCheckpoint() string {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return ""
+}
+
+func (x *Job) GetShipCheckpoint() bool {
+	if x != nil {
+		return x.ShipCheckpoint
+	}
+	return false
+}
+
+func (x *Job) GetBandwidthLimitKb() int32 {
+	if x != nil {
+		return x.BandwidthLimitKb
+	}
+	return 0
+}
+
+func (x *Job) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *Job) GetRunAs() string {
+	if x != nil {
+		return x.RunAs
+	}
+	return ""
+}
+
+func (x *Job) GetNodeCommands() map[string]string {
+	if x != nil {
+		return x.NodeCommands
+	}
+	return nil
+}
+
+func (x *Job) GetJsonOutput() bool {
+	if x != nil {
+		return x.JsonOutput
+	}
+	return false
+}
+
+func (x *Job) GetResults() map[string]string {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *Job) GetResultErrors() map[string]string {
+	if x != nil {
+		return x.ResultErrors
+	}
+	return nil
+}
+
+func (x *Job) GetDispatchOrder() string {
+	if x != nil {
+		return x.DispatchOrder
+	}
+	return ""
+}
+
+func (x *Job) GetTasks() []*TaskSpan {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *Job) GetCaptureEnv() bool {
+	if x != nil {
+		return x.CaptureEnv
+	}
+	return false
+}
+
+func (x *Job) GetRequirements() *ResourceRequirements {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+func (x *Job) GetSkippedNodes() map[string]string {
+	if x != nil {
+		return x.SkippedNodes
+	}
+	return nil
+}
+
+func (x *Job) GetLimits() *JobLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *Job) GetEnvMode() string {
+	if x != nil {
+		return x.EnvMode
+	}
+	return ""
+}
+
+func (x *Job) GetOutputWindow() *OutputWindow {
+	if x != nil {
+		return x.OutputWindow
+	}
+	return nil
+}
+
+func (x *Job) GetRolling() *RollingPolicy {
+	if x != nil {
+		return x.Rolling
+	}
+	return nil
+}
+
+func (x *Job) GetFailFast() *FailFast {
+	if x != nil {
+		return x.FailFast
+	}
+	return nil
+}
+
+func (x *Job) GetAfter() *JobDependency {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *Job) GetForwardStdin() bool {
+	if x != nil {
+		return x.ForwardStdin
+	}
+	return false
+}
+
+func (x *Job) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *Job) GetFailureClusters() []*FailureCluster {
+	if x != nil {
+		return x.FailureClusters
+	}
+	return nil
+}
+
+func (x *Job) GetSummary() *JobSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *Job) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Job) GetShell() string {
+	if x != nil {
+		return x.Shell
+	}
+	return ""
+}
+
+func (x *Job) GetOsCommands() map[string]string {
+	if x != nil {
+		return x.OsCommands
+	}
+	return nil
+}
+
+func (x *Job) GetScriptName() string {
+	if x != nil {
+		return x.ScriptName
+	}
+	return ""
+}
+
+func (x *Job) GetTimedOutNodes() []string {
+	if x != nil {
+		return x.TimedOutNodes
+	}
+	return nil
+}
+
+func (x *Job) GetOrphanedNodes() []string {
+	if x != nil {
+		return x.OrphanedNodes
+	}
+	return nil
+}
+
+func (x *Job) GetLostNodes() []string {
+	if x != nil {
+		return x.LostNodes
+	}
+	return nil
+}
+
+func (x *Job) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *Job) GetTruncatedNodes() map[string]int64 {
+	if x != nil {
+		return x.TruncatedNodes
+	}
+	return nil
+}
+
+func (x *Job) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *Job) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *Job) GetExclude() string {
+	if x != nil {
+		return x.Exclude
+	}
+	return ""
+}
+
+func (x *Job) GetSweepValues() map[string]string {
+	if x != nil {
+		return x.SweepValues
+	}
+	return nil
+}
+
+func (x *Job) GetWhere() []string {
+	if x != nil {
+		return x.Where
+	}
+	return nil
+}
+
+func (x *Job) GetRerunOf() int32 {
+	if x != nil {
+		return x.RerunOf
+	}
+	return 0
+}
+
+func (x *Job) GetExecutor() *JobExecutor {
+	if x != nil {
+		return x.Executor
+	}
+	return nil
+}
+
+func (x *Job) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type JobSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes            int32  `protobuf:"varint,1,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	SucceededNodes   int32  `protobuf:"varint,2,opt,name=succeeded_nodes,json=succeededNodes,proto3" json:"succeeded_nodes,omitempty"`
+	FailedNodes      int32  `protobuf:"varint,3,opt,name=failed_nodes,json=failedNodes,proto3" json:"failed_nodes,omitempty"`
+	UnreachableNodes int32  `protobuf:"varint,4,opt,name=unreachable_nodes,json=unreachableNodes,proto3" json:"unreachable_nodes,omitempty"`
+	SlowestNode      string `protobuf:"bytes,5,opt,name=slowest_node,json=slowestNode,proto3" json:"slowest_node,omitempty"`
+	SlowestDuration  int64  `protobuf:"varint,6,opt,name=slowest_duration,json=slowestDuration,proto3" json:"slowest_duration,omitempty"`
+	WallTime         int64  `protobuf:"varint,7,opt,name=wall_time,json=wallTime,proto3" json:"wall_time,omitempty"`
+	ExitCode         int32  `protobuf:"zigzag32,8,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	StdoutBytes      int64  `protobuf:"varint,9,opt,name=stdout_bytes,json=stdoutBytes,proto3" json:"stdout_bytes,omitempty"`
+	StderrBytes      int64  `protobuf:"varint,10,opt,name=stderr_bytes,json=stderrBytes,proto3" json:"stderr_bytes,omitempty"`
+	DispatchOverhead int64  `protobuf:"varint,11,opt,name=dispatch_overhead,json=dispatchOverhead,proto3" json:"dispatch_overhead,omitempty"`
+}
+
+func (x *JobSummary) Reset() {
+	*x = JobSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobSummary) ProtoMessage() {}
+
+func (x *JobSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobSummary.ProtoReflect.Descriptor instead.
+func (*JobSummary) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *JobSummary) GetNodes() int32 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+func (x *JobSummary) GetSucceededNodes() int32 {
+	if x != nil {
+		return x.SucceededNodes
+	}
+	return 0
+}
+
+func (x *JobSummary) GetFailedNodes() int32 {
+	if x != nil {
+		return x.FailedNodes
+	}
+	return 0
+}
+
+func (x *JobSummary) GetUnreachableNodes() int32 {
+	if x != nil {
+		return x.UnreachableNodes
+	}
+	return 0
+}
+
+func (x *JobSummary) GetSlowestNode() string {
+	if x != nil {
+		return x.SlowestNode
+	}
+	return ""
+}
+
+func (x *JobSummary) GetSlowestDuration() int64 {
+	if x != nil {
+		return x.SlowestDuration
+	}
+	return 0
+}
+
+func (x *JobSummary) GetWallTime() int64 {
+	if x != nil {
+		return x.WallTime
+	}
+	return 0
+}
+
+func (x *JobSummary) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *JobSummary) GetStdoutBytes() int64 {
+	if x != nil {
+		return x.StdoutBytes
+	}
+	return 0
+}
+
+func (x *JobSummary) GetStderrBytes() int64 {
+	if x != nil {
+		return x.StderrBytes
+	}
+	return 0
+}
+
+func (x *JobSummary) GetDispatchOverhead() int64 {
+	if x != nil {
+		return x.DispatchOverhead
+	}
+	return 0
+}
+
+type FailureCluster struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind       string   `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Value      string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Nodes      []string `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	TotalNodes int32    `protobuf:"varint,4,opt,name=total_nodes,json=totalNodes,proto3" json:"total_nodes,omitempty"`
+}
+
+func (x *FailureCluster) Reset() {
+	*x = FailureCluster{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FailureCluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailureCluster) ProtoMessage() {}
+
+func (x *FailureCluster) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailureCluster.ProtoReflect.Descriptor instead.
+func (*FailureCluster) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *FailureCluster) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *FailureCluster) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FailureCluster) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *FailureCluster) GetTotalNodes() int32 {
+	if x != nil {
+		return x.TotalNodes
+	}
+	return 0
+}
+
+type JobDependency struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId       int32 `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	SuccessOnly bool  `protobuf:"varint,2,opt,name=success_only,json=successOnly,proto3" json:"success_only,omitempty"`
+}
+
+func (x *JobDependency) Reset() {
+	*x = JobDependency{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobDependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobDependency) ProtoMessage() {}
+
+func (x *JobDependency) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobDependency.ProtoReflect.Descriptor instead.
+func (*JobDependency) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *JobDependency) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *JobDependency) GetSuccessOnly() bool {
+	if x != nil {
+		return x.SuccessOnly
+	}
+	return false
+}
+
+type TaskSpan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node          string           `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	DispatchTime  int64            `protobuf:"varint,2,opt,name=dispatch_time,json=dispatchTime,proto3" json:"dispatch_time,omitempty"`
+	StartTime     int64            `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       int64            `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	ExitCode      int32            `protobuf:"zigzag32,5,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Lost          bool             `protobuf:"varint,6,opt,name=lost,proto3" json:"lost,omitempty"`
+	Environment   *TaskEnvironment `protobuf:"bytes,7,opt,name=environment,proto3" json:"environment,omitempty"`
+	Checksum      *OutputChecksum  `protobuf:"bytes,8,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	ChecksumError string           `protobuf:"bytes,9,opt,name=checksum_error,json=checksumError,proto3" json:"checksum_error,omitempty"`
+	TimedOut      bool             `protobuf:"varint,10,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	StdoutBytes   int64            `protobuf:"varint,11,opt,name=stdout_bytes,json=stdoutBytes,proto3" json:"stdout_bytes,omitempty"`
+	StderrBytes   int64            `protobuf:"varint,12,opt,name=stderr_bytes,json=stderrBytes,proto3" json:"stderr_bytes,omitempty"`
+}
+
+func (x *TaskSpan) Reset() {
+	*x = TaskSpan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskSpan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskSpan) ProtoMessage() {}
+
+func (x *TaskSpan) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskSpan.ProtoReflect.Descriptor instead.
+func (*TaskSpan) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TaskSpan) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *TaskSpan) GetDispatchTime() int64 {
+	if x != nil {
+		return x.DispatchTime
+	}
+	return 0
+}
+
+func (x *TaskSpan) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *TaskSpan) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *TaskSpan) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *TaskSpan) GetLost() bool {
+	if x != nil {
+		return x.Lost
+	}
+	return false
+}
+
+func (x *TaskSpan) GetEnvironment() *TaskEnvironment {
+	if x != nil {
+		return x.Environment
+	}
+	return nil
+}
+
+func (x *TaskSpan) GetChecksum() *OutputChecksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+func (x *TaskSpan) GetChecksumError() string {
+	if x != nil {
+		return x.ChecksumError
+	}
+	return ""
+}
+
+func (x *TaskSpan) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+func (x *TaskSpan) GetStdoutBytes() int64 {
+	if x != nil {
+		return x.StdoutBytes
+	}
+	return 0
+}
+
+func (x *TaskSpan) GetStderrBytes() int64 {
+	if x != nil {
+		return x.StderrBytes
+	}
+	return 0
+}
+
+type TaskEnvironment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Variables          map[string]string `protobuf:"bytes,1,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	User               string            `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	WorkingDir         string            `protobuf:"bytes,3,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	Interpreter        string            `protobuf:"bytes,4,opt,name=interpreter,proto3" json:"interpreter,omitempty"`
+	InterpreterVersion string            `protobuf:"bytes,5,opt,name=interpreter_version,json=interpreterVersion,proto3" json:"interpreter_version,omitempty"`
+	Os                 string            `protobuf:"bytes,6,opt,name=os,proto3" json:"os,omitempty"`
+}
+
+func (x *TaskEnvironment) Reset() {
+	*x = TaskEnvironment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskEnvironment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEnvironment) ProtoMessage() {}
+
+func (x *TaskEnvironment) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEnvironment.ProtoReflect.Descriptor instead.
+func (*TaskEnvironment) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TaskEnvironment) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *TaskEnvironment) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *TaskEnvironment) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *TaskEnvironment) GetInterpreter() string {
+	if x != nil {
+		return x.Interpreter
+	}
+	return ""
+}
+
+func (x *TaskEnvironment) GetInterpreterVersion() string {
+	if x != nil {
+		return x.InterpreterVersion
+	}
+	return ""
+}
+
+func (x *TaskEnvironment) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+type Reschedule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromNode string `protobuf:"bytes,1,opt,name=from_node,json=fromNode,proto3" json:"from_node,omitempty"`
+	ToNode   string `protobuf:"bytes,2,opt,name=to_node,json=toNode,proto3" json:"to_node,omitempty"`
+	Time     int64  `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *Reschedule) Reset() {
+	*x = Reschedule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Reschedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reschedule) ProtoMessage() {}
+
+func (x *Reschedule) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reschedule.ProtoReflect.Descriptor instead.
+func (*Reschedule) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Reschedule) GetFromNode() string {
+	if x != nil {
+		return x.FromNode
+	}
+	return ""
+}
+
+func (x *Reschedule) GetToNode() string {
+	if x != nil {
+		return x.ToNode
+	}
+	return ""
+}
+
+func (x *Reschedule) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type GetJobsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Jobs []*Job `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+}
+
+func (x *GetJobsReply) Reset() {
+	*x = GetJobsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobsReply) ProtoMessage() {}
+
+func (x *GetJobsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobsReply.ProtoReflect.Descriptor instead.
+func (*GetJobsReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetJobsReply) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type GetOutputRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId  int32  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Node   string `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	Offset int64  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Tail   int32  `protobuf:"varint,4,opt,name=tail,proto3" json:"tail,omitempty"`
+	Timing bool   `protobuf:"varint,5,opt,name=timing,proto3" json:"timing,omitempty"`
+}
+
+func (x *GetOutputRequest) Reset() {
+	*x = GetOutputRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOutputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOutputRequest) ProtoMessage() {}
+
+func (x *GetOutputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOutputRequest.ProtoReflect.Descriptor instead.
+func (*GetOutputRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetOutputRequest) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *GetOutputRequest) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *GetOutputRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetOutputRequest) GetTail() int32 {
+	if x != nil {
+		return x.Tail
+	}
+	return 0
+}
+
+func (x *GetOutputRequest) GetTiming() bool {
+	if x != nil {
+		return x.Timing
+	}
+	return false
+}
+
+type GetOutputReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node   string    `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Stdout []byte    `protobuf:"bytes,2,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr []byte    `protobuf:"bytes,3,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	Task   *TaskSpan `protobuf:"bytes,4,opt,name=task,proto3" json:"task,omitempty"`
+}
+
+func (x *GetOutputReply) Reset() {
+	*x = GetOutputReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOutputReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOutputReply) ProtoMessage() {}
+
+func (x *GetOutputReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOutputReply.ProtoReflect.Descriptor instead.
+func (*GetOutputReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetOutputReply) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *GetOutputReply) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *GetOutputReply) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *GetOutputReply) GetTask() *TaskSpan {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type StartClusJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command          string                `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Nodes            []string              `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Pattern          string                `protobuf:"bytes,3,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Groups           []string              `protobuf:"bytes,4,rep,name=groups,proto3" json:"groups,omitempty"`
+	GroupsIntersect  bool                  `protobuf:"varint,5,opt,name=groups_intersect,json=groupsIntersect,proto3" json:"groups_intersect,omitempty"`
+	Sweep            string                `protobuf:"bytes,6,opt,name=sweep,proto3" json:"sweep,omitempty"`
+	Arguments        []string              `protobuf:"bytes,7,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	Name             string                `protobuf:"bytes,8,opt,name=name,proto3" json:"name,omitempty"`
+	MaxReschedules   int32                 `protobuf:"varint,9,opt,name=max_reschedules,json=maxReschedules,proto3" json:"max_reschedules,omitempty"`
+	Checkpoint       string                `protobuf:"bytes,10,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	ShipCheckpoint   bool                  `protobuf:"varint,11,opt,name=ship_checkpoint,json=shipCheckpoint,proto3" json:"ship_checkpoint,omitempty"`
+	BandwidthLimitKb int32                 `protobuf:"varint,12,opt,name=bandwidth_limit_kb,json=bandwidthLimitKb,proto3" json:"bandwidth_limit_kb,omitempty"`
+	WorkingDir       string                `protobuf:"bytes,13,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	RunAs            string                `protobuf:"bytes,14,opt,name=run_as,json=runAs,proto3" json:"run_as,omitempty"`
+	NodeCommands     map[string]string     `protobuf:"bytes,15,rep,name=node_commands,json=nodeCommands,proto3" json:"node_commands,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	JsonOutput       bool                  `protobuf:"varint,16,opt,name=json_output,json=jsonOutput,proto3" json:"json_output,omitempty"`
+	DispatchOrder    string                `protobuf:"bytes,17,opt,name=dispatch_order,json=dispatchOrder,proto3" json:"dispatch_order,omitempty"`
+	CaptureEnv       bool                  `protobuf:"varint,18,opt,name=capture_env,json=captureEnv,proto3" json:"capture_env,omitempty"`
+	Requirements     *ResourceRequirements `protobuf:"bytes,19,opt,name=requirements,proto3" json:"requirements,omitempty"`
+	Limits           *JobLimits            `protobuf:"bytes,20,opt,name=limits,proto3" json:"limits,omitempty"`
+	EnvMode          string                `protobuf:"bytes,21,opt,name=env_mode,json=envMode,proto3" json:"env_mode,omitempty"`
+	OutputWindow     *OutputWindow         `protobuf:"bytes,22,opt,name=output_window,json=outputWindow,proto3" json:"output_window,omitempty"`
+	Rolling          *RollingPolicy        `protobuf:"bytes,23,opt,name=rolling,proto3" json:"rolling,omitempty"`
+	FailFast         *FailFast             `protobuf:"bytes,24,opt,name=fail_fast,json=failFast,proto3" json:"fail_fast,omitempty"`
+	After            *JobDependency        `protobuf:"bytes,25,opt,name=after,proto3" json:"after,omitempty"`
+	ForwardStdin     bool                  `protobuf:"varint,26,opt,name=forward_stdin,json=forwardStdin,proto3" json:"forward_stdin,omitempty"`
+	Summary          bool                  `protobuf:"varint,27,opt,name=summary,proto3" json:"summary,omitempty"`
+	Labels           []string              `protobuf:"bytes,28,rep,name=labels,proto3" json:"labels,omitempty"`
+	Shell            string                `protobuf:"bytes,29,opt,name=shell,proto3" json:"shell,omitempty"`
+	OsCommands       map[string]string     `protobuf:"bytes,30,rep,name=os_commands,json=osCommands,proto3" json:"os_commands,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ScriptName       string                `protobuf:"bytes,31,opt,name=script_name,json=scriptName,proto3" json:"script_name,omitempty"`
+	Cluster          string                `protobuf:"bytes,32,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	RequestId        string                `protobuf:"bytes,33,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Exclude          string                `protobuf:"bytes,34,opt,name=exclude,proto3" json:"exclude,omitempty"`
+	DryRun           bool                  `protobuf:"varint,35,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Where            []string              `protobuf:"bytes,36,rep,name=where,proto3" json:"where,omitempty"`
+	RerunOf          int32                 `protobuf:"varint,37,opt,name=rerun_of,json=rerunOf,proto3" json:"rerun_of,omitempty"`
+	SweepValues      map[string]string     `protobuf:"bytes,38,rep,name=sweep_values,json=sweepValues,proto3" json:"sweep_values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Progress         bool                  `protobuf:"varint,39,opt,name=progress,proto3" json:"progress,omitempty"`
+	Executor         *JobExecutor          `protobuf:"bytes,40,opt,name=executor,proto3" json:"executor,omitempty"`
+	Peers            []string              `protobuf:"bytes,41,rep,name=peers,proto3" json:"peers,omitempty"`
+}
+
+func (x *StartClusJobRequest) Reset() {
+	*x = StartClusJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartClusJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartClusJobRequest) ProtoMessage() {}
+
+func (x *StartClusJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartClusJobRequest.ProtoReflect.Descriptor instead.
+func (*StartClusJobRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StartClusJobRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetGroupsIntersect() bool {
+	if x != nil {
+		return x.GroupsIntersect
+	}
+	return false
+}
+
+func (x *StartClusJobRequest) GetSweep() string {
+	if x != nil {
+		return x.Sweep
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetArguments() []string {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetMaxReschedules() int32 {
+	if x != nil {
+		return x.MaxReschedules
+	}
+	return 0
+}
+
+func (x *StartClusJobRequest) GetCheckpoint() string {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetShipCheckpoint() bool {
+	if x != nil {
+		return x.ShipCheckpoint
+	}
+	return false
+}
+
+func (x *StartClusJobRequest) GetBandwidthLimitKb() int32 {
+	if x != nil {
+		return x.BandwidthLimitKb
+	}
+	return 0
+}
+
+func (x *StartClusJobRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetRunAs() string {
+	if x != nil {
+		return x.RunAs
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetNodeCommands() map[string]string {
+	if x != nil {
+		return x.NodeCommands
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetJsonOutput() bool {
+	if x != nil {
+		return x.JsonOutput
+	}
+	return false
+}
+
+func (x *StartClusJobRequest) GetDispatchOrder() string {
+	if x != nil {
+		return x.DispatchOrder
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetCaptureEnv() bool {
+	if x != nil {
+		return x.CaptureEnv
+	}
+	return false
+}
+
+func (x *StartClusJobRequest) GetRequirements() *ResourceRequirements {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetLimits() *JobLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetEnvMode() string {
+	if x != nil {
+		return x.EnvMode
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetOutputWindow() *OutputWindow {
+	if x != nil {
+		return x.OutputWindow
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetRolling() *RollingPolicy {
+	if x != nil {
+		return x.Rolling
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetFailFast() *FailFast {
+	if x != nil {
+		return x.FailFast
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetAfter() *JobDependency {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetForwardStdin() bool {
+	if x != nil {
+		return x.ForwardStdin
+	}
+	return false
+}
+
+func (x *StartClusJobRequest) GetSummary() bool {
+	if x != nil {
+		return x.Summary
+	}
+	return false
+}
+
+func (x *StartClusJobRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetShell() string {
+	if x != nil {
+		return x.Shell
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetOsCommands() map[string]string {
+	if x != nil {
+		return x.OsCommands
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetScriptName() string {
+	if x != nil {
+		return x.ScriptName
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetExclude() string {
+	if x != nil {
+		return x.Exclude
+	}
+	return ""
+}
+
+func (x *StartClusJobRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *StartClusJobRequest) GetWhere() []string {
+	if x != nil {
+		return x.Where
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetRerunOf() int32 {
+	if x != nil {
+		return x.RerunOf
+	}
+	return 0
+}
+
+func (x *StartClusJobRequest) GetSweepValues() map[string]string {
+	if x != nil {
+		return x.SweepValues
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetProgress() bool {
+	if x != nil {
+		return x.Progress
+	}
+	return false
+}
+
+func (x *StartClusJobRequest) GetExecutor() *JobExecutor {
+	if x != nil {
+		return x.Executor
+	}
+	return nil
+}
+
+func (x *StartClusJobRequest) GetPeers() []string {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+type JobExecutor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Image  string   `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Mounts []string `protobuf:"bytes,3,rep,name=mounts,proto3" json:"mounts,omitempty"`
+}
+
+func (x *JobExecutor) Reset() {
+	*x = JobExecutor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobExecutor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobExecutor) ProtoMessage() {}
+
+func (x *JobExecutor) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobExecutor.ProtoReflect.Descriptor instead.
+func (*JobExecutor) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *JobExecutor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobExecutor) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *JobExecutor) GetMounts() []string {
+	if x != nil {
+		return x.Mounts
+	}
+	return nil
+}
+
+type RerunJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId      int32  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	FailedOnly bool   `protobuf:"varint,2,opt,name=failed_only,json=failedOnly,proto3" json:"failed_only,omitempty"`
+	Name       string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	RequestId  string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Progress   bool   `protobuf:"varint,5,opt,name=progress,proto3" json:"progress,omitempty"`
+}
+
+func (x *RerunJobRequest) Reset() {
+	*x = RerunJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RerunJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RerunJobRequest) ProtoMessage() {}
+
+func (x *RerunJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RerunJobRequest.ProtoReflect.Descriptor instead.
+func (*RerunJobRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RerunJobRequest) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *RerunJobRequest) GetFailedOnly() bool {
+	if x != nil {
+		return x.FailedOnly
+	}
+	return false
+}
+
+func (x *RerunJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RerunJobRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *RerunJobRequest) GetProgress() bool {
+	if x != nil {
+		return x.Progress
+	}
+	return false
+}
+
+type StartClusJobReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId         int32             `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Nodes         []string          `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Node          string            `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	Stdout        []byte            `protobuf:"bytes,4,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr        []byte            `protobuf:"bytes,5,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	ExitCode      int32             `protobuf:"zigzag32,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	RescheduledTo string            `protobuf:"bytes,7,opt,name=rescheduled_to,json=rescheduledTo,proto3" json:"rescheduled_to,omitempty"`
+	SkippedNodes  map[string]string `protobuf:"bytes,8,rep,name=skipped_nodes,json=skippedNodes,proto3" json:"skipped_nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Warnings      []*JobLintWarning `protobuf:"bytes,9,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	Summary       *JobSummary       `protobuf:"bytes,10,opt,name=summary,proto3" json:"summary,omitempty"`
+	TimedOut      bool              `protobuf:"varint,11,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	NodeCommands  map[string]string `protobuf:"bytes,12,rep,name=node_commands,json=nodeCommands,proto3" json:"node_commands,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SweepValues   map[string]string `protobuf:"bytes,13,rep,name=sweep_values,json=sweepValues,proto3" json:"sweep_values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Progress      *JobProgress      `protobuf:"bytes,14,opt,name=progress,proto3" json:"progress,omitempty"`
+	PeerJobs      map[string]int32  `protobuf:"bytes,15,rep,name=peer_jobs,json=peerJobs,proto3" json:"peer_jobs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	PeerErrors    map[string]string `protobuf:"bytes,16,rep,name=peer_errors,json=peerErrors,proto3" json:"peer_errors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *StartClusJobReply) Reset() {
+	*x = StartClusJobReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartClusJobReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartClusJobReply) ProtoMessage() {}
+
+func (x *StartClusJobReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartClusJobReply.ProtoReflect.Descriptor instead.
+func (*StartClusJobReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *StartClusJobReply) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *StartClusJobReply) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *StartClusJobReply) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *StartClusJobReply) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *StartClusJobReply) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *StartClusJobReply) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *StartClusJobReply) GetRescheduledTo() string {
+	if x != nil {
+		return x.RescheduledTo
+	}
+	return ""
+}
+
+func (x *StartClusJobReply) GetSkippedNodes() map[string]string {
+	if x != nil {
+		return x.SkippedNodes
+	}
+	return nil
+}
+
+func (x *StartClusJobReply) GetWarnings() []*JobLintWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *StartClusJobReply) GetSummary() *JobSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *StartClusJobReply) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+func (x *StartClusJobReply) GetNodeCommands() map[string]string {
+	if x != nil {
+		return x.NodeCommands
+	}
+	return nil
+}
+
+func (x *StartClusJobReply) GetSweepValues() map[string]string {
+	if x != nil {
+		return x.SweepValues
+	}
+	return nil
+}
+
+func (x *StartClusJobReply) GetProgress() *JobProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *StartClusJobReply) GetPeerJobs() map[string]int32 {
+	if x != nil {
+		return x.PeerJobs
+	}
+	return nil
+}
+
+func (x *StartClusJobReply) GetPeerErrors() map[string]string {
+	if x != nil {
+		return x.PeerErrors
+	}
+	return nil
+}
+
+type JobProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes         int32 `protobuf:"varint,1,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	FinishedNodes int32 `protobuf:"varint,2,opt,name=finished_nodes,json=finishedNodes,proto3" json:"finished_nodes,omitempty"`
+	FailedNodes   int32 `protobuf:"varint,3,opt,name=failed_nodes,json=failedNodes,proto3" json:"failed_nodes,omitempty"`
+	RunningNodes  int32 `protobuf:"varint,4,opt,name=running_nodes,json=runningNodes,proto3" json:"running_nodes,omitempty"`
+}
+
+func (x *JobProgress) Reset() {
+	*x = JobProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobProgress) ProtoMessage() {}
+
+func (x *JobProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobProgress.ProtoReflect.Descriptor instead.
+func (*JobProgress) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *JobProgress) GetNodes() int32 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+func (x *JobProgress) GetFinishedNodes() int32 {
+	if x != nil {
+		return x.FinishedNodes
+	}
+	return 0
+}
+
+func (x *JobProgress) GetFailedNodes() int32 {
+	if x != nil {
+		return x.FailedNodes
+	}
+	return 0
+}
+
+func (x *JobProgress) GetRunningNodes() int32 {
+	if x != nil {
+		return x.RunningNodes
+	}
+	return 0
+}
+
+type WatchClusJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId         int32            `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	StdoutOffsets map[string]int64 `protobuf:"bytes,2,rep,name=stdout_offsets,json=stdoutOffsets,proto3" json:"stdout_offsets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	StderrOffsets map[string]int64 `protobuf:"bytes,3,rep,name=stderr_offsets,json=stderrOffsets,proto3" json:"stderr_offsets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	EndedNodes    []string         `protobuf:"bytes,4,rep,name=ended_nodes,json=endedNodes,proto3" json:"ended_nodes,omitempty"`
+}
+
+func (x *WatchClusJobRequest) Reset() {
+	*x = WatchClusJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchClusJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchClusJobRequest) ProtoMessage() {}
+
+func (x *WatchClusJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchClusJobRequest.ProtoReflect.Descriptor instead.
+func (*WatchClusJobRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WatchClusJobRequest) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *WatchClusJobRequest) GetStdoutOffsets() map[string]int64 {
+	if x != nil {
+		return x.StdoutOffsets
+	}
+	return nil
+}
+
+func (x *WatchClusJobRequest) GetStderrOffsets() map[string]int64 {
+	if x != nil {
+		return x.StderrOffsets
+	}
+	return nil
+}
+
+func (x *WatchClusJobRequest) GetEndedNodes() []string {
+	if x != nil {
+		return x.EndedNodes
+	}
+	return nil
+}
+
+type JobLintWarning struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rule    string `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *JobLintWarning) Reset() {
+	*x = JobLintWarning{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobLintWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobLintWarning) ProtoMessage() {}
+
+func (x *JobLintWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobLintWarning.ProtoReflect.Descriptor instead.
+func (*JobLintWarning) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *JobLintWarning) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *JobLintWarning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CancelClusJobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobIds map[int32]bool `protobuf:"bytes,1,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *CancelClusJobsRequest) Reset() {
+	*x = CancelClusJobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelClusJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelClusJobsRequest) ProtoMessage() {}
+
+func (x *CancelClusJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelClusJobsRequest.ProtoReflect.Descriptor instead.
+func (*CancelClusJobsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CancelClusJobsRequest) GetJobIds() map[int32]bool {
+	if x != nil {
+		return x.JobIds
+	}
+	return nil
+}
+
+type CancelClusJobsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result        map[int32]JobState `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=clusrun.JobState"`
+	DelayedJobIds []int32            `protobuf:"varint,2,rep,packed,name=delayed_job_ids,json=delayedJobIds,proto3" json:"delayed_job_ids,omitempty"`
+	DelaySecond   int32              `protobuf:"varint,3,opt,name=delay_second,json=delaySecond,proto3" json:"delay_second,omitempty"`
+	UndoId        int32              `protobuf:"varint,4,opt,name=undo_id,json=undoId,proto3" json:"undo_id,omitempty"`
+}
+
+func (x *CancelClusJobsReply) Reset() {
+	*x = CancelClusJobsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelClusJobsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelClusJobsReply) ProtoMessage() {}
+
+func (x *CancelClusJobsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelClusJobsReply.ProtoReflect.Descriptor instead.
+func (*CancelClusJobsReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CancelClusJobsReply) GetResult() map[int32]JobState {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CancelClusJobsReply) GetDelayedJobIds() []int32 {
+	if x != nil {
+		return x.DelayedJobIds
+	}
+	return nil
+}
+
+func (x *CancelClusJobsReply) GetDelaySecond() int32 {
+	if x != nil {
+		return x.DelaySecond
+	}
+	return 0
+}
+
+func (x *CancelClusJobsReply) GetUndoId() int32 {
+	if x != nil {
+		return x.UndoId
+	}
+	return 0
+}
+
+type StartJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headnode         string        `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	JobId            int32         `protobuf:"varint,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Command          string        `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Arguments        []string      `protobuf:"bytes,4,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	Checkpoint       string        `protobuf:"bytes,5,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	ShipCheckpoint   bool          `protobuf:"varint,6,opt,name=ship_checkpoint,json=shipCheckpoint,proto3" json:"ship_checkpoint,omitempty"`
+	CheckpointData   []byte        `protobuf:"bytes,7,opt,name=checkpoint_data,json=checkpointData,proto3" json:"checkpoint_data,omitempty"`
+	OutputRateLimit  int64         `protobuf:"varint,8,opt,name=output_rate_limit,json=outputRateLimit,proto3" json:"output_rate_limit,omitempty"`
+	WorkingDir       string        `protobuf:"bytes,9,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	RunAs            string        `protobuf:"bytes,10,opt,name=run_as,json=runAs,proto3" json:"run_as,omitempty"`
+	ReportedHeadnode string        `protobuf:"bytes,11,opt,name=reported_headnode,json=reportedHeadnode,proto3" json:"reported_headnode,omitempty"`
+	CaptureEnv       bool          `protobuf:"varint,12,opt,name=capture_env,json=captureEnv,proto3" json:"capture_env,omitempty"`
+	Limits           *JobLimits    `protobuf:"bytes,13,opt,name=limits,proto3" json:"limits,omitempty"`
+	EnvMode          string        `protobuf:"bytes,14,opt,name=env_mode,json=envMode,proto3" json:"env_mode,omitempty"`
+	OutputWindow     *OutputWindow `protobuf:"bytes,15,opt,name=output_window,json=outputWindow,proto3" json:"output_window,omitempty"`
+	ForwardStdin     bool          `protobuf:"varint,16,opt,name=forward_stdin,json=forwardStdin,proto3" json:"forward_stdin,omitempty"`
+	Shell            string        `protobuf:"bytes,17,opt,name=shell,proto3" json:"shell,omitempty"`
+	ScriptName       string        `protobuf:"bytes,18,opt,name=script_name,json=scriptName,proto3" json:"script_name,omitempty"`
+	OutputLimit      int64         `protobuf:"varint,19,opt,name=output_limit,json=outputLimit,proto3" json:"output_limit,omitempty"`
+	Executor         *JobExecutor  `protobuf:"bytes,20,opt,name=executor,proto3" json:"executor,omitempty"`
+	Gpus             int32         `protobuf:"varint,21,opt,name=gpus,proto3" json:"gpus,omitempty"`
+}
+
+func (x *StartJobRequest) Reset() {
+	*x = StartJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartJobRequest) ProtoMessage() {}
+
+func (x *StartJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartJobRequest.ProtoReflect.Descriptor instead.
+func (*StartJobRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *StartJobRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *StartJobRequest) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *StartJobRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *StartJobRequest) GetArguments() []string {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *StartJobRequest) GetCheckpoint() string {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return ""
+}
+
+func (x *StartJobRequest) GetShipCheckpoint() bool {
+	if x != nil {
+		return x.ShipCheckpoint
+	}
+	return false
+}
+
+func (x *StartJobRequest) GetCheckpointData() []byte {
+	if x != nil {
+		return x.CheckpointData
+	}
+	return nil
+}
+
+func (x *StartJobRequest) GetOutputRateLimit() int64 {
+	if x != nil {
+		return x.OutputRateLimit
+	}
+	return 0
+}
+
+func (x *StartJobRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *StartJobRequest) GetRunAs() string {
+	if x != nil {
+		return x.RunAs
+	}
+	return ""
+}
+
+func (x *StartJobRequest) GetReportedHeadnode() string {
+	if x != nil {
+		return x.ReportedHeadnode
+	}
+	return ""
+}
+
+func (x *StartJobRequest) GetCaptureEnv() bool {
+	if x != nil {
+		return x.CaptureEnv
+	}
+	return false
+}
+
+func (x *StartJobRequest) GetLimits() *JobLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *StartJobRequest) GetEnvMode() string {
+	if x != nil {
+		return x.EnvMode
+	}
+	return ""
+}
+
+func (x *StartJobRequest) GetOutputWindow() *OutputWindow {
+	if x != nil {
+		return x.OutputWindow
+	}
+	return nil
+}
+
+func (x *StartJobRequest) GetForwardStdin() bool {
+	if x != nil {
+		return x.ForwardStdin
+	}
+	return false
+}
+
+func (x *StartJobRequest) GetShell() string {
+	if x != nil {
+		return x.Shell
+	}
+	return ""
+}
+
+func (x *StartJobRequest) GetScriptName() string {
+	if x != nil {
+		return x.ScriptName
+	}
+	return ""
+}
+
+func (x *StartJobRequest) GetOutputLimit() int64 {
+	if x != nil {
+		return x.OutputLimit
+	}
+	return 0
+}
+
+func (x *StartJobRequest) GetExecutor() *JobExecutor {
+	if x != nil {
+		return x.Executor
+	}
+	return nil
+}
+
+func (x *StartJobRequest) GetGpus() int32 {
+	if x != nil {
+		return x.Gpus
+	}
+	return 0
+}
+
+type StartJobReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stdout         []byte           `protobuf:"bytes,1,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr         []byte           `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	ExitCode       int32            `protobuf:"zigzag32,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	CheckpointData []byte           `protobuf:"bytes,4,opt,name=checkpoint_data,json=checkpointData,proto3" json:"checkpoint_data,omitempty"`
+	Environment    *TaskEnvironment `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	Checksum       *OutputChecksum  `protobuf:"bytes,6,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	TimedOut       bool             `protobuf:"varint,7,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	TruncatedSize  int64            `protobuf:"varint,8,opt,name=truncated_size,json=truncatedSize,proto3" json:"truncated_size,omitempty"`
+	StdoutSequence int64            `protobuf:"varint,9,opt,name=stdout_sequence,json=stdoutSequence,proto3" json:"stdout_sequence,omitempty"`
+	StderrSequence int64            `protobuf:"varint,10,opt,name=stderr_sequence,json=stderrSequence,proto3" json:"stderr_sequence,omitempty"`
+}
+
+func (x *StartJobReply) Reset() {
+	*x = StartJobReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartJobReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartJobReply) ProtoMessage() {}
+
+func (x *StartJobReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartJobReply.ProtoReflect.Descriptor instead.
+func (*StartJobReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *StartJobReply) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *StartJobReply) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *StartJobReply) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *StartJobReply) GetCheckpointData() []byte {
+	if x != nil {
+		return x.CheckpointData
+	}
+	return nil
+}
+
+func (x *StartJobReply) GetEnvironment() *TaskEnvironment {
+	if x != nil {
+		return x.Environment
+	}
+	return nil
+}
+
+func (x *StartJobReply) GetChecksum() *OutputChecksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+func (x *StartJobReply) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+func (x *StartJobReply) GetTruncatedSize() int64 {
+	if x != nil {
+		return x.TruncatedSize
+	}
+	return 0
+}
+
+func (x *StartJobReply) GetStdoutSequence() int64 {
+	if x != nil {
+		return x.StdoutSequence
+	}
+	return 0
+}
+
+func (x *StartJobReply) GetStderrSequence() int64 {
+	if x != nil {
+		return x.StderrSequence
+	}
+	return 0
+}
+
+type OutputChecksum struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StdoutSha256 string `protobuf:"bytes,1,opt,name=stdout_sha256,json=stdoutSha256,proto3" json:"stdout_sha256,omitempty"`
+	StderrSha256 string `protobuf:"bytes,2,opt,name=stderr_sha256,json=stderrSha256,proto3" json:"stderr_sha256,omitempty"`
+	StdoutSize   int64  `protobuf:"varint,3,opt,name=stdout_size,json=stdoutSize,proto3" json:"stdout_size,omitempty"`
+	StderrSize   int64  `protobuf:"varint,4,opt,name=stderr_size,json=stderrSize,proto3" json:"stderr_size,omitempty"`
+}
+
+func (x *OutputChecksum) Reset() {
+	*x = OutputChecksum{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutputChecksum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputChecksum) ProtoMessage() {}
+
+func (x *OutputChecksum) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputChecksum.ProtoReflect.Descriptor instead.
+func (*OutputChecksum) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *OutputChecksum) GetStdoutSha256() string {
+	if x != nil {
+		return x.StdoutSha256
+	}
+	return ""
+}
+
+func (x *OutputChecksum) GetStderrSha256() string {
+	if x != nil {
+		return x.StderrSha256
+	}
+	return ""
+}
+
+func (x *OutputChecksum) GetStdoutSize() int64 {
+	if x != nil {
+		return x.StdoutSize
+	}
+	return 0
+}
+
+func (x *OutputChecksum) GetStderrSize() int64 {
+	if x != nil {
+		return x.StderrSize
+	}
+	return 0
+}
+
+type QueryJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headnode string `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	JobId    int32  `protobuf:"varint,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+}
+
+func (x *QueryJobRequest) Reset() {
+	*x = QueryJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryJobRequest) ProtoMessage() {}
+
+func (x *QueryJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryJobRequest.ProtoReflect.Descriptor instead.
+func (*QueryJobRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *QueryJobRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *QueryJobRequest) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+type RelayJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path       []string             `protobuf:"bytes,1,rep,name=path,proto3" json:"path,omitempty"`
+	Request    *StartJobRequest     `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	Signatures []*HeadnodeSignature `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty"`
+}
+
+func (x *RelayJobRequest) Reset() {
+	*x = RelayJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RelayJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelayJobRequest) ProtoMessage() {}
+
+func (x *RelayJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelayJobRequest.ProtoReflect.Descriptor instead.
+func (*RelayJobRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RelayJobRequest) GetPath() []string {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *RelayJobRequest) GetRequest() *StartJobRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *RelayJobRequest) GetSignatures() []*HeadnodeSignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+type HeadnodeSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headnode  string `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	Timestamp int64  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Signature string `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *HeadnodeSignature) Reset() {
+	*x = HeadnodeSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeadnodeSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadnodeSignature) ProtoMessage() {}
+
+func (x *HeadnodeSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeadnodeSignature.ProtoReflect.Descriptor instead.
+func (*HeadnodeSignature) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *HeadnodeSignature) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *HeadnodeSignature) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *HeadnodeSignature) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type ResumeJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headnode     string `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	JobId        int32  `protobuf:"varint,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	StdoutOffset int64  `protobuf:"varint,3,opt,name=stdout_offset,json=stdoutOffset,proto3" json:"stdout_offset,omitempty"`
+	StderrOffset int64  `protobuf:"varint,4,opt,name=stderr_offset,json=stderrOffset,proto3" json:"stderr_offset,omitempty"`
+}
+
+func (x *ResumeJobRequest) Reset() {
+	*x = ResumeJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumeJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeJobRequest) ProtoMessage() {}
+
+func (x *ResumeJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeJobRequest.ProtoReflect.Descriptor instead.
+func (*ResumeJobRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ResumeJobRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *ResumeJobRequest) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *ResumeJobRequest) GetStdoutOffset() int64 {
+	if x != nil {
+		return x.StdoutOffset
+	}
+	return 0
+}
+
+func (x *ResumeJobRequest) GetStderrOffset() int64 {
+	if x != nil {
+		return x.StderrOffset
+	}
+	return 0
+}
+
+type QueryJobReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State    TaskState `protobuf:"varint,1,opt,name=state,proto3,enum=clusrun.TaskState" json:"state,omitempty"`
+	ExitCode int32     `protobuf:"zigzag32,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	TimedOut bool      `protobuf:"varint,3,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+}
+
+func (x *QueryJobReply) Reset() {
+	*x = QueryJobReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryJobReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryJobReply) ProtoMessage() {}
+
+func (x *QueryJobReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryJobReply.ProtoReflect.Descriptor instead.
+func (*QueryJobReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *QueryJobReply) GetState() TaskState {
+	if x != nil {
+		return x.State
+	}
+	return TaskState_TaskUnknown
+}
+
+func (x *QueryJobReply) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *QueryJobReply) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+type CancelJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headnode string `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	JobId    int32  `protobuf:"varint,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Force    bool   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CancelJobRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *CancelJobRequest) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *CancelJobRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type ValidateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headnode                string     `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	Clusnode                string     `protobuf:"bytes,2,opt,name=clusnode,proto3" json:"clusnode,omitempty"`
+	ReportedHeadnode        string     `protobuf:"bytes,3,opt,name=reported_headnode,json=reportedHeadnode,proto3" json:"reported_headnode,omitempty"`
+	Key                     []byte     `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Nonce                   []byte     `protobuf:"bytes,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Build                   *NodeBuild `protobuf:"bytes,6,opt,name=build,proto3" json:"build,omitempty"`
+	HeartbeatIntervalSecond int32      `protobuf:"varint,7,opt,name=heartbeat_interval_second,json=heartbeatIntervalSecond,proto3" json:"heartbeat_interval_second,omitempty"`
+	KeySignature            string     `protobuf:"bytes,8,opt,name=key_signature,json=keySignature,proto3" json:"key_signature,omitempty"`
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ValidateRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *ValidateRequest) GetClusnode() string {
+	if x != nil {
+		return x.Clusnode
+	}
+	return ""
+}
+
+func (x *ValidateRequest) GetReportedHeadnode() string {
+	if x != nil {
+		return x.ReportedHeadnode
+	}
+	return ""
+}
+
+func (x *ValidateRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *ValidateRequest) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+func (x *ValidateRequest) GetBuild() *NodeBuild {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+func (x *ValidateRequest) GetHeartbeatIntervalSecond() int32 {
+	if x != nil {
+		return x.HeartbeatIntervalSecond
+	}
+	return 0
+}
+
+func (x *ValidateRequest) GetKeySignature() string {
+	if x != nil {
+		return x.KeySignature
+	}
+	return ""
+}
+
+type ValidateReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodename        string      `protobuf:"bytes,1,opt,name=nodename,proto3" json:"nodename,omitempty"`
+	Signature       string      `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Build           *NodeBuild  `protobuf:"bytes,3,opt,name=build,proto3" json:"build,omitempty"`
+	System          *NodeSystem `protobuf:"bytes,4,opt,name=system,proto3" json:"system,omitempty"`
+	SecretSignature string      `protobuf:"bytes,5,opt,name=secret_signature,json=secretSignature,proto3" json:"secret_signature,omitempty"`
+}
+
+func (x *ValidateReply) Reset() {
+	*x = ValidateReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateReply) ProtoMessage() {}
+
+func (x *ValidateReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateReply.ProtoReflect.Descriptor instead.
+func (*ValidateReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ValidateReply) GetNodename() string {
+	if x != nil {
+		return x.Nodename
+	}
+	return ""
+}
+
+func (x *ValidateReply) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *ValidateReply) GetBuild() *NodeBuild {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+func (x *ValidateReply) GetSystem() *NodeSystem {
+	if x != nil {
+		return x.System
+	}
+	return nil
+}
+
+func (x *ValidateReply) GetSecretSignature() string {
+	if x != nil {
+		return x.SecretSignature
+	}
+	return ""
+}
+
+type SetNodeGroupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Groups []string `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	Nodes  []*Node  `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Remove bool     `protobuf:"varint,3,opt,name=remove,proto3" json:"remove,omitempty"`
+}
+
+func (x *SetNodeGroupsRequest) Reset() {
+	*x = SetNodeGroupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetNodeGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNodeGroupsRequest) ProtoMessage() {}
+
+func (x *SetNodeGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNodeGroupsRequest.ProtoReflect.Descriptor instead.
+func (*SetNodeGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SetNodeGroupsRequest) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *SetNodeGroupsRequest) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *SetNodeGroupsRequest) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type SetNodeLabelsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes        []string          `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Labels       map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	RemoveLabels []string          `protobuf:"bytes,3,rep,name=remove_labels,json=removeLabels,proto3" json:"remove_labels,omitempty"`
+}
+
+func (x *SetNodeLabelsRequest) Reset() {
+	*x = SetNodeLabelsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetNodeLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNodeLabelsRequest) ProtoMessage() {}
+
+func (x *SetNodeLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNodeLabelsRequest.ProtoReflect.Descriptor instead.
+func (*SetNodeLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SetNodeLabelsRequest) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *SetNodeLabelsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *SetNodeLabelsRequest) GetRemoveLabels() []string {
+	if x != nil {
+		return x.RemoveLabels
+	}
+	return nil
+}
+
+type SetHeadnodesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headnodes []string         `protobuf:"bytes,1,rep,name=headnodes,proto3" json:"headnodes,omitempty"`
+	Mode      SetHeadnodesMode `protobuf:"varint,2,opt,name=mode,proto3,enum=clusrun.SetHeadnodesMode" json:"mode,omitempty"`
+}
+
+func (x *SetHeadnodesRequest) Reset() {
+	*x = SetHeadnodesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetHeadnodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetHeadnodesRequest) ProtoMessage() {}
+
+func (x *SetHeadnodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetHeadnodesRequest.ProtoReflect.Descriptor instead.
+func (*SetHeadnodesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SetHeadnodesRequest) GetHeadnodes() []string {
+	if x != nil {
+		return x.Headnodes
+	}
+	return nil
+}
+
+func (x *SetHeadnodesRequest) GetMode() SetHeadnodesMode {
+	if x != nil {
+		return x.Mode
+	}
+	return SetHeadnodesMode_Default
+}
+
+type SetHeadnodesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results map[string]string `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *SetHeadnodesReply) Reset() {
+	*x = SetHeadnodesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetHeadnodesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetHeadnodesReply) ProtoMessage() {}
+
+func (x *SetHeadnodesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetHeadnodesReply.ProtoReflect.Descriptor instead.
+func (*SetHeadnodesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SetHeadnodesReply) GetResults() map[string]string {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type SetConfigsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Configs  map[string]string `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Nodes    []string          `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	DryRun   bool              `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Rollback bool              `protobuf:"varint,4,opt,name=rollback,proto3" json:"rollback,omitempty"`
+}
+
+func (x *SetConfigsRequest) Reset() {
+	*x = SetConfigsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetConfigsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConfigsRequest) ProtoMessage() {}
+
+func (x *SetConfigsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConfigsRequest.ProtoReflect.Descriptor instead.
+func (*SetConfigsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SetConfigsRequest) GetConfigs() map[string]string {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
+func (x *SetConfigsRequest) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *SetConfigsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *SetConfigsRequest) GetRollback() bool {
+	if x != nil {
+		return x.Rollback
+	}
+	return false
+}
+
+type SetConfigsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results     map[string]string             `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	NodeResults map[string]*NodeConfigsResult `protobuf:"bytes,2,rep,name=node_results,json=nodeResults,proto3" json:"node_results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *SetConfigsReply) Reset() {
+	*x = SetConfigsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetConfigsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConfigsReply) ProtoMessage() {}
+
+func (x *SetConfigsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConfigsReply.ProtoReflect.Descriptor instead.
+func (*SetConfigsReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SetConfigsReply) GetResults() map[string]string {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SetConfigsReply) GetNodeResults() map[string]*NodeConfigsResult {
+	if x != nil {
+		return x.NodeResults
+	}
+	return nil
+}
+
+type NodeConfigsResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results map[string]string `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Error   string            `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *NodeConfigsResult) Reset() {
+	*x = NodeConfigsResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeConfigsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeConfigsResult) ProtoMessage() {}
+
+func (x *NodeConfigsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeConfigsResult.ProtoReflect.Descriptor instead.
+func (*NodeConfigsResult) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *NodeConfigsResult) GetResults() map[string]string {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *NodeConfigsResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ApplyConfigsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headnode string            `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	Configs  map[string]string `protobuf:"bytes,2,rep,name=configs,proto3" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DryRun   bool              `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Rollback bool              `protobuf:"varint,4,opt,name=rollback,proto3" json:"rollback,omitempty"`
+}
+
+func (x *ApplyConfigsRequest) Reset() {
+	*x = ApplyConfigsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyConfigsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyConfigsRequest) ProtoMessage() {}
+
+func (x *ApplyConfigsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyConfigsRequest.ProtoReflect.Descriptor instead.
+func (*ApplyConfigsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ApplyConfigsRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *ApplyConfigsRequest) GetConfigs() map[string]string {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
+func (x *ApplyConfigsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ApplyConfigsRequest) GetRollback() bool {
+	if x != nil {
+		return x.Rollback
+	}
+	return false
+}
+
+type GetConfigsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Configs   map[string]string `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Sensitive []string          `protobuf:"bytes,2,rep,name=sensitive,proto3" json:"sensitive,omitempty"`
+}
+
+func (x *GetConfigsReply) Reset() {
+	*x = GetConfigsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConfigsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigsReply) ProtoMessage() {}
+
+func (x *GetConfigsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigsReply.ProtoReflect.Descriptor instead.
+func (*GetConfigsReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetConfigsReply) GetConfigs() map[string]string {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
+func (x *GetConfigsReply) GetSensitive() []string {
+	if x != nil {
+		return x.Sensitive
+	}
+	return nil
+}
+
+type ReportConfigsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headnode string `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
+}
+
+func (x *ReportConfigsRequest) Reset() {
+	*x = ReportConfigsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportConfigsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportConfigsRequest) ProtoMessage() {}
+
+func (x *ReportConfigsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportConfigsRequest.ProtoReflect.Descriptor instead.
+func (*ReportConfigsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ReportConfigsRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+type GetConfigDriftRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes     []string          `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Configs   []string          `protobuf:"bytes,2,rep,name=configs,proto3" json:"configs,omitempty"`
+	Baseline  map[string]string `protobuf:"bytes,3,rep,name=baseline,proto3" json:"baseline,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Remediate bool              `protobuf:"varint,4,opt,name=remediate,proto3" json:"remediate,omitempty"`
+	DryRun    bool              `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (x *GetConfigDriftRequest) Reset() {
+	*x = GetConfigDriftRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConfigDriftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigDriftRequest) ProtoMessage() {}
+
+func (x *GetConfigDriftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigDriftRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigDriftRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetConfigDriftRequest) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *GetConfigDriftRequest) GetConfigs() []string {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
+func (x *GetConfigDriftRequest) GetBaseline() map[string]string {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+func (x *GetConfigDriftRequest) GetRemediate() bool {
+	if x != nil {
+		return x.Remediate
+	}
+	return false
+}
+
+func (x *GetConfigDriftRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type GetConfigDriftReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Baseline     map[string]string             `protobuf:"bytes,1,rep,name=baseline,proto3" json:"baseline,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Drifts       []*ConfigDrift                `protobuf:"bytes,2,rep,name=drifts,proto3" json:"drifts,omitempty"`
+	NodeErrors   map[string]string             `protobuf:"bytes,3,rep,name=node_errors,json=nodeErrors,proto3" json:"node_errors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Remediations map[string]*NodeConfigsResult `protobuf:"bytes,4,rep,name=remediations,proto3" json:"remediations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Nodes        int32                         `protobuf:"varint,5,opt,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (x *GetConfigDriftReply) Reset() {
+	*x = GetConfigDriftReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConfigDriftReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigDriftReply) ProtoMessage() {}
+
+func (x *GetConfigDriftReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigDriftReply.ProtoReflect.Descriptor instead.
+func (*GetConfigDriftReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GetConfigDriftReply) GetBaseline() map[string]string {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+func (x *GetConfigDriftReply) GetDrifts() []*ConfigDrift {
+	if x != nil {
+		return x.Drifts
+	}
+	return nil
+}
+
+func (x *GetConfigDriftReply) GetNodeErrors() map[string]string {
+	if x != nil {
+		return x.NodeErrors
+	}
+	return nil
+}
+
+func (x *GetConfigDriftReply) GetRemediations() map[string]*NodeConfigsResult {
+	if x != nil {
+		return x.Remediations
+	}
+	return nil
+}
+
+func (x *GetConfigDriftReply) GetNodes() int32 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+type ConfigDrift struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node   string            `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Values map[string]string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ConfigDrift) Reset() {
+	*x = ConfigDrift{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigDrift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigDrift) ProtoMessage() {}
+
+func (x *ConfigDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigDrift.ProtoReflect.Descriptor instead.
+func (*ConfigDrift) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ConfigDrift) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *ConfigDrift) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type GetNodeAvailabilityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pattern       string  `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	WindowSeconds []int64 `protobuf:"varint,2,rep,packed,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	Cluster       string  `protobuf:"bytes,3,opt,name=cluster,proto3" json:"cluster,omitempty"`
+}
+
+func (x *GetNodeAvailabilityRequest) Reset() {
+	*x = GetNodeAvailabilityRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNodeAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeAvailabilityRequest) ProtoMessage() {}
+
+func (x *GetNodeAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*GetNodeAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetNodeAvailabilityRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *GetNodeAvailabilityRequest) GetWindowSeconds() []int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return nil
+}
+
+func (x *GetNodeAvailabilityRequest) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+type GetNodeAvailabilityReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes []*NodeAvailability `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (x *GetNodeAvailabilityReply) Reset() {
+	*x = GetNodeAvailabilityReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNodeAvailabilityReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeAvailabilityReply) ProtoMessage() {}
+
+func (x *GetNodeAvailabilityReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeAvailabilityReply.ProtoReflect.Descriptor instead.
+func (*GetNodeAvailabilityReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetNodeAvailabilityReply) GetNodes() []*NodeAvailability {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type NodeAvailability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node    string        `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Up      bool          `protobuf:"varint,2,opt,name=up,proto3" json:"up,omitempty"`
+	Since   int64         `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`
+	Uptimes []*NodeUptime `protobuf:"bytes,4,rep,name=uptimes,proto3" json:"uptimes,omitempty"`
+}
+
+func (x *NodeAvailability) Reset() {
+	*x = NodeAvailability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeAvailability) ProtoMessage() {}
+
+func (x *NodeAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeAvailability.ProtoReflect.Descriptor instead.
+func (*NodeAvailability) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *NodeAvailability) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *NodeAvailability) GetUp() bool {
+	if x != nil {
+		return x.Up
+	}
+	return false
+}
+
+func (x *NodeAvailability) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *NodeAvailability) GetUptimes() []*NodeUptime {
+	if x != nil {
+		return x.Uptimes
+	}
+	return nil
+}
+
+type NodeUptime struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WindowSeconds int64   `protobuf:"varint,1,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	Percent       float64 `protobuf:"fixed64,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	Downs         int32   `protobuf:"varint,3,opt,name=downs,proto3" json:"downs,omitempty"`
+	DownSeconds   int64   `protobuf:"varint,4,opt,name=down_seconds,json=downSeconds,proto3" json:"down_seconds,omitempty"`
+}
+
+func (x *NodeUptime) Reset() {
+	*x = NodeUptime{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeUptime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeUptime) ProtoMessage() {}
+
+func (x *NodeUptime) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeUptime.ProtoReflect.Descriptor instead.
+func (*NodeUptime) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *NodeUptime) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *NodeUptime) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *NodeUptime) GetDowns() int32 {
+	if x != nil {
+		return x.Downs
+	}
+	return 0
+}
+
+func (x *NodeUptime) GetDownSeconds() int64 {
+	if x != nil {
+		return x.DownSeconds
+	}
+	return 0
+}
+
+type GetCapabilitiesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Capabilities map[string]bool `protobuf:"bytes,1,rep,name=capabilities,proto3" json:"capabilities,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *GetCapabilitiesReply) Reset() {
+	*x = GetCapabilitiesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCapabilitiesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesReply) ProtoMessage() {}
+
+func (x *GetCapabilitiesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesReply.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetCapabilitiesReply) GetCapabilities() map[string]bool {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+type GetClusterSummaryReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes       int32            `protobuf:"varint,1,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	NodeStates  map[string]int32 `protobuf:"bytes,2,rep,name=node_states,json=nodeStates,proto3" json:"node_states,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	NodeGroups  map[string]int32 `protobuf:"bytes,3,rep,name=node_groups,json=nodeGroups,proto3" json:"node_groups,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	RunningJobs int32            `protobuf:"varint,4,opt,name=running_jobs,json=runningJobs,proto3" json:"running_jobs,omitempty"`
+	QueuedJobs  int32            `protobuf:"varint,5,opt,name=queued_jobs,json=queuedJobs,proto3" json:"queued_jobs,omitempty"`
+	Uptime      int64            `protobuf:"varint,6,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	StdoutBytes int64            `protobuf:"varint,7,opt,name=stdout_bytes,json=stdoutBytes,proto3" json:"stdout_bytes,omitempty"`
+	StderrBytes int64            `protobuf:"varint,8,opt,name=stderr_bytes,json=stderrBytes,proto3" json:"stderr_bytes,omitempty"`
+}
+
+func (x *GetClusterSummaryReply) Reset() {
+	*x = GetClusterSummaryReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetClusterSummaryReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClusterSummaryReply) ProtoMessage() {}
+
+func (x *GetClusterSummaryReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClusterSummaryReply.ProtoReflect.Descriptor instead.
+func (*GetClusterSummaryReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetClusterSummaryReply) GetNodes() int32 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+func (x *GetClusterSummaryReply) GetNodeStates() map[string]int32 {
+	if x != nil {
+		return x.NodeStates
+	}
+	return nil
+}
+
+func (x *GetClusterSummaryReply) GetNodeGroups() map[string]int32 {
+	if x != nil {
+		return x.NodeGroups
+	}
+	return nil
+}
+
+func (x *GetClusterSummaryReply) GetRunningJobs() int32 {
+	if x != nil {
+		return x.RunningJobs
+	}
+	return 0
+}
+
+func (x *GetClusterSummaryReply) GetQueuedJobs() int32 {
+	if x != nil {
+		return x.QueuedJobs
+	}
+	return 0
+}
+
+func (x *GetClusterSummaryReply) GetUptime() int64 {
+	if x != nil {
+		return x.Uptime
+	}
+	return 0
+}
+
+func (x *GetClusterSummaryReply) GetStdoutBytes() int64 {
+	if x != nil {
+		return x.StdoutBytes
+	}
+	return 0
+}
+
+func (x *GetClusterSummaryReply) GetStderrBytes() int64 {
+	if x != nil {
+		return x.StderrBytes
+	}
+	return 0
+}
+
+type FileChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path   string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	IsDir  bool   `protobuf:"varint,2,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`
+	Mode   uint32 `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	Data   []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Eof    bool   `protobuf:"varint,5,opt,name=eof,proto3" json:"eof,omitempty"`
+	Sha256 string `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
+}
+
+func (x *FileChunk) Reset() {
+	*x = FileChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChunk) ProtoMessage() {}
+
+func (x *FileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChunk.ProtoReflect.Descriptor instead.
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *FileChunk) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileChunk) GetIsDir() bool {
+	if x != nil {
+		return x.IsDir
+	}
+	return false
+}
+
+func (x *FileChunk) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *FileChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *FileChunk) GetEof() bool {
+	if x != nil {
+		return x.Eof
+	}
+	return false
+}
+
+func (x *FileChunk) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type UploadFilesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes           []string   `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Pattern         string     `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Groups          []string   `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+	GroupsIntersect bool       `protobuf:"varint,4,opt,name=groups_intersect,json=groupsIntersect,proto3" json:"groups_intersect,omitempty"`
+	Destination     string     `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+	Chunk           *FileChunk `protobuf:"bytes,6,opt,name=chunk,proto3" json:"chunk,omitempty"`
+}
+
+func (x *UploadFilesRequest) Reset() {
+	*x = UploadFilesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFilesRequest) ProtoMessage() {}
+
+func (x *UploadFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFilesRequest.ProtoReflect.Descriptor instead.
+func (*UploadFilesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *UploadFilesRequest) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *UploadFilesRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *UploadFilesRequest) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *UploadFilesRequest) GetGroupsIntersect() bool {
+	if x != nil {
+		return x.GroupsIntersect
+	}
+	return false
+}
+
+func (x *UploadFilesRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *UploadFilesRequest) GetChunk() *FileChunk {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type UploadFilesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results map[string]string `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *UploadFilesReply) Reset() {
+	*x = UploadFilesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadFilesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFilesReply) ProtoMessage() {}
+
+func (x *UploadFilesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFilesReply.ProtoReflect.Descriptor instead.
+func (*UploadFilesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *UploadFilesReply) GetResults() map[string]string {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ReceiveFilesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headnode    string     `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	Destination string     `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Chunk       *FileChunk `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+}
+
+func (x *ReceiveFilesRequest) Reset() {
+	*x = ReceiveFilesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReceiveFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveFilesRequest) ProtoMessage() {}
+
+func (x *ReceiveFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveFilesRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveFilesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ReceiveFilesRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *ReceiveFilesRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *ReceiveFilesRequest) GetChunk() *FileChunk {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type ReceiveFilesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Files int32 `protobuf:"varint,1,opt,name=files,proto3" json:"files,omitempty"`
+	Bytes int64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+}
+
+func (x *ReceiveFilesReply) Reset() {
+	*x = ReceiveFilesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReceiveFilesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveFilesReply) ProtoMessage() {}
+
+func (x *ReceiveFilesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveFilesReply.ProtoReflect.Descriptor instead.
+func (*ReceiveFilesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ReceiveFilesReply) GetFiles() int32 {
+	if x != nil {
+		return x.Files
+	}
+	return 0
+}
+
+func (x *ReceiveFilesReply) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+type GatherFilesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId int32    `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Paths []string `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	Nodes []string `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (x *GatherFilesRequest) Reset() {
+	*x = GatherFilesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GatherFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GatherFilesRequest) ProtoMessage() {}
+
+func (x *GatherFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GatherFilesRequest.ProtoReflect.Descriptor instead.
+func (*GatherFilesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *GatherFilesRequest) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *GatherFilesRequest) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *GatherFilesRequest) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type GatherFilesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results map[string]string `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Dir     string            `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`
+}
+
+func (x *GatherFilesReply) Reset() {
+	*x = GatherFilesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GatherFilesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GatherFilesReply) ProtoMessage() {}
+
+func (x *GatherFilesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GatherFilesReply.ProtoReflect.Descriptor instead.
+func (*GatherFilesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GatherFilesReply) GetResults() map[string]string {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *GatherFilesReply) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+type SendFilesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headnode   string   `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	Paths      []string `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	WorkingDir string   `protobuf:"bytes,3,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+}
+
+func (x *SendFilesRequest) Reset() {
+	*x = SendFilesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendFilesRequest) ProtoMessage() {}
+
+func (x *SendFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendFilesRequest.ProtoReflect.Descriptor instead.
+func (*SendFilesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *SendFilesRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *SendFilesRequest) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *SendFilesRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+type ResetNodeKeysRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes []string `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (x *ResetNodeKeysRequest) Reset() {
+	*x = ResetNodeKeysRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResetNodeKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetNodeKeysRequest) ProtoMessage() {}
+
+func (x *ResetNodeKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetNodeKeysRequest.ProtoReflect.Descriptor instead.
+func (*ResetNodeKeysRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ResetNodeKeysRequest) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type ResetNodeKeysReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results map[string]string `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ResetNodeKeysReply) Reset() {
+	*x = ResetNodeKeysReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResetNodeKeysReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetNodeKeysReply) ProtoMessage() {}
+
+func (x *ResetNodeKeysReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetNodeKeysReply.ProtoReflect.Descriptor instead.
+func (*ResetNodeKeysReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ResetNodeKeysReply) GetResults() map[string]string {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type DrainNodesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes   []string `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Undrain bool     `protobuf:"varint,2,opt,name=undrain,proto3" json:"undrain,omitempty"`
+}
+
+func (x *DrainNodesRequest) Reset() {
+	*x = DrainNodesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrainNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainNodesRequest) ProtoMessage() {}
+
+func (x *DrainNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainNodesRequest.ProtoReflect.Descriptor instead.
+func (*DrainNodesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *DrainNodesRequest) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *DrainNodesRequest) GetUndrain() bool {
+	if x != nil {
+		return x.Undrain
+	}
+	return false
+}
+
+type DrainNodesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results map[string]string `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *DrainNodesReply) Reset() {
+	*x = DrainNodesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrainNodesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainNodesReply) ProtoMessage() {}
+
+func (x *DrainNodesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainNodesReply.ProtoReflect.Descriptor instead.
+func (*DrainNodesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *DrainNodesReply) GetResults() map[string]string {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type RemoveNodesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pattern string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+}
+
+func (x *RemoveNodesRequest) Reset() {
+	*x = RemoveNodesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveNodesRequest) ProtoMessage() {}
+
+func (x *RemoveNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveNodesRequest.ProtoReflect.Descriptor instead.
+func (*RemoveNodesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *RemoveNodesRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+type RemoveNodesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RemovedNodes []string `protobuf:"bytes,1,rep,name=removed_nodes,json=removedNodes,proto3" json:"removed_nodes,omitempty"`
+	NotLostNodes []string `protobuf:"bytes,2,rep,name=not_lost_nodes,json=notLostNodes,proto3" json:"not_lost_nodes,omitempty"`
+	UndoId       int32    `protobuf:"varint,3,opt,name=undo_id,json=undoId,proto3" json:"undo_id,omitempty"`
+}
+
+func (x *RemoveNodesReply) Reset() {
+	*x = RemoveNodesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveNodesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveNodesReply) ProtoMessage() {}
+
+func (x *RemoveNodesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveNodesReply.ProtoReflect.Descriptor instead.
+func (*RemoveNodesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *RemoveNodesReply) GetRemovedNodes() []string {
+	if x != nil {
+		return x.RemovedNodes
+	}
+	return nil
+}
+
+func (x *RemoveNodesReply) GetNotLostNodes() []string {
+	if x != nil {
+		return x.NotLostNodes
+	}
+	return nil
+}
+
+func (x *RemoveNodesReply) GetUndoId() int32 {
+	if x != nil {
+		return x.UndoId
+	}
+	return 0
+}
+
+type RevalidateNodesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pattern     string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Concurrency int32  `protobuf:"varint,2,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+}
+
+func (x *RevalidateNodesRequest) Reset() {
+	*x = RevalidateNodesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[83]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevalidateNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevalidateNodesRequest) ProtoMessage() {}
+
+func (x *RevalidateNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[83]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevalidateNodesRequest.ProtoReflect.Descriptor instead.
+func (*RevalidateNodesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *RevalidateNodesRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *RevalidateNodesRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+type RevalidateNodesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValidatedNodes []string          `protobuf:"bytes,1,rep,name=validated_nodes,json=validatedNodes,proto3" json:"validated_nodes,omitempty"`
+	FailedNodes    map[string]string `protobuf:"bytes,2,rep,name=failed_nodes,json=failedNodes,proto3" json:"failed_nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *RevalidateNodesReply) Reset() {
+	*x = RevalidateNodesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[84]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevalidateNodesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevalidateNodesReply) ProtoMessage() {}
+
+func (x *RevalidateNodesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[84]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevalidateNodesReply.ProtoReflect.Descriptor instead.
+func (*RevalidateNodesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *RevalidateNodesReply) GetValidatedNodes() []string {
+	if x != nil {
+		return x.ValidatedNodes
+	}
+	return nil
+}
+
+func (x *RevalidateNodesReply) GetFailedNodes() map[string]string {
+	if x != nil {
+		return x.FailedNodes
+	}
+	return nil
+}
+
+type TestNodesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pattern     string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Concurrency int32  `protobuf:"varint,2,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	SkipJob     bool   `protobuf:"varint,3,opt,name=skip_job,json=skipJob,proto3" json:"skip_job,omitempty"`
+}
+
+func (x *TestNodesRequest) Reset() {
+	*x = TestNodesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[85]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestNodesRequest) ProtoMessage() {}
+
+func (x *TestNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[85]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestNodesRequest.ProtoReflect.Descriptor instead.
+func (*TestNodesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *TestNodesRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *TestNodesRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *TestNodesRequest) GetSkipJob() bool {
+	if x != nil {
+		return x.SkipJob
+	}
+	return false
+}
+
+type TestNodesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*NodeTestResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *TestNodesReply) Reset() {
+	*x = TestNodesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[86]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestNodesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestNodesReply) ProtoMessage() {}
+
+func (x *TestNodesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[86]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestNodesReply.ProtoReflect.Descriptor instead.
+func (*TestNodesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *TestNodesReply) GetResults() []*NodeTestResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type NodeTestResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node           string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	HeartbeatAgeMs int64  `protobuf:"varint,2,opt,name=heartbeat_age_ms,json=heartbeatAgeMs,proto3" json:"heartbeat_age_ms,omitempty"`
+	RoundTripMs    int64  `protobuf:"varint,3,opt,name=round_trip_ms,json=roundTripMs,proto3" json:"round_trip_ms,omitempty"`
+	ClockSkewMs    int64  `protobuf:"varint,4,opt,name=clock_skew_ms,json=clockSkewMs,proto3" json:"clock_skew_ms,omitempty"`
+	JobMs          int64  `protobuf:"varint,5,opt,name=job_ms,json=jobMs,proto3" json:"job_ms,omitempty"`
+	Error          string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *NodeTestResult) Reset() {
+	*x = NodeTestResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[87]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeTestResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeTestResult) ProtoMessage() {}
+
+func (x *NodeTestResult) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[87]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeTestResult.ProtoReflect.Descriptor instead.
+func (*NodeTestResult) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *NodeTestResult) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *NodeTestResult) GetHeartbeatAgeMs() int64 {
+	if x != nil {
+		return x.HeartbeatAgeMs
+	}
+	return 0
+}
+
+func (x *NodeTestResult) GetRoundTripMs() int64 {
+	if x != nil {
+		return x.RoundTripMs
+	}
+	return 0
+}
+
+func (x *NodeTestResult) GetClockSkewMs() int64 {
+	if x != nil {
+		return x.ClockSkewMs
+	}
+	return 0
+}
+
+func (x *NodeTestResult) GetJobMs() int64 {
+	if x != nil {
+		return x.JobMs
+	}
+	return 0
+}
+
+func (x *NodeTestResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ProbeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headnode string `protobuf:"bytes,1,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	RunJob   bool   `protobuf:"varint,2,opt,name=run_job,json=runJob,proto3" json:"run_job,omitempty"`
+}
+
+func (x *ProbeRequest) Reset() {
+	*x = ProbeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[88]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeRequest) ProtoMessage() {}
+
+func (x *ProbeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[88]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeRequest.ProtoReflect.Descriptor instead.
+func (*ProbeRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ProbeRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *ProbeRequest) GetRunJob() bool {
+	if x != nil {
+		return x.RunJob
+	}
+	return false
+}
+
+type ProbeReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TimeUnixNano int64  `protobuf:"varint,1,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	JobMs        int64  `protobuf:"varint,2,opt,name=job_ms,json=jobMs,proto3" json:"job_ms,omitempty"`
+	JobError     string `protobuf:"bytes,3,opt,name=job_error,json=jobError,proto3" json:"job_error,omitempty"`
+}
+
+func (x *ProbeReply) Reset() {
+	*x = ProbeReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[89]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeReply) ProtoMessage() {}
+
+func (x *ProbeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[89]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeReply.ProtoReflect.Descriptor instead.
+func (*ProbeReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ProbeReply) GetTimeUnixNano() int64 {
+	if x != nil {
+		return x.TimeUnixNano
+	}
+	return 0
+}
+
+func (x *ProbeReply) GetJobMs() int64 {
+	if x != nil {
+		return x.JobMs
+	}
+	return 0
+}
+
+func (x *ProbeReply) GetJobError() string {
+	if x != nil {
+		return x.JobError
+	}
+	return ""
+}
+
+type JobTemplate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Command     string   `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Arguments   []string `protobuf:"bytes,4,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	Nodes       []string `protobuf:"bytes,5,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Pattern     string   `protobuf:"bytes,6,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Groups      []string `protobuf:"bytes,7,rep,name=groups,proto3" json:"groups,omitempty"`
+	Sweep       string   `protobuf:"bytes,8,opt,name=sweep,proto3" json:"sweep,omitempty"`
+	EnvMode     string   `protobuf:"bytes,9,opt,name=env_mode,json=envMode,proto3" json:"env_mode,omitempty"`
+}
+
+func (x *JobTemplate) Reset() {
+	*x = JobTemplate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[90]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobTemplate) ProtoMessage() {}
+
+func (x *JobTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[90]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobTemplate.ProtoReflect.Descriptor instead.
+func (*JobTemplate) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *JobTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobTemplate) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *JobTemplate) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *JobTemplate) GetArguments() []string {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *JobTemplate) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *JobTemplate) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *JobTemplate) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *JobTemplate) GetSweep() string {
+	if x != nil {
+		return x.Sweep
+	}
+	return ""
+}
+
+func (x *JobTemplate) GetEnvMode() string {
+	if x != nil {
+		return x.EnvMode
+	}
+	return ""
+}
+
+type GetJobTemplatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *GetJobTemplatesRequest) Reset() {
+	*x = GetJobTemplatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[91]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobTemplatesRequest) ProtoMessage() {}
+
+func (x *GetJobTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[91]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*GetJobTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GetJobTemplatesRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type GetJobTemplatesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Templates []*JobTemplate `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+}
+
+func (x *GetJobTemplatesReply) Reset() {
+	*x = GetJobTemplatesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[92]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobTemplatesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobTemplatesReply) ProtoMessage() {}
+
+func (x *GetJobTemplatesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[92]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobTemplatesReply.ProtoReflect.Descriptor instead.
+func (*GetJobTemplatesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GetJobTemplatesReply) GetTemplates() []*JobTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type DeleteJobTemplatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *DeleteJobTemplatesRequest) Reset() {
+	*x = DeleteJobTemplatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[93]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteJobTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobTemplatesRequest) ProtoMessage() {}
+
+func (x *DeleteJobTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[93]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*DeleteJobTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *DeleteJobTemplatesRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type DeleteJobTemplatesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deleted  []string `protobuf:"bytes,1,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	NotFound []string `protobuf:"bytes,2,rep,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+}
+
+func (x *DeleteJobTemplatesReply) Reset() {
+	*x = DeleteJobTemplatesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[94]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteJobTemplatesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobTemplatesReply) ProtoMessage() {}
+
+func (x *DeleteJobTemplatesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[94]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobTemplatesReply.ProtoReflect.Descriptor instead.
+func (*DeleteJobTemplatesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *DeleteJobTemplatesReply) GetDeleted() []string {
+	if x != nil {
+		return x.Deleted
+	}
+	return nil
+}
+
+func (x *DeleteJobTemplatesReply) GetNotFound() []string {
+	if x != nil {
+		return x.NotFound
+	}
+	return nil
+}
+
+type JobSchedule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cron        string               `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`
+	Job         *StartClusJobRequest `protobuf:"bytes,3,opt,name=job,proto3" json:"job,omitempty"`
+	Disabled    bool                 `protobuf:"varint,4,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	NextRunTime int64                `protobuf:"varint,5,opt,name=next_run_time,json=nextRunTime,proto3" json:"next_run_time,omitempty"`
+	LastRunTime int64                `protobuf:"varint,6,opt,name=last_run_time,json=lastRunTime,proto3" json:"last_run_time,omitempty"`
+	LastJobId   int32                `protobuf:"varint,7,opt,name=last_job_id,json=lastJobId,proto3" json:"last_job_id,omitempty"`
+	LastError   string               `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+}
+
+func (x *JobSchedule) Reset() {
+	*x = JobSchedule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[95]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobSchedule) ProtoMessage() {}
+
+func (x *JobSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[95]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobSchedule.ProtoReflect.Descriptor instead.
+func (*JobSchedule) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *JobSchedule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobSchedule) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *JobSchedule) GetJob() *StartClusJobRequest {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *JobSchedule) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *JobSchedule) GetNextRunTime() int64 {
+	if x != nil {
+		return x.NextRunTime
+	}
+	return 0
+}
+
+func (x *JobSchedule) GetLastRunTime() int64 {
+	if x != nil {
+		return x.LastRunTime
+	}
+	return 0
+}
+
+func (x *JobSchedule) GetLastJobId() int32 {
+	if x != nil {
+		return x.LastJobId
+	}
+	return 0
+}
+
+func (x *JobSchedule) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type GetJobSchedulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *GetJobSchedulesRequest) Reset() {
+	*x = GetJobSchedulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[96]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobSchedulesRequest) ProtoMessage() {}
+
+func (x *GetJobSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[96]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*GetJobSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *GetJobSchedulesRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type GetJobSchedulesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schedules []*JobSchedule `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+}
+
+func (x *GetJobSchedulesReply) Reset() {
+	*x = GetJobSchedulesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[97]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobSchedulesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobSchedulesReply) ProtoMessage() {}
+
+func (x *GetJobSchedulesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[97]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobSchedulesReply.ProtoReflect.Descriptor instead.
+func (*GetJobSchedulesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *GetJobSchedulesReply) GetSchedules() []*JobSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type SetJobSchedulesEnabledRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names   []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	Enabled bool     `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *SetJobSchedulesEnabledRequest) Reset() {
+	*x = SetJobSchedulesEnabledRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[98]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetJobSchedulesEnabledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetJobSchedulesEnabledRequest) ProtoMessage() {}
+
+func (x *SetJobSchedulesEnabledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[98]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetJobSchedulesEnabledRequest.ProtoReflect.Descriptor instead.
+func (*SetJobSchedulesEnabledRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *SetJobSchedulesEnabledRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *SetJobSchedulesEnabledRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetJobSchedulesEnabledReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Updated  []string `protobuf:"bytes,1,rep,name=updated,proto3" json:"updated,omitempty"`
+	NotFound []string `protobuf:"bytes,2,rep,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+}
+
+func (x *SetJobSchedulesEnabledReply) Reset() {
+	*x = SetJobSchedulesEnabledReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[99]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetJobSchedulesEnabledReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetJobSchedulesEnabledReply) ProtoMessage() {}
+
+func (x *SetJobSchedulesEnabledReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[99]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetJobSchedulesEnabledReply.ProtoReflect.Descriptor instead.
+func (*SetJobSchedulesEnabledReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *SetJobSchedulesEnabledReply) GetUpdated() []string {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *SetJobSchedulesEnabledReply) GetNotFound() []string {
+	if x != nil {
+		return x.NotFound
+	}
+	return nil
+}
+
+type DeleteJobSchedulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *DeleteJobSchedulesRequest) Reset() {
+	*x = DeleteJobSchedulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[100]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteJobSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobSchedulesRequest) ProtoMessage() {}
+
+func (x *DeleteJobSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[100]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*DeleteJobSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *DeleteJobSchedulesRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type DeleteJobSchedulesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deleted  []string `protobuf:"bytes,1,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	NotFound []string `protobuf:"bytes,2,rep,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+}
+
+func (x *DeleteJobSchedulesReply) Reset() {
+	*x = DeleteJobSchedulesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[101]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteJobSchedulesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobSchedulesReply) ProtoMessage() {}
+
+func (x *DeleteJobSchedulesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[101]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobSchedulesReply.ProtoReflect.Descriptor instead.
+func (*DeleteJobSchedulesReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *DeleteJobSchedulesReply) GetDeleted() []string {
+	if x != nil {
+		return x.Deleted
+	}
+	return nil
+}
+
+func (x *DeleteJobSchedulesReply) GetNotFound() []string {
+	if x != nil {
+		return x.NotFound
+	}
+	return nil
+}
+
+type JobBookmark struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	JobId      int32  `protobuf:"varint,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	CreateTime int64  `protobuf:"varint,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+}
+
+func (x *JobBookmark) Reset() {
+	*x = JobBookmark{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[102]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobBookmark) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobBookmark) ProtoMessage() {}
+
+func (x *JobBookmark) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[102]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobBookmark.ProtoReflect.Descriptor instead.
+func (*JobBookmark) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *JobBookmark) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobBookmark) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *JobBookmark) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+type SaveJobBookmarkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User     string       `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Bookmark *JobBookmark `protobuf:"bytes,2,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+}
+
+func (x *SaveJobBookmarkRequest) Reset() {
+	*x = SaveJobBookmarkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[103]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveJobBookmarkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveJobBookmarkRequest) ProtoMessage() {}
+
+func (x *SaveJobBookmarkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[103]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveJobBookmarkRequest.ProtoReflect.Descriptor instead.
+func (*SaveJobBookmarkRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *SaveJobBookmarkRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *SaveJobBookmarkRequest) GetBookmark() *JobBookmark {
+	if x != nil {
+		return x.Bookmark
+	}
+	return nil
+}
+
+type GetJobBookmarksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User  string   `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Names []string `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *GetJobBookmarksRequest) Reset() {
+	*x = GetJobBookmarksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[104]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobBookmarksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobBookmarksRequest) ProtoMessage() {}
+
+func (x *GetJobBookmarksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[104]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobBookmarksRequest.ProtoReflect.Descriptor instead.
+func (*GetJobBookmarksRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *GetJobBookmarksRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *GetJobBookmarksRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type GetJobBookmarksReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bookmarks []*JobBookmark `protobuf:"bytes,1,rep,name=bookmarks,proto3" json:"bookmarks,omitempty"`
+}
+
+func (x *GetJobBookmarksReply) Reset() {
+	*x = GetJobBookmarksReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[105]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobBookmarksReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobBookmarksReply) ProtoMessage() {}
+
+func (x *GetJobBookmarksReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[105]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobBookmarksReply.ProtoReflect.Descriptor instead.
+func (*GetJobBookmarksReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *GetJobBookmarksReply) GetBookmarks() []*JobBookmark {
+	if x != nil {
+		return x.Bookmarks
+	}
+	return nil
+}
+
+type DeleteJobBookmarksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User  string   `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Names []string `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *DeleteJobBookmarksRequest) Reset() {
+	*x = DeleteJobBookmarksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[106]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteJobBookmarksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobBookmarksRequest) ProtoMessage() {}
+
+func (x *DeleteJobBookmarksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[106]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobBookmarksRequest.ProtoReflect.Descriptor instead.
+func (*DeleteJobBookmarksRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *DeleteJobBookmarksRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *DeleteJobBookmarksRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type DeleteJobBookmarksReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deleted  []string `protobuf:"bytes,1,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	NotFound []string `protobuf:"bytes,2,rep,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`
+}
+
+func (x *DeleteJobBookmarksReply) Reset() {
+	*x = DeleteJobBookmarksReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[107]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteJobBookmarksReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobBookmarksReply) ProtoMessage() {}
+
+func (x *DeleteJobBookmarksReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[107]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobBookmarksReply.ProtoReflect.Descriptor instead.
+func (*DeleteJobBookmarksReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *DeleteJobBookmarksReply) GetDeleted() []string {
+	if x != nil {
+		return x.Deleted
+	}
+	return nil
+}
+
+func (x *DeleteJobBookmarksReply) GetNotFound() []string {
+	if x != nil {
+		return x.NotFound
+	}
+	return nil
+}
+
+type TerminalSize struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows int32 `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Cols int32 `protobuf:"varint,2,opt,name=cols,proto3" json:"cols,omitempty"`
+}
+
+func (x *TerminalSize) Reset() {
+	*x = TerminalSize{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[108]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TerminalSize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminalSize) ProtoMessage() {}
+
+func (x *TerminalSize) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[108]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminalSize.ProtoReflect.Descriptor instead.
+func (*TerminalSize) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *TerminalSize) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *TerminalSize) GetCols() int32 {
+	if x != nil {
+		return x.Cols
+	}
+	return 0
+}
+
+type ShellRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node             string        `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Headnode         string        `protobuf:"bytes,2,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	ReportedHeadnode string        `protobuf:"bytes,3,opt,name=reported_headnode,json=reportedHeadnode,proto3" json:"reported_headnode,omitempty"`
+	Command          string        `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
+	RunAs            string        `protobuf:"bytes,5,opt,name=run_as,json=runAs,proto3" json:"run_as,omitempty"`
+	Term             string        `protobuf:"bytes,6,opt,name=term,proto3" json:"term,omitempty"`
+	Pty              bool          `protobuf:"varint,7,opt,name=pty,proto3" json:"pty,omitempty"`
+	Size             *TerminalSize `protobuf:"bytes,8,opt,name=size,proto3" json:"size,omitempty"`
+	Input            []byte        `protobuf:"bytes,9,opt,name=input,proto3" json:"input,omitempty"`
+	CloseInput       bool          `protobuf:"varint,10,opt,name=close_input,json=closeInput,proto3" json:"close_input,omitempty"`
+	Interrupt        bool          `protobuf:"varint,11,opt,name=interrupt,proto3" json:"interrupt,omitempty"`
+}
+
+func (x *ShellRequest) Reset() {
+	*x = ShellRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[109]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShellRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellRequest) ProtoMessage() {}
+
+func (x *ShellRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[109]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShellRequest.ProtoReflect.Descriptor instead.
+func (*ShellRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *ShellRequest) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *ShellRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *ShellRequest) GetReportedHeadnode() string {
+	if x != nil {
+		return x.ReportedHeadnode
+	}
+	return ""
+}
+
+func (x *ShellRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ShellRequest) GetRunAs() string {
+	if x != nil {
+		return x.RunAs
+	}
+	return ""
+}
+
+func (x *ShellRequest) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *ShellRequest) GetPty() bool {
+	if x != nil {
+		return x.Pty
+	}
+	return false
+}
+
+func (x *ShellRequest) GetSize() *TerminalSize {
+	if x != nil {
+		return x.Size
+	}
+	return nil
+}
+
+func (x *ShellRequest) GetInput() []byte {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *ShellRequest) GetCloseInput() bool {
+	if x != nil {
+		return x.CloseInput
+	}
+	return false
+}
+
+func (x *ShellRequest) GetInterrupt() bool {
+	if x != nil {
+		return x.Interrupt
+	}
+	return false
+}
+
+type ShellReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Output   []byte `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+	Exited   bool   `protobuf:"varint,2,opt,name=exited,proto3" json:"exited,omitempty"`
+	ExitCode int32  `protobuf:"zigzag32,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Pty      bool   `protobuf:"varint,4,opt,name=pty,proto3" json:"pty,omitempty"`
+}
+
+func (x *ShellReply) Reset() {
+	*x = ShellReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[110]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShellReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellReply) ProtoMessage() {}
+
+func (x *ShellReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[110]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShellReply.ProtoReflect.Descriptor instead.
+func (*ShellReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *ShellReply) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *ShellReply) GetExited() bool {
+	if x != nil {
+		return x.Exited
+	}
+	return false
+}
+
+func (x *ShellReply) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ShellReply) GetPty() bool {
+	if x != nil {
+		return x.Pty
+	}
+	return false
+}
+
+type UndoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *UndoRequest) Reset() {
+	*x = UndoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[111]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UndoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoRequest) ProtoMessage() {}
+
+func (x *UndoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[111]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoRequest.ProtoReflect.Descriptor instead.
+func (*UndoRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *UndoRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UndoOperation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	ExpireTime  int64  `protobuf:"varint,3,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+}
+
+func (x *UndoOperation) Reset() {
+	*x = UndoOperation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[112]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UndoOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoOperation) ProtoMessage() {}
+
+func (x *UndoOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[112]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoOperation.ProtoReflect.Descriptor instead.
+func (*UndoOperation) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *UndoOperation) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UndoOperation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UndoOperation) GetExpireTime() int64 {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return 0
+}
+
+type UndoReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operations []*UndoOperation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	Result     string           `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *UndoReply) Reset() {
+	*x = UndoReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[113]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UndoReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoReply) ProtoMessage() {}
+
+func (x *UndoReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[113]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoReply.ProtoReflect.Descriptor instead.
+func (*UndoReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *UndoReply) GetOperations() []*UndoOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *UndoReply) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+type PurgeJobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DryRun bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (x *PurgeJobsRequest) Reset() {
+	*x = PurgeJobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[114]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PurgeJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeJobsRequest) ProtoMessage() {}
+
+func (x *PurgeJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[114]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeJobsRequest.ProtoReflect.Descriptor instead.
+func (*PurgeJobsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *PurgeJobsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type PurgeJobsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobIds     []int32 `protobuf:"varint,1,rep,packed,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"`
+	FreedBytes int64   `protobuf:"varint,2,opt,name=freed_bytes,json=freedBytes,proto3" json:"freed_bytes,omitempty"`
+}
+
+func (x *PurgeJobsReply) Reset() {
+	*x = PurgeJobsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[115]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PurgeJobsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeJobsReply) ProtoMessage() {}
+
+func (x *PurgeJobsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[115]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeJobsReply.ProtoReflect.Descriptor instead.
+func (*PurgeJobsReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *PurgeJobsReply) GetJobIds() []int32 {
+	if x != nil {
+		return x.JobIds
+	}
+	return nil
+}
+
+func (x *PurgeJobsReply) GetFreedBytes() int64 {
+	if x != nil {
+		return x.FreedBytes
+	}
+	return 0
+}
+
+type ExportJobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Since      int64 `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	Until      int64 `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`
+	WithOutput bool  `protobuf:"varint,3,opt,name=with_output,json=withOutput,proto3" json:"with_output,omitempty"`
+}
+
+func (x *ExportJobsRequest) Reset() {
+	*x = ExportJobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[116]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportJobsRequest) ProtoMessage() {}
+
+func (x *ExportJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[116]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportJobsRequest.ProtoReflect.Descriptor instead.
+func (*ExportJobsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ExportJobsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *ExportJobsRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *ExportJobsRequest) GetWithOutput() bool {
+	if x != nil {
+		return x.WithOutput
+	}
+	return false
+}
+
+type ExportJobsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Job    *Job   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Output []byte `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+}
+
+func (x *ExportJobsReply) Reset() {
+	*x = ExportJobsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[117]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportJobsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportJobsReply) ProtoMessage() {}
+
+func (x *ExportJobsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[117]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportJobsReply.ProtoReflect.Descriptor instead.
+func (*ExportJobsReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *ExportJobsReply) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *ExportJobsReply) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+type ImportJobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Job    *Job   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Output []byte `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+}
+
+func (x *ImportJobsRequest) Reset() {
+	*x = ImportJobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[118]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJobsRequest) ProtoMessage() {}
+
+func (x *ImportJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[118]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJobsRequest.ProtoReflect.Descriptor instead.
+func (*ImportJobsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *ImportJobsRequest) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *ImportJobsRequest) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+type ImportJobsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobIds      map[int32]int32 `protobuf:"bytes,1,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	OutputFiles int32           `protobuf:"varint,2,opt,name=output_files,json=outputFiles,proto3" json:"output_files,omitempty"`
+}
+
+func (x *ImportJobsReply) Reset() {
+	*x = ImportJobsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[119]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportJobsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJobsReply) ProtoMessage() {}
+
+func (x *ImportJobsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[119]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJobsReply.ProtoReflect.Descriptor instead.
+func (*ImportJobsReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *ImportJobsReply) GetJobIds() map[int32]int32 {
+	if x != nil {
+		return x.JobIds
+	}
+	return nil
+}
+
+func (x *ImportJobsReply) GetOutputFiles() int32 {
+	if x != nil {
+		return x.OutputFiles
+	}
+	return 0
+}
+
+type QueryResultsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId      int32  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Expression string `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+}
+
+func (x *QueryResultsRequest) Reset() {
+	*x = QueryResultsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[120]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResultsRequest) ProtoMessage() {}
+
+func (x *QueryResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[120]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResultsRequest.ProtoReflect.Descriptor instead.
+func (*QueryResultsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *QueryResultsRequest) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *QueryResultsRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+type QueryResultsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Columns []string           `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	Rows    []*QueryResultsRow `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	Nodes   int32              `protobuf:"varint,3,opt,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (x *QueryResultsReply) Reset() {
+	*x = QueryResultsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[121]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryResultsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResultsReply) ProtoMessage() {}
+
+func (x *QueryResultsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[121]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResultsReply.ProtoReflect.Descriptor instead.
+func (*QueryResultsReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *QueryResultsReply) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *QueryResultsReply) GetRows() []*QueryResultsRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *QueryResultsReply) GetNodes() int32 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+type QueryResultsRow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node   string   `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Values []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *QueryResultsRow) Reset() {
+	*x = QueryResultsRow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[122]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryResultsRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResultsRow) ProtoMessage() {}
+
+func (x *QueryResultsRow) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[122]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResultsRow.ProtoReflect.Descriptor instead.
+func (*QueryResultsRow) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *QueryResultsRow) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *QueryResultsRow) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type SearchOutputRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text       string         `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	JobIds     map[int32]bool `protobuf:"bytes,2,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	MaxMatches int32          `protobuf:"varint,3,opt,name=max_matches,json=maxMatches,proto3" json:"max_matches,omitempty"`
+}
+
+func (x *SearchOutputRequest) Reset() {
+	*x = SearchOutputRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[123]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchOutputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOutputRequest) ProtoMessage() {}
+
+func (x *SearchOutputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[123]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOutputRequest.ProtoReflect.Descriptor instead.
+func (*SearchOutputRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *SearchOutputRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SearchOutputRequest) GetJobIds() map[int32]bool {
+	if x != nil {
+		return x.JobIds
+	}
+	return nil
+}
+
+func (x *SearchOutputRequest) GetMaxMatches() int32 {
+	if x != nil {
+		return x.MaxMatches
+	}
+	return 0
+}
+
+type SearchOutputReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Matches   []*SearchOutputMatch `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	Truncated bool                 `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+}
+
+func (x *SearchOutputReply) Reset() {
+	*x = SearchOutputReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[124]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchOutputReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOutputReply) ProtoMessage() {}
+
+func (x *SearchOutputReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[124]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOutputReply.ProtoReflect.Descriptor instead.
+func (*SearchOutputReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *SearchOutputReply) GetMatches() []*SearchOutputMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *SearchOutputReply) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type SearchOutputMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId  int32  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Node   string `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	Stderr bool   `protobuf:"varint,3,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	Line   int32  `protobuf:"varint,4,opt,name=line,proto3" json:"line,omitempty"`
+	Text   string `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *SearchOutputMatch) Reset() {
+	*x = SearchOutputMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[125]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchOutputMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOutputMatch) ProtoMessage() {}
+
+func (x *SearchOutputMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[125]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOutputMatch.ProtoReflect.Descriptor instead.
+func (*SearchOutputMatch) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *SearchOutputMatch) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *SearchOutputMatch) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *SearchOutputMatch) GetStderr() bool {
+	if x != nil {
+		return x.Stderr
+	}
+	return false
+}
+
+func (x *SearchOutputMatch) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *SearchOutputMatch) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ExportTimelineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId int32 `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+}
+
+func (x *ExportTimelineRequest) Reset() {
+	*x = ExportTimelineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[126]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTimelineRequest) ProtoMessage() {}
+
+func (x *ExportTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[126]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTimelineRequest.ProtoReflect.Descriptor instead.
+func (*ExportTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *ExportTimelineRequest) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+type ExportTimelineReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Trace string `protobuf:"bytes,1,opt,name=trace,proto3" json:"trace,omitempty"`
+}
+
+func (x *ExportTimelineReply) Reset() {
+	*x = ExportTimelineReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[127]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportTimelineReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTimelineReply) ProtoMessage() {}
+
+func (x *ExportTimelineReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[127]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTimelineReply.ProtoReflect.Descriptor instead.
+func (*ExportTimelineReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *ExportTimelineReply) GetTrace() string {
+	if x != nil {
+		return x.Trace
+	}
+	return ""
+}
+
+type JobInputRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId            int32  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Data             []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Close            bool   `protobuf:"varint,3,opt,name=close,proto3" json:"close,omitempty"`
+	Headnode         string `protobuf:"bytes,4,opt,name=headnode,proto3" json:"headnode,omitempty"`
+	ReportedHeadnode string `protobuf:"bytes,5,opt,name=reported_headnode,json=reportedHeadnode,proto3" json:"reported_headnode,omitempty"`
+}
+
+func (x *JobInputRequest) Reset() {
+	*x = JobInputRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[128]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobInputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobInputRequest) ProtoMessage() {}
+
+func (x *JobInputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[128]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobInputRequest.ProtoReflect.Descriptor instead.
+func (*JobInputRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *JobInputRequest) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *JobInputRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *JobInputRequest) GetClose() bool {
+	if x != nil {
+		return x.Close
+	}
+	return false
+}
+
+func (x *JobInputRequest) GetHeadnode() string {
+	if x != nil {
+		return x.Headnode
+	}
+	return ""
+}
+
+func (x *JobInputRequest) GetReportedHeadnode() string {
+	if x != nil {
+		return x.ReportedHeadnode
+	}
+	return ""
+}
+
+type JobInputReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Written     int64             `protobuf:"varint,1,opt,name=written,proto3" json:"written,omitempty"`
+	FailedNodes map[string]string `protobuf:"bytes,2,rep,name=failed_nodes,json=failedNodes,proto3" json:"failed_nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *JobInputReply) Reset() {
+	*x = JobInputReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[129]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobInputReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobInputReply) ProtoMessage() {}
+
+func (x *JobInputReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[129]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobInputReply.ProtoReflect.Descriptor instead.
+func (*JobInputReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *JobInputReply) GetWritten() int64 {
+	if x != nil {
+		return x.Written
+	}
+	return 0
+}
+
+func (x *JobInputReply) GetFailedNodes() map[string]string {
+	if x != nil {
+		return x.FailedNodes
+	}
+	return nil
+}
+
+type GetLoginOptionsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Password     bool   `protobuf:"varint,1,opt,name=password,proto3" json:"password,omitempty"`
+	OidcIssuer   string `protobuf:"bytes,2,opt,name=oidc_issuer,json=oidcIssuer,proto3" json:"oidc_issuer,omitempty"`
+	OidcClientId string `protobuf:"bytes,3,opt,name=oidc_client_id,json=oidcClientId,proto3" json:"oidc_client_id,omitempty"`
+}
+
+func (x *GetLoginOptionsReply) Reset() {
+	*x = GetLoginOptionsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[130]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLoginOptionsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginOptionsReply) ProtoMessage() {}
+
+func (x *GetLoginOptionsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[130]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginOptionsReply.ProtoReflect.Descriptor instead.
+func (*GetLoginOptionsReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *GetLoginOptionsReply) GetPassword() bool {
+	if x != nil {
+		return x.Password
+	}
+	return false
+}
+
+func (x *GetLoginOptionsReply) GetOidcIssuer() string {
+	if x != nil {
+		return x.OidcIssuer
+	}
+	return ""
+}
+
+func (x *GetLoginOptionsReply) GetOidcClientId() string {
+	if x != nil {
+		return x.OidcClientId
+	}
+	return ""
+}
+
+type LoginReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token     string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	User      string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Role      string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	Expire    int64  `protobuf:"varint,4,opt,name=expire,proto3" json:"expire,omitempty"`
+	MaxExpire int64  `protobuf:"varint,5,opt,name=max_expire,json=maxExpire,proto3" json:"max_expire,omitempty"`
+	Cluster   string `protobuf:"bytes,6,opt,name=cluster,proto3" json:"cluster,omitempty"`
+}
+
+func (x *LoginReply) Reset() {
+	*x = LoginReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[131]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoginReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginReply) ProtoMessage() {}
+
+func (x *LoginReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[131]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginReply.ProtoReflect.Descriptor instead.
+func (*LoginReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *LoginReply) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LoginReply) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *LoginReply) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *LoginReply) GetExpire() int64 {
+	if x != nil {
+		return x.Expire
+	}
+	return 0
+}
+
+func (x *LoginReply) GetMaxExpire() int64 {
+	if x != nil {
+		return x.MaxExpire
+	}
+	return 0
+}
+
+func (x *LoginReply) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+type SubscribeEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExcludeNodes bool   `protobuf:"varint,1,opt,name=exclude_nodes,json=excludeNodes,proto3" json:"exclude_nodes,omitempty"`
+	ExcludeJobs  bool   `protobuf:"varint,2,opt,name=exclude_jobs,json=excludeJobs,proto3" json:"exclude_jobs,omitempty"`
+	NodePattern  string `protobuf:"bytes,3,opt,name=node_pattern,json=nodePattern,proto3" json:"node_pattern,omitempty"`
+}
+
+func (x *SubscribeEventsRequest) Reset() {
+	*x = SubscribeEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[132]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[132]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *SubscribeEventsRequest) GetExcludeNodes() bool {
+	if x != nil {
+		return x.ExcludeNodes
+	}
+	return false
+}
+
+func (x *SubscribeEventsRequest) GetExcludeJobs() bool {
+	if x != nil {
+		return x.ExcludeJobs
+	}
+	return false
+}
+
+func (x *SubscribeEventsRequest) GetNodePattern() string {
+	if x != nil {
+		return x.NodePattern
+	}
+	return ""
+}
+
+type ClusterEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time int64      `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Node *NodeEvent `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	Job  *JobEvent  `protobuf:"bytes,3,opt,name=job,proto3" json:"job,omitempty"`
+}
+
+func (x *ClusterEvent) Reset() {
+	*x = ClusterEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[133]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClusterEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterEvent) ProtoMessage() {}
+
+func (x *ClusterEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[133]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterEvent.ProtoReflect.Descriptor instead.
+func (*ClusterEvent) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *ClusterEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *ClusterEvent) GetNode() *NodeEvent {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *ClusterEvent) GetJob() *JobEvent {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type NodeEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	From NodeState `protobuf:"varint,2,opt,name=from,proto3,enum=clusrun.NodeState" json:"from,omitempty"`
+	To   NodeState `protobuf:"varint,3,opt,name=to,proto3,enum=clusrun.NodeState" json:"to,omitempty"`
+}
+
+func (x *NodeEvent) Reset() {
+	*x = NodeEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[134]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeEvent) ProtoMessage() {}
+
+func (x *NodeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[134]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeEvent.ProtoReflect.Descriptor instead.
+func (*NodeEvent) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *NodeEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NodeEvent) GetFrom() NodeState {
+	if x != nil {
+		return x.From
+	}
+	return NodeState_Unknown
+}
+
+func (x *NodeEvent) GetTo() NodeState {
+	if x != nil {
+		return x.To
+	}
+	return NodeState_Unknown
+}
+
+type JobEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          int32    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	From        JobState `protobuf:"varint,3,opt,name=from,proto3,enum=clusrun.JobState" json:"from,omitempty"`
+	To          JobState `protobuf:"varint,4,opt,name=to,proto3,enum=clusrun.JobState" json:"to,omitempty"`
+	Nodes       int32    `protobuf:"varint,5,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	FailedNodes int32    `protobuf:"varint,6,opt,name=failed_nodes,json=failedNodes,proto3" json:"failed_nodes,omitempty"`
+}
+
+func (x *JobEvent) Reset() {
+	*x = JobEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[135]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobEvent) ProtoMessage() {}
+
+func (x *JobEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[135]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobEvent.ProtoReflect.Descriptor instead.
+func (*JobEvent) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *JobEvent) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *JobEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobEvent) GetFrom() JobState {
+	if x != nil {
+		return x.From
+	}
+	return JobState_Created
+}
+
+func (x *JobEvent) GetTo() JobState {
+	if x != nil {
+		return x.To
+	}
+	return JobState_Created
+}
+
+func (x *JobEvent) GetNodes() int32 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+func (x *JobEvent) GetFailedNodes() int32 {
+	if x != nil {
+		return x.FailedNodes
+	}
+	return 0
+}
+
+type AuditEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq      int64  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Time     int64  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Source   string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Action   string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	JobId    int32  `protobuf:"varint,5,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	RunAs    string `protobuf:"bytes,6,opt,name=run_as,json=runAs,proto3" json:"run_as,omitempty"`
+	Detail   string `protobuf:"bytes,7,opt,name=detail,proto3" json:"detail,omitempty"`
+	Result   string `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"`
+	PrevHash string `protobuf:"bytes,9,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Hash     string `protobuf:"bytes,10,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[136]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[136]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *AuditEntry) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetJobId() int32 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetRunAs() string {
+	if x != nil {
+		return x.RunAs
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type GetAuditLogRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SinceSeq int64  `protobuf:"varint,1,opt,name=since_seq,json=sinceSeq,proto3" json:"since_seq,omitempty"`
+	Limit    int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Since    int64  `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`
+	Until    int64  `protobuf:"varint,4,opt,name=until,proto3" json:"until,omitempty"`
+	Action   string `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Source   string `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *GetAuditLogRequest) Reset() {
+	*x = GetAuditLogRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[137]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditLogRequest) ProtoMessage() {}
+
+func (x *GetAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[137]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*GetAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *GetAuditLogRequest) GetSinceSeq() int64 {
+	if x != nil {
+		return x.SinceSeq
+	}
+	return 0
+}
+
+func (x *GetAuditLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetAuditLogRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *GetAuditLogRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *GetAuditLogRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *GetAuditLogRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type GetAuditLogReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries     []*AuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Count       int64         `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	LastHash    string        `protobuf:"bytes,3,opt,name=last_hash,json=lastHash,proto3" json:"last_hash,omitempty"`
+	Verified    bool          `protobuf:"varint,4,opt,name=verified,proto3" json:"verified,omitempty"`
+	VerifyError string        `protobuf:"bytes,5,opt,name=verify_error,json=verifyError,proto3" json:"verify_error,omitempty"`
+}
+
+func (x *GetAuditLogReply) Reset() {
+	*x = GetAuditLogReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_clusrun_proto_msgTypes[138]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAuditLogReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditLogReply) ProtoMessage() {}
+
+func (x *GetAuditLogReply) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_clusrun_proto_msgTypes[138]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditLogReply.ProtoReflect.Descriptor instead.
+func (*GetAuditLogReply) Descriptor() ([]byte, []int) {
+	return file_protobuf_clusrun_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *GetAuditLogReply) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetAuditLogReply) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetAuditLogReply) GetLastHash() string {
+	if x != nil {
+		return x.LastHash
+	}
+	return ""
+}
+
+func (x *GetAuditLogReply) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *GetAuditLogReply) GetVerifyError() string {
+	if x != nil {
+		return x.VerifyError
+	}
+	return ""
+}
+
+var File_protobuf_clusrun_proto protoreflect.FileDescriptor
+
+var file_protobuf_clusrun_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x22, 0xd6, 0x05, 0x0a, 0x10, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f,
+	0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f,
+	0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f,
+	0x6e, 0x65, 0x12, 0x34, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x6c, 0x61, 0x79,
+	0x65, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x6c, 0x6f,
+	0x73, 0x74, 0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0f, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x6f, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x2b,
+	0x0a, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x52, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x28, 0x0a, 0x05, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x05,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x3d, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18,
+	0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x65, 0x64,
+	0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0c, 0x6f, 0x72, 0x70,
+	0x68, 0x61, 0x6e, 0x65, 0x64, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x3a, 0x0a, 0x19, 0x68, 0x65, 0x61,
+	0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x17, 0x68, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x3d, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x18,
+	0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x39, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x83, 0x01, 0x0a, 0x10, 0x48,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12,
+	0x33, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x07, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x12, 0x3a, 0x0a, 0x19, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x17, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65,
+	0x61, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x22, 0xcb, 0x01, 0x0a, 0x0a, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x64,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x64,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61,
+	0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61,
+	0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x68,
+	0x0a, 0x15, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x68, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0a, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x22, 0x2d, 0x0a, 0x13, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0xbb, 0x02, 0x0a, 0x0d, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x70, 0x75,
+	0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x63, 0x70, 0x75,
+	0x4c, 0x6f, 0x61, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x29, 0x0a, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x5f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x66, 0x72, 0x65, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x46, 0x72, 0x65, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x4a, 0x6f, 0x62,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x67, 0x70, 0x75, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x70, 0x75, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x04, 0x67, 0x70, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x64, 0x6c, 0x65,
+	0x5f, 0x67, 0x70, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x64, 0x6c,
+	0x65, 0x47, 0x70, 0x75, 0x73, 0x22, 0xe8, 0x01, 0x0a, 0x07, 0x47, 0x70, 0x75, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6d, 0x62, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4d,
+	0x62, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x75, 0x73, 0x65, 0x64,
+	0x5f, 0x6d, 0x62, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x55, 0x73, 0x65, 0x64, 0x4d, 0x62, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x74, 0x69, 0x6c, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x75, 0x74,
+	0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62,
+	0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64,
+	0x22, 0x67, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x0e,
+	0x0a, 0x02, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x61, 0x72, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72,
+	0x63, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x22, 0x41, 0x0a, 0x09, 0x4e, 0x6f, 0x64,
+	0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x22, 0xdc, 0x01, 0x0a,
+	0x14, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x6d, 0x69, 0x6e, 0x5f, 0x66, 0x72, 0x65,
+	0x65, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6d, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x46, 0x72, 0x65, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x4d, 0x62, 0x12, 0x27, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x64,
+	0x69, 0x73, 0x6b, 0x5f, 0x6d, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x69,
+	0x6e, 0x46, 0x72, 0x65, 0x65, 0x44, 0x69, 0x73, 0x6b, 0x4d, 0x62, 0x12, 0x20, 0x0a, 0x0c, 0x6d,
+	0x61, 0x78, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x43, 0x70, 0x75, 0x4c, 0x6f, 0x61, 0x64, 0x12, 0x28, 0x0a,
+	0x10, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x6a, 0x6f, 0x62,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x52, 0x75, 0x6e, 0x6e,
+	0x69, 0x6e, 0x67, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x69,
+	0x64, 0x6c, 0x65, 0x5f, 0x67, 0x70, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x6d, 0x69, 0x6e, 0x49, 0x64, 0x6c, 0x65, 0x47, 0x70, 0x75, 0x73, 0x22, 0xd0, 0x01, 0x0a, 0x09,
+	0x4a, 0x6f, 0x62, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x70, 0x75,
+	0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x63, 0x70, 0x75, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6d, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x62, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x29,
+	0x0a, 0x10, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x69, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6e, 0x69, 0x63, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x69, 0x6f, 0x5f, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x69, 0x6f, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x50,
+	0x0a, 0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x17,
+	0x0a, 0x07, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x6b, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x73, 0x69, 0x7a, 0x65, 0x4b, 0x62, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x22, 0x5d, 0x0a, 0x08, 0x46, 0x61, 0x69, 0x6c, 0x46, 0x61, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x6d, 0x61, 0x78, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12,
+	0x2e, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x6d, 0x61,
+	0x78, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22,
+	0x58, 0x0a, 0x0d, 0x52, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x28, 0x0a, 0x10, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x6f, 0x6e, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x73, 0x74, 0x6f, 0x70, 0x4f,
+	0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0xa9, 0x02, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x73,
+	0x65, 0x63, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x6f, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x63, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x61, 0x72, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x68, 0x65, 0x72,
+	0x65, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x77, 0x68, 0x65, 0x72, 0x65, 0x22, 0xf8,
+	0x04, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x34, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x06, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x52, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x28, 0x0a, 0x05, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x05, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x73, 0x6b, 0x65, 0x77, 0x5f, 0x6d, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77, 0x4d, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x6b, 0x65, 0x77, 0x65, 0x64, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77, 0x65, 0x64,
+	0x12, 0x31, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e,
+	0x50, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39,
+	0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xe4, 0x01, 0x0a, 0x09, 0x4e, 0x6f,
+	0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x4a, 0x6f,
+	0x62, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x1e,
+	0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x22,
+	0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6a, 0x6f, 0x62, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6a, 0x6f, 0x62, 0x5f, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x6c, 0x61, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x22, 0x89, 0x01, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x23, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c,
+	0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x88, 0x03, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x3c, 0x0a, 0x07, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f,
+	0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x1a, 0x39, 0x0a, 0x0b,
+	0x4a, 0x6f, 0x62, 0x49, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9c, 0x16, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x77, 0x65,
+	0x65, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x77, 0x65, 0x65, 0x70, 0x12,
+	0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x27, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x11, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x40, 0x0a, 0x0c, 0x66, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x2e, 0x46,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13,
+	0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x63, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f,
+	0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18,
+	0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x6f, 0x64, 0x65,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x70,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x6f,
+	0x64, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x72,
+	0x65, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0e, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73,
+	0x12, 0x35, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18,
+	0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x52, 0x65, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x68, 0x69, 0x70, 0x5f,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x73, 0x68, 0x69, 0x70, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x2c, 0x0a, 0x12, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x5f, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x5f, 0x6b, 0x62, 0x18, 0x14, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x62, 0x61,
+	0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4b, 0x62, 0x12, 0x1f,
+	0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x15, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12,
+	0x15, 0x0a, 0x06, 0x72, 0x75, 0x6e, 0x5f, 0x61, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x72, 0x75, 0x6e, 0x41, 0x73, 0x12, 0x43, 0x0a, 0x0d, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x17, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x6e,
+	0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6a,
+	0x73, 0x6f, 0x6e, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x33, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x19, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x12, 0x43, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x27, 0x0a,
+	0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x1c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x6e, 0x52,
+	0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72,
+	0x65, 0x5f, 0x65, 0x6e, 0x76, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x45, 0x6e, 0x76, 0x12, 0x41, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x0c, 0x72, 0x65,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x43, 0x0a, 0x0d, 0x73, 0x6b,
+	0x69, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x1f, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x2e,
+	0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12,
+	0x2a, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x65,
+	0x6e, 0x76, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x21, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65,
+	0x6e, 0x76, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x3a, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x57, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x57, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x12, 0x30, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x23, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x52, 0x6f,
+	0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x07, 0x72, 0x6f, 0x6c,
+	0x6c, 0x69, 0x6e, 0x67, 0x12, 0x2e, 0x0a, 0x09, 0x66, 0x61, 0x69, 0x6c, 0x5f, 0x66, 0x61, 0x73,
+	0x74, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x46, 0x61, 0x73, 0x74, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c,
+	0x46, 0x61, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x25, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f,
+	0x62, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x05, 0x61, 0x66, 0x74,
+	0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x73, 0x74,
+	0x64, 0x69, 0x6e, 0x18, 0x26, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x72, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x27, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x42,
+	0x0a, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x28, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x52, 0x0f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x29, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f,
+	0x62, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x2a, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x65,
+	0x6c, 0x6c, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x12,
+	0x3d, 0x0a, 0x0b, 0x6f, 0x73, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x2c,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a,
+	0x6f, 0x62, 0x2e, 0x4f, 0x73, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0a, 0x6f, 0x73, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x2d, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x26, 0x0a, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x6e, 0x6f, 0x64,
+	0x65, 0x73, 0x18, 0x2e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x4f,
+	0x75, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x72, 0x70, 0x68, 0x61,
+	0x6e, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x2f, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0d, 0x6f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6c, 0x6f, 0x73, 0x74, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x30, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x31, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x49, 0x0a, 0x0f, 0x74, 0x72, 0x75, 0x6e, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x32, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x2e, 0x54,
+	0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0e, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x33, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x34, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x18, 0x35, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x12, 0x40, 0x0a, 0x0c, 0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x18, 0x36, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x2e, 0x53, 0x77, 0x65, 0x65, 0x70, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x73, 0x77, 0x65, 0x65, 0x70, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x68, 0x65, 0x72, 0x65, 0x18, 0x37,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x77, 0x68, 0x65, 0x72, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x72,
+	0x65, 0x72, 0x75, 0x6e, 0x5f, 0x6f, 0x66, 0x18, 0x38, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72,
+	0x65, 0x72, 0x75, 0x6e, 0x4f, 0x66, 0x12, 0x30, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x6f, 0x72, 0x18, 0x39, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72, 0x52, 0x08,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x3a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d,
+	0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x1a, 0x3e, 0x0a, 0x10, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3f, 0x0a, 0x11, 0x4e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x3f, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x1a, 0x3f, 0x0a, 0x11, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x4e, 0x6f,
+	0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x4f, 0x73, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x1a, 0x41, 0x0a, 0x13, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x53, 0x77, 0x65, 0x65, 0x70, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x96, 0x03, 0x0a, 0x0a, 0x4a, 0x6f, 0x62, 0x53, 0x75,
+	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x6e,
+	0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x75, 0x6e, 0x72, 0x65, 0x61,
+	0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x10, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x6c, 0x6f, 0x77, 0x65, 0x73, 0x74, 0x5f,
+	0x6e, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6c, 0x6f, 0x77,
+	0x65, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x6c, 0x6f, 0x77, 0x65,
+	0x73, 0x74, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x73, 0x6c, 0x6f, 0x77, 0x65, 0x73, 0x74, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x77, 0x61, 0x6c, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x11, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6f,
+	0x76, 0x65, 0x72, 0x68, 0x65, 0x61, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x64,
+	0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x76, 0x65, 0x72, 0x68, 0x65, 0x61, 0x64, 0x22,
+	0x71, 0x0a, 0x0e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e,
+	0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x22, 0x49, 0x0a, 0x0d, 0x4a, 0x6f, 0x62, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0xa9, 0x03,
+	0x0a, 0x08, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x11,
+	0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f,
+	0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x6f, 0x73, 0x74, 0x12, 0x3a,
+	0x0a, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x54, 0x61,
+	0x73, 0x6b, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0b, 0x65,
+	0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x08, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12,
+	0x25, 0x0a, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x5f, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75,
+	0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x5f,
+	0x6f, 0x75, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x64,
+	0x4f, 0x75, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x64, 0x6f, 0x75,
+	0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74,
+	0x64, 0x65, 0x72, 0x72, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xae, 0x02, 0x0a, 0x0f, 0x54, 0x61,
+	0x73, 0x6b, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x45, 0x0a,
+	0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x45,
+	0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b,
+	0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77,
+	0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x70, 0x72, 0x65, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x72, 0x65, 0x74, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x13, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x70, 0x72, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x70,
+	0x72, 0x65, 0x74, 0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02,
+	0x6f, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x1a, 0x3c, 0x0a, 0x0e,
+	0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x56, 0x0a, 0x0a, 0x52, 0x65,
+	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x72, 0x6f,
+	0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x6e, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6f, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x22, 0x30, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x20, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x04,
+	0x6a, 0x6f, 0x62, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x61, 0x69, 0x6c,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x22, 0x7b, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x25,
+	0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x70, 0x61, 0x6e, 0x52,
+	0x04, 0x74, 0x61, 0x73, 0x6b, 0x22, 0xc6, 0x0d, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43,
+	0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12,
+	0x29, 0x0a, 0x10, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73,
+	0x65, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x65, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x77,
+	0x65, 0x65, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x77, 0x65, 0x65, 0x70,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78,
+	0x52, 0x65, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x73,
+	0x68, 0x69, 0x70, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x68, 0x69, 0x70, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x6b, 0x62, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x10, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x4b, 0x62, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69,
+	0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67,
+	0x44, 0x69, 0x72, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x75, 0x6e, 0x5f, 0x61, 0x73, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x75, 0x6e, 0x41, 0x73, 0x12, 0x53, 0x0a, 0x0d, 0x6e, 0x6f,
+	0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74,
+	0x63, 0x68, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x5f, 0x65, 0x6e, 0x76, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x61,
+	0x70, 0x74, 0x75, 0x72, 0x65, 0x45, 0x6e, 0x76, 0x12, 0x41, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x0c, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52,
+	0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x76, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x76, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x3a, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x77, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x72, 0x75, 0x6e, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x30,
+	0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x69, 0x6e,
+	0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x07, 0x72, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67,
+	0x12, 0x2e, 0x0a, 0x09, 0x66, 0x61, 0x69, 0x6c, 0x5f, 0x66, 0x61, 0x73, 0x74, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x46, 0x61,
+	0x69, 0x6c, 0x46, 0x61, 0x73, 0x74, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x46, 0x61, 0x73, 0x74,
+	0x12, 0x2c, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x44, 0x65, 0x70,
+	0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x12, 0x23,
+	0x0a, 0x0d, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18,
+	0x1a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x53, 0x74,
+	0x64, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x1b,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x16, 0x0a,
+	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x1c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x18, 0x1d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x12, 0x4d, 0x0a, 0x0b, 0x6f,
+	0x73, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x1e, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4f,
+	0x73, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a,
+	0x6f, 0x73, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x20, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x21, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18,
+	0x22, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x17,
+	0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x23, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x68, 0x65, 0x72, 0x65,
+	0x18, 0x24, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x77, 0x68, 0x65, 0x72, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x72, 0x65, 0x72, 0x75, 0x6e, 0x5f, 0x6f, 0x66, 0x18, 0x25, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x72, 0x65, 0x72, 0x75, 0x6e, 0x4f, 0x66, 0x12, 0x50, 0x0a, 0x0c, 0x73, 0x77, 0x65, 0x65,
+	0x70, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x26, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6c,
+	0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x77, 0x65,
+	0x65, 0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x73,
+	0x77, 0x65, 0x65, 0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x27, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x70, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x6f, 0x72, 0x18, 0x28, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72, 0x52, 0x08,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x18, 0x29, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x1a, 0x3f,
+	0x0a, 0x11, 0x4e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x3d, 0x0a, 0x0f, 0x4f, 0x73, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e,
+	0x0a, 0x10, 0x53, 0x77, 0x65, 0x65, 0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4f,
+	0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x22,
+	0x98, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x72, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0xc3, 0x08, 0x0a, 0x11, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64,
+	0x65, 0x72, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72,
+	0x72, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x11, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x25,
+	0x0a, 0x0e, 0x72, 0x65, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x6f,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x54, 0x6f, 0x12, 0x51, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6c, 0x75, 0x73,
+	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70,
+	0x70, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x4c, 0x69, 0x6e, 0x74, 0x57, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2d, 0x0a,
+	0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x12, 0x51, 0x0a, 0x0d, 0x6e, 0x6f, 0x64,
+	0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4e, 0x6f, 0x64,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c,
+	0x6e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x4e, 0x0a, 0x0c,
+	0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x53,
+	0x77, 0x65, 0x65, 0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0b, 0x73, 0x77, 0x65, 0x65, 0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x08,
+	0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x45,
+	0x0a, 0x09, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x50, 0x65,
+	0x65, 0x72, 0x4a, 0x6f, 0x62, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x70, 0x65, 0x65,
+	0x72, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x4b, 0x0a, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f,
+	0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x1a, 0x3f, 0x0a, 0x11, 0x4e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x53, 0x77, 0x65, 0x65, 0x70, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3b, 0x0a, 0x0d, 0x50, 0x65, 0x65, 0x72, 0x4a, 0x6f, 0x62, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x65, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x92, 0x01, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
+	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x6e, 0x6f, 0x64, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x81, 0x03, 0x0a, 0x13, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43,
+	0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a,
+	0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a,
+	0x6f, 0x62, 0x49, 0x64, 0x12, 0x56, 0x0a, 0x0e, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x5f, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6c, 0x75, 0x73,
+	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x64, 0x6f, 0x75,
+	0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x73,
+	0x74, 0x64, 0x6f, 0x75, 0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x12, 0x56, 0x0a, 0x0e,
+	0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x6e, 0x64, 0x65, 0x64,
+	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x1a, 0x40, 0x0a, 0x12, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x4f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x40, 0x0a, 0x12, 0x53, 0x74, 0x64, 0x65, 0x72,
+	0x72, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3e, 0x0a, 0x0e, 0x4a, 0x6f, 0x62,
+	0x4c, 0x69, 0x6e, 0x74, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x97, 0x01, 0x0a, 0x15, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x07, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x06, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x49,
+	0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x89, 0x02, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6c,
+	0x75, 0x73, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x40, 0x0a, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6c, 0x75, 0x73,
+	0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x26, 0x0a,
+	0x0f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x5f, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0d, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x4a,
+	0x6f, 0x62, 0x49, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x64, 0x65, 0x6c,
+	0x61, 0x79, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x6e, 0x64, 0x6f,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x6e, 0x64, 0x6f, 0x49,
+	0x64, 0x1a, 0x4c, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x27, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x11, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0xe8, 0x05, 0x0a, 0x0f, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12,
+	0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x27,
+	0x0a, 0x0f, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x68, 0x69, 0x70, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x15, 0x0a,
+	0x06, 0x72, 0x75, 0x6e, 0x5f, 0x61, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72,
+	0x75, 0x6e, 0x41, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64,
+	0x5f, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x65, 0x6e, 0x76,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x45,
+	0x6e, 0x76, 0x12, 0x2a, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x19,
+	0x0a, 0x08, 0x65, 0x6e, 0x76, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x65, 0x6e, 0x76, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x3a, 0x0a, 0x0d, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x57,
+	0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x5f, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66, 0x6f,
+	0x72, 0x77, 0x61, 0x72, 0x64, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68,
+	0x65, 0x6c, 0x6c, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x68, 0x65, 0x6c, 0x6c,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x30, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x65, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x70, 0x75, 0x73, 0x18, 0x15,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x67, 0x70, 0x75, 0x73, 0x22, 0x8c, 0x03, 0x0a, 0x0d, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74,
+	0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x1b, 0x0a, 0x09,
+	0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x11, 0x52,
+	0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x3a, 0x0a, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x33,
+	0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x4f, 0x75, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61,
+	0x74, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x74, 0x64, 0x6f, 0x75,
+	0x74, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x73, 0x74, 0x64, 0x65, 0x72,
+	0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x9c, 0x01, 0x0a, 0x0e, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x23, 0x0a, 0x0d,
+	0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x53, 0x68, 0x61, 0x32, 0x35,
+	0x36, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x5f, 0x73, 0x68, 0x61, 0x32,
+	0x35, 0x36, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72,
+	0x53, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x64,
+	0x6f, 0x75, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x64, 0x65, 0x72,
+	0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74,
+	0x64, 0x65, 0x72, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x44, 0x0a, 0x0f, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68,
+	0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68,
+	0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x22, 0x95,
+	0x01, 0x0a, 0x0f, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x32, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64,
+	0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0x6b, 0x0a, 0x11, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f,
+	0x64, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68,
+	0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68,
+	0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x22, 0x8f, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x4a, 0x6f,
+	0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64,
+	0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64,
+	0x6e, 0x6f, 0x64, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x74, 0x64, 0x6f, 0x75, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x4f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x73, 0x0a, 0x0d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f,
+	0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x11, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x22, 0x5b, 0x0a, 0x10, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f,
+	0x62, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0xa9, 0x02, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68,
+	0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68,
+	0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6c, 0x75, 0x73, 0x6e,
+	0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x75, 0x73, 0x6e,
+	0x6f, 0x64, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x05, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x12, 0x3a, 0x0a, 0x19, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x5f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x17, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6b, 0x65, 0x79, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x22, 0xcb, 0x01, 0x0a, 0x0d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
+	0x28, 0x0a, 0x05, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x52, 0x05, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x06,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x22, 0x6b, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x12, 0x23, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52,
+	0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0xcf,
+	0x01, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x41, 0x0a,
+	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x62, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x64, 0x6e,
+	0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x68, 0x65, 0x61, 0x64,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65,
+	0x74, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x22, 0x92, 0x01, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x41, 0x0a, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64,
+	0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x3a, 0x0a,
+	0x0c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xdd, 0x01, 0x0a, 0x11, 0x53, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x41, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f,
+	0x72, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x1a, 0x3a, 0x0a,
+	0x0c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb8, 0x02, 0x0a, 0x0f, 0x53, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3f, 0x0a,
+	0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x4c,
+	0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x3a, 0x0a, 0x0c,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5a, 0x0a, 0x10, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0xa8, 0x01, 0x0a, 0x11, 0x4e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x41, 0x0a, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x1a, 0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0xe7, 0x01, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e,
+	0x6f, 0x64, 0x65, 0x12, 0x43, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x41,
+	0x70, 0x70, 0x6c, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f,
+	0x72, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x1a, 0x3a, 0x0a,
+	0x0c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xac, 0x01, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3f, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x1a, 0x3a, 0x0a, 0x0c,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x32, 0x0a, 0x14, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x85, 0x02, 0x0a,
+	0x15, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72, 0x69, 0x66, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x48, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72, 0x69, 0x66,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x65, 0x12, 0x17,
+	0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x1a, 0x3b, 0x0a, 0x0d, 0x42, 0x61, 0x73, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x9d, 0x04, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x44, 0x72, 0x69, 0x66, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x46, 0x0a, 0x08,
+	0x62, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x44, 0x72, 0x69, 0x66, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x42, 0x61, 0x73,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x62, 0x61, 0x73, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x66, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72, 0x69, 0x66, 0x74, 0x52, 0x06, 0x64, 0x72, 0x69, 0x66,
+	0x74, 0x73, 0x12, 0x4d, 0x0a, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72, 0x69, 0x66, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x12, 0x52, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72, 0x69, 0x66, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x65, 0x64, 0x69, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x42,
+	0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x4e, 0x6f, 0x64, 0x65,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5b, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x96, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44,
+	0x72, 0x69, 0x66, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x38, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72, 0x69, 0x66, 0x74, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x77, 0x0a,
+	0x1a, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0d, 0x77,
+	0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x22, 0x4b, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64,
+	0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x2f, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x05, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x22, 0x7b, 0x0a, 0x10, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x75,
+	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x75, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x69, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x12, 0x2d, 0x0a, 0x07, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64,
+	0x65, 0x55, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x52, 0x07, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x22, 0x86, 0x01, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x55, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x25, 0x0a, 0x0e, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x64, 0x6f, 0x77, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x64, 0x6f, 0x77, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x6f, 0x77, 0x6e, 0x5f, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x64, 0x6f,
+	0x77, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0xac, 0x01, 0x0a, 0x14, 0x47, 0x65,
+	0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x53, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x43, 0x61, 0x70, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf2, 0x03, 0x0a, 0x16, 0x47, 0x65, 0x74,
+	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x50, 0x0a, 0x0b, 0x6e, 0x6f, 0x64,
+	0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x50, 0x0a, 0x0b, 0x6e,
+	0x6f, 0x64, 0x65, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2f, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x4a, 0x6f, 0x62, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x4a, 0x6f, 0x62,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x64,
+	0x6f, 0x75, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x42, 0x79, 0x74, 0x65, 0x73, 0x1a,
+	0x3d, 0x0a, 0x0f, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d,
+	0x0a, 0x0f, 0x4e, 0x6f, 0x64, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x88, 0x01,
+	0x0a, 0x09, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
+	0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x69, 0x73, 0x44, 0x69, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10,
+	0x0a, 0x03, 0x65, 0x6f, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x65, 0x6f, 0x66,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x22, 0xd3, 0x01, 0x0a, 0x12, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x65,
+	0x63, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x90,
+	0x01, 0x0a, 0x10, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x40, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x7d, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64,
+	0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64,
+	0x6e, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x22, 0x3f, 0x0a, 0x11, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x22, 0x57, 0x0a, 0x12, 0x47, 0x61, 0x74, 0x68, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70,
+	0x61, 0x74, 0x68, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0xa2, 0x01, 0x0a, 0x10, 0x47,
+	0x61, 0x74, 0x68, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x40, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x61, 0x74, 0x68, 0x65,
+	0x72, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x64, 0x69, 0x72, 0x1a, 0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x65, 0x0a, 0x10, 0x53, 0x65, 0x6e, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x70, 0x61, 0x74, 0x68, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67,
+	0x5f, 0x64, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b,
+	0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x22, 0x2c, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4e,
+	0x6f, 0x64, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e,
+	0x6f, 0x64, 0x65, 0x73, 0x22, 0x94, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4e, 0x6f,
+	0x64, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x42, 0x0a, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65,
+	0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x1a,
+	0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x43, 0x0a, 0x11, 0x44,
+	0x72, 0x61, 0x69, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x6e, 0x64, 0x72, 0x61, 0x69,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x75, 0x6e, 0x64, 0x72, 0x61, 0x69, 0x6e,
+	0x22, 0x8e, 0x01, 0x0a, 0x0f, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x3f, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x44, 0x72, 0x61, 0x69, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x2e, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x22, 0x76, 0x0a, 0x10, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64,
+	0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6e, 0x6f,
+	0x74, 0x5f, 0x6c, 0x6f, 0x73, 0x74, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x6e, 0x6f, 0x74, 0x4c, 0x6f, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x6e, 0x64, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x75, 0x6e, 0x64, 0x6f, 0x49, 0x64, 0x22, 0x54, 0x0a, 0x16, 0x52, 0x65, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x20, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22,
+	0xd2, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x6f,
+	0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x12, 0x51, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x52, 0x65, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f,
+	0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x69, 0x0a, 0x10, 0x54, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x6a, 0x6f, 0x62,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x4a, 0x6f, 0x62, 0x22,
+	0x43, 0x0a, 0x0e, 0x54, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x31, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64,
+	0x65, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x22, 0xc3, 0x01, 0x0a, 0x0e, 0x4e, 0x6f, 0x64, 0x65, 0x54, 0x65, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x68,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x5f, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x41, 0x67, 0x65, 0x4d, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x74,
+	0x72, 0x69, 0x70, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x54, 0x72, 0x69, 0x70, 0x4d, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x73, 0x6b, 0x65, 0x77, 0x5f, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77, 0x4d, 0x73, 0x12, 0x15, 0x0a,
+	0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6a,
+	0x6f, 0x62, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x43, 0x0a, 0x0c, 0x50, 0x72,
+	0x6f, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65,
+	0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65,
+	0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x5f, 0x6a, 0x6f,
+	0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x22,
+	0x66, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x24, 0x0a,
+	0x0e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x78, 0x4e,
+	0x61, 0x6e, 0x6f, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x4d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x6f,
+	0x62, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6a,
+	0x6f, 0x62, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xf4, 0x01, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x54,
+	0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x67, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x77, 0x65, 0x65, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x77,
+	0x65, 0x65, 0x70, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x76, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x76, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0x2e,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x4a,
+	0x0a, 0x14, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x32, 0x0a, 0x09, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52,
+	0x09, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x22, 0x31, 0x0a, 0x19, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x50, 0x0a,
+	0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22,
+	0x88, 0x02, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72, 0x75, 0x6e, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6e, 0x65, 0x78, 0x74,
+	0x52, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x72, 0x75, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x6c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x2e, 0x0a, 0x16, 0x47, 0x65,
+	0x74, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x4a, 0x0a, 0x14, 0x47, 0x65,
+	0x74, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x32, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x09, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x4f, 0x0a, 0x1d, 0x53, 0x65, 0x74, 0x4a, 0x6f, 0x62,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x54, 0x0a, 0x1b, 0x53, 0x65, 0x74, 0x4a, 0x6f,
+	0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x31, 0x0a,
+	0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x22, 0x50, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x66, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x46, 0x6f, 0x75,
+	0x6e, 0x64, 0x22, 0x59, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72,
+	0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x5e, 0x0a,
+	0x16, 0x53, 0x61, 0x76, 0x65, 0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x08, 0x62,
+	0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d,
+	0x61, 0x72, 0x6b, 0x52, 0x08, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x22, 0x42, 0x0a,
+	0x16, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x22, 0x4a, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d,
+	0x61, 0x72, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x32, 0x0a, 0x09, 0x62, 0x6f, 0x6f,
+	0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61,
+	0x72, 0x6b, 0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x22, 0x45, 0x0a,
+	0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61,
+	0x72, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x22, 0x50, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f,
+	0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x74,
+	0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f,
+	0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x36, 0x0a, 0x0c, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
+	0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x22, 0xc2,
+	0x02, 0x0a, 0x0c, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12,
+	0x2b, 0x0a, 0x11, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64,
+	0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x75, 0x6e, 0x5f, 0x61, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x75, 0x6e, 0x41, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x72,
+	0x6d, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03,
+	0x70, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x54, 0x65, 0x72, 0x6d,
+	0x69, 0x6e, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65,
+	0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x72, 0x75,
+	0x70, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x72,
+	0x75, 0x70, 0x74, 0x22, 0x6b, 0x0a, 0x0a, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69,
+	0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x74, 0x65,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x11, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x70, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x70, 0x74, 0x79,
+	0x22, 0x1d, 0x0a, 0x0b, 0x55, 0x6e, 0x64, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x62, 0x0a, 0x0d, 0x55, 0x6e, 0x64, 0x6f, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x22, 0x5b, 0x0a, 0x09, 0x55, 0x6e, 0x64, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x36, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x55,
+	0x6e, 0x64, 0x6f, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x22, 0x2b, 0x0a, 0x10, 0x50, 0x75, 0x72, 0x67, 0x65, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x22, 0x4a, 0x0a,
+	0x0e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x17, 0x0a, 0x07, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x06, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x65, 0x65,
+	0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66,
+	0x72, 0x65, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x60, 0x0a, 0x11, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73,
+	0x69, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x69,
+	0x74, 0x68, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x77, 0x69, 0x74, 0x68, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x49, 0x0a, 0x0f, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e,
+	0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x16,
+	0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x4b, 0x0a, 0x11, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x03, 0x6a,
+	0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x22, 0xae, 0x01, 0x0a, 0x0f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4a, 0x6f,
+	0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3d, 0x0a, 0x07, 0x6a, 0x6f, 0x62, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
+	0x6a, 0x6f, 0x62, 0x49, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4a, 0x6f, 0x62,
+	0x49, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x4c, 0x0a, 0x13, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6a,
+	0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62,
+	0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x71, 0x0a, 0x11, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x73, 0x12, 0x2c, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x3d, 0x0a, 0x0f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x6f, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x22, 0xc8, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x12, 0x41, 0x0a, 0x07, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x4a, 0x6f, 0x62, 0x49, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6a, 0x6f, 0x62,
+	0x49, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x67, 0x0a, 0x11, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x34, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72,
+	0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74,
+	0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0x7e, 0x0a, 0x11, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x15, 0x0a,
+	0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a,
+	0x6f, 0x62, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65,
+	0x72, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0x2e, 0x0a, 0x15, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x22, 0x2b, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x72, 0x61, 0x63, 0x65, 0x22, 0x9b, 0x01, 0x0a, 0x0f, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65,
+	0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65,
+	0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x10, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x6e,
+	0x6f, 0x64, 0x65, 0x22, 0xb5, 0x01, 0x0a, 0x0d, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12,
+	0x4a, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x46, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x46,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x79, 0x0a, 0x14, 0x47,
+	0x65, 0x74, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6f, 0x69, 0x64, 0x63, 0x5f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x69, 0x64, 0x63, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72,
+	0x12, 0x24, 0x0a, 0x0e, 0x6f, 0x69, 0x64, 0x63, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x69, 0x64, 0x63, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x9b, 0x01, 0x0a, 0x0a, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
+	0x61, 0x78, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x6d, 0x61, 0x78, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x22, 0x83, 0x01, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x23, 0x0a, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f,
+	0x6a, 0x6f, 0x62, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x5f,
+	0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e,
+	0x6f, 0x64, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0x6f, 0x0a, 0x0c, 0x43, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x26,
+	0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f,
+	0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x22, 0x6b, 0x0a, 0x09, 0x4e,
+	0x6f, 0x64, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x22, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x02, 0x74, 0x6f, 0x22, 0xb1, 0x01, 0x0a, 0x08, 0x4a, 0x6f, 0x62,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x12, 0x21, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x02, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0xf1, 0x01, 0x0a,
+	0x0a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x75, 0x6e, 0x5f,
+	0x61, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x75, 0x6e, 0x41, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x65, 0x76, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x22, 0xa3, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x69, 0x6e, 0x63, 0x65,
+	0x5f, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x53, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69,
+	0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0xb3, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1a, 0x0a,
+	0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x2a, 0x85, 0x01, 0x0a,
+	0x10, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x10, 0x00,
+	0x12, 0x0d, 0x0a, 0x09, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x10, 0x01, 0x12,
+	0x0f, 0x0a, 0x0b, 0x55, 0x6e, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x10, 0x02,
+	0x12, 0x12, 0x0a, 0x0e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x73, 0x10, 0x03, 0x12, 0x14, 0x0a, 0x10, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65,
+	0x53, 0x74, 0x6f, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x10, 0x04, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x65,
+	0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x10, 0x05, 0x2a, 0x46, 0x0a, 0x09, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x09,
+	0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x79, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x6f, 0x73, 0x74, 0x10, 0x03, 0x12, 0x0c,
+	0x0a, 0x08, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x04, 0x2a, 0xa7, 0x01, 0x0a,
+	0x08, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x69, 0x73, 0x70, 0x61, 0x74,
+	0x63, 0x68, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x6e, 0x69,
+	0x6e, 0x67, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x69, 0x6e,
+	0x67, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x10,
+	0x04, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x05, 0x12, 0x0c, 0x0a,
+	0x08, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x10, 0x06, 0x12, 0x10, 0x0a, 0x0c, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x07, 0x12, 0x0b, 0x0a,
+	0x07, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x10, 0x08, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x61,
+	0x69, 0x74, 0x69, 0x6e, 0x67, 0x10, 0x09, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x6e, 0x74, 0x72, 0x61,
+	0x63, 0x6b, 0x65, 0x64, 0x10, 0x0a, 0x2a, 0x3c, 0x0a, 0x09, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x61, 0x73, 0x6b, 0x55, 0x6e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x75, 0x6e, 0x6e,
+	0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x6e, 0x64,
+	0x65, 0x64, 0x10, 0x02, 0x2a, 0x34, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x6e,
+	0x6f, 0x64, 0x65, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x64, 0x64, 0x10, 0x01, 0x12, 0x0a,
+	0x0a, 0x06, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x10, 0x02, 0x32, 0xa1, 0x1d, 0x0a, 0x08, 0x48,
+	0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x48,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x4d, 0x0a, 0x0f, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x48,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62,
+	0x65, 0x61, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01,
+	0x12, 0x3e, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x3b, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x17, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47,
+	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a,
+	0x09, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x47, 0x65, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x4a,
+	0x6f, 0x62, 0x12, 0x1c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01,
+	0x12, 0x50, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f,
+	0x62, 0x73, 0x12, 0x1e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x44, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73,
+	0x12, 0x1a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x12, 0x1d, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65,
+	0x74, 0x4e, 0x6f, 0x64, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1d, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x53, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x72, 0x75, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12,
+	0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x1f, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x49, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x12, 0x1b, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x47, 0x0a,
+	0x0b, 0x47, 0x61, 0x74, 0x68, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x61, 0x74, 0x68, 0x65, 0x72, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x72, 0x75, 0x6e, 0x2e, 0x47, 0x61, 0x74, 0x68, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4d, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4e,
+	0x6f, 0x64, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1d, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x09, 0x50, 0x75, 0x72, 0x67, 0x65, 0x4a, 0x6f,
+	0x62, 0x73, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x50, 0x75, 0x72,
+	0x67, 0x65, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x4a, 0x6f, 0x62,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01,
+	0x12, 0x46, 0x0a, 0x0a, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x1a,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4a,
+	0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x4a, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x12, 0x1c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x50, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x12, 0x1e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x50, 0x0a, 0x0e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x12, 0x1e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0a, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x44, 0x72, 0x61,
+	0x69, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x4e, 0x6f,
+	0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0b, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x72, 0x75, 0x6e, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0f, 0x52, 0x65, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x52, 0x65, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x52, 0x65, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x04, 0x55, 0x6e, 0x64, 0x6f,
+	0x12, 0x14, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x55, 0x6e, 0x64, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x55, 0x6e, 0x64, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0f,
+	0x53, 0x61, 0x76, 0x65, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12,
+	0x14, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x1a, 0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4a, 0x6f,
+	0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5c, 0x0a, 0x12,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74,
+	0x65, 0x73, 0x12, 0x22, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x17, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x22,
+	0x00, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x0f, 0x53, 0x61, 0x76, 0x65, 0x4a, 0x6f, 0x62, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x14, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x1a, 0x0e, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x53,
+	0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x73, 0x12, 0x1f, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4a,
+	0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74,
+	0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x68, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x26, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x53, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x45,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5c, 0x0a,
+	0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0f, 0x53,
+	0x61, 0x76, 0x65, 0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x1f,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x4a, 0x6f, 0x62,
+	0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x53, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d,
+	0x61, 0x72, 0x6b, 0x73, 0x12, 0x1f, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47,
+	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5c, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x12, 0x22, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62,
+	0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x20, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x4a, 0x6f, 0x62, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x05, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x12, 0x15, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53,
+	0x68, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12,
+	0x49, 0x0a, 0x0f, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62,
+	0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x0e, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x2e,
+	0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4d,
+	0x0a, 0x0f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x1f, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x43, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4c, 0x0a,
+	0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6c, 0x75, 0x73, 0x4a, 0x6f, 0x62, 0x12, 0x1c, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6c, 0x75,
+	0x73, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x09, 0x54,
+	0x65, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x54, 0x65,
+	0x73, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x47,
+	0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x1b, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x06, 0x54, 0x75, 0x6e, 0x6e, 0x65,
+	0x6c, 0x12, 0x13, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e,
+	0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x13, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e,
+	0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x22, 0x00, 0x28, 0x01, 0x30,
+	0x01, 0x12, 0x42, 0x0a, 0x0a, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12,
+	0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x08, 0x52, 0x65, 0x72, 0x75, 0x6e, 0x4a, 0x6f,
+	0x62, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x52, 0x65, 0x72, 0x75,
+	0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6c, 0x75, 0x73, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x0e, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72, 0x69, 0x66, 0x74, 0x12, 0x1e, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x44, 0x72, 0x69, 0x66, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x44, 0x72, 0x69, 0x66, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5f, 0x0a,
+	0x13, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x12, 0x23, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47,
+	0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x32, 0x80,
+	0x09, 0x0a, 0x08, 0x43, 0x6c, 0x75, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x40, 0x0a, 0x08, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x38, 0x0a,
+	0x09, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x08, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x48, 0x65,
+	0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x0e, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x46, 0x69,
+	0x6c, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28,
+	0x01, 0x12, 0x3e, 0x0a, 0x09, 0x53, 0x65, 0x6e, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x19,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x72, 0x75, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x39, 0x0a, 0x05, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x13, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x68, 0x65, 0x6c,
+	0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x47, 0x0a, 0x0d,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x18, 0x2e,
+	0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75,
+	0x6e, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x47, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x4c, 0x6f, 0x67, 0x12, 0x1b, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47,
+	0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3e,
+	0x0a, 0x08, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x72, 0x75, 0x6e, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48,
+	0x0a, 0x0c, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x1c,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x1d, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x72, 0x75, 0x6e, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x4a, 0x6f,
+	0x62, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x62,
+	0x65, 0x12, 0x15, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x62,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72,
+	0x75, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x40, 0x0a, 0x08, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x4a, 0x6f, 0x62, 0x12, 0x18, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x72, 0x75, 0x6e, 0x2e,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30,
+	0x01, 0x42, 0x12, 0x5a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x3b, 0x63, 0x6c,
+	0x75, 0x73, 0x72, 0x75, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protobuf_clusrun_proto_rawDescOnce sync.Once
+	file_protobuf_clusrun_proto_rawDescData = file_protobuf_clusrun_proto_rawDesc
+)
+
+func file_protobuf_clusrun_proto_rawDescGZIP() []byte {
+	file_protobuf_clusrun_proto_rawDescOnce.Do(func() {
+		file_protobuf_clusrun_proto_rawDescData = protoimpl.X.CompressGZIP(file_protobuf_clusrun_proto_rawDescData)
+	})
+	return file_protobuf_clusrun_proto_rawDescData
+}
+
+var file_protobuf_clusrun_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_protobuf_clusrun_proto_msgTypes = make([]protoimpl.MessageInfo, 189)
+var file_protobuf_clusrun_proto_goTypes = []interface{}{
+	(HeartbeatCommand)(0),                 // 0: clusrun.HeartbeatCommand
+	(NodeState)(0),                        // 1: clusrun.NodeState
+	(JobState)(0),                         // 2: clusrun.JobState
+	(TaskState)(0),                        // 3: clusrun.TaskState
+	(SetHeadnodesMode)(0),                 // 4: clusrun.SetHeadnodesMode
+	(*HeartbeatRequest)(nil),              // 5: clusrun.HeartbeatRequest
+	(*HeartbeatControl)(nil),              // 6: clusrun.HeartbeatControl
+	(*TunnelData)(nil),                    // 7: clusrun.TunnelData
+	(*BatchHeartbeatRequest)(nil),         // 8: clusrun.BatchHeartbeatRequest
+	(*BatchHeartbeatReply)(nil),           // 9: clusrun.BatchHeartbeatReply
+	(*NodeResources)(nil),                 // 10: clusrun.NodeResources
+	(*GpuInfo)(nil),                       // 11: clusrun.GpuInfo
+	(*NodeSystem)(nil),                    // 12: clusrun.NodeSystem
+	(*NodeBuild)(nil),                     // 13: clusrun.NodeBuild
+	(*ResourceRequirements)(nil),          // 14: clusrun.ResourceRequirements
+	(*JobLimits)(nil),                     // 15: clusrun.JobLimits
+	(*OutputWindow)(nil),                  // 16: clusrun.OutputWindow
+	(*FailFast)(nil),                      // 17: clusrun.FailFast
+	(*RollingPolicy)(nil),                 // 18: clusrun.RollingPolicy
+	(*Empty)(nil),                         // 19: clusrun.Empty
+	(*GetNodesRequest)(nil),               // 20: clusrun.GetNodesRequest
+	(*Node)(nil),                          // 21: clusrun.Node
+	(*NodeStats)(nil),                     // 22: clusrun.NodeStats
+	(*GetNodesReply)(nil),                 // 23: clusrun.GetNodesReply
+	(*GetJobsRequest)(nil),                // 24: clusrun.GetJobsRequest
+	(*Job)(nil),                           // 25: clusrun.Job
+	(*JobSummary)(nil),                    // 26: clusrun.JobSummary
+	(*FailureCluster)(nil),                // 27: clusrun.FailureCluster
+	(*JobDependency)(nil),                 // 28: clusrun.JobDependency
+	(*TaskSpan)(nil),                      // 29: clusrun.TaskSpan
+	(*TaskEnvironment)(nil),               // 30: clusrun.TaskEnvironment
+	(*Reschedule)(nil),                    // 31: clusrun.Reschedule
+	(*GetJobsReply)(nil),                  // 32: clusrun.GetJobsReply
+	(*GetOutputRequest)(nil),              // 33: clusrun.GetOutputRequest
+	(*GetOutputReply)(nil),                // 34: clusrun.GetOutputReply
+	(*StartClusJobRequest)(nil),           // 35: clusrun.StartClusJobRequest
+	(*JobExecutor)(nil),                   // 36: clusrun.JobExecutor
+	(*RerunJobRequest)(nil),               // 37: clusrun.RerunJobRequest
+	(*StartClusJobReply)(nil),             // 38: clusrun.StartClusJobReply
+	(*JobProgress)(nil),                   // 39: clusrun.JobProgress
+	(*WatchClusJobRequest)(nil),           // 40: clusrun.WatchClusJobRequest
+	(*JobLintWarning)(nil),                // 41: clusrun.JobLintWarning
+	(*CancelClusJobsRequest)(nil),         // 42: clusrun.CancelClusJobsRequest
+	(*CancelClusJobsReply)(nil),           // 43: clusrun.CancelClusJobsReply
+	(*StartJobRequest)(nil),               // 44: clusrun.StartJobRequest
+	(*StartJobReply)(nil),                 // 45: clusrun.StartJobReply
+	(*OutputChecksum)(nil),                // 46: clusrun.OutputChecksum
+	(*QueryJobRequest)(nil),               // 47: clusrun.QueryJobRequest
+	(*RelayJobRequest)(nil),               // 48: clusrun.RelayJobRequest
+	(*HeadnodeSignature)(nil),             // 49: clusrun.HeadnodeSignature
+	(*ResumeJobRequest)(nil),              // 50: clusrun.ResumeJobRequest
+	(*QueryJobReply)(nil),                 // 51: clusrun.QueryJobReply
+	(*CancelJobRequest)(nil),              // 52: clusrun.CancelJobRequest
+	(*ValidateRequest)(nil),               // 53: clusrun.ValidateRequest
+	(*ValidateReply)(nil),                 // 54: clusrun.ValidateReply
+	(*SetNodeGroupsRequest)(nil),          // 55: clusrun.SetNodeGroupsRequest
+	(*SetNodeLabelsRequest)(nil),          // 56: clusrun.SetNodeLabelsRequest
+	(*SetHeadnodesRequest)(nil),           // 57: clusrun.SetHeadnodesRequest
+	(*SetHeadnodesReply)(nil),             // 58: clusrun.SetHeadnodesReply
+	(*SetConfigsRequest)(nil),             // 59: clusrun.SetConfigsRequest
+	(*SetConfigsReply)(nil),               // 60: clusrun.SetConfigsReply
+	(*NodeConfigsResult)(nil),             // 61: clusrun.NodeConfigsResult
+	(*ApplyConfigsRequest)(nil),           // 62: clusrun.ApplyConfigsRequest
+	(*GetConfigsReply)(nil),               // 63: clusrun.GetConfigsReply
+	(*ReportConfigsRequest)(nil),          // 64: clusrun.ReportConfigsRequest
+	(*GetConfigDriftRequest)(nil),         // 65: clusrun.GetConfigDriftRequest
+	(*GetConfigDriftReply)(nil),           // 66: clusrun.GetConfigDriftReply
+	(*ConfigDrift)(nil),                   // 67: clusrun.ConfigDrift
+	(*GetNodeAvailabilityRequest)(nil),    // 68: clusrun.GetNodeAvailabilityRequest
+	(*GetNodeAvailabilityReply)(nil),      // 69: clusrun.GetNodeAvailabilityReply
+	(*NodeAvailability)(nil),              // 70: clusrun.NodeAvailability
+	(*NodeUptime)(nil),                    // 71: clusrun.NodeUptime
+	(*GetCapabilitiesReply)(nil),          // 72: clusrun.GetCapabilitiesReply
+	(*GetClusterSummaryReply)(nil),        // 73: clusrun.GetClusterSummaryReply
+	(*FileChunk)(nil),                     // 74: clusrun.FileChunk
+	(*UploadFilesRequest)(nil),            // 75: clusrun.UploadFilesRequest
+	(*UploadFilesReply)(nil),              // 76: clusrun.UploadFilesReply
+	(*ReceiveFilesRequest)(nil),           // 77: clusrun.ReceiveFilesRequest
+	(*ReceiveFilesReply)(nil),             // 78: clusrun.ReceiveFilesReply
+	(*GatherFilesRequest)(nil),            // 79: clusrun.GatherFilesRequest
+	(*GatherFilesReply)(nil),              // 80: clusrun.GatherFilesReply
+	(*SendFilesRequest)(nil),              // 81: clusrun.SendFilesRequest
+	(*ResetNodeKeysRequest)(nil),          // 82: clusrun.ResetNodeKeysRequest
+	(*ResetNodeKeysReply)(nil),            // 83: clusrun.ResetNodeKeysReply
+	(*DrainNodesRequest)(nil),             // 84: clusrun.DrainNodesRequest
+	(*DrainNodesReply)(nil),               // 85: clusrun.DrainNodesReply
+	(*RemoveNodesRequest)(nil),            // 86: clusrun.RemoveNodesRequest
+	(*RemoveNodesReply)(nil),              // 87: clusrun.RemoveNodesReply
+	(*RevalidateNodesRequest)(nil),        // 88: clusrun.RevalidateNodesRequest
+	(*RevalidateNodesReply)(nil),          // 89: clusrun.RevalidateNodesReply
+	(*TestNodesRequest)(nil),              // 90: clusrun.TestNodesRequest
+	(*TestNodesReply)(nil),                // 91: clusrun.TestNodesReply
+	(*NodeTestResult)(nil),                // 92: clusrun.NodeTestResult
+	(*ProbeRequest)(nil),                  // 93: clusrun.ProbeRequest
+	(*ProbeReply)(nil),                    // 94: clusrun.ProbeReply
+	(*JobTemplate)(nil),                   // 95: clusrun.JobTemplate
+	(*GetJobTemplatesRequest)(nil),        // 96: clusrun.GetJobTemplatesRequest
+	(*GetJobTemplatesReply)(nil),          // 97: clusrun.GetJobTemplatesReply
+	(*DeleteJobTemplatesRequest)(nil),     // 98: clusrun.DeleteJobTemplatesRequest
+	(*DeleteJobTemplatesReply)(nil),       // 99: clusrun.DeleteJobTemplatesReply
+	(*JobSchedule)(nil),                   // 100: clusrun.JobSchedule
+	(*GetJobSchedulesRequest)(nil),        // 101: clusrun.GetJobSchedulesRequest
+	(*GetJobSchedulesReply)(nil),          // 102: clusrun.GetJobSchedulesReply
+	(*SetJobSchedulesEnabledRequest)(nil), // 103: clusrun.SetJobSchedulesEnabledRequest
+	(*SetJobSchedulesEnabledReply)(nil),   // 104: clusrun.SetJobSchedulesEnabledReply
+	(*DeleteJobSchedulesRequest)(nil),     // 105: clusrun.DeleteJobSchedulesRequest
+	(*DeleteJobSchedulesReply)(nil),       // 106: clusrun.DeleteJobSchedulesReply
+	(*JobBookmark)(nil),                   // 107: clusrun.JobBookmark
+	(*SaveJobBookmarkRequest)(nil),        // 108: clusrun.SaveJobBookmarkRequest
+	(*GetJobBookmarksRequest)(nil),        // 109: clusrun.GetJobBookmarksRequest
+	(*GetJobBookmarksReply)(nil),          // 110: clusrun.GetJobBookmarksReply
+	(*DeleteJobBookmarksRequest)(nil),     // 111: clusrun.DeleteJobBookmarksRequest
+	(*DeleteJobBookmarksReply)(nil),       // 112: clusrun.DeleteJobBookmarksReply
+	(*TerminalSize)(nil),                  // 113: clusrun.TerminalSize
+	(*ShellRequest)(nil),                  // 114: clusrun.ShellRequest
+	(*ShellReply)(nil),                    // 115: clusrun.ShellReply
+	(*UndoRequest)(nil),                   // 116: clusrun.UndoRequest
+	(*UndoOperation)(nil),                 // 117: clusrun.UndoOperation
+	(*UndoReply)(nil),                     // 118: clusrun.UndoReply
+	(*PurgeJobsRequest)(nil),              // 119: clusrun.PurgeJobsRequest
+	(*PurgeJobsReply)(nil),                // 120: clusrun.PurgeJobsReply
+	(*ExportJobsRequest)(nil),             // 121: clusrun.ExportJobsRequest
+	(*ExportJobsReply)(nil),               // 122: clusrun.ExportJobsReply
+	(*ImportJobsRequest)(nil),             // 123: clusrun.ImportJobsRequest
+	(*ImportJobsReply)(nil),               // 124: clusrun.ImportJobsReply
+	(*QueryResultsRequest)(nil),           // 125: clusrun.QueryResultsRequest
+	(*QueryResultsReply)(nil),             // 126: clusrun.QueryResultsReply
+	(*QueryResultsRow)(nil),               // 127: clusrun.QueryResultsRow
+	(*SearchOutputRequest)(nil),           // 128: clusrun.SearchOutputRequest
+	(*SearchOutputReply)(nil),             // 129: clusrun.SearchOutputReply
+	(*SearchOutputMatch)(nil),             // 130: clusrun.SearchOutputMatch
+	(*ExportTimelineRequest)(nil),         // 131: clusrun.ExportTimelineRequest
+	(*ExportTimelineReply)(nil),           // 132: clusrun.ExportTimelineReply
+	(*JobInputRequest)(nil),               // 133: clusrun.JobInputRequest
+	(*JobInputReply)(nil),                 // 134: clusrun.JobInputReply
+	(*GetLoginOptionsReply)(nil),          // 135: clusrun.GetLoginOptionsReply
+	(*LoginReply)(nil),                    // 136: clusrun.LoginReply
+	(*SubscribeEventsRequest)(nil),        // 137: clusrun.SubscribeEventsRequest
+	(*ClusterEvent)(nil),                  // 138: clusrun.ClusterEvent
+	(*NodeEvent)(nil),                     // 139: clusrun.NodeEvent
+	(*JobEvent)(nil),                      // 140: clusrun.JobEvent
+	(*AuditEntry)(nil),                    // 141: clusrun.AuditEntry
+	(*GetAuditLogRequest)(nil),            // 142: clusrun.GetAuditLogRequest
+	(*GetAuditLogReply)(nil),              // 143: clusrun.GetAuditLogReply
+	nil,                                   // 144: clusrun.HeartbeatRequest.LabelsEntry
+	nil,                                   // 145: clusrun.HeartbeatRequest.ProbesEntry
+	nil,                                   // 146: clusrun.Node.LabelsEntry
+	nil,                                   // 147: clusrun.Node.ProbesEntry
+	nil,                                   // 148: clusrun.GetJobsRequest.JobIdsEntry
+	nil,                                   // 149: clusrun.Job.FailedNodesEntry
+	nil,                                   // 150: clusrun.Job.NodeCommandsEntry
+	nil,                                   // 151: clusrun.Job.ResultsEntry
+	nil,                                   // 152: clusrun.Job.ResultErrorsEntry
+	nil,                                   // 153: clusrun.Job.SkippedNodesEntry
+	nil,                                   // 154: clusrun.Job.OsCommandsEntry
+	nil,                                   // 155: clusrun.Job.TruncatedNodesEntry
+	nil,                                   // 156: clusrun.Job.SweepValuesEntry
+	nil,                                   // 157: clusrun.TaskEnvironment.VariablesEntry
+	nil,                                   // 158: clusrun.StartClusJobRequest.NodeCommandsEntry
+	nil,                                   // 159: clusrun.StartClusJobRequest.OsCommandsEntry
+	nil,                                   // 160: clusrun.StartClusJobRequest.SweepValuesEntry
+	nil,                                   // 161: clusrun.StartClusJobReply.SkippedNodesEntry
+	nil,                                   // 162: clusrun.StartClusJobReply.NodeCommandsEntry
+	nil,                                   // 163: clusrun.StartClusJobReply.SweepValuesEntry
+	nil,                                   // 164: clusrun.StartClusJobReply.PeerJobsEntry
+	nil,                                   // 165: clusrun.StartClusJobReply.PeerErrorsEntry
+	nil,                                   // 166: clusrun.WatchClusJobRequest.StdoutOffsetsEntry
+	nil,                                   // 167: clusrun.WatchClusJobRequest.StderrOffsetsEntry
+	nil,                                   // 168: clusrun.CancelClusJobsRequest.JobIdsEntry
+	nil,                                   // 169: clusrun.CancelClusJobsReply.ResultEntry
+	nil,                                   // 170: clusrun.SetNodeLabelsRequest.LabelsEntry
+	nil,                                   // 171: clusrun.SetHeadnodesReply.ResultsEntry
+	nil,                                   // 172: clusrun.SetConfigsRequest.ConfigsEntry
+	nil,                                   // 173: clusrun.SetConfigsReply.ResultsEntry
+	nil,                                   // 174: clusrun.SetConfigsReply.NodeResultsEntry
+	nil,                                   // 175: clusrun.NodeConfigsResult.ResultsEntry
+	nil,                                   // 176: clusrun.ApplyConfigsRequest.ConfigsEntry
+	nil,                                   // 177: clusrun.GetConfigsReply.ConfigsEntry
+	nil,                                   // 178: clusrun.GetConfigDriftRequest.BaselineEntry
+	nil,                                   // 179: clusrun.GetConfigDriftReply.BaselineEntry
+	nil,                                   // 180: clusrun.GetConfigDriftReply.NodeErrorsEntry
+	nil,                                   // 181: clusrun.GetConfigDriftReply.RemediationsEntry
+	nil,                                   // 182: clusrun.ConfigDrift.ValuesEntry
+	nil,                                   // 183: clusrun.GetCapabilitiesReply.CapabilitiesEntry
+	nil,                                   // 184: clusrun.GetClusterSummaryReply.NodeStatesEntry
+	nil,                                   // 185: clusrun.GetClusterSummaryReply.NodeGroupsEntry
+	nil,                                   // 186: clusrun.UploadFilesReply.ResultsEntry
+	nil,                                   // 187: clusrun.GatherFilesReply.ResultsEntry
+	nil,                                   // 188: clusrun.ResetNodeKeysReply.ResultsEntry
+	nil,                                   // 189: clusrun.DrainNodesReply.ResultsEntry
+	nil,                                   // 190: clusrun.RevalidateNodesReply.FailedNodesEntry
+	nil,                                   // 191: clusrun.ImportJobsReply.JobIdsEntry
+	nil,                                   // 192: clusrun.SearchOutputRequest.JobIdsEntry
+	nil,                                   // 193: clusrun.JobInputReply.FailedNodesEntry
+}
+var file_protobuf_clusrun_proto_depIdxs = []int32{
+	10,  // 0: clusrun.HeartbeatRequest.resources:type_name -> clusrun.NodeResources
+	12,  // 1: clusrun.HeartbeatRequest.system:type_name -> clusrun.NodeSystem
+	13,  // 2: clusrun.HeartbeatRequest.build:type_name -> clusrun.NodeBuild
+	144, // 3: clusrun.HeartbeatRequest.labels:type_name -> clusrun.HeartbeatRequest.LabelsEntry
+	145, // 4: clusrun.HeartbeatRequest.probes:type_name -> clusrun.HeartbeatRequest.ProbesEntry
+	0,   // 5: clusrun.HeartbeatControl.command:type_name -> clusrun.HeartbeatCommand
+	5,   // 6: clusrun.BatchHeartbeatRequest.heartbeats:type_name -> clusrun.HeartbeatRequest
+	11,  // 7: clusrun.NodeResources.gpus:type_name -> clusrun.GpuInfo
+	1,   // 8: clusrun.GetNodesRequest.state:type_name -> clusrun.NodeState
+	1,   // 9: clusrun.Node.state:type_name -> clusrun.NodeState
+	10,  // 10: clusrun.Node.resources:type_name -> clusrun.NodeResources
+	12,  // 11: clusrun.Node.system:type_name -> clusrun.NodeSystem
+	13,  // 12: clusrun.Node.build:type_name -> clusrun.NodeBuild
+	22,  // 13: clusrun.Node.stats:type_name -> clusrun.NodeStats
+	146, // 14: clusrun.Node.labels:type_name -> clusrun.Node.LabelsEntry
+	147, // 15: clusrun.Node.probes:type_name -> clusrun.Node.ProbesEntry
+	21,  // 16: clusrun.GetNodesReply.nodes:type_name -> clusrun.Node
+	148, // 17: clusrun.GetJobsRequest.job_ids:type_name -> clusrun.GetJobsRequest.JobIdsEntry
+	2,   // 18: clusrun.GetJobsRequest.states:type_name -> clusrun.JobState
+	2,   // 19: clusrun.Job.state:type_name -> clusrun.JobState
+	149, // 20: clusrun.Job.failed_nodes:type_name -> clusrun.Job.FailedNodesEntry
+	31,  // 21: clusrun.Job.reschedules:type_name -> clusrun.Reschedule
+	150, // 22: clusrun.Job.node_commands:type_name -> clusrun.Job.NodeCommandsEntry
+	151, // 23: clusrun.Job.results:type_name -> clusrun.Job.ResultsEntry
+	152, // 24: clusrun.Job.result_errors:type_name -> clusrun.Job.ResultErrorsEntry
+	29,  // 25: clusrun.Job.tasks:type_name -> clusrun.TaskSpan
+	14,  // 26: clusrun.Job.requirements:type_name -> clusrun.ResourceRequirements
+	153, // 27: clusrun.Job.skipped_nodes:type_name -> clusrun.Job.SkippedNodesEntry
+	15,  // 28: clusrun.Job.limits:type_name -> clusrun.JobLimits
+	16,  // 29: clusrun.Job.output_window:type_name -> clusrun.OutputWindow
+	18,  // 30: clusrun.Job.rolling:type_name -> clusrun.RollingPolicy
+	17,  // 31: clusrun.Job.fail_fast:type_name -> clusrun.FailFast
+	28,  // 32: clusrun.Job.after:type_name -> clusrun.JobDependency
+	27,  // 33: clusrun.Job.failure_clusters:type_name -> clusrun.FailureCluster
+	26,  // 34: clusrun.Job.summary:type_name -> clusrun.JobSummary
+	154, // 35: clusrun.Job.os_commands:type_name -> clusrun.Job.OsCommandsEntry
+	155, // 36: clusrun.Job.truncated_nodes:type_name -> clusrun.Job.TruncatedNodesEntry
+	156, // 37: clusrun.Job.sweep_values:type_name -> clusrun.Job.SweepValuesEntry
+	36,  // 38: clusrun.Job.executor:type_name -> clusrun.JobExecutor
+	30,  // 39: clusrun.TaskSpan.environment:type_name -> clusrun.TaskEnvironment
+	46,  // 40: clusrun.TaskSpan.checksum:type_name -> clusrun.OutputChecksum
+	157, // 41: clusrun.TaskEnvironment.variables:type_name -> clusrun.TaskEnvironment.VariablesEntry
+	25,  // 42: clusrun.GetJobsReply.jobs:type_name -> clusrun.Job
+	29,  // 43: clusrun.GetOutputReply.task:type_name -> clusrun.TaskSpan
+	158, // 44: clusrun.StartClusJobRequest.node_commands:type_name -> clusrun.StartClusJobRequest.NodeCommandsEntry
+	14,  // 45: clusrun.StartClusJobRequest.requirements:type_name -> clusrun.ResourceRequirements
+	15,  // 46: clusrun.StartClusJobRequest.limits:type_name -> clusrun.JobLimits
+	16,  // 47: clusrun.StartClusJobRequest.output_window:type_name -> clusrun.OutputWindow
+	18,  // 48: clusrun.StartClusJobRequest.rolling:type_name -> clusrun.RollingPolicy
+	17,  // 49: clusrun.StartClusJobRequest.fail_fast:type_name -> clusrun.FailFast
+	28,  // 50: clusrun.StartClusJobRequest.after:type_name -> clusrun.JobDependency
+	159, // 51: clusrun.StartClusJobRequest.os_commands:type_name -> clusrun.StartClusJobRequest.OsCommandsEntry
+	160, // 52: clusrun.StartClusJobRequest.sweep_values:type_name -> clusrun.StartClusJobRequest.SweepValuesEntry
+	36,  // 53: clusrun.StartClusJobRequest.executor:type_name -> clusrun.JobExecutor
+	161, // 54: clusrun.StartClusJobReply.skipped_nodes:type_name -> clusrun.StartClusJobReply.SkippedNodesEntry
+	41,  // 55: clusrun.StartClusJobReply.warnings:type_name -> clusrun.JobLintWarning
+	26,  // 56: clusrun.StartClusJobReply.summary:type_name -> clusrun.JobSummary
+	162, // 57: clusrun.StartClusJobReply.node_commands:type_name -> clusrun.StartClusJobReply.NodeCommandsEntry
+	163, // 58: clusrun.StartClusJobReply.sweep_values:type_name -> clusrun.StartClusJobReply.SweepValuesEntry
+	39,  // 59: clusrun.StartClusJobReply.progress:type_name -> clusrun.JobProgress
+	164, // 60: clusrun.StartClusJobReply.peer_jobs:type_name -> clusrun.StartClusJobReply.PeerJobsEntry
+	165, // 61: clusrun.StartClusJobReply.peer_errors:type_name -> clusrun.StartClusJobReply.PeerErrorsEntry
+	166, // 62: clusrun.WatchClusJobRequest.stdout_offsets:type_name -> clusrun.WatchClusJobRequest.StdoutOffsetsEntry
+	167, // 63: clusrun.WatchClusJobRequest.stderr_offsets:type_name -> clusrun.WatchClusJobRequest.StderrOffsetsEntry
+	168, // 64: clusrun.CancelClusJobsRequest.job_ids:type_name -> clusrun.CancelClusJobsRequest.JobIdsEntry
+	169, // 65: clusrun.CancelClusJobsReply.result:type_name -> clusrun.CancelClusJobsReply.ResultEntry
+	15,  // 66: clusrun.StartJobRequest.limits:type_name -> clusrun.JobLimits
+	16,  // 67: clusrun.StartJobRequest.output_window:type_name -> clusrun.OutputWindow
+	36,  // 68: clusrun.StartJobRequest.executor:type_name -> clusrun.JobExecutor
+	30,  // 69: clusrun.StartJobReply.environment:type_name -> clusrun.TaskEnvironment
+	46,  // 70: clusrun.StartJobReply.checksum:type_name -> clusrun.OutputChecksum
+	44,  // 71: clusrun.RelayJobRequest.request:type_name -> clusrun.StartJobRequest
+	49,  // 72: clusrun.RelayJobRequest.signatures:type_name -> clusrun.HeadnodeSignature
+	3,   // 73: clusrun.QueryJobReply.state:type_name -> clusrun.TaskState
+	13,  // 74: clusrun.ValidateRequest.build:type_name -> clusrun.NodeBuild
+	13,  // 75: clusrun.ValidateReply.build:type_name -> clusrun.NodeBuild
+	12,  // 76: clusrun.ValidateReply.system:type_name -> clusrun.NodeSystem
+	21,  // 77: clusrun.SetNodeGroupsRequest.nodes:type_name -> clusrun.Node
+	170, // 78: clusrun.SetNodeLabelsRequest.labels:type_name -> clusrun.SetNodeLabelsRequest.LabelsEntry
+	4,   // 79: clusrun.SetHeadnodesRequest.mode:type_name -> clusrun.SetHeadnodesMode
+	171, // 80: clusrun.SetHeadnodesReply.results:type_name -> clusrun.SetHeadnodesReply.ResultsEntry
+	172, // 81: clusrun.SetConfigsRequest.configs:type_name -> clusrun.SetConfigsRequest.ConfigsEntry
+	173, // 82: clusrun.SetConfigsReply.results:type_name -> clusrun.SetConfigsReply.ResultsEntry
+	174, // 83: clusrun.SetConfigsReply.node_results:type_name -> clusrun.SetConfigsReply.NodeResultsEntry
+	175, // 84: clusrun.NodeConfigsResult.results:type_name -> clusrun.NodeConfigsResult.ResultsEntry
+	176, // 85: clusrun.ApplyConfigsRequest.configs:type_name -> clusrun.ApplyConfigsRequest.ConfigsEntry
+	177, // 86: clusrun.GetConfigsReply.configs:type_name -> clusrun.GetConfigsReply.ConfigsEntry
+	178, // 87: clusrun.GetConfigDriftRequest.baseline:type_name -> clusrun.GetConfigDriftRequest.BaselineEntry
+	179, // 88: clusrun.GetConfigDriftReply.baseline:type_name -> clusrun.GetConfigDriftReply.BaselineEntry
+	67,  // 89: clusrun.GetConfigDriftReply.drifts:type_name -> clusrun.ConfigDrift
+	180, // 90: clusrun.GetConfigDriftReply.node_errors:type_name -> clusrun.GetConfigDriftReply.NodeErrorsEntry
+	181, // 91: clusrun.GetConfigDriftReply.remediations:type_name -> clusrun.GetConfigDriftReply.RemediationsEntry
+	182, // 92: clusrun.ConfigDrift.values:type_name -> clusrun.ConfigDrift.ValuesEntry
+	70,  // 93: clusrun.GetNodeAvailabilityReply.nodes:type_name -> clusrun.NodeAvailability
+	71,  // 94: clusrun.NodeAvailability.uptimes:type_name -> clusrun.NodeUptime
+	183, // 95: clusrun.GetCapabilitiesReply.capabilities:type_name -> clusrun.GetCapabilitiesReply.CapabilitiesEntry
+	184, // 96: clusrun.GetClusterSummaryReply.node_states:type_name -> clusrun.GetClusterSummaryReply.NodeStatesEntry
+	185, // 97: clusrun.GetClusterSummaryReply.node_groups:type_name -> clusrun.GetClusterSummaryReply.NodeGroupsEntry
+	74,  // 98: clusrun.UploadFilesRequest.chunk:type_name -> clusrun.FileChunk
+	186, // 99: clusrun.UploadFilesReply.results:type_name -> clusrun.UploadFilesReply.ResultsEntry
+	74,  // 100: clusrun.ReceiveFilesRequest.chunk:type_name -> clusrun.FileChunk
+	187, // 101: clusrun.GatherFilesReply.results:type_name -> clusrun.GatherFilesReply.ResultsEntry
+	188, // 102: clusrun.ResetNodeKeysReply.results:type_name -> clusrun.ResetNodeKeysReply.ResultsEntry
+	189, // 103: clusrun.DrainNodesReply.results:type_name -> clusrun.DrainNodesReply.ResultsEntry
+	190, // 104: clusrun.RevalidateNodesReply.failed_nodes:type_name -> clusrun.RevalidateNodesReply.FailedNodesEntry
+	92,  // 105: clusrun.TestNodesReply.results:type_name -> clusrun.NodeTestResult
+	95,  // 106: clusrun.GetJobTemplatesReply.templates:type_name -> clusrun.JobTemplate
+	35,  // 107: clusrun.JobSchedule.job:type_name -> clusrun.StartClusJobRequest
+	100, // 108: clusrun.GetJobSchedulesReply.schedules:type_name -> clusrun.JobSchedule
+	107, // 109: clusrun.SaveJobBookmarkRequest.bookmark:type_name -> clusrun.JobBookmark
+	107, // 110: clusrun.GetJobBookmarksReply.bookmarks:type_name -> clusrun.JobBookmark
+	113, // 111: clusrun.ShellRequest.size:type_name -> clusrun.TerminalSize
+	117, // 112: clusrun.UndoReply.operations:type_name -> clusrun.UndoOperation
+	25,  // 113: clusrun.ExportJobsReply.job:type_name -> clusrun.Job
+	25,  // 114: clusrun.ImportJobsRequest.job:type_name -> clusrun.Job
+	191, // 115: clusrun.ImportJobsReply.job_ids:type_name -> clusrun.ImportJobsReply.JobIdsEntry
+	127, // 116: clusrun.QueryResultsReply.rows:type_name -> clusrun.QueryResultsRow
+	192, // 117: clusrun.SearchOutputRequest.job_ids:type_name -> clusrun.SearchOutputRequest.JobIdsEntry
+	130, // 118: clusrun.SearchOutputReply.matches:type_name -> clusrun.SearchOutputMatch
+	193, // 119: clusrun.JobInputReply.failed_nodes:type_name -> clusrun.JobInputReply.FailedNodesEntry
+	139, // 120: clusrun.ClusterEvent.node:type_name -> clusrun.NodeEvent
+	140, // 121: clusrun.ClusterEvent.job:type_name -> clusrun.JobEvent
+	1,   // 122: clusrun.NodeEvent.from:type_name -> clusrun.NodeState
+	1,   // 123: clusrun.NodeEvent.to:type_name -> clusrun.NodeState
+	2,   // 124: clusrun.JobEvent.from:type_name -> clusrun.JobState
+	2,   // 125: clusrun.JobEvent.to:type_name -> clusrun.JobState
+	141, // 126: clusrun.GetAuditLogReply.entries:type_name -> clusrun.AuditEntry
+	2,   // 127: clusrun.CancelClusJobsReply.ResultEntry.value:type_name -> clusrun.JobState
+	61,  // 128: clusrun.SetConfigsReply.NodeResultsEntry.value:type_name -> clusrun.NodeConfigsResult
+	61,  // 129: clusrun.GetConfigDriftReply.RemediationsEntry.value:type_name -> clusrun.NodeConfigsResult
+	5,   // 130: clusrun.Headnode.Heartbeat:input_type -> clusrun.HeartbeatRequest
+	5,   // 131: clusrun.Headnode.HeartbeatStream:input_type -> clusrun.HeartbeatRequest
+	20,  // 132: clusrun.Headnode.GetNodes:input_type -> clusrun.GetNodesRequest
+	24,  // 133: clusrun.Headnode.GetJobs:input_type -> clusrun.GetJobsRequest
+	33,  // 134: clusrun.Headnode.GetOutput:input_type -> clusrun.GetOutputRequest
+	35,  // 135: clusrun.Headnode.StartClusJob:input_type -> clusrun.StartClusJobRequest
+	42,  // 136: clusrun.Headnode.CancelClusJobs:input_type -> clusrun.CancelClusJobsRequest
+	59,  // 137: clusrun.Headnode.SetConfigs:input_type -> clusrun.SetConfigsRequest
+	19,  // 138: clusrun.Headnode.GetConfigs:input_type -> clusrun.Empty
+	55,  // 139: clusrun.Headnode.SetNodeGroups:input_type -> clusrun.SetNodeGroupsRequest
+	56,  // 140: clusrun.Headnode.SetNodeLabels:input_type -> clusrun.SetNodeLabelsRequest
+	19,  // 141: clusrun.Headnode.GetCapabilities:input_type -> clusrun.Empty
+	19,  // 142: clusrun.Headnode.GetClusterSummary:input_type -> clusrun.Empty
+	75,  // 143: clusrun.Headnode.UploadFiles:input_type -> clusrun.UploadFilesRequest
+	79,  // 144: clusrun.Headnode.GatherFiles:input_type -> clusrun.GatherFilesRequest
+	82,  // 145: clusrun.Headnode.ResetNodeKeys:input_type -> clusrun.ResetNodeKeysRequest
+	119, // 146: clusrun.Headnode.PurgeJobs:input_type -> clusrun.PurgeJobsRequest
+	121, // 147: clusrun.Headnode.ExportJobs:input_type -> clusrun.ExportJobsRequest
+	123, // 148: clusrun.Headnode.ImportJobs:input_type -> clusrun.ImportJobsRequest
+	125, // 149: clusrun.Headnode.QueryResults:input_type -> clusrun.QueryResultsRequest
+	128, // 150: clusrun.Headnode.SearchOutput:input_type -> clusrun.SearchOutputRequest
+	131, // 151: clusrun.Headnode.ExportTimeline:input_type -> clusrun.ExportTimelineRequest
+	8,   // 152: clusrun.Headnode.BatchHeartbeat:input_type -> clusrun.BatchHeartbeatRequest
+	84,  // 153: clusrun.Headnode.DrainNodes:input_type -> clusrun.DrainNodesRequest
+	86,  // 154: clusrun.Headnode.RemoveNodes:input_type -> clusrun.RemoveNodesRequest
+	88,  // 155: clusrun.Headnode.RevalidateNodes:input_type -> clusrun.RevalidateNodesRequest
+	116, // 156: clusrun.Headnode.Undo:input_type -> clusrun.UndoRequest
+	95,  // 157: clusrun.Headnode.SaveJobTemplate:input_type -> clusrun.JobTemplate
+	96,  // 158: clusrun.Headnode.GetJobTemplates:input_type -> clusrun.GetJobTemplatesRequest
+	98,  // 159: clusrun.Headnode.DeleteJobTemplates:input_type -> clusrun.DeleteJobTemplatesRequest
+	24,  // 160: clusrun.Headnode.StreamJobs:input_type -> clusrun.GetJobsRequest
+	100, // 161: clusrun.Headnode.SaveJobSchedule:input_type -> clusrun.JobSchedule
+	101, // 162: clusrun.Headnode.GetJobSchedules:input_type -> clusrun.GetJobSchedulesRequest
+	103, // 163: clusrun.Headnode.SetJobSchedulesEnabled:input_type -> clusrun.SetJobSchedulesEnabledRequest
+	105, // 164: clusrun.Headnode.DeleteJobSchedules:input_type -> clusrun.DeleteJobSchedulesRequest
+	108, // 165: clusrun.Headnode.SaveJobBookmark:input_type -> clusrun.SaveJobBookmarkRequest
+	109, // 166: clusrun.Headnode.GetJobBookmarks:input_type -> clusrun.GetJobBookmarksRequest
+	111, // 167: clusrun.Headnode.DeleteJobBookmarks:input_type -> clusrun.DeleteJobBookmarksRequest
+	114, // 168: clusrun.Headnode.Shell:input_type -> clusrun.ShellRequest
+	133, // 169: clusrun.Headnode.ForwardJobInput:input_type -> clusrun.JobInputRequest
+	19,  // 170: clusrun.Headnode.GetLoginOptions:input_type -> clusrun.Empty
+	19,  // 171: clusrun.Headnode.Login:input_type -> clusrun.Empty
+	137, // 172: clusrun.Headnode.SubscribeEvents:input_type -> clusrun.SubscribeEventsRequest
+	40,  // 173: clusrun.Headnode.WatchClusJob:input_type -> clusrun.WatchClusJobRequest
+	90,  // 174: clusrun.Headnode.TestNodes:input_type -> clusrun.TestNodesRequest
+	142, // 175: clusrun.Headnode.GetAuditLog:input_type -> clusrun.GetAuditLogRequest
+	7,   // 176: clusrun.Headnode.Tunnel:input_type -> clusrun.TunnelData
+	20,  // 177: clusrun.Headnode.WatchNodes:input_type -> clusrun.GetNodesRequest
+	37,  // 178: clusrun.Headnode.RerunJob:input_type -> clusrun.RerunJobRequest
+	65,  // 179: clusrun.Headnode.GetConfigDrift:input_type -> clusrun.GetConfigDriftRequest
+	68,  // 180: clusrun.Headnode.GetNodeAvailability:input_type -> clusrun.GetNodeAvailabilityRequest
+	44,  // 181: clusrun.Clusnode.StartJob:input_type -> clusrun.StartJobRequest
+	52,  // 182: clusrun.Clusnode.CancelJob:input_type -> clusrun.CancelJobRequest
+	53,  // 183: clusrun.Clusnode.Validate:input_type -> clusrun.ValidateRequest
+	57,  // 184: clusrun.Clusnode.SetHeadnodes:input_type -> clusrun.SetHeadnodesRequest
+	59,  // 185: clusrun.Clusnode.SetConfigs:input_type -> clusrun.SetConfigsRequest
+	19,  // 186: clusrun.Clusnode.GetConfigs:input_type -> clusrun.Empty
+	77,  // 187: clusrun.Clusnode.ReceiveFiles:input_type -> clusrun.ReceiveFilesRequest
+	81,  // 188: clusrun.Clusnode.SendFiles:input_type -> clusrun.SendFilesRequest
+	114, // 189: clusrun.Clusnode.Shell:input_type -> clusrun.ShellRequest
+	133, // 190: clusrun.Clusnode.WriteJobInput:input_type -> clusrun.JobInputRequest
+	142, // 191: clusrun.Clusnode.GetAuditLog:input_type -> clusrun.GetAuditLogRequest
+	47,  // 192: clusrun.Clusnode.QueryJob:input_type -> clusrun.QueryJobRequest
+	62,  // 193: clusrun.Clusnode.ApplyConfigs:input_type -> clusrun.ApplyConfigsRequest
+	64,  // 194: clusrun.Clusnode.ReportConfigs:input_type -> clusrun.ReportConfigsRequest
+	50,  // 195: clusrun.Clusnode.ResumeJob:input_type -> clusrun.ResumeJobRequest
+	93,  // 196: clusrun.Clusnode.Probe:input_type -> clusrun.ProbeRequest
+	48,  // 197: clusrun.Clusnode.RelayJob:input_type -> clusrun.RelayJobRequest
+	19,  // 198: clusrun.Headnode.Heartbeat:output_type -> clusrun.Empty
+	6,   // 199: clusrun.Headnode.HeartbeatStream:output_type -> clusrun.HeartbeatControl
+	23,  // 200: clusrun.Headnode.GetNodes:output_type -> clusrun.GetNodesReply
+	32,  // 201: clusrun.Headnode.GetJobs:output_type -> clusrun.GetJobsReply
+	34,  // 202: clusrun.Headnode.GetOutput:output_type -> clusrun.GetOutputReply
+	38,  // 203: clusrun.Headnode.StartClusJob:output_type -> clusrun.StartClusJobReply
+	43,  // 204: clusrun.Headnode.CancelClusJobs:output_type -> clusrun.CancelClusJobsReply
+	60,  // 205: clusrun.Headnode.SetConfigs:output_type -> clusrun.SetConfigsReply
+	63,  // 206: clusrun.Headnode.GetConfigs:output_type -> clusrun.GetConfigsReply
+	19,  // 207: clusrun.Headnode.SetNodeGroups:output_type -> clusrun.Empty
+	19,  // 208: clusrun.Headnode.SetNodeLabels:output_type -> clusrun.Empty
+	72,  // 209: clusrun.Headnode.GetCapabilities:output_type -> clusrun.GetCapabilitiesReply
+	73,  // 210: clusrun.Headnode.GetClusterSummary:output_type -> clusrun.GetClusterSummaryReply
+	76,  // 211: clusrun.Headnode.UploadFiles:output_type -> clusrun.UploadFilesReply
+	80,  // 212: clusrun.Headnode.GatherFiles:output_type -> clusrun.GatherFilesReply
+	83,  // 213: clusrun.Headnode.ResetNodeKeys:output_type -> clusrun.ResetNodeKeysReply
+	120, // 214: clusrun.Headnode.PurgeJobs:output_type -> clusrun.PurgeJobsReply
+	122, // 215: clusrun.Headnode.ExportJobs:output_type -> clusrun.ExportJobsReply
+	124, // 216: clusrun.Headnode.ImportJobs:output_type -> clusrun.ImportJobsReply
+	126, // 217: clusrun.Headnode.QueryResults:output_type -> clusrun.QueryResultsReply
+	129, // 218: clusrun.Headnode.SearchOutput:output_type -> clusrun.SearchOutputReply
+	132, // 219: clusrun.Headnode.ExportTimeline:output_type -> clusrun.ExportTimelineReply
+	9,   // 220: clusrun.Headnode.BatchHeartbeat:output_type -> clusrun.BatchHeartbeatReply
+	85,  // 221: clusrun.Headnode.DrainNodes:output_type -> clusrun.DrainNodesReply
+	87,  // 222: clusrun.Headnode.RemoveNodes:output_type -> clusrun.RemoveNodesReply
+	89,  // 223: clusrun.Headnode.RevalidateNodes:output_type -> clusrun.RevalidateNodesReply
+	118, // 224: clusrun.Headnode.Undo:output_type -> clusrun.UndoReply
+	19,  // 225: clusrun.Headnode.SaveJobTemplate:output_type -> clusrun.Empty
+	97,  // 226: clusrun.Headnode.GetJobTemplates:output_type -> clusrun.GetJobTemplatesReply
+	99,  // 227: clusrun.Headnode.DeleteJobTemplates:output_type -> clusrun.DeleteJobTemplatesReply
+	25,  // 228: clusrun.Headnode.StreamJobs:output_type -> clusrun.Job
+	19,  // 229: clusrun.Headnode.SaveJobSchedule:output_type -> clusrun.Empty
+	102, // 230: clusrun.Headnode.GetJobSchedules:output_type -> clusrun.GetJobSchedulesReply
+	104, // 231: clusrun.Headnode.SetJobSchedulesEnabled:output_type -> clusrun.SetJobSchedulesEnabledReply
+	106, // 232: clusrun.Headnode.DeleteJobSchedules:output_type -> clusrun.DeleteJobSchedulesReply
+	19,  // 233: clusrun.Headnode.SaveJobBookmark:output_type -> clusrun.Empty
+	110, // 234: clusrun.Headnode.GetJobBookmarks:output_type -> clusrun.GetJobBookmarksReply
+	112, // 235: clusrun.Headnode.DeleteJobBookmarks:output_type -> clusrun.DeleteJobBookmarksReply
+	115, // 236: clusrun.Headnode.Shell:output_type -> clusrun.ShellReply
+	134, // 237: clusrun.Headnode.ForwardJobInput:output_type -> clusrun.JobInputReply
+	135, // 238: clusrun.Headnode.GetLoginOptions:output_type -> clusrun.GetLoginOptionsReply
+	136, // 239: clusrun.Headnode.Login:output_type -> clusrun.LoginReply
+	138, // 240: clusrun.Headnode.SubscribeEvents:output_type -> clusrun.ClusterEvent
+	38,  // 241: clusrun.Headnode.WatchClusJob:output_type -> clusrun.StartClusJobReply
+	91,  // 242: clusrun.Headnode.TestNodes:output_type -> clusrun.TestNodesReply
+	143, // 243: clusrun.Headnode.GetAuditLog:output_type -> clusrun.GetAuditLogReply
+	7,   // 244: clusrun.Headnode.Tunnel:output_type -> clusrun.TunnelData
+	23,  // 245: clusrun.Headnode.WatchNodes:output_type -> clusrun.GetNodesReply
+	38,  // 246: clusrun.Headnode.RerunJob:output_type -> clusrun.StartClusJobReply
+	66,  // 247: clusrun.Headnode.GetConfigDrift:output_type -> clusrun.GetConfigDriftReply
+	69,  // 248: clusrun.Headnode.GetNodeAvailability:output_type -> clusrun.GetNodeAvailabilityReply
+	45,  // 249: clusrun.Clusnode.StartJob:output_type -> clusrun.StartJobReply
+	19,  // 250: clusrun.Clusnode.CancelJob:output_type -> clusrun.Empty
+	54,  // 251: clusrun.Clusnode.Validate:output_type -> clusrun.ValidateReply
+	58,  // 252: clusrun.Clusnode.SetHeadnodes:output_type -> clusrun.SetHeadnodesReply
+	60,  // 253: clusrun.Clusnode.SetConfigs:output_type -> clusrun.SetConfigsReply
+	63,  // 254: clusrun.Clusnode.GetConfigs:output_type -> clusrun.GetConfigsReply
+	78,  // 255: clusrun.Clusnode.ReceiveFiles:output_type -> clusrun.ReceiveFilesReply
+	74,  // 256: clusrun.Clusnode.SendFiles:output_type -> clusrun.FileChunk
+	115, // 257: clusrun.Clusnode.Shell:output_type -> clusrun.ShellReply
+	134, // 258: clusrun.Clusnode.WriteJobInput:output_type -> clusrun.JobInputReply
+	143, // 259: clusrun.Clusnode.GetAuditLog:output_type -> clusrun.GetAuditLogReply
+	51,  // 260: clusrun.Clusnode.QueryJob:output_type -> clusrun.QueryJobReply
+	60,  // 261: clusrun.Clusnode.ApplyConfigs:output_type -> clusrun.SetConfigsReply
+	63,  // 262: clusrun.Clusnode.ReportConfigs:output_type -> clusrun.GetConfigsReply
+	45,  // 263: clusrun.Clusnode.ResumeJob:output_type -> clusrun.StartJobReply
+	94,  // 264: clusrun.Clusnode.Probe:output_type -> clusrun.ProbeReply
+	45,  // 265: clusrun.Clusnode.RelayJob:output_type -> clusrun.StartJobReply
+	198, // [198:266] is the sub-list for method output_type
+	130, // [130:198] is the sub-list for method input_type
+	130, // [130:130] is the sub-list for extension type_name
+	130, // [130:130] is the sub-list for extension extendee
+	0,   // [0:130] is the sub-list for field type_name
+}
+
+func init() { file_protobuf_clusrun_proto_init() }
+func file_protobuf_clusrun_proto_init() {
+	if File_protobuf_clusrun_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protobuf_clusrun_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeartbeatRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeartbeatControl); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TunnelData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchHeartbeatRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchHeartbeatReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeResources); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GpuInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeSystem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeBuild); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceRequirements); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutputWindow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FailFast); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RollingPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNodesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Node); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNodesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Job); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FailureCluster); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobDependency); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskSpan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskEnvironment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Reschedule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOutputRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOutputReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartClusJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobExecutor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RerunJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartClusJobReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchClusJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobLintWarning); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelClusJobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelClusJobsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartJobReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutputChecksum); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelayJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeadnodeSignature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryJobReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidateReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetNodeGroupsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetNodeLabelsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetHeadnodesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetHeadnodesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetConfigsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetConfigsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeConfigsResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyConfigsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConfigsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportConfigsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConfigDriftRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConfigDriftReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigDrift); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNodeAvailabilityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNodeAvailabilityReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeAvailability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeUptime); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCapabilitiesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetClusterSummaryReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadFilesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadFilesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReceiveFilesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReceiveFilesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GatherFilesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GatherFilesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendFilesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetNodeKeysRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetNodeKeysReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainNodesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainNodesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveNodesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveNodesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevalidateNodesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevalidateNodesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[85].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestNodesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[86].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestNodesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[87].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeTestResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[88].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProbeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[89].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProbeReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[90].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobTemplate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[91].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobTemplatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[92].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobTemplatesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[93].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteJobTemplatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[94].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteJobTemplatesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[95].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobSchedule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[96].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobSchedulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[97].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobSchedulesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[98].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetJobSchedulesEnabledRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[99].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetJobSchedulesEnabledReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[100].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteJobSchedulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[101].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteJobSchedulesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[102].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobBookmark); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[103].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveJobBookmarkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[104].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobBookmarksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[105].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobBookmarksReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[106].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteJobBookmarksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[107].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteJobBookmarksReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[108].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TerminalSize); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[109].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShellRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[110].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShellReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[111].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UndoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[112].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UndoOperation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[113].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UndoReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[114].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PurgeJobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[115].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PurgeJobsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[116].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportJobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[117].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportJobsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[118].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportJobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[119].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportJobsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[120].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryResultsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[121].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryResultsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[122].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryResultsRow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[123].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchOutputRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
 			default:
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CancelClusJobsRequest); i {
+		file_protobuf_clusrun_proto_msgTypes[124].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchOutputReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2310,8 +14782,8 @@ func file_protobuf_clusrun_proto_init() {
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CancelClusJobsReply); i {
+		file_protobuf_clusrun_proto_msgTypes[125].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchOutputMatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2322,8 +14794,8 @@ func file_protobuf_clusrun_proto_init() {
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartJobRequest); i {
+		file_protobuf_clusrun_proto_msgTypes[126].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportTimelineRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2334,8 +14806,8 @@ func file_protobuf_clusrun_proto_init() {
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartJobReply); i {
+		file_protobuf_clusrun_proto_msgTypes[127].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportTimelineReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2346,8 +14818,8 @@ func file_protobuf_clusrun_proto_init() {
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CancelJobRequest); i {
+		file_protobuf_clusrun_proto_msgTypes[128].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobInputRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2358,8 +14830,8 @@ func file_protobuf_clusrun_proto_init() {
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ValidateRequest); i {
+		file_protobuf_clusrun_proto_msgTypes[129].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobInputReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2370,8 +14842,8 @@ func file_protobuf_clusrun_proto_init() {
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ValidateReply); i {
+		file_protobuf_clusrun_proto_msgTypes[130].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLoginOptionsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2382,8 +14854,8 @@ func file_protobuf_clusrun_proto_init() {
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetNodeGroupsRequest); i {
+		file_protobuf_clusrun_proto_msgTypes[131].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoginReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2394,8 +14866,8 @@ func file_protobuf_clusrun_proto_init() {
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetHeadnodesRequest); i {
+		file_protobuf_clusrun_proto_msgTypes[132].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeEventsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2406,8 +14878,8 @@ func file_protobuf_clusrun_proto_init() {
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetHeadnodesReply); i {
+		file_protobuf_clusrun_proto_msgTypes[133].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClusterEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2418,8 +14890,8 @@ func file_protobuf_clusrun_proto_init() {
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetConfigsRequest); i {
+		file_protobuf_clusrun_proto_msgTypes[134].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2430,8 +14902,8 @@ func file_protobuf_clusrun_proto_init() {
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetConfigsReply); i {
+		file_protobuf_clusrun_proto_msgTypes[135].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2442,8 +14914,32 @@ func file_protobuf_clusrun_proto_init() {
 				return nil
 			}
 		}
-		file_protobuf_clusrun_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetConfigsReply); i {
+		file_protobuf_clusrun_proto_msgTypes[136].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[137].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAuditLogRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_clusrun_proto_msgTypes[138].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAuditLogReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2455,123 +14951,2357 @@ func file_protobuf_clusrun_proto_init() {
 			}
 		}
 	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_protobuf_clusrun_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   33,
-			NumExtensions: 0,
-			NumServices:   2,
-		},
-		GoTypes:           file_protobuf_clusrun_proto_goTypes,
-		DependencyIndexes: file_protobuf_clusrun_proto_depIdxs,
-		EnumInfos:         file_protobuf_clusrun_proto_enumTypes,
-		MessageInfos:      file_protobuf_clusrun_proto_msgTypes,
-	}.Build()
-	File_protobuf_clusrun_proto = out.File
-	file_protobuf_clusrun_proto_rawDesc = nil
-	file_protobuf_clusrun_proto_goTypes = nil
-	file_protobuf_clusrun_proto_depIdxs = nil
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protobuf_clusrun_proto_rawDesc,
+			NumEnums:      5,
+			NumMessages:   189,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_protobuf_clusrun_proto_goTypes,
+		DependencyIndexes: file_protobuf_clusrun_proto_depIdxs,
+		EnumInfos:         file_protobuf_clusrun_proto_enumTypes,
+		MessageInfos:      file_protobuf_clusrun_proto_msgTypes,
+	}.Build()
+	File_protobuf_clusrun_proto = out.File
+	file_protobuf_clusrun_proto_rawDesc = nil
+	file_protobuf_clusrun_proto_goTypes = nil
+	file_protobuf_clusrun_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// HeadnodeClient is the client API for Headnode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HeadnodeClient interface {
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*Empty, error)
+	HeartbeatStream(ctx context.Context, opts ...grpc.CallOption) (Headnode_HeartbeatStreamClient, error)
+	GetNodes(ctx context.Context, in *GetNodesRequest, opts ...grpc.CallOption) (*GetNodesReply, error)
+	GetJobs(ctx context.Context, in *GetJobsRequest, opts ...grpc.CallOption) (*GetJobsReply, error)
+	GetOutput(ctx context.Context, in *GetOutputRequest, opts ...grpc.CallOption) (Headnode_GetOutputClient, error)
+	StartClusJob(ctx context.Context, in *StartClusJobRequest, opts ...grpc.CallOption) (Headnode_StartClusJobClient, error)
+	CancelClusJobs(ctx context.Context, in *CancelClusJobsRequest, opts ...grpc.CallOption) (*CancelClusJobsReply, error)
+	SetConfigs(ctx context.Context, in *SetConfigsRequest, opts ...grpc.CallOption) (*SetConfigsReply, error)
+	GetConfigs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetConfigsReply, error)
+	SetNodeGroups(ctx context.Context, in *SetNodeGroupsRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetNodeLabels(ctx context.Context, in *SetNodeLabelsRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetCapabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetCapabilitiesReply, error)
+	GetClusterSummary(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetClusterSummaryReply, error)
+	UploadFiles(ctx context.Context, opts ...grpc.CallOption) (Headnode_UploadFilesClient, error)
+	GatherFiles(ctx context.Context, in *GatherFilesRequest, opts ...grpc.CallOption) (*GatherFilesReply, error)
+	ResetNodeKeys(ctx context.Context, in *ResetNodeKeysRequest, opts ...grpc.CallOption) (*ResetNodeKeysReply, error)
+	PurgeJobs(ctx context.Context, in *PurgeJobsRequest, opts ...grpc.CallOption) (*PurgeJobsReply, error)
+	ExportJobs(ctx context.Context, in *ExportJobsRequest, opts ...grpc.CallOption) (Headnode_ExportJobsClient, error)
+	ImportJobs(ctx context.Context, opts ...grpc.CallOption) (Headnode_ImportJobsClient, error)
+	QueryResults(ctx context.Context, in *QueryResultsRequest, opts ...grpc.CallOption) (*QueryResultsReply, error)
+	SearchOutput(ctx context.Context, in *SearchOutputRequest, opts ...grpc.CallOption) (*SearchOutputReply, error)
+	ExportTimeline(ctx context.Context, in *ExportTimelineRequest, opts ...grpc.CallOption) (*ExportTimelineReply, error)
+	BatchHeartbeat(ctx context.Context, in *BatchHeartbeatRequest, opts ...grpc.CallOption) (*BatchHeartbeatReply, error)
+	DrainNodes(ctx context.Context, in *DrainNodesRequest, opts ...grpc.CallOption) (*DrainNodesReply, error)
+	RemoveNodes(ctx context.Context, in *RemoveNodesRequest, opts ...grpc.CallOption) (*RemoveNodesReply, error)
+	RevalidateNodes(ctx context.Context, in *RevalidateNodesRequest, opts ...grpc.CallOption) (*RevalidateNodesReply, error)
+	Undo(ctx context.Context, in *UndoRequest, opts ...grpc.CallOption) (*UndoReply, error)
+	SaveJobTemplate(ctx context.Context, in *JobTemplate, opts ...grpc.CallOption) (*Empty, error)
+	GetJobTemplates(ctx context.Context, in *GetJobTemplatesRequest, opts ...grpc.CallOption) (*GetJobTemplatesReply, error)
+	DeleteJobTemplates(ctx context.Context, in *DeleteJobTemplatesRequest, opts ...grpc.CallOption) (*DeleteJobTemplatesReply, error)
+	StreamJobs(ctx context.Context, in *GetJobsRequest, opts ...grpc.CallOption) (Headnode_StreamJobsClient, error)
+	SaveJobSchedule(ctx context.Context, in *JobSchedule, opts ...grpc.CallOption) (*Empty, error)
+	GetJobSchedules(ctx context.Context, in *GetJobSchedulesRequest, opts ...grpc.CallOption) (*GetJobSchedulesReply, error)
+	SetJobSchedulesEnabled(ctx context.Context, in *SetJobSchedulesEnabledRequest, opts ...grpc.CallOption) (*SetJobSchedulesEnabledReply, error)
+	DeleteJobSchedules(ctx context.Context, in *DeleteJobSchedulesRequest, opts ...grpc.CallOption) (*DeleteJobSchedulesReply, error)
+	SaveJobBookmark(ctx context.Context, in *SaveJobBookmarkRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetJobBookmarks(ctx context.Context, in *GetJobBookmarksRequest, opts ...grpc.CallOption) (*GetJobBookmarksReply, error)
+	DeleteJobBookmarks(ctx context.Context, in *DeleteJobBookmarksRequest, opts ...grpc.CallOption) (*DeleteJobBookmarksReply, error)
+	Shell(ctx context.Context, opts ...grpc.CallOption) (Headnode_ShellClient, error)
+	ForwardJobInput(ctx context.Context, opts ...grpc.CallOption) (Headnode_ForwardJobInputClient, error)
+	GetLoginOptions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetLoginOptionsReply, error)
+	Login(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LoginReply, error)
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (Headnode_SubscribeEventsClient, error)
+	WatchClusJob(ctx context.Context, in *WatchClusJobRequest, opts ...grpc.CallOption) (Headnode_WatchClusJobClient, error)
+	TestNodes(ctx context.Context, in *TestNodesRequest, opts ...grpc.CallOption) (*TestNodesReply, error)
+	GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogReply, error)
+	Tunnel(ctx context.Context, opts ...grpc.CallOption) (Headnode_TunnelClient, error)
+	WatchNodes(ctx context.Context, in *GetNodesRequest, opts ...grpc.CallOption) (Headnode_WatchNodesClient, error)
+	RerunJob(ctx context.Context, in *RerunJobRequest, opts ...grpc.CallOption) (Headnode_RerunJobClient, error)
+	GetConfigDrift(ctx context.Context, in *GetConfigDriftRequest, opts ...grpc.CallOption) (*GetConfigDriftReply, error)
+	GetNodeAvailability(ctx context.Context, in *GetNodeAvailabilityRequest, opts ...grpc.CallOption) (*GetNodeAvailabilityReply, error)
+}
+
+type headnodeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHeadnodeClient(cc grpc.ClientConnInterface) HeadnodeClient {
+	return &headnodeClient{cc}
+}
+
+func (c *headnodeClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/Heartbeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) HeartbeatStream(ctx context.Context, opts ...grpc.CallOption) (Headnode_HeartbeatStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[0], "/clusrun.Headnode/HeartbeatStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeHeartbeatStreamClient{stream}
+	return x, nil
+}
+
+type Headnode_HeartbeatStreamClient interface {
+	Send(*HeartbeatRequest) error
+	Recv() (*HeartbeatControl, error)
+	grpc.ClientStream
+}
+
+type headnodeHeartbeatStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeHeartbeatStreamClient) Send(m *HeartbeatRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *headnodeHeartbeatStreamClient) Recv() (*HeartbeatControl, error) {
+	m := new(HeartbeatControl)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) GetNodes(ctx context.Context, in *GetNodesRequest, opts ...grpc.CallOption) (*GetNodesReply, error) {
+	out := new(GetNodesReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) GetJobs(ctx context.Context, in *GetJobsRequest, opts ...grpc.CallOption) (*GetJobsReply, error) {
+	out := new(GetJobsReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) GetOutput(ctx context.Context, in *GetOutputRequest, opts ...grpc.CallOption) (Headnode_GetOutputClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[1], "/clusrun.Headnode/GetOutput", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeGetOutputClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Headnode_GetOutputClient interface {
+	Recv() (*GetOutputReply, error)
+	grpc.ClientStream
+}
+
+type headnodeGetOutputClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeGetOutputClient) Recv() (*GetOutputReply, error) {
+	m := new(GetOutputReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) StartClusJob(ctx context.Context, in *StartClusJobRequest, opts ...grpc.CallOption) (Headnode_StartClusJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[2], "/clusrun.Headnode/StartClusJob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeStartClusJobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Headnode_StartClusJobClient interface {
+	Recv() (*StartClusJobReply, error)
+	grpc.ClientStream
+}
+
+type headnodeStartClusJobClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeStartClusJobClient) Recv() (*StartClusJobReply, error) {
+	m := new(StartClusJobReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) CancelClusJobs(ctx context.Context, in *CancelClusJobsRequest, opts ...grpc.CallOption) (*CancelClusJobsReply, error) {
+	out := new(CancelClusJobsReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/CancelClusJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) SetConfigs(ctx context.Context, in *SetConfigsRequest, opts ...grpc.CallOption) (*SetConfigsReply, error) {
+	out := new(SetConfigsReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/SetConfigs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) GetConfigs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetConfigsReply, error) {
+	out := new(GetConfigsReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetConfigs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) SetNodeGroups(ctx context.Context, in *SetNodeGroupsRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/SetNodeGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) SetNodeLabels(ctx context.Context, in *SetNodeLabelsRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/SetNodeLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) GetCapabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetCapabilitiesReply, error) {
+	out := new(GetCapabilitiesReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) GetClusterSummary(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetClusterSummaryReply, error) {
+	out := new(GetClusterSummaryReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetClusterSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) UploadFiles(ctx context.Context, opts ...grpc.CallOption) (Headnode_UploadFilesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[3], "/clusrun.Headnode/UploadFiles", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeUploadFilesClient{stream}
+	return x, nil
+}
+
+type Headnode_UploadFilesClient interface {
+	Send(*UploadFilesRequest) error
+	CloseAndRecv() (*UploadFilesReply, error)
+	grpc.ClientStream
+}
+
+type headnodeUploadFilesClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeUploadFilesClient) Send(m *UploadFilesRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *headnodeUploadFilesClient) CloseAndRecv() (*UploadFilesReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadFilesReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) GatherFiles(ctx context.Context, in *GatherFilesRequest, opts ...grpc.CallOption) (*GatherFilesReply, error) {
+	out := new(GatherFilesReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GatherFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) ResetNodeKeys(ctx context.Context, in *ResetNodeKeysRequest, opts ...grpc.CallOption) (*ResetNodeKeysReply, error) {
+	out := new(ResetNodeKeysReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/ResetNodeKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) PurgeJobs(ctx context.Context, in *PurgeJobsRequest, opts ...grpc.CallOption) (*PurgeJobsReply, error) {
+	out := new(PurgeJobsReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/PurgeJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) ExportJobs(ctx context.Context, in *ExportJobsRequest, opts ...grpc.CallOption) (Headnode_ExportJobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[4], "/clusrun.Headnode/ExportJobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeExportJobsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Headnode_ExportJobsClient interface {
+	Recv() (*ExportJobsReply, error)
+	grpc.ClientStream
+}
+
+type headnodeExportJobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeExportJobsClient) Recv() (*ExportJobsReply, error) {
+	m := new(ExportJobsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) ImportJobs(ctx context.Context, opts ...grpc.CallOption) (Headnode_ImportJobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[5], "/clusrun.Headnode/ImportJobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeImportJobsClient{stream}
+	return x, nil
+}
+
+type Headnode_ImportJobsClient interface {
+	Send(*ImportJobsRequest) error
+	CloseAndRecv() (*ImportJobsReply, error)
+	grpc.ClientStream
+}
+
+type headnodeImportJobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeImportJobsClient) Send(m *ImportJobsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *headnodeImportJobsClient) CloseAndRecv() (*ImportJobsReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportJobsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) QueryResults(ctx context.Context, in *QueryResultsRequest, opts ...grpc.CallOption) (*QueryResultsReply, error) {
+	out := new(QueryResultsReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/QueryResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) SearchOutput(ctx context.Context, in *SearchOutputRequest, opts ...grpc.CallOption) (*SearchOutputReply, error) {
+	out := new(SearchOutputReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/SearchOutput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) ExportTimeline(ctx context.Context, in *ExportTimelineRequest, opts ...grpc.CallOption) (*ExportTimelineReply, error) {
+	out := new(ExportTimelineReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/ExportTimeline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) BatchHeartbeat(ctx context.Context, in *BatchHeartbeatRequest, opts ...grpc.CallOption) (*BatchHeartbeatReply, error) {
+	out := new(BatchHeartbeatReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/BatchHeartbeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) DrainNodes(ctx context.Context, in *DrainNodesRequest, opts ...grpc.CallOption) (*DrainNodesReply, error) {
+	out := new(DrainNodesReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/DrainNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) RemoveNodes(ctx context.Context, in *RemoveNodesRequest, opts ...grpc.CallOption) (*RemoveNodesReply, error) {
+	out := new(RemoveNodesReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/RemoveNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) RevalidateNodes(ctx context.Context, in *RevalidateNodesRequest, opts ...grpc.CallOption) (*RevalidateNodesReply, error) {
+	out := new(RevalidateNodesReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/RevalidateNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) Undo(ctx context.Context, in *UndoRequest, opts ...grpc.CallOption) (*UndoReply, error) {
+	out := new(UndoReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/Undo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) SaveJobTemplate(ctx context.Context, in *JobTemplate, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/SaveJobTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) GetJobTemplates(ctx context.Context, in *GetJobTemplatesRequest, opts ...grpc.CallOption) (*GetJobTemplatesReply, error) {
+	out := new(GetJobTemplatesReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetJobTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) DeleteJobTemplates(ctx context.Context, in *DeleteJobTemplatesRequest, opts ...grpc.CallOption) (*DeleteJobTemplatesReply, error) {
+	out := new(DeleteJobTemplatesReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/DeleteJobTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) StreamJobs(ctx context.Context, in *GetJobsRequest, opts ...grpc.CallOption) (Headnode_StreamJobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[6], "/clusrun.Headnode/StreamJobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeStreamJobsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Headnode_StreamJobsClient interface {
+	Recv() (*Job, error)
+	grpc.ClientStream
+}
+
+type headnodeStreamJobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeStreamJobsClient) Recv() (*Job, error) {
+	m := new(Job)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) SaveJobSchedule(ctx context.Context, in *JobSchedule, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/SaveJobSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) GetJobSchedules(ctx context.Context, in *GetJobSchedulesRequest, opts ...grpc.CallOption) (*GetJobSchedulesReply, error) {
+	out := new(GetJobSchedulesReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetJobSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) SetJobSchedulesEnabled(ctx context.Context, in *SetJobSchedulesEnabledRequest, opts ...grpc.CallOption) (*SetJobSchedulesEnabledReply, error) {
+	out := new(SetJobSchedulesEnabledReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/SetJobSchedulesEnabled", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) DeleteJobSchedules(ctx context.Context, in *DeleteJobSchedulesRequest, opts ...grpc.CallOption) (*DeleteJobSchedulesReply, error) {
+	out := new(DeleteJobSchedulesReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/DeleteJobSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) SaveJobBookmark(ctx context.Context, in *SaveJobBookmarkRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/SaveJobBookmark", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) GetJobBookmarks(ctx context.Context, in *GetJobBookmarksRequest, opts ...grpc.CallOption) (*GetJobBookmarksReply, error) {
+	out := new(GetJobBookmarksReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetJobBookmarks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) DeleteJobBookmarks(ctx context.Context, in *DeleteJobBookmarksRequest, opts ...grpc.CallOption) (*DeleteJobBookmarksReply, error) {
+	out := new(DeleteJobBookmarksReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/DeleteJobBookmarks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) Shell(ctx context.Context, opts ...grpc.CallOption) (Headnode_ShellClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[7], "/clusrun.Headnode/Shell", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeShellClient{stream}
+	return x, nil
+}
+
+type Headnode_ShellClient interface {
+	Send(*ShellRequest) error
+	Recv() (*ShellReply, error)
+	grpc.ClientStream
+}
+
+type headnodeShellClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeShellClient) Send(m *ShellRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *headnodeShellClient) Recv() (*ShellReply, error) {
+	m := new(ShellReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) ForwardJobInput(ctx context.Context, opts ...grpc.CallOption) (Headnode_ForwardJobInputClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[8], "/clusrun.Headnode/ForwardJobInput", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeForwardJobInputClient{stream}
+	return x, nil
+}
+
+type Headnode_ForwardJobInputClient interface {
+	Send(*JobInputRequest) error
+	Recv() (*JobInputReply, error)
+	grpc.ClientStream
+}
+
+type headnodeForwardJobInputClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeForwardJobInputClient) Send(m *JobInputRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *headnodeForwardJobInputClient) Recv() (*JobInputReply, error) {
+	m := new(JobInputReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) GetLoginOptions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetLoginOptionsReply, error) {
+	out := new(GetLoginOptionsReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetLoginOptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) Login(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LoginReply, error) {
+	out := new(LoginReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/Login", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (Headnode_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[9], "/clusrun.Headnode/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Headnode_SubscribeEventsClient interface {
+	Recv() (*ClusterEvent, error)
+	grpc.ClientStream
+}
+
+type headnodeSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeSubscribeEventsClient) Recv() (*ClusterEvent, error) {
+	m := new(ClusterEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) WatchClusJob(ctx context.Context, in *WatchClusJobRequest, opts ...grpc.CallOption) (Headnode_WatchClusJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[10], "/clusrun.Headnode/WatchClusJob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeWatchClusJobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Headnode_WatchClusJobClient interface {
+	Recv() (*StartClusJobReply, error)
+	grpc.ClientStream
+}
+
+type headnodeWatchClusJobClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeWatchClusJobClient) Recv() (*StartClusJobReply, error) {
+	m := new(StartClusJobReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) TestNodes(ctx context.Context, in *TestNodesRequest, opts ...grpc.CallOption) (*TestNodesReply, error) {
+	out := new(TestNodesReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/TestNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogReply, error) {
+	out := new(GetAuditLogReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) Tunnel(ctx context.Context, opts ...grpc.CallOption) (Headnode_TunnelClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[11], "/clusrun.Headnode/Tunnel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeTunnelClient{stream}
+	return x, nil
+}
+
+type Headnode_TunnelClient interface {
+	Send(*TunnelData) error
+	Recv() (*TunnelData, error)
+	grpc.ClientStream
+}
+
+type headnodeTunnelClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeTunnelClient) Send(m *TunnelData) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *headnodeTunnelClient) Recv() (*TunnelData, error) {
+	m := new(TunnelData)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) WatchNodes(ctx context.Context, in *GetNodesRequest, opts ...grpc.CallOption) (Headnode_WatchNodesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[12], "/clusrun.Headnode/WatchNodes", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeWatchNodesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Headnode_WatchNodesClient interface {
+	Recv() (*GetNodesReply, error)
+	grpc.ClientStream
+}
+
+type headnodeWatchNodesClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeWatchNodesClient) Recv() (*GetNodesReply, error) {
+	m := new(GetNodesReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) RerunJob(ctx context.Context, in *RerunJobRequest, opts ...grpc.CallOption) (Headnode_RerunJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[13], "/clusrun.Headnode/RerunJob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headnodeRerunJobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Headnode_RerunJobClient interface {
+	Recv() (*StartClusJobReply, error)
+	grpc.ClientStream
+}
+
+type headnodeRerunJobClient struct {
+	grpc.ClientStream
+}
+
+func (x *headnodeRerunJobClient) Recv() (*StartClusJobReply, error) {
+	m := new(StartClusJobReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *headnodeClient) GetConfigDrift(ctx context.Context, in *GetConfigDriftRequest, opts ...grpc.CallOption) (*GetConfigDriftReply, error) {
+	out := new(GetConfigDriftReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetConfigDrift", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headnodeClient) GetNodeAvailability(ctx context.Context, in *GetNodeAvailabilityRequest, opts ...grpc.CallOption) (*GetNodeAvailabilityReply, error) {
+	out := new(GetNodeAvailabilityReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetNodeAvailability", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HeadnodeServer is the server API for Headnode service.
+type HeadnodeServer interface {
+	Heartbeat(context.Context, *HeartbeatRequest) (*Empty, error)
+	HeartbeatStream(Headnode_HeartbeatStreamServer) error
+	GetNodes(context.Context, *GetNodesRequest) (*GetNodesReply, error)
+	GetJobs(context.Context, *GetJobsRequest) (*GetJobsReply, error)
+	GetOutput(*GetOutputRequest, Headnode_GetOutputServer) error
+	StartClusJob(*StartClusJobRequest, Headnode_StartClusJobServer) error
+	CancelClusJobs(context.Context, *CancelClusJobsRequest) (*CancelClusJobsReply, error)
+	SetConfigs(context.Context, *SetConfigsRequest) (*SetConfigsReply, error)
+	GetConfigs(context.Context, *Empty) (*GetConfigsReply, error)
+	SetNodeGroups(context.Context, *SetNodeGroupsRequest) (*Empty, error)
+	SetNodeLabels(context.Context, *SetNodeLabelsRequest) (*Empty, error)
+	GetCapabilities(context.Context, *Empty) (*GetCapabilitiesReply, error)
+	GetClusterSummary(context.Context, *Empty) (*GetClusterSummaryReply, error)
+	UploadFiles(Headnode_UploadFilesServer) error
+	GatherFiles(context.Context, *GatherFilesRequest) (*GatherFilesReply, error)
+	ResetNodeKeys(context.Context, *ResetNodeKeysRequest) (*ResetNodeKeysReply, error)
+	PurgeJobs(context.Context, *PurgeJobsRequest) (*PurgeJobsReply, error)
+	ExportJobs(*ExportJobsRequest, Headnode_ExportJobsServer) error
+	ImportJobs(Headnode_ImportJobsServer) error
+	QueryResults(context.Context, *QueryResultsRequest) (*QueryResultsReply, error)
+	SearchOutput(context.Context, *SearchOutputRequest) (*SearchOutputReply, error)
+	ExportTimeline(context.Context, *ExportTimelineRequest) (*ExportTimelineReply, error)
+	BatchHeartbeat(context.Context, *BatchHeartbeatRequest) (*BatchHeartbeatReply, error)
+	DrainNodes(context.Context, *DrainNodesRequest) (*DrainNodesReply, error)
+	RemoveNodes(context.Context, *RemoveNodesRequest) (*RemoveNodesReply, error)
+	RevalidateNodes(context.Context, *RevalidateNodesRequest) (*RevalidateNodesReply, error)
+	Undo(context.Context, *UndoRequest) (*UndoReply, error)
+	SaveJobTemplate(context.Context, *JobTemplate) (*Empty, error)
+	GetJobTemplates(context.Context, *GetJobTemplatesRequest) (*GetJobTemplatesReply, error)
+	DeleteJobTemplates(context.Context, *DeleteJobTemplatesRequest) (*DeleteJobTemplatesReply, error)
+	StreamJobs(*GetJobsRequest, Headnode_StreamJobsServer) error
+	SaveJobSchedule(context.Context, *JobSchedule) (*Empty, error)
+	GetJobSchedules(context.Context, *GetJobSchedulesRequest) (*GetJobSchedulesReply, error)
+	SetJobSchedulesEnabled(context.Context, *SetJobSchedulesEnabledRequest) (*SetJobSchedulesEnabledReply, error)
+	DeleteJobSchedules(context.Context, *DeleteJobSchedulesRequest) (*DeleteJobSchedulesReply, error)
+	SaveJobBookmark(context.Context, *SaveJobBookmarkRequest) (*Empty, error)
+	GetJobBookmarks(context.Context, *GetJobBookmarksRequest) (*GetJobBookmarksReply, error)
+	DeleteJobBookmarks(context.Context, *DeleteJobBookmarksRequest) (*DeleteJobBookmarksReply, error)
+	Shell(Headnode_ShellServer) error
+	ForwardJobInput(Headnode_ForwardJobInputServer) error
+	GetLoginOptions(context.Context, *Empty) (*GetLoginOptionsReply, error)
+	Login(context.Context, *Empty) (*LoginReply, error)
+	SubscribeEvents(*SubscribeEventsRequest, Headnode_SubscribeEventsServer) error
+	WatchClusJob(*WatchClusJobRequest, Headnode_WatchClusJobServer) error
+	TestNodes(context.Context, *TestNodesRequest) (*TestNodesReply, error)
+	GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogReply, error)
+	Tunnel(Headnode_TunnelServer) error
+	WatchNodes(*GetNodesRequest, Headnode_WatchNodesServer) error
+	RerunJob(*RerunJobRequest, Headnode_RerunJobServer) error
+	GetConfigDrift(context.Context, *GetConfigDriftRequest) (*GetConfigDriftReply, error)
+	GetNodeAvailability(context.Context, *GetNodeAvailabilityRequest) (*GetNodeAvailabilityReply, error)
+}
+
+// UnimplementedHeadnodeServer can be embedded to have forward compatible implementations.
+type UnimplementedHeadnodeServer struct {
+}
+
+func (*UnimplementedHeadnodeServer) Heartbeat(context.Context, *HeartbeatRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (*UnimplementedHeadnodeServer) HeartbeatStream(Headnode_HeartbeatStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method HeartbeatStream not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetNodes(context.Context, *GetNodesRequest) (*GetNodesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodes not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetJobs(context.Context, *GetJobsRequest) (*GetJobsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobs not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetOutput(*GetOutputRequest, Headnode_GetOutputServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetOutput not implemented")
+}
+func (*UnimplementedHeadnodeServer) StartClusJob(*StartClusJobRequest, Headnode_StartClusJobServer) error {
+	return status.Errorf(codes.Unimplemented, "method StartClusJob not implemented")
+}
+func (*UnimplementedHeadnodeServer) CancelClusJobs(context.Context, *CancelClusJobsRequest) (*CancelClusJobsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelClusJobs not implemented")
+}
+func (*UnimplementedHeadnodeServer) SetConfigs(context.Context, *SetConfigsRequest) (*SetConfigsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConfigs not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetConfigs(context.Context, *Empty) (*GetConfigsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigs not implemented")
+}
+func (*UnimplementedHeadnodeServer) SetNodeGroups(context.Context, *SetNodeGroupsRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetNodeGroups not implemented")
+}
+func (*UnimplementedHeadnodeServer) SetNodeLabels(context.Context, *SetNodeLabelsRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetNodeLabels not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetCapabilities(context.Context, *Empty) (*GetCapabilitiesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetClusterSummary(context.Context, *Empty) (*GetClusterSummaryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterSummary not implemented")
+}
+func (*UnimplementedHeadnodeServer) UploadFiles(Headnode_UploadFilesServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadFiles not implemented")
+}
+func (*UnimplementedHeadnodeServer) GatherFiles(context.Context, *GatherFilesRequest) (*GatherFilesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GatherFiles not implemented")
+}
+func (*UnimplementedHeadnodeServer) ResetNodeKeys(context.Context, *ResetNodeKeysRequest) (*ResetNodeKeysReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetNodeKeys not implemented")
+}
+func (*UnimplementedHeadnodeServer) PurgeJobs(context.Context, *PurgeJobsRequest) (*PurgeJobsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeJobs not implemented")
+}
+func (*UnimplementedHeadnodeServer) ExportJobs(*ExportJobsRequest, Headnode_ExportJobsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportJobs not implemented")
+}
+func (*UnimplementedHeadnodeServer) ImportJobs(Headnode_ImportJobsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportJobs not implemented")
+}
+func (*UnimplementedHeadnodeServer) QueryResults(context.Context, *QueryResultsRequest) (*QueryResultsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryResults not implemented")
+}
+func (*UnimplementedHeadnodeServer) SearchOutput(context.Context, *SearchOutputRequest) (*SearchOutputReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchOutput not implemented")
+}
+func (*UnimplementedHeadnodeServer) ExportTimeline(context.Context, *ExportTimelineRequest) (*ExportTimelineReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportTimeline not implemented")
+}
+func (*UnimplementedHeadnodeServer) BatchHeartbeat(context.Context, *BatchHeartbeatRequest) (*BatchHeartbeatReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchHeartbeat not implemented")
+}
+func (*UnimplementedHeadnodeServer) DrainNodes(context.Context, *DrainNodesRequest) (*DrainNodesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainNodes not implemented")
+}
+func (*UnimplementedHeadnodeServer) RemoveNodes(context.Context, *RemoveNodesRequest) (*RemoveNodesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveNodes not implemented")
+}
+func (*UnimplementedHeadnodeServer) RevalidateNodes(context.Context, *RevalidateNodesRequest) (*RevalidateNodesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevalidateNodes not implemented")
+}
+func (*UnimplementedHeadnodeServer) Undo(context.Context, *UndoRequest) (*UndoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Undo not implemented")
+}
+func (*UnimplementedHeadnodeServer) SaveJobTemplate(context.Context, *JobTemplate) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveJobTemplate not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetJobTemplates(context.Context, *GetJobTemplatesRequest) (*GetJobTemplatesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobTemplates not implemented")
+}
+func (*UnimplementedHeadnodeServer) DeleteJobTemplates(context.Context, *DeleteJobTemplatesRequest) (*DeleteJobTemplatesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteJobTemplates not implemented")
+}
+func (*UnimplementedHeadnodeServer) StreamJobs(*GetJobsRequest, Headnode_StreamJobsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamJobs not implemented")
+}
+func (*UnimplementedHeadnodeServer) SaveJobSchedule(context.Context, *JobSchedule) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveJobSchedule not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetJobSchedules(context.Context, *GetJobSchedulesRequest) (*GetJobSchedulesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobSchedules not implemented")
+}
+func (*UnimplementedHeadnodeServer) SetJobSchedulesEnabled(context.Context, *SetJobSchedulesEnabledRequest) (*SetJobSchedulesEnabledReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetJobSchedulesEnabled not implemented")
+}
+func (*UnimplementedHeadnodeServer) DeleteJobSchedules(context.Context, *DeleteJobSchedulesRequest) (*DeleteJobSchedulesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteJobSchedules not implemented")
+}
+func (*UnimplementedHeadnodeServer) SaveJobBookmark(context.Context, *SaveJobBookmarkRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveJobBookmark not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetJobBookmarks(context.Context, *GetJobBookmarksRequest) (*GetJobBookmarksReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobBookmarks not implemented")
+}
+func (*UnimplementedHeadnodeServer) DeleteJobBookmarks(context.Context, *DeleteJobBookmarksRequest) (*DeleteJobBookmarksReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteJobBookmarks not implemented")
+}
+func (*UnimplementedHeadnodeServer) Shell(Headnode_ShellServer) error {
+	return status.Errorf(codes.Unimplemented, "method Shell not implemented")
+}
+func (*UnimplementedHeadnodeServer) ForwardJobInput(Headnode_ForwardJobInputServer) error {
+	return status.Errorf(codes.Unimplemented, "method ForwardJobInput not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetLoginOptions(context.Context, *Empty) (*GetLoginOptionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoginOptions not implemented")
+}
+func (*UnimplementedHeadnodeServer) Login(context.Context, *Empty) (*LoginReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (*UnimplementedHeadnodeServer) SubscribeEvents(*SubscribeEventsRequest, Headnode_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (*UnimplementedHeadnodeServer) WatchClusJob(*WatchClusJobRequest, Headnode_WatchClusJobServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchClusJob not implemented")
+}
+func (*UnimplementedHeadnodeServer) TestNodes(context.Context, *TestNodesRequest) (*TestNodesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestNodes not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditLog not implemented")
+}
+func (*UnimplementedHeadnodeServer) Tunnel(Headnode_TunnelServer) error {
+	return status.Errorf(codes.Unimplemented, "method Tunnel not implemented")
+}
+func (*UnimplementedHeadnodeServer) WatchNodes(*GetNodesRequest, Headnode_WatchNodesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNodes not implemented")
+}
+func (*UnimplementedHeadnodeServer) RerunJob(*RerunJobRequest, Headnode_RerunJobServer) error {
+	return status.Errorf(codes.Unimplemented, "method RerunJob not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetConfigDrift(context.Context, *GetConfigDriftRequest) (*GetConfigDriftReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigDrift not implemented")
+}
+func (*UnimplementedHeadnodeServer) GetNodeAvailability(context.Context, *GetNodeAvailabilityRequest) (*GetNodeAvailabilityReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeAvailability not implemented")
+}
+
+func RegisterHeadnodeServer(s *grpc.Server, srv HeadnodeServer) {
+	s.RegisterService(&_Headnode_serviceDesc, srv)
+}
+
+func _Headnode_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/Heartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_HeartbeatStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HeadnodeServer).HeartbeatStream(&headnodeHeartbeatStreamServer{stream})
+}
+
+type Headnode_HeartbeatStreamServer interface {
+	Send(*HeartbeatControl) error
+	Recv() (*HeartbeatRequest, error)
+	grpc.ServerStream
+}
+
+type headnodeHeartbeatStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeHeartbeatStreamServer) Send(m *HeartbeatControl) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *headnodeHeartbeatStreamServer) Recv() (*HeartbeatRequest, error) {
+	m := new(HeartbeatRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Headnode_GetNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GetNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GetNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GetNodes(ctx, req.(*GetNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_GetJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GetJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GetJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GetJobs(ctx, req.(*GetJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_GetOutput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetOutputRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HeadnodeServer).GetOutput(m, &headnodeGetOutputServer{stream})
+}
+
+type Headnode_GetOutputServer interface {
+	Send(*GetOutputReply) error
+	grpc.ServerStream
+}
+
+type headnodeGetOutputServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeGetOutputServer) Send(m *GetOutputReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Headnode_StartClusJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StartClusJobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HeadnodeServer).StartClusJob(m, &headnodeStartClusJobServer{stream})
+}
+
+type Headnode_StartClusJobServer interface {
+	Send(*StartClusJobReply) error
+	grpc.ServerStream
+}
+
+type headnodeStartClusJobServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeStartClusJobServer) Send(m *StartClusJobReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Headnode_CancelClusJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelClusJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).CancelClusJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/CancelClusJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).CancelClusJobs(ctx, req.(*CancelClusJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_SetConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).SetConfigs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/SetConfigs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).SetConfigs(ctx, req.(*SetConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_GetConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GetConfigs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GetConfigs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GetConfigs(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_SetNodeGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetNodeGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).SetNodeGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/SetNodeGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).SetNodeGroups(ctx, req.(*SetNodeGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_SetNodeLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetNodeLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).SetNodeLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/SetNodeLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).SetNodeLabels(ctx, req.(*SetNodeLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GetCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GetCapabilities(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_GetClusterSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GetClusterSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GetClusterSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GetClusterSummary(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_UploadFiles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HeadnodeServer).UploadFiles(&headnodeUploadFilesServer{stream})
+}
+
+type Headnode_UploadFilesServer interface {
+	SendAndClose(*UploadFilesReply) error
+	Recv() (*UploadFilesRequest, error)
+	grpc.ServerStream
+}
+
+type headnodeUploadFilesServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeUploadFilesServer) SendAndClose(m *UploadFilesReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *headnodeUploadFilesServer) Recv() (*UploadFilesRequest, error) {
+	m := new(UploadFilesRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Headnode_GatherFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GatherFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GatherFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GatherFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GatherFiles(ctx, req.(*GatherFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_ResetNodeKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetNodeKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).ResetNodeKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/ResetNodeKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).ResetNodeKeys(ctx, req.(*ResetNodeKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_PurgeJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).PurgeJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/PurgeJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).PurgeJobs(ctx, req.(*PurgeJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_ExportJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportJobsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HeadnodeServer).ExportJobs(m, &headnodeExportJobsServer{stream})
+}
+
+type Headnode_ExportJobsServer interface {
+	Send(*ExportJobsReply) error
+	grpc.ServerStream
+}
+
+type headnodeExportJobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeExportJobsServer) Send(m *ExportJobsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Headnode_ImportJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HeadnodeServer).ImportJobs(&headnodeImportJobsServer{stream})
+}
+
+type Headnode_ImportJobsServer interface {
+	SendAndClose(*ImportJobsReply) error
+	Recv() (*ImportJobsRequest, error)
+	grpc.ServerStream
+}
+
+type headnodeImportJobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeImportJobsServer) SendAndClose(m *ImportJobsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *headnodeImportJobsServer) Recv() (*ImportJobsRequest, error) {
+	m := new(ImportJobsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Headnode_QueryResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).QueryResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/QueryResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).QueryResults(ctx, req.(*QueryResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_SearchOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).SearchOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/SearchOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).SearchOutput(ctx, req.(*SearchOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_ExportTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).ExportTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/ExportTimeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).ExportTimeline(ctx, req.(*ExportTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_BatchHeartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchHeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).BatchHeartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/BatchHeartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).BatchHeartbeat(ctx, req.(*BatchHeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_DrainNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).DrainNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/DrainNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).DrainNodes(ctx, req.(*DrainNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_RemoveNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).RemoveNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/RemoveNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).RemoveNodes(ctx, req.(*RemoveNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_RevalidateNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevalidateNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).RevalidateNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/RevalidateNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).RevalidateNodes(ctx, req.(*RevalidateNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_Undo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).Undo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/Undo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).Undo(ctx, req.(*UndoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_SaveJobTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobTemplate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).SaveJobTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/SaveJobTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).SaveJobTemplate(ctx, req.(*JobTemplate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_GetJobTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GetJobTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GetJobTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GetJobTemplates(ctx, req.(*GetJobTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_DeleteJobTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).DeleteJobTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/DeleteJobTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).DeleteJobTemplates(ctx, req.(*DeleteJobTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_StreamJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetJobsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HeadnodeServer).StreamJobs(m, &headnodeStreamJobsServer{stream})
+}
+
+type Headnode_StreamJobsServer interface {
+	Send(*Job) error
+	grpc.ServerStream
+}
+
+type headnodeStreamJobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeStreamJobsServer) Send(m *Job) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Headnode_SaveJobSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobSchedule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).SaveJobSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/SaveJobSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).SaveJobSchedule(ctx, req.(*JobSchedule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_GetJobSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GetJobSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GetJobSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GetJobSchedules(ctx, req.(*GetJobSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_SetJobSchedulesEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetJobSchedulesEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).SetJobSchedulesEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/SetJobSchedulesEnabled",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).SetJobSchedulesEnabled(ctx, req.(*SetJobSchedulesEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_DeleteJobSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).DeleteJobSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/DeleteJobSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).DeleteJobSchedules(ctx, req.(*DeleteJobSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+func _Headnode_SaveJobBookmark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveJobBookmarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).SaveJobBookmark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/SaveJobBookmark",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).SaveJobBookmark(ctx, req.(*SaveJobBookmarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func _Headnode_GetJobBookmarks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobBookmarksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GetJobBookmarks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GetJobBookmarks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GetJobBookmarks(ctx, req.(*GetJobBookmarksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// HeadnodeClient is the client API for Headnode service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type HeadnodeClient interface {
-	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*Empty, error)
-	GetNodes(ctx context.Context, in *GetNodesRequest, opts ...grpc.CallOption) (*GetNodesReply, error)
-	GetJobs(ctx context.Context, in *GetJobsRequest, opts ...grpc.CallOption) (*GetJobsReply, error)
-	GetOutput(ctx context.Context, in *GetOutputRequest, opts ...grpc.CallOption) (Headnode_GetOutputClient, error)
-	StartClusJob(ctx context.Context, in *StartClusJobRequest, opts ...grpc.CallOption) (Headnode_StartClusJobClient, error)
-	CancelClusJobs(ctx context.Context, in *CancelClusJobsRequest, opts ...grpc.CallOption) (*CancelClusJobsReply, error)
-	SetConfigs(ctx context.Context, in *SetConfigsRequest, opts ...grpc.CallOption) (*SetConfigsReply, error)
-	GetConfigs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetConfigsReply, error)
-	SetNodeGroups(ctx context.Context, in *SetNodeGroupsRequest, opts ...grpc.CallOption) (*Empty, error)
+func _Headnode_DeleteJobBookmarks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobBookmarksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).DeleteJobBookmarks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/DeleteJobBookmarks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).DeleteJobBookmarks(ctx, req.(*DeleteJobBookmarksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type headnodeClient struct {
-	cc grpc.ClientConnInterface
+func _Headnode_Shell_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HeadnodeServer).Shell(&headnodeShellServer{stream})
 }
 
-func NewHeadnodeClient(cc grpc.ClientConnInterface) HeadnodeClient {
-	return &headnodeClient{cc}
+type Headnode_ShellServer interface {
+	Send(*ShellReply) error
+	Recv() (*ShellRequest, error)
+	grpc.ServerStream
 }
 
-func (c *headnodeClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/clusrun.Headnode/Heartbeat", in, out, opts...)
-	if err != nil {
+type headnodeShellServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeShellServer) Send(m *ShellReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *headnodeShellServer) Recv() (*ShellRequest, error) {
+	m := new(ShellRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *headnodeClient) GetNodes(ctx context.Context, in *GetNodesRequest, opts ...grpc.CallOption) (*GetNodesReply, error) {
-	out := new(GetNodesReply)
-	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetNodes", in, out, opts...)
-	if err != nil {
+func _Headnode_ForwardJobInput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HeadnodeServer).ForwardJobInput(&headnodeForwardJobInputServer{stream})
+}
+
+type Headnode_ForwardJobInputServer interface {
+	Send(*JobInputReply) error
+	Recv() (*JobInputRequest, error)
+	grpc.ServerStream
+}
+
+type headnodeForwardJobInputServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeForwardJobInputServer) Send(m *JobInputReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *headnodeForwardJobInputServer) Recv() (*JobInputRequest, error) {
+	m := new(JobInputRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *headnodeClient) GetJobs(ctx context.Context, in *GetJobsRequest, opts ...grpc.CallOption) (*GetJobsReply, error) {
-	out := new(GetJobsReply)
-	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetJobs", in, out, opts...)
-	if err != nil {
+func _Headnode_GetLoginOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GetLoginOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GetLoginOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GetLoginOptions(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).Login(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HeadnodeServer).SubscribeEvents(m, &headnodeSubscribeEventsServer{stream})
+}
+
+type Headnode_SubscribeEventsServer interface {
+	Send(*ClusterEvent) error
+	grpc.ServerStream
+}
+
+type headnodeSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeSubscribeEventsServer) Send(m *ClusterEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Headnode_WatchClusJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchClusJobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HeadnodeServer).WatchClusJob(m, &headnodeWatchClusJobServer{stream})
+}
+
+type Headnode_WatchClusJobServer interface {
+	Send(*StartClusJobReply) error
+	grpc.ServerStream
+}
+
+type headnodeWatchClusJobServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeWatchClusJobServer) Send(m *StartClusJobReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Headnode_TestNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).TestNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/TestNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).TestNodes(ctx, req.(*TestNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_GetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GetAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GetAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GetAuditLog(ctx, req.(*GetAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Headnode_Tunnel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HeadnodeServer).Tunnel(&headnodeTunnelServer{stream})
+}
+
+type Headnode_TunnelServer interface {
+	Send(*TunnelData) error
+	Recv() (*TunnelData, error)
+	grpc.ServerStream
+}
+
+type headnodeTunnelServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeTunnelServer) Send(m *TunnelData) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *headnodeTunnelServer) Recv() (*TunnelData, error) {
+	m := new(TunnelData)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Headnode_WatchNodes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetNodesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HeadnodeServer).WatchNodes(m, &headnodeWatchNodesServer{stream})
+}
+
+type Headnode_WatchNodesServer interface {
+	Send(*GetNodesReply) error
+	grpc.ServerStream
+}
+
+type headnodeWatchNodesServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeWatchNodesServer) Send(m *GetNodesReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Headnode_RerunJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RerunJobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HeadnodeServer).RerunJob(m, &headnodeRerunJobServer{stream})
+}
+
+type Headnode_RerunJobServer interface {
+	Send(*StartClusJobReply) error
+	grpc.ServerStream
+}
+
+type headnodeRerunJobServer struct {
+	grpc.ServerStream
+}
+
+func (x *headnodeRerunJobServer) Send(m *StartClusJobReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Headnode_GetConfigDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigDriftRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GetConfigDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GetConfigDrift",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GetConfigDrift(ctx, req.(*GetConfigDriftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *headnodeClient) GetOutput(ctx context.Context, in *GetOutputRequest, opts ...grpc.CallOption) (Headnode_GetOutputClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[0], "/clusrun.Headnode/GetOutput", opts...)
-	if err != nil {
+func _Headnode_GetNodeAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeAvailabilityRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &headnodeGetOutputClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(HeadnodeServer).GetNodeAvailability(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Headnode/GetNodeAvailability",
 	}
-	return x, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadnodeServer).GetNodeAvailability(ctx, req.(*GetNodeAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type Headnode_GetOutputClient interface {
-	Recv() (*GetOutputReply, error)
-	grpc.ClientStream
+var _Headnode_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "clusrun.Headnode",
+	HandlerType: (*HeadnodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Heartbeat",
+			Handler:    _Headnode_Heartbeat_Handler,
+		},
+		{
+			MethodName: "GetNodes",
+			Handler:    _Headnode_GetNodes_Handler,
+		},
+		{
+			MethodName: "GetJobs",
+			Handler:    _Headnode_GetJobs_Handler,
+		},
+		{
+			MethodName: "CancelClusJobs",
+			Handler:    _Headnode_CancelClusJobs_Handler,
+		},
+		{
+			MethodName: "SetConfigs",
+			Handler:    _Headnode_SetConfigs_Handler,
+		},
+		{
+			MethodName: "GetConfigs",
+			Handler:    _Headnode_GetConfigs_Handler,
+		},
+		{
+			MethodName: "SetNodeGroups",
+			Handler:    _Headnode_SetNodeGroups_Handler,
+		},
+		{
+			MethodName: "SetNodeLabels",
+			Handler:    _Headnode_SetNodeLabels_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _Headnode_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "GetClusterSummary",
+			Handler:    _Headnode_GetClusterSummary_Handler,
+		},
+		{
+			MethodName: "GatherFiles",
+			Handler:    _Headnode_GatherFiles_Handler,
+		},
+		{
+			MethodName: "ResetNodeKeys",
+			Handler:    _Headnode_ResetNodeKeys_Handler,
+		},
+		{
+			MethodName: "PurgeJobs",
+			Handler:    _Headnode_PurgeJobs_Handler,
+		},
+		{
+			MethodName: "QueryResults",
+			Handler:    _Headnode_QueryResults_Handler,
+		},
+		{
+			MethodName: "SearchOutput",
+			Handler:    _Headnode_SearchOutput_Handler,
+		},
+		{
+			MethodName: "ExportTimeline",
+			Handler:    _Headnode_ExportTimeline_Handler,
+		},
+		{
+			MethodName: "BatchHeartbeat",
+			Handler:    _Headnode_BatchHeartbeat_Handler,
+		},
+		{
+			MethodName: "DrainNodes",
+			Handler:    _Headnode_DrainNodes_Handler,
+		},
+		{
+			MethodName: "RemoveNodes",
+			Handler:    _Headnode_RemoveNodes_Handler,
+		},
+		{
+			MethodName: "RevalidateNodes",
+			Handler:    _Headnode_RevalidateNodes_Handler,
+		},
+		{
+			MethodName: "Undo",
+			Handler:    _Headnode_Undo_Handler,
+		},
+		{
+			MethodName: "SaveJobTemplate",
+			Handler:    _Headnode_SaveJobTemplate_Handler,
+		},
+		{
+			MethodName: "GetJobTemplates",
+			Handler:    _Headnode_GetJobTemplates_Handler,
+		},
+		{
+			MethodName: "DeleteJobTemplates",
+			Handler:    _Headnode_DeleteJobTemplates_Handler,
+		},
+		{
+			MethodName: "SaveJobSchedule",
+			Handler:    _Headnode_SaveJobSchedule_Handler,
+		},
+		{
+			MethodName: "GetJobSchedules",
+			Handler:    _Headnode_GetJobSchedules_Handler,
+		},
+		{
+			MethodName: "SetJobSchedulesEnabled",
+			Handler:    _Headnode_SetJobSchedulesEnabled_Handler,
+		},
+		{
+			MethodName: "DeleteJobSchedules",
+			Handler:    _Headnode_DeleteJobSchedules_Handler,
+		},
+		{
+			MethodName: "SaveJobBookmark",
+			Handler:    _Headnode_SaveJobBookmark_Handler,
+		},
+		{
+			MethodName: "GetJobBookmarks",
+			Handler:    _Headnode_GetJobBookmarks_Handler,
+		},
+		{
+			MethodName: "DeleteJobBookmarks",
+			Handler:    _Headnode_DeleteJobBookmarks_Handler,
+		},
+		{
+			MethodName: "GetLoginOptions",
+			Handler:    _Headnode_GetLoginOptions_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _Headnode_Login_Handler,
+		},
+		{
+			MethodName: "TestNodes",
+			Handler:    _Headnode_TestNodes_Handler,
+		},
+		{
+			MethodName: "GetAuditLog",
+			Handler:    _Headnode_GetAuditLog_Handler,
+		},
+		{
+			MethodName: "GetConfigDrift",
+			Handler:    _Headnode_GetConfigDrift_Handler,
+		},
+		{
+			MethodName: "GetNodeAvailability",
+			Handler:    _Headnode_GetNodeAvailability_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "HeartbeatStream",
+			Handler:       _Headnode_HeartbeatStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetOutput",
+			Handler:       _Headnode_GetOutput_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StartClusJob",
+			Handler:       _Headnode_StartClusJob_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadFiles",
+			Handler:       _Headnode_UploadFiles_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportJobs",
+			Handler:       _Headnode_ExportJobs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportJobs",
+			Handler:       _Headnode_ImportJobs_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamJobs",
+			Handler:       _Headnode_StreamJobs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Shell",
+			Handler:       _Headnode_Shell_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ForwardJobInput",
+			Handler:       _Headnode_ForwardJobInput_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _Headnode_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchClusJob",
+			Handler:       _Headnode_WatchClusJob_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Tunnel",
+			Handler:       _Headnode_Tunnel_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchNodes",
+			Handler:       _Headnode_WatchNodes_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RerunJob",
+			Handler:       _Headnode_RerunJob_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "protobuf/clusrun.proto",
 }
 
-type headnodeGetOutputClient struct {
-	grpc.ClientStream
+// ClusnodeClient is the client API for Clusnode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ClusnodeClient interface {
+	StartJob(ctx context.Context, in *StartJobRequest, opts ...grpc.CallOption) (Clusnode_StartJobClient, error)
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Empty, error)
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateReply, error)
+	SetHeadnodes(ctx context.Context, in *SetHeadnodesRequest, opts ...grpc.CallOption) (*SetHeadnodesReply, error)
+	SetConfigs(ctx context.Context, in *SetConfigsRequest, opts ...grpc.CallOption) (*SetConfigsReply, error)
+	GetConfigs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetConfigsReply, error)
+	ReceiveFiles(ctx context.Context, opts ...grpc.CallOption) (Clusnode_ReceiveFilesClient, error)
+	SendFiles(ctx context.Context, in *SendFilesRequest, opts ...grpc.CallOption) (Clusnode_SendFilesClient, error)
+	Shell(ctx context.Context, opts ...grpc.CallOption) (Clusnode_ShellClient, error)
+	WriteJobInput(ctx context.Context, opts ...grpc.CallOption) (Clusnode_WriteJobInputClient, error)
+	GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogReply, error)
+	QueryJob(ctx context.Context, in *QueryJobRequest, opts ...grpc.CallOption) (*QueryJobReply, error)
+	ApplyConfigs(ctx context.Context, in *ApplyConfigsRequest, opts ...grpc.CallOption) (*SetConfigsReply, error)
+	ReportConfigs(ctx context.Context, in *ReportConfigsRequest, opts ...grpc.CallOption) (*GetConfigsReply, error)
+	ResumeJob(ctx context.Context, in *ResumeJobRequest, opts ...grpc.CallOption) (Clusnode_ResumeJobClient, error)
+	Probe(ctx context.Context, in *ProbeRequest, opts ...grpc.CallOption) (*ProbeReply, error)
+	RelayJob(ctx context.Context, in *RelayJobRequest, opts ...grpc.CallOption) (Clusnode_RelayJobClient, error)
 }
 
-func (x *headnodeGetOutputClient) Recv() (*GetOutputReply, error) {
-	m := new(GetOutputReply)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+type clusnodeClient struct {
+	cc grpc.ClientConnInterface
 }
 
-func (c *headnodeClient) StartClusJob(ctx context.Context, in *StartClusJobRequest, opts ...grpc.CallOption) (Headnode_StartClusJobClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Headnode_serviceDesc.Streams[1], "/clusrun.Headnode/StartClusJob", opts...)
+func NewClusnodeClient(cc grpc.ClientConnInterface) ClusnodeClient {
+	return &clusnodeClient{cc}
+}
+
+func (c *clusnodeClient) StartJob(ctx context.Context, in *StartJobRequest, opts ...grpc.CallOption) (Clusnode_StartJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Clusnode_serviceDesc.Streams[0], "/clusrun.Clusnode/StartJob", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &headnodeStartClusJobClient{stream}
+	x := &clusnodeStartJobClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2581,350 +17311,238 @@ func (c *headnodeClient) StartClusJob(ctx context.Context, in *StartClusJobReque
 	return x, nil
 }
 
-type Headnode_StartClusJobClient interface {
-	Recv() (*StartClusJobReply, error)
+type Clusnode_StartJobClient interface {
+	Recv() (*StartJobReply, error)
 	grpc.ClientStream
 }
 
-type headnodeStartClusJobClient struct {
+type clusnodeStartJobClient struct {
 	grpc.ClientStream
 }
 
-func (x *headnodeStartClusJobClient) Recv() (*StartClusJobReply, error) {
-	m := new(StartClusJobReply)
+func (x *clusnodeStartJobClient) Recv() (*StartJobReply, error) {
+	m := new(StartJobReply)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *headnodeClient) CancelClusJobs(ctx context.Context, in *CancelClusJobsRequest, opts ...grpc.CallOption) (*CancelClusJobsReply, error) {
-	out := new(CancelClusJobsReply)
-	err := c.cc.Invoke(ctx, "/clusrun.Headnode/CancelClusJobs", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *headnodeClient) SetConfigs(ctx context.Context, in *SetConfigsRequest, opts ...grpc.CallOption) (*SetConfigsReply, error) {
-	out := new(SetConfigsReply)
-	err := c.cc.Invoke(ctx, "/clusrun.Headnode/SetConfigs", in, out, opts...)
+func (c *clusnodeClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/CancelJob", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *headnodeClient) GetConfigs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetConfigsReply, error) {
-	out := new(GetConfigsReply)
-	err := c.cc.Invoke(ctx, "/clusrun.Headnode/GetConfigs", in, out, opts...)
+func (c *clusnodeClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateReply, error) {
+	out := new(ValidateReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/Validate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *headnodeClient) SetNodeGroups(ctx context.Context, in *SetNodeGroupsRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/clusrun.Headnode/SetNodeGroups", in, out, opts...)
+func (c *clusnodeClient) SetHeadnodes(ctx context.Context, in *SetHeadnodesRequest, opts ...grpc.CallOption) (*SetHeadnodesReply, error) {
+	out := new(SetHeadnodesReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/SetHeadnodes", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
-
-// HeadnodeServer is the server API for Headnode service.
-type HeadnodeServer interface {
-	Heartbeat(context.Context, *HeartbeatRequest) (*Empty, error)
-	GetNodes(context.Context, *GetNodesRequest) (*GetNodesReply, error)
-	GetJobs(context.Context, *GetJobsRequest) (*GetJobsReply, error)
-	GetOutput(*GetOutputRequest, Headnode_GetOutputServer) error
-	StartClusJob(*StartClusJobRequest, Headnode_StartClusJobServer) error
-	CancelClusJobs(context.Context, *CancelClusJobsRequest) (*CancelClusJobsReply, error)
-	SetConfigs(context.Context, *SetConfigsRequest) (*SetConfigsReply, error)
-	GetConfigs(context.Context, *Empty) (*GetConfigsReply, error)
-	SetNodeGroups(context.Context, *SetNodeGroupsRequest) (*Empty, error)
-}
-
-// UnimplementedHeadnodeServer can be embedded to have forward compatible implementations.
-type UnimplementedHeadnodeServer struct {
-}
-
-func (*UnimplementedHeadnodeServer) Heartbeat(context.Context, *HeartbeatRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
-}
-func (*UnimplementedHeadnodeServer) GetNodes(context.Context, *GetNodesRequest) (*GetNodesReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetNodes not implemented")
-}
-func (*UnimplementedHeadnodeServer) GetJobs(context.Context, *GetJobsRequest) (*GetJobsReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetJobs not implemented")
-}
-func (*UnimplementedHeadnodeServer) GetOutput(*GetOutputRequest, Headnode_GetOutputServer) error {
-	return status.Errorf(codes.Unimplemented, "method GetOutput not implemented")
-}
-func (*UnimplementedHeadnodeServer) StartClusJob(*StartClusJobRequest, Headnode_StartClusJobServer) error {
-	return status.Errorf(codes.Unimplemented, "method StartClusJob not implemented")
+
+func (c *clusnodeClient) SetConfigs(ctx context.Context, in *SetConfigsRequest, opts ...grpc.CallOption) (*SetConfigsReply, error) {
+	out := new(SetConfigsReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/SetConfigs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (*UnimplementedHeadnodeServer) CancelClusJobs(context.Context, *CancelClusJobsRequest) (*CancelClusJobsReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CancelClusJobs not implemented")
+
+func (c *clusnodeClient) GetConfigs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetConfigsReply, error) {
+	out := new(GetConfigsReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/GetConfigs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (*UnimplementedHeadnodeServer) SetConfigs(context.Context, *SetConfigsRequest) (*SetConfigsReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetConfigs not implemented")
+
+func (c *clusnodeClient) ReceiveFiles(ctx context.Context, opts ...grpc.CallOption) (Clusnode_ReceiveFilesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Clusnode_serviceDesc.Streams[1], "/clusrun.Clusnode/ReceiveFiles", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &clusnodeReceiveFilesClient{stream}
+	return x, nil
 }
-func (*UnimplementedHeadnodeServer) GetConfigs(context.Context, *Empty) (*GetConfigsReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetConfigs not implemented")
+
+type Clusnode_ReceiveFilesClient interface {
+	Send(*ReceiveFilesRequest) error
+	CloseAndRecv() (*ReceiveFilesReply, error)
+	grpc.ClientStream
 }
-func (*UnimplementedHeadnodeServer) SetNodeGroups(context.Context, *SetNodeGroupsRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetNodeGroups not implemented")
+
+type clusnodeReceiveFilesClient struct {
+	grpc.ClientStream
 }
 
-func RegisterHeadnodeServer(s *grpc.Server, srv HeadnodeServer) {
-	s.RegisterService(&_Headnode_serviceDesc, srv)
+func (x *clusnodeReceiveFilesClient) Send(m *ReceiveFilesRequest) error {
+	return x.ClientStream.SendMsg(m)
 }
 
-func _Headnode_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HeartbeatRequest)
-	if err := dec(in); err != nil {
+func (x *clusnodeReceiveFilesClient) CloseAndRecv() (*ReceiveFilesReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(HeadnodeServer).Heartbeat(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/clusrun.Headnode/Heartbeat",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeadnodeServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	m := new(ReceiveFilesReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
 }
 
-func _Headnode_GetNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNodesRequest)
-	if err := dec(in); err != nil {
+func (c *clusnodeClient) SendFiles(ctx context.Context, in *SendFilesRequest, opts ...grpc.CallOption) (Clusnode_SendFilesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Clusnode_serviceDesc.Streams[2], "/clusrun.Clusnode/SendFiles", opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(HeadnodeServer).GetNodes(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/clusrun.Headnode/GetNodes",
+	x := &clusnodeSendFilesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeadnodeServer).GetNodes(ctx, req.(*GetNodesRequest))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return x, nil
 }
 
-func _Headnode_GetJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetJobsRequest)
-	if err := dec(in); err != nil {
+type Clusnode_SendFilesClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type clusnodeSendFilesClient struct {
+	grpc.ClientStream
+}
+
+func (x *clusnodeSendFilesClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(HeadnodeServer).GetJobs(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/clusrun.Headnode/GetJobs",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeadnodeServer).GetJobs(ctx, req.(*GetJobsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
 }
 
-func _Headnode_GetOutput_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetOutputRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (c *clusnodeClient) Shell(ctx context.Context, opts ...grpc.CallOption) (Clusnode_ShellClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Clusnode_serviceDesc.Streams[3], "/clusrun.Clusnode/Shell", opts...)
+	if err != nil {
+		return nil, err
 	}
-	return srv.(HeadnodeServer).GetOutput(m, &headnodeGetOutputServer{stream})
+	x := &clusnodeShellClient{stream}
+	return x, nil
 }
 
-type Headnode_GetOutputServer interface {
-	Send(*GetOutputReply) error
-	grpc.ServerStream
+type Clusnode_ShellClient interface {
+	Send(*ShellRequest) error
+	Recv() (*ShellReply, error)
+	grpc.ClientStream
 }
 
-type headnodeGetOutputServer struct {
-	grpc.ServerStream
+type clusnodeShellClient struct {
+	grpc.ClientStream
 }
 
-func (x *headnodeGetOutputServer) Send(m *GetOutputReply) error {
-	return x.ServerStream.SendMsg(m)
+func (x *clusnodeShellClient) Send(m *ShellRequest) error {
+	return x.ClientStream.SendMsg(m)
 }
 
-func _Headnode_StartClusJob_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(StartClusJobRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (x *clusnodeShellClient) Recv() (*ShellReply, error) {
+	m := new(ShellReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return srv.(HeadnodeServer).StartClusJob(m, &headnodeStartClusJobServer{stream})
+	return m, nil
 }
 
-type Headnode_StartClusJobServer interface {
-	Send(*StartClusJobReply) error
-	grpc.ServerStream
+func (c *clusnodeClient) WriteJobInput(ctx context.Context, opts ...grpc.CallOption) (Clusnode_WriteJobInputClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Clusnode_serviceDesc.Streams[4], "/clusrun.Clusnode/WriteJobInput", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &clusnodeWriteJobInputClient{stream}
+	return x, nil
 }
 
-type headnodeStartClusJobServer struct {
-	grpc.ServerStream
+type Clusnode_WriteJobInputClient interface {
+	Send(*JobInputRequest) error
+	Recv() (*JobInputReply, error)
+	grpc.ClientStream
 }
 
-func (x *headnodeStartClusJobServer) Send(m *StartClusJobReply) error {
-	return x.ServerStream.SendMsg(m)
+type clusnodeWriteJobInputClient struct {
+	grpc.ClientStream
 }
 
-func _Headnode_CancelClusJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CancelClusJobsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(HeadnodeServer).CancelClusJobs(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/clusrun.Headnode/CancelClusJobs",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeadnodeServer).CancelClusJobs(ctx, req.(*CancelClusJobsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (x *clusnodeWriteJobInputClient) Send(m *JobInputRequest) error {
+	return x.ClientStream.SendMsg(m)
 }
 
-func _Headnode_SetConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetConfigsRequest)
-	if err := dec(in); err != nil {
+func (x *clusnodeWriteJobInputClient) Recv() (*JobInputReply, error) {
+	m := new(JobInputReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(HeadnodeServer).SetConfigs(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/clusrun.Headnode/SetConfigs",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeadnodeServer).SetConfigs(ctx, req.(*SetConfigsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
 }
 
-func _Headnode_GetConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
-	if err := dec(in); err != nil {
+func (c *clusnodeClient) GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogReply, error) {
+	out := new(GetAuditLogReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/GetAuditLog", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(HeadnodeServer).GetConfigs(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/clusrun.Headnode/GetConfigs",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeadnodeServer).GetConfigs(ctx, req.(*Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Headnode_SetNodeGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetNodeGroupsRequest)
-	if err := dec(in); err != nil {
+func (c *clusnodeClient) QueryJob(ctx context.Context, in *QueryJobRequest, opts ...grpc.CallOption) (*QueryJobReply, error) {
+	out := new(QueryJobReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/QueryJob", in, out, opts...)
+	if err != nil {
 		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(HeadnodeServer).SetNodeGroups(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/clusrun.Headnode/SetNodeGroups",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeadnodeServer).SetNodeGroups(ctx, req.(*SetNodeGroupsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-var _Headnode_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "clusrun.Headnode",
-	HandlerType: (*HeadnodeServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Heartbeat",
-			Handler:    _Headnode_Heartbeat_Handler,
-		},
-		{
-			MethodName: "GetNodes",
-			Handler:    _Headnode_GetNodes_Handler,
-		},
-		{
-			MethodName: "GetJobs",
-			Handler:    _Headnode_GetJobs_Handler,
-		},
-		{
-			MethodName: "CancelClusJobs",
-			Handler:    _Headnode_CancelClusJobs_Handler,
-		},
-		{
-			MethodName: "SetConfigs",
-			Handler:    _Headnode_SetConfigs_Handler,
-		},
-		{
-			MethodName: "GetConfigs",
-			Handler:    _Headnode_GetConfigs_Handler,
-		},
-		{
-			MethodName: "SetNodeGroups",
-			Handler:    _Headnode_SetNodeGroups_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "GetOutput",
-			Handler:       _Headnode_GetOutput_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "StartClusJob",
-			Handler:       _Headnode_StartClusJob_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "protobuf/clusrun.proto",
-}
-
-// ClusnodeClient is the client API for Clusnode service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ClusnodeClient interface {
-	StartJob(ctx context.Context, in *StartJobRequest, opts ...grpc.CallOption) (Clusnode_StartJobClient, error)
-	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Empty, error)
-	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateReply, error)
-	SetHeadnodes(ctx context.Context, in *SetHeadnodesRequest, opts ...grpc.CallOption) (*SetHeadnodesReply, error)
-	SetConfigs(ctx context.Context, in *SetConfigsRequest, opts ...grpc.CallOption) (*SetConfigsReply, error)
-	GetConfigs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetConfigsReply, error)
+	}
+	return out, nil
 }
 
-type clusnodeClient struct {
-	cc grpc.ClientConnInterface
+func (c *clusnodeClient) ApplyConfigs(ctx context.Context, in *ApplyConfigsRequest, opts ...grpc.CallOption) (*SetConfigsReply, error) {
+	out := new(SetConfigsReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/ApplyConfigs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewClusnodeClient(cc grpc.ClientConnInterface) ClusnodeClient {
-	return &clusnodeClient{cc}
+func (c *clusnodeClient) ReportConfigs(ctx context.Context, in *ReportConfigsRequest, opts ...grpc.CallOption) (*GetConfigsReply, error) {
+	out := new(GetConfigsReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/ReportConfigs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *clusnodeClient) StartJob(ctx context.Context, in *StartJobRequest, opts ...grpc.CallOption) (Clusnode_StartJobClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Clusnode_serviceDesc.Streams[0], "/clusrun.Clusnode/StartJob", opts...)
+func (c *clusnodeClient) ResumeJob(ctx context.Context, in *ResumeJobRequest, opts ...grpc.CallOption) (Clusnode_ResumeJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Clusnode_serviceDesc.Streams[5], "/clusrun.Clusnode/ResumeJob", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &clusnodeStartJobClient{stream}
+	x := &clusnodeResumeJobClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2934,16 +17552,16 @@ func (c *clusnodeClient) StartJob(ctx context.Context, in *StartJobRequest, opts
 	return x, nil
 }
 
-type Clusnode_StartJobClient interface {
+type Clusnode_ResumeJobClient interface {
 	Recv() (*StartJobReply, error)
 	grpc.ClientStream
 }
 
-type clusnodeStartJobClient struct {
+type clusnodeResumeJobClient struct {
 	grpc.ClientStream
 }
 
-func (x *clusnodeStartJobClient) Recv() (*StartJobReply, error) {
+func (x *clusnodeResumeJobClient) Recv() (*StartJobReply, error) {
 	m := new(StartJobReply)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -2951,49 +17569,45 @@ func (x *clusnodeStartJobClient) Recv() (*StartJobReply, error) {
 	return m, nil
 }
 
-func (c *clusnodeClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/CancelJob", in, out, opts...)
+func (c *clusnodeClient) Probe(ctx context.Context, in *ProbeRequest, opts ...grpc.CallOption) (*ProbeReply, error) {
+	out := new(ProbeReply)
+	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/Probe", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *clusnodeClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateReply, error) {
-	out := new(ValidateReply)
-	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/Validate", in, out, opts...)
+func (c *clusnodeClient) RelayJob(ctx context.Context, in *RelayJobRequest, opts ...grpc.CallOption) (Clusnode_RelayJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Clusnode_serviceDesc.Streams[6], "/clusrun.Clusnode/RelayJob", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *clusnodeClient) SetHeadnodes(ctx context.Context, in *SetHeadnodesRequest, opts ...grpc.CallOption) (*SetHeadnodesReply, error) {
-	out := new(SetHeadnodesReply)
-	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/SetHeadnodes", in, out, opts...)
-	if err != nil {
+	x := &clusnodeRelayJobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *clusnodeClient) SetConfigs(ctx context.Context, in *SetConfigsRequest, opts ...grpc.CallOption) (*SetConfigsReply, error) {
-	out := new(SetConfigsReply)
-	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/SetConfigs", in, out, opts...)
-	if err != nil {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return x, nil
 }
 
-func (c *clusnodeClient) GetConfigs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetConfigsReply, error) {
-	out := new(GetConfigsReply)
-	err := c.cc.Invoke(ctx, "/clusrun.Clusnode/GetConfigs", in, out, opts...)
-	if err != nil {
+type Clusnode_RelayJobClient interface {
+	Recv() (*StartJobReply, error)
+	grpc.ClientStream
+}
+
+type clusnodeRelayJobClient struct {
+	grpc.ClientStream
+}
+
+func (x *clusnodeRelayJobClient) Recv() (*StartJobReply, error) {
+	m := new(StartJobReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
 // ClusnodeServer is the server API for Clusnode service.
@@ -3004,6 +17618,17 @@ type ClusnodeServer interface {
 	SetHeadnodes(context.Context, *SetHeadnodesRequest) (*SetHeadnodesReply, error)
 	SetConfigs(context.Context, *SetConfigsRequest) (*SetConfigsReply, error)
 	GetConfigs(context.Context, *Empty) (*GetConfigsReply, error)
+	ReceiveFiles(Clusnode_ReceiveFilesServer) error
+	SendFiles(*SendFilesRequest, Clusnode_SendFilesServer) error
+	Shell(Clusnode_ShellServer) error
+	WriteJobInput(Clusnode_WriteJobInputServer) error
+	GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogReply, error)
+	QueryJob(context.Context, *QueryJobRequest) (*QueryJobReply, error)
+	ApplyConfigs(context.Context, *ApplyConfigsRequest) (*SetConfigsReply, error)
+	ReportConfigs(context.Context, *ReportConfigsRequest) (*GetConfigsReply, error)
+	ResumeJob(*ResumeJobRequest, Clusnode_ResumeJobServer) error
+	Probe(context.Context, *ProbeRequest) (*ProbeReply, error)
+	RelayJob(*RelayJobRequest, Clusnode_RelayJobServer) error
 }
 
 // UnimplementedClusnodeServer can be embedded to have forward compatible implementations.
@@ -3028,6 +17653,39 @@ func (*UnimplementedClusnodeServer) SetConfigs(context.Context, *SetConfigsReque
 func (*UnimplementedClusnodeServer) GetConfigs(context.Context, *Empty) (*GetConfigsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfigs not implemented")
 }
+func (*UnimplementedClusnodeServer) ReceiveFiles(Clusnode_ReceiveFilesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReceiveFiles not implemented")
+}
+func (*UnimplementedClusnodeServer) SendFiles(*SendFilesRequest, Clusnode_SendFilesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SendFiles not implemented")
+}
+func (*UnimplementedClusnodeServer) Shell(Clusnode_ShellServer) error {
+	return status.Errorf(codes.Unimplemented, "method Shell not implemented")
+}
+func (*UnimplementedClusnodeServer) WriteJobInput(Clusnode_WriteJobInputServer) error {
+	return status.Errorf(codes.Unimplemented, "method WriteJobInput not implemented")
+}
+func (*UnimplementedClusnodeServer) GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditLog not implemented")
+}
+func (*UnimplementedClusnodeServer) QueryJob(context.Context, *QueryJobRequest) (*QueryJobReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryJob not implemented")
+}
+func (*UnimplementedClusnodeServer) ApplyConfigs(context.Context, *ApplyConfigsRequest) (*SetConfigsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyConfigs not implemented")
+}
+func (*UnimplementedClusnodeServer) ReportConfigs(context.Context, *ReportConfigsRequest) (*GetConfigsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportConfigs not implemented")
+}
+func (*UnimplementedClusnodeServer) ResumeJob(*ResumeJobRequest, Clusnode_ResumeJobServer) error {
+	return status.Errorf(codes.Unimplemented, "method ResumeJob not implemented")
+}
+func (*UnimplementedClusnodeServer) Probe(context.Context, *ProbeRequest) (*ProbeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Probe not implemented")
+}
+func (*UnimplementedClusnodeServer) RelayJob(*RelayJobRequest, Clusnode_RelayJobServer) error {
+	return status.Errorf(codes.Unimplemented, "method RelayJob not implemented")
+}
 
 func RegisterClusnodeServer(s *grpc.Server, srv ClusnodeServer) {
 	s.RegisterService(&_Clusnode_serviceDesc, srv)
@@ -3144,6 +17802,237 @@ func _Clusnode_GetConfigs_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Clusnode_ReceiveFiles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ClusnodeServer).ReceiveFiles(&clusnodeReceiveFilesServer{stream})
+}
+
+type Clusnode_ReceiveFilesServer interface {
+	SendAndClose(*ReceiveFilesReply) error
+	Recv() (*ReceiveFilesRequest, error)
+	grpc.ServerStream
+}
+
+type clusnodeReceiveFilesServer struct {
+	grpc.ServerStream
+}
+
+func (x *clusnodeReceiveFilesServer) SendAndClose(m *ReceiveFilesReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *clusnodeReceiveFilesServer) Recv() (*ReceiveFilesRequest, error) {
+	m := new(ReceiveFilesRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Clusnode_SendFiles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SendFilesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClusnodeServer).SendFiles(m, &clusnodeSendFilesServer{stream})
+}
+
+type Clusnode_SendFilesServer interface {
+	Send(*FileChunk) error
+	grpc.ServerStream
+}
+
+type clusnodeSendFilesServer struct {
+	grpc.ServerStream
+}
+
+func (x *clusnodeSendFilesServer) Send(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Clusnode_Shell_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ClusnodeServer).Shell(&clusnodeShellServer{stream})
+}
+
+type Clusnode_ShellServer interface {
+	Send(*ShellReply) error
+	Recv() (*ShellRequest, error)
+	grpc.ServerStream
+}
+
+type clusnodeShellServer struct {
+	grpc.ServerStream
+}
+
+func (x *clusnodeShellServer) Send(m *ShellReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *clusnodeShellServer) Recv() (*ShellRequest, error) {
+	m := new(ShellRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Clusnode_WriteJobInput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ClusnodeServer).WriteJobInput(&clusnodeWriteJobInputServer{stream})
+}
+
+type Clusnode_WriteJobInputServer interface {
+	Send(*JobInputReply) error
+	Recv() (*JobInputRequest, error)
+	grpc.ServerStream
+}
+
+type clusnodeWriteJobInputServer struct {
+	grpc.ServerStream
+}
+
+func (x *clusnodeWriteJobInputServer) Send(m *JobInputReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *clusnodeWriteJobInputServer) Recv() (*JobInputRequest, error) {
+	m := new(JobInputRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Clusnode_GetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusnodeServer).GetAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Clusnode/GetAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusnodeServer).GetAuditLog(ctx, req.(*GetAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Clusnode_QueryJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusnodeServer).QueryJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Clusnode/QueryJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusnodeServer).QueryJob(ctx, req.(*QueryJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Clusnode_ApplyConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusnodeServer).ApplyConfigs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Clusnode/ApplyConfigs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusnodeServer).ApplyConfigs(ctx, req.(*ApplyConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Clusnode_ReportConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusnodeServer).ReportConfigs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Clusnode/ReportConfigs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusnodeServer).ReportConfigs(ctx, req.(*ReportConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Clusnode_ResumeJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ResumeJobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClusnodeServer).ResumeJob(m, &clusnodeResumeJobServer{stream})
+}
+
+type Clusnode_ResumeJobServer interface {
+	Send(*StartJobReply) error
+	grpc.ServerStream
+}
+
+type clusnodeResumeJobServer struct {
+	grpc.ServerStream
+}
+
+func (x *clusnodeResumeJobServer) Send(m *StartJobReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Clusnode_Probe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusnodeServer).Probe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusrun.Clusnode/Probe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusnodeServer).Probe(ctx, req.(*ProbeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Clusnode_RelayJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RelayJobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClusnodeServer).RelayJob(m, &clusnodeRelayJobServer{stream})
+}
+
+type Clusnode_RelayJobServer interface {
+	Send(*StartJobReply) error
+	grpc.ServerStream
+}
+
+type clusnodeRelayJobServer struct {
+	grpc.ServerStream
+}
+
+func (x *clusnodeRelayJobServer) Send(m *StartJobReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Clusnode_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "clusrun.Clusnode",
 	HandlerType: (*ClusnodeServer)(nil),
@@ -3168,6 +18057,26 @@ var _Clusnode_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfigs",
 			Handler:    _Clusnode_GetConfigs_Handler,
 		},
+		{
+			MethodName: "GetAuditLog",
+			Handler:    _Clusnode_GetAuditLog_Handler,
+		},
+		{
+			MethodName: "QueryJob",
+			Handler:    _Clusnode_QueryJob_Handler,
+		},
+		{
+			MethodName: "ApplyConfigs",
+			Handler:    _Clusnode_ApplyConfigs_Handler,
+		},
+		{
+			MethodName: "ReportConfigs",
+			Handler:    _Clusnode_ReportConfigs_Handler,
+		},
+		{
+			MethodName: "Probe",
+			Handler:    _Clusnode_Probe_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3175,6 +18084,38 @@ var _Clusnode_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Clusnode_StartJob_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ReceiveFiles",
+			Handler:       _Clusnode_ReceiveFiles_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SendFiles",
+			Handler:       _Clusnode_SendFiles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Shell",
+			Handler:       _Clusnode_Shell_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WriteJobInput",
+			Handler:       _Clusnode_WriteJobInput_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ResumeJob",
+			Handler:       _Clusnode_ResumeJob_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RelayJob",
+			Handler:       _Clusnode_RelayJob_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "protobuf/clusrun.proto",
 }